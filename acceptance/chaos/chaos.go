@@ -0,0 +1,280 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chaos runs failover scenarios against a running SCION topology. A
+// scenario injects a fault, e.g., kills a border router, cuts a link or
+// injects a revocation, and measures the time until the expectations on the
+// system hold again, e.g., until the daemon returns paths that avoid the
+// failed link, until the traffic through the gateways flows again, or until
+// beaconing re-converged after the fault was reverted.
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// DefaultPollInterval is the default interval between evaluating a check
+// that does not hold yet.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// Fault is a failure that is injected into the system under test.
+type Fault interface {
+	// Inject injects the fault.
+	Inject(ctx context.Context) error
+	// Revert undoes the fault.
+	Revert(ctx context.Context) error
+}
+
+// Check probes a property of the system under test. It returns nil if the
+// property holds.
+type Check interface {
+	Check(ctx context.Context) error
+}
+
+// Expectation is a check that must hold within a time bound.
+type Expectation struct {
+	// Name identifies the expectation in the report.
+	Name  string
+	Check Check
+	// Within is the maximum time until the check holds, measured from the
+	// start of the phase.
+	Within time.Duration
+}
+
+// Scenario is a failover scenario. It is run in three phases:
+//
+//  1. steady: the Steady expectations must hold before the fault is injected.
+//  2. recovery: the fault is injected and the Recovery expectations must hold
+//     within their time bounds.
+//  3. restore: the fault is reverted and the Restore expectations must hold
+//     within their time bounds.
+type Scenario struct {
+	Name     string
+	Steady   []Expectation
+	Fault    Fault
+	Recovery []Expectation
+	Restore  []Expectation
+}
+
+// Phase names used in the report.
+const (
+	PhaseSteady   = "steady"
+	PhaseRecovery = "recovery"
+	PhaseRestore  = "restore"
+)
+
+// Runner runs scenarios.
+type Runner struct {
+	// PollInterval is the interval between evaluating a check that does not
+	// hold yet. If zero, DefaultPollInterval is used.
+	PollInterval time.Duration
+	// Logf logs the progress. It may be nil.
+	Logf func(format string, args ...interface{})
+}
+
+// Run runs the scenario and reports the time at which every expectation
+// held. Failed expectations are recorded in the report; an error is only
+// returned if the steady state is not reached or if the fault cannot be
+// injected or reverted. The fault is always reverted before Run returns.
+func (r Runner) Run(ctx context.Context, s Scenario) (ScenarioReport, error) {
+	rep, err := r.run(ctx, s)
+	if err != nil {
+		rep.Error = err.Error()
+	}
+	return rep, err
+}
+
+func (r Runner) run(ctx context.Context, s Scenario) (ScenarioReport, error) {
+	rep := ScenarioReport{Name: s.Name}
+	steady := r.await(ctx, s.Name, PhaseSteady, s.Steady)
+	rep.Phases = append(rep.Phases, steady)
+	if !steady.Passed() {
+		return rep, serrors.New("steady state not reached", "scenario", s.Name)
+	}
+
+	r.logf("[%s] Injecting fault", s.Name)
+	if err := s.Fault.Inject(ctx); err != nil {
+		// The fault might be partially injected.
+		if rerr := r.revert(s); rerr != nil {
+			r.logf("[%s] Failed to revert fault: %s", s.Name, rerr)
+		}
+		return rep, serrors.WrapStr("injecting fault", err, "scenario", s.Name)
+	}
+	rep.Phases = append(rep.Phases, r.await(ctx, s.Name, PhaseRecovery, s.Recovery))
+
+	if err := r.revert(s); err != nil {
+		return rep, serrors.WrapStr("reverting fault", err, "scenario", s.Name)
+	}
+	rep.Phases = append(rep.Phases, r.await(ctx, s.Name, PhaseRestore, s.Restore))
+	return rep, nil
+}
+
+// revert reverts the fault of the scenario. It uses a fresh context, such
+// that the fault is reverted even if the run was canceled.
+func (r Runner) revert(s Scenario) error {
+	r.logf("[%s] Reverting fault", s.Name)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return s.Fault.Revert(ctx)
+}
+
+// await evaluates all expectations concurrently until they hold or their time
+// bound is exceeded.
+func (r Runner) await(ctx context.Context, scenario, phase string,
+	exps []Expectation) PhaseReport {
+
+	start := time.Now()
+	rep := PhaseReport{Name: phase, Results: make([]Result, len(exps))}
+	var wg sync.WaitGroup
+	for i, e := range exps {
+		i, e := i, e
+		wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			res := r.poll(ctx, start, e)
+			if res.Held {
+				r.logf("[%s] %s: %q held after %s", scenario, phase, e.Name,
+					res.Elapsed.Duration())
+			} else {
+				r.logf("[%s] %s: %q did not hold within %s: %s", scenario, phase, e.Name,
+					e.Within, res.Error)
+			}
+			rep.Results[i] = res
+		}()
+	}
+	wg.Wait()
+	return rep
+}
+
+func (r Runner) poll(ctx context.Context, start time.Time, e Expectation) Result {
+	res := Result{Name: e.Name, Within: Seconds(e.Within.Seconds())}
+	interval := r.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	ctx, cancel := context.WithDeadline(ctx, start.Add(e.Within))
+	defer cancel()
+	for {
+		err := e.Check.Check(ctx)
+		if err == nil {
+			res.Held, res.Elapsed = true, Seconds(time.Since(start).Seconds())
+			return res
+		}
+		res.Error = err.Error()
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return res
+		}
+	}
+}
+
+func (r Runner) logf(format string, args ...interface{}) {
+	if r.Logf != nil {
+		r.Logf(format, args...)
+	}
+}
+
+// Report is the report of a set of scenarios. It is meant to be exported as
+// JSON.
+type Report struct {
+	Scenarios []ScenarioReport `json:"scenarios"`
+}
+
+// Err returns an error describing the failed scenarios, or nil if all of them
+// passed.
+func (r Report) Err() error {
+	var failed []string
+	for _, s := range r.Scenarios {
+		if !s.Passed() {
+			failed = append(failed, s.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return serrors.New("scenarios failed", "scenarios", failed)
+	}
+	return nil
+}
+
+// ScenarioReport is the report of a single scenario.
+type ScenarioReport struct {
+	Name   string        `json:"name"`
+	Phases []PhaseReport `json:"phases"`
+	// Error is set if the scenario could not be run to completion.
+	Error string `json:"error,omitempty"`
+}
+
+// Passed indicates whether the scenario completed and all expectations held.
+func (r ScenarioReport) Passed() bool {
+	if r.Error != "" {
+		return false
+	}
+	for _, p := range r.Phases {
+		if !p.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// PhaseReport is the report of a scenario phase.
+type PhaseReport struct {
+	Name    string   `json:"name"`
+	Results []Result `json:"results"`
+}
+
+// Passed indicates whether all expectations of the phase held.
+func (r PhaseReport) Passed() bool {
+	for _, res := range r.Results {
+		if !res.Held {
+			return false
+		}
+	}
+	return true
+}
+
+// Result is the result of an expectation.
+type Result struct {
+	Name string `json:"name"`
+	// Within is the time bound of the expectation.
+	Within Seconds `json:"within_s"`
+	// Held indicates whether the check held within the time bound.
+	Held bool `json:"held"`
+	// Elapsed is the time from the start of the phase until the check held,
+	// e.g., the recovery time after the fault injection.
+	Elapsed Seconds `json:"elapsed_s,omitempty"`
+	// Error is the last error of the check if it did not hold.
+	Error string `json:"error,omitempty"`
+}
+
+// Seconds is a duration that is exported as a floating point number of
+// seconds.
+type Seconds float64
+
+// Duration returns the duration.
+func (s Seconds) Duration() time.Duration {
+	return time.Duration(float64(s) * float64(time.Second))
+}
+
+// String returns the duration in seconds.
+func (s Seconds) String() string {
+	return fmt.Sprintf("%.3fs", float64(s))
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/acceptance/chaos"
+)
+
+// fakeFault records the injection state of the fault.
+type fakeFault struct {
+	mu        sync.Mutex
+	injected  bool
+	reverts   int
+	injectErr error
+}
+
+func (f *fakeFault) Inject(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.injected = true
+	return f.injectErr
+}
+
+func (f *fakeFault) Revert(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.injected = false
+	f.reverts++
+	return nil
+}
+
+func (f *fakeFault) Injected() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.injected
+}
+
+type checkFunc func(context.Context) error
+
+func (f checkFunc) Check(ctx context.Context) error { return f(ctx) }
+
+func TestRunnerRun(t *testing.T) {
+	runner := chaos.Runner{PollInterval: time.Millisecond}
+	never := checkFunc(func(context.Context) error { return errors.New("never") })
+
+	t.Run("all phases hold", func(t *testing.T) {
+		fault := &fakeFault{}
+		s := chaos.Scenario{
+			Name: "ok",
+			Steady: []chaos.Expectation{
+				{Name: "healthy", Check: checkFunc(func(context.Context) error {
+					if fault.Injected() {
+						return errors.New("fault injected")
+					}
+					return nil
+				}), Within: time.Second},
+			},
+			Fault: fault,
+			Recovery: []chaos.Expectation{
+				{Name: "failover", Check: checkFunc(func(context.Context) error {
+					if !fault.Injected() {
+						return errors.New("fault not injected")
+					}
+					return nil
+				}), Within: time.Second},
+			},
+			Restore: []chaos.Expectation{
+				{Name: "restored", Check: checkFunc(func(context.Context) error {
+					if fault.Injected() {
+						return errors.New("fault injected")
+					}
+					return nil
+				}), Within: time.Second},
+			},
+		}
+		rep, err := runner.Run(context.Background(), s)
+		require.NoError(t, err)
+		assert.True(t, rep.Passed())
+		require.Len(t, rep.Phases, 3)
+		for i, name := range []string{
+			chaos.PhaseSteady, chaos.PhaseRecovery, chaos.PhaseRestore,
+		} {
+			assert.Equal(t, name, rep.Phases[i].Name)
+			require.Len(t, rep.Phases[i].Results, 1)
+			assert.True(t, rep.Phases[i].Results[0].Held)
+		}
+		assert.Equal(t, 1, fault.reverts)
+	})
+	t.Run("recovery times out", func(t *testing.T) {
+		fault := &fakeFault{}
+		s := chaos.Scenario{
+			Name:  "timeout",
+			Fault: fault,
+			Recovery: []chaos.Expectation{
+				{Name: "never", Check: never, Within: 20 * time.Millisecond},
+			},
+		}
+		rep, err := runner.Run(context.Background(), s)
+		require.NoError(t, err)
+		assert.False(t, rep.Passed())
+		require.Len(t, rep.Phases, 3)
+		res := rep.Phases[1].Results[0]
+		assert.False(t, res.Held)
+		assert.Equal(t, "never", res.Error)
+		assert.False(t, fault.Injected())
+		assert.Error(t, chaos.Report{Scenarios: []chaos.ScenarioReport{rep}}.Err())
+	})
+	t.Run("steady state not reached", func(t *testing.T) {
+		fault := &fakeFault{}
+		s := chaos.Scenario{
+			Name: "unsteady",
+			Steady: []chaos.Expectation{
+				{Name: "never", Check: never, Within: 20 * time.Millisecond},
+			},
+			Fault: fault,
+		}
+		rep, err := runner.Run(context.Background(), s)
+		assert.Error(t, err)
+		assert.NotEmpty(t, rep.Error)
+		assert.False(t, rep.Passed())
+		assert.Len(t, rep.Phases, 1)
+		assert.Equal(t, 0, fault.reverts)
+	})
+	t.Run("inject error reverts fault", func(t *testing.T) {
+		fault := &fakeFault{injectErr: errors.New("partial")}
+		rep, err := runner.Run(context.Background(), chaos.Scenario{Name: "e", Fault: fault})
+		assert.Error(t, err)
+		assert.False(t, rep.Passed())
+		assert.False(t, fault.Injected())
+		assert.Equal(t, 1, fault.reverts)
+	})
+}
+
+func TestSecondsDuration(t *testing.T) {
+	s := chaos.Seconds(1.5)
+	assert.Equal(t, 1500*time.Millisecond, s.Duration())
+	assert.Equal(t, "1.500s", s.String())
+}
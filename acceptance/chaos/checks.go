@@ -0,0 +1,132 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// PathsAvoid holds if the daemon returns at least one path to the destination
+// and the paths do not traverse any of the avoided interfaces. It verifies that
+// the daemon fails over to alternative paths.
+type PathsAvoid struct {
+	Daemon      daemon.Connector
+	Destination addr.IA
+	Avoid       []snet.PathInterface
+	// FirstOnly restricts the check to the first returned path, which is the
+	// path that applications use by default.
+	FirstOnly bool
+}
+
+func (c PathsAvoid) Check(ctx context.Context) error {
+	paths, err := c.Daemon.Paths(ctx, c.Destination, 0, daemon.PathReqFlags{})
+	if err != nil {
+		return serrors.WrapStr("fetching paths", err)
+	}
+	if len(paths) == 0 {
+		return serrors.New("no paths", "destination", c.Destination)
+	}
+	if c.FirstOnly {
+		paths = paths[:1]
+	}
+	for _, p := range paths {
+		for _, intf := range p.Metadata().Interfaces {
+			for _, avoid := range c.Avoid {
+				if intf.IA == avoid.IA && intf.ID == avoid.ID {
+					return serrors.New("path traverses avoided interface",
+						"path", fmt.Sprint(p), "interface", FormatInterface(avoid))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// PathCount holds if the daemon returns at least Min paths to the
+// destination. It verifies that beaconing re-converged after a fault.
+type PathCount struct {
+	Daemon      daemon.Connector
+	Destination addr.IA
+	Min         int
+	// Refresh requests fresh paths, bypassing the caches of the daemon.
+	Refresh bool
+}
+
+func (c PathCount) Check(ctx context.Context) error {
+	paths, err := c.Daemon.Paths(ctx, c.Destination, 0,
+		daemon.PathReqFlags{Refresh: c.Refresh})
+	if err != nil {
+		return serrors.WrapStr("fetching paths", err)
+	}
+	if len(paths) < c.Min {
+		return serrors.New("not enough paths", "destination", c.Destination,
+			"actual", len(paths), "min", c.Min)
+	}
+	return nil
+}
+
+// CommandCheck holds if the command exits successfully.
+type CommandCheck struct {
+	Cmd []string
+}
+
+func (c CommandCheck) Check(ctx context.Context) error {
+	return runCommand(ctx, c.Cmd)
+}
+
+// ExecCheck holds if the command exits successfully in the container of the
+// docker compose service, e.g., a ping through the gateways from a tester.
+type ExecCheck struct {
+	Compose Compose
+	Service string
+	Cmd     []string
+}
+
+func (c ExecCheck) Check(ctx context.Context) error {
+	return c.Compose.Exec(ctx, c.Service, c.Cmd...)
+}
+
+// ParseInterface parses an interface in the format ISD-AS#ID.
+func ParseInterface(s string) (snet.PathInterface, error) {
+	rawIA, rawID, ok := strings.Cut(s, "#")
+	if !ok {
+		return snet.PathInterface{}, serrors.New("invalid interface, expected ISD-AS#ID",
+			"interface", s)
+	}
+	ia, err := addr.ParseIA(rawIA)
+	if err != nil {
+		return snet.PathInterface{}, serrors.WrapStr("parsing ISD-AS", err, "interface", s)
+	}
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		return snet.PathInterface{}, serrors.WrapStr("parsing interface ID", err,
+			"interface", s)
+	}
+	return snet.PathInterface{IA: ia, ID: common.IFIDType(id)}, nil
+}
+
+// FormatInterface formats the interface as ISD-AS#ID.
+func FormatInterface(intf snet.PathInterface) string {
+	return fmt.Sprintf("%s#%d", intf.IA, intf.ID)
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/topology/underlay"
+)
+
+// Compose runs docker compose commands against the topology.
+type Compose struct {
+	// File is the docker compose file of the topology.
+	File string
+	// Project is the docker compose project name. If empty, the default
+	// project name is used.
+	Project string
+}
+
+// Run runs the docker compose command with the given arguments.
+func (c Compose) Run(ctx context.Context, args ...string) error {
+	argv := []string{"compose", "--compatibility", "-f", c.File}
+	if c.Project != "" {
+		argv = append(argv, "-p", c.Project)
+	}
+	return runCommand(ctx, append([]string{"docker"}, append(argv, args...)...))
+}
+
+// Exec runs the command in the container of the service.
+func (c Compose) Exec(ctx context.Context, service string, args ...string) error {
+	return c.Run(ctx, append([]string{"exec", "-T", service}, args...)...)
+}
+
+func runCommand(ctx context.Context, argv []string) error {
+	if len(argv) == 0 {
+		return serrors.New("empty command")
+	}
+	out, err := exec.CommandContext(ctx, argv[0], argv[1:]...).CombinedOutput()
+	if err != nil {
+		return serrors.WrapStr("running command", err,
+			"cmd", strings.Join(argv, " "), "output", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// KillService kills the container of a docker compose service, e.g., a border
+// router, and starts it again on revert.
+type KillService struct {
+	Compose Compose
+	Service string
+}
+
+func (f KillService) Inject(ctx context.Context) error {
+	return f.Compose.Run(ctx, "kill", f.Service)
+}
+
+func (f KillService) Revert(ctx context.Context) error {
+	return f.Compose.Run(ctx, "start", f.Service)
+}
+
+// DisconnectNetwork disconnects a container from a docker network, e.g., the
+// network of an inter-AS link, and reconnects it with the same address on
+// revert.
+type DisconnectNetwork struct {
+	Container string
+	Network   string
+	// IP is the address of the container in the network. It is restored on
+	// revert.
+	IP netip.Addr
+}
+
+func (f DisconnectNetwork) Inject(ctx context.Context) error {
+	return runCommand(ctx, []string{"docker", "network", "disconnect", "-f",
+		f.Network, f.Container})
+}
+
+func (f DisconnectNetwork) Revert(ctx context.Context) error {
+	ipFlag := "--ip"
+	if f.IP.Is6() {
+		ipFlag = "--ip6"
+	}
+	return runCommand(ctx, []string{"docker", "network", "connect", ipFlag, f.IP.String(),
+		f.Network, f.Container})
+}
+
+// CommandFault runs arbitrary commands to inject and revert a fault.
+type CommandFault struct {
+	InjectCmd []string
+	// RevertCmd is run on revert. If empty, nothing is done.
+	RevertCmd []string
+}
+
+func (f CommandFault) Inject(ctx context.Context) error {
+	return runCommand(ctx, f.InjectCmd)
+}
+
+func (f CommandFault) Revert(ctx context.Context) error {
+	if len(f.RevertCmd) == 0 {
+		return nil
+	}
+	return runCommand(ctx, f.RevertCmd)
+}
+
+// Revocation reports the revocation of an interface to a daemon, the same way
+// an application does when it receives an SCMP interface down message. The
+// daemon applies its own TTL to the revocation and the revocation expires by
+// itself, revert is a no-op.
+type Revocation struct {
+	Daemon daemon.Connector
+	// IA and Interface identify the revoked interface.
+	IA        addr.IA
+	Interface uint64
+}
+
+func (f Revocation) Inject(ctx context.Context) error {
+	return f.Daemon.RevNotification(ctx, &path_mgmt.RevInfo{
+		IfID:         common.IFIDType(f.Interface),
+		RawIsdas:     f.IA,
+		RawTimestamp: util.TimeToSecs(time.Now()),
+		RawTTL:       uint32(path_mgmt.MinRevTTL / time.Second),
+	})
+}
+
+func (f Revocation) Revert(context.Context) error {
+	return nil
+}
+
+// SCMPRevocation sends an SCMP external interface down message to an
+// application, which reports the revocation to its daemon. The message quotes
+// a packet sent by the application, such that the dispatcher delivers it to
+// the application. The revocation expires by itself, revert is a no-op.
+type SCMPRevocation struct {
+	Conn snet.PacketConn
+	// Local is the source address of the message.
+	Local *snet.UDPAddr
+	// Target is the address of the application. It includes the path and
+	// next hop from the local host. If the next hop is nil, the application
+	// is in the local AS and the message is sent to its dispatcher.
+	Target *snet.UDPAddr
+	// IA and Interface identify the revoked interface.
+	IA        addr.IA
+	Interface uint64
+}
+
+func (f SCMPRevocation) Inject(ctx context.Context) error {
+	local, ok := netip.AddrFromSlice(f.Local.Host.IP)
+	if !ok {
+		return serrors.New("invalid local IP", "ip", f.Local.Host.IP)
+	}
+	target, ok := netip.AddrFromSlice(f.Target.Host.IP)
+	if !ok {
+		return serrors.New("invalid target IP", "ip", f.Target.Host.IP)
+	}
+	localAddr := snet.SCIONAddress{IA: f.Local.IA, Host: addr.HostIP(local.Unmap())}
+	targetAddr := snet.SCIONAddress{IA: f.Target.IA, Host: addr.HostIP(target.Unmap())}
+	quote := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Source:      targetAddr,
+			Destination: localAddr,
+			Path:        snetpath.Empty{},
+			Payload: snet.UDPPayload{
+				SrcPort: uint16(f.Target.Host.Port),
+				DstPort: uint16(f.Local.Host.Port),
+			},
+		},
+	}
+	if err := quote.Serialize(); err != nil {
+		return serrors.WrapStr("serializing quoted packet", err)
+	}
+	pkt := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Source:      localAddr,
+			Destination: targetAddr,
+			Path:        f.Target.Path,
+			Payload: snet.SCMPExternalInterfaceDown{
+				IA:        f.IA,
+				Interface: f.Interface,
+				Payload:   quote.Bytes,
+			},
+		},
+	}
+	nextHop := f.Target.NextHop
+	if nextHop == nil {
+		nextHop = &net.UDPAddr{IP: f.Target.Host.IP, Port: underlay.EndhostPort}
+	}
+	if err := f.Conn.WriteTo(pkt, nextHop); err != nil {
+		return serrors.WrapStr("sending SCMP message", err)
+	}
+	return nil
+}
+
+func (f SCMPRevocation) Revert(context.Context) error {
+	return nil
+}
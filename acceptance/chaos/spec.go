@@ -0,0 +1,370 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// Spec is the specification of a set of scenarios. It is loaded from a YAML
+// file of the following form:
+//
+//	scenarios:
+//	  - name: kill-router
+//	    steady:
+//	      - name: two paths
+//	        within: 60s
+//	        path_count: {src: "1-ff00:0:111", dst: "1-ff00:0:112", min: 2}
+//	    fault:
+//	      kill_service: scion_br1-ff00_0_110-1
+//	    recovery:
+//	      - name: daemon failover
+//	        within: 30s
+//	        paths_avoid:
+//	          src: "1-ff00:0:111"
+//	          dst: "1-ff00:0:112"
+//	          avoid: ["1-ff00:0:110#1"]
+//	          first_only: true
+//	    restore:
+//	      - name: beaconing re-convergence
+//	        within: 60s
+//	        path_count: {src: "1-ff00:0:111", dst: "1-ff00:0:112", min: 2, refresh: true}
+//
+// The daemons are identified by the ISD-AS they serve (src).
+type Spec struct {
+	Scenarios []ScenarioSpec `yaml:"scenarios"`
+}
+
+// ScenarioSpec specifies a scenario.
+type ScenarioSpec struct {
+	Name     string            `yaml:"name"`
+	Steady   []ExpectationSpec `yaml:"steady"`
+	Fault    FaultSpec         `yaml:"fault"`
+	Recovery []ExpectationSpec `yaml:"recovery"`
+	Restore  []ExpectationSpec `yaml:"restore"`
+}
+
+// FaultSpec specifies a fault. Exactly one of the fields must be set.
+type FaultSpec struct {
+	// KillService is the docker compose service that is killed.
+	KillService string `yaml:"kill_service,omitempty"`
+	// DisconnectNetwork disconnects a container from a docker network.
+	DisconnectNetwork *DisconnectNetworkSpec `yaml:"disconnect_network,omitempty"`
+	// Revocation reports the revocation of an interface to the daemon of src.
+	Revocation *RevocationSpec `yaml:"revocation,omitempty"`
+	// Command runs arbitrary commands on the host.
+	Command *CommandFaultSpec `yaml:"command,omitempty"`
+}
+
+// ExpectationSpec specifies an expectation. Exactly one of the checks must be
+// set.
+type ExpectationSpec struct {
+	Name   string `yaml:"name"`
+	Within string `yaml:"within"`
+	// PathsAvoid checks that the daemon of src fails over to paths that avoid
+	// the interfaces in the format ISD-AS#ID.
+	PathsAvoid *PathsAvoidSpec `yaml:"paths_avoid,omitempty"`
+	// PathCount checks that the daemon of src returns at least min paths.
+	PathCount *PathCountSpec `yaml:"path_count,omitempty"`
+	// GatewayPing pings the tester of dst from the tester of src. The traffic
+	// is routed through the gateways of the ASes.
+	GatewayPing *GatewayPingSpec `yaml:"gateway_ping,omitempty"`
+	// Exec runs a command in the container of a docker compose service.
+	Exec *ExecSpec `yaml:"exec,omitempty"`
+	// Command runs a command on the host.
+	Command []string `yaml:"command,omitempty"`
+}
+
+// DisconnectNetworkSpec specifies a DisconnectNetwork fault.
+type DisconnectNetworkSpec struct {
+	Container string `yaml:"container"`
+	Network   string `yaml:"network"`
+	IP        string `yaml:"ip"`
+}
+
+// RevocationSpec specifies a Revocation fault. The interface is in the format
+// ISD-AS#ID.
+type RevocationSpec struct {
+	Src       string `yaml:"src"`
+	Interface string `yaml:"interface"`
+}
+
+// CommandFaultSpec specifies a CommandFault.
+type CommandFaultSpec struct {
+	Inject []string `yaml:"inject"`
+	Revert []string `yaml:"revert,omitempty"`
+}
+
+// PathsAvoidSpec specifies a PathsAvoid check.
+type PathsAvoidSpec struct {
+	Src       string   `yaml:"src"`
+	Dst       string   `yaml:"dst"`
+	Avoid     []string `yaml:"avoid"`
+	FirstOnly bool     `yaml:"first_only,omitempty"`
+}
+
+// PathCountSpec specifies a PathCount check.
+type PathCountSpec struct {
+	Src     string `yaml:"src"`
+	Dst     string `yaml:"dst"`
+	Min     int    `yaml:"min"`
+	Refresh bool   `yaml:"refresh,omitempty"`
+}
+
+// GatewayPingSpec specifies a gateway ping. It is run as an ExecCheck in the
+// tester of src.
+type GatewayPingSpec struct {
+	Src string `yaml:"src"`
+	Dst string `yaml:"dst"`
+}
+
+// ExecSpec specifies an ExecCheck.
+type ExecSpec struct {
+	Service string   `yaml:"service"`
+	Cmd     []string `yaml:"cmd"`
+}
+
+// Env provides access to the topology under test.
+type Env struct {
+	Compose Compose
+	// Daemon returns a connector to the daemon of the AS.
+	Daemon func(ctx context.Context, ia addr.IA) (daemon.Connector, error)
+	// Tester returns the IP address of the tester of the AS that is reachable
+	// through the gateways.
+	Tester func(ia addr.IA) (netip.Addr, error)
+}
+
+// LoadSpec loads the specification from the YAML file.
+func LoadSpec(file string) (*Spec, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var s Spec
+	if err := yaml.UnmarshalStrict(raw, &s); err != nil {
+		return nil, serrors.WrapStr("parsing scenarios", err, "file", file)
+	}
+	return &s, nil
+}
+
+// Build builds the scenarios of the specification.
+func (s *Spec) Build(ctx context.Context, env Env) ([]Scenario, error) {
+	scenarios := make([]Scenario, 0, len(s.Scenarios))
+	names := make(map[string]struct{}, len(s.Scenarios))
+	for _, spec := range s.Scenarios {
+		if spec.Name == "" {
+			return nil, serrors.New("scenario without name")
+		}
+		if _, ok := names[spec.Name]; ok {
+			return nil, serrors.New("duplicate scenario", "name", spec.Name)
+		}
+		names[spec.Name] = struct{}{}
+		sc, err := spec.build(ctx, env)
+		if err != nil {
+			return nil, serrors.WrapStr("building scenario", err, "name", spec.Name)
+		}
+		scenarios = append(scenarios, sc)
+	}
+	return scenarios, nil
+}
+
+func (s ScenarioSpec) build(ctx context.Context, env Env) (Scenario, error) {
+	sc := Scenario{Name: s.Name}
+	var err error
+	if sc.Fault, err = s.Fault.build(ctx, env); err != nil {
+		return Scenario{}, serrors.WrapStr("building fault", err)
+	}
+	for _, p := range []struct {
+		dst   *[]Expectation
+		specs []ExpectationSpec
+	}{
+		{&sc.Steady, s.Steady},
+		{&sc.Recovery, s.Recovery},
+		{&sc.Restore, s.Restore},
+	} {
+		for _, spec := range p.specs {
+			e, err := spec.build(ctx, env)
+			if err != nil {
+				return Scenario{}, serrors.WrapStr("building expectation", err,
+					"name", spec.Name)
+			}
+			*p.dst = append(*p.dst, e)
+		}
+	}
+	return sc, nil
+}
+
+func (s FaultSpec) build(ctx context.Context, env Env) (Fault, error) {
+	var faults []Fault
+	if s.KillService != "" {
+		faults = append(faults, KillService{Compose: env.Compose, Service: s.KillService})
+	}
+	if d := s.DisconnectNetwork; d != nil {
+		ip, err := netip.ParseAddr(d.IP)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing IP", err)
+		}
+		faults = append(faults, DisconnectNetwork{
+			Container: d.Container,
+			Network:   d.Network,
+			IP:        ip,
+		})
+	}
+	if r := s.Revocation; r != nil {
+		sd, err := daemonFor(ctx, env, r.Src)
+		if err != nil {
+			return nil, err
+		}
+		intf, err := ParseInterface(r.Interface)
+		if err != nil {
+			return nil, err
+		}
+		faults = append(faults, Revocation{
+			Daemon:    sd,
+			IA:        intf.IA,
+			Interface: uint64(intf.ID),
+		})
+	}
+	if c := s.Command; c != nil {
+		faults = append(faults, CommandFault{InjectCmd: c.Inject, RevertCmd: c.Revert})
+	}
+	if len(faults) != 1 {
+		return nil, serrors.New("exactly one fault must be set", "actual", len(faults))
+	}
+	return faults[0], nil
+}
+
+func (s ExpectationSpec) build(ctx context.Context, env Env) (Expectation, error) {
+	within, err := time.ParseDuration(s.Within)
+	if err != nil {
+		return Expectation{}, serrors.WrapStr("parsing within", err)
+	}
+	if within <= 0 {
+		return Expectation{}, serrors.New("within must be positive", "within", s.Within)
+	}
+	var checks []Check
+	if p := s.PathsAvoid; p != nil {
+		sd, dst, err := daemonAndDst(ctx, env, p.Src, p.Dst)
+		if err != nil {
+			return Expectation{}, err
+		}
+		avoid := make([]snet.PathInterface, 0, len(p.Avoid))
+		for _, raw := range p.Avoid {
+			intf, err := ParseInterface(raw)
+			if err != nil {
+				return Expectation{}, err
+			}
+			avoid = append(avoid, intf)
+		}
+		checks = append(checks, PathsAvoid{
+			Daemon:      sd,
+			Destination: dst,
+			Avoid:       avoid,
+			FirstOnly:   p.FirstOnly,
+		})
+	}
+	if p := s.PathCount; p != nil {
+		sd, dst, err := daemonAndDst(ctx, env, p.Src, p.Dst)
+		if err != nil {
+			return Expectation{}, err
+		}
+		checks = append(checks, PathCount{
+			Daemon:      sd,
+			Destination: dst,
+			Min:         p.Min,
+			Refresh:     p.Refresh,
+		})
+	}
+	if p := s.GatewayPing; p != nil {
+		c, err := gatewayPing(env, p.Src, p.Dst)
+		if err != nil {
+			return Expectation{}, err
+		}
+		checks = append(checks, c)
+	}
+	if e := s.Exec; e != nil {
+		checks = append(checks, ExecCheck{Compose: env.Compose, Service: e.Service, Cmd: e.Cmd})
+	}
+	if len(s.Command) > 0 {
+		checks = append(checks, CommandCheck{Cmd: s.Command})
+	}
+	if len(checks) != 1 {
+		return Expectation{}, serrors.New("exactly one check must be set",
+			"actual", len(checks))
+	}
+	return Expectation{Name: s.Name, Check: checks[0], Within: within}, nil
+}
+
+func daemonAndDst(ctx context.Context, env Env,
+	rawSrc, rawDst string) (daemon.Connector, addr.IA, error) {
+
+	sd, err := daemonFor(ctx, env, rawSrc)
+	if err != nil {
+		return nil, 0, err
+	}
+	dst, err := addr.ParseIA(rawDst)
+	if err != nil {
+		return nil, 0, serrors.WrapStr("parsing dst", err)
+	}
+	return sd, dst, nil
+}
+
+func daemonFor(ctx context.Context, env Env, rawSrc string) (daemon.Connector, error) {
+	src, err := addr.ParseIA(rawSrc)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing src", err)
+	}
+	if env.Daemon == nil {
+		return nil, serrors.New("no daemon available", "src", src)
+	}
+	sd, err := env.Daemon(ctx, src)
+	if err != nil {
+		return nil, serrors.WrapStr("connecting to daemon", err, "src", src)
+	}
+	return sd, nil
+}
+
+func gatewayPing(env Env, rawSrc, rawDst string) (Check, error) {
+	src, err := addr.ParseIA(rawSrc)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing src", err)
+	}
+	dst, err := addr.ParseIA(rawDst)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing dst", err)
+	}
+	if env.Tester == nil {
+		return nil, serrors.New("no tester addresses available", "dst", dst)
+	}
+	ip, err := env.Tester(dst)
+	if err != nil {
+		return nil, serrors.WrapStr("resolving tester", err, "dst", dst)
+	}
+	return ExecCheck{
+		Compose: env.Compose,
+		Service: "tester_" + addr.FormatIA(src, addr.WithFileSeparator()),
+		Cmd:     []string{"ping", "-c", "1", "-W", "1", ip.String()},
+	}, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos_test
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/acceptance/chaos"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/daemon/mock_daemon"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+func TestSpecBuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spec, err := chaos.LoadSpec("testdata/scenarios.yml")
+	require.NoError(t, err)
+	require.Len(t, spec.Scenarios, 2)
+
+	sd := mock_daemon.NewMockConnector(ctrl)
+	var daemons []addr.IA
+	env := chaos.Env{
+		Compose: chaos.Compose{File: "scion-dc.yml", Project: "scion"},
+		Daemon: func(_ context.Context, ia addr.IA) (daemon.Connector, error) {
+			daemons = append(daemons, ia)
+			return sd, nil
+		},
+		Tester: func(ia addr.IA) (netip.Addr, error) {
+			return netip.MustParseAddr("172.20.0.19"), nil
+		},
+	}
+	scenarios, err := spec.Build(context.Background(), env)
+	require.NoError(t, err)
+	require.Len(t, scenarios, 2)
+
+	kill := scenarios[0]
+	assert.Equal(t, "kill-router", kill.Name)
+	assert.Equal(t, chaos.KillService{
+		Compose: env.Compose,
+		Service: "scion_br1-ff00_0_110-1",
+	}, kill.Fault)
+	require.Len(t, kill.Recovery, 2)
+	assert.Equal(t, 30*time.Second, kill.Recovery[0].Within)
+	assert.Equal(t, chaos.PathsAvoid{
+		Daemon:      sd,
+		Destination: xtest.MustParseIA("1-ff00:0:112"),
+		Avoid: []snet.PathInterface{
+			{IA: xtest.MustParseIA("1-ff00:0:110"), ID: 1},
+		},
+		FirstOnly: true,
+	}, kill.Recovery[0].Check)
+	assert.Equal(t, chaos.ExecCheck{
+		Compose: env.Compose,
+		Service: "tester_1-ff00_0_111",
+		Cmd:     []string{"ping", "-c", "1", "-W", "1", "172.20.0.19"},
+	}, kill.Recovery[1].Check)
+	require.Len(t, kill.Restore, 1)
+	assert.Equal(t, chaos.PathCount{
+		Daemon:      sd,
+		Destination: xtest.MustParseIA("1-ff00:0:112"),
+		Min:         2,
+		Refresh:     true,
+	}, kill.Restore[0].Check)
+
+	rev := scenarios[1]
+	assert.Equal(t, chaos.Revocation{
+		Daemon:    sd,
+		IA:        xtest.MustParseIA("1-ff00:0:111"),
+		Interface: 41,
+	}, rev.Fault)
+	require.Len(t, rev.Recovery, 2)
+	assert.Equal(t, chaos.ExecCheck{
+		Compose: env.Compose,
+		Service: "tester_1-ff00_0_111",
+		Cmd:     []string{"scion", "ping", "1-ff00:0:112,127.0.0.1", "-c", "1"},
+	}, rev.Recovery[0].Check)
+	assert.Equal(t, chaos.CommandCheck{Cmd: []string{"true"}}, rev.Recovery[1].Check)
+	assert.Len(t, daemons, 4)
+}
+
+func TestSpecBuildErrors(t *testing.T) {
+	env := chaos.Env{}
+	testCases := map[string]chaos.Spec{
+		"no fault": {Scenarios: []chaos.ScenarioSpec{{Name: "a"}}},
+		"two faults": {Scenarios: []chaos.ScenarioSpec{{
+			Name: "a",
+			Fault: chaos.FaultSpec{
+				KillService: "br",
+				Command:     &chaos.CommandFaultSpec{Inject: []string{"true"}},
+			},
+		}}},
+		"duplicate name": {Scenarios: []chaos.ScenarioSpec{
+			{Name: "a", Fault: chaos.FaultSpec{KillService: "br"}},
+			{Name: "a", Fault: chaos.FaultSpec{KillService: "br"}},
+		}},
+		"invalid within": {Scenarios: []chaos.ScenarioSpec{{
+			Name:   "a",
+			Fault:  chaos.FaultSpec{KillService: "br"},
+			Steady: []chaos.ExpectationSpec{{Name: "e", Within: "soon", Command: []string{"true"}}},
+		}}},
+		"no check": {Scenarios: []chaos.ScenarioSpec{{
+			Name:   "a",
+			Fault:  chaos.FaultSpec{KillService: "br"},
+			Steady: []chaos.ExpectationSpec{{Name: "e", Within: "1s"}},
+		}}},
+		"gateway ping without testers": {Scenarios: []chaos.ScenarioSpec{{
+			Name:  "a",
+			Fault: chaos.FaultSpec{KillService: "br"},
+			Recovery: []chaos.ExpectationSpec{{
+				Name:        "e",
+				Within:      "1s",
+				GatewayPing: &chaos.GatewayPingSpec{Src: "1-ff00:0:111", Dst: "1-ff00:0:112"},
+			}},
+		}}},
+		"revocation without daemon": {Scenarios: []chaos.ScenarioSpec{{
+			Name: "a",
+			Fault: chaos.FaultSpec{Revocation: &chaos.RevocationSpec{
+				Src:       "1-ff00:0:111",
+				Interface: "1-ff00:0:111#41",
+			}},
+		}}},
+	}
+	for name, spec := range testCases {
+		spec := spec
+		t.Run(name, func(t *testing.T) {
+			_, err := spec.Build(context.Background(), env)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Chaos_runner runs the failover scenarios of a chaos specification against a
+// running docker compose topology. It writes a JSON report that records the
+// recovery time of every expectation and exits with a non-zero code if a
+// scenario fails.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/netip"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/scionproto/scion/acceptance/chaos"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/tools/integration"
+)
+
+var (
+	scenarios = flag.String("scenarios", "", "The scenario specification file.")
+	gen       = flag.String("gen", "gen", "The directory of the generated topology.")
+	project   = flag.String("project", "scion", "The docker compose project name.")
+	report    = flag.String("report", "", "The file the JSON report is written to. "+
+		"If empty, the report is written to stdout.")
+	poll = flag.Duration("poll", chaos.DefaultPollInterval,
+		"The interval between evaluating a check that does not hold yet.")
+)
+
+func main() {
+	flag.Parse()
+	if err := realMain(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func realMain() error {
+	if *scenarios == "" {
+		return serrors.New("--scenarios must be set")
+	}
+	spec, err := chaos.LoadSpec(*scenarios)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	daemons := &daemons{
+		file:  filepath.Join(*gen, integration.DaemonAddressesFile),
+		conns: make(map[addr.IA]daemon.Connector),
+	}
+	defer daemons.Close()
+	env := chaos.Env{
+		Compose: chaos.Compose{
+			File:    filepath.Join(*gen, "scion-dc.yml"),
+			Project: *project,
+		},
+		Daemon: daemons.Get,
+		Tester: func(ia addr.IA) (netip.Addr, error) {
+			return testerAddr(filepath.Join(*gen, "sig-testing.conf"), ia)
+		},
+	}
+	built, err := spec.Build(ctx, env)
+	if err != nil {
+		return err
+	}
+	runner := chaos.Runner{
+		PollInterval: *poll,
+		Logf: func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, "%s "+format+"\n",
+				append([]interface{}{time.Now().Format(time.RFC3339)}, args...)...)
+		},
+	}
+	var rep chaos.Report
+	for _, s := range built {
+		// Failing scenarios are recorded in the report, the remaining
+		// scenarios are still run.
+		r, _ := runner.Run(ctx, s)
+		rep.Scenarios = append(rep.Scenarios, r)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if err := writeReport(rep); err != nil {
+		return err
+	}
+	return rep.Err()
+}
+
+func writeReport(rep chaos.Report) error {
+	out := os.Stdout
+	if *report != "" {
+		f, err := os.Create(*report)
+		if err != nil {
+			return serrors.WrapStr("creating report", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "    ")
+	return enc.Encode(rep)
+}
+
+// testerAddr reads the address of the tester of the AS from the gateway
+// testing configuration. Every line of the file is of the form "<ISD-AS> <IP>".
+func testerAddr(file string, ia addr.IA) (netip.Addr, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	for _, line := range strings.Split(string(raw), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != ia.String() {
+			continue
+		}
+		return netip.ParseAddr(fields[1])
+	}
+	return netip.Addr{}, serrors.New("tester not found", "isd_as", ia, "file", file)
+}
+
+// daemons connects lazily to the daemons of the topology.
+type daemons struct {
+	file  string
+	conns map[addr.IA]daemon.Connector
+}
+
+func (d *daemons) Get(ctx context.Context, ia addr.IA) (daemon.Connector, error) {
+	if conn, ok := d.conns[ia]; ok {
+		return conn, nil
+	}
+	address, err := integration.GetSCIONDAddress(d.file, ia)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := daemon.Service{Address: address}.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.conns[ia] = conn
+	return conn, nil
+}
+
+func (d *daemons) Close() {
+	for _, conn := range d.conns {
+		conn.Close()
+	}
+}
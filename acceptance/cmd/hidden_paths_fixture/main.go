@@ -0,0 +1,72 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Hidden_paths_fixture generates the hidden path configuration of every AS of
+// a hidden path acceptance test from the fixture specification. The
+// configuration files are written to the output directory and a JSON manifest
+// that lists the configuration file of every AS and the expected reachability
+// between the ASes is written to stdout.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/scionproto/scion/acceptance/hiddenpaths"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+var (
+	spec = flag.String("spec", "", "The fixture specification file.")
+	out  = flag.String("out", "", "The directory the configuration files are written to.")
+)
+
+type manifest struct {
+	Configs      map[addr.IA]string        `json:"configs"`
+	Expectations []hiddenpaths.Expectation `json:"expectations"`
+}
+
+func main() {
+	flag.Parse()
+	if err := realMain(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func realMain() error {
+	if *spec == "" || *out == "" {
+		return serrors.New("both --spec and --out must be set")
+	}
+	f, err := hiddenpaths.LoadFixture(*spec)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		return err
+	}
+	configs, err := f.WriteConfigs(*out)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "    ")
+	return enc.Encode(manifest{
+		Configs:      configs,
+		Expectations: f.Expectations(),
+	})
+}
@@ -0,0 +1,136 @@
+# Copyright 2023 SCION Association
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#   http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import functools
+import http.server
+import json
+import threading
+from typing import Dict, List, NamedTuple
+
+from plumbum import cli
+from plumbum import cmd
+
+from acceptance.common import base, scion
+from tools.topology.scion_addr import ISD_AS
+
+
+class Expectation(NamedTuple):
+    src: ISD_AS
+    dst: ISD_AS
+    reachable: bool
+
+
+class TestHiddenPaths(base.TestTopogen):
+    """
+    Base class for hidden path tests.
+
+    The hidden path groups of the topology are described by the fixture
+    specification passed with --hp-spec. See the acceptance/hiddenpaths Go
+    package for the format. During setup, the hidden_paths_fixture tool derives
+    the hidden path configuration of every AS from the specification. The
+    configuration is served over HTTP and referenced from the configuration of
+    the daemons and control services. Writers register their hidden
+    down-segments at the registries of their groups.
+
+    The default run step waits for connectivity and then checks, for every pair
+    of ASes in the specification, that the paths can be resolved if and only
+    if the source is allowed to read the hidden segments of the destination.
+    Tests can extend the run step with additional checks.
+
+    Subclasses must define config_server_ips, which maps every ISD-AS in the
+    specification to the host IP on the docker network of the AS.
+    """
+
+    hp_spec = cli.SwitchAttr("hp-spec", cli.ExistingFile, mandatory=True,
+                             help="Hidden paths fixture specification")
+
+    config_server_port = 9099
+    config_server_ips: Dict[str, str] = {}
+
+    def setup_prepare(self):
+        super().setup_prepare()
+
+        out_dir = self.artifacts / "hidden_paths"
+        fixture = self.get_executable("hidden-paths-fixture")
+        manifest = fixture("--spec", self.hp_spec, "--out", out_dir)
+        (self.artifacts / "hidden_paths.json").write(manifest)
+
+        for isd_as, config in json.loads(manifest)["configs"].items():
+            url = "http://%s:%d/%s" % (self.config_server_ips[isd_as],
+                                       self.config_server_port, config)
+            self._configure_as(ISD_AS(isd_as), url)
+
+    def _configure_as(self, isd_as: ISD_AS, config_url: str):
+        as_dir = self.artifacts / "gen" / ("AS%s" % isd_as.as_file_fmt())
+        scion.update_toml({"sd.hidden_path_groups": config_url}, [as_dir / "sd.toml"])
+
+        control_id = "cs%s-1" % isd_as.file_fmt()
+        scion.update_toml({"path.hidden_paths_cfg": config_url},
+                          [as_dir / ("%s.toml" % control_id)])
+
+        # For simplicity, expose the hidden segment services in all hidden
+        # paths ASes, even though some don't need the registration service.
+        # They are behind the same server as the control service.
+        topology_file = as_dir / "topology.json"
+        control_service_addr = scion.load_from_json(
+            "control_service.%s.addr" % control_id, [topology_file])
+        scion.update_json({
+            "hidden_segment_lookup_service.%s.addr" % control_id: control_service_addr,
+            "hidden_segment_registration_service.%s.addr" % control_id: control_service_addr,
+        }, [topology_file])
+
+    def setup_start(self):
+        # The configuration server runs on 0.0.0.0 and needs to be reachable
+        # from every daemon and control service.
+        handler = functools.partial(http.server.SimpleHTTPRequestHandler,
+                                    directory=str(self.artifacts / "hidden_paths"))
+        self._config_server = http.server.HTTPServer(
+            ("0.0.0.0", self.config_server_port), handler)
+        threading.Thread(target=self._config_server.serve_forever).start()
+        print("HTTP configuration server started on %s:%d" %
+              self._config_server.server_address)
+
+        super().setup_start()
+
+    def stop_config_server(self):
+        server = getattr(self, "_config_server", None)
+        if server is not None:
+            server.shutdown()
+            self._config_server = None
+
+    def teardown(self):
+        self.stop_config_server()
+        super().teardown()
+
+    def _run(self):
+        self.await_connectivity()
+        # By now the configuration must have been downloaded everywhere.
+        self.stop_config_server()
+        self.check_expectations()
+
+    def expectations(self) -> List[Expectation]:
+        """Returns the expected reachability between the ASes of the fixture."""
+        manifest = json.loads((self.artifacts / "hidden_paths.json").read())
+        return [Expectation(ISD_AS(e["src"]), ISD_AS(e["dst"]), e["reachable"])
+                for e in manifest["expectations"]]
+
+    def check_expectations(self):
+        for e in self.expectations():
+            self.showpaths(e.src, e.dst, e.reachable)
+
+    def showpaths(self, src: ISD_AS, dst: ISD_AS, reachable: bool):
+        """Runs showpaths from src to dst and asserts whether paths are found."""
+        print(cmd.docker("exec", "-t", "tester_%s" % src.file_fmt(), "scion",
+                         "sp", str(dst), "--timeout", "2s",
+                         retcode=0 if reachable else 1))
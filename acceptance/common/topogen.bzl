@@ -73,7 +73,7 @@ def topogen_test(
     py_binary(
         name = "%s_setup" % name,
         srcs = [src],
-        args = ["setup"] + common_args,
+        args = ["setup"] + args + common_args,
         main = src,
         deps = [":%s_lib" % name],
         data = data + common_data,
@@ -91,7 +91,7 @@ def topogen_test(
     py_binary(
         name = "%s_teardown" % name,
         srcs = [src],
-        args = ["teardown"] + common_args,
+        args = ["teardown"] + args + common_args,
         main = src,
         deps = [":%s_lib" % name],
         data = data + common_data,
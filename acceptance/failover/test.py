@@ -0,0 +1,44 @@
+#!/usr/bin/env python3
+
+# Copyright 2023 SCION Association
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#   http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+from plumbum import cli
+
+from acceptance.common import base
+
+
+class Test(base.TestTopogen):
+    """
+    Injects faults into a running topology and asserts the recovery of the
+    daemon path selection, the gateway sessions and the beaconing.
+
+    The scenarios are specified in testdata/scenarios.yml and run by the
+    chaos-runner. The runner writes a JSON report with the recovery time of
+    every expectation to the artifacts directory.
+    """
+
+    scenarios = cli.SwitchAttr("scenarios", cli.ExistingFile, mandatory=True,
+                               help="Scenario specification file")
+
+    def _run(self):
+        self.await_connectivity()
+        runner = self.get_executable("chaos-runner")
+        runner["--scenarios", self.scenarios,
+               "--gen", self.artifacts / "gen",
+               "--report", self.artifacts / "chaos-report.json"].run_fg()
+
+
+if __name__ == "__main__":
+    base.main(Test)
@@ -2,16 +2,11 @@
 
 # Copyright 2020 Anapaya Systems
 
-import http.server
-import threading
-
-from plumbum import cmd
-
 from acceptance.common import base
-from acceptance.common import scion
+from acceptance.common import hidden_paths
 
 
-class Test(base.TestTopogen):
+class Test(hidden_paths.TestHiddenPaths):
     """
     Constructs a simple Hidden Paths topology with one core, four leaf ASes and
     two hidden path groups.
@@ -43,116 +38,18 @@ class Test(base.TestTopogen):
         AS2 <-> AS4, AS2 <-> AS5, AS4 <-> AS5 (Group ff00:0:2-4)
       Expect no connectivity:
         AS3 <-> AS4 (Group ff00:0:2-3 to group ff00:0:2-4)
-    """
-
-    http_server_port = 9099
-
-    def setup_prepare(self):
-        super().setup_prepare()
-
-        as_numbers = ["2", "3", "4", "5"]
-        # HTTP configuration server runs on 0.0.0.0 and needs to be reachable from
-        # every daemon and control service. There is one host IP on every AS bridge.
-        # We use this IP for the configuration download URLs.
-        server_ips = {
-            "2": "172.20.0.49",
-            "3": "172.20.0.57",
-            "4": "172.20.0.65",
-            "5": "172.20.0.73",
-        }
-        # Each AS participating in hidden paths has their own hidden paths configuration file.
-        hp_configs = {
-            "2": "hp_groups_as2_as5.yml",
-            "3": "hp_groups_as3.yml",
-            "4": "hp_groups_as4.yml",
-            "5": "hp_groups_as2_as5.yml",
-        }
-
-        # Edit all the configuration files of daemons and control services with
-        # the computed configuration URL
-        for as_number in as_numbers:
-            hp_config_url = "http://%s:%d/acceptance/hidden_paths/testdata/%s" % (
-                server_ips[as_number], self.http_server_port, hp_configs[as_number])
-
-            daemon_path = self.artifacts / "gen" / ("ASff00_0_%s" % as_number) \
-                / "sd.toml"
-            scion.update_toml({"sd.hidden_path_groups": hp_config_url}, [daemon_path])
-
-            control_id = "cs1-ff00_0_%s-1" % as_number
-            control_path = self.artifacts / "gen" / ("ASff00_0_%s" % as_number) \
-                / ("%s.toml" % control_id)
-            scion.update_toml({"path.hidden_paths_cfg": hp_config_url}, [control_path])
-
-            # For simplicity, expose the services in all hidden paths ASes,
-            # even though some don't need the registration service.
-            as_dir_path = self.artifacts / "gen" / ("ASff00_0_%s" % as_number)
-
-            # The hidden_segment services are behind the same server as the control_service.
-            topology_file = as_dir_path / "topology.json"
-            control_service_addr = scion.load_from_json(
-                'control_service.%s.addr' % control_id, [topology_file])
-            topology_update = {
-                "hidden_segment_lookup_service.%s.addr" % control_id:
-                    control_service_addr,
-                "hidden_segment_registration_service.%s.addr" % control_id:
-                    control_service_addr,
-            }
-            scion.update_json(topology_update, [topology_file])
-
-    def setup_start(self):
-        server = http.server.HTTPServer(
-                ("0.0.0.0", self.http_server_port), http.server.SimpleHTTPRequestHandler)
-        server_thread = threading.Thread(target=configuration_server, args=[server])
-        server_thread.start()
-        self._server = server
-
-        super().setup_start()
-
-        self._testers = {
-            "2": "tester_1-ff00_0_2",
-            "3": "tester_1-ff00_0_3",
-            "4": "tester_1-ff00_0_4",
-            "5": "tester_1-ff00_0_5",
-        }
-        self._ases = {
-            "2": "1-ff00:0:2",
-            "3": "1-ff00:0:3",
-            "4": "1-ff00:0:4",
-            "5": "1-ff00:0:5",
-        }
-
-    def _run(self):
-        self.await_connectivity()
-        self._server.shutdown()  # by now configuration must have been downloaded everywhere
-
-        # Group 3
-        self._showpaths_bidirectional("2", "3", 0)
-        self._showpaths_bidirectional("2", "5", 0)
-        self._showpaths_bidirectional("3", "5", 0)
-
-        # Group 4
-        self._showpaths_bidirectional("2", "4", 0)
-        self._showpaths_bidirectional("2", "5", 0)
-        self._showpaths_bidirectional("4", "5", 0)
-
-        # Group 3 X 4
-        self._showpaths_bidirectional("3", "4", 1)
-
-    def _showpaths_bidirectional(self, source: str, destination: str, retcode: int):
-        self._showpaths_run(source, destination, retcode)
-        self._showpaths_run(destination, source, retcode)
-
-    def _showpaths_run(self, source_as: str, destination_as: str, retcode: int):
-        print(cmd.docker("exec", "-t", self._testers[source_as], "scion",
-                         "sp", self._ases[destination_as],
-                         "--timeout", "2s",
-                         retcode=retcode))
 
+    The expectations are derived from the fixture specification in
+    testdata/hidden_paths.yml.
+    """
 
-def configuration_server(server):
-    print("HTTP configuration server starting on %s:%d." % server.server_address)
-    server.serve_forever()
-    print("HTTP configuration server closed.")
+    # There is one host IP on every AS bridge.
+    config_server_ips = {
+        "1-ff00:0:2": "172.20.0.49",
+        "1-ff00:0:3": "172.20.0.57",
+        "1-ff00:0:4": "172.20.0.65",
+        "1-ff00:0:5": "172.20.0.73",
+    }
 
 
 if __name__ == "__main__":
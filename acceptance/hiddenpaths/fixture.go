@@ -0,0 +1,234 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hiddenpaths provides a reusable fixture for hidden path acceptance
+// tests. The fixture is described by a single specification file that contains
+// all hidden path groups of the test topology and the interfaces on which the
+// writers register their hidden down-segments. From the specification, the
+// fixture derives the hidden path configuration of every AS and the expected
+// reachability between the ASes.
+//
+// The specification uses the hidden path groups format, extended with the
+// registration_interfaces section:
+//
+//	groups:
+//	  "ff00:0:2-3":
+//	    owner: "1-ff00:0:2"
+//	    writers:
+//	      - "1-ff00:0:3"
+//	    readers:
+//	      - "1-ff00:0:5"
+//	    registries:
+//	      - "1-ff00:0:2"
+//	registration_interfaces:
+//	  "1-ff00:0:3": [1]
+package hiddenpaths
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Fixture describes the hidden path setup of an acceptance test topology.
+type Fixture struct {
+	// Groups contains all hidden path groups of the topology.
+	Groups hiddenpath.Groups
+	// Interfaces maps every writer to the interfaces on which it registers its
+	// down-segments in the groups it writes to.
+	Interfaces map[addr.IA][]uint64
+}
+
+// Expectation is the expected outcome of a path lookup from Src to Dst.
+type Expectation struct {
+	Src       addr.IA `json:"src"`
+	Dst       addr.IA `json:"dst"`
+	Reachable bool    `json:"reachable"`
+}
+
+type specInfo struct {
+	Interfaces map[string][]uint64 `yaml:"registration_interfaces,omitempty"`
+}
+
+// LoadFixture loads the fixture from the specification file.
+func LoadFixture(file string) (*Fixture, error) {
+	groups, err := hiddenpath.LoadHiddenPathGroups(file)
+	if err != nil {
+		return nil, serrors.WrapStr("loading groups", err)
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var info specInfo
+	if err := yaml.Unmarshal(raw, &info); err != nil {
+		return nil, serrors.WrapStr("parsing registration interfaces", err, "file", file)
+	}
+	f := &Fixture{
+		Groups:     groups,
+		Interfaces: make(map[addr.IA][]uint64, len(info.Interfaces)),
+	}
+	for rawIA, ifIDs := range info.Interfaces {
+		ia, err := addr.ParseIA(rawIA)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing registration interfaces", err, "file", file)
+		}
+		f.Interfaces[ia] = ifIDs
+	}
+	if err := f.Validate(); err != nil {
+		return nil, serrors.WrapStr("validating", err, "file", file)
+	}
+	return f, nil
+}
+
+// Validate checks that every writer has at least one registration interface
+// and that only writers have registration interfaces.
+func (f *Fixture) Validate() error {
+	for _, ia := range f.ASes() {
+		roles := f.Groups.Roles(ia)
+		if roles.Writer && len(f.Interfaces[ia]) == 0 {
+			return serrors.New("writer without registration interfaces", "isd_as", ia)
+		}
+	}
+	for ia := range f.Interfaces {
+		if !f.Groups.Roles(ia).Writer {
+			return serrors.New("registration interfaces for non-writer", "isd_as", ia)
+		}
+	}
+	return nil
+}
+
+// ASes returns all ASes that have a role in at least one group, in ascending
+// order.
+func (f *Fixture) ASes() []addr.IA {
+	set := make(map[addr.IA]struct{})
+	for _, group := range f.Groups {
+		set[group.Owner] = struct{}{}
+		for _, members := range []map[addr.IA]struct{}{
+			group.Writers, group.Readers, group.Registries,
+		} {
+			for ia := range members {
+				set[ia] = struct{}{}
+			}
+		}
+	}
+	ases := make([]addr.IA, 0, len(set))
+	for ia := range set {
+		ases = append(ases, ia)
+	}
+	sort.Slice(ases, func(i, j int) bool { return ases[i] < ases[j] })
+	return ases
+}
+
+// Config returns the hidden path configuration of the AS. The configuration
+// contains the groups the AS has a role in. For writers, it additionally
+// contains the registration policy that registers the down-segments of the
+// registration interfaces in the groups the AS writes to. The configuration is
+// understood by both the control service and the daemon.
+func (f *Fixture) Config(ia addr.IA) ([]byte, error) {
+	member := make(hiddenpath.Groups)
+	written := make(map[hiddenpath.GroupID]*hiddenpath.Group)
+	for id, group := range f.Groups {
+		if !(hiddenpath.Groups{id: group}).Roles(ia).None() {
+			member[id] = group
+		}
+		if _, ok := group.Writers[ia]; ok {
+			written[id] = group
+		}
+	}
+	if len(member) == 0 {
+		return nil, serrors.New("AS has no role in any group", "isd_as", ia)
+	}
+	if len(written) == 0 {
+		return yaml.Marshal(member)
+	}
+	policy := make(hiddenpath.RegistrationPolicy)
+	for _, ifID := range f.Interfaces[ia] {
+		policy[ifID] = hiddenpath.InterfacePolicy{Groups: written}
+	}
+	return yaml.Marshal(policy)
+}
+
+// WriteConfigs writes the hidden path configuration of every AS that has a
+// role in a group to the directory. It returns the file name of the
+// configuration, relative to the directory, for every AS.
+func (f *Fixture) WriteConfigs(dir string) (map[addr.IA]string, error) {
+	files := make(map[addr.IA]string)
+	for _, ia := range f.ASes() {
+		raw, err := f.Config(ia)
+		if err != nil {
+			return nil, err
+		}
+		name := "hp_groups_" + addr.FormatIA(ia, addr.WithFileSeparator()) + ".yml"
+		if err := os.WriteFile(filepath.Join(dir, name), raw, 0644); err != nil {
+			return nil, serrors.WrapStr("writing configuration", err, "isd_as", ia)
+		}
+		files[ia] = name
+	}
+	return files, nil
+}
+
+// Reachable returns whether a path lookup from src to dst is expected to
+// succeed. The down-segments of a writer are only registered in the hidden
+// path groups it writes to. Thus, a writer can only be reached from ASes that
+// are allowed to read at least one of these groups. All other ASes register
+// their down-segments publicly.
+func (f *Fixture) Reachable(src, dst addr.IA) bool {
+	hidden := false
+	for _, group := range f.Groups {
+		if _, ok := group.Writers[dst]; !ok {
+			continue
+		}
+		hidden = true
+		if canRead(src, group) {
+			return true
+		}
+	}
+	return !hidden
+}
+
+// Expectations returns the expected reachability between all distinct pairs
+// of ASes that have a role in a group.
+func (f *Fixture) Expectations() []Expectation {
+	ases := f.ASes()
+	var exps []Expectation
+	for _, src := range ases {
+		for _, dst := range ases {
+			if src == dst {
+				continue
+			}
+			exps = append(exps, Expectation{
+				Src:       src,
+				Dst:       dst,
+				Reachable: f.Reachable(src, dst),
+			})
+		}
+	}
+	return exps
+}
+
+// canRead mirrors the authorization of hidden segment lookups: all members of
+// a group are allowed to read its segments.
+func canRead(ia addr.IA, group *hiddenpath.Group) bool {
+	_, writer := group.Writers[ia]
+	_, reader := group.Readers[ia]
+	_, registry := group.Registries[ia]
+	return ia == group.Owner || writer || reader || registry
+}
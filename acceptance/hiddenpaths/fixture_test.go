@@ -0,0 +1,98 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpaths_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/acceptance/hiddenpaths"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+var (
+	as2 = xtest.MustParseIA("1-ff00:0:2")
+	as3 = xtest.MustParseIA("1-ff00:0:3")
+	as4 = xtest.MustParseIA("1-ff00:0:4")
+	as5 = xtest.MustParseIA("1-ff00:0:5")
+)
+
+func TestLoadFixture(t *testing.T) {
+	f, err := hiddenpaths.LoadFixture("testdata/spec.yml")
+	require.NoError(t, err)
+	assert.Len(t, f.Groups, 2)
+	assert.Equal(t, []addr.IA{as2, as3, as4, as5}, f.ASes())
+	assert.Equal(t, map[addr.IA][]uint64{as3: {1}, as4: {1}}, f.Interfaces)
+
+	f.Interfaces = nil
+	assert.Error(t, f.Validate())
+}
+
+func TestFixtureWriteConfigs(t *testing.T) {
+	f, err := hiddenpaths.LoadFixture("testdata/spec.yml")
+	require.NoError(t, err)
+	dir := t.TempDir()
+	files, err := f.WriteConfigs(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+
+	// Every AS only knows the groups it has a role in.
+	expectedGroups := map[addr.IA]int{as2: 2, as3: 1, as4: 1, as5: 2}
+	for ia, file := range files {
+		groups, policy, err := hiddenpath.LoadConfiguration(filepath.Join(dir, file))
+		require.NoError(t, err, ia)
+		assert.Len(t, groups, expectedGroups[ia], ia)
+		if ia == as3 || ia == as4 {
+			require.Contains(t, policy, uint64(1), ia)
+			assert.False(t, policy[1].Public, ia)
+			assert.Len(t, policy[1].Groups, 1, ia)
+		} else {
+			assert.Empty(t, policy, ia)
+		}
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, files[as5]))
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "registration_policy_per_interface")
+}
+
+func TestFixtureReachable(t *testing.T) {
+	f, err := hiddenpaths.LoadFixture("testdata/spec.yml")
+	require.NoError(t, err)
+	testCases := []struct {
+		Src, Dst  addr.IA
+		Reachable bool
+	}{
+		{Src: as2, Dst: as3, Reachable: true},
+		{Src: as5, Dst: as3, Reachable: true},
+		{Src: as3, Dst: as5, Reachable: true},
+		{Src: as3, Dst: as2, Reachable: true},
+		{Src: as3, Dst: as4, Reachable: false},
+		{Src: as4, Dst: as3, Reachable: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.Reachable, f.Reachable(tc.Src, tc.Dst), "%s -> %s", tc.Src, tc.Dst)
+	}
+	exps := f.Expectations()
+	assert.Len(t, exps, 12)
+	for _, exp := range exps {
+		assert.Equal(t, f.Reachable(exp.Src, exp.Dst), exp.Reachable)
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bootstrapper implements the bootstrapping of SCION end hosts. The
+// bootstrapper discovers a bootstrap server of the local AS, fetches the
+// topology and the TRCs from it, and writes them together with a SCION Daemon
+// configuration to the configuration directory.
+//
+// Any server that exposes the /topology, /trcs and /trcs/{id}/blob endpoints
+// of the control service management API can be used as bootstrap server.
+package bootstrapper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/topology"
+)
+
+// maxResponseSize is the maximum size of a response of a bootstrap server.
+const maxResponseSize = 1 << 20
+
+// Bundle is the configuration of the local AS fetched from a bootstrap server.
+type Bundle struct {
+	// IA is the ISD-AS of the local AS.
+	IA addr.IA
+	// Topology is the raw topology of the local AS.
+	Topology []byte
+	// TRCs are the TRCs of the local ISD.
+	TRCs []cppki.SignedTRC
+}
+
+// Fetch fetches the configuration from the bootstrap server, which is given
+// either as URL or in the format host:port. The topology and the TRCs are
+// validated syntactically, and at least one TRC of the local ISD must be
+// available. The TRCs of other ISDs are ignored.
+func Fetch(ctx context.Context, client *http.Client, server string) (*Bundle, error) {
+	base := server
+	if !strings.Contains(base, "://") {
+		base = "http://" + base
+	}
+	rawTopo, err := get(ctx, client, base+"/topology")
+	if err != nil {
+		return nil, serrors.WrapStr("fetching topology", err)
+	}
+	topo, err := topology.RWTopologyFromJSONBytes(rawTopo)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing topology", err)
+	}
+	rawTRCs, err := get(ctx, client, base+"/trcs")
+	if err != nil {
+		return nil, serrors.WrapStr("fetching TRC list", err)
+	}
+	var briefs []struct {
+		ID struct {
+			ISD    int `json:"isd"`
+			Base   int `json:"base_number"`
+			Serial int `json:"serial_number"`
+		} `json:"id"`
+	}
+	if err := json.Unmarshal(rawTRCs, &briefs); err != nil {
+		return nil, serrors.WrapStr("parsing TRC list", err)
+	}
+	bundle := &Bundle{IA: topo.IA, Topology: rawTopo}
+	for _, brief := range briefs {
+		id := cppki.TRCID{
+			ISD:    addr.ISD(brief.ID.ISD),
+			Base:   scrypto.Version(brief.ID.Base),
+			Serial: scrypto.Version(brief.ID.Serial),
+		}
+		if id.ISD != topo.IA.ISD() {
+			continue
+		}
+		trc, err := fetchTRC(ctx, client, base, id)
+		if err != nil {
+			return nil, serrors.WrapStr("fetching TRC", err, "id", id)
+		}
+		bundle.TRCs = append(bundle.TRCs, trc)
+	}
+	if len(bundle.TRCs) == 0 {
+		return nil, serrors.New("no TRC available for local ISD", "isd", topo.IA.ISD())
+	}
+	return bundle, nil
+}
+
+func fetchTRC(ctx context.Context, client *http.Client, base string,
+	id cppki.TRCID) (cppki.SignedTRC, error) {
+
+	raw, err := get(ctx, client, fmt.Sprintf("%s/trcs/isd%d-b%d-s%d/blob",
+		base, id.ISD, id.Base, id.Serial))
+	if err != nil {
+		return cppki.SignedTRC{}, err
+	}
+	if block, _ := pem.Decode(raw); block != nil {
+		raw = block.Bytes
+	}
+	trc, err := cppki.DecodeSignedTRC(raw)
+	if err != nil {
+		return cppki.SignedTRC{}, serrors.WrapStr("parsing TRC", err)
+	}
+	if trc.TRC.ID != id {
+		return cppki.SignedTRC{}, serrors.New("TRC ID mismatch", "actual", trc.TRC.ID)
+	}
+	return trc, nil
+}
+
+func get(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, serrors.New("unexpected status", "url", url, "status", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+}
+
+// DaemonConfig are the parameters of the SCION Daemon configuration that is
+// written by the bootstrapper.
+type DaemonConfig struct {
+	// ID is the identifier of the daemon.
+	ID string
+	// Address is the address the daemon API listens on.
+	Address string
+	// CacheDir is the directory of the path and trust databases.
+	CacheDir string
+}
+
+var daemonConfig = template.Must(template.New("sd").Parse(`[general]
+id = "{{ .ID }}"
+config_dir = "{{ .ConfigDir }}"
+
+[log.console]
+level = "info"
+
+[trust_db]
+connection = "{{ .CacheDir }}/{{ .ID }}.trust.db"
+
+[path_db]
+connection = "{{ .CacheDir }}/{{ .ID }}.path.db"
+
+[sd]
+address = "{{ .Address }}"
+`))
+
+// Write writes the topology, the TRCs and the daemon configuration to the
+// configuration directory dir. The TRCs are written to the certs
+// subdirectory, the daemon configuration to sd.toml. The path of the daemon
+// configuration is returned.
+//
+// If TRCs of the local ISD are installed in the certs subdirectory already,
+// the fetched TRCs must be verifiable updates of them, otherwise nothing is
+// written. Installed TRCs are never replaced. If no TRC is installed, the
+// fetched TRCs are trusted on first use.
+func (b *Bundle) Write(dir string, sd DaemonConfig) (string, error) {
+	certs := filepath.Join(dir, "certs")
+	if err := os.MkdirAll(certs, 0755); err != nil {
+		return "", serrors.WrapStr("creating certificate directory", err)
+	}
+	trcs, err := b.verifiedTRCs(certs)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(sd.CacheDir, 0755); err != nil {
+		return "", serrors.WrapStr("creating cache directory", err)
+	}
+	if err := writeFile(filepath.Join(dir, "topology.json"), b.Topology); err != nil {
+		return "", err
+	}
+	for _, trc := range trcs {
+		file := filepath.Join(certs, trc.TRC.ID.String()+".trc")
+		if err := writeFile(file, trc.Raw); err != nil {
+			return "", err
+		}
+	}
+	var cfg strings.Builder
+	err = daemonConfig.Execute(&cfg, struct {
+		DaemonConfig
+		ConfigDir string
+	}{DaemonConfig: sd, ConfigDir: dir})
+	if err != nil {
+		return "", serrors.WrapStr("generating daemon configuration", err)
+	}
+	sdFile := filepath.Join(dir, "sd.toml")
+	if err := writeFile(sdFile, []byte(cfg.String())); err != nil {
+		return "", err
+	}
+	return sdFile, nil
+}
+
+// verifiedTRCs returns the fetched TRCs that are not installed in the
+// certificate directory yet. Each of them must be an update of the latest
+// installed TRC, or of another returned TRC, that verifies against its
+// predecessor. A fetched TRC that differs from the installed TRC with the same
+// ID is an error. If no TRC is installed, the oldest fetched TRC becomes the
+// trust anchor without verification against a predecessor.
+func (b *Bundle) verifiedTRCs(certs string) ([]cppki.SignedTRC, error) {
+	installed, err := loadTRCs(certs, b.IA.ISD())
+	if err != nil {
+		return nil, err
+	}
+	var anchor *cppki.SignedTRC
+	for id, trc := range installed {
+		if anchor == nil || olderTRC(anchor.TRC.ID, id) {
+			trc := trc
+			anchor = &trc
+		}
+	}
+	fetched := append([]cppki.SignedTRC(nil), b.TRCs...)
+	sort.Slice(fetched, func(i, j int) bool {
+		return olderTRC(fetched[i].TRC.ID, fetched[j].TRC.ID)
+	})
+	var verified []cppki.SignedTRC
+	for _, trc := range fetched {
+		trc := trc
+		id := trc.TRC.ID
+		if existing, ok := installed[id]; ok {
+			if !bytes.Equal(existing.Raw, trc.Raw) {
+				return nil, serrors.New("fetched TRC differs from installed TRC", "id", id)
+			}
+			continue
+		}
+		switch {
+		case anchor == nil && id.IsBase():
+			if err := trc.Verify(nil); err != nil {
+				return nil, serrors.WrapStr("verifying base TRC", err, "id", id)
+			}
+		case anchor == nil:
+		case olderTRC(id, anchor.TRC.ID):
+			continue
+		case id.Base != anchor.TRC.ID.Base || id.Serial != anchor.TRC.ID.Serial+1:
+			return nil, serrors.New("fetched TRC is not an update of the trusted TRC",
+				"id", id, "trusted", anchor.TRC.ID)
+		default:
+			if err := trc.Verify(&anchor.TRC); err != nil {
+				return nil, serrors.WrapStr("verifying TRC update", err, "id", id)
+			}
+		}
+		verified = append(verified, trc)
+		anchor = &trc
+	}
+	return verified, nil
+}
+
+// loadTRCs loads the TRCs of the ISD that are installed in the certificate
+// directory.
+func loadTRCs(certs string, isd addr.ISD) (map[cppki.TRCID]cppki.SignedTRC, error) {
+	files, err := filepath.Glob(filepath.Join(certs, fmt.Sprintf("ISD%d-B*-S*.trc", isd)))
+	if err != nil {
+		return nil, serrors.WrapStr("listing installed TRCs", err)
+	}
+	trcs := make(map[cppki.TRCID]cppki.SignedTRC, len(files))
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, serrors.WrapStr("reading installed TRC", err, "file", file)
+		}
+		if block, _ := pem.Decode(raw); block != nil {
+			raw = block.Bytes
+		}
+		trc, err := cppki.DecodeSignedTRC(raw)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing installed TRC", err, "file", file)
+		}
+		trcs[trc.TRC.ID] = trc
+	}
+	return trcs, nil
+}
+
+// olderTRC indicates whether the TRC with ID a precedes the TRC with ID b.
+func olderTRC(a, b cppki.TRCID) bool {
+	if a.Base != b.Base {
+		return a.Base < b.Base
+	}
+	return a.Serial < b.Serial
+}
+
+// writeFile atomically replaces the file with the data.
+func writeFile(file string, data []byte) error {
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return serrors.WrapStr("writing file", err, "file", file)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		return serrors.WrapStr("replacing file", err, "file", file)
+	}
+	return nil
+}
+
+// Bootstrapper discovers a bootstrap server and writes the configuration
+// fetched from it.
+type Bootstrapper struct {
+	// Discoverers are queried for bootstrap servers in order. The servers are
+	// tried in the order in which they are discovered until the configuration
+	// is fetched successfully.
+	Discoverers []Discoverer
+	// Client is the HTTP client used to contact the bootstrap servers.
+	Client *http.Client
+	// ConfigDir is the directory the configuration is written to.
+	ConfigDir string
+	// Daemon are the parameters of the daemon configuration.
+	Daemon DaemonConfig
+}
+
+// Run bootstraps the end host. It returns the path of the written daemon
+// configuration.
+func (b *Bootstrapper) Run(ctx context.Context) (string, error) {
+	logger := log.FromCtx(ctx)
+	tried := make(map[string]struct{})
+	var errs serrors.List
+	for _, d := range b.Discoverers {
+		servers, err := d.Discover(ctx)
+		if err != nil {
+			logger.Info("Discovery failed", "discoverer", fmt.Sprintf("%T", d), "err", err)
+		}
+		for _, server := range servers {
+			if _, ok := tried[server]; ok {
+				continue
+			}
+			tried[server] = struct{}{}
+			bundle, err := Fetch(ctx, b.Client, server)
+			if err != nil {
+				logger.Info("Bootstrapping failed", "server", server, "err", err)
+				errs = append(errs, serrors.WithCtx(err, "server", server))
+				continue
+			}
+			sdFile, err := bundle.Write(b.ConfigDir, b.Daemon)
+			if err != nil {
+				logger.Info("Writing configuration failed", "server", server, "err", err)
+				errs = append(errs, serrors.WithCtx(err, "server", server))
+				continue
+			}
+			logger.Info("Bootstrapped", "server", server, "isd_as", bundle.IA,
+				"trcs", len(bundle.TRCs))
+			return sdFile, nil
+		}
+	}
+	if len(tried) == 0 {
+		return "", serrors.New("no bootstrap server discovered")
+	}
+	return "", serrors.WrapStr("bootstrapping failed", errs.ToError())
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bootstrapper_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/bootstrapper"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+)
+
+func TestBootstrapperRun(t *testing.T) {
+	rawTopo, err := os.ReadFile("testdata/topology.json")
+	require.NoError(t, err)
+	rawTRC, err := os.ReadFile("testdata/ISD1-B1-S1.trc")
+	require.NoError(t, err)
+	trcs := `[{"id": {"isd": 1, "base_number": 1, "serial_number": 1}},
+		{"id": {"isd": 2, "base_number": 1, "serial_number": 1}}]`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/topology", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(rawTopo)
+	})
+	mux.HandleFunc("/trcs", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(trcs))
+	})
+	mux.HandleFunc("/trcs/isd1-b1-s1/blob", func(w http.ResponseWriter, _ *http.Request) {
+		_ = pem.Encode(w, &pem.Block{Type: "TRC", Bytes: rawTRC})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	broken := httptest.NewServer(http.NotFoundHandler())
+	defer broken.Close()
+
+	dir := t.TempDir()
+	b := &bootstrapper.Bootstrapper{
+		Discoverers: []bootstrapper.Discoverer{
+			bootstrapper.Static{broken.Listener.Addr().String()},
+			bootstrapper.Static{broken.URL, server.URL},
+		},
+		Client:    server.Client(),
+		ConfigDir: dir,
+		Daemon: bootstrapper.DaemonConfig{
+			ID:       "sd",
+			Address:  "127.0.0.1:30255",
+			CacheDir: filepath.Join(dir, "cache"),
+		},
+	}
+	sdFile, err := b.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "sd.toml"), sdFile)
+
+	topo, err := os.ReadFile(filepath.Join(dir, "topology.json"))
+	require.NoError(t, err)
+	assert.Equal(t, rawTopo, topo)
+	trc, err := os.ReadFile(filepath.Join(dir, "certs", "ISD1-B1-S1.trc"))
+	require.NoError(t, err)
+	assert.Equal(t, rawTRC, trc)
+	assert.NoFileExists(t, filepath.Join(dir, "certs", "ISD2-B1-S1.trc"))
+	assert.DirExists(t, filepath.Join(dir, "cache"))
+
+	sd, err := os.ReadFile(sdFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(sd), `config_dir = "`+dir+`"`)
+	assert.Contains(t, string(sd), `address = "127.0.0.1:30255"`)
+	assert.Contains(t, string(sd), filepath.Join(dir, "cache", "sd.path.db"))
+
+	bundle, err := bootstrapper.Fetch(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:311"), bundle.IA)
+	require.Len(t, bundle.TRCs, 1)
+
+	t.Run("no TRC of local ISD", func(t *testing.T) {
+		trcs = `[{"id": {"isd": 2, "base_number": 1, "serial_number": 1}}]`
+		defer func() { trcs = `[]` }()
+		_, err := bootstrapper.Fetch(context.Background(), server.Client(), server.URL)
+		assert.Error(t, err)
+	})
+	t.Run("no server", func(t *testing.T) {
+		b := &bootstrapper.Bootstrapper{Discoverers: []bootstrapper.Discoverer{
+			bootstrapper.Static{},
+		}}
+		_, err := b.Run(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestBundleWriteTRCs(t *testing.T) {
+	s1, s2, s3 := loadTRC(t, "ISD1-B1-S1.trc"), loadTRC(t, "ISD1-B1-S2.trc"),
+		loadTRC(t, "ISD1-B1-S3.trc")
+	modified := s1
+	modified.Raw = append(append([]byte(nil), s1.Raw...), 0)
+	forged := loadTRC(t, "ISD1-B1-S2.trc")
+	forged.SignerInfos[0].Signature[0] ^= 0xFF
+
+	testCases := map[string]struct {
+		installed []cppki.SignedTRC
+		fetched   []cppki.SignedTRC
+		written   []cppki.SignedTRC
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"trust on first use": {
+			fetched:   []cppki.SignedTRC{s2, s1},
+			written:   []cppki.SignedTRC{s1, s2},
+			assertErr: assert.NoError,
+		},
+		"verified update": {
+			installed: []cppki.SignedTRC{s1},
+			fetched:   []cppki.SignedTRC{s1, s2},
+			written:   []cppki.SignedTRC{s2},
+			assertErr: assert.NoError,
+		},
+		"older TRC ignored": {
+			installed: []cppki.SignedTRC{s2},
+			fetched:   []cppki.SignedTRC{s1},
+			assertErr: assert.NoError,
+		},
+		"installed TRC not replaced": {
+			installed: []cppki.SignedTRC{s1},
+			fetched:   []cppki.SignedTRC{modified},
+			assertErr: assert.Error,
+		},
+		"forged update": {
+			installed: []cppki.SignedTRC{s1},
+			fetched:   []cppki.SignedTRC{forged},
+			assertErr: assert.Error,
+		},
+		"missing update": {
+			installed: []cppki.SignedTRC{s1},
+			fetched:   []cppki.SignedTRC{s3},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			certs := filepath.Join(dir, "certs")
+			require.NoError(t, os.MkdirAll(certs, 0755))
+			for _, trc := range tc.installed {
+				file := filepath.Join(certs, trc.TRC.ID.String()+".trc")
+				require.NoError(t, os.WriteFile(file, trc.Raw, 0644))
+			}
+			b := &bootstrapper.Bundle{
+				IA:       xtest.MustParseIA("1-ff00:0:311"),
+				Topology: []byte("{}"),
+				TRCs:     tc.fetched,
+			}
+			_, err := b.Write(dir, bootstrapper.DaemonConfig{
+				ID:       "sd",
+				CacheDir: filepath.Join(dir, "cache"),
+			})
+			tc.assertErr(t, err)
+
+			files, err := filepath.Glob(filepath.Join(certs, "*.trc"))
+			require.NoError(t, err)
+			assert.Len(t, files, len(tc.installed)+len(tc.written))
+			for _, trc := range tc.written {
+				raw, err := os.ReadFile(filepath.Join(certs, trc.TRC.ID.String()+".trc"))
+				require.NoError(t, err)
+				assert.Equal(t, trc.Raw, raw)
+			}
+		})
+	}
+}
+
+func loadTRC(t *testing.T, file string) cppki.SignedTRC {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", file))
+	require.NoError(t, err)
+	trc, err := cppki.DecodeSignedTRC(raw)
+	require.NoError(t, err)
+	return trc
+}
+
+func TestFetchTopology(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("{", 10)))
+	}))
+	defer server.Close()
+	_, err := bootstrapper.Fetch(context.Background(), server.Client(),
+		strings.TrimPrefix(server.URL, "http://"))
+	assert.Error(t, err)
+}
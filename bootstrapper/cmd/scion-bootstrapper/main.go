@@ -0,0 +1,100 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/scionproto/scion/bootstrapper"
+	"github.com/scionproto/scion/bootstrapper/config"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/app/launcher"
+)
+
+var globalCfg config.Config
+
+func main() {
+	application := launcher.Application{
+		TOMLConfig: &globalCfg,
+		ShortName:  "SCION Bootstrapper",
+		Main:       realMain,
+	}
+	application.Run()
+}
+
+func realMain(ctx context.Context) error {
+	cfg := globalCfg.Bootstrapper
+	configDir, err := filepath.Abs(globalCfg.General.ConfigDir)
+	if err != nil {
+		return serrors.WrapStr("resolving configuration directory", err)
+	}
+	cacheDir, err := filepath.Abs(cfg.CacheDir)
+	if err != nil {
+		return serrors.WrapStr("resolving cache directory", err)
+	}
+	b := &bootstrapper.Bootstrapper{
+		Discoverers: discoverers(cfg),
+		Client:      &http.Client{Timeout: cfg.Timeout.Duration},
+		ConfigDir:   configDir,
+		Daemon: bootstrapper.DaemonConfig{
+			ID:       cfg.DaemonID,
+			Address:  cfg.DaemonAddress,
+			CacheDir: cacheDir,
+		},
+	}
+	sdFile, err := b.Run(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg.DaemonBinary == "" {
+		return nil
+	}
+	log.Info("Starting daemon", "binary", cfg.DaemonBinary, "config", sdFile)
+	cmd := exec.CommandContext(ctx, cfg.DaemonBinary, "--config", sdFile)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil && ctx.Err() == nil {
+		return serrors.WrapStr("running daemon", err)
+	}
+	return nil
+}
+
+func discoverers(cfg config.BootstrapperConfig) []bootstrapper.Discoverer {
+	var ds []bootstrapper.Discoverer
+	if len(cfg.Servers) > 0 {
+		ds = append(ds, bootstrapper.Static(cfg.Servers))
+	}
+	if !cfg.DisableDHCP {
+		ds = append(ds, bootstrapper.DHCPLeases{Files: cfg.DHCPLeaseFiles})
+	}
+	if !cfg.DisableDNSSD {
+		domains := cfg.DNSSDDomains
+		if len(domains) == 0 {
+			var err error
+			if domains, err = bootstrapper.SearchDomains(config.DefaultResolvConf); err != nil {
+				log.Info("Reading search domains failed", "err", err)
+			}
+		}
+		ds = append(ds, bootstrapper.DNSSD{Domains: domains})
+	}
+	if !cfg.DisableMDNS {
+		ds = append(ds, bootstrapper.MDNS{Timeout: cfg.MDNSTimeout.Duration})
+	}
+	return ds
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config contains the configuration of the SCION end host
+// bootstrapper.
+package config
+
+import (
+	"io"
+	"time"
+
+	"github.com/scionproto/scion/bootstrapper"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/private/config"
+	"github.com/scionproto/scion/private/env"
+)
+
+const (
+	// DefaultResolvConf is the default resolver configuration file from which
+	// the DNS-SD domains are read.
+	DefaultResolvConf = "/etc/resolv.conf"
+	// DefaultDHCPLeaseFiles is the default glob pattern matching the lease
+	// files of the DHCP client.
+	DefaultDHCPLeaseFiles = "/var/lib/dhcp/dhclient*.leases"
+	// DefaultTimeout is the default timeout of a request to a bootstrap
+	// server.
+	DefaultTimeout = 10 * time.Second
+	// DefaultCacheDir is the default directory of the databases of the daemon.
+	DefaultCacheDir = "/var/lib/scion"
+	// DefaultDaemonID is the default identifier of the daemon.
+	DefaultDaemonID = "sd"
+)
+
+var _ config.Config = (*Config)(nil)
+
+type Config struct {
+	General      env.General        `toml:"general,omitempty"`
+	Logging      log.Config         `toml:"log,omitempty"`
+	Bootstrapper BootstrapperConfig `toml:"bootstrapper,omitempty"`
+}
+
+func (cfg *Config) InitDefaults() {
+	config.InitAll(
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Bootstrapper,
+	)
+}
+
+func (cfg *Config) Validate() error {
+	return config.ValidateAll(
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Bootstrapper,
+	)
+}
+
+func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteSample(dst, path, config.CtxMap{config.ID: idSample},
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Bootstrapper,
+	)
+}
+
+var _ config.Config = (*BootstrapperConfig)(nil)
+
+// BootstrapperConfig configures how bootstrap servers are discovered and how
+// the daemon is configured.
+type BootstrapperConfig struct {
+	// Servers are bootstrap servers that are tried before any discovered
+	// ones, in the format host[:port].
+	Servers []string `toml:"servers,omitempty"`
+	// DisableDHCP disables the discovery with the WWW server option of the
+	// DHCP leases.
+	DisableDHCP bool `toml:"disable_dhcp,omitempty"`
+	// DHCPLeaseFiles are glob patterns matching the DHCP lease files.
+	DHCPLeaseFiles []string `toml:"dhcp_lease_files,omitempty"`
+	// DisableDNSSD disables the discovery with DNS-SD.
+	DisableDNSSD bool `toml:"disable_dns_sd,omitempty"`
+	// DNSSDDomains are the domains in which the bootstrap servers are looked
+	// up with DNS-SD. If empty, the search domains of the resolver
+	// configuration are used.
+	DNSSDDomains []string `toml:"dns_sd_domains,omitempty"`
+	// DisableMDNS disables the discovery with multicast DNS.
+	DisableMDNS bool `toml:"disable_mdns,omitempty"`
+	// MDNSTimeout is the time to wait for mDNS responses.
+	MDNSTimeout util.DurWrap `toml:"mdns_timeout,omitempty"`
+	// Timeout is the timeout of a request to a bootstrap server.
+	Timeout util.DurWrap `toml:"timeout,omitempty"`
+	// CacheDir is the directory of the path and trust databases of the
+	// daemon.
+	CacheDir string `toml:"cache_dir,omitempty"`
+	// DaemonID is the identifier of the daemon.
+	DaemonID string `toml:"daemon_id,omitempty"`
+	// DaemonAddress is the address the daemon API listens on.
+	DaemonAddress string `toml:"daemon_address,omitempty"`
+	// DaemonBinary is the daemon executable that is started with the written
+	// configuration. If empty, the daemon is not started.
+	DaemonBinary string `toml:"daemon_binary,omitempty"`
+}
+
+func (cfg *BootstrapperConfig) InitDefaults() {
+	if len(cfg.DHCPLeaseFiles) == 0 {
+		cfg.DHCPLeaseFiles = []string{DefaultDHCPLeaseFiles}
+	}
+	if cfg.MDNSTimeout.Duration == 0 {
+		cfg.MDNSTimeout.Duration = bootstrapper.DefaultMDNSTimeout
+	}
+	if cfg.Timeout.Duration == 0 {
+		cfg.Timeout.Duration = DefaultTimeout
+	}
+	if cfg.CacheDir == "" {
+		cfg.CacheDir = DefaultCacheDir
+	}
+	if cfg.DaemonID == "" {
+		cfg.DaemonID = DefaultDaemonID
+	}
+	if cfg.DaemonAddress == "" {
+		cfg.DaemonAddress = daemon.DefaultAPIAddress
+	}
+}
+
+func (cfg *BootstrapperConfig) Validate() error {
+	if len(cfg.Servers) == 0 && cfg.DisableDHCP && cfg.DisableDNSSD && cfg.DisableMDNS {
+		return serrors.New("no servers configured and all discovery mechanisms disabled")
+	}
+	if cfg.MDNSTimeout.Duration < 0 {
+		return serrors.New("MDNSTimeout must not be negative")
+	}
+	if cfg.Timeout.Duration < 0 {
+		return serrors.New("Timeout must not be negative")
+	}
+	return nil
+}
+
+func (cfg *BootstrapperConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, bootstrapperSample)
+}
+
+func (cfg *BootstrapperConfig) ConfigName() string {
+	return "bootstrapper"
+}
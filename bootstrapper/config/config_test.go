@@ -0,0 +1,90 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/bootstrapper"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log/logtest"
+	"github.com/scionproto/scion/private/config/configtest"
+	"github.com/scionproto/scion/private/env/envtest"
+)
+
+func TestConfigSample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg Config
+	cfg.Sample(&sample, nil, nil)
+
+	InitTestConfig(&cfg)
+	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
+	assert.NoError(t, err)
+	CheckTestConfig(t, &cfg, idSample)
+	configtest.CheckSampleCoverage(t, &cfg)
+}
+
+func InitTestConfig(cfg *Config) {
+	envtest.InitTest(&cfg.General, nil, nil, nil)
+	logtest.InitTestLogging(&cfg.Logging)
+	InitTestBootstrapperConfig(&cfg.Bootstrapper)
+}
+
+func InitTestBootstrapperConfig(cfg *BootstrapperConfig) {
+	cfg.Servers = []string{"garbage"}
+	cfg.DisableDHCP = true
+	cfg.DisableMDNS = true
+	cfg.DaemonBinary = "garbage"
+}
+
+func CheckTestConfig(t *testing.T, cfg *Config, id string) {
+	envtest.CheckTest(t, &cfg.General, nil, nil, nil, id)
+	logtest.CheckTestLogging(t, &cfg.Logging, id)
+	CheckTestBootstrapperConfig(t, &cfg.Bootstrapper)
+}
+
+func CheckTestBootstrapperConfig(t *testing.T, cfg *BootstrapperConfig) {
+	assert.Empty(t, cfg.Servers)
+	assert.False(t, cfg.DisableDHCP)
+	assert.Equal(t, []string{DefaultDHCPLeaseFiles}, cfg.DHCPLeaseFiles)
+	assert.False(t, cfg.DisableDNSSD)
+	assert.Empty(t, cfg.DNSSDDomains)
+	assert.False(t, cfg.DisableMDNS)
+	assert.Equal(t, bootstrapper.DefaultMDNSTimeout, cfg.MDNSTimeout.Duration)
+	assert.Equal(t, DefaultTimeout, cfg.Timeout.Duration)
+	assert.Equal(t, DefaultCacheDir, cfg.CacheDir)
+	assert.Equal(t, DefaultDaemonID, cfg.DaemonID)
+	assert.Equal(t, daemon.DefaultAPIAddress, cfg.DaemonAddress)
+	assert.Empty(t, cfg.DaemonBinary)
+}
+
+func TestBootstrapperConfigValidate(t *testing.T) {
+	var cfg BootstrapperConfig
+	cfg.InitDefaults()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.DisableDHCP, cfg.DisableDNSSD, cfg.DisableMDNS = true, true, true
+	assert.Error(t, cfg.Validate())
+
+	cfg.Servers = []string{"10.0.0.1"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Timeout.Duration = -1
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+const idSample = "bootstrapper"
+
+const bootstrapperSample = `
+# Bootstrap servers in the format host[:port] that are tried before any
+# discovered ones. The default port is 8041. (default [])
+servers = []
+
+# Disable the discovery of bootstrap servers with the WWW server option
+# (option 72) of the DHCP leases. (default false)
+disable_dhcp = false
+
+# Glob patterns matching the lease files of the DHCP client.
+# (default ["/var/lib/dhcp/dhclient*.leases"])
+dhcp_lease_files = ["/var/lib/dhcp/dhclient*.leases"]
+
+# Disable the discovery of bootstrap servers with DNS-SD. (default false)
+disable_dns_sd = false
+
+# The domains in which the SRV records of _sciondiscovery._tcp are looked up.
+# If empty, the search domains in /etc/resolv.conf are used. (default [])
+dns_sd_domains = []
+
+# Disable the discovery of bootstrap servers with multicast DNS.
+# (default false)
+disable_mdns = false
+
+# The time to wait for mDNS responses. (default 1s)
+mdns_timeout = "1s"
+
+# The timeout of a request to a bootstrap server. (default 10s)
+timeout = "10s"
+
+# The directory of the path and trust databases of the daemon.
+# (default "/var/lib/scion")
+cache_dir = "/var/lib/scion"
+
+# The identifier of the daemon. (default "sd")
+daemon_id = "sd"
+
+# The address the daemon API listens on. (default "127.0.0.1:30255")
+daemon_address = "127.0.0.1:30255"
+
+# The daemon executable that is started with the written configuration. If
+# empty, the bootstrapper exits after writing the configuration. (default "")
+daemon_binary = ""
+`
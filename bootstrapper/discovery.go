@@ -0,0 +1,281 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bootstrapper
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// ServiceName is the DNS-SD service name under which bootstrap servers are
+	// announced.
+	ServiceName = "_sciondiscovery._tcp"
+	// DefaultPort is the port of a bootstrap server if a hint does not contain
+	// one.
+	DefaultPort = 8041
+	// DefaultMDNSGroup is the multicast address mDNS queries are sent to.
+	DefaultMDNSGroup = "224.0.0.251:5353"
+	// DefaultMDNSTimeout is the default time to wait for mDNS responses.
+	DefaultMDNSTimeout = time.Second
+)
+
+// Discoverer discovers bootstrap servers. Discover returns the addresses of the
+// servers in the format host:port.
+type Discoverer interface {
+	Discover(ctx context.Context) ([]string, error)
+}
+
+// Static is a Discoverer that returns a fixed list of servers. The servers are
+// either URLs or in the format host[:port]. Servers without a port get the
+// DefaultPort.
+type Static []string
+
+func (s Static) Discover(_ context.Context) ([]string, error) {
+	servers := make([]string, 0, len(s))
+	for _, server := range s {
+		servers = append(servers, withDefaultPort(server))
+	}
+	return servers, nil
+}
+
+// DNSSD discovers bootstrap servers with DNS-SD, i.e., by looking up the SRV
+// records of the ServiceName in the configured domains.
+type DNSSD struct {
+	// Domains are the domains in which the servers are looked up.
+	Domains []string
+	// Resolver is the resolver used for the lookups. If nil, the default
+	// resolver is used.
+	Resolver *net.Resolver
+}
+
+func (d DNSSD) Discover(ctx context.Context) ([]string, error) {
+	resolver := d.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	var servers []string
+	var errs serrors.List
+	for _, domain := range d.Domains {
+		_, srvs, err := resolver.LookupSRV(ctx, "sciondiscovery", "tcp", domain)
+		if err != nil {
+			errs = append(errs, serrors.WrapStr("looking up SRV records", err, "domain", domain))
+			continue
+		}
+		for _, srv := range srvs {
+			host := strings.TrimSuffix(srv.Target, ".")
+			servers = append(servers, net.JoinHostPort(host, strconv.Itoa(int(srv.Port))))
+		}
+	}
+	if len(servers) == 0 && len(errs) > 0 {
+		return nil, errs.ToError()
+	}
+	return servers, nil
+}
+
+// SearchDomains returns the search domains configured in the resolver
+// configuration file, typically /etc/resolv.conf. They are usually set by
+// DHCP, which makes them suitable for DNS-SD.
+func SearchDomains(resolvConf string) ([]string, error) {
+	f, err := os.Open(resolvConf)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var domains []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || (fields[0] != "search" && fields[0] != "domain") {
+			continue
+		}
+		domains = append(domains, fields[1:]...)
+	}
+	return domains, scanner.Err()
+}
+
+// DHCPLeases discovers bootstrap servers from the WWW server option (option
+// 72) in the lease files of the ISC DHCP client. The option is expected to be
+// configured by the DHCP server of networks that offer SCION connectivity.
+type DHCPLeases struct {
+	// Files are glob patterns matching the lease files.
+	Files []string
+}
+
+func (d DHCPLeases) Discover(_ context.Context) ([]string, error) {
+	var servers []string
+	for _, pattern := range d.Files {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, serrors.WrapStr("matching lease files", err, "pattern", pattern)
+		}
+		for _, file := range files {
+			raw, err := os.ReadFile(file)
+			if err != nil {
+				return nil, serrors.WrapStr("reading lease file", err, "file", file)
+			}
+			servers = append(servers, parseWWWServers(string(raw))...)
+		}
+	}
+	return servers, nil
+}
+
+// parseWWWServers returns the servers in the WWW server option of the most
+// recent lease, i.e., the last one in the file.
+func parseWWWServers(leases string) []string {
+	var servers []string
+	for _, line := range strings.Split(leases, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "option www-server ") {
+			continue
+		}
+		value := strings.TrimSuffix(strings.TrimPrefix(line, "option www-server "), ";")
+		servers = servers[:0]
+		for _, ip := range strings.Split(value, ",") {
+			servers = append(servers, withDefaultPort(strings.TrimSpace(ip)))
+		}
+	}
+	return servers
+}
+
+// MDNS discovers bootstrap servers on the local link with multicast DNS.
+type MDNS struct {
+	// Group is the address the query is sent to. If empty, DefaultMDNSGroup is
+	// used.
+	Group string
+	// Timeout is the time to wait for responses. If zero, DefaultMDNSTimeout
+	// is used.
+	Timeout time.Duration
+}
+
+func (m MDNS) Discover(ctx context.Context) ([]string, error) {
+	group, timeout := m.Group, m.Timeout
+	if group == "" {
+		group = DefaultMDNSGroup
+	}
+	if timeout == 0 {
+		timeout = DefaultMDNSTimeout
+	}
+	dst, err := net.ResolveUDPAddr("udp", group)
+	if err != nil {
+		return nil, serrors.WrapStr("resolving mDNS group", err, "group", group)
+	}
+	conn, err := net.ListenUDP("udp", nil)
+	if err != nil {
+		return nil, serrors.WrapStr("listening for mDNS responses", err)
+	}
+	defer conn.Close()
+	query, err := mdnsQuery()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.WriteTo(query, dst); err != nil {
+		return nil, serrors.WrapStr("sending mDNS query", err)
+	}
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+	var servers []string
+	buf := make([]byte, 9000)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			// The deadline terminates the collection of responses.
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				return servers, nil
+			}
+			return servers, serrors.WrapStr("reading mDNS response", err)
+		}
+		servers = append(servers, parseMDNSResponse(buf[:n])...)
+	}
+}
+
+func mdnsQuery() ([]byte, error) {
+	name, err := dnsmessage.NewName(ServiceName + ".local.")
+	if err != nil {
+		return nil, err
+	}
+	msg := dnsmessage.Message{
+		Questions: []dnsmessage.Question{{
+			Name: name,
+			Type: dnsmessage.TypePTR,
+			// The top bit requests a unicast response (RFC 6762, section 5.4).
+			Class: dnsmessage.ClassINET | 1<<15,
+		}},
+	}
+	return msg.Pack()
+}
+
+// parseMDNSResponse returns the servers announced in the SRV records of the
+// response. The SRV targets are resolved with the address records in the
+// same response, if present.
+func parseMDNSResponse(raw []byte) []string {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(raw); err != nil || !msg.Header.Response {
+		return nil
+	}
+	type target struct {
+		host string
+		port uint16
+	}
+	var targets []target
+	addrs := make(map[string]string)
+	for _, rr := range append(msg.Answers, msg.Additionals...) {
+		switch body := rr.Body.(type) {
+		case *dnsmessage.SRVResource:
+			targets = append(targets, target{host: body.Target.String(), port: body.Port})
+		case *dnsmessage.AResource:
+			addrs[rr.Header.Name.String()] = net.IP(body.A[:]).String()
+		case *dnsmessage.AAAAResource:
+			if _, ok := addrs[rr.Header.Name.String()]; !ok {
+				addrs[rr.Header.Name.String()] = net.IP(body.AAAA[:]).String()
+			}
+		}
+	}
+	servers := make([]string, 0, len(targets))
+	for _, t := range targets {
+		host, ok := addrs[t.host]
+		if !ok {
+			host = strings.TrimSuffix(t.host, ".")
+		}
+		servers = append(servers, net.JoinHostPort(host, strconv.Itoa(int(t.port))))
+	}
+	return servers
+}
+
+func withDefaultPort(server string) string {
+	if strings.Contains(server, "://") {
+		return server
+	}
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(strings.Trim(server, "[]"), strconv.Itoa(DefaultPort))
+}
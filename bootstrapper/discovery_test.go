@@ -0,0 +1,163 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bootstrapper_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/scionproto/scion/bootstrapper"
+)
+
+func TestStatic(t *testing.T) {
+	servers, err := bootstrapper.Static{
+		"10.0.0.1", "10.0.0.2:80", "fd00::1", "[fd00::2]:80", "https://example.com",
+	}.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"10.0.0.1:8041", "10.0.0.2:80", "[fd00::1]:8041", "[fd00::2]:80", "https://example.com",
+	}, servers)
+}
+
+func TestDHCPLeases(t *testing.T) {
+	dir := t.TempDir()
+	leases := `lease {
+  interface "eth0";
+  fixed-address 10.0.0.10;
+  option www-server 10.0.0.1;
+}
+lease {
+  interface "eth0";
+  fixed-address 10.0.0.10;
+  option domain-name "example.com";
+  option www-server 10.0.0.2, 10.0.0.3;
+}
+`
+	file := filepath.Join(dir, "dhclient.eth0.leases")
+	require.NoError(t, os.WriteFile(file, []byte(leases), 0644))
+
+	servers, err := bootstrapper.DHCPLeases{
+		Files: []string{filepath.Join(dir, "dhclient*.leases"), filepath.Join(dir, "none")},
+	}.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2:8041", "10.0.0.3:8041"}, servers)
+}
+
+func TestSearchDomains(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "resolv.conf")
+	conf := "nameserver 10.0.0.53\ndomain example.com\nsearch a.example.com b.example.com\n"
+	require.NoError(t, os.WriteFile(file, []byte(conf), 0644))
+
+	domains, err := bootstrapper.SearchDomains(file)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "a.example.com", "b.example.com"}, domains)
+
+	_, err = bootstrapper.SearchDomains(filepath.Join(t.TempDir(), "none"))
+	assert.Error(t, err)
+}
+
+func TestMDNS(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	go serveDNS(t, conn, "bootstrap.local.")
+
+	servers, err := bootstrapper.MDNS{
+		Group:   conn.LocalAddr().String(),
+		Timeout: 200 * time.Millisecond,
+	}.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:8041"}, servers)
+}
+
+func TestDNSSD(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	go serveDNS(t, conn, "bootstrap.example.com.")
+
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", conn.LocalAddr().String())
+		},
+	}
+	servers, err := bootstrapper.DNSSD{
+		Domains:  []string{"example.com"},
+		Resolver: resolver,
+	}.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bootstrap.example.com:8041"}, servers)
+}
+
+// serveDNS answers every query on conn with an SRV record pointing to target
+// on the default port and an A record for target.
+func serveDNS(t *testing.T, conn net.PacketConn, target string) {
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		var query dnsmessage.Message
+		if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) == 0 {
+			continue
+		}
+		q := query.Questions[0]
+		targetName := dnsmessage.MustNewName(target)
+		resp := dnsmessage.Message{
+			Header: dnsmessage.Header{
+				ID:            query.ID,
+				Response:      true,
+				Authoritative: true,
+			},
+			Questions: query.Questions,
+			Answers: []dnsmessage.Resource{{
+				Header: dnsmessage.ResourceHeader{
+					Name:  q.Name,
+					Class: dnsmessage.ClassINET,
+					TTL:   60,
+				},
+				Body: &dnsmessage.SRVResource{
+					Port:   bootstrapper.DefaultPort,
+					Target: targetName,
+				},
+			}},
+			Additionals: []dnsmessage.Resource{{
+				Header: dnsmessage.ResourceHeader{
+					Name:  targetName,
+					Class: dnsmessage.ClassINET,
+					TTL:   60,
+				},
+				Body: &dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}},
+			}},
+		}
+		raw, err := resp.Pack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, _ = conn.WriteTo(raw, addr)
+	}
+}
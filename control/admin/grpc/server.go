@@ -0,0 +1,299 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpc implements the admin gRPC service of the control service. It
+// exposes the internal state of the control service to operators.
+package grpc
+
+import (
+	"context"
+	"crypto/x509"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/control/beaconing"
+	api "github.com/scionproto/scion/control/mgmtapi"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/log"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/pathdb/query"
+	beaconstorage "github.com/scionproto/scion/private/storage/beacon"
+	truststorage "github.com/scionproto/scion/private/storage/trust"
+	"github.com/scionproto/scion/private/trust"
+)
+
+// BeaconStore lists the beacons in the beacon database.
+type BeaconStore interface {
+	GetBeacons(context.Context, *beaconstorage.QueryParams) ([]beaconstorage.Beacon, error)
+}
+
+// SegmentStore lists the segments in the path database.
+type SegmentStore interface {
+	Get(context.Context, *query.Params) (query.Results, error)
+}
+
+// TrustStore lists the trust material in the trust database.
+type TrustStore interface {
+	SignedTRCs(context.Context, truststorage.TRCsQuery) (cppki.SignedTRCs, error)
+	Chains(context.Context, trust.ChainQuery) ([][]*x509.Certificate, error)
+}
+
+// HiddenPathStats provides the statistics of the hidden path servers.
+type HiddenPathStats interface {
+	Stats() []hiddenpath.GroupStats
+}
+
+// RegistrationStats provides the statistics of the segment registrations.
+type RegistrationStats interface {
+	Counts() []beaconing.RegistrationCount
+}
+
+// AdminServer serves the admin gRPC service.
+type AdminServer struct {
+	BeaconDB BeaconStore
+	PathDB   SegmentStore
+	TrustDB  TrustStore
+	// HiddenPaths provides the hidden path statistics. If it is nil, no
+	// statistics are returned.
+	HiddenPaths HiddenPathStats
+	// RegStats provides the segment registration statistics. If it is nil,
+	// no statistics are returned.
+	RegStats RegistrationStats
+}
+
+// Beacons returns the number of beacons per neighbor.
+func (s AdminServer) Beacons(ctx context.Context,
+	_ *cppb.BeaconsRequest) (*cppb.BeaconsResponse, error) {
+
+	beacons, err := s.BeaconDB.GetBeacons(ctx, &beaconstorage.QueryParams{})
+	if err != nil {
+		log.FromCtx(ctx).Debug("Failed to list beacons", "err", err)
+		return nil, status.Error(codes.Internal, "listing beacons")
+	}
+	type neighborKey struct {
+		ia   addr.IA
+		ifID uint16
+	}
+	neighbors := make(map[neighborKey]*cppb.NeighborBeacons)
+	origins := make(map[neighborKey]map[addr.IA]struct{})
+	lastUpdated := make(map[neighborKey]time.Time)
+	for _, b := range beacons {
+		entries := b.Beacon.Segment.ASEntries
+		if len(entries) == 0 {
+			continue
+		}
+		k := neighborKey{ia: entries[len(entries)-1].Local, ifID: b.Beacon.InIfId}
+		n, ok := neighbors[k]
+		if !ok {
+			n = &cppb.NeighborBeacons{IsdAs: uint64(k.ia), InterfaceId: uint64(k.ifID)}
+			neighbors[k] = n
+			origins[k] = make(map[addr.IA]struct{})
+		}
+		n.Beacons++
+		origins[k][b.Beacon.Segment.FirstIA()] = struct{}{}
+		if b.LastUpdated.After(lastUpdated[k]) {
+			lastUpdated[k] = b.LastUpdated
+		}
+	}
+	rep := &cppb.BeaconsResponse{Neighbors: make([]*cppb.NeighborBeacons, 0, len(neighbors))}
+	for k, n := range neighbors {
+		n.Origins = uint32(len(origins[k]))
+		n.LastUpdated = timestamppb.New(lastUpdated[k])
+		rep.Neighbors = append(rep.Neighbors, n)
+	}
+	sort.Slice(rep.Neighbors, func(i, j int) bool {
+		a, b := rep.Neighbors[i], rep.Neighbors[j]
+		if a.IsdAs != b.IsdAs {
+			return a.IsdAs < b.IsdAs
+		}
+		return a.InterfaceId < b.InterfaceId
+	})
+	return rep, nil
+}
+
+// ListBeacons returns the beacons in the beacon database.
+func (s AdminServer) ListBeacons(ctx context.Context,
+	_ *cppb.ListBeaconsRequest) (*cppb.ListBeaconsResponse, error) {
+
+	beacons, err := s.BeaconDB.GetBeacons(ctx, &beaconstorage.QueryParams{})
+	if err != nil {
+		log.FromCtx(ctx).Debug("Failed to list beacons", "err", err)
+		return nil, status.Error(codes.Internal, "listing beacons")
+	}
+	rep := &cppb.ListBeaconsResponse{
+		Beacons: make([]*cppb.BeaconSummary, 0, len(beacons)),
+	}
+	for _, b := range beacons {
+		ps := b.Beacon.Segment
+		rep.Beacons = append(rep.Beacons, &cppb.BeaconSummary{
+			Id:                 ps.ID(),
+			StartIsdAs:         uint64(ps.FirstIA()),
+			IngressInterfaceId: uint64(b.Beacon.InIfId),
+			Interfaces:         segmentInterfaces(ps),
+			Usages:             api.UnpackBeaconUsages(b.Usage),
+			Timestamp:          timestamppb.New(ps.Info.Timestamp),
+			Expiration:         timestamppb.New(ps.MinExpiry()),
+			LastUpdated:        timestamppb.New(b.LastUpdated),
+		})
+	}
+	return rep, nil
+}
+
+// Segments returns the segments in the path database.
+func (s AdminServer) Segments(ctx context.Context,
+	_ *cppb.AdminSegmentsRequest) (*cppb.AdminSegmentsResponse, error) {
+
+	results, err := s.PathDB.Get(ctx, &query.Params{})
+	if err != nil {
+		log.FromCtx(ctx).Debug("Failed to list segments", "err", err)
+		return nil, status.Error(codes.Internal, "listing segments")
+	}
+	rep := &cppb.AdminSegmentsResponse{
+		Segments: make([]*cppb.SegmentSummary, 0, len(results)),
+	}
+	for _, r := range results {
+		rep.Segments = append(rep.Segments, &cppb.SegmentSummary{
+			Id:               r.Seg.ID(),
+			Type:             cppb.SegmentType(r.Type),
+			StartIsdAs:       uint64(r.Seg.FirstIA()),
+			EndIsdAs:         uint64(r.Seg.LastIA()),
+			Hops:             uint32(len(r.Seg.ASEntries)),
+			Expiration:       timestamppb.New(r.Seg.MinExpiry()),
+			LastUpdated:      timestamppb.New(r.LastUpdate),
+			HiddenPathGroups: r.HPGroupIDs,
+			Interfaces:       segmentInterfaces(r.Seg),
+		})
+	}
+	return rep, nil
+}
+
+// segmentInterfaces returns the interfaces of the hop entries of the segment.
+func segmentInterfaces(ps *seg.PathSegment) []*cppb.SegmentInterface {
+	var intfs []*cppb.SegmentInterface
+	for _, entry := range ps.ASEntries {
+		hf := entry.HopEntry.HopField
+		for _, ifID := range []uint16{hf.ConsIngress, hf.ConsEgress} {
+			if ifID == 0 {
+				continue
+			}
+			intfs = append(intfs, &cppb.SegmentInterface{
+				IsdAs:       uint64(entry.Local),
+				InterfaceId: uint64(ifID),
+			})
+		}
+	}
+	return intfs
+}
+
+// TrustMaterial returns the TRCs and certificate chains in the trust
+// database.
+func (s AdminServer) TrustMaterial(ctx context.Context,
+	_ *cppb.TrustMaterialRequest) (*cppb.TrustMaterialResponse, error) {
+
+	logger := log.FromCtx(ctx)
+	trcs, err := s.TrustDB.SignedTRCs(ctx, truststorage.TRCsQuery{})
+	if err != nil {
+		logger.Debug("Failed to list TRCs", "err", err)
+		return nil, status.Error(codes.Internal, "listing TRCs")
+	}
+	chains, err := s.TrustDB.Chains(ctx, trust.ChainQuery{})
+	if err != nil {
+		logger.Debug("Failed to list certificate chains", "err", err)
+		return nil, status.Error(codes.Internal, "listing certificate chains")
+	}
+	rep := &cppb.TrustMaterialResponse{
+		Trcs:   make([]*cppb.TRCSummary, 0, len(trcs)),
+		Chains: make([]*cppb.ChainSummary, 0, len(chains)),
+	}
+	for _, trc := range trcs {
+		rep.Trcs = append(rep.Trcs, &cppb.TRCSummary{
+			Isd:       uint32(trc.TRC.ID.ISD),
+			Base:      uint64(trc.TRC.ID.Base),
+			Serial:    uint64(trc.TRC.ID.Serial),
+			NotBefore: timestamppb.New(trc.TRC.Validity.NotBefore),
+			NotAfter:  timestamppb.New(trc.TRC.Validity.NotAfter),
+		})
+	}
+	for _, chain := range chains {
+		subject, err := cppki.ExtractIA(chain[0].Subject)
+		if err != nil {
+			continue
+		}
+		issuer, err := cppki.ExtractIA(chain[1].Subject)
+		if err != nil {
+			continue
+		}
+		rep.Chains = append(rep.Chains, &cppb.ChainSummary{
+			IsdAs:        uint64(subject),
+			IssuerIsdAs:  uint64(issuer),
+			SubjectKeyId: chain[0].SubjectKeyId,
+			NotBefore:    timestamppb.New(chain[0].NotBefore),
+			NotAfter:     timestamppb.New(chain[0].NotAfter),
+		})
+	}
+	return rep, nil
+}
+
+// HiddenPathStats returns the statistics of the hidden path servers.
+func (s AdminServer) HiddenPathStats(ctx context.Context,
+	_ *cppb.HiddenPathStatsRequest) (*cppb.HiddenPathStatsResponse, error) {
+
+	rep := &cppb.HiddenPathStatsResponse{}
+	if s.HiddenPaths == nil {
+		return rep, nil
+	}
+	for _, g := range s.HiddenPaths.Stats() {
+		rep.Groups = append(rep.Groups, &cppb.HiddenPathGroupStats{
+			GroupId:     g.GroupID.ToUint64(),
+			Action:      string(g.Action),
+			Allowed:     g.Allowed,
+			Denied:      g.Denied,
+			Segments:    g.Segments,
+			LastRequest: timestamppb.New(g.LastRequest),
+		})
+	}
+	return rep, nil
+}
+
+// Registrations returns the statistics of the segment registrations.
+func (s AdminServer) Registrations(ctx context.Context,
+	_ *cppb.RegistrationsRequest) (*cppb.RegistrationsResponse, error) {
+
+	rep := &cppb.RegistrationsResponse{}
+	if s.RegStats == nil {
+		return rep, nil
+	}
+	for _, c := range s.RegStats.Counts() {
+		stats := &cppb.RegistrationStats{
+			Type:      cppb.SegmentType(c.Type),
+			Succeeded: c.Succeeded,
+			Failed:    c.Failed,
+		}
+		if !c.LastSuccess.IsZero() {
+			stats.LastSuccess = timestamppb.New(c.LastSuccess)
+		}
+		if !c.LastFailure.IsZero() {
+			stats.LastFailure = timestamppb.New(c.LastFailure)
+		}
+		rep.Registrations = append(rep.Registrations, stats)
+	}
+	return rep, nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admingrpc "github.com/scionproto/scion/control/admin/grpc"
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/pathdb/query"
+	beaconstorage "github.com/scionproto/scion/private/storage/beacon"
+)
+
+func TestAdminServerBeacons(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia120 := xtest.MustParseIA("1-ff00:0:120")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	now := time.Now().Truncate(time.Second)
+	beacons := beaconStore{
+		{Beacon: testBeacon(1, ia110, ia111), LastUpdated: now.Add(-time.Minute)},
+		{Beacon: testBeacon(1, ia120, ia111), LastUpdated: now},
+		{Beacon: testBeacon(1, ia120, ia110, ia111), LastUpdated: now.Add(-time.Hour)},
+		{Beacon: testBeacon(2, ia110), LastUpdated: now},
+	}
+	s := admingrpc.AdminServer{BeaconDB: beacons}
+	rep, err := s.Beacons(context.Background(), &cppb.BeaconsRequest{})
+	require.NoError(t, err)
+	require.Len(t, rep.Neighbors, 2)
+	assert.Equal(t, uint64(ia110), rep.Neighbors[0].IsdAs)
+	assert.Equal(t, uint64(2), rep.Neighbors[0].InterfaceId)
+	assert.Equal(t, uint32(1), rep.Neighbors[0].Beacons)
+	assert.Equal(t, uint64(ia111), rep.Neighbors[1].IsdAs)
+	assert.Equal(t, uint64(1), rep.Neighbors[1].InterfaceId)
+	assert.Equal(t, uint32(3), rep.Neighbors[1].Beacons)
+	assert.Equal(t, uint32(2), rep.Neighbors[1].Origins)
+	assert.True(t, now.Equal(rep.Neighbors[1].LastUpdated.AsTime()))
+}
+
+func TestAdminServerListBeacons(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	now := time.Now().Truncate(time.Second)
+	b := testBeacon(3, ia110, ia111)
+	b.Segment.ASEntries[0].HopEntry.HopField.ConsEgress = 2
+	b.Segment.ASEntries[1].HopEntry.HopField.ConsIngress = 3
+	s := admingrpc.AdminServer{
+		BeaconDB: beaconStore{{
+			Beacon:      b,
+			Usage:       beacon.UsageUpReg | beacon.UsageProp,
+			LastUpdated: now,
+		}},
+	}
+	rep, err := s.ListBeacons(context.Background(), &cppb.ListBeaconsRequest{})
+	require.NoError(t, err)
+	require.Len(t, rep.Beacons, 1)
+	summary := rep.Beacons[0]
+	assert.Equal(t, b.Segment.ID(), summary.Id)
+	assert.Equal(t, uint64(ia110), summary.StartIsdAs)
+	assert.Equal(t, uint64(3), summary.IngressInterfaceId)
+	assert.Equal(t, []string{"up_registration", "propagation"}, summary.Usages)
+	require.Len(t, summary.Interfaces, 2)
+	assert.Equal(t, uint64(ia110), summary.Interfaces[0].IsdAs)
+	assert.Equal(t, uint64(2), summary.Interfaces[0].InterfaceId)
+	assert.Equal(t, uint64(ia111), summary.Interfaces[1].IsdAs)
+	assert.Equal(t, uint64(3), summary.Interfaces[1].InterfaceId)
+	assert.True(t, now.Equal(summary.LastUpdated.AsTime()))
+}
+
+func TestAdminServerSegments(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	now := time.Now().Truncate(time.Second)
+	s := admingrpc.AdminServer{
+		PathDB: segmentStore{{
+			Seg:        testBeacon(0, ia110, ia111).Segment,
+			LastUpdate: now,
+			Type:       seg.TypeDown,
+			HPGroupIDs: []uint64{42},
+		}},
+	}
+	rep, err := s.Segments(context.Background(), &cppb.AdminSegmentsRequest{})
+	require.NoError(t, err)
+	require.Len(t, rep.Segments, 1)
+	summary := rep.Segments[0]
+	assert.Equal(t, cppb.SegmentType_SEGMENT_TYPE_DOWN, summary.Type)
+	assert.Equal(t, uint64(ia110), summary.StartIsdAs)
+	assert.Equal(t, uint64(ia111), summary.EndIsdAs)
+	assert.Equal(t, uint32(2), summary.Hops)
+	assert.Equal(t, []uint64{42}, summary.HiddenPathGroups)
+	assert.NotEmpty(t, summary.Id)
+}
+
+func TestAdminServerHiddenPathStats(t *testing.T) {
+	s := admingrpc.AdminServer{}
+	rep, err := s.HiddenPathStats(context.Background(), &cppb.HiddenPathStatsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, rep.Groups)
+
+	stats := &hiddenpath.StatsAuditor{}
+	id := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110"), Suffix: 1}
+	stats.SetGroups(hiddenpath.Groups{id: {ID: id}})
+	stats.Audit(context.Background(), hiddenpath.AuditRecord{
+		Time:          time.Now(),
+		Action:        hiddenpath.AuditRegistration,
+		GroupIDs:      []hiddenpath.GroupID{id},
+		MatchedGroups: []hiddenpath.GroupID{id},
+		Segments:      2,
+		Allowed:       true,
+	})
+	s.HiddenPaths = stats
+	rep, err = s.HiddenPathStats(context.Background(), &cppb.HiddenPathStatsRequest{})
+	require.NoError(t, err)
+	require.Len(t, rep.Groups, 1)
+	assert.Equal(t, id.ToUint64(), rep.Groups[0].GroupId)
+	assert.Equal(t, "registration", rep.Groups[0].Action)
+	assert.Equal(t, uint64(1), rep.Groups[0].Allowed)
+	assert.Equal(t, uint64(2), rep.Groups[0].Segments)
+}
+
+func TestAdminServerRegistrations(t *testing.T) {
+	s := admingrpc.AdminServer{}
+	rep, err := s.Registrations(context.Background(), &cppb.RegistrationsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, rep.Registrations)
+
+	stats := &beaconing.RegistrationStats{}
+	stats.Record(seg.TypeUp, 3, true)
+	stats.Record(seg.TypeDown, 1, false)
+	s.RegStats = stats
+	rep, err = s.Registrations(context.Background(), &cppb.RegistrationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, rep.Registrations, 2)
+	up, down := rep.Registrations[0], rep.Registrations[1]
+	assert.Equal(t, cppb.SegmentType_SEGMENT_TYPE_UP, up.Type)
+	assert.Equal(t, uint64(3), up.Succeeded)
+	assert.NotNil(t, up.LastSuccess)
+	assert.Nil(t, up.LastFailure)
+	assert.Equal(t, cppb.SegmentType_SEGMENT_TYPE_DOWN, down.Type)
+	assert.Equal(t, uint64(1), down.Failed)
+	assert.Nil(t, down.LastSuccess)
+}
+
+// testBeacon creates a beacon that traverses the given ASes and is received
+// on the given interface.
+func testBeacon(ifID uint16, ias ...addr.IA) beacon.Beacon {
+	entries := make([]seg.ASEntry, 0, len(ias))
+	for _, ia := range ias {
+		entries = append(entries, seg.ASEntry{Local: ia})
+	}
+	return beacon.Beacon{
+		Segment: &seg.PathSegment{
+			Info:      seg.Info{Timestamp: time.Now()},
+			ASEntries: entries,
+		},
+		InIfId: ifID,
+	}
+}
+
+type beaconStore []beaconstorage.Beacon
+
+func (s beaconStore) GetBeacons(context.Context,
+	*beaconstorage.QueryParams) ([]beaconstorage.Beacon, error) {
+
+	return s, nil
+}
+
+type segmentStore query.Results
+
+func (s segmentStore) Get(context.Context, *query.Params) (query.Results, error) {
+	return query.Results(s), nil
+}
@@ -187,6 +187,10 @@ type Policy struct {
 	Filter Filter `yaml:"Filter"`
 	// Type is the policy type.
 	Type PolicyType `yaml:"Type"`
+	// Selection is the name of the selection policy that selects the best
+	// beacons from the candidate beacons. If empty, DefaultSelectionPolicy is
+	// used.
+	Selection string `yaml:"Selection"`
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -201,6 +205,9 @@ func (p *Policy) InitDefaults() {
 		m := DefaultMaxExpTime
 		p.MaxExpTime = &m
 	}
+	if p.Selection == "" {
+		p.Selection = DefaultSelectionPolicy
+	}
 	p.Filter.InitDefaults()
 }
 
@@ -14,15 +14,82 @@
 
 package beacon
 
-import "math"
+import (
+	"math"
+	"sort"
+	"sync"
 
-type selectionAlgorithm interface {
-	// SelectBeacons selects the `n` best beacons from the provided slice of beacons.
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// DefaultSelectionPolicy is the name of the selection policy that is used if a
+// beaconing policy does not specify one.
+const DefaultSelectionPolicy = "diversity"
+
+// SelectionPolicy selects the beacons that are propagated or registered from
+// the candidate beacons of a single origin AS.
+type SelectionPolicy interface {
+	// SelectBeacons selects the `n` best beacons from the provided slice of
+	// beacons. The candidate beacons are sorted by their length, shortest
+	// first.
 	SelectBeacons(beacons []Beacon, resultSize int) []Beacon
 }
 
+var selectionPolicies = struct {
+	sync.RWMutex
+	m map[string]SelectionPolicy
+}{
+	m: map[string]SelectionPolicy{
+		DefaultSelectionPolicy: baseAlgo{},
+	},
+}
+
+// RegisterSelectionPolicy registers a selection policy under the given name.
+// Beaconing policies refer to the selection policy by this name. Registering
+// a name twice returns an error.
+func RegisterSelectionPolicy(name string, policy SelectionPolicy) error {
+	if name == "" || policy == nil {
+		return serrors.New("name and selection policy must be set")
+	}
+	selectionPolicies.Lock()
+	defer selectionPolicies.Unlock()
+	if _, ok := selectionPolicies.m[name]; ok {
+		return serrors.New("selection policy already registered", "name", name)
+	}
+	selectionPolicies.m[name] = policy
+	return nil
+}
+
+// LookupSelectionPolicy returns the selection policy registered under the
+// given name. The empty name refers to the default selection policy.
+func LookupSelectionPolicy(name string) (SelectionPolicy, error) {
+	if name == "" {
+		name = DefaultSelectionPolicy
+	}
+	selectionPolicies.RLock()
+	defer selectionPolicies.RUnlock()
+	policy, ok := selectionPolicies.m[name]
+	if !ok {
+		return nil, serrors.New("unknown selection policy", "name", name,
+			"registered", registeredSelectionPolicies())
+	}
+	return policy, nil
+}
+
+// registeredSelectionPolicies returns the sorted names of all registered
+// selection policies. The caller must hold the lock.
+func registeredSelectionPolicies() []string {
+	names := make([]string, 0, len(selectionPolicies.m))
+	for name := range selectionPolicies.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // baseAlgo implements a very simple selection algorithm that optimizes for
-// short paths, but also tries to achieve some path diversity.
+// short paths, but also tries to achieve some path diversity. It is registered
+// as the DefaultSelectionPolicy.
 type baseAlgo struct{}
 
 // SelectBeacons implements a very simple selection algorithm. The best beacon
@@ -42,10 +42,14 @@ func NewBeaconStore(policies Policies, db DB) (*Store, error) {
 	if err := policies.Validate(); err != nil {
 		return nil, err
 	}
+	selections, err := lookupSelections(&policies.Prop, &policies.UpReg, &policies.DownReg)
+	if err != nil {
+		return nil, err
+	}
 	s := &Store{
 		baseStore: baseStore{
-			db:   db,
-			algo: baseAlgo{},
+			db:         db,
+			selections: selections,
 		},
 		policies: policies,
 	}
@@ -81,7 +85,7 @@ func (s *Store) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, error
 	if err != nil {
 		return nil, err
 	}
-	return s.algo.SelectBeacons(beacons, policy.BestSetSize), nil
+	return s.selections[policy.Type].SelectBeacons(beacons, policy.BestSetSize), nil
 }
 
 // MaxExpTime returns the segment maximum expiration time for the given policy.
@@ -112,10 +116,14 @@ func NewCoreBeaconStore(policies CorePolicies, db DB) (*CoreStore, error) {
 	if err := policies.Validate(); err != nil {
 		return nil, err
 	}
+	selections, err := lookupSelections(&policies.Prop, &policies.CoreReg)
+	if err != nil {
+		return nil, err
+	}
 	s := &CoreStore{
 		baseStore: baseStore{
-			db:   db,
-			algo: baseAlgo{},
+			db:         db,
+			selections: selections,
 		},
 		policies: policies,
 	}
@@ -146,6 +154,7 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, e
 	if err != nil {
 		return nil, err
 	}
+	selection := s.selections[policy.Type]
 	var beacons []Beacon
 	for _, src := range srcs {
 		candidateBeacons, err := s.db.CandidateBeacons(ctx, policy.CandidateSetSize,
@@ -155,7 +164,7 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, e
 			log.FromCtx(ctx).Error("Error getting candidate beacons", "src", src, "err", err)
 			continue
 		}
-		selBeacons := s.algo.SelectBeacons(candidateBeacons, policy.BestSetSize)
+		selBeacons := selection.SelectBeacons(candidateBeacons, policy.BestSetSize)
 		beacons = append(beacons, selBeacons...)
 	}
 	return beacons, nil
@@ -174,9 +183,23 @@ func (s *CoreStore) MaxExpTime(policyType PolicyType) uint8 {
 
 // baseStore is the basis for the beacon store.
 type baseStore struct {
-	db     DB
-	usager usager
-	algo   selectionAlgorithm
+	db         DB
+	usager     usager
+	selections map[PolicyType]SelectionPolicy
+}
+
+// lookupSelections resolves the selection policies referenced by the given
+// policies.
+func lookupSelections(policies ...*Policy) (map[PolicyType]SelectionPolicy, error) {
+	selections := make(map[PolicyType]SelectionPolicy, len(policies))
+	for _, p := range policies {
+		selection, err := LookupSelectionPolicy(p.Selection)
+		if err != nil {
+			return nil, serrors.WrapStr("resolving selection policy", err, "policy", p.Type)
+		}
+		selections[p.Type] = selection
+	}
+	return selections, nil
 }
 
 // PreFilter indicates whether the beacon will be filtered on insert by
@@ -17,9 +17,11 @@ package beacon_test
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/control/beacon"
@@ -266,6 +268,59 @@ func testCoreStoreSelection(t *testing.T,
 	}
 }
 
+// longestSelection selects the longest candidate beacons.
+type longestSelection struct{}
+
+func (longestSelection) SelectBeacons(beacons []beacon.Beacon, n int) []beacon.Beacon {
+	if len(beacons) <= n {
+		return beacons
+	}
+	return beacons[len(beacons)-n:]
+}
+
+var registerLongest sync.Once
+
+func TestStoreSelectionPolicy(t *testing.T) {
+	registerLongest.Do(func() {
+		require.NoError(t, beacon.RegisterSelectionPolicy("longest", longestSelection{}))
+	})
+	assert.Error(t, beacon.RegisterSelectionPolicy("longest", longestSelection{}))
+	assert.Error(t, beacon.RegisterSelectionPolicy(beacon.DefaultSelectionPolicy,
+		longestSelection{}))
+
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+	stub := graph.If_111_A_112_X
+	beacons := []beacon.Beacon{
+		testBeacon(g, graph.If_120_X_111_B, stub),
+		testBeacon(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub),
+		testBeacon(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub),
+	}
+
+	db := mock_beacon.NewMockDB(mctrl)
+	policies := beacon.Policies{
+		Prop:    beacon.Policy{BestSetSize: 2, Selection: "longest"},
+		UpReg:   beacon.Policy{BestSetSize: 2},
+		DownReg: beacon.Policy{BestSetSize: 2},
+	}
+	store, err := beacon.NewBeaconStore(policies, db)
+	require.NoError(t, err)
+	db.EXPECT().CandidateBeacons(gomock.Any(), gomock.Any(), gomock.Any(), addr.IA(0)).
+		Return(beacons, nil).Times(2)
+
+	res, err := store.BeaconsToPropagate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, beacons[1:], res)
+	res, err = store.SegmentsToRegister(context.Background(), seg.TypeUp)
+	require.NoError(t, err)
+	assert.Equal(t, beacons[:2], res)
+
+	policies.Prop.Selection = "unknown"
+	_, err = beacon.NewBeaconStore(policies, db)
+	assert.Error(t, err)
+}
+
 func testBeacon(g *graph.Graph, desc ...uint16) beacon.Beacon {
 	pseg := testSegment(g, desc)
 	asEntry := pseg.ASEntries[pseg.MaxIdx()]
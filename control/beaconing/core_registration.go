@@ -0,0 +1,155 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+)
+
+const (
+	// DefaultCoreRegistrationInitialBackoff is the default time a remote core
+	// AS is skipped after a failed registration.
+	DefaultCoreRegistrationInitialBackoff = 5 * time.Second
+	// DefaultCoreRegistrationMaxBackoff is the default upper bound of the time
+	// a remote core AS is skipped after consecutive failed registrations.
+	DefaultCoreRegistrationMaxBackoff = 5 * time.Minute
+)
+
+// backoffSkipped is the result label of registrations that are skipped
+// because the remote is backing off.
+const backoffSkipped = "skipped_backoff"
+
+var _ Writer = (*RemoteCoreWriter)(nil)
+
+// RemoteCoreWriter registers core segments at the core ASes that originated
+// them. Only the segments of the configured remote core ASes are registered.
+// After a failed registration, the segments of a remote are skipped for a
+// backoff period that doubles with every consecutive failure.
+type RemoteCoreWriter struct {
+	// Writer registers the segments at the origin of the segments. Usually, it
+	// is a RemoteWriter for core segments.
+	Writer Writer
+	// Remotes are the core ASes the segments are registered at.
+	Remotes []addr.IA
+	// InitialBackoff is the time a remote is skipped after a failed
+	// registration. If zero, DefaultCoreRegistrationInitialBackoff is used.
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper bound of the backoff. If zero,
+	// DefaultCoreRegistrationMaxBackoff is used.
+	MaxBackoff time.Duration
+	// Registrations counts the registration attempts per remote. It has the
+	// labels isd_as and result. If it is nil, nothing is counted.
+	Registrations metrics.Counter
+
+	mtx     sync.Mutex
+	backoff map[addr.IA]coreBackoff
+}
+
+type coreBackoff struct {
+	failures int
+	until    time.Time
+}
+
+// Write registers the segments of the configured remotes that are not backing
+// off.
+func (w *RemoteCoreWriter) Write(
+	ctx context.Context,
+	segments []beacon.Beacon,
+	peers []uint16,
+) (WriteStats, error) {
+
+	now := time.Now()
+	remotes := make(map[addr.IA]bool, len(w.Remotes))
+	for _, ia := range w.Remotes {
+		remotes[ia] = true
+	}
+	attempted := make(map[addr.IA]struct{})
+	skipped := make(map[addr.IA]struct{})
+	var toWrite []beacon.Beacon
+	w.mtx.Lock()
+	for _, b := range segments {
+		origin := b.Segment.FirstIA()
+		if !remotes[origin] {
+			continue
+		}
+		if now.Before(w.backoff[origin].until) {
+			skipped[origin] = struct{}{}
+			continue
+		}
+		attempted[origin] = struct{}{}
+		toWrite = append(toWrite, b)
+	}
+	w.mtx.Unlock()
+	for origin := range skipped {
+		w.count(origin, backoffSkipped)
+	}
+	if len(toWrite) == 0 {
+		return WriteStats{}, nil
+	}
+
+	stats, err := w.Writer.Write(ctx, toWrite, peers)
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if w.backoff == nil {
+		w.backoff = make(map[addr.IA]coreBackoff)
+	}
+	for origin := range attempted {
+		if _, ok := stats.StartIAs[origin]; ok {
+			delete(w.backoff, origin)
+			w.count(origin, prom.Success)
+			continue
+		}
+		b := w.backoff[origin]
+		b.failures++
+		backoff := w.backoffDuration(b.failures)
+		b.until = now.Add(backoff)
+		w.backoff[origin] = b
+		w.count(origin, prom.ErrNetwork)
+		log.FromCtx(ctx).Debug("Backing off core segment registration",
+			"remote", origin, "failures", b.failures, "backoff", backoff)
+	}
+	return stats, err
+}
+
+func (w *RemoteCoreWriter) backoffDuration(failures int) time.Duration {
+	backoff, maxBackoff := w.InitialBackoff, w.MaxBackoff
+	if backoff == 0 {
+		backoff = DefaultCoreRegistrationInitialBackoff
+	}
+	if maxBackoff == 0 {
+		maxBackoff = DefaultCoreRegistrationMaxBackoff
+	}
+	for i := 1; i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+func (w *RemoteCoreWriter) count(remote addr.IA, result string) {
+	metrics.CounterInc(metrics.CounterWith(w.Registrations,
+		"isd_as", remote.String(), prom.LabelResult, result))
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+func TestRemoteCoreWriter(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia120 := xtest.MustParseIA("1-ff00:0:120")
+	ia130 := xtest.MustParseIA("1-ff00:0:130")
+	segments := []beacon.Beacon{
+		diversityBeacon(hop{ia110, 0}, hop{ia130, 1}),
+		diversityBeacon(hop{ia120, 0}, hop{ia130, 2}),
+		diversityBeacon(hop{ia130, 0}, hop{ia110, 3}),
+	}
+
+	var written []addr.IA
+	registrations := metrics.NewTestCounter()
+	w := &beaconing.RemoteCoreWriter{
+		// The registrations at 1-ff00:0:120 fail.
+		Writer: writerFunc(func(segs []beacon.Beacon) beaconing.WriteStats {
+			stats := beaconing.WriteStats{StartIAs: map[addr.IA]struct{}{}}
+			for _, b := range segs {
+				written = append(written, b.Segment.FirstIA())
+				if b.Segment.FirstIA() != ia120 {
+					stats.Count++
+					stats.StartIAs[b.Segment.FirstIA()] = struct{}{}
+				}
+			}
+			return stats
+		}),
+		Remotes:       []addr.IA{ia110, ia120},
+		Registrations: registrations,
+	}
+	value := func(ia addr.IA, result string) float64 {
+		return metrics.CounterValue(registrations.With(
+			"isd_as", ia.String(), prom.LabelResult, result))
+	}
+
+	stats, err := w.Write(context.Background(), segments, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Count)
+	assert.Equal(t, []addr.IA{ia110, ia120}, written)
+	assert.Equal(t, 1.0, value(ia110, prom.Success))
+	assert.Equal(t, 1.0, value(ia120, prom.ErrNetwork))
+
+	// The failed remote is backing off.
+	written = nil
+	_, err = w.Write(context.Background(), segments, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []addr.IA{ia110}, written)
+	assert.Equal(t, 2.0, value(ia110, prom.Success))
+	assert.Equal(t, 1.0, value(ia120, "skipped_backoff"))
+}
+
+type writerFunc func([]beacon.Beacon) beaconing.WriteStats
+
+func (f writerFunc) Write(_ context.Context, segs []beacon.Beacon,
+	_ []uint16) (beaconing.WriteStats, error) {
+
+	return f(segs), nil
+}
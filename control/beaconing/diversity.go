@@ -0,0 +1,114 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+// selectDiverse selects up to n beacons from the candidates. The beacons are
+// selected round-robin over the origin ASes, such that every origin AS is
+// covered before a second beacon of an origin AS is selected. For every origin
+// AS, the candidate with the highest link diversity compared to the beacons of
+// that origin AS in sent and in the selection so far is chosen. Ties are
+// broken in favor of the shorter beacon. The beacons in sent are the beacons
+// that are already propagated to the same neighbor over a different
+// interface, taking them into account spreads the paths over the parallel
+// links to the neighbor.
+func selectDiverse(candidates []beacon.Beacon, n int, sent []beacon.Beacon) []beacon.Beacon {
+	if n <= 0 || len(candidates) <= n {
+		return candidates
+	}
+	var origins []addr.IA
+	remaining := make(map[addr.IA][]beacon.Beacon)
+	for _, b := range candidates {
+		origin := b.Segment.FirstIA()
+		if _, ok := remaining[origin]; !ok {
+			origins = append(origins, origin)
+		}
+		remaining[origin] = append(remaining[origin], b)
+	}
+	sort.Slice(origins, func(i, j int) bool { return origins[i] < origins[j] })
+	selected := make(map[addr.IA][]beacon.Beacon)
+	for _, b := range sent {
+		origin := b.Segment.FirstIA()
+		selected[origin] = append(selected[origin], b)
+	}
+
+	result := make([]beacon.Beacon, 0, n)
+	for len(result) < n && len(result) < len(candidates) {
+		for _, origin := range origins {
+			if len(result) == n {
+				break
+			}
+			cands := remaining[origin]
+			if len(cands) == 0 {
+				continue
+			}
+			best := mostDiverse(cands, selected[origin])
+			result = append(result, cands[best])
+			selected[origin] = append(selected[origin], cands[best])
+			remaining[origin] = append(cands[:best:best], cands[best+1:]...)
+		}
+	}
+	return result
+}
+
+// mostDiverse returns the index of the candidate with the highest link
+// diversity compared to the selected beacons. The diversity of a candidate is
+// the minimum diversity compared to any of the selected beacons. If nothing is
+// selected yet, the shortest candidate is returned.
+func mostDiverse(candidates, selected []beacon.Beacon) int {
+	best, bestDiversity, bestLen := -1, -1, 0
+	for i, c := range candidates {
+		diversity := 0
+		for j, s := range selected {
+			if d := c.Diversity(s); j == 0 || d < diversity {
+				diversity = d
+			}
+		}
+		l := len(c.Segment.ASEntries)
+		if diversity > bestDiversity || (diversity == bestDiversity && l < bestLen) {
+			best, bestDiversity, bestLen = i, diversity, l
+		}
+	}
+	return best
+}
+
+// diversity returns the number of distinct AS-level paths and the number of
+// distinct inter-AS links of the beacons.
+func diversity(beacons []beacon.Beacon) (asPaths, links int) {
+	type link struct {
+		ia      addr.IA
+		ingress uint16
+	}
+	paths := make(map[string]struct{})
+	linkSet := make(map[link]struct{})
+	for _, b := range beacons {
+		var sb strings.Builder
+		for _, entry := range b.Segment.ASEntries {
+			sb.WriteString(entry.Local.String())
+			sb.WriteByte(' ')
+			linkSet[link{ia: entry.Local, ingress: entry.HopEntry.HopField.ConsIngress}] =
+				struct{}{}
+		}
+		paths[sb.String()] = struct{}{}
+	}
+	return len(paths), len(linkSet)
+}
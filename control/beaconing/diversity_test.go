@@ -0,0 +1,89 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	seg "github.com/scionproto/scion/pkg/segment"
+)
+
+func TestSelectDiverse(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia120 := xtest.MustParseIA("1-ff00:0:120")
+	ia130 := xtest.MustParseIA("1-ff00:0:130")
+	ia140 := xtest.MustParseIA("1-ff00:0:140")
+
+	// Candidates are ordered by length, as provided by the beacon store.
+	short := diversityBeacon(hop{ia110, 0}, hop{ia130, 1})
+	sameLinks := diversityBeacon(hop{ia110, 0}, hop{ia130, 1}, hop{ia140, 2})
+	diverse := diversityBeacon(hop{ia110, 0}, hop{ia120, 3}, hop{ia140, 4})
+	other := diversityBeacon(hop{ia120, 0}, hop{ia140, 5}, hop{ia130, 6})
+	candidates := []beacon.Beacon{short, sameLinks, diverse, other}
+
+	t.Run("all candidates", func(t *testing.T) {
+		assert.Equal(t, candidates, beaconing.SelectDiverse(candidates, 0, nil))
+		assert.Equal(t, candidates, beaconing.SelectDiverse(candidates, 4, nil))
+	})
+	t.Run("origins first", func(t *testing.T) {
+		selected := beaconing.SelectDiverse(candidates, 2, nil)
+		assert.Equal(t, []beacon.Beacon{short, other}, selected)
+	})
+	t.Run("most diverse", func(t *testing.T) {
+		selected := beaconing.SelectDiverse(candidates, 3, nil)
+		assert.Equal(t, []beacon.Beacon{short, other, diverse}, selected)
+	})
+	t.Run("parallel interface", func(t *testing.T) {
+		selected := beaconing.SelectDiverse(candidates, 2, []beacon.Beacon{short, other})
+		assert.Equal(t, []beacon.Beacon{diverse, other}, selected)
+	})
+}
+
+func TestDiversity(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia120 := xtest.MustParseIA("1-ff00:0:120")
+	ia130 := xtest.MustParseIA("1-ff00:0:130")
+	asPaths, links := beaconing.Diversity([]beacon.Beacon{
+		diversityBeacon(hop{ia110, 0}, hop{ia130, 1}),
+		diversityBeacon(hop{ia110, 0}, hop{ia130, 2}),
+		diversityBeacon(hop{ia120, 0}, hop{ia130, 3}),
+	})
+	assert.Equal(t, 2, asPaths)
+	assert.Equal(t, 5, links)
+}
+
+type hop struct {
+	ia      addr.IA
+	ingress uint16
+}
+
+func diversityBeacon(hops ...hop) beacon.Beacon {
+	entries := make([]seg.ASEntry, 0, len(hops))
+	for _, h := range hops {
+		entries = append(entries, seg.ASEntry{
+			Local: h.ia,
+			HopEntry: seg.HopEntry{
+				HopField: seg.HopField{ConsIngress: h.ingress},
+			},
+		})
+	}
+	return beacon.Beacon{Segment: &seg.PathSegment{ASEntries: entries}}
+}
@@ -24,3 +24,8 @@ func (cfg StaticInfoCfg) TestGenerate(ifType map[common.IFIDType]topology.LinkTy
 	ingress, egress common.IFIDType) *staticinfo.Extension {
 	return cfg.generate(ifType, ingress, egress)
 }
+
+var (
+	SelectDiverse = selectDiverse
+	Diversity     = diversity
+)
@@ -54,8 +54,9 @@ type DefaultExtender struct {
 	IA addr.IA
 	// SignerGen is used to sign path segments.
 	SignerGen SignerGen
-	// MAC is used to calculate the hop field MAC.
-	MAC func() hash.Hash
+	// MAC is used to calculate the hop field MAC. It returns the MAC together
+	// with the key phase of the key that the MAC uses.
+	MAC func() (hash.Hash, bool)
 	// Intfs holds all interfaces in the AS.
 	Intfs *ifstate.Interfaces
 	// MTU is the MTU value set in the AS entries.
@@ -223,6 +224,7 @@ func (s *DefaultExtender) createHopEntry(
 			ConsEgress:  hopF.ConsEgress,
 			ExpTime:     hopF.ExpTime,
 			MAC:         hopF.Mac,
+			KeyPhase:    hopF.KeyPhase,
 		},
 	}, epicMac, nil
 }
@@ -245,6 +247,7 @@ func (s *DefaultExtender) createPeerEntry(ingress, egress uint16, expTime uint8,
 			ConsEgress:  hopF.ConsEgress,
 			ExpTime:     hopF.ExpTime,
 			MAC:         hopF.Mac,
+			KeyPhase:    hopF.KeyPhase,
 		},
 	}, epicMac, nil
 }
@@ -302,7 +305,7 @@ func (s *DefaultExtender) createHopF(ingress, egress uint16, expTime uint8, ts t
 	input := make([]byte, path.MACBufferSize)
 	path.MACInput(beta, util.TimeToSecs(ts), expTime, ingress, egress, input)
 
-	mac := s.MAC()
+	mac, keyPhase := s.MAC()
 	// Write must not return an error: https://godoc.org/hash#Hash
 	if _, err := mac.Write(input); err != nil {
 		panic(err)
@@ -315,6 +318,7 @@ func (s *DefaultExtender) createHopF(ingress, egress uint16, expTime uint8, ts t
 		ConsEgress:  egress,
 		ExpTime:     expTime,
 		Mac:         m,
+		KeyPhase:    keyPhase,
 	}, fullMAC[path.MacLen:]
 }
 
@@ -102,10 +102,10 @@ func TestDefaultExtenderExtend(t *testing.T) {
 			ext := &beaconing.DefaultExtender{
 				IA:        topo.IA(),
 				SignerGen: testSignerGen{Signer: testSigner(t, priv, topo.IA())},
-				MAC: func() hash.Hash {
+				MAC: func() (hash.Hash, bool) {
 					mac, err := scrypto.InitMac(make([]byte, 16))
 					require.NoError(t, err)
-					return mac
+					return mac, true
 				},
 				Intfs:      intfs,
 				MTU:        1337,
@@ -155,6 +155,7 @@ func TestDefaultExtenderExtend(t *testing.T) {
 				assert.Equal(t, tc.ingress, entry.HopEntry.HopField.ConsIngress)
 				assert.Equal(t, tc.egress, entry.HopEntry.HopField.ConsEgress)
 				assert.Equal(t, ext.MaxExpTime(), entry.HopEntry.HopField.ExpTime)
+				assert.True(t, entry.HopEntry.HopField.KeyPhase)
 				// FIXME(roosd): Check hop field can be authenticated.
 			})
 			t.Run("peer entry check", func(t *testing.T) {
@@ -162,6 +163,7 @@ func TestDefaultExtenderExtend(t *testing.T) {
 					assert.Equal(t, tc.peers[i], entry.PeerEntries[i].HopField.ConsIngress)
 					assert.Equal(t, tc.egress, entry.PeerEntries[i].HopField.ConsEgress)
 					assert.Equal(t, ext.MaxExpTime(), entry.PeerEntries[i].HopField.ExpTime)
+					assert.True(t, entry.PeerEntries[i].HopField.KeyPhase)
 					// FIXME(roosd): Check hop field can be authenticated.
 				}
 			})
@@ -175,10 +177,10 @@ func TestDefaultExtenderExtend(t *testing.T) {
 		ext := &beaconing.DefaultExtender{
 			IA:        topo.IA(),
 			SignerGen: testSignerGen{Signer: testSigner(t, priv, topo.IA())},
-			MAC: func() hash.Hash {
+			MAC: func() (hash.Hash, bool) {
 				mac, err := scrypto.InitMac(make([]byte, 16))
 				require.NoError(t, err)
-				return mac
+				return mac, false
 			},
 			Intfs:      intfs,
 			MTU:        1337,
@@ -269,10 +271,10 @@ func TestDefaultExtenderExtend(t *testing.T) {
 				ext := &beaconing.DefaultExtender{
 					IA:        topo.IA(),
 					SignerGen: tc.SignerGen,
-					MAC: func() hash.Hash {
+					MAC: func() (hash.Hash, bool) {
 						mac, err := scrypto.InitMac(make([]byte, 16))
 						require.NoError(t, err)
-						return mac
+						return mac, false
 					},
 					Intfs:      intfs,
 					MTU:        1337,
@@ -340,10 +342,10 @@ func TestDefaultExtenderExtend(t *testing.T) {
 				ext := &beaconing.DefaultExtender{
 					IA:        topo.IA(),
 					SignerGen: testSignerGen{Signer: testSigner(t, priv, topo.IA())},
-					MAC: func() hash.Hash {
+					MAC: func() (hash.Hash, bool) {
 						mac, err := scrypto.InitMac(make([]byte, 16))
 						require.NoError(t, err)
-						return mac
+						return mac, false
 					},
 					Intfs:      intfs,
 					MTU:        1337,
@@ -28,6 +28,7 @@ import (
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
@@ -68,6 +69,10 @@ type Originator struct {
 	OriginationInterfaces func() []*ifstate.Interface
 
 	Originated metrics.Counter
+	// Clock provides the current time of each run. It determines the
+	// timestamp, and thus the expiry, of the originated beacons. If nil, the
+	// wall clock is used.
+	Clock clock.Clock
 
 	// Tick is mutable.
 	Tick Tick
@@ -80,7 +85,7 @@ func (o *Originator) Name() string {
 
 // Run originates core and downstream beacons.
 func (o *Originator) Run(ctx context.Context) {
-	o.Tick.SetNow(time.Now())
+	o.Tick.SetNow(clock.Now(o.Clock))
 	o.originateBeacons(ctx)
 	o.Tick.UpdateLast()
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/scionproto/scion/control/beaconing/mock_beaconing"
 	"github.com/scionproto/scion/control/ifstate"
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
 	"github.com/scionproto/scion/pkg/scrypto/signed"
@@ -126,6 +127,7 @@ func TestOriginatorRun(t *testing.T) {
 		intfs := ifstate.NewInterfaces(interfaceInfos(topo), ifstate.Config{})
 		senderFactory := mock_beaconing.NewMockSenderFactory(mctrl)
 		sender := mock_beaconing.NewMockSender(mctrl)
+		c := clock.NewManual(time.Now())
 		o := beaconing.Originator{
 			Extender: &beaconing.DefaultExtender{
 				IA:         topo.IA(),
@@ -143,7 +145,8 @@ func TestOriginatorRun(t *testing.T) {
 			OriginationInterfaces: func() []*ifstate.Interface {
 				return intfs.Filtered(originationFilter)
 			},
-			Tick: beaconing.NewTick(2 * time.Second),
+			Tick:  beaconing.NewTick(2 * time.Second),
+			Clock: c,
 		}
 
 		senderFactory.EXPECT().NewSender(gomock.Any(), gomock.Any(), gomock.Any(),
@@ -164,12 +167,12 @@ func TestOriginatorRun(t *testing.T) {
 
 		// Initial run. Two writes expected, one write will fail.
 		o.Run(context.Background())
-		time.Sleep(1 * time.Second)
+		c.Advance(time.Second)
 		// Second run. One write expected.
 		o.Run(context.Background())
 		// Third run. No write expected
 		o.Run(context.Background())
-		time.Sleep(1 * time.Second)
+		c.Advance(time.Second)
 		// Fourth run. Since period has passed, two writes are expected.
 		o.Run(context.Background())
 	})
@@ -27,6 +27,7 @@ import (
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
@@ -50,7 +51,8 @@ var _ periodic.Task = (*Propagator)(nil)
 // Propagator forwards beacons to neighboring ASes. In a core AS, the beacons
 // are propagated to neighbors on core links. In a non-core AS, the beacons are
 // forwarded on child links. Selection of the beacons is handled by the beacon
-// provider, the propagator only filters AS loops.
+// provider, the propagator filters AS loops and, if MaxBeaconsPerInterface is
+// set, selects the most diverse beacons for every neighbor.
 type Propagator struct {
 	Extender              Extender
 	SenderFactory         SenderFactory
@@ -60,9 +62,24 @@ type Propagator struct {
 	AllInterfaces         *ifstate.Interfaces
 	PropagationInterfaces func() []*ifstate.Interface
 	AllowIsdLoop          bool
+	// MaxBeaconsPerInterface is the maximum number of beacons propagated on an
+	// egress interface. The beacons are selected such that the AS-path and
+	// interface diversity of the beacons sent to a neighbor AS, possibly over
+	// multiple parallel interfaces, is maximized. If zero, all beacons
+	// provided by the beacon provider are propagated.
+	MaxBeaconsPerInterface int
 
 	Propagated     metrics.Counter
 	InternalErrors metrics.Counter
+	// DistinctASPaths is the number of distinct AS-level paths of the beacons
+	// selected for an egress interface. If nil, it is not reported.
+	DistinctASPaths metrics.Gauge
+	// DistinctLinks is the number of distinct inter-AS links of the beacons
+	// selected for an egress interface. If nil, it is not reported.
+	DistinctLinks metrics.Gauge
+	// Clock provides the current time of each run. If nil, the wall clock is
+	// used.
+	Clock clock.Clock
 
 	// Tick is mutable.
 	Tick Tick
@@ -78,7 +95,7 @@ func (p *Propagator) Name() string {
 // interfaces. In a non-core beacon server, child interfaces are the target
 // interfaces.
 func (p *Propagator) Run(ctx context.Context) {
-	p.Tick.SetNow(time.Now())
+	p.Tick.SetNow(clock.Now(p.Clock))
 	if err := p.run(ctx); err != nil {
 		withSilent(ctx, p.Tick.Passed()).Error("Unable to propagate beacons", "err", err)
 	}
@@ -175,12 +192,24 @@ func (p *Propagator) beaconsPerInterface(
 		beacons = append(beacons, b)
 	}
 	r := make(map[*ifstate.Interface][]beacon.Beacon)
+	// The beacons selected for the interfaces to a neighbor, such that
+	// parallel interfaces to the same neighbor carry diverse beacons.
+	sent := make(map[addr.IA][]beacon.Beacon)
 	for _, intf := range intfs {
-		toPropagate := make([]beacon.Beacon, 0, len(beacons))
+		var candidates []beacon.Beacon
 		for _, b := range beacons {
 			if p.shouldIgnore(b, intf) {
 				continue
 			}
+			candidates = append(candidates, b)
+		}
+		neighbor := intf.TopoInfo().IA
+		candidates = selectDiverse(candidates, p.MaxBeaconsPerInterface, sent[neighbor])
+		sent[neighbor] = append(sent[neighbor], candidates...)
+		p.reportDiversity(intf, candidates)
+
+		toPropagate := make([]beacon.Beacon, 0, len(candidates))
+		for _, b := range candidates {
 			ps, err := seg.BeaconFromPB(seg.PathSegmentToPB(b.Segment))
 			if err != nil {
 				return nil, err
@@ -192,6 +221,21 @@ func (p *Propagator) beaconsPerInterface(
 	return r, nil
 }
 
+// reportDiversity reports the diversity of the beacons selected for the
+// egress interface.
+func (p *Propagator) reportDiversity(intf *ifstate.Interface, beacons []beacon.Beacon) {
+	if p.DistinctASPaths == nil && p.DistinctLinks == nil {
+		return
+	}
+	labels := []string{
+		"egress_interface", strconv.Itoa(int(intf.TopoInfo().ID)),
+		prom.LabelNeighIA, intf.TopoInfo().IA.String(),
+	}
+	asPaths, links := diversity(beacons)
+	metrics.GaugeSet(metrics.GaugeWith(p.DistinctASPaths, labels...), float64(asPaths))
+	metrics.GaugeSet(metrics.GaugeWith(p.DistinctLinks, labels...), float64(links))
+}
+
 // shouldIgnore indicates whether a beacon should not be sent on the egress
 // interface because it creates a loop.
 func (p *Propagator) shouldIgnore(bseg beacon.Beacon, intf *ifstate.Interface) bool {
@@ -0,0 +1,236 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	seg "github.com/scionproto/scion/pkg/segment"
+)
+
+// RegistrationFilter excludes segments from being registered. In contrast to
+// the filters of the beaconing policies, which are applied when a beacon is
+// received, the registration filter is applied every time the segments are
+// registered. Changes to the filter therefore also apply to the beacons that
+// are already in the beacon store.
+type RegistrationFilter struct {
+	// Up contains the rules for up segment registration.
+	Up RegistrationRules `yaml:"Up"`
+	// Down contains the rules for down segment registration.
+	Down RegistrationRules `yaml:"Down"`
+	// Core contains the rules for core segment registration.
+	Core RegistrationRules `yaml:"Core"`
+}
+
+// RegistrationRules lists the ASes and interfaces a segment must not traverse
+// to be registered.
+type RegistrationRules struct {
+	// ExcludeASes contains the ASes that may not appear in a registered
+	// segment. A wildcard AS matches all ASes of the ISD.
+	ExcludeASes []addr.IA `yaml:"ExcludeASes"`
+	// ExcludeInterfaces contains the interfaces that may not appear in a
+	// registered segment.
+	ExcludeInterfaces []InterfaceRef `yaml:"ExcludeInterfaces"`
+}
+
+// InterfaceRef identifies an interface of an AS. Its string representation
+// is "<ISD-AS>#<interface ID>", e.g., "1-ff00:0:110#2".
+type InterfaceRef struct {
+	IA addr.IA
+	ID common.IFIDType
+}
+
+// ParseInterfaceRef parses the string representation of an interface
+// reference.
+func ParseInterfaceRef(s string) (InterfaceRef, error) {
+	parts := strings.Split(s, "#")
+	if len(parts) != 2 {
+		return InterfaceRef{}, serrors.New("interface must be of the form <ISD-AS>#<ID>",
+			"interface", s)
+	}
+	ia, err := addr.ParseIA(parts[0])
+	if err != nil {
+		return InterfaceRef{}, serrors.WrapStr("parsing ISD-AS", err, "interface", s)
+	}
+	id, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return InterfaceRef{}, serrors.WrapStr("parsing interface ID", err, "interface", s)
+	}
+	return InterfaceRef{IA: ia, ID: common.IFIDType(id)}, nil
+}
+
+func (r InterfaceRef) String() string {
+	return fmt.Sprintf("%s#%d", r.IA, r.ID)
+}
+
+func (r InterfaceRef) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *InterfaceRef) UnmarshalText(text []byte) error {
+	parsed, err := ParseInterfaceRef(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// ParseRegistrationFilterYaml parses the registration filter in yaml format.
+func ParseRegistrationFilterYaml(b []byte) (*RegistrationFilter, error) {
+	f := &RegistrationFilter{}
+	if err := yaml.UnmarshalStrict(b, f); err != nil {
+		return nil, serrors.WrapStr("parsing registration filter", err)
+	}
+	return f, nil
+}
+
+// LoadRegistrationFilter loads the registration filter from a yaml file.
+func LoadRegistrationFilter(file string) (*RegistrationFilter, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("reading registration filter", err, "file", file)
+	}
+	return ParseRegistrationFilterYaml(b)
+}
+
+// Filter returns the beacons that may be registered as segments of the given
+// type. The local IA is used to match the interface over which the beacon was
+// received.
+func (f *RegistrationFilter) Filter(segType seg.Type, localIA addr.IA,
+	beacons []beacon.Beacon) []beacon.Beacon {
+
+	if f == nil {
+		return beacons
+	}
+	var rules RegistrationRules
+	switch segType {
+	case seg.TypeUp:
+		rules = f.Up
+	case seg.TypeDown:
+		rules = f.Down
+	case seg.TypeCore:
+		rules = f.Core
+	}
+	if len(rules.ExcludeASes) == 0 && len(rules.ExcludeInterfaces) == 0 {
+		return beacons
+	}
+	result := make([]beacon.Beacon, 0, len(beacons))
+	for _, b := range beacons {
+		if !rules.excludes(localIA, b) {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+func (r RegistrationRules) excludes(localIA addr.IA, b beacon.Beacon) bool {
+	for _, entry := range b.Segment.ASEntries {
+		if r.excludesAS(entry.Local) {
+			return true
+		}
+		hf := entry.HopEntry.HopField
+		if r.excludesInterface(entry.Local, common.IFIDType(hf.ConsIngress)) ||
+			r.excludesInterface(entry.Local, common.IFIDType(hf.ConsEgress)) {
+			return true
+		}
+	}
+	return r.excludesInterface(localIA, common.IFIDType(b.InIfId))
+}
+
+func (r RegistrationRules) excludesAS(ia addr.IA) bool {
+	for _, excluded := range r.ExcludeASes {
+		if excluded.ISD() != ia.ISD() {
+			continue
+		}
+		if excluded.AS() == 0 || excluded.AS() == ia.AS() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r RegistrationRules) excludesInterface(ia addr.IA, id common.IFIDType) bool {
+	if id == 0 {
+		return false
+	}
+	for _, excluded := range r.ExcludeInterfaces {
+		if excluded.IA.Equal(ia) && excluded.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// RegistrationFilterLoader holds the registration filter loaded from a file,
+// and reloads it on request.
+type RegistrationFilterLoader struct {
+	// File is the path of the registration filter file.
+	File string
+
+	mtx    sync.RWMutex
+	filter *RegistrationFilter
+}
+
+// Load loads the registration filter from the file. If loading fails, the
+// previously loaded filter is kept.
+func (l *RegistrationFilterLoader) Load() error {
+	filter, err := LoadRegistrationFilter(l.File)
+	if err != nil {
+		return err
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.filter = filter
+	return nil
+}
+
+// Get returns the currently loaded registration filter. It returns nil if no
+// filter has been loaded.
+func (l *RegistrationFilterLoader) Get() *RegistrationFilter {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.filter
+}
+
+// Run reloads the registration filter whenever a value is received on the
+// reload channel, until the context is canceled.
+func (l *RegistrationFilterLoader) Run(ctx context.Context, reload <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reload:
+			if err := l.Load(); err != nil {
+				log.FromCtx(ctx).Info("Failed to reload registration filter, "+
+					"keeping previous filter", "err", err)
+				continue
+			}
+			log.FromCtx(ctx).Info("Reloaded registration filter", "file", l.File)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+	seg "github.com/scionproto/scion/pkg/segment"
+)
+
+func TestRegistrationFilter(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+	localIA := xtest.MustParseIA("1-ff00:0:111")
+	short := testBeacon(g, []uint16{graph.If_120_X_111_B})
+	long := testBeacon(g, []uint16{graph.If_130_B_120_A, graph.If_120_X_111_B})
+	beacons := []beacon.Beacon{short, long}
+
+	testCases := map[string]struct {
+		Filter   string
+		SegType  seg.Type
+		Expected []beacon.Beacon
+	}{
+		"empty": {
+			Filter:   "",
+			SegType:  seg.TypeUp,
+			Expected: beacons,
+		},
+		"exclude AS": {
+			Filter:   `Up: {ExcludeASes: ["1-ff00:0:130"]}`,
+			SegType:  seg.TypeUp,
+			Expected: []beacon.Beacon{short},
+		},
+		"other segment type": {
+			Filter:   `Up: {ExcludeASes: ["1-ff00:0:130"]}`,
+			SegType:  seg.TypeDown,
+			Expected: beacons,
+		},
+		"exclude ISD": {
+			Filter:   `Down: {ExcludeASes: ["1-0"]}`,
+			SegType:  seg.TypeDown,
+			Expected: []beacon.Beacon{},
+		},
+		"exclude remote interface": {
+			Filter: fmt.Sprintf(`Up: {ExcludeInterfaces: ["1-ff00:0:130#%d"]}`,
+				graph.If_130_B_120_A),
+			SegType:  seg.TypeUp,
+			Expected: []beacon.Beacon{short},
+		},
+		"exclude local interface": {
+			Filter: fmt.Sprintf(`Up: {ExcludeInterfaces: ["1-ff00:0:111#%d"]}`,
+				graph.If_111_B_120_X),
+			SegType:  seg.TypeUp,
+			Expected: []beacon.Beacon{},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			f, err := beaconing.ParseRegistrationFilterYaml([]byte(tc.Filter))
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, f.Filter(tc.SegType, localIA, beacons))
+		})
+	}
+}
+
+func TestParseRegistrationFilterYaml(t *testing.T) {
+	f, err := beaconing.ParseRegistrationFilterYaml([]byte(`
+Core:
+  ExcludeASes: ["1-ff00:0:110"]
+  ExcludeInterfaces: ["1-ff00:0:120#3"]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []beaconing.InterfaceRef{
+		{IA: xtest.MustParseIA("1-ff00:0:120"), ID: 3},
+	}, f.Core.ExcludeInterfaces)
+
+	_, err = beaconing.ParseRegistrationFilterYaml([]byte(
+		`Core: {ExcludeInterfaces: ["1-ff00:0:120"]}`))
+	assert.Error(t, err)
+	_, err = beaconing.ParseRegistrationFilterYaml([]byte(`Unknown: {}`))
+	assert.Error(t, err)
+}
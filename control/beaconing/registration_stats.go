@@ -0,0 +1,86 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	seg "github.com/scionproto/scion/pkg/segment"
+)
+
+// RegistrationCount are the counts of the segment registrations of a segment
+// type.
+type RegistrationCount struct {
+	Type seg.Type
+	// Succeeded is the number of segments that were registered.
+	Succeeded uint64
+	// Failed is the number of segments whose registration failed.
+	Failed uint64
+	// LastSuccess is the time of the last successful registration.
+	LastSuccess time.Time
+	// LastFailure is the time of the last failed registration.
+	LastFailure time.Time
+}
+
+// RegistrationStats counts the segment registrations of the writers per
+// segment type. It is safe for concurrent use. All methods can be called on a
+// nil RegistrationStats, in which case nothing is counted.
+type RegistrationStats struct {
+	mtx    sync.Mutex
+	counts map[seg.Type]*RegistrationCount
+}
+
+// Record records the result of the registration of n segments of the given
+// type.
+func (s *RegistrationStats) Record(t seg.Type, n int, ok bool) {
+	if s == nil || n == 0 {
+		return
+	}
+	now := time.Now()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.counts == nil {
+		s.counts = make(map[seg.Type]*RegistrationCount)
+	}
+	c, found := s.counts[t]
+	if !found {
+		c = &RegistrationCount{Type: t}
+		s.counts[t] = c
+	}
+	if ok {
+		c.Succeeded += uint64(n)
+		c.LastSuccess = now
+	} else {
+		c.Failed += uint64(n)
+		c.LastFailure = now
+	}
+}
+
+// Counts returns the counts ordered by segment type.
+func (s *RegistrationStats) Counts() []RegistrationCount {
+	if s == nil {
+		return nil
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	counts := make([]RegistrationCount, 0, len(s.counts))
+	for _, c := range s.counts {
+		counts = append(counts, *c)
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Type < counts[j].Type })
+	return counts
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/beaconing"
+	seg "github.com/scionproto/scion/pkg/segment"
+)
+
+func TestRegistrationStats(t *testing.T) {
+	var nilStats *beaconing.RegistrationStats
+	nilStats.Record(seg.TypeUp, 1, true)
+	assert.Empty(t, nilStats.Counts())
+
+	stats := &beaconing.RegistrationStats{}
+	stats.Record(seg.TypeDown, 2, true)
+	stats.Record(seg.TypeDown, 1, false)
+	stats.Record(seg.TypeUp, 3, true)
+	stats.Record(seg.TypeCore, 0, false)
+	counts := stats.Counts()
+	require.Len(t, counts, 2)
+	assert.Equal(t, seg.TypeUp, counts[0].Type)
+	assert.Equal(t, uint64(3), counts[0].Succeeded)
+	assert.True(t, counts[0].LastFailure.IsZero())
+	assert.Equal(t, seg.TypeDown, counts[1].Type)
+	assert.Equal(t, uint64(2), counts[1].Succeeded)
+	assert.Equal(t, uint64(1), counts[1].Failed)
+	assert.False(t, counts[1].LastSuccess.IsZero())
+	assert.False(t, counts[1].LastFailure.IsZero())
+}
@@ -15,12 +15,15 @@
 package beaconing
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
@@ -103,6 +106,57 @@ func ParseStaticInfoCfg(file string) (*StaticInfoCfg, error) {
 	return &cfg, nil
 }
 
+// StaticInfoLoader holds the static info configuration loaded from a file, and
+// reloads it on request. This allows operators to update the static info that
+// is attached to originated and propagated beacons without restarting the
+// control service.
+type StaticInfoLoader struct {
+	// File is the path of the static info configuration file.
+	File string
+
+	mtx sync.RWMutex
+	cfg *StaticInfoCfg
+}
+
+// Load loads the static info configuration from the file. If loading fails,
+// the previously loaded configuration is kept.
+func (l *StaticInfoLoader) Load() error {
+	cfg, err := ParseStaticInfoCfg(l.File)
+	if err != nil {
+		return err
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.cfg = cfg
+	return nil
+}
+
+// Get returns the currently loaded static info configuration. It returns nil
+// if no configuration has been loaded.
+func (l *StaticInfoLoader) Get() *StaticInfoCfg {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.cfg
+}
+
+// Run reloads the static info configuration whenever a value is received on
+// the reload channel, until the context is canceled.
+func (l *StaticInfoLoader) Run(ctx context.Context, reload <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reload:
+			if err := l.Load(); err != nil {
+				log.FromCtx(ctx).Info("Failed to reload static info, keeping previous settings",
+					"err", err)
+				continue
+			}
+			log.FromCtx(ctx).Info("Reloaded static info", "file", l.File)
+		}
+	}
+}
+
 // clean checks or corrects the entries in the static info configuration.
 // In particular, it will
 //   - ensure there are no entries for the 0 interface ID (as this is invalid
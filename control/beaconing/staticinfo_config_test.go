@@ -15,10 +15,14 @@
 package beaconing_test
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/control/beaconing"
 	"github.com/scionproto/scion/pkg/private/common"
@@ -217,6 +221,35 @@ func TestParsing(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestStaticInfoLoader(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "staticInfoConfig.json")
+	loader := &beaconing.StaticInfoLoader{File: file}
+	assert.Error(t, loader.Load())
+	assert.Nil(t, loader.Get())
+
+	raw, err := os.ReadFile("testdata/testconfigfile.json")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, raw, 0644))
+	ctx, cancelF := context.WithCancel(context.Background())
+	reload := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		loader.Run(ctx, reload)
+	}()
+	reload <- struct{}{}
+	// Send a second reload to ensure the first one has been processed.
+	reload <- struct{}{}
+	assert.Equal(t, getTestConfigData(), loader.Get())
+
+	// A broken file does not replace the loaded configuration.
+	require.NoError(t, os.WriteFile(file, []byte("{"), 0644))
+	reload <- struct{}{}
+	cancelF()
+	<-done
+	assert.Equal(t, getTestConfigData(), loader.Get())
+}
+
 func TestGenerateStaticInfo(t *testing.T) {
 	cfg := getTestConfigData()
 
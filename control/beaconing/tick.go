@@ -15,6 +15,7 @@
 package beaconing
 
 import (
+	"math/rand"
 	"time"
 )
 
@@ -25,12 +26,25 @@ type Tick struct {
 	now    time.Time
 	last   time.Time
 	period time.Duration
+	// jitter is the upper bound of the random delay added to the period.
+	jitter time.Duration
+	// delay is the random delay added to the current period.
+	delay time.Duration
 }
 
 func NewTick(period time.Duration) Tick {
 	return Tick{period: period}
 }
 
+// NewTickWithJitter returns a Tick whose period is extended by a random delay
+// in [0, jitter) every time the period passes. This prevents many ASes from
+// acting in lockstep.
+func NewTickWithJitter(period, jitter time.Duration) Tick {
+	t := Tick{period: period, jitter: jitter}
+	t.updateDelay()
+	return t
+}
+
 func (t *Tick) SetNow(now time.Time) {
 	t.now = now
 }
@@ -39,10 +53,10 @@ func (t *Tick) Now() time.Time {
 	return t.now
 }
 
-// Overdue returns true if the Tick's period has elapsed since timestamp in the
-// past up to the Tick's Now time.
+// Overdue returns true if the Tick's period, including the current random
+// delay, has elapsed since timestamp in the past up to the Tick's Now time.
 func (t *Tick) Overdue(timestamp time.Time) bool {
-	return t.now.Sub(timestamp) > t.period
+	return t.now.Sub(timestamp) > t.period+t.delay
 }
 
 func (t *Tick) Period() time.Duration {
@@ -54,11 +68,19 @@ func (t *Tick) Period() time.Duration {
 func (t *Tick) UpdateLast() {
 	if t.Passed() {
 		t.last = t.now
+		t.updateDelay()
 	}
 }
 
-// Passed returns true if the Tick's period has elapsed since the last UpdateLast call up to
-// the Tick's Now time.
+// Passed returns true if the Tick's period, including the current random delay, has elapsed
+// since the last UpdateLast call up to the Tick's Now time.
 func (t *Tick) Passed() bool {
-	return t.now.Sub(t.last) >= t.period
+	return t.now.Sub(t.last) >= t.period+t.delay
+}
+
+func (t *Tick) updateDelay() {
+	if t.jitter <= 0 {
+		return
+	}
+	t.delay = time.Duration(rand.Int63n(int64(t.jitter)))
 }
@@ -0,0 +1,57 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/control/beaconing"
+)
+
+func TestTickWithJitter(t *testing.T) {
+	period, jitter := 5*time.Second, 2*time.Second
+	now := time.Now()
+
+	tick := beaconing.NewTickWithJitter(period, jitter)
+	tick.SetNow(now)
+	assert.True(t, tick.Passed(), "first tick must pass immediately")
+	tick.UpdateLast()
+
+	for i := 0; i < 20; i++ {
+		tick.SetNow(now.Add(period - time.Millisecond))
+		assert.False(t, tick.Passed())
+		assert.False(t, tick.Overdue(now))
+		tick.SetNow(now.Add(period + jitter))
+		assert.True(t, tick.Passed())
+		assert.True(t, tick.Overdue(now.Add(-time.Millisecond)))
+		tick.UpdateLast()
+		now = now.Add(period + jitter)
+	}
+}
+
+func TestTickWithoutJitter(t *testing.T) {
+	period := 5 * time.Second
+	now := time.Now()
+
+	tick := beaconing.NewTickWithJitter(period, 0)
+	tick.SetNow(now)
+	tick.UpdateLast()
+	tick.SetNow(now.Add(period))
+	assert.True(t, tick.Passed())
+	assert.Equal(t, period, tick.Period())
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
@@ -89,6 +90,14 @@ type WriteScheduler struct {
 	// Write is used to write the segments once the scheduling determines it is
 	// time to write.
 	Writer Writer
+	// IA is the local ISD-AS. It is used by the registration filter.
+	IA addr.IA
+	// RegistrationFilter returns the filter that excludes segments from being
+	// registered. If it is nil, or returns nil, all segments are registered.
+	RegistrationFilter func() *RegistrationFilter
+	// Clock provides the current time of each run. If nil, the wall clock is
+	// used.
+	Clock clock.Clock
 
 	// Tick is mutable. It's used to determine when to call write.
 	Tick Tick
@@ -105,7 +114,7 @@ func (r *WriteScheduler) Name() string {
 
 // Run writes path segments using the configured writer.
 func (r *WriteScheduler) Run(ctx context.Context) {
-	r.Tick.SetNow(time.Now())
+	r.Tick.SetNow(clock.Now(r.Clock))
 	if err := r.run(ctx); err != nil {
 		log.FromCtx(ctx).Error("Unable to register", "seg_type", r.Type, "err", err)
 	}
@@ -120,6 +129,14 @@ func (r *WriteScheduler) run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if r.RegistrationFilter != nil {
+		total := len(segments)
+		segments = r.RegistrationFilter().Filter(r.Type, r.IA, segments)
+		if filtered := total - len(segments); filtered > 0 {
+			log.FromCtx(ctx).Debug("Excluded segments from registration",
+				"seg_type", r.Type, "filtered", filtered)
+		}
+	}
 	peers := sortedIntfs(r.Intfs, topology.Peer)
 	stats, err := r.Writer.Write(ctx, segments, peers)
 	if err != nil {
@@ -151,6 +168,11 @@ type RemoteWriter struct {
 	RPC RPC
 	// Pather is used to find paths to a remote.
 	Pather Pather
+	// Stats counts the registrations. If it is nil, nothing is counted.
+	Stats *RegistrationStats
+	// Parallelism is the maximum number of concurrent registrations. If zero,
+	// all segments are registered concurrently.
+	Parallelism int
 }
 
 // Write writes the segment at the source AS of the segment.
@@ -164,6 +186,10 @@ func (r *RemoteWriter) Write(
 	s := newSummary()
 	var expected int
 	var wg sync.WaitGroup
+	var sem chan struct{}
+	if r.Parallelism > 0 {
+		sem = make(chan struct{}, r.Parallelism)
+	}
 	for _, b := range segments {
 		if r.Intfs.Get(b.InIfId) == nil {
 			continue
@@ -181,6 +207,7 @@ func (r *RemoteWriter) Write(
 			pather:  r.Pather,
 			summary: s,
 			wg:      &wg,
+			sem:     sem,
 		}
 
 		// Avoid head-of-line blocking when sending message to slow servers.
@@ -210,6 +237,8 @@ type LocalWriter struct {
 	Extender Extender
 	// Intfs gives access to the interfaces this CS beacons over.
 	Intfs *ifstate.Interfaces
+	// Stats counts the registrations. If it is nil, nothing is counted.
+	Stats *RegistrationStats
 }
 
 // Write terminates the segments and registers them in the SegmentStore.
@@ -241,9 +270,11 @@ func (r *LocalWriter) Write(
 	stats, err := r.Store.StoreSegs(ctx, toRegister)
 	if err != nil {
 		metrics.CounterInc(r.InternalErrors)
+		r.Stats.Record(r.Type, len(toRegister), false)
 		return WriteStats{}, err
 	}
 	r.updateMetricsFromStat(stats, beacons)
+	r.Stats.Record(r.Type, len(stats.InsertedSegs)+len(stats.UpdatedSegs), true)
 	sum := summarizeStats(stats, beacons)
 	return WriteStats{Count: sum.count, StartIAs: sum.srcs}, nil
 }
@@ -288,6 +319,9 @@ type remoteWriter struct {
 	pather  Pather
 	summary *summary
 	wg      *sync.WaitGroup
+	// sem limits the number of concurrent registrations. If nil, there is no
+	// limit.
+	sem chan struct{}
 }
 
 // start extends the beacon and starts a go routine that registers the beacon
@@ -312,6 +346,10 @@ func (r *remoteWriter) startSendSegReg(ctx context.Context, bseg beacon.Beacon,
 	go func() {
 		defer log.HandlePanic()
 		defer r.wg.Done()
+		if r.sem != nil {
+			r.sem <- struct{}{}
+			defer func() { <-r.sem }()
+		}
 
 		labels := writerLabels{
 			StartIA: bseg.Segment.FirstIA(),
@@ -325,8 +363,10 @@ func (r *remoteWriter) startSendSegReg(ctx context.Context, bseg beacon.Beacon,
 				"seg_type", r.writer.Type, "addr", addr, "err", err)
 			metrics.CounterInc(metrics.CounterWith(r.writer.Registered,
 				labels.WithResult(prom.ErrNetwork).Expand()...))
+			r.writer.Stats.Record(r.writer.Type, 1, false)
 			return
 		}
+		r.writer.Stats.Record(r.writer.Type, 1, true)
 		r.summary.AddSrc(bseg.Segment.FirstIA())
 		r.summary.Inc()
 
@@ -353,13 +353,13 @@ func (s testSignerGen) Generate(ctx context.Context) (trust.Signer, error) {
 	return s.Signer, nil
 }
 
-var macFactory = func() hash.Hash {
+var macFactory = func() (hash.Hash, bool) {
 	mac, err := scrypto.InitMac(make([]byte, 16))
 	// This can only happen if the library is messed up badly.
 	if err != nil {
 		panic(err)
 	}
-	return mac
+	return mac, false
 }
 
 type topoWrap struct {
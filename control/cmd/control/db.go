@@ -0,0 +1,527 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	admingrpc "github.com/scionproto/scion/control/admin/grpc"
+	"github.com/scionproto/scion/control/config"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/private/app/command"
+	caconfig "github.com/scionproto/scion/private/ca/config"
+	libconfig "github.com/scionproto/scion/private/config"
+	"github.com/scionproto/scion/private/mgmtapi/jwtauth"
+	"github.com/scionproto/scion/private/storage"
+	beaconsqlite "github.com/scionproto/scion/private/storage/beacon/sqlite"
+	pathsqlite "github.com/scionproto/scion/private/storage/path/sqlite"
+)
+
+// dbHop is an interface traversed by a beacon or a segment.
+type dbHop struct {
+	IA        addr.IA `json:"isd_as"`
+	Interface uint64  `json:"interface_id"`
+}
+
+func (h dbHop) String() string {
+	return fmt.Sprintf("%s#%d", h.IA, h.Interface)
+}
+
+// dbBeacon is a beacon as reported by the db beacons command.
+type dbBeacon struct {
+	ID          string    `json:"id"`
+	Origin      addr.IA   `json:"origin_isd_as"`
+	Ingress     uint64    `json:"ingress_interface_id"`
+	Hops        []dbHop   `json:"hops"`
+	Usages      []string  `json:"usages"`
+	Timestamp   time.Time `json:"timestamp"`
+	Expiration  time.Time `json:"expiration"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+// dbSegment is a segment as reported by the db segments command.
+type dbSegment struct {
+	ID               string    `json:"id"`
+	Type             string    `json:"type"`
+	Origin           addr.IA   `json:"origin_isd_as"`
+	End              addr.IA   `json:"end_isd_as"`
+	Hops             []dbHop   `json:"hops"`
+	HiddenPathGroups []uint64  `json:"hidden_path_groups"`
+	Expiration       time.Time `json:"expiration"`
+	LastUpdated      time.Time `json:"last_updated"`
+}
+
+// dbAPI is the part of the admin API used by the db commands. It is
+// implemented by the admin API client for running control services, and by
+// the admin server operating directly on the databases for offline inspection.
+type dbAPI interface {
+	ListBeacons(context.Context, *cppb.ListBeaconsRequest) (*cppb.ListBeaconsResponse, error)
+	Segments(context.Context, *cppb.AdminSegmentsRequest) (*cppb.AdminSegmentsResponse, error)
+}
+
+type adminClientAPI struct {
+	client cppb.AdminServiceClient
+}
+
+func (a adminClientAPI) ListBeacons(ctx context.Context,
+	req *cppb.ListBeaconsRequest) (*cppb.ListBeaconsResponse, error) {
+
+	return a.client.ListBeacons(ctx, req)
+}
+
+func (a adminClientAPI) Segments(ctx context.Context,
+	req *cppb.AdminSegmentsRequest) (*cppb.AdminSegmentsResponse, error) {
+
+	return a.client.Segments(ctx, req)
+}
+
+// dbFilter selects the entries that are displayed.
+type dbFilter struct {
+	// Origin is the ISD-AS of the first AS. Wildcards are allowed.
+	Origin addr.IA
+	// Interface is the interface that must be traversed. Ignored if zero.
+	Interface dbHop
+	// Now is the reference time for the expiry filters.
+	Now time.Time
+	// All includes the expired entries.
+	All bool
+	// ExpiresWithin only includes entries that expire within the given
+	// duration. Ignored if zero.
+	ExpiresWithin time.Duration
+}
+
+func (f dbFilter) matchOrigin(ia addr.IA) bool {
+	return (f.Origin.ISD() == 0 || f.Origin.ISD() == ia.ISD()) &&
+		(f.Origin.AS() == 0 || f.Origin.AS() == ia.AS())
+}
+
+func (f dbFilter) matchHops(hops []dbHop) bool {
+	if f.Interface == (dbHop{}) {
+		return true
+	}
+	for _, hop := range hops {
+		if hop == f.Interface {
+			return true
+		}
+	}
+	return false
+}
+
+func (f dbFilter) matchExpiration(expiration time.Time) bool {
+	if !f.All && expiration.Before(f.Now) {
+		return false
+	}
+	return f.ExpiresWithin == 0 || expiration.Before(f.Now.Add(f.ExpiresWithin))
+}
+
+// dbFlags are the flags shared by the db subcommands.
+type dbFlags struct {
+	config        string
+	db            string
+	admin         bool
+	addr          string
+	sharedSecret  string
+	format        string
+	origin        string
+	iface         string
+	all           bool
+	expiresWithin time.Duration
+	timeout       time.Duration
+}
+
+func (f *dbFlags) register(cmd *cobra.Command, ifaceUsage string) {
+	cmd.Flags().StringVar(&f.config, "config", "",
+		"Configuration file of the control service")
+	cmd.Flags().StringVar(&f.db, "db", "",
+		"SQLite database file, overrides the file in the configuration")
+	cmd.Flags().BoolVar(&f.admin, "admin", false,
+		"Query the admin API of the running control service instead of the database")
+	cmd.Flags().StringVar(&f.addr, "addr", "", "Address of the admin API, implies --admin")
+	cmd.Flags().StringVar(&f.sharedSecret, "shared-secret", "",
+		"PEM file with the shared secret of the admin API")
+	cmd.Flags().StringVar(&f.format, "format", "human",
+		"Specify the output format (human|json)")
+	cmd.Flags().StringVar(&f.origin, "origin", "",
+		"Only display entries originated by this ISD-AS (wildcards allowed)")
+	cmd.Flags().StringVar(&f.iface, "interface", "", ifaceUsage)
+	cmd.Flags().BoolVar(&f.all, "all", false, "Include expired entries")
+	cmd.Flags().DurationVar(&f.expiresWithin, "expires-within", 0,
+		"Only display entries that expire within this period")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 5*time.Second,
+		"Timeout for querying the database or the admin API")
+}
+
+func (f *dbFlags) filter() (dbFilter, error) {
+	switch f.format {
+	case "human", "json":
+	default:
+		return dbFilter{}, serrors.New("format not supported", "format", f.format)
+	}
+	filter := dbFilter{
+		Now:           time.Now(),
+		All:           f.all,
+		ExpiresWithin: f.expiresWithin,
+	}
+	if f.origin != "" {
+		var err error
+		if filter.Origin, err = addr.ParseIA(f.origin); err != nil {
+			return dbFilter{}, serrors.WrapStr("parsing origin", err)
+		}
+	}
+	return filter, nil
+}
+
+// open returns the API to query. The database connection is selected from the
+// configuration with dbConfig. The returned closer must be called when the API
+// is no longer used.
+func (f *dbFlags) open(ctx context.Context, dbConfig func(*config.Config) storage.DBConfig,
+	openDB func(path string) (dbAPI, io.Closer, error)) (dbAPI, io.Closer, error) {
+
+	var cfg config.Config
+	if f.config != "" {
+		if err := libconfig.LoadFile(f.config, &cfg); err != nil {
+			return nil, nil, serrors.WrapStr("loading config from file", err,
+				"file", f.config)
+		}
+	}
+	if f.admin || f.addr != "" {
+		if f.addr == "" {
+			f.addr = cfg.Admin.Addr
+		}
+		if f.sharedSecret == "" {
+			f.sharedSecret = cfg.Admin.SharedSecret
+		}
+		if f.addr == "" || f.sharedSecret == "" {
+			return nil, nil, serrors.New("admin address and shared secret must be " +
+				"specified either in the configuration file or with flags")
+		}
+		conn, err := grpc.DialContext(ctx, f.addr,
+			grpc.WithInsecure(),
+			grpc.WithPerRPCCredentials(jwtauth.GRPCCredentials{
+				TokenSource: &jwtauth.JWTTokenSource{
+					Subject:   "scion-control-db",
+					Generator: caconfig.NewPEMSymmetricKey(f.sharedSecret).Get,
+				},
+			}),
+		)
+		if err != nil {
+			return nil, nil, serrors.WrapStr("connecting to admin API", err, "addr", f.addr)
+		}
+		return adminClientAPI{client: cppb.NewAdminServiceClient(conn)}, conn, nil
+	}
+	if f.db == "" {
+		dbCfg := dbConfig(&cfg)
+		if dbCfg.Backend != "" && dbCfg.Backend != storage.BackendSqlite {
+			return nil, nil, serrors.New("only sqlite databases can be inspected offline, "+
+				"use --admin instead", "backend", dbCfg.Backend)
+		}
+		f.db = dbCfg.Connection
+	}
+	if f.db == "" {
+		return nil, nil, serrors.New("database must be specified either in the " +
+			"configuration file or with the --db flag")
+	}
+	// Opening a non-existing sqlite database creates it, which is not desired
+	// for inspection.
+	if _, err := os.Stat(f.db); err != nil {
+		return nil, nil, serrors.WrapStr("accessing database", err)
+	}
+	return openDB(f.db)
+}
+
+func (f *dbFlags) write(out io.Writer, v interface{}, human func(io.Writer) error) error {
+	if f.format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	return human(out)
+}
+
+func newDB(pather command.Pather) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "db",
+		Short: "Inspect the beacon and path databases of the control service",
+		Long: `'db' lists the beacons and the segments stored by the control service to help
+debugging beaconing issues.
+
+The databases are opened directly, which only works for sqlite databases. The
+database files are read from the beacon_db and path_db sections of the control
+service configuration file. They can be overridden with the --db flag.
+
+With --admin, the entries are queried from the admin API of the running
+control service instead. The address of the admin API and the shared secret
+are read from the admin section of the configuration file, and can be
+overridden with the --addr and --shared-secret flags.
+`,
+	}
+	cmd.AddCommand(
+		newDBBeacons(cmd),
+		newDBSegments(cmd),
+	)
+	return cmd
+}
+
+func newDBBeacons(pather command.Pather) *cobra.Command {
+	var flags dbFlags
+	var cmd = &cobra.Command{
+		Use:   "beacons",
+		Short: "List the beacons in the beacon database",
+		Example: fmt.Sprintf(`  %[1]s beacons --config cs.toml --origin 1-ff00:0:110
+  %[1]s beacons --db cs.beacon.db --interface 2 --format json
+  %[1]s beacons --config cs.toml --admin --expires-within 1h`,
+			pather.CommandPath()),
+		Long: `'beacons' lists the beacons in the beacon database.
+
+By default, expired beacons are not displayed. The --interface flag selects
+the beacons received on the given ingress interface.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter, err := flags.filter()
+			if err != nil {
+				return err
+			}
+			var ingress uint64
+			if flags.iface != "" {
+				if ingress, err = strconv.ParseUint(flags.iface, 10, 16); err != nil {
+					return serrors.WrapStr("parsing interface", err)
+				}
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancelF := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancelF()
+			api, closer, err := flags.open(ctx,
+				func(cfg *config.Config) storage.DBConfig { return cfg.BeaconDB },
+				func(path string) (dbAPI, io.Closer, error) {
+					db, err := beaconsqlite.New(path, 0)
+					if err != nil {
+						return nil, nil, serrors.WrapStr("opening beacon database", err,
+							"file", path)
+					}
+					return admingrpc.AdminServer{BeaconDB: db}, db, nil
+				},
+			)
+			if err != nil {
+				return err
+			}
+			defer closer.Close()
+			rep, err := api.ListBeacons(ctx, &cppb.ListBeaconsRequest{})
+			if err != nil {
+				return serrors.WrapStr("listing beacons", err)
+			}
+			beacons := filterBeacons(rep.Beacons, filter, ingress)
+			return flags.write(cmd.OutOrStdout(), beacons, func(w io.Writer) error {
+				return writeBeacons(w, beacons)
+			})
+		},
+	}
+	flags.register(cmd, "Only display beacons received on this ingress interface")
+	return cmd
+}
+
+func newDBSegments(pather command.Pather) *cobra.Command {
+	var flags dbFlags
+	var cmd = &cobra.Command{
+		Use:   "segments",
+		Short: "List the segments in the path database",
+		Example: fmt.Sprintf(`  %[1]s segments --config cs.toml --origin 1-0
+  %[1]s segments --db cs.path.db --interface 1-ff00:0:110#2 --format json
+  %[1]s segments --config cs.toml --admin --all`,
+			pather.CommandPath()),
+		Long: `'segments' lists the segments in the path database.
+
+By default, expired segments are not displayed. The --interface flag selects
+the segments that traverse the given interface, specified as ISD-AS#IF.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter, err := flags.filter()
+			if err != nil {
+				return err
+			}
+			if flags.iface != "" {
+				if filter.Interface, err = parseDBHop(flags.iface); err != nil {
+					return err
+				}
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancelF := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancelF()
+			api, closer, err := flags.open(ctx,
+				func(cfg *config.Config) storage.DBConfig { return cfg.PathDB },
+				func(path string) (dbAPI, io.Closer, error) {
+					db, err := pathsqlite.New(path)
+					if err != nil {
+						return nil, nil, serrors.WrapStr("opening path database", err,
+							"file", path)
+					}
+					return admingrpc.AdminServer{PathDB: db}, db, nil
+				},
+			)
+			if err != nil {
+				return err
+			}
+			defer closer.Close()
+			rep, err := api.Segments(ctx, &cppb.AdminSegmentsRequest{})
+			if err != nil {
+				return serrors.WrapStr("listing segments", err)
+			}
+			segments := filterSegments(rep.Segments, filter)
+			return flags.write(cmd.OutOrStdout(), segments, func(w io.Writer) error {
+				return writeSegments(w, segments)
+			})
+		},
+	}
+	flags.register(cmd, "Only display segments traversing this interface (ISD-AS#IF)")
+	return cmd
+}
+
+// parseDBHop parses an interface in the ISD-AS#IF format.
+func parseDBHop(s string) (dbHop, error) {
+	rawIA, rawIf, ok := strings.Cut(s, "#")
+	if !ok {
+		return dbHop{}, serrors.New("interface must be of the form ISD-AS#IF", "input", s)
+	}
+	ia, err := addr.ParseIA(rawIA)
+	if err != nil {
+		return dbHop{}, serrors.WrapStr("parsing interface ISD-AS", err, "input", s)
+	}
+	ifID, err := strconv.ParseUint(rawIf, 10, 16)
+	if err != nil {
+		return dbHop{}, serrors.WrapStr("parsing interface ID", err, "input", s)
+	}
+	return dbHop{IA: ia, Interface: ifID}, nil
+}
+
+// filterBeacons converts the beacons that match the filter. If ingress is not
+// zero, only the beacons received on that interface are included.
+func filterBeacons(summaries []*cppb.BeaconSummary, filter dbFilter,
+	ingress uint64) []dbBeacon {
+
+	beacons := []dbBeacon{}
+	for _, s := range summaries {
+		b := dbBeacon{
+			ID:          hex.EncodeToString(s.Id),
+			Origin:      addr.IA(s.StartIsdAs),
+			Ingress:     s.IngressInterfaceId,
+			Hops:        dbHops(s.Interfaces),
+			Usages:      s.Usages,
+			Timestamp:   s.Timestamp.AsTime(),
+			Expiration:  s.Expiration.AsTime(),
+			LastUpdated: s.LastUpdated.AsTime(),
+		}
+		if !filter.matchOrigin(b.Origin) || !filter.matchHops(b.Hops) ||
+			!filter.matchExpiration(b.Expiration) {
+			continue
+		}
+		if ingress != 0 && b.Ingress != ingress {
+			continue
+		}
+		if b.Usages == nil {
+			b.Usages = []string{}
+		}
+		beacons = append(beacons, b)
+	}
+	return beacons
+}
+
+// filterSegments converts the segments that match the filter.
+func filterSegments(summaries []*cppb.SegmentSummary, filter dbFilter) []dbSegment {
+	segments := []dbSegment{}
+	for _, s := range summaries {
+		sg := dbSegment{
+			ID:               hex.EncodeToString(s.Id),
+			Type:             strings.ToLower(strings.TrimPrefix(s.Type.String(), "SEGMENT_TYPE_")),
+			Origin:           addr.IA(s.StartIsdAs),
+			End:              addr.IA(s.EndIsdAs),
+			Hops:             dbHops(s.Interfaces),
+			HiddenPathGroups: s.HiddenPathGroups,
+			Expiration:       s.Expiration.AsTime(),
+			LastUpdated:      s.LastUpdated.AsTime(),
+		}
+		if !filter.matchOrigin(sg.Origin) || !filter.matchHops(sg.Hops) ||
+			!filter.matchExpiration(sg.Expiration) {
+			continue
+		}
+		if sg.HiddenPathGroups == nil {
+			sg.HiddenPathGroups = []uint64{}
+		}
+		segments = append(segments, sg)
+	}
+	return segments
+}
+
+func dbHops(intfs []*cppb.SegmentInterface) []dbHop {
+	hops := make([]dbHop, 0, len(intfs))
+	for _, intf := range intfs {
+		hops = append(hops, dbHop{IA: addr.IA(intf.IsdAs), Interface: intf.InterfaceId})
+	}
+	return hops
+}
+
+func writeBeacons(out io.Writer, beacons []dbBeacon) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tORIGIN\tINGRESS\tUSAGES\tEXPIRATION\tLAST UPDATED\tHOPS")
+	for _, b := range beacons {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n", shortID(b.ID), b.Origin, b.Ingress,
+			strings.Join(b.Usages, ","), formatTime(&b.Expiration),
+			formatTime(&b.LastUpdated), formatHops(b.Hops))
+	}
+	return w.Flush()
+}
+
+func writeSegments(out io.Writer, segments []dbSegment) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tORIGIN\tEND\tEXPIRATION\tLAST UPDATED\tHOPS")
+	for _, s := range segments {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", shortID(s.ID), s.Type, s.Origin,
+			s.End, formatTime(&s.Expiration), formatTime(&s.LastUpdated),
+			formatHops(s.Hops))
+	}
+	return w.Flush()
+}
+
+// shortID shortens a hex encoded ID for the human-readable output.
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+func formatHops(hops []dbHop) string {
+	parts := make([]string, 0, len(hops))
+	for _, hop := range hops {
+		parts = append(parts, hop.String())
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+)
+
+func TestFilterBeacons(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia120 := xtest.MustParseIA("2-ff00:0:120")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	summary := func(id byte, origin uint64, ingress uint64,
+		expiration time.Time) *cppb.BeaconSummary {
+
+		return &cppb.BeaconSummary{
+			Id:                 []byte{id},
+			StartIsdAs:         origin,
+			IngressInterfaceId: ingress,
+			Interfaces: []*cppb.SegmentInterface{
+				{IsdAs: origin, InterfaceId: 5},
+				{IsdAs: uint64(ia111), InterfaceId: ingress},
+			},
+			Usages:      []string{"propagation"},
+			Timestamp:   timestamppb.New(now.Add(-time.Hour)),
+			Expiration:  timestamppb.New(expiration),
+			LastUpdated: timestamppb.New(now),
+		}
+	}
+	summaries := []*cppb.BeaconSummary{
+		summary(1, uint64(ia110), 1, now.Add(time.Hour)),
+		summary(2, uint64(ia120), 2, now.Add(5*time.Hour)),
+		summary(3, uint64(ia110), 2, now.Add(-time.Minute)),
+	}
+	ids := func(beacons []dbBeacon) []string {
+		ids := []string{}
+		for _, b := range beacons {
+			ids = append(ids, b.ID)
+		}
+		return ids
+	}
+
+	testCases := map[string]struct {
+		Filter   dbFilter
+		Ingress  uint64
+		Expected []string
+	}{
+		"unexpired": {
+			Filter:   dbFilter{Now: now},
+			Expected: []string{"01", "02"},
+		},
+		"all": {
+			Filter:   dbFilter{Now: now, All: true},
+			Expected: []string{"01", "02", "03"},
+		},
+		"origin wildcard": {
+			Filter:   dbFilter{Now: now, All: true, Origin: xtest.MustParseIA("1-0")},
+			Expected: []string{"01", "03"},
+		},
+		"ingress": {
+			Filter:   dbFilter{Now: now},
+			Ingress:  2,
+			Expected: []string{"02"},
+		},
+		"expires within": {
+			Filter:   dbFilter{Now: now, ExpiresWithin: 2 * time.Hour},
+			Expected: []string{"01"},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, ids(filterBeacons(summaries, tc.Filter, tc.Ingress)))
+		})
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeBeacons(&buf, filterBeacons(summaries, dbFilter{Now: now}, 0)))
+	assert.Contains(t, buf.String(), "1-ff00:0:110#5 1-ff00:0:111#1")
+	assert.Contains(t, buf.String(), "propagation")
+}
+
+func TestFilterSegments(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	summaries := []*cppb.SegmentSummary{
+		{
+			Id:         []byte{1},
+			Type:       cppb.SegmentType_SEGMENT_TYPE_DOWN,
+			StartIsdAs: uint64(ia110),
+			EndIsdAs:   uint64(ia111),
+			Interfaces: []*cppb.SegmentInterface{
+				{IsdAs: uint64(ia110), InterfaceId: 2},
+				{IsdAs: uint64(ia111), InterfaceId: 3},
+			},
+			Expiration:  timestamppb.New(now.Add(time.Hour)),
+			LastUpdated: timestamppb.New(now),
+		},
+		{
+			Id:          []byte{2},
+			Type:        cppb.SegmentType_SEGMENT_TYPE_UP,
+			StartIsdAs:  uint64(ia110),
+			EndIsdAs:    uint64(ia111),
+			Expiration:  timestamppb.New(now.Add(time.Hour)),
+			LastUpdated: timestamppb.New(now),
+		},
+	}
+	hop, err := parseDBHop("1-ff00:0:111#3")
+	require.NoError(t, err)
+	segments := filterSegments(summaries, dbFilter{Now: now, Interface: hop})
+	require.Len(t, segments, 1)
+	assert.Equal(t, "01", segments[0].ID)
+	assert.Equal(t, "down", segments[0].Type)
+	assert.Equal(t, []uint64{}, segments[0].HiddenPathGroups)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeSegments(&buf, segments))
+	assert.Contains(t, buf.String(), "1-ff00:0:110#2 1-ff00:0:111#3")
+
+	_, err = parseDBHop("1-ff00:0:111")
+	assert.Error(t, err)
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/control/config"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/app/command"
+	libconfig "github.com/scionproto/scion/private/config"
+)
+
+// hiddenPathMembership is the membership of an AS in the hidden path groups as
+// reported by the hidden-paths command.
+type hiddenPathMembership struct {
+	IA         addr.IA              `json:"isd_as"`
+	Readers    []hiddenpath.GroupID `json:"reader_groups"`
+	Writers    []hiddenpath.GroupID `json:"writer_groups"`
+	Registries []addr.IA            `json:"registries"`
+}
+
+func newHiddenPaths(pather command.Pather) *cobra.Command {
+	var flags struct {
+		config string
+		ia     string
+		format string
+	}
+	var cmd = &cobra.Command{
+		Use:   "hidden-paths [location]",
+		Short: "Display which ASes can access the hidden paths of the configured groups",
+		Example: fmt.Sprintf(`  %[1]s hidden-paths --config cs.toml
+  %[1]s hidden-paths hp_groups.yml --isd-as 1-ff00:0:111 --format json`,
+			pather.CommandPath()),
+		Long: `'hidden-paths' loads the hidden path groups and displays for every AS that
+is a reader or a writer in any of the groups, in which groups it is a reader,
+in which groups it is a writer, and the registries it queries or registers
+hidden paths at.
+
+The location of the hidden path groups is read from the path.hidden_paths_cfg
+option of the control service configuration file. It can be overridden with
+the location argument, which accepts the same formats as the option.
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch flags.format {
+			case "human", "json":
+			default:
+				return serrors.New("format not supported", "format", flags.format)
+			}
+			var filter addr.IA
+			if flags.ia != "" {
+				var err error
+				if filter, err = addr.ParseIA(flags.ia); err != nil {
+					return serrors.WrapStr("parsing ISD-AS", err)
+				}
+			}
+			var location string
+			if len(args) == 1 {
+				location = args[0]
+			} else if flags.config != "" {
+				var cfg config.Config
+				if err := libconfig.LoadFile(flags.config, &cfg); err != nil {
+					return serrors.WrapStr("loading config from file", err,
+						"file", flags.config)
+				}
+				location = cfg.PS.HiddenPathsCfg
+			}
+			if location == "" {
+				return serrors.New("location of the hidden path groups must be specified " +
+					"either in the configuration file or as argument")
+			}
+			cmd.SilenceUsage = true
+
+			groups, err := hiddenpath.LoadHiddenPathGroups(location)
+			if err != nil {
+				return serrors.WrapStr("loading hidden path groups", err)
+			}
+			memberships := hiddenPathMemberships(groups, filter)
+			if flags.format == "json" {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(memberships)
+			}
+			return writeHiddenPathMemberships(cmd.OutOrStdout(), memberships)
+		},
+	}
+	cmd.Flags().StringVar(&flags.config, "config", "",
+		"Configuration file of the control service")
+	cmd.Flags().StringVar(&flags.ia, "isd-as", "",
+		"Only display the membership of this ISD-AS")
+	cmd.Flags().StringVar(&flags.format, "format", "human",
+		"Specify the output format (human|json)")
+	return cmd
+}
+
+// hiddenPathMemberships returns the memberships in the groups. If filter is
+// not zero, only the membership of the filter AS is returned.
+func hiddenPathMemberships(groups hiddenpath.Groups,
+	filter addr.IA) []hiddenPathMembership {
+
+	memberships := []hiddenPathMembership{}
+	for _, m := range groups.Memberships() {
+		if !filter.IsZero() && m.IA != filter {
+			continue
+		}
+		// Empty lists are encoded as [] instead of null in JSON.
+		membership := hiddenPathMembership{
+			IA:         m.IA,
+			Readers:    []hiddenpath.GroupID{},
+			Writers:    []hiddenpath.GroupID{},
+			Registries: []addr.IA{},
+		}
+		membership.Readers = append(membership.Readers, m.Readers...)
+		membership.Writers = append(membership.Writers, m.Writers...)
+		membership.Registries = append(membership.Registries, m.Registries...)
+		memberships = append(memberships, membership)
+	}
+	return memberships
+}
+
+func writeHiddenPathMemberships(out io.Writer, memberships []hiddenPathMembership) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ISD-AS\tREADER GROUPS\tWRITER GROUPS\tREGISTRIES")
+	for _, m := range memberships {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.IA, joinGroupIDs(m.Readers),
+			joinGroupIDs(m.Writers), joinIAs(m.Registries))
+	}
+	return w.Flush()
+}
+
+func joinGroupIDs(ids []hiddenpath.GroupID) string {
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s = append(s, id.String())
+	}
+	return joinList(s)
+}
+
+func joinIAs(ias []addr.IA) string {
+	s := make([]string, 0, len(ias))
+	for _, ia := range ias {
+		s = append(s, ia.String())
+	}
+	return joinList(s)
+}
+
+func joinList(list []string) string {
+	if len(list) == 0 {
+		return "-"
+	}
+	return strings.Join(list, ",")
+}
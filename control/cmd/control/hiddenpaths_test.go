@@ -0,0 +1,90 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/app/command"
+)
+
+const hiddenPathGroups = `
+groups:
+  ff00:0:110-69b5:
+    owner: 1-ff00:0:110
+    writers:
+    - 1-ff00:0:111
+    readers:
+    - 1-ff00:0:114
+    registries:
+    - 1-ff00:0:113
+  ff00:0:110-abcd:
+    owner: 1-ff00:0:110
+    writers:
+    - 1-ff00:0:112
+    readers:
+    - 1-ff00:0:114
+    registries:
+    - 1-ff00:0:115
+`
+
+func TestHiddenPaths(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "groups.yml")
+	require.NoError(t, os.WriteFile(file, []byte(hiddenPathGroups), 0644))
+
+	run := func(t *testing.T, args ...string) string {
+		cmd := newHiddenPaths(command.StringPather("control"))
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs(args)
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	t.Run("human", func(t *testing.T) {
+		expected := "" +
+			"ISD-AS        READER GROUPS                    WRITER GROUPS    REGISTRIES\n" +
+			"1-ff00:0:111  -                                ff00:0:110-69b5  1-ff00:0:113\n" +
+			"1-ff00:0:112  -                                ff00:0:110-abcd  1-ff00:0:115\n" +
+			"1-ff00:0:114  ff00:0:110-69b5,ff00:0:110-abcd  -                " +
+			"1-ff00:0:113,1-ff00:0:115\n"
+		assert.Equal(t, expected, run(t, file))
+	})
+	t.Run("json filtered", func(t *testing.T) {
+		var memberships []map[string]interface{}
+		out := run(t, file, "--isd-as", "1-ff00:0:111", "--format", "json")
+		require.NoError(t, json.Unmarshal([]byte(out), &memberships))
+		assert.Equal(t, []map[string]interface{}{{
+			"isd_as":        "1-ff00:0:111",
+			"reader_groups": []interface{}{},
+			"writer_groups": []interface{}{"ff00:0:110-69b5"},
+			"registries":    []interface{}{"1-ff00:0:113"},
+		}}, memberships)
+	})
+	t.Run("no location", func(t *testing.T) {
+		cmd := newHiddenPaths(command.StringPather("control"))
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(nil)
+		assert.Error(t, cmd.Execute())
+	})
+}
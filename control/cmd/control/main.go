@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"path/filepath"
@@ -36,15 +37,21 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	cs "github.com/scionproto/scion/control"
+	admingrpc "github.com/scionproto/scion/control/admin/grpc"
 	"github.com/scionproto/scion/control/beacon"
 	"github.com/scionproto/scion/control/beaconing"
 	beaconinggrpc "github.com/scionproto/scion/control/beaconing/grpc"
+	"github.com/scionproto/scion/control/colibri"
+	colibrigrpc "github.com/scionproto/scion/control/colibri/grpc"
 	"github.com/scionproto/scion/control/config"
 	"github.com/scionproto/scion/control/drkey"
 	drkeygrpc "github.com/scionproto/scion/control/drkey/grpc"
 	"github.com/scionproto/scion/control/ifstate"
+	ifstategrpc "github.com/scionproto/scion/control/ifstate/grpc"
+	"github.com/scionproto/scion/control/lease"
 	api "github.com/scionproto/scion/control/mgmtapi"
 	"github.com/scionproto/scion/control/onehop"
 	segreggrpc "github.com/scionproto/scion/control/segreg/grpc"
@@ -54,6 +61,7 @@ import (
 	cstrustgrpc "github.com/scionproto/scion/control/trust/grpc"
 	cstrustmetrics "github.com/scionproto/scion/control/trust/metrics"
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
 	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
 	libmetrics "github.com/scionproto/scion/pkg/metrics"
@@ -80,10 +88,12 @@ import (
 	"github.com/scionproto/scion/private/mgmtapi/jwtauth"
 	segapi "github.com/scionproto/scion/private/mgmtapi/segments/api"
 	"github.com/scionproto/scion/private/periodic"
+	"github.com/scionproto/scion/private/ratelimit"
 	segfetchergrpc "github.com/scionproto/scion/private/segment/segfetcher/grpc"
 	"github.com/scionproto/scion/private/segment/seghandler"
 	"github.com/scionproto/scion/private/service"
 	"github.com/scionproto/scion/private/storage"
+	beaconstorage "github.com/scionproto/scion/private/storage/beacon"
 	beaconstoragemetrics "github.com/scionproto/scion/private/storage/beacon/metrics"
 	"github.com/scionproto/scion/private/storage/drkey/level1"
 	"github.com/scionproto/scion/private/storage/drkey/secret"
@@ -105,7 +115,12 @@ func main() {
 		ShortName:  "SCION Control Service",
 		// TODO(scrye): Deprecated additional sampler, remove once Anapaya/scion#5000 is in.
 		Samplers: []func(command.Pather) *cobra.Command{newSamplePolicy},
-		Main:     realMain,
+		Commands: []func(command.Pather) *cobra.Command{newStatus, newHiddenPaths, newDB},
+		Validate: func(context.Context) error { return cs.ValidateConfig(&globalCfg) },
+		ShutdownTimeout: func() time.Duration {
+			return globalCfg.General.ShutdownTimeout.Duration
+		},
+		Main: realMain,
 	}
 	application.Run()
 }
@@ -123,11 +138,18 @@ func realMain(ctx context.Context) error {
 		return serrors.WrapStr("creating topology loader", err)
 	}
 	g, errCtx := errgroup.WithContext(ctx)
+	// shutdownCtx bounds the time spent on finishing the work in progress
+	// once the service is shutting down.
+	shutdownCtx, cancelShutdown := app.WithShutdownTimeout(errCtx,
+		globalCfg.General.ShutdownTimeout.Duration)
+	defer cancelShutdown()
 	g.Go(func() error {
 		defer log.HandlePanic()
 		return topo.Run(errCtx)
 	})
-	intfs := ifstate.NewInterfaces(adaptInterfaceMap(topo.InterfaceInfoMap()), ifstate.Config{})
+	intfs := ifstate.NewInterfaces(adaptInterfaceMap(topo.InterfaceInfoMap()), ifstate.Config{
+		LivenessTimeout: globalCfg.BS.LivenessTimeout.Duration,
+	})
 	g.Go(func() error {
 		defer log.HandlePanic()
 		sub := topo.Subscribe()
@@ -142,7 +164,7 @@ func realMain(ctx context.Context) error {
 		}
 	})
 
-	closer, err := cs.InitTracer(globalCfg.Tracing, globalCfg.General.ID)
+	closer, err := globalCfg.Tracing.InitTracer(globalCfg.General.ID)
 	if err != nil {
 		return serrors.WrapStr("initializing tracer", err)
 	}
@@ -160,7 +182,8 @@ func realMain(ctx context.Context) error {
 	})
 	defer pathDB.Close()
 
-	macGen, err := cs.MACGenFactory(globalCfg.General.ConfigDir)
+	macGen, err := cs.MACGenFactory(globalCfg.General.ConfigDir,
+		globalCfg.BS.HopFieldKeyRotation.Duration, globalCfg.BS.HopFieldMACAlgorithms())
 	if err != nil {
 		return err
 	}
@@ -198,6 +221,19 @@ func realMain(ctx context.Context) error {
 	if err := cs.LoadTrustMaterial(ctx, globalCfg.General.ConfigDir, trustDB); err != nil {
 		return err
 	}
+	revocations := &trust.RevocationStore{DB: trustDB}
+	if err := cs.LoadRevocations(ctx, globalCfg.General.ConfigDir, revocations); err != nil {
+		return err
+	}
+	tlsVerifier := trust.NewTLSCryptoVerifier(trustDB)
+	tlsVerifier.Revocations = revocations
+
+	keyRings, err := cs.NewKeyRings(globalCfg.Keys, globalCfg.General.ConfigDir)
+	if err != nil {
+		return serrors.WrapStr("initializing key rings", err)
+	}
+	defer keyRings.Close()
+	signer := cs.NewSigner(topo.IA(), trustDB, globalCfg.General.ConfigDir, keyRings.AS)
 
 	// FIXME: readability would be improved if we could be consistent with address
 	// representations in NetworkConfig (string or cooked, chose one).
@@ -211,18 +247,24 @@ func realMain(ctx context.Context) error {
 			// configured, QUICStack() uses the same IP and port as
 			// for the public address.
 			Address:     globalCfg.QUIC.Address,
-			TLSVerifier: trust.NewTLSCryptoVerifier(trustDB),
+			TLSVerifier: tlsVerifier,
 			GetCertificate: cs.NewTLSCertificateLoader(
 				topo.IA(), x509.ExtKeyUsageServerAuth, trustDB, globalCfg.General.ConfigDir,
+				keyRings.AS,
 			).GetCertificate,
 			GetClientCertificate: cs.NewTLSCertificateLoader(
 				topo.IA(), x509.ExtKeyUsageClientAuth, trustDB, globalCfg.General.ConfigDir,
+				keyRings.AS,
 			).GetClientCertificate,
 		},
-		SVCResolver: topo,
+		SVCResolver: &infraenv.SVCBalancer{Instances: topo.UnderlayMulticast},
 		SCMPHandler: snet.DefaultSCMPHandler{
-			RevocationHandler: cs.RevocationHandler{RevCache: revCache},
-			SCMPErrors:        metrics.SCMPErrors,
+			RevocationHandler: cs.RevocationHandler{
+				RevCache: revCache,
+				IA:       topo.IA(),
+				Signer:   signer,
+			},
+			SCMPErrors: metrics.SCMPErrors,
 		},
 		SCIONNetworkMetrics:    metrics.SCIONNetworkMetrics,
 		SCIONPacketConnMetrics: metrics.SCIONPacketConnMetrics,
@@ -240,12 +282,17 @@ func realMain(ctx context.Context) error {
 	dialer := &libgrpc.QUICDialer{
 		Rewriter: &onehop.AddressRewriter{
 			Rewriter: nc.AddressRewriter(nil),
-			MAC:      macGen(),
+			MAC:      macGen,
 		},
 		Dialer: quicStack.InsecureDialer,
 	}
 
-	beaconDB, err := storage.NewBeaconStorage(globalCfg.BeaconDB, topo.IA())
+	beaconDB, err := storage.NewBeaconStorage(globalCfg.BeaconDB, topo.IA(),
+		beaconstorage.Limits{
+			MaxPerOrigin: globalCfg.BS.MaxBeaconsPerOrigin,
+			MaxTotal:     globalCfg.BS.MaxBeacons,
+		},
+	)
 	if err != nil {
 		return serrors.WrapStr("initializing beacon storage", err)
 	}
@@ -274,19 +321,22 @@ func realMain(ctx context.Context) error {
 		MaxCacheExpiration: globalCfg.TrustEngine.Cache.Expiration.Duration,
 		Cache:              trustengineCache,
 	}
+	trustFetcher := trustgrpc.Fetcher{
+		IA:       topo.IA(),
+		Dialer:   dialer,
+		Requests: libmetrics.NewPromCounter(trustmetrics.RPC.Fetches),
+	}
 	provider := trust.FetchingProvider{
-		DB: trustDB,
-		Fetcher: trustgrpc.Fetcher{
-			IA:       topo.IA(),
-			Dialer:   dialer,
-			Requests: libmetrics.NewPromCounter(trustmetrics.RPC.Fetches),
-		},
+		DB:       trustDB,
+		Fetcher:  trustFetcher,
 		Recurser: trust.ASLocalRecurser{IA: topo.IA()},
 		// XXX(roosd): cyclic dependency on router. It is set below.
+		TRCDiscovery: &trust.TRCDiscoveryLimiter{},
 	}
 	verifier := compat.Verifier{
 		Verifier: trust.Verifier{
 			Engine:             provider,
+			Revocations:        revocations,
 			CacheHits:          cacheHits,
 			MaxCacheExpiration: globalCfg.TrustEngine.Cache.Expiration.Duration,
 			Cache:              trustengineCache,
@@ -301,32 +351,80 @@ func realMain(ctx context.Context) error {
 		RevCache:      revCache,
 		QueryInterval: globalCfg.PS.QueryInterval.Duration,
 		RPC: &segfetchergrpc.Requester{
-			Dialer: dialer,
+			Dialer:      dialer,
+			PageSize:    globalCfg.PS.SegmentPageSize,
+			Compression: globalCfg.PS.SegmentLookupCompression,
 		},
 		Inspector: inspector,
 		Verifier:  verifier,
 	}
+	segRouter := segreq.NewRouter(fetcherCfg)
 	provider.Router = trust.AuthRouter{
 		ISD:    topo.IA().ISD(),
 		DB:     trustDB,
-		Router: segreq.NewRouter(fetcherCfg),
+		Router: segRouter,
 	}
 
+	rpcLimiter := &ratelimit.GRPCLimiter{
+		Services: []string{
+			"proto.control_plane.v1.SegmentLookupService",
+			"proto.control_plane.v1.SegmentRegistrationService",
+			"proto.control_plane.v1.TrustMaterialService",
+		},
+		PerClient: &ratelimit.Limiter{
+			Rate:  globalCfg.RPCLimits.ClientRate,
+			Burst: globalCfg.RPCLimits.ClientBurst,
+		},
+		Global: &ratelimit.Limiter{
+			Rate:  globalCfg.RPCLimits.GlobalRate,
+			Burst: globalCfg.RPCLimits.GlobalBurst,
+		},
+		Rejected: libmetrics.NewPromCounter(metrics.RPCRateLimitedTotal),
+	}
 	quicServer := grpc.NewServer(
 		grpc.Creds(libgrpc.PassThroughCredentials{}),
 		libgrpc.UnaryServerInterceptor(),
+		grpc.ChainUnaryInterceptor(rpcLimiter.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(rpcLimiter.StreamServerInterceptor()),
+		grpc.MaxRecvMsgSize(globalCfg.RPCLimits.MaxRequestSize),
 		libgrpc.DefaultMaxConcurrentStreams(),
 	)
-	tcpServer := grpc.NewServer(
+	intraASTLS := infraenv.IntraASTLS{
+		Config: globalCfg.GRPCTLS,
+		IA:     topo.IA(),
+		GetCertificate: cs.NewTLSCertificateLoader(
+			topo.IA(), x509.ExtKeyUsageServerAuth, trustDB, globalCfg.General.ConfigDir,
+			keyRings.AS,
+		).GetCertificate,
+		GetClientCertificate: cs.NewTLSCertificateLoader(
+			topo.IA(), x509.ExtKeyUsageClientAuth, trustDB, globalCfg.General.ConfigDir,
+			keyRings.AS,
+		).GetClientCertificate,
+		TLSVerifier: tlsVerifier,
+	}
+	tcpCreds, err := intraASTLS.ServerCredentials()
+	if err != nil {
+		return serrors.WrapStr("initializing TLS for gRPC/TCP API", err)
+	}
+	tcpServerOpts := []grpc.ServerOption{
 		libgrpc.UnaryServerInterceptor(),
+		grpc.ChainUnaryInterceptor(rpcLimiter.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(rpcLimiter.StreamServerInterceptor()),
+		grpc.MaxRecvMsgSize(globalCfg.RPCLimits.MaxRequestSize),
 		libgrpc.DefaultMaxConcurrentStreams(),
-	)
+	}
+	if tcpCreds != nil {
+		log.Info("Mutual TLS enabled for gRPC/TCP API")
+		tcpServerOpts = append(tcpServerOpts, grpc.Creds(tcpCreds))
+	}
+	tcpServer := grpc.NewServer(tcpServerOpts...)
 
 	// Register trust material related handlers.
 	trustServer := &cstrustgrpc.MaterialServer{
-		Provider: provider,
-		IA:       topo.IA(),
-		Requests: libmetrics.NewPromCounter(cstrustmetrics.Handler.Requests),
+		Provider:    provider,
+		IA:          topo.IA(),
+		Revocations: revocations,
+		Requests:    libmetrics.NewPromCounter(cstrustmetrics.Handler.Requests),
 	}
 	cppb.RegisterTrustMaterialServiceServer(quicServer, trustServer)
 	cppb.RegisterTrustMaterialServiceServer(tcpServer, trustServer)
@@ -342,7 +440,54 @@ func realMain(ctx context.Context) error {
 		},
 	})
 
+	// Handle interface liveness.
+	if globalCfg.BS.InterfaceLiveness {
+		livenessInterval := globalCfg.BS.LivenessInterval.Duration
+		cppb.RegisterInterfaceLivenessServiceServer(quicServer, ifstategrpc.LivenessServer{
+			Handler: ifstate.LivenessHandler{
+				Interfaces: intfs,
+				MaxAge:     globalCfg.BS.LivenessTimeout.Duration,
+			},
+			Verifier: verifier,
+		})
+		livenessRPC := &ifstategrpc.LivenessSender{Dialer: dialer, Signer: signer}
+		defer livenessRPC.Close()
+		livenessSender := periodic.Start(
+			&ifstate.LivenessSender{
+				Interfaces: intfs,
+				RPC:        livenessRPC,
+			},
+			livenessInterval,
+			livenessInterval,
+		)
+		defer livenessSender.Kill()
+		livenessRevoker := periodic.Start(
+			&ifstate.LivenessRevoker{
+				Interfaces: intfs,
+				IA:         topo.IA(),
+				Revoker: cs.RevocationHandler{
+					RevCache: revCache,
+					IA:       topo.IA(),
+					Signer:   signer,
+				},
+				GracePeriod: globalCfg.BS.LivenessTimeout.Duration,
+			},
+			livenessInterval,
+			livenessInterval,
+		)
+		defer livenessRevoker.Kill()
+		log.Info("Interface liveness enabled", "interval", livenessInterval,
+			"timeout", globalCfg.BS.LivenessTimeout.Duration)
+	}
+
 	// Handle segment lookup
+	var lookupACL segreq.LookupACL
+	if globalCfg.PS.SegmentLookupACL != "" && topo.Core() {
+		if lookupACL, err = segreq.LoadLookupACL(globalCfg.PS.SegmentLookupACL); err != nil {
+			return serrors.WrapStr("loading segment lookup ACL", err)
+		}
+		log.Info("Segment lookup ACL loaded", "destinations", len(lookupACL))
+	}
 	authLookupServer := &segreqgrpc.LookupServer{
 		Lookuper: segreq.AuthoritativeLookup{
 			LocalIA:     topo.IA(),
@@ -350,6 +495,9 @@ func realMain(ctx context.Context) error {
 			PathDB:      pathDB,
 		},
 		RevCache:     revCache,
+		MinValidity:  globalCfg.PS.MinSegmentValidity.Duration,
+		MaxPageSize:  globalCfg.PS.MaxSegmentPageSize,
+		ACL:          lookupACL,
 		Requests:     libmetrics.NewPromCounter(metrics.SegmentLookupRequestsTotal),
 		SegmentsSent: libmetrics.NewPromCounter(metrics.SegmentLookupSegmentsSentTotal),
 	}
@@ -366,6 +514,8 @@ func realMain(ctx context.Context) error {
 			},
 		},
 		RevCache:     revCache,
+		MinValidity:  globalCfg.PS.MinSegmentValidity.Duration,
+		MaxPageSize:  globalCfg.PS.MaxSegmentPageSize,
 		Requests:     libmetrics.NewPromCounter(metrics.SegmentLookupRequestsTotal),
 		SegmentsSent: libmetrics.NewPromCounter(metrics.SegmentLookupSegmentsSentTotal),
 	}
@@ -394,8 +544,6 @@ func realMain(ctx context.Context) error {
 
 	}
 
-	signer := cs.NewSigner(topo.IA(), trustDB, globalCfg.General.ConfigDir)
-
 	var chainBuilder renewal.ChainBuilder
 	var caClient *caapi.Client
 	var caHealthCached *cachedCAHealth
@@ -427,6 +575,7 @@ func realMain(ctx context.Context) error {
 					DB:                   trustDB,
 					MaxValidity:          globalCfg.CA.MaxASValidity.Duration,
 					ConfigDir:            globalCfg.General.ConfigDir,
+					KeyRing:              keyRings.CA,
 					Metrics:              metrics.RenewalMetrics,
 					ForceECDSAWithSHA512: !globalCfg.Features.AppropriateDigest,
 				},
@@ -561,6 +710,60 @@ func realMain(ctx context.Context) error {
 	)
 	trcRunner.TriggerRun()
 
+	periodic.Start(
+		periodic.Func{
+			TaskName: "crl loader",
+			Task: func(ctx context.Context) {
+				err := cs.LoadRevocations(ctx, globalCfg.General.ConfigDir, revocations)
+				if err != nil {
+					log.Info("Failed to load CRLs from disk", "err", err)
+				}
+			},
+		},
+		30*time.Second,
+		5*time.Second,
+	)
+	crlUpdater := trust.CRLUpdater{
+		DB:      trustDB,
+		Store:   revocations,
+		Fetcher: trustFetcher,
+		Router:  provider.Router,
+	}
+	periodic.Start(
+		periodic.Func{
+			TaskName: "crl updater",
+			Task: func(ctx context.Context) {
+				if _, err := crlUpdater.Update(ctx); err != nil {
+					log.Info("Failed to fetch CRLs", "err", err)
+				}
+			},
+		},
+		time.Minute,
+		30*time.Second,
+	)
+
+	if globalCfg.Renewal.Enabled {
+		periodic.Start(
+			&cstrust.ChainRenewer{
+				IA:        topo.IA(),
+				SignerGen: signer.SignerGen,
+				DB:        trustDB,
+				Dir:       filepath.Join(globalCfg.General.ConfigDir, "crypto/as"),
+				Requester: renewalgrpc.ChainRequester{
+					IA:     topo.IA(),
+					Dialer: dialer,
+					Router: segRouter,
+				},
+				LeadTime: globalCfg.Renewal.LeadTime.Duration,
+				Renewals: libmetrics.NewPromCounter(metrics.RenewalClientRenewalsTotal),
+			},
+			time.Minute,
+			30*time.Second,
+		)
+		log.Info("Automatic AS certificate renewal enabled",
+			"lead_time", globalCfg.Renewal.LeadTime)
+	}
+
 	ds := discovery.Topology{
 		Information: topo,
 		Requests:    libmetrics.NewPromCounter(metrics.DiscoveryRequestsTotal),
@@ -570,16 +773,47 @@ func realMain(ctx context.Context) error {
 	dsHealth := health.NewServer()
 	dsHealth.SetServingStatus("discovery", healthpb.HealthCheckResponse_SERVING)
 	healthpb.RegisterHealthServer(tcpServer, dsHealth)
+	readiness := cs.NewHealth(topo, beaconDB, pathDB)
+	g.Go(func() error {
+		defer log.HandlePanic()
+		readiness.UpdateGRPC(errCtx, dsHealth, 0)
+		return nil
+	})
 
+	hpAuditor, closeHPAuditor, err := cs.NewHiddenPathAuditor(
+		globalCfg.PS.HiddenPathsAuditFile,
+		globalCfg.PS.HiddenPathsAuditSyslog,
+	)
+	if err != nil {
+		return serrors.WrapStr("initializing hidden path audit log", err)
+	}
+	defer closeHPAuditor()
+	// The statistics of the hidden path servers are exposed by the admin API.
+	hpStats := &hiddenpath.StatsAuditor{}
+	var hpAuditorWithStats hiddenpath.Auditor = hpStats
+	if hpAuditor != nil {
+		hpAuditorWithStats = hiddenpath.MultiAuditor{hpAuditor, hpStats}
+	}
 	hpCfg := cs.HiddenPathConfigurator{
-		LocalIA:           topo.IA(),
-		Verifier:          verifier,
-		Signer:            signer,
-		PathDB:            pathDB,
-		Dialer:            dialer,
-		FetcherConfig:     fetcherCfg,
-		IntraASTCPServer:  tcpServer,
-		InterASQUICServer: quicServer,
+		LocalIA:            topo.IA(),
+		Verifier:           verifier,
+		Signer:             signer,
+		PathDB:             pathDB,
+		Dialer:             dialer,
+		FetcherConfig:      fetcherCfg,
+		IntraASTCPServer:   tcpServer,
+		InterASQUICServer:  quicServer,
+		Auditor:            hpAuditorWithStats,
+		Stats:              hpStats,
+		Federation:         globalCfg.PS.HiddenPathsFederation,
+		FederationCacheTTL: globalCfg.PS.HiddenPathsFederationCacheTTL.Duration,
+		RegistryFailover: globalCfg.PS.HiddenPathsRegistrySelection ==
+			config.RegistrySelectionFailover,
+		RegistryState:         globalCfg.PS.HiddenPathsRegistryState,
+		RegistryProbeInterval: globalCfg.PS.HiddenPathsRegistryProbeInterval.Duration,
+		SegmentQuota:          globalCfg.PS.HiddenPathsSegmentQuota,
+		SegmentEvictions: libmetrics.NewPromCounter(
+			metrics.HiddenPathSegmentEvictionsTotal),
 	}
 	hpWriterCfg, err := hpCfg.Setup(globalCfg.PS.HiddenPathsCfg)
 	if err != nil {
@@ -663,6 +897,16 @@ func realMain(ctx context.Context) error {
 		log.Info("DRKey is DISABLED by configuration")
 	}
 
+	if globalCfg.Colibri.Enabled {
+		cppb.RegisterColibriServiceServer(tcpServer, colibrigrpc.Server{
+			Admitter: &colibri.Store{
+				LocalIA:  topo.IA(),
+				Capacity: globalCfg.Colibri.InterfaceCapacity,
+			},
+		})
+		log.Info("COLIBRI reservation service is enabled (EXPERIMENTAL)")
+	}
+
 	promgrpc.Register(quicServer)
 	promgrpc.Register(tcpServer)
 
@@ -674,7 +918,7 @@ func realMain(ctx context.Context) error {
 		}
 		return nil
 	})
-	cleanup.Add(func() error { quicServer.GracefulStop(); return nil })
+	cleanup.Add(func() error { libgrpc.GracefulStop(shutdownCtx, quicServer); return nil })
 	g.Go(func() error {
 		defer log.HandlePanic()
 		if err := tcpServer.Serve(tcpStack); err != nil {
@@ -682,7 +926,7 @@ func realMain(ctx context.Context) error {
 		}
 		return nil
 	})
-	cleanup.Add(func() error { tcpServer.GracefulStop(); return nil })
+	cleanup.Add(func() error { libgrpc.GracefulStop(shutdownCtx, tcpServer); return nil })
 
 	if globalCfg.API.Addr != "" {
 		r := chi.NewRouter()
@@ -726,21 +970,88 @@ func realMain(ctx context.Context) error {
 		})
 		cleanup.Add(s.Close)
 	}
+	regStats := &beaconing.RegistrationStats{}
+	if globalCfg.Admin.Addr != "" {
+		adminAuth := &jwtauth.GRPCVerifier{
+			Generator: caconfig.NewPEMSymmetricKey(globalCfg.Admin.SharedSecret).Get,
+			Logger:    log.New("component", "admin_api"),
+		}
+		adminServer := grpc.NewServer(
+			libgrpc.UnaryServerInterceptor(),
+			grpc.ChainUnaryInterceptor(adminAuth.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(adminAuth.StreamServerInterceptor()),
+		)
+		cppb.RegisterAdminServiceServer(adminServer, admingrpc.AdminServer{
+			BeaconDB:    beaconDB,
+			PathDB:      pathDB,
+			TrustDB:     trustDB,
+			HiddenPaths: hpStats,
+			RegStats:    regStats,
+		})
+		reflection.Register(adminServer)
+		adminListener, err := net.Listen("tcp", globalCfg.Admin.Addr)
+		if err != nil {
+			return serrors.WrapStr("listening on admin address", err,
+				"addr", globalCfg.Admin.Addr)
+		}
+		log.Info("Exposing admin API", "addr", globalCfg.Admin.Addr)
+		g.Go(func() error {
+			defer log.HandlePanic()
+			if err := adminServer.Serve(adminListener); err != nil {
+				return serrors.WrapStr("serving admin API", err)
+			}
+			return nil
+		})
+		cleanup.Add(func() error { libgrpc.GracefulStop(shutdownCtx, adminServer); return nil })
+	}
 	err = cs.RegisterHTTPEndpoints(
 		globalCfg.General.ID,
 		&globalCfg,
 		signer,
 		chainBuilder,
 		topo,
+		readiness,
 	)
 	if err != nil {
 		return err
 	}
 
-	staticInfo, err := beaconing.ParseStaticInfoCfg(globalCfg.General.StaticInfoConfig())
-	if err != nil {
+	staticInfo := &beaconing.StaticInfoLoader{File: globalCfg.General.StaticInfoConfig()}
+	if err := staticInfo.Load(); err != nil {
 		log.Info("No static info file found. Static info settings disabled.", "err", err)
 	}
+	staticInfoReload := app.SIGHUPChannel(ctx)
+	g.Go(func() error {
+		defer log.HandlePanic()
+		staticInfo.Run(errCtx, staticInfoReload)
+		return nil
+	})
+
+	var registrationFilter func() *beaconing.RegistrationFilter
+	if file := globalCfg.BS.Policies.RegistrationFilter; file != "" {
+		loader := &beaconing.RegistrationFilterLoader{File: file}
+		if err := loader.Load(); err != nil {
+			return serrors.WrapStr("loading registration filter", err)
+		}
+		reload := app.SIGHUPChannel(ctx)
+		g.Go(func() error {
+			defer log.HandlePanic()
+			loader.Run(errCtx, reload)
+			return nil
+		})
+		registrationFilter = loader.Get
+	}
+
+	var remoteCoreCfg *cs.RemoteCoreRegistrationCfg
+	if rc := globalCfg.BS.RemoteCoreRegistration; len(rc.Remotes) > 0 {
+		remoteCoreCfg = &cs.RemoteCoreRegistrationCfg{
+			Remotes:        rc.Remotes,
+			Interval:       rc.Interval.Duration,
+			Parallelism:    rc.Parallelism,
+			InitialBackoff: rc.InitialBackoff.Duration,
+			MaxBackoff:     rc.MaxBackoff.Duration,
+		}
+	}
 
 	var propagationFilter func(intf *ifstate.Interface) bool
 	if topo.Core() {
@@ -759,6 +1070,34 @@ func realMain(ctx context.Context) error {
 		topoInfo := intf.TopoInfo()
 		return topoInfo.LinkType == topology.Core || topoInfo.LinkType == topology.Child
 	}
+	if globalCfg.BS.InterfaceLiveness {
+		propagationFilter = aliveFilter(propagationFilter)
+		originationFilter = aliveFilter(originationFilter)
+	}
+
+	var csLease *lease.Lease
+	if globalCfg.Lease.Enabled {
+		leaseCreds, err := intraASTLS.ClientCredentials()
+		if err != nil {
+			return serrors.WrapStr("initializing TLS for control service lease", err)
+		}
+		csLease = &lease.Lease{
+			Peers: func() []lease.Peer {
+				var peers []lease.Peer
+				for _, name := range topo.Get().SVCNames(addr.SvcCS) {
+					if a := topo.ControlServiceAddress(name); a != nil {
+						peers = append(peers, lease.Peer{Name: name, Addr: a})
+					}
+				}
+				return lease.PrecedingPeers(globalCfg.General.ID, peers)
+			},
+			Checker: lease.HealthChecker{
+				Dialer: &libgrpc.TCPDialer{Credentials: leaseCreds},
+			},
+			Duration: globalCfg.Lease.Duration.Duration,
+		}
+		log.Info("Control service lease enabled")
+	}
 
 	tasks, err := cs.StartTasks(cs.TasksConfig{
 		IA:            topo.IA(),
@@ -767,10 +1106,10 @@ func realMain(ctx context.Context) error {
 		Public:        nc.Public,
 		AllInterfaces: intfs,
 		PropagationInterfaces: func() []*ifstate.Interface {
-			return intfs.Filtered(propagationFilter)
+			return intfs.Filtered(cs.NotRevoked(revCache, topo.IA(), propagationFilter))
 		},
 		OriginationInterfaces: func() []*ifstate.Interface {
-			return intfs.Filtered(originationFilter)
+			return intfs.Filtered(cs.NotRevoked(revCache, topo.IA(), originationFilter))
 		},
 		TrustDB:  trustDB,
 		PathDB:   pathDB,
@@ -778,23 +1117,31 @@ func realMain(ctx context.Context) error {
 		BeaconSenderFactory: &beaconinggrpc.BeaconSenderFactory{
 			Dialer: dialer,
 		},
-		SegmentRegister: beaconinggrpc.Registrar{Dialer: dialer},
-		BeaconStore:     beaconStore,
-		SignerGen:       signer.SignerGen,
-		Inspector:       inspector,
-		Metrics:         metrics,
-		DRKeyEngine:     drkeyEngine,
-		MACGen:          macGen,
-		NextHopper:      topo,
-		StaticInfo:      func() *beaconing.StaticInfoCfg { return staticInfo },
+		SegmentRegister:    beaconinggrpc.Registrar{Dialer: dialer},
+		BeaconStore:        beaconStore,
+		SignerGen:          signer.SignerGen,
+		Inspector:          inspector,
+		Metrics:            metrics,
+		DRKeyEngine:        drkeyEngine,
+		MACGen:             macGen,
+		NextHopper:         topo,
+		StaticInfo:         staticInfo.Get,
+		RegistrationFilter: registrationFilter,
+		RegistrationStats:  regStats,
 
 		OriginationInterval:       globalCfg.BS.OriginationInterval.Duration,
 		PropagationInterval:       globalCfg.BS.PropagationInterval.Duration,
 		RegistrationInterval:      globalCfg.BS.RegistrationInterval.Duration,
+		RegistrationJitter:        globalCfg.BS.RegistrationJitter.Duration,
+		SegmentLifetime:           globalCfg.BS.SegmentLifetime.Duration,
 		DRKeyEpochInterval:        epochDuration,
 		HiddenPathRegistrationCfg: hpWriterCfg,
+		RemoteCoreRegistrationCfg: remoteCoreCfg,
+		MaxBeaconsPerInterface:    globalCfg.BS.MaxBeaconsPerInterface,
 		AllowIsdLoop:              isdLoopAllowed,
 		EPIC:                      globalCfg.BS.EPIC,
+		Lease:                     csLease,
+		LeaseCheckInterval:        globalCfg.Lease.CheckInterval.Duration,
 	})
 	if err != nil {
 		return serrors.WrapStr("starting periodic tasks", err)
@@ -810,6 +1157,9 @@ func realMain(ctx context.Context) error {
 	g.Go(func() error {
 		defer log.HandlePanic()
 		<-errCtx.Done()
+		// Let the periodic tasks finish their current run, e.g., the beacon
+		// registrations, before the servers are stopped.
+		tasks.Shutdown(shutdownCtx)
 		return cleanup.Do()
 	})
 
@@ -861,6 +1211,14 @@ func adaptInterfaceMap(in map[common.IFIDType]topology.IFInfo) map[uint16]ifstat
 	return converted
 }
 
+// aliveFilter wraps the interface filter such that interfaces without recent
+// liveness messages from the neighbor are excluded.
+func aliveFilter(filter func(*ifstate.Interface) bool) func(*ifstate.Interface) bool {
+	return func(intf *ifstate.Interface) bool {
+		return filter(intf) && intf.Alive(time.Now())
+	}
+}
+
 type cachedCAHealth struct {
 	status api.CAHealthStatus
 	mtx    sync.Mutex
@@ -0,0 +1,347 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/scionproto/scion/control/config"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/app/command"
+	caconfig "github.com/scionproto/scion/private/ca/config"
+	libconfig "github.com/scionproto/scion/private/config"
+	"github.com/scionproto/scion/private/mgmtapi/jwtauth"
+)
+
+// csStatus is the status of the control service as reported by the status
+// command.
+type csStatus struct {
+	Beacons       []beaconStatus       `json:"beacons"`
+	Registrations []registrationStatus `json:"registrations"`
+	TRCs          []trcStatus          `json:"trcs"`
+	Chains        []chainStatus        `json:"chains"`
+	Warnings      []string             `json:"warnings"`
+}
+
+type beaconStatus struct {
+	Neighbor    addr.IA   `json:"isd_as"`
+	Interface   uint64    `json:"interface_id"`
+	Beacons     uint32    `json:"beacons"`
+	Origins     uint32    `json:"origins"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+type registrationStatus struct {
+	Type        string     `json:"type"`
+	Succeeded   uint64     `json:"succeeded"`
+	Failed      uint64     `json:"failed"`
+	SuccessRate float64    `json:"success_rate"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LastFailure *time.Time `json:"last_failure,omitempty"`
+}
+
+type trcStatus struct {
+	ISD       addr.ISD  `json:"isd"`
+	Base      uint64    `json:"base"`
+	Serial    uint64    `json:"serial"`
+	NotBefore time.Time `json:"not_before"`
+	NotAfter  time.Time `json:"not_after"`
+	Status    string    `json:"status"`
+}
+
+type chainStatus struct {
+	IA           addr.IA   `json:"isd_as"`
+	Issuer       addr.IA   `json:"issuer_isd_as"`
+	SubjectKeyID string    `json:"subject_key_id"`
+	NotBefore    time.Time `json:"not_before"`
+	NotAfter     time.Time `json:"not_after"`
+	Status       string    `json:"status"`
+}
+
+func newStatus(pather command.Pather) *cobra.Command {
+	var flags struct {
+		config        string
+		addr          string
+		sharedSecret  string
+		format        string
+		expiryWarning time.Duration
+		timeout       time.Duration
+	}
+	var cmd = &cobra.Command{
+		Use:   "status",
+		Short: "Display the status of a running control service",
+		Example: fmt.Sprintf(`  %[1]s status --config cs.toml
+  %[1]s status --addr 127.0.0.1:30258 --shared-secret admin.key --format json`,
+			pather.CommandPath()),
+		Long: `'status' queries the admin API of a running control service and displays
+the beaconing health, the segment registration success rates, and the trust
+material. A warning is displayed for every TRC and AS certificate that is the
+most recent one of its ISD or AS and that expires within the expiry warning
+period.
+
+The address of the admin API and the shared secret are read from the admin
+section of the control service configuration file. They can be overridden with
+the --addr and --shared-secret flags.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch flags.format {
+			case "human", "json":
+			default:
+				return serrors.New("format not supported", "format", flags.format)
+			}
+			if flags.config != "" {
+				var cfg config.Config
+				if err := libconfig.LoadFile(flags.config, &cfg); err != nil {
+					return serrors.WrapStr("loading config from file", err,
+						"file", flags.config)
+				}
+				if flags.addr == "" {
+					flags.addr = cfg.Admin.Addr
+				}
+				if flags.sharedSecret == "" {
+					flags.sharedSecret = cfg.Admin.SharedSecret
+				}
+			}
+			if flags.addr == "" || flags.sharedSecret == "" {
+				return serrors.New("admin address and shared secret must be specified " +
+					"either in the configuration file or with flags")
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancelF := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancelF()
+			conn, err := grpc.DialContext(ctx, flags.addr,
+				grpc.WithInsecure(),
+				grpc.WithPerRPCCredentials(jwtauth.GRPCCredentials{
+					TokenSource: &jwtauth.JWTTokenSource{
+						Subject:   "scion-control-status",
+						Generator: caconfig.NewPEMSymmetricKey(flags.sharedSecret).Get,
+					},
+				}),
+			)
+			if err != nil {
+				return serrors.WrapStr("connecting to admin API", err, "addr", flags.addr)
+			}
+			defer conn.Close()
+			status, err := fetchStatus(ctx, cppb.NewAdminServiceClient(conn),
+				time.Now(), flags.expiryWarning)
+			if err != nil {
+				return err
+			}
+			if flags.format == "json" {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(status)
+			}
+			return writeStatus(cmd.OutOrStdout(), status)
+		},
+	}
+	cmd.Flags().StringVar(&flags.config, "config", "",
+		"Configuration file of the control service")
+	cmd.Flags().StringVar(&flags.addr, "addr", "", "Address of the admin API")
+	cmd.Flags().StringVar(&flags.sharedSecret, "shared-secret", "",
+		"PEM file with the shared secret of the admin API")
+	cmd.Flags().StringVar(&flags.format, "format", "human",
+		"Specify the output format (human|json)")
+	cmd.Flags().DurationVar(&flags.expiryWarning, "expiry-warning", 7*24*time.Hour,
+		"Warn about trust material that expires within this period")
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", 5*time.Second,
+		"Timeout for querying the admin API")
+	return cmd
+}
+
+// fetchStatus queries the status from the admin API. Trust material that
+// expires before now+expiryWarning is reported in the warnings.
+func fetchStatus(ctx context.Context, client cppb.AdminServiceClient, now time.Time,
+	expiryWarning time.Duration) (csStatus, error) {
+
+	status := csStatus{
+		Beacons:       []beaconStatus{},
+		Registrations: []registrationStatus{},
+		TRCs:          []trcStatus{},
+		Chains:        []chainStatus{},
+		Warnings:      []string{},
+	}
+	beacons, err := client.Beacons(ctx, &cppb.BeaconsRequest{})
+	if err != nil {
+		return csStatus{}, serrors.WrapStr("querying beacons", err)
+	}
+	for _, n := range beacons.Neighbors {
+		status.Beacons = append(status.Beacons, beaconStatus{
+			Neighbor:    addr.IA(n.IsdAs),
+			Interface:   n.InterfaceId,
+			Beacons:     n.Beacons,
+			Origins:     n.Origins,
+			LastUpdated: n.LastUpdated.AsTime(),
+		})
+	}
+	if len(status.Beacons) == 0 {
+		status.Warnings = append(status.Warnings, "no beacons received")
+	}
+
+	regs, err := client.Registrations(ctx, &cppb.RegistrationsRequest{})
+	if err != nil {
+		return csStatus{}, serrors.WrapStr("querying registrations", err)
+	}
+	for _, r := range regs.Registrations {
+		reg := registrationStatus{
+			Type:      seg.Type(r.Type).String(),
+			Succeeded: r.Succeeded,
+			Failed:    r.Failed,
+		}
+		if total := r.Succeeded + r.Failed; total != 0 {
+			reg.SuccessRate = float64(r.Succeeded) / float64(total)
+		}
+		if r.LastSuccess != nil {
+			t := r.LastSuccess.AsTime()
+			reg.LastSuccess = &t
+		}
+		if r.LastFailure != nil {
+			t := r.LastFailure.AsTime()
+			reg.LastFailure = &t
+		}
+		if reg.LastFailure != nil &&
+			(reg.LastSuccess == nil || reg.LastSuccess.Before(*reg.LastFailure)) {
+
+			status.Warnings = append(status.Warnings,
+				fmt.Sprintf("last %s segment registration failed", reg.Type))
+		}
+		status.Registrations = append(status.Registrations, reg)
+	}
+
+	trust, err := client.TrustMaterial(ctx, &cppb.TrustMaterialRequest{})
+	if err != nil {
+		return csStatus{}, serrors.WrapStr("querying trust material", err)
+	}
+	latestTRCs := make(map[addr.ISD]trcStatus)
+	for _, t := range trust.Trcs {
+		trc := trcStatus{
+			ISD:       addr.ISD(t.Isd),
+			Base:      t.Base,
+			Serial:    t.Serial,
+			NotBefore: t.NotBefore.AsTime(),
+			NotAfter:  t.NotAfter.AsTime(),
+		}
+		trc.Status = validityStatus(trc.NotBefore, trc.NotAfter, now, expiryWarning)
+		status.TRCs = append(status.TRCs, trc)
+		if latest, ok := latestTRCs[trc.ISD]; !ok || trc.Serial > latest.Serial {
+			latestTRCs[trc.ISD] = trc
+		}
+	}
+	latestChains := make(map[addr.IA]chainStatus)
+	for _, c := range trust.Chains {
+		chain := chainStatus{
+			IA:           addr.IA(c.IsdAs),
+			Issuer:       addr.IA(c.IssuerIsdAs),
+			SubjectKeyID: fmt.Sprintf("%x", c.SubjectKeyId),
+			NotBefore:    c.NotBefore.AsTime(),
+			NotAfter:     c.NotAfter.AsTime(),
+		}
+		chain.Status = validityStatus(chain.NotBefore, chain.NotAfter, now, expiryWarning)
+		status.Chains = append(status.Chains, chain)
+		if latest, ok := latestChains[chain.IA]; !ok || chain.NotAfter.After(latest.NotAfter) {
+			latestChains[chain.IA] = chain
+		}
+	}
+	// Only the most recent trust material is checked, superseded material
+	// is expected to expire.
+	for _, trc := range status.TRCs {
+		if latestTRCs[trc.ISD] != trc || trc.Status == "valid" {
+			continue
+		}
+		status.Warnings = append(status.Warnings, fmt.Sprintf("TRC ISD%d-B%d-S%d is %s",
+			trc.ISD, trc.Base, trc.Serial, trc.Status))
+	}
+	for _, chain := range status.Chains {
+		if latestChains[chain.IA] != chain || chain.Status == "valid" {
+			continue
+		}
+		status.Warnings = append(status.Warnings, fmt.Sprintf(
+			"AS certificate of %s (subject key ID %s) is %s",
+			chain.IA, chain.SubjectKeyID, chain.Status))
+	}
+	return status, nil
+}
+
+func validityStatus(notBefore, notAfter, now time.Time, expiryWarning time.Duration) string {
+	switch {
+	case now.Before(notBefore):
+		return "not yet valid"
+	case !now.Before(notAfter):
+		return "expired"
+	case now.Add(expiryWarning).After(notAfter):
+		return "expiring"
+	default:
+		return "valid"
+	}
+}
+
+func writeStatus(out io.Writer, status csStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Beacons:")
+	fmt.Fprintln(w, "NEIGHBOR\tINTERFACE\tBEACONS\tORIGINS\tLAST UPDATED")
+	for _, b := range status.Beacons {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", b.Neighbor, b.Interface, b.Beacons,
+			b.Origins, formatTime(&b.LastUpdated))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Segment registrations:")
+	fmt.Fprintln(w, "TYPE\tSUCCEEDED\tFAILED\tSUCCESS RATE\tLAST SUCCESS\tLAST FAILURE")
+	for _, r := range status.Registrations {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\t%s\t%s\n", r.Type, r.Succeeded, r.Failed,
+			100*r.SuccessRate, formatTime(r.LastSuccess), formatTime(r.LastFailure))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "TRCs:")
+	fmt.Fprintln(w, "TRC\tNOT BEFORE\tNOT AFTER\tSTATUS")
+	for _, t := range status.TRCs {
+		fmt.Fprintf(w, "ISD%d-B%d-S%d\t%s\t%s\t%s\n", t.ISD, t.Base, t.Serial,
+			formatTime(&t.NotBefore), formatTime(&t.NotAfter), t.Status)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "AS certificates:")
+	fmt.Fprintln(w, "ISD-AS\tISSUER\tSUBJECT KEY ID\tNOT BEFORE\tNOT AFTER\tSTATUS")
+	for _, c := range status.Chains {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", c.IA, c.Issuer, c.SubjectKeyID,
+			formatTime(&c.NotBefore), formatTime(&c.NotAfter), c.Status)
+	}
+	if len(status.Warnings) != 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Warnings:")
+		for _, warning := range status.Warnings {
+			fmt.Fprintf(w, "  %s\n", warning)
+		}
+	}
+	return w.Flush()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+)
+
+func TestFetchStatus(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	client := fakeAdminClient{
+		beacons: &cppb.BeaconsResponse{Neighbors: []*cppb.NeighborBeacons{{
+			IsdAs:       uint64(ia110),
+			InterfaceId: 1,
+			Beacons:     5,
+			Origins:     2,
+			LastUpdated: timestamppb.New(now),
+		}}},
+		registrations: &cppb.RegistrationsResponse{
+			Registrations: []*cppb.RegistrationStats{
+				{
+					Type:        cppb.SegmentType_SEGMENT_TYPE_UP,
+					Succeeded:   3,
+					Failed:      1,
+					LastSuccess: timestamppb.New(now),
+					LastFailure: timestamppb.New(now.Add(-time.Minute)),
+				},
+				{
+					Type:        cppb.SegmentType_SEGMENT_TYPE_DOWN,
+					Failed:      2,
+					LastFailure: timestamppb.New(now),
+				},
+			},
+		},
+		trust: &cppb.TrustMaterialResponse{
+			Trcs: []*cppb.TRCSummary{
+				{
+					Isd:       1,
+					Base:      1,
+					Serial:    1,
+					NotBefore: timestamppb.New(now.Add(-48 * time.Hour)),
+					NotAfter:  timestamppb.New(now.Add(-time.Hour)),
+				},
+				{
+					Isd:       1,
+					Base:      1,
+					Serial:    2,
+					NotBefore: timestamppb.New(now.Add(-2 * time.Hour)),
+					NotAfter:  timestamppb.New(now.Add(365 * 24 * time.Hour)),
+				},
+			},
+			Chains: []*cppb.ChainSummary{{
+				IsdAs:        uint64(ia111),
+				IssuerIsdAs:  uint64(ia110),
+				SubjectKeyId: []byte{0xab},
+				NotBefore:    timestamppb.New(now.Add(-time.Hour)),
+				NotAfter:     timestamppb.New(now.Add(time.Hour)),
+			}},
+		},
+	}
+	status, err := fetchStatus(context.Background(), client, now, 24*time.Hour)
+	require.NoError(t, err)
+
+	require.Len(t, status.Beacons, 1)
+	assert.Equal(t, ia110, status.Beacons[0].Neighbor)
+	require.Len(t, status.Registrations, 2)
+	assert.Equal(t, "up", status.Registrations[0].Type)
+	assert.Equal(t, 0.75, status.Registrations[0].SuccessRate)
+	assert.Equal(t, 0.0, status.Registrations[1].SuccessRate)
+	require.Len(t, status.TRCs, 2)
+	assert.Equal(t, "expired", status.TRCs[0].Status)
+	assert.Equal(t, "valid", status.TRCs[1].Status)
+	require.Len(t, status.Chains, 1)
+	assert.Equal(t, "expiring", status.Chains[0].Status)
+	// The expired TRC is superseded and does not cause a warning.
+	assert.Equal(t, []string{
+		"last down segment registration failed",
+		"AS certificate of 1-ff00:0:111 (subject key ID ab) is expiring",
+	}, status.Warnings)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeStatus(&buf, status))
+	assert.Contains(t, buf.String(), "75.0%")
+	assert.Contains(t, buf.String(), "ISD1-B1-S2")
+}
+
+type fakeAdminClient struct {
+	cppb.AdminServiceClient
+	beacons       *cppb.BeaconsResponse
+	registrations *cppb.RegistrationsResponse
+	trust         *cppb.TrustMaterialResponse
+}
+
+func (c fakeAdminClient) Beacons(context.Context, *cppb.BeaconsRequest,
+	...grpc.CallOption) (*cppb.BeaconsResponse, error) {
+
+	return c.beacons, nil
+}
+
+func (c fakeAdminClient) Registrations(context.Context, *cppb.RegistrationsRequest,
+	...grpc.CallOption) (*cppb.RegistrationsResponse, error) {
+
+	return c.registrations, nil
+}
+
+func (c fakeAdminClient) TrustMaterial(context.Context, *cppb.TrustMaterialRequest,
+	...grpc.CallOption) (*cppb.TrustMaterialResponse, error) {
+
+	return c.trust, nil
+}
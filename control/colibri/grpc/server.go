@@ -0,0 +1,73 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/colibri"
+	"github.com/scionproto/scion/pkg/private/common"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+)
+
+// Admitter admits reservations.
+type Admitter interface {
+	Admit(req colibri.Request, now time.Time) (colibri.Response, error)
+}
+
+// Server serves the COLIBRI reservation requests of the end hosts in the
+// local AS.
+type Server struct {
+	Admitter Admitter
+}
+
+// Reserve admits the requested reservation.
+func (s Server) Reserve(ctx context.Context,
+	req *cppb.ColibriReserveRequest) (*cppb.ColibriReserveResponse, error) {
+
+	if err := req.Expiration.CheckValid(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid expiration: %s", err)
+	}
+	rep, err := s.Admitter.Admit(colibri.Request{
+		SrcIA:      addr.IA(req.SrcIsdAs),
+		DstIA:      addr.IA(req.DstIsdAs),
+		Ingress:    common.IFIDType(req.Ingress),
+		Egress:     common.IFIDType(req.Egress),
+		Bandwidth:  req.Bandwidth,
+		Expiration: req.Expiration.AsTime(),
+	}, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pb := &cppb.ColibriReserveResponse{
+		Accepted:      rep.Accepted,
+		Bandwidth:     rep.Bandwidth,
+		FailureReason: rep.FailureReason,
+	}
+	if rep.Accepted {
+		pb.Id = &cppb.ColibriReservationID{
+			As:     uint64(rep.ID.ASID),
+			Suffix: rep.ID.Suffix,
+		}
+		pb.Expiration = timestamppb.New(rep.Expiration)
+	}
+	return pb, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package colibri implements the admission of COLIBRI bandwidth reservations
+// in the control service.
+package colibri
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/colibri"
+	"github.com/scionproto/scion/pkg/private/common"
+)
+
+// Store keeps track of the reservations admitted by the local AS. It admits a
+// new reservation if the bandwidth reserved on its ingress and egress
+// interface stays within the capacity of the interface.
+type Store struct {
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+	// Capacity is the bandwidth in Kbit/s that can be reserved on each
+	// interface.
+	Capacity uint64
+
+	mtx          sync.Mutex
+	reservations map[colibri.ReservationID]colibri.Reservation
+	lastSuffix   uint32
+}
+
+// Admit admits the reservation if enough bandwidth is available. An error is
+// returned if the request is invalid.
+func (s *Store) Admit(req colibri.Request, now time.Time) (colibri.Response, error) {
+	if err := req.Validate(now); err != nil {
+		return colibri.Response{}, err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.deleteExpiredLocked(now)
+	available := s.availableLocked(req.Ingress, req.Egress)
+	if req.Bandwidth > available {
+		return colibri.Response{
+			Bandwidth:     available,
+			FailureReason: "insufficient bandwidth",
+		}, nil
+	}
+	if s.reservations == nil {
+		s.reservations = make(map[colibri.ReservationID]colibri.Reservation)
+	}
+	s.lastSuffix++
+	r := colibri.Reservation{
+		ID:      colibri.ReservationID{ASID: s.LocalIA.AS(), Suffix: s.lastSuffix},
+		Request: req,
+	}
+	s.reservations[r.ID] = r
+	return colibri.Response{
+		Accepted:   true,
+		ID:         r.ID,
+		Bandwidth:  r.Bandwidth,
+		Expiration: r.Expiration,
+	}, nil
+}
+
+// Get returns the reservation with the given ID. Expired reservations are not
+// returned.
+func (s *Store) Get(id colibri.ReservationID, now time.Time) (colibri.Reservation, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	r, ok := s.reservations[id]
+	if !ok || r.Expired(now) {
+		return colibri.Reservation{}, false
+	}
+	return r, true
+}
+
+// Reservations returns all reservations that are not expired, ordered by ID.
+func (s *Store) Reservations(now time.Time) []colibri.Reservation {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.deleteExpiredLocked(now)
+	result := make([]colibri.Reservation, 0, len(s.reservations))
+	for _, r := range s.reservations {
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID.Suffix < result[j].ID.Suffix
+	})
+	return result
+}
+
+// availableLocked returns the bandwidth that can still be reserved between
+// the ingress and the egress interface.
+func (s *Store) availableLocked(ingress, egress common.IFIDType) uint64 {
+	var reserved map[common.IFIDType]uint64
+	for _, r := range s.reservations {
+		if reserved == nil {
+			reserved = make(map[common.IFIDType]uint64)
+		}
+		reserved[r.Ingress] += r.Bandwidth
+		if r.Egress != r.Ingress {
+			reserved[r.Egress] += r.Bandwidth
+		}
+	}
+	// Traffic that originates or terminates in the local AS is not limited
+	// by the interface capacity.
+	available := ^uint64(0)
+	for _, ifID := range []common.IFIDType{ingress, egress} {
+		if ifID == 0 {
+			continue
+		}
+		free := uint64(0)
+		if reserved[ifID] < s.Capacity {
+			free = s.Capacity - reserved[ifID]
+		}
+		if free < available {
+			available = free
+		}
+	}
+	return available
+}
+
+func (s *Store) deleteExpiredLocked(now time.Time) {
+	for id, r := range s.reservations {
+		if r.Expired(now) {
+			delete(s.reservations, id)
+		}
+	}
+}
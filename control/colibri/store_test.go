@@ -0,0 +1,112 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cs_colibri "github.com/scionproto/scion/control/colibri"
+	"github.com/scionproto/scion/pkg/colibri"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+func TestStoreAdmit(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	now := time.Now()
+	request := func(ingress, egress uint16, bw uint64, exp time.Duration) colibri.Request {
+		return colibri.Request{
+			SrcIA:      local,
+			DstIA:      dst,
+			Ingress:    common.IFIDType(ingress),
+			Egress:     common.IFIDType(egress),
+			Bandwidth:  bw,
+			Expiration: now.Add(exp),
+		}
+	}
+	s := &cs_colibri.Store{LocalIA: local, Capacity: 100}
+
+	rep, err := s.Admit(request(0, 1, 60, time.Minute), now)
+	require.NoError(t, err)
+	assert.True(t, rep.Accepted)
+	assert.Equal(t, local.AS(), rep.ID.ASID)
+	assert.EqualValues(t, 60, rep.Bandwidth)
+
+	// Interface 1 only has 40 Kbit/s left.
+	rep, err = s.Admit(request(2, 1, 50, time.Minute), now)
+	require.NoError(t, err)
+	assert.False(t, rep.Accepted)
+	assert.EqualValues(t, 40, rep.Bandwidth)
+	assert.NotEmpty(t, rep.FailureReason)
+
+	rep2, err := s.Admit(request(2, 1, 40, 2*time.Minute), now)
+	require.NoError(t, err)
+	assert.True(t, rep2.Accepted)
+	assert.NotEqual(t, rep.ID, rep2.ID)
+
+	// Traffic that stays in the AS is not limited.
+	rep, err = s.Admit(request(0, 0, 1000, time.Minute), now)
+	require.NoError(t, err)
+	assert.True(t, rep.Accepted)
+	assert.Len(t, s.Reservations(now), 3)
+
+	// Expired reservations release their bandwidth.
+	later := now.Add(90 * time.Second)
+	assert.Len(t, s.Reservations(later), 1)
+	_, ok := s.Get(rep2.ID, later)
+	assert.True(t, ok)
+	rep, err = s.Admit(colibri.Request{
+		SrcIA:      local,
+		DstIA:      dst,
+		Egress:     1,
+		Bandwidth:  60,
+		Expiration: later.Add(time.Minute),
+	}, later)
+	require.NoError(t, err)
+	assert.True(t, rep.Accepted)
+}
+
+func TestStoreAdmitInvalid(t *testing.T) {
+	now := time.Now()
+	valid := colibri.Request{
+		SrcIA:      xtest.MustParseIA("1-ff00:0:110"),
+		DstIA:      xtest.MustParseIA("1-ff00:0:112"),
+		Egress:     1,
+		Bandwidth:  10,
+		Expiration: now.Add(time.Minute),
+	}
+	testCases := map[string]func(r *colibri.Request){
+		"no destination": func(r *colibri.Request) { r.DstIA = 0 },
+		"no bandwidth":   func(r *colibri.Request) { r.Bandwidth = 0 },
+		"expired":        func(r *colibri.Request) { r.Expiration = now },
+		"too long": func(r *colibri.Request) {
+			r.Expiration = now.Add(colibri.MaxReservationDuration + time.Second)
+		},
+	}
+	for name, modify := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := &cs_colibri.Store{Capacity: 100}
+			req := valid
+			modify(&req)
+			_, err := s.Admit(req, now)
+			assert.Error(t, err)
+		})
+	}
+}
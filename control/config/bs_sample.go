@@ -24,8 +24,67 @@ propagation_interval = "5s"
 # The interval between registering beacons. (default 5s)
 registration_interval = "5s"
 
+# The upper bound of the random delay that is added to every registration
+# interval. Spreading registrations over time avoids that the segments of many
+# ASes are registered, and consequently expire, at the same time. (default 0s)
+registration_jitter = "0s"
+
+# The lifetime of the hop fields in originated beacons. The lifetime is rounded
+# down to a multiple of 337.5s and must be between 337.5s and 24h. If zero, the
+# maximum expiration time of the propagation policy is used. (default 0s)
+segment_lifetime = "0s"
+
 # Add EPIC authenticators to the beacons. (default false)
 epic = false
+
+# The maximum number of beacons per origin AS that are kept in the beacon
+# database. The longest beacons are evicted first. (default 1000)
+max_beacons_per_origin = 1000
+
+# The maximum number of beacons that are kept in the beacon database. The least
+# recently updated beacons are evicted first. (default 100000)
+max_beacons = 100000
+
+# The rotation period of the hop field MAC key. The key is derived from the AS
+# master key and rotated at multiples of the period since the Unix epoch. The
+# period must be at least 24h1m and equal to the one configured in the routers
+# of the AS. If zero, the key is not rotated. (default 0s)
+hop_field_key_rotation = "0s"
+
+# The algorithm of the hop field MACs, either "aes-cmac" or "hmac-sha256". It
+# must be equal to the one configured in the routers of the AS.
+# (default "aes-cmac")
+hop_field_mac_algorithm = "aes-cmac"
+
+# The algorithm of the hop field MACs in the key epochs before
+# hop_field_mac_epoch. (default "aes-cmac")
+hop_field_mac_prev_algorithm = "aes-cmac"
+
+# The first key epoch, counted in hop_field_key_rotation periods since the Unix
+# epoch, whose hop field MACs use hop_field_mac_algorithm. It allows to switch
+# the algorithm without invalidating the hop fields in use, and requires the
+# key to be rotated. If zero, all key epochs use hop_field_mac_algorithm.
+# (default 0)
+hop_field_mac_epoch = 0
+
+# The maximum number of beacons that are propagated on an egress interface in
+# every propagation round. The beacons are selected such that the diversity of
+# the beacons sent to a neighbor is maximized. If zero, all candidates of the
+# propagation policy are propagated. (default 0)
+max_beacons_per_interface = 0
+
+# Enable the authenticated liveness exchange with the neighboring control
+# services. Interfaces on which no signed liveness message of the neighbor was
+# received within liveness_timeout are excluded from beaconing and revoked.
+# All neighbors must enable it as well. (default false)
+interface_liveness = false
+
+# The interval between sending liveness messages to the neighbors. (default 1s)
+liveness_interval = "1s"
+
+# The time after the last accepted liveness message at which an interface is
+# considered down. It must be larger than liveness_interval. (default 3s)
+liveness_timeout = "3s"
 `
 
 const policiesSample = `
@@ -49,4 +108,32 @@ up_registration = ""
 # the default policy is used. In a core beacon server, this field is ignored.
 # (default "")
 down_registration = ""
+
+# The file path for the registration filter, which excludes segments that
+# traverse certain ASes or interfaces from registration. In case of the empty
+# string, no segments are excluded. The file is reloaded on SIGHUP.
+# (default "")
+registration_filter = ""
+`
+
+const remoteCoreRegistrationSample = `
+# The core ASes at which the core segments they originated are registered, in
+# addition to the local registration. In a non-core beacon server, this field
+# is ignored. If empty, core segments are only registered locally. (default [])
+remotes = []
+
+# The interval between registering the core segments at the remote core ASes.
+# If zero, the registration interval is used. (default "0s")
+interval = "0s"
+
+# The maximum number of concurrent registrations. If zero, the number of
+# concurrent registrations is not limited. (default 0)
+parallelism = 0
+
+# The time a remote core AS is skipped after a failed registration. The
+# backoff doubles with every consecutive failure. (default "5s")
+initial_backoff = "5s"
+
+# The upper bound of the backoff. (default "5m")
+max_backoff = "5m"
 `
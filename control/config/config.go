@@ -17,12 +17,17 @@ package config
 
 import (
 	"io"
+	"net"
 	"strings"
 	"time"
 
+	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/slayers/path"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/env"
 	api "github.com/scionproto/scion/private/mgmtapi"
@@ -42,8 +47,47 @@ const (
 	// DefaultQueryInterval is the default interval after which the segment
 	// cache expires.
 	DefaultQueryInterval = 5 * time.Minute
+	// DefaultHiddenPathsFederationCacheTTL is the default duration for which
+	// the results of federated hidden segment lookups are cached.
+	DefaultHiddenPathsFederationCacheTTL = time.Minute
+	// DefaultHiddenPathsRegistryProbeInterval is the default interval in
+	// which the reachability of the hidden path registries is probed.
+	DefaultHiddenPathsRegistryProbeInterval = time.Minute
 	// DefaultMaxASValidity is the default validity period for renewed AS certificates.
 	DefaultMaxASValidity = 3 * 24 * time.Hour
+	// DefaultMaxBeaconsPerOrigin is the default maximum number of beacons per
+	// origin AS that are kept in the beacon database.
+	DefaultMaxBeaconsPerOrigin = 1000
+	// DefaultMaxBeacons is the default maximum number of beacons that are kept
+	// in the beacon database.
+	DefaultMaxBeacons = 100000
+	// DefaultColibriInterfaceCapacity is the default bandwidth in Kbit/s that
+	// can be reserved on each interface.
+	DefaultColibriInterfaceCapacity = 1000000
+	// DefaultRenewalLeadTime is the default time before the expiration of the
+	// AS certificate at which it is renewed.
+	DefaultRenewalLeadTime = 24 * time.Hour
+	// DefaultRPCClientBurst is the default number of requests a single client
+	// can send in a burst above the client rate limit.
+	DefaultRPCClientBurst = 20
+	// DefaultRPCGlobalBurst is the default number of requests that can be
+	// served in a burst above the global rate limit.
+	DefaultRPCGlobalBurst = 200
+	// DefaultRPCMaxRequestSize is the default maximum size of a request in
+	// bytes. It is the default of gRPC.
+	DefaultRPCMaxRequestSize = 4 << 20
+	// DefaultLeaseCheckInterval is the default interval in which the other
+	// control service instances are checked for the lease.
+	DefaultLeaseCheckInterval = 2 * time.Second
+	// DefaultLeaseDuration is the default time during which no preceding
+	// control service instance must be serving before the lease is taken.
+	DefaultLeaseDuration = 10 * time.Second
+	// DefaultASKeyLabel is the default label of the AS signing keys in a
+	// PKCS#11 token.
+	DefaultASKeyLabel = "cp-as"
+	// DefaultCAKeyLabel is the default label of the CA signing keys in a
+	// PKCS#11 token.
+	DefaultCAKeyLabel = "cp-ca"
 )
 
 var _ config.Config = (*Config)(nil)
@@ -57,6 +101,7 @@ type Config struct {
 	API         api.Config         `toml:"api,omitempty"`
 	Tracing     env.Tracing        `toml:"tracing,omitempty"`
 	QUIC        env.QUIC           `toml:"quic,omitempty"`
+	GRPCTLS     env.GRPCTLS        `toml:"grpc_tls,omitempty"`
 	BeaconDB    storage.DBConfig   `toml:"beacon_db,omitempty"`
 	TrustDB     storage.DBConfig   `toml:"trust_db,omitempty"`
 	PathDB      storage.DBConfig   `toml:"path_db,omitempty"`
@@ -65,6 +110,12 @@ type Config struct {
 	CA          CA                 `toml:"ca,omitempty"`
 	TrustEngine trustengine.Config `toml:"trustengine,omitempty"`
 	DRKey       DRKeyConfig        `toml:"drkey,omitempty"`
+	Colibri     ColibriConfig      `toml:"colibri,omitempty"`
+	Renewal     RenewalConfig      `toml:"renewal,omitempty"`
+	Admin       AdminConfig        `toml:"admin,omitempty"`
+	RPCLimits   RPCLimitsConfig    `toml:"rpc_limits,omitempty"`
+	Lease       LeaseConfig        `toml:"lease,omitempty"`
+	Keys        KeysConfig         `toml:"keys,omitempty"`
 }
 
 // InitDefaults initializes the default values for all parts of the config.
@@ -84,17 +135,42 @@ func (cfg *Config) InitDefaults() {
 		&cfg.CA,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
+		&cfg.Colibri,
+		&cfg.Renewal,
+		&cfg.Admin,
+		&cfg.RPCLimits,
+		&cfg.Lease,
+		&cfg.Keys,
 	)
 }
 
 // Validate validates all parts of the config.
 func (cfg *Config) Validate() error {
+	if err := cfg.validateAll(); err != nil {
+		return err
+	}
+	return validateKeysRenewal(&cfg.Keys, &cfg.Renewal)
+}
+
+// validateKeysRenewal checks that the AS certificate is not renewed
+// automatically if the keys are held in a PKCS#11 token. The renewal
+// generates a new key in the file system, which would not be used.
+func validateKeysRenewal(keys *KeysConfig, renewal *RenewalConfig) error {
+	if keys.Backend == KeysPKCS11 && renewal.Enabled {
+		return serrors.New("renewal.enabled is not supported with the pkcs11 keys backend")
+	}
+	return nil
+}
+
+func (cfg *Config) validateAll() error {
 	return config.ValidateAll(
 		&cfg.General,
 		&cfg.Features,
 		&cfg.Logging,
 		&cfg.Metrics,
+		&cfg.Tracing,
 		&cfg.API,
+		&cfg.GRPCTLS,
 		&cfg.BeaconDB,
 		&cfg.TrustDB,
 		&cfg.PathDB,
@@ -103,6 +179,12 @@ func (cfg *Config) Validate() error {
 		&cfg.CA,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
+		&cfg.Colibri,
+		&cfg.Renewal,
+		&cfg.Admin,
+		&cfg.RPCLimits,
+		&cfg.Lease,
+		&cfg.Keys,
 	)
 }
 
@@ -116,6 +198,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.API,
 		&cfg.Tracing,
 		&cfg.QUIC,
+		&cfg.GRPCTLS,
 		config.OverrideName(
 			config.FormatData(
 				&cfg.BeaconDB,
@@ -142,6 +225,12 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.CA,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
+		&cfg.Colibri,
+		&cfg.Renewal,
+		&cfg.Admin,
+		&cfg.RPCLimits,
+		&cfg.Lease,
+		&cfg.Keys,
 	)
 }
 
@@ -155,10 +244,54 @@ type BSConfig struct {
 	PropagationInterval util.DurWrap `toml:"propagation_interval,omitempty"`
 	// RegistrationInterval is the interval between registering segments.
 	RegistrationInterval util.DurWrap `toml:"registration_interval,omitempty"`
+	// RegistrationJitter is the upper bound of the random delay that is added
+	// to every registration interval. If zero, no jitter is added.
+	RegistrationJitter util.DurWrap `toml:"registration_jitter,omitempty"`
+	// SegmentLifetime is the lifetime of the hop fields in originated beacons.
+	// If zero, the maximum expiration time of the propagation policy is used.
+	SegmentLifetime util.DurWrap `toml:"segment_lifetime,omitempty"`
 	// Policies contains the policy files.
 	Policies Policies `toml:"policies,omitempty"`
 	// EPIC specifies whether the EPIC authenticators should be added to the beacons.
 	EPIC bool `toml:"epic,omitempty" default:"false"`
+	// MaxBeaconsPerOrigin is the maximum number of beacons per origin AS that
+	// are kept in the beacon database.
+	MaxBeaconsPerOrigin int `toml:"max_beacons_per_origin,omitempty"`
+	// MaxBeacons is the maximum number of beacons that are kept in the beacon
+	// database.
+	MaxBeacons int `toml:"max_beacons,omitempty"`
+	// HopFieldKeyRotation is the rotation period of the hop field MAC key. If
+	// zero, the key is not rotated. It must be equal to the rotation period
+	// configured in the routers of the AS.
+	HopFieldKeyRotation util.DurWrap `toml:"hop_field_key_rotation,omitempty"`
+	// HopFieldMACAlgorithm is the algorithm of the hop field MACs. If empty,
+	// AES-CMAC is used. It must be equal to the algorithm configured in the
+	// routers of the AS.
+	HopFieldMACAlgorithm scrypto.MACAlgorithm `toml:"hop_field_mac_algorithm,omitempty"`
+	// HopFieldMACPrevAlgorithm is the algorithm of the hop field MACs in
+	// the key epochs before HopFieldMACEpoch.
+	HopFieldMACPrevAlgorithm scrypto.MACAlgorithm `toml:"hop_field_mac_prev_algorithm,omitempty"`
+	// HopFieldMACEpoch is the first key epoch that uses HopFieldMACAlgorithm.
+	// It requires the hop field MAC key to be rotated.
+	HopFieldMACEpoch uint64 `toml:"hop_field_mac_epoch,omitempty"`
+	// MaxBeaconsPerInterface is the maximum number of beacons that are
+	// propagated on an egress interface in every propagation round. The
+	// beacons are selected from the candidates of the propagation policy such
+	// that the diversity of the beacons sent to a neighbor is maximized. If
+	// zero, all candidates are propagated.
+	MaxBeaconsPerInterface int `toml:"max_beacons_per_interface,omitempty"`
+	// InterfaceLiveness enables the authenticated liveness exchange with the
+	// neighboring control services. Interfaces without liveness are neither
+	// used for beaconing nor announced as available, and they are revoked.
+	InterfaceLiveness bool `toml:"interface_liveness,omitempty"`
+	// LivenessInterval is the interval between sending liveness messages.
+	LivenessInterval util.DurWrap `toml:"liveness_interval,omitempty"`
+	// LivenessTimeout is the time after the last accepted liveness message
+	// at which an interface is considered down.
+	LivenessTimeout util.DurWrap `toml:"liveness_timeout,omitempty"`
+	// RemoteCoreRegistration configures the registration of core segments at
+	// remote core ASes.
+	RemoteCoreRegistration RemoteCoreRegistration `toml:"remote_core_registration,omitempty"`
 }
 
 // InitDefaults the default values for the durations that are equal to zero.
@@ -176,13 +309,74 @@ func (cfg *BSConfig) Validate() error {
 	if cfg.RegistrationInterval.Duration == 0 {
 		initDurWrap(&cfg.RegistrationInterval, DefaultRegistrationInterval)
 	}
-	return nil
+	if cfg.RegistrationJitter.Duration < 0 {
+		return serrors.New("registration_jitter must not be negative",
+			"value", cfg.RegistrationJitter)
+	}
+	if cfg.SegmentLifetime.Duration != 0 {
+		if _, err := path.ExpTimeFromDuration(cfg.SegmentLifetime.Duration); err != nil {
+			return serrors.WrapStr("invalid segment_lifetime", err,
+				"value", cfg.SegmentLifetime)
+		}
+	}
+	if cfg.MaxBeaconsPerOrigin < 0 {
+		return serrors.New("max_beacons_per_origin must not be negative",
+			"value", cfg.MaxBeaconsPerOrigin)
+	}
+	if cfg.MaxBeaconsPerOrigin == 0 {
+		cfg.MaxBeaconsPerOrigin = DefaultMaxBeaconsPerOrigin
+	}
+	if cfg.MaxBeacons < 0 {
+		return serrors.New("max_beacons must not be negative", "value", cfg.MaxBeacons)
+	}
+	if cfg.MaxBeacons == 0 {
+		cfg.MaxBeacons = DefaultMaxBeacons
+	}
+	if rotation := cfg.HopFieldKeyRotation.Duration; rotation != 0 &&
+		rotation < scrypto.MinHFKeyRotationPeriod {
+
+		return serrors.New("hop_field_key_rotation too short",
+			"value", cfg.HopFieldKeyRotation, "min", scrypto.MinHFKeyRotationPeriod)
+	}
+	if err := cfg.HopFieldMACAlgorithm.Validate(); err != nil {
+		return serrors.WrapStr("invalid hop_field_mac_algorithm", err)
+	}
+	if err := cfg.HopFieldMACPrevAlgorithm.Validate(); err != nil {
+		return serrors.WrapStr("invalid hop_field_mac_prev_algorithm", err)
+	}
+	if cfg.HopFieldMACEpoch != 0 && cfg.HopFieldKeyRotation.Duration == 0 {
+		return serrors.New("hop_field_mac_epoch requires hop_field_key_rotation")
+	}
+	if cfg.MaxBeaconsPerInterface < 0 {
+		return serrors.New("max_beacons_per_interface must not be negative",
+			"value", cfg.MaxBeaconsPerInterface)
+	}
+	if cfg.LivenessInterval.Duration == 0 {
+		initDurWrap(&cfg.LivenessInterval, ifstate.DefaultLivenessInterval)
+	}
+	if cfg.LivenessTimeout.Duration == 0 {
+		initDurWrap(&cfg.LivenessTimeout, ifstate.DefaultLivenessTimeout)
+	}
+	if cfg.LivenessTimeout.Duration <= cfg.LivenessInterval.Duration {
+		return serrors.New("liveness_timeout must be larger than liveness_interval",
+			"timeout", cfg.LivenessTimeout, "interval", cfg.LivenessInterval)
+	}
+	return cfg.RemoteCoreRegistration.Validate()
+}
+
+// HopFieldMACAlgorithms returns the hop field MAC algorithms per key epoch.
+func (cfg *BSConfig) HopFieldMACAlgorithms() scrypto.HFMACAlgorithms {
+	return scrypto.HFMACAlgorithms{
+		Current:  cfg.HopFieldMACAlgorithm,
+		Previous: cfg.HopFieldMACPrevAlgorithm,
+		Epoch:    cfg.HopFieldMACEpoch,
+	}
 }
 
 // Sample generates a sample for the beacon server specific configuration.
 func (cfg *BSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, bsSample)
-	config.WriteSample(dst, path, ctx, &cfg.Policies)
+	config.WriteSample(dst, path, ctx, &cfg.Policies, &cfg.RemoteCoreRegistration)
 }
 
 // ConfigName is the toml key for the beacon server specific configuration.
@@ -190,6 +384,57 @@ func (cfg *BSConfig) ConfigName() string {
 	return "beaconing"
 }
 
+var _ config.Config = (*RemoteCoreRegistration)(nil)
+
+// RemoteCoreRegistration configures the registration of core segments at the
+// remote core ASes that originated them. If no remotes are configured, core
+// segments are only registered locally.
+type RemoteCoreRegistration struct {
+	// Remotes are the core ASes the core segments are registered at.
+	Remotes []addr.IA `toml:"remotes,omitempty"`
+	// Interval is the interval between registering the segments. If zero, the
+	// registration interval is used.
+	Interval util.DurWrap `toml:"interval,omitempty"`
+	// Parallelism is the maximum number of concurrent registrations. If zero,
+	// the number of concurrent registrations is not limited.
+	Parallelism int `toml:"parallelism,omitempty"`
+	// InitialBackoff is the time a remote is skipped after a failed
+	// registration. The backoff doubles with every consecutive failure.
+	InitialBackoff util.DurWrap `toml:"initial_backoff,omitempty"`
+	// MaxBackoff is the upper bound of the backoff.
+	MaxBackoff util.DurWrap `toml:"max_backoff,omitempty"`
+}
+
+func (cfg *RemoteCoreRegistration) InitDefaults() {}
+
+func (cfg *RemoteCoreRegistration) Validate() error {
+	if cfg.Interval.Duration < 0 {
+		return serrors.New("remote_core_registration.interval must not be negative",
+			"value", cfg.Interval)
+	}
+	if cfg.Parallelism < 0 {
+		return serrors.New("remote_core_registration.parallelism must not be negative",
+			"value", cfg.Parallelism)
+	}
+	if cfg.InitialBackoff.Duration < 0 || cfg.MaxBackoff.Duration < 0 {
+		return serrors.New("remote_core_registration backoff must not be negative",
+			"initial_backoff", cfg.InitialBackoff, "max_backoff", cfg.MaxBackoff)
+	}
+	if cfg.MaxBackoff.Duration != 0 && cfg.InitialBackoff.Duration > cfg.MaxBackoff.Duration {
+		return serrors.New("remote_core_registration.initial_backoff must not exceed max_backoff",
+			"initial_backoff", cfg.InitialBackoff, "max_backoff", cfg.MaxBackoff)
+	}
+	return nil
+}
+
+func (cfg *RemoteCoreRegistration) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, remoteCoreRegistrationSample)
+}
+
+func (cfg *RemoteCoreRegistration) ConfigName() string {
+	return "remote_core_registration"
+}
+
 func initDurWrap(w *util.DurWrap, def time.Duration) {
 	if w.Duration == 0 {
 		w.Duration = def
@@ -206,18 +451,108 @@ type PSConfig struct {
 	// If HiddenPathsCfg begins with http:// or https://, it will be fetched
 	// over the network from the specified URL instead.
 	HiddenPathsCfg string `toml:"hidden_paths_cfg,omitempty"`
+	// HiddenPathsAuditFile specifies the file to which audit records of
+	// hidden path authorization decisions are appended. If empty, no audit
+	// records are written to a file.
+	HiddenPathsAuditFile string `toml:"hidden_paths_audit_file,omitempty"`
+	// HiddenPathsAuditSyslog specifies whether audit records of hidden path
+	// authorization decisions are sent to the local syslog daemon.
+	HiddenPathsAuditSyslog bool `toml:"hidden_paths_audit_syslog,omitempty"`
+	// HiddenPathsFederation enables the federation of hidden segment lookups.
+	// If a registry has no segments for a lookup, it forwards the lookup to
+	// the other registries of the requested groups.
+	HiddenPathsFederation bool `toml:"hidden_paths_federation,omitempty"`
+	// HiddenPathsFederationCacheTTL is the duration for which the results of
+	// federated hidden segment lookups are cached.
+	HiddenPathsFederationCacheTTL util.DurWrap `toml:"hidden_paths_federation_cache_ttl,omitempty"`
+	// HiddenPathsRegistrySelection defines at which registries of a hidden
+	// path group a writer registers its segments. If it is the empty string,
+	// the segments are registered at all registries.
+	HiddenPathsRegistrySelection RegistrySelection `toml:"hidden_paths_registry_selection,omitempty"`
+	// HiddenPathsRegistryState specifies the file in which the failover order
+	// of the registries is persisted. If empty, the order is not persisted.
+	HiddenPathsRegistryState string `toml:"hidden_paths_registry_state,omitempty"`
+	// HiddenPathsRegistryProbeInterval is the interval in which the
+	// reachability of the registries is probed in the failover selection.
+	HiddenPathsRegistryProbeInterval util.DurWrap `toml:"hidden_paths_registry_probe_interval,omitempty"`
+	// HiddenPathsSegmentQuota is the maximum number of hidden segments that a
+	// registry stores per hidden path group and writer AS. If a writer exceeds
+	// the quota, its least recently registered segments are evicted. If zero,
+	// the number of segments is not limited.
+	HiddenPathsSegmentQuota int `toml:"hidden_paths_segment_quota,omitempty"`
+	// MinSegmentValidity is the minimum remaining validity of the segments
+	// that are served to segment requests. Segments that expire earlier are
+	// not served. If zero, all segments that have not yet expired are served.
+	MinSegmentValidity util.DurWrap `toml:"min_segment_validity,omitempty"`
+	// SegmentLookupACL specifies the file name of the ACL that restricts which
+	// ASes may look up the segments to a destination AS. It is only enforced
+	// by core control services. If SegmentLookupACL begins with http:// or
+	// https://, it will be fetched over the network from the specified URL
+	// instead.
+	SegmentLookupACL string `toml:"segment_lookup_acl,omitempty"`
+	// MaxSegmentPageSize is the maximum number of segments in a response to a
+	// segment request. If zero, the number of segments is not limited.
+	MaxSegmentPageSize int `toml:"max_segment_page_size,omitempty"`
+	// SegmentPageSize is the number of segments that are requested per page
+	// from remote control services. If zero, the remote decides.
+	SegmentPageSize int `toml:"segment_page_size,omitempty"`
+	// SegmentLookupCompression enables the gzip compression of the segment
+	// requests to remote control services.
+	SegmentLookupCompression bool `toml:"segment_lookup_compression,omitempty"`
 }
 
 func (cfg *PSConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	if cfg.HiddenPathsFederationCacheTTL.Duration == 0 {
+		cfg.HiddenPathsFederationCacheTTL.Duration = DefaultHiddenPathsFederationCacheTTL
+	}
+	if cfg.HiddenPathsRegistrySelection == "" {
+		cfg.HiddenPathsRegistrySelection = RegistrySelectionAll
+	}
+	if cfg.HiddenPathsRegistryProbeInterval.Duration == 0 {
+		cfg.HiddenPathsRegistryProbeInterval.Duration = DefaultHiddenPathsRegistryProbeInterval
+	}
 }
 
 func (cfg *PSConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("query_interval must not be zero")
 	}
+	switch strings.ToLower(string(cfg.HiddenPathsRegistrySelection)) {
+	case string(RegistrySelectionAll):
+		cfg.HiddenPathsRegistrySelection = RegistrySelectionAll
+	case string(RegistrySelectionFailover):
+		cfg.HiddenPathsRegistrySelection = RegistrySelectionFailover
+	default:
+		return serrors.New("unknown hidden path registry selection",
+			"selection", cfg.HiddenPathsRegistrySelection)
+	}
+	if cfg.HiddenPathsRegistryProbeInterval.Duration < 0 {
+		return serrors.New("hidden_paths_registry_probe_interval must not be negative",
+			"value", cfg.HiddenPathsRegistryProbeInterval)
+	}
+	if cfg.HiddenPathsSegmentQuota < 0 {
+		return serrors.New("hidden_paths_segment_quota must not be negative",
+			"value", cfg.HiddenPathsSegmentQuota)
+	}
+	if cfg.HiddenPathsFederationCacheTTL.Duration < 0 {
+		return serrors.New("hidden_paths_federation_cache_ttl must not be negative",
+			"value", cfg.HiddenPathsFederationCacheTTL)
+	}
+	if cfg.MinSegmentValidity.Duration < 0 {
+		return serrors.New("min_segment_validity must not be negative",
+			"value", cfg.MinSegmentValidity)
+	}
+	if cfg.MaxSegmentPageSize < 0 {
+		return serrors.New("max_segment_page_size must not be negative",
+			"value", cfg.MaxSegmentPageSize)
+	}
+	if cfg.SegmentPageSize < 0 {
+		return serrors.New("segment_page_size must not be negative",
+			"value", cfg.SegmentPageSize)
+	}
 	return nil
 }
 
@@ -225,10 +560,299 @@ func (cfg *PSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap)
 	config.WriteString(dst, psSample)
 }
 
+// RegistrySelection defines at which registries of a hidden path group a
+// writer registers its segments.
+type RegistrySelection string
+
+const (
+	// RegistrySelectionAll registers the segments at all registries.
+	RegistrySelectionAll RegistrySelection = "all"
+	// RegistrySelectionFailover registers the segments at the best registry
+	// and only fails over to the next one if the registration fails.
+	RegistrySelectionFailover RegistrySelection = "failover"
+)
+
 func (cfg *PSConfig) ConfigName() string {
 	return "path"
 }
 
+var _ config.Config = (*ColibriConfig)(nil)
+
+// ColibriConfig is the configuration of the experimental COLIBRI bandwidth
+// reservation service.
+type ColibriConfig struct {
+	// Enabled enables the COLIBRI reservation service.
+	Enabled bool `toml:"enabled,omitempty"`
+	// InterfaceCapacity is the bandwidth in Kbit/s that can be reserved on
+	// each interface.
+	InterfaceCapacity uint64 `toml:"interface_capacity,omitempty"`
+}
+
+func (cfg *ColibriConfig) InitDefaults() {
+	if cfg.InterfaceCapacity == 0 {
+		cfg.InterfaceCapacity = DefaultColibriInterfaceCapacity
+	}
+}
+
+func (cfg *ColibriConfig) Validate() error {
+	return nil
+}
+
+func (cfg *ColibriConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, colibriSample)
+}
+
+func (cfg *ColibriConfig) ConfigName() string {
+	return "colibri"
+}
+
+var _ config.Config = (*RenewalConfig)(nil)
+
+// RenewalConfig is the configuration of the automatic renewal of the AS
+// certificate.
+type RenewalConfig struct {
+	// Enabled enables the automatic renewal of the AS certificate.
+	Enabled bool `toml:"enabled,omitempty"`
+	// LeadTime is the time before the expiration of the AS certificate at
+	// which it is renewed.
+	LeadTime util.DurWrap `toml:"lead_time,omitempty"`
+}
+
+func (cfg *RenewalConfig) InitDefaults() {
+	initDurWrap(&cfg.LeadTime, DefaultRenewalLeadTime)
+}
+
+func (cfg *RenewalConfig) Validate() error {
+	if cfg.LeadTime.Duration <= 0 {
+		return serrors.New("lead_time must be positive", "value", cfg.LeadTime)
+	}
+	return nil
+}
+
+func (cfg *RenewalConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, renewalSample)
+}
+
+func (cfg *RenewalConfig) ConfigName() string {
+	return "renewal"
+}
+
+var _ config.Config = (*AdminConfig)(nil)
+
+// AdminConfig is the configuration of the admin gRPC API, which exposes the
+// internal state of the control service to operators.
+type AdminConfig struct {
+	// Addr is the TCP address the admin API is served on. If it is empty, the
+	// admin API is disabled. It must be a loopback address, because the API is
+	// served without TLS and the JWT tokens would be sent in clear text.
+	Addr string `toml:"addr,omitempty"`
+	// SharedSecret is the path to the PEM-encoded shared secret that is used
+	// to verify the JWT tokens of the requests.
+	SharedSecret string `toml:"shared_secret,omitempty"`
+}
+
+func (cfg *AdminConfig) InitDefaults() {}
+
+func (cfg *AdminConfig) Validate() error {
+	if cfg.Addr == "" {
+		return nil
+	}
+	if cfg.SharedSecret == "" {
+		return serrors.New("shared_secret must be set if the admin API is enabled")
+	}
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return serrors.WrapStr("parsing admin address", err, "addr", cfg.Addr)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return serrors.New("admin address must be a loopback address", "addr", cfg.Addr)
+	}
+	return nil
+}
+
+func (cfg *AdminConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, adminSample)
+}
+
+func (cfg *AdminConfig) ConfigName() string {
+	return "admin"
+}
+
+var _ config.Config = (*RPCLimitsConfig)(nil)
+
+// RPCLimitsConfig is the configuration of the limits on the requests to the
+// segment lookup, segment registration and trust material gRPC APIs.
+type RPCLimitsConfig struct {
+	// ClientRate is the number of requests per second a single client can
+	// send. If zero, the rate per client is not limited.
+	ClientRate float64 `toml:"client_rate,omitempty"`
+	// ClientBurst is the number of requests a single client can send in a
+	// burst above ClientRate. (default 20)
+	ClientBurst int `toml:"client_burst,omitempty"`
+	// GlobalRate is the number of requests per second of all clients
+	// together. If zero, the total rate is not limited.
+	GlobalRate float64 `toml:"global_rate,omitempty"`
+	// GlobalBurst is the number of requests that can be served in a burst
+	// above GlobalRate. (default 200)
+	GlobalBurst int `toml:"global_burst,omitempty"`
+	// MaxRequestSize is the maximum size of a request in bytes. It applies to
+	// all gRPC APIs of the control service. (default 4MiB)
+	MaxRequestSize int `toml:"max_request_size,omitempty"`
+}
+
+func (cfg *RPCLimitsConfig) InitDefaults() {
+	if cfg.ClientBurst == 0 {
+		cfg.ClientBurst = DefaultRPCClientBurst
+	}
+	if cfg.GlobalBurst == 0 {
+		cfg.GlobalBurst = DefaultRPCGlobalBurst
+	}
+	if cfg.MaxRequestSize == 0 {
+		cfg.MaxRequestSize = DefaultRPCMaxRequestSize
+	}
+}
+
+func (cfg *RPCLimitsConfig) Validate() error {
+	if cfg.ClientRate < 0 {
+		return serrors.New("client_rate must not be negative", "value", cfg.ClientRate)
+	}
+	if cfg.ClientBurst < 0 {
+		return serrors.New("client_burst must not be negative", "value", cfg.ClientBurst)
+	}
+	if cfg.GlobalRate < 0 {
+		return serrors.New("global_rate must not be negative", "value", cfg.GlobalRate)
+	}
+	if cfg.GlobalBurst < 0 {
+		return serrors.New("global_burst must not be negative", "value", cfg.GlobalBurst)
+	}
+	if cfg.MaxRequestSize < 0 {
+		return serrors.New("max_request_size must not be negative",
+			"value", cfg.MaxRequestSize)
+	}
+	return nil
+}
+
+func (cfg *RPCLimitsConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, rpcLimitsSample)
+}
+
+func (cfg *RPCLimitsConfig) ConfigName() string {
+	return "rpc_limits"
+}
+
+var _ config.Config = (*LeaseConfig)(nil)
+
+// LeaseConfig is the configuration of the lease that coordinates multiple
+// control service instances of the AS.
+type LeaseConfig struct {
+	// Enabled enables the lease. If disabled, the instance always originates,
+	// propagates and registers beacons.
+	Enabled bool `toml:"enabled,omitempty"`
+	// CheckInterval is the interval in which the preceding instances are
+	// checked. (default 2s)
+	CheckInterval util.DurWrap `toml:"check_interval,omitempty"`
+	// Duration is the time during which no preceding instance must be serving
+	// before the lease is taken. (default 10s)
+	Duration util.DurWrap `toml:"duration,omitempty"`
+}
+
+func (cfg *LeaseConfig) InitDefaults() {
+	if cfg.CheckInterval.Duration == 0 {
+		cfg.CheckInterval.Duration = DefaultLeaseCheckInterval
+	}
+	if cfg.Duration.Duration == 0 {
+		cfg.Duration.Duration = DefaultLeaseDuration
+	}
+}
+
+func (cfg *LeaseConfig) Validate() error {
+	if cfg.CheckInterval.Duration <= 0 {
+		return serrors.New("check_interval must be positive",
+			"value", cfg.CheckInterval.Duration)
+	}
+	if cfg.Duration.Duration <= cfg.CheckInterval.Duration {
+		return serrors.New("duration must be larger than check_interval",
+			"duration", cfg.Duration.Duration, "check_interval", cfg.CheckInterval.Duration)
+	}
+	return nil
+}
+
+func (cfg *LeaseConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, leaseSample)
+}
+
+func (cfg *LeaseConfig) ConfigName() string {
+	return "lease"
+}
+
+// KeysBackend is the backend that holds the private keys.
+type KeysBackend string
+
+const (
+	// KeysFile loads the private keys from the PEM files in the crypto
+	// directories of the configuration directory.
+	KeysFile KeysBackend = "file"
+	// KeysPKCS11 uses the private keys held in a PKCS#11 token.
+	KeysPKCS11 KeysBackend = "pkcs11"
+)
+
+var _ config.Config = (*KeysConfig)(nil)
+
+// KeysConfig is the configuration of the backend that holds the private keys
+// used to sign control-plane messages and, in case of an in-process CA, AS
+// certificates.
+type KeysConfig struct {
+	// Backend is the backend that holds the private keys. (default file)
+	Backend KeysBackend `toml:"backend,omitempty"`
+	// PKCS11Module is the path to the PKCS#11 module of the token.
+	PKCS11Module string `toml:"pkcs11_module,omitempty"`
+	// PKCS11Token is the label of the PKCS#11 token.
+	PKCS11Token string `toml:"pkcs11_token,omitempty"`
+	// PKCS11PINFile is the path to the file that contains the user PIN of the
+	// PKCS#11 token.
+	PKCS11PINFile string `toml:"pkcs11_pin_file,omitempty"`
+	// ASKeyLabel is the label of the AS signing keys in the PKCS#11 token.
+	// (default cp-as)
+	ASKeyLabel string `toml:"as_key_label,omitempty"`
+	// CAKeyLabel is the label of the CA signing keys in the PKCS#11 token.
+	// (default cp-ca)
+	CAKeyLabel string `toml:"ca_key_label,omitempty"`
+}
+
+func (cfg *KeysConfig) InitDefaults() {
+	if cfg.Backend == "" {
+		cfg.Backend = KeysFile
+	}
+	if cfg.ASKeyLabel == "" {
+		cfg.ASKeyLabel = DefaultASKeyLabel
+	}
+	if cfg.CAKeyLabel == "" {
+		cfg.CAKeyLabel = DefaultCAKeyLabel
+	}
+}
+
+func (cfg *KeysConfig) Validate() error {
+	switch cfg.Backend {
+	case KeysFile:
+	case KeysPKCS11:
+		if cfg.PKCS11Module == "" || cfg.PKCS11Token == "" || cfg.PKCS11PINFile == "" {
+			return serrors.New("pkcs11_module, pkcs11_token and pkcs11_pin_file must be " +
+				"set for the pkcs11 backend")
+		}
+	default:
+		return serrors.New("unknown keys backend", "backend", cfg.Backend)
+	}
+	return nil
+}
+
+func (cfg *KeysConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, keysSample)
+}
+
+func (cfg *KeysConfig) ConfigName() string {
+	return "keys"
+}
+
 var _ config.Config = (*Policies)(nil)
 
 // Policies contains the file paths of the policies.
@@ -250,6 +874,10 @@ type Policies struct {
 	// If this is the empty string, the default policy is used. In a core beacon
 	// server, this field is ignored.
 	DownRegistration string `toml:"down_registration,omitempty"`
+	// RegistrationFilter contains the file path for the registration filter.
+	// If this is the empty string, no segments are excluded from
+	// registration. The file is reloaded on SIGHUP.
+	RegistrationFilter string `toml:"registration_filter,omitempty"`
 }
 
 // Sample generates a sample for the beacon server specific configuration.
@@ -17,11 +17,15 @@ package config
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/pelletier/go-toml"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/control/ifstate"
 	"github.com/scionproto/scion/pkg/log/logtest"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/private/config/configtest"
 	"github.com/scionproto/scion/private/env/envtest"
 	"github.com/scionproto/scion/private/mgmtapi/jwtauth"
 	apitest "github.com/scionproto/scion/private/mgmtapi/mgmtapitest"
@@ -37,18 +41,254 @@ func TestConfigSample(t *testing.T) {
 	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
 	assert.NoError(t, err)
 	CheckTestConfig(t, &cfg, idSample)
+	configtest.CheckSampleCoverage(t, &cfg)
+}
+
+func TestBSConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		jitter    time.Duration
+		lifetime  time.Duration
+		rotation  time.Duration
+		macAlg    scrypto.MACAlgorithm
+		macEpoch  uint64
+		backoff   [2]time.Duration
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"defaults": {
+			assertErr: assert.NoError,
+		},
+		"jitter and lifetime": {
+			jitter:    time.Second,
+			lifetime:  6 * time.Hour,
+			assertErr: assert.NoError,
+		},
+		"negative jitter": {
+			jitter:    -time.Second,
+			assertErr: assert.Error,
+		},
+		"lifetime too short": {
+			lifetime:  time.Minute,
+			assertErr: assert.Error,
+		},
+		"lifetime too long": {
+			lifetime:  25 * time.Hour,
+			assertErr: assert.Error,
+		},
+		"key rotation": {
+			rotation:  48 * time.Hour,
+			assertErr: assert.NoError,
+		},
+		"key rotation too short": {
+			rotation:  24 * time.Hour,
+			assertErr: assert.Error,
+		},
+		"mac algorithm": {
+			macAlg:    scrypto.MACAlgorithmHMACSHA256,
+			assertErr: assert.NoError,
+		},
+		"unknown mac algorithm": {
+			macAlg:    "md5",
+			assertErr: assert.Error,
+		},
+		"mac epoch": {
+			rotation:  48 * time.Hour,
+			macAlg:    scrypto.MACAlgorithmHMACSHA256,
+			macEpoch:  10000,
+			assertErr: assert.NoError,
+		},
+		"mac epoch without key rotation": {
+			macAlg:    scrypto.MACAlgorithmHMACSHA256,
+			macEpoch:  10000,
+			assertErr: assert.Error,
+		},
+		"core registration backoff": {
+			backoff:   [2]time.Duration{time.Second, time.Minute},
+			assertErr: assert.NoError,
+		},
+		"core registration initial backoff exceeds max": {
+			backoff:   [2]time.Duration{time.Minute, time.Second},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var cfg BSConfig
+			cfg.RegistrationJitter.Duration = tc.jitter
+			cfg.SegmentLifetime.Duration = tc.lifetime
+			cfg.HopFieldKeyRotation.Duration = tc.rotation
+			cfg.HopFieldMACAlgorithm = tc.macAlg
+			cfg.HopFieldMACEpoch = tc.macEpoch
+			cfg.RemoteCoreRegistration.InitialBackoff.Duration = tc.backoff[0]
+			cfg.RemoteCoreRegistration.MaxBackoff.Duration = tc.backoff[1]
+			tc.assertErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestLeaseConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		checkInterval time.Duration
+		duration      time.Duration
+		assertErr     assert.ErrorAssertionFunc
+	}{
+		"defaults": {
+			assertErr: assert.NoError,
+		},
+		"duration larger than check interval": {
+			checkInterval: time.Second,
+			duration:      3 * time.Second,
+			assertErr:     assert.NoError,
+		},
+		"duration equal to check interval": {
+			checkInterval: 5 * time.Second,
+			duration:      5 * time.Second,
+			assertErr:     assert.Error,
+		},
+		"negative check interval": {
+			checkInterval: -time.Second,
+			assertErr:     assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var cfg LeaseConfig
+			cfg.CheckInterval.Duration = tc.checkInterval
+			cfg.Duration.Duration = tc.duration
+			cfg.InitDefaults()
+			tc.assertErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestAdminConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		cfg       AdminConfig
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"disabled": {
+			assertErr: assert.NoError,
+		},
+		"loopback IPv4": {
+			cfg:       AdminConfig{Addr: "127.0.0.1:30454", SharedSecret: "secret"},
+			assertErr: assert.NoError,
+		},
+		"loopback IPv6": {
+			cfg:       AdminConfig{Addr: "[::1]:30454", SharedSecret: "secret"},
+			assertErr: assert.NoError,
+		},
+		"localhost": {
+			cfg:       AdminConfig{Addr: "localhost:30454", SharedSecret: "secret"},
+			assertErr: assert.NoError,
+		},
+		"no shared secret": {
+			cfg:       AdminConfig{Addr: "127.0.0.1:30454"},
+			assertErr: assert.Error,
+		},
+		"all interfaces": {
+			cfg:       AdminConfig{Addr: ":30454", SharedSecret: "secret"},
+			assertErr: assert.Error,
+		},
+		"non-loopback": {
+			cfg:       AdminConfig{Addr: "192.0.2.1:30454", SharedSecret: "secret"},
+			assertErr: assert.Error,
+		},
+		"host name": {
+			cfg:       AdminConfig{Addr: "cs.example.org:30454", SharedSecret: "secret"},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tc.assertErr(t, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestKeysConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		cfg       KeysConfig
+		renewal   bool
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"defaults": {
+			assertErr: assert.NoError,
+		},
+		"pkcs11": {
+			cfg: KeysConfig{
+				Backend:       KeysPKCS11,
+				PKCS11Module:  "/usr/lib/softhsm/libsofthsm2.so",
+				PKCS11Token:   "scion",
+				PKCS11PINFile: "pin",
+			},
+			assertErr: assert.NoError,
+		},
+		"pkcs11 without token": {
+			cfg: KeysConfig{
+				Backend:       KeysPKCS11,
+				PKCS11Module:  "/usr/lib/softhsm/libsofthsm2.so",
+				PKCS11PINFile: "pin",
+			},
+			assertErr: assert.Error,
+		},
+		"pkcs11 with renewal": {
+			cfg: KeysConfig{
+				Backend:       KeysPKCS11,
+				PKCS11Module:  "/usr/lib/softhsm/libsofthsm2.so",
+				PKCS11Token:   "scion",
+				PKCS11PINFile: "pin",
+			},
+			renewal:   true,
+			assertErr: assert.Error,
+		},
+		"unknown backend": {
+			cfg:       KeysConfig{Backend: "vault"},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := tc.cfg
+			cfg.InitDefaults()
+			err := cfg.Validate()
+			if err == nil {
+				err = validateKeysRenewal(&cfg, &RenewalConfig{Enabled: tc.renewal})
+			}
+			tc.assertErr(t, err)
+		})
+	}
 }
 
 func InitTestConfig(cfg *Config) {
 	apitest.InitConfig(&cfg.API)
 	envtest.InitTest(&cfg.General, &cfg.Metrics, &cfg.Tracing, nil)
+	envtest.InitTestGRPCTLS(&cfg.GRPCTLS)
 	logtest.InitTestLogging(&cfg.Logging)
 	InitTestBSConfig(&cfg.BS)
 	InitTestPSConfig(&cfg.PS)
 	InitTestCA(&cfg.CA)
+	InitTestColibri(&cfg.Colibri)
+	InitTestRenewal(&cfg.Renewal)
+	InitTestAdmin(&cfg.Admin)
+	InitTestRPCLimits(&cfg.RPCLimits)
+	InitTestLease(&cfg.Lease)
+	InitTestKeys(&cfg.Keys)
 }
 
 func InitTestBSConfig(cfg *BSConfig) {
+	cfg.RegistrationJitter.Duration = time.Hour
+	cfg.SegmentLifetime.Duration = time.Hour
+	cfg.HopFieldKeyRotation.Duration = 48 * time.Hour
+	cfg.HopFieldMACAlgorithm = scrypto.MACAlgorithmHMACSHA256
+	cfg.HopFieldMACPrevAlgorithm = scrypto.MACAlgorithmHMACSHA256
+	cfg.HopFieldMACEpoch = 10000
 	InitTestPolicies(&cfg.Policies)
 }
 
@@ -57,11 +297,13 @@ func InitTestPolicies(cfg *Policies) {
 	cfg.CoreRegistration = "test"
 	cfg.UpRegistration = "test"
 	cfg.DownRegistration = "test"
+	cfg.RegistrationFilter = "test"
 }
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	apitest.CheckConfig(t, &cfg.API)
 	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, &cfg.Tracing, nil, id)
+	envtest.CheckTestGRPCTLS(t, &cfg.GRPCTLS)
 	logtest.CheckTestLogging(t, &cfg.Logging, id)
 	storagetest.CheckTestTrustDBConfig(t, &cfg.TrustDB, id)
 	storagetest.CheckTestBeaconDBConfig(t, &cfg.BeaconDB, id)
@@ -69,13 +311,40 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	CheckTestBSConfig(t, &cfg.BS)
 	CheckTestPSConfig(t, &cfg.PS, id)
 	CheckTestCA(t, &cfg.CA)
+	CheckTestColibri(t, &cfg.Colibri)
+	CheckTestRenewal(t, &cfg.Renewal)
+	CheckTestAdmin(t, &cfg.Admin)
+	CheckTestRPCLimits(t, &cfg.RPCLimits)
+	CheckTestLease(t, &cfg.Lease)
+	CheckTestKeys(t, &cfg.Keys)
 }
 
 func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
 	assert.Equal(t, DefaultOriginationInterval, cfg.OriginationInterval.Duration)
 	assert.Equal(t, DefaultPropagationInterval, cfg.PropagationInterval.Duration)
 	assert.Equal(t, DefaultRegistrationInterval, cfg.RegistrationInterval.Duration)
+	assert.Zero(t, cfg.RegistrationJitter.Duration)
+	assert.Zero(t, cfg.SegmentLifetime.Duration)
+	assert.Equal(t, DefaultMaxBeaconsPerOrigin, cfg.MaxBeaconsPerOrigin)
+	assert.Equal(t, DefaultMaxBeacons, cfg.MaxBeacons)
+	assert.Zero(t, cfg.HopFieldKeyRotation.Duration)
+	assert.Equal(t, scrypto.MACAlgorithmAESCMAC, cfg.HopFieldMACAlgorithm)
+	assert.Equal(t, scrypto.MACAlgorithmAESCMAC, cfg.HopFieldMACPrevAlgorithm)
+	assert.Zero(t, cfg.HopFieldMACEpoch)
+	assert.Zero(t, cfg.MaxBeaconsPerInterface)
+	assert.False(t, cfg.InterfaceLiveness)
+	assert.Equal(t, ifstate.DefaultLivenessInterval, cfg.LivenessInterval.Duration)
+	assert.Equal(t, ifstate.DefaultLivenessTimeout, cfg.LivenessTimeout.Duration)
 	CheckTestPolicies(t, &cfg.Policies)
+	CheckTestRemoteCoreRegistration(t, &cfg.RemoteCoreRegistration)
+}
+
+func CheckTestRemoteCoreRegistration(t *testing.T, cfg *RemoteCoreRegistration) {
+	assert.Empty(t, cfg.Remotes)
+	assert.Zero(t, cfg.Interval.Duration)
+	assert.Zero(t, cfg.Parallelism)
+	assert.Equal(t, 5*time.Second, cfg.InitialBackoff.Duration)
+	assert.Equal(t, 5*time.Minute, cfg.MaxBackoff.Duration)
 }
 
 func CheckTestPolicies(t *testing.T, cfg *Policies) {
@@ -83,15 +352,40 @@ func CheckTestPolicies(t *testing.T, cfg *Policies) {
 	assert.Empty(t, cfg.CoreRegistration)
 	assert.Empty(t, cfg.UpRegistration)
 	assert.Empty(t, cfg.DownRegistration)
+	assert.Empty(t, cfg.RegistrationFilter)
 }
 
 func InitTestPSConfig(cfg *PSConfig) {
 	cfg.HiddenPathsCfg = "garbage"
+	cfg.HiddenPathsFederation = true
+	cfg.HiddenPathsFederationCacheTTL.Duration = time.Hour
+	cfg.HiddenPathsRegistrySelection = RegistrySelectionFailover
+	cfg.HiddenPathsRegistryState = "garbage"
+	cfg.HiddenPathsRegistryProbeInterval.Duration = time.Hour
+	cfg.HiddenPathsSegmentQuota = 42
+	cfg.MinSegmentValidity.Duration = time.Hour
+	cfg.SegmentLookupACL = "garbage"
+	cfg.MaxSegmentPageSize = 42
+	cfg.SegmentPageSize = 42
+	cfg.SegmentLookupCompression = true
 }
 
 func CheckTestPSConfig(t *testing.T, cfg *PSConfig, id string) {
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
 	assert.Empty(t, cfg.HiddenPathsCfg)
+	assert.False(t, cfg.HiddenPathsFederation)
+	assert.Equal(t, DefaultHiddenPathsFederationCacheTTL,
+		cfg.HiddenPathsFederationCacheTTL.Duration)
+	assert.Equal(t, RegistrySelectionAll, cfg.HiddenPathsRegistrySelection)
+	assert.Empty(t, cfg.HiddenPathsRegistryState)
+	assert.Equal(t, DefaultHiddenPathsRegistryProbeInterval,
+		cfg.HiddenPathsRegistryProbeInterval.Duration)
+	assert.Zero(t, cfg.HiddenPathsSegmentQuota)
+	assert.Zero(t, cfg.MinSegmentValidity.Duration)
+	assert.Empty(t, cfg.SegmentLookupACL)
+	assert.Zero(t, cfg.MaxSegmentPageSize)
+	assert.Zero(t, cfg.SegmentPageSize)
+	assert.False(t, cfg.SegmentLookupCompression)
 }
 
 func InitTestCA(cfg *CA) {
@@ -109,3 +403,69 @@ func CheckTestService(t *testing.T, cfg *CAService) {
 	assert.Equal(t, jwtauth.DefaultTokenLifetime, cfg.Lifetime.Duration)
 	assert.Empty(t, cfg.ClientID)
 }
+
+func InitTestColibri(cfg *ColibriConfig) {
+	cfg.Enabled = true
+}
+
+func CheckTestColibri(t *testing.T, cfg *ColibriConfig) {
+	assert.False(t, cfg.Enabled)
+	assert.EqualValues(t, DefaultColibriInterfaceCapacity, cfg.InterfaceCapacity)
+}
+
+func InitTestRenewal(cfg *RenewalConfig) {
+	cfg.Enabled = true
+}
+
+func CheckTestRenewal(t *testing.T, cfg *RenewalConfig) {
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, DefaultRenewalLeadTime, cfg.LeadTime.Duration)
+}
+
+func InitTestAdmin(cfg *AdminConfig) {
+	cfg.Addr = "garbage"
+	cfg.SharedSecret = "garbage"
+}
+
+func CheckTestAdmin(t *testing.T, cfg *AdminConfig) {
+	assert.Empty(t, cfg.Addr)
+	assert.Empty(t, cfg.SharedSecret)
+}
+
+func InitTestRPCLimits(cfg *RPCLimitsConfig) {
+	cfg.ClientRate = 42
+	cfg.GlobalRate = 42
+}
+
+func CheckTestRPCLimits(t *testing.T, cfg *RPCLimitsConfig) {
+	assert.Zero(t, cfg.ClientRate)
+	assert.Equal(t, DefaultRPCClientBurst, cfg.ClientBurst)
+	assert.Zero(t, cfg.GlobalRate)
+	assert.Equal(t, DefaultRPCGlobalBurst, cfg.GlobalBurst)
+	assert.Equal(t, DefaultRPCMaxRequestSize, cfg.MaxRequestSize)
+}
+
+func InitTestLease(cfg *LeaseConfig) {
+	cfg.Enabled = true
+}
+
+func CheckTestLease(t *testing.T, cfg *LeaseConfig) {
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, DefaultLeaseCheckInterval, cfg.CheckInterval.Duration)
+	assert.Equal(t, DefaultLeaseDuration, cfg.Duration.Duration)
+}
+
+func InitTestKeys(cfg *KeysConfig) {
+	cfg.Backend = KeysPKCS11
+	cfg.PKCS11Module = "garbage"
+	cfg.ASKeyLabel = "garbage"
+}
+
+func CheckTestKeys(t *testing.T, cfg *KeysConfig) {
+	assert.Equal(t, KeysFile, cfg.Backend)
+	assert.Empty(t, cfg.PKCS11Module)
+	assert.Empty(t, cfg.PKCS11Token)
+	assert.Empty(t, cfg.PKCS11PINFile)
+	assert.Equal(t, DefaultASKeyLabel, cfg.ASKeyLabel)
+	assert.Equal(t, DefaultCAKeyLabel, cfg.CAKeyLabel)
+}
@@ -23,6 +23,56 @@ query_interval = "5m"
 # paths functionality is not enabled. If the path starts with http:// or
 # https:// the configuration is fetched from the given URL. (default: "")
 hidden_paths_cfg = ""
+# The file to which audit records of hidden path authorization decisions are
+# appended as JSON lines. If the path is empty, no audit file is written.
+# (default: "")
+hidden_paths_audit_file = ""
+# Whether audit records of hidden path authorization decisions are sent to the
+# local syslog daemon. (default: false)
+hidden_paths_audit_syslog = false
+# Whether hidden segment lookups are federated between registries. If enabled,
+# a registry that has no segments for a lookup forwards it to the other
+# registries of the requested groups. (default: false)
+hidden_paths_federation = false
+# The duration for which the results of federated hidden segment lookups are
+# cached. (default: 1m)
+hidden_paths_federation_cache_ttl = "1m"
+# The registries of a hidden path group at which the segments of a writer are
+# registered. Either "all" to register at every registry, or "failover" to
+# register at the best registry, based on probes and load hints, and only fail
+# over to the next one if the registration fails. (default: all)
+hidden_paths_registry_selection = "all"
+# The file in which the failover order of the registries is persisted. If the
+# path is empty, the order is not persisted. (default: "")
+hidden_paths_registry_state = ""
+# The interval in which the reachability of the registries is probed with the
+# failover selection. (default: 1m)
+hidden_paths_registry_probe_interval = "1m"
+# The maximum number of hidden segments that a registry stores per hidden path
+# group and writer AS. If a writer exceeds the quota, its least recently
+# registered segments are evicted. If zero, the number of segments is not
+# limited. (default: 0)
+hidden_paths_segment_quota = 0
+# The minimum remaining validity of the segments that are served to segment
+# requests. Segments that expire earlier are not served. If zero, all segments
+# that have not yet expired are served. (default: 0s)
+min_segment_validity = "0s"
+# The path to the ACL that restricts which ASes may look up the segments to a
+# destination AS. It is only enforced by core control services. If the path is
+# empty, every AS may look up all segments. If the path starts with http:// or
+# https:// the ACL is fetched from the given URL. (default: "")
+segment_lookup_acl = ""
+# The maximum number of segments in a response to a segment request. Larger
+# results are returned in multiple pages. Clients that do not support
+# pagination only receive the first page. If zero, the number of segments is
+# not limited. (default: 0)
+max_segment_page_size = 0
+# The number of segments that are requested per page from remote control
+# services. If zero, the remote control service decides. (default: 0)
+segment_page_size = 0
+# Whether the segment requests to remote control services and their responses
+# are compressed with gzip. (default: false)
+segment_lookup_compression = false
 `
 
 const caSample = `
@@ -64,6 +114,94 @@ lifetime = "10m"
 client_id = ""
 `
 
+const colibriSample = `
+# Whether the experimental COLIBRI bandwidth reservation service is enabled.
+# (default false)
+enabled = false
+# The bandwidth in Kbit/s that can be reserved on each interface.
+# (default 1000000)
+interface_capacity = 1000000
+`
+
+const renewalSample = `
+# Whether the AS certificate is renewed automatically. If enabled, the control
+# service requests a new certificate from the CA that issued the current one
+# before the current certificate expires. (default false)
+enabled = false
+# The time before the expiration of the AS certificate at which it is renewed.
+# It should be well below the validity period of the AS certificates issued by
+# the CA. (default 24h)
+lead_time = "24h"
+`
+
+const adminSample = `
+# The TCP address the admin gRPC API is served on, e.g., "127.0.0.1:30454".
+# The admin API exposes the internal state of the control service. It is served
+# without TLS, thus, the address must be a loopback address. If it is empty,
+# the admin API is disabled. (default "")
+addr = ""
+# The path to the PEM-encoded shared secret that is used to verify the JWT
+# tokens of the admin API requests. It must be set if the admin API is
+# enabled. (default "")
+shared_secret = ""
+`
+
+const rpcLimitsSample = `
+# The limits apply to the segment lookup, segment registration and trust
+# material requests. Requests above the limits are rejected with the gRPC
+# status RESOURCE_EXHAUSTED.
+#
+# The number of requests per second a single client, i.e., a single IP
+# address, can send. If zero, the rate per client is not limited. (default 0)
+client_rate = 0.0
+# The number of requests a single client can send in a burst above the rate
+# limit. (default 20)
+client_burst = 20
+# The number of requests per second of all clients together. If zero, the
+# total rate is not limited. (default 0)
+global_rate = 0.0
+# The number of requests that can be served in a burst above the total rate
+# limit. (default 200)
+global_burst = 200
+# The maximum size of a request in bytes. Larger requests are rejected. It
+# applies to all gRPC APIs of the control service. (default 4194304)
+max_request_size = 4194304
+`
+
+const leaseSample = `
+# Enable the lease that coordinates multiple control service instances of the
+# AS. The instances are ordered by their name in the topology, and only the
+# first instance that is serving originates, propagates and registers
+# beacons. (default false)
+enabled = false
+# The interval in which the preceding instances are checked. (default 2s)
+check_interval = "2s"
+# The time during which no preceding instance must be serving before the lease
+# is taken. It must be larger than the check interval. (default 10s)
+duration = "10s"
+`
+
+const keysSample = `
+# The backend that holds the private keys used to sign control-plane messages
+# and, with an in-process CA, AS certificates. Either "file", to load the keys
+# from the crypto directories in the configuration directory, or "pkcs11", to
+# use the keys held in a PKCS#11 token such as an HSM. (default "file")
+backend = "file"
+# The path to the PKCS#11 module of the token, i.e., the shared library
+# provided by the vendor. Required for the pkcs11 backend. (default "")
+pkcs11_module = ""
+# The label of the PKCS#11 token. Required for the pkcs11 backend.
+# (default "")
+pkcs11_token = ""
+# The path to the file that contains the user PIN of the PKCS#11 token.
+# Required for the pkcs11 backend. (default "")
+pkcs11_pin_file = ""
+# The label of the AS signing keys in the PKCS#11 token. (default "cp-as")
+as_key_label = "cp-as"
+# The label of the CA signing keys in the PKCS#11 token. (default "cp-ca")
+ca_key_label = "cp-ca"
+`
+
 const drkeySample = `
 # Number of distinct Level1Keys to be prefetched.
 prefetch_entries = 10000
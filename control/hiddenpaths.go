@@ -15,6 +15,8 @@
 package control
 
 import (
+	"time"
+
 	"google.golang.org/grpc"
 
 	beaconinggrpc "github.com/scionproto/scion/control/beaconing/grpc"
@@ -24,6 +26,7 @@ import (
 	hpgrpc "github.com/scionproto/scion/pkg/experimental/hiddenpath/grpc"
 	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
 	hspb "github.com/scionproto/scion/pkg/proto/hidden_segment"
 	"github.com/scionproto/scion/private/pathdb"
 	infra "github.com/scionproto/scion/private/segment/verifier"
@@ -39,40 +42,69 @@ type HiddenPathConfigurator struct {
 	FetcherConfig     segreq.FetcherConfig
 	IntraASTCPServer  *grpc.Server
 	InterASQUICServer *grpc.Server
+	// Auditor records the authorization decisions of the hidden path
+	// registration and authoritative lookup servers. It can be nil.
+	Auditor hiddenpath.Auditor
+	// Stats counts the authorization decisions per configured group. It is
+	// informed about the configured groups during setup. It can be nil.
+	Stats *hiddenpath.StatsAuditor
+	// Federation enables forwarding lookups for which the local registry has
+	// no segments to the other registries of the requested groups.
+	Federation bool
+	// FederationCacheTTL is the duration for which the results of federated
+	// lookups are cached. If zero, they are not cached.
+	FederationCacheTTL time.Duration
+	// RegistryFailover enables the selection of a single registry per group
+	// for the segment registrations of the writer. If it is false, the
+	// segments are registered at all registries.
+	RegistryFailover bool
+	// RegistryState is the file in which the failover order of the registries
+	// is persisted. If empty, the order is not persisted.
+	RegistryState string
+	// RegistryProbeInterval is the interval in which the reachability of the
+	// registries is probed.
+	RegistryProbeInterval time.Duration
+	// SegmentQuota is the maximum number of segments that the registry stores
+	// per group and writer AS. If zero, the number of segments is not limited.
+	SegmentQuota int
+	// SegmentEvictions counts the segments that are evicted because of the
+	// quota. It can be nil.
+	SegmentEvictions metrics.Counter
 }
 
 // Setup sets up the hidden paths servers using the configuration at the given
-// location. An empty location will not enable any hidden path behavior. It
+// location. An empty location will not enable any hidden path behavior, except
+// for serving the empty list of groups in which the local AS is a reader. It
 // returns the configuration for the hidden segment writer. The return value can
 // be nil if this AS isn't a writer.
 func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationCfg, error) {
-	if location == "" {
-		return nil, nil
-	}
 	groups, regPolicy, err := hiddenpath.LoadConfiguration(location)
 	if err != nil {
 		return nil, err
 	}
+	if c.Stats != nil {
+		c.Stats.SetGroups(groups)
+	}
+	// The groups are always served, such that the daemons in the AS can
+	// distinguish a control service without reader groups from an unreachable
+	// one.
+	hspb.RegisterHiddenSegmentGroupsServiceServer(c.IntraASTCPServer, hpgrpc.GroupsServer{
+		Groups:  groups,
+		LocalIA: c.LocalIA,
+	})
 	roles := groups.Roles(c.LocalIA)
 	if roles.None() {
 		return nil, nil
 	}
+	localAuth := c.localAuthServer(groups)
 	log.Info("Starting hidden path forward server")
 	hspb.RegisterHiddenSegmentLookupServiceServer(c.IntraASTCPServer, &hpgrpc.SegmentServer{
 		Lookup: hiddenpath.ForwardServer{
 			Groups:    groups,
-			LocalAuth: c.localAuthServer(groups),
+			LocalAuth: localAuth,
 			LocalIA:   c.LocalIA,
-			RPC: &hpgrpc.AuthoritativeRequester{
-				Dialer: c.Dialer,
-				Signer: c.Signer,
-			},
-			Resolver: hiddenpath.LookupResolver{
-				Router: segreq.NewRouter(c.FetcherConfig),
-				Discoverer: &hpgrpc.Discoverer{
-					Dialer: c.Dialer,
-				},
-			},
+			RPC:       c.authoritativeRequester(),
+			Resolver:  c.lookupResolver(),
 			Verifier: hiddenpath.VerifierAdapter{
 				Verifier: c.Verifier,
 			},
@@ -82,7 +114,7 @@ func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationC
 		log.Info("Starting hidden path authoritative and registration server")
 		hspb.RegisterAuthoritativeHiddenSegmentLookupServiceServer(c.InterASQUICServer,
 			&hpgrpc.AuthoritativeSegmentServer{
-				Lookup:   c.localAuthServer(groups),
+				Lookup:   localAuth,
 				Verifier: c.Verifier,
 			},
 		)
@@ -92,13 +124,20 @@ func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationC
 					Groups: groups,
 					DB: &hiddenpath.Storer{
 						DB: c.PathDB,
+						Quota: &hiddenpath.SegmentQuota{
+							MaxSegments: c.SegmentQuota,
+							Evictions:   c.SegmentEvictions,
+						},
 					},
 					Verifier: hiddenpath.VerifierAdapter{
 						Verifier: c.Verifier,
 					},
 					LocalIA: c.LocalIA,
+					Auditor: c.Auditor,
+					Replay:  &hiddenpath.ReplayFilter{},
 				},
 				Verifier: c.Verifier,
+				Load:     &hpgrpc.RegistrationLoad{},
 			},
 		)
 	}
@@ -106,7 +145,7 @@ func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationC
 		return nil, nil
 	}
 	log.Info("Using hidden path beacon writer")
-	return &HiddenPathRegistrationCfg{
+	cfg := &HiddenPathRegistrationCfg{
 		Policy: regPolicy,
 		Router: segreq.NewRouter(c.FetcherConfig),
 		Discoverer: &hpgrpc.Discoverer{
@@ -117,7 +156,57 @@ func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationC
 			RegularRegistration: beaconinggrpc.Registrar{Dialer: c.Dialer},
 			Signer:              c.Signer,
 		},
-	}, nil
+	}
+	if c.RegistryFailover {
+		selector, err := hiddenpath.NewRegistrySelector(c.RegistryState)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Using hidden path registry failover", "state", c.RegistryState)
+		cfg.Selector = selector
+		cfg.ProbeInterval = c.RegistryProbeInterval
+	}
+	return cfg, nil
+}
+
+// NewHiddenPathAuditor creates an auditor for hidden path authorization
+// decisions that writes to the configured sinks. If no sink is configured, nil
+// is returned. The returned cleanup function closes all sinks.
+func NewHiddenPathAuditor(file string, toSyslog bool) (hiddenpath.Auditor, func(), error) {
+	var sinks []*hiddenpath.WriterAuditor
+	cleanup := func() {
+		for _, s := range sinks {
+			if err := s.Close(); err != nil {
+				log.Info("Failed to close hidden path audit sink", "err", err)
+			}
+		}
+	}
+	if file != "" {
+		a, err := hiddenpath.NewFileAuditor(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		sinks = append(sinks, a)
+	}
+	if toSyslog {
+		a, err := hiddenpath.NewSyslogAuditor("scion-control")
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		sinks = append(sinks, a)
+	}
+	switch len(sinks) {
+	case 0:
+		return nil, cleanup, nil
+	case 1:
+		return sinks[0], cleanup, nil
+	}
+	auditor := make(hiddenpath.MultiAuditor, 0, len(sinks))
+	for _, s := range sinks {
+		auditor = append(auditor, s)
+	}
+	return auditor, cleanup, nil
 }
 
 func (c HiddenPathConfigurator) localAuthServer(groups hiddenpath.Groups) hiddenpath.Lookuper {
@@ -125,11 +214,45 @@ func (c HiddenPathConfigurator) localAuthServer(groups hiddenpath.Groups) hidden
 	if !roles.Registry {
 		return nil
 	}
-	return hiddenpath.AuthoritativeServer{
+	auth := hiddenpath.AuthoritativeServer{
 		Groups: groups,
 		DB: &hiddenpath.Storer{
 			DB: c.PathDB,
 		},
 		LocalIA: c.LocalIA,
+		Auditor: c.Auditor,
+	}
+	if !c.Federation {
+		return auth
+	}
+	log.Info("Federating hidden segment lookups", "cache_ttl", c.FederationCacheTTL)
+	return hiddenpath.FederatedServer{
+		Local:    auth,
+		Groups:   groups,
+		LocalIA:  c.LocalIA,
+		RPC:      c.authoritativeRequester(),
+		Resolver: c.lookupResolver(),
+		Verifier: hiddenpath.VerifierAdapter{
+			Verifier: c.Verifier,
+		},
+		Cache: &hiddenpath.FederationCache{
+			TTL: c.FederationCacheTTL,
+		},
+	}
+}
+
+func (c HiddenPathConfigurator) authoritativeRequester() hiddenpath.RPC {
+	return &hpgrpc.AuthoritativeRequester{
+		Dialer: c.Dialer,
+		Signer: c.Signer,
+	}
+}
+
+func (c HiddenPathConfigurator) lookupResolver() hiddenpath.AddressResolver {
+	return hiddenpath.LookupResolver{
+		Router: segreq.NewRouter(c.FetcherConfig),
+		Discoverer: &hpgrpc.Discoverer{
+			Dialer: c.Dialer,
+		},
 	}
 }
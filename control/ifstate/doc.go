@@ -21,14 +21,14 @@
 // calling the NewInterfaces constructor. The state of a specific interface is
 // stored in the Interface struct.
 //
-// # Revoker
+// # Liveness
 //
-// The revoker is a periodic task that revokes interfaces that have timed out
-// and renews revocations of already revoked interfaces. Create it with the
-// NewRevoker costructor.
+// Neighboring control services periodically exchange signed liveness messages
+// over one-hop paths. The LivenessSender task sends one message per neighbor AS
+// on all interfaces to that neighbor, and the LivenessHandler validates
+// received messages against the interface they entered the AS on and rejects
+// replayed messages. Interfaces without recent liveness messages are not Alive.
 //
-// # Handler
-//
-// The handler handles interface state requests. It can be instantiated with
-// the NewHandler constructor.
+// The LivenessRevoker task revokes interfaces that are not alive and renews the
+// revocations for as long as the interface stays down.
 package ifstate
@@ -0,0 +1,326 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpc implements the authenticated interface liveness exchange
+// between neighboring control services.
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/control/onehop"
+	"github.com/scionproto/scion/pkg/addr"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/pkg/snet"
+	infra "github.com/scionproto/scion/private/segment/verifier"
+)
+
+// LivenessHandler handles verified liveness messages.
+type LivenessHandler interface {
+	Handle(m ifstate.LivenessMessage) error
+}
+
+// LivenessServer handles gRPC interface liveness requests.
+type LivenessServer struct {
+	Handler  LivenessHandler
+	Verifier infra.Verifier
+}
+
+// InterfaceLiveness verifies the signed liveness message of a neighbor and
+// passes it to the handler.
+func (s LivenessServer) InterfaceLiveness(ctx context.Context,
+	req *cppb.InterfaceLivenessRequest) (*cppb.InterfaceLivenessResponse, error) {
+
+	logger := log.FromCtx(ctx)
+	gPeer, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, serrors.New("peer must exist")
+	}
+	p, ok := gPeer.Addr.(*snet.UDPAddr)
+	if !ok {
+		logger.Debug("peer must be *snet.UDPAddr", "actual", fmt.Sprintf("%T", gPeer.Addr))
+		return nil, status.Error(codes.InvalidArgument, "peer must be *snet.UDPAddr")
+	}
+	pathIngress, pathEgress, err := extractInterfaces(p.Path)
+	if err != nil {
+		logger.Debug("Failed to extract interfaces", "peer", p, "err", err)
+		return nil, status.Error(codes.InvalidArgument, "failed to extract interfaces")
+	}
+	msg, err := s.Verifier.WithIA(p.IA).WithServer(p).Verify(ctx, req.SignedRequest)
+	if err != nil {
+		logger.Debug("Failed to verify signature", "peer", p, "err", err)
+		return nil, status.Error(codes.Unauthenticated, "verifying signature")
+	}
+	var body cppb.InterfaceLivenessBody
+	if err := proto.Unmarshal(msg.Body, &body); err != nil {
+		logger.Debug("Failed to parse body", "peer", p, "err", err)
+		return nil, status.Error(codes.InvalidArgument, "parsing body")
+	}
+	link := findLink(body.Links, pathEgress)
+	if link == nil {
+		logger.Debug("No link for egress interface", "peer", p, "egress", pathEgress)
+		return nil, status.Error(codes.InvalidArgument, "no link for egress interface")
+	}
+	err = s.Handler.Handle(ifstate.LivenessMessage{
+		Peer:        p.IA,
+		PathIngress: pathIngress,
+		Egress:      uint16(link.EgressInterface),
+		Ingress:     uint16(link.IngressInterface),
+		Timestamp:   msg.Header.Timestamp,
+	})
+	if err != nil {
+		logger.Debug("Rejected liveness message", "peer", p, "err", err)
+		return nil, status.Error(codes.PermissionDenied, "rejected liveness message")
+	}
+	return &cppb.InterfaceLivenessResponse{}, nil
+}
+
+// extractInterfaces extracts the local ingress interface ID and the egress
+// interface ID of the neighbor from a one-hop path.
+func extractInterfaces(path snet.DataplanePath) (uint16, uint16, error) {
+	invertedPath, ok := path.(snet.RawReplyPath)
+	if !ok {
+		return 0, 0, serrors.New("unexpected path", "type", common.TypeOf(path))
+	}
+	rawScionPath, ok := invertedPath.Path.(*scion.Raw)
+	if !ok {
+		return 0, 0, serrors.New("unexpected path", "type", common.TypeOf(path))
+	}
+	if rawScionPath.PathMeta.CurrHF == 0 {
+		return 0, 0, serrors.New("no previous hop field")
+	}
+	hf, err := rawScionPath.GetCurrentHopField()
+	if err != nil {
+		return 0, 0, serrors.WrapStr("getting current hop field", err)
+	}
+	prev, err := rawScionPath.GetHopField(int(rawScionPath.PathMeta.CurrHF) - 1)
+	if err != nil {
+		return 0, 0, serrors.WrapStr("getting previous hop field", err)
+	}
+	return hf.ConsIngress, prev.ConsEgress, nil
+}
+
+// findLink returns the link with the given egress interface of the neighbor,
+// or nil if there is none.
+func findLink(links []*cppb.InterfaceLivenessLink,
+	egress uint16) *cppb.InterfaceLivenessLink {
+
+	for _, link := range links {
+		if link.EgressInterface == uint64(egress) {
+			return link
+		}
+	}
+	return nil
+}
+
+// Signer signs requests.
+type Signer interface {
+	// Sign signs the msg and returns a signed message.
+	Sign(ctx context.Context, msg []byte, associatedData ...[]byte) (*cryptopb.SignedMessage, error)
+}
+
+// DefaultLivenessConnMaxAge is the default maximum age of a cached connection
+// to a neighbor. The one-hop path of a connection is fixed when it is dialed,
+// thus, the connection is redialed before the hop field MAC becomes invalid.
+const DefaultLivenessConnMaxAge = time.Minute
+
+// LivenessSender sends signed liveness messages to neighboring control
+// services over one-hop paths. The connections to the neighbors are cached and
+// reused across calls.
+type LivenessSender struct {
+	// Dialer is used to dial the gRPC connection to the neighbor.
+	Dialer libgrpc.Dialer
+	Signer Signer
+	// ConnMaxAge is the maximum age of a cached connection. If zero,
+	// DefaultLivenessConnMaxAge is used.
+	ConnMaxAge time.Duration
+
+	mu sync.Mutex
+	// conns holds the cached connections per neighbor and local interface.
+	conns map[addr.IA]map[uint16]*livenessConn
+}
+
+type livenessConn struct {
+	conn    *grpc.ClientConn
+	nextHop netip.AddrPort
+	dialed  time.Time
+}
+
+// SendLiveness signs one liveness message for all interfaces to the neighbor
+// and sends it over each of the interfaces.
+func (s *LivenessSender) SendLiveness(ctx context.Context, neighbor addr.IA,
+	intfs []ifstate.InterfaceInfo) error {
+
+	links := make([]*cppb.InterfaceLivenessLink, 0, len(intfs))
+	for _, intf := range intfs {
+		links = append(links, &cppb.InterfaceLivenessLink{
+			EgressInterface:  uint64(intf.ID),
+			IngressInterface: uint64(intf.RemoteID),
+		})
+	}
+	body, err := proto.Marshal(&cppb.InterfaceLivenessBody{Links: links})
+	if err != nil {
+		return serrors.WrapStr("packing body", err)
+	}
+	signed, err := s.Signer.Sign(ctx, body)
+	if err != nil {
+		return serrors.WrapStr("signing liveness message", err)
+	}
+	s.dropRemoved(neighbor, intfs)
+	req := &cppb.InterfaceLivenessRequest{SignedRequest: signed}
+	errs := make([]error, len(intfs))
+	var wg sync.WaitGroup
+	for i, intf := range intfs {
+		i, intf := i, intf
+		wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			if err := s.send(ctx, intf, req); err != nil {
+				errs[i] = serrors.WithCtx(err, "interface_id", intf.ID)
+			}
+		}()
+	}
+	wg.Wait()
+	var list serrors.List
+	for _, err := range errs {
+		if err != nil {
+			list = append(list, err)
+		}
+	}
+	return list.ToError()
+}
+
+// Close closes all cached connections.
+func (s *LivenessSender) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conns := range s.conns {
+		for _, c := range conns {
+			c.conn.Close()
+		}
+	}
+	s.conns = nil
+	return nil
+}
+
+func (s *LivenessSender) send(ctx context.Context, intf ifstate.InterfaceInfo,
+	req *cppb.InterfaceLivenessRequest) error {
+
+	conn, err := s.conn(ctx, intf)
+	if err != nil {
+		return serrors.WrapStr("dialing gRPC conn", err)
+	}
+	client := cppb.NewInterfaceLivenessServiceClient(conn)
+	if _, err := client.InterfaceLiveness(ctx, req, libgrpc.RetryProfile...); err != nil {
+		// Drop the connection such that it is redialed on the next call.
+		s.drop(intf.IA, intf.ID, conn)
+		return err
+	}
+	return nil
+}
+
+// conn returns the cached connection for the interface, or dials a new one if
+// there is none, it is too old, or the next hop changed.
+func (s *LivenessSender) conn(ctx context.Context,
+	intf ifstate.InterfaceInfo) (*grpc.ClientConn, error) {
+
+	maxAge := s.ConnMaxAge
+	if maxAge == 0 {
+		maxAge = DefaultLivenessConnMaxAge
+	}
+	s.mu.Lock()
+	c, ok := s.conns[intf.IA][intf.ID]
+	s.mu.Unlock()
+	if ok && c.nextHop == intf.InternalAddr && time.Since(c.dialed) < maxAge {
+		return c.conn, nil
+	}
+	if ok {
+		s.drop(intf.IA, intf.ID, c.conn)
+	}
+	remote := &onehop.Addr{
+		IA:      intf.IA,
+		Egress:  intf.ID,
+		SVC:     addr.SvcCS,
+		NextHop: net.UDPAddrFromAddrPort(intf.InternalAddr),
+	}
+	conn, err := s.Dialer.Dial(ctx, remote)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conns == nil {
+		s.conns = make(map[addr.IA]map[uint16]*livenessConn)
+	}
+	if s.conns[intf.IA] == nil {
+		s.conns[intf.IA] = make(map[uint16]*livenessConn)
+	}
+	s.conns[intf.IA][intf.ID] = &livenessConn{
+		conn:    conn,
+		nextHop: intf.InternalAddr,
+		dialed:  time.Now(),
+	}
+	return conn, nil
+}
+
+// drop closes and removes the cached connection, if it is still cached.
+func (s *LivenessSender) drop(ia addr.IA, ifID uint16, conn *grpc.ClientConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.conns[ia][ifID]; ok && c.conn == conn {
+		delete(s.conns[ia], ifID)
+	}
+	conn.Close()
+}
+
+// dropRemoved closes and removes the cached connections to the neighbor that
+// are not used by any of the interfaces anymore.
+func (s *LivenessSender) dropRemoved(neighbor addr.IA, intfs []ifstate.InterfaceInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ifID, c := range s.conns[neighbor] {
+		if !containsInterface(intfs, ifID) {
+			c.conn.Close()
+			delete(s.conns[neighbor], ifID)
+		}
+	}
+}
+
+func containsInterface(intfs []ifstate.InterfaceInfo, ifID uint16) bool {
+	for _, intf := range intfs {
+		if intf.ID == ifID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/control/ifstate"
+	ifstategrpc "github.com/scionproto/scion/control/ifstate/grpc"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
+)
+
+func TestLivenessSenderSendLiveness(t *testing.T) {
+	peer := xtest.MustParseIA("1-ff00:0:111")
+	intfs := []ifstate.InterfaceInfo{
+		{
+			ID:           1,
+			IA:           peer,
+			RemoteID:     11,
+			InternalAddr: netip.MustParseAddrPort("127.0.0.1:30001"),
+		},
+		{
+			ID:           2,
+			IA:           peer,
+			RemoteID:     22,
+			InternalAddr: netip.MustParseAddrPort("127.0.0.1:30002"),
+		},
+	}
+
+	svc := xtest.NewGRPCService()
+	server := &recordingLivenessServer{}
+	cppb.RegisterInterfaceLivenessServiceServer(svc.Server(), server)
+	svc.Start(t)
+	dialer := &countingDialer{Dialer: svc}
+	s := &ifstategrpc.LivenessSender{
+		Dialer: dialer,
+		Signer: bodySigner{},
+	}
+	defer s.Close()
+	ctx := context.Background()
+
+	// One signed message covers all links and is sent over each of them.
+	require.NoError(t, s.SendLiveness(ctx, peer, intfs))
+	assert.Equal(t, 2, dialer.Count())
+	bodies := server.take()
+	require.Len(t, bodies, 2)
+	for _, body := range bodies {
+		require.Len(t, body.Links, 2)
+		assert.EqualValues(t, 1, body.Links[0].EgressInterface)
+		assert.EqualValues(t, 11, body.Links[0].IngressInterface)
+		assert.EqualValues(t, 2, body.Links[1].EgressInterface)
+		assert.EqualValues(t, 22, body.Links[1].IngressInterface)
+	}
+
+	// The connections are reused.
+	require.NoError(t, s.SendLiveness(ctx, peer, intfs))
+	assert.Equal(t, 2, dialer.Count())
+	assert.Len(t, server.take(), 2)
+
+	// A connection is redialed if the next hop changes.
+	intfs[1].InternalAddr = netip.MustParseAddrPort("127.0.0.1:30003")
+	require.NoError(t, s.SendLiveness(ctx, peer, intfs))
+	assert.Equal(t, 3, dialer.Count())
+	assert.Len(t, server.take(), 2)
+
+	// The connections are redialed once they exceed the maximum age.
+	s.ConnMaxAge = time.Nanosecond
+	require.NoError(t, s.SendLiveness(ctx, peer, intfs))
+	assert.Equal(t, 5, dialer.Count())
+	assert.Len(t, server.take(), 2)
+}
+
+// bodySigner wraps the message into a signed message without signature.
+type bodySigner struct{}
+
+func (bodySigner) Sign(_ context.Context, msg []byte,
+	_ ...[]byte) (*cryptopb.SignedMessage, error) {
+
+	return &cryptopb.SignedMessage{HeaderAndBody: msg}, nil
+}
+
+type recordingLivenessServer struct {
+	mu     sync.Mutex
+	bodies []*cppb.InterfaceLivenessBody
+}
+
+func (s *recordingLivenessServer) InterfaceLiveness(_ context.Context,
+	req *cppb.InterfaceLivenessRequest) (*cppb.InterfaceLivenessResponse, error) {
+
+	var body cppb.InterfaceLivenessBody
+	if err := proto.Unmarshal(req.SignedRequest.HeaderAndBody, &body); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bodies = append(s.bodies, &body)
+	return &cppb.InterfaceLivenessResponse{}, nil
+}
+
+func (s *recordingLivenessServer) take() []*cppb.InterfaceLivenessBody {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bodies := s.bodies
+	s.bodies = nil
+	return bodies
+}
+
+type countingDialer struct {
+	Dialer *xtest.GRPCService
+
+	mu    sync.Mutex
+	count int
+}
+
+func (d *countingDialer) Dial(ctx context.Context, addr net.Addr) (*grpc.ClientConn, error) {
+	d.mu.Lock()
+	d.count++
+	d.mu.Unlock()
+	return d.Dialer.Dial(ctx, addr)
+}
+
+func (d *countingDialer) Count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.count
+}
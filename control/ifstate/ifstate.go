@@ -39,26 +39,26 @@ type InterfaceInfo struct {
 }
 
 const (
-	// DefaultKeepaliveInterval is the default time between sending IFID
-	// keepalive packets to the neighbor.
-	DefaultKeepaliveInterval = time.Second
-	// DefaultKeepaliveTimeout specifies the default for how long an interface
-	// can receive no IFID keepalive packets until it is considered expired.
-	DefaultKeepaliveTimeout = 3 * DefaultKeepaliveInterval
+	// DefaultLivenessInterval is the default time between sending liveness
+	// messages to the neighbor.
+	DefaultLivenessInterval = time.Second
+	// DefaultLivenessTimeout specifies the default for how long an interface
+	// can receive no liveness messages until it is considered expired.
+	DefaultLivenessTimeout = 3 * DefaultLivenessInterval
 )
 
 // Config enables configuration of the interfaces.
 type Config struct {
-	// KeepaliveTimeout specifies for how long an interface can receive no
-	// IFID keepalive packets until it is considered expired.
-	KeepaliveTimeout time.Duration
+	// LivenessTimeout specifies for how long an interface can receive no
+	// liveness messages until it is considered expired.
+	LivenessTimeout time.Duration
 }
 
 // InitDefaults initializes the config fields that are not set to the
 // default values.
 func (c *Config) InitDefaults() {
-	if c.KeepaliveTimeout == 0 {
-		c.KeepaliveTimeout = DefaultKeepaliveTimeout
+	if c.LivenessTimeout == 0 {
+		c.LivenessTimeout = DefaultLivenessTimeout
 	}
 }
 
@@ -147,7 +147,11 @@ type Interface struct {
 	topoInfo      InterfaceInfo
 	lastOriginate time.Time
 	lastPropagate time.Time
-	cfg           Config
+	// lastLiveness is the time at which the last liveness message was
+	// accepted, and livenessTimestamp is the signing time of that message.
+	lastLiveness      time.Time
+	livenessTimestamp time.Time
+	cfg               Config
 }
 
 // Activate sets the remote interface ID.
@@ -195,6 +199,39 @@ func (intf *Interface) LastPropagate() time.Time {
 	return intf.lastPropagate
 }
 
+// Alive indicates whether a liveness message was accepted within the liveness
+// timeout.
+func (intf *Interface) Alive(now time.Time) bool {
+	intf.mu.RLock()
+	defer intf.mu.RUnlock()
+	if intf.lastLiveness.IsZero() {
+		return false
+	}
+	return now.Before(intf.lastLiveness.Add(intf.cfg.LivenessTimeout))
+}
+
+// LastLiveness indicates the last time a liveness message was accepted on
+// this interface.
+func (intf *Interface) LastLiveness() time.Time {
+	intf.mu.RLock()
+	defer intf.mu.RUnlock()
+	return intf.lastLiveness
+}
+
+// acceptLiveness records a liveness message that was signed at the given
+// timestamp. Messages that are not newer than the last accepted one are
+// rejected, which prevents replays.
+func (intf *Interface) acceptLiveness(timestamp, now time.Time) bool {
+	intf.mu.Lock()
+	defer intf.mu.Unlock()
+	if !timestamp.After(intf.livenessTimestamp) {
+		return false
+	}
+	intf.livenessTimestamp = timestamp
+	intf.lastLiveness = now
+	return true
+}
+
 func (intf *Interface) reset() {
 	intf.mu.Lock()
 	defer intf.mu.Unlock()
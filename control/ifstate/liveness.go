@@ -0,0 +1,241 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ifstate
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/clock"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/private/periodic"
+)
+
+// DefaultLivenessRevocationTTL is the default validity of revocations issued
+// for interfaces that are not alive.
+const DefaultLivenessRevocationTTL = path_mgmt.MinRevTTL
+
+// LivenessMessage is a liveness message of a neighbor whose signature has been
+// verified.
+type LivenessMessage struct {
+	// Peer is the ISD-AS of the neighbor that signed the message.
+	Peer addr.IA
+	// PathIngress is the local interface on which the message entered the
+	// AS. It is taken from the path of the message.
+	PathIngress uint16
+	// Egress is the neighbor interface the message was sent on, as claimed by
+	// the signed body.
+	Egress uint16
+	// Ingress is the local interface the message is destined to, as claimed
+	// by the signed body. It is zero if the neighbor does not know the
+	// interface ID.
+	Ingress uint16
+	// Timestamp is the signing time of the message.
+	Timestamp time.Time
+}
+
+// LivenessHandler validates liveness messages and marks the interfaces they
+// were received on as alive.
+type LivenessHandler struct {
+	Interfaces *Interfaces
+	// MaxAge is the maximum time a message may be older or newer than the
+	// local time. If zero, DefaultLivenessTimeout is used.
+	MaxAge time.Duration
+	// Clock provides the current time. If nil, the wall clock is used.
+	Clock clock.Clock
+}
+
+// Handle validates the message and records it on the interface. A message is
+// only accepted if it was received on the interface it claims, the interface
+// connects to the signing neighbor, and it is newer than the last accepted
+// message of that interface. If the remote interface ID is not known yet, the
+// interface is activated with the one claimed by the neighbor.
+func (h LivenessHandler) Handle(m LivenessMessage) error {
+	if m.Ingress != 0 && m.PathIngress != m.Ingress {
+		return serrors.New("ingress interface mismatch",
+			"path", m.PathIngress, "message", m.Ingress)
+	}
+	intf := h.Interfaces.Get(m.PathIngress)
+	if intf == nil {
+		return serrors.New("unknown interface", "interface_id", m.PathIngress)
+	}
+	info := intf.TopoInfo()
+	if info.IA != m.Peer {
+		return serrors.New("neighbor mismatch", "interface_id", m.PathIngress,
+			"expected", info.IA, "actual", m.Peer)
+	}
+	if info.RemoteID != 0 && info.RemoteID != m.Egress {
+		return serrors.New("remote interface mismatch", "interface_id", m.PathIngress,
+			"expected", info.RemoteID, "actual", m.Egress)
+	}
+	maxAge := h.MaxAge
+	if maxAge == 0 {
+		maxAge = DefaultLivenessTimeout
+	}
+	now := clock.Now(h.Clock)
+	if m.Timestamp.Before(now.Add(-maxAge)) || m.Timestamp.After(now.Add(maxAge)) {
+		return serrors.New("timestamp out of range", "interface_id", m.PathIngress,
+			"timestamp", m.Timestamp, "now", now)
+	}
+	if !intf.acceptLiveness(m.Timestamp, now) {
+		return serrors.New("stale liveness message", "interface_id", m.PathIngress,
+			"timestamp", m.Timestamp)
+	}
+	if info.RemoteID == 0 && m.Egress != 0 {
+		intf.Activate(m.Egress)
+	}
+	return nil
+}
+
+// LivenessRPC sends a liveness message to a neighbor.
+type LivenessRPC interface {
+	// SendLiveness sends one liveness message covering all interfaces to the
+	// same neighbor AS over each of these interfaces.
+	SendLiveness(ctx context.Context, neighbor addr.IA, intfs []InterfaceInfo) error
+}
+
+var _ periodic.Task = (*LivenessSender)(nil)
+
+// LivenessSender is a periodic task that sends liveness messages on all
+// interfaces.
+type LivenessSender struct {
+	Interfaces *Interfaces
+	RPC        LivenessRPC
+}
+
+// Name returns the tasks name.
+func (s *LivenessSender) Name() string {
+	return "control_ifstate_liveness_sender"
+}
+
+// Run sends a liveness message to every neighbor, batching all interfaces to
+// the same neighbor.
+func (s *LivenessSender) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	neighbors := make(map[addr.IA][]InterfaceInfo)
+	for _, intf := range s.Interfaces.All() {
+		info := intf.TopoInfo()
+		neighbors[info.IA] = append(neighbors[info.IA], info)
+	}
+	var wg sync.WaitGroup
+	for ia, infos := range neighbors {
+		ia, infos := ia, infos
+		wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			if err := s.RPC.SendLiveness(ctx, ia, infos); err != nil {
+				logger.Debug("Failed to send liveness message", "isd_as", ia, "err", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Revoker inserts revocations of local interfaces.
+type Revoker interface {
+	Revoke(ctx context.Context, revInfo *path_mgmt.RevInfo) error
+}
+
+var _ periodic.Task = (*LivenessRevoker)(nil)
+
+// LivenessRevoker is a periodic task that revokes interfaces that are not
+// alive. Revocations are renewed before they expire for as long as the
+// interface stays down.
+type LivenessRevoker struct {
+	Interfaces *Interfaces
+	// IA is the ISD-AS of the local AS.
+	IA      addr.IA
+	Revoker Revoker
+	// RevocationTTL is the validity of the issued revocations. If zero,
+	// DefaultLivenessRevocationTTL is used.
+	RevocationTTL time.Duration
+	// GracePeriod is the time after the first run during which no
+	// revocations are issued, such that neighbors have time to send their
+	// first liveness messages. If zero, DefaultLivenessTimeout is used.
+	GracePeriod time.Duration
+	// Clock provides the current time. If nil, the wall clock is used.
+	Clock clock.Clock
+
+	mu      sync.Mutex
+	started time.Time
+	// revoked holds the issuing time of the last revocation per interface.
+	revoked map[uint16]time.Time
+}
+
+// Name returns the tasks name.
+func (r *LivenessRevoker) Name() string {
+	return "control_ifstate_liveness_revoker"
+}
+
+// Run revokes all interfaces that are not alive and whose last revocation is
+// past half of its validity.
+func (r *LivenessRevoker) Run(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := clock.Now(r.Clock)
+	if r.revoked == nil {
+		r.revoked = make(map[uint16]time.Time)
+		r.started = now
+	}
+	grace := r.GracePeriod
+	if grace == 0 {
+		grace = DefaultLivenessTimeout
+	}
+	if now.Before(r.started.Add(grace)) {
+		return
+	}
+	ttl := r.RevocationTTL
+	if ttl == 0 {
+		ttl = DefaultLivenessRevocationTTL
+	}
+	logger := log.FromCtx(ctx)
+	all := r.Interfaces.All()
+	for ifID := range r.revoked {
+		if _, ok := all[ifID]; !ok {
+			delete(r.revoked, ifID)
+		}
+	}
+	for ifID, intf := range all {
+		if intf.Alive(now) {
+			delete(r.revoked, ifID)
+			continue
+		}
+		if last, ok := r.revoked[ifID]; ok && now.Before(last.Add(ttl/2)) {
+			continue
+		}
+		revInfo := &path_mgmt.RevInfo{
+			IfID:         common.IFIDType(ifID),
+			RawIsdas:     r.IA,
+			RawTimestamp: util.TimeToSecs(now),
+			RawTTL:       uint32(ttl / time.Second),
+		}
+		if err := r.Revoker.Revoke(ctx, revInfo); err != nil {
+			logger.Info("Failed to revoke interface", "interface_id", ifID, "err", err)
+			continue
+		}
+		if _, ok := r.revoked[ifID]; !ok {
+			logger.Info("Revoked interface without liveness", "interface_id", ifID,
+				"last_liveness", intf.LastLiveness())
+		}
+		r.revoked[ifID] = now
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ifstate_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/clock"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+func TestLivenessHandlerHandle(t *testing.T) {
+	peer := xtest.MustParseIA("1-ff00:0:111")
+	now := time.Now()
+	valid := ifstate.LivenessMessage{
+		Peer:        peer,
+		PathIngress: 1,
+		Egress:      11,
+		Ingress:     1,
+		Timestamp:   now,
+	}
+	testCases := map[string]struct {
+		Modify    func(m *ifstate.LivenessMessage)
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Modify:    func(m *ifstate.LivenessMessage) {},
+			Assertion: assert.NoError,
+		},
+		"unknown ingress in body": {
+			Modify:    func(m *ifstate.LivenessMessage) { m.Ingress = 0 },
+			Assertion: assert.NoError,
+		},
+		"ingress mismatch": {
+			Modify:    func(m *ifstate.LivenessMessage) { m.Ingress = 2 },
+			Assertion: assert.Error,
+		},
+		"unknown interface": {
+			Modify: func(m *ifstate.LivenessMessage) {
+				m.PathIngress, m.Ingress = 3, 3
+			},
+			Assertion: assert.Error,
+		},
+		"wrong neighbor": {
+			Modify: func(m *ifstate.LivenessMessage) {
+				m.Peer = xtest.MustParseIA("1-ff00:0:112")
+			},
+			Assertion: assert.Error,
+		},
+		"wrong remote interface": {
+			Modify:    func(m *ifstate.LivenessMessage) { m.Egress = 12 },
+			Assertion: assert.Error,
+		},
+		"too old": {
+			Modify:    func(m *ifstate.LivenessMessage) { m.Timestamp = now.Add(-time.Minute) },
+			Assertion: assert.Error,
+		},
+		"from the future": {
+			Modify:    func(m *ifstate.LivenessMessage) { m.Timestamp = now.Add(time.Minute) },
+			Assertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			intfs := livenessInterfaces(peer)
+			h := ifstate.LivenessHandler{Interfaces: intfs, Clock: clock.NewManual(now)}
+			m := valid
+			tc.Modify(&m)
+			err := h.Handle(m)
+			tc.Assertion(t, err)
+			assert.Equal(t, err == nil, intfs.Get(1).Alive(now))
+		})
+	}
+	t.Run("replay is rejected", func(t *testing.T) {
+		intfs := livenessInterfaces(peer)
+		c := clock.NewManual(now)
+		h := ifstate.LivenessHandler{Interfaces: intfs, Clock: c}
+		require.NoError(t, h.Handle(valid))
+		c.Advance(time.Second)
+		assert.Error(t, h.Handle(valid))
+		next := valid
+		next.Timestamp = now.Add(time.Second)
+		assert.NoError(t, h.Handle(next))
+		assert.Equal(t, c.Now(), intfs.Get(1).LastLiveness())
+	})
+	t.Run("remote interface is learned", func(t *testing.T) {
+		intfs := livenessInterfaces(peer)
+		intfs.Get(1).TopoInfoRef().RemoteID = 0
+		h := ifstate.LivenessHandler{Interfaces: intfs, Clock: clock.NewManual(now)}
+		require.NoError(t, h.Handle(valid))
+		assert.EqualValues(t, 11, intfs.Get(1).TopoInfo().RemoteID)
+	})
+}
+
+func TestInterfaceAlive(t *testing.T) {
+	peer := xtest.MustParseIA("1-ff00:0:111")
+	now := time.Now()
+	intfs := livenessInterfaces(peer)
+	assert.False(t, intfs.Get(1).Alive(now))
+	h := ifstate.LivenessHandler{Interfaces: intfs, Clock: clock.NewManual(now)}
+	require.NoError(t, h.Handle(ifstate.LivenessMessage{
+		Peer:        peer,
+		PathIngress: 1,
+		Ingress:     1,
+		Egress:      11,
+		Timestamp:   now,
+	}))
+	assert.True(t, intfs.Get(1).Alive(now.Add(ifstate.DefaultLivenessTimeout-time.Millisecond)))
+	assert.False(t, intfs.Get(1).Alive(now.Add(ifstate.DefaultLivenessTimeout)))
+}
+
+func TestLivenessRevokerRun(t *testing.T) {
+	peer := xtest.MustParseIA("1-ff00:0:111")
+	local := xtest.MustParseIA("1-ff00:0:110")
+	now := time.Now()
+	c := clock.NewManual(now)
+	intfs := livenessInterfaces(peer)
+	revoker := &recordingRevoker{}
+	r := &ifstate.LivenessRevoker{
+		Interfaces: intfs,
+		IA:         local,
+		Revoker:    revoker,
+		Clock:      c,
+	}
+	h := ifstate.LivenessHandler{Interfaces: intfs, Clock: c}
+	ctx := context.Background()
+
+	// No revocations within the grace period.
+	r.Run(ctx)
+	assert.Empty(t, revoker.take())
+
+	// Interface 2 stays down, interface 1 is alive.
+	c.Advance(ifstate.DefaultLivenessTimeout)
+	require.NoError(t, h.Handle(ifstate.LivenessMessage{
+		Peer: peer, PathIngress: 1, Ingress: 1, Egress: 11, Timestamp: c.Now(),
+	}))
+	r.Run(ctx)
+	revs := revoker.take()
+	require.Len(t, revs, 1)
+	assert.EqualValues(t, 2, revs[0].IfID)
+	assert.Equal(t, local, revs[0].IA())
+	assert.Equal(t, path_mgmt.MinRevTTL, revs[0].TTL())
+
+	// The revocation is not renewed before half of its validity.
+	c.Advance(time.Second)
+	r.Run(ctx)
+	assert.Empty(t, revoker.take())
+
+	// Interface 1 times out, the revocation of interface 2 is renewed.
+	c.Advance(path_mgmt.MinRevTTL / 2)
+	r.Run(ctx)
+	revs = revoker.take()
+	require.Len(t, revs, 2)
+}
+
+func TestLivenessSenderRun(t *testing.T) {
+	peer := xtest.MustParseIA("1-ff00:0:111")
+	other := xtest.MustParseIA("1-ff00:0:112")
+	intfs := ifstate.NewInterfaces(map[uint16]ifstate.InterfaceInfo{
+		1: {ID: 1, IA: peer, RemoteID: 11},
+		2: {ID: 2, IA: peer, RemoteID: 22},
+		3: {ID: 3, IA: other, RemoteID: 33},
+	}, ifstate.Config{})
+	rpc := &recordingLivenessRPC{sent: make(map[addr.IA][]uint16)}
+	s := &ifstate.LivenessSender{Interfaces: intfs, RPC: rpc}
+	s.Run(context.Background())
+	assert.ElementsMatch(t, []uint16{1, 2}, rpc.sent[peer])
+	assert.ElementsMatch(t, []uint16{3}, rpc.sent[other])
+	assert.Len(t, rpc.sent, 2)
+}
+
+func livenessInterfaces(peer addr.IA) *ifstate.Interfaces {
+	return ifstate.NewInterfaces(map[uint16]ifstate.InterfaceInfo{
+		1: {ID: 1, IA: peer, RemoteID: 11},
+		2: {ID: 2, IA: peer, RemoteID: 22},
+	}, ifstate.Config{})
+}
+
+type recordingRevoker struct {
+	mu   sync.Mutex
+	revs []*path_mgmt.RevInfo
+}
+
+func (r *recordingRevoker) Revoke(_ context.Context, revInfo *path_mgmt.RevInfo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.revs = append(r.revs, revInfo)
+	return nil
+}
+
+func (r *recordingRevoker) take() []*path_mgmt.RevInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	revs := r.revs
+	r.revs = nil
+	return revs
+}
+
+type recordingLivenessRPC struct {
+	mu   sync.Mutex
+	sent map[addr.IA][]uint16
+}
+
+func (r *recordingLivenessRPC) SendLiveness(_ context.Context, neighbor addr.IA,
+	intfs []ifstate.InterfaceInfo) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, intf := range intfs {
+		r.sent[neighbor] = append(r.sent[neighbor], intf.ID)
+	}
+	return nil
+}
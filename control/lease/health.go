@@ -0,0 +1,50 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"context"
+	"net"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// HealthChecker checks whether an instance is serving with the gRPC health
+// service.
+type HealthChecker struct {
+	Dialer libgrpc.Dialer
+}
+
+// Serving indicates whether the instance reports the serving status for the
+// overall server.
+func (c HealthChecker) Serving(ctx context.Context, addr net.Addr) bool {
+	conn, err := c.Dialer.Dial(ctx, addr)
+	if err != nil {
+		log.FromCtx(ctx).Debug("Failed to dial control service instance",
+			"addr", addr, "err", err)
+		return false
+	}
+	defer conn.Close()
+	rep, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		log.FromCtx(ctx).Debug("Failed to check control service instance",
+			"addr", addr, "err", err)
+		return false
+	}
+	return rep.Status == healthpb.HealthCheckResponse_SERVING
+}
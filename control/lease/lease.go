@@ -0,0 +1,149 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lease coordinates the control service instances of an AS such that
+// beacons are originated, propagated and registered by exactly one instance.
+//
+// The instances are ordered by their name in the topology. An instance holds
+// the lease if none of the instances that precede it is serving. An instance
+// takes the lease only after no preceding instance was serving for the
+// duration of the lease, and it gives the lease up as soon as a preceding
+// instance is serving again. The duration of the lease must be larger than
+// the interval in which the instances are checked, such that a preceding
+// instance that starts up only takes the lease after the other instances gave
+// it up.
+package lease
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/private/periodic"
+)
+
+// Peer is a control service instance of the local AS.
+type Peer struct {
+	// Name is the name of the instance in the topology.
+	Name string
+	// Addr is the address of the gRPC API of the instance.
+	Addr net.Addr
+}
+
+// Checker checks whether a control service instance is serving.
+type Checker interface {
+	Serving(ctx context.Context, addr net.Addr) bool
+}
+
+// PrecedingPeers returns the peers whose names precede the name of the local
+// instance, in the order of their names.
+func PrecedingPeers(local string, peers []Peer) []Peer {
+	var preceding []Peer
+	for _, p := range peers {
+		if p.Name < local {
+			preceding = append(preceding, p)
+		}
+	}
+	sort.Slice(preceding, func(i, j int) bool {
+		return preceding[i].Name < preceding[j].Name
+	})
+	return preceding
+}
+
+// Lease periodically checks whether the local instance holds the lease. It
+// implements the periodic.Task interface.
+type Lease struct {
+	// Peers returns the instances that precede the local instance.
+	Peers func() []Peer
+	// Checker checks whether an instance is serving.
+	Checker Checker
+	// Duration is the time during which no preceding instance must be serving
+	// before the local instance takes the lease.
+	Duration time.Duration
+
+	mu          sync.Mutex
+	owner       bool
+	vacantSince time.Time
+}
+
+// Name returns the name of the task.
+func (l *Lease) Name() string {
+	return "control_lease"
+}
+
+// Run checks the preceding instances and updates the ownership of the lease.
+func (l *Lease) Run(ctx context.Context) {
+	holder := ""
+	for _, p := range l.Peers() {
+		if l.Checker.Serving(ctx, p.Addr) {
+			holder = p.Name
+			break
+		}
+	}
+	l.update(holder, time.Now())
+}
+
+func (l *Lease) update(holder string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if holder != "" {
+		if l.owner {
+			log.Info("Gave up control service lease", "holder", holder)
+		}
+		l.owner = false
+		l.vacantSince = time.Time{}
+		return
+	}
+	if l.owner {
+		return
+	}
+	if l.vacantSince.IsZero() {
+		l.vacantSince = now
+	}
+	if now.Sub(l.vacantSince) >= l.Duration {
+		log.Info("Took control service lease")
+		l.owner = true
+	}
+}
+
+// Owner indicates whether the local instance holds the lease. A nil lease is
+// always owned.
+func (l *Lease) Owner() bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.owner
+}
+
+// Guard returns a task that runs the given task only while the local instance
+// holds the lease. If the lease is nil, the task is returned unchanged.
+func (l *Lease) Guard(task periodic.Task) periodic.Task {
+	if l == nil {
+		return task
+	}
+	return periodic.Func{
+		TaskName: task.Name(),
+		Task: func(ctx context.Context) {
+			if l.Owner() {
+				task.Run(ctx)
+			}
+		},
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/private/periodic"
+)
+
+type checker map[string]bool
+
+func (c checker) Serving(_ context.Context, addr net.Addr) bool {
+	return c[addr.String()]
+}
+
+func peer(name, addr string) Peer {
+	return Peer{Name: name, Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 30252}}
+}
+
+func TestPrecedingPeers(t *testing.T) {
+	peers := []Peer{
+		peer("cs1-ff00_0_110-3", "10.0.0.3"),
+		peer("cs1-ff00_0_110-1", "10.0.0.1"),
+		peer("cs1-ff00_0_110-2", "10.0.0.2"),
+	}
+	assert.Empty(t, PrecedingPeers("cs1-ff00_0_110-1", peers))
+	assert.Equal(t, []Peer{peers[1], peers[2]}, PrecedingPeers("cs1-ff00_0_110-3", peers))
+}
+
+func TestLeaseRun(t *testing.T) {
+	preceding := peer("cs1-ff00_0_110-1", "10.0.0.1")
+	c := checker{}
+	l := &Lease{
+		Peers:    func() []Peer { return []Peer{preceding} },
+		Checker:  c,
+		Duration: 10 * time.Second,
+	}
+
+	c[preceding.Addr.String()] = true
+	l.Run(context.Background())
+	assert.False(t, l.Owner())
+
+	c[preceding.Addr.String()] = false
+	l.Run(context.Background())
+	assert.False(t, l.Owner(), "lease must not be taken before the duration")
+	l.vacantSince = l.vacantSince.Add(-l.Duration)
+	l.Run(context.Background())
+	assert.True(t, l.Owner())
+
+	c[preceding.Addr.String()] = true
+	l.Run(context.Background())
+	assert.False(t, l.Owner(), "lease must be given up immediately")
+}
+
+func TestLeaseUpdate(t *testing.T) {
+	now := time.Now()
+	l := &Lease{Duration: 10 * time.Second}
+
+	l.update("", now)
+	assert.False(t, l.Owner())
+	l.update("", now.Add(5*time.Second))
+	assert.False(t, l.Owner())
+	l.update("cs1", now.Add(6*time.Second))
+	assert.False(t, l.Owner())
+	l.update("", now.Add(12*time.Second))
+	assert.False(t, l.Owner(), "vacancy must restart after a holder was seen")
+	l.update("", now.Add(22*time.Second))
+	assert.True(t, l.Owner())
+}
+
+func TestGuard(t *testing.T) {
+	var runs int
+	task := periodic.Func{
+		TaskName: "test_task",
+		Task:     func(context.Context) { runs++ },
+	}
+
+	var nilLease *Lease
+	assert.True(t, nilLease.Owner())
+	nilLease.Guard(task).Run(context.Background())
+	assert.Equal(t, 1, runs)
+
+	l := &Lease{Duration: time.Second}
+	guarded := l.Guard(task)
+	assert.Equal(t, "test_task", guarded.Name())
+	guarded.Run(context.Background())
+	assert.Equal(t, 1, runs)
+
+	l.update("", time.Now().Add(-time.Second))
+	l.update("", time.Now())
+	guarded.Run(context.Background())
+	assert.Equal(t, 2, runs)
+}
@@ -17,21 +17,37 @@ package control
 import (
 	"hash"
 	"path/filepath"
+	"time"
 
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/private/keyconf"
 )
 
-// MACGenFactory creates a MAC factory
-func MACGenFactory(configDir string) (func() hash.Hash, error) {
+// MACGenFactory creates a MAC factory. If rotation is non-zero, the hop field
+// MAC key is derived from the master key and rotated with the given period.
+// Otherwise, the MAC key is static and the key phase is never set. The MACs use
+// the algorithms selected per key epoch; with a static key, the current
+// algorithm is used.
+func MACGenFactory(configDir string, rotation time.Duration,
+	algs scrypto.HFMACAlgorithms) (func() (hash.Hash, bool), error) {
+
 	mk, err := keyconf.LoadMaster(filepath.Join(configDir, "keys"))
 	if err != nil {
 		return nil, serrors.WrapStr("loading master key", err)
 	}
-	hfMacFactory, err := scrypto.HFMacFactory(mk.Key0)
+	if rotation != 0 {
+		schedule := scrypto.HFKeySchedule{Master: mk.Key0, Period: rotation, Algorithms: algs}
+		if err := schedule.Validate(); err != nil {
+			return nil, serrors.WrapStr("validating hop field key schedule", err)
+		}
+		return schedule.MACGen(), nil
+	}
+	hfMacFactory, err := scrypto.HFMacFactoryWithAlgorithm(algs.Current, mk.Key0)
 	if err != nil {
 		return nil, err
 	}
-	return hfMacFactory, nil
+	return func() (hash.Hash, bool) {
+		return hfMacFactory(), false
+	}, nil
 }
@@ -15,16 +15,16 @@
 package control
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
-	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
+	"github.com/scionproto/scion/control/beacon"
 	cstrust "github.com/scionproto/scion/control/trust"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/metrics"
@@ -37,21 +37,11 @@ import (
 	"github.com/scionproto/scion/private/ca/renewal"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/discovery"
-	"github.com/scionproto/scion/private/env"
+	"github.com/scionproto/scion/private/pathdb"
 	"github.com/scionproto/scion/private/service"
 	"github.com/scionproto/scion/private/topology"
 )
 
-// InitTracer initializes the global tracer.
-func InitTracer(tracing env.Tracing, id string) (io.Closer, error) {
-	tracer, trCloser, err := tracing.NewTracer(id)
-	if err != nil {
-		return nil, err
-	}
-	opentracing.SetGlobalTracer(tracer)
-	return trCloser, nil
-}
-
 // Metrics defines the metrics exposed by the control server.
 //
 // XXX(roosd): Currently, most counters are created in the packages. The will
@@ -61,15 +51,21 @@ type Metrics struct {
 	BeaconingOriginatedTotal               *prometheus.CounterVec
 	BeaconingPropagatedTotal               *prometheus.CounterVec
 	BeaconingPropagatorInternalErrorsTotal *prometheus.CounterVec
+	BeaconingPropagationASPaths            *prometheus.GaugeVec
+	BeaconingPropagationLinks              *prometheus.GaugeVec
 	BeaconingReceivedTotal                 *prometheus.CounterVec
 	BeaconingRegisteredTotal               *prometheus.CounterVec
 	BeaconingRegistrarInternalErrorsTotal  *prometheus.CounterVec
+	BeaconingRemoteCoreRegistrationsTotal  *prometheus.CounterVec
 	CAHealth                               *prometheus.GaugeVec
 	DiscoveryRequestsTotal                 *prometheus.CounterVec
+	HiddenPathSegmentEvictionsTotal        *prometheus.CounterVec
 	PathDBQueriesTotal                     *prometheus.CounterVec
 	RenewalServerRequestsTotal             *prometheus.CounterVec
 	RenewalHandledRequestsTotal            *prometheus.CounterVec
 	RenewalRegisteredHandlers              *prometheus.GaugeVec
+	RenewalClientRenewalsTotal             *prometheus.CounterVec
+	RPCRateLimitedTotal                    *prometheus.CounterVec
 	SegmentLookupRequestsTotal             *prometheus.CounterVec
 	SegmentLookupSegmentsSentTotal         *prometheus.CounterVec
 	SegmentRegistrationsTotal              *prometheus.CounterVec
@@ -156,6 +152,22 @@ func NewMetrics() *Metrics {
 			},
 			[]string{},
 		),
+		BeaconingPropagationASPaths: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "control_beaconing_propagation_distinct_as_paths",
+				Help: "Number of distinct AS-level paths of the beacons selected for " +
+					"propagation on the egress interface.",
+			},
+			[]string{"egress_interface", prom.LabelNeighIA},
+		),
+		BeaconingPropagationLinks: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "control_beaconing_propagation_distinct_links",
+				Help: "Number of distinct inter-AS links of the beacons selected for " +
+					"propagation on the egress interface.",
+			},
+			[]string{"egress_interface", prom.LabelNeighIA},
+		),
 		BeaconingReceivedTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "control_beaconing_received_beacons_total",
@@ -177,6 +189,13 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"seg_type"},
 		),
+		BeaconingRemoteCoreRegistrationsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_beaconing_remote_core_registrations_total",
+				Help: "Total number of core segment registration attempts per remote core AS.",
+			},
+			[]string{"isd_as", prom.LabelResult},
+		),
 		CAHealth: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "renewal_ca_health_status",
@@ -193,6 +212,14 @@ func NewMetrics() *Metrics {
 			},
 			discovery.Topology{}.RequestsLabels(),
 		),
+		HiddenPathSegmentEvictionsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_hiddenpath_segment_evictions_total",
+				Help: "Total number of hidden segments evicted because the writer " +
+					"exceeded the segment quota of the group.",
+			},
+			[]string{},
+		),
 		PathDBQueriesTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "pathdb_queries_total",
@@ -222,6 +249,20 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"type"},
 		),
+		RenewalClientRenewalsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "renewal_client_renewals_total",
+				Help: "Total number of attempts to renew the AS certificate chain.",
+			},
+			[]string{prom.LabelResult},
+		),
+		RPCRateLimitedTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_rpc_rate_limited_requests_total",
+				Help: "Total number of requests rejected because of the rate limits.",
+			},
+			[]string{"method", "limit"},
+		),
 		SegmentLookupRequestsTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "control_segment_lookup_requests_total",
@@ -326,6 +367,7 @@ func RegisterHTTPEndpoints(
 	signer cstrust.RenewingSigner,
 	ca renewal.ChainBuilder,
 	topo *topology.Loader,
+	health *service.Health,
 ) error {
 	statusPages := service.StatusPages{
 		"info":      service.NewInfoStatusPage(),
@@ -333,6 +375,9 @@ func RegisterHTTPEndpoints(
 		"log/level": service.NewLogLevelStatusPage(),
 		"signer":    signerStatusPage(signer),
 	}
+	if health != nil {
+		health.AddStatusPages(statusPages)
+	}
 	if topo != nil {
 		statusPages["topology"] = service.NewTopologyStatusPage(topo)
 	}
@@ -345,6 +390,35 @@ func RegisterHTTPEndpoints(
 	return nil
 }
 
+// NewHealth creates the readiness checks of the control service. The control
+// service is ready if the topology is loaded and the beacon and path databases
+// are reachable. A non-core control service additionally needs to have
+// received at least one beacon.
+func NewHealth(topo *topology.Loader, beacons beacon.DB, paths pathdb.DB) *service.Health {
+	health := &service.Health{}
+	health.AddCheck("topology", func(context.Context) error {
+		if topo.IA().IsZero() {
+			return serrors.New("topology not loaded")
+		}
+		return nil
+	})
+	health.AddCheck("path_db", func(ctx context.Context) error {
+		_, err := paths.GetNextQuery(ctx, topo.IA(), topo.IA())
+		return err
+	})
+	health.AddCheck("beacon_db", func(ctx context.Context) error {
+		sources, err := beacons.BeaconSources(ctx)
+		if err != nil {
+			return err
+		}
+		if len(sources) == 0 && !topo.Core() {
+			return serrors.New("no beacon received")
+		}
+		return nil
+	})
+	return health
+}
+
 func signerStatusPage(signer cstrust.RenewingSigner) service.StatusPage {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"hash"
 	"net"
-	"sync"
 	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
@@ -52,10 +51,9 @@ func (a Addr) String() string {
 type AddressRewriter struct {
 	// Rewriter is used to perform the SVC resolution.
 	Rewriter *infraenv.AddressRewriter
-	// MAC is the mac to issue hop fields.
-	MAC hash.Hash
-	// macMtx protects the MAC.
-	macMtx sync.Mutex
+	// MAC is used to issue hop fields. It returns the MAC together with the
+	// key phase of the key that the MAC uses.
+	MAC func() (hash.Hash, bool)
 }
 
 func (r *AddressRewriter) RedirectToQUIC(
@@ -80,8 +78,12 @@ func (r *AddressRewriter) RedirectToQUIC(
 }
 
 func (r *AddressRewriter) getPath(egress uint16) (path.OneHop, error) {
-	r.macMtx.Lock()
-	defer r.macMtx.Unlock()
-
-	return path.NewOneHop(egress, time.Now(), 63, r.MAC)
+	mac, keyPhase := r.MAC()
+	ohp, err := path.NewOneHop(egress, time.Now(), path.OneHopExpTime, mac)
+	if err != nil {
+		return path.OneHop{}, err
+	}
+	// The key phase is not part of the MAC input.
+	ohp.FirstHop.KeyPhase = keyPhase
+	return ohp, nil
 }
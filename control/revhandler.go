@@ -16,19 +16,43 @@ package control
 
 import (
 	"context"
+	"time"
 
+	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/revcache"
 )
 
+// revocationLookupTimeout bounds the revocation cache lookup of NotRevoked.
+const revocationLookupTimeout = time.Second
+
 // RevocationHandler handles raw revocations from the snet stack and inserts
-// them into the
+// them into the revocation cache. Revocations of local interfaces are signed
+// before insertion, such that they can be distributed on segment lookups.
 type RevocationHandler struct {
 	RevCache revcache.RevCache
+	// IA is the ISD-AS of the local AS.
+	IA addr.IA
+	// Signer signs the revocations of local interfaces. If it is nil, the
+	// revocations are inserted unsigned and are not distributed.
+	Signer seg.Signer
 }
 
 func (h RevocationHandler) Revoke(ctx context.Context, revInfo *path_mgmt.RevInfo) error {
+	if h.Signer != nil && revInfo.IA() == h.IA && revInfo.Signed == nil {
+		signed, err := revcache.Sign(ctx, h.Signer, revInfo)
+		if err != nil {
+			// The revocation is still useful locally.
+			log.FromCtx(ctx).Info("Failed to sign revocation", "revocation", revInfo,
+				"err", err)
+		}
+		revInfo.Signed = signed
+	}
 	if _, err := h.RevCache.Insert(ctx, revInfo); err != nil {
 		return serrors.WrapStr("inserting revocation", err,
 			"isd_as", revInfo.IA(),
@@ -38,3 +62,32 @@ func (h RevocationHandler) Revoke(ctx context.Context, revInfo *path_mgmt.RevInf
 	}
 	return nil
 }
+
+// NotRevoked wraps the interface filter such that local interfaces with an
+// active revocation in the revocation cache are excluded. The border routers
+// detect failing links with BFD and report them with SCMP interface down
+// messages, which are inserted into the revocation cache by the
+// RevocationHandler. Excluding these interfaces stops beacons from being
+// originated or propagated over links that are known to be down. If the
+// revocation cache cannot be queried, the interface is not excluded.
+func NotRevoked(revCache revcache.RevCache, localIA addr.IA,
+	filter func(*ifstate.Interface) bool) func(*ifstate.Interface) bool {
+
+	return func(intf *ifstate.Interface) bool {
+		if !filter(intf) {
+			return false
+		}
+		if revCache == nil {
+			return true
+		}
+		ifID := common.IFIDType(intf.TopoInfo().ID)
+		ctx, cancelF := context.WithTimeout(context.Background(), revocationLookupTimeout)
+		defer cancelF()
+		revs, err := revCache.Get(ctx, revcache.SingleKey(localIA, ifID))
+		if err != nil {
+			log.Debug("Failed to query revocation cache", "interface_id", ifID, "err", err)
+			return true
+		}
+		return len(revs) == 0
+	}
+}
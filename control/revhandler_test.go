@@ -0,0 +1,106 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cs "github.com/scionproto/scion/control"
+	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
+	"github.com/scionproto/scion/private/revcache"
+	"github.com/scionproto/scion/private/revcache/memrevcache"
+	"github.com/scionproto/scion/private/topology"
+)
+
+func TestNotRevoked(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	intfs := ifstate.NewInterfaces(map[uint16]ifstate.InterfaceInfo{
+		1: {ID: 1, LinkType: topology.Child},
+		2: {ID: 2, LinkType: topology.Child},
+		3: {ID: 3, LinkType: topology.Parent},
+	}, ifstate.Config{})
+	childFilter := func(intf *ifstate.Interface) bool {
+		return intf.TopoInfo().LinkType == topology.Child
+	}
+	revCache := memrevcache.New()
+	_, err := revCache.Insert(context.Background(), &path_mgmt.RevInfo{
+		IfID:         2,
+		RawIsdas:     localIA,
+		RawTimestamp: util.TimeToSecs(time.Now()),
+		RawTTL:       10,
+	})
+	require.NoError(t, err)
+
+	var ids []uint16
+	for _, intf := range intfs.Filtered(cs.NotRevoked(revCache, localIA, childFilter)) {
+		ids = append(ids, intf.TopoInfo().ID)
+	}
+	assert.Equal(t, []uint16{1}, ids)
+	assert.Len(t, intfs.Filtered(cs.NotRevoked(nil, localIA, childFilter)), 2)
+}
+
+func TestRevocationHandlerRevoke(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	remoteIA := xtest.MustParseIA("1-ff00:0:111")
+	signed := &cryptopb.SignedMessage{HeaderAndBody: []byte("signed")}
+	revCache := memrevcache.New()
+	h := cs.RevocationHandler{
+		RevCache: revCache,
+		IA:       localIA,
+		Signer: signerFunc(func(context.Context, []byte) (*cryptopb.SignedMessage, error) {
+			return signed, nil
+		}),
+	}
+	for _, ia := range []addr.IA{localIA, remoteIA} {
+		err := h.Revoke(context.Background(), &path_mgmt.RevInfo{
+			IfID:         1,
+			RawIsdas:     ia,
+			RawTimestamp: util.TimeToSecs(time.Now()),
+			RawTTL:       10,
+		})
+		require.NoError(t, err)
+	}
+
+	revs, err := revCache.Get(context.Background(), revcache.SingleKey(localIA, 1))
+	require.NoError(t, err)
+	require.Len(t, revs, 1)
+	for _, rev := range revs {
+		assert.Equal(t, signed, rev.Signed)
+	}
+	revs, err = revCache.Get(context.Background(), revcache.SingleKey(remoteIA, 1))
+	require.NoError(t, err)
+	require.Len(t, revs, 1)
+	for _, rev := range revs {
+		assert.Nil(t, rev.Signed)
+	}
+}
+
+type signerFunc func(ctx context.Context, msg []byte) (*cryptopb.SignedMessage, error)
+
+func (f signerFunc) Sign(ctx context.Context, msg []byte,
+	_ ...[]byte) (*cryptopb.SignedMessage, error) {
+
+	return f(ctx, msg)
+}
@@ -17,18 +17,23 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/scionproto/scion/pkg/addr"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	edpb "github.com/scionproto/scion/pkg/proto/error_details"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/private/segment/seghandler"
@@ -73,6 +78,7 @@ func (s *RegistrationServer) SegmentsRegistration(ctx context.Context,
 	labels.Source = peerToLabel(peer.IA, s.LocalIA)
 	labels.Type = classifySegs(ctx, req.Segments)
 
+	now := time.Now()
 	var segs []*seg.Meta
 	for segType, segments := range req.Segments {
 		for _, pb := range segments.Segments {
@@ -81,6 +87,16 @@ func (s *RegistrationServer) SegmentsRegistration(ctx context.Context,
 				s.failMetric(span, labels.WithResult(prom.ErrParse), err)
 				return nil, status.Error(codes.InvalidArgument, "failed to parse segments")
 			}
+			if exp := ps.MaxExpiry(); !now.Before(exp) {
+				err := libgrpc.StatusError(codes.FailedPrecondition, "segment expired",
+					&edpb.ErrorSegmentExpired{
+						SegmentId:  ps.ID(),
+						Expiration: timestamppb.New(exp),
+					},
+				)
+				s.failMetric(span, labels.WithResult(prom.ErrInvalidReq), err)
+				return nil, err
+			}
 			segs = append(segs, &seg.Meta{
 				Type:    seg.Type(segType),
 				Segment: ps,
@@ -88,7 +104,10 @@ func (s *RegistrationServer) SegmentsRegistration(ctx context.Context,
 		}
 	}
 
-	res := s.SegHandler.Handle(ctx,
+	handleCtx, handleSpan := tracing.StartOTelSpan(ctx, "segreg.handle",
+		attribute.Int("segments", len(segs)),
+	)
+	res := s.SegHandler.Handle(handleCtx,
 		seghandler.Segments{
 			Segs: segs,
 		},
@@ -99,6 +118,8 @@ func (s *RegistrationServer) SegmentsRegistration(ctx context.Context,
 			SVC:     addr.SvcCS,
 		},
 	)
+	tracing.OTelError(handleSpan, res.Err())
+	handleSpan.End()
 	if err := res.Err(); err != nil {
 		s.failMetric(span, labels.WithResult(prom.ErrProcess), err)
 		// TODO(roosd): Classify crypto/db error and return appropriate status code.
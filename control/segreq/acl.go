@@ -0,0 +1,123 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreq
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/config"
+)
+
+// LookupACL restricts which ASes may look up the segments to a destination AS.
+// It is keyed by the destination AS. Destinations without an entry can be
+// looked up by every AS.
+type LookupACL map[addr.IA]LookupACLEntry
+
+// LookupACLEntry lists the ASes that may or may not look up the segments to a
+// destination. An entry with AS number 0 (e.g., 1-0) matches every AS of the
+// ISD, and 0-0 matches every AS.
+type LookupACLEntry struct {
+	// Allow are the ASes that may look up the segments. If empty, every AS
+	// that is not denied may look them up.
+	Allow []addr.IA
+	// Deny are the ASes that may not look up the segments. Deny takes
+	// precedence over Allow.
+	Deny []addr.IA
+}
+
+// Allowed returns whether the requester may look up the segments to dst.
+func (a LookupACL) Allowed(requester, dst addr.IA) bool {
+	entry, ok := a[dst]
+	if !ok {
+		return true
+	}
+	if matchesAny(requester, entry.Deny) {
+		return false
+	}
+	return len(entry.Allow) == 0 || matchesAny(requester, entry.Allow)
+}
+
+func matchesAny(ia addr.IA, patterns []addr.IA) bool {
+	for _, p := range patterns {
+		if (p.ISD() == 0 || p.ISD() == ia.ISD()) && (p.AS() == 0 || p.AS() == ia.AS()) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadLookupACL loads the segment lookup ACL from the given location. If the
+// location begins with http:// or https:// the ACL is fetched via HTTP. The
+// file has the following YAML format:
+//
+//	destinations:
+//	  "1-ff00:0:110":
+//	    allow: ["1-ff00:0:111", "2-0"]
+//	    deny: ["2-ff00:0:210"]
+func LoadLookupACL(location string) (LookupACL, error) {
+	r, err := config.LoadResource(location)
+	if err != nil {
+		return nil, serrors.WithCtx(err, "location", location)
+	}
+	defer r.Close()
+	var raw struct {
+		Destinations map[string]struct {
+			Allow []string `yaml:"allow,omitempty"`
+			Deny  []string `yaml:"deny,omitempty"`
+		} `yaml:"destinations,omitempty"`
+	}
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil && err != io.EOF {
+		return nil, serrors.WrapStr("parsing", err, "location", location)
+	}
+	acl := make(LookupACL, len(raw.Destinations))
+	for rawDst, rawEntry := range raw.Destinations {
+		dst, err := addr.ParseIA(rawDst)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing destination", err, "location", location)
+		}
+		if dst.IsWildcard() {
+			return nil, serrors.New("destination must not be a wildcard",
+				"destination", dst, "location", location)
+		}
+		allow, err := parseIAs(rawEntry.Allow)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing allow list", err,
+				"destination", dst, "location", location)
+		}
+		deny, err := parseIAs(rawEntry.Deny)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing deny list", err,
+				"destination", dst, "location", location)
+		}
+		acl[dst] = LookupACLEntry{Allow: allow, Deny: deny}
+	}
+	return acl, nil
+}
+
+func parseIAs(raw []string) ([]addr.IA, error) {
+	ias := make([]addr.IA, 0, len(raw))
+	for _, r := range raw {
+		ia, err := addr.ParseIA(r)
+		if err != nil {
+			return nil, err
+		}
+		ias = append(ias, ia)
+	}
+	return ias, nil
+}
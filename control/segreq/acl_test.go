@@ -0,0 +1,122 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreq_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/segreq"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+func TestLookupACLAllowed(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	ia210 := xtest.MustParseIA("2-ff00:0:210")
+	ia211 := xtest.MustParseIA("2-ff00:0:211")
+	acl := segreq.LookupACL{
+		ia110: {
+			Allow: []addr.IA{ia111, addr.MustIAFrom(2, 0)},
+			Deny:  []addr.IA{ia211},
+		},
+		ia111: {
+			Deny: []addr.IA{addr.MustIAFrom(2, 0)},
+		},
+		ia112: {
+			Deny: []addr.IA{addr.MustIAFrom(0, 0)},
+		},
+	}
+	testCases := map[string]struct {
+		requester addr.IA
+		dst       addr.IA
+		allowed   bool
+	}{
+		"allowed AS":              {requester: ia111, dst: ia110, allowed: true},
+		"allowed ISD":             {requester: ia210, dst: ia110, allowed: true},
+		"denied takes precedence": {requester: ia211, dst: ia110, allowed: false},
+		"not in allow list":       {requester: ia112, dst: ia110, allowed: false},
+		"denied ISD":              {requester: ia210, dst: ia111, allowed: false},
+		"not denied":              {requester: ia112, dst: ia111, allowed: true},
+		"all denied":              {requester: ia111, dst: ia112, allowed: false},
+		"no entry":                {requester: ia210, dst: ia211, allowed: true},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.allowed, acl.Allowed(tc.requester, tc.dst))
+		})
+	}
+}
+
+func TestLoadLookupACL(t *testing.T) {
+	testCases := map[string]struct {
+		content   string
+		want      segreq.LookupACL
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			content: `
+destinations:
+  "1-ff00:0:110":
+    allow: ["1-ff00:0:111", "2-0"]
+    deny: ["2-ff00:0:210"]
+  "1-ff00:0:111":
+    deny: ["0-0"]
+`,
+			want: segreq.LookupACL{
+				xtest.MustParseIA("1-ff00:0:110"): {
+					Allow: []addr.IA{xtest.MustParseIA("1-ff00:0:111"), addr.MustIAFrom(2, 0)},
+					Deny:  []addr.IA{xtest.MustParseIA("2-ff00:0:210")},
+				},
+				xtest.MustParseIA("1-ff00:0:111"): {
+					Allow: []addr.IA{},
+					Deny:  []addr.IA{addr.MustIAFrom(0, 0)},
+				},
+			},
+			assertErr: assert.NoError,
+		},
+		"empty": {
+			want:      segreq.LookupACL{},
+			assertErr: assert.NoError,
+		},
+		"wildcard destination": {
+			content:   "destinations:\n  \"1-0\":\n    deny: [\"0-0\"]\n",
+			assertErr: assert.Error,
+		},
+		"invalid entry": {
+			content:   "destinations:\n  \"1-ff00:0:110\":\n    allow: [\"garbage\"]\n",
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			file := filepath.Join(t.TempDir(), "acl.yml")
+			require.NoError(t, os.WriteFile(file, []byte(tc.content), 0644))
+			acl, err := segreq.LoadLookupACL(file)
+			tc.assertErr(t, err)
+			assert.Equal(t, tc.want, acl)
+		})
+	}
+}
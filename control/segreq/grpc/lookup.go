@@ -15,16 +15,34 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	// Register the gzip compressor, such that compressed requests are
+	// answered with compressed responses.
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
+	"github.com/scionproto/scion/control/segreq"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/serrors"
 	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
 	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/pathdb/query"
 	"github.com/scionproto/scion/private/revcache"
 	"github.com/scionproto/scion/private/segment/segfetcher"
 	"github.com/scionproto/scion/private/tracing"
@@ -35,10 +53,24 @@ type Lookuper interface {
 	LookupSegments(ctx context.Context, src, dst addr.IA) (segfetcher.Segments, error)
 }
 
+// errDenied is the result label of the requests that are denied by the ACL.
+const errDenied = "err_denied"
+
 // LookupServer handles path segment lookups.
 type LookupServer struct {
 	Lookuper Lookuper
 	RevCache revcache.RevCache
+	// MinValidity is the minimum remaining validity of served segments.
+	// Segments that expire earlier are omitted from the reply. Requests can
+	// ask for a longer validity, but not for a shorter one.
+	MinValidity time.Duration
+	// ACL, if set, restricts which ASes may look up the segments to a
+	// destination. The requesting AS is determined from the peer address.
+	ACL segreq.LookupACL
+	// MaxPageSize is the maximum number of segments in a response. Requests
+	// for more segments are served in pages of this size. If zero, the number
+	// of segments is only limited by the page size of the request.
+	MaxPageSize int
 
 	// Requests aggregates all the incoming requests received by the handler.
 	// If it is not initialized, nothing is reported.
@@ -62,7 +94,25 @@ func (s LookupServer) Segments(ctx context.Context,
 	setQueryTags(span, src, dst)
 	logger.Debug("Received segment request", "src", src, "dst", dst)
 
-	segs, err := s.Lookuper.LookupSegments(ctx, src, dst)
+	if s.ACL != nil {
+		requester, err := peerIA(ctx)
+		if err != nil || !s.ACL.Allowed(requester, dst) {
+			logger.Debug("Segment request denied by ACL", "requester", requester, "err", err)
+			s.updateMetric(span, labels.WithResult(errDenied), err)
+			return nil, status.Error(codes.PermissionDenied, "segment lookup not allowed")
+		}
+	}
+	params, err := filterParams(req.Filter, time.Now(), s.MinValidity)
+	if err != nil {
+		logger.Debug("Invalid segment filter", "err", err)
+		s.updateMetric(span, labels.WithResult(prom.ErrInvalidReq), err)
+		return nil, err
+	}
+	lookupCtx, lookupSpan := tracing.StartOTelSpan(ctx, "segreq.lookup")
+	segs, err := s.Lookuper.LookupSegments(lookupCtx, src, dst)
+	lookupSpan.SetAttributes(attribute.Int("segments", len(segs)))
+	tracing.OTelError(lookupSpan, err)
+	lookupSpan.End()
 	if err != nil {
 		logger.Debug("Failed to lookup requested segments", "err", err)
 		s.updateMetric(span, labels.WithResult(segfetcher.ErrToMetricsLabel(err)), err)
@@ -74,6 +124,15 @@ func (s LookupServer) Segments(ctx context.Context,
 		// We have some segments and continue with a partial result.
 	}
 
+	if params != nil {
+		segs = filterSegments(segs, params)
+	}
+	segs, nextPageToken, err := paginate(segs, req.PageToken, s.pageSize(req.PageSize))
+	if err != nil {
+		logger.Debug("Invalid page token", "err", err)
+		s.updateMetric(span, labels.WithResult(prom.ErrInvalidReq), err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	labels.Desc.SegType = determineReplyType(segs)
 	if span != nil {
 		span.SetTag("seg_type", labels.Desc.SegType)
@@ -88,15 +147,186 @@ func (s LookupServer) Segments(ctx context.Context,
 		}
 		s.Segments = append(s.Segments, seg.PathSegmentToPB(meta.Segment))
 	}
+	revCtx, revSpan := tracing.StartOTelSpan(ctx, "segreq.revocations")
+	sRevInfos, err := s.signedRevocations(revCtx, segs)
+	tracing.OTelError(revSpan, err)
+	revSpan.End()
+	if err != nil {
+		// The segments are still useful without the revocations.
+		logger.Info("Failed to lookup revocations", "err", err)
+	}
 
-	logger.Debug("Replied with segments", "count", len(segs))
+	logger.Debug("Replied with segments", "count", len(segs), "revocations", len(sRevInfos),
+		"more", len(nextPageToken) > 0)
 	s.updateMetric(span, labels.WithResult(prom.Success), nil)
 	s.incSent(s.SegmentsSent, labels.Desc, len(segs))
 	return &cppb.SegmentsResponse{
-		Segments: m,
+		Segments:          m,
+		SignedRevocations: sRevInfos,
+		NextPageToken:     nextPageToken,
 	}, nil
 }
 
+// pageSize returns the number of segments in a page for the requested page
+// size. Zero means that the segments are not paginated.
+func (s LookupServer) pageSize(requested uint32) int {
+	size := int(requested)
+	if s.MaxPageSize > 0 && (size == 0 || size > s.MaxPageSize) {
+		size = s.MaxPageSize
+	}
+	return size
+}
+
+// paginate returns the page of at most size segments that follows the segment
+// identified by the token, and the token of the next page. The segments are
+// ordered by their type and full ID, such that a token remains valid if
+// segments are added or removed between the requests. If size is zero, all
+// segments following the token are returned.
+func paginate(segs segfetcher.Segments, token []byte,
+	size int) (segfetcher.Segments, []byte, error) {
+
+	if len(token) == 0 && (size == 0 || len(segs) <= size) {
+		return segs, nil, nil
+	}
+	if len(token) != 0 && len(token) != pageTokenLen {
+		return nil, nil, serrors.New("invalid page token length", "length", len(token))
+	}
+	keyed := make([]keyedSegment, 0, len(segs))
+	for _, meta := range segs {
+		key := pageKey(meta)
+		if len(token) != 0 && bytes.Compare(key, token) <= 0 {
+			continue
+		}
+		keyed = append(keyed, keyedSegment{key: key, meta: meta})
+	}
+	sort.Slice(keyed, func(i, j int) bool {
+		return bytes.Compare(keyed[i].key, keyed[j].key) < 0
+	})
+	var next []byte
+	if size > 0 && len(keyed) > size {
+		keyed = keyed[:size]
+		next = keyed[size-1].key
+	}
+	page := make(segfetcher.Segments, 0, len(keyed))
+	for _, k := range keyed {
+		page = append(page, k.meta)
+	}
+	return page, next, nil
+}
+
+// pageTokenLen is the length of a page token. The token consists of the type
+// and the full ID of the last segment of the page.
+const pageTokenLen = 1 + sha256.Size
+
+type keyedSegment struct {
+	key  []byte
+	meta *seg.Meta
+}
+
+func pageKey(meta *seg.Meta) []byte {
+	return append([]byte{byte(meta.Type)}, meta.Segment.FullID()...)
+}
+
+// peerIA returns the ISD-AS of the peer of the request.
+func peerIA(ctx context.Context) (addr.IA, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return 0, serrors.New("peer not present")
+	}
+	a, ok := p.Addr.(*snet.UDPAddr)
+	if !ok {
+		return 0, serrors.New("invalid peer address type, expected snet.UDPAddr",
+			"type", fmt.Sprintf("%T", p.Addr))
+	}
+	return a.IA, nil
+}
+
+// signedRevocations returns the signed revocations of the interfaces that are
+// traversed by the segments. Revocations that are not signed, e.g., the ones
+// reported by the border routers of remote ASes, are not distributed.
+func (s LookupServer) signedRevocations(ctx context.Context,
+	segs segfetcher.Segments) ([]*cryptopb.SignedMessage, error) {
+
+	if s.RevCache == nil || len(segs) == 0 {
+		return nil, nil
+	}
+	pathSegs := make([]*seg.PathSegment, 0, len(segs))
+	for _, meta := range segs {
+		pathSegs = append(pathSegs, meta.Segment)
+	}
+	revs, err := revcache.RelevantRevInfos(ctx, s.RevCache, pathSegs)
+	if err != nil {
+		return nil, err
+	}
+	var sRevInfos []*cryptopb.SignedMessage
+	for _, rev := range revs {
+		if rev.Signed != nil {
+			sRevInfos = append(sRevInfos, rev.Signed)
+		}
+	}
+	return sRevInfos, nil
+}
+
+// filterParams converts the request filter to query parameters. The minimum
+// validity is enforced even if the request does not contain a filter. If
+// nothing needs to be filtered, nil is returned.
+func filterParams(filter *cppb.SegmentsFilter, now time.Time,
+	minValidity time.Duration) (*query.Params, error) {
+
+	if filter == nil && minValidity <= 0 {
+		return nil, nil
+	}
+	params := &query.Params{
+		MaxHops: int(filter.GetMaxHops()),
+	}
+	for _, intf := range filter.GetInterfaces() {
+		params.Intfs = append(params.Intfs, &query.IntfSpec{
+			IA:   addr.IA(intf.IsdAs),
+			IfID: common.IFIDType(intf.Id),
+		})
+	}
+	for _, isd := range filter.GetTransitIsds() {
+		if isd > uint32(addr.MaxISD) {
+			return nil, serrors.New("invalid transit ISD", "isd", isd)
+		}
+		params.TransitISDs = append(params.TransitISDs, addr.ISD(isd))
+	}
+	validity := time.Duration(filter.GetMinLifetime()) * time.Second
+	if validity < minValidity {
+		validity = minValidity
+	}
+	if validity > 0 {
+		params.ValidUntil = now.Add(validity)
+	}
+	return params, nil
+}
+
+// filterSegments returns the segments that match the query parameters.
+func filterSegments(segs segfetcher.Segments, params *query.Params) segfetcher.Segments {
+	filtered := make(segfetcher.Segments, 0, len(segs))
+	for _, meta := range segs {
+		if !params.Matches(meta.Segment) || !traversesAny(meta.Segment, params.Intfs) {
+			continue
+		}
+		filtered = append(filtered, meta)
+	}
+	return filtered
+}
+
+// traversesAny returns whether the segment traverses any of the interfaces. An
+// empty interface list is traversed by every segment.
+func traversesAny(ps *seg.PathSegment, intfs []*query.IntfSpec) bool {
+	if len(intfs) == 0 {
+		return true
+	}
+	for _, intf := range intfs {
+		if intf.TraversedBy(ps) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s LookupServer) updateMetric(span opentracing.Span, l requestLabels, err error) {
 	if s.Requests != nil {
 		s.Requests.With(l.Expand()...).Add(1)
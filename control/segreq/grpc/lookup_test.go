@@ -0,0 +1,406 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/control/segreq"
+	segreqgrpc "github.com/scionproto/scion/control/segreq/grpc"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/revcache/memrevcache"
+	"github.com/scionproto/scion/private/segment/segfetcher"
+)
+
+type lookuperFunc func(ctx context.Context, src, dst addr.IA) (segfetcher.Segments, error)
+
+func (f lookuperFunc) LookupSegments(ctx context.Context,
+	src, dst addr.IA) (segfetcher.Segments, error) {
+
+	return f(ctx, src, dst)
+}
+
+func TestLookupServerMinValidity(t *testing.T) {
+	now := time.Now()
+	// The segments expire in roughly 1h and 6h, respectively.
+	shortLived := newSegment(t, now, time.Hour)
+	longLived := newSegment(t, now, 6*time.Hour)
+	segs := segfetcher.Segments{
+		{Type: seg.TypeDown, Segment: shortLived},
+		{Type: seg.TypeDown, Segment: longLived},
+	}
+	lookuper := lookuperFunc(func(context.Context, addr.IA, addr.IA) (segfetcher.Segments, error) {
+		return segs, nil
+	})
+
+	testCases := map[string]struct {
+		minValidity time.Duration
+		expected    int
+	}{
+		"no minimum": {
+			expected: 2,
+		},
+		"filter short lived": {
+			minValidity: 2 * time.Hour,
+			expected:    1,
+		},
+		"filter all": {
+			minValidity: 12 * time.Hour,
+			expected:    0,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			s := segreqgrpc.LookupServer{
+				Lookuper:    lookuper,
+				MinValidity: tc.minValidity,
+			}
+			rep, err := s.Segments(context.Background(), &cppb.SegmentsRequest{
+				SrcIsdAs: uint64(xtest.MustParseIA("1-ff00:0:110")),
+				DstIsdAs: uint64(xtest.MustParseIA("1-ff00:0:111")),
+			})
+			require.NoError(t, err)
+			assert.Len(t, rep.Segments[int32(seg.TypeDown)].GetSegments(), tc.expected)
+		})
+	}
+}
+
+func TestLookupServerFilter(t *testing.T) {
+	now := time.Now()
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia210 := xtest.MustParseIA("2-ff00:0:210")
+	// The short segment stays within ISD 1, the long one transits ISD 2.
+	short := newSegment(t, now, time.Hour)
+	short.ASEntries = []seg.ASEntry{
+		newASEntry(t, ia110, 0, 1, time.Hour),
+		newASEntry(t, ia111, 2, 0, time.Hour),
+	}
+	long := newSegment(t, now, 6*time.Hour)
+	long.ASEntries = []seg.ASEntry{
+		newASEntry(t, ia110, 0, 3, 6*time.Hour),
+		newASEntry(t, ia210, 4, 5, 6*time.Hour),
+		newASEntry(t, ia111, 6, 0, 6*time.Hour),
+	}
+	segs := segfetcher.Segments{
+		{Type: seg.TypeDown, Segment: short},
+		{Type: seg.TypeDown, Segment: long},
+	}
+	lookuper := lookuperFunc(func(context.Context, addr.IA, addr.IA) (segfetcher.Segments, error) {
+		return segs, nil
+	})
+
+	testCases := map[string]struct {
+		filter      *cppb.SegmentsFilter
+		minValidity time.Duration
+		expected    []*seg.PathSegment
+		assertErr   assert.ErrorAssertionFunc
+	}{
+		"no filter": {
+			expected:  []*seg.PathSegment{short, long},
+			assertErr: assert.NoError,
+		},
+		"interface": {
+			filter: &cppb.SegmentsFilter{
+				Interfaces: []*cppb.SegmentsFilter_Interface{
+					{IsdAs: uint64(ia210), Id: 5},
+					{IsdAs: uint64(ia111), Id: 42},
+				},
+			},
+			expected:  []*seg.PathSegment{long},
+			assertErr: assert.NoError,
+		},
+		"transit ISD": {
+			filter:    &cppb.SegmentsFilter{TransitIsds: []uint32{1}},
+			expected:  []*seg.PathSegment{short},
+			assertErr: assert.NoError,
+		},
+		"max hops": {
+			filter:    &cppb.SegmentsFilter{MaxHops: 2},
+			expected:  []*seg.PathSegment{short},
+			assertErr: assert.NoError,
+		},
+		"min lifetime": {
+			filter:    &cppb.SegmentsFilter{MinLifetime: uint32((2 * time.Hour).Seconds())},
+			expected:  []*seg.PathSegment{long},
+			assertErr: assert.NoError,
+		},
+		"min lifetime below server minimum": {
+			filter:      &cppb.SegmentsFilter{MinLifetime: 1},
+			minValidity: 2 * time.Hour,
+			expected:    []*seg.PathSegment{long},
+			assertErr:   assert.NoError,
+		},
+		"invalid transit ISD": {
+			filter:    &cppb.SegmentsFilter{TransitIsds: []uint32{1 << 16}},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			s := segreqgrpc.LookupServer{
+				Lookuper:    lookuper,
+				MinValidity: tc.minValidity,
+			}
+			rep, err := s.Segments(context.Background(), &cppb.SegmentsRequest{
+				SrcIsdAs: uint64(ia110),
+				DstIsdAs: uint64(ia111),
+				Filter:   tc.filter,
+			})
+			tc.assertErr(t, err)
+			if err != nil {
+				return
+			}
+			// The segments are distinguished by their number of AS entries.
+			var hops []int
+			for _, pb := range rep.Segments[int32(seg.TypeDown)].GetSegments() {
+				hops = append(hops, len(pb.AsEntries))
+			}
+			var expected []int
+			for _, ps := range tc.expected {
+				expected = append(expected, len(ps.ASEntries))
+			}
+			assert.Equal(t, expected, hops)
+		})
+	}
+}
+
+func TestLookupServerRevocations(t *testing.T) {
+	now := time.Now()
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ps := newSegment(t, now, time.Hour)
+	ps.ASEntries = []seg.ASEntry{
+		newASEntry(t, ia110, 0, 1, time.Hour),
+		newASEntry(t, ia111, 2, 0, time.Hour),
+	}
+	lookuper := lookuperFunc(func(context.Context, addr.IA, addr.IA) (segfetcher.Segments, error) {
+		return segfetcher.Segments{{Type: seg.TypeDown, Segment: ps}}, nil
+	})
+	signedOnPath := &cryptopb.SignedMessage{HeaderAndBody: []byte("on path")}
+	revCache := memrevcache.New()
+	for _, rev := range []*path_mgmt.RevInfo{
+		newRevInfo(ia110, 1, signedOnPath),
+		// Unsigned revocations are not distributed.
+		newRevInfo(ia111, 2, nil),
+		// Revocations of interfaces that are not on the segments are not
+		// distributed.
+		newRevInfo(ia111, 3, &cryptopb.SignedMessage{HeaderAndBody: []byte("off path")}),
+	} {
+		_, err := revCache.Insert(context.Background(), rev)
+		require.NoError(t, err)
+	}
+
+	s := segreqgrpc.LookupServer{
+		Lookuper: lookuper,
+		RevCache: revCache,
+	}
+	rep, err := s.Segments(context.Background(), &cppb.SegmentsRequest{
+		SrcIsdAs: uint64(ia110),
+		DstIsdAs: uint64(ia111),
+	})
+	require.NoError(t, err)
+	assert.Len(t, rep.Segments[int32(seg.TypeDown)].GetSegments(), 1)
+	require.Len(t, rep.SignedRevocations, 1)
+	assert.Equal(t, signedOnPath.HeaderAndBody, rep.SignedRevocations[0].HeaderAndBody)
+}
+
+func TestLookupServerACL(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	lookuper := lookuperFunc(func(context.Context, addr.IA, addr.IA) (segfetcher.Segments, error) {
+		return nil, nil
+	})
+	s := segreqgrpc.LookupServer{
+		Lookuper: lookuper,
+		ACL: segreq.LookupACL{
+			ia111: {Allow: []addr.IA{ia110}},
+		},
+	}
+	withPeer := func(ia addr.IA) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &snet.UDPAddr{IA: ia, Host: &net.UDPAddr{}},
+		})
+	}
+	testCases := map[string]struct {
+		ctx       context.Context
+		dst       addr.IA
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"allowed": {
+			ctx:       withPeer(ia110),
+			dst:       ia111,
+			assertErr: assert.NoError,
+		},
+		"denied": {
+			ctx: withPeer(ia112),
+			dst: ia111,
+			assertErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		"no entry": {
+			ctx:       withPeer(ia112),
+			dst:       ia110,
+			assertErr: assert.NoError,
+		},
+		"unknown peer": {
+			ctx:       context.Background(),
+			dst:       ia111,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := s.Segments(tc.ctx, &cppb.SegmentsRequest{
+				SrcIsdAs: uint64(ia110),
+				DstIsdAs: uint64(tc.dst),
+			})
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestLookupServerPagination(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	var segs segfetcher.Segments
+	for i := uint16(1); i <= 7; i++ {
+		segs = append(segs, &seg.Meta{
+			Type: seg.TypeDown,
+			Segment: &seg.PathSegment{
+				// The raw info identifies the segment in the response.
+				Info:      seg.Info{Timestamp: time.Now(), Raw: []byte{byte(i)}},
+				ASEntries: []seg.ASEntry{newASEntry(t, ia110, 0, i, time.Hour)},
+			},
+		})
+	}
+	lookuper := lookuperFunc(func(context.Context, addr.IA, addr.IA) (segfetcher.Segments, error) {
+		return segs, nil
+	})
+	fetchAll := func(t *testing.T, s segreqgrpc.LookupServer, pageSize uint32) ([]int, int) {
+		var pages []int
+		ids := map[string]struct{}{}
+		var token []byte
+		for {
+			rep, err := s.Segments(context.Background(), &cppb.SegmentsRequest{
+				SrcIsdAs:  uint64(ia110),
+				DstIsdAs:  uint64(xtest.MustParseIA("1-ff00:0:111")),
+				PageSize:  pageSize,
+				PageToken: token,
+			})
+			require.NoError(t, err)
+			page := rep.Segments[int32(seg.TypeDown)].GetSegments()
+			pages = append(pages, len(page))
+			for _, pb := range page {
+				ids[string(pb.SegmentInfo)] = struct{}{}
+			}
+			if token = rep.NextPageToken; len(token) == 0 {
+				return pages, len(ids)
+			}
+			require.Less(t, len(pages), 10, "too many pages")
+		}
+	}
+
+	t.Run("no pagination", func(t *testing.T) {
+		pages, count := fetchAll(t, segreqgrpc.LookupServer{Lookuper: lookuper}, 0)
+		assert.Equal(t, []int{7}, pages)
+		assert.Equal(t, 7, count)
+	})
+	t.Run("requested page size", func(t *testing.T) {
+		pages, count := fetchAll(t, segreqgrpc.LookupServer{Lookuper: lookuper}, 3)
+		assert.Equal(t, []int{3, 3, 1}, pages)
+		assert.Equal(t, 7, count)
+	})
+	t.Run("max page size", func(t *testing.T) {
+		s := segreqgrpc.LookupServer{Lookuper: lookuper, MaxPageSize: 4}
+		pages, count := fetchAll(t, s, 10)
+		assert.Equal(t, []int{4, 3}, pages)
+		assert.Equal(t, 7, count)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		s := segreqgrpc.LookupServer{Lookuper: lookuper}
+		_, err := s.Segments(context.Background(), &cppb.SegmentsRequest{
+			SrcIsdAs:  uint64(ia110),
+			DstIsdAs:  uint64(xtest.MustParseIA("1-ff00:0:111")),
+			PageToken: []byte("garbage"),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func newRevInfo(ia addr.IA, ifID common.IFIDType,
+	signed *cryptopb.SignedMessage) *path_mgmt.RevInfo {
+
+	return &path_mgmt.RevInfo{
+		IfID:         ifID,
+		RawIsdas:     ia,
+		RawTimestamp: util.TimeToSecs(time.Now()),
+		RawTTL:       uint32(path_mgmt.MinRevTTL.Seconds()),
+		Signed:       signed,
+	}
+}
+
+func newASEntry(t *testing.T, ia addr.IA, ingress, egress uint16,
+	lifetime time.Duration) seg.ASEntry {
+
+	expTime, err := path.ExpTimeFromDuration(lifetime)
+	require.NoError(t, err)
+	return seg.ASEntry{
+		Local: ia,
+		HopEntry: seg.HopEntry{
+			HopField: seg.HopField{
+				ConsIngress: ingress,
+				ConsEgress:  egress,
+				ExpTime:     expTime,
+			},
+		},
+	}
+}
+
+func newSegment(t *testing.T, ts time.Time, lifetime time.Duration) *seg.PathSegment {
+	expTime, err := path.ExpTimeFromDuration(lifetime)
+	require.NoError(t, err)
+	return &seg.PathSegment{
+		Info: seg.Info{Timestamp: ts},
+		ASEntries: []seg.ASEntry{
+			{HopEntry: seg.HopEntry{HopField: seg.HopField{ExpTime: expTime}}},
+		},
+	}
+}
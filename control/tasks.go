@@ -18,16 +18,20 @@ import (
 	"context"
 	"hash"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/scionproto/scion/control/beacon"
 	"github.com/scionproto/scion/control/beaconing"
 	"github.com/scionproto/scion/control/drkey"
 	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/control/lease"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
 	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/pkg/slayers/path"
 	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/pkg/snet/addrutil"
 	"github.com/scionproto/scion/private/pathdb"
@@ -61,21 +65,48 @@ type TasksConfig struct {
 	Metrics               *Metrics
 	DRKeyEngine           *drkey.ServiceEngine
 
-	MACGen     func() hash.Hash
+	MACGen     func() (hash.Hash, bool)
 	StaticInfo func() *beaconing.StaticInfoCfg
+	// RegistrationFilter returns the filter that excludes segments from
+	// registration. It may be nil.
+	RegistrationFilter func() *beaconing.RegistrationFilter
+	// RegistrationStats counts the segment registrations. It may be nil.
+	RegistrationStats *beaconing.RegistrationStats
 
 	OriginationInterval  time.Duration
 	PropagationInterval  time.Duration
 	RegistrationInterval time.Duration
 	DRKeyEpochInterval   time.Duration
+	// RegistrationJitter is the upper bound of the random delay added to the
+	// registration interval.
+	RegistrationJitter time.Duration
+	// SegmentLifetime is the lifetime of the hop fields in originated
+	// beacons. If zero, the maximum expiration time of the propagation policy
+	// is used.
+	SegmentLifetime time.Duration
 	// HiddenPathRegistrationCfg contains the required options to configure
 	// hidden paths down segment registration. If it is nil, normal path
 	// registration is used instead.
 	HiddenPathRegistrationCfg *HiddenPathRegistrationCfg
+	// RemoteCoreRegistrationCfg contains the options to register core
+	// segments at remote core ASes. If it is nil, core segments are only
+	// registered locally.
+	RemoteCoreRegistrationCfg *RemoteCoreRegistrationCfg
+
+	// MaxBeaconsPerInterface is the maximum number of beacons propagated on
+	// an egress interface. If zero, all candidates are propagated.
+	MaxBeaconsPerInterface int
 
 	AllowIsdLoop bool
 
 	EPIC bool
+
+	// Lease coordinates the origination, propagation and registration of
+	// beacons between the control service instances of the AS. If it is nil,
+	// the local instance always handles them.
+	Lease *lease.Lease
+	// LeaseCheckInterval is the interval in which the lease is checked.
+	LeaseCheckInterval time.Duration
 }
 
 // Originator starts a periodic beacon origination task. For non-core ASes, no
@@ -84,10 +115,20 @@ func (t *TasksConfig) Originator() *periodic.Runner {
 	if !t.Core {
 		return nil
 	}
+	maxExp := func() uint8 {
+		return t.BeaconStore.MaxExpTime(beacon.PropPolicy)
+	}
+	if t.SegmentLifetime != 0 {
+		expTime, err := path.ExpTimeFromDuration(t.SegmentLifetime)
+		if err != nil {
+			log.Error("Ignoring invalid segment lifetime", "lifetime", t.SegmentLifetime,
+				"err", err)
+		} else {
+			maxExp = func() uint8 { return expTime }
+		}
+	}
 	s := &beaconing.Originator{
-		Extender: t.extender("originator", t.IA, t.MTU, func() uint8 {
-			return t.BeaconStore.MaxExpTime(beacon.PropPolicy)
-		}),
+		Extender:              t.extender("originator", t.IA, t.MTU, maxExp),
 		SenderFactory:         t.BeaconSenderFactory,
 		IA:                    t.IA,
 		AllInterfaces:         t.AllInterfaces,
@@ -97,7 +138,7 @@ func (t *TasksConfig) Originator() *periodic.Runner {
 	if t.Metrics != nil {
 		s.Originated = metrics.NewPromCounter(t.Metrics.BeaconingOriginatedTotal)
 	}
-	return periodic.Start(s, 500*time.Millisecond, t.OriginationInterval)
+	return periodic.Start(t.Lease.Guard(s), 500*time.Millisecond, t.OriginationInterval)
 }
 
 // Propagator starts a periodic beacon propagation task.
@@ -106,25 +147,32 @@ func (t *TasksConfig) Propagator() *periodic.Runner {
 		Extender: t.extender("propagator", t.IA, t.MTU, func() uint8 {
 			return t.BeaconStore.MaxExpTime(beacon.PropPolicy)
 		}),
-		SenderFactory:         t.BeaconSenderFactory,
-		Provider:              t.BeaconStore,
-		IA:                    t.IA,
-		AllInterfaces:         t.AllInterfaces,
-		PropagationInterfaces: t.PropagationInterfaces,
-		AllowIsdLoop:          t.AllowIsdLoop,
-		Tick:                  beaconing.NewTick(t.PropagationInterval),
+		SenderFactory:          t.BeaconSenderFactory,
+		Provider:               t.BeaconStore,
+		IA:                     t.IA,
+		AllInterfaces:          t.AllInterfaces,
+		PropagationInterfaces:  t.PropagationInterfaces,
+		AllowIsdLoop:           t.AllowIsdLoop,
+		MaxBeaconsPerInterface: t.MaxBeaconsPerInterface,
+		Tick:                   beaconing.NewTick(t.PropagationInterval),
 	}
 	if t.Metrics != nil {
 		p.Propagated = metrics.NewPromCounter(t.Metrics.BeaconingPropagatedTotal)
 		p.InternalErrors = metrics.NewPromCounter(t.Metrics.BeaconingPropagatorInternalErrorsTotal)
+		p.DistinctASPaths = metrics.NewPromGauge(t.Metrics.BeaconingPropagationASPaths)
+		p.DistinctLinks = metrics.NewPromGauge(t.Metrics.BeaconingPropagationLinks)
 	}
-	return periodic.Start(p, 500*time.Millisecond, t.PropagationInterval)
+	return periodic.Start(t.Lease.Guard(p), 500*time.Millisecond, t.PropagationInterval)
 }
 
 // SegmentWriters starts periodic segment registration tasks.
 func (t *TasksConfig) SegmentWriters() []*periodic.Runner {
 	if t.Core {
-		return []*periodic.Runner{t.segmentWriter(seg.TypeCore, beacon.CoreRegPolicy)}
+		writers := []*periodic.Runner{t.segmentWriter(seg.TypeCore, beacon.CoreRegPolicy)}
+		if t.RemoteCoreRegistrationCfg != nil {
+			writers = append(writers, t.remoteCoreWriter())
+		}
+		return writers
 	}
 	return []*periodic.Runner{
 		t.segmentWriter(seg.TypeDown, beacon.DownRegPolicy),
@@ -152,6 +200,7 @@ func (t *TasksConfig) segmentWriter(segType seg.Type,
 				return t.BeaconStore.MaxExpTime(policyType)
 			}),
 			Store: &seghandler.DefaultStorage{PathDB: t.PathDB},
+			Stats: t.RegistrationStats,
 		}
 
 	case t.HiddenPathRegistrationCfg != nil:
@@ -171,6 +220,8 @@ func (t *TasksConfig) segmentWriter(segType seg.Type,
 				Router:     t.HiddenPathRegistrationCfg.Router,
 				Discoverer: t.HiddenPathRegistrationCfg.Discoverer,
 			},
+			Stats:    t.RegistrationStats,
+			Selector: t.HiddenPathRegistrationCfg.Selector,
 		}
 	default:
 		writer = &beaconing.RemoteWriter{
@@ -185,6 +236,7 @@ func (t *TasksConfig) segmentWriter(segType seg.Type,
 			Pather: addrutil.Pather{
 				NextHopper: t.NextHopper,
 			},
+			Stats: t.RegistrationStats,
 		}
 	}
 	r := &beaconing.WriteScheduler{
@@ -192,14 +244,67 @@ func (t *TasksConfig) segmentWriter(segType seg.Type,
 		Intfs:    t.AllInterfaces,
 		Type:     segType,
 		Writer:   writer,
-		Tick:     beaconing.NewTick(t.RegistrationInterval),
+		Tick:     beaconing.NewTickWithJitter(t.RegistrationInterval, t.RegistrationJitter),
+
+		IA:                 t.IA,
+		RegistrationFilter: t.RegistrationFilter,
 	}
 	// The period of the task is short because we want to retry quickly
 	// if we fail fast. So during one interval we'll make as many attempts
 	// as we can until we succeed. After succeeding, the task does nothing
 	// until the end of the interval. The interval itself is used as a
 	// timeout. If we fail slow we give up at the end of the cycle.
-	return periodic.Start(r, 500*time.Millisecond, t.RegistrationInterval)
+	return periodic.Start(t.Lease.Guard(r), 500*time.Millisecond, t.RegistrationInterval)
+}
+
+// remoteCoreWriter starts a periodic task that registers core segments at the
+// remote core ASes that originated them.
+func (t *TasksConfig) remoteCoreWriter() *periodic.Runner {
+	cfg := t.RemoteCoreRegistrationCfg
+	var internalErr, registered, registrations metrics.Counter
+	if t.Metrics != nil {
+		internalErr = metrics.NewPromCounter(t.Metrics.BeaconingRegistrarInternalErrorsTotal)
+		registered = metrics.NewPromCounter(t.Metrics.BeaconingRegisteredTotal)
+		registrations = metrics.NewPromCounter(
+			t.Metrics.BeaconingRemoteCoreRegistrationsTotal)
+	}
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = t.RegistrationInterval
+	}
+	writer := &beaconing.RemoteCoreWriter{
+		Writer: &beaconing.RemoteWriter{
+			InternalErrors: metrics.CounterWith(internalErr,
+				"seg_type", seg.TypeCore.String()),
+			Registered: registered,
+			Type:       seg.TypeCore,
+			Intfs:      t.AllInterfaces,
+			Extender: t.extender("registrar", t.IA, t.MTU, func() uint8 {
+				return t.BeaconStore.MaxExpTime(beacon.CoreRegPolicy)
+			}),
+			RPC: t.SegmentRegister,
+			Pather: addrutil.Pather{
+				NextHopper: t.NextHopper,
+			},
+			Stats:       t.RegistrationStats,
+			Parallelism: cfg.Parallelism,
+		},
+		Remotes:        cfg.Remotes,
+		InitialBackoff: cfg.InitialBackoff,
+		MaxBackoff:     cfg.MaxBackoff,
+		Registrations:  registrations,
+	}
+	r := &beaconing.WriteScheduler{
+		Provider: t.BeaconStore,
+		Intfs:    t.AllInterfaces,
+		Type:     seg.TypeCore,
+		Writer:   writer,
+		Tick:     beaconing.NewTickWithJitter(interval, t.RegistrationJitter),
+
+		IA:                 t.IA,
+		RegistrationFilter: t.RegistrationFilter,
+	}
+	return periodic.Start(t.Lease.Guard(r), 500*time.Millisecond, interval)
 }
 
 func (t *TasksConfig) extender(
@@ -228,6 +333,34 @@ func (t *TasksConfig) extender(
 	}
 }
 
+// LeaseChecker starts the periodic task that checks the lease. If no lease is
+// configured, no periodic runner is started.
+func (t *TasksConfig) LeaseChecker() *periodic.Runner {
+	if t.Lease == nil {
+		return nil
+	}
+	return periodic.Start(t.Lease, t.LeaseCheckInterval, t.LeaseCheckInterval)
+}
+
+// HiddenPathRegistryProber starts a periodic task that probes the reachability
+// of the hidden path registries. It is only started if the registries are
+// selected for the segment registrations.
+func (t *TasksConfig) HiddenPathRegistryProber() *periodic.Runner {
+	cfg := t.HiddenPathRegistrationCfg
+	if cfg == nil || cfg.Selector == nil {
+		return nil
+	}
+	p := &hiddenpath.RegistryProber{
+		Policy: cfg.Policy,
+		Resolver: hiddenpath.RegistrationResolver{
+			Router:     cfg.Router,
+			Discoverer: cfg.Discoverer,
+		},
+		Selector: cfg.Selector,
+	}
+	return periodic.Start(t.Lease.Guard(p), cfg.ProbeInterval, cfg.ProbeInterval)
+}
+
 func (t *TasksConfig) DRKeyCleaners() []*periodic.Runner {
 	if t.DRKeyEngine == nil {
 		return nil
@@ -263,6 +396,10 @@ type Tasks struct {
 	Propagator      *periodic.Runner
 	Registrars      []*periodic.Runner
 	DRKeyPrefetcher *periodic.Runner
+	LeaseChecker    *periodic.Runner
+	// RegistryProber probes the hidden path registries. It is nil if the
+	// segments are registered at all registries.
+	RegistryProber *periodic.Runner
 
 	PathCleaner   *periodic.Runner
 	DRKeyCleaners []*periodic.Runner
@@ -273,9 +410,11 @@ func StartTasks(cfg TasksConfig) (*Tasks, error) {
 	segCleaner := pathdb.NewCleaner(cfg.PathDB, "control_pathstorage_segments")
 	segRevCleaner := revcache.NewCleaner(cfg.RevCache, "control_pathstorage_revocation")
 	return &Tasks{
-		Originator: cfg.Originator(),
-		Propagator: cfg.Propagator(),
-		Registrars: cfg.SegmentWriters(),
+		LeaseChecker:   cfg.LeaseChecker(),
+		Originator:     cfg.Originator(),
+		Propagator:     cfg.Propagator(),
+		Registrars:     cfg.SegmentWriters(),
+		RegistryProber: cfg.HiddenPathRegistryProber(),
 		PathCleaner: periodic.Start(
 			periodic.Func{
 				Task: func(ctx context.Context) {
@@ -303,6 +442,8 @@ func (t *Tasks) Kill() {
 		t.Propagator,
 		t.PathCleaner,
 		t.DRKeyPrefetcher,
+		t.LeaseChecker,
+		t.RegistryProber,
 	})
 	killRunners(t.Registrars)
 	killRunners(t.DRKeyCleaners)
@@ -312,6 +453,44 @@ func (t *Tasks) Kill() {
 	t.Registrars = nil
 	t.DRKeyPrefetcher = nil
 	t.DRKeyCleaners = nil
+	t.LeaseChecker = nil
+	t.RegistryProber = nil
+}
+
+// Shutdown stops all tasks. The runs in progress, e.g., segment
+// registrations, are finished unless ctx is done before, in which case they
+// are canceled.
+func (t *Tasks) Shutdown(ctx context.Context) {
+	if t == nil {
+		return
+	}
+	runners := append([]*periodic.Runner{
+		t.Originator,
+		t.Propagator,
+		t.PathCleaner,
+		t.DRKeyPrefetcher,
+		t.LeaseChecker,
+		t.RegistryProber,
+	}, t.Registrars...)
+	runners = append(runners, t.DRKeyCleaners...)
+	var wg sync.WaitGroup
+	for _, r := range runners {
+		wg.Add(1)
+		go func(r *periodic.Runner) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			r.Shutdown(ctx)
+		}(r)
+	}
+	wg.Wait()
+	t.Originator = nil
+	t.Propagator = nil
+	t.PathCleaner = nil
+	t.Registrars = nil
+	t.DRKeyPrefetcher = nil
+	t.DRKeyCleaners = nil
+	t.LeaseChecker = nil
+	t.RegistryProber = nil
 }
 
 func killRunners(runners []*periodic.Runner) {
@@ -327,6 +506,29 @@ type HiddenPathRegistrationCfg struct {
 	Router     snet.Router
 	Discoverer hiddenpath.Discoverer
 	RPC        hiddenpath.Register
+	// Selector selects the registry per group at which the segments are
+	// registered. If it is nil, they are registered at all registries.
+	Selector *hiddenpath.RegistrySelector
+	// ProbeInterval is the interval in which the reachability of the
+	// registries is probed for the selector.
+	ProbeInterval time.Duration
+}
+
+// RemoteCoreRegistrationCfg contains the options to register core segments at
+// the remote core ASes that originated them.
+type RemoteCoreRegistrationCfg struct {
+	// Remotes are the core ASes the segments are registered at.
+	Remotes []addr.IA
+	// Interval is the registration interval. If zero, the registration
+	// interval of the tasks is used.
+	Interval time.Duration
+	// Parallelism is the maximum number of concurrent registrations. If zero,
+	// it is not limited.
+	Parallelism int
+	// InitialBackoff and MaxBackoff bound the time a remote is skipped after
+	// failed registrations.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
 }
 
 // Store is the interface to interact with the beacon store.
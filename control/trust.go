@@ -18,10 +18,16 @@ import (
 	"context"
 	"crypto/x509"
 	"errors"
+	"io"
+	"io/fs"
+	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/scionproto/scion/control/config"
 	cstrust "github.com/scionproto/scion/control/trust"
+	"github.com/scionproto/scion/control/trust/pkcs11"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -65,22 +71,103 @@ func LoadTrustMaterial(ctx context.Context, configDir string, db trust.DB) error
 	return nil
 }
 
+// LoadRevocations loads the certificate revocation lists from the crls
+// directory in the config directory into the revocation store. A missing
+// directory is not considered an error. The logger must not be nil.
+func LoadRevocations(ctx context.Context, configDir string,
+	store *trust.RevocationStore) error {
+
+	logger := log.FromCtx(ctx)
+	crlsDir := filepath.Join(configDir, "crls")
+	if _, err := os.Stat(crlsDir); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	loaded, err := trust.LoadCRLs(ctx, crlsDir, store)
+	if err != nil {
+		return serrors.WrapStr("loading CRLs from disk", err)
+	}
+	if len(loaded.Loaded) > 0 {
+		logger.Info("CRLs loaded", "files", loaded.Loaded)
+	}
+	for f, r := range loaded.Ignored {
+		if errors.Is(r, trust.ErrAlreadyExists) {
+			logger.Debug("Ignoring existing CRL", "file", f)
+			continue
+		}
+		logger.Info("Ignoring non-CRL", "file", f, "reason", r)
+	}
+	return nil
+}
+
+// KeyRings are the key rings that provide the private keys of the control
+// service.
+type KeyRings struct {
+	// AS provides the AS signing keys.
+	AS trust.KeyRing
+	// CA provides the CA signing keys.
+	CA trust.KeyRing
+
+	closer io.Closer
+}
+
+// NewKeyRings creates the key rings of the configured backend. With the file
+// backend, the keys are loaded from the crypto directories in cfgDir. The key
+// rings must be closed when they are no longer used.
+func NewKeyRings(cfg config.KeysConfig, cfgDir string) (KeyRings, error) {
+	if cfg.Backend != config.KeysPKCS11 {
+		return KeyRings{
+			AS: cstrust.LoadingRing{Dir: filepath.Join(cfgDir, "crypto/as")},
+			CA: cstrust.LoadingRing{Dir: filepath.Join(cfgDir, "crypto/ca")},
+		}, nil
+	}
+	pin, err := os.ReadFile(cfg.PKCS11PINFile)
+	if err != nil {
+		return KeyRings{}, serrors.WrapStr("reading PKCS#11 PIN", err,
+			"file", cfg.PKCS11PINFile)
+	}
+	token, err := pkcs11.Open(pkcs11.Config{
+		Module: cfg.PKCS11Module,
+		Token:  cfg.PKCS11Token,
+		PIN:    strings.TrimSpace(string(pin)),
+	})
+	if err != nil {
+		return KeyRings{}, serrors.WrapStr("opening PKCS#11 token", err)
+	}
+	return KeyRings{
+		AS:     pkcs11.KeyRing{Token: token, Label: cfg.ASKeyLabel},
+		CA:     pkcs11.KeyRing{Token: token, Label: cfg.CAKeyLabel},
+		closer: token,
+	}, nil
+}
+
+// Close releases the backend of the key rings.
+func (r KeyRings) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
 func NewTLSCertificateLoader(
 	ia addr.IA,
 	extKeyUsage x509.ExtKeyUsage,
 	db trust.DB,
 	cfgDir string,
+	keyRing trust.KeyRing,
 ) cstrust.TLSCertificateLoader {
 
 	return cstrust.TLSCertificateLoader{
-		SignerGen: newCachingSignerGen(ia, extKeyUsage, db, cfgDir),
+		SignerGen: newCachingSignerGen(ia, extKeyUsage, db, cfgDir, keyRing),
 	}
 }
 
-// NewSigner creates a renewing signer backed by a certificate chain.
-func NewSigner(ia addr.IA, db trust.DB, cfgDir string) cstrust.RenewingSigner {
+// NewSigner creates a renewing signer backed by a certificate chain. The
+// private keys are provided by the key ring.
+func NewSigner(ia addr.IA, db trust.DB, cfgDir string,
+	keyRing trust.KeyRing) cstrust.RenewingSigner {
+
 	signer := cstrust.RenewingSigner{
-		SignerGen: newCachingSignerGen(ia, x509.ExtKeyUsageAny, db, cfgDir),
+		SignerGen: newCachingSignerGen(ia, x509.ExtKeyUsageAny, db, cfgDir, keyRing),
 	}
 
 	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
@@ -99,6 +186,7 @@ func newCachingSignerGen(
 	extKeyUsage x509.ExtKeyUsage,
 	db trust.DB,
 	cfgDir string,
+	keyRing trust.KeyRing,
 ) *cstrust.CachingSignerGen {
 
 	gen := trust.SignerGen{
@@ -108,9 +196,7 @@ func newCachingSignerGen(
 			TRCDirs: []string{filepath.Join(cfgDir, "certs")},
 			DB:      db,
 		},
-		KeyRing: cstrust.LoadingRing{
-			Dir: filepath.Join(cfgDir, "crypto/as"),
-		},
+		KeyRing:     keyRing,
 		ExtKeyUsage: extKeyUsage,
 	}
 	return &cstrust.CachingSignerGen{
@@ -124,7 +210,9 @@ type ChainBuilderConfig struct {
 	DB          trust.DB
 	MaxValidity time.Duration
 	ConfigDir   string
-	Metrics     renewal.Metrics
+	// KeyRing provides the CA signing keys.
+	KeyRing trust.KeyRing
+	Metrics renewal.Metrics
 
 	// ForceECDSAWithSHA512 forces the CA policy to use ECDSAWithSHA512 as the
 	// signature algorithm for signing the issued certificate. This field
@@ -146,9 +234,7 @@ func NewChainBuilder(cfg ChainBuilderConfig) renewal.ChainBuilder {
 					DB:  cfg.DB,
 					Dir: filepath.Join(cfg.ConfigDir, "crypto/ca"),
 				},
-				KeyRing: cstrust.LoadingRing{
-					Dir: filepath.Join(cfg.ConfigDir, "crypto/ca"),
-				},
+				KeyRing:              cfg.KeyRing,
 				ForceECDSAWithSHA512: cfg.ForceECDSAWithSHA512,
 				CASigners:            cfg.Metrics.CASigners,
 			},
@@ -19,4 +19,5 @@ var (
 	RequestToTRCQuery   = requestToTRCQuery
 	ChainsToResponse    = chainsToResponse
 	TRCToResponse       = trcToResponse
+	CRLsToResponse      = crlsToResponse
 )
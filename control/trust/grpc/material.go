@@ -16,18 +16,22 @@ package grpc
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 
 	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
 
 	trustmetrics "github.com/scionproto/scion/control/trust/metrics"
 	"github.com/scionproto/scion/pkg/addr"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/util"
 	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	edpb "github.com/scionproto/scion/pkg/proto/error_details"
 	"github.com/scionproto/scion/pkg/scrypto/cppki"
 	"github.com/scionproto/scion/private/tracing"
 	"github.com/scionproto/scion/private/trust"
@@ -39,6 +43,9 @@ type MaterialServer struct {
 	Provider trust.Provider
 	// IA is the local ISD-AS.
 	IA addr.IA
+	// Revocations provides the certificate revocation lists. If it is not
+	// initialized, no revocation lists are served.
+	Revocations *trust.RevocationStore
 
 	// Requests aggregates all the incoming requests received by the handler. If
 	// it is not initialized, nothing is reported.
@@ -106,11 +113,51 @@ func (s MaterialServer) TRC(ctx context.Context, req *cppb.TRCRequest) (*cppb.TR
 		s.updateMetric(span, labels.WithResult(trustmetrics.ErrInternal), err)
 		return nil, err
 	}
+	if trc.IsZero() {
+		logger.Debug("TRC not found", "id", id)
+		err := libgrpc.StatusError(codes.NotFound, "TRC not found",
+			&edpb.ErrorTRCMissing{
+				Isd:    uint32(id.ISD),
+				Base:   uint64(id.Base),
+				Serial: uint64(id.Serial),
+			},
+		)
+		s.updateMetric(span, labels.WithResult(trustmetrics.ErrNotFound), err)
+		return nil, err
+	}
 	logger.Debug("Replied with TRC", "id", id)
 	s.updateMetric(span, labels.WithResult(trustmetrics.Success), nil)
 	return trcToResponse(trc), nil
 }
 
+func (s MaterialServer) CRLs(ctx context.Context,
+	req *cppb.CRLsRequest) (*cppb.CRLsResponse, error) {
+
+	labels := requestLabels{
+		ReqType: trustmetrics.CRLReq,
+		Client:  "unknown",
+	}
+	peer, ok := peer.FromContext(ctx)
+	if ok {
+		labels.Client = trustmetrics.PeerToLabel(peer.Addr, s.IA)
+	}
+	span := opentracing.SpanFromContext(ctx)
+	if span != nil {
+		span.SetTag("query.authority_key_id", fmt.Sprintf("%x", req.AuthorityKeyId))
+	}
+	logger := log.FromCtx(ctx)
+	logger.Debug("Received CRL request",
+		"authority_key_id", fmt.Sprintf("%x", req.AuthorityKeyId), "peer", peer.Addr)
+
+	var crls []*x509.RevocationList
+	if s.Revocations != nil {
+		crls = s.Revocations.CRLs(req.AuthorityKeyId)
+	}
+	logger.Debug("Replied with CRLs", "count", len(crls))
+	s.updateMetric(span, labels.WithResult(trustmetrics.Success), nil)
+	return crlsToResponse(crls), nil
+}
+
 func (s MaterialServer) updateMetric(span opentracing.Span, l requestLabels, err error) {
 	if s.Requests != nil {
 		s.Requests.With(l.Expand()...).Add(1)
@@ -0,0 +1,56 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	trustgrpc "github.com/scionproto/scion/control/trust/grpc"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	edpb "github.com/scionproto/scion/pkg/proto/error_details"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/trust/mock_trust"
+)
+
+func TestMaterialServerTRCNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mock_trust.NewMockProvider(ctrl)
+	provider.EXPECT().GetSignedTRC(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(cppki.SignedTRC{}, nil)
+	s := trustgrpc.MaterialServer{Provider: provider}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1")},
+	})
+	_, err := s.TRC(ctx, &cppb.TRCRequest{Isd: 1, Base: 1, Serial: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	var detail edpb.ErrorTRCMissing
+	require.True(t, libgrpc.ErrorDetail(err, &detail))
+	assert.Equal(t, uint32(1), detail.Isd)
+	assert.Equal(t, uint64(1), detail.Base)
+	assert.Equal(t, uint64(2), detail.Serial)
+}
@@ -73,3 +73,13 @@ func trcToResponse(trc cppki.SignedTRC) *cppb.TRCResponse {
 		Trc: trc.Raw,
 	}
 }
+
+func crlsToResponse(crls []*x509.RevocationList) *cppb.CRLsResponse {
+	rep := &cppb.CRLsResponse{
+		Crls: make([][]byte, 0, len(crls)),
+	}
+	for _, crl := range crls {
+		rep.Crls = append(rep.Crls, crl.Raw)
+	}
+	return rep
+}
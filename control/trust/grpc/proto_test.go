@@ -140,3 +140,10 @@ func TestTRCToRep(t *testing.T) {
 	rep := trustgrpc.TRCToResponse(trc)
 	assert.Equal(t, trc.Raw, rep.Trc)
 }
+
+func TestCRLsToRep(t *testing.T) {
+	crls := []*x509.RevocationList{{Raw: []byte("ca1-crl")}, {Raw: []byte("ca2-crl")}}
+	rep := trustgrpc.CRLsToResponse(crls)
+	assert.Equal(t, [][]byte{crls[0].Raw, crls[1].Raw}, rep.Crls)
+	assert.Empty(t, trustgrpc.CRLsToResponse(nil).Crls)
+}
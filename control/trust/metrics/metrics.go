@@ -35,6 +35,7 @@ const (
 const (
 	TRCReq   = "trc_request"
 	ChainReq = "chain_request"
+	CRLReq   = "crl_request"
 )
 
 // Result types
@@ -42,6 +43,7 @@ const (
 	Success = prom.Success
 
 	ErrInternal = prom.ErrInternal
+	ErrNotFound = prom.ErrNotFound
 	ErrParse    = prom.ErrParse
 )
 
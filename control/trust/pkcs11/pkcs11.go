@@ -0,0 +1,142 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pkcs11 provides private keys that are held in a PKCS#11 token, such
+// as a hardware security module (HSM). The keys never leave the token, the
+// signatures are computed by the token.
+//
+// Accessing PKCS#11 modules requires cgo. The support is only compiled in with
+// the pkcs11 build tag. Without it, Open always fails.
+//
+// Only ECDSA keys are supported. For every private key, the token must hold
+// the corresponding public key object with the same CKA_ID.
+package pkcs11
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"io"
+	"math/big"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Config configures the access to a PKCS#11 token.
+type Config struct {
+	// Module is the path to the PKCS#11 module, i.e., the shared library
+	// provided by the vendor of the token.
+	Module string
+	// Token is the label of the token.
+	Token string
+	// PIN is the user PIN of the token.
+	PIN string
+}
+
+// KeyRing provides the private keys in a PKCS#11 token that have a specific
+// label. It implements the key ring of the trust package.
+type KeyRing struct {
+	Token *Token
+	// Label is the CKA_LABEL of the private keys.
+	Label string
+}
+
+// PrivateKeys returns the private keys in the token that have the configured
+// label.
+func (r KeyRing) PrivateKeys(ctx context.Context) ([]crypto.Signer, error) {
+	if r.Token == nil {
+		return nil, serrors.New("token not opened")
+	}
+	return r.Token.privateKeys(r.Label)
+}
+
+// signFunc signs the digest with the private key in the token and returns
+// the raw signature.
+type signFunc func(digest []byte) ([]byte, error)
+
+// signer is a crypto.Signer for an ECDSA key held in a token.
+type signer struct {
+	public *ecdsa.PublicKey
+	sign   signFunc
+}
+
+func (s signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign signs the digest in the token. The ASN.1 encoded signature is
+// returned, as for ecdsa.PrivateKey.
+func (s signer) Sign(_ io.Reader, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	raw, err := s.sign(digest)
+	if err != nil {
+		return nil, serrors.WrapStr("signing in token", err)
+	}
+	return encodeSignature(raw)
+}
+
+var curves = []struct {
+	oid   asn1.ObjectIdentifier
+	curve elliptic.Curve
+}{
+	{oid: asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}, curve: elliptic.P256()},
+	{oid: asn1.ObjectIdentifier{1, 3, 132, 0, 34}, curve: elliptic.P384()},
+	{oid: asn1.ObjectIdentifier{1, 3, 132, 0, 35}, curve: elliptic.P521()},
+}
+
+// parsePublicKey parses the public key from the CKA_EC_PARAMS and CKA_EC_POINT
+// attributes of a public key object. The parameters are the DER encoded OID of
+// the named curve, and the point is the DER encoded octet string containing
+// the uncompressed point. Some tokens omit the octet string encoding, which is
+// accepted as well.
+func parsePublicKey(params, point []byte) (*ecdsa.PublicKey, error) {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(params, &oid); err != nil {
+		return nil, serrors.WrapStr("parsing EC parameters", err)
+	}
+	var curve elliptic.Curve
+	for _, c := range curves {
+		if c.oid.Equal(oid) {
+			curve = c.curve
+		}
+	}
+	if curve == nil {
+		return nil, serrors.New("unsupported curve", "oid", oid)
+	}
+	var raw []byte
+	if rest, err := asn1.Unmarshal(point, &raw); err != nil || len(rest) != 0 {
+		raw = point
+	}
+	x, y := elliptic.Unmarshal(curve, raw)
+	if x == nil {
+		return nil, serrors.New("invalid EC point")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// encodeSignature converts the raw ECDSA signature returned by the token,
+// which is the concatenation of r and s, to the ASN.1 encoding.
+func encodeSignature(raw []byte) ([]byte, error) {
+	if len(raw) == 0 || len(raw)%2 != 0 {
+		return nil, serrors.New("invalid signature length", "length", len(raw))
+	}
+	sig := struct {
+		R, S *big.Int
+	}{
+		R: new(big.Int).SetBytes(raw[:len(raw)/2]),
+		S: new(big.Int).SetBytes(raw[len(raw)/2:]),
+	}
+	return asn1.Marshal(sig)
+}
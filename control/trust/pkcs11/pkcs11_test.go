@@ -0,0 +1,107 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs11
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	testCases := map[string]struct {
+		Curve elliptic.Curve
+		OID   asn1.ObjectIdentifier
+	}{
+		"P-256": {Curve: elliptic.P256(), OID: asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}},
+		"P-384": {Curve: elliptic.P384(), OID: asn1.ObjectIdentifier{1, 3, 132, 0, 34}},
+		"P-521": {Curve: elliptic.P521(), OID: asn1.ObjectIdentifier{1, 3, 132, 0, 35}},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			key, err := ecdsa.GenerateKey(tc.Curve, rand.Reader)
+			require.NoError(t, err)
+			params, err := asn1.Marshal(tc.OID)
+			require.NoError(t, err)
+			raw := elliptic.Marshal(tc.Curve, key.X, key.Y)
+			point, err := asn1.Marshal(raw)
+			require.NoError(t, err)
+
+			public, err := parsePublicKey(params, point)
+			require.NoError(t, err)
+			assert.True(t, key.PublicKey.Equal(public))
+
+			// Tokens that do not wrap the point in an octet string.
+			public, err = parsePublicKey(params, raw)
+			require.NoError(t, err)
+			assert.True(t, key.PublicKey.Equal(public))
+		})
+	}
+
+	t.Run("unsupported curve", func(t *testing.T) {
+		params, err := asn1.Marshal(asn1.ObjectIdentifier{1, 3, 132, 0, 10})
+		require.NoError(t, err)
+		_, err = parsePublicKey(params, []byte{4, 1, 2})
+		assert.Error(t, err)
+	})
+	t.Run("invalid point", func(t *testing.T) {
+		params, err := asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+		require.NoError(t, err)
+		_, err = parsePublicKey(params, []byte{4, 1, 2})
+		assert.Error(t, err)
+	})
+}
+
+func TestSignerSign(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	s := signer{
+		public: &key.PublicKey,
+		// Simulate a token that returns the raw concatenation of r and s.
+		sign: func(digest []byte) ([]byte, error) {
+			r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+			if err != nil {
+				return nil, err
+			}
+			raw := make([]byte, 64)
+			r.FillBytes(raw[:32])
+			s.FillBytes(raw[32:])
+			return raw, nil
+		},
+	}
+	digest := sha256.Sum256([]byte("message"))
+	sig, err := s.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig))
+	assert.Equal(t, &key.PublicKey, s.Public())
+
+	_, err = encodeSignature([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestKeyRingNotOpened(t *testing.T) {
+	_, err := KeyRing{Label: "cp-as"}.PrivateKeys(context.Background())
+	assert.Error(t, err)
+}
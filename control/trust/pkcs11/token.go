@@ -0,0 +1,181 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build pkcs11
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+
+	p11 "github.com/miekg/pkcs11"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Token is an open session to a PKCS#11 token. The session is shared by all
+// keys of the token, the operations are serialized.
+type Token struct {
+	mu      sync.Mutex
+	ctx     *p11.Ctx
+	session p11.SessionHandle
+}
+
+// Open loads the PKCS#11 module, opens a session to the token with the
+// configured label and logs in with the PIN.
+func Open(cfg Config) (*Token, error) {
+	ctx := p11.New(cfg.Module)
+	if ctx == nil {
+		return nil, serrors.New("loading PKCS#11 module", "module", cfg.Module)
+	}
+	if err := ctx.Initialize(); err != nil && !isError(err, p11.CKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		ctx.Destroy()
+		return nil, serrors.WrapStr("initializing PKCS#11 module", err, "module", cfg.Module)
+	}
+	t := &Token{ctx: ctx}
+	if err := t.open(cfg); err != nil {
+		ctx.Finalize()
+		ctx.Destroy()
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Token) open(cfg Config) error {
+	slots, err := t.ctx.GetSlotList(true)
+	if err != nil {
+		return serrors.WrapStr("listing slots", err)
+	}
+	for _, slot := range slots {
+		info, err := t.ctx.GetTokenInfo(slot)
+		if err != nil || info.Label != cfg.Token {
+			continue
+		}
+		if t.session, err = t.ctx.OpenSession(slot, p11.CKF_SERIAL_SESSION); err != nil {
+			return serrors.WrapStr("opening session", err, "token", cfg.Token)
+		}
+		err = t.ctx.Login(t.session, p11.CKU_USER, cfg.PIN)
+		if err != nil && !isError(err, p11.CKR_USER_ALREADY_LOGGED_IN) {
+			t.ctx.CloseSession(t.session)
+			return serrors.WrapStr("logging in", err, "token", cfg.Token)
+		}
+		return nil
+	}
+	return serrors.New("token not found", "token", cfg.Token)
+}
+
+// Close logs out, closes the session and unloads the module.
+func (t *Token) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ctx.Logout(t.session)
+	t.ctx.CloseSession(t.session)
+	err := t.ctx.Finalize()
+	t.ctx.Destroy()
+	return err
+}
+
+func (t *Token) privateKeys(label string) ([]crypto.Signer, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	handles, err := t.findObjects([]*p11.Attribute{
+		p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PRIVATE_KEY),
+		p11.NewAttribute(p11.CKA_KEY_TYPE, p11.CKK_EC),
+		p11.NewAttribute(p11.CKA_LABEL, label),
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("searching private keys", err, "label", label)
+	}
+	signers := make([]crypto.Signer, 0, len(handles))
+	for _, handle := range handles {
+		public, err := t.publicKey(handle)
+		if err != nil {
+			return nil, serrors.WrapStr("loading public key", err, "label", label)
+		}
+		handle := handle
+		signers = append(signers, signer{
+			public: public,
+			sign: func(digest []byte) ([]byte, error) {
+				return t.sign(handle, digest)
+			},
+		})
+	}
+	return signers, nil
+}
+
+// publicKey loads the public key that belongs to the private key, i.e., the
+// public key object with the same CKA_ID.
+func (t *Token) publicKey(private p11.ObjectHandle) (*ecdsa.PublicKey, error) {
+	attrs, err := t.ctx.GetAttributeValue(t.session, private, []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_ID, nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+	handles, err := t.findObjects([]*p11.Attribute{
+		p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PUBLIC_KEY),
+		p11.NewAttribute(p11.CKA_KEY_TYPE, p11.CKK_EC),
+		p11.NewAttribute(p11.CKA_ID, attrs[0].Value),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(handles) != 1 {
+		return nil, serrors.New("no unique public key found", "count", len(handles))
+	}
+	attrs, err = t.ctx.GetAttributeValue(t.session, handles[0], []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_EC_PARAMS, nil),
+		p11.NewAttribute(p11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parsePublicKey(attrs[0].Value, attrs[1].Value)
+}
+
+func (t *Token) findObjects(template []*p11.Attribute) ([]p11.ObjectHandle, error) {
+	if err := t.ctx.FindObjectsInit(t.session, template); err != nil {
+		return nil, err
+	}
+	defer t.ctx.FindObjectsFinal(t.session)
+	var handles []p11.ObjectHandle
+	for {
+		found, _, err := t.ctx.FindObjects(t.session, 16)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return handles, nil
+		}
+		handles = append(handles, found...)
+	}
+}
+
+func (t *Token) sign(key p11.ObjectHandle, digest []byte) ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	mechanism := []*p11.Mechanism{p11.NewMechanism(p11.CKM_ECDSA, nil)}
+	if err := t.ctx.SignInit(t.session, mechanism, key); err != nil {
+		return nil, err
+	}
+	return t.ctx.Sign(t.session, digest)
+}
+
+func isError(err error, code uint) bool {
+	var p11Err p11.Error
+	return errors.As(err, &p11Err) && uint(p11Err) == code
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !pkcs11
+
+package pkcs11
+
+import (
+	"crypto"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Token is an open session to a PKCS#11 token.
+type Token struct{}
+
+// Open always fails, because PKCS#11 support is not compiled in.
+func Open(cfg Config) (*Token, error) {
+	return nil, serrors.New("PKCS#11 support not compiled in, build with the pkcs11 tag")
+}
+
+// Close closes the session to the token.
+func (t *Token) Close() error {
+	return nil
+}
+
+func (t *Token) privateKeys(label string) ([]crypto.Signer, error) {
+	return nil, serrors.New("PKCS#11 support not compiled in, build with the pkcs11 tag")
+}
@@ -0,0 +1,272 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/trust"
+)
+
+// ChainRequester requests a renewed certificate chain from the issuing CA.
+type ChainRequester interface {
+	RequestChain(ctx context.Context, ca addr.IA,
+		req *cppb.ChainRenewalRequest) ([]*x509.Certificate, error)
+}
+
+// ChainRenewer is a periodic task that renews the AS certificate chain before
+// it expires. If the chain of the current signer expires within the lead time,
+// a fresh private key is generated and a certificate for it is requested from
+// the CA that issued the current chain. The renewed chain and the private key
+// are written to the crypto directory and inserted into the database, such that
+// the signer picks them up.
+type ChainRenewer struct {
+	// IA is the local ISD-AS.
+	IA addr.IA
+	// SignerGen generates the current signer.
+	SignerGen SignerGen
+	// DB is used to verify the renewed chain and stores it.
+	DB trust.DB
+	// Dir is the directory the renewed chain and private key are written to.
+	Dir string
+	// Requester sends the renewal requests.
+	Requester ChainRequester
+	// LeadTime is the time before the expiration of the current chain at
+	// which renewal is attempted.
+	LeadTime time.Duration
+	// Renewals counts the renewal attempts. It must be instantiated with the
+	// label "result". If it is nil, nothing is reported.
+	Renewals metrics.Counter
+}
+
+// Name returns the tasks name.
+func (r *ChainRenewer) Name() string {
+	return "control_trust_chain_renewer"
+}
+
+// Run renews the certificate chain if it is about to expire.
+func (r *ChainRenewer) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	signer, err := r.SignerGen.Generate(ctx)
+	if err != nil {
+		logger.Info("Failed to load signer for certificate renewal", "err", err)
+		return
+	}
+	expiration := signer.ChainValidity.NotAfter
+	if time.Until(expiration) > r.LeadTime {
+		return
+	}
+	logger.Info("Renewing AS certificate chain", "expiration", expiration)
+	chain, result, err := r.renew(ctx, signer)
+	metrics.CounterInc(metrics.CounterWith(r.Renewals, prom.LabelResult, result))
+	if err != nil {
+		logger.Info("Failed to renew AS certificate chain", "err", err)
+		return
+	}
+	logger.Info("Renewed AS certificate chain",
+		"subject_key_id", fmt.Sprintf("%x", chain[0].SubjectKeyId),
+		"validity", cppki.Validity{
+			NotBefore: chain[0].NotBefore,
+			NotAfter:  chain[0].NotAfter,
+		},
+	)
+}
+
+func (r *ChainRenewer) renew(ctx context.Context,
+	signer trust.Signer) ([]*x509.Certificate, string, error) {
+
+	if len(signer.Chain) != 2 {
+		return nil, prom.ErrInternal, serrors.New("signer without certificate chain")
+	}
+	ca, err := cppki.ExtractIA(signer.Chain[1].Subject)
+	if err != nil {
+		return nil, prom.ErrInternal, serrors.WrapStr("extracting CA ISD-AS", err)
+	}
+	key, err := newPrivateKey(signer.PrivateKey)
+	if err != nil {
+		return nil, prom.ErrInternal, serrors.WrapStr("generating private key", err)
+	}
+	csr, err := newCSR(signer.Chain[0], key)
+	if err != nil {
+		return nil, prom.ErrInternal, serrors.WrapStr("creating CSR", err)
+	}
+	req, err := renewal.NewChainRenewalRequest(ctx, csr, signer)
+	if err != nil {
+		return nil, prom.ErrCrypto, serrors.WrapStr("signing renewal request", err)
+	}
+	chain, err := r.Requester.RequestChain(ctx, ca, req)
+	if err != nil {
+		return nil, prom.ErrNetwork, serrors.WrapStr("requesting chain", err, "ca", ca)
+	}
+	if err := r.verify(ctx, chain, key); err != nil {
+		return nil, prom.ErrVerify, serrors.WrapStr("verifying renewed chain", err)
+	}
+	if err := r.install(ctx, chain, key); err != nil {
+		return nil, prom.ErrInternal, serrors.WrapStr("installing renewed chain", err)
+	}
+	return chain, prom.Success, nil
+}
+
+// verify checks that the chain authenticates the private key and is verifiable
+// with the active TRCs.
+func (r *ChainRenewer) verify(ctx context.Context, chain []*x509.Certificate,
+	key crypto.Signer) error {
+
+	skid, err := cppki.SubjectKeyID(key.Public())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(chain[0].SubjectKeyId, skid) {
+		return serrors.New("chain does not authenticate private key")
+	}
+	ia, err := cppki.ExtractIA(chain[0].Subject)
+	if err != nil {
+		return err
+	}
+	if !ia.Equal(r.IA) {
+		return serrors.New("chain for wrong ISD-AS", "expected", r.IA, "actual", ia)
+	}
+	trcs, err := r.activeTRCs(ctx)
+	if err != nil {
+		return err
+	}
+	return cppki.VerifyChain(chain, cppki.VerifyOptions{TRC: trcs})
+}
+
+func (r *ChainRenewer) activeTRCs(ctx context.Context) ([]*cppki.TRC, error) {
+	trc, err := r.DB.SignedTRC(ctx, cppki.TRCID{
+		ISD:    r.IA.ISD(),
+		Base:   scrypto.LatestVer,
+		Serial: scrypto.LatestVer,
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("loading TRC", err)
+	}
+	if trc.IsZero() {
+		return nil, serrors.New("TRC not found", "isd", r.IA.ISD())
+	}
+	trcs := []*cppki.TRC{&trc.TRC}
+	if !trc.TRC.InGracePeriod(time.Now()) {
+		return trcs, nil
+	}
+	grace, err := r.DB.SignedTRC(ctx, cppki.TRCID{
+		ISD:    r.IA.ISD(),
+		Base:   trc.TRC.ID.Base,
+		Serial: trc.TRC.ID.Serial - 1,
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("loading grace TRC", err)
+	}
+	if !grace.IsZero() {
+		trcs = append(trcs, &grace.TRC)
+	}
+	return trcs, nil
+}
+
+// install writes the private key and the chain to the crypto directory and
+// inserts the chain into the database. The key is written first, such that the
+// chain is never used without its key. Each file is replaced atomically.
+func (r *ChainRenewer) install(ctx context.Context, chain []*x509.Certificate,
+	key crypto.Signer) error {
+
+	rawKey, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return serrors.WrapStr("encoding private key", err)
+	}
+	var pemChain []byte
+	for _, cert := range chain {
+		pemChain = append(pemChain, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: cert.Raw,
+		})...)
+	}
+	suffix := fmt.Sprintf("%d", chain[0].NotBefore.Unix())
+	keyFile := filepath.Join(r.Dir, "cp-as."+suffix+".key")
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rawKey})
+	if err := writeFileAtomic(keyFile, pemKey, 0600); err != nil {
+		return serrors.WrapStr("writing private key", err)
+	}
+	chainFile := filepath.Join(r.Dir, fmt.Sprintf("%s.%s.pem",
+		addr.FormatIA(r.IA, addr.WithDefaultPrefix(), addr.WithFileSeparator()), suffix))
+	if err := writeFileAtomic(chainFile, pemChain, 0644); err != nil {
+		return serrors.WrapStr("writing certificate chain", err)
+	}
+	if _, err := r.DB.InsertChain(ctx, chain); err != nil {
+		return serrors.WrapStr("inserting certificate chain", err)
+	}
+	return nil
+}
+
+// newPrivateKey generates a private key on the same curve as the current key.
+func newPrivateKey(current crypto.Signer) (crypto.Signer, error) {
+	curve := elliptic.P256()
+	if key, ok := current.(*ecdsa.PrivateKey); ok {
+		curve = key.Curve
+	}
+	return ecdsa.GenerateKey(curve, rand.Reader)
+}
+
+// newCSR creates a CSR for the key with the subject of the current certificate.
+func newCSR(current *x509.Certificate, key crypto.Signer) ([]byte, error) {
+	subject := current.Subject
+	// Only the extra names are encoded. They override the parsed attributes
+	// with the same type, e.g., the common name.
+	subject.ExtraNames = subject.Names
+	return x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: subject,
+	}, key)
+}
+
+// writeFileAtomic writes the data to a temporary file in the same directory and
+// renames it to the target file.
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".renewal-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"context"
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstrust "github.com/scionproto/scion/control/trust"
+	"github.com/scionproto/scion/control/trust/mock_trust"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/scrypto/cms/protocol"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/pkg/scrypto/signed"
+	"github.com/scionproto/scion/private/trust"
+	mock_libtrust "github.com/scionproto/scion/private/trust/mock_trust"
+)
+
+func TestChainRenewerRun(t *testing.T) {
+	dir := genCrypto(t)
+
+	ia := xtest.MustParseIA("1-ff00:0:111")
+	trc := xtest.LoadTRC(t, filepath.Join(dir, "trcs/ISD1-B1-S1.trc"))
+	chain := xtest.LoadChain(t,
+		filepath.Join(dir, "ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem"))
+	key := xtest.LoadSigner(t, filepath.Join(dir, "ISD1/ASff00_0_111/crypto/as/cp-as.key"))
+	signer := trust.Signer{
+		PrivateKey:   key,
+		Algorithm:    signed.ECDSAWithSHA256,
+		IA:           ia,
+		Subject:      chain[0].Subject,
+		Chain:        chain,
+		SubjectKeyID: chain[0].SubjectKeyId,
+		Expiration:   chain[0].NotAfter,
+		TRCID:        trc.TRC.ID,
+		ChainValidity: cppki.Validity{
+			NotBefore: chain[0].NotBefore,
+			NotAfter:  chain[0].NotAfter,
+		},
+	}
+	ca := cppki.CAPolicy{
+		Validity: 24 * time.Hour,
+		Certificate: xtest.LoadChain(t,
+			filepath.Join(dir, "ISD1/ASff00_0_110/crypto/ca/ISD1-ASff00_0_110.ca.crt"))[0],
+		Signer: xtest.LoadSigner(t, filepath.Join(dir, "ISD1/ASff00_0_110/crypto/ca/cp-ca.key")),
+	}
+	issue := func(_ context.Context, _ addr.IA,
+		req *cppb.ChainRenewalRequest) ([]*x509.Certificate, error) {
+
+		csr, err := extractCSR(req.CmsSignedRequest)
+		require.NoError(t, err)
+		return ca.CreateChain(csr)
+	}
+
+	testCases := map[string]struct {
+		LeadTime  time.Duration
+		Requester requesterFunc
+		DB        func(ctrl *gomock.Controller) trust.DB
+		Renewed   bool
+	}{
+		"not expiring": {
+			LeadTime: time.Hour,
+			Requester: func(context.Context, addr.IA,
+				*cppb.ChainRenewalRequest) ([]*x509.Certificate, error) {
+
+				panic("should not be called")
+			},
+			DB: func(ctrl *gomock.Controller) trust.DB {
+				return mock_libtrust.NewMockDB(ctrl)
+			},
+		},
+		"renewed": {
+			LeadTime:  2 * 365 * 24 * time.Hour,
+			Requester: issue,
+			DB: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_libtrust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				db.EXPECT().InsertChain(gomock.Any(), gomock.Any()).Return(true, nil)
+				return db
+			},
+			Renewed: true,
+		},
+		"request fails": {
+			LeadTime: 2 * 365 * 24 * time.Hour,
+			Requester: func(context.Context, addr.IA,
+				*cppb.ChainRenewalRequest) ([]*x509.Certificate, error) {
+
+				return nil, serrors.New("unavailable")
+			},
+			DB: func(ctrl *gomock.Controller) trust.DB {
+				return mock_libtrust.NewMockDB(ctrl)
+			},
+		},
+		"chain for other key": {
+			LeadTime: 2 * 365 * 24 * time.Hour,
+			Requester: func(context.Context, addr.IA,
+				*cppb.ChainRenewalRequest) ([]*x509.Certificate, error) {
+
+				return chain, nil
+			},
+			DB: func(ctrl *gomock.Controller) trust.DB {
+				return mock_libtrust.NewMockDB(ctrl)
+			},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gen := mock_trust.NewMockSignerGen(ctrl)
+			gen.EXPECT().Generate(gomock.Any()).Return(signer, nil)
+			outDir := t.TempDir()
+			renewer := cstrust.ChainRenewer{
+				IA:        ia,
+				SignerGen: gen,
+				DB:        tc.DB(ctrl),
+				Dir:       outDir,
+				Requester: tc.Requester,
+				LeadTime:  tc.LeadTime,
+			}
+			renewer.Run(context.Background())
+
+			keys, err := cstrust.LoadingRing{Dir: outDir}.PrivateKeys(context.Background())
+			require.NoError(t, err)
+			chains, err := filepath.Glob(filepath.Join(outDir, "*.pem"))
+			require.NoError(t, err)
+			if !tc.Renewed {
+				assert.Empty(t, keys)
+				assert.Empty(t, chains)
+				return
+			}
+			require.Len(t, keys, 1)
+			require.Len(t, chains, 1)
+			renewed := xtest.LoadChain(t, chains[0])
+			skid, err := cppki.SubjectKeyID(keys[0].Public())
+			require.NoError(t, err)
+			assert.Equal(t, skid, renewed[0].SubjectKeyId)
+			assert.Equal(t, chain[0].Subject.String(), renewed[0].Subject.String())
+		})
+	}
+}
+
+type requesterFunc func(context.Context, addr.IA,
+	*cppb.ChainRenewalRequest) ([]*x509.Certificate, error)
+
+func (f requesterFunc) RequestChain(ctx context.Context, ca addr.IA,
+	req *cppb.ChainRenewalRequest) ([]*x509.Certificate, error) {
+
+	return f(ctx, ca, req)
+}
+
+func extractCSR(raw []byte) (*x509.CertificateRequest, error) {
+	ci, err := protocol.ParseContentInfo(raw)
+	if err != nil {
+		return nil, err
+	}
+	sd, err := ci.SignedDataContent()
+	if err != nil {
+		return nil, err
+	}
+	csr, err := sd.EncapContentInfo.DataEContent()
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificateRequest(csr)
+}
@@ -20,9 +20,11 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	cs "github.com/scionproto/scion/control"
+	"github.com/scionproto/scion/control/config"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/private/app/command"
 	"github.com/scionproto/scion/private/storage/trust/sqlite"
@@ -35,16 +37,32 @@ func TestNewSigner(t *testing.T) {
 	db, err := sqlite.New("file::memory:")
 	require.NoError(t, err)
 
+	cfgDir := filepath.Join(dir, "/ISD1/ASff00_0_110")
+	keyRings, err := cs.NewKeyRings(config.KeysConfig{Backend: config.KeysFile}, cfgDir)
+	require.NoError(t, err)
+	defer keyRings.Close()
+
 	signer := cs.NewSigner(
 		xtest.MustParseIA("1-ff00:0:110"),
 		db,
-		filepath.Join(dir, "/ISD1/ASff00_0_110"),
+		cfgDir,
+		keyRings.AS,
 	)
 
 	_, err = signer.Sign(context.Background(), []byte("message"))
 	require.NoError(t, err)
 }
 
+func TestNewKeyRingsPKCS11(t *testing.T) {
+	_, err := cs.NewKeyRings(config.KeysConfig{
+		Backend:       config.KeysPKCS11,
+		PKCS11Module:  "/nonexistent/libpkcs11.so",
+		PKCS11Token:   "scion",
+		PKCS11PINFile: filepath.Join(t.TempDir(), "pin"),
+	}, t.TempDir())
+	assert.Error(t, err)
+}
+
 func testCrypto(t *testing.T) string {
 	dir := t.TempDir()
 
@@ -0,0 +1,111 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/control/config"
+	"github.com/scionproto/scion/control/segreq"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/keyconf"
+	"github.com/scionproto/scion/private/topology"
+)
+
+// ValidateConfig loads and cross-validates the files referenced by the control
+// service configuration, i.e., the topology, the master keys, the beaconing
+// policies, the registration filter, the segment lookup ACL and the hidden path
+// configuration. All errors are reported at once in a serrors.List.
+func ValidateConfig(cfg *config.Config) error {
+	var errs serrors.List
+	topo, err := loadTopology(cfg.General.Topology(), cfg.General.ID)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := keyconf.LoadMaster(filepath.Join(cfg.General.ConfigDir, "keys")); err != nil {
+		errs = append(errs, serrors.WrapStr("loading master keys", err))
+	}
+	if topo != nil {
+		if topo.Core() {
+			_, err = LoadCorePolicies(cfg.BS.Policies)
+		} else {
+			_, err = LoadNonCorePolicies(cfg.BS.Policies)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if file := cfg.BS.Policies.RegistrationFilter; file != "" {
+		loader := &beaconing.RegistrationFilterLoader{File: file}
+		if err := loader.Load(); err != nil {
+			errs = append(errs, serrors.WrapStr("loading registration filter", err))
+		}
+	}
+	if location := cfg.PS.SegmentLookupACL; location != "" {
+		if _, err := segreq.LoadLookupACL(location); err != nil {
+			errs = append(errs, serrors.WrapStr("loading segment lookup ACL", err))
+		}
+	}
+	if location := cfg.PS.HiddenPathsCfg; location != "" {
+		_, policy, err := hiddenpath.LoadConfiguration(location)
+		switch {
+		case err != nil:
+			errs = append(errs, serrors.WrapStr("loading hidden paths configuration", err))
+		case topo != nil:
+			errs = append(errs, validateRegistrationPolicy(policy, topo)...)
+		}
+	}
+	return errs.ToError()
+}
+
+func loadTopology(file, id string) (topology.Topology, error) {
+	rw, err := topology.RWTopologyFromJSONFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("loading topology", err, "file", file)
+	}
+	validator := &topology.ControlValidator{ID: id}
+	if err := validator.Validate(rw, nil); err != nil {
+		return nil, serrors.WrapStr("validating topology", err, "file", file)
+	}
+	return topology.FromRWTopology(rw), nil
+}
+
+// validateRegistrationPolicy checks that the hidden path registration policy
+// only refers to interfaces that exist in the topology.
+func validateRegistrationPolicy(policy hiddenpath.RegistrationPolicy,
+	topo topology.Topology) []error {
+
+	ifIDs := make(map[common.IFIDType]struct{})
+	for _, ifID := range topo.InterfaceIDs() {
+		ifIDs[ifID] = struct{}{}
+	}
+	policyIDs := make([]uint64, 0, len(policy))
+	for ifID := range policy {
+		policyIDs = append(policyIDs, ifID)
+	}
+	sort.Slice(policyIDs, func(i, j int) bool { return policyIDs[i] < policyIDs[j] })
+	var errs []error
+	for _, ifID := range policyIDs {
+		if _, ok := ifIDs[common.IFIDType(ifID)]; !ok {
+			errs = append(errs, serrors.New("hidden path registration policy for unknown interface",
+				"interface_id", ifID))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cs "github.com/scionproto/scion/control"
+	"github.com/scionproto/scion/control/config"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const validateTopo = `{
+  "isd_as": "1-ff00:0:110",
+  "mtu": 1472,
+  "attributes": ["core"],
+  "border_routers": {
+    "br1-ff00_0_110-1": {
+      "internal_addr": "127.0.0.1:31002",
+      "interfaces": {
+        "1": {
+          "underlay": {"public": "127.0.0.4:50000", "remote": "127.0.0.5:50000"},
+          "isd_as": "1-ff00:0:111",
+          "link_to": "CHILD",
+          "mtu": 1472
+        }
+      }
+    }
+  },
+  "control_service": {
+    "cs1-ff00_0_110-1": {"addr": "127.0.0.1:31000"}
+  }
+}`
+
+const validateHiddenPaths = `---
+groups:
+  ff00:0:110-1:
+    owner: 1-ff00:0:110
+    writers:
+    - 1-ff00:0:110
+    readers:
+    - 1-ff00:0:111
+    registries:
+    - 1-ff00:0:110
+registration_policy_per_interface:
+  1:
+  - ff00:0:110-1
+  2:
+  - public
+`
+
+func TestValidateConfig(t *testing.T) {
+	prepare := func(t *testing.T) *config.Config {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "topology.json"),
+			[]byte(validateTopo), 0644))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "keys"), 0755))
+		for _, f := range []string{"master0.key", "master1.key"} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "keys", f),
+				[]byte("MTIzNDU2Nzg5MGFiY2RlZg=="), 0644))
+		}
+		cfg := &config.Config{}
+		cfg.InitDefaults()
+		cfg.General.ID = "cs1-ff00_0_110-1"
+		cfg.General.ConfigDir = dir
+		return cfg
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		cfg := prepare(t)
+		assert.NoError(t, cs.ValidateConfig(cfg))
+	})
+	t.Run("all errors reported", func(t *testing.T) {
+		cfg := prepare(t)
+		cfg.General.ID = "cs1-ff00_0_110-2"
+		require.NoError(t, os.Remove(filepath.Join(cfg.General.ConfigDir, "keys", "master1.key")))
+		cfg.BS.Policies.Propagation = filepath.Join(cfg.General.ConfigDir, "missing.yml")
+		err := cs.ValidateConfig(cfg)
+		var errs serrors.List
+		require.ErrorAs(t, err, &errs)
+		// The topology does not contain the ID and the master key is missing.
+		// The policies are not checked without a valid topology.
+		assert.Len(t, errs, 2)
+	})
+	t.Run("hidden path policy for unknown interface", func(t *testing.T) {
+		cfg := prepare(t)
+		cfg.PS.HiddenPathsCfg = filepath.Join(cfg.General.ConfigDir, "hp.yml")
+		require.NoError(t, os.WriteFile(cfg.PS.HiddenPathsCfg, []byte(validateHiddenPaths),
+			0644))
+		err := cs.ValidateConfig(cfg)
+		var errs serrors.List
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "unknown interface")
+	})
+	t.Run("invalid segment lookup ACL", func(t *testing.T) {
+		cfg := prepare(t)
+		cfg.PS.SegmentLookupACL = filepath.Join(cfg.General.ConfigDir, "acl.yml")
+		require.NoError(t, os.WriteFile(cfg.PS.SegmentLookupACL,
+			[]byte("destinations:\n  \"1-0\":\n    deny: [\"0-0\"]\n"), 0644))
+		err := cs.ValidateConfig(cfg)
+		var errs serrors.List
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "segment lookup ACL")
+	})
+}
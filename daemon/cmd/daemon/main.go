@@ -30,25 +30,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/scionproto/scion/daemon"
 	"github.com/scionproto/scion/daemon/config"
-	sd_drkey "github.com/scionproto/scion/daemon/drkey"
-	sd_grpc "github.com/scionproto/scion/daemon/drkey/grpc"
-	"github.com/scionproto/scion/daemon/fetcher"
 	api "github.com/scionproto/scion/daemon/mgmtapi"
-	"github.com/scionproto/scion/pkg/addr"
-	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
-	hpgrpc "github.com/scionproto/scion/pkg/experimental/hiddenpath/grpc"
 	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
-	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
 	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
-	"github.com/scionproto/scion/pkg/scrypto/signed"
 	"github.com/scionproto/scion/private/app"
 	"github.com/scionproto/scion/private/app/launcher"
 	cppkiapi "github.com/scionproto/scion/private/mgmtapi/cppki/api"
@@ -56,18 +49,13 @@ import (
 	"github.com/scionproto/scion/private/pathdb"
 	"github.com/scionproto/scion/private/periodic"
 	"github.com/scionproto/scion/private/revcache"
-	"github.com/scionproto/scion/private/segment/segfetcher"
-	segfetchergrpc "github.com/scionproto/scion/private/segment/segfetcher/grpc"
-	infra "github.com/scionproto/scion/private/segment/verifier"
 	"github.com/scionproto/scion/private/service"
 	"github.com/scionproto/scion/private/storage"
-	"github.com/scionproto/scion/private/storage/drkey/level2"
+	pathdbcache "github.com/scionproto/scion/private/storage/path/cache"
 	pathstoragemetrics "github.com/scionproto/scion/private/storage/path/metrics"
 	truststoragemetrics "github.com/scionproto/scion/private/storage/trust/metrics"
 	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/private/trust"
-	"github.com/scionproto/scion/private/trust/compat"
-	trustmetrics "github.com/scionproto/scion/private/trust/metrics"
 )
 
 var globalCfg config.Config
@@ -76,7 +64,11 @@ func main() {
 	application := launcher.Application{
 		TOMLConfig: &globalCfg,
 		ShortName:  "SCION Daemon",
-		Main:       realMain,
+		Validate:   func(context.Context) error { return daemon.ValidateConfig(&globalCfg) },
+		ShutdownTimeout: func() time.Duration {
+			return globalCfg.General.ShutdownTimeout.Duration
+		},
+		Main: realMain,
 	}
 	application.Run()
 }
@@ -92,12 +84,15 @@ func realMain(ctx context.Context) error {
 		return serrors.WrapStr("creating topology loader", err)
 	}
 	g, errCtx := errgroup.WithContext(ctx)
+	shutdownCtx, cancelShutdown := app.WithShutdownTimeout(errCtx,
+		globalCfg.General.ShutdownTimeout.Duration)
+	defer cancelShutdown()
 	g.Go(func() error {
 		defer log.HandlePanic()
 		return topo.Run(errCtx)
 	})
 
-	closer, err := daemon.InitTracer(globalCfg.Tracing, globalCfg.General.ID)
+	closer, err := globalCfg.Tracing.InitTracer(globalCfg.General.ID)
 	if err != nil {
 		return serrors.WrapStr("initializing tracer", err)
 	}
@@ -111,6 +106,22 @@ func realMain(ctx context.Context) error {
 	pathDB = pathstoragemetrics.WrapDB(pathDB, pathstoragemetrics.Config{
 		Driver: string(storage.BackendSqlite),
 	})
+	if !globalCfg.SD.DisablePathDBCache {
+		pathDB, err = pathdbcache.WrapDB(pathDB, pathdbcache.Config{
+			Size: globalCfg.SD.PathDBCacheSize,
+			TTL:  globalCfg.SD.PathDBCacheTTL.Duration,
+			Lookups: metrics.NewPromCounter(promauto.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "sd_pathdb_cache_lookups_total",
+					Help: "Total number of path DB lookups, by result of the cache lookup.",
+				},
+				[]string{prom.LabelResult},
+			)),
+		})
+		if err != nil {
+			return serrors.WrapStr("initializing path storage cache", err)
+		}
+	}
 	defer pathDB.Close()
 	defer revCache.Close()
 	cleaner := periodic.Start(pathdb.NewCleaner(pathDB, "sd_segments"),
@@ -120,19 +131,6 @@ func realMain(ctx context.Context) error {
 		10*time.Second, 10*time.Second)
 	defer rcCleaner.Stop()
 
-	dialer := &libgrpc.TCPDialer{
-		SvcResolver: func(dst addr.SVC) []resolver.Address {
-			if base := dst.Base(); base != addr.SvcCS {
-				panic("Unsupported address type, implementation error?")
-			}
-			targets := []resolver.Address{}
-			for _, entry := range topo.ControlServiceAddresses() {
-				targets = append(targets, resolver.Address{Addr: entry.String()})
-			}
-			return targets
-		},
-	}
-
 	trustDB, err := storage.NewTrustStorage(globalCfg.TrustDB)
 	if err != nil {
 		return serrors.WrapStr("initializing trust database", err)
@@ -148,16 +146,6 @@ func realMain(ctx context.Context) error {
 			[]string{"driver", "operation", prom.LabelResult},
 		),
 	})
-	engine, err := daemon.TrustEngine(globalCfg.General.ConfigDir, topo.IA(), trustDB, dialer)
-	if err != nil {
-		return serrors.WrapStr("creating trust engine", err)
-	}
-	engine.Inspector = trust.CachingInspector{
-		Inspector:          engine.Inspector,
-		Cache:              globalCfg.TrustEngine.Cache.New(),
-		CacheHits:          metrics.NewPromCounter(trustmetrics.CacheHitsTotal),
-		MaxCacheExpiration: globalCfg.TrustEngine.Cache.Expiration.Duration,
-	}
 	trcLoader := periodic.Start(periodic.Func{
 		Task: func(ctx context.Context) {
 			trcDirs := filepath.Join(globalCfg.General.ConfigDir, "certs")
@@ -173,112 +161,47 @@ func realMain(ctx context.Context) error {
 	}, 10*time.Second, 10*time.Second)
 	defer trcLoader.Stop()
 
-	var drkeyClientEngine *sd_drkey.ClientEngine
-	if globalCfg.DRKeyLevel2DB.Connection != "" {
-		backend, err := storage.NewDRKeyLevel2Storage(globalCfg.DRKeyLevel2DB)
-		if err != nil {
-			return serrors.WrapStr("creating level2 DRKey DB", err)
-		}
-		counter := metrics.NewPromCounter(
-			promauto.NewCounterVec(
-				prometheus.CounterOpts{
-					Name: "drkey_level2db_queries_total",
-					Help: "Total queries to the database",
-				},
-				[]string{"operation", prom.LabelResult},
-			),
-		)
-		level2DB := &level2.Database{
-			Backend: backend,
-			Metrics: &level2.Metrics{
-				QueriesTotal: func(op, label string) metrics.Counter {
-					return metrics.CounterWith(
-						counter,
-						"operation", op,
-						prom.LabelResult, label,
-					)
-				},
-			},
-		}
-		defer level2DB.Close()
-
-		drkeyFetcher := &sd_grpc.Fetcher{
-			Dialer: dialer,
-		}
-		drkeyClientEngine = &sd_drkey.ClientEngine{
-			IA:      topo.IA(),
-			DB:      level2DB,
-			Fetcher: drkeyFetcher,
-		}
-		cleaners := drkeyClientEngine.CreateStorageCleaners()
-		for _, cleaner := range cleaners {
-			cleaner_task := periodic.Start(cleaner,
-				5*time.Minute, 5*time.Minute)
-			defer cleaner_task.Stop()
-		}
-	}
-
 	listen := daemon.APIAddress(globalCfg.SD.Address)
 	listener, err := net.Listen("tcp", listen)
 	if err != nil {
 		return serrors.WrapStr("listening", err)
 	}
 
-	hpGroups, err := hiddenpath.LoadHiddenPathGroups(globalCfg.SD.HiddenPathGroups)
+	serverCfg, serverCleanup, err := daemon.NewServerConfig(&globalCfg, daemon.ServerDeps{
+		Topology: topo,
+		PathDB:   pathDB,
+		TrustDB:  trustDB,
+		RevCache: revCache,
+	})
 	if err != nil {
-		return serrors.WrapStr("loading hidden path groups", err)
-	}
-	var requester segfetcher.RPC = &segfetchergrpc.Requester{
-		Dialer: dialer,
-	}
-	if len(hpGroups) > 0 {
-		requester = &hpgrpc.Requester{
-			RegularLookup: requester,
-			HPGroups:      hpGroups,
-			Dialer:        dialer,
-		}
-	}
-
-	createVerifier := func() infra.Verifier {
-		if globalCfg.SD.DisableSegVerification {
-			return acceptAllVerifier{}
-		}
-		return compat.Verifier{Verifier: trust.Verifier{
-			Engine:             engine,
-			Cache:              globalCfg.TrustEngine.Cache.New(),
-			CacheHits:          metrics.NewPromCounter(trustmetrics.CacheHitsTotal),
-			MaxCacheExpiration: globalCfg.TrustEngine.Cache.Expiration.Duration,
-		}}
+		return err
 	}
+	defer serverCleanup.Do()
 
 	server := grpc.NewServer(
 		libgrpc.UnaryServerInterceptor(),
 		libgrpc.DefaultMaxConcurrentStreams(),
 	)
-	sdpb.RegisterDaemonServiceServer(server, daemon.NewServer(
-		daemon.ServerConfig{
-			IA:       topo.IA(),
-			MTU:      topo.MTU(),
-			Topology: topo,
-			Fetcher: fetcher.NewFetcher(
-				fetcher.FetcherConfig{
-					IA:         topo.IA(),
-					MTU:        topo.MTU(),
-					Core:       topo.Core(),
-					NextHopper: topo,
-					RPC:        requester,
-					PathDB:     pathDB,
-					Inspector:  engine,
-					Verifier:   createVerifier(),
-					RevCache:   revCache,
-					Cfg:        globalCfg.SD,
-				},
-			),
-			Engine:      engine,
-			RevCache:    revCache,
-			DRKeyClient: drkeyClientEngine,
-		},
-	))
+	sdpb.RegisterDaemonServiceServer(server, daemon.NewServer(serverCfg))
+
+	readiness := &service.Health{}
+	readiness.AddCheck("topology", func(context.Context) error {
+		if topo.IA().IsZero() {
+			return serrors.New("topology not loaded")
+		}
+		return nil
+	})
+	readiness.AddCheck("path_db", func(ctx context.Context) error {
+		_, err := pathDB.GetNextQuery(ctx, topo.IA(), topo.IA())
+		return err
+	})
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	g.Go(func() error {
+		defer log.HandlePanic()
+		readiness.UpdateGRPC(errCtx, healthServer, 0)
+		return nil
+	})
 
 	promgrpc.Register(server)
 
@@ -290,7 +213,7 @@ func realMain(ctx context.Context) error {
 		}
 		return nil
 	})
-	cleanup.Add(func() error { server.GracefulStop(); return nil })
+	cleanup.Add(func() error { libgrpc.GracefulStop(shutdownCtx, server); return nil })
 
 	if globalCfg.API.Addr != "" {
 		r := chi.NewRouter()
@@ -334,6 +257,7 @@ func realMain(ctx context.Context) error {
 		"log/level": service.NewLogLevelStatusPage(),
 		"topology":  service.NewTopologyStatusPage(topo),
 	}
+	readiness.AddStatusPages(statusPages)
 	if err := statusPages.Register(http.DefaultServeMux, globalCfg.General.ID); err != nil {
 		return serrors.WrapStr("registering status pages", err)
 	}
@@ -352,22 +276,6 @@ func realMain(ctx context.Context) error {
 	return g.Wait()
 }
 
-type acceptAllVerifier struct{}
-
-func (acceptAllVerifier) Verify(ctx context.Context, signedMsg *cryptopb.SignedMessage,
-	associatedData ...[]byte) (*signed.Message, error) {
-
-	return nil, nil
-}
-
-func (v acceptAllVerifier) WithServer(net.Addr) infra.Verifier {
-	return v
-}
-
-func (v acceptAllVerifier) WithIA(addr.IA) infra.Verifier {
-	return v
-}
-
 func loaderMetrics() topology.LoaderMetrics {
 	updates := prom.NewCounterVec("", "",
 		"topology_updates_total",
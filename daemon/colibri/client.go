@@ -0,0 +1,71 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package colibri forwards the COLIBRI reservation requests of the end hosts
+// to the local control service.
+package colibri
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/colibri"
+	sc_grpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// Client requests reservations from the local control service.
+type Client struct {
+	Dialer sc_grpc.Dialer
+}
+
+var _ colibri.Reserver = (*Client)(nil)
+
+// Reserve requests the reservation from the local control service.
+func (c *Client) Reserve(ctx context.Context, req colibri.Request) (colibri.Response, error) {
+	conn, err := c.Dialer.Dial(ctx, &snet.SVCAddr{SVC: addr.SvcCS})
+	if err != nil {
+		return colibri.Response{}, serrors.WrapStr("dialing", err)
+	}
+	defer conn.Close()
+	client := cppb.NewColibriServiceClient(conn)
+	rep, err := client.Reserve(ctx, &cppb.ColibriReserveRequest{
+		SrcIsdAs:   uint64(req.SrcIA),
+		DstIsdAs:   uint64(req.DstIA),
+		Ingress:    uint64(req.Ingress),
+		Egress:     uint64(req.Egress),
+		Bandwidth:  req.Bandwidth,
+		Expiration: timestamppb.New(req.Expiration),
+	})
+	if err != nil {
+		return colibri.Response{}, serrors.WrapStr("requesting reservation", err)
+	}
+	res := colibri.Response{
+		Accepted:      rep.Accepted,
+		Bandwidth:     rep.Bandwidth,
+		FailureReason: rep.FailureReason,
+	}
+	if rep.Accepted {
+		if rep.Id == nil {
+			return colibri.Response{}, serrors.New("accepted reservation without ID")
+		}
+		res.ID = colibri.ReservationID{ASID: addr.AS(rep.Id.As), Suffix: rep.Id.Suffix}
+		res.Expiration = rep.Expiration.AsTime()
+	}
+	return res, nil
+}
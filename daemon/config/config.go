@@ -20,19 +20,54 @@ import (
 	"io"
 	"time"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/daemon"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/env"
 	api "github.com/scionproto/scion/private/mgmtapi"
 	"github.com/scionproto/scion/private/storage"
+	pathdbcache "github.com/scionproto/scion/private/storage/path/cache"
 	trustengine "github.com/scionproto/scion/private/trust/config"
 )
 
 var (
 	DefaultQueryInterval = 5 * time.Minute
+	// DefaultPrefetchDestinations is the default maximum number of
+	// destinations tracked by the path prefetcher.
+	DefaultPrefetchDestinations = 1000
+	// DefaultPrefetchBudget is the default maximum number of destinations
+	// refreshed per prefetch interval.
+	DefaultPrefetchBudget = 20
+	// DefaultPrefetchLead is the default time before the expiration of the
+	// paths at which they are refreshed.
+	DefaultPrefetchLead = 2 * time.Minute
+	// DefaultPrefetchIdleTimeout is the default time after which a destination
+	// that was not requested anymore is no longer prefetched.
+	DefaultPrefetchIdleTimeout = 30 * time.Minute
+	// DefaultNegativeCacheTTL is the default time for which a segment request
+	// that was answered without segments is not repeated.
+	DefaultNegativeCacheTTL = 5 * time.Second
+	// DefaultHiddenPathGroupSyncInterval is the default interval in which the
+	// hidden path groups of the local AS are fetched from the control service.
+	DefaultHiddenPathGroupSyncInterval = 5 * time.Minute
+	// DefaultHiddenPathCacheTTL is the default time for which the result of a
+	// hidden path request is cached.
+	DefaultHiddenPathCacheTTL = time.Minute
+)
+
+// The default weights of the path ranking.
+const (
+	DefaultRankingHops      = 1.0
+	DefaultRankingExpiry    = 0.1
+	DefaultRankingLoss      = 4.0
+	DefaultRankingRTT       = 1.0
+	DefaultRankingLatency   = 0.5
+	DefaultRankingBandwidth = 0.5
 )
 
 var _ config.Config = (*Config)(nil)
@@ -44,11 +79,13 @@ type Config struct {
 	Metrics       env.Metrics        `toml:"metrics,omitempty"`
 	API           api.Config         `toml:"api,omitempty"`
 	Tracing       env.Tracing        `toml:"tracing,omitempty"`
+	GRPCTLS       env.GRPCTLS        `toml:"grpc_tls,omitempty"`
 	TrustDB       storage.DBConfig   `toml:"trust_db,omitempty"`
 	PathDB        storage.DBConfig   `toml:"path_db,omitempty"`
 	SD            SDConfig           `toml:"sd,omitempty"`
 	TrustEngine   trustengine.Config `toml:"trustengine,omitempty"`
 	DRKeyLevel2DB storage.DBConfig   `toml:"drkey_level2_db,omitempty"`
+	PathRanking   RankingConfig      `toml:"path_ranking,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -63,6 +100,7 @@ func (cfg *Config) InitDefaults() {
 		cfg.PathDB.WithDefault(fmt.Sprintf(storage.DefaultPathDBPath, "sd")),
 		&cfg.SD,
 		&cfg.TrustEngine,
+		&cfg.PathRanking,
 	)
 }
 
@@ -72,12 +110,15 @@ func (cfg *Config) Validate() error {
 		&cfg.Features,
 		&cfg.Logging,
 		&cfg.Metrics,
+		&cfg.Tracing,
 		&cfg.API,
+		&cfg.GRPCTLS,
 		&cfg.TrustDB,
 		&cfg.PathDB,
 		&cfg.SD,
 		&cfg.TrustEngine,
 		&cfg.DRKeyLevel2DB,
+		&cfg.PathRanking,
 	)
 }
 
@@ -89,6 +130,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Metrics,
 		&cfg.API,
 		&cfg.Tracing,
+		&cfg.GRPCTLS,
 		config.OverrideName(
 			config.FormatData(
 				&cfg.TrustDB,
@@ -112,6 +154,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 			),
 			"drkey_level2_db",
 		),
+		&cfg.PathRanking,
 	)
 }
 
@@ -127,10 +170,81 @@ type SDConfig struct {
 	// QueryInterval specifies after how much time segments
 	// for a destination should be refetched.
 	QueryInterval util.DurWrap `toml:"query_interval,omitempty"`
-	// HiddenPathGroup is a file that contains the hiddenpath groups.
+	// HiddenPathGroup is a file that contains the hiddenpath groups. It can
+	// also be a directory or a glob pattern matching multiple files.
 	// If HiddenPathGroups begins with http:// or https://, it will be fetched
 	// over the network from the specified URL instead.
 	HiddenPathGroups string `toml:"hidden_path_groups,omitempty"`
+	// DisableHiddenPathGroupSync disables fetching the hidden path groups in
+	// which the local AS is a reader from the control service. Only the groups
+	// in HiddenPathGroups are then used by default.
+	DisableHiddenPathGroupSync bool `toml:"disable_hidden_path_group_sync,omitempty"`
+	// HiddenPathGroupSyncInterval is the interval in which the hidden path
+	// groups are fetched from the control service.
+	HiddenPathGroupSyncInterval util.DurWrap `toml:"hidden_path_group_sync_interval,omitempty"`
+	// DisableHiddenPathCache disables the caching of the results of hidden
+	// path requests.
+	DisableHiddenPathCache bool `toml:"disable_hidden_path_cache,omitempty"`
+	// HiddenPathCacheTTL is the time for which the result of a hidden path
+	// request is cached.
+	HiddenPathCacheTTL util.DurWrap `toml:"hidden_path_cache_ttl,omitempty"`
+	// PathPolicies is a JSON file that contains named path policies. Clients
+	// can request paths that are filtered by one of these policies by name.
+	PathPolicies string `toml:"path_policies,omitempty"`
+	// Geofence is the set of ISDs and ASes that the paths returned to the
+	// applications must not traverse. An entry with AS number 0 (e.g., 1-0)
+	// forbids the whole ISD. The geofence cannot be overridden by the
+	// applications.
+	Geofence []addr.IA `toml:"geofence,omitempty"`
+	// DisablePrefetch disables the proactive refreshing of the paths of
+	// recently requested destinations.
+	DisablePrefetch bool `toml:"disable_prefetch,omitempty"`
+	// PrefetchDestinations is the maximum number of destinations that are
+	// tracked for prefetching.
+	PrefetchDestinations int `toml:"prefetch_destinations,omitempty"`
+	// PrefetchBudget is the maximum number of destinations that are refreshed
+	// in one prefetch interval.
+	PrefetchBudget int `toml:"prefetch_budget,omitempty"`
+	// PrefetchLead is the time before the expiration of the last path of a
+	// destination at which the paths are refreshed.
+	PrefetchLead util.DurWrap `toml:"prefetch_lead,omitempty"`
+	// PrefetchIdleTimeout is the time after which a destination that was not
+	// requested anymore is no longer prefetched.
+	PrefetchIdleTimeout util.DurWrap `toml:"prefetch_idle_timeout,omitempty"`
+	// DisableNegativeCache disables the caching of segment requests that were
+	// answered without segments.
+	DisableNegativeCache bool `toml:"disable_negative_cache,omitempty"`
+	// NegativeCacheTTL is the time for which a segment request that was
+	// answered without segments is not repeated.
+	NegativeCacheTTL util.DurWrap `toml:"negative_cache_ttl,omitempty"`
+	// DisablePathDBCache disables the in-memory cache of the path DB lookups.
+	DisablePathDBCache bool `toml:"disable_path_db_cache,omitempty"`
+	// PathDBCacheSize is the maximum number of path DB lookups that are
+	// cached.
+	PathDBCacheSize int `toml:"path_db_cache_size,omitempty"`
+	// PathDBCacheTTL is the time for which a path DB lookup is cached.
+	PathDBCacheTTL util.DurWrap `toml:"path_db_cache_ttl,omitempty"`
+	// DisableCSFailover disables the failover between the control service
+	// instances of the local AS. If disabled, the requests are spread across
+	// all instances in round robin fashion.
+	DisableCSFailover bool `toml:"disable_cs_failover,omitempty"`
+	// CSFailureMemory is the time for which a control service instance that
+	// failed is tried only after all other instances.
+	CSFailureMemory util.DurWrap `toml:"cs_failure_memory,omitempty"`
+	// SegmentPageSize is the number of segments that are requested per page
+	// from the control service. If zero, the control service decides.
+	SegmentPageSize int `toml:"segment_page_size,omitempty"`
+	// SegmentLookupCompression enables the gzip compression of the segment
+	// requests to the control service.
+	SegmentLookupCompression bool `toml:"segment_lookup_compression,omitempty"`
+	// SegmentMinLifetime is the minimum remaining lifetime of the segments
+	// that are returned by the control service. If zero, the remaining
+	// lifetime is not restricted.
+	SegmentMinLifetime util.DurWrap `toml:"segment_min_lifetime,omitempty"`
+	// SegmentMaxHops is the maximum number of AS hops of the segments that
+	// are returned by the control service. If zero, the number of hops is not
+	// restricted.
+	SegmentMaxHops int `toml:"segment_max_hops,omitempty"`
 }
 
 func (cfg *SDConfig) InitDefaults() {
@@ -140,15 +254,95 @@ func (cfg *SDConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	if cfg.HiddenPathGroupSyncInterval.Duration == 0 {
+		cfg.HiddenPathGroupSyncInterval.Duration = DefaultHiddenPathGroupSyncInterval
+	}
+	if cfg.HiddenPathCacheTTL.Duration == 0 {
+		cfg.HiddenPathCacheTTL.Duration = DefaultHiddenPathCacheTTL
+	}
+	if cfg.PrefetchDestinations == 0 {
+		cfg.PrefetchDestinations = DefaultPrefetchDestinations
+	}
+	if cfg.PrefetchBudget == 0 {
+		cfg.PrefetchBudget = DefaultPrefetchBudget
+	}
+	if cfg.PrefetchLead.Duration == 0 {
+		cfg.PrefetchLead.Duration = DefaultPrefetchLead
+	}
+	if cfg.PrefetchIdleTimeout.Duration == 0 {
+		cfg.PrefetchIdleTimeout.Duration = DefaultPrefetchIdleTimeout
+	}
+	if cfg.NegativeCacheTTL.Duration == 0 {
+		cfg.NegativeCacheTTL.Duration = DefaultNegativeCacheTTL
+	}
+	if cfg.PathDBCacheSize == 0 {
+		cfg.PathDBCacheSize = pathdbcache.DefaultSize
+	}
+	if cfg.PathDBCacheTTL.Duration == 0 {
+		cfg.PathDBCacheTTL.Duration = pathdbcache.DefaultTTL
+	}
+	if cfg.CSFailureMemory.Duration == 0 {
+		cfg.CSFailureMemory.Duration = libgrpc.DefaultFailureMemory
+	}
 }
 
 func (cfg *SDConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("QueryInterval must not be zero")
 	}
+	if cfg.HiddenPathGroupSyncInterval.Duration < 0 {
+		return serrors.New("HiddenPathGroupSyncInterval must not be negative")
+	}
+	if cfg.HiddenPathCacheTTL.Duration < 0 {
+		return serrors.New("HiddenPathCacheTTL must not be negative")
+	}
+	if cfg.PrefetchDestinations < 0 {
+		return serrors.New("PrefetchDestinations must not be negative")
+	}
+	if cfg.PrefetchBudget < 0 {
+		return serrors.New("PrefetchBudget must not be negative")
+	}
+	if cfg.NegativeCacheTTL.Duration < 0 {
+		return serrors.New("NegativeCacheTTL must not be negative")
+	}
+	if cfg.PathDBCacheSize < 0 {
+		return serrors.New("PathDBCacheSize must not be negative")
+	}
+	if cfg.PathDBCacheTTL.Duration < 0 {
+		return serrors.New("PathDBCacheTTL must not be negative")
+	}
+	if cfg.CSFailureMemory.Duration < 0 {
+		return serrors.New("CSFailureMemory must not be negative")
+	}
+	if cfg.SegmentPageSize < 0 {
+		return serrors.New("SegmentPageSize must not be negative")
+	}
+	if cfg.SegmentMinLifetime.Duration < 0 {
+		return serrors.New("SegmentMinLifetime must not be negative")
+	}
+	if cfg.SegmentMaxHops < 0 {
+		return serrors.New("SegmentMaxHops must not be negative")
+	}
+	for _, ia := range cfg.Geofence {
+		if ia.ISD() == 0 {
+			return serrors.New("Geofence must not contain wildcard ISDs", "entry", ia)
+		}
+	}
 	return nil
 }
 
+// SegmentsFilter returns the filter that is sent with the segment requests to
+// the control service. If no filter is configured, nil is returned.
+func (cfg *SDConfig) SegmentsFilter() *cppb.SegmentsFilter {
+	if cfg.SegmentMinLifetime.Duration <= 0 && cfg.SegmentMaxHops <= 0 {
+		return nil
+	}
+	return &cppb.SegmentsFilter{
+		MinLifetime: uint32(cfg.SegmentMinLifetime.Duration / time.Second),
+		MaxHops:     uint32(cfg.SegmentMaxHops),
+	}
+}
+
 func (cfg *SDConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, sdSample)
 }
@@ -156,3 +350,66 @@ func (cfg *SDConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap)
 func (cfg *SDConfig) ConfigName() string {
 	return "sd"
 }
+
+var _ config.Config = (*RankingConfig)(nil)
+
+// RankingConfig configures the ranking of the paths that are returned by the
+// daemon. Every path gets a score that is the weighted sum of its normalized
+// properties, and the paths are returned in the order of increasing score. If
+// no weight is set, the default weights are used.
+type RankingConfig struct {
+	// Disable disables the ranking. The paths are then returned in the order
+	// in which they are combined.
+	Disable bool `toml:"disable,omitempty"`
+	// Hops is the weight of the number of hops of a path.
+	Hops float64 `toml:"hops,omitempty"`
+	// Expiry is the weight of the remaining lifetime of a path.
+	Expiry float64 `toml:"expiry,omitempty"`
+	// Loss is the weight of the loss rate reported by the applications.
+	Loss float64 `toml:"loss,omitempty"`
+	// RTT is the weight of the round trip time reported by the applications.
+	RTT float64 `toml:"rtt,omitempty"`
+	// Latency is the weight of the latency in the static path metadata.
+	Latency float64 `toml:"latency,omitempty"`
+	// Bandwidth is the weight of the bandwidth in the static path metadata.
+	Bandwidth float64 `toml:"bandwidth,omitempty"`
+}
+
+func (cfg *RankingConfig) InitDefaults() {
+	if cfg.Hops == 0 && cfg.Expiry == 0 && cfg.Loss == 0 && cfg.RTT == 0 &&
+		cfg.Latency == 0 && cfg.Bandwidth == 0 {
+
+		cfg.Hops = DefaultRankingHops
+		cfg.Expiry = DefaultRankingExpiry
+		cfg.Loss = DefaultRankingLoss
+		cfg.RTT = DefaultRankingRTT
+		cfg.Latency = DefaultRankingLatency
+		cfg.Bandwidth = DefaultRankingBandwidth
+	}
+}
+
+func (cfg *RankingConfig) Validate() error {
+	weights := map[string]float64{
+		"hops":      cfg.Hops,
+		"expiry":    cfg.Expiry,
+		"loss":      cfg.Loss,
+		"rtt":       cfg.RTT,
+		"latency":   cfg.Latency,
+		"bandwidth": cfg.Bandwidth,
+	}
+	for name, weight := range weights {
+		if weight < 0 {
+			return serrors.New("ranking weight must not be negative",
+				"weight", name, "value", weight)
+		}
+	}
+	return nil
+}
+
+func (cfg *RankingConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, rankingSample)
+}
+
+func (cfg *RankingConfig) ConfigName() string {
+	return "path_ranking"
+}
@@ -17,14 +17,20 @@ package config
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/pelletier/go-toml"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/daemon"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log/logtest"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/private/config/configtest"
 	"github.com/scionproto/scion/private/env/envtest"
 	apitest "github.com/scionproto/scion/private/mgmtapi/mgmtapitest"
+	pathdbcache "github.com/scionproto/scion/private/storage/path/cache"
 	storagetest "github.com/scionproto/scion/private/storage/test"
 )
 
@@ -37,31 +43,123 @@ func TestConfigSample(t *testing.T) {
 	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
 	assert.NoError(t, err)
 	CheckTestConfig(t, &cfg, idSample)
+	configtest.CheckSampleCoverage(t, &cfg)
 }
 
 func InitTestConfig(cfg *Config) {
 	envtest.InitTest(&cfg.General, &cfg.Metrics, &cfg.Tracing, nil)
+	envtest.InitTestGRPCTLS(&cfg.GRPCTLS)
 	logtest.InitTestLogging(&cfg.Logging)
 	apitest.InitConfig(&cfg.API)
 	InitTestSDConfig(&cfg.SD)
+	InitTestRankingConfig(&cfg.PathRanking)
 }
 
 func InitTestSDConfig(cfg *SDConfig) {
 	cfg.Address = "garbage"
 	cfg.DisableSegVerification = true
+	cfg.DisableHiddenPathGroupSync = true
+	cfg.DisableHiddenPathCache = true
+	cfg.PathPolicies = "garbage"
+	cfg.Geofence = []addr.IA{addr.MustIAFrom(1, 0)}
+	cfg.DisablePrefetch = true
+	cfg.DisableNegativeCache = true
+	cfg.DisablePathDBCache = true
+	cfg.DisableCSFailover = true
+	cfg.SegmentPageSize = 42
+	cfg.SegmentLookupCompression = true
+	cfg.SegmentMinLifetime.Duration = time.Hour
+	cfg.SegmentMaxHops = 7
 }
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, &cfg.Tracing, nil, id)
+	envtest.CheckTestGRPCTLS(t, &cfg.GRPCTLS)
 	logtest.CheckTestLogging(t, &cfg.Logging, id)
 	storagetest.CheckTestTrustDBConfig(t, &cfg.TrustDB, id)
 	storagetest.CheckTestPathDBConfig(t, &cfg.PathDB, id)
 	apitest.CheckConfig(t, &cfg.API)
 	CheckTestSDConfig(t, &cfg.SD, id)
+	CheckTestRankingConfig(t, &cfg.PathRanking)
 }
 
 func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	assert.Equal(t, daemon.DefaultAPIAddress, cfg.Address)
 	assert.False(t, cfg.DisableSegVerification)
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.False(t, cfg.DisableHiddenPathGroupSync)
+	assert.Equal(t, DefaultHiddenPathGroupSyncInterval,
+		cfg.HiddenPathGroupSyncInterval.Duration)
+	assert.False(t, cfg.DisableHiddenPathCache)
+	assert.Equal(t, DefaultHiddenPathCacheTTL, cfg.HiddenPathCacheTTL.Duration)
+	assert.Empty(t, cfg.PathPolicies)
+	assert.Empty(t, cfg.Geofence)
+	assert.False(t, cfg.DisablePrefetch)
+	assert.Equal(t, DefaultPrefetchDestinations, cfg.PrefetchDestinations)
+	assert.Equal(t, DefaultPrefetchBudget, cfg.PrefetchBudget)
+	assert.Equal(t, DefaultPrefetchLead, cfg.PrefetchLead.Duration)
+	assert.Equal(t, DefaultPrefetchIdleTimeout, cfg.PrefetchIdleTimeout.Duration)
+	assert.False(t, cfg.DisableNegativeCache)
+	assert.Equal(t, DefaultNegativeCacheTTL, cfg.NegativeCacheTTL.Duration)
+	assert.False(t, cfg.DisablePathDBCache)
+	assert.Equal(t, pathdbcache.DefaultSize, cfg.PathDBCacheSize)
+	assert.Equal(t, pathdbcache.DefaultTTL, cfg.PathDBCacheTTL.Duration)
+	assert.False(t, cfg.DisableCSFailover)
+	assert.Equal(t, libgrpc.DefaultFailureMemory, cfg.CSFailureMemory.Duration)
+	assert.Zero(t, cfg.SegmentPageSize)
+	assert.False(t, cfg.SegmentLookupCompression)
+	assert.Zero(t, cfg.SegmentMinLifetime.Duration)
+	assert.Zero(t, cfg.SegmentMaxHops)
+	assert.Nil(t, cfg.SegmentsFilter())
+}
+
+func TestSDConfigSegmentsFilter(t *testing.T) {
+	var cfg SDConfig
+	cfg.InitDefaults()
+	cfg.SegmentMinLifetime.Duration = 90 * time.Second
+	cfg.SegmentMaxHops = 5
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, &cppb.SegmentsFilter{MinLifetime: 90, MaxHops: 5}, cfg.SegmentsFilter())
+
+	cfg.SegmentMaxHops = -1
+	assert.Error(t, cfg.Validate())
+}
+
+func TestSDConfigGeofence(t *testing.T) {
+	var cfg SDConfig
+	cfg.InitDefaults()
+	cfg.Geofence = []addr.IA{addr.MustIAFrom(1, 0), addr.MustIAFrom(2, 0xff0000000210)}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Geofence = append(cfg.Geofence, addr.MustIAFrom(0, 0))
+	assert.Error(t, cfg.Validate())
+}
+
+func InitTestRankingConfig(cfg *RankingConfig) {
+	cfg.Disable = true
+	cfg.Hops = 42
+}
+
+func CheckTestRankingConfig(t *testing.T, cfg *RankingConfig) {
+	assert.False(t, cfg.Disable)
+	assert.Equal(t, DefaultRankingHops, cfg.Hops)
+	assert.Equal(t, DefaultRankingExpiry, cfg.Expiry)
+	assert.Equal(t, DefaultRankingLoss, cfg.Loss)
+	assert.Equal(t, DefaultRankingRTT, cfg.RTT)
+	assert.Equal(t, DefaultRankingLatency, cfg.Latency)
+	assert.Equal(t, DefaultRankingBandwidth, cfg.Bandwidth)
+}
+
+func TestRankingConfig(t *testing.T) {
+	var cfg RankingConfig
+	cfg.InitDefaults()
+	assert.Equal(t, DefaultRankingHops, cfg.Hops)
+	assert.NoError(t, cfg.Validate())
+
+	cfg = RankingConfig{Loss: 1}
+	cfg.InitDefaults()
+	assert.Equal(t, RankingConfig{Loss: 1}, cfg)
+
+	cfg.RTT = -1
+	assert.Error(t, cfg.Validate())
 }
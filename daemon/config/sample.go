@@ -27,6 +27,131 @@ disable_seg_verification = false
 # The time after which segments for a destination are refetched. (default 5m)
 query_interval = "5m"
 
-# The configuration containing hidden path groups. (default "")
+# The configuration containing hidden path groups. Can be a file, a directory
+# or a glob pattern. (default "")
 hidden_path_groups =  ""
+
+# Disable fetching the hidden path groups in which the local AS is a reader
+# from the control service. If disabled, hidden path requests without explicit
+# groups only query the groups in hidden_path_groups. (default false)
+disable_hidden_path_group_sync = false
+
+# The interval in which the hidden path groups are fetched from the control
+# service. (default 5m)
+hidden_path_group_sync_interval = "5m"
+
+# Disable the caching of the results of hidden path requests. (default false)
+disable_hidden_path_cache = false
+
+# The time for which the result of a hidden path request is cached. Requests
+# that set refresh bypass the cache. (default 1m)
+hidden_path_cache_ttl = "1m"
+
+# The JSON file containing named path policies. Applications can request paths
+# that are filtered by one of these policies by its name. (default "")
+path_policies = ""
+
+# The ISDs and ASes that the returned paths must not traverse. Paths through
+# any of them are never returned to applications, regardless of the request.
+# An entry with AS number 0 (e.g., "1-0") forbids the whole ISD. (default [])
+geofence = []
+
+# Disable the proactive refreshing of the paths of recently requested
+# destinations. (default false)
+disable_prefetch = false
+
+# The maximum number of recently requested destinations whose paths are
+# refreshed before they expire. (default 1000)
+prefetch_destinations = 1000
+
+# The maximum number of destinations refreshed per prefetch run. The prefetcher
+# runs every 10 seconds. (default 20)
+prefetch_budget = 20
+
+# The time before the expiration of the last path of a destination at which
+# the paths are refreshed. (default 2m)
+prefetch_lead = "2m"
+
+# The time after which a destination that was not requested anymore is no
+# longer refreshed. (default 30m)
+prefetch_idle_timeout = "30m"
+
+# Disable the caching of segment requests that were answered without any
+# segments. (default false)
+disable_negative_cache = false
+
+# The time for which a segment request that was answered without any segments
+# is not sent again. The cache is cleared whenever new segments are received.
+# (default 5s)
+negative_cache_ttl = "5s"
+
+# Disable the in-memory cache of the path DB lookups. The cache is cleared
+# whenever segments are inserted or removed. (default false)
+disable_path_db_cache = false
+
+# The maximum number of cached path DB lookups. Each destination requires a
+# few lookups. (default 10000)
+path_db_cache_size = 10000
+
+# The time for which a path DB lookup is cached. (default 1m)
+path_db_cache_ttl = "1m"
+
+# Disable the failover between the control service instances of the local AS.
+# With failover, the instances are tried one after the other, and instances
+# that recently failed are tried last. Without failover, the requests are
+# spread across all instances in round robin fashion. (default false)
+disable_cs_failover = false
+
+# The time for which a control service instance that failed is tried only
+# after all other instances. (default 30s)
+cs_failure_memory = "30s"
+
+# The number of segments that are requested per page from the control service.
+# If zero, the control service decides. (default 0)
+segment_page_size = 0
+
+# Whether the segment requests to the control service and their responses are
+# compressed with gzip. (default false)
+segment_lookup_compression = false
+
+# The minimum remaining lifetime of the segments that are returned by the
+# control service. If zero, the remaining lifetime is not restricted.
+# (default 0s)
+segment_min_lifetime = "0s"
+
+# The maximum number of AS hops of the segments that are returned by the
+# control service. If zero, the number of hops is not restricted. (default 0)
+segment_max_hops = 0
+`
+
+const rankingSample = `
+# Disable the ranking of the returned paths. The paths are then returned in the
+# order in which they are combined. (default false)
+disable = false
+
+# The weights of the path properties in the score of a path. The paths are
+# returned in the order of increasing score. Each property is normalized to the
+# range [0, 1] over the returned paths. If no weight is set, all weights are
+# set to their defaults.
+
+# The weight of the number of hops. (default 1.0)
+hops = 1.0
+
+# The weight of the remaining lifetime; longer lived paths are preferred.
+# (default 0.1)
+expiry = 0.1
+
+# The weight of the loss rate reported by the applications. (default 4.0)
+loss = 4.0
+
+# The weight of the round trip time reported by the applications.
+# (default 1.0)
+rtt = 1.0
+
+# The weight of the latency in the static path metadata. (default 0.5)
+latency = 0.5
+
+# The weight of the bandwidth in the static path metadata; paths with more
+# bandwidth are preferred. (default 0.5)
+bandwidth = 0.5
 `
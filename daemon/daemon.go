@@ -17,41 +17,35 @@ package daemon
 import (
 	"context"
 	"errors"
-	"io"
 	"net"
 	"path/filepath"
 	"strconv"
+	"time"
 
-	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/scionproto/scion/daemon/drkey"
 	"github.com/scionproto/scion/daemon/fetcher"
 	"github.com/scionproto/scion/daemon/internal/servers"
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/colibri"
 	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
 	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
-	"github.com/scionproto/scion/private/env"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
+	"github.com/scionproto/scion/pkg/scrypto/signed"
+	"github.com/scionproto/scion/private/path/pathpol"
 	"github.com/scionproto/scion/private/revcache"
+	infra "github.com/scionproto/scion/private/segment/verifier"
 	"github.com/scionproto/scion/private/trust"
 	trustgrpc "github.com/scionproto/scion/private/trust/grpc"
 	trustmetrics "github.com/scionproto/scion/private/trust/metrics"
 )
 
-// InitTracer initializes the global tracer.
-func InitTracer(tracing env.Tracing, id string) (io.Closer, error) {
-	tracer, trCloser, err := tracing.NewTracer(id)
-	if err != nil {
-		return nil, err
-	}
-	opentracing.SetGlobalTracer(tracer)
-	return trCloser, nil
-}
-
 // TrustEngine builds the trust engine backed by the trust database.
 func TrustEngine(
 	cfgDir string,
@@ -98,13 +92,32 @@ func TrustEngine(
 				Dialer:   dialer,
 				Requests: metrics.NewPromCounter(trustmetrics.RPC.Fetches),
 			},
-			Recurser: trust.LocalOnlyRecurser{},
-			Router:   trust.LocalRouter{IA: ia},
+			Recurser:     trust.LocalOnlyRecurser{},
+			Router:       trust.LocalRouter{IA: ia},
+			TRCDiscovery: &trust.TRCDiscoveryLimiter{},
 		},
 		DB: db,
 	}, nil
 }
 
+// AcceptAllVerifier is a segment verifier that accepts all segments. It is
+// used if the segment verification is disabled.
+type AcceptAllVerifier struct{}
+
+func (AcceptAllVerifier) Verify(ctx context.Context, signedMsg *cryptopb.SignedMessage,
+	associatedData ...[]byte) (*signed.Message, error) {
+
+	return nil, nil
+}
+
+func (v AcceptAllVerifier) WithServer(net.Addr) infra.Verifier {
+	return v
+}
+
+func (v AcceptAllVerifier) WithIA(addr.IA) infra.Verifier {
+	return v
+}
+
 // ServerConfig is the configuration for the daemon API server.
 type ServerConfig struct {
 	IA          addr.IA
@@ -114,99 +127,205 @@ type ServerConfig struct {
 	Engine      trust.Engine
 	Topology    servers.Topology
 	DRKeyClient *drkey.ClientEngine
+	// Colibri, if set, forwards COLIBRI reservation requests to the control
+	// service.
+	Colibri colibri.Reserver
+	// WatchInterval is the interval in which the path sets of watched
+	// destinations are re-evaluated.
+	WatchInterval time.Duration
+	// Prefetcher, if set, is notified about the requested destinations.
+	Prefetcher *servers.Prefetcher
+	// PathPolicies are the named path policies that clients can request.
+	PathPolicies map[string]*pathpol.Policy
+	// Geofence are the ISDs and ASes that the returned paths must not
+	// traverse.
+	Geofence []addr.IA
+	// Ranking, if set, are the weights with which the returned paths are
+	// ranked.
+	Ranking *RankingWeights
+	// HPGroups are the locally configured hidden path groups.
+	HPGroups hiddenpath.Groups
+	// LearnedHPGroups, if set, are the hidden path groups learned from the
+	// control service.
+	LearnedHPGroups *hiddenpath.LearnedGroups
+	// HiddenPathCacheTTL is the time for which the result of a hidden path
+	// request is cached. If zero, the results are not cached.
+	HiddenPathCacheTTL time.Duration
+	// DisableMetrics disables the request metrics of the server. The metrics
+	// are registered with the default prometheus registry, which is only
+	// possible once per process.
+	DisableMetrics bool
 }
 
+// RankingWeights are the weights of the path properties with which the
+// returned paths are ranked.
+type RankingWeights = servers.RankingWeights
+
 // NewServer constructs a daemon API server.
 func NewServer(cfg ServerConfig) *servers.DaemonServer {
-	return &servers.DaemonServer{
-		IA:          cfg.IA,
-		MTU:         cfg.MTU,
-		Topology:    cfg.Topology,
-		Fetcher:     cfg.Fetcher,
-		ASInspector: cfg.Engine.Inspector,
-		RevCache:    cfg.RevCache,
-		DRKeyClient: cfg.DRKeyClient,
-		Metrics: servers.Metrics{
-			PathsRequests: servers.RequestMetrics{
-				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
-					Namespace: "sd",
-					Subsystem: "path",
-					Name:      "requests_total",
-					Help:      "The amount of path requests received.",
-				}, servers.PathsRequestsLabels),
-				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
-					Namespace: "sd",
-					Subsystem: "path",
-					Name:      "request_duration_seconds",
-					Help:      "Time to handle path requests.",
-					Buckets:   prom.DefaultLatencyBuckets,
-				}, servers.LatencyLabels),
-			},
-			ASRequests: servers.RequestMetrics{
-				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
-					Namespace: "sd",
-					Subsystem: "as_info",
-					Name:      "requests_total",
-					Help:      "The amount of AS requests received.",
-				}, servers.ASRequestsLabels),
-				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
-					Namespace: "sd",
-					Subsystem: "as_info",
-					Name:      "request_duration_seconds",
-					Help:      "Time to handle AS requests.",
-					Buckets:   prom.DefaultLatencyBuckets,
-				}, servers.LatencyLabels),
-			},
-			InterfacesRequests: servers.RequestMetrics{
-				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
-					Namespace: "sd",
-					Subsystem: "if_info",
-					Name:      "requests_total",
-					Help:      "The amount of interfaces requests received.",
-				}, servers.InterfacesRequestsLabels),
-				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
-					Namespace: "sd",
-					Subsystem: "if_info",
-					Name:      "request_duration_seconds",
-					Help:      "Time to handle interfaces requests.",
-					Buckets:   prom.DefaultLatencyBuckets,
-				}, servers.LatencyLabels),
-			},
-			ServicesRequests: servers.RequestMetrics{
-				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
-					Namespace: "sd",
-					Subsystem: "service_info",
-					Name:      "requests_total",
-					Help:      "The amount of services requests received.",
-				}, servers.ServicesRequestsLabels),
-				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
-					Namespace: "sd",
-					Subsystem: "service_info",
-					Name:      "request_duration_seconds",
-					Help:      "Time to handle services requests.",
-					Buckets:   prom.DefaultLatencyBuckets,
-				}, servers.LatencyLabels),
-			},
-			InterfaceDownNotifications: servers.RequestMetrics{
-				Requests: metrics.NewPromCounter(prom.SafeRegister(
-					prometheus.NewCounterVec(prometheus.CounterOpts{
-						Namespace: "sd",
-						Name:      "received_revocations_total",
-						Help:      "The amount of revocations received.",
-					}, servers.InterfaceDownNotificationsLabels)).(*prometheus.CounterVec),
-				),
-				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+	pathHealth := &servers.PathHealth{}
+	var ranker *servers.Ranker
+	if cfg.Ranking != nil {
+		ranker = &servers.Ranker{Weights: *cfg.Ranking, Health: pathHealth}
+	}
+	var hiddenPathCache *servers.HiddenPathCache
+	if cfg.HiddenPathCacheTTL > 0 {
+		hiddenPathCache = &servers.HiddenPathCache{TTL: cfg.HiddenPathCacheTTL}
+	}
+	s := &servers.DaemonServer{
+		IA:              cfg.IA,
+		MTU:             cfg.MTU,
+		Topology:        cfg.Topology,
+		Fetcher:         cfg.Fetcher,
+		ASInspector:     cfg.Engine.Inspector,
+		RevCache:        cfg.RevCache,
+		DRKeyClient:     cfg.DRKeyClient,
+		Colibri:         cfg.Colibri,
+		WatchInterval:   cfg.WatchInterval,
+		Prefetcher:      cfg.Prefetcher,
+		PathPolicies:    cfg.PathPolicies,
+		Geofence:        cfg.Geofence,
+		PathHealth:      pathHealth,
+		Ranker:          ranker,
+		HPGroups:        cfg.HPGroups,
+		LearnedHPGroups: cfg.LearnedHPGroups,
+		HiddenPathCache: hiddenPathCache,
+	}
+	if !cfg.DisableMetrics {
+		s.Metrics = serverMetrics()
+	}
+	return s
+}
+
+func serverMetrics() servers.Metrics {
+	return servers.Metrics{
+		PathsRequests: servers.RequestMetrics{
+			Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+				Namespace: "sd",
+				Subsystem: "path",
+				Name:      "requests_total",
+				Help:      "The amount of path requests received.",
+			}, servers.PathsRequestsLabels),
+			Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "sd",
+				Subsystem: "path",
+				Name:      "request_duration_seconds",
+				Help:      "Time to handle path requests.",
+				Buckets:   prom.DefaultLatencyBuckets,
+			}, servers.LatencyLabels),
+		},
+		ASRequests: servers.RequestMetrics{
+			Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+				Namespace: "sd",
+				Subsystem: "as_info",
+				Name:      "requests_total",
+				Help:      "The amount of AS requests received.",
+			}, servers.ASRequestsLabels),
+			Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "sd",
+				Subsystem: "as_info",
+				Name:      "request_duration_seconds",
+				Help:      "Time to handle AS requests.",
+				Buckets:   prom.DefaultLatencyBuckets,
+			}, servers.LatencyLabels),
+		},
+		InterfacesRequests: servers.RequestMetrics{
+			Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+				Namespace: "sd",
+				Subsystem: "if_info",
+				Name:      "requests_total",
+				Help:      "The amount of interfaces requests received.",
+			}, servers.InterfacesRequestsLabels),
+			Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "sd",
+				Subsystem: "if_info",
+				Name:      "request_duration_seconds",
+				Help:      "Time to handle interfaces requests.",
+				Buckets:   prom.DefaultLatencyBuckets,
+			}, servers.LatencyLabels),
+		},
+		ServicesRequests: servers.RequestMetrics{
+			Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+				Namespace: "sd",
+				Subsystem: "service_info",
+				Name:      "requests_total",
+				Help:      "The amount of services requests received.",
+			}, servers.ServicesRequestsLabels),
+			Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "sd",
+				Subsystem: "service_info",
+				Name:      "request_duration_seconds",
+				Help:      "Time to handle services requests.",
+				Buckets:   prom.DefaultLatencyBuckets,
+			}, servers.LatencyLabels),
+		},
+		InterfaceDownNotifications: servers.RequestMetrics{
+			Requests: metrics.NewPromCounter(prom.SafeRegister(
+				prometheus.NewCounterVec(prometheus.CounterOpts{
 					Namespace: "sd",
-					Subsystem: "revocation",
-					Name:      "notification_duration_seconds",
-					Help:      "Time to handle interface down notifications.",
-					Buckets:   prom.DefaultLatencyBuckets,
-				}, servers.LatencyLabels),
-			},
+					Name:      "received_revocations_total",
+					Help:      "The amount of revocations received.",
+				}, servers.InterfaceDownNotificationsLabels)).(*prometheus.CounterVec),
+			),
+			Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "sd",
+				Subsystem: "revocation",
+				Name:      "notification_duration_seconds",
+				Help:      "Time to handle interface down notifications.",
+				Buckets:   prom.DefaultLatencyBuckets,
+			}, servers.LatencyLabels),
 		},
 	}
 }
 
+// PrefetcherConfig is the configuration for the path prefetcher.
+type PrefetcherConfig struct {
+	Fetcher         fetcher.Fetcher
+	MaxDestinations int
+	Budget          int
+	Lead            time.Duration
+	IdleTimeout     time.Duration
+	// DisableMetrics disables the metrics of the prefetcher. The metrics are
+	// registered with the default prometheus registry, which is only possible
+	// once per process.
+	DisableMetrics bool
+}
+
+// NewPrefetcher constructs a path prefetcher. The prefetcher must be started
+// as a periodic task.
+func NewPrefetcher(cfg PrefetcherConfig) *servers.Prefetcher {
+	p := &servers.Prefetcher{
+		Fetcher:         cfg.Fetcher,
+		MaxDestinations: cfg.MaxDestinations,
+		Budget:          cfg.Budget,
+		Lead:            cfg.Lead,
+		IdleTimeout:     cfg.IdleTimeout,
+	}
+	if !cfg.DisableMetrics {
+		p.Metrics = prefetchMetrics()
+	}
+	return p
+}
+
+func prefetchMetrics() servers.PrefetchMetrics {
+	return servers.PrefetchMetrics{
+		Refreshes: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+			Namespace: "sd",
+			Subsystem: "prefetch",
+			Name:      "refreshes_total",
+			Help:      "The amount of destinations refreshed by the path prefetcher.",
+		}, servers.PrefetchRefreshesLabels),
+		Destinations: metrics.NewPromGauge(prom.SafeRegister(
+			prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: "sd",
+				Subsystem: "prefetch",
+				Name:      "destinations",
+				Help:      "The number of destinations tracked by the path prefetcher.",
+			}, []string{})).(*prometheus.GaugeVec),
+		),
+	}
+}
+
 // APIAddress returns the API address to listen on, based on the provided
 // address. Addresses with missing or zero port are returned with the default
 // daemon port. All other addresses are returned without modification. If the
@@ -68,6 +68,10 @@ type FetcherConfig struct {
 }
 
 func NewFetcher(cfg FetcherConfig) Fetcher {
+	var negativeCache *segfetcher.NegativeCache
+	if !cfg.Cfg.DisableNegativeCache {
+		negativeCache = &segfetcher.NegativeCache{TTL: cfg.Cfg.NegativeCacheTTL.Duration}
+	}
 	return &fetcher{
 		pather: segfetcher.Pather{
 			IA:         cfg.IA,
@@ -93,7 +97,8 @@ func NewFetcher(cfg FetcherConfig) Fetcher {
 					RPC:         cfg.RPC,
 					DstProvider: &dstProvider{},
 				},
-				Metrics: segfetcher.NewFetcherMetrics("sd"),
+				NegativeCache: negativeCache,
+				Metrics:       segfetcher.NewFetcherMetrics("sd"),
 			},
 			Splitter: &segfetcher.MultiSegmentSplitter{
 				LocalIA:   cfg.IA,
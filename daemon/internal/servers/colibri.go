@@ -0,0 +1,62 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/colibri"
+	"github.com/scionproto/scion/pkg/private/common"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+)
+
+// ColibriReserve forwards the reservation request to the local control
+// service.
+func (s *DaemonServer) ColibriReserve(ctx context.Context,
+	req *sdpb.ColibriReserveRequest) (*sdpb.ColibriReserveResponse, error) {
+
+	if s.Colibri == nil {
+		return nil, status.Error(codes.Unavailable, "COLIBRI is not available")
+	}
+	if err := req.Expiration.CheckValid(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid expiration: %s", err)
+	}
+	rep, err := s.Colibri.Reserve(ctx, colibri.Request{
+		SrcIA:      s.IA,
+		DstIA:      addr.IA(req.DestinationIsdAs),
+		Egress:     common.IFIDType(req.Egress),
+		Bandwidth:  req.Bandwidth,
+		Expiration: req.Expiration.AsTime(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	pb := &sdpb.ColibriReserveResponse{
+		Accepted:      rep.Accepted,
+		Bandwidth:     rep.Bandwidth,
+		FailureReason: rep.FailureReason,
+	}
+	if rep.Accepted {
+		pb.IdAs = uint64(rep.ID.ASID)
+		pb.IdSuffix = rep.ID.Suffix
+		pb.Expiration = timestamppb.New(rep.Expiration)
+	}
+	return pb, nil
+}
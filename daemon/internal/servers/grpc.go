@@ -16,6 +16,7 @@ package servers
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"net"
 	"time"
@@ -23,12 +24,19 @@ import (
 	durationpb "github.com/golang/protobuf/ptypes/duration"
 	timestamppb "github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	drkey_daemon "github.com/scionproto/scion/daemon/drkey"
 	"github.com/scionproto/scion/daemon/fetcher"
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/colibri"
 	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	hpgrpc "github.com/scionproto/scion/pkg/experimental/hiddenpath/grpc"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
@@ -40,8 +48,12 @@ import (
 	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
 	"github.com/scionproto/scion/pkg/snet"
 	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/path/combinator"
+	"github.com/scionproto/scion/private/path/pathpol"
 	"github.com/scionproto/scion/private/revcache"
+	"github.com/scionproto/scion/private/segment/segfetcher"
 	"github.com/scionproto/scion/private/topology"
+	"github.com/scionproto/scion/private/tracing"
 	"github.com/scionproto/scion/private/trust"
 )
 
@@ -49,6 +61,10 @@ type Topology interface {
 	InterfaceIDs() []uint16
 	UnderlayNextHop(uint16) *net.UDPAddr
 	ControlServiceAddresses() []*net.UDPAddr
+	ControlServiceNames() []string
+	ControlServiceAddress(string) *net.UDPAddr
+	InterfaceInfoMap() map[common.IFIDType]topology.IFInfo
+	Core() bool
 }
 
 // DaemonServer handles gRPC requests to the SCION daemon.
@@ -60,11 +76,41 @@ type DaemonServer struct {
 	RevCache    revcache.RevCache
 	ASInspector trust.Inspector
 	DRKeyClient *drkey_daemon.ClientEngine
+	// Colibri forwards COLIBRI reservation requests. If nil, reservation
+	// requests are rejected.
+	Colibri colibri.Reserver
+	// WatchInterval is the interval in which the path sets of watched
+	// destinations are re-evaluated. If zero, DefaultWatchInterval is used.
+	WatchInterval time.Duration
+	// Prefetcher, if set, tracks the requested destinations to refresh their
+	// paths before they expire.
+	Prefetcher *Prefetcher
+	// PathPolicies are the named path policies that clients can request to
+	// filter the returned paths with.
+	PathPolicies map[string]*pathpol.Policy
+	// Geofence are the ISDs and ASes that the returned paths must not
+	// traverse. An entry with AS number 0 forbids the whole ISD. It applies to
+	// all requests and cannot be overridden by the clients.
+	Geofence []addr.IA
+	// PathHealth aggregates the path usage statistics reported by the clients.
+	// If nil, reports are rejected.
+	PathHealth *PathHealth
+	// Ranker, if set, orders the returned paths. Otherwise, the paths are
+	// returned in the order in which they are combined.
+	Ranker *Ranker
+	// HPGroups are the locally configured hidden path groups.
+	HPGroups hiddenpath.Groups
+	// LearnedHPGroups are the hidden path groups learned from the control
+	// service. It can be nil.
+	LearnedHPGroups *hiddenpath.LearnedGroups
+	// HiddenPathCache, if set, caches the results of hidden path requests.
+	HiddenPathCache *HiddenPathCache
 
 	Metrics Metrics
 
 	foregroundPathDedupe singleflight.Group
 	backgroundPathDedupe singleflight.Group
+	pathUpdates          pathNotifier
 }
 
 // Paths serves the paths request.
@@ -89,17 +135,89 @@ func (s *DaemonServer) paths(ctx context.Context,
 		ctx, cancelF = context.WithTimeout(ctx, 10*time.Second)
 		defer cancelF()
 	}
+	var policy *pathpol.Policy
+	if req.Policy != "" {
+		var ok bool
+		if policy, ok = s.PathPolicies[req.Policy]; !ok {
+			return nil, metricsError{
+				err:    status.Errorf(codes.InvalidArgument, "unknown path policy %q", req.Policy),
+				result: prom.ErrInvalidReq,
+			}
+		}
+	}
 	srcIA, dstIA := addr.IA(req.SourceIsdAs), addr.IA(req.DestinationIsdAs)
 	go func() {
 		defer log.HandlePanic()
 		s.backgroundPaths(ctx, srcIA, dstIA, req.Refresh)
 	}()
-	paths, err := s.fetchPaths(ctx, &s.foregroundPathDedupe, srcIA, dstIA, req.Refresh)
+	var waypoints []addr.IA
+	for _, wp := range req.Waypoints {
+		waypoints = append(waypoints, addr.IA(wp))
+	}
+	if len(waypoints) > 0 {
+		ctx = segfetcher.ContextWithWaypoints(ctx, waypoints)
+	}
+	refresh := req.Refresh
+	var hiddenKey string
+	if req.Hidden {
+		groups := make([]hiddenpath.GroupID, 0, len(req.HiddenPathGroupIds))
+		for _, id := range req.HiddenPathGroupIds {
+			groups = append(groups, hiddenpath.GroupIDFromUint64(id))
+		}
+		if len(groups) == 0 {
+			groups = s.defaultHiddenPathGroups(dstIA)
+		}
+		ctx = hpgrpc.ContextWithGroups(ctx, groups)
+		// Hidden segments are only looked up when the segments are fetched from
+		// the control service, hence the path database is bypassed.
+		refresh = true
+		if s.HiddenPathCache != nil {
+			hiddenKey = hiddenPathCacheKey(srcIA, dstIA, groups, waypoints)
+		}
+	}
+	fetchCtx, span := tracing.StartOTelSpan(ctx, "daemon.fetch_paths",
+		attribute.String("src", srcIA.String()),
+		attribute.String("dst", dstIA.String()),
+		attribute.Bool("refresh", refresh),
+		attribute.Bool("hidden", req.Hidden),
+	)
+	var (
+		paths  []snet.Path
+		err    error
+		cached bool
+	)
+	if hiddenKey != "" && !req.Refresh {
+		paths, cached = s.HiddenPathCache.Get(hiddenKey, time.Now())
+	}
+	if !cached {
+		paths, err = s.fetchPaths(fetchCtx, &s.foregroundPathDedupe, srcIA, dstIA, refresh)
+		if err == nil && hiddenKey != "" {
+			s.HiddenPathCache.Add(hiddenKey, paths, time.Now())
+		}
+	}
+	span.SetAttributes(
+		attribute.Int("paths", len(paths)),
+		attribute.Bool("cached", cached),
+	)
+	tracing.OTelError(span, err)
+	span.End()
 	if err != nil {
-		log.FromCtx(ctx).Debug("Fetching paths", "err", err,
-			"src", srcIA, "dst", dstIA, "refresh", req.Refresh)
+		log.FromCtx(ctx).Debug("Fetching paths", "err", err, "src", srcIA, "dst", dstIA,
+			"refresh", refresh, "hidden", req.Hidden, "waypoints", waypoints)
+		// Pass on the status of the control service if it carries error details,
+		// e.g., if the hidden path lookup was not authorized.
+		if st, ok := libgrpc.DetailedStatus(err); ok {
+			return nil, st.Err()
+		}
 		return nil, err
 	}
+	if s.Prefetcher != nil {
+		s.Prefetcher.Track(srcIA, dstIA, paths)
+	}
+	_, span = tracing.StartOTelSpan(ctx, "daemon.filter_paths")
+	paths = s.Ranker.Rank(policy.Filter(filterPeering(paths, req.Peering)), time.Now())
+	span.SetAttributes(attribute.Int("paths", len(paths)))
+	span.End()
 	reply := &sdpb.PathsResponse{}
 	for _, p := range paths {
 		reply.Paths = append(reply.Paths, pathToPB(p))
@@ -107,6 +225,22 @@ func (s *DaemonServer) paths(ctx context.Context,
 	return reply, nil
 }
 
+// filterPeering returns the paths that satisfy the requested peering mode.
+func filterPeering(paths []snet.Path, mode sdpb.PeeringMode) []snet.Path {
+	if mode == sdpb.PeeringMode_PEERING_MODE_UNSPECIFIED {
+		return paths
+	}
+	wantPeering := mode == sdpb.PeeringMode_PEERING_MODE_REQUIRE
+	var result []snet.Path
+	for _, p := range paths {
+		meta := p.Metadata()
+		if meta != nil && meta.Peering == wantPeering {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
 func (s *DaemonServer) fetchPaths(
 	ctx context.Context,
 	group *singleflight.Group,
@@ -114,7 +248,9 @@ func (s *DaemonServer) fetchPaths(
 	refresh bool,
 ) ([]snet.Path, error) {
 
-	r, err, _ := group.Do(fmt.Sprintf("%s%s%t", src, dst, refresh),
+	key := fmt.Sprintf("%s%s%t%v%v", src, dst, refresh, hpgrpc.GroupsFromContext(ctx),
+		segfetcher.WaypointsFromContext(ctx))
+	r, err, _ := group.Do(key,
 		func() (interface{}, error) {
 			return s.Fetcher.GetPaths(ctx, src, dst, refresh)
 		},
@@ -122,7 +258,107 @@ func (s *DaemonServer) fetchPaths(
 	// just cast to the correct type, ignore the "ok", since that can only be
 	// false in case of a nil result.
 	paths, _ := r.([]snet.Path)
-	return paths, err
+	return filterGeofence(paths, s.Geofence), err
+}
+
+// filterGeofence returns the paths that do not traverse any of the ISDs or
+// ASes in the geofence. The input slice is not modified, as it may be shared
+// with concurrent requests.
+func filterGeofence(paths []snet.Path, geofence []addr.IA) []snet.Path {
+	if len(geofence) == 0 {
+		return paths
+	}
+	var result []snet.Path
+	for _, p := range paths {
+		if !traversesAny(p, geofence) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func traversesAny(p snet.Path, ias []addr.IA) bool {
+	hops := []addr.IA{p.Source(), p.Destination()}
+	if meta := p.Metadata(); meta != nil {
+		for _, intf := range meta.Interfaces {
+			hops = append(hops, intf.IA)
+		}
+	}
+	for _, hop := range hops {
+		for _, ia := range ias {
+			if hop == ia || (ia.AS() == 0 && hop.ISD() == ia.ISD()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PathByFingerprint serves the path by fingerprint request. The path is looked
+// up among the cached paths to the destination. If no cached path has the
+// requested fingerprint, a NotFound error is returned.
+func (s *DaemonServer) PathByFingerprint(ctx context.Context,
+	req *sdpb.PathByFingerprintRequest) (*sdpb.PathByFingerprintResponse, error) {
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancelF context.CancelFunc
+		ctx, cancelF = context.WithTimeout(ctx, 10*time.Second)
+		defer cancelF()
+	}
+	srcIA, dstIA := addr.IA(req.SourceIsdAs), addr.IA(req.DestinationIsdAs)
+	fingerprint := snet.PathFingerprint(req.Fingerprint)
+	paths, err := s.fetchPaths(ctx, &s.foregroundPathDedupe, srcIA, dstIA, false)
+	if err != nil {
+		log.FromCtx(ctx).Debug("Fetching paths", "err", err, "src", srcIA, "dst", dstIA)
+		return nil, err
+	}
+	for _, p := range paths {
+		if snet.Fingerprint(p) == fingerprint {
+			return &sdpb.PathByFingerprintResponse{Path: pathToPB(p)}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "no path with fingerprint")
+}
+
+// DisjointPaths serves the disjoint paths request. The paths are ranked before
+// the disjoint paths are selected, such that the most preferred path is always
+// returned first.
+func (s *DaemonServer) DisjointPaths(ctx context.Context,
+	req *sdpb.DisjointPathsRequest) (*sdpb.DisjointPathsResponse, error) {
+
+	if req.Count == 0 {
+		return nil, status.Error(codes.InvalidArgument, "count must be positive")
+	}
+	var mode combinator.DisjointnessMode
+	switch req.Mode {
+	case sdpb.DisjointnessMode_DISJOINTNESS_MODE_UNSPECIFIED,
+		sdpb.DisjointnessMode_DISJOINTNESS_MODE_LINK:
+		mode = combinator.LinkDisjoint
+	case sdpb.DisjointnessMode_DISJOINTNESS_MODE_AS:
+		mode = combinator.ASDisjoint
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown mode %v", req.Mode)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancelF context.CancelFunc
+		ctx, cancelF = context.WithTimeout(ctx, 10*time.Second)
+		defer cancelF()
+	}
+	srcIA, dstIA := addr.IA(req.SourceIsdAs), addr.IA(req.DestinationIsdAs)
+	paths, err := s.fetchPaths(ctx, &s.foregroundPathDedupe, srcIA, dstIA, req.Refresh)
+	if err != nil {
+		log.FromCtx(ctx).Debug("Fetching paths", "err", err, "src", srcIA, "dst", dstIA)
+		return nil, err
+	}
+	if s.Prefetcher != nil {
+		s.Prefetcher.Track(srcIA, dstIA, paths)
+	}
+	paths = combinator.SelectDisjoint(s.Ranker.Rank(paths, time.Now()), int(req.Count), mode)
+	reply := &sdpb.DisjointPathsResponse{}
+	for _, p := range paths {
+		reply.Paths = append(reply.Paths, pathToPB(p))
+	}
+	return reply, nil
 }
 
 func pathToPB(path snet.Path) *sdpb.Path {
@@ -184,6 +420,7 @@ func pathToPB(path snet.Path) *sdpb.Path {
 		InternalHops: meta.InternalHops,
 		Notes:        meta.Notes,
 		EpicAuths:    epicAuths,
+		Peering:      meta.Peering,
 	}
 
 }
@@ -348,14 +585,53 @@ func (s *DaemonServer) notifyInterfaceDown(ctx context.Context,
 			result: prom.ErrDB,
 		}
 	}
+	s.pathUpdates.notify()
 	return &sdpb.NotifyInterfaceDownResponse{}, nil
 }
 
+// ReportPathUsage aggregates the path usage statistics reported by a client.
+func (s *DaemonServer) ReportPathUsage(ctx context.Context,
+	req *sdpb.ReportPathUsageRequest) (*sdpb.ReportPathUsageResponse, error) {
+
+	if s.PathHealth == nil {
+		return nil, status.Error(codes.Unavailable, "path usage reporting is not enabled")
+	}
+	now := time.Now()
+	for i, u := range req.Usages {
+		if len(u.Fingerprint) != sha256.Size {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"invalid fingerprint length %d at index %d", len(u.Fingerprint), i)
+		}
+		rtts := make([]time.Duration, 0, len(u.RttSamples))
+		for _, rtt := range u.RttSamples {
+			if d := rtt.AsDuration(); d > 0 {
+				rtts = append(rtts, d)
+			}
+		}
+		s.PathHealth.Report(snet.PathUsage{
+			Destination: addr.IA(u.DestinationIsdAs),
+			Fingerprint: snet.PathFingerprint(u.Fingerprint),
+			PacketsSent: u.PacketsSent,
+			PacketsLost: u.PacketsLost,
+			RTTs:        rtts,
+		}, now)
+	}
+	return &sdpb.ReportPathUsageResponse{}, nil
+}
+
+// errDRKeyNotAvailable is returned by the DRKey handlers if the daemon was
+// started without a DRKey level 2 database.
+var errDRKeyNotAvailable = status.Error(codes.Unavailable,
+	"DRKey is not available, drkey_level2_db is not configured")
+
 func (s *DaemonServer) DRKeyASHost(
 	ctx context.Context,
 	req *pb_daemon.DRKeyASHostRequest,
 ) (*pb_daemon.DRKeyASHostResponse, error) {
 
+	if s.DRKeyClient == nil {
+		return nil, errDRKeyNotAvailable
+	}
 	meta, err := requestToASHostMeta(req)
 	if err != nil {
 		return nil, serrors.WrapStr("parsing protobuf ASHostReq", err)
@@ -378,6 +654,9 @@ func (s *DaemonServer) DRKeyHostAS(
 	req *pb_daemon.DRKeyHostASRequest,
 ) (*pb_daemon.DRKeyHostASResponse, error) {
 
+	if s.DRKeyClient == nil {
+		return nil, errDRKeyNotAvailable
+	}
 	meta, err := requestToHostASMeta(req)
 	if err != nil {
 		return nil, serrors.WrapStr("parsing protobuf HostASReq", err)
@@ -400,6 +679,9 @@ func (s *DaemonServer) DRKeyHostHost(
 	req *pb_daemon.DRKeyHostHostRequest,
 ) (*pb_daemon.DRKeyHostHostResponse, error) {
 
+	if s.DRKeyClient == nil {
+		return nil, errDRKeyNotAvailable
+	}
 	meta, err := requestToHostHostMeta(req)
 	if err != nil {
 		return nil, serrors.WrapStr("parsing protobuf HostHostReq", err)
@@ -0,0 +1,385 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/scionproto/scion/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	hpgrpc "github.com/scionproto/scion/pkg/experimental/hiddenpath/grpc"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	edpb "github.com/scionproto/scion/pkg/proto/error_details"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/path/pathpol"
+	"github.com/scionproto/scion/private/segment/segfetcher"
+)
+
+func TestPathByFingerprint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	newPath := func(ifID uint64) snet.Path {
+		return snetpath.Path{
+			Src: src,
+			Dst: dst,
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: src, ID: 1},
+					{IA: dst, ID: common.IFIDType(1 + ifID)},
+				},
+				Expiry: time.Now().Add(time.Hour),
+			},
+			NextHop:       &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30041},
+			DataplanePath: snetpath.SCION{Raw: []byte{byte(ifID)}},
+		}
+	}
+	p1, p2 := newPath(1), newPath(2)
+
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, false).
+		Return([]snet.Path{p1, p2}, nil).Times(2)
+	s := &DaemonServer{Fetcher: f}
+
+	reply, err := s.PathByFingerprint(context.Background(), &sdpb.PathByFingerprintRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Fingerprint:      []byte(snet.Fingerprint(p2)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, reply.Path.Raw)
+
+	_, err = s.PathByFingerprint(context.Background(), &sdpb.PathByFingerprintRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Fingerprint:      []byte(snet.Fingerprint(newPath(3))),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDisjointPaths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	newPath := func(egress, ingress uint64) snet.Path {
+		return snetpath.Path{
+			Src: src,
+			Dst: dst,
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: src, ID: common.IFIDType(egress)},
+					{IA: dst, ID: common.IFIDType(ingress)},
+				},
+				Expiry: time.Now().Add(time.Hour),
+			},
+			NextHop:       &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30041},
+			DataplanePath: snetpath.SCION{Raw: []byte{byte(egress), byte(ingress)}},
+		}
+	}
+	// The first two paths share the link 110#1-112#1.
+	paths := []snet.Path{newPath(1, 1), newPath(1, 1), newPath(2, 2)}
+
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, true).Return(paths, nil)
+	s := &DaemonServer{Fetcher: f}
+
+	reply, err := s.DisjointPaths(context.Background(), &sdpb.DisjointPathsRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Count:            2,
+		Refresh:          true,
+	})
+	require.NoError(t, err)
+	require.Len(t, reply.Paths, 2)
+	assert.Equal(t, []byte{1, 1}, reply.Paths[0].Raw)
+	assert.Equal(t, []byte{2, 2}, reply.Paths[1].Raw)
+
+	_, err = s.DisjointPaths(context.Background(), &sdpb.DisjointPathsRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPathsHidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	group, err := hiddenpath.ParseGroupID("ff00:0:111-42")
+	require.NoError(t, err)
+
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, true).DoAndReturn(
+		func(ctx context.Context, _, _ addr.IA, _ bool) ([]snet.Path, error) {
+			assert.Equal(t, []hiddenpath.GroupID{group}, hpgrpc.GroupsFromContext(ctx))
+			return nil, nil
+		},
+	)
+	s := &DaemonServer{Fetcher: f}
+
+	_, err = s.paths(context.Background(), &sdpb.PathsRequest{
+		SourceIsdAs:        uint64(src),
+		DestinationIsdAs:   uint64(dst),
+		Hidden:             true,
+		HiddenPathGroupIds: []uint64{group.ToUint64()},
+	})
+	require.NoError(t, err)
+}
+
+func TestPathsHiddenNotAuthorized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	group, err := hiddenpath.ParseGroupID("ff00:0:111-42")
+	require.NoError(t, err)
+	detail := &edpb.ErrorGroupNotAuthorized{
+		GroupId: group.ToUint64(),
+		IsdAs:   uint64(src),
+		Reason:  edpb.ErrorGroupNotAuthorized_REASON_NOT_MEMBER,
+	}
+
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, true).Return(nil, serrors.WrapStr(
+		"fetching segments",
+		libgrpc.StatusError(codes.PermissionDenied, "not authorized", detail),
+	))
+	s := &DaemonServer{Fetcher: f}
+
+	_, err = s.paths(context.Background(), &sdpb.PathsRequest{
+		SourceIsdAs:        uint64(src),
+		DestinationIsdAs:   uint64(dst),
+		Hidden:             true,
+		HiddenPathGroupIds: []uint64{group.ToUint64()},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	var got edpb.ErrorGroupNotAuthorized
+	require.True(t, libgrpc.ErrorDetail(err, &got))
+	assert.True(t, proto.Equal(detail, &got))
+}
+
+func TestPathsWaypoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("2-ff00:0:212")
+	waypoints := []addr.IA{xtest.MustParseIA("1-ff00:0:130"), xtest.MustParseIA("2-0")}
+
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, false).DoAndReturn(
+		func(ctx context.Context, _, _ addr.IA, _ bool) ([]snet.Path, error) {
+			assert.Equal(t, waypoints, segfetcher.WaypointsFromContext(ctx))
+			return nil, nil
+		},
+	)
+	s := &DaemonServer{Fetcher: f}
+
+	_, err := s.paths(context.Background(), &sdpb.PathsRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Waypoints:        []uint64{uint64(waypoints[0]), uint64(waypoints[1])},
+	})
+	require.NoError(t, err)
+}
+
+func TestPathsPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	newPath := func(ifID uint64) snet.Path {
+		return snetpath.Path{
+			Src: src,
+			Dst: dst,
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: src, ID: 1},
+					{IA: dst, ID: common.IFIDType(ifID)},
+				},
+				Expiry: time.Now().Add(time.Hour),
+			},
+			NextHop:       &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30041},
+			DataplanePath: snetpath.SCION{Raw: []byte{byte(ifID)}},
+		}
+	}
+	var policy pathpol.Policy
+	require.NoError(t, json.Unmarshal([]byte(`{"acl": ["- 1-ff00:0:112#2", "+"]}`), &policy))
+
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, false).
+		Return([]snet.Path{newPath(2), newPath(3)}, nil).AnyTimes()
+	s := &DaemonServer{
+		Fetcher:      f,
+		PathPolicies: map[string]*pathpol.Policy{"no_2": &policy},
+	}
+
+	reply, err := s.paths(context.Background(), &sdpb.PathsRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Policy:           "no_2",
+	})
+	require.NoError(t, err)
+	require.Len(t, reply.Paths, 1)
+	assert.Equal(t, []byte{3}, reply.Paths[0].Raw)
+
+	_, err = s.Paths(context.Background(), &sdpb.PathsRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Policy:           "unknown",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPathsGeofence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	newPath := func(transit addr.IA, raw byte) snet.Path {
+		return snetpath.Path{
+			Src: src,
+			Dst: dst,
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: src, ID: 1},
+					{IA: transit, ID: 2},
+					{IA: transit, ID: 3},
+					{IA: dst, ID: 4},
+				},
+				Expiry: time.Now().Add(time.Hour),
+			},
+			NextHop:       &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30041},
+			DataplanePath: snetpath.SCION{Raw: []byte{raw}},
+		}
+	}
+	paths := []snet.Path{
+		newPath(xtest.MustParseIA("1-ff00:0:111"), 1),
+		newPath(xtest.MustParseIA("1-ff00:0:120"), 2),
+		newPath(xtest.MustParseIA("2-ff00:0:210"), 3),
+	}
+
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, false).Return(paths, nil).AnyTimes()
+	s := &DaemonServer{
+		Fetcher:  f,
+		Geofence: []addr.IA{xtest.MustParseIA("1-ff00:0:120"), xtest.MustParseIA("2-0")},
+	}
+
+	reply, err := s.paths(context.Background(), &sdpb.PathsRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+	})
+	require.NoError(t, err)
+	require.Len(t, reply.Paths, 1)
+	assert.Equal(t, []byte{1}, reply.Paths[0].Raw)
+	// The paths returned by the fetcher are shared and must not be modified.
+	assert.Len(t, paths, 3)
+	assert.Equal(t, []byte{2}, paths[1].Dataplane().(snetpath.SCION).Raw)
+
+	_, err = s.PathByFingerprint(context.Background(), &sdpb.PathByFingerprintRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Fingerprint:      []byte(snet.Fingerprint(paths[2])),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestReportPathUsage(t *testing.T) {
+	fingerprint := sha256.Sum256([]byte("path"))
+	req := &sdpb.ReportPathUsageRequest{
+		Usages: []*sdpb.PathUsage{{
+			DestinationIsdAs: uint64(xtest.MustParseIA("1-ff00:0:112")),
+			Fingerprint:      fingerprint[:],
+			PacketsSent:      10,
+			PacketsLost:      2,
+			RttSamples:       []*durationpb.Duration{durationpb.New(time.Millisecond)},
+		}},
+	}
+
+	s := &DaemonServer{PathHealth: &PathHealth{}}
+	_, err := s.ReportPathUsage(context.Background(), req)
+	require.NoError(t, err)
+	stats, ok := s.PathHealth.Stats(snet.PathFingerprint(fingerprint[:]), time.Now())
+	require.True(t, ok)
+	assert.Equal(t, uint64(10), stats.PacketsSent)
+	assert.Equal(t, uint64(2), stats.PacketsLost)
+	assert.Equal(t, time.Millisecond, stats.RTT)
+
+	req.Usages[0].Fingerprint = []byte("short")
+	_, err = s.ReportPathUsage(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = (&DaemonServer{}).ReportPathUsage(context.Background(), req)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestFilterPeering(t *testing.T) {
+	newPath := func(peering bool) snet.Path {
+		return snetpath.Path{Meta: snet.PathMetadata{Peering: peering}}
+	}
+	peering, regular := newPath(true), newPath(false)
+	paths := []snet.Path{peering, regular}
+
+	assert.Equal(t, paths, filterPeering(paths, sdpb.PeeringMode_PEERING_MODE_UNSPECIFIED))
+	assert.Equal(t, []snet.Path{regular},
+		filterPeering(paths, sdpb.PeeringMode_PEERING_MODE_FORBID))
+	assert.Equal(t, []snet.Path{peering},
+		filterPeering(paths, sdpb.PeeringMode_PEERING_MODE_REQUIRE))
+}
+
+func TestDRKeyNotConfigured(t *testing.T) {
+	s := &DaemonServer{}
+	_, err := s.DRKeyASHost(context.Background(), &sdpb.DRKeyASHostRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = s.DRKeyHostAS(context.Background(), &sdpb.DRKeyHostASRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = s.DRKeyHostHost(context.Background(), &sdpb.DRKeyHostHostRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestColibriNotConfigured(t *testing.T) {
+	s := &DaemonServer{}
+	_, err := s.ColibriReserve(context.Background(), &sdpb.ColibriReserveRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+const (
+	// DefaultPathHealthMaxAge is the default time after which the statistics
+	// of a path that is not reported anymore are discarded.
+	DefaultPathHealthMaxAge = 10 * time.Minute
+	// DefaultPathHealthMaxPaths is the default maximum number of paths for
+	// which statistics are kept.
+	DefaultPathHealthMaxPaths = 10000
+)
+
+// PathStats are the aggregated usage statistics of a path.
+type PathStats struct {
+	// PacketsSent is the number of packets that were sent over the path.
+	PacketsSent uint64
+	// PacketsLost is the number of packets that were lost on the path.
+	PacketsLost uint64
+	// RTT is the smoothed round trip time of the path. It is zero if no RTT
+	// sample was reported.
+	RTT time.Duration
+	// LastReport is the time of the last report for the path.
+	LastReport time.Time
+}
+
+// LossRate returns the fraction of the sent packets that were lost. It is
+// zero if no loss was reported.
+func (s PathStats) LossRate() float64 {
+	if s.PacketsLost == 0 {
+		return 0
+	}
+	if s.PacketsLost >= s.PacketsSent {
+		return 1
+	}
+	return float64(s.PacketsLost) / float64(s.PacketsSent)
+}
+
+// PathHealth aggregates the path usage statistics that are reported by the
+// clients of the daemon.
+type PathHealth struct {
+	// MaxAge is the time after which the statistics of a path that is not
+	// reported anymore are discarded. If zero, DefaultPathHealthMaxAge is used.
+	MaxAge time.Duration
+	// MaxPaths is the maximum number of paths for which statistics are kept.
+	// Reports for additional paths are ignored. If zero,
+	// DefaultPathHealthMaxPaths is used.
+	MaxPaths int
+
+	mtx   sync.Mutex
+	paths map[snet.PathFingerprint]*PathStats
+}
+
+// Report adds the usage statistics to the aggregated statistics of the path.
+func (h *PathHealth) Report(usage snet.PathUsage, now time.Time) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	h.expire(now)
+	stats, ok := h.paths[usage.Fingerprint]
+	if !ok {
+		if h.paths == nil {
+			h.paths = make(map[snet.PathFingerprint]*PathStats)
+		}
+		if len(h.paths) >= h.maxPaths() {
+			return
+		}
+		stats = &PathStats{}
+		h.paths[usage.Fingerprint] = stats
+	}
+	stats.PacketsSent += usage.PacketsSent
+	stats.PacketsLost += usage.PacketsLost
+	for _, rtt := range usage.RTTs {
+		if stats.RTT == 0 {
+			stats.RTT = rtt
+			continue
+		}
+		// Smooth the RTT the same way TCP does (RFC 6298).
+		stats.RTT += (rtt - stats.RTT) / 8
+	}
+	stats.LastReport = now
+}
+
+// Stats returns the aggregated statistics of the path. The second return value
+// is false if no statistics are known for the path. Calling Stats on a nil
+// PathHealth returns no statistics.
+func (h *PathHealth) Stats(fingerprint snet.PathFingerprint, now time.Time) (PathStats, bool) {
+	if h == nil {
+		return PathStats{}, false
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	stats, ok := h.paths[fingerprint]
+	if !ok || now.Sub(stats.LastReport) > h.maxAge() {
+		return PathStats{}, false
+	}
+	return *stats, true
+}
+
+func (h *PathHealth) expire(now time.Time) {
+	for fp, stats := range h.paths {
+		if now.Sub(stats.LastReport) > h.maxAge() {
+			delete(h.paths, fp)
+		}
+	}
+}
+
+func (h *PathHealth) maxAge() time.Duration {
+	if h.MaxAge == 0 {
+		return DefaultPathHealthMaxAge
+	}
+	return h.MaxAge
+}
+
+func (h *PathHealth) maxPaths() int {
+	if h.MaxPaths == 0 {
+		return DefaultPathHealthMaxPaths
+	}
+	return h.MaxPaths
+}
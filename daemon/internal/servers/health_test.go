@@ -0,0 +1,86 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+func TestPathHealth(t *testing.T) {
+	now := time.Now()
+	fp1, fp2 := snet.PathFingerprint("path1"), snet.PathFingerprint("path2")
+
+	t.Run("aggregates reports", func(t *testing.T) {
+		h := &PathHealth{}
+		h.Report(snet.PathUsage{
+			Fingerprint: fp1,
+			PacketsSent: 10,
+			PacketsLost: 1,
+			RTTs:        []time.Duration{80 * time.Millisecond},
+		}, now)
+		h.Report(snet.PathUsage{
+			Fingerprint: fp1,
+			PacketsSent: 10,
+			RTTs:        []time.Duration{160 * time.Millisecond},
+		}, now.Add(time.Second))
+
+		stats, ok := h.Stats(fp1, now.Add(time.Second))
+		assert.True(t, ok)
+		assert.Equal(t, PathStats{
+			PacketsSent: 20,
+			PacketsLost: 1,
+			RTT:         90 * time.Millisecond,
+			LastReport:  now.Add(time.Second),
+		}, stats)
+		assert.Equal(t, 0.05, stats.LossRate())
+
+		_, ok = h.Stats(fp2, now)
+		assert.False(t, ok)
+	})
+	t.Run("expires stale paths", func(t *testing.T) {
+		h := &PathHealth{MaxAge: time.Minute}
+		h.Report(snet.PathUsage{Fingerprint: fp1, PacketsSent: 1}, now)
+		_, ok := h.Stats(fp1, now.Add(2*time.Minute))
+		assert.False(t, ok)
+
+		h.Report(snet.PathUsage{Fingerprint: fp2, PacketsSent: 1}, now.Add(2*time.Minute))
+		h.Report(snet.PathUsage{Fingerprint: fp1, PacketsSent: 1}, now.Add(2*time.Minute))
+		stats, ok := h.Stats(fp1, now.Add(2*time.Minute))
+		assert.True(t, ok)
+		assert.Equal(t, uint64(1), stats.PacketsSent)
+	})
+	t.Run("limits number of paths", func(t *testing.T) {
+		h := &PathHealth{MaxPaths: 1}
+		h.Report(snet.PathUsage{Fingerprint: fp1, PacketsSent: 1}, now)
+		h.Report(snet.PathUsage{Fingerprint: fp2, PacketsSent: 1}, now)
+		_, ok := h.Stats(fp1, now)
+		assert.True(t, ok)
+		_, ok = h.Stats(fp2, now)
+		assert.False(t, ok)
+	})
+}
+
+func TestPathStatsLossRate(t *testing.T) {
+	assert.Equal(t, 0.0, PathStats{}.LossRate())
+	assert.Equal(t, 0.0, PathStats{PacketsSent: 10}.LossRate())
+	assert.Equal(t, 0.5, PathStats{PacketsSent: 10, PacketsLost: 5}.LossRate())
+	assert.Equal(t, 1.0, PathStats{PacketsSent: 1, PacketsLost: 5}.LossRate())
+	assert.Equal(t, 1.0, PathStats{PacketsLost: 1}.LossRate())
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// HiddenPathGroups returns the hidden path groups that are used for hidden
+// path requests without explicit groups. These are the locally configured
+// groups and the groups learned from the control service.
+func (s *DaemonServer) HiddenPathGroups(ctx context.Context,
+	_ *sdpb.HiddenPathGroupsRequest) (*sdpb.HiddenPathGroupsResponse, error) {
+
+	reply := &sdpb.HiddenPathGroupsResponse{}
+	add := func(groups hiddenpath.Groups, learned bool) {
+		for _, group := range groups {
+			if learned {
+				if _, ok := s.HPGroups[group.ID]; ok {
+					continue
+				}
+			}
+			pb := &sdpb.HiddenPathGroup{
+				Id:      group.ID.ToUint64(),
+				Learned: learned,
+			}
+			for writer := range group.Writers {
+				pb.Writers = append(pb.Writers, uint64(writer))
+			}
+			sort.Slice(pb.Writers, func(i, j int) bool { return pb.Writers[i] < pb.Writers[j] })
+			reply.Groups = append(reply.Groups, pb)
+		}
+	}
+	add(s.HPGroups, false)
+	add(s.LearnedHPGroups.Groups(), true)
+	sort.Slice(reply.Groups, func(i, j int) bool {
+		return reply.Groups[i].Id < reply.Groups[j].Id
+	})
+	return reply, nil
+}
+
+// defaultHiddenPathGroups returns the groups that are queried for a hidden path
+// request to dst without explicit groups.
+func (s *DaemonServer) defaultHiddenPathGroups(dst addr.IA) []hiddenpath.GroupID {
+	return hiddenpath.WriterGroupIDs(dst, s.HPGroups,
+		s.LearnedHPGroups.Groups())
+}
+
+// HiddenPathCache caches the results of hidden path requests. Hidden segments
+// are not served from the path database, hence without the cache every hidden
+// path request results in a request to the control service. An entry expires
+// after TTL, or earlier if one of the cached paths expires. Empty results are
+// not cached, such that newly registered hidden segments are found
+// immediately.
+type HiddenPathCache struct {
+	// TTL is the maximum time for which a result is cached.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]hiddenPathEntry
+}
+
+type hiddenPathEntry struct {
+	paths  []snet.Path
+	expiry time.Time
+}
+
+// Get returns the cached paths for the key, if there are any that have not
+// expired at the given time.
+func (c *HiddenPathCache) Get(key string, now time.Time) ([]snet.Path, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(e.expiry) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.paths, true
+}
+
+// Add caches the paths for the key. Expired entries are evicted.
+func (c *HiddenPathCache) Add(key string, paths []snet.Path, now time.Time) {
+	if len(paths) == 0 {
+		return
+	}
+	expiry := now.Add(c.TTL)
+	for _, p := range paths {
+		if meta := p.Metadata(); meta != nil && meta.Expiry.Before(expiry) {
+			expiry = meta.Expiry
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if !now.Before(e.expiry) {
+			delete(c.entries, k)
+		}
+	}
+	if !now.Before(expiry) {
+		return
+	}
+	if c.entries == nil {
+		c.entries = make(map[string]hiddenPathEntry)
+	}
+	c.entries[key] = hiddenPathEntry{paths: paths, expiry: expiry}
+}
+
+func hiddenPathCacheKey(src, dst addr.IA, groups []hiddenpath.GroupID,
+	waypoints []addr.IA) string {
+
+	return fmt.Sprintf("%s%s%v%v", src, dst, groups, waypoints)
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	hpgrpc "github.com/scionproto/scion/pkg/experimental/hiddenpath/grpc"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestPathsHiddenDefaultGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	configured, learned := testHPGroups(t, dst)
+
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, true).DoAndReturn(
+		func(ctx context.Context, _, _ addr.IA, _ bool) ([]snet.Path, error) {
+			want := []hiddenpath.GroupID{
+				mustParseGroupID(t, "ff00:0:111-1"),
+				mustParseGroupID(t, "ff00:0:111-3"),
+			}
+			assert.Equal(t, want, hpgrpc.GroupsFromContext(ctx))
+			return nil, nil
+		},
+	)
+	s := &DaemonServer{
+		Fetcher:         f,
+		HPGroups:        configured,
+		LearnedHPGroups: learned,
+	}
+
+	_, err := s.paths(context.Background(), &sdpb.PathsRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Hidden:           true,
+	})
+	require.NoError(t, err)
+}
+
+func TestPathsHiddenCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	p := snetpath.Path{
+		Src:  src,
+		Dst:  dst,
+		Meta: snet.PathMetadata{Expiry: time.Now().Add(time.Hour)},
+	}
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	s := &DaemonServer{
+		Fetcher:         f,
+		HiddenPathCache: &HiddenPathCache{TTL: time.Minute},
+	}
+	req := &sdpb.PathsRequest{
+		SourceIsdAs:        uint64(src),
+		DestinationIsdAs:   uint64(dst),
+		Hidden:             true,
+		HiddenPathGroupIds: []uint64{mustParseGroupID(t, "ff00:0:111-1").ToUint64()},
+	}
+
+	// The second request is served from the cache.
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, true).Return([]snet.Path{p}, nil)
+	for i := 0; i < 2; i++ {
+		rep, err := s.paths(context.Background(), req)
+		require.NoError(t, err)
+		assert.Len(t, rep.Paths, 1)
+	}
+
+	// Other groups and refresh requests are not served from the cache.
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, true).Return([]snet.Path{p}, nil).Times(2)
+	other := &sdpb.PathsRequest{
+		SourceIsdAs:        uint64(src),
+		DestinationIsdAs:   uint64(dst),
+		Hidden:             true,
+		HiddenPathGroupIds: []uint64{mustParseGroupID(t, "ff00:0:111-2").ToUint64()},
+	}
+	_, err := s.paths(context.Background(), other)
+	require.NoError(t, err)
+	req.Refresh = true
+	_, err = s.paths(context.Background(), req)
+	require.NoError(t, err)
+}
+
+func TestHiddenPathCache(t *testing.T) {
+	now := time.Now()
+	newPath := func(expiry time.Time) snet.Path {
+		return snetpath.Path{Meta: snet.PathMetadata{Expiry: expiry}}
+	}
+	c := &HiddenPathCache{TTL: time.Minute}
+
+	c.Add("ttl", []snet.Path{newPath(now.Add(time.Hour))}, now)
+	_, ok := c.Get("ttl", now.Add(59*time.Second))
+	assert.True(t, ok)
+	_, ok = c.Get("ttl", now.Add(time.Minute))
+	assert.False(t, ok)
+
+	c.Add("expiry", []snet.Path{
+		newPath(now.Add(time.Hour)),
+		newPath(now.Add(10 * time.Second)),
+	}, now)
+	_, ok = c.Get("expiry", now.Add(9*time.Second))
+	assert.True(t, ok)
+	_, ok = c.Get("expiry", now.Add(10*time.Second))
+	assert.False(t, ok)
+
+	c.Add("empty", nil, now)
+	_, ok = c.Get("empty", now)
+	assert.False(t, ok)
+}
+
+func TestHiddenPathGroups(t *testing.T) {
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	configured, learned := testHPGroups(t, dst)
+	s := &DaemonServer{
+		HPGroups:        configured,
+		LearnedHPGroups: learned,
+	}
+	rep, err := s.HiddenPathGroups(context.Background(), &sdpb.HiddenPathGroupsRequest{})
+	require.NoError(t, err)
+	want := []*sdpb.HiddenPathGroup{
+		{
+			Id:      mustParseGroupID(t, "ff00:0:111-1").ToUint64(),
+			Writers: []uint64{uint64(dst)},
+		},
+		{
+			Id:      mustParseGroupID(t, "ff00:0:111-2").ToUint64(),
+			Writers: []uint64{uint64(xtest.MustParseIA("1-ff00:0:113"))},
+		},
+		{
+			Id:      mustParseGroupID(t, "ff00:0:111-3").ToUint64(),
+			Writers: []uint64{uint64(dst)},
+			Learned: true,
+		},
+	}
+	assert.Equal(t, want, rep.Groups)
+}
+
+// testHPGroups returns configured and learned hidden path groups. The
+// destination is a writer in the groups ff00:0:111-1 and ff00:0:111-3. The
+// group ff00:0:111-1 is both configured and learned.
+func testHPGroups(t *testing.T, dst addr.IA) (hiddenpath.Groups,
+	*hiddenpath.LearnedGroups) {
+
+	newGroup := func(id string, writer addr.IA) *hiddenpath.Group {
+		return &hiddenpath.Group{
+			ID:      mustParseGroupID(t, id),
+			Writers: map[addr.IA]struct{}{writer: {}},
+		}
+	}
+	g1 := newGroup("ff00:0:111-1", dst)
+	g2 := newGroup("ff00:0:111-2", xtest.MustParseIA("1-ff00:0:113"))
+	g3 := newGroup("ff00:0:111-3", dst)
+	learned := &hiddenpath.LearnedGroups{}
+	learned.Set(hiddenpath.Groups{g1.ID: g1, g3.ID: g3})
+	return hiddenpath.Groups{g1.ID: g1, g2.ID: g2}, learned
+}
+
+func mustParseGroupID(t *testing.T, s string) hiddenpath.GroupID {
+	t.Helper()
+	id, err := hiddenpath.ParseGroupID(s)
+	require.NoError(t, err)
+	return id
+}
@@ -0,0 +1,235 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/daemon/fetcher"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+const (
+	// prefetchMinBackoff is the time a destination is not refreshed after the
+	// first unsuccessful refresh. It doubles with every further unsuccessful
+	// refresh, up to prefetchMaxBackoff.
+	prefetchMinBackoff = 30 * time.Second
+	prefetchMaxBackoff = 10 * time.Minute
+	// prefetchMaxFailures is the number of consecutive unsuccessful refreshes
+	// after which a destination is no longer tracked.
+	prefetchMaxFailures = 5
+)
+
+// PrefetchMetrics are the metrics of the path prefetcher. Each field may be
+// set individually.
+type PrefetchMetrics struct {
+	// Refreshes counts the refreshed destinations, labeled by result.
+	Refreshes metrics.Counter
+	// Destinations is the number of tracked destinations.
+	Destinations metrics.Gauge
+}
+
+// PrefetchRefreshesLabels are the labels of the PrefetchMetrics.Refreshes
+// counter.
+var PrefetchRefreshesLabels = []string{prom.LabelResult}
+
+// Prefetcher tracks the recently requested destinations and refreshes their
+// paths before they expire. This avoids that the first request after the
+// expiration of the path segments has to wait for the segments to be fetched
+// from the network.
+//
+// The prefetcher is a periodic task; every run refreshes at most Budget
+// destinations whose last path expires within Lead. A refresh is unsuccessful
+// if fetching the paths fails. After a successful refresh the destination is
+// not refreshed again for prefetchMinBackoff, even if the fetched paths still
+// expire within Lead. After an unsuccessful refresh the destination is backed
+// off exponentially, and after repeated unsuccessful refreshes it is no longer
+// tracked until it is requested again.
+type Prefetcher struct {
+	// Fetcher is used to refresh the paths.
+	Fetcher fetcher.Fetcher
+	// MaxDestinations is the maximum number of tracked destinations. If the
+	// limit is reached, the least recently requested destination is evicted.
+	MaxDestinations int
+	// Budget is the maximum number of destinations refreshed per run.
+	Budget int
+	// Lead is the time before the expiration of the paths at which the paths
+	// are refreshed.
+	Lead time.Duration
+	// IdleTimeout is the time after which a destination that was not
+	// requested anymore is no longer tracked.
+	IdleTimeout time.Duration
+	// Metrics are the prefetcher metrics.
+	Metrics PrefetchMetrics
+
+	mtx  sync.Mutex
+	dsts map[prefetchKey]*prefetchEntry
+}
+
+type prefetchKey struct {
+	src, dst addr.IA
+}
+
+type prefetchEntry struct {
+	requested time.Time
+	// expiry is the expiration time of the path that expires last.
+	expiry time.Time
+	// failures is the number of consecutive unsuccessful refreshes.
+	failures int
+	// backoff is the time before which the destination is not refreshed.
+	backoff time.Time
+}
+
+// Track records a request for the paths from src to dst.
+func (p *Prefetcher) Track(src, dst addr.IA, paths []snet.Path) {
+	expiry := lastExpiry(paths)
+	if expiry.IsZero() {
+		// Nothing to refresh.
+		return
+	}
+	now := time.Now()
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.dsts == nil {
+		p.dsts = make(map[prefetchKey]*prefetchEntry)
+	}
+	key := prefetchKey{src: src, dst: dst}
+	if e, ok := p.dsts[key]; ok {
+		e.requested, e.expiry = now, expiry
+		return
+	}
+	if p.MaxDestinations > 0 && len(p.dsts) >= p.MaxDestinations {
+		p.evictLocked()
+	}
+	p.dsts[key] = &prefetchEntry{requested: now, expiry: expiry}
+	metrics.GaugeSet(p.Metrics.Destinations, float64(len(p.dsts)))
+}
+
+// evictLocked removes the least recently requested destination.
+func (p *Prefetcher) evictLocked() {
+	var oldest prefetchKey
+	var oldestTime time.Time
+	for k, e := range p.dsts {
+		if oldestTime.IsZero() || e.requested.Before(oldestTime) {
+			oldest, oldestTime = k, e.requested
+		}
+	}
+	delete(p.dsts, oldest)
+}
+
+// Name returns the task name.
+func (p *Prefetcher) Name() string {
+	return "sd_path_prefetcher"
+}
+
+// Run refreshes the paths of the tracked destinations that expire soon.
+func (p *Prefetcher) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	for _, key := range p.due(time.Now()) {
+		paths, err := p.Fetcher.GetPaths(ctx, key.src, key.dst, true)
+		if err != nil {
+			logger.Debug("Prefetching paths failed", "src", key.src, "dst", key.dst,
+				"err", err)
+			p.incRefreshes(errToMetricResult(err))
+			p.backOff(key, time.Now())
+			continue
+		}
+		p.incRefreshes(prom.Success)
+		p.mtx.Lock()
+		if e, ok := p.dsts[key]; ok {
+			// Paths that expire within Lead even after the refresh are not
+			// refreshed again immediately.
+			e.expiry, e.failures = lastExpiry(paths), 0
+			e.backoff = time.Now().Add(prefetchMinBackoff)
+		}
+		p.mtx.Unlock()
+	}
+}
+
+// backOff records an unsuccessful refresh of the destination. The destination
+// is not refreshed again before the backoff expires, and it is dropped after
+// prefetchMaxFailures consecutive unsuccessful refreshes.
+func (p *Prefetcher) backOff(key prefetchKey, now time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	e, ok := p.dsts[key]
+	if !ok {
+		return
+	}
+	e.failures++
+	if e.failures >= prefetchMaxFailures {
+		delete(p.dsts, key)
+		metrics.GaugeSet(p.Metrics.Destinations, float64(len(p.dsts)))
+		return
+	}
+	backoff := prefetchMinBackoff << (e.failures - 1)
+	if backoff > prefetchMaxBackoff {
+		backoff = prefetchMaxBackoff
+	}
+	e.backoff = now.Add(backoff)
+}
+
+// due removes the idle destinations and returns the destinations that should
+// be refreshed, the ones that expire first are returned first.
+func (p *Prefetcher) due(now time.Time) []prefetchKey {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	var due []prefetchKey
+	for k, e := range p.dsts {
+		if p.IdleTimeout > 0 && now.Sub(e.requested) > p.IdleTimeout {
+			delete(p.dsts, k)
+			continue
+		}
+		if now.Before(e.backoff) {
+			continue
+		}
+		if e.expiry.IsZero() || e.expiry.Sub(now) <= p.Lead {
+			due = append(due, k)
+		}
+	}
+	metrics.GaugeSet(p.Metrics.Destinations, float64(len(p.dsts)))
+	sort.Slice(due, func(i, j int) bool {
+		return p.dsts[due[i]].expiry.Before(p.dsts[due[j]].expiry)
+	})
+	if p.Budget > 0 && len(due) > p.Budget {
+		due = due[:p.Budget]
+	}
+	return due
+}
+
+// lastExpiry returns the expiration time of the path that expires last. It
+// returns the zero time if none of the paths has an expiration time.
+func lastExpiry(paths []snet.Path) time.Time {
+	var last time.Time
+	for _, p := range paths {
+		if meta := p.Metadata(); meta != nil && meta.Expiry.After(last) {
+			last = meta.Expiry
+		}
+	}
+	return last
+}
+
+func (p *Prefetcher) incRefreshes(result string) {
+	if p.Metrics.Refreshes != nil {
+		p.Metrics.Refreshes.With(prom.LabelResult, result).Add(1)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestPrefetcher(t *testing.T) {
+	src := xtest.MustParseIA("1-ff00:0:110")
+	soon := xtest.MustParseIA("1-ff00:0:111")
+	sooner := xtest.MustParseIA("1-ff00:0:112")
+	later := xtest.MustParseIA("1-ff00:0:113")
+	pathsExpiringIn := func(dst addr.IA, d time.Duration) []snet.Path {
+		return []snet.Path{snetpath.Path{
+			Src:  src,
+			Dst:  dst,
+			Meta: snet.PathMetadata{Expiry: time.Now().Add(d)},
+		}}
+	}
+
+	t.Run("refreshes the paths that expire first within budget", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		f := mock_fetcher.NewMockFetcher(ctrl)
+		p := &Prefetcher{
+			Fetcher: f,
+			Budget:  1,
+			Lead:    time.Minute,
+		}
+		p.Track(src, soon, pathsExpiringIn(soon, 30*time.Second))
+		p.Track(src, sooner, pathsExpiringIn(sooner, 10*time.Second))
+		p.Track(src, later, pathsExpiringIn(later, time.Hour))
+
+		f.EXPECT().GetPaths(gomock.Any(), src, sooner, true).
+			Return(pathsExpiringIn(sooner, time.Hour), nil)
+		p.Run(context.Background())
+
+		f.EXPECT().GetPaths(gomock.Any(), src, soon, true).
+			Return(pathsExpiringIn(soon, time.Hour), nil)
+		p.Run(context.Background())
+
+		// Nothing is due anymore.
+		p.Run(context.Background())
+	})
+	t.Run("unsuccessful refreshes are backed off", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		f := mock_fetcher.NewMockFetcher(ctrl)
+		p := &Prefetcher{
+			Fetcher: f,
+			Lead:    time.Minute,
+		}
+		key := prefetchKey{src: src, dst: soon}
+		p.Track(src, soon, pathsExpiringIn(soon, 30*time.Second))
+
+		f.EXPECT().GetPaths(gomock.Any(), src, soon, true).
+			Return(nil, serrors.New("test"))
+		p.Run(context.Background())
+		// Backed off, nothing is due.
+		p.Run(context.Background())
+		assert.Equal(t, 1, p.dsts[key].failures)
+
+		for i := 1; i < prefetchMaxFailures; i++ {
+			p.dsts[key].backoff = time.Time{}
+			f.EXPECT().GetPaths(gomock.Any(), src, soon, true).
+				Return(nil, serrors.New("test"))
+			p.Run(context.Background())
+		}
+		assert.Empty(t, p.dsts)
+	})
+	t.Run("refreshes of short-lived paths are successful", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		f := mock_fetcher.NewMockFetcher(ctrl)
+		p := &Prefetcher{
+			Fetcher: f,
+			Lead:    time.Minute,
+		}
+		key := prefetchKey{src: src, dst: soon}
+		p.Track(src, soon, pathsExpiringIn(soon, 30*time.Second))
+
+		// One of the refreshed paths still expires within the lead time.
+		for i := 0; i < prefetchMaxFailures+1; i++ {
+			p.dsts[key].backoff = time.Time{}
+			f.EXPECT().GetPaths(gomock.Any(), src, soon, true).Return(
+				append(pathsExpiringIn(soon, 10*time.Second),
+					pathsExpiringIn(soon, 30*time.Second)...),
+				nil,
+			)
+			p.Run(context.Background())
+			// Not refreshed again immediately.
+			p.Run(context.Background())
+		}
+		assert.Contains(t, p.dsts, key)
+		assert.Zero(t, p.dsts[key].failures)
+	})
+	t.Run("refreshes are scheduled from the last expiry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		p := &Prefetcher{
+			Fetcher: mock_fetcher.NewMockFetcher(ctrl),
+			Lead:    time.Minute,
+		}
+		// The paths are not refreshed while one of them is valid beyond Lead.
+		p.Track(src, soon, append(pathsExpiringIn(soon, 10*time.Second),
+			pathsExpiringIn(soon, time.Hour)...))
+		p.Run(context.Background())
+	})
+	t.Run("idle destinations are dropped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		p := &Prefetcher{
+			Fetcher:     mock_fetcher.NewMockFetcher(ctrl),
+			Lead:        time.Minute,
+			IdleTimeout: time.Minute,
+		}
+		p.Track(src, soon, pathsExpiringIn(soon, 30*time.Second))
+		p.dsts[prefetchKey{src: src, dst: soon}].requested = time.Now().Add(-time.Hour)
+		p.Run(context.Background())
+		assert.Empty(t, p.dsts)
+	})
+	t.Run("least recently requested destination is evicted", func(t *testing.T) {
+		p := &Prefetcher{MaxDestinations: 2}
+		p.Track(src, soon, pathsExpiringIn(soon, time.Hour))
+		p.Track(src, sooner, pathsExpiringIn(sooner, time.Hour))
+		p.Track(src, soon, pathsExpiringIn(soon, time.Hour))
+		p.Track(src, later, pathsExpiringIn(later, time.Hour))
+		assert.Len(t, p.dsts, 2)
+		assert.NotContains(t, p.dsts, prefetchKey{src: src, dst: sooner})
+	})
+}
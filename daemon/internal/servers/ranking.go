@@ -0,0 +1,188 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// RankingWeights are the weights of the path properties in the score of a
+// path.
+type RankingWeights struct {
+	// Hops is the weight of the number of hops.
+	Hops float64
+	// Expiry is the weight of the remaining lifetime. Longer lived paths are
+	// preferred.
+	Expiry float64
+	// Loss is the weight of the loss rate reported by the applications.
+	Loss float64
+	// RTT is the weight of the round trip time reported by the applications.
+	RTT float64
+	// Latency is the weight of the latency in the static path metadata.
+	Latency float64
+	// Bandwidth is the weight of the bandwidth in the static path metadata.
+	// Paths with more bandwidth are preferred.
+	Bandwidth float64
+}
+
+// Ranker orders paths by a score that is the weighted sum of the properties of
+// the paths. Every property except the loss rate is normalized to the range
+// [0, 1] over the ranked paths; the loss rate is used as is. Paths for which a
+// property is unknown get the neutral value 0.5 for that property, and a loss
+// rate of 0. A lower score is better.
+type Ranker struct {
+	// Weights are the weights of the path properties.
+	Weights RankingWeights
+	// Health, if set, provides the loss rate and the round trip time of the
+	// paths.
+	Health *PathHealth
+}
+
+// Rank returns the paths sorted by increasing score. Paths with the same score
+// keep their relative order. The input slice is not modified, since it might be
+// shared with concurrent requests. Calling Rank on a nil ranker returns the
+// paths unchanged.
+func (r *Ranker) Rank(paths []snet.Path, now time.Time) []snet.Path {
+	if r == nil || len(paths) < 2 {
+		return paths
+	}
+	paths = append([]snet.Path(nil), paths...)
+	hops := make(rankingProperty, len(paths))
+	expiry := make(rankingProperty, len(paths))
+	loss := make(rankingProperty, len(paths))
+	rtt := make(rankingProperty, len(paths))
+	latency := make(rankingProperty, len(paths))
+	bandwidth := make(rankingProperty, len(paths))
+	for i, p := range paths {
+		meta := p.Metadata()
+		if meta == nil {
+			continue
+		}
+		hops[i] = known(float64(len(meta.Interfaces)))
+		// Negate the properties for which higher values are better.
+		expiry[i] = known(-meta.Expiry.Sub(now).Seconds())
+		latency[i] = totalLatency(meta)
+		bandwidth[i] = minBandwidth(meta)
+		if stats, ok := r.Health.Stats(snet.Fingerprint(p), now); ok {
+			loss[i] = known(stats.LossRate())
+			if stats.RTT > 0 {
+				rtt[i] = known(stats.RTT.Seconds())
+			}
+		}
+	}
+	hopsN, expiryN := hops.normalized(), expiry.normalized()
+	rttN, latencyN, bandwidthN := rtt.normalized(), latency.normalized(), bandwidth.normalized()
+	scores := make([]float64, len(paths))
+	for i := range paths {
+		scores[i] = r.Weights.Hops*hopsN[i] +
+			r.Weights.Expiry*expiryN[i] +
+			r.Weights.Loss*loss[i].value +
+			r.Weights.RTT*rttN[i] +
+			r.Weights.Latency*latencyN[i] +
+			r.Weights.Bandwidth*bandwidthN[i]
+	}
+	sort.Stable(rankedPaths{paths: paths, scores: scores})
+	return paths
+}
+
+type rankingValue struct {
+	value float64
+	known bool
+}
+
+func known(v float64) rankingValue {
+	return rankingValue{value: v, known: true}
+}
+
+type rankingProperty []rankingValue
+
+// normalized returns the values scaled to the range [0, 1]. Unknown values
+// are mapped to 0.5. If all known values are equal, they are mapped to 0.
+func (p rankingProperty) normalized() []float64 {
+	first := true
+	var lo, hi float64
+	for _, v := range p {
+		if !v.known {
+			continue
+		}
+		if first || v.value < lo {
+			lo = v.value
+		}
+		if first || v.value > hi {
+			hi = v.value
+		}
+		first = false
+	}
+	result := make([]float64, len(p))
+	for i, v := range p {
+		switch {
+		case !v.known:
+			result[i] = 0.5
+		case hi > lo:
+			result[i] = (v.value - lo) / (hi - lo)
+		}
+	}
+	return result
+}
+
+// totalLatency returns the sum of the latencies of the path. It is unknown if
+// the latency of any link is unknown.
+func totalLatency(meta *snet.PathMetadata) rankingValue {
+	if len(meta.Latency) == 0 {
+		return rankingValue{}
+	}
+	var total time.Duration
+	for _, l := range meta.Latency {
+		if l < 0 {
+			return rankingValue{}
+		}
+		total += l
+	}
+	return known(total.Seconds())
+}
+
+// minBandwidth returns the negated bottleneck bandwidth of the path, such that
+// lower values are better. It is unknown if the bandwidth of any link is
+// unknown.
+func minBandwidth(meta *snet.PathMetadata) rankingValue {
+	if len(meta.Bandwidth) == 0 {
+		return rankingValue{}
+	}
+	bottleneck := meta.Bandwidth[0]
+	for _, bw := range meta.Bandwidth {
+		if bw == 0 {
+			return rankingValue{}
+		}
+		if bw < bottleneck {
+			bottleneck = bw
+		}
+	}
+	return known(-float64(bottleneck))
+}
+
+type rankedPaths struct {
+	paths  []snet.Path
+	scores []float64
+}
+
+func (r rankedPaths) Len() int           { return len(r.paths) }
+func (r rankedPaths) Less(i, j int) bool { return r.scores[i] < r.scores[j] }
+func (r rankedPaths) Swap(i, j int) {
+	r.paths[i], r.paths[j] = r.paths[j], r.paths[i]
+	r.scores[i], r.scores[j] = r.scores[j], r.scores[i]
+}
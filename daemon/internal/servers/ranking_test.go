@@ -0,0 +1,120 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestRankerRank(t *testing.T) {
+	now := time.Now()
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	// newPath creates a path with the given number of hops. The first
+	// interface ID distinguishes paths with the same number of hops.
+	newPath := func(id, hops int, meta snet.PathMetadata) snet.Path {
+		meta.Interfaces = []snet.PathInterface{{IA: src, ID: common.IFIDType(id)}}
+		for i := 1; i < hops; i++ {
+			meta.Interfaces = append(meta.Interfaces,
+				snet.PathInterface{IA: addr.MustIAFrom(1, addr.AS(i)), ID: 1})
+		}
+		if meta.Expiry.IsZero() {
+			meta.Expiry = now.Add(time.Hour)
+		}
+		return snetpath.Path{Src: src, Dst: dst, Meta: meta}
+	}
+
+	t.Run("nil ranker", func(t *testing.T) {
+		paths := []snet.Path{newPath(1, 4, snet.PathMetadata{}), newPath(2, 2, snet.PathMetadata{})}
+		var r *Ranker
+		assert.Equal(t, paths, r.Rank(paths, now))
+	})
+	t.Run("hops", func(t *testing.T) {
+		long, short := newPath(1, 6, snet.PathMetadata{}), newPath(2, 2, snet.PathMetadata{})
+		paths := []snet.Path{long, short}
+		r := &Ranker{Weights: RankingWeights{Hops: 1}}
+		assert.Equal(t, []snet.Path{short, long}, r.Rank(paths, now))
+		// The input is not modified.
+		assert.Equal(t, []snet.Path{long, short}, paths)
+	})
+	t.Run("equal scores keep order", func(t *testing.T) {
+		a, b := newPath(1, 2, snet.PathMetadata{}), newPath(2, 2, snet.PathMetadata{})
+		r := &Ranker{Weights: RankingWeights{Hops: 1}}
+		assert.Equal(t, []snet.Path{a, b}, r.Rank([]snet.Path{a, b}, now))
+	})
+	t.Run("expiry", func(t *testing.T) {
+		soon := newPath(1, 2, snet.PathMetadata{Expiry: now.Add(time.Minute)})
+		late := newPath(2, 2, snet.PathMetadata{Expiry: now.Add(time.Hour)})
+		r := &Ranker{Weights: RankingWeights{Expiry: 1}}
+		assert.Equal(t, []snet.Path{late, soon}, r.Rank([]snet.Path{soon, late}, now))
+	})
+	t.Run("health", func(t *testing.T) {
+		lossy, healthy := newPath(1, 2, snet.PathMetadata{}), newPath(2, 4, snet.PathMetadata{})
+		health := &PathHealth{}
+		health.Report(snet.PathUsage{
+			Fingerprint: snet.Fingerprint(lossy),
+			PacketsSent: 10,
+			PacketsLost: 5,
+		}, now)
+		r := &Ranker{
+			Weights: RankingWeights{Hops: 1, Loss: 4},
+			Health:  health,
+		}
+		assert.Equal(t, []snet.Path{healthy, lossy}, r.Rank([]snet.Path{lossy, healthy}, now))
+	})
+	t.Run("rtt", func(t *testing.T) {
+		slow, fast := newPath(1, 2, snet.PathMetadata{}), newPath(2, 2, snet.PathMetadata{})
+		health := &PathHealth{}
+		health.Report(snet.PathUsage{
+			Fingerprint: snet.Fingerprint(slow),
+			RTTs:        []time.Duration{time.Second},
+		}, now)
+		health.Report(snet.PathUsage{
+			Fingerprint: snet.Fingerprint(fast),
+			RTTs:        []time.Duration{time.Millisecond},
+		}, now)
+		r := &Ranker{Weights: RankingWeights{RTT: 1}, Health: health}
+		assert.Equal(t, []snet.Path{fast, slow}, r.Rank([]snet.Path{slow, fast}, now))
+	})
+	t.Run("static metadata", func(t *testing.T) {
+		slow := newPath(1, 2, snet.PathMetadata{
+			Latency:   []time.Duration{50 * time.Millisecond},
+			Bandwidth: []uint64{100},
+		})
+		fast := newPath(2, 2, snet.PathMetadata{
+			Latency:   []time.Duration{5 * time.Millisecond},
+			Bandwidth: []uint64{1000},
+		})
+		unknown := newPath(3, 2, snet.PathMetadata{
+			Latency:   []time.Duration{snet.LatencyUnset},
+			Bandwidth: []uint64{0},
+		})
+		paths := []snet.Path{slow, unknown, fast}
+
+		r := &Ranker{Weights: RankingWeights{Latency: 1}}
+		assert.Equal(t, []snet.Path{fast, unknown, slow}, r.Rank(paths, now))
+		r = &Ranker{Weights: RankingWeights{Bandwidth: 1}}
+		assert.Equal(t, []snet.Path{fast, unknown, slow}, r.Rank(paths, now))
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"sort"
+
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/private/topology"
+)
+
+// LocalTopology serves the local topology request. It returns the local AS view of the
+// daemon, i.e., the border routers with their interfaces and the control
+// service instances.
+func (s *DaemonServer) LocalTopology(_ context.Context,
+	_ *sdpb.LocalTopologyRequest) (*sdpb.LocalTopologyResponse, error) {
+
+	topo := s.Topology
+	reply := &sdpb.LocalTopologyResponse{
+		IsdAs: uint64(s.IA),
+		Core:  topo.Core(),
+		Mtu:   uint32(s.MTU),
+	}
+	routers := make(map[string]*sdpb.BorderRouter)
+	for _, info := range topo.InterfaceInfoMap() {
+		br, ok := routers[info.BRName]
+		if !ok {
+			br = &sdpb.BorderRouter{Name: info.BRName}
+			if info.InternalAddr != nil {
+				br.InternalAddress = &sdpb.Underlay{Address: info.InternalAddr.String()}
+			}
+			routers[info.BRName] = br
+			reply.BorderRouters = append(reply.BorderRouters, br)
+		}
+		br.Interfaces = append(br.Interfaces, &sdpb.BorderRouterInterface{
+			Id:            uint64(info.ID),
+			NeighborIsdAs: uint64(info.IA),
+			NeighborId:    uint64(info.RemoteIFID),
+			Relation:      linkRelation(info.LinkType),
+			Mtu:           uint32(info.MTU),
+		})
+	}
+	sort.Slice(reply.BorderRouters, func(i, j int) bool {
+		return reply.BorderRouters[i].Name < reply.BorderRouters[j].Name
+	})
+	for _, br := range reply.BorderRouters {
+		ifs := br.Interfaces
+		sort.Slice(ifs, func(i, j int) bool { return ifs[i].Id < ifs[j].Id })
+	}
+	for _, name := range topo.ControlServiceNames() {
+		cs := &sdpb.ControlService{Name: name}
+		if a := topo.ControlServiceAddress(name); a != nil {
+			cs.Address = &sdpb.Underlay{Address: a.String()}
+		}
+		reply.ControlServices = append(reply.ControlServices, cs)
+	}
+	sort.Slice(reply.ControlServices, func(i, j int) bool {
+		return reply.ControlServices[i].Name < reply.ControlServices[j].Name
+	})
+	return reply, nil
+}
+
+func linkRelation(t topology.LinkType) sdpb.LinkRelation {
+	switch t {
+	case topology.Core:
+		return sdpb.LinkRelation_LINK_RELATION_CORE
+	case topology.Parent:
+		return sdpb.LinkRelation_LINK_RELATION_PARENT
+	case topology.Child:
+		return sdpb.LinkRelation_LINK_RELATION_CHILD
+	case topology.Peer:
+		return sdpb.LinkRelation_LINK_RELATION_PEER
+	default:
+		return sdpb.LinkRelation_LINK_RELATION_UNSPECIFIED
+	}
+}
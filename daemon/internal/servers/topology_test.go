@@ -0,0 +1,102 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/private/topology"
+)
+
+type fakeTopology struct {
+	Topology
+	core bool
+	ifs  map[common.IFIDType]topology.IFInfo
+	cs   map[string]*net.UDPAddr
+}
+
+func (t fakeTopology) Core() bool { return t.core }
+
+func (t fakeTopology) InterfaceInfoMap() map[common.IFIDType]topology.IFInfo { return t.ifs }
+
+func (t fakeTopology) ControlServiceNames() []string {
+	names := make([]string, 0, len(t.cs))
+	for name := range t.cs {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (t fakeTopology) ControlServiceAddress(name string) *net.UDPAddr { return t.cs[name] }
+
+func TestLocalTopology(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:110")
+	core := xtest.MustParseIA("1-ff00:0:120")
+	child := xtest.MustParseIA("1-ff00:0:111")
+	s := &DaemonServer{
+		IA:  local,
+		MTU: 1472,
+		Topology: fakeTopology{
+			core: true,
+			ifs: map[common.IFIDType]topology.IFInfo{
+				3: {ID: 3, BRName: "br2", IA: child, RemoteIFID: 13, LinkType: topology.Child,
+					MTU: 1400, InternalAddr: xtest.MustParseUDPAddr(t, "127.0.0.2:30042")},
+				2: {ID: 2, BRName: "br1", IA: child, RemoteIFID: 12, LinkType: topology.Child,
+					MTU: 1400, InternalAddr: xtest.MustParseUDPAddr(t, "127.0.0.1:30042")},
+				1: {ID: 1, BRName: "br1", IA: core, RemoteIFID: 11, LinkType: topology.Core,
+					MTU: 1472, InternalAddr: xtest.MustParseUDPAddr(t, "127.0.0.1:30042")},
+			},
+			cs: map[string]*net.UDPAddr{
+				"cs2": xtest.MustParseUDPAddr(t, "127.0.0.2:30252"),
+				"cs1": xtest.MustParseUDPAddr(t, "127.0.0.1:30252"),
+			},
+		},
+	}
+
+	reply, err := s.LocalTopology(context.Background(), &sdpb.LocalTopologyRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(local), reply.IsdAs)
+	assert.True(t, reply.Core)
+	assert.Equal(t, uint32(1472), reply.Mtu)
+
+	require.Len(t, reply.BorderRouters, 2)
+	br1, br2 := reply.BorderRouters[0], reply.BorderRouters[1]
+	assert.Equal(t, "br1", br1.Name)
+	assert.Equal(t, "127.0.0.1:30042", br1.InternalAddress.Address)
+	require.Len(t, br1.Interfaces, 2)
+	assert.Equal(t, uint64(1), br1.Interfaces[0].Id)
+	assert.Equal(t, uint64(core), br1.Interfaces[0].NeighborIsdAs)
+	assert.Equal(t, uint64(11), br1.Interfaces[0].NeighborId)
+	assert.Equal(t, sdpb.LinkRelation_LINK_RELATION_CORE, br1.Interfaces[0].Relation)
+	assert.Equal(t, uint64(2), br1.Interfaces[1].Id)
+	assert.Equal(t, sdpb.LinkRelation_LINK_RELATION_CHILD, br1.Interfaces[1].Relation)
+	assert.Equal(t, uint32(1400), br1.Interfaces[1].Mtu)
+	assert.Equal(t, "br2", br2.Name)
+	require.Len(t, br2.Interfaces, 1)
+	assert.Equal(t, uint64(3), br2.Interfaces[0].Id)
+
+	require.Len(t, reply.ControlServices, 2)
+	assert.Equal(t, "cs1", reply.ControlServices[0].Name)
+	assert.Equal(t, "127.0.0.1:30252", reply.ControlServices[0].Address.Address)
+	assert.Equal(t, "cs2", reply.ControlServices[1].Name)
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+const (
+	// DefaultWatchInterval is the default interval in which the path set of a
+	// watched destination is re-evaluated.
+	DefaultWatchInterval = 10 * time.Second
+	// minWatchInterval is the minimum time between two re-evaluations of the
+	// path set of a watched destination that are not triggered by a
+	// revocation. It prevents spinning if the paths have already expired.
+	minWatchInterval = time.Second
+	// watchFetchTimeout is the timeout for a single path lookup of a watch.
+	watchFetchTimeout = 10 * time.Second
+)
+
+// WatchPaths streams the paths to the requested destination. The current path
+// set is sent immediately. Afterwards, the path set is re-evaluated whenever a
+// revocation is received, a path expires, or the watch interval elapses, and
+// it is sent again if it changed.
+func (s *DaemonServer) WatchPaths(req *sdpb.WatchPathsRequest,
+	stream sdpb.DaemonService_WatchPathsServer) error {
+
+	ctx := stream.Context()
+	srcIA, dstIA := addr.IA(req.SourceIsdAs), addr.IA(req.DestinationIsdAs)
+	logger := log.FromCtx(ctx).New("src", srcIA, "dst", dstIA)
+	interval := s.WatchInterval
+	if interval == 0 {
+		interval = DefaultWatchInterval
+	}
+
+	var last []pathVersion
+	first := true
+	for {
+		// Get the update channel before fetching the paths, so that no
+		// revocation that happens during the fetch is missed.
+		updated := s.pathUpdates.wait()
+		fetchCtx, cancelF := context.WithTimeout(ctx, watchFetchTimeout)
+		paths, err := s.fetchPaths(fetchCtx, &s.foregroundPathDedupe, srcIA, dstIA, false)
+		cancelF()
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			logger.Debug("Fetching paths for watch", "err", err)
+		default:
+			current := pathVersions(paths)
+			if first || !equalPathVersions(last, current) {
+				reply := &sdpb.WatchPathsResponse{}
+				for _, p := range s.Ranker.Rank(paths, time.Now()) {
+					reply.Paths = append(reply.Paths, pathToPB(p))
+				}
+				if err := stream.Send(reply); err != nil {
+					return err
+				}
+				last, first = current, false
+			}
+		}
+
+		wait := interval
+		if next := nextExpiry(paths); !next.IsZero() && time.Until(next) < wait {
+			// Wake up shortly after the path expired.
+			wait = time.Until(next) + time.Second
+		}
+		if wait < minWatchInterval {
+			wait = minWatchInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-updated:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// pathVersion identifies a path and its expiration time.
+type pathVersion struct {
+	fingerprint snet.PathFingerprint
+	expiry      time.Time
+}
+
+func pathVersions(paths []snet.Path) []pathVersion {
+	result := make([]pathVersion, 0, len(paths))
+	for _, p := range paths {
+		v := pathVersion{fingerprint: snet.Fingerprint(p)}
+		if meta := p.Metadata(); meta != nil {
+			v.expiry = meta.Expiry
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+func equalPathVersions(a, b []pathVersion) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].fingerprint != b[i].fingerprint || !a[i].expiry.Equal(b[i].expiry) {
+			return false
+		}
+	}
+	return true
+}
+
+func nextExpiry(paths []snet.Path) time.Time {
+	var next time.Time
+	for _, p := range paths {
+		meta := p.Metadata()
+		if meta == nil || meta.Expiry.IsZero() {
+			continue
+		}
+		if next.IsZero() || meta.Expiry.Before(next) {
+			next = meta.Expiry
+		}
+	}
+	return next
+}
+
+// pathNotifier notifies the path watches that the path sets might have
+// changed. The zero value is ready to use.
+type pathNotifier struct {
+	mtx sync.Mutex
+	ch  chan struct{}
+}
+
+// wait returns a channel that is closed on the next notification.
+func (n *pathNotifier) wait() <-chan struct{} {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if n.ch == nil {
+		n.ch = make(chan struct{})
+	}
+	return n.ch
+}
+
+// notify wakes up all waiters.
+func (n *pathNotifier) notify() {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if n.ch != nil {
+		close(n.ch)
+		n.ch = nil
+	}
+}
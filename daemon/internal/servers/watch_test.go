@@ -0,0 +1,172 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/scionproto/scion/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies chan *sdpb.WatchPathsResponse
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(r *sdpb.WatchPathsResponse) error {
+	s.replies <- r
+	return nil
+}
+
+func TestWatchPaths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	newPath := func(ifID uint64) snet.Path {
+		return snetpath.Path{
+			Src: src,
+			Dst: dst,
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: src, ID: 1},
+					{IA: dst, ID: common.IFIDType(1 + ifID)},
+				},
+				Expiry: expiry,
+			},
+			NextHop:       &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30041},
+			DataplanePath: snetpath.SCION{Raw: []byte{byte(ifID)}},
+		}
+	}
+	p1, p2 := newPath(1), newPath(2)
+
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	gomock.InOrder(
+		f.EXPECT().GetPaths(gomock.Any(), src, dst, false).Return([]snet.Path{p1, p2}, nil),
+		f.EXPECT().GetPaths(gomock.Any(), src, dst, false).Return([]snet.Path{p1, p2}, nil),
+		f.EXPECT().GetPaths(gomock.Any(), src, dst, false).Return([]snet.Path{p1}, nil),
+		f.EXPECT().GetPaths(gomock.Any(), src, dst, false).Return([]snet.Path{p1}, nil).
+			AnyTimes(),
+	)
+	s := &DaemonServer{
+		Fetcher:       f,
+		WatchInterval: time.Hour,
+	}
+	ctx, cancelF := context.WithCancel(context.Background())
+	stream := &fakeWatchStream{
+		ctx:     ctx,
+		replies: make(chan *sdpb.WatchPathsResponse, 10),
+	}
+	done := make(chan error)
+	go func() {
+		done <- s.WatchPaths(&sdpb.WatchPathsRequest{
+			SourceIsdAs:      uint64(src),
+			DestinationIsdAs: uint64(dst),
+		}, stream)
+	}()
+
+	reply := <-stream.replies
+	assert.Len(t, reply.Paths, 2)
+
+	// The unchanged path set is not sent again.
+	s.pathUpdates.notify()
+	select {
+	case reply := <-stream.replies:
+		t.Fatalf("unexpected reply %v", reply)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	s.pathUpdates.notify()
+	reply = <-stream.replies
+	require.Len(t, reply.Paths, 1)
+	assert.Equal(t, []byte{1}, reply.Paths[0].Raw)
+
+	cancelF()
+	assert.NoError(t, <-done)
+}
+
+func TestWatchPathsExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	expired := snetpath.Path{
+		Src: src,
+		Dst: dst,
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{{IA: src, ID: 1}, {IA: dst, ID: 2}},
+			Expiry:     time.Now().Add(-time.Hour),
+		},
+		NextHop:       &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30041},
+		DataplanePath: snetpath.SCION{Raw: []byte{1}},
+	}
+
+	var lookups int32
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), src, dst, false).DoAndReturn(
+		func(context.Context, addr.IA, addr.IA, bool) ([]snet.Path, error) {
+			atomic.AddInt32(&lookups, 1)
+			return []snet.Path{expired}, nil
+		},
+	).AnyTimes()
+	s := &DaemonServer{
+		Fetcher:       f,
+		WatchInterval: time.Hour,
+	}
+	ctx, cancelF := context.WithCancel(context.Background())
+	stream := &fakeWatchStream{
+		ctx:     ctx,
+		replies: make(chan *sdpb.WatchPathsResponse, 10),
+	}
+	done := make(chan error)
+	go func() {
+		done <- s.WatchPaths(&sdpb.WatchPathsRequest{
+			SourceIsdAs:      uint64(src),
+			DestinationIsdAs: uint64(dst),
+		}, stream)
+	}()
+
+	<-stream.replies
+	// The path set is not re-evaluated before the minimum interval, even
+	// though the path has long expired.
+	time.Sleep(minWatchInterval / 2)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&lookups))
+
+	cancelF()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,280 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/resolver"
+
+	sd_colibri "github.com/scionproto/scion/daemon/colibri"
+	"github.com/scionproto/scion/daemon/config"
+	sd_drkey "github.com/scionproto/scion/daemon/drkey"
+	sd_grpc "github.com/scionproto/scion/daemon/drkey/grpc"
+	"github.com/scionproto/scion/daemon/fetcher"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	hpgrpc "github.com/scionproto/scion/pkg/experimental/hiddenpath/grpc"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/app"
+	"github.com/scionproto/scion/private/app/appnet"
+	"github.com/scionproto/scion/private/path/pathpol"
+	"github.com/scionproto/scion/private/periodic"
+	"github.com/scionproto/scion/private/revcache"
+	segfetchergrpc "github.com/scionproto/scion/private/segment/segfetcher/grpc"
+	infra "github.com/scionproto/scion/private/segment/verifier"
+	"github.com/scionproto/scion/private/storage"
+	"github.com/scionproto/scion/private/storage/drkey/level2"
+	"github.com/scionproto/scion/private/topology"
+	"github.com/scionproto/scion/private/trust"
+	"github.com/scionproto/scion/private/trust/compat"
+	trustmetrics "github.com/scionproto/scion/private/trust/metrics"
+)
+
+// ServerDeps are the resources that the daemon API server uses, but that are
+// owned by the caller.
+type ServerDeps struct {
+	Topology *topology.Loader
+	PathDB   storage.PathDB
+	TrustDB  storage.TrustDB
+	RevCache revcache.RevCache
+	// DisableMetrics disables the metrics of the server and of the path
+	// prefetcher and the DRKey database. The metrics are registered with the
+	// default prometheus registry, which is only possible once per process.
+	DisableMetrics bool
+}
+
+// NewServerConfig builds the configuration of the daemon API server from the
+// daemon configuration. It is used by both the daemon and the embedded daemon,
+// such that they behave the same for the same configuration.
+//
+// The periodic tasks that the server relies on, i.e., the path prefetcher, the
+// hidden path group syncer and the DRKey storage cleaners, are started. The
+// returned cleanup stops them and closes the DRKey database. It must be run
+// once the server has been stopped.
+func NewServerConfig(cfg *config.Config, deps ServerDeps) (ServerConfig, app.Cleanup, error) {
+	var cleanup app.Cleanup
+	serverCfg, err := newServerConfig(cfg, deps, &cleanup)
+	if err != nil {
+		// The error of the construction is more relevant than the one of the
+		// cleanup.
+		_ = cleanup.Do()
+		return ServerConfig{}, nil, err
+	}
+	return serverCfg, cleanup, nil
+}
+
+func newServerConfig(cfg *config.Config, deps ServerDeps,
+	cleanup *app.Cleanup) (ServerConfig, error) {
+
+	topo := deps.Topology
+	dialer := &libgrpc.TCPDialer{
+		SvcResolver: func(dst addr.SVC) []resolver.Address {
+			if base := dst.Base(); base != addr.SvcCS {
+				panic("Unsupported address type, implementation error?")
+			}
+			targets := []resolver.Address{}
+			for _, entry := range topo.ControlServiceAddresses() {
+				targets = append(targets, resolver.Address{Addr: entry.String()})
+			}
+			return targets
+		},
+	}
+	if !cfg.SD.DisableCSFailover {
+		dialer.Health = &libgrpc.InstanceHealth{
+			FailureMemory: cfg.SD.CSFailureMemory.Duration,
+		}
+	}
+	intraASTLS := appnet.IntraASTLS{
+		Config:      cfg.GRPCTLS,
+		IA:          topo.IA(),
+		TLSVerifier: trust.NewTLSCryptoVerifier(deps.TrustDB),
+	}
+	var err error
+	if dialer.Credentials, err = intraASTLS.ClientCredentials(); err != nil {
+		return ServerConfig{}, serrors.WrapStr(
+			"initializing TLS for control service connections", err)
+	}
+
+	engine, err := TrustEngine(cfg.General.ConfigDir, topo.IA(), deps.TrustDB, dialer)
+	if err != nil {
+		return ServerConfig{}, serrors.WrapStr("creating trust engine", err)
+	}
+	engine.Inspector = trust.CachingInspector{
+		Inspector:          engine.Inspector,
+		Cache:              cfg.TrustEngine.Cache.New(),
+		CacheHits:          metrics.NewPromCounter(trustmetrics.CacheHitsTotal),
+		MaxCacheExpiration: cfg.TrustEngine.Cache.Expiration.Duration,
+	}
+
+	drkeyClientEngine, err := newDRKeyClientEngine(cfg, deps, dialer, cleanup)
+	if err != nil {
+		return ServerConfig{}, err
+	}
+
+	hpGroups, err := hiddenpath.LoadHiddenPathGroups(cfg.SD.HiddenPathGroups)
+	if err != nil {
+		return ServerConfig{}, serrors.WrapStr("loading hidden path groups", err)
+	}
+	// The hidden segment requester is always installed, such that hidden
+	// segments can be requested for explicit groups even if no groups are
+	// configured locally.
+	learnedHPGroups := &hiddenpath.LearnedGroups{}
+	requester := &hpgrpc.Requester{
+		RegularLookup: &segfetchergrpc.Requester{
+			Dialer:      dialer,
+			PageSize:    cfg.SD.SegmentPageSize,
+			Compression: cfg.SD.SegmentLookupCompression,
+			Filter:      cfg.SD.SegmentsFilter(),
+		},
+		HPGroups:      hpGroups,
+		LearnedGroups: learnedHPGroups,
+		Dialer:        dialer,
+	}
+	if !cfg.SD.DisableHiddenPathGroupSync {
+		hpGroupSyncer := periodic.Start(
+			hiddenpath.GroupSyncer{
+				Fetcher: hpgrpc.GroupFetcher{Dialer: dialer},
+				Server:  &snet.SVCAddr{SVC: addr.SvcCS},
+				Groups:  learnedHPGroups,
+			},
+			cfg.SD.HiddenPathGroupSyncInterval.Duration,
+			10*time.Second,
+		)
+		cleanup.Add(func() error { hpGroupSyncer.Stop(); return nil })
+	}
+
+	var verifier infra.Verifier = AcceptAllVerifier{}
+	if !cfg.SD.DisableSegVerification {
+		verifier = compat.Verifier{Verifier: trust.Verifier{
+			Engine:             engine,
+			Cache:              cfg.TrustEngine.Cache.New(),
+			CacheHits:          metrics.NewPromCounter(trustmetrics.CacheHitsTotal),
+			MaxCacheExpiration: cfg.TrustEngine.Cache.Expiration.Duration,
+		}}
+	}
+	pathFetcher := fetcher.NewFetcher(
+		fetcher.FetcherConfig{
+			IA:         topo.IA(),
+			MTU:        topo.MTU(),
+			Core:       topo.Core(),
+			NextHopper: topo,
+			RPC:        requester,
+			PathDB:     deps.PathDB,
+			Inspector:  engine,
+			Verifier:   verifier,
+			RevCache:   deps.RevCache,
+			Cfg:        cfg.SD,
+		},
+	)
+	serverCfg := ServerConfig{
+		IA:              topo.IA(),
+		MTU:             topo.MTU(),
+		Topology:        topo,
+		Fetcher:         pathFetcher,
+		Engine:          engine,
+		RevCache:        deps.RevCache,
+		DRKeyClient:     drkeyClientEngine,
+		Colibri:         &sd_colibri.Client{Dialer: dialer},
+		Geofence:        cfg.SD.Geofence,
+		HPGroups:        hpGroups,
+		LearnedHPGroups: learnedHPGroups,
+		DisableMetrics:  deps.DisableMetrics,
+	}
+	if !cfg.SD.DisableHiddenPathCache {
+		serverCfg.HiddenPathCacheTTL = cfg.SD.HiddenPathCacheTTL.Duration
+	}
+	if !cfg.PathRanking.Disable {
+		serverCfg.Ranking = &RankingWeights{
+			Hops:      cfg.PathRanking.Hops,
+			Expiry:    cfg.PathRanking.Expiry,
+			Loss:      cfg.PathRanking.Loss,
+			RTT:       cfg.PathRanking.RTT,
+			Latency:   cfg.PathRanking.Latency,
+			Bandwidth: cfg.PathRanking.Bandwidth,
+		}
+	}
+	if cfg.SD.PathPolicies != "" {
+		serverCfg.PathPolicies, err = pathpol.LoadPolicyMap(cfg.SD.PathPolicies)
+		if err != nil {
+			return ServerConfig{}, serrors.WrapStr("loading path policies", err)
+		}
+	}
+	if !cfg.SD.DisablePrefetch {
+		serverCfg.Prefetcher = NewPrefetcher(PrefetcherConfig{
+			Fetcher:         pathFetcher,
+			MaxDestinations: cfg.SD.PrefetchDestinations,
+			Budget:          cfg.SD.PrefetchBudget,
+			Lead:            cfg.SD.PrefetchLead.Duration,
+			IdleTimeout:     cfg.SD.PrefetchIdleTimeout.Duration,
+			DisableMetrics:  deps.DisableMetrics,
+		})
+		prefetchTask := periodic.Start(serverCfg.Prefetcher, 10*time.Second, 10*time.Second)
+		cleanup.Add(func() error { prefetchTask.Stop(); return nil })
+	}
+	return serverCfg, nil
+}
+
+// newDRKeyClientEngine creates the DRKey client engine if the level 2 DRKey
+// database is configured. Otherwise, nil is returned.
+func newDRKeyClientEngine(cfg *config.Config, deps ServerDeps, dialer libgrpc.Dialer,
+	cleanup *app.Cleanup) (*sd_drkey.ClientEngine, error) {
+
+	if cfg.DRKeyLevel2DB.Connection == "" {
+		return nil, nil
+	}
+	backend, err := storage.NewDRKeyLevel2Storage(cfg.DRKeyLevel2DB)
+	if err != nil {
+		return nil, serrors.WrapStr("creating level2 DRKey DB", err)
+	}
+	level2DB := &level2.Database{Backend: backend}
+	if !deps.DisableMetrics {
+		counter := metrics.NewPromCounter(
+			promauto.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "drkey_level2db_queries_total",
+					Help: "Total queries to the database",
+				},
+				[]string{"operation", prom.LabelResult},
+			),
+		)
+		level2DB.Metrics = &level2.Metrics{
+			QueriesTotal: func(op, label string) metrics.Counter {
+				return metrics.CounterWith(
+					counter,
+					"operation", op,
+					prom.LabelResult, label,
+				)
+			},
+		}
+	}
+	drkeyClientEngine := &sd_drkey.ClientEngine{
+		IA:      deps.Topology.IA(),
+		DB:      level2DB,
+		Fetcher: &sd_grpc.Fetcher{Dialer: dialer},
+	}
+	for _, cleaner := range drkeyClientEngine.CreateStorageCleaners() {
+		cleanerTask := periodic.Start(cleaner, 5*time.Minute, 5*time.Minute)
+		cleanup.Add(func() error { cleanerTask.Stop(); return nil })
+	}
+	cleanup.Add(level2DB.Close)
+	return drkeyClientEngine, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/daemon"
+	"github.com/scionproto/scion/daemon/config"
+	"github.com/scionproto/scion/private/storage"
+	"github.com/scionproto/scion/private/topology"
+)
+
+const topo = `{
+  "isd_as": "1-ff00:0:110",
+  "mtu": 1400,
+  "attributes": ["core"],
+  "control_service": {
+    "cs1": {"addr": "127.0.0.2:31000"}
+  }
+}`
+
+func TestNewServerConfig(t *testing.T) {
+	dir := t.TempDir()
+	topoFile := filepath.Join(dir, "topology.json")
+	require.NoError(t, os.WriteFile(topoFile, []byte(topo), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "certs"), 0755))
+	loader, err := topology.NewLoader(topology.LoaderCfg{
+		File:      topoFile,
+		Validator: &topology.DefaultValidator{},
+	})
+	require.NoError(t, err)
+
+	newDeps := func(t *testing.T) daemon.ServerDeps {
+		pathDB, err := storage.NewPathStorage(storage.DBConfig{Connection: "file::memory:"})
+		require.NoError(t, err)
+		t.Cleanup(func() { pathDB.Close() })
+		trustDB, err := storage.NewTrustStorage(storage.DBConfig{Connection: "file::memory:"})
+		require.NoError(t, err)
+		t.Cleanup(func() { trustDB.Close() })
+		return daemon.ServerDeps{
+			Topology:       loader,
+			PathDB:         pathDB,
+			TrustDB:        trustDB,
+			RevCache:       storage.NewRevocationStorage(),
+			DisableMetrics: true,
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		var cfg config.Config
+		cfg.InitDefaults()
+		cfg.General.ConfigDir = dir
+
+		serverCfg, cleanup, err := daemon.NewServerConfig(&cfg, newDeps(t))
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, cleanup.Do()) }()
+		assert.NotNil(t, serverCfg.Ranking)
+		assert.NotNil(t, serverCfg.Prefetcher)
+		assert.NotNil(t, serverCfg.LearnedHPGroups)
+		assert.NotNil(t, serverCfg.Colibri)
+		assert.Nil(t, serverCfg.DRKeyClient)
+		assert.True(t, serverCfg.DisableMetrics)
+	})
+	t.Run("configured sections", func(t *testing.T) {
+		var cfg config.Config
+		cfg.InitDefaults()
+		cfg.General.ConfigDir = dir
+		cfg.PathRanking.Disable = true
+		cfg.SD.DisablePrefetch = true
+		cfg.SD.DisableHiddenPathCache = true
+		cfg.DRKeyLevel2DB.Connection = filepath.Join(t.TempDir(), "drkey.db")
+
+		serverCfg, cleanup, err := daemon.NewServerConfig(&cfg, newDeps(t))
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, cleanup.Do()) }()
+		assert.Nil(t, serverCfg.Ranking)
+		assert.Nil(t, serverCfg.Prefetcher)
+		assert.Zero(t, serverCfg.HiddenPathCacheTTL)
+		assert.NotNil(t, serverCfg.DRKeyClient)
+	})
+	t.Run("invalid path policies", func(t *testing.T) {
+		var cfg config.Config
+		cfg.InitDefaults()
+		cfg.General.ConfigDir = dir
+		cfg.SD.PathPolicies = filepath.Join(dir, "missing.yml")
+
+		_, _, err := daemon.NewServerConfig(&cfg, newDeps(t))
+		assert.Error(t, err)
+	})
+}
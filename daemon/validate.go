@@ -0,0 +1,56 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"github.com/scionproto/scion/daemon/config"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/path/pathpol"
+	"github.com/scionproto/scion/private/topology"
+)
+
+// ValidateConfig loads and cross-validates the files referenced by the daemon
+// configuration, i.e., the topology, the hidden path groups and the path
+// policies. It also checks that the geofence does not exclude the local AS,
+// since no path would be returned otherwise. All errors are reported at once
+// in a serrors.List.
+func ValidateConfig(cfg *config.Config) error {
+	var errs serrors.List
+	file := cfg.General.Topology()
+	topo, err := topology.RWTopologyFromJSONFile(file)
+	if err != nil {
+		errs = append(errs, serrors.WrapStr("loading topology", err, "file", file))
+	} else if err := (&topology.DefaultValidator{}).Validate(topo, nil); err != nil {
+		errs = append(errs, serrors.WrapStr("validating topology", err, "file", file))
+	} else {
+		local := topo.IA
+		for _, ia := range cfg.SD.Geofence {
+			if ia == local || (ia.AS() == 0 && ia.ISD() == local.ISD()) {
+				errs = append(errs, serrors.New("geofence excludes the local AS",
+					"local_isd_as", local, "geofence_entry", ia))
+			}
+		}
+	}
+	if _, err := hiddenpath.LoadHiddenPathGroups(cfg.SD.HiddenPathGroups); err != nil {
+		errs = append(errs, serrors.WrapStr("loading hidden path groups", err))
+	}
+	if cfg.SD.PathPolicies != "" {
+		if _, err := pathpol.LoadPolicyMap(cfg.SD.PathPolicies); err != nil {
+			errs = append(errs, serrors.WrapStr("loading path policies", err))
+		}
+	}
+	return errs.ToError()
+}
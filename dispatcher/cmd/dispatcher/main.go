@@ -28,6 +28,7 @@ import (
 	"github.com/go-chi/cors"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/scionproto/scion/dispatcher"
 	"github.com/scionproto/scion/dispatcher/config"
 	api "github.com/scionproto/scion/dispatcher/mgmtapi"
 	"github.com/scionproto/scion/dispatcher/network"
@@ -37,6 +38,7 @@ import (
 	"github.com/scionproto/scion/pkg/slayers/path"
 	"github.com/scionproto/scion/private/app"
 	"github.com/scionproto/scion/private/app/launcher"
+	"github.com/scionproto/scion/private/ratelimit"
 	"github.com/scionproto/scion/private/service"
 )
 
@@ -59,18 +61,67 @@ func realMain(ctx context.Context) error {
 
 	path.StrictDecoding(false)
 
+	var nat *dispatcher.NAT
+	if len(globalCfg.Dispatcher.NATRouters) > 0 {
+		nat = &dispatcher.NAT{
+			Interval: globalCfg.Dispatcher.NATKeepaliveInterval.Duration,
+		}
+		for _, r := range globalCfg.Dispatcher.NATRouters {
+			router, err := net.ResolveUDPAddr("udp", r)
+			if err != nil {
+				return serrors.WrapStr("parsing NAT router address", err, "router", r)
+			}
+			nat.Routers = append(nat.Routers, router)
+		}
+	}
+
+	var handoff *network.Handoff
+	if globalCfg.Dispatcher.HandoffSocket != "" {
+		var err error
+		if handoff, err = network.TakeOver(globalCfg.Dispatcher.HandoffSocket); err != nil {
+			return serrors.WrapStr("taking over from running dispatcher", err)
+		}
+	}
+	ready := make(chan struct{})
+	disp := &network.Dispatcher{
+		UnderlaySocket:    fmt.Sprintf(":%d", globalCfg.Dispatcher.UnderlayPort),
+		ApplicationSocket: globalCfg.Dispatcher.ApplicationSocket,
+		SocketFileMode:    os.FileMode(globalCfg.Dispatcher.SocketFileMode),
+		Workers:           globalCfg.Dispatcher.Workers,
+		NAT:               nat,
+		SCMPErrorLimiter: &ratelimit.Limiter{
+			Rate:  globalCfg.Dispatcher.SCMPErrorRatePerAS,
+			Burst: globalCfg.Dispatcher.SCMPErrorBurst,
+		},
+		Ready:         ready,
+		HandoffSocket: globalCfg.Dispatcher.HandoffSocket,
+		Handoff:       handoff,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	var cleanup app.Cleanup
 	g, errCtx := errgroup.WithContext(ctx)
 	g.Go(func() error {
 		defer log.HandlePanic()
-		return RunDispatcher(
-			globalCfg.Dispatcher.DeleteSocket,
-			globalCfg.Dispatcher.ApplicationSocket,
-			os.FileMode(globalCfg.Dispatcher.SocketFileMode),
-			globalCfg.Dispatcher.UnderlayPort,
-		)
+		if err := RunDispatcher(errCtx, globalCfg.Dispatcher.DeleteSocket, disp); err != nil {
+			return err
+		}
+		if disp.HandedOff() {
+			log.Info("Handed off to new dispatcher instance, shutting down")
+			cancel()
+		}
+		return nil
 	})
 
+	// The previous dispatcher instance still serves its HTTP endpoints until
+	// it terminates.
+	if handoff != nil {
+		if err := handoff.Wait(errCtx); err != nil {
+			return g.Wait()
+		}
+	}
+
 	// Initialise and start service management API endpoints.
 	if globalCfg.API.Addr != "" {
 		r := chi.NewRouter()
@@ -107,6 +158,16 @@ func realMain(ctx context.Context) error {
 		"config":    service.NewConfigStatusPage(globalCfg),
 		"log/level": service.NewLogLevelStatusPage(),
 	}
+	readiness := &service.Health{}
+	readiness.AddCheck("sockets", func(context.Context) error {
+		select {
+		case <-ready:
+			return nil
+		default:
+			return serrors.New("sockets not open")
+		}
+	})
+	readiness.AddStatusPages(statusPages)
 	if err := statusPages.Register(http.DefaultServeMux, globalCfg.Dispatcher.ID); err != nil {
 		return serrors.WrapStr("registering status pages", err)
 	}
@@ -117,6 +178,12 @@ func realMain(ctx context.Context) error {
 	})
 
 	defer func() {
+		// The application socket is used by the new dispatcher instance after
+		// a handoff.
+		if disp.HandedOff() {
+			disp.ReleaseHandoff()
+			return
+		}
 		if err := deleteSocket(globalCfg.Dispatcher.ApplicationSocket); err != nil {
 			log.Error("deleting socket", "err", err)
 		}
@@ -128,31 +195,28 @@ func realMain(ctx context.Context) error {
 		return cleanup.Do()
 	})
 
-	// XXX(lukedirtwalker): unfortunately the dispatcher can't be signalled to
-	// be stopped, so we just exit manually if the context is done.
-	select {
-	case <-ctx.Done():
-		return nil
-	case <-errCtx.Done():
-		return g.Wait()
-	}
+	return g.Wait()
 }
 
-func RunDispatcher(deleteSocketFlag bool, applicationSocket string, socketFileMode os.FileMode,
-	underlayPort int) error {
-
-	if deleteSocketFlag {
-		if err := deleteSocket(globalCfg.Dispatcher.ApplicationSocket); err != nil {
+// RunDispatcher runs the dispatcher until ctx is done. The connections of the
+// applications are closed on shutdown, such that they reconnect to the next
+// dispatcher instance.
+func RunDispatcher(ctx context.Context, deleteSocketFlag bool, d *network.Dispatcher) error {
+	// The application socket of the previous instance is inherited on a
+	// handoff, it must not be deleted.
+	if deleteSocketFlag && d.Handoff == nil {
+		if err := deleteSocket(d.ApplicationSocket); err != nil {
 			return err
 		}
 	}
-	dispatcher := &network.Dispatcher{
-		UnderlaySocket:    fmt.Sprintf(":%d", underlayPort),
-		ApplicationSocket: applicationSocket,
-		SocketFileMode:    socketFileMode,
-	}
-	log.Debug("Dispatcher starting", "appSocket", applicationSocket, "underlayPort", underlayPort)
-	return dispatcher.ListenAndServe()
+	log.Debug("Dispatcher starting", "appSocket", d.ApplicationSocket,
+		"underlay", d.UnderlaySocket, "workers", d.Workers, "handoff", d.Handoff != nil)
+	go func() {
+		defer log.HandlePanic()
+		<-ctx.Done()
+		d.Close()
+	}()
+	return d.ListenAndServe()
 }
 
 func deleteSocket(socket string) error {
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/dispatcher/network"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/pkg/snet"
@@ -422,8 +423,11 @@ func TestDataplaneIntegration(t *testing.T) {
 	settings := InitTestSettings(t, dispatcherTestPort)
 
 	go func() {
-		err := RunDispatcher(false, settings.ApplicationSocket, reliable.DefaultDispSocketFileMode,
-			settings.UnderlayPort)
+		err := RunDispatcher(context.Background(), false, &network.Dispatcher{
+			UnderlaySocket:    fmt.Sprintf(":%d", settings.UnderlayPort),
+			ApplicationSocket: settings.ApplicationSocket,
+			SocketFileMode:    reliable.DefaultDispSocketFileMode,
+		})
 		require.NoError(t, err, "dispatcher error")
 	}()
 	time.Sleep(defaultWaitDuration)
@@ -437,6 +441,100 @@ func TestDataplaneIntegration(t *testing.T) {
 	}
 }
 
+func TestHandoff(t *testing.T) {
+	dispatcherTestPort := 40033
+	settings := InitTestSettings(t, dispatcherTestPort)
+	handoffSocket := filepath.Join(filepath.Dir(settings.ApplicationSocket), "handoff.sock")
+	newDispatcher := func(handoff *network.Handoff) *network.Dispatcher {
+		return &network.Dispatcher{
+			UnderlaySocket:    fmt.Sprintf(":%d", settings.UnderlayPort),
+			ApplicationSocket: settings.ApplicationSocket,
+			SocketFileMode:    reliable.DefaultDispSocketFileMode,
+			HandoffSocket:     handoffSocket,
+			Handoff:           handoff,
+			Ready:             make(chan struct{}),
+		}
+	}
+
+	oldDisp := newDispatcher(nil)
+	oldErr := make(chan error, 1)
+	go func() {
+		oldErr <- RunDispatcher(context.Background(), true, oldDisp)
+	}()
+	select {
+	case <-oldDisp.Ready:
+	case err := <-oldErr:
+		t.Fatal(err)
+	}
+	info, err := os.Stat(handoffSocket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	tc := genTestCases(dispatcherTestPort)[0]
+	ctx, cancelF := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancelF()
+	conn, _, err := reliable.NewDispatcher(settings.ApplicationSocket).Register(
+		ctx,
+		tc.ClientAddress.IA,
+		&net.UDPAddr{
+			IP:   tc.ClientAddress.PublicAddress.AsSlice(),
+			Port: int(tc.ClientAddress.PublicPort),
+		},
+		tc.ClientAddress.ServiceAddress,
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	handoff, err := network.TakeOver(handoffSocket)
+	require.NoError(t, err)
+	require.NotNil(t, handoff)
+	select {
+	case err := <-oldErr:
+		require.NoError(t, err)
+	case <-time.After(defaultTimeout):
+		t.Fatal("old dispatcher did not stop")
+	}
+	assert.True(t, oldDisp.HandedOff())
+
+	newDisp := newDispatcher(handoff)
+	newCtx, newCancel := context.WithCancel(context.Background())
+	defer newCancel()
+	newErr := make(chan error, 1)
+	go func() {
+		newErr <- RunDispatcher(newCtx, true, newDisp)
+	}()
+	select {
+	case <-newDisp.Ready:
+	case err := <-newErr:
+		t.Fatal(err)
+	}
+	defer func() {
+		newCancel()
+		assert.NoError(t, <-newErr)
+	}()
+	oldDisp.ReleaseHandoff()
+	require.NoError(t, handoff.Wait(ctx))
+
+	// The registration of the application is served by the new instance.
+	for _, packet := range tc.TestPackets {
+		require.NoError(t, packet.Serialize())
+		_, err = conn.WriteTo(packet.Bytes, tc.UnderlayAddress)
+		require.NoError(t, err)
+	}
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(defaultTimeout)))
+	rcvPkt := snet.Packet{}
+	rcvPkt.Prepare()
+	n, _, err := conn.ReadFrom(rcvPkt.Bytes)
+	require.NoError(t, err)
+	rcvPkt.Bytes = rcvPkt.Bytes[:n]
+	require.NoError(t, rcvPkt.Decode())
+	assert.Equal(t, tc.ExpectedPacket.PacketInfo, rcvPkt.PacketInfo)
+
+	// The new instance serves new handoffs itself.
+	_, err = os.Stat(handoffSocket)
+	assert.NoError(t, err)
+}
+
 func RunTestCase(t *testing.T, tc *TestCase, settings *TestSettings) {
 	dispatcherService := reliable.NewDispatcher(settings.ApplicationSocket)
 	ctx, cancelF := context.WithTimeout(context.Background(), defaultTimeout)
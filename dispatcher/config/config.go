@@ -19,6 +19,8 @@ package config
 import (
 	"fmt"
 	"io"
+	"net"
+	"runtime"
 
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -54,6 +56,27 @@ type Dispatcher struct {
 	// DeleteSocket specifies whether the dispatcher should delete the
 	// socket file prior to attempting to create a new one.
 	DeleteSocket bool `toml:"delete_socket,omitempty"`
+	// Workers is the number of workers serving the underlay port per address
+	// family (default 1)
+	Workers int `toml:"workers,omitempty"`
+	// NATRouters are the internal underlay addresses of the border routers
+	// that the STUN binding requests are sent to if the end host is behind a
+	// NAT. If empty, the NAT traversal is disabled.
+	NATRouters []string `toml:"nat_routers,omitempty"`
+	// NATKeepaliveInterval is the interval in which the STUN binding requests
+	// are sent (default 15s)
+	NATKeepaliveInterval util.DurWrap `toml:"nat_keepalive_interval,omitempty"`
+	// SCMPErrorRatePerAS is the number of SCMP error messages per second from
+	// a single source AS that are delivered to the applications. If zero, the
+	// rate is not limited.
+	SCMPErrorRatePerAS float64 `toml:"scmp_error_rate_per_as,omitempty"`
+	// SCMPErrorBurst is the number of SCMP error messages that can be
+	// delivered in a burst above the rate limit (default 10)
+	SCMPErrorBurst int `toml:"scmp_error_burst,omitempty"`
+	// HandoffSocket is the unix socket on which a running dispatcher hands off
+	// its sockets and the registrations of the applications to a new
+	// dispatcher instance. If empty, the handoff is disabled.
+	HandoffSocket string `toml:"handoff_socket,omitempty"`
 }
 
 func (cfg *Dispatcher) Validate() error {
@@ -66,14 +89,57 @@ func (cfg *Dispatcher) Validate() error {
 	if cfg.UnderlayPort == 0 {
 		cfg.UnderlayPort = topology.EndhostPort
 	}
+	if cfg.Workers == 0 {
+		cfg.Workers = 1
+	}
+	if cfg.Workers < 0 {
+		return serrors.New("workers must not be negative", "workers", cfg.Workers)
+	}
+	if cfg.NATKeepaliveInterval.Duration < 0 {
+		return serrors.New("nat_keepalive_interval must not be negative",
+			"nat_keepalive_interval", cfg.NATKeepaliveInterval)
+	}
+	if cfg.SCMPErrorRatePerAS < 0 {
+		return serrors.New("scmp_error_rate_per_as must not be negative",
+			"scmp_error_rate_per_as", cfg.SCMPErrorRatePerAS)
+	}
+	if cfg.SCMPErrorBurst == 0 {
+		cfg.SCMPErrorBurst = 10
+	}
+	if cfg.SCMPErrorBurst < 0 {
+		return serrors.New("scmp_error_burst must not be negative",
+			"scmp_error_burst", cfg.SCMPErrorBurst)
+	}
+	for _, router := range cfg.NATRouters {
+		if _, err := net.ResolveUDPAddr("udp", router); err != nil {
+			return serrors.WrapStr("parsing nat_routers", err, "nat_router", router)
+		}
+	}
 	if cfg.ID == "" {
 		return serrors.New("id must be set")
 	}
+	return cfg.validatePlatform(runtime.GOOS)
+}
+
+// validatePlatform checks that the configured features are supported on the
+// given operating system.
+func (cfg *Dispatcher) validatePlatform(goos string) error {
+	// The kernel only distributes the packets among the sockets of the
+	// workers on linux.
+	if cfg.Workers > 1 && goos != "linux" {
+		return serrors.New("multiple workers are only supported on linux",
+			"workers", cfg.Workers, "os", goos)
+	}
+	// The handoff requires SOCK_SEQPACKET unix sockets, which are not
+	// available on macOS and windows.
+	if cfg.HandoffSocket != "" && (goos == "darwin" || goos == "windows") {
+		return serrors.New("handoff_socket is not supported on this platform", "os", goos)
+	}
 	return nil
 }
 
 func (cfg *Dispatcher) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
-	config.WriteString(dst, fmt.Sprintf(dispSample, idSample))
+	config.WriteString(dst, fmt.Sprintf(dispSample, idSample, reliable.DefaultDispPath))
 }
 
 func (cfg *Dispatcher) ConfigName() string {
@@ -18,12 +18,14 @@ package config
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/pelletier/go-toml"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/pkg/log/logtest"
 	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/config/configtest"
 	"github.com/scionproto/scion/private/env/envtest"
 	apitest "github.com/scionproto/scion/private/mgmtapi/mgmtapitest"
 	"github.com/scionproto/scion/private/topology"
@@ -38,6 +40,58 @@ func TestConfigSample(t *testing.T) {
 	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
 	assert.NoError(t, err)
 	CheckTestConfig(t, &cfg, idSample)
+	configtest.CheckSampleCoverage(t, &cfg)
+}
+
+func TestValidatePlatform(t *testing.T) {
+	testCases := map[string]struct {
+		Cfg       Dispatcher
+		GOOS      string
+		AssertErr assert.ErrorAssertionFunc
+	}{
+		"workers linux": {
+			Cfg:       Dispatcher{Workers: 4, HandoffSocket: "/tmp/handoff.sock"},
+			GOOS:      "linux",
+			AssertErr: assert.NoError,
+		},
+		"single worker darwin": {
+			Cfg:       Dispatcher{Workers: 1},
+			GOOS:      "darwin",
+			AssertErr: assert.NoError,
+		},
+		"handoff darwin": {
+			Cfg:       Dispatcher{Workers: 1, HandoffSocket: "/tmp/handoff.sock"},
+			GOOS:      "darwin",
+			AssertErr: assert.Error,
+		},
+		"handoff freebsd": {
+			Cfg:       Dispatcher{Workers: 1, HandoffSocket: "/tmp/handoff.sock"},
+			GOOS:      "freebsd",
+			AssertErr: assert.NoError,
+		},
+		"workers darwin": {
+			Cfg:       Dispatcher{Workers: 4},
+			GOOS:      "darwin",
+			AssertErr: assert.Error,
+		},
+		"single worker windows": {
+			Cfg:       Dispatcher{Workers: 1},
+			GOOS:      "windows",
+			AssertErr: assert.NoError,
+		},
+		"handoff windows": {
+			Cfg:       Dispatcher{Workers: 1, HandoffSocket: "handoff.sock"},
+			GOOS:      "windows",
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tc.AssertErr(t, tc.Cfg.validatePlatform(tc.GOOS))
+		})
+	}
 }
 
 func InitTestConfig(cfg *Config) {
@@ -45,6 +99,9 @@ func InitTestConfig(cfg *Config) {
 	envtest.InitTest(nil, &cfg.Metrics, nil, nil)
 	logtest.InitTestLogging(&cfg.Logging)
 	cfg.Dispatcher.DeleteSocket = true
+	cfg.Dispatcher.Workers = 4
+	cfg.Dispatcher.NATRouters = []string{"10.0.0.1:30042"}
+	cfg.Dispatcher.HandoffSocket = "/tmp/handoff.sock"
 }
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
@@ -56,4 +113,10 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	assert.Equal(t, reliable.DefaultDispSocketFileMode, int(cfg.Dispatcher.SocketFileMode))
 	assert.Equal(t, topology.EndhostPort, cfg.Dispatcher.UnderlayPort)
 	assert.False(t, cfg.Dispatcher.DeleteSocket)
+	assert.Equal(t, 1, cfg.Dispatcher.Workers)
+	assert.Empty(t, cfg.Dispatcher.NATRouters)
+	assert.Equal(t, 15*time.Second, cfg.Dispatcher.NATKeepaliveInterval.Duration)
+	assert.Zero(t, cfg.Dispatcher.SCMPErrorRatePerAS)
+	assert.Equal(t, 10, cfg.Dispatcher.SCMPErrorBurst)
+	assert.Empty(t, cfg.Dispatcher.HandoffSocket)
 }
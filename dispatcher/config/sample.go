@@ -19,10 +19,10 @@ const idSample = "dispatcher"
 
 const dispSample = `
 # ID of the Dispatcher. (required)
-id = "%s"
+id = "%[1]s"
 
-# The local API socket. (default /run/shm/dispatcher/default.sock)
-application_socket = "/run/shm/dispatcher/default.sock"
+# The local API socket. (default %[2]s)
+application_socket = %[2]q
 
 # File permissions of the ApplicationSocket socket file, in octal. (default "0770")
 socket_file_mode = "0770"
@@ -32,4 +32,40 @@ underlay_port = 30041
 
 # Remove the socket file (if it exists) on start. (default false)
 delete_socket = false
+
+# The number of workers serving the underlay port per address family. With
+# more than one worker, each worker has its own socket opened with
+# SO_REUSEPORT, and the kernel distributes the incoming packets among them.
+# (default 1)
+workers = 1
+
+# The internal underlay addresses of the border routers of the local AS, e.g.,
+# ["10.0.0.1:30042", "10.0.0.2:30042"]. If set, the dispatcher sends STUN
+# binding requests to each router to keep the NAT mappings of the end host
+# alive and to learn the address the NAT maps the end host to. The dispatcher
+# translates between the local and the mapped address in the SCION header, so
+# that the end host can communicate from behind a NAT. Packets to the end host
+# can only enter the AS at the listed routers, which must have NAT traversal
+# enabled. (default [])
+nat_routers = []
+
+# The interval in which the STUN binding requests are sent. (default 15s)
+nat_keepalive_interval = "15s"
+
+# The number of SCMP error messages per second from a single source AS that
+# are delivered to the applications. If zero, the rate is not limited.
+# (default 0)
+scmp_error_rate_per_as = 0.0
+
+# The number of SCMP error messages that can be delivered in a burst above the
+# rate limit. (default 10)
+scmp_error_burst = 10
+
+# The unix socket used for hitless upgrades, e.g.,
+# "/run/shm/dispatcher/handoff.sock". On start, the dispatcher connects to the
+# socket and takes over the underlay sockets, the application socket, and the
+# registered applications of the dispatcher instance running there. Then it
+# listens on the socket itself, so that it can hand off to the next instance.
+# If empty, the handoff is disabled. (default "")
+handoff_socket = ""
 `
@@ -17,6 +17,8 @@ package dispatcher
 import (
 	"context"
 	"net"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/scionproto/scion/dispatcher/internal/registration"
@@ -25,6 +27,7 @@ import (
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/private/ratelimit"
 	"github.com/scionproto/scion/private/ringbuf"
 	"github.com/scionproto/scion/private/underlay/conn"
 )
@@ -40,8 +43,23 @@ const (
 type Server struct {
 	// routingTable is used to register new connections.
 	routingTable *IATable
-	ipv4Conn     net.PacketConn
-	ipv6Conn     net.PacketConn
+	// ipv4Conns and ipv6Conns are the underlay sockets of the workers. Each
+	// socket is served by its own worker.
+	ipv4Conns []net.PacketConn
+	ipv6Conns []net.PacketConn
+	// NAT, if not nil, enables the NAT traversal. It must be set before Serve
+	// is called.
+	NAT *NAT
+	// SCMPErrorLimiter, if not nil, limits the rate of the SCMP error
+	// messages delivered per source AS. It must be set before Serve is
+	// called.
+	SCMPErrorLimiter *ratelimit.Limiter
+
+	stopOnce sync.Once
+	// stop is closed to stop the workers.
+	stop chan struct{}
+	// workers tracks the running workers.
+	workers sync.WaitGroup
 }
 
 // NewServer creates new instance of Server. Internally, it opens the dispatcher ports
@@ -49,14 +67,14 @@ type Server struct {
 func NewServer(address string, ipv4Conn, ipv6Conn net.PacketConn) (*Server, error) {
 	if ipv4Conn == nil {
 		var err error
-		ipv4Conn, err = openConn("udp4", address)
+		ipv4Conn, err = openConn("udp4", address, false)
 		if err != nil {
 			return nil, err
 		}
 	}
 	if ipv6Conn == nil {
 		var err error
-		ipv6Conn, err = openConn("udp6", address)
+		ipv6Conn, err = openConn("udp6", address, false)
 		if err != nil {
 			ipv4Conn.Close()
 			return nil, err
@@ -64,31 +82,114 @@ func NewServer(address string, ipv4Conn, ipv6Conn net.PacketConn) (*Server, erro
 	}
 	return &Server{
 		routingTable: NewIATable(32768, 65535),
-		ipv4Conn:     ipv4Conn,
-		ipv6Conn:     ipv6Conn,
+		ipv4Conns:    []net.PacketConn{ipv4Conn},
+		ipv6Conns:    []net.PacketConn{ipv6Conn},
+		stop:         make(chan struct{}),
 	}, nil
 }
 
+// NewMultiWorkerServer creates a new instance of Server that serves the
+// dispatcher ports with the given number of workers per address family. Each
+// worker has its own underlay socket. The sockets are bound to the same
+// address with SO_REUSEPORT, and the kernel distributes the incoming packets
+// among them by flow. All workers share the registration table. The address
+// must specify a port, otherwise each socket is bound to a different port.
+func NewMultiWorkerServer(address string, workers int) (*Server, error) {
+	if workers < 1 {
+		return nil, serrors.New("at least one worker is required", "workers", workers)
+	}
+	s := &Server{
+		routingTable: NewIATable(32768, 65535),
+		stop:         make(chan struct{}),
+	}
+	var err error
+	if s.ipv4Conns, err = openConns("udp4", address, workers); err != nil {
+		return nil, err
+	}
+	if s.ipv6Conns, err = openConns("udp6", address, workers); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewServerFromUDPConns creates a new instance of Server that serves the
+// given, already open underlay sockets, e.g., the ones inherited from another
+// dispatcher process. Each socket is served by its own worker.
+func NewServerFromUDPConns(ipv4Conns, ipv6Conns []*net.UDPConn) (*Server, error) {
+	if len(ipv4Conns) == 0 || len(ipv6Conns) == 0 {
+		return nil, serrors.New("at least one socket per address family is required",
+			"ipv4", len(ipv4Conns), "ipv6", len(ipv6Conns))
+	}
+	s := &Server{
+		routingTable: NewIATable(32768, 65535),
+		stop:         make(chan struct{}),
+	}
+	wrap := func(conns []*net.UDPConn) ([]net.PacketConn, error) {
+		var wrapped []net.PacketConn
+		for _, c := range conns {
+			uc, err := conn.NewFromUDPConn(c)
+			if err != nil {
+				return nil, err
+			}
+			wrapped = append(wrapped, &underlayConnWrapper{Conn: uc})
+		}
+		return wrapped, nil
+	}
+	var err error
+	if s.ipv4Conns, err = wrap(ipv4Conns); err != nil {
+		return nil, err
+	}
+	if s.ipv6Conns, err = wrap(ipv6Conns); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// openConns opens n underlay sockets with SO_REUSEPORT on the same address.
+func openConns(network, address string, n int) ([]net.PacketConn, error) {
+	conns := make([]net.PacketConn, 0, n)
+	for i := 0; i < n; i++ {
+		c, err := openConn(network, address, true)
+		if err != nil {
+			for _, c := range conns {
+				c.Close()
+			}
+			return nil, err
+		}
+		conns = append(conns, c)
+	}
+	return conns, nil
+}
+
 // Serve starts reading packets from network and dispatching them to different connections.
 // The function blocks and returns if there's an error or when Close has been called.
 func (as *Server) Serve() error {
-	errChan := make(chan error)
-	go func() {
-		defer log.HandlePanic()
-		netToRingDataplane := &NetToRingDataplane{
-			UnderlayConn: as.ipv4Conn,
-			RoutingTable: as.routingTable,
-		}
-		errChan <- netToRingDataplane.Run()
-	}()
-	go func() {
-		defer log.HandlePanic()
-		netToRingDataplane := &NetToRingDataplane{
-			UnderlayConn: as.ipv6Conn,
-			RoutingTable: as.routingTable,
-		}
-		errChan <- netToRingDataplane.Run()
-	}()
+	conns := append(append([]net.PacketConn{}, as.ipv4Conns...), as.ipv6Conns...)
+	errChan := make(chan error, len(conns))
+	as.workers.Add(len(conns))
+	for _, conn := range conns {
+		go func(conn net.PacketConn) {
+			defer log.HandlePanic()
+			defer as.workers.Done()
+			netToRingDataplane := &NetToRingDataplane{
+				UnderlayConn:     conn,
+				RoutingTable:     as.routingTable,
+				NAT:              as.NAT,
+				SCMPErrorLimiter: as.SCMPErrorLimiter,
+				Done:             as.stop,
+			}
+			errChan <- netToRingDataplane.Run()
+		}(conn)
+	}
+	if as.NAT != nil {
+		// All sockets of an address family are bound to the same address, so
+		// a single mapping per router covers all of them.
+		go func() {
+			defer log.HandlePanic()
+			as.NAT.Run(as.ipv4Conns[0], as.ipv6Conns[0])
+		}()
+	}
 	return <-errChan
 }
 
@@ -101,23 +202,76 @@ func (as *Server) Register(ctx context.Context, ia addr.IA, address *net.UDPAddr
 	if err != nil {
 		return nil, 0, err
 	}
+	port := ref.UDPAddr().Port
+	// Spread the outgoing traffic of the connections across the sockets of
+	// the workers.
 	var ovConn net.PacketConn
 	if address.IP.To4() == nil {
-		ovConn = as.ipv6Conn
+		ovConn = as.ipv6Conns[port%len(as.ipv6Conns)]
 	} else {
-		ovConn = as.ipv4Conn
+		ovConn = as.ipv4Conns[port%len(as.ipv4Conns)]
 	}
 	conn := &Conn{
 		conn:         ovConn,
 		ring:         tableEntry.appIngressRing,
 		regReference: ref,
+		nat:          as.NAT,
+	}
+	return conn, uint16(port), nil
+}
+
+// Stop stops reading from the underlay sockets without closing them, e.g., to
+// hand them off to another process. It returns once all workers stopped, i.e.,
+// no more packets are enqueued to the rings of the connections. Serve returns
+// nil once the server is stopped.
+func (as *Server) Stop() {
+	as.stopOnce.Do(func() {
+		close(as.stop)
+		// Interrupt the blocked reads. The deadline only affects this
+		// process, the sockets can be used as they are in another process.
+		for _, c := range append(append([]net.PacketConn{}, as.ipv4Conns...), as.ipv6Conns...) {
+			if err := c.SetReadDeadline(time.Unix(1, 0)); err != nil {
+				log.Info("Failed to interrupt underlay reads", "err", err)
+			}
+		}
+	})
+	as.workers.Wait()
+}
+
+// SyscallConns returns the raw underlay sockets, e.g., to pass them to
+// another process.
+func (as *Server) SyscallConns() (ipv4, ipv6 []syscall.RawConn, err error) {
+	raw := func(conns []net.PacketConn) ([]syscall.RawConn, error) {
+		var rcs []syscall.RawConn
+		for _, c := range conns {
+			sc, ok := c.(syscall.Conn)
+			if !ok {
+				return nil, serrors.New("underlay socket does not expose raw socket")
+			}
+			rc, err := sc.SyscallConn()
+			if err != nil {
+				return nil, err
+			}
+			rcs = append(rcs, rc)
+		}
+		return rcs, nil
+	}
+	if ipv4, err = raw(as.ipv4Conns); err != nil {
+		return nil, nil, err
+	}
+	if ipv6, err = raw(as.ipv6Conns); err != nil {
+		return nil, nil, err
 	}
-	return conn, uint16(ref.UDPAddr().Port), nil
+	return ipv4, ipv6, nil
 }
 
 func (as *Server) Close() {
-	as.ipv4Conn.Close()
-	as.ipv6Conn.Close()
+	for _, c := range as.ipv4Conns {
+		c.Close()
+	}
+	for _, c := range as.ipv6Conns {
+		c.Close()
+	}
 }
 
 // Conn represents a connection bound to a specific SCION port/SVC.
@@ -128,6 +282,8 @@ type Conn struct {
 	ring *ringbuf.Ring
 	// regReference is the reference to the registration in the routing table.
 	regReference registration.RegReference
+	// nat, if not nil, translates the source host address of the packets.
+	nat *NAT
 }
 
 func (ac *Conn) WriteTo(p []byte, addr net.Addr) (int, error) {
@@ -142,6 +298,9 @@ func (ac *Conn) Write(pkt *respool.Packet) (int, error) {
 	// If this becomes ever a problem, we can namespace the ID per registered
 	// application.
 	_ = registerIfSCMPInfo(ac.regReference, pkt)
+	if ac.nat != nil {
+		ac.nat.translateSrc(pkt)
+	}
 	return pkt.SendOnConn(ac.conn, pkt.UnderlayRemote)
 }
 
@@ -199,7 +358,9 @@ func (ac *Conn) SetWriteDeadline(t time.Time) error {
 //
 // Note that Go-style dual-stacked IPv4/IPv6 connections are not supported. If
 // network is udp, it will be treated as udp4.
-func openConn(network, address string) (net.PacketConn, error) {
+//
+// If reusePort is set, the socket is opened with SO_REUSEPORT.
+func openConn(network, address string, reusePort bool) (net.PacketConn, error) {
 	// We cannot allow the Go standard library to open both types of sockets
 	// because the socket options are specific to only one socket type, so we
 	// degrade udp to only udp4.
@@ -220,6 +381,7 @@ func openConn(network, address string) (net.PacketConn, error) {
 	c, err := conn.New(listeningAddress, nil, &conn.Config{
 		SendBufferSize:    SendBufferSize,
 		ReceiveBufferSize: ReceiveBufferSize,
+		ReusePort:         reusePort,
 	})
 	if err != nil {
 		return nil, serrors.WrapStr("unable to open conn", err)
@@ -265,6 +427,14 @@ func (o *underlayConnWrapper) WriteTo(p []byte, a net.Addr) (int, error) {
 	return o.Conn.WriteTo(p, udpAddr)
 }
 
+func (o *underlayConnWrapper) SyscallConn() (syscall.RawConn, error) {
+	sc, ok := o.Conn.(syscall.Conn)
+	if !ok {
+		return nil, serrors.New("underlay conn does not expose raw socket")
+	}
+	return sc.SyscallConn()
+}
+
 func (o *underlayConnWrapper) Close() error {
 	return o.Conn.Close()
 }
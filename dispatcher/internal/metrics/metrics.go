@@ -27,6 +27,7 @@ const Namespace = "disp"
 const (
 	PacketResultParseError    = "parse_error"
 	PacketResultRouteNotFound = "route_not_found"
+	PacketResultRateLimited   = "rate_limited"
 	PacketResultOk            = "ok"
 )
 
@@ -121,7 +121,9 @@ func (pkt *Packet) Free() {
 	}
 }
 
-func (pkt *Packet) DecodeFromConn(conn net.PacketConn) error {
+// ReadFromConn reads the next packet from the underlay conn. The packet must
+// be decoded with Decode before its layers are accessed.
+func (pkt *Packet) ReadFromConn(conn net.PacketConn) error {
 	n, readExtra, err := conn.ReadFrom(pkt.buffer)
 	if err != nil {
 		return err
@@ -130,6 +132,16 @@ func (pkt *Packet) DecodeFromConn(conn net.PacketConn) error {
 	metrics.M.NetReadBytes().Add(float64(n))
 
 	pkt.UnderlayRemote = readExtra.(*net.UDPAddr)
+	return nil
+}
+
+// Bytes returns the raw packet. The caller must not modify it.
+func (pkt *Packet) Bytes() []byte {
+	return pkt.buffer
+}
+
+// Decode decodes the packet read with ReadFromConn.
+func (pkt *Packet) Decode() error {
 	if err := pkt.decodeBuffer(); err != nil {
 		metrics.M.NetReadPkts(
 			metrics.IncomingPacket{
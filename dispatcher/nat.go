@@ -0,0 +1,232 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/dispatcher/internal/respool"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/snet/addrutil"
+	"github.com/scionproto/scion/pkg/stun"
+)
+
+// DefaultNATKeepaliveInterval is the default interval in which the NAT mapping
+// is refreshed.
+const DefaultNATKeepaliveInterval = 15 * time.Second
+
+// NAT lets the end host reach the border routers of the local AS through a
+// NAT. The dispatcher periodically sends STUN binding requests from its
+// underlay socket to each border router. This keeps the mappings in the NAT
+// alive, lets the routers learn the mapped port of the host, and tells the
+// dispatcher the address the NAT maps its local address to. The requests are
+// sent to all border routers, since the packets to the end host can enter the
+// AS at any of them.
+//
+// Similar to the address family transition router of DS-Lite (RFC 6333), the
+// dispatcher then rewrites the local address in the source host address of
+// the outgoing SCION packets to the mapped address, and the mapped address in
+// the destination host address of the incoming packets to the local address.
+// The address is translated with the mapping towards the border router the
+// packet is sent to or received from. The applications therefore only ever see
+// their local address.
+type NAT struct {
+	// Routers are the internal underlay addresses of the border routers the
+	// STUN binding requests are sent to.
+	Routers []*net.UDPAddr
+	// Interval is the interval in which the STUN binding requests are sent. If
+	// it is zero, DefaultNATKeepaliveInterval is used.
+	Interval time.Duration
+
+	mtx sync.RWMutex
+	// routers holds the mapping towards each border router.
+	routers map[netip.AddrPort]*natMapping
+}
+
+// natMapping is the mapping of the local address towards a border router.
+type natMapping struct {
+	local   netip.Addr
+	mapped  netip.Addr
+	pending stun.TxID
+}
+
+// Run sends the STUN binding requests on the underlay socket of the address
+// family of the respective router until the sockets are closed. The responses
+// must be passed to handleResponse by the readers of the sockets.
+func (n *NAT) Run(ipv4, ipv6 net.PacketConn) {
+	interval := n.Interval
+	if interval == 0 {
+		interval = DefaultNATKeepaliveInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for ; ; <-ticker.C {
+		for _, router := range n.Routers {
+			conn := ipv4
+			if router.IP.To4() == nil {
+				conn = ipv6
+			}
+			if err := n.sendRequest(conn, router); err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+				log.Info("Failed to send STUN binding request", "router", router, "err", err)
+			}
+		}
+	}
+}
+
+func (n *NAT) sendRequest(conn net.PacketConn, router *net.UDPAddr) error {
+	// The local address is resolved on every round, it changes if the host
+	// moves to a different network.
+	localIP, err := addrutil.ResolveLocal(router.IP)
+	if err != nil {
+		return err
+	}
+	local, ok := netip.AddrFromSlice(localIP)
+	if !ok {
+		return &net.AddrError{Err: "invalid local address", Addr: localIP.String()}
+	}
+	id := stun.NewTxID()
+	n.mtx.Lock()
+	if n.routers == nil {
+		n.routers = make(map[netip.AddrPort]*natMapping)
+	}
+	m, ok := n.routers[natKey(router)]
+	if !ok {
+		m = &natMapping{}
+		n.routers[natKey(router)] = m
+	}
+	m.local = local.Unmap()
+	m.pending = id
+	n.mtx.Unlock()
+	_, err = conn.WriteTo(stun.Request(id), router)
+	return err
+}
+
+// handleResponse processes a STUN binding response read from the underlay
+// socket. Responses that are not received from a router the last request was
+// sent to are ignored.
+func (n *NAT) handleResponse(b []byte, src *net.UDPAddr) {
+	id, mapped, err := stun.ParseResponse(b)
+	if err != nil {
+		log.Debug("Ignoring invalid STUN response", "err", err)
+		return
+	}
+	ip := mapped.Addr().Unmap()
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	m, ok := n.routers[natKey(src)]
+	if !ok || id != m.pending {
+		log.Debug("Ignoring unexpected STUN response", "router", src, "mapped", mapped)
+		return
+	}
+	if ip.Is4() != m.local.Is4() {
+		log.Info("Ignoring mapped address of different address family",
+			"router", src, "local", m.local, "mapped", ip)
+		return
+	}
+	if ip != m.mapped {
+		log.Info("NAT mapping changed", "router", src, "local", m.local, "mapped", ip)
+		m.mapped = ip
+	}
+}
+
+// translateSrc rewrites the source host address of the outgoing packet from
+// the local to the mapped address.
+func (n *NAT) translateSrc(pkt *respool.Packet) {
+	local, mapped, ok := n.mapping(pkt.UnderlayRemote)
+	if !ok {
+		return
+	}
+	rewriteHost(pkt, pkt.SCION.RawSrcAddr, pkt.SCION.SrcAddrType, local, mapped)
+}
+
+// translateDst rewrites the destination host address of the incoming packet
+// from the mapped to the local address.
+func (n *NAT) translateDst(pkt *respool.Packet) {
+	local, mapped, ok := n.mapping(pkt.UnderlayRemote)
+	if !ok {
+		return
+	}
+	rewriteHost(pkt, pkt.SCION.RawDstAddr, pkt.SCION.DstAddrType, mapped, local)
+}
+
+// mapping returns the mapping towards the router. It returns false if there is
+// no mapping, or if the local address is not translated.
+func (n *NAT) mapping(router *net.UDPAddr) (local, mapped netip.Addr, ok bool) {
+	if router == nil {
+		return netip.Addr{}, netip.Addr{}, false
+	}
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	m, ok := n.routers[natKey(router)]
+	if !ok || !m.mapped.IsValid() || m.mapped == m.local {
+		return netip.Addr{}, netip.Addr{}, false
+	}
+	return m.local, m.mapped, true
+}
+
+// natKey returns the key of the router in the mappings.
+func natKey(router *net.UDPAddr) netip.AddrPort {
+	a := router.AddrPort()
+	return netip.AddrPortFrom(a.Addr().Unmap(), a.Port())
+}
+
+// rewriteHost replaces the host address raw, if it is equal to from, with to
+// in place and updates the L4 checksum, which covers the address in the
+// pseudo header, accordingly.
+func rewriteHost(pkt *respool.Packet, raw []byte, t slayers.AddrType, from, to netip.Addr) {
+	if t != slayers.T4Ip && t != slayers.T16Ip {
+		return
+	}
+	if cur, ok := netip.AddrFromSlice(raw); !ok || cur != from {
+		return
+	}
+	var csum []byte
+	switch pkt.L4 {
+	case slayers.LayerTypeSCIONUDP:
+		csum = pkt.UDP.Contents[6:8]
+		pkt.UDP.Checksum = updateChecksum(pkt.UDP.Checksum, raw, to.AsSlice())
+		binary.BigEndian.PutUint16(csum, pkt.UDP.Checksum)
+	case slayers.LayerTypeSCMP:
+		csum = pkt.SCMP.Contents[2:4]
+		pkt.SCMP.Checksum = updateChecksum(pkt.SCMP.Checksum, raw, to.AsSlice())
+		binary.BigEndian.PutUint16(csum, pkt.SCMP.Checksum)
+	default:
+		return
+	}
+	copy(raw, to.AsSlice())
+}
+
+// updateChecksum incrementally updates the internet checksum csum for the
+// replacement of the data old with new of the same, even length (RFC 1624).
+func updateChecksum(csum uint16, old, new []byte) uint16 {
+	sum := uint32(^csum)
+	for i := 0; i+1 < len(old); i += 2 {
+		sum += uint32(^binary.BigEndian.Uint16(old[i:]))
+		sum += uint32(binary.BigEndian.Uint16(new[i:]))
+	}
+	for sum > 0xffff {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
@@ -0,0 +1,244 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/dispatcher/internal/respool"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path/empty"
+	"github.com/scionproto/scion/pkg/stun"
+)
+
+func TestNATTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		L4        slayers.L4ProtocolType
+		Src, Dst  string
+		Router    string
+		Translate func(n *NAT, pkt *respool.Packet)
+		ExpSrc    string
+		ExpDst    string
+	}{
+		"UDP source": {
+			L4:        slayers.L4UDP,
+			Src:       "192.168.1.10",
+			Dst:       "10.0.0.2",
+			Translate: (*NAT).translateSrc,
+			ExpSrc:    "203.0.113.7",
+			ExpDst:    "10.0.0.2",
+		},
+		"SCMP source": {
+			L4:        slayers.L4SCMP,
+			Src:       "192.168.1.10",
+			Dst:       "10.0.0.2",
+			Translate: (*NAT).translateSrc,
+			ExpSrc:    "203.0.113.7",
+			ExpDst:    "10.0.0.2",
+		},
+		"UDP destination": {
+			L4:        slayers.L4UDP,
+			Src:       "10.0.0.2",
+			Dst:       "203.0.113.7",
+			Translate: (*NAT).translateDst,
+			ExpSrc:    "10.0.0.2",
+			ExpDst:    "192.168.1.10",
+		},
+		"SCMP destination": {
+			L4:        slayers.L4SCMP,
+			Src:       "10.0.0.2",
+			Dst:       "203.0.113.7",
+			Translate: (*NAT).translateDst,
+			ExpSrc:    "10.0.0.2",
+			ExpDst:    "192.168.1.10",
+		},
+		"other source": {
+			L4:        slayers.L4UDP,
+			Src:       "192.168.1.11",
+			Dst:       "10.0.0.2",
+			Translate: (*NAT).translateSrc,
+			ExpSrc:    "192.168.1.11",
+			ExpDst:    "10.0.0.2",
+		},
+		"other destination": {
+			L4:        slayers.L4UDP,
+			Src:       "10.0.0.2",
+			Dst:       "192.168.1.10",
+			Translate: (*NAT).translateDst,
+			ExpSrc:    "10.0.0.2",
+			ExpDst:    "192.168.1.10",
+		},
+		"other router": {
+			L4:        slayers.L4UDP,
+			Src:       "192.168.1.10",
+			Dst:       "10.0.0.2",
+			Router:    "10.0.0.2:30042",
+			Translate: (*NAT).translateSrc,
+			ExpSrc:    "192.168.1.10",
+			ExpDst:    "10.0.0.2",
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			n := newTestNAT("192.168.1.10", "203.0.113.7")
+			raw := natTestPacket(t, tc.L4, tc.Src, tc.Dst)
+			pkt := decodeNATTestPacket(t, raw)
+			pkt.UnderlayRemote = natTestRouter
+			if tc.Router != "" {
+				pkt.UnderlayRemote = net.UDPAddrFromAddrPort(netip.MustParseAddrPort(tc.Router))
+			}
+			tc.Translate(n, pkt)
+			assert.Equal(t, natTestPacket(t, tc.L4, tc.ExpSrc, tc.ExpDst), raw)
+		})
+	}
+	t.Run("no mapping", func(t *testing.T) {
+		n := newTestNAT("192.168.1.10", "")
+		raw := natTestPacket(t, slayers.L4UDP, "192.168.1.10", "10.0.0.2")
+		pkt := decodeNATTestPacket(t, raw)
+		pkt.UnderlayRemote = natTestRouter
+		n.translateSrc(pkt)
+		assert.Equal(t, natTestPacket(t, slayers.L4UDP, "192.168.1.10", "10.0.0.2"), raw)
+	})
+}
+
+func TestNATHandleResponse(t *testing.T) {
+	n := newTestNAT("192.168.1.10", "")
+	m := n.routers[natKey(natTestRouter)]
+	id := stun.NewTxID()
+	m.pending = id
+	mapped := netip.MustParseAddrPort("203.0.113.7:40000")
+
+	n.handleResponse(stun.AppendResponse(nil, stun.NewTxID(), mapped), natTestRouter)
+	assert.False(t, m.mapped.IsValid(), "unexpected transaction ID")
+	n.handleResponse(stun.AppendResponse(nil, id, mapped),
+		net.UDPAddrFromAddrPort(netip.MustParseAddrPort("10.0.0.2:30042")))
+	assert.False(t, m.mapped.IsValid(), "other router")
+	n.handleResponse(stun.AppendResponse(nil, id,
+		netip.MustParseAddrPort("[2001:db8::1]:40000")), natTestRouter)
+	assert.False(t, m.mapped.IsValid(), "different address family")
+	n.handleResponse(stun.AppendResponse(nil, id, mapped), natTestRouter)
+	assert.Equal(t, mapped.Addr(), m.mapped)
+}
+
+func TestNATRun(t *testing.T) {
+	var routers []*net.UDPConn
+	n := &NAT{Interval: 10 * time.Millisecond}
+	for i := 0; i < 2; i++ {
+		router, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+		defer router.Close()
+		routers = append(routers, router)
+		n.Routers = append(n.Routers, router.LocalAddr().(*net.UDPAddr))
+	}
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		n.Run(conn, nil)
+	}()
+
+	buf := make([]byte, 1500)
+	for _, router := range routers {
+		for i := 0; i < 2; i++ {
+			require.NoError(t, router.SetReadDeadline(time.Now().Add(time.Second)))
+			m, from, err := router.ReadFrom(buf)
+			require.NoError(t, err)
+			assert.Equal(t, conn.LocalAddr(), from)
+			_, err = stun.ParseRequest(buf[:m])
+			assert.NoError(t, err)
+		}
+	}
+	n.mtx.RLock()
+	for _, router := range n.Routers {
+		assert.Equal(t, netip.MustParseAddr("127.0.0.1"), n.routers[natKey(router)].local)
+	}
+	n.mtx.RUnlock()
+
+	conn.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the conn was closed")
+	}
+}
+
+var natTestRouter = &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 30042}
+
+// newTestNAT returns a NAT with the mapping of local to mapped towards
+// natTestRouter. If mapped is empty, the mapping is not known yet.
+func newTestNAT(local, mapped string) *NAT {
+	m := &natMapping{local: netip.MustParseAddr(local)}
+	if mapped != "" {
+		m.mapped = netip.MustParseAddr(mapped)
+	}
+	return &NAT{
+		Routers: []*net.UDPAddr{natTestRouter},
+		routers: map[netip.AddrPort]*natMapping{natKey(natTestRouter): m},
+	}
+}
+
+func natTestPacket(t *testing.T, l4 slayers.L4ProtocolType, src, dst string) []byte {
+	scn := &slayers.SCION{
+		NextHdr:  l4,
+		PathType: empty.PathType,
+		Path:     empty.Path{},
+		SrcIA:    xtest.MustParseIA("1-ff00:0:110"),
+		DstIA:    xtest.MustParseIA("1-ff00:0:111"),
+	}
+	require.NoError(t, scn.SetSrcAddr(addr.MustParseHost(src)))
+	require.NoError(t, scn.SetDstAddr(addr.MustParseHost(dst)))
+	layers := []gopacket.SerializableLayer{scn}
+	switch l4 {
+	case slayers.L4UDP:
+		udp := &slayers.UDP{SrcPort: 31000, DstPort: 31001}
+		udp.SetNetworkLayerForChecksum(scn)
+		layers = append(layers, udp, gopacket.Payload("payload"))
+	case slayers.L4SCMP:
+		scmp := &slayers.SCMP{
+			TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeEchoRequest, 0),
+		}
+		scmp.SetNetworkLayerForChecksum(scn)
+		layers = append(layers, scmp, &slayers.SCMPEcho{Identifier: 42, SeqNumber: 1})
+	}
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, layers...))
+	return buf.Bytes()
+}
+
+// decodeNATTestPacket decodes the layers of the packet in place, so that they
+// reference raw.
+func decodeNATTestPacket(t *testing.T, raw []byte) *respool.Packet {
+	pkt := &respool.Packet{}
+	parser := gopacket.NewDecodingLayerParser(slayers.LayerTypeSCION,
+		&pkt.SCION, &pkt.UDP, &pkt.SCMP)
+	parser.IgnoreUnsupported = true
+	decoded := []gopacket.LayerType{}
+	require.NoError(t, parser.DecodeLayers(raw, &decoded))
+	pkt.L4 = decoded[len(decoded)-1]
+	return pkt
+}
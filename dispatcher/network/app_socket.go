@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/scionproto/scion/dispatcher"
 	"github.com/scionproto/scion/dispatcher/internal/metrics"
@@ -33,12 +35,23 @@ import (
 type AppSocketServer struct {
 	Listener   *reliable.Listener
 	DispServer *dispatcher.Server
+
+	mtx    sync.Mutex
+	closed bool
+	// conns contains the handlers of the application connections that are
+	// currently served.
+	conns map[net.PacketConn]*AppConnHandler
 }
 
+// Serve accepts connections until the listener fails. It returns nil if the
+// server was closed.
 func (s *AppSocketServer) Serve() error {
 	for {
 		conn, err := s.Listener.Accept()
 		if err != nil {
+			if s.isClosed() {
+				return nil
+			}
 			return err
 		}
 		pconn := conn.(net.PacketConn)
@@ -48,72 +61,287 @@ func (s *AppSocketServer) Serve() error {
 
 // Handle passes conn off to a per-connection state handler.
 func (h *AppSocketServer) Handle(conn net.PacketConn) {
-	ch := &AppConnHandler{
-		Conn:   conn,
-		Logger: log.New("clientID", fmt.Sprintf("%p", conn)),
+	ch := newAppConnHandler(conn)
+	if !h.track(ch) {
+		conn.Close()
+		return
 	}
 	go func() {
 		defer log.HandlePanic()
+		defer h.untrack(ch)
 		ch.Handle(h.DispServer)
 	}()
 }
 
+// handleInherited serves a connection that was inherited from another
+// dispatcher instance. If reg is not nil, the application is registered again
+// with the port it was assigned by the other instance. Otherwise, the
+// connection is served as a new one.
+func (h *AppSocketServer) handleInherited(conn *reliable.Conn, reg *reliable.Registration) {
+	if reg == nil {
+		h.Handle(conn)
+		return
+	}
+	ch := newAppConnHandler(conn)
+	// The registration is restored synchronously, such that the packets for
+	// the application are delivered as soon as the underlay is served.
+	dispConn, err := ch.register(h.DispServer, reg)
+	if err != nil {
+		ch.Logger.Info("Restoring registration failed", "err", err)
+		conn.Close()
+		return
+	}
+	if !h.track(ch) {
+		dispConn.Close()
+		conn.Close()
+		return
+	}
+	go func() {
+		defer log.HandlePanic()
+		defer h.untrack(ch)
+		defer ch.closeConn()
+		ch.serve(dispConn.(*dispatcher.Conn), reg)
+	}()
+}
+
+// detachedApp is an application connection that was detached from the
+// dispatcher to hand it off to another dispatcher instance.
+type detachedApp struct {
+	conn *reliable.Conn
+	// registration is the registration of the application. It is nil if the
+	// application did not register yet.
+	registration *reliable.Registration
+	// buffered is the data that was read from the connection but not
+	// processed yet.
+	buffered []byte
+}
+
+// detach stops accepting new connections and stops serving the application
+// connections without closing them. It returns the detached connections.
+func (h *AppSocketServer) detach() []detachedApp {
+	h.mtx.Lock()
+	h.closed = true
+	handlers := make([]*AppConnHandler, 0, len(h.conns))
+	for _, ch := range h.conns {
+		handlers = append(handlers, ch)
+	}
+	h.mtx.Unlock()
+
+	// Interrupt Accept, the listener itself is handed off.
+	if err := h.Listener.SetDeadline(time.Unix(1, 0)); err != nil {
+		log.Info("Failed to interrupt accepting application connections", "err", err)
+	}
+	apps := make([]detachedApp, len(handlers))
+	var wg sync.WaitGroup
+	wg.Add(len(handlers))
+	for i, ch := range handlers {
+		go func(i int, ch *AppConnHandler) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			apps[i] = ch.detach()
+		}(i, ch)
+	}
+	wg.Wait()
+	return apps
+}
+
+// Close stops accepting new connections and closes the connections of the
+// applications. The applications observe the closed connection and can
+// reconnect once the dispatcher is available again.
+func (h *AppSocketServer) Close() error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.closed = true
+	err := h.Listener.Close()
+	for conn := range h.conns {
+		conn.Close()
+	}
+	return err
+}
+
+func (h *AppSocketServer) track(ch *AppConnHandler) bool {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.closed {
+		return false
+	}
+	if h.conns == nil {
+		h.conns = make(map[net.PacketConn]*AppConnHandler)
+	}
+	h.conns[ch.Conn] = ch
+	return true
+}
+
+func (h *AppSocketServer) untrack(ch *AppConnHandler) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	delete(h.conns, ch.Conn)
+}
+
+func (h *AppSocketServer) isClosed() bool {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.closed
+}
+
+// detachTimeout is the maximum time spent on delivering the packets that are
+// queued for an application when its connection is detached.
+const detachTimeout = time.Second
+
 // AppConnHandler handles a single SCION application connection.
 type AppConnHandler struct {
 	// Conn is the local socket to which the application is connected.
 	Conn     net.PacketConn
 	DispConn *dispatcher.Conn
 	Logger   log.Logger
+
+	mtx sync.Mutex
+	// registration is the registration of the application with the port it
+	// was assigned. It is nil until the application is registered.
+	registration *reliable.Registration
+	// detached is set once the connection is detached to hand it off. It is
+	// then no longer served, but kept open.
+	detached bool
+	// done is closed once the connection is no longer served.
+	done chan struct{}
+}
+
+func newAppConnHandler(conn net.PacketConn) *AppConnHandler {
+	return &AppConnHandler{
+		Conn:   conn,
+		Logger: log.New("clientID", fmt.Sprintf("%p", conn)),
+		done:   make(chan struct{}),
+	}
 }
 
 func (h *AppConnHandler) Handle(appServer *dispatcher.Server) {
 	h.Logger.Debug("Accepted new client")
 	defer h.Logger.Debug("Closed client socket")
-	defer h.Conn.Close()
+	defer h.closeConn()
 
-	dispConn, err := h.doRegExchange(appServer)
+	dispConn, reg, err := h.doRegExchange(appServer)
 	if err != nil {
+		if h.isDetached() {
+			return
+		}
 		metrics.M.AppConnErrors().Inc()
 		h.Logger.Info("Registration error", "err", err)
 		return
 	}
-	h.DispConn = dispConn.(*dispatcher.Conn)
-	defer h.DispConn.Close()
+	h.serve(dispConn.(*dispatcher.Conn), reg)
+}
+
+// serve moves the packets between the application and the network until the
+// connection is closed or detached.
+func (h *AppConnHandler) serve(dispConn *dispatcher.Conn, reg *reliable.Registration) {
+	h.mtx.Lock()
+	h.registration = reg
+	h.mtx.Unlock()
+	h.DispConn = dispConn
 	svc := h.DispConn.SVCAddr().String()
 	metrics.M.OpenSockets(metrics.SVC{Type: svc}).Inc()
 	defer metrics.M.OpenSockets(metrics.SVC{Type: svc}).Dec()
 
+	ringDone := make(chan struct{})
 	go func() {
 		defer log.HandlePanic()
+		defer close(ringDone)
 		h.RunRingToAppDataplane()
 	}()
 
 	h.RunAppToNetDataplane()
+	// Closing the ring lets the ring to app dataplane deliver the queued
+	// packets before it returns.
+	h.DispConn.Close()
+	if h.isDetached() {
+		// The connection is handed off only once the queued packets are
+		// delivered, otherwise they could interleave with the packets
+		// delivered by the other dispatcher instance.
+		select {
+		case <-ringDone:
+		case <-time.After(detachTimeout):
+			h.Logger.Info("Delivering queued packets timed out, dropping them")
+			_ = h.Conn.SetWriteDeadline(time.Unix(1, 0))
+			<-ringDone
+		}
+	}
+}
+
+// detach stops serving the connection without closing it. It returns once the
+// connection is no longer served.
+func (h *AppConnHandler) detach() detachedApp {
+	h.mtx.Lock()
+	h.detached = true
+	h.mtx.Unlock()
+	// Interrupt the blocked reads. The deadline only affects this process.
+	if err := h.Conn.SetReadDeadline(time.Unix(1, 0)); err != nil {
+		h.Logger.Info("Failed to interrupt reading from client", "err", err)
+	}
+	<-h.done
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	app := detachedApp{registration: h.registration}
+	if conn, ok := h.Conn.(*reliable.Conn); ok {
+		app.conn = conn
+		app.buffered = conn.Buffered()
+	}
+	return app
+}
+
+// closeConn closes the connection unless it was detached, and marks the
+// connection as no longer served.
+func (h *AppConnHandler) closeConn() {
+	if !h.isDetached() {
+		h.Conn.Close()
+	}
+	close(h.done)
+}
+
+func (h *AppConnHandler) isDetached() bool {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.detached
 }
 
 // doRegExchange manages an application's registration request, and returns a
 // reference to registered data that should be freed at the end of the
-// registration, information about allocated ring buffers and whether an error occurred.
-func (h *AppConnHandler) doRegExchange(appServer *dispatcher.Server) (net.PacketConn, error) {
+// registration, the registration with the assigned port and whether an error
+// occurred.
+func (h *AppConnHandler) doRegExchange(
+	appServer *dispatcher.Server) (net.PacketConn, *reliable.Registration, error) {
 
 	b := respool.GetBuffer()
 	defer respool.PutBuffer(b)
 
 	regInfo, err := h.recvRegistration(b)
 	if err != nil {
-		return nil, serrors.WrapStr("receiving registration message", err)
+		return nil, nil, serrors.WrapStr("receiving registration message", err)
+	}
+	appConn, err := h.register(appServer, regInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+	port := uint16(regInfo.PublicAddress.Port)
+	if err := h.sendConfirmation(b, &reliable.Confirmation{Port: port}); err != nil {
+		appConn.Close()
+		return nil, nil, serrors.WrapStr("sending registration confirmation message", err)
 	}
+	return appConn, regInfo, nil
+}
+
+// register registers the application with the dispatcher. The public address
+// of regInfo is updated with the port assigned to the application.
+func (h *AppConnHandler) register(appServer *dispatcher.Server,
+	regInfo *reliable.Registration) (net.PacketConn, error) {
+
 	appConn, _, err := appServer.Register(nil,
 		regInfo.IA, regInfo.PublicAddress, regInfo.SVCAddress)
 	if err != nil {
 		return nil, serrors.WrapStr("add registration", err, "registration", regInfo)
 	}
 	udpAddr := appConn.(*dispatcher.Conn).LocalAddr().(*net.UDPAddr)
-	port := uint16(udpAddr.Port)
-	if err := h.sendConfirmation(b, &reliable.Confirmation{Port: port}); err != nil {
-		appConn.Close()
-		return nil, serrors.WrapStr("sending registration confirmation message", err)
-	}
+	regInfo.PublicAddress = &net.UDPAddr{IP: regInfo.PublicAddress.IP, Port: udpAddr.Port}
 	h.logRegistration(regInfo.IA, udpAddr, getBindIP(regInfo.BindAddress),
 		regInfo.SVCAddress)
 	return appConn, nil
@@ -170,6 +398,9 @@ func (h *AppConnHandler) RunAppToNetDataplane() {
 		// rare.
 
 		if err := pkt.DecodeFromReliableConn(h.Conn); err != nil {
+			if h.isDetached() {
+				return
+			}
 			if err == io.EOF {
 				h.Logger.Debug("[app->network] EOF received from client")
 			} else {
@@ -206,7 +437,9 @@ func (h *AppConnHandler) RunRingToAppDataplane() {
 		if err != nil {
 			metrics.M.AppWriteErrors().Inc()
 			h.Logger.Error("[network->app] App connection error.", "err", err)
-			h.Conn.Close()
+			if !h.isDetached() {
+				h.Conn.Close()
+			}
 			return
 		}
 		metrics.M.AppWritePkts().Inc()
@@ -15,37 +15,102 @@
 package network
 
 import (
+	"errors"
+	"net"
 	"os"
+	"runtime"
+	"sync"
 
 	"github.com/scionproto/scion/dispatcher"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/ratelimit"
 )
 
 type Dispatcher struct {
 	UnderlaySocket    string
 	ApplicationSocket string
 	SocketFileMode    os.FileMode
+	// Workers is the number of workers serving the underlay socket per address
+	// family. If it is larger than one, each worker has its own socket opened
+	// with SO_REUSEPORT.
+	Workers int
+	// NAT, if not nil, enables the NAT traversal for end hosts behind a NAT.
+	NAT *dispatcher.NAT
+	// SCMPErrorLimiter, if not nil, limits the rate of the SCMP error
+	// messages delivered per source AS.
+	SCMPErrorLimiter *ratelimit.Limiter
+	// Ready, if not nil, is closed once the underlay and the application
+	// sockets are open and the dispatcher serves them.
+	Ready chan struct{}
+	// HandoffSocket, if not empty, is the unix socket on which the dispatcher
+	// hands off its sockets and the application connections to a new
+	// dispatcher instance, see TakeOver.
+	HandoffSocket string
+	// Handoff, if not nil, is the state handed off by the previous dispatcher
+	// instance. Its sockets are used instead of opening new ones.
+	Handoff *Handoff
+
+	mtx        sync.Mutex
+	closed     bool
+	dispServer *dispatcher.Server
+	appServer  *AppSocketServer
+	// handoffListener accepts the handoff requests.
+	handoffListener *net.UnixListener
+	// handedOff is set once the dispatcher handed off to a new instance.
+	handedOff bool
+	// handoffErr is the error that occurred during a failed handoff.
+	handoffErr error
+	// handoffConn is the connection to the new instance after a successful
+	// handoff.
+	handoffConn *net.UnixConn
 }
 
 func (d *Dispatcher) ListenAndServe() error {
-	dispServer, err := dispatcher.NewServer(d.UnderlaySocket, nil, nil)
+	dispServer, dispServerConn, err := d.open()
 	if err != nil {
 		return err
 	}
 	defer dispServer.Close()
+	defer dispServerConn.Close()
+	dispServer.NAT = d.NAT
+	dispServer.SCMPErrorLimiter = d.SCMPErrorLimiter
+	// The file mode of unix sockets is not enforced on windows.
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(d.ApplicationSocket, d.SocketFileMode); err != nil {
+			return serrors.WrapStr("chmod failed", err, "socket file", d.ApplicationSocket)
+		}
+	}
 
-	dispServerConn, err := reliable.Listen(d.ApplicationSocket)
-	if err != nil {
-		return err
+	appServer := &AppSocketServer{
+		Listener:   dispServerConn,
+		DispServer: dispServer,
 	}
-	defer dispServerConn.Close()
-	if err := os.Chmod(d.ApplicationSocket, d.SocketFileMode); err != nil {
-		return serrors.WrapStr("chmod failed", err, "socket file", d.ApplicationSocket)
+	if d.Handoff != nil {
+		// The registrations are restored before the underlay is served, such
+		// that no packets for the applications are dropped.
+		for _, app := range d.Handoff.apps {
+			appServer.handleInherited(app.conn, app.registration)
+		}
+	}
+	var handoffListener *net.UnixListener
+	if d.HandoffSocket != "" {
+		if handoffListener, err = listenHandoff(d.HandoffSocket); err != nil {
+			return err
+		}
+		defer handoffListener.Close()
+	}
+	d.mtx.Lock()
+	if d.closed {
+		d.mtx.Unlock()
+		return nil
 	}
+	d.dispServer, d.appServer = dispServer, appServer
+	d.handoffListener = handoffListener
+	d.mtx.Unlock()
 
-	errChan := make(chan error)
+	errChan := make(chan error, 2)
 	go func() {
 		defer log.HandlePanic()
 		errChan <- dispServer.Serve()
@@ -53,12 +118,142 @@ func (d *Dispatcher) ListenAndServe() error {
 
 	go func() {
 		defer log.HandlePanic()
-		dispServer := &AppSocketServer{
-			Listener:   dispServerConn,
-			DispServer: dispServer,
-		}
-		errChan <- dispServer.Serve()
+		errChan <- appServer.Serve()
 	}()
 
-	return <-errChan
+	if handoffListener != nil {
+		go func() {
+			defer log.HandlePanic()
+			d.serveHandoff(handoffListener)
+		}()
+	}
+
+	if d.Ready != nil {
+		close(d.Ready)
+	}
+	err = <-errChan
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.handoffErr != nil {
+		return serrors.WrapStr("handing off to new dispatcher instance", d.handoffErr)
+	}
+	if d.closed {
+		return nil
+	}
+	return err
+}
+
+// open opens the underlay sockets and the application socket, or takes them
+// from the handoff state.
+func (d *Dispatcher) open() (*dispatcher.Server, *reliable.Listener, error) {
+	if d.Handoff != nil {
+		dispServer, err := dispatcher.NewServerFromUDPConns(d.Handoff.ipv4Conns,
+			d.Handoff.ipv6Conns)
+		if err != nil {
+			return nil, nil, err
+		}
+		return dispServer, d.Handoff.listener, nil
+	}
+	var dispServer *dispatcher.Server
+	var err error
+	if d.Workers > 1 {
+		dispServer, err = dispatcher.NewMultiWorkerServer(d.UnderlaySocket, d.Workers)
+	} else {
+		dispServer, err = dispatcher.NewServer(d.UnderlaySocket, nil, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	dispServerConn, err := reliable.Listen(d.ApplicationSocket)
+	if err != nil {
+		dispServer.Close()
+		return nil, nil, err
+	}
+	return dispServer, dispServerConn, nil
+}
+
+// listenHandoff listens on the handoff socket. The socket is only accessible
+// by the user of the dispatcher, since the handoff passes all its sockets.
+func listenHandoff(socket string) (*net.UnixListener, error) {
+	// A stale socket is left behind if the previous instance crashed.
+	if err := os.Remove(socket); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, serrors.WrapStr("removing handoff socket", err, "socket", socket)
+	}
+	l, err := net.ListenUnix("unixpacket", &net.UnixAddr{Name: socket, Net: "unixpacket"})
+	if err != nil {
+		return nil, serrors.WrapStr("listening on handoff socket", err, "socket", socket)
+	}
+	if err := os.Chmod(socket, 0600); err != nil {
+		l.Close()
+		return nil, serrors.WrapStr("chmod failed", err, "socket file", socket)
+	}
+	return l, nil
+}
+
+// serveHandoff accepts handoff requests until the listener is closed.
+// Requests of processes running as another user are rejected. The listener
+// is closed once a handoff got past sending the sockets, the dispatcher is
+// stopped afterwards.
+func (d *Dispatcher) serveHandoff(l *net.UnixListener) {
+	for {
+		conn, err := l.AcceptUnix()
+		if err != nil {
+			return
+		}
+		if err := checkHandoffPeer(conn); err != nil {
+			log.Info("Rejected handoff request", "err", err)
+			conn.Close()
+			continue
+		}
+		log.Info("New dispatcher instance requested handoff")
+		if err := d.handOff(conn); err != nil {
+			log.Error("Handing off to new dispatcher instance failed", "err", err)
+			conn.Close()
+		}
+	}
+}
+
+// HandedOff returns whether the dispatcher handed off its sockets to a new
+// dispatcher instance.
+func (d *Dispatcher) HandedOff() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.handedOff
+}
+
+// ReleaseHandoff signals the new dispatcher instance that this instance
+// terminates. The new instance waits for it before it serves its HTTP
+// endpoints, it must thus be called once the HTTP endpoints of this instance
+// are closed.
+func (d *Dispatcher) ReleaseHandoff() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.handoffConn != nil {
+		d.handoffConn.Close()
+		d.handoffConn = nil
+	}
+}
+
+// Close stops the dispatcher. The connections of the applications are closed
+// first, such that they observe the shutdown and can reconnect to the next
+// dispatcher instance. ListenAndServe returns nil once the dispatcher is
+// closed.
+func (d *Dispatcher) Close() error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	if d.handoffListener != nil {
+		d.handoffListener.Close()
+	}
+	var err error
+	if d.appServer != nil {
+		err = d.appServer.Close()
+	}
+	if d.dispServer != nil {
+		d.dispServer.Close()
+	}
+	return err
 }
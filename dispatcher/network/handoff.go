@@ -0,0 +1,365 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"syscall"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+)
+
+// The handoff protocol runs on a SOCK_SEQPACKET unix socket. The new
+// dispatcher instance connects to the handoff socket of the running instance,
+// which stops serving and sends its sockets as a sequence of messages:
+//
+//   - the underlay sockets and the listener of the application socket,
+//   - one message per application connection, and
+//   - a final message marking the end of the handoff.
+//
+// The sockets are attached to the messages as SCM_RIGHTS control messages.
+// The running instance closes its handoff listener before it sends the final
+// message, and it keeps the handoff connection open until it terminates.
+
+const (
+	// handoffMsgSize is the maximum size of a handoff message.
+	handoffMsgSize = 1 << 16
+	// handoffMaxFDs is the maximum number of sockets attached to a message.
+	handoffMaxFDs = 128
+)
+
+// handoffMsg is a message of the handoff protocol.
+type handoffMsg struct {
+	// IPv4 and IPv6 are the number of attached IPv4 and IPv6 underlay sockets.
+	// They are followed by the attached listener of the application socket.
+	IPv4 int `json:"ipv4,omitempty"`
+	IPv6 int `json:"ipv6,omitempty"`
+	// App is set if the connection of an application is attached.
+	App bool `json:"app,omitempty"`
+	// Registration is the serialized registration of the application. It is
+	// empty if the application did not register yet.
+	Registration []byte `json:"registration,omitempty"`
+	// Buffered is the data that was read from the application connection but
+	// not processed yet.
+	Buffered []byte `json:"buffered,omitempty"`
+	// Done marks the last message.
+	Done bool `json:"done,omitempty"`
+}
+
+// Handoff is the state of a running dispatcher instance that was handed off
+// to this instance.
+type Handoff struct {
+	ipv4Conns []*net.UDPConn
+	ipv6Conns []*net.UDPConn
+	listener  *reliable.Listener
+	apps      []inheritedApp
+	// conn is the connection to the previous instance. It is closed once the
+	// previous instance terminated.
+	conn *net.UnixConn
+}
+
+type inheritedApp struct {
+	conn         *reliable.Conn
+	registration *reliable.Registration
+}
+
+// TakeOver connects to the handoff socket of a running dispatcher and takes
+// over its underlay sockets, its application socket, and the connections and
+// registrations of its applications. The running dispatcher stops serving
+// them. It returns nil if no dispatcher is listening on the handoff socket.
+func TakeOver(handoffSocket string) (*Handoff, error) {
+	conn, err := net.DialUnix("unixpacket", nil,
+		&net.UnixAddr{Name: handoffSocket, Net: "unixpacket"})
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ECONNREFUSED) {
+			return nil, nil
+		}
+		return nil, serrors.WrapStr("connecting to handoff socket", err,
+			"socket", handoffSocket)
+	}
+	h := &Handoff{conn: conn}
+	if err := h.receive(); err != nil {
+		h.close()
+		return nil, err
+	}
+	log.Info("Took over from running dispatcher", "applications", len(h.apps))
+	return h, nil
+}
+
+func (h *Handoff) receive() error {
+	b := make([]byte, handoffMsgSize)
+	oob := make([]byte, rightsSpace(handoffMaxFDs))
+	for {
+		n, oobn, flags, _, err := h.conn.ReadMsgUnix(b, oob)
+		if err != nil {
+			return serrors.WrapStr("receiving handoff message", err)
+		}
+		if n == 0 {
+			return serrors.New("handoff aborted by running dispatcher")
+		}
+		files, err := parseRights(oob[:oobn])
+		if err != nil {
+			return err
+		}
+		if flags&truncatedFlags != 0 {
+			closeFiles(files)
+			return serrors.New("handoff message truncated")
+		}
+		var msg handoffMsg
+		if err := json.Unmarshal(b[:n], &msg); err != nil {
+			closeFiles(files)
+			return serrors.WrapStr("decoding handoff message", err)
+		}
+		if msg.Done {
+			closeFiles(files)
+			return nil
+		}
+		err = h.add(&msg, files)
+		closeFiles(files)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// add adds the sockets in files to the handoff state. The sockets are
+// duplicated, files must be closed by the caller.
+func (h *Handoff) add(msg *handoffMsg, files []*os.File) error {
+	switch {
+	case msg.App:
+		if len(files) != 1 {
+			return serrors.New("unexpected number of sockets for application",
+				"sockets", len(files))
+		}
+		c, err := net.FileConn(files[0])
+		if err != nil {
+			return serrors.WrapStr("inheriting application connection", err)
+		}
+		uc, ok := c.(*net.UnixConn)
+		if !ok {
+			c.Close()
+			return serrors.New("application connection is not a unix socket")
+		}
+		conn, err := reliable.NewConn(uc, msg.Buffered)
+		if err != nil {
+			uc.Close()
+			return err
+		}
+		app := inheritedApp{conn: conn}
+		if len(msg.Registration) > 0 {
+			app.registration = &reliable.Registration{}
+			if err := app.registration.DecodeFromBytes(msg.Registration); err != nil {
+				conn.Close()
+				return serrors.WrapStr("decoding registration", err)
+			}
+		}
+		h.apps = append(h.apps, app)
+	default:
+		if h.listener != nil || len(files) != msg.IPv4+msg.IPv6+1 {
+			return serrors.New("unexpected sockets", "sockets", len(files),
+				"ipv4", msg.IPv4, "ipv6", msg.IPv6)
+		}
+		for i, f := range files[:msg.IPv4+msg.IPv6] {
+			c, err := net.FilePacketConn(f)
+			if err != nil {
+				return serrors.WrapStr("inheriting underlay socket", err)
+			}
+			uc, ok := c.(*net.UDPConn)
+			if !ok {
+				c.Close()
+				return serrors.New("underlay socket is not a UDP socket")
+			}
+			if i < msg.IPv4 {
+				h.ipv4Conns = append(h.ipv4Conns, uc)
+			} else {
+				h.ipv6Conns = append(h.ipv6Conns, uc)
+			}
+		}
+		l, err := net.FileListener(files[len(files)-1])
+		if err != nil {
+			return serrors.WrapStr("inheriting application socket", err)
+		}
+		ul, ok := l.(*net.UnixListener)
+		if !ok {
+			l.Close()
+			return serrors.New("application socket is not a unix socket")
+		}
+		h.listener = &reliable.Listener{UnixListener: ul}
+	}
+	return nil
+}
+
+// Wait blocks until the previous dispatcher instance terminated or ctx is
+// done. Until then, the previous instance might still use the addresses of its
+// HTTP endpoints.
+func (h *Handoff) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		defer log.HandlePanic()
+		defer close(done)
+		_, _ = io.Copy(io.Discard, h.conn)
+	}()
+	select {
+	case <-done:
+		h.conn.Close()
+		return nil
+	case <-ctx.Done():
+		h.conn.Close()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// close closes all inherited sockets.
+func (h *Handoff) close() {
+	for _, c := range append(h.ipv4Conns, h.ipv6Conns...) {
+		c.Close()
+	}
+	if h.listener != nil {
+		h.listener.SetUnlinkOnClose(false)
+		h.listener.Close()
+	}
+	for _, app := range h.apps {
+		app.conn.Close()
+	}
+	h.conn.Close()
+}
+
+// handOff hands off the sockets and the application connections to the
+// dispatcher instance connected on conn. If the handoff fails before the
+// sockets are sent, the dispatcher keeps serving. Otherwise, the dispatcher
+// stops serving them, even if the handoff fails.
+func (d *Dispatcher) handOff(conn *net.UnixConn) (err error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.closed {
+		return serrors.New("dispatcher closed")
+	}
+	ipv4, ipv6, err := d.dispServer.SyscallConns()
+	if err != nil {
+		return err
+	}
+	listener, err := d.appServer.Listener.SyscallConn()
+	if err != nil {
+		return err
+	}
+	// The new instance only serves the sockets once the handoff is complete,
+	// they can thus be sent before this instance stops serving them.
+	socks := append(append(append([]syscall.RawConn{}, ipv4...), ipv6...), listener)
+	err = sendHandoffMsg(conn, &handoffMsg{IPv4: len(ipv4), IPv6: len(ipv6)}, socks...)
+	if err != nil {
+		return err
+	}
+
+	d.closed = true
+	defer func() {
+		if err != nil {
+			d.handoffErr = err
+		}
+	}()
+	// Closing the handoff listener unlinks the handoff socket, the new
+	// instance listens on it once the handoff is complete.
+	d.handoffListener.Close()
+
+	d.dispServer.Stop()
+	apps := d.appServer.detach()
+	// The local copies of the sockets are closed once they are sent. The
+	// sockets remain open in the new instance. The application socket must
+	// not be unlinked, the new instance keeps using it.
+	d.appServer.Listener.SetUnlinkOnClose(false)
+	defer d.dispServer.Close()
+	defer d.appServer.Listener.Close()
+	for _, app := range apps {
+		if app.conn != nil {
+			defer app.conn.Close()
+		}
+	}
+
+	handedOff := 0
+	for _, app := range apps {
+		if app.conn == nil {
+			continue
+		}
+		msg := &handoffMsg{App: true, Buffered: app.buffered}
+		if app.registration != nil {
+			b := make([]byte, handoffMsgSize)
+			n, err := app.registration.SerializeTo(b)
+			if err != nil {
+				return serrors.WrapStr("serializing registration", err)
+			}
+			msg.Registration = b[:n]
+		}
+		rc, err := app.conn.SyscallConn()
+		if err != nil {
+			return err
+		}
+		if err := sendHandoffMsg(conn, msg, rc); err != nil {
+			return err
+		}
+		handedOff++
+	}
+	if err := sendHandoffMsg(conn, &handoffMsg{Done: true}); err != nil {
+		return err
+	}
+	// The connection is kept open until the process terminates, see
+	// ReleaseHandoff.
+	d.handoffConn = conn
+	d.handedOff = true
+	log.Info("Handed off to new dispatcher instance", "applications", handedOff)
+	return nil
+}
+
+// sendHandoffMsg sends msg with the sockets socks attached.
+func sendHandoffMsg(conn *net.UnixConn, msg *handoffMsg, socks ...syscall.RawConn) error {
+	if len(socks) > handoffMaxFDs {
+		return serrors.New("too many sockets", "sockets", len(socks), "max", handoffMaxFDs)
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return serrors.WrapStr("encoding handoff message", err)
+	}
+	fds := make([]int, 0, len(socks))
+	for _, sock := range socks {
+		// The file descriptor stays valid after Control returns, since the
+		// socket is only closed after the message is sent.
+		err := sock.Control(func(fd uintptr) { fds = append(fds, int(fd)) })
+		if err != nil {
+			return serrors.WrapStr("accessing socket", err)
+		}
+	}
+	var oob []byte
+	if len(fds) > 0 {
+		if oob, err = unixRights(fds); err != nil {
+			return err
+		}
+	}
+	if _, _, err := conn.WriteMsgUnix(b, oob, nil); err != nil {
+		return serrors.WrapStr("sending handoff message", err)
+	}
+	return nil
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		f.Close()
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"net"
+	"os"
+	"syscall"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// checkHandoffPeer checks that the process connected on conn runs as the same
+// user as the dispatcher.
+func checkHandoffPeer(conn *net.UnixConn) error {
+	rc, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var cred *syscall.Ucred
+	var credErr error
+	err = rc.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET,
+			syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return err
+	}
+	if credErr != nil {
+		return serrors.WrapStr("reading peer credentials", credErr)
+	}
+	if uid := os.Geteuid(); int(cred.Uid) != uid {
+		return serrors.New("peer runs as different user", "peer_uid", cred.Uid,
+			"uid", uid, "peer_pid", cred.Pid)
+	}
+	return nil
+}
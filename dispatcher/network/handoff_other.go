@@ -0,0 +1,29 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux && !freebsd
+
+package network
+
+import (
+	"net"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// checkHandoffPeer rejects all handoff requests, the credentials of the peer
+// cannot be checked on this platform.
+func checkHandoffPeer(*net.UnixConn) error {
+	return serrors.New("peer credentials not supported on this platform")
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package network
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// truncatedFlags are the flags with which a truncated handoff message is
+// received.
+const truncatedFlags = syscall.MSG_TRUNC | syscall.MSG_CTRUNC
+
+// rightsSpace returns the size of the control message buffer for n passed
+// sockets.
+func rightsSpace(n int) int {
+	return syscall.CmsgSpace(4 * n)
+}
+
+// unixRights encodes the sockets as SCM_RIGHTS control message.
+func unixRights(fds []int) ([]byte, error) {
+	return syscall.UnixRights(fds...), nil
+}
+
+// parseRights returns the files passed in the SCM_RIGHTS control messages.
+func parseRights(oob []byte) ([]*os.File, error) {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing control message", err)
+	}
+	var files []*os.File
+	for _, m := range msgs {
+		fds, err := syscall.ParseUnixRights(&m)
+		if err != nil {
+			closeFiles(files)
+			return nil, serrors.WrapStr("parsing passed sockets", err)
+		}
+		for _, fd := range fds {
+			files = append(files, os.NewFile(uintptr(fd), "handoff"))
+		}
+	}
+	return files, nil
+}
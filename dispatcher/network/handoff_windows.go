@@ -0,0 +1,38 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"os"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// The handoff relies on passing sockets in SCM_RIGHTS control messages, which
+// is not supported on windows.
+
+const truncatedFlags = 0
+
+func rightsSpace(int) int {
+	return 0
+}
+
+func unixRights([]int) ([]byte, error) {
+	return nil, serrors.New("passing sockets is not supported on windows")
+}
+
+func parseRights([]byte) ([]*os.File, error) {
+	return nil, serrors.New("passing sockets is not supported on windows")
+}
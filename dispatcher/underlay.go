@@ -16,7 +16,9 @@
 package dispatcher
 
 import (
+	"errors"
 	"net"
+	"time"
 
 	"github.com/google/gopacket"
 
@@ -29,6 +31,8 @@ import (
 	"github.com/scionproto/scion/pkg/slayers"
 	"github.com/scionproto/scion/pkg/slayers/path/epic"
 	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/pkg/stun"
+	"github.com/scionproto/scion/private/ratelimit"
 	"github.com/scionproto/scion/private/ringbuf"
 )
 
@@ -48,8 +52,20 @@ const (
 type NetToRingDataplane struct {
 	UnderlayConn net.PacketConn
 	RoutingTable *IATable
+	// NAT, if not nil, handles the STUN responses read from the underlay
+	// socket and translates the destination host address of the packets.
+	NAT *NAT
+	// SCMPErrorLimiter, if not nil, limits the rate of the SCMP error
+	// messages delivered per source AS.
+	SCMPErrorLimiter *ratelimit.Limiter
+	// Done, if not nil, stops the dataplane once it is closed and the read
+	// from the underlay socket returns, e.g., because the read deadline was
+	// set to interrupt it.
+	Done <-chan struct{}
 }
 
+// Run reads packets from the underlay socket until the socket is closed or the
+// dataplane is stopped with Done.
 func (dp *NetToRingDataplane) Run() error {
 	for {
 		pkt := respool.GetPacket()
@@ -57,10 +73,38 @@ func (dp *NetToRingDataplane) Run() error {
 		// let the GC take care of this situation as they should be fairly
 		// rare.
 
-		if err := pkt.DecodeFromConn(dp.UnderlayConn); err != nil {
+		if err := pkt.ReadFromConn(dp.UnderlayConn); err != nil {
+			if dp.stopped() {
+				return nil
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
 			log.Debug("error receiving next packet from underlay conn", "err", err)
 			continue
 		}
+		if dp.NAT != nil && stun.Is(pkt.Bytes()) {
+			dp.NAT.handleResponse(pkt.Bytes(), pkt.UnderlayRemote)
+			pkt.Free()
+			continue
+		}
+		if err := pkt.Decode(); err != nil {
+			log.Debug("error decoding next packet from underlay conn", "err", err)
+			continue
+		}
+		if dp.NAT != nil {
+			dp.NAT.translateDst(pkt)
+		}
+		if dp.SCMPErrorLimiter != nil && pkt.L4 == slayers.LayerTypeSCMP &&
+			!pkt.SCMP.TypeCode.InfoMsg() &&
+			!dp.SCMPErrorLimiter.Allow(uint64(pkt.SCION.SrcIA), time.Now()) {
+
+			metrics.M.NetReadPkts(
+				metrics.IncomingPacket{Result: metrics.PacketResultRateLimited},
+			).Inc()
+			pkt.Free()
+			continue
+		}
 		dst, err := getDst(pkt)
 		if err != nil {
 			log.Debug("unable to route packet", "err", err)
@@ -74,6 +118,15 @@ func (dp *NetToRingDataplane) Run() error {
 	}
 }
 
+func (dp *NetToRingDataplane) stopped() bool {
+	select {
+	case <-dp.Done:
+		return true
+	default:
+		return false
+	}
+}
+
 func getDst(pkt *respool.Packet) (Destination, error) {
 	switch pkt.L4 {
 	case slayers.LayerTypeSCIONUDP:
@@ -24,18 +24,22 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
+	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/scionproto/scion/gateway"
 	"github.com/scionproto/scion/gateway/config"
+	"github.com/scionproto/scion/gateway/control"
 	"github.com/scionproto/scion/gateway/dataplane"
 	api "github.com/scionproto/scion/gateway/mgmtapi"
+	"github.com/scionproto/scion/gateway/pathhealth"
 	"github.com/scionproto/scion/pkg/daemon"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/snet/addrutil"
 	"github.com/scionproto/scion/pkg/sock/reliable"
 	"github.com/scionproto/scion/private/app"
+	"github.com/scionproto/scion/private/app/command"
 	"github.com/scionproto/scion/private/app/launcher"
 	"github.com/scionproto/scion/private/service"
 )
@@ -46,6 +50,8 @@ func main() {
 	application := launcher.Application{
 		TOMLConfig: &globalCfg,
 		ShortName:  "SCION IP Gateway",
+		Commands:   []func(command.Pather) *cobra.Command{newSessions},
+		Validate:   validateConfig,
 		Main:       realMain,
 	}
 	application.Run()
@@ -55,10 +61,7 @@ func realMain(ctx context.Context) error {
 	daemonService := &daemon.Service{
 		Address: globalCfg.Daemon.Address,
 	}
-	daemon, err := daemonService.Connect(ctx)
-	if err != nil {
-		return serrors.WrapStr("connecting to daemon", err)
-	}
+	daemon := &daemon.ReconnectingConnector{Factory: daemonService}
 	defer daemon.Close()
 	localIA, err := daemon.LocalIA(ctx)
 	if err != nil {
@@ -95,6 +98,7 @@ func realMain(ctx context.Context) error {
 		probeAddress.IP = controlAddress.IP
 		probeAddress.Zone = controlAddress.Zone
 	}
+	pathOverrides := &pathhealth.PathOverrides{}
 	var cleanup app.Cleanup
 	g, errCtx := errgroup.WithContext(ctx)
 	if globalCfg.API.Addr != "" {
@@ -105,9 +109,10 @@ func realMain(ctx context.Context) error {
 		r.Get("/", api.ServeSpecInteractive)
 		r.Get("/openapi.json", api.ServeSpecJSON)
 		server := api.Server{
-			Config:   service.NewConfigStatusPage(globalCfg).Handler,
-			Info:     service.NewInfoStatusPage().Handler,
-			LogLevel: service.NewLogLevelStatusPage().Handler,
+			Config:        service.NewConfigStatusPage(globalCfg).Handler,
+			Info:          service.NewInfoStatusPage().Handler,
+			LogLevel:      service.NewLogLevelStatusPage().Handler,
+			PathOverrides: pathOverrides,
 		}
 		log.Info("Exposing API", "addr", globalCfg.API.Addr)
 		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
@@ -133,6 +138,7 @@ func realMain(ctx context.Context) error {
 		"log/level": service.NewLogLevelStatusPage(),
 	}
 	routingTable := &dataplane.AtomicRoutingTable{}
+	encryption := globalCfg.Gateway.DataplaneEncryption
 	gw := &gateway.Gateway{
 		ID:                       globalCfg.Gateway.ID,
 		TrafficPolicyFile:        globalCfg.Gateway.TrafficPolicy,
@@ -143,6 +149,11 @@ func realMain(ctx context.Context) error {
 		PathMonitorIP:            controlAddressIP,
 		ProbeServerAddr:          probeAddress,
 		ProbeClientIP:            controlAddress.IP,
+		PathProbeInterval:        globalCfg.Gateway.PathProbeInterval.Duration,
+		PathProbeUpThreshold:     globalCfg.Gateway.PathProbeUpThreshold,
+		PathProbeDownThreshold:   globalCfg.Gateway.PathProbeDownThreshold,
+		SessionProbeInterval:     globalCfg.Gateway.SessionProbeInterval.Duration,
+		SessionHealthExpiration:  globalCfg.Gateway.SessionHealthExpiration.Duration,
 		DataServerAddr:           dataAddress,
 		DataClientIP:             dataAddress.IP,
 		Dispatcher:               reliable.NewDispatcher(""),
@@ -150,11 +161,16 @@ func realMain(ctx context.Context) error {
 		RouteSourceIPv4:          globalCfg.Tunnel.SrcIPv4,
 		RouteSourceIPv6:          globalCfg.Tunnel.SrcIPv6,
 		TunnelName:               globalCfg.Tunnel.Name,
+		PathMTUDiscovery:         globalCfg.Tunnel.PathMTUDiscovery,
+		EncryptDataplane:         encryption != config.DataplaneEncryptionDisabled,
+		RequireEncryption:        encryption == config.DataplaneEncryptionRequired,
+		PathOverrides:            pathOverrides,
 		RoutingTableReader:       routingTable,
 		RoutingTableSwapper:      routingTable,
 		ConfigReloadTrigger:      app.SIGHUPChannel(ctx),
 		HTTPEndpoints:            httpPages,
 		HTTPServeMux:             http.DefaultServeMux,
+		Health:                   &service.Health{},
 		Metrics:                  gateway.NewMetrics(localIA),
 	}
 
@@ -174,3 +190,14 @@ func realMain(ctx context.Context) error {
 
 	return g.Wait()
 }
+
+// validateConfig loads the traffic policy and the IP routing policy referenced
+// by the configuration and reports all errors.
+func validateConfig(ctx context.Context) error {
+	loader := &gateway.Loader{
+		SessionPoliciesFile: globalCfg.Gateway.TrafficPolicy,
+		RoutingPolicyFile:   globalCfg.Gateway.IPRoutingPolicy,
+		SessionPolicyParser: &control.LegacySessionPolicyAdapter{},
+	}
+	return loader.Check(ctx)
+}
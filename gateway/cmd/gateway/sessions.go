@@ -0,0 +1,266 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/gateway/config"
+	api "github.com/scionproto/scion/gateway/mgmtapi"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/app/command"
+	libconfig "github.com/scionproto/scion/private/config"
+)
+
+type sessionsFlags struct {
+	config  string
+	addr    string
+	timeout time.Duration
+}
+
+func newSessions(pather command.Pather) *cobra.Command {
+	var flags sessionsFlags
+	var cmd = &cobra.Command{
+		Use:   "sessions",
+		Short: "Manage the path selection of the sessions of a running gateway",
+		Long: `'sessions' overrides the automatic path selection of the sessions to a
+remote AS via the API of a running gateway.
+
+As long as at least one of the pinned paths to a remote AS is alive and
+allowed by the path policy, only pinned paths are used. Otherwise, the
+gateway falls back to the other paths. Excluded paths are never used. The
+fingerprints of the paths are listed on the status page of the gateway.
+
+The overrides are not persisted. They are lost when the gateway restarts.
+
+The address of the API is read from the api section of the gateway
+configuration file. It can be overridden with the --addr flag.
+`,
+	}
+	cmd.PersistentFlags().StringVar(&flags.config, "config", "",
+		"Configuration file of the gateway")
+	cmd.PersistentFlags().StringVar(&flags.addr, "addr", "", "Address of the gateway API")
+	cmd.PersistentFlags().DurationVar(&flags.timeout, "timeout", 5*time.Second,
+		"Timeout for querying the gateway API")
+	cmd.AddCommand(
+		newSessionsList(cmd, &flags),
+		newSessionsModify(cmd, &flags, "pin", "Pin a path to a remote AS",
+			func(o *api.PathOverride, fp string) {
+				o.Excluded = removeFingerprint(o.Excluded, fp)
+				o.Pinned = addFingerprint(o.Pinned, fp)
+			}),
+		newSessionsModify(cmd, &flags, "exclude", "Exclude a path to a remote AS",
+			func(o *api.PathOverride, fp string) {
+				o.Pinned = removeFingerprint(o.Pinned, fp)
+				o.Excluded = addFingerprint(o.Excluded, fp)
+			}),
+		newSessionsModify(cmd, &flags, "unpin", "Remove a pinned or excluded path",
+			func(o *api.PathOverride, fp string) {
+				o.Pinned = removeFingerprint(o.Pinned, fp)
+				o.Excluded = removeFingerprint(o.Excluded, fp)
+			}),
+		newSessionsClear(cmd, &flags),
+	)
+	return cmd
+}
+
+func newSessionsList(pather command.Pather, flags *sessionsFlags) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "list",
+		Short:   "List the pinned and excluded paths",
+		Example: fmt.Sprintf("  %s list --config gateway.toml", pather.CommandPath()),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := flags.client()
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancelF := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancelF()
+			overrides, err := fetchPathOverrides(ctx, client)
+			if err != nil {
+				return err
+			}
+			return writePathOverrides(cmd.OutOrStdout(), overrides)
+		},
+	}
+	return cmd
+}
+
+func newSessionsModify(pather command.Pather, flags *sessionsFlags, use, short string,
+	modify func(o *api.PathOverride, fingerprint string)) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:   use + " <remote> <path-fingerprint>",
+		Short: short,
+		Example: fmt.Sprintf("  %s %s 1-ff00:0:110 0ab3e7f0c1b0a98b6a5e6b7d3c2a5f4e..."+
+			" --config gateway.toml", pather.CommandPath(), use),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ia, err := addr.ParseIA(args[0])
+			if err != nil {
+				return serrors.WrapStr("parsing remote", err)
+			}
+			fp, err := snet.ParsePathFingerprint(args[1])
+			if err != nil {
+				return err
+			}
+			client, err := flags.client()
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancelF := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancelF()
+			overrides, err := fetchPathOverrides(ctx, client)
+			if err != nil {
+				return err
+			}
+			override := api.PathOverride{IsdAs: ia.String()}
+			for _, o := range overrides {
+				if o.IsdAs == ia.String() {
+					override = o
+				}
+			}
+			modify(&override, fp.String())
+			rep, err := client.SetPathOverrideWithResponse(ctx, ia.String(),
+				api.PathOverrideConfig{Pinned: &override.Pinned, Excluded: &override.Excluded})
+			if err != nil {
+				return serrors.WrapStr("setting path override", err)
+			}
+			if rep.JSON200 == nil {
+				return apiError(rep.StatusCode(), rep.JSON400, rep.Body)
+			}
+			return writePathOverrides(cmd.OutOrStdout(), []api.PathOverride{*rep.JSON200})
+		},
+	}
+	return cmd
+}
+
+func newSessionsClear(pather command.Pather, flags *sessionsFlags) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "clear <remote>",
+		Short:   "Remove all pinned and excluded paths to a remote AS",
+		Example: fmt.Sprintf("  %s clear 1-ff00:0:110 --config gateway.toml", pather.CommandPath()),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ia, err := addr.ParseIA(args[0])
+			if err != nil {
+				return serrors.WrapStr("parsing remote", err)
+			}
+			client, err := flags.client()
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancelF := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancelF()
+			rep, err := client.DeletePathOverrideWithResponse(ctx, ia.String())
+			if err != nil {
+				return serrors.WrapStr("removing path override", err)
+			}
+			if rep.StatusCode() != http.StatusNoContent {
+				return apiError(rep.StatusCode(), rep.JSON400, rep.Body)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// client creates the client for the gateway API. The address of the API is
+// taken from the configuration file, unless it is set explicitly.
+func (f *sessionsFlags) client() (*api.ClientWithResponses, error) {
+	if f.config != "" && f.addr == "" {
+		var cfg config.Config
+		if err := libconfig.LoadFile(f.config, &cfg); err != nil {
+			return nil, serrors.WrapStr("loading config from file", err, "file", f.config)
+		}
+		f.addr = cfg.API.Addr
+	}
+	if f.addr == "" {
+		return nil, serrors.New("API address must be specified either in the " +
+			"configuration file or with flags")
+	}
+	return api.NewClientWithResponses("http://" + f.addr + "/api/v1")
+}
+
+func fetchPathOverrides(ctx context.Context,
+	client *api.ClientWithResponses) ([]api.PathOverride, error) {
+
+	rep, err := client.GetPathOverridesWithResponse(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("fetching path overrides", err)
+	}
+	if rep.JSON200 == nil {
+		return nil, apiError(rep.StatusCode(), nil, rep.Body)
+	}
+	return *rep.JSON200, nil
+}
+
+func apiError(status int, problem *api.Problem, body []byte) error {
+	if problem != nil && problem.Detail != nil {
+		return serrors.New(problem.Title, "status", status, "detail", *problem.Detail)
+	}
+	return serrors.New("unexpected response", "status", status,
+		"body", strings.TrimSpace(string(body)))
+}
+
+func writePathOverrides(out io.Writer, overrides []api.PathOverride) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REMOTE\tSTATE\tFINGERPRINT")
+	for _, o := range overrides {
+		for _, fp := range o.Pinned {
+			fmt.Fprintf(w, "%s\tpinned\t%s\n", o.IsdAs, fp)
+		}
+		for _, fp := range o.Excluded {
+			fmt.Fprintf(w, "%s\texcluded\t%s\n", o.IsdAs, fp)
+		}
+	}
+	return w.Flush()
+}
+
+func addFingerprint(fingerprints []string, fp string) []string {
+	for _, f := range fingerprints {
+		if f == fp {
+			return fingerprints
+		}
+	}
+	return append(fingerprints, fp)
+}
+
+func removeFingerprint(fingerprints []string, fp string) []string {
+	result := []string{}
+	for _, f := range fingerprints {
+		if f != fp {
+			result = append(result, f)
+		}
+	}
+	return result
+}
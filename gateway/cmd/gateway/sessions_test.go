@@ -0,0 +1,90 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/scionproto/scion/gateway/mgmtapi"
+	"github.com/scionproto/scion/gateway/pathhealth"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+func TestSessions(t *testing.T) {
+	overrides := &pathhealth.PathOverrides{}
+	srv := httptest.NewServer(api.HandlerFromMuxWithBaseURL(
+		&api.Server{PathOverrides: overrides}, chi.NewRouter(), "/api/v1"))
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	run := func(args ...string) (string, error) {
+		cmd := newSessions(&cobra.Command{Use: "gateway"})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append(args, "--addr", addr))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	fp1 := snet.PathFingerprint(bytes.Repeat([]byte{1}, 32))
+	fp2 := snet.PathFingerprint(bytes.Repeat([]byte{2}, 32))
+
+	_, err := run("pin", ia.String(), fp1.String())
+	require.NoError(t, err)
+	assert.Equal(t, pathhealth.PathOverride{Pinned: []snet.PathFingerprint{fp1}},
+		overrides.Get(ia))
+
+	_, err = run("exclude", ia.String(), fp2.String())
+	require.NoError(t, err)
+	assert.Equal(t, pathhealth.PathOverride{
+		Pinned:   []snet.PathFingerprint{fp1},
+		Excluded: []snet.PathFingerprint{fp2},
+	}, overrides.Get(ia))
+
+	_, err = run("exclude", ia.String(), fp1.String())
+	require.NoError(t, err)
+	assert.Equal(t, pathhealth.PathOverride{Excluded: []snet.PathFingerprint{fp2, fp1}},
+		overrides.Get(ia))
+
+	out, err := run("list")
+	require.NoError(t, err)
+	assert.Contains(t, out, ia.String()+"  excluded  "+fp2.String())
+	assert.Contains(t, out, ia.String()+"  excluded  "+fp1.String())
+
+	_, err = run("unpin", ia.String(), fp2.String())
+	require.NoError(t, err)
+	assert.Equal(t, []snet.PathFingerprint{fp1}, overrides.Get(ia).Excluded)
+
+	_, err = run("clear", ia.String())
+	require.NoError(t, err)
+	assert.Equal(t, pathhealth.PathOverride{}, overrides.Get(ia))
+	assert.Empty(t, overrides.Remotes())
+
+	_, err = run("pin", ia.String(), "garbage")
+	assert.Error(t, err)
+	_, err = run("pin", "garbage", fp1.String())
+	assert.Error(t, err)
+}
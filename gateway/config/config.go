@@ -18,8 +18,11 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/env"
 	api "github.com/scionproto/scion/private/mgmtapi"
@@ -43,6 +46,24 @@ const (
 
 	DefaultTunnelName           = "sig"
 	DefaultTunnelRoutingTableID = 11
+
+	DefaultPathProbeInterval       = 500 * time.Millisecond
+	DefaultPathProbeUpThreshold    = 3
+	DefaultPathProbeDownThreshold  = 2
+	DefaultSessionProbeInterval    = 500 * time.Millisecond
+	DefaultSessionHealthExpiration = 2 * time.Second
+)
+
+// Dataplane encryption modes.
+const (
+	// DataplaneEncryptionDisabled sends all frames as plain SCION/UDP packets.
+	DataplaneEncryptionDisabled = "disabled"
+	// DataplaneEncryptionPreferred sends frames over QUIC if the remote gateway
+	// supports it and falls back to plain packets otherwise. The QUIC
+	// connection is retried while plain packets are sent.
+	DataplaneEncryptionPreferred = "preferred"
+	// DataplaneEncryptionRequired only sends and accepts frames over QUIC.
+	DataplaneEncryptionRequired = "required"
 )
 
 type Config struct {
@@ -107,6 +128,24 @@ type Gateway struct {
 	DataAddr string `toml:"data_addr,omitempty"`
 	// Probe address, for probing paths.
 	ProbeAddr string `toml:"probe_addr,omitempty"`
+	// PathProbeInterval is the interval at which paths to remote ASes are
+	// probed.
+	PathProbeInterval util.DurWrap `toml:"path_probe_interval,omitempty"`
+	// PathProbeUpThreshold is the number of consecutive probe replies after
+	// which a path is considered healthy.
+	PathProbeUpThreshold int `toml:"path_probe_up_threshold,omitempty"`
+	// PathProbeDownThreshold is the number of probe intervals without a reply
+	// after which a path is considered unhealthy.
+	PathProbeDownThreshold int `toml:"path_probe_down_threshold,omitempty"`
+	// SessionProbeInterval is the interval at which remote gateways are
+	// probed.
+	SessionProbeInterval util.DurWrap `toml:"session_probe_interval,omitempty"`
+	// SessionHealthExpiration is the duration after the last probe reply after
+	// which a session to a remote gateway is considered unhealthy.
+	SessionHealthExpiration util.DurWrap `toml:"session_health_expiration,omitempty"`
+	// DataplaneEncryption is the encryption mode of the frames exchanged with
+	// remote gateways. One of "disabled", "preferred" or "required".
+	DataplaneEncryption string `toml:"dataplane_encryption,omitempty"`
 }
 
 func (cfg *Gateway) Validate() error {
@@ -119,6 +158,51 @@ func (cfg *Gateway) Validate() error {
 	cfg.CtrlAddr = DefaultAddress(cfg.CtrlAddr, defaultCtrlPort)
 	cfg.DataAddr = DefaultAddress(cfg.DataAddr, defaultDataPort)
 	cfg.ProbeAddr = DefaultAddress(cfg.ProbeAddr, defaultProbePort)
+	if cfg.PathProbeInterval.Duration == 0 {
+		cfg.PathProbeInterval.Duration = DefaultPathProbeInterval
+	}
+	if cfg.PathProbeUpThreshold == 0 {
+		cfg.PathProbeUpThreshold = DefaultPathProbeUpThreshold
+	}
+	if cfg.PathProbeDownThreshold == 0 {
+		cfg.PathProbeDownThreshold = DefaultPathProbeDownThreshold
+	}
+	if cfg.SessionProbeInterval.Duration == 0 {
+		cfg.SessionProbeInterval.Duration = DefaultSessionProbeInterval
+	}
+	if cfg.SessionHealthExpiration.Duration == 0 {
+		cfg.SessionHealthExpiration.Duration = DefaultSessionHealthExpiration
+	}
+	if cfg.PathProbeInterval.Duration < 0 {
+		return serrors.New("path_probe_interval must be positive",
+			"value", cfg.PathProbeInterval)
+	}
+	if cfg.PathProbeUpThreshold < 0 {
+		return serrors.New("path_probe_up_threshold must be positive",
+			"value", cfg.PathProbeUpThreshold)
+	}
+	if cfg.PathProbeDownThreshold < 0 {
+		return serrors.New("path_probe_down_threshold must be positive",
+			"value", cfg.PathProbeDownThreshold)
+	}
+	if cfg.SessionProbeInterval.Duration < 0 {
+		return serrors.New("session_probe_interval must be positive",
+			"value", cfg.SessionProbeInterval)
+	}
+	if cfg.SessionHealthExpiration.Duration <= cfg.SessionProbeInterval.Duration {
+		return serrors.New("session_health_expiration must be larger than "+
+			"session_probe_interval",
+			"session_health_expiration", cfg.SessionHealthExpiration,
+			"session_probe_interval", cfg.SessionProbeInterval)
+	}
+	switch cfg.DataplaneEncryption {
+	case "":
+		cfg.DataplaneEncryption = DataplaneEncryptionDisabled
+	case DataplaneEncryptionDisabled, DataplaneEncryptionPreferred, DataplaneEncryptionRequired:
+	default:
+		return serrors.New("invalid dataplane_encryption",
+			"value", cfg.DataplaneEncryption)
+	}
 	return nil
 }
 
@@ -140,6 +224,8 @@ type Tunnel struct {
 	SrcIPv4 net.IP `toml:"src_ipv4,omitempty"`
 	// SrcIPv6 is the source address to put into the routing table.
 	SrcIPv6 net.IP `toml:"src_ipv6,omitempty"`
+	// PathMTUDiscovery enables path MTU discovery for tunneled IP packets.
+	PathMTUDiscovery bool `toml:"path_mtu_discovery,omitempty"`
 }
 
 func (cfg *Tunnel) Validate() error {
@@ -17,13 +17,16 @@ package config_test
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	toml "github.com/pelletier/go-toml"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/gateway/config"
-	"github.com/scionproto/scion/gateway/config/configtest"
+	gwconfigtest "github.com/scionproto/scion/gateway/config/configtest"
 	"github.com/scionproto/scion/pkg/log/logtest"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/private/config/configtest"
 	"github.com/scionproto/scion/private/env/envtest"
 	apitest "github.com/scionproto/scion/private/mgmtapi/mgmtapitest"
 )
@@ -37,20 +40,82 @@ func TestConfigSample(t *testing.T) {
 	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
 	assert.NoError(t, err)
 	CheckConfig(t, &cfg)
+	configtest.CheckSampleCoverage(t, &cfg)
+}
+
+func TestGatewayValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Modify    func(cfg *config.Gateway)
+		AssertErr assert.ErrorAssertionFunc
+	}{
+		"defaults": {
+			Modify:    func(cfg *config.Gateway) {},
+			AssertErr: assert.NoError,
+		},
+		"custom values": {
+			Modify: func(cfg *config.Gateway) {
+				cfg.PathProbeInterval = util.DurWrap{Duration: 100 * time.Millisecond}
+				cfg.PathProbeDownThreshold = 3
+				cfg.SessionProbeInterval = util.DurWrap{Duration: 100 * time.Millisecond}
+				cfg.SessionHealthExpiration = util.DurWrap{Duration: 300 * time.Millisecond}
+			},
+			AssertErr: assert.NoError,
+		},
+		"negative path probe interval": {
+			Modify: func(cfg *config.Gateway) {
+				cfg.PathProbeInterval = util.DurWrap{Duration: -time.Second}
+			},
+			AssertErr: assert.Error,
+		},
+		"negative down threshold": {
+			Modify: func(cfg *config.Gateway) {
+				cfg.PathProbeDownThreshold = -1
+			},
+			AssertErr: assert.Error,
+		},
+		"health expiration below probe interval": {
+			Modify: func(cfg *config.Gateway) {
+				cfg.SessionProbeInterval = util.DurWrap{Duration: time.Second}
+				cfg.SessionHealthExpiration = util.DurWrap{Duration: 500 * time.Millisecond}
+			},
+			AssertErr: assert.Error,
+		},
+		"required dataplane encryption": {
+			Modify: func(cfg *config.Gateway) {
+				cfg.DataplaneEncryption = config.DataplaneEncryptionRequired
+			},
+			AssertErr: assert.NoError,
+		},
+		"invalid dataplane encryption": {
+			Modify: func(cfg *config.Gateway) {
+				cfg.DataplaneEncryption = "always"
+			},
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var cfg config.Gateway
+			tc.Modify(&cfg)
+			tc.AssertErr(t, cfg.Validate())
+		})
+	}
 }
 
 func InitConfig(cfg *config.Config) {
 	envtest.InitTest(nil, &cfg.Metrics, nil, &cfg.Daemon)
 	logtest.InitTestLogging(&cfg.Logging)
 	apitest.InitConfig(&cfg.API)
-	configtest.InitGateway(&cfg.Gateway)
-	configtest.InitTunnel(&cfg.Tunnel)
+	gwconfigtest.InitGateway(&cfg.Gateway)
+	gwconfigtest.InitTunnel(&cfg.Tunnel)
 }
 
 func CheckConfig(t *testing.T, cfg *config.Config) {
 	envtest.CheckTest(t, nil, &cfg.Metrics, nil, &cfg.Daemon, "gateway")
 	logtest.CheckTestLogging(t, &cfg.Logging, "gateway")
-	configtest.CheckGateway(t, &cfg.Gateway)
+	gwconfigtest.CheckGateway(t, &cfg.Gateway)
 	apitest.CheckConfig(t, &cfg.API)
-	configtest.CheckTunnel(t, &cfg.Tunnel)
+	gwconfigtest.CheckTunnel(t, &cfg.Tunnel)
 }
@@ -31,10 +31,17 @@ func CheckGateway(t *testing.T, cfg *config.Gateway) {
 	assert.Equal(t, config.DefaultCtrlAddr, cfg.CtrlAddr)
 	assert.Equal(t, config.DefaultDataAddr, cfg.DataAddr)
 	assert.Equal(t, config.DefaultProbeAddr, cfg.ProbeAddr)
+	assert.Equal(t, config.DefaultPathProbeInterval, cfg.PathProbeInterval.Duration)
+	assert.Equal(t, config.DefaultPathProbeUpThreshold, cfg.PathProbeUpThreshold)
+	assert.Equal(t, config.DefaultPathProbeDownThreshold, cfg.PathProbeDownThreshold)
+	assert.Equal(t, config.DefaultSessionProbeInterval, cfg.SessionProbeInterval.Duration)
+	assert.Equal(t, config.DefaultSessionHealthExpiration, cfg.SessionHealthExpiration.Duration)
+	assert.Equal(t, config.DataplaneEncryptionDisabled, cfg.DataplaneEncryption)
 }
 
 func InitTunnel(cfg *config.Tunnel) {}
 
 func CheckTunnel(t *testing.T, cfg *config.Tunnel) {
 	assert.Equal(t, config.DefaultTunnelName, cfg.Name)
+	assert.False(t, cfg.PathMTUDiscovery)
 }
@@ -57,6 +57,44 @@ data_addr = ":30056"
 #
 # (default ":30856")
 probe_addr = ":30856"
+
+# The interval at which paths to remote ASes are probed with SCMP traceroute
+# requests. (default "500ms")
+path_probe_interval = "500ms"
+
+# The number of consecutive probe replies after which a path is considered
+# healthy. (default 3)
+path_probe_up_threshold = 3
+
+# The number of probe intervals without a reply after which a path is
+# considered unhealthy and traffic is moved to another path. The time to detect
+# a failed path is path_probe_interval * path_probe_down_threshold.
+# (default 2)
+path_probe_down_threshold = 2
+
+# The interval at which remote gateways are probed. (default "500ms")
+session_probe_interval = "500ms"
+
+# The duration after the last probe reply from a remote gateway after which the
+# session to it is considered unhealthy and traffic fails over to the next
+# session. Must be larger than session_probe_interval. (default "2s")
+session_health_expiration = "2s"
+
+# The encryption mode of the encapsulated traffic exchanged with remote
+# gateways. If enabled, frames are sent as QUIC datagrams over the data
+# address. Note that the QUIC connections use ephemeral self-signed
+# certificates, i.e., this is only opportunistic encryption: the traffic is
+# protected against passive eavesdroppers but the remote gateway is not
+# authenticated.
+#
+# (default "disabled")
+#
+# Values:
+#  "disabled"  -> frames are sent as plain SCION/UDP packets.
+#  "preferred" -> frames are sent over QUIC if the remote gateway supports it,
+#                 and as plain packets otherwise.
+#  "required"  -> frames are only sent and accepted over QUIC.
+dataplane_encryption = "disabled"
 `
 
 const tunnelSample = `
@@ -68,4 +106,11 @@ src_ipv4 = "192.0.2.100"
 # Source hint to put to put into the routing table for IPv6 routes.
 # (default "")
 src_ipv6 = "2001:db8::2:1"
+# Enable path MTU discovery for tunneled IP packets. If enabled, IPv6 packets
+# and IPv4 packets with the don't fragment flag that exceed the MTU of the SCION
+# path are dropped, and an ICMP "fragmentation needed" or "packet too big"
+# error is sent back to the sender. If disabled, such packets are split across
+# multiple encapsulated frames and reassembled by the remote gateway.
+# (default false)
+path_mtu_discovery = false
 `
@@ -72,6 +72,14 @@ type Engine struct {
 	// DataplaneSessionFactory is used to construct dataplane sessions.
 	DataplaneSessionFactory DataplaneSessionFactory
 
+	// SessionProbeInterval is the interval at which remote gateways are probed by the
+	// session monitors. If zero, the session monitor default is used.
+	SessionProbeInterval time.Duration
+
+	// SessionHealthExpiration is the duration after the last successful probe after which a
+	// session is considered unhealthy. If zero, the session monitor default is used.
+	SessionHealthExpiration time.Duration
+
 	// Metrics are the metrics which are modified during the operation of the engine.
 	// If empty, no metrics are reported.
 	Metrics EngineMetrics
@@ -246,15 +254,19 @@ func (e *Engine) Status(w io.Writer) {
 func renderPathInfo(p pathhealth.PathInfo, w io.Writer, indent int) {
 	var paths [][]string
 	for _, path := range p {
-		state := ""
+		var state []string
 		if path.Current {
-			state = "-->"
+			state = append(state, "-->")
+		}
+		if path.Pinned {
+			state = append(state, "pinned")
 		}
 		if !path.Rejected {
 			paths = append(paths, []string{
 				strings.Repeat(" ", indent),
-				state,
+				strings.Join(state, " "),
 				fmt.Sprintf("%v", path.Revoked),
+				path.Fingerprint,
 				path.Path,
 			})
 		} else {
@@ -262,6 +274,7 @@ func renderPathInfo(p pathhealth.PathInfo, w io.Writer, indent int) {
 				strings.Repeat(" ", indent),
 				path.RejectReason,
 				"",
+				path.Fingerprint,
 				path.Path,
 			})
 		}
@@ -275,7 +288,7 @@ func renderPathInfo(p pathhealth.PathInfo, w io.Writer, indent int) {
 	table.SetRowSeparator("")
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.SetHeader([]string{"", "STATE", "REVOKED", "PATH"})
+	table.SetHeader([]string{"", "STATE", "REVOKED", "FINGERPRINT", "PATH"})
 	table.AppendBulk(paths)
 	table.Render()
 
@@ -323,6 +336,7 @@ func (e *Engine) initWorkers(ctx context.Context) error {
 			config.PolicyID,
 			config.IA,
 			config.Gateway.Data,
+			config.BatchDelay,
 		)
 		remoteIA := config.IA
 		pathMonitorRegistration := e.PathMonitor.Register(
@@ -360,6 +374,9 @@ func (e *Engine) initWorkers(ctx context.Context) error {
 			Events:    sessionMonitorEvents,
 			Paths:     pathMonitorRegistration,
 			ProbeConn: probeConn,
+
+			ProbeInterval:    e.SessionProbeInterval,
+			HealthExpiration: e.SessionHealthExpiration,
 			Metrics: SessionMonitorMetrics{
 				Probes: metrics.CounterWith(
 					e.Metrics.SessionMonitorMetrics.Probes, labels...),
@@ -496,7 +513,8 @@ type PktWriter interface {
 // DataplaneSessionFactory is used to construct a data-plane session with a specific ID towards a
 // remote.
 type DataplaneSessionFactory interface {
-	New(sessID uint8, policyID int, remoteIA addr.IA, remoteAddr net.Addr) DataplaneSession
+	New(sessID uint8, policyID int, remoteIA addr.IA, remoteAddr net.Addr,
+		batchDelay time.Duration) DataplaneSession
 }
 
 // PathMonitor is used to construct registrations for path discovery.
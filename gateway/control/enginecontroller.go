@@ -220,6 +220,14 @@ type DefaultEngineFactory struct {
 	// DataplaneSessionFactory is used to construct dataplane sessions.
 	DataplaneSessionFactory DataplaneSessionFactory
 
+	// SessionProbeInterval is the interval at which engines probe remote gateways. If zero, a
+	// default is used.
+	SessionProbeInterval time.Duration
+
+	// SessionHealthExpiration is the duration after the last successful probe after which a
+	// session is considered unhealthy. If zero, a default is used.
+	SessionHealthExpiration time.Duration
+
 	// Metrics contains the metrics that will be modified during engine operation. If empty, no
 	// metrics are reported.
 	Metrics EngineMetrics
@@ -239,6 +247,8 @@ func (f *DefaultEngineFactory) New(table RoutingTable,
 		ProbeConnFactory:        f.ProbeConnFactory,
 		DeviceManager:           f.DeviceManager,
 		DataplaneSessionFactory: f.DataplaneSessionFactory,
+		SessionProbeInterval:    f.SessionProbeInterval,
+		SessionHealthExpiration: f.SessionHealthExpiration,
 		Metrics:                 f.Metrics,
 	}
 }
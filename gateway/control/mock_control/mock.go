@@ -9,6 +9,7 @@ import (
 	io "io"
 	net "net"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	gopacket "github.com/google/gopacket"
@@ -599,17 +600,17 @@ func (m *MockDataplaneSessionFactory) EXPECT() *MockDataplaneSessionFactoryMockR
 }
 
 // New mocks base method.
-func (m *MockDataplaneSessionFactory) New(arg0 byte, arg1 int, arg2 addr.IA, arg3 net.Addr) control.DataplaneSession {
+func (m *MockDataplaneSessionFactory) New(arg0 byte, arg1 int, arg2 addr.IA, arg3 net.Addr, arg4 time.Duration) control.DataplaneSession {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "New", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "New", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(control.DataplaneSession)
 	return ret0
 }
 
 // New indicates an expected call of New.
-func (mr *MockDataplaneSessionFactoryMockRecorder) New(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockDataplaneSessionFactoryMockRecorder) New(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockDataplaneSessionFactory)(nil).New), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockDataplaneSessionFactory)(nil).New), arg0, arg1, arg2, arg3, arg4)
 }
 
 // MockPktWriter is a mock of PktWriter interface.
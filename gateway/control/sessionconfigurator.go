@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/gateway/pktcls"
@@ -56,6 +57,9 @@ type SessionConfig struct {
 	PathPolicy policies.PathPolicy
 	// PathCount is the max number of paths to use.
 	PathCount int
+	// BatchDelay is the maximum time the dataplane waits for more IP packets
+	// to fill a frame.
+	BatchDelay time.Duration
 	// Gateway describes a discovered remote gateway instance.
 	Gateway Gateway
 	// Prefixes contains the network prefixes that are reachable through this
@@ -214,6 +218,7 @@ func diffSessionPolicies(a, b SessionPolicies) bool {
 func diffSessionPolicy(a, b SessionPolicy) bool {
 	if a.TrafficMatcher.String() != b.TrafficMatcher.String() ||
 		a.PathCount != b.PathCount ||
+		a.BatchDelay != b.BatchDelay ||
 		// no better way than comparing pointers here:
 		a.PerfPolicy != b.PerfPolicy ||
 		prefixesKey(a.Prefixes) != prefixesKey(b.Prefixes) {
@@ -317,6 +322,7 @@ func buildSessionConfigs(sessionPolicies SessionPolicies,
 				PerfPolicy:     sessionPolicy.PerfPolicy,
 				PathPolicy:     pathPol,
 				PathCount:      sessionPolicy.PathCount,
+				BatchDelay:     sessionPolicy.BatchDelay,
 				Gateway:        entry.Gateway,
 				Prefixes:       mergePrefixes(sessionPolicy.Prefixes, entry.Prefixes),
 			})
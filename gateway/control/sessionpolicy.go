@@ -19,11 +19,13 @@ import (
 	"encoding/json"
 	"net"
 	"os"
+	"time"
 
 	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/gateway/pktcls"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/private/path/pathpol"
 )
 
@@ -38,16 +40,39 @@ var (
 	DefaultPathCount  = 1
 )
 
+// maxBatchDelay is the largest batching delay that can be configured for a
+// session policy.
+const maxBatchDelay = 10 * time.Millisecond
+
 // LegacySessionPolicyAdapter parses the legacy gateway JSON configuration and
 // adapts it into the session policies format.
+//
+// Besides the network prefixes and the path count, the configuration of a
+// remote AS can list traffic classes. Each traffic class results in a session
+// policy with the traffic matcher and the path policy of the class. The
+// traffic classes are matched in the order in which they are listed. Traffic
+// that does not match any class uses a session policy with the default path
+// policy, which is added after the traffic classes.
+//
+// The batching delay, i.e., the time the dataplane waits for more IP packets to
+// fill a frame, can be set for the remote AS and overridden per traffic class.
 type LegacySessionPolicyAdapter struct{}
 
 // Parse parses the raw JSON into a SessionPolicies struct.
 func (LegacySessionPolicyAdapter) Parse(ctx context.Context, raw []byte) (SessionPolicies, error) {
+	type TrafficClass struct {
+		Name       string
+		Matcher    string
+		PathPolicy *pathpol.Policy
+		PathCount  int
+		BatchDelay *util.DurWrap
+	}
 	type JSONFormat struct {
 		ASes map[addr.IA]struct {
-			Nets      []string
-			PathCount int
+			Nets           []string
+			PathCount      int
+			BatchDelay     *util.DurWrap
+			TrafficClasses []TrafficClass
 		}
 		ConfigVersion uint64
 	}
@@ -65,19 +90,109 @@ func (LegacySessionPolicyAdapter) Parse(ctx context.Context, raw []byte) (Sessio
 		if asEntry.PathCount != 0 {
 			pathCount = asEntry.PathCount
 		}
+		var batchDelay time.Duration
+		if asEntry.BatchDelay != nil {
+			if batchDelay, err = parseBatchDelay(*asEntry.BatchDelay); err != nil {
+				return nil, serrors.WithCtx(err, "isd_as", ia)
+			}
+		}
+		names := make(map[string]struct{}, len(asEntry.TrafficClasses))
+		for i, class := range asEntry.TrafficClasses {
+			if class.Name == "" {
+				return nil, serrors.New("traffic class without name", "isd_as", ia, "index", i)
+			}
+			if _, ok := names[class.Name]; ok {
+				return nil, serrors.New("duplicate traffic class", "isd_as", ia,
+					"name", class.Name)
+			}
+			names[class.Name] = struct{}{}
+			matcher, err := parseTrafficMatcher(class.Matcher)
+			if err != nil {
+				return nil, serrors.WithCtx(err, "isd_as", ia, "name", class.Name)
+			}
+			policy := SessionPolicy{
+				ID:             i,
+				IA:             ia,
+				TrafficMatcher: matcher,
+				PerfPolicy:     DefaultPerfPolicy,
+				PathPolicy:     DefaultPathPolicy,
+				PathCount:      pathCount,
+				BatchDelay:     batchDelay,
+				Prefixes:       prefixes,
+			}
+			if class.PathPolicy != nil {
+				policy.PathPolicy = class.PathPolicy
+			}
+			if class.PathCount != 0 {
+				policy.PathCount = class.PathCount
+			}
+			if class.BatchDelay != nil {
+				if policy.BatchDelay, err = parseBatchDelay(*class.BatchDelay); err != nil {
+					return nil, serrors.WithCtx(err, "isd_as", ia, "name", class.Name)
+				}
+			}
+			policies = append(policies, policy)
+		}
 		policies = append(policies, SessionPolicy{
-			ID:             0,
+			ID:             len(asEntry.TrafficClasses),
 			IA:             ia,
 			TrafficMatcher: pktcls.CondTrue,
 			PerfPolicy:     DefaultPerfPolicy,
 			PathPolicy:     DefaultPathPolicy,
 			PathCount:      pathCount,
+			BatchDelay:     batchDelay,
 			Prefixes:       prefixes,
 		})
 	}
 	return policies, nil
 }
 
+func parseBatchDelay(d util.DurWrap) (time.Duration, error) {
+	if d.Duration < 0 || d.Duration > maxBatchDelay {
+		return 0, serrors.New("batch delay out of range", "batch_delay", d,
+			"max", maxBatchDelay)
+	}
+	return d.Duration, nil
+}
+
+// parseTrafficMatcher parses the traffic matcher of a traffic class. References
+// to other traffic classes are not supported.
+func parseTrafficMatcher(raw string) (pktcls.Cond, error) {
+	if raw == "" {
+		return nil, serrors.New("traffic class without matcher")
+	}
+	matcher, err := pktcls.BuildClassTree(raw)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing traffic matcher", err, "matcher", raw)
+	}
+	if hasClassReference(matcher) {
+		return nil, serrors.New("traffic matcher must not reference a class", "matcher", raw)
+	}
+	return matcher, nil
+}
+
+func hasClassReference(cond pktcls.Cond) bool {
+	switch c := cond.(type) {
+	case pktcls.CondClass:
+		return true
+	case pktcls.CondAnyOf:
+		for _, child := range c {
+			if hasClassReference(child) {
+				return true
+			}
+		}
+	case pktcls.CondAllOf:
+		for _, child := range c {
+			if hasClassReference(child) {
+				return true
+			}
+		}
+	case pktcls.CondNot:
+		return c.Operand != nil && hasClassReference(c.Operand)
+	}
+	return false
+}
+
 func parsePrefixes(rawNets []string) ([]*net.IPNet, error) {
 	nets := make([]*net.IPNet, 0, len(rawNets))
 	for _, s := range rawNets {
@@ -147,6 +262,7 @@ func (p SessionPolicies) Copy() SessionPolicies {
 // - a path class defined by a path policy,
 // - a performance policy,
 // - a path count,
+// - a batching delay,
 // - a remote IA,
 // - a set of prefixes.
 type SessionPolicy struct {
@@ -167,6 +283,10 @@ type SessionPolicy struct {
 	// PathCount  defines the number of paths that can be simultaneously used
 	// within a session.
 	PathCount int
+	// BatchDelay is the maximum time the dataplane waits for more IP packets
+	// to fill a frame before sending it. If zero, frames are sent as soon as
+	// no more packets are pending.
+	BatchDelay time.Duration
 	// Prefixes contains the network prefixes that are reachable through this
 	// session.
 	Prefixes []*net.IPNet
@@ -182,6 +302,7 @@ func (sp SessionPolicy) Copy() SessionPolicy {
 		PerfPolicy: sp.PerfPolicy,
 		PathPolicy: copyPathPolicy(sp.PathPolicy),
 		PathCount:  sp.PathCount,
+		BatchDelay: sp.BatchDelay,
 		Prefixes:   copyPrefixes(sp.Prefixes),
 	}
 }
@@ -19,6 +19,7 @@ import (
 	"net"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -107,6 +108,169 @@ func TestLegacySessionPolicyAdapterParse(t *testing.T) {
 			},
 			AssertErr: assert.NoError,
 		},
+		"traffic classes": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"PathCount": 2,
+					"TrafficClasses": [
+					  {
+						"Name": "voice",
+						"Matcher": "all(dscp=0x2e,protocol=UDP)",
+						"PathPolicy": {"sequence": "1-ff00:0:111 1-ff00:0:110"},
+						"PathCount": 1
+					  },
+					  {
+						"Name": "bulk",
+						"Matcher": "dstport=5000-6000"
+					  }
+					]
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID: 0,
+					IA: xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.NewCondAllOf(
+						pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e}),
+						pktcls.NewCondIPv4(&pktcls.IPv4MatchProtocol{Protocol: 17}),
+					),
+					PerfPolicy: control.DefaultPerfPolicy,
+					PathPolicy: &pathpol.Policy{
+						Sequence: mustSequence(t, "1-ff00:0:111 1-ff00:0:110"),
+					},
+					PathCount: 1,
+					Prefixes:  []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+				},
+				control.SessionPolicy{
+					ID: 1,
+					IA: xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.NewCondPorts(
+						&pktcls.PortMatchDestination{MinPort: 5000, MaxPort: 6000},
+					),
+					PerfPolicy: control.DefaultPerfPolicy,
+					PathPolicy: control.DefaultPathPolicy,
+					PathCount:  2,
+					Prefixes:   []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+				},
+				control.SessionPolicy{
+					ID:             2,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      2,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"invalid traffic matcher": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": ["172.20.4.0/24"],
+					"TrafficClasses": [{"Name": "voice", "Matcher": "dscp="}]
+				  }
+				}
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"traffic matcher referencing a class": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": ["172.20.4.0/24"],
+					"TrafficClasses": [{"Name": "voice", "Matcher": "not(cls=bulk)"}]
+				  }
+				}
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"batch delay": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": ["172.20.4.0/24"],
+					"BatchDelay": "100us",
+					"TrafficClasses": [
+					  {"Name": "voice", "Matcher": "dscp=0x2e", "BatchDelay": "500us"}
+					]
+				  }
+				}
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e}),
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      1,
+					BatchDelay:     500 * time.Microsecond,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+				},
+				control.SessionPolicy{
+					ID:             1,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      1,
+					BatchDelay:     100 * time.Microsecond,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"batch delay too large": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": ["172.20.4.0/24"],
+					"TrafficClasses": [
+					  {"Name": "voice", "Matcher": "dscp=0x2e", "BatchDelay": "1s"}
+					]
+				  }
+				}
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"duplicate traffic class": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": ["172.20.4.0/24"],
+					"TrafficClasses": [
+					  {"Name": "voice", "Matcher": "dscp=0x2e"},
+					  {"Name": "voice", "Matcher": "dscp=0x2c"}
+					]
+				  }
+				}
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
 	}
 	for name, tc := range testCases {
 		name, tc := name, tc
@@ -119,6 +283,12 @@ func TestLegacySessionPolicyAdapterParse(t *testing.T) {
 	}
 }
 
+func mustSequence(t *testing.T, s string) *pathpol.Sequence {
+	seq, err := pathpol.NewSequence(s)
+	require.NoError(t, err)
+	return seq
+}
+
 func TestLoadSessionPolicies(t *testing.T) {
 	file, err := os.CreateTemp("", "control_sess_pol_load")
 	require.NoError(t, err)
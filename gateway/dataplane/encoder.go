@@ -63,17 +63,25 @@ type encoder struct {
 	// frame is the frame being built at the moment.
 	// To avoid allocations, we reuse the same frame buffer over and over again.
 	frame []byte
+	// batchDelay is the maximum time a frame that is not full is held back
+	// waiting for more packets. If zero, the frame is sent as soon as there
+	// are no more packets available.
+	batchDelay time.Duration
 }
 
 // newEncoder creates a new encoder instance.
 // mtu is max size of the frame, excluding SCION header, but including SIG header.
-func newEncoder(sessionID uint8, streamID uint32, mtu uint16) *encoder {
+// batchDelay is the maximum time to wait for more packets to fill a frame.
+func newEncoder(sessionID uint8, streamID uint32, mtu uint16,
+	batchDelay time.Duration) *encoder {
+
 	return &encoder{
-		sessionID: sessionID,
-		streamID:  streamID,
-		seq:       0,
-		ring:      newPktRing(),
-		frame:     make([]byte, 0, mtu),
+		sessionID:  sessionID,
+		streamID:   streamID,
+		seq:        0,
+		ring:       newPktRing(),
+		frame:      make([]byte, 0, mtu),
+		batchDelay: batchDelay,
 	}
 }
 
@@ -111,6 +119,9 @@ func (e *encoder) Read() []byte {
 	}
 	// Read more packets and fill in as much of the frame as possible.
 	var indexSet bool
+	// deadline is the time until which the encoder waits for more packets
+	// once the ring is drained. It is only set if batching is enabled.
+	var deadline time.Time
 	for {
 		// Check whether one more packet would fit into the frame.
 		// At least 40B are needed to fit IPv6 header into it.
@@ -120,13 +131,23 @@ func (e *encoder) Read() []byte {
 		// If there's nothing but the header in the current frame we are going to fetch more
 		// data in blocking manner. If there's already some data in the frame we will
 		// still try to stuff it with more packets, but if there are no packets available,
-		// we'll send what we have immediately.
+		// we'll send what we have immediately, or once the batching delay expires.
 		block := (pos == hdrLen)
 		var n int
 		e.pkt, n = e.ring.Read(block)
 		if n == 0 {
-			// No more packets to stuff into the frame. Go on with sending.
-			return e.frame[:pos]
+			if e.batchDelay <= 0 {
+				// No more packets to stuff into the frame. Go on with sending.
+				return e.frame[:pos]
+			}
+			if deadline.IsZero() {
+				deadline = time.Now().Add(e.batchDelay)
+			}
+			// Wait for more packets until the batching delay expires.
+			if e.pkt, n = e.ring.ReadDeadline(deadline); n == 0 {
+				// The batching delay expired. Go on with sending.
+				return e.frame[:pos]
+			}
 		}
 		if n == -1 {
 			if block {
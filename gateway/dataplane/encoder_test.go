@@ -16,20 +16,21 @@ package dataplane
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestEncoder(t *testing.T) {
 	t.Run("closed ringbuf", func(t *testing.T) {
-		e := newEncoder(1, 2, 1500)
+		e := newEncoder(1, 2, 1500, 0)
 		e.Close()
 		f := e.Read()
 		assert.Nil(t, f)
 	})
 
 	t.Run("simple IPv4 packet", func(t *testing.T) {
-		e := newEncoder(1, 2, 1500)
+		e := newEncoder(1, 2, 1500, 0)
 		e.Write([]byte{
 			// IPv4 header.
 			0x40, 0, 0, 23, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
@@ -51,7 +52,7 @@ func TestEncoder(t *testing.T) {
 	})
 
 	t.Run("simple IPv6 packet", func(t *testing.T) {
-		e := newEncoder(1, 2, 1500)
+		e := newEncoder(1, 2, 1500, 0)
 		e.Write([]byte{
 			// IPv6 header.
 			0x60, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
@@ -75,7 +76,7 @@ func TestEncoder(t *testing.T) {
 	})
 
 	t.Run("two packets in a single frame", func(t *testing.T) {
-		e := newEncoder(1, 2, 1500)
+		e := newEncoder(1, 2, 1500, 0)
 		e.Write([]byte{
 			// IPv4 header.
 			0x40, 0, 0, 23, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
@@ -107,7 +108,7 @@ func TestEncoder(t *testing.T) {
 	})
 
 	t.Run("single packet split into two frames", func(t *testing.T) {
-		e := newEncoder(1, 2, 56)
+		e := newEncoder(1, 2, 56, 0)
 		e.Write([]byte{
 			// IPv4 header.
 			0x40, 0, 0, 42, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
@@ -136,7 +137,7 @@ func TestEncoder(t *testing.T) {
 	})
 
 	t.Run("second packet starting at non-zero position in the second frame", func(t *testing.T) {
-		e := newEncoder(1, 2, 58)
+		e := newEncoder(1, 2, 58, 0)
 		e.Write([]byte{
 			// IPv4 header.
 			0x40, 0, 0, 44, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
@@ -173,4 +174,60 @@ func TestEncoder(t *testing.T) {
 		f = e.Read()
 		assert.Nil(t, f)
 	})
+	t.Run("batching delay waits for more packets", func(t *testing.T) {
+		e := newEncoder(1, 2, 1500, time.Second)
+		e.Write([]byte{
+			// IPv4 header.
+			0x40, 0, 0, 21, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			// Payload.
+			1,
+		})
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			e.Write([]byte{
+				// IPv4 header.
+				0x40, 0, 0, 21, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				// Payload.
+				2,
+			})
+			e.Close()
+		}()
+		f := e.Read()
+		assert.EqualValues(t, []byte{
+			// SIG frame header.
+			0, 1, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0,
+			// IPv4 header.
+			0x40, 0, 0, 21, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			// Payload.
+			1,
+			// IPv4 header.
+			0x40, 0, 0, 21, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			// Payload.
+			2,
+		}, f)
+		f = e.Read()
+		assert.Nil(t, f)
+	})
+
+	t.Run("batching delay expires", func(t *testing.T) {
+		e := newEncoder(1, 2, 1500, 20*time.Millisecond)
+		defer e.Close()
+		e.Write([]byte{
+			// IPv4 header.
+			0x40, 0, 0, 21, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			// Payload.
+			1,
+		})
+		start := time.Now()
+		f := e.Read()
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+		assert.EqualValues(t, []byte{
+			// SIG frame header.
+			0, 1, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0,
+			// IPv4 header.
+			0x40, 0, 0, 21, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			// Payload.
+			1,
+		}, f)
+	})
 }
@@ -0,0 +1,124 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"encoding/binary"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// minIPv4MTU is the smallest MTU that can be announced to IPv4 senders.
+	minIPv4MTU = 68
+	// icmpv4QuoteLen is the number of bytes following the IPv4 header of the
+	// offending packet that are included in an ICMPv4 error.
+	icmpv4QuoteLen = 8
+	// maxICMPv6Len is the maximum length of an ICMPv6 error message including
+	// the IPv6 header. It must not exceed the minimum IPv6 MTU.
+	maxICMPv6Len = common.MinMTU
+)
+
+// exceedsMTU returns whether the packet is too big for the given MTU and must
+// not be split up, i.e., it is an IPv6 packet or an IPv4 packet with the
+// don't fragment flag set. Packets are never rejected if the MTU is below the
+// minimum that can be announced to the sender.
+func exceedsMTU(packet gopacket.Packet, mtu int) bool {
+	if len(packet.Data()) <= mtu {
+		return false
+	}
+	switch ip := packet.NetworkLayer().(type) {
+	case *layers.IPv4:
+		return ip.Flags&layers.IPv4DontFragment != 0 && mtu >= minIPv4MTU
+	case *layers.IPv6:
+		return mtu >= common.MinMTU
+	default:
+		return false
+	}
+}
+
+// packetTooBig creates an ICMP error that informs the sender of the packet
+// that it has to reduce the packet size to the given MTU. For IPv4 this is a
+// "fragmentation needed" message, for IPv6 a "packet too big" message.
+//
+// The error is sent on behalf of the destination of the original packet. This
+// way it passes reverse path filtering when it is injected into the tunnel
+// device, since the source is routed via that device.
+func packetTooBig(packet gopacket.Packet, mtu int) ([]byte, error) {
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	data := packet.Data()
+	switch ip := packet.NetworkLayer().(type) {
+	case *layers.IPv4:
+		quoteLen := int(ip.IHL)*4 + icmpv4QuoteLen
+		if quoteLen > len(data) {
+			quoteLen = len(data)
+		}
+		reply := &layers.IPv4{
+			Version:  4,
+			IHL:      5,
+			TTL:      64,
+			Protocol: layers.IPProtocolICMPv4,
+			SrcIP:    ip.DstIP,
+			DstIP:    ip.SrcIP,
+		}
+		icmp := &layers.ICMPv4{
+			TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable,
+				layers.ICMPv4CodeFragmentationNeeded),
+			// The next-hop MTU is carried in the second half of the rest of
+			// the header, which corresponds to the sequence number field.
+			Seq: uint16(mtu),
+		}
+		err := gopacket.SerializeLayers(buf, opts, reply, icmp, gopacket.Payload(data[:quoteLen]))
+		if err != nil {
+			return nil, err
+		}
+	case *layers.IPv6:
+		reply := &layers.IPv6{
+			Version:    6,
+			HopLimit:   64,
+			NextHeader: layers.IPProtocolICMPv6,
+			SrcIP:      ip.DstIP,
+			DstIP:      ip.SrcIP,
+		}
+		icmp := &layers.ICMPv6{
+			TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypePacketTooBig, 0),
+		}
+		if err := icmp.SetNetworkLayerForChecksum(reply); err != nil {
+			return nil, err
+		}
+		// The message body consists of the MTU followed by as much of the
+		// offending packet as fits into the minimum IPv6 MTU.
+		quoteLen := maxICMPv6Len - 40 - 8
+		if quoteLen > len(data) {
+			quoteLen = len(data)
+		}
+		body := make([]byte, 4+quoteLen)
+		binary.BigEndian.PutUint32(body[:4], uint32(mtu))
+		copy(body[4:], data[:quoteLen])
+		err := gopacket.SerializeLayers(buf, opts, reply, icmp, gopacket.Payload(body))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, serrors.New("unsupported network layer",
+			"type", common.TypeOf(packet.NetworkLayer()))
+	}
+	return buf.Bytes(), nil
+}
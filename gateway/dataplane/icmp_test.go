@@ -0,0 +1,140 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExceedsMTU(t *testing.T) {
+	testCases := map[string]struct {
+		Packet   gopacket.Packet
+		MTU      int
+		Expected bool
+	}{
+		"ipv4 fits": {
+			Packet: newIPv4TestPacket(t, layers.IPv4DontFragment, 100),
+			MTU:    120,
+		},
+		"ipv4 too big with DF": {
+			Packet:   newIPv4TestPacket(t, layers.IPv4DontFragment, 200),
+			MTU:      120,
+			Expected: true,
+		},
+		"ipv4 too big without DF": {
+			Packet: newIPv4TestPacket(t, 0, 200),
+			MTU:    120,
+		},
+		"ipv4 MTU below minimum": {
+			Packet: newIPv4TestPacket(t, layers.IPv4DontFragment, 200),
+			MTU:    60,
+		},
+		"ipv6 too big": {
+			Packet:   newIPv6TestPacket(t, 1500),
+			MTU:      1400,
+			Expected: true,
+		},
+		"ipv6 MTU below minimum": {
+			Packet: newIPv6TestPacket(t, 1500),
+			MTU:    1000,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.Expected, exceedsMTU(tc.Packet, tc.MTU))
+		})
+	}
+}
+
+func TestPacketTooBig(t *testing.T) {
+	t.Run("ipv4", func(t *testing.T) {
+		pkt := newIPv4TestPacket(t, layers.IPv4DontFragment, 200)
+		raw, err := packetTooBig(pkt, 120)
+		require.NoError(t, err)
+
+		reply := gopacket.NewPacket(raw, layers.LayerTypeIPv4, gopacket.Default)
+		require.Nil(t, reply.ErrorLayer())
+		ip := reply.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+		assert.Equal(t, net.IP{192, 0, 2, 2}, ip.SrcIP.To4())
+		assert.Equal(t, net.IP{192, 0, 2, 1}, ip.DstIP.To4())
+		icmp := reply.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4)
+		assert.Equal(t, layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable,
+			layers.ICMPv4CodeFragmentationNeeded), icmp.TypeCode)
+		assert.Equal(t, uint16(120), icmp.Seq)
+		assert.Equal(t, pkt.Data()[:28], icmp.Payload)
+	})
+	t.Run("ipv6", func(t *testing.T) {
+		pkt := newIPv6TestPacket(t, 1500)
+		raw, err := packetTooBig(pkt, 1400)
+		require.NoError(t, err)
+		assert.Len(t, raw, maxICMPv6Len)
+
+		reply := gopacket.NewPacket(raw, layers.LayerTypeIPv6, gopacket.Default)
+		require.Nil(t, reply.ErrorLayer())
+		ip := reply.Layer(layers.LayerTypeIPv6).(*layers.IPv6)
+		assert.Equal(t, net.ParseIP("2001:db8::2"), ip.SrcIP)
+		assert.Equal(t, net.ParseIP("2001:db8::1"), ip.DstIP)
+		icmp := reply.Layer(layers.LayerTypeICMPv6).(*layers.ICMPv6)
+		assert.Equal(t, layers.CreateICMPv6TypeCode(layers.ICMPv6TypePacketTooBig, 0),
+			icmp.TypeCode)
+		assert.Equal(t, uint32(1400), binary.BigEndian.Uint32(icmp.Payload[:4]))
+		assert.Equal(t, pkt.Data()[:maxICMPv6Len-48], icmp.Payload[4:])
+	})
+}
+
+func newIPv4TestPacket(t *testing.T, flags layers.IPv4Flag, length int) gopacket.Packet {
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true}
+	err := gopacket.SerializeLayers(buf, opts,
+		&layers.IPv4{
+			Version:  4,
+			IHL:      5,
+			TTL:      64,
+			Flags:    flags,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    net.IP{192, 0, 2, 1},
+			DstIP:    net.IP{192, 0, 2, 2},
+		},
+		gopacket.Payload(make([]byte, length-20)),
+	)
+	require.NoError(t, err)
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv4, decodeOptions)
+}
+
+func newIPv6TestPacket(t *testing.T, length int) gopacket.Packet {
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true}
+	err := gopacket.SerializeLayers(buf, opts,
+		&layers.IPv6{
+			Version:    6,
+			HopLimit:   64,
+			NextHeader: layers.IPProtocolNoNextHeader,
+			SrcIP:      net.ParseIP("2001:db8::1"),
+			DstIP:      net.ParseIP("2001:db8::2"),
+		},
+		gopacket.Payload(make([]byte, length-40)),
+	)
+	require.NoError(t, err)
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv6, decodeOptions)
+}
@@ -49,6 +49,9 @@ type IPForwarderMetrics struct {
 	IPPktsInvalid metrics.Counter
 	//  IPPktsFragmented the number of fragmented packet. If nil, the metric is not reported.
 	IPPktsFragmented metrics.Counter
+	// IPPktsTooBig counts the number of IP packets that were discarded because they exceeded
+	// the path MTU and an ICMP error was sent back instead. If nil, the metric is not reported.
+	IPPktsTooBig metrics.Counter
 	// ReceiveLocalErrors counts the number of read errors encountered on the raw packets source.
 	// If nil, the metric is not reported.
 	ReceiveLocalErrors metrics.Counter
@@ -63,6 +66,11 @@ type IPForwarder struct {
 	Reader io.Reader
 	// RoutingTable is used to decide where packets should be sent. It must not be nil.
 	RoutingTable control.RoutingTableReader
+	// ICMPWriter enables path MTU discovery if it is set. IP packets that do not fit
+	// into a single frame of the session and must not be fragmented are discarded, and
+	// an ICMP error carrying the path MTU is written to ICMPWriter instead. If nil, such
+	// packets are split across multiple frames and reassembled by the remote gateway.
+	ICMPWriter io.Writer
 	// Metrics is used by the forwarder to report information about internal operation.
 	// If a metric is not initialized, it is not reported.
 	Metrics IPForwarderMetrics
@@ -129,11 +137,49 @@ func (f *IPForwarder) Run(ctx context.Context) error {
 			metrics.CounterInc(f.Metrics.IPPktsNoRoute)
 			continue
 		}
+		if mtu := f.pathMTU(session); exceedsMTU(packet, mtu) {
+			metrics.CounterInc(f.Metrics.IPPktsTooBig)
+			f.sendPacketTooBig(logger, packet, mtu)
+			continue
+		}
 
 		session.Write(packet)
 	}
 }
 
+// mtuProvider is implemented by sessions that know their path MTU.
+type mtuProvider interface {
+	MTU() int
+}
+
+// pathMTU returns the path MTU of the session if path MTU discovery is enabled.
+// Otherwise, or if the session does not know its path MTU, it returns
+// common.MaxMTU such that no packet exceeds it.
+func (f *IPForwarder) pathMTU(session control.PktWriter) int {
+	if f.ICMPWriter == nil {
+		return common.MaxMTU
+	}
+	s, ok := session.(mtuProvider)
+	if !ok {
+		return common.MaxMTU
+	}
+	if mtu := s.MTU(); mtu > 0 {
+		return mtu
+	}
+	return common.MaxMTU
+}
+
+func (f *IPForwarder) sendPacketTooBig(logger log.Logger, packet gopacket.Packet, mtu int) {
+	raw, err := packetTooBig(packet, mtu)
+	if err != nil {
+		logger.Debug("forwarder: failed to create ICMP error", "err", err)
+		return
+	}
+	if _, err := f.ICMPWriter.Write(raw); err != nil {
+		logger.Debug("forwarder: failed to write ICMP error", "err", err)
+	}
+}
+
 func (f *IPForwarder) validate() error {
 	if f.Reader == nil {
 		return serrors.New("packet reader must not be nil")
@@ -212,6 +212,90 @@ func TestIPForwarderRun(t *testing.T) {
 	})
 }
 
+func TestIPForwarderPathMTUDiscovery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reader := mock_io.NewMockReader(ctrl)
+	rt := dataplane.NewRoutingTable([]*control.RoutingChain{
+		{
+			Prefixes:        []*net.IPNet{xtest.MustParseCIDR(t, "10.0.0.0/8")},
+			TrafficMatchers: []control.TrafficMatcher{{ID: 1, Matcher: pktcls.CondTrue}},
+		},
+	})
+	art := &dataplane.AtomicRoutingTable{}
+	art.SetRoutingTable(rt)
+
+	session := mtuSession{MockPktWriter: mock_control.NewMockPktWriter(ctrl), mtu: 100}
+	require.NoError(t, rt.SetSession(1, session))
+
+	small := newSizedIPv4Packet(t, layers.IPv4DontFragment, 100)
+	bigDF := newSizedIPv4Packet(t, layers.IPv4DontFragment, 200)
+	big := newSizedIPv4Packet(t, 0, 200)
+	for _, pkt := range []gopacket.Packet{small, bigDF, big} {
+		pkt := pkt
+		reader.EXPECT().Read(gomock.Any()).DoAndReturn(
+			func(b []byte) (int, error) { return copy(b, pkt.Data()), nil },
+		)
+	}
+	session.EXPECT().Write(Packet(small))
+	session.EXPECT().Write(Packet(big))
+
+	errDone := serrors.New("done")
+	reader.EXPECT().Read(gomock.Any()).Return(0, errDone)
+
+	var icmpWriter bytes.Buffer
+	ipForwarder := &dataplane.IPForwarder{
+		Reader:       reader,
+		RoutingTable: art,
+		ICMPWriter:   &icmpWriter,
+	}
+	done := make(chan struct{})
+	go func() {
+		err := ipForwarder.Run(context.Background())
+		require.True(t, errors.Is(err, errDone), err)
+		close(done)
+	}()
+	xtest.AssertReadReturnsBefore(t, done, time.Second)
+
+	reply := gopacket.NewPacket(icmpWriter.Bytes(), layers.LayerTypeIPv4, gopacket.Default)
+	icmp, ok := reply.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4)
+	require.True(t, ok)
+	assert.Equal(t, layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable,
+		layers.ICMPv4CodeFragmentationNeeded), icmp.TypeCode)
+	assert.Equal(t, uint16(100), icmp.Seq)
+}
+
+// mtuSession is a packet writer that reports a fixed path MTU.
+type mtuSession struct {
+	*mock_control.MockPktWriter
+	mtu int
+}
+
+func (s mtuSession) MTU() int {
+	return s.mtu
+}
+
+func newSizedIPv4Packet(t *testing.T, flags layers.IPv4Flag, length int) gopacket.Packet {
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true}
+	err := gopacket.SerializeLayers(buf, opts,
+		&layers.IPv4{
+			Version:  4,
+			IHL:      5,
+			TTL:      64,
+			Flags:    flags,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    net.IP{127, 0, 0, 1},
+			DstIP:    net.IP{10, 0, 0, 1},
+		},
+		gopacket.Payload(make([]byte, length-20)),
+	)
+	require.NoError(t, err)
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv4,
+		gopacket.DecodeOptions{NoCopy: true, Lazy: true})
+}
+
 func newIPv4Packet(t *testing.T, destination net.IP) gopacket.Packet {
 	buf := gopacket.NewSerializeBuffer()
 	opts := gopacket.SerializeOptions{}
@@ -14,7 +14,11 @@
 
 package dataplane
 
-import "github.com/scionproto/scion/private/ringbuf"
+import (
+	"time"
+
+	"github.com/scionproto/scion/private/ringbuf"
+)
 
 const (
 	batchSize = 32
@@ -68,6 +72,24 @@ func (pr *pktRing) Read(block bool) ([]byte, int) {
 	return pkt, 1
 }
 
+// ReadDeadline returns next packet from the ringbuffer. It blocks until a
+// packet is available, the ringbuffer is closed, or the deadline expires.
+// Returns 1 if successful, 0 if the deadline expired or -1 if the ringbuf was closed.
+func (pr *pktRing) ReadDeadline(deadline time.Time) ([]byte, int) {
+	if len(pr.entries) == 0 {
+		pr.entries = pr.storage[:]
+		n, _ := pr.ring.ReadDeadline(pr.entries, deadline)
+		if n <= 0 {
+			pr.entries = pr.storage[:0]
+			return nil, n
+		}
+		pr.entries = pr.storage[:n]
+	}
+	pkt := pr.entries[0].([]byte)
+	pr.entries = pr.entries[1:]
+	return pkt, 1
+}
+
 // Close closes the ring. This causes the Read function to return nil.
 func (pr *pktRing) Close() {
 	pr.ring.Close()
@@ -16,6 +16,7 @@ package dataplane
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -70,3 +71,33 @@ func TestPktReader(t *testing.T) {
 	assert.Equal(t, -1, n)
 	assert.Nil(t, pkt)
 }
+
+func TestPktReaderDeadline(t *testing.T) {
+	raw := make([]byte, 10)
+	p := newPktRing()
+
+	// If the deadline expires, nil is returned.
+	start := time.Now()
+	pkt, n := p.ReadDeadline(start.Add(10 * time.Millisecond))
+	assert.Equal(t, 0, n)
+	assert.Nil(t, pkt)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+
+	// A packet written while waiting is returned.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p.Write(raw, true)
+	}()
+	pkt, n = p.ReadDeadline(time.Now().Add(time.Minute))
+	assert.Equal(t, 1, n)
+	assert.Equal(t, raw, pkt)
+
+	// Closing the ring wakes up the reader.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p.Close()
+	}()
+	pkt, n = p.ReadDeadline(time.Now().Add(time.Minute))
+	assert.Equal(t, -1, n)
+	assert.Nil(t, pkt)
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// DataplaneALPN is the application protocol negotiated for QUIC connections
+	// that carry frames as datagrams.
+	DataplaneALPN = "scion-gateway-dataplane"
+	// quicPacketLen is the maximum size of the QUIC packets carrying frames. It
+	// is the size quic-go uses for non-UDP addresses.
+	quicPacketLen = 1200
+	// quicOverhead is an upper bound of the QUIC packet overhead for a single
+	// datagram frame, i.e., short header, AEAD tag and datagram frame header.
+	quicOverhead = 50
+	// quicDialTimeout is the timeout for establishing a QUIC connection to the
+	// remote gateway.
+	quicDialTimeout = 3 * time.Second
+	// quicRedialInterval is the initial interval between two failed attempts
+	// to establish a QUIC connection for the same path.
+	quicRedialInterval = 5 * time.Second
+	// quicMaxRedialInterval is the maximum interval between two failed
+	// attempts to establish a QUIC connection for the same path.
+	quicMaxRedialInterval = 5 * time.Minute
+	// quicKeepAlivePeriod is the interval at which keep alive packets are sent
+	// on idle QUIC connections.
+	quicKeepAlivePeriod = 10 * time.Second
+	// ingressQueueLen is the number of frames buffered by the QUIC ingress
+	// connection.
+	ingressQueueLen = 128
+)
+
+// DatagramDialer establishes QUIC connections to remote gateways that are used
+// to send frames as datagrams.
+type DatagramDialer interface {
+	Dial(ctx context.Context, remote net.Addr) (quic.Connection, error)
+}
+
+// QUICDialer dials QUIC connections with datagram support via a QUIC
+// transport.
+type QUICDialer struct {
+	// Transport is the transport used to dial connections. It must not be nil.
+	Transport *quic.Transport
+	// TLSConfig is the TLS configuration. The DataplaneALPN protocol is
+	// negotiated regardless of the configured protocols.
+	TLSConfig *tls.Config
+}
+
+// Dial dials a QUIC connection to the remote gateway. An error is returned if
+// the remote does not support the encrypted dataplane.
+func (d QUICDialer) Dial(ctx context.Context, remote net.Addr) (quic.Connection, error) {
+	tlsConfig := d.TLSConfig.Clone()
+	tlsConfig.NextProtos = []string{DataplaneALPN}
+	conn, err := d.Transport.Dial(ctx, remote, tlsConfig, quicConfig())
+	if err != nil {
+		return nil, err
+	}
+	if !conn.ConnectionState().SupportsDatagrams {
+		_ = conn.CloseWithError(0, "datagrams not supported")
+		return nil, serrors.New("remote does not support datagrams")
+	}
+	return conn, nil
+}
+
+func quicConfig() *quic.Config {
+	return &quic.Config{
+		EnableDatagrams:         true,
+		KeepAlivePeriod:         quicKeepAlivePeriod,
+		DisablePathMTUDiscovery: true,
+	}
+}
+
+// plainBufs holds the buffers for plain frames read from the QUIC transport.
+var plainBufs = sync.Pool{
+	New: func() interface{} {
+		return make([]byte, common.SupportedMTU)
+	},
+}
+
+type ingressFrame struct {
+	raw []byte
+	src net.Addr
+	err error
+	// pooled indicates that raw must be returned to plainBufs.
+	pooled bool
+}
+
+// QUICIngressConn is a ReadConn that receives frames both as plain SCION/UDP
+// packets and as datagrams of QUIC connections. The two are distinguished by
+// the first byte of the packet: SIG frames start with version 0, while QUIC
+// packets always have the fixed bit set.
+type QUICIngressConn struct {
+	frames chan ingressFrame
+	// requireEncryption indicates that plain frames are dropped.
+	requireEncryption bool
+}
+
+// NewQUICIngressConn starts listening for QUIC connections on the transport
+// and reading plain frames from it. If requireEncryption is set, the plain
+// frames are dropped. It runs until the context is canceled.
+func NewQUICIngressConn(ctx context.Context, transport *quic.Transport,
+	tlsConfig *tls.Config, requireEncryption bool) (*QUICIngressConn, error) {
+
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.NextProtos = []string{DataplaneALPN}
+	listener, err := transport.Listen(tlsConfig, quicConfig())
+	if err != nil {
+		return nil, serrors.WrapStr("listening for QUIC connections", err)
+	}
+	c := &QUICIngressConn{
+		frames:            make(chan ingressFrame, ingressQueueLen),
+		requireEncryption: requireEncryption,
+	}
+	go func() {
+		defer log.HandlePanic()
+		c.readPlain(ctx, transport)
+	}()
+	go func() {
+		defer log.HandlePanic()
+		defer listener.Close()
+		c.accept(ctx, listener)
+	}()
+	return c, nil
+}
+
+// ReadFrom reads the next frame, either received as a plain packet or as a
+// QUIC datagram.
+func (c *QUICIngressConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	f := <-c.frames
+	if f.pooled {
+		defer plainBufs.Put(f.raw[:cap(f.raw)])
+	}
+	if f.err != nil {
+		return 0, nil, f.err
+	}
+	return copy(b, f.raw), f.src, nil
+}
+
+func (c *QUICIngressConn) readPlain(ctx context.Context, transport *quic.Transport) {
+	for {
+		buf := plainBufs.Get().([]byte)
+		n, src, err := transport.ReadNonQUICPacket(ctx, buf)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil && c.requireEncryption {
+			plainBufs.Put(buf)
+			continue
+		}
+		select {
+		case c.frames <- ingressFrame{raw: buf[:n], src: src, err: err, pooled: true}:
+		case <-ctx.Done():
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (c *QUICIngressConn) accept(ctx context.Context, listener *quic.Listener) {
+	logger := log.FromCtx(ctx)
+	for {
+		conn, err := listener.Accept(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Info("Failed to accept QUIC dataplane connection", "err", err)
+			}
+			return
+		}
+		logger.Debug("Accepted QUIC dataplane connection", "remote", conn.RemoteAddr())
+		go func() {
+			defer log.HandlePanic()
+			c.receive(ctx, conn)
+		}()
+	}
+}
+
+func (c *QUICIngressConn) receive(ctx context.Context, conn quic.Connection) {
+	for {
+		msg, err := conn.ReceiveMessage(ctx)
+		if err != nil {
+			log.FromCtx(ctx).Debug("QUIC dataplane connection closed",
+				"remote", conn.RemoteAddr(), "err", err)
+			return
+		}
+		select {
+		case c.frames <- ingressFrame{raw: msg, src: conn.RemoteAddr()}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/quic-go/quic-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/mocks/net/mock_net"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/app/appnet"
+)
+
+func TestQUICIngressConn(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tlsConfig, err := appnet.GenerateTLSConfig()
+	require.NoError(t, err)
+
+	serverConn := newLocalUDPConn(t)
+	serverTransport := newQUICTransport(t, serverConn)
+	ingress, err := NewQUICIngressConn(ctx, serverTransport, tlsConfig, false)
+	require.NoError(t, err)
+
+	t.Run("plain frame", func(t *testing.T) {
+		clientConn := newLocalUDPConn(t)
+		frame := []byte{0, 1, 2, 3}
+		// Plain packets received before the ingress connection started
+		// reading are dropped, hence keep sending until one is received.
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			ticker := time.NewTicker(10 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				_, _ = clientConn.WriteTo(frame, serverConn.LocalAddr())
+				select {
+				case <-ticker.C:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		buf := make([]byte, 100)
+		n, src, err := ingress.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, frame, buf[:n])
+		assert.Equal(t, clientConn.LocalAddr().String(), src.String())
+	})
+	t.Run("datagram frame", func(t *testing.T) {
+		clientConn := newLocalUDPConn(t)
+		dialer := QUICDialer{Transport: newQUICTransport(t, clientConn), TLSConfig: tlsConfig}
+		conn, err := dialer.Dial(ctx, serverConn.LocalAddr())
+		require.NoError(t, err)
+		defer conn.CloseWithError(0, "")
+
+		frame := []byte{0, 4, 5, 6}
+		require.NoError(t, conn.SendMessage(frame))
+
+		buf := make([]byte, 100)
+		for {
+			n, src, err := ingress.ReadFrom(buf)
+			require.NoError(t, err)
+			// Skip plain frames still queued from the previous test.
+			if buf[1] == 1 {
+				continue
+			}
+			assert.Equal(t, frame, buf[:n])
+			assert.Equal(t, clientConn.LocalAddr().String(), src.String())
+			return
+		}
+	})
+}
+
+func TestSenderEncryptionFallback(t *testing.T) {
+	testCases := map[string]struct {
+		RequireEncryption bool
+		ExpPlainFrames    int
+	}{
+		"fallback to plain frames": {
+			ExpPlainFrames: 1,
+		},
+		"encryption required": {
+			RequireEncryption: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			conn := mock_net.NewMockPacketConn(ctrl)
+			conn.EXPECT().LocalAddr().Return(
+				&snet.UDPAddr{Host: &net.UDPAddr{IP: net.IP{192, 168, 1, 1}}},
+			).AnyTimes()
+			sent := make(chan struct{}, 1)
+			conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(f []byte, _ interface{}) (int, error) {
+					sent <- struct{}{}
+					return len(f), nil
+				}).Times(tc.ExpPlainFrames)
+
+			dialed := make(chan struct{}, 1)
+			dialer := dialerFunc(func(context.Context, net.Addr) (quic.Connection, error) {
+				dialed <- struct{}{}
+				return nil, serrors.New("not supported")
+			})
+			addr := net.UDPAddr{IP: net.IP{192, 168, 1, 2}, Port: 30056}
+			c, err := newSender(1, conn, createMockPath(ctrl, 1400), addr, nil,
+				SessionMetrics{}, dialer, tc.RequireEncryption, 0)
+			require.NoError(t, err)
+			defer c.Close()
+			assert.Equal(t, quicPacketLen-quicOverhead-hdrLen, c.MTU())
+
+			c.Write(newIPv4TestPacket(t, 0, 30).Data())
+			select {
+			case <-dialed:
+			case <-time.After(time.Second):
+				t.Fatal("no dial attempt")
+			}
+			if tc.ExpPlainFrames > 0 {
+				select {
+				case <-sent:
+				case <-time.After(time.Second):
+					t.Fatal("no plain frame sent")
+				}
+			}
+		})
+	}
+}
+
+func TestQUICIngressConnRequireEncryption(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tlsConfig, err := appnet.GenerateTLSConfig()
+	require.NoError(t, err)
+
+	serverConn := newLocalUDPConn(t)
+	ingress, err := NewQUICIngressConn(ctx, newQUICTransport(t, serverConn), tlsConfig, true)
+	require.NoError(t, err)
+
+	plainConn := newLocalUDPConn(t)
+	for i := 0; i < 10; i++ {
+		_, err := plainConn.WriteTo([]byte{0, 1, 2, 3}, serverConn.LocalAddr())
+		require.NoError(t, err)
+	}
+	dialer := QUICDialer{Transport: newQUICTransport(t, newLocalUDPConn(t)), TLSConfig: tlsConfig}
+	conn, err := dialer.Dial(ctx, serverConn.LocalAddr())
+	require.NoError(t, err)
+	defer conn.CloseWithError(0, "")
+	frame := []byte{0, 4, 5, 6}
+	require.NoError(t, conn.SendMessage(frame))
+
+	buf := make([]byte, 100)
+	n, _, err := ingress.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, frame, buf[:n])
+}
+
+func TestSenderRedial(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tlsConfig, err := appnet.GenerateTLSConfig()
+	require.NoError(t, err)
+	serverConn := newLocalUDPConn(t)
+	ingress, err := NewQUICIngressConn(ctx, newQUICTransport(t, serverConn), tlsConfig, false)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	conn := mock_net.NewMockPacketConn(ctrl)
+	conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(f []byte, _ interface{}) (int, error) {
+			return len(f), nil
+		}).Times(2)
+
+	quicDialer := QUICDialer{
+		Transport: newQUICTransport(t, newLocalUDPConn(t)),
+		TLSConfig: tlsConfig,
+	}
+	dials := 0
+	dialer := dialerFunc(func(ctx context.Context, _ net.Addr) (quic.Connection, error) {
+		dials++
+		if dials == 1 {
+			return nil, serrors.New("not supported")
+		}
+		return quicDialer.Dial(ctx, serverConn.LocalAddr())
+	})
+	c := &sender{
+		conn:    conn,
+		address: &snet.UDPAddr{Host: &net.UDPAddr{IP: net.IP{192, 168, 1, 2}, Port: 30056}},
+		dialer:  dialer,
+	}
+	defer func() {
+		if c.quicConn != nil {
+			_ = c.quicConn.CloseWithError(0, "")
+		}
+	}()
+
+	// The failed dial falls back to plain frames.
+	require.NoError(t, c.send([]byte{0, 1}))
+	assert.True(t, c.plain)
+	assert.Equal(t, quicRedialInterval, c.dialBackoff)
+
+	// Once the backoff expired, the connection is re-established in the
+	// background while plain frames are sent.
+	c.nextDial = time.Time{}
+	require.NoError(t, c.send([]byte{0, 2}))
+	require.NotNil(t, c.redial)
+	require.Eventually(t, func() bool { return len(c.redial) == 1 },
+		time.Second, 10*time.Millisecond)
+
+	require.NoError(t, c.send([]byte{0, 3}))
+	assert.False(t, c.plain)
+	assert.Zero(t, c.dialBackoff)
+	buf := make([]byte, 100)
+	n, _, err := ingress.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 3}, buf[:n])
+}
+
+type dialerFunc func(context.Context, net.Addr) (quic.Connection, error)
+
+func (f dialerFunc) Dial(ctx context.Context, remote net.Addr) (quic.Connection, error) {
+	return f(ctx, remote)
+}
+
+func newLocalUDPConn(t *testing.T) net.PacketConn {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func newQUICTransport(t *testing.T, conn net.PacketConn) *quic.Transport {
+	transport := &quic.Transport{Conn: conn}
+	t.Cleanup(func() { transport.Close() })
+	return transport
+}
@@ -15,7 +15,11 @@
 package dataplane
 
 import (
+	"context"
 	"net"
+	"time"
+
+	"github.com/quic-go/quic-go"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
@@ -42,11 +46,36 @@ type sender struct {
 	path               snet.Path
 	pathFingerprint    snet.PathFingerprint
 	metrics            SessionMetrics
+	// mtu is the size of the largest IP packet that fits into a single frame.
+	// It is immutable, contrary to the frame buffer of the encoder.
+	mtu int
+
+	// dialer is used to establish the QUIC connection for encrypted frames. If
+	// nil, frames are sent unencrypted.
+	dialer DatagramDialer
+	// requireEncryption prevents falling back to unencrypted frames if no QUIC
+	// connection can be established.
+	requireEncryption bool
+	// The fields below are only accessed by the sending goroutine.
+	//
+	// quicConn is the QUIC connection used for encrypted frames.
+	quicConn quic.Connection
+	// nextDial is the earliest time of the next attempt to establish quicConn.
+	nextDial time.Time
+	// dialBackoff is the interval between failed attempts to establish
+	// quicConn. It is doubled after each failed attempt.
+	dialBackoff time.Duration
+	// redial delivers the result of the attempt to establish quicConn in the
+	// background. It is nil if no attempt is in progress.
+	redial chan dialResult
+	// plain indicates that the sender fell back to unencrypted frames.
+	plain bool
 }
 
 func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 	gatewayAddr net.UDPAddr, pathStatsPublisher PathStatsPublisher,
-	metrics SessionMetrics) (*sender, error) {
+	metrics SessionMetrics, dialer DatagramDialer, requireEncryption bool,
+	batchDelay time.Duration) (*sender, error) {
 
 	// MTU must account for the size of the SCION header.
 	localAddr := conn.LocalAddr().(*snet.UDPAddr)
@@ -57,12 +86,20 @@ func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 	}
 	pathLen := len(scionPath.Raw)
 	mtu := int(path.Metadata().MTU) - slayers.CmnHdrLen - addrLen - pathLen - udpHdrLen
+	if dialer != nil {
+		// Frames must fit into a single QUIC packet.
+		if mtu > quicPacketLen {
+			mtu = quicPacketLen
+		}
+		mtu -= quicOverhead
+	}
 	if mtu < minMTU {
 		return nil, serrors.New("insufficient MTU", "mtu", mtu, "minMTU", minMTU)
 	}
 
 	c := &sender{
-		encoder: newEncoder(sessID, NewStreamID(), uint16(mtu)),
+		encoder: newEncoder(sessID, NewStreamID(), uint16(mtu), batchDelay),
+		mtu:     mtu - hdrLen,
 		conn:    conn,
 		address: &snet.UDPAddr{
 			IA:      path.Destination(),
@@ -74,6 +111,8 @@ func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 		path:               path,
 		pathFingerprint:    snet.Fingerprint(path),
 		metrics:            metrics,
+		dialer:             dialer,
+		requireEncryption:  requireEncryption,
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -82,6 +121,12 @@ func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 	return c, nil
 }
 
+// MTU returns the size of the largest IP packet that can be sent via the
+// sender without splitting it across multiple frames.
+func (c *sender) MTU() int {
+	return c.mtu
+}
+
 // Close closes the sender. The function returns immediately, but any buffered
 // data will still be sent out.
 func (c *sender) Close() {
@@ -103,8 +148,7 @@ func (c *sender) run() {
 			// Sender was closed and all the buffered frames were sent.
 			break
 		}
-		_, err := c.conn.WriteTo(frame, c.address)
-		if err != nil {
+		if err := c.send(frame); err != nil {
 			increaseCounterMetric(c.metrics.SendExternalErrors, 1)
 			continue
 		}
@@ -116,4 +160,124 @@ func (c *sender) run() {
 				1, int64(len(frame)))
 		}
 	}
+	if c.redial != nil {
+		if r := <-c.redial; r.conn != nil {
+			_ = r.conn.CloseWithError(0, "sender closed")
+		}
+	}
+	if c.quicConn != nil {
+		_ = c.quicConn.CloseWithError(0, "sender closed")
+	}
+}
+
+// send sends the frame to the remote gateway. If a dialer is configured, the
+// frame is sent as QUIC datagram. If no QUIC connection can be established,
+// the sender falls back to unencrypted frames unless encryption is required.
+// While it sends unencrypted frames, establishing the QUIC connection is
+// retried in the background with an exponential backoff.
+func (c *sender) send(frame []byte) error {
+	if c.dialer == nil {
+		_, err := c.conn.WriteTo(frame, c.address)
+		return err
+	}
+	if c.quicConn == nil {
+		if c.plain {
+			c.dialInBackground()
+		} else {
+			c.dial()
+		}
+	}
+	if c.quicConn != nil {
+		if err := c.quicConn.SendMessage(frame); err != nil {
+			// The connection is broken, e.g., because the remote gateway
+			// restarted. It is re-established with the next frame.
+			_ = c.quicConn.CloseWithError(0, "send failed")
+			c.quicConn = nil
+			return err
+		}
+		return nil
+	}
+	if c.requireEncryption {
+		return serrors.New("no encrypted connection to remote gateway")
+	}
+	if !c.plain {
+		log.Info("No encrypted connection to remote gateway, falling back to "+
+			"unencrypted frames", "remote", c.address, "path", c.pathFingerprint,
+			"retry_in", c.dialBackoff)
+		c.plain = true
+	}
+	_, err := c.conn.WriteTo(frame, c.address)
+	return err
+}
+
+type dialResult struct {
+	conn quic.Connection
+	err  error
+}
+
+// dial establishes the QUIC connection to the remote gateway, unless the
+// backoff after the last failed attempt did not expire yet.
+func (c *sender) dial() {
+	if time.Now().Before(c.nextDial) {
+		return
+	}
+	conn, err := c.dialRemote()
+	c.dialed(dialResult{conn: conn, err: err})
+}
+
+// dialInBackground establishes the QUIC connection to the remote gateway
+// without blocking the frames sent in the meantime. It picks up the result of
+// an attempt started earlier, or starts a new attempt once the backoff
+// expired.
+func (c *sender) dialInBackground() {
+	if c.redial != nil {
+		select {
+		case r := <-c.redial:
+			c.redial = nil
+			c.dialed(r)
+		default:
+		}
+		return
+	}
+	if time.Now().Before(c.nextDial) {
+		return
+	}
+	redial := make(chan dialResult, 1)
+	c.redial = redial
+	go func() {
+		defer log.HandlePanic()
+		conn, err := c.dialRemote()
+		redial <- dialResult{conn: conn, err: err}
+	}()
+}
+
+func (c *sender) dialRemote() (quic.Connection, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), quicDialTimeout)
+	defer cancel()
+	return c.dialer.Dial(ctx, c.address)
+}
+
+// dialed records the result of an attempt to establish the QUIC connection.
+func (c *sender) dialed(r dialResult) {
+	if r.err != nil {
+		c.dialBackoff *= 2
+		switch {
+		case c.dialBackoff == 0:
+			c.dialBackoff = quicRedialInterval
+		case c.dialBackoff > quicMaxRedialInterval:
+			c.dialBackoff = quicMaxRedialInterval
+		}
+		c.nextDial = time.Now().Add(c.dialBackoff)
+		log.Debug("Failed to establish encrypted connection to remote gateway",
+			"remote", c.address, "path", c.pathFingerprint, "retry_in", c.dialBackoff,
+			"err", r.err)
+		return
+	}
+	c.quicConn = r.conn
+	c.dialBackoff = 0
+	if c.plain {
+		log.Info("Established encrypted connection to remote gateway, stopped sending "+
+			"unencrypted frames", "remote", c.address, "path", c.pathFingerprint)
+		c.plain = false
+	}
 }
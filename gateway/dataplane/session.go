@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
@@ -59,6 +60,17 @@ type Session struct {
 	DataPlaneConn      net.PacketConn
 	PathStatsPublisher PathStatsPublisher
 	Metrics            SessionMetrics
+	// Dialer, if set, is used to establish QUIC connections to the remote
+	// gateway over which frames are sent encrypted as datagrams. If the remote
+	// gateway does not support it, unencrypted frames are sent instead.
+	Dialer DatagramDialer
+	// RequireEncryption disables the fallback to unencrypted frames. It only
+	// has an effect if Dialer is set.
+	RequireEncryption bool
+	// BatchDelay is the maximum time a frame is held back to batch more IP
+	// packets into it. If zero, frames are sent as soon as no more packets
+	// are pending.
+	BatchDelay time.Duration
 
 	mutex sync.Mutex
 	// senders is a list of currently used senders.
@@ -92,6 +104,23 @@ func (s *Session) Write(packet gopacket.Packet) {
 	s.senders[index].Write(packet.Data())
 }
 
+// MTU returns the size of the largest IP packet that can be encapsulated into a
+// single frame on all the paths of the session. Larger packets are split across
+// multiple frames and reassembled by the remote gateway. If the session has no
+// paths, zero is returned.
+func (s *Session) MTU() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var mtu int
+	for _, snd := range s.senders {
+		if m := snd.MTU(); mtu == 0 || m < mtu {
+			mtu = m
+		}
+	}
+	return mtu
+}
+
 func (s *Session) String() string {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -138,6 +167,9 @@ func (s *Session) SetPaths(paths []snet.Path) error {
 			s.GatewayAddr,
 			s.PathStatsPublisher,
 			s.Metrics,
+			s.Dialer,
+			s.RequireEncryption,
+			s.BatchDelay,
 		)
 		if err != nil {
 			// Collect newly created senders to avoid go routine leak.
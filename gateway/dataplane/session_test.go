@@ -84,6 +84,24 @@ func TestTwoPaths(t *testing.T) {
 	sess.Close()
 }
 
+func TestSessionMTU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	frameChan := make(chan ([]byte))
+	sess := createSession(t, ctrl, frameChan)
+	assert.Equal(t, 0, sess.MTU())
+
+	// The SCION header takes up 40 bytes with an empty path, the SIG frame
+	// header another 16 bytes.
+	require.NoError(t, sess.SetPaths([]snet.Path{
+		createMockPath(ctrl, 300),
+		createMockPath(ctrl, 200),
+	}))
+	assert.Equal(t, 144, sess.MTU())
+	sess.Close()
+}
+
 func TestNoLeak(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
@@ -16,6 +16,7 @@ package gateway
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"net"
 	"net/http"
@@ -27,6 +28,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	quic "github.com/quic-go/quic-go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/scionproto/scion/gateway/control"
 	controlgrpc "github.com/scionproto/scion/gateway/control/grpc"
@@ -66,10 +69,15 @@ type DataplaneSessionFactory struct {
 	PacketConnFactory  PacketConnFactory
 	PathStatsPublisher dataplane.PathStatsPublisher
 	Metrics            dataplane.SessionMetrics
+	// TLSConfig, if set, enables sending frames encrypted over QUIC.
+	TLSConfig *tls.Config
+	// RequireEncryption disables the fallback to unencrypted frames for remote
+	// gateways that do not support encryption.
+	RequireEncryption bool
 }
 
 func (dpf DataplaneSessionFactory) New(id uint8, policyID int,
-	remoteIA addr.IA, remoteAddr net.Addr) control.DataplaneSession {
+	remoteIA addr.IA, remoteAddr net.Addr, batchDelay time.Duration) control.DataplaneSession {
 
 	conn, err := dpf.PacketConnFactory.New()
 	if err != nil {
@@ -89,6 +97,14 @@ func (dpf DataplaneSessionFactory) New(id uint8, policyID int,
 		DataPlaneConn:      conn,
 		PathStatsPublisher: dpf.PathStatsPublisher,
 		Metrics:            metrics,
+		BatchDelay:         batchDelay,
+	}
+	if dpf.TLSConfig != nil {
+		sess.Dialer = dataplane.QUICDialer{
+			Transport: &quic.Transport{Conn: conn},
+			TLSConfig: dpf.TLSConfig,
+		}
+		sess.RequireEncryption = dpf.RequireEncryption
 	}
 	return sess
 }
@@ -182,6 +198,21 @@ type Gateway struct {
 	ProbeServerAddr *net.UDPAddr
 	// ProbeClientIP is the IP from which local probes will be sent out.
 	ProbeClientIP net.IP
+	// PathProbeInterval is the interval at which paths are probed. If zero, a
+	// default is used.
+	PathProbeInterval time.Duration
+	// PathProbeUpThreshold is the number of consecutive probe replies after
+	// which a path is considered alive. If zero, a default is used.
+	PathProbeUpThreshold int
+	// PathProbeDownThreshold is the number of probe intervals without a reply
+	// after which a path is considered down. If zero, a default is used.
+	PathProbeDownThreshold int
+	// SessionProbeInterval is the interval at which remote gateways are
+	// probed. If zero, a default is used.
+	SessionProbeInterval time.Duration
+	// SessionHealthExpiration is the duration after the last probe reply after
+	// which a session is considered unhealthy. If zero, a default is used.
+	SessionHealthExpiration time.Duration
 
 	// DataServerAddr is the address for encapsulated data traffic received from other gateways.
 	DataServerAddr *net.UDPAddr
@@ -203,6 +234,21 @@ type Gateway struct {
 	RouteSourceIPv6 net.IP
 	// TunnelName is the device name for the Linux global tunnel device.
 	TunnelName string
+	// PathMTUDiscovery enables path MTU discovery for tunneled traffic. IP
+	// packets that exceed the path MTU and must not be fragmented are answered
+	// with an ICMP error instead of being split across multiple frames.
+	PathMTUDiscovery bool
+	// EncryptDataplane enables sending frames to remote gateways encrypted
+	// over QUIC. Remote gateways that do not support it are sent unencrypted
+	// frames, unless RequireEncryption is set.
+	EncryptDataplane bool
+	// RequireEncryption disables the fallback to unencrypted frames, and
+	// unencrypted frames received from remote gateways are dropped.
+	// It only has an effect if EncryptDataplane is set.
+	RequireEncryption bool
+	// PathOverrides, if set, contains the operator overrides of the path
+	// selection, e.g., pinned or excluded paths.
+	PathOverrides *pathhealth.PathOverrides
 
 	// RoutingTableReader is used for routing the packets.
 	RoutingTableReader control.RoutingTableReader
@@ -216,6 +262,10 @@ type Gateway struct {
 	// HTTPServeMux is the http server mux that is used to expose gateway http
 	// endpoints.
 	HTTPServeMux *http.ServeMux
+	// Health, if set, tracks the readiness of the gateway. The gateway adds
+	// its readiness checks and exposes them via the HTTP endpoints and the
+	// gRPC health checking protocol on the control server.
+	Health *service.Health
 
 	// Metrics are the metrics exported by the gateway.
 	Metrics *Metrics
@@ -225,6 +275,22 @@ func (g *Gateway) Run(ctx context.Context) error {
 	logger := log.FromCtx(ctx)
 	logger.Debug("Gateway starting up...")
 
+	started := make(chan struct{})
+	if g.Health != nil {
+		g.Health.AddCheck("started", func(context.Context) error {
+			select {
+			case <-started:
+				return nil
+			default:
+				return serrors.New("gateway is starting up")
+			}
+		})
+		g.Health.AddCheck("daemon", func(ctx context.Context) error {
+			_, err := g.Daemon.LocalIA(ctx)
+			return err
+		})
+	}
+
 	// *************************************************************************
 	// Set up support for Linux tunnel devices.
 	// *************************************************************************
@@ -240,6 +306,8 @@ func (g *Gateway) Run(ctx context.Context) error {
 		fwMetrics.ReceiveLocalErrors = metrics.NewPromCounter(g.Metrics.ReceiveLocalErrorsTotal)
 		fwMetrics.IPPktsNoRoute = metrics.CounterWith(
 			metrics.NewPromCounter(g.Metrics.IPPktsDiscardedTotal), "reason", "no_route")
+		fwMetrics.IPPktsTooBig = metrics.CounterWith(
+			metrics.NewPromCounter(g.Metrics.IPPktsDiscardedTotal), "reason", "too_big")
 	}
 
 	tunnelName := g.TunnelName
@@ -252,8 +320,9 @@ func (g *Gateway) Run(ctx context.Context) error {
 			routemgr.FixedTunnelName(tunnelName),
 			xnet.OpenerWithOptions(ctx),
 		),
-		Router:  g.RoutingTableReader,
-		Metrics: fwMetrics,
+		Router:           g.RoutingTableReader,
+		PathMTUDiscovery: g.PathMTUDiscovery,
+		Metrics:          fwMetrics,
 	}
 	deviceManager := &routemgr.SingleDeviceManager{
 		DeviceOpener: tunnelReader.GetDeviceOpenerWithAsyncReader(ctx),
@@ -326,7 +395,9 @@ func (g *Gateway) Run(ctx context.Context) error {
 						LocalIA:    localIA,
 						LocalIP:    g.PathMonitorIP,
 					},
-					ProbeInterval:          0, // using default for now
+					ProbeInterval:          g.PathProbeInterval,
+					UpThreshold:            g.PathProbeUpThreshold,
+					DownThreshold:          g.PathProbeDownThreshold,
 					ProbesSent:             probesSent,
 					ProbesReceived:         probesReceived,
 					ProbesSendErrors:       probesSendErrors,
@@ -341,6 +412,7 @@ func (g *Gateway) Run(ctx context.Context) error {
 			},
 		},
 		revStore:              revStore,
+		overrides:             g.PathOverrides,
 		sessionPathsAvailable: sessionPathsAvailable,
 	}
 
@@ -598,6 +670,14 @@ func (g *Gateway) Run(ctx context.Context) error {
 			PrefixesAdvertised: paMetric,
 		},
 	)
+	if g.Health != nil {
+		healthServer := health.NewServer()
+		healthpb.RegisterHealthServer(discoveryServer, healthServer)
+		go func() {
+			defer log.HandlePanic()
+			g.Health.UpdateGRPC(ctx, healthServer, 0)
+		}()
+	}
 
 	go func() {
 		defer log.HandlePanic()
@@ -627,9 +707,21 @@ func (g *Gateway) Run(ctx context.Context) error {
 		}
 	}()
 
+	// The encrypted dataplane is run over QUIC with the ephemeral TLS
+	// certificates. This is only opportunistic encryption: it protects the
+	// frames against passive eavesdroppers, but DOES NOT authenticate the
+	// remote gateway. As for the control server, SCMP
+	// errors are ignored to not break the QUIC connections.
+	dataNetwork := scionNetwork
+	var dataTLSConfig *tls.Config
+	if g.EncryptDataplane {
+		dataNetwork = scionNetworkNoSCMP
+		dataTLSConfig = ephemeralTLSConfig
+	}
+
 	// Start dataplane ingress
-	if err := StartIngress(ctx, scionNetwork, g.DataServerAddr, deviceManager,
-		g.Metrics); err != nil {
+	if err := StartIngress(ctx, dataNetwork, g.DataServerAddr, dataTLSConfig,
+		g.RequireEncryption, deviceManager, g.Metrics); err != nil {
 
 		return err
 	}
@@ -676,12 +768,16 @@ func (g *Gateway) Run(ctx context.Context) error {
 			DeviceManager: deviceManager,
 			DataplaneSessionFactory: DataplaneSessionFactory{
 				PacketConnFactory: PacketConnFactory{
-					Network: scionNetwork,
+					Network: dataNetwork,
 					Addr:    &net.UDPAddr{IP: g.DataClientIP},
 				},
-				Metrics: CreateSessionMetrics(g.Metrics),
+				Metrics:           CreateSessionMetrics(g.Metrics),
+				TLSConfig:         dataTLSConfig,
+				RequireEncryption: g.RequireEncryption,
 			},
-			Metrics: CreateEngineMetrics(g.Metrics),
+			SessionProbeInterval:    g.SessionProbeInterval,
+			SessionHealthExpiration: g.SessionHealthExpiration,
+			Metrics:                 CreateEngineMetrics(g.Metrics),
 		},
 		RoutePublisherFactory: routePublisherFactory,
 		RouteSourceIPv4:       g.RouteSourceIPv4,
@@ -727,9 +823,13 @@ func (g *Gateway) Run(ctx context.Context) error {
 			RoutingPolicyPublisherAdapter{ConfigPublisher: configPublisher}, ""),
 	}
 
+	if g.Health != nil {
+		g.Health.AddStatusPages(g.HTTPEndpoints)
+	}
 	if err := g.HTTPEndpoints.Register(g.HTTPServeMux, g.ID); err != nil {
 		return serrors.WrapStr("registering HTTP pages", err)
 	}
+	close(started)
 	<-ctx.Done()
 	return nil
 }
@@ -803,8 +903,12 @@ func CreateIngressMetrics(m *Metrics) dataplane.IngressMetrics {
 	}
 }
 
+// StartIngress starts the dataplane ingress server. If tlsConfig is set, frames
+// are accepted encrypted over QUIC, and unencrypted unless requireEncryption is
+// set.
 func StartIngress(ctx context.Context, scionNetwork *snet.SCIONNetwork, dataAddr *net.UDPAddr,
-	deviceManager control.DeviceManager, metrics *Metrics) error {
+	tlsConfig *tls.Config, requireEncryption bool, deviceManager control.DeviceManager,
+	metrics *Metrics) error {
 
 	logger := log.FromCtx(ctx)
 	dataplaneServerConn, err := scionNetwork.Listen(
@@ -816,9 +920,17 @@ func StartIngress(ctx context.Context, scionNetwork *snet.SCIONNetwork, dataAddr
 	if err != nil {
 		return serrors.WrapStr("creating ingress conn", err)
 	}
+	var ingressConn dataplane.ReadConn = dataplaneServerConn
+	if tlsConfig != nil {
+		ingressConn, err = dataplane.NewQUICIngressConn(ctx,
+			&quic.Transport{Conn: dataplaneServerConn}, tlsConfig, requireEncryption)
+		if err != nil {
+			return serrors.WrapStr("creating encrypted ingress conn", err)
+		}
+	}
 	ingressMetrics := CreateIngressMetrics(metrics)
 	ingressServer := &dataplane.IngressServer{
-		Conn:          dataplaneServerConn,
+		Conn:          ingressConn,
 		DeviceManager: deviceManager,
 		Metrics:       ingressMetrics,
 	}
@@ -906,9 +1018,10 @@ func createRouteManager(ctx context.Context,
 }
 
 type TunnelReader struct {
-	DeviceOpener control.DeviceOpener
-	Router       control.RoutingTableReader
-	Metrics      dataplane.IPForwarderMetrics
+	DeviceOpener     control.DeviceOpener
+	Router           control.RoutingTableReader
+	PathMTUDiscovery bool
+	Metrics          dataplane.IPForwarderMetrics
 }
 
 func (r *TunnelReader) GetDeviceOpenerWithAsyncReader(ctx context.Context) control.DeviceOpener {
@@ -924,6 +1037,9 @@ func (r *TunnelReader) GetDeviceOpenerWithAsyncReader(ctx context.Context) contr
 			RoutingTable: r.Router,
 			Metrics:      r.Metrics,
 		}
+		if r.PathMTUDiscovery {
+			forwarder.ICMPWriter = handle
+		}
 
 		go func() {
 			defer log.HandlePanic()
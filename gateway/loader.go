@@ -57,6 +57,17 @@ func (l *Loader) Close(ctx context.Context) error {
 	return l.workerBase.CloseWrapper(ctx, nil)
 }
 
+// Check loads the session policies and the routing policy once, without
+// publishing them. All errors are reported at once in a serrors.List. Check
+// does not require the Publisher and the Trigger to be set.
+func (l *Loader) Check(ctx context.Context) error {
+	if l.SessionPoliciesFile == "" {
+		return serrors.New("SessionPoliciesFile must be set")
+	}
+	_, _, err := l.loadFiles(ctx)
+	return err
+}
+
 func (l *Loader) validate(ctx context.Context) error {
 	if l.SessionPoliciesFile == "" {
 		return serrors.New("SessionPoliciesFile must be set")
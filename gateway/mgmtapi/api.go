@@ -15,7 +15,13 @@
 package mgmtapi
 
 import (
+	"encoding/json"
 	"net/http"
+
+	"github.com/scionproto/scion/gateway/pathhealth"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/snet"
+	api "github.com/scionproto/scion/private/mgmtapi"
 )
 
 // Server implements the Posix Gateway Service API.
@@ -23,6 +29,9 @@ type Server struct {
 	Config   http.HandlerFunc
 	Info     http.HandlerFunc
 	LogLevel http.HandlerFunc
+	// PathOverrides contains the path overrides of the running gateway. If it
+	// is nil, path overrides are not supported.
+	PathOverrides *pathhealth.PathOverrides
 }
 
 // GetConfig is an indirection to the http handler.
@@ -44,3 +53,143 @@ func (s *Server) GetLogLevel(w http.ResponseWriter, r *http.Request) {
 func (s *Server) SetLogLevel(w http.ResponseWriter, r *http.Request) {
 	s.LogLevel(w, r)
 }
+
+// GetPathOverrides lists the path overrides of all remote ASes.
+func (s *Server) GetPathOverrides(w http.ResponseWriter, r *http.Request) {
+	if !s.pathOverridesSupported(w) {
+		return
+	}
+	rep := []PathOverride{}
+	for _, ia := range s.PathOverrides.Remotes() {
+		rep = append(rep, pathOverride(ia, s.PathOverrides.Get(ia)))
+	}
+	writeJSON(w, rep)
+}
+
+// SetPathOverride sets the path override of a remote AS.
+func (s *Server) SetPathOverride(w http.ResponseWriter, r *http.Request, isdAs IsdAs) {
+	if !s.pathOverridesSupported(w) {
+		return
+	}
+	ia, err := addr.ParseIA(isdAs)
+	if err != nil {
+		pathOverrideBadRequest(w, "invalid isd_as: "+err.Error())
+		return
+	}
+	var req PathOverrideConfig
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		pathOverrideBadRequest(w, "invalid request body: "+err.Error())
+		return
+	}
+	var override pathhealth.PathOverride
+	if req.Pinned != nil {
+		if override.Pinned, err = parseFingerprints(*req.Pinned); err != nil {
+			pathOverrideBadRequest(w, "invalid pinned path: "+err.Error())
+			return
+		}
+	}
+	if req.Excluded != nil {
+		if override.Excluded, err = parseFingerprints(*req.Excluded); err != nil {
+			pathOverrideBadRequest(w, "invalid excluded path: "+err.Error())
+			return
+		}
+	}
+	for _, fp := range override.Pinned {
+		for _, excluded := range override.Excluded {
+			if fp == excluded {
+				pathOverrideBadRequest(w, "path is both pinned and excluded: "+fp.String())
+				return
+			}
+		}
+	}
+	s.PathOverrides.Set(ia, override)
+	writeJSON(w, pathOverride(ia, s.PathOverrides.Get(ia)))
+}
+
+// DeletePathOverride removes the path override of a remote AS.
+func (s *Server) DeletePathOverride(w http.ResponseWriter, r *http.Request, isdAs IsdAs) {
+	if !s.pathOverridesSupported(w) {
+		return
+	}
+	ia, err := addr.ParseIA(isdAs)
+	if err != nil {
+		pathOverrideBadRequest(w, "invalid isd_as: "+err.Error())
+		return
+	}
+	s.PathOverrides.Set(ia, pathhealth.PathOverride{})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) pathOverridesSupported(w http.ResponseWriter) bool {
+	if s.PathOverrides != nil {
+		return true
+	}
+	ErrorResponse(w, Problem{
+		Detail: api.StringRef("path overrides are not supported"),
+		Status: http.StatusInternalServerError,
+		Title:  "error overriding paths",
+		Type:   api.StringRef(api.InternalError),
+	})
+	return false
+}
+
+func pathOverrideBadRequest(w http.ResponseWriter, detail string) {
+	ErrorResponse(w, Problem{
+		Detail: api.StringRef(detail),
+		Status: http.StatusBadRequest,
+		Title:  "invalid path override",
+		Type:   api.StringRef(api.BadRequest),
+	})
+}
+
+func parseFingerprints(raw []PathFingerprint) ([]snet.PathFingerprint, error) {
+	fingerprints := make([]snet.PathFingerprint, 0, len(raw))
+	for _, r := range raw {
+		fp, err := snet.ParsePathFingerprint(r)
+		if err != nil {
+			return nil, err
+		}
+		fingerprints = append(fingerprints, fp)
+	}
+	return fingerprints, nil
+}
+
+func pathOverride(ia addr.IA, override pathhealth.PathOverride) PathOverride {
+	rep := PathOverride{
+		IsdAs:    ia.String(),
+		Pinned:   []PathFingerprint{},
+		Excluded: []PathFingerprint{},
+	}
+	for _, fp := range override.Pinned {
+		rep.Pinned = append(rep.Pinned, fp.String())
+	}
+	for _, fp := range override.Excluded {
+		rep.Excluded = append(rep.Excluded, fp.String())
+	}
+	return rep
+}
+
+func writeJSON(w http.ResponseWriter, rep interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// ErrorResponse writes the problem as the HTTP response.
+func ErrorResponse(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	// no point in catching error here, there is nothing we can do about it anymore.
+	_ = enc.Encode(p)
+}
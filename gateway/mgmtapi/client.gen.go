@@ -12,6 +12,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 )
 
 // RequestEditorFn  is the function signature for the RequestEditor callback function
@@ -100,6 +102,17 @@ type ClientInterface interface {
 	SetLogLevelWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	SetLogLevel(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPathOverrides request
+	GetPathOverrides(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeletePathOverride request
+	DeletePathOverride(ctx context.Context, isdAs IsdAs, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetPathOverride request with any body
+	SetPathOverrideWithBody(ctx context.Context, isdAs IsdAs, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetPathOverride(ctx context.Context, isdAs IsdAs, body SetPathOverrideJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -162,6 +175,54 @@ func (c *Client) SetLogLevel(ctx context.Context, body SetLogLevelJSONRequestBod
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetPathOverrides(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPathOverridesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeletePathOverride(ctx context.Context, isdAs IsdAs, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeletePathOverrideRequest(c.Server, isdAs)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPathOverrideWithBody(ctx context.Context, isdAs IsdAs, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPathOverrideRequestWithBody(c.Server, isdAs, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPathOverride(ctx context.Context, isdAs IsdAs, body SetPathOverrideJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPathOverrideRequest(c.Server, isdAs, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewGetConfigRequest generates requests for GetConfig
 func NewGetConfigRequest(server string) (*http.Request, error) {
 	var err error
@@ -283,6 +344,114 @@ func NewSetLogLevelRequestWithBody(server string, contentType string, body io.Re
 	return req, nil
 }
 
+// NewGetPathOverridesRequest generates requests for GetPathOverrides
+func NewGetPathOverridesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions/path-overrides")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeletePathOverrideRequest generates requests for DeletePathOverride
+func NewDeletePathOverrideRequest(server string, isdAs IsdAs) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "isd_as", runtime.ParamLocationPath, isdAs)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions/path-overrides/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetPathOverrideRequest calls the generic SetPathOverride builder with application/json body
+func NewSetPathOverrideRequest(server string, isdAs IsdAs, body SetPathOverrideJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetPathOverrideRequestWithBody(server, isdAs, "application/json", bodyReader)
+}
+
+// NewSetPathOverrideRequestWithBody generates requests for SetPathOverride with any type of body
+func NewSetPathOverrideRequestWithBody(server string, isdAs IsdAs, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "isd_as", runtime.ParamLocationPath, isdAs)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions/path-overrides/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -339,6 +508,17 @@ type ClientWithResponsesInterface interface {
 	SetLogLevelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
 
 	SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
+
+	// GetPathOverrides request
+	GetPathOverridesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPathOverridesResponse, error)
+
+	// DeletePathOverride request
+	DeletePathOverrideWithResponse(ctx context.Context, isdAs IsdAs, reqEditors ...RequestEditorFn) (*DeletePathOverrideResponse, error)
+
+	// SetPathOverride request with any body
+	SetPathOverrideWithBodyWithResponse(ctx context.Context, isdAs IsdAs, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPathOverrideResponse, error)
+
+	SetPathOverrideWithResponse(ctx context.Context, isdAs IsdAs, body SetPathOverrideJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPathOverrideResponse, error)
 }
 
 type GetConfigResponse struct {
@@ -431,6 +611,73 @@ func (r SetLogLevelResponse) StatusCode() int {
 	return 0
 }
 
+type GetPathOverridesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]PathOverride
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPathOverridesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPathOverridesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeletePathOverrideResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r DeletePathOverrideResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeletePathOverrideResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetPathOverrideResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PathOverride
+	JSON400      *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SetPathOverrideResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetPathOverrideResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // GetConfigWithResponse request returning *GetConfigResponse
 func (c *ClientWithResponses) GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error) {
 	rsp, err := c.GetConfig(ctx, reqEditors...)
@@ -475,6 +722,41 @@ func (c *ClientWithResponses) SetLogLevelWithResponse(ctx context.Context, body
 	return ParseSetLogLevelResponse(rsp)
 }
 
+// GetPathOverridesWithResponse request returning *GetPathOverridesResponse
+func (c *ClientWithResponses) GetPathOverridesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPathOverridesResponse, error) {
+	rsp, err := c.GetPathOverrides(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPathOverridesResponse(rsp)
+}
+
+// DeletePathOverrideWithResponse request returning *DeletePathOverrideResponse
+func (c *ClientWithResponses) DeletePathOverrideWithResponse(ctx context.Context, isdAs IsdAs, reqEditors ...RequestEditorFn) (*DeletePathOverrideResponse, error) {
+	rsp, err := c.DeletePathOverride(ctx, isdAs, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeletePathOverrideResponse(rsp)
+}
+
+// SetPathOverrideWithBodyWithResponse request with arbitrary body returning *SetPathOverrideResponse
+func (c *ClientWithResponses) SetPathOverrideWithBodyWithResponse(ctx context.Context, isdAs IsdAs, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPathOverrideResponse, error) {
+	rsp, err := c.SetPathOverrideWithBody(ctx, isdAs, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPathOverrideResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetPathOverrideWithResponse(ctx context.Context, isdAs IsdAs, body SetPathOverrideJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPathOverrideResponse, error) {
+	rsp, err := c.SetPathOverride(ctx, isdAs, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPathOverrideResponse(rsp)
+}
+
 // ParseGetConfigResponse parses an HTTP response from a GetConfigWithResponse call
 func ParseGetConfigResponse(rsp *http.Response) (*GetConfigResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -592,3 +874,88 @@ func ParseSetLogLevelResponse(rsp *http.Response) (*SetLogLevelResponse, error)
 
 	return response, nil
 }
+
+// ParseGetPathOverridesResponse parses an HTTP response from a GetPathOverridesWithResponse call
+func ParseGetPathOverridesResponse(rsp *http.Response) (*GetPathOverridesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPathOverridesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []PathOverride
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeletePathOverrideResponse parses an HTTP response from a DeletePathOverrideWithResponse call
+func ParseDeletePathOverrideResponse(rsp *http.Response) (*DeletePathOverrideResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeletePathOverrideResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetPathOverrideResponse parses an HTTP response from a SetPathOverrideWithResponse call
+func ParseSetPathOverrideResponse(rsp *http.Response) (*SetPathOverrideResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetPathOverrideResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PathOverride
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -24,6 +25,15 @@ type ServerInterface interface {
 	// Set logging level
 	// (PUT /log/level)
 	SetLogLevel(w http.ResponseWriter, r *http.Request)
+	// List the path overrides
+	// (GET /sessions/path-overrides)
+	GetPathOverrides(w http.ResponseWriter, r *http.Request)
+	// Remove the path override of a remote AS
+	// (DELETE /sessions/path-overrides/{isd_as})
+	DeletePathOverride(w http.ResponseWriter, r *http.Request, isdAs IsdAs)
+	// Set the path override of a remote AS
+	// (PUT /sessions/path-overrides/{isd_as})
+	SetPathOverride(w http.ResponseWriter, r *http.Request, isdAs IsdAs)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -95,6 +105,73 @@ func (siw *ServerInterfaceWrapper) SetLogLevel(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetPathOverrides operation middleware
+func (siw *ServerInterfaceWrapper) GetPathOverrides(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPathOverrides(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeletePathOverride operation middleware
+func (siw *ServerInterfaceWrapper) DeletePathOverride(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd_as" -------------
+	var isdAs IsdAs
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "isd_as", runtime.ParamLocationPath, chi.URLParam(r, "isd_as"), &isdAs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isd_as", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeletePathOverride(w, r, isdAs)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetPathOverride operation middleware
+func (siw *ServerInterfaceWrapper) SetPathOverride(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd_as" -------------
+	var isdAs IsdAs
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "isd_as", runtime.ParamLocationPath, chi.URLParam(r, "isd_as"), &isdAs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isd_as", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetPathOverride(w, r, isdAs)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -220,6 +297,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/log/level", wrapper.SetLogLevel)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sessions/path-overrides", wrapper.GetPathOverrides)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/sessions/path-overrides/{isd_as}", wrapper.DeletePathOverride)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/sessions/path-overrides/{isd_as}", wrapper.SetPathOverride)
+	})
 
 	return r
 }
@@ -18,17 +18,38 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xVTW/bOBD9K8TsHgXL+dg96NYERWDAbYK6t8AHWhrLDCgOS1KuDUP/vRjSUmM5QYoe",
-	"ArQ3ajh88+bxcXSAkhpLBk3wUBzAobdkPMaPG1l9wW8t+sBfJZmAJi6ltVqVMigy+ZMnwzFfbrCRvPrX",
-	"4RoK+Cf/CZ2nXZ8vgjSVdNVH58hB13UZVOhLpyyDQcE1hTsW5d3jQcadUz3HLWpeW0cWXVCJqO7Dp1hz",
-	"qmtlapG2M0DTNlA8QoWrtoYMlFkThyOXZQa4k43VCEW/E/aWv3xwytSRDlNTDiuGSbDLIY1WT1gG6DI4",
-	"7fKMLvbhU7oxWzTovazxzeoD61F1zov0z/AX6LaqRPHhYSbW5ETYoHggr3biTgb8LvdcU4UowEmcT0AG",
-	"W3Q+IU0n08kFN0oWjbQKCriaTCeXkIGVYRObzEsya1XzssboGpYgemZWQQF3GG5TRnbqusvpdGS3gLuQ",
-	"Wy3VyGhjgc7MtGjLEr1ft1rc98WZ9nUq8ZJRByr5M/dHI7ZNI92etXHKBB/l+3r/aS5So22CF2ulccJK",
-	"ytrzPZXUNGRgyRh5fzGvKTJLvvuz9LiRXpWCW3NN0sDKGoVcURuiSkzdkRb+aEDriGm8qpKmOh+e9GtS",
-	"DdPgTbl+f1oNNd5NyzsMQo/G1plGGdj2BVEWI1Ei/g1V+3fRox+2z+unWRVci91fdUuLX7mleAQdT00o",
-	"Hg/QOg0FbEKwRZ4fNuRDVxwsudDl0qp8e8EjVjolVzppxClpiq9lqwMUoKmUOobZA+RG21fT6//+ZxWW",
-	"A53xP+A2sou/ANxZ8liJ1V4sbmf3n/vnGd+lkQ2PkmMz3bL7EQAA//93UAL/KQgAAA==",
+	"H4sIAAAAAAAC/9xY3XLbNhZ+lTNoL7ZTSqJjO45156Rp6pl044nS2YvWuwMShyQaEmABULHWy3ffOQBJ",
+	"kZScuN20ne2VLRI8P9/5/XDPUl3VWqFylq3vmUFba2XR/3jOxVv8pUHr6FeqlUPl/+V1XcqUO6nV6mer",
+	"FT2zaYEVp/++NJixNftitRe9Cm/tauO4EtyIl8Zow9q2jZhAmxpZkzC2Jp1gOqX0tvuQ5F5bceX/wTte",
+	"1SWyNTtZZFkcr+P1yUnMIlZz59CQmH/+9JP4evG3H/kiixeXt/cn0Vm7/ur+STt99NV/6NyXLGJOOi/x",
+	"evPN4moD1wKVk5lEQ+92Nb2yzkiVszZir3X+GrdYkjG10TUaJwNkZf946tVrnedS5RBeRwxVU7H1j0xg",
+	"0uQsYlJlmh57VG6jkYfdm5kJbcQIJGlQkJgg9nY4ppOfMXVk6Q13xbdS5WhqI5U7tOw7vANUqRYoINsf",
+	"BJ0Bh5q7IgJuoZTWoQCtwBUI1nHXWKh5jks2NjbmySleZHF6ksT88lnylJ/j0+RCnKZP+Hl2hiciTi+T",
+	"Z/wie4rn4iw9TZ7wkyzGS/EsvUie8lEcyPCxQcfCQGfebNEYKfAwFHiXlo0ggO6ZdFjZT+XmHKt2UMmN",
+	"4Tv6La34F/+koJCobcRqqdTnNGAW9s6aQU+09/l2hqTuYApxNVhph3C1YQ+kTI/qC60ymX8c22k+0dcW",
+	"XMEdcIOgcIsGGouCMuVzhWGP60eVk1rwplq5xXLnM1mrnP5q5bFwBVYgLTSWJyV+Phvbh/BPPaKN8c3z",
+	"KPxGJyVWh5gLdFwe6S1XUDQVV2CQC3IC8K4uufIKwNaYykym4DS4QlrQadoYgyrt3Yc6KAywSQsFlnXW",
+	"lPRFqVPucHKKKwG53CJwsZUkREGhP9Dh2ugUUSzhH0Y6hwqkgpcqL6Ut/FeDfZk2gCqXCtHYCBrb8LLc",
+	"gdIObCMdNSJtQFGzwbRQMuUltZz3WOhSoLFeGp0m80r5bxTTLvRCK4Wpd99pENzxhFsEJysUoJujrUQq",
+	"67hK8Ri8P7y9BoMZBtQCTP14sKEh9ig/iG4EuMyXkOyAC0GTgENmeF6hGgkzoA3YJllQ3w0RG4VnV+MS",
+	"vuc7SLrMngbIaO2CUmmHj2TXsHVjUso9MWvYq+7gKh0wW/gh9IXT71EtaPosKHALj94ioJdpU3HH1qwx",
+	"cjEgcwzWMCkOQX1XIHz37t1NP0rIMshRoeEU/2TnzdZG5lKBRUNNhJLi4yk88e08Po1Yxe9kRaP2/PIy",
+	"YpVU4ddJHA/GSuUwR8P2FXuYAbbQhpKzqrjZHdSND8yfnfQbNL4ef1B8y2VJOo8FJDwgDzPelBRDnujG",
+	"rZOSq/csekzuN0r+0lA3nRXBGA/Qqtz12ecXxzs3wm0rBQq4urlewpu61l0yjyspdC+p4O23LxYXz+KL",
+	"CKTvTgqlK9CAwVRXFSoRvk0QBPaGesAJr1rTKuM08NAjF0M4hE4bKr6gR2kDeakTH5LgX5duszA/rnh+",
+	"RYnMJnpXL30qHtvophv04WTuH08j6U9Dhdby/NNmDHvoTHvbdqvqgfw+/a5urgfkbrSVd/CKO/zAd+PV",
+	"bvycvmAR26KxQVK8jJcn5KiuUfFasjU7XcbLJ2G9L7yTq3TYS3L0Sy1B4GN5LdiavULXbS7RlNE8ieMZ",
+	"laHMXNUllzMSMwfogKhsmjRFa2lSvumVk9lncfzQ9jCYshoxK5LcdRbChtaJUE/v3nz/erouQCbDjuJ4",
+	"bilOVAJasVuSseoD8xAi14FJ/H/h8ZxbmYJUoZ4IA2Id4JvW0FyMLsF2Cei3EGsfRKnU+WogaQ9BNfC7",
+	"T8L125nwoOMPw/IV0vSYEtEDjCJWN0dA2cxA8fKfa7H7Q/Do6fNYf+hVzjTY/qWitHlMlCiTLVpqmXZF",
+	"bXHREww7yusZiNKGkgnsyQ/Insd5pm89OSzLPT3EjkwVnBZ+qMdMhirsoHDG3NH+r9XzaCbWazxCw9ro",
+	"yOY58cMuZwHY4zQ5NgpDj/zHA7G6D/y8DaEo0eFhXX3jn0+cOEDt7Pj+PDEPPnDr47alxXCfhA+A3W0u",
+	"X/+6Yuip6RFYr9WWl1JAuD6bQ/rWG3YI6pHbiEOIae4bXqFDQ6+OQTFIOKLCu40WnA7bW11qgWyd8dIi",
+	"bTNs7fcKFjHFK08I+luVaZeJHgnScPlj3c6vO1b6fbG9HVrr1IE+8N523jhNgy4NXlgsOybbLc89Lv2+",
+	"PHi+hKv95QZ3UCK3bnTL0Vd9KHRpgZeexStBJa8/7FmXV1zrUqa7KOzxk0/7i5UlvJw2j9l1D1ypUXZK",
+	"V4DS49aj9Lz7hPQNy8/DXWYz7TK/0zQ6cgnWtu08J37PyTNtbI9oZL4FWHR/ZvnPJsThZPttLcDL8bcA",
+	"oQM0pmRrVjhXr1er+0Jb167va21cu+K1XG1PiFFwI4nq+cDQkSnt9TTaP6YGo83s9Wl8dv6UPL0dDDqk",
+	"PPtS7OzPA6exy303Gbxoo7mAF36ae8qEd4EFJzvYvLh+8/d+nR1L6oZ/e9v+dwDrGOPstRkAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
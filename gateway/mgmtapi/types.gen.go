@@ -10,6 +10,9 @@ const (
 	Info  LogLevelLevel = "info"
 )
 
+// IsdAs defines model for IsdAs.
+type IsdAs = string
+
 // LogLevel defines model for LogLevel.
 type LogLevel struct {
 	// Level Logging level
@@ -19,6 +22,43 @@ type LogLevel struct {
 // LogLevelLevel Logging level
 type LogLevelLevel string
 
+// PathFingerprint Hex encoded fingerprint of a path, as listed on the status page.
+type PathFingerprint = string
+
+// PathOverride defines model for PathOverride.
+type PathOverride struct {
+	Excluded []PathFingerprint `json:"excluded"`
+	IsdAs    IsdAs             `json:"isd_as"`
+	Pinned   []PathFingerprint `json:"pinned"`
+}
+
+// PathOverrideConfig defines model for PathOverrideConfig.
+type PathOverrideConfig struct {
+	// Excluded Paths that are never used.
+	Excluded *[]PathFingerprint `json:"excluded,omitempty"`
+
+	// Pinned Paths that are used exclusively as long as one of them is usable.
+	Pinned *[]PathFingerprint `json:"pinned,omitempty"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Detail A human readable explanation specific to this occurrence of the problem that is helpful to locate the problem and give advice on how to proceed. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
+	Detail *string `json:"detail,omitempty"`
+
+	// Instance A URI reference that identifies the specific occurrence of the problem, e.g. by adding a fragment identifier or sub-path to the problem type. May be used to locate the root of this problem in the source code.
+	Instance *string `json:"instance,omitempty"`
+
+	// Status The HTTP status code generated by the origin server for this occurrence of the problem.
+	Status int `json:"status"`
+
+	// Title A short summary of the problem type. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
+	Title string `json:"title"`
+
+	// Type A URI reference that uniquely identifies the problem type only in the context of the provided API. Opposed to the specification in RFC-7807, it is neither recommended to be dereferencable and point to a human-readable documentation nor globally unique for the problem type.
+	Type *string `json:"type,omitempty"`
+}
+
 // StandardError defines model for StandardError.
 type StandardError struct {
 	// Error Error message
@@ -30,3 +70,6 @@ type BadRequest = StandardError
 
 // SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
 type SetLogLevelJSONRequestBody = LogLevel
+
+// SetPathOverrideJSONRequestBody defines body for SetPathOverride for application/json ContentType.
+type SetPathOverrideJSONRequestBody = PathOverrideConfig
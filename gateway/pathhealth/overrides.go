@@ -0,0 +1,102 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathhealth
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// PathOverride overrides the automatic path selection for the sessions to a
+// remote AS.
+type PathOverride struct {
+	// Pinned are the paths that are used exclusively, as long as at least one
+	// of them is alive and allowed by the path policy.
+	Pinned []snet.PathFingerprint
+	// Excluded are the paths that are never used.
+	Excluded []snet.PathFingerprint
+}
+
+// IsZero returns whether the override has no effect.
+func (o PathOverride) IsZero() bool {
+	return len(o.Pinned) == 0 && len(o.Excluded) == 0
+}
+
+// PathOverrides stores the path overrides per remote AS. It is safe for
+// concurrent use. The zero value is ready to use.
+type PathOverrides struct {
+	mtx       sync.RWMutex
+	overrides map[addr.IA]PathOverride
+}
+
+// Get returns the override for the remote AS. It can be called on a nil
+// receiver, in which case the zero override is returned.
+func (o *PathOverrides) Get(remote addr.IA) PathOverride {
+	if o == nil {
+		return PathOverride{}
+	}
+	o.mtx.RLock()
+	defer o.mtx.RUnlock()
+	return o.overrides[remote]
+}
+
+// Set replaces the override for the remote AS. A zero override removes it.
+func (o *PathOverrides) Set(remote addr.IA, override PathOverride) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if override.IsZero() {
+		delete(o.overrides, remote)
+		return
+	}
+	if o.overrides == nil {
+		o.overrides = make(map[addr.IA]PathOverride)
+	}
+	o.overrides[remote] = PathOverride{
+		Pinned:   append([]snet.PathFingerprint(nil), override.Pinned...),
+		Excluded: append([]snet.PathFingerprint(nil), override.Excluded...),
+	}
+}
+
+// Remotes returns the remote ASes that have an override, in ascending order.
+func (o *PathOverrides) Remotes() []addr.IA {
+	o.mtx.RLock()
+	defer o.mtx.RUnlock()
+	remotes := make([]addr.IA, 0, len(o.overrides))
+	for ia := range o.overrides {
+		remotes = append(remotes, ia)
+	}
+	sort.Slice(remotes, func(i, j int) bool { return remotes[i] < remotes[j] })
+	return remotes
+}
+
+func (o PathOverride) isPinned(fingerprint snet.PathFingerprint) bool {
+	return containsFingerprint(o.Pinned, fingerprint)
+}
+
+func (o PathOverride) isExcluded(fingerprint snet.PathFingerprint) bool {
+	return containsFingerprint(o.Excluded, fingerprint)
+}
+
+func containsFingerprint(fingerprints []snet.PathFingerprint, fp snet.PathFingerprint) bool {
+	for _, f := range fingerprints {
+		if f == fp {
+			return true
+		}
+	}
+	return false
+}
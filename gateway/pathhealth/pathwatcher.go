@@ -37,6 +37,12 @@ import (
 const (
 	// defaultProbeInterval specifies how often should path probes be sent.
 	defaultProbeInterval = 500 * time.Millisecond
+	// defaultUpThreshold is the number of consecutive probe replies after which
+	// a path is considered alive.
+	defaultUpThreshold = 3
+	// defaultDownThreshold is the number of probe intervals without a reply
+	// after which a path is considered down.
+	defaultDownThreshold = 2
 )
 
 // ProbeConnFactory is used to construct net.PacketConn objects for sending and
@@ -58,6 +64,13 @@ type DefaultPathWatcherFactory struct {
 	// Probeinterval defines the interval at which probes are sent. If it is not
 	// set a default is used.
 	ProbeInterval time.Duration
+	// UpThreshold is the number of consecutive probe replies after which a path
+	// is considered alive. If it is not set a default is used.
+	UpThreshold int
+	// DownThreshold is the number of probe intervals without a reply after
+	// which a path is considered down. The time to detect a failed path is thus
+	// ProbeInterval * DownThreshold. If it is not set a default is used.
+	DownThreshold int
 	// ProbesSent keeps track of how many path probes have been sent per remote
 	// AS.
 	ProbesSent func(remote addr.IA) metrics.Counter
@@ -93,9 +106,11 @@ func (f *DefaultPathWatcherFactory) New(
 		}
 		return create(remote)
 	}
-	return &pathWatcher{
+	w := &pathWatcher{
 		remote:        remote,
 		probeInterval: f.ProbeInterval,
+		upThreshold:   f.UpThreshold,
+		downThreshold: f.DownThreshold,
 		conn: &snet.SCIONPacketConn{
 			Conn: nc,
 			SCMPHandler: scmpHandler{
@@ -117,7 +132,9 @@ func (f *DefaultPathWatcherFactory) New(
 		probesReceived:   createCounter(f.ProbesReceived, remote),
 		probesSendErrors: createCounter(f.ProbesSendErrors, remote),
 		path:             createPathWrap(path),
-	}, nil
+	}
+	w.initDefaults()
+	return w, nil
 }
 
 type pathWatcher struct {
@@ -126,6 +143,12 @@ type pathWatcher struct {
 	// probeInterval defines the interval at which probes are sent. If it is not
 	// set a default is used.
 	probeInterval time.Duration
+	// upThreshold is the number of consecutive probe replies after which the
+	// path is considered alive.
+	upThreshold int
+	// downThreshold is the number of probe intervals without a reply after
+	// which the path is considered down.
+	downThreshold int
 	// conn is the packet conn used to send probes on. The pathwatcher takes
 	// ownership and will close it on termination.
 	conn snet.PacketConn
@@ -154,7 +177,6 @@ type pathWatcher struct {
 }
 
 func (w *pathWatcher) Run(ctx context.Context) {
-	w.initDefaults()
 	ctx, logger := log.WithLabels(
 		ctx,
 		"debug_id", log.NewDebugID().String(),
@@ -226,12 +248,22 @@ func (w *pathWatcher) State() State {
 		}
 	}
 	return State{
-		IsAlive: w.pathState.active(),
+		IsAlive: w.pathState.active(now),
 	}
 }
 
 func (w *pathWatcher) initDefaults() {
-	w.probeInterval = defaultProbeInterval
+	if w.probeInterval == 0 {
+		w.probeInterval = defaultProbeInterval
+	}
+	if w.upThreshold == 0 {
+		w.upThreshold = defaultUpThreshold
+	}
+	if w.downThreshold == 0 {
+		w.downThreshold = defaultDownThreshold
+	}
+	w.pathState.timeout = w.probeInterval * time.Duration(w.downThreshold)
+	w.pathState.upThreshold = w.upThreshold
 	w.packet = &snet.Packet{}
 }
 
@@ -304,6 +336,13 @@ func (w *pathWatcher) prepareProbePacket() error {
 }
 
 type pathState struct {
+	// timeout is the duration after the last received probe reply after which
+	// the path is considered down.
+	timeout time.Duration
+	// upThreshold is the number of consecutive probe replies required for the
+	// path to be considered alive.
+	upThreshold int
+
 	mu                sync.Mutex
 	consecutiveProbes int
 	lastReceived      time.Time
@@ -313,7 +352,7 @@ func (s *pathState) sendProbe(now time.Time) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	// Probe timed out.
-	if s.lastReceived.Add(defaultProbeInterval * 2).Before(now) {
+	if s.timedOut(now) {
 		s.consecutiveProbes = 0
 		return
 	}
@@ -323,15 +362,23 @@ func (s *pathState) receiveProbe(now time.Time) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.lastReceived = now
-	if s.consecutiveProbes < 3 {
+	if s.consecutiveProbes < s.upThreshold {
 		s.consecutiveProbes++
 	}
 }
 
-func (s *pathState) active() bool {
+// active returns whether the path is considered alive. A path is alive if
+// enough consecutive replies have been received and the last one is not older
+// than the timeout. The latter ensures that a failed path is detected as soon
+// as the timeout passes, independently of when the next probe is sent.
+func (s *pathState) active(now time.Time) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	return s.consecutiveProbes == 3
+	return s.consecutiveProbes >= s.upThreshold && !s.timedOut(now)
+}
+
+func (s *pathState) timedOut(now time.Time) bool {
+	return s.lastReceived.Add(s.timeout).Before(now)
 }
 
 // pathWrap is the monitored pathWrap it already contains a few precalculated values to
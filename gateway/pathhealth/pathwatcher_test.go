@@ -0,0 +1,70 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathhealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathStateThresholds(t *testing.T) {
+	interval := 100 * time.Millisecond
+	newState := func() *pathState {
+		w := &pathWatcher{probeInterval: interval, upThreshold: 2, downThreshold: 3}
+		w.initDefaults()
+		return &w.pathState
+	}
+	start := time.Now()
+
+	t.Run("up after threshold replies", func(t *testing.T) {
+		s := newState()
+		assert.False(t, s.active(start))
+		s.sendProbe(start)
+		s.receiveProbe(start)
+		assert.False(t, s.active(start))
+		s.sendProbe(start.Add(interval))
+		s.receiveProbe(start.Add(interval))
+		assert.True(t, s.active(start.Add(interval)))
+	})
+	t.Run("down after missed intervals", func(t *testing.T) {
+		s := newState()
+		s.receiveProbe(start)
+		s.receiveProbe(start)
+		assert.True(t, s.active(start.Add(3*interval)))
+		assert.False(t, s.active(start.Add(3*interval+time.Millisecond)))
+	})
+	t.Run("timeout resets consecutive replies", func(t *testing.T) {
+		s := newState()
+		s.receiveProbe(start)
+		s.receiveProbe(start)
+		now := start.Add(4 * interval)
+		s.sendProbe(now)
+		s.receiveProbe(now)
+		assert.False(t, s.active(now))
+		s.receiveProbe(now)
+		assert.True(t, s.active(now))
+	})
+}
+
+func TestPathWatcherDefaults(t *testing.T) {
+	w := &pathWatcher{}
+	w.initDefaults()
+	assert.Equal(t, defaultProbeInterval, w.probeInterval)
+	assert.Equal(t, defaultUpThreshold, w.upThreshold)
+	assert.Equal(t, defaultDownThreshold, w.downThreshold)
+	assert.Equal(t, defaultProbeInterval*defaultDownThreshold, w.pathState.timeout)
+}
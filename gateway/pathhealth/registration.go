@@ -45,10 +45,12 @@ type PathSelector interface {
 
 type PathInfoEntry struct {
 	Path         string
+	Fingerprint  string
 	Rejected     bool
 	RejectReason string
 	Current      bool
 	Revoked      bool
+	Pinned       bool
 }
 
 // PathInfo contains debug info about onging path monitoring.
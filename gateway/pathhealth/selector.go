@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/snet"
 )
 
@@ -26,6 +27,8 @@ const (
 	deadInfo = "dead (probes are not passing through)"
 	// rejectedInfo is a string to log about paths rejected by path policies.
 	rejectedInfo = "rejected by path policy"
+	// excludedInfo is a string to log about paths excluded by a path override.
+	excludedInfo = "excluded by operator"
 )
 
 // PathPolicy filters the set of paths.
@@ -41,6 +44,12 @@ type FilteringPathSelector struct {
 	RevocationStore
 	// PathCount is the max number of paths to return to the user. Defaults to 1.
 	PathCount int
+	// RemoteIA is the remote AS the paths lead to. It is used to look up the
+	// path override.
+	RemoteIA addr.IA
+	// Overrides, if set, contains the operator overrides of the path
+	// selection.
+	Overrides *PathOverrides
 }
 
 // Select selects the best paths.
@@ -51,35 +60,55 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 		Selectable  Selectable
 		IsCurrent   bool
 		IsRevoked   bool
+		IsPinned    bool
 	}
+	override := f.Overrides.Get(f.RemoteIA)
 
 	// Sort out the paths allowed by the path policy.
 	var allowed []Allowed
 	var dead []snet.Path
 	var rejected []snet.Path
+	var excluded []snet.Path
+	var pinned int
 	for _, selectable := range selectables {
 		path := selectable.Path()
 		if !isPathAllowed(f.PathPolicy, path) {
 			rejected = append(rejected, path)
 			continue
 		}
+		fingerprint := snet.Fingerprint(path)
+		if override.isExcluded(fingerprint) {
+			excluded = append(excluded, path)
+			continue
+		}
 
 		state := selectable.State()
 		if !state.IsAlive {
 			dead = append(dead, path)
 			continue
 		}
-		fingerprint := snet.Fingerprint(path)
 		_, isCurrent := current[fingerprint]
+		isPinned := override.isPinned(fingerprint)
+		if isPinned {
+			pinned++
+		}
 		allowed = append(allowed, Allowed{
 			Path:        path,
 			Fingerprint: fingerprint,
 			IsCurrent:   isCurrent,
 			IsRevoked:   f.RevocationStore.IsRevoked(path),
+			IsPinned:    isPinned,
 		})
 	}
-	// Sort the allowed paths according the the perf policy.
+	// Sort the allowed paths according the the perf policy. Pinned paths are
+	// always preferred.
 	sort.SliceStable(allowed, func(i, j int) bool {
+		switch {
+		case allowed[i].IsPinned && !allowed[j].IsPinned:
+			return true
+		case !allowed[i].IsPinned && allowed[j].IsPinned:
+			return false
+		}
 		// If some of the paths are alive (probes are passing through), yet still revoked
 		// prefer the non-revoked paths as the revoked ones may be flaky.
 		switch {
@@ -97,30 +126,32 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 	var pathInfo PathInfo
 	for _, a := range allowed {
 		pathInfo = append(pathInfo, PathInfoEntry{
-			Current: a.IsCurrent,
-			Revoked: a.IsRevoked,
-			Path:    fmt.Sprintf("%s", a.Path),
+			Current:     a.IsCurrent,
+			Revoked:     a.IsRevoked,
+			Pinned:      a.IsPinned,
+			Fingerprint: a.Fingerprint.String(),
+			Path:        fmt.Sprintf("%s", a.Path),
 		})
 	}
 	for _, path := range dead {
-		pathInfo = append(pathInfo, PathInfoEntry{
-			Rejected:     true,
-			RejectReason: deadInfo,
-			Path:         fmt.Sprintf("%s", path),
-		})
+		pathInfo = append(pathInfo, rejectedPathInfo(path, deadInfo))
 	}
 	for _, path := range rejected {
-		pathInfo = append(pathInfo, PathInfoEntry{
-			Rejected:     true,
-			RejectReason: rejectedInfo,
-			Path:         fmt.Sprintf("%s", path),
-		})
+		pathInfo = append(pathInfo, rejectedPathInfo(path, rejectedInfo))
+	}
+	for _, path := range excluded {
+		pathInfo = append(pathInfo, rejectedPathInfo(path, excludedInfo))
 	}
 
 	pathCount := f.PathCount
 	if pathCount == 0 {
 		pathCount = 1
 	}
+	// If some of the pinned paths are usable, only they are selected. Otherwise,
+	// the selection falls back to the other paths.
+	if pinned > 0 && pathCount > pinned {
+		pathCount = pinned
+	}
 	if pathCount > len(allowed) {
 		pathCount = len(allowed)
 	}
@@ -134,7 +165,16 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 		PathInfo:      pathInfo,
 		PathsAlive:    len(allowed),
 		PathsDead:     len(dead),
-		PathsRejected: len(rejected),
+		PathsRejected: len(rejected) + len(excluded),
+	}
+}
+
+func rejectedPathInfo(path snet.Path, reason string) PathInfoEntry {
+	return PathInfoEntry{
+		Rejected:     true,
+		RejectReason: reason,
+		Fingerprint:  snet.Fingerprint(path).String(),
+		Path:         fmt.Sprintf("%s", path),
 	}
 }
 
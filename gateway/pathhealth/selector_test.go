@@ -0,0 +1,125 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathhealth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+type testSelectable struct {
+	path  snet.Path
+	alive bool
+}
+
+func (s testSelectable) Path() snet.Path { return s.path }
+func (s testSelectable) State() State    { return State{IsAlive: s.alive} }
+
+func TestFilteringPathSelectorOverrides(t *testing.T) {
+	remote := addr.MustIAFrom(1, 0xff00_0000_0110)
+	newPath := func(ifIDs ...common.IFIDType) snet.Path {
+		p := snetpath.Path{Dst: remote}
+		for _, id := range ifIDs {
+			p.Meta.Interfaces = append(p.Meta.Interfaces, snet.PathInterface{IA: remote, ID: id})
+		}
+		return p
+	}
+	short := newPath(1, 2)
+	long := newPath(1, 2, 3, 4)
+	longer := newPath(1, 2, 3, 4, 5, 6)
+
+	testCases := map[string]struct {
+		Override  PathOverride
+		PathCount int
+		Dead      []snet.Path
+		Expected  []snet.Path
+	}{
+		"no override": {
+			PathCount: 2,
+			Expected:  []snet.Path{short, long},
+		},
+		"pinned path is used exclusively": {
+			Override:  PathOverride{Pinned: []snet.PathFingerprint{snet.Fingerprint(longer)}},
+			PathCount: 2,
+			Expected:  []snet.Path{longer},
+		},
+		"pinned paths are preferred": {
+			Override: PathOverride{Pinned: []snet.PathFingerprint{
+				snet.Fingerprint(longer), snet.Fingerprint(long),
+			}},
+			PathCount: 1,
+			Expected:  []snet.Path{long},
+		},
+		"dead pinned path falls back to other paths": {
+			Override:  PathOverride{Pinned: []snet.PathFingerprint{snet.Fingerprint(longer)}},
+			PathCount: 1,
+			Dead:      []snet.Path{longer},
+			Expected:  []snet.Path{short},
+		},
+		"excluded path is not used": {
+			Override:  PathOverride{Excluded: []snet.PathFingerprint{snet.Fingerprint(short)}},
+			PathCount: 2,
+			Expected:  []snet.Path{long, longer},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			overrides := &PathOverrides{}
+			overrides.Set(remote, tc.Override)
+			selector := FilteringPathSelector{
+				PathCount:       tc.PathCount,
+				RevocationStore: &MemoryRevocationStore{},
+				RemoteIA:        remote,
+				Overrides:       overrides,
+			}
+			var selectables []Selectable
+			for _, p := range []snet.Path{longer, long, short} {
+				alive := true
+				for _, dead := range tc.Dead {
+					if snet.Fingerprint(dead) == snet.Fingerprint(p) {
+						alive = false
+					}
+				}
+				selectables = append(selectables, testSelectable{path: p, alive: alive})
+			}
+			selection := selector.Select(selectables, nil)
+			assert.Equal(t, tc.Expected, selection.Paths)
+			assert.Equal(t, len(tc.Override.Excluded), selection.PathsRejected)
+		})
+	}
+}
+
+func TestPathOverrides(t *testing.T) {
+	var nilOverrides *PathOverrides
+	assert.True(t, nilOverrides.Get(addr.MustIAFrom(1, 1)).IsZero())
+
+	overrides := &PathOverrides{}
+	fp := snet.PathFingerprint("fingerprint")
+	overrides.Set(addr.MustIAFrom(2, 1), PathOverride{Pinned: []snet.PathFingerprint{fp}})
+	overrides.Set(addr.MustIAFrom(1, 1), PathOverride{Excluded: []snet.PathFingerprint{fp}})
+	assert.Equal(t, []addr.IA{addr.MustIAFrom(1, 1), addr.MustIAFrom(2, 1)},
+		overrides.Remotes())
+
+	overrides.Set(addr.MustIAFrom(2, 1), PathOverride{})
+	assert.Equal(t, []addr.IA{addr.MustIAFrom(1, 1)}, overrides.Remotes())
+	assert.True(t, overrides.Get(addr.MustIAFrom(2, 1)).IsZero())
+}
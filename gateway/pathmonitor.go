@@ -28,6 +28,7 @@ import (
 type PathMonitor struct {
 	*pathhealth.Monitor
 	revStore              pathhealth.RevocationStore
+	overrides             *pathhealth.PathOverrides
 	sessionPathsAvailable metrics.Gauge
 }
 
@@ -42,6 +43,8 @@ func (pm *PathMonitor) Register(
 		PathPolicy:      policies.PathPolicy,
 		PathCount:       policies.PathCount,
 		RevocationStore: pm.revStore,
+		RemoteIA:        remote,
+		Overrides:       pm.overrides,
 	})
 	return &registration{
 		Registration: reg,
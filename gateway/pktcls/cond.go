@@ -180,6 +180,8 @@ func (c CondBool) String() string {
 var _ Cond = (*CondIPv4)(nil)
 
 // CondIPv4 conditions return true if the embedded IPv4 predicate returns true.
+// IPv6 packets are evaluated if the predicate also implements IPv6Predicate,
+// otherwise the condition is false for IPv6 packets.
 type CondIPv4 struct {
 	Predicate IPv4Predicate
 }
@@ -192,17 +194,15 @@ func (c *CondIPv4) Eval(v gopacket.Layer) bool {
 	if c.Predicate == nil || v == nil {
 		return false
 	}
-	t := v.LayerType()
-	if t != layers.LayerTypeIPv4 {
-		return false
-	}
-
-	p, ok := v.(*layers.IPv4)
-	if !ok {
+	switch p := v.(type) {
+	case *layers.IPv4:
+		return c.Predicate.Eval(p)
+	case *layers.IPv6:
+		p6, ok := c.Predicate.(IPv6Predicate)
+		return ok && p6.EvalIPv6(p)
+	default:
 		return false
 	}
-
-	return c.Predicate.Eval(p)
 }
 
 func (c *CondIPv4) Type() string {
@@ -243,19 +243,22 @@ func (c *CondPorts) Eval(v gopacket.Layer) bool {
 	}
 	// Port predicates are independent on particular L3 or L4 protocol.
 	// Here we extract the ports and pass them to the embedded predicate.
-	l3 := v.LayerType()
-	if l3 != layers.LayerTypeIPv4 {
-		return false
-	}
-	ipv4, ok := v.(*layers.IPv4)
-	if !ok {
+	var l4 gopacket.LayerType
+	switch ip := v.(type) {
+	case *layers.IPv4:
+		l4 = ip.NextLayerType()
+	case *layers.IPv6:
+		// Extension headers are not skipped, packets with extension headers
+		// do not match.
+		l4 = ip.NextHeader.LayerType()
+	default:
 		return false
 	}
 
-	switch ipv4.NextLayerType() {
+	switch l4 {
 	case layers.LayerTypeUDP:
 		udp := &layers.UDP{}
-		err := udp.DecodeFromBytes(ipv4.LayerPayload(), gopacket.NilDecodeFeedback)
+		err := udp.DecodeFromBytes(v.LayerPayload(), gopacket.NilDecodeFeedback)
 		if err != nil {
 			return false
 		}
@@ -265,7 +268,7 @@ func (c *CondPorts) Eval(v gopacket.Layer) bool {
 		})
 	case layers.LayerTypeTCP:
 		tcp := &layers.TCP{}
-		err := tcp.DecodeFromBytes(ipv4.LayerPayload(), gopacket.NilDecodeFeedback)
+		err := tcp.DecodeFromBytes(v.LayerPayload(), gopacket.NilDecodeFeedback)
 		if err != nil {
 			return false
 		}
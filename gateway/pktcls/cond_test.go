@@ -155,6 +155,41 @@ func TestIPCond(t *testing.T) {
 			},
 			ExpEval: false,
 		},
+		{
+			Name: "Match IPv6 destination and DSCP",
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondIPv4(
+					&pktcls.IPv4MatchDestination{
+						Net: &net.IPNet{
+							IP:   net.ParseIP("2001:db8::"),
+							Mask: net.CIDRMask(32, 128),
+						},
+					},
+				),
+				pktcls.NewCondIPv4(
+					&pktcls.IPv4MatchDSCP{
+						DSCP: 0x2e,
+					},
+				),
+			),
+			Packet: &layers.IPv6{
+				DstIP:        net.ParseIP("2001:db8::1"),
+				TrafficClass: 0x2e << 2,
+			},
+			ExpEval: true,
+		},
+		{
+			Name: "Do not match IPv6 protocol",
+			Cond: pktcls.NewCondIPv4(
+				&pktcls.IPv4MatchProtocol{
+					Protocol: 6,
+				},
+			),
+			Packet: &layers.IPv6{
+				NextHeader: layers.IPProtocolUDP,
+			},
+			ExpEval: false,
+		},
 	}
 
 	for _, test := range testCases {
@@ -203,10 +238,41 @@ func TestPortCond(t *testing.T) {
 			t.Parallel()
 			pkt := createUDPPacket(tc.SrcPort, tc.DstPort)
 			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(pkt))
+			pkt6 := createUDPv6Packet(tc.SrcPort, tc.DstPort)
+			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(pkt6))
 		})
 	}
 }
 
+func createUDPv6Packet(src, dst uint16) gopacket.Layer {
+	ip := &layers.IPv6{
+		Version:    6,
+		HopLimit:   64,
+		SrcIP:      net.ParseIP("2001:db8::3"),
+		DstIP:      net.ParseIP("2001:db8::2"),
+		NextHeader: layers.IPProtocolUDP,
+	}
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(src),
+		DstPort: layers.UDPPort(dst),
+	}
+	_ = udp.SetNetworkLayerForChecksum(ip)
+	input := gopacket.NewSerializeBuffer()
+	options := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	if err := gopacket.SerializeLayers(input, options,
+		ip, udp, gopacket.Payload([]byte("payload"))); err != nil {
+		panic(err)
+	}
+	pkt := &layers.IPv6{}
+	if err := pkt.DecodeFromBytes(input.Bytes(), gopacket.NilDecodeFeedback); err != nil {
+		panic(err)
+	}
+	return pkt
+}
+
 func createUDPPacket(src, dst uint16) gopacket.Layer {
 	ip := &layers.IPv4{
 		Version:  4,
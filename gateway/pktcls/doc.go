@@ -25,9 +25,12 @@
 // return true.  AllOf or AnyOf without subconditions return true. Boolean
 // conditions always return their internal value. IPv4 conditions include
 // predicates that compare the analyzed packet to preset values. Supported IPv4
-// conditions currently include destination network match, source network match
-// and ToS/DSCP fields match. Multiple predicates can be checked by enumerating
-// them under AllOf or AnyOf.
+// conditions currently include destination network match, source network match,
+// ToS/DSCP fields match and L4 protocol match. Despite their name, IPv4
+// conditions also match the corresponding fields of IPv6 packets. Port
+// conditions match the source or destination port ranges of TCP and UDP
+// packets. Multiple predicates can be checked by enumerating them under AllOf
+// or AnyOf.
 //
 // The package contains support for JSON marshaling and unmarshaling of
 // classes. Due to the custom formatting of the JSON output, marshaling must be
@@ -33,7 +33,16 @@ type IPv4Predicate interface {
 	fmt.Stringer
 }
 
+// IPv6Predicate is implemented by the IPv4 predicates that can also test the
+// corresponding fields of IPv6 packets. The ToS field corresponds to the IPv6
+// traffic class, and the L4 protocol to the IPv6 next header.
+type IPv6Predicate interface {
+	// EvalIPv6 returns true if the IPv6 packet matched the predicate.
+	EvalIPv6(*layers.IPv6) bool
+}
+
 var _ IPv4Predicate = (*IPv4MatchSource)(nil)
+var _ IPv6Predicate = (*IPv4MatchSource)(nil)
 
 // IPv4MatchSource checks whether the source IPv4 address is contained in Net.
 type IPv4MatchSource struct {
@@ -48,6 +57,10 @@ func (m *IPv4MatchSource) Eval(p *layers.IPv4) bool {
 	return m.Net.Contains(p.SrcIP)
 }
 
+func (m *IPv4MatchSource) EvalIPv6(p *layers.IPv6) bool {
+	return m.Net.Contains(p.SrcIP)
+}
+
 func (m *IPv4MatchSource) String() string {
 	if m.Net == nil {
 		return "src="
@@ -78,6 +91,7 @@ func (m *IPv4MatchSource) UnmarshalJSON(b []byte) error {
 }
 
 var _ IPv4Predicate = (*IPv4MatchDestination)(nil)
+var _ IPv6Predicate = (*IPv4MatchDestination)(nil)
 
 // IPv4MatchDestination checks whether the destination IPv4 address is contained in
 // Net.
@@ -93,6 +107,10 @@ func (m *IPv4MatchDestination) Eval(p *layers.IPv4) bool {
 	return m.Net.Contains(p.DstIP)
 }
 
+func (m *IPv4MatchDestination) EvalIPv6(p *layers.IPv6) bool {
+	return m.Net.Contains(p.DstIP)
+}
+
 func (m *IPv4MatchDestination) String() string {
 	if m.Net == nil {
 		return "dst="
@@ -122,6 +140,7 @@ func (m *IPv4MatchDestination) UnmarshalJSON(b []byte) error {
 }
 
 var _ IPv4Predicate = (*IPv4MatchToS)(nil)
+var _ IPv6Predicate = (*IPv4MatchToS)(nil)
 
 // IPv4MatchToS checks whether the ToS field matches.
 type IPv4MatchToS struct {
@@ -136,6 +155,10 @@ func (m *IPv4MatchToS) Eval(p *layers.IPv4) bool {
 	return m.TOS == p.TOS
 }
 
+func (m *IPv4MatchToS) EvalIPv6(p *layers.IPv6) bool {
+	return m.TOS == p.TrafficClass
+}
+
 func (m *IPv4MatchToS) String() string {
 	return fmt.Sprintf("tos=%s", m.toHex())
 }
@@ -163,6 +186,7 @@ func (m *IPv4MatchToS) UnmarshalJSON(b []byte) error {
 }
 
 var _ IPv4Predicate = (*IPv4MatchDSCP)(nil)
+var _ IPv6Predicate = (*IPv4MatchDSCP)(nil)
 
 // IPv4MatchDSCP checks whether the DSCP subset of the TOS field matches.
 type IPv4MatchDSCP struct {
@@ -177,6 +201,10 @@ func (m *IPv4MatchDSCP) Eval(p *layers.IPv4) bool {
 	return m.DSCP == p.TOS>>2
 }
 
+func (m *IPv4MatchDSCP) EvalIPv6(p *layers.IPv6) bool {
+	return m.DSCP == p.TrafficClass>>2
+}
+
 func (m *IPv4MatchDSCP) String() string {
 	return fmt.Sprintf("dscp=%s", m.toHex())
 }
@@ -203,6 +231,7 @@ func (m *IPv4MatchDSCP) UnmarshalJSON(b []byte) error {
 }
 
 var _ IPv4Predicate = (*IPv4MatchProtocol)(nil)
+var _ IPv6Predicate = (*IPv4MatchProtocol)(nil)
 
 // IPv4Matchprotocol checks whether the the L4 protocol matches.
 type IPv4MatchProtocol struct {
@@ -217,6 +246,10 @@ func (m *IPv4MatchProtocol) Eval(p *layers.IPv4) bool {
 	return m.Protocol == uint8(p.Protocol)
 }
 
+func (m *IPv4MatchProtocol) EvalIPv6(p *layers.IPv6) bool {
+	return m.Protocol == uint8(p.NextHeader)
+}
+
 func (m *IPv4MatchProtocol) String() string {
 	return fmt.Sprintf("protocol=%s", layers.IPProtocolMetadata[m.Protocol].Name)
 }
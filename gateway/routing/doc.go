@@ -60,4 +60,12 @@
 //	                           10.0.2.0/24. It also matches 10.0.1.0/24 and 10.0.2.0/24.
 //	!10.0.1.0/24,10.0.2.0/24   Matches all IP prefixes that are not a subset of 10.0.1.0/24 and
 //	                           not a subset of 10.0.2.0/24.
+//
+// Accept and reject rules can additionally restrict the length of the prefixes
+// they apply to, similar to BGP prefix lists. The options follow the prefix
+// matcher column. A rule only applies to a learned prefix if its length is
+// within the bounds.
+//
+//	ge=<n>    The rule applies to prefixes with a length of at least n.
+//	le=<n>    The rule applies to prefixes with a length of at most n.
 package routing
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
@@ -27,18 +28,26 @@ import (
 	"github.com/scionproto/scion/pkg/private/serrors"
 )
 
+// Options that restrict the prefix length a rule applies to.
+const (
+	minLengthOption = "ge"
+	maxLengthOption = "le"
+)
+
 // MarshalText marshals the policy.
 func (p Policy) MarshalText() ([]byte, error) {
 	var buf bytes.Buffer
 	writer := tabwriter.NewWriter(&buf, 0, 0, 4, ' ', 0)
 
 	for _, rule := range p.Rules {
-		var nextHop string
+		// The last column either holds the next hop of advertise rules or the
+		// prefix length options of accept and reject rules.
+		options := rule.Network.lengthOptions()
 		if rule.NextHop != nil {
-			nextHop = rule.NextHop.String()
+			options = rule.NextHop.String()
 		}
 		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t", rule.Action, rule.From, rule.To, rule.Network,
-			nextHop)
+			options)
 		if len(rule.Comment) != 0 {
 			fmt.Fprintf(writer, "# %s", rule.Comment)
 		}
@@ -99,15 +108,23 @@ func parseRule(b []byte) (Rule, error) {
 	}
 
 	maxColumns := 4
-	if action == Advertise {
+	switch action {
+	case Advertise:
 		maxColumns = 5
+	case Accept, Reject:
+		maxColumns = 6
 	}
 	if len(columns) > maxColumns {
 		return Rule{}, serrors.New("invalid number of columns", "columns", len(columns))
 	}
 
 	var nextHop net.IP
-	if len(columns) >= 5 {
+	switch {
+	case action == Accept || action == Reject:
+		if err := parseLengthOptions(columns[4:], &networkMatcher); err != nil {
+			return Rule{}, serrors.WrapStr("parsing prefix length options", err)
+		}
+	case len(columns) >= 5:
 		nextHop = net.ParseIP(string(columns[4]))
 		if nextHop == nil {
 			return Rule{}, serrors.New("invalid pingable address", "input", string(columns[4]))
@@ -160,6 +177,39 @@ func parseNetworkMatcher(b []byte) (NetworkMatcher, error) {
 	}, nil
 }
 
+// parseLengthOptions parses the prefix length options "ge=<n>" and "le=<n>" and
+// sets the corresponding bounds on the network matcher.
+func parseLengthOptions(options [][]byte, m *NetworkMatcher) error {
+	for _, option := range options {
+		key, value, ok := strings.Cut(string(option), "=")
+		if !ok {
+			return serrors.New("invalid option", "input", string(option))
+		}
+		length, err := strconv.Atoi(value)
+		if err != nil || length <= 0 || length > 128 {
+			return serrors.New("invalid prefix length", "input", string(option))
+		}
+		var bound *int
+		switch key {
+		case minLengthOption:
+			bound = &m.MinLength
+		case maxLengthOption:
+			bound = &m.MaxLength
+		default:
+			return serrors.New("unknown option", "input", string(option))
+		}
+		if *bound != 0 {
+			return serrors.New("duplicate option", "input", string(option))
+		}
+		*bound = length
+	}
+	if m.MinLength != 0 && m.MaxLength != 0 && m.MinLength > m.MaxLength {
+		return serrors.New("minimum prefix length larger than maximum",
+			minLengthOption, m.MinLength, maxLengthOption, m.MaxLength)
+	}
+	return nil
+}
+
 func parseAction(b []byte) (Action, error) {
 	switch string(b) {
 	case Accept.String():
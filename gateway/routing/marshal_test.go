@@ -16,6 +16,7 @@ package routing_test
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"testing"
@@ -123,6 +124,30 @@ func testPolicies(t *testing.T) map[string]routing.Policy {
 				},
 			},
 		},
+		"prefixlength.policy": {
+			Rules: []routing.Rule{
+				{
+					Action: routing.Reject,
+					From:   routing.NewIAMatcher(t, "0-0"),
+					To:     routing.NewIAMatcher(t, "1-ff00:0:112"),
+					Network: routing.NetworkMatcher{
+						Allowed:   []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+						MaxLength: 15,
+					},
+					Comment: "Reject aggregates",
+				},
+				{
+					Action: routing.Accept,
+					From:   routing.NewIAMatcher(t, "1-ff00:0:110"),
+					To:     routing.NewIAMatcher(t, "1-ff00:0:112"),
+					Network: routing.NetworkMatcher{
+						Allowed:   []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+						MinLength: 16,
+						MaxLength: 24,
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -161,6 +186,40 @@ func TestParseRule(t *testing.T) {
 			Input:        []byte("reject 1-ff00:0:110 1-ff00:0:111 127.0.0.0/24 127.0.0.0/24"),
 			ErrAssertion: assert.Error,
 		},
+		"prefix length options": {
+			Input: []byte("accept 1-ff00:0:110 1-ff00:0:111 10.0.0.0/8 le=24 ge=16"),
+			Expected: routing.Rule{
+				Action: routing.Accept,
+				From:   routing.NewIAMatcher(t, "1-ff00:0:110"),
+				To:     routing.NewIAMatcher(t, "1-ff00:0:111"),
+				Network: routing.NetworkMatcher{
+					Allowed:   []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+					MinLength: 16,
+					MaxLength: 24,
+				},
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"unknown option": {
+			Input:        []byte("accept 1-ff00:0:110 1-ff00:0:111 10.0.0.0/8 eq=24"),
+			ErrAssertion: assert.Error,
+		},
+		"invalid prefix length": {
+			Input:        []byte("accept 1-ff00:0:110 1-ff00:0:111 10.0.0.0/8 le=129"),
+			ErrAssertion: assert.Error,
+		},
+		"duplicate option": {
+			Input:        []byte("accept 1-ff00:0:110 1-ff00:0:111 10.0.0.0/8 le=24 le=16"),
+			ErrAssertion: assert.Error,
+		},
+		"inverted prefix length bounds": {
+			Input:        []byte("reject 1-ff00:0:110 1-ff00:0:111 10.0.0.0/8 ge=24 le=16"),
+			ErrAssertion: assert.Error,
+		},
+		"prefix length on advertise": {
+			Input:        []byte("advertise 1-ff00:0:110 1-ff00:0:111 10.0.0.0/8 le=24"),
+			ErrAssertion: assert.Error,
+		},
 		"invalid action": {
 			Input:        []byte("party 1-ff00:0:110 1-ff00:0:111 127.0.0.0/24"),
 			ErrAssertion: assert.Error,
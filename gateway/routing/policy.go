@@ -81,7 +81,7 @@ func (p Policy) Match(from, to addr.IA, ipPrefix netip.Prefix) (IPSet, error) {
 	}
 	for i := len(p.Rules) - 1; i >= 0; i-- {
 		rule := p.Rules[i]
-		if !rule.From.Match(from) || !rule.To.Match(to) {
+		if !rule.From.Match(from) || !rule.To.Match(to) || !rule.Network.MatchLength(ipPrefix) {
 			continue
 		}
 		set, err := rule.Network.IPSet()
@@ -129,6 +129,24 @@ type IAMatcher interface {
 type NetworkMatcher struct {
 	Allowed []netip.Prefix
 	Negated bool
+	// MinLength is the minimum prefix length a prefix must have for the
+	// matcher to apply. Zero means no lower bound.
+	MinLength int
+	// MaxLength is the maximum prefix length a prefix may have for the matcher
+	// to apply. Zero means no upper bound.
+	MaxLength int
+}
+
+// MatchLength returns whether the length of the prefix is within the bounds of
+// the matcher.
+func (m NetworkMatcher) MatchLength(prefix netip.Prefix) bool {
+	if m.MinLength != 0 && prefix.Bits() < m.MinLength {
+		return false
+	}
+	if m.MaxLength != 0 && prefix.Bits() > m.MaxLength {
+		return false
+	}
+	return true
 }
 
 // IPSet returns a set containing all IPs allowed by the matcher.
@@ -159,6 +177,18 @@ func (m NetworkMatcher) String() string {
 	return negated + strings.Join(networks, ",")
 }
 
+// lengthOptions returns the prefix length bounds in the policy file syntax.
+func (m NetworkMatcher) lengthOptions() string {
+	var options []string
+	if m.MinLength != 0 {
+		options = append(options, fmt.Sprintf("%s=%d", minLengthOption, m.MinLength))
+	}
+	if m.MaxLength != 0 {
+		options = append(options, fmt.Sprintf("%s=%d", maxLengthOption, m.MaxLength))
+	}
+	return strings.Join(options, " ")
+}
+
 // Action represents the rule decision.
 type Action int
 
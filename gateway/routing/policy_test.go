@@ -121,6 +121,22 @@ func TestNetworkMatch(t *testing.T) {
 		}
 	}
 
+	lengthBounded := func() *routing.Policy {
+		return &routing.Policy{
+			Rules: []routing.Rule{
+				{
+					Action: routing.Accept,
+					Network: routing.NetworkMatcher{
+						Allowed:   []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+						MinLength: 16,
+						MaxLength: 24,
+					},
+				},
+			},
+			DefaultAction: routing.Reject,
+		}
+	}
+
 	testCases := map[string]struct {
 		policy *routing.Policy
 		in     string
@@ -186,6 +202,21 @@ func TestNetworkMatch(t *testing.T) {
 			in:     "10.0.0.0/23",
 			out:    "10.0.0.0/23",
 		},
+		"prefix length within bounds": {
+			policy: lengthBounded(),
+			in:     "10.1.0.0/16",
+			out:    "10.1.0.0/16",
+		},
+		"prefix length too short": {
+			policy: lengthBounded(),
+			in:     "10.0.0.0/8",
+			out:    "",
+		},
+		"prefix length too long": {
+			policy: lengthBounded(),
+			in:     "10.1.1.0/25",
+			out:    "",
+		},
 	}
 	for name, tc := range testCases {
 		name, tc := name, tc
@@ -0,0 +1,352 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/loadgen"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/addrutil"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/app"
+	"github.com/scionproto/scion/private/app/command"
+	"github.com/scionproto/scion/private/app/flag"
+	"github.com/scionproto/scion/private/app/path"
+)
+
+func main() {
+	executable := filepath.Base(os.Args[0])
+	cmd := &cobra.Command{
+		Use:   executable,
+		Short: "SCION dataplane load generator.",
+		Long: executable + " generates UDP traffic over SCION paths and measures " +
+			"the latency and loss of the traffic.",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+	}
+	cmd.AddCommand(
+		command.NewVersion(cmd),
+		newRun(cmd),
+		newReflect(cmd),
+	)
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		if code := app.ExitCode(err); code != -1 {
+			os.Exit(code)
+		}
+		os.Exit(2)
+	}
+}
+
+func newRun(pather command.Pather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		rate     int
+		size     int
+		duration time.Duration
+		timeout  time.Duration
+		maxPaths int
+		sequence string
+		refresh  bool
+		out      string
+		logLevel string
+	}
+
+	var cmd = &cobra.Command{
+		Use:   "run [flags] <remote>",
+		Short: "Send traffic to a reflector and report latency and loss",
+		Example: fmt.Sprintf(`  %[1]s run 1-ff00:0:110,10.0.0.1:30100
+  %[1]s run 1-ff00:0:110,10.0.0.1:30100 --rate 10000 --size 1200 --paths 4
+  %[1]s run 1-ff00:0:110,10.0.0.1:30100 --duration 1m --out result.json`,
+			pather.CommandPath()),
+		Long: fmt.Sprintf(`'run' sends UDP packets to a remote that runs 'reflect' and measures the
+round trip time and loss of the reflected packets.
+
+The packets are sent over up to --paths paths that match --sequence. Every
+path receives --rate packets per second of --size bytes of UDP payload for
+--duration. Replies that arrive more than --timeout after the last packet was
+sent are counted as lost.
+
+The results per path and in total are printed, and written as JSON to the file
+set with --out. Use "-" to write the JSON result to stdout instead of the
+human readable summary.
+
+If no reply packet is received at all, run exits with code 1.
+On other errors, run exits with code 2.
+
+%s`, app.SequenceHelp),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			remote, err := snet.ParseUDPAddr(args[0])
+			if err != nil {
+				return serrors.WrapStr("parsing remote", err)
+			}
+			if remote.Host.Port == 0 {
+				return serrors.New("remote port must be set", "remote", args[0])
+			}
+			if flags.maxPaths < 1 {
+				return serrors.New("--paths must be positive", "paths", flags.maxPaths)
+			}
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return serrors.WrapStr("setting up logging", err)
+			}
+			cmd.SilenceUsage = true
+
+			if err := envFlags.LoadExternalVars(); err != nil {
+				return err
+			}
+			ctx := app.WithSignal(context.Background(), os.Interrupt, syscall.SIGTERM)
+			connectCtx, cancelF := context.WithTimeout(ctx, time.Second)
+			defer cancelF()
+			sd, err := daemon.NewService(envFlags.Daemon()).Connect(connectCtx)
+			if err != nil {
+				return serrors.WrapStr("connecting to SCION Daemon", err)
+			}
+			defer sd.Close()
+			info, err := app.QueryASInfo(ctx, sd)
+			if err != nil {
+				return err
+			}
+
+			targets, localIP, err := selectTargets(ctx, sd, remote, flags.sequence,
+				flags.refresh, flags.maxPaths, net.IP(envFlags.Local().AsSlice()))
+			if err != nil {
+				return err
+			}
+			conn, err := listen(ctx, envFlags.Dispatcher(), info.IA, localIP, 0, sd)
+			if err != nil {
+				return err
+			}
+
+			human := cmd.OutOrStdout()
+			if flags.out == "-" {
+				human = io.Discard
+			}
+			fmt.Fprintf(human, "Sending %d pps of %d bytes to %s over %d path(s) for %s\n",
+				flags.rate, flags.size, remote, len(targets), flags.duration)
+			res, err := loadgen.Run(ctx, loadgen.Config{
+				Conn:        conn,
+				Targets:     targets,
+				Rate:        flags.rate,
+				PayloadSize: flags.size,
+				Duration:    flags.duration,
+				Timeout:     flags.timeout,
+				ErrHandler: func(err error) {
+					log.Debug("Load generator error", "err", err)
+				},
+			})
+			if err != nil {
+				return err
+			}
+			printSummary(human, res)
+			if err := writeResult(flags.out, cmd.OutOrStdout(), res); err != nil {
+				return err
+			}
+			if res.Total.Received == 0 {
+				return app.WithExitCode(serrors.New("no reply packet received"), 1)
+			}
+			return nil
+		},
+	}
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().IntVar(&flags.rate, "rate", 100, "packets per second per path")
+	cmd.Flags().IntVar(&flags.size, "size", 100, "UDP payload size in bytes")
+	cmd.Flags().DurationVar(&flags.duration, "duration", 10*time.Second,
+		"time during which packets are sent")
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", loadgen.DefaultTimeout,
+		"time to wait for replies after the last packet")
+	cmd.Flags().IntVar(&flags.maxPaths, "paths", 1, "maximum number of paths to use")
+	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
+	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "set refresh flag for path request")
+	cmd.Flags().StringVar(&flags.out, "out", "", "file the JSON result is written to")
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+	return cmd
+}
+
+func newReflect(pather command.Pather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		port     uint16
+		logLevel string
+	}
+
+	var cmd = &cobra.Command{
+		Use:     "reflect [flags]",
+		Short:   "Return the traffic of load generators to its senders",
+		Example: fmt.Sprintf(`  %[1]s reflect --local 10.0.0.1 --port 30100`, pather.CommandPath()),
+		Long: `'reflect' listens on the local address and port and returns every packet
+sent by 'run' to its sender on the reversed path. It runs until it is
+interrupted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return serrors.WrapStr("setting up logging", err)
+			}
+			cmd.SilenceUsage = true
+
+			if err := envFlags.LoadExternalVars(); err != nil {
+				return err
+			}
+			localIP := net.IP(envFlags.Local().AsSlice())
+			if localIP == nil {
+				return serrors.New("local address must be set with --local")
+			}
+			ctx := app.WithSignal(context.Background(), os.Interrupt, syscall.SIGTERM)
+			connectCtx, cancelF := context.WithTimeout(ctx, time.Second)
+			defer cancelF()
+			sd, err := daemon.NewService(envFlags.Daemon()).Connect(connectCtx)
+			if err != nil {
+				return serrors.WrapStr("connecting to SCION Daemon", err)
+			}
+			defer sd.Close()
+			info, err := app.QueryASInfo(ctx, sd)
+			if err != nil {
+				return err
+			}
+			conn, err := listen(ctx, envFlags.Dispatcher(), info.IA, localIP, flags.port, sd)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Reflecting on %s\n", conn.LocalAddr())
+			return loadgen.Reflect(ctx, conn)
+		},
+	}
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().Uint16Var(&flags.port, "port", 30100, "UDP port to listen on")
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+	return cmd
+}
+
+// selectTargets returns a target for every path to the remote that matches
+// the sequence, up to maxPaths. If localIP is nil, it is resolved based on the
+// next hop of the first path.
+func selectTargets(ctx context.Context, sd daemon.Connector, remote *snet.UDPAddr,
+	sequence string, refresh bool, maxPaths int,
+	localIP net.IP) ([]loadgen.Target, net.IP, error) {
+
+	paths, err := sd.Paths(ctx, remote.IA, 0, daemon.PathReqFlags{Refresh: refresh})
+	if err != nil {
+		return nil, nil, serrors.WrapStr("retrieving paths", err)
+	}
+	if paths, err = path.Filter(sequence, paths); err != nil {
+		return nil, nil, err
+	}
+	if len(paths) == 0 {
+		return nil, nil, serrors.New("no path available", "remote", remote.IA)
+	}
+	path.Sort(paths)
+	if len(paths) > maxPaths {
+		paths = paths[:maxPaths]
+	}
+	targets := make([]loadgen.Target, 0, len(paths))
+	for _, p := range paths {
+		dst := remote.Copy()
+		dst.Path = p.Dataplane()
+		dst.NextHop = p.UnderlayNextHop()
+		targets = append(targets, loadgen.Target{
+			Name: snet.Fingerprint(p).String(),
+			Addr: dst,
+		})
+	}
+	if localIP == nil {
+		dst := targets[0].Addr.(*snet.UDPAddr)
+		target := dst.Host.IP
+		if dst.NextHop != nil {
+			target = dst.NextHop.IP
+		}
+		if localIP, err = addrutil.ResolveLocal(target); err != nil {
+			return nil, nil, serrors.WrapStr("resolving local address", err)
+		}
+	}
+	return targets, localIP, nil
+}
+
+func listen(ctx context.Context, dispatcher string, ia addr.IA, ip net.IP, port uint16,
+	sd daemon.Connector) (*snet.Conn, error) {
+
+	network := &snet.SCIONNetwork{
+		LocalIA: ia,
+		Dispatcher: &snet.DefaultPacketDispatcherService{
+			Dispatcher: reliable.NewDispatcher(dispatcher),
+			SCMPHandler: snet.DefaultSCMPHandler{
+				RevocationHandler: daemon.RevHandler{Connector: sd},
+			},
+		},
+	}
+	conn, err := network.Listen(ctx, "udp", &net.UDPAddr{IP: ip, Port: int(port)}, addr.SvcNone)
+	if err != nil {
+		return nil, serrors.WrapStr("listening", err)
+	}
+	return conn, nil
+}
+
+func printSummary(w io.Writer, res loadgen.Result) {
+	for i, t := range res.Targets {
+		fmt.Fprintf(w, "[%2d] %s\n", i, t.Name)
+		printTarget(w, t)
+	}
+	fmt.Fprintf(w, "--- total ---\n")
+	printTarget(w, res.Total)
+}
+
+func printTarget(w io.Writer, t loadgen.TargetResult) {
+	fmt.Fprintf(w, "     %d packets transmitted (%.0f pps), %d received, %.2f%% packet loss,"+
+		" %d duplicates, %d reordered\n",
+		t.Sent, t.SendRate, t.Received, t.Loss, t.Duplicates, t.Reordered)
+	if t.Received == 0 {
+		return
+	}
+	l := t.Latency
+	fmt.Fprintf(w, "     rtt min/avg/max/mdev = %s/%s/%s/%s p50/p90/p99 = %s/%s/%s\n",
+		l.Min, l.Avg, l.Max, l.Mdev, l.P50, l.P90, l.P99)
+}
+
+func writeResult(file string, stdout io.Writer, res loadgen.Result) error {
+	if file == "" {
+		return nil
+	}
+	w := stdout
+	if file != "-" {
+		f, err := os.Create(file)
+		if err != nil {
+			return serrors.WrapStr("creating result file", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(res); err != nil {
+		return serrors.WrapStr("writing result", err, "file", file)
+	}
+	return nil
+}
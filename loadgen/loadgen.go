@@ -0,0 +1,244 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loadgen generates UDP traffic at a configured packet rate and size
+// towards one or more targets and measures the latency and loss of the
+// traffic. The targets reflect the packets with Reflect. Combined with SCION
+// connections, every target is typically the same remote host reached over a
+// different path, such that the routers, dispatchers and gateways on the paths
+// can be benchmarked.
+package loadgen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// DefaultTimeout is the default time to wait for replies after the last
+	// packet was sent.
+	DefaultTimeout = time.Second
+
+	// maxPacketSize is the size of the receive buffers.
+	maxPacketSize = 1 << 16
+)
+
+// Target is a destination of the generated traffic.
+type Target struct {
+	// Name identifies the target in the result, e.g., the fingerprint of the
+	// path.
+	Name string
+	// Addr is the address the packets are sent to. For SCION connections, it
+	// includes the path.
+	Addr net.Addr
+}
+
+// Config configures a load generator run.
+type Config struct {
+	// Conn is used to send the packets and receive the replies. It is closed
+	// when the run finishes.
+	Conn net.PacketConn
+	// Targets are the destinations of the traffic.
+	Targets []Target
+	// Rate is the number of packets per second that are sent to every target.
+	Rate int
+	// PayloadSize is the size of the UDP payload. It must be at least
+	// HeaderLen.
+	PayloadSize int
+	// Duration is the time during which packets are sent.
+	Duration time.Duration
+	// Timeout is the time to wait for replies after the last packet was sent.
+	// Replies that arrive later are counted as lost. If zero, DefaultTimeout
+	// is used.
+	Timeout time.Duration
+	// ErrHandler is invoked for errors that do not abort the run. It may be
+	// nil.
+	ErrHandler func(error)
+}
+
+func (c *Config) validate() error {
+	if c.Conn == nil {
+		return serrors.New("connection must be set")
+	}
+	if len(c.Targets) == 0 {
+		return serrors.New("at least one target is required")
+	}
+	if c.Rate <= 0 {
+		return serrors.New("rate must be positive", "rate", c.Rate)
+	}
+	if c.PayloadSize < HeaderLen || c.PayloadSize > maxPacketSize {
+		return serrors.New("invalid payload size", "size", c.PayloadSize,
+			"min", HeaderLen, "max", maxPacketSize)
+	}
+	if c.Duration <= 0 {
+		return serrors.New("duration must be positive", "duration", c.Duration)
+	}
+	return nil
+}
+
+// Run sends the traffic to all targets and collects the replies. It returns
+// early if the context is canceled; the result then covers the packets sent
+// so far.
+func Run(ctx context.Context, cfg Config) (Result, error) {
+	if err := cfg.validate(); err != nil {
+		return Result{}, err
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	var rawRun [8]byte
+	if _, err := rand.Read(rawRun[:]); err != nil {
+		return Result{}, serrors.WrapStr("generating run ID", err)
+	}
+	r := &runner{
+		cfg:   cfg,
+		run:   binary.BigEndian.Uint64(rawRun[:]),
+		start: time.Now(),
+		flows: make([]*flow, len(cfg.Targets)),
+	}
+	for i := range r.flows {
+		r.flows[i] = &flow{}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer log.HandlePanic()
+		defer close(done)
+		r.receive()
+	}()
+	sendTime := r.send(ctx)
+
+	// Wait for the outstanding replies, then stop the receiver.
+	select {
+	case <-time.After(cfg.Timeout):
+	case <-ctx.Done():
+	}
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+	if err := cfg.Conn.Close(); err != nil {
+		r.handleErr(serrors.WrapStr("closing connection", err))
+	}
+	<-done
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := Result{
+		Start:       r.start,
+		Duration:    Millis(sendTime),
+		Rate:        cfg.Rate,
+		PayloadSize: cfg.PayloadSize,
+		Targets:     make([]TargetResult, 0, len(cfg.Targets)),
+	}
+	for i, t := range cfg.Targets {
+		res.Targets = append(res.Targets, r.flows[i].result(t.Name, sendTime))
+	}
+	res.Total = total(res.Targets, sendTime)
+	return res, nil
+}
+
+type runner struct {
+	cfg   Config
+	run   uint64
+	start time.Time
+
+	mu     sync.Mutex
+	flows  []*flow
+	closed bool
+}
+
+// send paces the packets to all targets and returns the time spent sending.
+// If sending falls behind the schedule, the missed packets are sent
+// immediately, such that the average rate is maintained.
+func (r *runner) send(ctx context.Context) time.Duration {
+	interval := time.Second / time.Duration(r.cfg.Rate)
+	count := uint64(r.cfg.Duration / interval)
+	if count == 0 {
+		count = 1
+	}
+	buf := make([]byte, r.cfg.PayloadSize)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+	for seq := uint64(0); seq < count; seq++ {
+		if wait := time.Until(r.start.Add(time.Duration(seq) * interval)); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return time.Since(r.start)
+			}
+		} else if ctx.Err() != nil {
+			return time.Since(r.start)
+		}
+		for i, t := range r.cfg.Targets {
+			h := header{Flow: uint32(i), Run: r.run, Seq: seq, Sent: time.Since(r.start)}
+			h.encode(buf)
+			_, err := r.cfg.Conn.WriteTo(buf, t.Addr)
+			r.mu.Lock()
+			r.flows[i].sent(err == nil)
+			r.mu.Unlock()
+			if err != nil {
+				r.handleErr(serrors.WrapStr("sending packet", err, "target", t.Name))
+			}
+		}
+	}
+	return time.Since(r.start)
+}
+
+func (r *runner) receive() {
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := r.cfg.Conn.ReadFrom(buf)
+		now := time.Since(r.start)
+		r.mu.Lock()
+		closed := r.closed
+		r.mu.Unlock()
+		if closed {
+			return
+		}
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			r.handleErr(serrors.WrapStr("reading packet", err))
+			continue
+		}
+		h, err := decodeHeader(buf[:n])
+		if err != nil || h.Run != r.run || int(h.Flow) >= len(r.flows) {
+			continue
+		}
+		r.mu.Lock()
+		r.flows[h.Flow].received(h.Seq, now-h.Sent)
+		r.mu.Unlock()
+	}
+}
+
+func (r *runner) handleErr(err error) {
+	if r.cfg.ErrHandler != nil {
+		r.cfg.ErrHandler(err)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgen_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/loadgen"
+)
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reliable := listen(t)
+	lossy := &dropEveryOther{PacketConn: listen(t)}
+	var wg sync.WaitGroup
+	for _, conn := range []net.PacketConn{reliable, lossy} {
+		conn := conn
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, loadgen.Reflect(ctx, conn))
+		}()
+	}
+
+	res, err := loadgen.Run(context.Background(), loadgen.Config{
+		Conn: listen(t),
+		Targets: []loadgen.Target{
+			{Name: "reliable", Addr: reliable.LocalAddr()},
+			{Name: "lossy", Addr: lossy.LocalAddr()},
+		},
+		Rate:        500,
+		PayloadSize: 100,
+		Duration:    100 * time.Millisecond,
+		Timeout:     200 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	cancel()
+	wg.Wait()
+
+	require.Len(t, res.Targets, 2)
+	r, l := res.Targets[0], res.Targets[1]
+	assert.Equal(t, "reliable", r.Name)
+	assert.Equal(t, 50, r.Sent)
+	assert.Equal(t, 50, r.Received)
+	assert.Zero(t, r.Loss)
+	assert.Positive(t, r.Latency.Min)
+	assert.LessOrEqual(t, r.Latency.Min, r.Latency.P50)
+	assert.LessOrEqual(t, r.Latency.P50, r.Latency.Max)
+
+	assert.Equal(t, "lossy", l.Name)
+	assert.Equal(t, 50, l.Sent)
+	assert.Equal(t, 25, l.Received)
+	assert.Equal(t, 50.0, l.Loss)
+
+	assert.Equal(t, 100, res.Total.Sent)
+	assert.Equal(t, 75, res.Total.Received)
+	assert.Equal(t, 25.0, res.Total.Loss)
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	sink := listen(t)
+	start := time.Now()
+	res, err := loadgen.Run(ctx, loadgen.Config{
+		Conn:        listen(t),
+		Targets:     []loadgen.Target{{Addr: sink.LocalAddr()}},
+		Rate:        100,
+		PayloadSize: loadgen.HeaderLen,
+		Duration:    time.Hour,
+	})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Positive(t, res.Targets[0].Sent)
+	assert.Equal(t, 100.0, res.Targets[0].Loss)
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	valid := func() loadgen.Config {
+		return loadgen.Config{
+			Conn:        listen(t),
+			Targets:     []loadgen.Target{{Addr: &net.UDPAddr{}}},
+			Rate:        1,
+			PayloadSize: loadgen.HeaderLen,
+			Duration:    time.Second,
+		}
+	}
+	testCases := map[string]func(c *loadgen.Config){
+		"no connection":     func(c *loadgen.Config) { c.Conn = nil },
+		"no targets":        func(c *loadgen.Config) { c.Targets = nil },
+		"zero rate":         func(c *loadgen.Config) { c.Rate = 0 },
+		"payload too small": func(c *loadgen.Config) { c.PayloadSize = loadgen.HeaderLen - 1 },
+		"payload too large": func(c *loadgen.Config) { c.PayloadSize = 1 << 17 },
+		"no duration":       func(c *loadgen.Config) { c.Duration = 0 },
+	}
+	for name, modify := range testCases {
+		modify := modify
+		t.Run(name, func(t *testing.T) {
+			cfg := valid()
+			modify(&cfg)
+			_, err := loadgen.Run(context.Background(), cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func listen(t *testing.T) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// dropEveryOther drops every other received packet.
+type dropEveryOther struct {
+	net.PacketConn
+	count int
+}
+
+func (c *dropEveryOther) ReadFrom(b []byte) (int, net.Addr, error) {
+	for {
+		n, addr, err := c.PacketConn.ReadFrom(b)
+		if err != nil {
+			return n, addr, err
+		}
+		c.count++
+		if c.count%2 == 0 {
+			return n, addr, nil
+		}
+	}
+}
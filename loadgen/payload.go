@@ -0,0 +1,70 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgen
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// HeaderLen is the length of the load generator header at the start of every
+// payload. It is the minimum payload size.
+const HeaderLen = 32
+
+// magic identifies load generator payloads.
+var magic = [4]byte{'S', 'L', 'G', 1}
+
+// header is the header of a load generator payload. The reflector returns the
+// payload unmodified, such that the sender can match the reply to the request.
+type header struct {
+	// Flow is the index of the target the packet was sent to.
+	Flow uint32
+	// Run identifies the run the packet belongs to. Replies of other runs are
+	// ignored.
+	Run uint64
+	// Seq is the sequence number of the packet within the flow.
+	Seq uint64
+	// Sent is the time the packet was sent, relative to the start of the run.
+	Sent time.Duration
+}
+
+func (h header) encode(b []byte) {
+	copy(b[0:4], magic[:])
+	binary.BigEndian.PutUint32(b[4:8], h.Flow)
+	binary.BigEndian.PutUint64(b[8:16], h.Run)
+	binary.BigEndian.PutUint64(b[16:24], h.Seq)
+	binary.BigEndian.PutUint64(b[24:32], uint64(h.Sent))
+}
+
+func decodeHeader(b []byte) (header, error) {
+	if len(b) < HeaderLen {
+		return header{}, serrors.New("payload too short", "len", len(b), "min", HeaderLen)
+	}
+	if !isLoadgen(b) {
+		return header{}, serrors.New("not a load generator payload")
+	}
+	return header{
+		Flow: binary.BigEndian.Uint32(b[4:8]),
+		Run:  binary.BigEndian.Uint64(b[8:16]),
+		Seq:  binary.BigEndian.Uint64(b[16:24]),
+		Sent: time.Duration(binary.BigEndian.Uint64(b[24:32])),
+	}, nil
+}
+
+func isLoadgen(b []byte) bool {
+	return len(b) >= HeaderLen && [4]byte{b[0], b[1], b[2], b[3]} == magic
+}
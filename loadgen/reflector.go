@@ -0,0 +1,57 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Reflect returns every load generator packet received on conn to its sender
+// until the context is canceled. Other packets are dropped. If conn is a SCION
+// connection, the replies are sent on the reversed path. The connection is
+// closed when the context is canceled.
+func Reflect(ctx context.Context, conn net.PacketConn) error {
+	go func() {
+		defer log.HandlePanic()
+		<-ctx.Done()
+		conn.Close()
+	}()
+	logger := log.FromCtx(ctx)
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, remote, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return serrors.WrapStr("reading packet", err)
+		}
+		if !isLoadgen(buf[:n]) {
+			continue
+		}
+		if _, err := conn.WriteTo(buf[:n], remote); err != nil {
+			logger.Debug("Failed to reflect packet", "remote", remote, "err", err)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Result is the result of a load generator run. It is meant to be exported as
+// JSON.
+type Result struct {
+	// Start is the time the run started.
+	Start time.Time `json:"start"`
+	// Duration is the time spent sending.
+	Duration Millis `json:"duration_ms"`
+	// Rate is the configured number of packets per second per target.
+	Rate int `json:"rate_pps"`
+	// PayloadSize is the size of the UDP payload of every packet.
+	PayloadSize int `json:"payload_size"`
+	// Targets contains the result of every target.
+	Targets []TargetResult `json:"targets"`
+	// Total aggregates the results of all targets.
+	Total TargetResult `json:"total"`
+}
+
+// TargetResult is the result of the traffic sent to one target.
+type TargetResult struct {
+	// Name is the name of the target.
+	Name string `json:"name,omitempty"`
+	// Sent is the number of packets that were sent.
+	Sent int `json:"sent"`
+	// SendErrors is the number of packets that could not be sent.
+	SendErrors int `json:"send_errors"`
+	// Received is the number of distinct packets that were reflected.
+	Received int `json:"received"`
+	// Duplicates is the number of replies that were received more than once.
+	Duplicates int `json:"duplicates"`
+	// Reordered is the number of replies that were received after a reply
+	// with a higher sequence number.
+	Reordered int `json:"reordered"`
+	// Loss is the percentage of sent packets without reply.
+	Loss float64 `json:"loss_percent"`
+	// SendRate is the achieved number of packets sent per second.
+	SendRate float64 `json:"send_rate_pps"`
+	// Latency describes the round trip times of the received replies. It is
+	// empty if no reply was received.
+	Latency Latency `json:"latency"`
+}
+
+// Latency summarizes round trip times.
+type Latency struct {
+	Min  Millis `json:"min_ms"`
+	Avg  Millis `json:"avg_ms"`
+	Max  Millis `json:"max_ms"`
+	Mdev Millis `json:"mdev_ms"`
+	P50  Millis `json:"p50_ms"`
+	P90  Millis `json:"p90_ms"`
+	P99  Millis `json:"p99_ms"`
+}
+
+// Millis is a duration that is exported as a floating point number of
+// milliseconds, rounded to microseconds.
+type Millis time.Duration
+
+// String returns the duration in milliseconds.
+func (d Millis) String() string {
+	return fmt.Sprintf("%.3fms", float64(d)/1e6)
+}
+
+// MarshalJSON encodes the duration as milliseconds.
+func (d Millis) MarshalJSON() ([]byte, error) {
+	return json.Marshal(math.Round(float64(d)/1000) / 1000)
+}
+
+// UnmarshalJSON decodes a duration in milliseconds.
+func (d *Millis) UnmarshalJSON(b []byte) error {
+	var ms float64
+	if err := json.Unmarshal(b, &ms); err != nil {
+		return err
+	}
+	*d = Millis(math.Round(ms * 1e6))
+	return nil
+}
+
+// flow tracks the packets of one target.
+type flow struct {
+	sentCount  int
+	sendErrors int
+	// seen has a bit set for every sequence number that was received.
+	seen       []uint64
+	maxSeq     uint64
+	anyRecv    bool
+	duplicates int
+	reordered  int
+	rtts       []time.Duration
+}
+
+func (f *flow) sent(ok bool) {
+	if ok {
+		f.sentCount++
+	} else {
+		f.sendErrors++
+	}
+}
+
+func (f *flow) received(seq uint64, rtt time.Duration) {
+	word, bit := seq/64, seq%64
+	for uint64(len(f.seen)) <= word {
+		f.seen = append(f.seen, 0)
+	}
+	if f.seen[word]&(1<<bit) != 0 {
+		f.duplicates++
+		return
+	}
+	f.seen[word] |= 1 << bit
+	if f.anyRecv && seq < f.maxSeq {
+		f.reordered++
+	}
+	if !f.anyRecv || seq > f.maxSeq {
+		f.maxSeq = seq
+	}
+	f.anyRecv = true
+	f.rtts = append(f.rtts, rtt)
+}
+
+func (f *flow) result(name string, sendTime time.Duration) TargetResult {
+	res := TargetResult{
+		Name:       name,
+		Sent:       f.sentCount,
+		SendErrors: f.sendErrors,
+		Received:   len(f.rtts),
+		Duplicates: f.duplicates,
+		Reordered:  f.reordered,
+		Latency:    latency(f.rtts),
+	}
+	res.finish(sendTime)
+	return res
+}
+
+func (r *TargetResult) finish(sendTime time.Duration) {
+	if r.Sent > 0 {
+		r.Loss = 100 * float64(r.Sent-r.Received) / float64(r.Sent)
+	}
+	if sendTime > 0 {
+		r.SendRate = float64(r.Sent) / sendTime.Seconds()
+	}
+}
+
+// total aggregates the target results. The latency percentiles of the total
+// are approximated by the maximum over the targets.
+func total(targets []TargetResult, sendTime time.Duration) TargetResult {
+	var t TargetResult
+	var weighted, sqWeighted float64
+	for _, r := range targets {
+		t.Sent += r.Sent
+		t.SendErrors += r.SendErrors
+		t.Duplicates += r.Duplicates
+		t.Reordered += r.Reordered
+		if r.Received == 0 {
+			continue
+		}
+		if t.Received == 0 || r.Latency.Min < t.Latency.Min {
+			t.Latency.Min = r.Latency.Min
+		}
+		for _, p := range []struct{ dst, src *Millis }{
+			{&t.Latency.Max, &r.Latency.Max},
+			{&t.Latency.P50, &r.Latency.P50},
+			{&t.Latency.P90, &r.Latency.P90},
+			{&t.Latency.P99, &r.Latency.P99},
+		} {
+			if *p.src > *p.dst {
+				*p.dst = *p.src
+			}
+		}
+		n := float64(r.Received)
+		avg, mdev := float64(r.Latency.Avg), float64(r.Latency.Mdev)
+		weighted += n * avg
+		sqWeighted += n * (mdev*mdev + avg*avg)
+		t.Received += r.Received
+	}
+	if t.Received > 0 {
+		n := float64(t.Received)
+		avg := weighted / n
+		t.Latency.Avg = Millis(avg)
+		t.Latency.Mdev = Millis(math.Sqrt(math.Max(sqWeighted/n-avg*avg, 0)))
+	}
+	t.finish(sendTime)
+	return t
+}
+
+func latency(rtts []time.Duration) Latency {
+	if len(rtts) == 0 {
+		return Latency{}
+	}
+	sorted := append([]time.Duration(nil), rtts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var sum, sqSum float64
+	for _, rtt := range sorted {
+		sum += float64(rtt)
+		sqSum += float64(rtt) * float64(rtt)
+	}
+	n := float64(len(sorted))
+	avg := sum / n
+	return Latency{
+		Min:  Millis(sorted[0]),
+		Avg:  Millis(avg),
+		Max:  Millis(sorted[len(sorted)-1]),
+		Mdev: Millis(math.Sqrt(math.Max(sqSum/n-avg*avg, 0))),
+		P50:  Millis(percentile(sorted, 50)),
+		P90:  Millis(percentile(sorted, 90)),
+		P99:  Millis(percentile(sorted, 99)),
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgen
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlow(t *testing.T) {
+	f := &flow{}
+	for i := 0; i < 5; i++ {
+		f.sent(true)
+	}
+	f.sent(false)
+	f.received(0, 1*time.Millisecond)
+	f.received(3, 4*time.Millisecond)
+	f.received(1, 2*time.Millisecond)
+	f.received(3, 9*time.Millisecond)
+	f.received(130, 3*time.Millisecond)
+
+	res := f.result("a", time.Second)
+	assert.Equal(t, "a", res.Name)
+	assert.Equal(t, 5, res.Sent)
+	assert.Equal(t, 1, res.SendErrors)
+	assert.Equal(t, 4, res.Received)
+	assert.Equal(t, 1, res.Duplicates)
+	assert.Equal(t, 1, res.Reordered)
+	assert.InDelta(t, 20.0, res.Loss, 1e-9)
+	assert.InDelta(t, 5.0, res.SendRate, 1e-9)
+	assert.Equal(t, Millis(time.Millisecond), res.Latency.Min)
+	assert.Equal(t, Millis(4*time.Millisecond), res.Latency.Max)
+	assert.Equal(t, Millis(2500*time.Microsecond), res.Latency.Avg)
+	assert.Equal(t, Millis(2*time.Millisecond), res.Latency.P50)
+	assert.Equal(t, Millis(4*time.Millisecond), res.Latency.P99)
+}
+
+func TestTotal(t *testing.T) {
+	a := (&flow{sentCount: 2, rtts: []time.Duration{time.Millisecond, time.Millisecond}}).
+		result("a", time.Second)
+	b := (&flow{sentCount: 2, rtts: []time.Duration{3 * time.Millisecond}}).
+		result("b", time.Second)
+	empty := (&flow{sentCount: 2}).result("c", time.Second)
+
+	tot := total([]TargetResult{a, b, empty}, time.Second)
+	assert.Equal(t, 6, tot.Sent)
+	assert.Equal(t, 3, tot.Received)
+	assert.InDelta(t, 50.0, tot.Loss, 1e-9)
+	assert.Equal(t, Millis(time.Millisecond), tot.Latency.Min)
+	assert.Equal(t, Millis(3*time.Millisecond), tot.Latency.Max)
+	assert.InDelta(t, float64(5*time.Millisecond/3), float64(tot.Latency.Avg), 1)
+}
+
+func TestMillisJSON(t *testing.T) {
+	raw, err := json.Marshal(Millis(1234567 * time.Nanosecond))
+	require.NoError(t, err)
+	assert.Equal(t, "1.235", string(raw))
+	var d Millis
+	require.NoError(t, json.Unmarshal(raw, &d))
+	assert.Equal(t, Millis(1235*time.Microsecond), d)
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathmon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// DefaultStatusWindow is the default time window over which the availability
+// of the paths is computed.
+const DefaultStatusWindow = time.Hour
+
+// API serves the recorded results as JSON.
+type API struct {
+	// DB is the database the results are read from.
+	DB DB
+}
+
+// ResultJSON is the JSON representation of a result.
+type ResultJSON struct {
+	Time        time.Time `json:"time"`
+	Destination addr.IA   `json:"isd_as"`
+	Fingerprint string    `json:"fingerprint"`
+	Sequence    string    `json:"sequence"`
+	Sent        int       `json:"sent"`
+	Received    int       `json:"received"`
+	MinRTT      float64   `json:"min_rtt_ms"`
+	AvgRTT      float64   `json:"avg_rtt_ms"`
+	MaxRTT      float64   `json:"max_rtt_ms"`
+}
+
+// PathStatus is the JSON representation of the status of a path.
+type PathStatus struct {
+	Destination addr.IA `json:"isd_as"`
+	Fingerprint string  `json:"fingerprint"`
+	Sequence    string  `json:"sequence"`
+	// Availability is the ratio of the monitoring rounds in the window in which
+	// the path was available.
+	Availability float64 `json:"availability"`
+	// Rounds is the number of monitoring rounds in the window.
+	Rounds int `json:"rounds"`
+	// Last is the result of the last monitoring round.
+	Last ResultJSON `json:"last"`
+}
+
+// ServeStatus serves the status of all paths that were probed in the time
+// window given by the optional query parameter "window" (e.g., "30m").
+func (a *API) ServeStatus(w http.ResponseWriter, r *http.Request) {
+	window := DefaultStatusWindow
+	if raw := r.URL.Query().Get("window"); raw != "" {
+		var err error
+		if window, err = time.ParseDuration(raw); err != nil || window <= 0 {
+			http.Error(w, fmt.Sprintf("invalid window: %q", raw), http.StatusBadRequest)
+			return
+		}
+	}
+	results, err := a.DB.Results(r.Context(), Query{Since: time.Now().Add(-window)})
+	if err != nil {
+		log.FromCtx(r.Context()).Info("Failed to read results", "err", err)
+		http.Error(w, "failed to read results", http.StatusInternalServerError)
+		return
+	}
+	type key struct {
+		dst addr.IA
+		fp  string
+	}
+	status := []PathStatus{}
+	index := make(map[key]int)
+	for _, res := range results {
+		k := key{dst: res.Destination, fp: res.Fingerprint}
+		i, ok := index[k]
+		if !ok {
+			// The results are ordered by time, the most recent first.
+			i = len(status)
+			index[k] = i
+			status = append(status, PathStatus{
+				Destination: res.Destination,
+				Fingerprint: res.Fingerprint,
+				Sequence:    res.Sequence,
+				Last:        resultJSON(res),
+			})
+		}
+		status[i].Rounds++
+		if res.Available() {
+			status[i].Availability++
+		}
+	}
+	for i := range status {
+		status[i].Availability /= float64(status[i].Rounds)
+	}
+	writeJSON(w, r, status)
+}
+
+// ServeHistory serves the recorded results, the most recent first. The
+// results can be filtered with the optional query parameters "isd_as",
+// "fingerprint", "since" (RFC 3339) and "limit".
+func (a *API) ServeHistory(w http.ResponseWriter, r *http.Request) {
+	var q Query
+	params := r.URL.Query()
+	if raw := params.Get("isd_as"); raw != "" {
+		ia, err := addr.ParseIA(raw)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid isd_as: %q", raw), http.StatusBadRequest)
+			return
+		}
+		q.Destination = ia
+	}
+	q.Fingerprint = params.Get("fingerprint")
+	if raw := params.Get("since"); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid since: %q", raw), http.StatusBadRequest)
+			return
+		}
+		q.Since = since
+	}
+	if raw := params.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			http.Error(w, fmt.Sprintf("invalid limit: %q", raw), http.StatusBadRequest)
+			return
+		}
+		q.Limit = limit
+	}
+	results, err := a.DB.Results(r.Context(), q)
+	if err != nil {
+		log.FromCtx(r.Context()).Info("Failed to read results", "err", err)
+		http.Error(w, "failed to read results", http.StatusInternalServerError)
+		return
+	}
+	history := make([]ResultJSON, 0, len(results))
+	for _, res := range results {
+		history = append(history, resultJSON(res))
+	}
+	writeJSON(w, r, history)
+}
+
+func resultJSON(r Result) ResultJSON {
+	return ResultJSON{
+		Time:        r.Time.UTC(),
+		Destination: r.Destination,
+		Fingerprint: r.Fingerprint,
+		Sequence:    r.Sequence,
+		Sent:        r.Sent,
+		Received:    r.Received,
+		MinRTT:      millis(r.MinRTT),
+		AvgRTT:      millis(r.AvgRTT),
+		MaxRTT:      millis(r.MaxRTT),
+	}
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(v); err != nil {
+		log.FromCtx(r.Context()).Info("Failed to write response", "err", err)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathmon_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pathmon"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+func TestAPI(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	now := time.Now().Truncate(time.Second)
+	db := &memDB{}
+	require.NoError(t, db.InsertResults(context.Background(), []pathmon.Result{
+		{Time: now.Add(-2 * time.Hour), Destination: ia110, Fingerprint: "a", Sent: 3},
+		{Time: now.Add(-2 * time.Minute), Destination: ia110, Fingerprint: "a", Sent: 3},
+		{Time: now.Add(-time.Minute), Destination: ia111, Fingerprint: "b", Sent: 3},
+		{
+			Time:        now.Add(-time.Minute),
+			Destination: ia110,
+			Fingerprint: "a",
+			Sent:        3,
+			Received:    3,
+			AvgRTT:      1500 * time.Microsecond,
+		},
+	}))
+	api := &pathmon.API{DB: db}
+
+	t.Run("status", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		api.ServeStatus(rr, httptest.NewRequest(http.MethodGet, "/status", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		var status []pathmon.PathStatus
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+		require.Len(t, status, 2)
+		assert.Equal(t, ia110, status[0].Destination)
+		assert.Equal(t, 2, status[0].Rounds)
+		assert.Equal(t, 0.5, status[0].Availability)
+		assert.Equal(t, 1.5, status[0].Last.AvgRTT)
+		assert.True(t, now.Add(-time.Minute).Equal(status[0].Last.Time))
+		assert.Equal(t, ia111, status[1].Destination)
+		assert.Equal(t, 0.0, status[1].Availability)
+	})
+	t.Run("status window", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		api.ServeStatus(rr, httptest.NewRequest(http.MethodGet, "/status?window=3h", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		var status []pathmon.PathStatus
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+		require.Len(t, status, 2)
+		assert.Equal(t, 3, status[0].Rounds)
+	})
+	t.Run("status invalid window", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		api.ServeStatus(rr, httptest.NewRequest(http.MethodGet, "/status?window=x", nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+	t.Run("history", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		api.ServeHistory(rr, httptest.NewRequest(http.MethodGet,
+			"/history?isd_as=1-ff00:0:110&fingerprint=a", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		var history []pathmon.ResultJSON
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &history))
+		require.Len(t, history, 3)
+		assert.Equal(t, 3, history[0].Received)
+	})
+	t.Run("history invalid", func(t *testing.T) {
+		for _, query := range []string{"isd_as=x", "since=x", "limit=-1"} {
+			rr := httptest.NewRecorder()
+			api.ServeHistory(rr, httptest.NewRequest(http.MethodGet, "/history?"+query, nil))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+	})
+}
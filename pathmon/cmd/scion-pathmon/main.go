@@ -0,0 +1,144 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	_ "net/http/pprof"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/scionproto/scion/pathmon"
+	"github.com/scionproto/scion/pathmon/config"
+	"github.com/scionproto/scion/pathmon/sqlite"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/app"
+	"github.com/scionproto/scion/private/app/launcher"
+	"github.com/scionproto/scion/private/periodic"
+	"github.com/scionproto/scion/private/service"
+	"github.com/scionproto/scion/private/storage"
+	"github.com/scionproto/scion/private/storage/cleaner"
+)
+
+var globalCfg config.Config
+
+func main() {
+	application := launcher.Application{
+		TOMLConfig: &globalCfg,
+		ShortName:  "SCION Path Monitor",
+		Main:       realMain,
+	}
+	application.Run()
+}
+
+func realMain(ctx context.Context) error {
+	dsts, err := globalCfg.PathMon.ParseDestinations()
+	if err != nil {
+		return err
+	}
+	db, err := sqlite.New(globalCfg.DB.Connection)
+	if err != nil {
+		return serrors.WrapStr("initializing result database", err)
+	}
+	storage.SetConnLimits(db, globalCfg.DB)
+	defer db.Close()
+
+	connectCtx, cancelF := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelF()
+	sd := &daemon.ReconnectingConnector{Factory: daemon.NewService(globalCfg.PathMon.Daemon)}
+	defer sd.Close()
+	localIA, err := sd.LocalIA(connectCtx)
+	if err != nil {
+		return serrors.WrapStr("querying local ISD-AS", err)
+	}
+
+	interval := globalCfg.PathMon.Interval.Duration
+	monitor := periodic.Start(&pathmon.Monitor{
+		Daemon:       sd,
+		Destinations: dsts,
+		Prober: pathmon.SCMPProber{
+			Dispatcher: reliable.NewDispatcher(globalCfg.PathMon.Dispatcher),
+			LocalIA:    localIA,
+			LocalIP:    net.ParseIP(globalCfg.PathMon.LocalIP),
+			Count:      globalCfg.PathMon.Count,
+			Interval:   globalCfg.PathMon.ProbeInterval.Duration,
+			Timeout:    globalCfg.PathMon.Timeout.Duration,
+		},
+		DB:       db,
+		MaxPaths: globalCfg.PathMon.MaxPaths,
+		Metrics:  pathmon.NewMetrics(),
+	}, interval, interval)
+	defer monitor.Stop()
+
+	retention := globalCfg.PathMon.Retention.Duration
+	resultCleaner := periodic.Start(cleaner.New(func(ctx context.Context) (int, error) {
+		return db.DeleteBefore(ctx, time.Now().Add(-retention))
+	}, "pathmon_results"), 5*time.Minute, 5*time.Minute)
+	defer resultCleaner.Stop()
+
+	g, errCtx := errgroup.WithContext(ctx)
+	var cleanup app.Cleanup
+	if globalCfg.API.Addr != "" {
+		api := &pathmon.API{DB: db}
+		r := chi.NewRouter()
+		r.Get("/api/v1/status", api.ServeStatus)
+		r.Get("/api/v1/history", api.ServeHistory)
+		log.Info("Exposing API", "addr", globalCfg.API.Addr)
+		apiServer := &http.Server{
+			Addr:    globalCfg.API.Addr,
+			Handler: r,
+		}
+		g.Go(func() error {
+			defer log.HandlePanic()
+			err := apiServer.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return serrors.WrapStr("serving API", err)
+			}
+			return nil
+		})
+		cleanup.Add(apiServer.Close)
+	}
+
+	// Start HTTP endpoints.
+	statusPages := service.StatusPages{
+		"info":      service.NewInfoStatusPage(),
+		"config":    service.NewConfigStatusPage(globalCfg),
+		"log/level": service.NewLogLevelStatusPage(),
+	}
+	if err := statusPages.Register(http.DefaultServeMux, globalCfg.General.ID); err != nil {
+		return serrors.WrapStr("registering status pages", err)
+	}
+
+	g.Go(func() error {
+		defer log.HandlePanic()
+		return globalCfg.Metrics.ServePrometheus(errCtx)
+	})
+
+	g.Go(func() error {
+		defer log.HandlePanic()
+		<-errCtx.Done()
+		return cleanup.Do()
+	})
+
+	return g.Wait()
+}
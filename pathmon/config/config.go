@@ -0,0 +1,209 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config contains the configuration of the SCION path monitor.
+package config
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/config"
+	"github.com/scionproto/scion/private/env"
+	api "github.com/scionproto/scion/private/mgmtapi"
+	"github.com/scionproto/scion/private/storage"
+)
+
+const (
+	// DefaultDBPath is the default connection string of the result database.
+	DefaultDBPath = "/share/cache/%s.results.db"
+	// DefaultInterval is the default time between two monitoring rounds.
+	DefaultInterval = 30 * time.Second
+	// DefaultCount is the default number of echo requests sent over every
+	// path in a monitoring round.
+	DefaultCount = 3
+	// DefaultProbeInterval is the default time between two echo requests on
+	// the same path.
+	DefaultProbeInterval = 100 * time.Millisecond
+	// DefaultTimeout is the default time after which an echo request is
+	// considered lost.
+	DefaultTimeout = time.Second
+	// DefaultMaxPaths is the default maximum number of paths that are probed
+	// per destination.
+	DefaultMaxPaths = 10
+	// DefaultRetention is the default time for which results are kept.
+	DefaultRetention = 7 * 24 * time.Hour
+)
+
+var _ config.Config = (*Config)(nil)
+
+type Config struct {
+	General env.General      `toml:"general,omitempty"`
+	Logging log.Config       `toml:"log,omitempty"`
+	Metrics env.Metrics      `toml:"metrics,omitempty"`
+	API     api.Config       `toml:"api,omitempty"`
+	DB      storage.DBConfig `toml:"db,omitempty"`
+	PathMon PathMonConfig    `toml:"pathmon,omitempty"`
+}
+
+func (cfg *Config) InitDefaults() {
+	config.InitAll(
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.API,
+		cfg.DB.WithDefault(fmt.Sprintf(DefaultDBPath, idSample)),
+		&cfg.PathMon,
+	)
+}
+
+func (cfg *Config) Validate() error {
+	return config.ValidateAll(
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.API,
+		&cfg.DB,
+		&cfg.PathMon,
+	)
+}
+
+func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteSample(dst, path, config.CtxMap{config.ID: idSample},
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.API,
+		config.OverrideName(
+			config.FormatData(
+				&cfg.DB,
+				fmt.Sprintf(DefaultDBPath, idSample),
+			),
+			"db",
+		),
+		&cfg.PathMon,
+	)
+}
+
+var _ config.Config = (*PathMonConfig)(nil)
+
+// PathMonConfig configures which destinations are monitored and how the
+// paths to them are probed.
+type PathMonConfig struct {
+	// Daemon is the address of the SCION Daemon that is queried for paths.
+	Daemon string `toml:"daemon,omitempty"`
+	// Dispatcher is the path to the dispatcher socket.
+	Dispatcher string `toml:"dispatcher,omitempty"`
+	// LocalIP is the local IP address the probes are sent from. If not set,
+	// the address is resolved based on the next hop of the probed path.
+	LocalIP string `toml:"local_ip,omitempty"`
+	// Destinations are the monitored hosts in the format
+	// ISD-AS,IP (e.g., 1-ff00:0:110,10.0.0.1).
+	Destinations []string `toml:"destinations,omitempty"`
+	// Interval is the time between two monitoring rounds.
+	Interval util.DurWrap `toml:"interval,omitempty"`
+	// Count is the number of echo requests sent over every path in a
+	// monitoring round.
+	Count uint16 `toml:"count,omitempty"`
+	// ProbeInterval is the time between two echo requests on the same path.
+	ProbeInterval util.DurWrap `toml:"probe_interval,omitempty"`
+	// Timeout is the time after which an echo request is considered lost.
+	Timeout util.DurWrap `toml:"timeout,omitempty"`
+	// MaxPaths is the maximum number of paths that are probed per
+	// destination.
+	MaxPaths int `toml:"max_paths,omitempty"`
+	// Retention is the time for which results are kept in the database.
+	Retention util.DurWrap `toml:"retention,omitempty"`
+}
+
+func (cfg *PathMonConfig) InitDefaults() {
+	if cfg.Daemon == "" {
+		cfg.Daemon = daemon.DefaultAPIAddress
+	}
+	if cfg.Dispatcher == "" {
+		cfg.Dispatcher = reliable.DefaultDispPath
+	}
+	if cfg.Interval.Duration == 0 {
+		cfg.Interval.Duration = DefaultInterval
+	}
+	if cfg.Count == 0 {
+		cfg.Count = DefaultCount
+	}
+	if cfg.ProbeInterval.Duration == 0 {
+		cfg.ProbeInterval.Duration = DefaultProbeInterval
+	}
+	if cfg.Timeout.Duration == 0 {
+		cfg.Timeout.Duration = DefaultTimeout
+	}
+	if cfg.MaxPaths == 0 {
+		cfg.MaxPaths = DefaultMaxPaths
+	}
+	if cfg.Retention.Duration == 0 {
+		cfg.Retention.Duration = DefaultRetention
+	}
+}
+
+func (cfg *PathMonConfig) Validate() error {
+	if _, err := cfg.ParseDestinations(); err != nil {
+		return err
+	}
+	if cfg.LocalIP != "" && net.ParseIP(cfg.LocalIP) == nil {
+		return serrors.New("invalid local IP", "local_ip", cfg.LocalIP)
+	}
+	if cfg.Interval.Duration < 0 {
+		return serrors.New("Interval must not be negative")
+	}
+	if cfg.ProbeInterval.Duration < 0 {
+		return serrors.New("ProbeInterval must not be negative")
+	}
+	if cfg.Timeout.Duration < 0 {
+		return serrors.New("Timeout must not be negative")
+	}
+	if cfg.MaxPaths < 0 {
+		return serrors.New("MaxPaths must not be negative")
+	}
+	if cfg.Retention.Duration < 0 {
+		return serrors.New("Retention must not be negative")
+	}
+	return nil
+}
+
+// ParseDestinations parses the configured destinations.
+func (cfg *PathMonConfig) ParseDestinations() ([]*snet.UDPAddr, error) {
+	dsts := make([]*snet.UDPAddr, 0, len(cfg.Destinations))
+	for _, raw := range cfg.Destinations {
+		dst, err := snet.ParseUDPAddr(raw)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing destination", err, "destination", raw)
+		}
+		dsts = append(dsts, dst)
+	}
+	return dsts, nil
+}
+
+func (cfg *PathMonConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, pathmonSample)
+}
+
+func (cfg *PathMonConfig) ConfigName() string {
+	return "pathmon"
+}
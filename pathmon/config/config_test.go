@@ -0,0 +1,100 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log/logtest"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/config/configtest"
+	"github.com/scionproto/scion/private/env/envtest"
+	apitest "github.com/scionproto/scion/private/mgmtapi/mgmtapitest"
+)
+
+func TestConfigSample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg Config
+	cfg.Sample(&sample, nil, nil)
+
+	InitTestConfig(&cfg)
+	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
+	assert.NoError(t, err)
+	CheckTestConfig(t, &cfg, idSample)
+	configtest.CheckSampleCoverage(t, &cfg)
+}
+
+func InitTestConfig(cfg *Config) {
+	envtest.InitTest(&cfg.General, &cfg.Metrics, nil, nil)
+	logtest.InitTestLogging(&cfg.Logging)
+	apitest.InitConfig(&cfg.API)
+	InitTestPathMonConfig(&cfg.PathMon)
+}
+
+func InitTestPathMonConfig(cfg *PathMonConfig) {
+	cfg.Daemon = "garbage"
+	cfg.LocalIP = "garbage"
+	cfg.Destinations = []string{"garbage"}
+}
+
+func CheckTestConfig(t *testing.T, cfg *Config, id string) {
+	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, nil, nil, id)
+	logtest.CheckTestLogging(t, &cfg.Logging, id)
+	apitest.CheckConfig(t, &cfg.API)
+	assert.Equal(t, fmt.Sprintf(DefaultDBPath, id), cfg.DB.Connection)
+	CheckTestPathMonConfig(t, &cfg.PathMon)
+}
+
+func CheckTestPathMonConfig(t *testing.T, cfg *PathMonConfig) {
+	assert.Equal(t, daemon.DefaultAPIAddress, cfg.Daemon)
+	assert.Equal(t, reliable.DefaultDispPath, cfg.Dispatcher)
+	assert.Empty(t, cfg.LocalIP)
+	assert.Empty(t, cfg.Destinations)
+	assert.Equal(t, DefaultInterval, cfg.Interval.Duration)
+	assert.Equal(t, uint16(DefaultCount), cfg.Count)
+	assert.Equal(t, DefaultProbeInterval, cfg.ProbeInterval.Duration)
+	assert.Equal(t, DefaultTimeout, cfg.Timeout.Duration)
+	assert.Equal(t, DefaultMaxPaths, cfg.MaxPaths)
+	assert.Equal(t, DefaultRetention, cfg.Retention.Duration)
+}
+
+func TestPathMonConfigValidate(t *testing.T) {
+	var cfg PathMonConfig
+	cfg.InitDefaults()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Destinations = []string{"1-ff00:0:110,10.0.0.1", "1-ff00:0:111,10.0.0.2"}
+	assert.NoError(t, cfg.Validate())
+	dsts, err := cfg.ParseDestinations()
+	assert.NoError(t, err)
+	assert.Len(t, dsts, 2)
+
+	cfg.Destinations = []string{"1-ff00:0:110"}
+	assert.Error(t, cfg.Validate())
+
+	cfg.Destinations = nil
+	cfg.LocalIP = "10.0.0.300"
+	assert.Error(t, cfg.Validate())
+
+	cfg.LocalIP = ""
+	cfg.MaxPaths = -1
+	assert.Error(t, cfg.Validate())
+}
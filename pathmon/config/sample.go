@@ -0,0 +1,53 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+const idSample = "pathmon"
+
+const pathmonSample = `
+# Address of the SCION Daemon that is queried for paths.
+# (default 127.0.0.1:30255)
+daemon = "127.0.0.1:30255"
+
+# Path to the dispatcher socket. (default "/run/shm/dispatcher/default.sock")
+dispatcher = "/run/shm/dispatcher/default.sock"
+
+# The local IP address the probes are sent from. If not set, the address is
+# resolved based on the next hop of the probed path. (default "")
+local_ip = ""
+
+# The monitored hosts in the format ISD-AS,IP. Every available path to each
+# host is probed with SCMP echo requests. (default [])
+destinations = []
+
+# The time between two monitoring rounds. (default 30s)
+interval = "30s"
+
+# The number of echo requests sent over every path in a monitoring round.
+# (default 3)
+count = 3
+
+# The time between two echo requests on the same path. (default 100ms)
+probe_interval = "100ms"
+
+# The time after which an echo request is considered lost. (default 1s)
+timeout = "1s"
+
+# The maximum number of paths that are probed per destination. (default 10)
+max_paths = 10
+
+# The time for which the results are kept in the database. (default 168h)
+retention = "168h"
+`
@@ -0,0 +1,83 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathmon
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/scionproto/scion/pkg/metrics"
+)
+
+// Metrics are the metrics of the path monitor. All metrics are optional.
+type Metrics struct {
+	// Paths is the number of probed paths per destination. It has the label
+	// isd_as.
+	Paths metrics.Gauge
+	// PathUp indicates whether a path was available in the last round. It has
+	// the labels isd_as and fingerprint.
+	PathUp metrics.Gauge
+	// PathRTT is the average round trip time of a path in the last round in
+	// seconds. It has the labels isd_as and fingerprint.
+	PathRTT metrics.Gauge
+	// PathLoss is the ratio of lost echo requests on a path in the last round.
+	// It has the labels isd_as and fingerprint.
+	PathLoss metrics.Gauge
+	// Errors is the number of failures to fetch or probe paths. It has the
+	// label isd_as.
+	Errors metrics.Counter
+}
+
+// NewMetrics creates the Prometheus metrics of the path monitor.
+func NewMetrics() Metrics {
+	pathLabels := []string{"isd_as", "fingerprint"}
+	return Metrics{
+		Paths: metrics.NewPromGauge(promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "pathmon_paths",
+				Help: "Number of probed paths per destination.",
+			},
+			[]string{"isd_as"},
+		)),
+		PathUp: metrics.NewPromGauge(promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "pathmon_path_up",
+				Help: "Whether the path was available in the last monitoring round.",
+			},
+			pathLabels,
+		)),
+		PathRTT: metrics.NewPromGauge(promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "pathmon_path_rtt_seconds",
+				Help: "Average round trip time of the path in the last monitoring round.",
+			},
+			pathLabels,
+		)),
+		PathLoss: metrics.NewPromGauge(promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "pathmon_path_loss_ratio",
+				Help: "Ratio of lost echo requests on the path in the last monitoring round.",
+			},
+			pathLabels,
+		)),
+		Errors: metrics.NewPromCounter(promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "pathmon_errors_total",
+				Help: "Total number of failures to fetch or probe paths.",
+			},
+			[]string{"isd_as"},
+		)),
+	}
+}
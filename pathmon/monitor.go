@@ -0,0 +1,212 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathmon
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scmp/probe"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/path/pathpol"
+	"github.com/scionproto/scion/private/periodic"
+)
+
+// Prober probes a single path to a destination.
+type Prober interface {
+	Probe(ctx context.Context, remote *snet.UDPAddr, path snet.Path) (probe.Stats, error)
+}
+
+// SCMPProber probes paths with SCMP echo requests.
+type SCMPProber struct {
+	// Dispatcher is the dispatcher used to send and receive the probes.
+	Dispatcher reliable.Dispatcher
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+	// LocalIP is the local IP address. If nil, the address is resolved based
+	// on the underlay next hop of the probed path.
+	LocalIP net.IP
+	// Count is the number of echo requests sent over every path.
+	Count uint16
+	// Interval is the time between two echo requests.
+	Interval time.Duration
+	// Timeout is the time after which an echo request is considered lost.
+	Timeout time.Duration
+}
+
+// Probe sends the echo requests over the path and returns the statistics.
+func (p SCMPProber) Probe(ctx context.Context, remote *snet.UDPAddr,
+	path snet.Path) (probe.Stats, error) {
+
+	res, err := probe.ProbePath(ctx, probe.ProbeConfig{
+		Target: probe.Target{
+			Dispatcher: p.Dispatcher,
+			LocalIA:    p.LocalIA,
+			LocalIP:    p.LocalIP,
+			Remote:     remote,
+			Path:       path,
+		},
+		Count:    p.Count,
+		Interval: p.Interval,
+		Timeout:  p.Timeout,
+	})
+	if err != nil {
+		return probe.Stats{}, err
+	}
+	return res.Stats, nil
+}
+
+var _ periodic.Task = (*Monitor)(nil)
+
+// Monitor is a periodic task that probes all paths to the configured
+// destinations and records the results.
+type Monitor struct {
+	// Daemon is queried for the paths to the destinations.
+	Daemon daemon.Connector
+	// Destinations are the monitored hosts.
+	Destinations []*snet.UDPAddr
+	// Prober probes the paths.
+	Prober Prober
+	// DB stores the results.
+	DB DB
+	// MaxPaths is the maximum number of paths probed per destination. If
+	// zero, all paths are probed.
+	MaxPaths int
+	// Metrics are the metrics that are updated with the results. The metrics
+	// are optional.
+	Metrics Metrics
+
+	// fingerprints are the fingerprints of the paths that were probed in the
+	// last round per destination. The metrics of paths that are no longer
+	// probed, e.g., because they expired or were replaced, are removed.
+	fingerprints map[addr.IA]map[string]struct{}
+}
+
+// Name returns the task name.
+func (m *Monitor) Name() string {
+	return "pathmon_monitor"
+}
+
+// Run probes all paths to all destinations. The paths to a destination are
+// probed concurrently.
+func (m *Monitor) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	for _, dst := range m.Destinations {
+		results, err := m.probeDestination(ctx, dst)
+		if err != nil {
+			logger.Info("Failed to probe destination", "isd_as", dst.IA, "err", err)
+			metrics.CounterInc(metrics.CounterWith(m.Metrics.Errors,
+				"isd_as", dst.IA.String()))
+			continue
+		}
+		m.deleteStaleMetrics(dst.IA, results)
+		if err := m.DB.InsertResults(ctx, results); err != nil {
+			logger.Error("Failed to store results", "isd_as", dst.IA, "err", err)
+		}
+	}
+}
+
+func (m *Monitor) probeDestination(ctx context.Context, dst *snet.UDPAddr) ([]Result, error) {
+	paths, err := m.Daemon.Paths(ctx, dst.IA, 0, daemon.PathReqFlags{})
+	if err != nil {
+		return nil, serrors.WrapStr("fetching paths", err)
+	}
+	if m.MaxPaths > 0 && len(paths) > m.MaxPaths {
+		paths = paths[:m.MaxPaths]
+	}
+	metrics.GaugeSet(metrics.GaugeWith(m.Metrics.Paths, "isd_as", dst.IA.String()),
+		float64(len(paths)))
+
+	results := make([]Result, len(paths))
+	var wg sync.WaitGroup
+	wg.Add(len(paths))
+	for i, path := range paths {
+		go func(i int, path snet.Path) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			results[i] = m.probePath(ctx, dst, path)
+		}(i, path)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+func (m *Monitor) probePath(ctx context.Context, dst *snet.UDPAddr, path snet.Path) Result {
+	r := Result{
+		Time:        time.Now(),
+		Destination: dst.IA,
+		Fingerprint: snet.Fingerprint(path).String(),
+	}
+	if seq, err := pathpol.GetSequence(path); err == nil {
+		r.Sequence = seq
+	}
+	labels := []string{"isd_as", dst.IA.String(), "fingerprint", r.Fingerprint}
+	stats, err := m.Prober.Probe(ctx, dst, path)
+	if err != nil {
+		log.FromCtx(ctx).Info("Failed to probe path", "isd_as", dst.IA,
+			"fingerprint", r.Fingerprint, "err", err)
+		metrics.CounterInc(metrics.CounterWith(m.Metrics.Errors, "isd_as", dst.IA.String()))
+		metrics.GaugeSet(metrics.GaugeWith(m.Metrics.PathUp, labels...), 0)
+		return r
+	}
+	r.Sent = stats.Sent
+	r.Received = stats.Received
+	r.MinRTT = stats.MinRTT
+	r.AvgRTT = stats.AvgRTT
+	r.MaxRTT = stats.MaxRTT
+
+	up := 0.0
+	if r.Available() {
+		up = 1
+		metrics.GaugeSet(metrics.GaugeWith(m.Metrics.PathRTT, labels...),
+			r.AvgRTT.Seconds())
+	}
+	metrics.GaugeSet(metrics.GaugeWith(m.Metrics.PathUp, labels...), up)
+	if r.Sent > 0 {
+		metrics.GaugeSet(metrics.GaugeWith(m.Metrics.PathLoss, labels...),
+			float64(r.Sent-r.Received)/float64(r.Sent))
+	}
+	return r
+}
+
+// deleteStaleMetrics removes the path metrics of the paths to dst that were
+// probed in the last round but not in this one.
+func (m *Monitor) deleteStaleMetrics(dst addr.IA, results []Result) {
+	current := make(map[string]struct{}, len(results))
+	for _, r := range results {
+		current[r.Fingerprint] = struct{}{}
+	}
+	for fp := range m.fingerprints[dst] {
+		if _, ok := current[fp]; ok {
+			continue
+		}
+		labels := []string{"isd_as", dst.String(), "fingerprint", fp}
+		metrics.GaugeDelete(metrics.GaugeWith(m.Metrics.PathUp, labels...))
+		metrics.GaugeDelete(metrics.GaugeWith(m.Metrics.PathRTT, labels...))
+		metrics.GaugeDelete(metrics.GaugeWith(m.Metrics.PathLoss, labels...))
+	}
+	if m.fingerprints == nil {
+		m.fingerprints = make(map[addr.IA]map[string]struct{})
+	}
+	m.fingerprints[dst] = current
+}
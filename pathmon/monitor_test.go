@@ -0,0 +1,234 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathmon_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pathmon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/daemon/mock_daemon"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/scmp/probe"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestMonitorRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:112")
+	dst110 := mustParseAddr(t, "1-ff00:0:110,10.0.0.1")
+	dst111 := mustParseAddr(t, "1-ff00:0:111,10.0.0.2")
+	good := testPath(src, dst110.IA, 1)
+	bad := testPath(src, dst110.IA, 2)
+	unused := testPath(src, dst110.IA, 3)
+
+	sd := mock_daemon.NewMockConnector(ctrl)
+	sd.EXPECT().Paths(gomock.Any(), dst110.IA, addr.IA(0), daemon.PathReqFlags{}).
+		Return([]snet.Path{good, bad, unused}, nil)
+	sd.EXPECT().Paths(gomock.Any(), dst111.IA, addr.IA(0), daemon.PathReqFlags{}).
+		Return(nil, serrors.New("no paths"))
+
+	db := &memDB{}
+	m := &pathmon.Monitor{
+		Daemon:       sd,
+		Destinations: []*snet.UDPAddr{dst110, dst111},
+		Prober: proberFunc(func(_ context.Context, _ *snet.UDPAddr,
+			p snet.Path) (probe.Stats, error) {
+
+			if snet.Fingerprint(p) == snet.Fingerprint(bad) {
+				return probe.Stats{Sent: 3, Loss: 100}, nil
+			}
+			return probe.Stats{
+				Sent:     3,
+				Received: 2,
+				MinRTT:   time.Millisecond,
+				AvgRTT:   2 * time.Millisecond,
+				MaxRTT:   3 * time.Millisecond,
+			}, nil
+		}),
+		DB:       db,
+		MaxPaths: 2,
+		Metrics: pathmon.Metrics{
+			Paths:    metrics.NewTestGauge(),
+			PathUp:   metrics.NewTestGauge(),
+			PathRTT:  metrics.NewTestGauge(),
+			PathLoss: metrics.NewTestGauge(),
+			Errors:   metrics.NewTestCounter(),
+		},
+	}
+	m.Run(context.Background())
+
+	require.Len(t, db.results, 2)
+	results := db.results
+	sort.Slice(results, func(i, j int) bool { return results[i].Received > results[j].Received })
+	assert.Equal(t, snet.Fingerprint(good).String(), results[0].Fingerprint)
+	assert.Equal(t, dst110.IA, results[0].Destination)
+	assert.Equal(t, 3, results[0].Sent)
+	assert.Equal(t, 2, results[0].Received)
+	assert.Equal(t, 2*time.Millisecond, results[0].AvgRTT)
+	assert.NotEmpty(t, results[0].Sequence)
+	assert.True(t, results[0].Available())
+	assert.Equal(t, snet.Fingerprint(bad).String(), results[1].Fingerprint)
+	assert.False(t, results[1].Available())
+
+	goodLabels := []string{"isd_as", dst110.IA.String(),
+		"fingerprint", snet.Fingerprint(good).String()}
+	badLabels := []string{"isd_as", dst110.IA.String(),
+		"fingerprint", snet.Fingerprint(bad).String()}
+	assert.Equal(t, 2.0, metrics.GaugeValue(m.Metrics.Paths.With("isd_as", dst110.IA.String())))
+	assert.Equal(t, 1.0, metrics.GaugeValue(m.Metrics.PathUp.With(goodLabels...)))
+	assert.Equal(t, 0.0, metrics.GaugeValue(m.Metrics.PathUp.With(badLabels...)))
+	assert.Equal(t, 0.002, metrics.GaugeValue(m.Metrics.PathRTT.With(goodLabels...)))
+	assert.InDelta(t, 1.0/3, metrics.GaugeValue(m.Metrics.PathLoss.With(goodLabels...)), 1e-9)
+	assert.Equal(t, 1.0, metrics.GaugeValue(m.Metrics.PathLoss.With(badLabels...)))
+	assert.Equal(t, 1.0,
+		metrics.CounterValue(m.Metrics.Errors.With("isd_as", dst111.IA.String())))
+}
+
+func TestMonitorRunDeletesStaleMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := xtest.MustParseIA("1-ff00:0:112")
+	dst := mustParseAddr(t, "1-ff00:0:110,10.0.0.1")
+	old := testPath(src, dst.IA, 1)
+	replacement := testPath(src, dst.IA, 2)
+
+	sd := mock_daemon.NewMockConnector(ctrl)
+	gomock.InOrder(
+		sd.EXPECT().Paths(gomock.Any(), dst.IA, addr.IA(0), daemon.PathReqFlags{}).
+			Return([]snet.Path{old}, nil),
+		sd.EXPECT().Paths(gomock.Any(), dst.IA, addr.IA(0), daemon.PathReqFlags{}).
+			Return([]snet.Path{replacement}, nil),
+	)
+	newGauge := func(name string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name},
+			[]string{"isd_as", "fingerprint"})
+	}
+	up, rtt, loss := newGauge("up"), newGauge("rtt"), newGauge("loss")
+	m := &pathmon.Monitor{
+		Daemon:       sd,
+		Destinations: []*snet.UDPAddr{dst},
+		Prober: proberFunc(func(context.Context, *snet.UDPAddr,
+			snet.Path) (probe.Stats, error) {
+
+			return probe.Stats{Sent: 1, Received: 1, AvgRTT: time.Millisecond}, nil
+		}),
+		DB: &memDB{},
+		Metrics: pathmon.Metrics{
+			PathUp:   metrics.NewPromGauge(up),
+			PathRTT:  metrics.NewPromGauge(rtt),
+			PathLoss: metrics.NewPromGauge(loss),
+		},
+	}
+
+	m.Run(context.Background())
+	oldLabels := []string{dst.IA.String(), snet.Fingerprint(old).String()}
+	for _, g := range []*prometheus.GaugeVec{up, rtt, loss} {
+		assert.Equal(t, 1, testutil.CollectAndCount(g))
+	}
+	assert.Equal(t, 1.0, testutil.ToFloat64(up.WithLabelValues(oldLabels...)))
+
+	m.Run(context.Background())
+	newLabels := []string{dst.IA.String(), snet.Fingerprint(replacement).String()}
+	for _, g := range []*prometheus.GaugeVec{up, rtt, loss} {
+		assert.Equal(t, 1, testutil.CollectAndCount(g))
+		assert.False(t, g.DeleteLabelValues(oldLabels...))
+	}
+	assert.Equal(t, 1.0, testutil.ToFloat64(up.WithLabelValues(newLabels...)))
+}
+
+type proberFunc func(context.Context, *snet.UDPAddr, snet.Path) (probe.Stats, error)
+
+func (f proberFunc) Probe(ctx context.Context, remote *snet.UDPAddr,
+	path snet.Path) (probe.Stats, error) {
+
+	return f(ctx, remote, path)
+}
+
+// memDB is an in-memory result database. Results returns the results ordered
+// by time, the most recent first, and ignores the limit.
+type memDB struct {
+	mu      sync.Mutex
+	results []pathmon.Result
+}
+
+func (db *memDB) InsertResults(_ context.Context, results []pathmon.Result) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.results = append(db.results, results...)
+	return nil
+}
+
+func (db *memDB) Results(_ context.Context, q pathmon.Query) ([]pathmon.Result, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var results []pathmon.Result
+	for i := len(db.results) - 1; i >= 0; i-- {
+		r := db.results[i]
+		if (!q.Destination.IsZero() && r.Destination != q.Destination) ||
+			(q.Fingerprint != "" && r.Fingerprint != q.Fingerprint) ||
+			r.Time.Before(q.Since) {
+			continue
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func (db *memDB) DeleteBefore(context.Context, time.Time) (int, error) {
+	return 0, nil
+}
+
+func (db *memDB) Close() error {
+	return nil
+}
+
+func testPath(src, dst addr.IA, ifID uint64) snet.Path {
+	return snetpath.Path{
+		Src: src,
+		Dst: dst,
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: src, ID: common.IFIDType(ifID)},
+				{IA: dst, ID: common.IFIDType(ifID + 10)},
+			},
+		},
+	}
+}
+
+func mustParseAddr(t *testing.T, s string) *snet.UDPAddr {
+	t.Helper()
+	a, err := snet.ParseUDPAddr(s)
+	require.NoError(t, err)
+	return a
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pathmon implements a path monitor that continuously probes all paths
+// to a set of destinations with SCMP echo requests. The results are recorded
+// in a database, exported as Prometheus metrics and served over a JSON API.
+package pathmon
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+// Result is the outcome of probing a single path in one monitoring round.
+type Result struct {
+	// Time is the time at which the probing started.
+	Time time.Time
+	// Destination is the ISD-AS of the probed destination.
+	Destination addr.IA
+	// Fingerprint is the fingerprint of the probed path.
+	Fingerprint string
+	// Sequence is the hop predicate sequence of the probed path.
+	Sequence string
+	// Sent is the number of sent echo requests.
+	Sent int
+	// Received is the number of received echo replies.
+	Received int
+	// MinRTT, AvgRTT and MaxRTT describe the round trip times of the received
+	// replies. They are zero if no reply was received.
+	MinRTT time.Duration
+	AvgRTT time.Duration
+	MaxRTT time.Duration
+}
+
+// Available indicates whether the path was available, i.e., whether at least
+// one echo reply was received.
+func (r Result) Available() bool {
+	return r.Received > 0
+}
+
+// Query selects the results that are returned from the database. Zero values
+// do not restrict the results.
+type Query struct {
+	// Destination restricts the results to the given destination.
+	Destination addr.IA
+	// Fingerprint restricts the results to the given path.
+	Fingerprint string
+	// Since restricts the results to the ones recorded at or after the given
+	// time.
+	Since time.Time
+	// Limit is the maximum number of results.
+	Limit int
+}
+
+// DB stores the monitoring results.
+type DB interface {
+	io.Closer
+	// InsertResults stores the results.
+	InsertResults(ctx context.Context, results []Result) error
+	// Results returns the results matching the query, the most recent first.
+	Results(ctx context.Context, q Query) ([]Result, error)
+	// DeleteBefore deletes all results recorded before the given time and
+	// returns the number of deleted results.
+	DeleteBefore(ctx context.Context, t time.Time) (int, error)
+}
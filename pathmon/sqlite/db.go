@@ -0,0 +1,176 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlite implements the path monitor result database with sqlite.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pathmon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/storage/db"
+)
+
+const (
+	// SchemaVersion is the version of the SQLite schema understood by this backend.
+	// Whenever changes to the schema are made, this version number should be increased
+	// to prevent data corruption between incompatible database schemas.
+	SchemaVersion = 1
+	// Schema is the SQLite database layout.
+	Schema = `CREATE TABLE Results(
+		RowID INTEGER PRIMARY KEY AUTOINCREMENT,
+		Timestamp INTEGER NOT NULL,
+		DstIsdID INTEGER NOT NULL,
+		DstAsID INTEGER NOT NULL,
+		Fingerprint TEXT NOT NULL,
+		Sequence TEXT NOT NULL,
+		Sent INTEGER NOT NULL,
+		Received INTEGER NOT NULL,
+		MinRTT INTEGER NOT NULL,
+		AvgRTT INTEGER NOT NULL,
+		MaxRTT INTEGER NOT NULL
+	);
+	CREATE INDEX Results_Dst ON Results(DstIsdID, DstAsID, Timestamp);
+	CREATE INDEX Results_Timestamp ON Results(Timestamp);`
+)
+
+var _ pathmon.DB = (*Backend)(nil)
+
+// Backend implements the path monitor result database with sqlite.
+type Backend struct {
+	mu sync.RWMutex
+	db *sql.DB
+}
+
+// New creates a new sqlite backend at the given path.
+func New(path string) (*Backend, error) {
+	db, err := db.NewSqlite(path, Schema, SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{db: db}, nil
+}
+
+// SetMaxOpenConns sets the maximum number of open connections.
+func (b *Backend) SetMaxOpenConns(maxOpenConns int) {
+	b.db.SetMaxOpenConns(maxOpenConns)
+}
+
+// SetMaxIdleConns sets the maximum number of idle connections.
+func (b *Backend) SetMaxIdleConns(maxIdleConns int) {
+	b.db.SetMaxIdleConns(maxIdleConns)
+}
+
+// Close closes the database connection.
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+// InsertResults stores the results.
+func (b *Backend) InsertResults(ctx context.Context, results []pathmon.Result) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return db.DoInTx(ctx, b.db, func(ctx context.Context, tx *sql.Tx) error {
+		query := `INSERT INTO Results (Timestamp, DstIsdID, DstAsID, Fingerprint,
+			Sequence, Sent, Received, MinRTT, AvgRTT, MaxRTT)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		for _, r := range results {
+			_, err := tx.ExecContext(ctx, query, r.Time.UnixNano(),
+				r.Destination.ISD(), r.Destination.AS(), r.Fingerprint, r.Sequence,
+				r.Sent, r.Received, r.MinRTT, r.AvgRTT, r.MaxRTT)
+			if err != nil {
+				return db.NewWriteError("inserting result", err)
+			}
+		}
+		return nil
+	})
+}
+
+// Results returns the results matching the query, the most recent first.
+func (b *Backend) Results(ctx context.Context,
+	q pathmon.Query) ([]pathmon.Result, error) {
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var where []string
+	var args []interface{}
+	if !q.Destination.IsZero() {
+		where = append(where, "DstIsdID = ? AND DstAsID = ?")
+		args = append(args, q.Destination.ISD(), q.Destination.AS())
+	}
+	if q.Fingerprint != "" {
+		where = append(where, "Fingerprint = ?")
+		args = append(args, q.Fingerprint)
+	}
+	if !q.Since.IsZero() {
+		where = append(where, "Timestamp >= ?")
+		args = append(args, q.Since.UnixNano())
+	}
+	query := `SELECT Timestamp, DstIsdID, DstAsID, Fingerprint, Sequence, Sent,
+		Received, MinRTT, AvgRTT, MaxRTT FROM Results`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY Timestamp DESC, RowID DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+	rows, err := b.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, db.NewReadError("querying results", err)
+	}
+	defer rows.Close()
+	var results []pathmon.Result
+	for rows.Next() {
+		var r pathmon.Result
+		var ts int64
+		var isd addr.ISD
+		var as addr.AS
+		err := rows.Scan(&ts, &isd, &as, &r.Fingerprint, &r.Sequence, &r.Sent,
+			&r.Received, &r.MinRTT, &r.AvgRTT, &r.MaxRTT)
+		if err != nil {
+			return nil, serrors.WrapStr("scanning result", err)
+		}
+		r.Time = time.Unix(0, ts)
+		r.Destination = addr.MustIAFrom(isd, as)
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewReadError("iterating results", err)
+	}
+	return results, nil
+}
+
+// DeleteBefore deletes all results recorded before the given time and returns
+// the number of deleted results.
+func (b *Backend) DeleteBefore(ctx context.Context, t time.Time) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	res, err := b.db.ExecContext(ctx, "DELETE FROM Results WHERE Timestamp < ?", t.UnixNano())
+	if err != nil {
+		return 0, db.NewWriteError("deleting results", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, db.NewWriteError("counting deleted results", err)
+	}
+	return int(n), nil
+}
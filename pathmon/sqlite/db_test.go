@@ -0,0 +1,87 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pathmon"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+func TestBackend(t *testing.T) {
+	ctx := context.Background()
+	b, err := New(filepath.Join(t.TempDir(), "pathmon.db"))
+	require.NoError(t, err)
+	defer b.Close()
+
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	now := time.Unix(1700000000, 0)
+	results := []pathmon.Result{
+		{
+			Time:        now.Add(-2 * time.Minute),
+			Destination: ia110,
+			Fingerprint: "a",
+			Sequence:    "1-ff00:0:112#1 1-ff00:0:110#2",
+			Sent:        3,
+			Received:    2,
+			MinRTT:      time.Millisecond,
+			AvgRTT:      2 * time.Millisecond,
+			MaxRTT:      3 * time.Millisecond,
+		},
+		{Time: now.Add(-time.Minute), Destination: ia110, Fingerprint: "b", Sent: 3},
+		{Time: now, Destination: ia110, Fingerprint: "a", Sent: 3, Received: 3},
+		{Time: now, Destination: ia111, Fingerprint: "c", Sent: 3, Received: 1},
+	}
+	require.NoError(t, b.InsertResults(ctx, results))
+
+	t.Run("all", func(t *testing.T) {
+		got, err := b.Results(ctx, pathmon.Query{})
+		require.NoError(t, err)
+		require.Len(t, got, 4)
+		assert.Equal(t, results[3], got[0])
+		assert.Equal(t, results[0], got[3])
+	})
+	t.Run("destination", func(t *testing.T) {
+		got, err := b.Results(ctx, pathmon.Query{Destination: ia111})
+		require.NoError(t, err)
+		assert.Equal(t, []pathmon.Result{results[3]}, got)
+	})
+	t.Run("fingerprint", func(t *testing.T) {
+		got, err := b.Results(ctx, pathmon.Query{Destination: ia110, Fingerprint: "a"})
+		require.NoError(t, err)
+		assert.Equal(t, []pathmon.Result{results[2], results[0]}, got)
+	})
+	t.Run("since and limit", func(t *testing.T) {
+		got, err := b.Results(ctx, pathmon.Query{Since: now.Add(-time.Minute), Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []pathmon.Result{results[3], results[2]}, got)
+	})
+	t.Run("delete", func(t *testing.T) {
+		n, err := b.DeleteBefore(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		got, err := b.Results(ctx, pathmon.Query{})
+		require.NoError(t, err)
+		assert.Equal(t, []pathmon.Result{results[3], results[2]}, got)
+	})
+}
@@ -0,0 +1,24 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package colibri contains the basic types for COLIBRI bandwidth reservations.
+//
+// COLIBRI allows end hosts to reserve bandwidth along a path. A reservation is
+// requested from the control service of the local AS, which admits it if the
+// requested bandwidth is available on the interfaces of the reservation.
+//
+// EXPERIMENTAL: This package only contains the groundwork for bandwidth
+// reservations. Reservations are only admitted by the local AS, and they are
+// not enforced in the data plane.
+package colibri
@@ -0,0 +1,107 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// MaxReservationDuration is the maximum duration of a reservation.
+const MaxReservationDuration = 5 * time.Minute
+
+// ReservationID identifies a reservation. It consists of the AS that admitted
+// the reservation and a suffix that is unique within that AS.
+type ReservationID struct {
+	ASID   addr.AS
+	Suffix uint32
+}
+
+func (id ReservationID) String() string {
+	return fmt.Sprintf("%s-%08x", id.ASID, id.Suffix)
+}
+
+// Request is a request for a bandwidth reservation from the source to the
+// destination AS. The ingress and egress interfaces are the interfaces of the
+// admitting AS over which the reserved traffic enters and leaves the AS. An
+// interface ID of 0 denotes the AS itself, i.e., the traffic originates or
+// terminates in the AS.
+type Request struct {
+	SrcIA   addr.IA
+	DstIA   addr.IA
+	Ingress common.IFIDType
+	Egress  common.IFIDType
+	// Bandwidth is the requested bandwidth in Kbit/s.
+	Bandwidth uint64
+	// Expiration is the time until which the reservation is requested.
+	Expiration time.Time
+}
+
+// Validate checks that the request is well-formed at the given time.
+func (r Request) Validate(now time.Time) error {
+	if r.SrcIA.IsZero() || r.DstIA.IsZero() {
+		return serrors.New("source and destination must be set",
+			"src", r.SrcIA, "dst", r.DstIA)
+	}
+	if r.Bandwidth == 0 {
+		return serrors.New("bandwidth must be positive")
+	}
+	if !r.Expiration.After(now) {
+		return serrors.New("expiration must be in the future", "expiration", r.Expiration)
+	}
+	if r.Expiration.Sub(now) > MaxReservationDuration {
+		return serrors.New("reservation exceeds maximum duration",
+			"expiration", r.Expiration, "max_duration", MaxReservationDuration)
+	}
+	return nil
+}
+
+// Response is the response to a reservation request.
+type Response struct {
+	// Accepted indicates whether the reservation was admitted.
+	Accepted bool
+	// ID identifies the admitted reservation. It is only set if the
+	// reservation was accepted.
+	ID ReservationID
+	// Bandwidth is the reserved bandwidth in Kbit/s if the reservation was
+	// accepted. Otherwise, it is the maximum bandwidth that could have been
+	// admitted.
+	Bandwidth uint64
+	// Expiration is the expiration time of the admitted reservation.
+	Expiration time.Time
+	// FailureReason describes why the reservation was rejected.
+	FailureReason string
+}
+
+// Reservation is an admitted reservation.
+type Reservation struct {
+	ID ReservationID
+	Request
+}
+
+// Expired returns whether the reservation is expired at the given time.
+func (r Reservation) Expired(now time.Time) bool {
+	return !r.Expiration.After(now)
+}
+
+// Reserver requests bandwidth reservations.
+type Reserver interface {
+	Reserve(ctx context.Context, req Request) (Response, error)
+}
@@ -20,6 +20,7 @@ import (
 	"net"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/snet"
@@ -28,13 +29,102 @@ import (
 
 type PathReqFlags struct {
 	Refresh bool
-	Hidden  bool
+	// Hidden requests hidden segments from the hidden path groups the local AS
+	// is a reader of.
+	Hidden bool
+	// HiddenGroups restricts the hidden segment lookup to the hidden path
+	// groups with the given IDs. It is only used if Hidden is set. If empty,
+	// the groups returned by Connector.HiddenPathGroups in which the
+	// destination is a writer are queried.
+	HiddenGroups []uint64
+	// Policy is the name of a path policy configured in the daemon. If set,
+	// only the paths that conform to the policy are returned.
+	Policy string
+	// Peering restricts the returned paths based on whether they traverse a
+	// peering link.
+	Peering PeeringMode
+	// Waypoints restricts the returned paths to the ones that traverse all the
+	// given ASes. A waypoint with AS number 0 (e.g., 1-0) is satisfied by any
+	// AS of that ISD.
+	Waypoints []addr.IA
+}
+
+// PeeringMode determines how paths that traverse a peering link are treated.
+type PeeringMode int
+
+const (
+	// PeeringAllow returns paths regardless of whether they traverse a
+	// peering link.
+	PeeringAllow PeeringMode = iota
+	// PeeringForbid only returns paths that do not traverse a peering link.
+	PeeringForbid
+	// PeeringRequire only returns paths that traverse a peering link.
+	PeeringRequire
+)
+
+// DisjointnessMode determines the resources that disjoint paths should not
+// share.
+type DisjointnessMode int
+
+const (
+	// DisjointLink minimizes the inter-AS links shared by the paths.
+	DisjointLink DisjointnessMode = iota
+	// DisjointAS minimizes the transit ASes shared by the paths.
+	DisjointAS
+)
+
+// HiddenPathGroup is a hidden path group that is known to the daemon.
+type HiddenPathGroup struct {
+	// ID is the uint64 representation of the group ID.
+	ID uint64
+	// Writers are the ASes whose hidden segments can be looked up in the
+	// group.
+	Writers []addr.IA
+	// Learned indicates that the group was learned from the control service.
+	// Otherwise, it is configured in the daemon.
+	Learned bool
 }
 
 // ASInfo provides information about the local AS.
 type ASInfo struct {
-	IA  addr.IA
-	MTU uint16
+	IA   addr.IA
+	Core bool
+	MTU  uint16
+}
+
+// LocalTopology describes the local AS as seen by the daemon.
+type LocalTopology struct {
+	IA   addr.IA
+	Core bool
+	MTU  uint16
+	// BorderRouters is sorted by name.
+	BorderRouters []BorderRouter
+	// ControlServices is sorted by name.
+	ControlServices []ControlService
+}
+
+// BorderRouter describes a border router of the local AS.
+type BorderRouter struct {
+	Name         string
+	InternalAddr *net.UDPAddr
+	// Interfaces is sorted by interface ID.
+	Interfaces []BorderRouterInterface
+}
+
+// BorderRouterInterface describes an inter-AS interface of a border router.
+type BorderRouterInterface struct {
+	ID         common.IFIDType
+	NeighborIA addr.IA
+	NeighborID common.IFIDType
+	// LinkType is the relation of the neighbor to the local AS.
+	LinkType topology.LinkType
+	MTU      uint16
+}
+
+// ControlService describes a control service instance of the local AS.
+type ControlService struct {
+	Name string
+	Addr *net.UDPAddr
 }
 
 type Querier struct {
@@ -21,6 +21,7 @@ import (
 	"net"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/colibri"
 	"github.com/scionproto/scion/pkg/daemon/internal/metrics"
 	"github.com/scionproto/scion/pkg/drkey"
 	libmetrics "github.com/scionproto/scion/pkg/metrics"
@@ -33,6 +34,9 @@ import (
 // Errors for SCION Daemon API requests
 var (
 	ErrUnableToConnect = serrors.New("unable to connect to the SCION Daemon")
+	// ErrPathNotFound indicates that the daemon has no cached path with the
+	// requested fingerprint.
+	ErrPathNotFound = serrors.New("path not found")
 )
 
 const (
@@ -66,9 +70,28 @@ type Connector interface {
 	LocalIA(ctx context.Context) (addr.IA, error)
 	// Paths requests from the daemon a set of end to end paths between the source and destination.
 	Paths(ctx context.Context, dst, src addr.IA, f PathReqFlags) ([]snet.Path, error)
+	// WatchPaths watches the set of end to end paths between the source and
+	// destination. The handler is called with the current set of paths, and
+	// again whenever paths appear, expire, or are revoked. WatchPaths blocks
+	// until the context is canceled or the stream to the daemon fails.
+	WatchPaths(ctx context.Context, dst, src addr.IA, handler func([]snet.Path)) error
+	// PathByFingerprint requests from the daemon the cached path between the
+	// source and destination that has the given fingerprint. If the path is no
+	// longer cached, ErrPathNotFound is returned.
+	PathByFingerprint(ctx context.Context, dst, src addr.IA,
+		fingerprint snet.PathFingerprint) (snet.Path, error)
+	// DisjointPaths requests from the daemon up to k maximally disjoint paths
+	// between the source and destination. The first path is the most preferred
+	// path, each following path shares as few resources as possible with the
+	// paths before it.
+	DisjointPaths(ctx context.Context, dst, src addr.IA, k int,
+		mode DisjointnessMode, refresh bool) ([]snet.Path, error)
 	// ASInfo requests from the daemon information about AS ia, the zero IA can be
 	// use to detect the local IA.
 	ASInfo(ctx context.Context, ia addr.IA) (ASInfo, error)
+	// LocalTopology requests from the daemon the topology of the local AS,
+	// i.e., its border routers with their interfaces and its control services.
+	LocalTopology(ctx context.Context) (LocalTopology, error)
 	// IFInfo requests from SCION Daemon addresses and ports of interfaces. Slice
 	// ifs contains interface IDs of BRs. If empty, a fresh (i.e., uncached)
 	// answer containing all interfaces is returned.
@@ -81,12 +104,24 @@ type Connector interface {
 	SVCInfo(ctx context.Context, svcTypes []addr.SVC) (map[addr.SVC][]string, error)
 	// RevNotification sends a RevocationInfo message to the daemon.
 	RevNotification(ctx context.Context, revInfo *path_mgmt.RevInfo) error
+	// ReportPathUsage sends path usage statistics to the daemon. The daemon
+	// aggregates them to track the health of the paths.
+	ReportPathUsage(ctx context.Context, usages []snet.PathUsage) error
+	// HiddenPathGroups requests the hidden path groups in which the local AS
+	// is a reader. These are the groups that the daemon queries for hidden
+	// path requests without explicit groups.
+	HiddenPathGroups(ctx context.Context) ([]HiddenPathGroup, error)
 	// DRKeyGetASHostKey requests a AS-Host Key from the daemon.
 	DRKeyGetASHostKey(ctx context.Context, meta drkey.ASHostMeta) (drkey.ASHostKey, error)
 	// DRKeyGetHostASKey requests a Host-AS Key from the daemon.
 	DRKeyGetHostASKey(ctx context.Context, meta drkey.HostASMeta) (drkey.HostASKey, error)
 	// DRKeyGetHostHostKey requests a Host-Host Key from the daemon.
 	DRKeyGetHostHostKey(ctx context.Context, meta drkey.HostHostMeta) (drkey.HostHostKey, error)
+	// ColibriReserve requests a COLIBRI bandwidth reservation from the local
+	// AS. The source of the reservation is the local AS, and the traffic leaves
+	// the AS over the egress interface of the request. The source and ingress
+	// of the request are ignored. EXPERIMENTAL.
+	ColibriReserve(ctx context.Context, req colibri.Request) (colibri.Response, error)
 	// Close shuts down the connection to the daemon.
 	Close() error
 }
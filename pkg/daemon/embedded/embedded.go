@@ -0,0 +1,232 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package embedded runs the path lookup logic of the SCION daemon in the
+// calling process. Tools and tests can use it to resolve paths by talking to
+// the control service of the local AS directly, without a separate daemon
+// process.
+//
+// The embedded daemon serves the daemon API over an in-memory connection, such
+// that it behaves like a remote daemon. The daemon API server is set up in the
+// same way as in the daemon, only the metrics are not exposed.
+package embedded
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	sd "github.com/scionproto/scion/daemon"
+	"github.com/scionproto/scion/daemon/config"
+	"github.com/scionproto/scion/pkg/daemon"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/private/app"
+	libconfig "github.com/scionproto/scion/private/config"
+	"github.com/scionproto/scion/private/env"
+	"github.com/scionproto/scion/private/periodic"
+	"github.com/scionproto/scion/private/revcache"
+	"github.com/scionproto/scion/private/storage"
+	pathdbcache "github.com/scionproto/scion/private/storage/path/cache"
+	"github.com/scionproto/scion/private/topology"
+	trustengine "github.com/scionproto/scion/private/trust/config"
+)
+
+// InMemory is the database connection with which the databases are kept in
+// memory.
+const InMemory = "file::memory:"
+
+// Config is the configuration of an embedded daemon.
+type Config struct {
+	// Topology is the topology file of the local AS.
+	Topology string
+	// ConfigDir is the directory whose certs subdirectory contains the TRCs
+	// and certificate chains of the local ISD.
+	ConfigDir string
+	// PathDB is the path database. If the connection is empty, the segments
+	// are kept in memory.
+	PathDB storage.DBConfig
+	// TrustDB is the trust database. If the connection is empty, the trust
+	// material is kept in memory.
+	TrustDB storage.DBConfig
+	// SD configures the path lookups like for the daemon. Unset values are
+	// initialized with the defaults of the daemon. The address is ignored.
+	SD config.SDConfig
+	// PathRanking configures the ranking of the paths like for the daemon.
+	// Unset weights are initialized with the defaults of the daemon.
+	PathRanking config.RankingConfig
+	// GRPCTLS configures TLS for the connections to the control service like
+	// for the daemon.
+	GRPCTLS env.GRPCTLS
+	// TrustEngine configures the caching of the trust engine like for the
+	// daemon.
+	TrustEngine trustengine.Config
+	// DRKeyLevel2DB is the level 2 DRKey database. If the connection is empty,
+	// DRKey requests are not supported.
+	DRKeyLevel2DB storage.DBConfig
+}
+
+// Daemon is a SCION daemon that runs in the calling process. It implements
+// the daemon.Connector interface. Close must be called to release the
+// resources of the daemon.
+type Daemon struct {
+	daemon.Connector
+
+	server  *grpc.Server
+	pathDB  storage.PathDB
+	trustDB storage.TrustDB
+	tasks   []*periodic.Runner
+	cleanup app.Cleanup
+}
+
+// New starts an embedded daemon. The topology file and the trust material are
+// loaded once, they are not reloaded.
+func New(ctx context.Context, cfg Config) (_ *Daemon, err error) {
+	if cfg.Topology == "" {
+		return nil, serrors.New("topology file must be set")
+	}
+	if cfg.ConfigDir == "" {
+		return nil, serrors.New("config directory must be set")
+	}
+	sdCfg := &config.Config{
+		General:       env.General{ConfigDir: cfg.ConfigDir},
+		GRPCTLS:       cfg.GRPCTLS,
+		SD:            cfg.SD,
+		TrustEngine:   cfg.TrustEngine,
+		DRKeyLevel2DB: cfg.DRKeyLevel2DB,
+		PathRanking:   cfg.PathRanking,
+	}
+	libconfig.InitAll(&sdCfg.SD, &sdCfg.TrustEngine, &sdCfg.PathRanking)
+	err = libconfig.ValidateAll(
+		&sdCfg.GRPCTLS,
+		&sdCfg.SD,
+		&sdCfg.TrustEngine,
+		&sdCfg.DRKeyLevel2DB,
+		&sdCfg.PathRanking,
+	)
+	if err != nil {
+		return nil, serrors.WrapStr("validating daemon config", err)
+	}
+	topo, err := topology.NewLoader(topology.LoaderCfg{
+		File:      cfg.Topology,
+		Validator: &topology.DefaultValidator{},
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("loading topology", err)
+	}
+	d := &Daemon{}
+	defer func() {
+		if err != nil {
+			// The error of the construction is more relevant than the one of
+			// the cleanup.
+			_ = d.Close()
+		}
+	}()
+	if d.pathDB, err = storage.NewPathStorage(withDefault(cfg.PathDB)); err != nil {
+		return nil, serrors.WrapStr("initializing path storage", err)
+	}
+	if d.trustDB, err = storage.NewTrustStorage(withDefault(cfg.TrustDB)); err != nil {
+		return nil, serrors.WrapStr("initializing trust storage", err)
+	}
+	pathDB := storage.PathDB(d.pathDB)
+	if !sdCfg.SD.DisablePathDBCache {
+		pathDB, err = pathdbcache.WrapDB(pathDB, pathdbcache.Config{
+			Size: sdCfg.SD.PathDBCacheSize,
+			TTL:  sdCfg.SD.PathDBCacheTTL.Duration,
+		})
+		if err != nil {
+			return nil, serrors.WrapStr("initializing path storage cache", err)
+		}
+	}
+	revCache := storage.NewRevocationStorage()
+	d.tasks = append(d.tasks, periodic.Start(revcache.NewCleaner(revCache, "sd_revocation"),
+		10*time.Second, 10*time.Second))
+
+	var serverCfg sd.ServerConfig
+	serverCfg, d.cleanup, err = sd.NewServerConfig(sdCfg, sd.ServerDeps{
+		Topology:       topo,
+		PathDB:         pathDB,
+		TrustDB:        d.trustDB,
+		RevCache:       revCache,
+		DisableMetrics: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	d.server = grpc.NewServer(libgrpc.UnaryServerInterceptor())
+	sdpb.RegisterDaemonServiceServer(d.server, sd.NewServer(serverCfg))
+	go func() {
+		defer log.HandlePanic()
+		err := d.server.Serve(listener)
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			log.Error("Serving embedded daemon API", "err", err)
+		}
+	}()
+	conn, err := grpc.DialContext(ctx, "embedded",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, serrors.WrapStr("connecting to embedded daemon API", err)
+	}
+	d.Connector = daemon.NewConnector(conn, daemon.Metrics{})
+	return d, nil
+}
+
+// Close stops the daemon and releases its resources.
+func (d *Daemon) Close() error {
+	var errs serrors.List
+	if d.Connector != nil {
+		if err := d.Connector.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if d.server != nil {
+		d.server.Stop()
+	}
+	for _, task := range d.tasks {
+		task.Stop()
+	}
+	if err := d.cleanup.Do(); err != nil {
+		errs = append(errs, err)
+	}
+	if d.trustDB != nil {
+		if err := d.trustDB.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if d.pathDB != nil {
+		if err := d.pathDB.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs.ToError()
+}
+
+func withDefault(c storage.DBConfig) storage.DBConfig {
+	if c.Connection == "" {
+		c.Connection = InMemory
+	}
+	return c
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedded_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/daemon/embedded"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/private/topology"
+)
+
+const topo = `{
+  "isd_as": "1-ff00:0:110",
+  "mtu": 1400,
+  "attributes": ["core"],
+  "border_routers": {
+    "br1": {
+      "internal_addr": "127.0.0.1:31002",
+      "interfaces": {
+        "1": {
+          "underlay": {"public": "127.0.0.4:50000", "remote": "127.0.0.5:50000"},
+          "isd_as": "1-ff00:0:111",
+          "link_to": "CHILD",
+          "mtu": 1280
+        }
+      }
+    }
+  },
+  "control_service": {
+    "cs1": {"addr": "127.0.0.2:31000"}
+  }
+}`
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	topoFile := filepath.Join(dir, "topology.json")
+	require.NoError(t, os.WriteFile(topoFile, []byte(topo), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "certs"), 0755))
+
+	t.Run("missing topology", func(t *testing.T) {
+		_, err := embedded.New(context.Background(), embedded.Config{ConfigDir: dir})
+		assert.Error(t, err)
+	})
+	t.Run("invalid topology", func(t *testing.T) {
+		_, err := embedded.New(context.Background(), embedded.Config{
+			Topology:  filepath.Join(dir, "missing.json"),
+			ConfigDir: dir,
+		})
+		assert.Error(t, err)
+	})
+	t.Run("local AS", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		d, err := embedded.New(ctx, embedded.Config{Topology: topoFile, ConfigDir: dir})
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, d.Close()) }()
+
+		local, err := d.LocalTopology(ctx)
+		require.NoError(t, err)
+		ia := local.IA
+		assert.Equal(t, xtest.MustParseIA("1-ff00:0:110"), ia)
+		assert.True(t, local.Core)
+		assert.Equal(t, uint16(1400), local.MTU)
+		require.Len(t, local.BorderRouters, 1)
+		require.Len(t, local.BorderRouters[0].Interfaces, 1)
+		intf := local.BorderRouters[0].Interfaces[0]
+		assert.Equal(t, xtest.MustParseIA("1-ff00:0:111"), intf.NeighborIA)
+		assert.Equal(t, topology.Child, intf.LinkType)
+		require.Len(t, local.ControlServices, 1)
+		assert.Equal(t, "127.0.0.2:31000", local.ControlServices[0].Addr.String())
+
+		// Paths within the local AS do not require the control service.
+		paths, err := d.Paths(ctx, ia, ia, daemon.PathReqFlags{})
+		require.NoError(t, err)
+		assert.Len(t, paths, 1)
+	})
+	t.Run("multiple instances", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		cfg := embedded.Config{Topology: topoFile, ConfigDir: dir}
+		d1, err := embedded.New(ctx, cfg)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, d1.Close()) }()
+		d2, err := embedded.New(ctx, cfg)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, d2.Close()) }()
+
+		_, err = d1.LocalTopology(ctx)
+		assert.NoError(t, err)
+		_, err = d2.LocalTopology(ctx)
+		assert.NoError(t, err)
+	})
+}
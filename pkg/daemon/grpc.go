@@ -20,9 +20,13 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/colibri"
 	"github.com/scionproto/scion/pkg/drkey"
 	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/private/common"
@@ -60,6 +64,12 @@ func (s Service) Connect(ctx context.Context) (Connector, error) {
 	return grpcConn{conn: conn, metrics: s.Metrics}, nil
 }
 
+// NewConnector returns a connector that uses the given gRPC client connection
+// to the daemon API. Closing the connector closes the connection.
+func NewConnector(conn *grpc.ClientConn, metrics Metrics) Connector {
+	return grpcConn{conn: conn, metrics: metrics}
+}
+
 type grpcConn struct {
 	conn    *grpc.ClientConn
 	metrics Metrics
@@ -79,10 +89,14 @@ func (c grpcConn) Paths(ctx context.Context, dst, src addr.IA,
 
 	client := sdpb.NewDaemonServiceClient(c.conn)
 	response, err := client.Paths(ctx, &sdpb.PathsRequest{
-		SourceIsdAs:      uint64(src),
-		DestinationIsdAs: uint64(dst),
-		Hidden:           f.Hidden,
-		Refresh:          f.Refresh,
+		SourceIsdAs:        uint64(src),
+		DestinationIsdAs:   uint64(dst),
+		Hidden:             f.Hidden,
+		HiddenPathGroupIds: f.HiddenGroups,
+		Refresh:            f.Refresh,
+		Peering:            peeringModeToPB(f.Peering),
+		Policy:             f.Policy,
+		Waypoints:          iasToPB(f.Waypoints),
 	})
 	if err != nil {
 		c.metrics.incPaths(err)
@@ -93,6 +107,124 @@ func (c grpcConn) Paths(ctx context.Context, dst, src addr.IA,
 	return paths, err
 }
 
+func (c grpcConn) WatchPaths(ctx context.Context, dst, src addr.IA,
+	handler func([]snet.Path)) error {
+
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	stream, err := client.WatchPaths(ctx, &sdpb.WatchPathsRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		paths, err := pathResponseToPaths(response.Paths, dst)
+		if err != nil {
+			return serrors.WrapStr("parsing reply", err)
+		}
+		handler(paths)
+	}
+}
+
+func iasToPB(ias []addr.IA) []uint64 {
+	if len(ias) == 0 {
+		return nil
+	}
+	pb := make([]uint64, 0, len(ias))
+	for _, ia := range ias {
+		pb = append(pb, uint64(ia))
+	}
+	return pb
+}
+
+func peeringModeToPB(m PeeringMode) sdpb.PeeringMode {
+	switch m {
+	case PeeringForbid:
+		return sdpb.PeeringMode_PEERING_MODE_FORBID
+	case PeeringRequire:
+		return sdpb.PeeringMode_PEERING_MODE_REQUIRE
+	default:
+		return sdpb.PeeringMode_PEERING_MODE_UNSPECIFIED
+	}
+}
+
+func linkRelationFromPB(r sdpb.LinkRelation) topology.LinkType {
+	switch r {
+	case sdpb.LinkRelation_LINK_RELATION_CORE:
+		return topology.Core
+	case sdpb.LinkRelation_LINK_RELATION_PARENT:
+		return topology.Parent
+	case sdpb.LinkRelation_LINK_RELATION_CHILD:
+		return topology.Child
+	case sdpb.LinkRelation_LINK_RELATION_PEER:
+		return topology.Peer
+	default:
+		return topology.Unset
+	}
+}
+
+func (c grpcConn) PathByFingerprint(ctx context.Context, dst, src addr.IA,
+	fingerprint snet.PathFingerprint) (snet.Path, error) {
+
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.PathByFingerprint(ctx, &sdpb.PathByFingerprintRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Fingerprint:      []byte(fingerprint),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, serrors.Wrap(ErrPathNotFound, err, "fingerprint", fingerprint)
+		}
+		return nil, err
+	}
+	if response.Path == nil {
+		return nil, serrors.New("empty reply")
+	}
+	p, err := convertPath(response.Path, dst)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing reply", err)
+	}
+	return p, nil
+}
+
+func (c grpcConn) DisjointPaths(ctx context.Context, dst, src addr.IA, k int,
+	mode DisjointnessMode, refresh bool) ([]snet.Path, error) {
+
+	if k <= 0 {
+		return nil, serrors.New("number of paths must be positive", "k", k)
+	}
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.DisjointPaths(ctx, &sdpb.DisjointPathsRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Count:            uint32(k),
+		Mode:             disjointnessModeToPB(mode),
+		Refresh:          refresh,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pathResponseToPaths(response.Paths, dst)
+}
+
+func disjointnessModeToPB(m DisjointnessMode) sdpb.DisjointnessMode {
+	switch m {
+	case DisjointAS:
+		return sdpb.DisjointnessMode_DISJOINTNESS_MODE_AS
+	default:
+		return sdpb.DisjointnessMode_DISJOINTNESS_MODE_LINK
+	}
+}
+
 func (c grpcConn) ASInfo(ctx context.Context, ia addr.IA) (ASInfo, error) {
 	client := sdpb.NewDaemonServiceClient(c.conn)
 	response, err := client.AS(ctx, &sdpb.ASRequest{IsdAs: uint64(ia)})
@@ -102,11 +234,68 @@ func (c grpcConn) ASInfo(ctx context.Context, ia addr.IA) (ASInfo, error) {
 	}
 	c.metrics.incAS(nil)
 	return ASInfo{
-		IA:  addr.IA(response.IsdAs),
-		MTU: uint16(response.Mtu),
+		IA:   addr.IA(response.IsdAs),
+		Core: response.Core,
+		MTU:  uint16(response.Mtu),
 	}, nil
 }
 
+func (c grpcConn) LocalTopology(ctx context.Context) (LocalTopology, error) {
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.LocalTopology(ctx, &sdpb.LocalTopologyRequest{})
+	if err != nil {
+		return LocalTopology{}, err
+	}
+	return convertLocalTopology(response)
+}
+
+func convertLocalTopology(response *sdpb.LocalTopologyResponse) (LocalTopology, error) {
+	topo := LocalTopology{
+		IA:              addr.IA(response.IsdAs),
+		Core:            response.Core,
+		MTU:             uint16(response.Mtu),
+		BorderRouters:   make([]BorderRouter, 0, len(response.BorderRouters)),
+		ControlServices: make([]ControlService, 0, len(response.ControlServices)),
+	}
+	for _, pb := range response.BorderRouters {
+		br := BorderRouter{
+			Name:       pb.Name,
+			Interfaces: make([]BorderRouterInterface, 0, len(pb.Interfaces)),
+		}
+		if pb.InternalAddress != nil {
+			a, err := net.ResolveUDPAddr("udp", pb.InternalAddress.Address)
+			if err != nil {
+				return LocalTopology{}, serrors.WrapStr("parsing border router address", err,
+					"name", pb.Name)
+			}
+			br.InternalAddr = a
+		}
+		for _, intf := range pb.Interfaces {
+			br.Interfaces = append(br.Interfaces, BorderRouterInterface{
+				ID:         common.IFIDType(intf.Id),
+				NeighborIA: addr.IA(intf.NeighborIsdAs),
+				NeighborID: common.IFIDType(intf.NeighborId),
+				LinkType:   linkRelationFromPB(intf.Relation),
+				MTU:        uint16(intf.Mtu),
+			})
+		}
+		topo.BorderRouters = append(topo.BorderRouters, br)
+	}
+	for _, pb := range response.ControlServices {
+		cs := ControlService{Name: pb.Name}
+		if pb.Address != nil {
+			a, err := net.ResolveUDPAddr("udp", pb.Address.Address)
+			if err != nil {
+				return LocalTopology{}, serrors.WrapStr("parsing control service address", err,
+					"name", pb.Name)
+			}
+			cs.Addr = a
+		}
+		topo.ControlServices = append(topo.ControlServices, cs)
+	}
+	return topo, nil
+}
+
 func (c grpcConn) IFInfo(ctx context.Context,
 	_ []common.IFIDType) (map[common.IFIDType]*net.UDPAddr, error) {
 
@@ -167,6 +356,49 @@ func (c grpcConn) RevNotification(ctx context.Context, revInfo *path_mgmt.RevInf
 
 }
 
+func (c grpcConn) ReportPathUsage(ctx context.Context, usages []snet.PathUsage) error {
+	req := &sdpb.ReportPathUsageRequest{
+		Usages: make([]*sdpb.PathUsage, 0, len(usages)),
+	}
+	for _, u := range usages {
+		rtts := make([]*durationpb.Duration, 0, len(u.RTTs))
+		for _, rtt := range u.RTTs {
+			rtts = append(rtts, durationpb.New(rtt))
+		}
+		req.Usages = append(req.Usages, &sdpb.PathUsage{
+			DestinationIsdAs: uint64(u.Destination),
+			Fingerprint:      []byte(u.Fingerprint),
+			PacketsSent:      u.PacketsSent,
+			PacketsLost:      u.PacketsLost,
+			RttSamples:       rtts,
+		})
+	}
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	_, err := client.ReportPathUsage(ctx, req)
+	return err
+}
+
+func (c grpcConn) HiddenPathGroups(ctx context.Context) ([]HiddenPathGroup, error) {
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.HiddenPathGroups(ctx, &sdpb.HiddenPathGroupsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]HiddenPathGroup, 0, len(response.Groups))
+	for _, g := range response.Groups {
+		writers := make([]addr.IA, 0, len(g.Writers))
+		for _, w := range g.Writers {
+			writers = append(writers, addr.IA(w))
+		}
+		groups = append(groups, HiddenPathGroup{
+			ID:      g.Id,
+			Writers: writers,
+			Learned: g.Learned,
+		})
+	}
+	return groups, nil
+}
+
 func (c grpcConn) DRKeyGetASHostKey(ctx context.Context,
 	meta drkey.ASHostMeta) (drkey.ASHostKey, error) {
 
@@ -215,6 +447,31 @@ func (c grpcConn) DRKeyGetHostHostKey(ctx context.Context,
 	return key, nil
 }
 
+func (c grpcConn) ColibriReserve(ctx context.Context,
+	req colibri.Request) (colibri.Response, error) {
+
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	reply, err := client.ColibriReserve(ctx, &sdpb.ColibriReserveRequest{
+		DestinationIsdAs: uint64(req.DstIA),
+		Egress:           uint64(req.Egress),
+		Bandwidth:        req.Bandwidth,
+		Expiration:       timestamppb.New(req.Expiration),
+	})
+	if err != nil {
+		return colibri.Response{}, err
+	}
+	res := colibri.Response{
+		Accepted:      reply.Accepted,
+		Bandwidth:     reply.Bandwidth,
+		FailureReason: reply.FailureReason,
+	}
+	if reply.Accepted {
+		res.ID = colibri.ReservationID{ASID: addr.AS(reply.IdAs), Suffix: reply.IdSuffix}
+		res.Expiration = reply.Expiration.AsTime()
+	}
+	return res, nil
+}
+
 func (c grpcConn) Close() error {
 	return c.conn.Close()
 }
@@ -289,6 +546,7 @@ func convertPath(p *sdpb.Path, dst addr.IA) (path.Path, error) {
 			LinkType:     linkType,
 			InternalHops: p.InternalHops,
 			Notes:        p.Notes,
+			Peering:      p.Peering,
 		},
 	}
 
@@ -0,0 +1,142 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/topology"
+)
+
+func TestConvertPathStaticMetadata(t *testing.T) {
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	pb := &sdpb.Path{
+		Raw: []byte{0x01},
+		Interface: &sdpb.Interface{
+			Address: &sdpb.Underlay{Address: "127.0.0.1:30041"},
+		},
+		Interfaces: []*sdpb.PathInterface{
+			{IsdAs: uint64(src), Id: 1},
+			{IsdAs: uint64(xtest.MustParseIA("1-ff00:0:111")), Id: 2},
+			{IsdAs: uint64(xtest.MustParseIA("1-ff00:0:111")), Id: 3},
+			{IsdAs: uint64(dst), Id: 4},
+		},
+		Mtu:        1472,
+		Expiration: &timestamppb.Timestamp{Seconds: 1700000000},
+		Latency: []*durationpb.Duration{
+			durationpb.New(10 * time.Millisecond),
+			durationpb.New(snet.LatencyUnset),
+			durationpb.New(1500 * time.Millisecond),
+		},
+		Bandwidth: []uint64{1000000, 0, 400000},
+		Geo: []*sdpb.GeoCoordinates{
+			{Latitude: 47.37, Longitude: 8.54, Address: "Zurich"},
+			{},
+			{},
+			{Latitude: 46.95, Longitude: 7.44, Address: "Bern"},
+		},
+		LinkType: []sdpb.LinkType{
+			sdpb.LinkType_LINK_TYPE_DIRECT,
+			sdpb.LinkType_LINK_TYPE_OPEN_NET,
+		},
+		InternalHops: []uint32{3},
+		Notes:        []string{"origin", "", "destination"},
+	}
+
+	p, err := convertPath(pb, dst)
+	require.NoError(t, err)
+	meta := p.Metadata()
+	assert.Equal(t, src, p.Source())
+	assert.Equal(t, dst, p.Destination())
+	assert.Equal(t, uint16(1472), meta.MTU)
+	assert.Equal(t, time.Unix(1700000000, 0), meta.Expiry)
+	assert.Equal(t, []time.Duration{
+		10 * time.Millisecond, snet.LatencyUnset, 1500 * time.Millisecond,
+	}, meta.Latency)
+	assert.Equal(t, []uint64{1000000, 0, 400000}, meta.Bandwidth)
+	assert.Equal(t, []snet.GeoCoordinates{
+		{Latitude: 47.37, Longitude: 8.54, Address: "Zurich"},
+		{},
+		{},
+		{Latitude: 46.95, Longitude: 7.44, Address: "Bern"},
+	}, meta.Geo)
+	assert.Equal(t, []snet.LinkType{snet.LinkTypeDirect, snet.LinkTypeOpennet}, meta.LinkType)
+	assert.Equal(t, []uint32{3}, meta.InternalHops)
+	assert.Equal(t, []string{"origin", "", "destination"}, meta.Notes)
+}
+
+func TestConvertLocalTopology(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:110")
+	parent := xtest.MustParseIA("1-ff00:0:111")
+	pb := &sdpb.LocalTopologyResponse{
+		IsdAs: uint64(local),
+		Core:  true,
+		Mtu:   1472,
+		BorderRouters: []*sdpb.BorderRouter{
+			{
+				Name:            "br1",
+				InternalAddress: &sdpb.Underlay{Address: "127.0.0.1:30042"},
+				Interfaces: []*sdpb.BorderRouterInterface{
+					{
+						Id:            1,
+						NeighborIsdAs: uint64(parent),
+						NeighborId:    41,
+						Relation:      sdpb.LinkRelation_LINK_RELATION_PARENT,
+						Mtu:           1400,
+					},
+				},
+			},
+		},
+		ControlServices: []*sdpb.ControlService{
+			{Name: "cs1", Address: &sdpb.Underlay{Address: "127.0.0.1:30252"}},
+		},
+	}
+
+	topo, err := convertLocalTopology(pb)
+	require.NoError(t, err)
+	assert.Equal(t, LocalTopology{
+		IA:   local,
+		Core: true,
+		MTU:  1472,
+		BorderRouters: []BorderRouter{
+			{
+				Name:         "br1",
+				InternalAddr: &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 30042},
+				Interfaces: []BorderRouterInterface{
+					{ID: 1, NeighborIA: parent, NeighborID: 41, LinkType: topology.Parent,
+						MTU: 1400},
+				},
+			},
+		},
+		ControlServices: []ControlService{
+			{Name: "cs1", Addr: &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 30252}},
+		},
+	}, topo)
+
+	pb.ControlServices[0].Address.Address = "invalid"
+	_, err = convertLocalTopology(pb)
+	assert.Error(t, err)
+}
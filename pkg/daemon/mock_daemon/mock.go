@@ -11,6 +11,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	addr "github.com/scionproto/scion/pkg/addr"
+	colibri "github.com/scionproto/scion/pkg/colibri"
 	daemon "github.com/scionproto/scion/pkg/daemon"
 	drkey "github.com/scionproto/scion/pkg/drkey"
 	common "github.com/scionproto/scion/pkg/private/common"
@@ -70,6 +71,21 @@ func (mr *MockConnectorMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockConnector)(nil).Close))
 }
 
+// ColibriReserve mocks base method.
+func (m *MockConnector) ColibriReserve(arg0 context.Context, arg1 colibri.Request) (colibri.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ColibriReserve", arg0, arg1)
+	ret0, _ := ret[0].(colibri.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ColibriReserve indicates an expected call of ColibriReserve.
+func (mr *MockConnectorMockRecorder) ColibriReserve(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColibriReserve", reflect.TypeOf((*MockConnector)(nil).ColibriReserve), arg0, arg1)
+}
+
 // DRKeyGetASHostKey mocks base method.
 func (m *MockConnector) DRKeyGetASHostKey(arg0 context.Context, arg1 drkey.ASHostMeta) (drkey.ASHostKey, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +131,36 @@ func (mr *MockConnectorMockRecorder) DRKeyGetHostHostKey(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DRKeyGetHostHostKey", reflect.TypeOf((*MockConnector)(nil).DRKeyGetHostHostKey), arg0, arg1)
 }
 
+// DisjointPaths mocks base method.
+func (m *MockConnector) DisjointPaths(arg0 context.Context, arg1, arg2 addr.IA, arg3 int, arg4 daemon.DisjointnessMode, arg5 bool) ([]snet.Path, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisjointPaths", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]snet.Path)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisjointPaths indicates an expected call of DisjointPaths.
+func (mr *MockConnectorMockRecorder) DisjointPaths(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisjointPaths", reflect.TypeOf((*MockConnector)(nil).DisjointPaths), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// HiddenPathGroups mocks base method.
+func (m *MockConnector) HiddenPathGroups(arg0 context.Context) ([]daemon.HiddenPathGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HiddenPathGroups", arg0)
+	ret0, _ := ret[0].([]daemon.HiddenPathGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HiddenPathGroups indicates an expected call of HiddenPathGroups.
+func (mr *MockConnectorMockRecorder) HiddenPathGroups(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HiddenPathGroups", reflect.TypeOf((*MockConnector)(nil).HiddenPathGroups), arg0)
+}
+
 // IFInfo mocks base method.
 func (m *MockConnector) IFInfo(arg0 context.Context, arg1 []common.IFIDType) (map[common.IFIDType]*net.UDPAddr, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +191,36 @@ func (mr *MockConnectorMockRecorder) LocalIA(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalIA", reflect.TypeOf((*MockConnector)(nil).LocalIA), arg0)
 }
 
+// LocalTopology mocks base method.
+func (m *MockConnector) LocalTopology(arg0 context.Context) (daemon.LocalTopology, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocalTopology", arg0)
+	ret0, _ := ret[0].(daemon.LocalTopology)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LocalTopology indicates an expected call of LocalTopology.
+func (mr *MockConnectorMockRecorder) LocalTopology(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalTopology", reflect.TypeOf((*MockConnector)(nil).LocalTopology), arg0)
+}
+
+// PathByFingerprint mocks base method.
+func (m *MockConnector) PathByFingerprint(arg0 context.Context, arg1, arg2 addr.IA, arg3 snet.PathFingerprint) (snet.Path, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathByFingerprint", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(snet.Path)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PathByFingerprint indicates an expected call of PathByFingerprint.
+func (mr *MockConnectorMockRecorder) PathByFingerprint(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathByFingerprint", reflect.TypeOf((*MockConnector)(nil).PathByFingerprint), arg0, arg1, arg2, arg3)
+}
+
 // Paths mocks base method.
 func (m *MockConnector) Paths(arg0 context.Context, arg1, arg2 addr.IA, arg3 daemon.PathReqFlags) ([]snet.Path, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +236,20 @@ func (mr *MockConnectorMockRecorder) Paths(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Paths", reflect.TypeOf((*MockConnector)(nil).Paths), arg0, arg1, arg2, arg3)
 }
 
+// ReportPathUsage mocks base method.
+func (m *MockConnector) ReportPathUsage(arg0 context.Context, arg1 []snet.PathUsage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportPathUsage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportPathUsage indicates an expected call of ReportPathUsage.
+func (mr *MockConnectorMockRecorder) ReportPathUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportPathUsage", reflect.TypeOf((*MockConnector)(nil).ReportPathUsage), arg0, arg1)
+}
+
 // RevNotification mocks base method.
 func (m *MockConnector) RevNotification(arg0 context.Context, arg1 *path_mgmt.RevInfo) error {
 	m.ctrl.T.Helper()
@@ -188,3 +278,17 @@ func (mr *MockConnectorMockRecorder) SVCInfo(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SVCInfo", reflect.TypeOf((*MockConnector)(nil).SVCInfo), arg0, arg1)
 }
+
+// WatchPaths mocks base method.
+func (m *MockConnector) WatchPaths(arg0 context.Context, arg1, arg2 addr.IA, arg3 func([]snet.Path)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchPaths", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchPaths indicates an expected call of WatchPaths.
+func (mr *MockConnectorMockRecorder) WatchPaths(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchPaths", reflect.TypeOf((*MockConnector)(nil).WatchPaths), arg0, arg1, arg2, arg3)
+}
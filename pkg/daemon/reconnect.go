@@ -0,0 +1,381 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/colibri"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+const (
+	// DefaultRequestTimeout is the default timeout for requests whose context
+	// has no deadline.
+	DefaultRequestTimeout = 10 * time.Second
+	// DefaultFailureThreshold is the default number of consecutive connection
+	// failures after which the circuit breaker opens.
+	DefaultFailureThreshold = 3
+	// DefaultBreakerTimeout is the default time during which requests fail
+	// fast after the circuit breaker opened.
+	DefaultBreakerTimeout = 5 * time.Second
+)
+
+var (
+	// ErrCircuitOpen indicates that the request was not sent because the
+	// connection to the SCION daemon failed repeatedly.
+	ErrCircuitOpen = serrors.New("circuit open, SCION Daemon unavailable")
+	// ErrConnectorClosed indicates that the connector was closed.
+	ErrConnectorClosed = serrors.New("connector closed")
+)
+
+// ConnectorFactory creates connections to the SCION daemon. Service
+// implements it.
+type ConnectorFactory interface {
+	Connect(ctx context.Context) (Connector, error)
+}
+
+var _ Connector = (*ReconnectingConnector)(nil)
+
+// ReconnectingConnector is a Connector that maintains a single persistent
+// connection to the SCION daemon and shares it between all requests. The
+// connection is established on the first request and re-established after
+// it failed.
+//
+// Requests whose context has no deadline are bounded by the request timeout.
+// After the configured number of consecutive connection failures, the
+// circuit breaker opens and requests fail fast with ErrCircuitOpen until the
+// breaker timeout expired. Errors returned by the daemon itself, e.g.,
+// ErrPathNotFound, are not considered connection failures.
+type ReconnectingConnector struct {
+	// Factory is used to connect to the daemon.
+	Factory ConnectorFactory
+	// RequestTimeout is the timeout for requests without deadline. If zero,
+	// DefaultRequestTimeout is used. It does not apply to WatchPaths.
+	RequestTimeout time.Duration
+	// FailureThreshold is the number of consecutive connection failures after
+	// which the circuit breaker opens. If zero, DefaultFailureThreshold is
+	// used.
+	FailureThreshold int
+	// BreakerTimeout is the time during which requests fail fast after the
+	// circuit breaker opened. If zero, DefaultBreakerTimeout is used.
+	BreakerTimeout time.Duration
+
+	mtx       sync.Mutex
+	conn      Connector
+	failures  int
+	openUntil time.Time
+	closed    bool
+}
+
+func (c *ReconnectingConnector) LocalIA(ctx context.Context) (addr.IA, error) {
+	var ia addr.IA
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		ia, err = conn.LocalIA(ctx)
+		return err
+	})
+	return ia, err
+}
+
+func (c *ReconnectingConnector) Paths(ctx context.Context, dst, src addr.IA,
+	f PathReqFlags) ([]snet.Path, error) {
+
+	var paths []snet.Path
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		paths, err = conn.Paths(ctx, dst, src, f)
+		return err
+	})
+	return paths, err
+}
+
+func (c *ReconnectingConnector) WatchPaths(ctx context.Context, dst, src addr.IA,
+	handler func([]snet.Path)) error {
+
+	return c.do(ctx, false, func(ctx context.Context, conn Connector) error {
+		return conn.WatchPaths(ctx, dst, src, handler)
+	})
+}
+
+func (c *ReconnectingConnector) PathByFingerprint(ctx context.Context, dst, src addr.IA,
+	fingerprint snet.PathFingerprint) (snet.Path, error) {
+
+	var path snet.Path
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		path, err = conn.PathByFingerprint(ctx, dst, src, fingerprint)
+		return err
+	})
+	return path, err
+}
+
+func (c *ReconnectingConnector) DisjointPaths(ctx context.Context, dst, src addr.IA, k int,
+	mode DisjointnessMode, refresh bool) ([]snet.Path, error) {
+
+	var paths []snet.Path
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		paths, err = conn.DisjointPaths(ctx, dst, src, k, mode, refresh)
+		return err
+	})
+	return paths, err
+}
+
+func (c *ReconnectingConnector) ASInfo(ctx context.Context, ia addr.IA) (ASInfo, error) {
+	var info ASInfo
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		info, err = conn.ASInfo(ctx, ia)
+		return err
+	})
+	return info, err
+}
+
+func (c *ReconnectingConnector) LocalTopology(ctx context.Context) (LocalTopology, error) {
+	var topo LocalTopology
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		topo, err = conn.LocalTopology(ctx)
+		return err
+	})
+	return topo, err
+}
+
+func (c *ReconnectingConnector) IFInfo(ctx context.Context,
+	ifs []common.IFIDType) (map[common.IFIDType]*net.UDPAddr, error) {
+
+	var infos map[common.IFIDType]*net.UDPAddr
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		infos, err = conn.IFInfo(ctx, ifs)
+		return err
+	})
+	return infos, err
+}
+
+func (c *ReconnectingConnector) SVCInfo(ctx context.Context,
+	svcTypes []addr.SVC) (map[addr.SVC][]string, error) {
+
+	var infos map[addr.SVC][]string
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		infos, err = conn.SVCInfo(ctx, svcTypes)
+		return err
+	})
+	return infos, err
+}
+
+func (c *ReconnectingConnector) RevNotification(ctx context.Context,
+	revInfo *path_mgmt.RevInfo) error {
+
+	return c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		return conn.RevNotification(ctx, revInfo)
+	})
+}
+
+func (c *ReconnectingConnector) ReportPathUsage(ctx context.Context,
+	usages []snet.PathUsage) error {
+
+	return c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		return conn.ReportPathUsage(ctx, usages)
+	})
+}
+
+func (c *ReconnectingConnector) HiddenPathGroups(
+	ctx context.Context) ([]HiddenPathGroup, error) {
+
+	var groups []HiddenPathGroup
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		groups, err = conn.HiddenPathGroups(ctx)
+		return err
+	})
+	return groups, err
+}
+
+func (c *ReconnectingConnector) DRKeyGetASHostKey(ctx context.Context,
+	meta drkey.ASHostMeta) (drkey.ASHostKey, error) {
+
+	var key drkey.ASHostKey
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		key, err = conn.DRKeyGetASHostKey(ctx, meta)
+		return err
+	})
+	return key, err
+}
+
+func (c *ReconnectingConnector) DRKeyGetHostASKey(ctx context.Context,
+	meta drkey.HostASMeta) (drkey.HostASKey, error) {
+
+	var key drkey.HostASKey
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		key, err = conn.DRKeyGetHostASKey(ctx, meta)
+		return err
+	})
+	return key, err
+}
+
+func (c *ReconnectingConnector) DRKeyGetHostHostKey(ctx context.Context,
+	meta drkey.HostHostMeta) (drkey.HostHostKey, error) {
+
+	var key drkey.HostHostKey
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		key, err = conn.DRKeyGetHostHostKey(ctx, meta)
+		return err
+	})
+	return key, err
+}
+
+func (c *ReconnectingConnector) ColibriReserve(ctx context.Context,
+	req colibri.Request) (colibri.Response, error) {
+
+	var res colibri.Response
+	err := c.do(ctx, true, func(ctx context.Context, conn Connector) error {
+		var err error
+		res, err = conn.ColibriReserve(ctx, req)
+		return err
+	})
+	return res, err
+}
+
+// Close closes the connection to the daemon. Subsequent requests fail with
+// ErrConnectorClosed.
+func (c *ReconnectingConnector) Close() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.closed = true
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// do runs the request f on the persistent connection. If timeout is set, the
+// request timeout is applied if the context has no deadline.
+func (c *ReconnectingConnector) do(ctx context.Context, timeout bool,
+	f func(context.Context, Connector) error) error {
+
+	reqCtx := ctx
+	if _, ok := ctx.Deadline(); timeout && !ok {
+		var cancelF context.CancelFunc
+		reqCtx, cancelF = context.WithTimeout(ctx, c.requestTimeout())
+		defer cancelF()
+	}
+	conn, err := c.connect(ctx, reqCtx)
+	if err != nil {
+		return err
+	}
+	err = f(reqCtx, conn)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	switch {
+	case err == nil:
+		c.failures = 0
+	case ctx.Err() == nil && isConnFailure(err):
+		// Drop the connection, so that the next request reconnects.
+		if c.conn == conn {
+			conn.Close()
+			c.conn = nil
+		}
+		c.failLocked()
+	}
+	return err
+}
+
+// connect returns the persistent connection, and establishes it if
+// necessary. The lock is held while connecting, so that concurrent requests
+// do not open multiple connections.
+func (c *ReconnectingConnector) connect(ctx, reqCtx context.Context) (Connector, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.closed {
+		return nil, ErrConnectorClosed
+	}
+	if c.conn != nil {
+		return c.conn, nil
+	}
+	if time.Now().Before(c.openUntil) {
+		return nil, ErrCircuitOpen
+	}
+	conn, err := c.Factory.Connect(reqCtx)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.failLocked()
+		}
+		return nil, serrors.Wrap(ErrUnableToConnect, err)
+	}
+	c.conn = conn
+	return conn, nil
+}
+
+func (c *ReconnectingConnector) failLocked() {
+	c.failures++
+	if c.failures >= c.failureThreshold() {
+		c.openUntil = time.Now().Add(c.breakerTimeout())
+	}
+}
+
+func (c *ReconnectingConnector) requestTimeout() time.Duration {
+	if c.RequestTimeout == 0 {
+		return DefaultRequestTimeout
+	}
+	return c.RequestTimeout
+}
+
+func (c *ReconnectingConnector) failureThreshold() int {
+	if c.FailureThreshold == 0 {
+		return DefaultFailureThreshold
+	}
+	return c.FailureThreshold
+}
+
+func (c *ReconnectingConnector) breakerTimeout() time.Duration {
+	if c.BreakerTimeout == 0 {
+		return DefaultBreakerTimeout
+	}
+	return c.BreakerTimeout
+}
+
+// isConnFailure returns whether err indicates that the connection to the
+// daemon failed, as opposed to an error reported by the daemon.
+func isConnFailure(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/daemon/mock_daemon"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+func TestReconnectingConnector(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+
+	t.Run("connection is reused", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		conn := mock_daemon.NewMockConnector(ctrl)
+		conn.EXPECT().LocalIA(gomock.Any()).Return(ia, nil).Times(2)
+		conn.EXPECT().Close()
+		factory := &countingFactory{conns: []daemon.Connector{conn}}
+		c := &daemon.ReconnectingConnector{Factory: factory}
+
+		for i := 0; i < 2; i++ {
+			localIA, err := c.LocalIA(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, ia, localIA)
+		}
+		assert.Equal(t, 1, factory.calls)
+		assert.NoError(t, c.Close())
+		_, err := c.LocalIA(context.Background())
+		assert.ErrorIs(t, err, daemon.ErrConnectorClosed)
+	})
+	t.Run("request timeout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		conn := mock_daemon.NewMockConnector(ctrl)
+		conn.EXPECT().LocalIA(gomock.Any()).DoAndReturn(
+			func(ctx context.Context) (addr.IA, error) {
+				deadline, ok := ctx.Deadline()
+				assert.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(time.Second), deadline, time.Second)
+				return ia, nil
+			},
+		)
+		c := &daemon.ReconnectingConnector{
+			Factory:        &countingFactory{conns: []daemon.Connector{conn}},
+			RequestTimeout: time.Second,
+		}
+		_, err := c.LocalIA(context.Background())
+		assert.NoError(t, err)
+	})
+	t.Run("reconnect after connection failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		broken := mock_daemon.NewMockConnector(ctrl)
+		broken.EXPECT().LocalIA(gomock.Any()).
+			Return(addr.IA(0), status.Error(codes.Unavailable, "connection refused"))
+		broken.EXPECT().Close()
+		conn := mock_daemon.NewMockConnector(ctrl)
+		conn.EXPECT().LocalIA(gomock.Any()).Return(ia, nil)
+		factory := &countingFactory{conns: []daemon.Connector{broken, conn}}
+		c := &daemon.ReconnectingConnector{Factory: factory}
+
+		_, err := c.LocalIA(context.Background())
+		assert.Error(t, err)
+		localIA, err := c.LocalIA(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, ia, localIA)
+		assert.Equal(t, 2, factory.calls)
+	})
+	t.Run("daemon error keeps connection", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		conn := mock_daemon.NewMockConnector(ctrl)
+		conn.EXPECT().PathByFingerprint(gomock.Any(), ia, addr.IA(0), gomock.Any()).
+			Return(nil, daemon.ErrPathNotFound).Times(2)
+		factory := &countingFactory{conns: []daemon.Connector{conn}}
+		c := &daemon.ReconnectingConnector{Factory: factory, FailureThreshold: 1}
+
+		for i := 0; i < 2; i++ {
+			_, err := c.PathByFingerprint(context.Background(), ia, 0, "")
+			assert.ErrorIs(t, err, daemon.ErrPathNotFound)
+		}
+		assert.Equal(t, 1, factory.calls)
+	})
+	t.Run("circuit breaker", func(t *testing.T) {
+		factory := &countingFactory{}
+		c := &daemon.ReconnectingConnector{
+			Factory:          factory,
+			FailureThreshold: 2,
+			BreakerTimeout:   time.Hour,
+		}
+		for i := 0; i < 2; i++ {
+			_, err := c.LocalIA(context.Background())
+			assert.ErrorIs(t, err, daemon.ErrUnableToConnect)
+		}
+		_, err := c.LocalIA(context.Background())
+		assert.ErrorIs(t, err, daemon.ErrCircuitOpen)
+		assert.Equal(t, 2, factory.calls)
+	})
+}
+
+// countingFactory returns the connections in order, and an error once they
+// are exhausted.
+type countingFactory struct {
+	conns []daemon.Connector
+	calls int
+}
+
+func (f *countingFactory) Connect(context.Context) (daemon.Connector, error) {
+	f.calls++
+	if len(f.conns) == 0 {
+		return nil, serrors.New("connection refused")
+	}
+	conn := f.conns[0]
+	f.conns = f.conns[1:]
+	return conn, nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"context"
+	"crypto/subtle"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/spao"
+)
+
+// DefaultSCMPAcceptanceWindow is the default acceptance window for the
+// timestamps of authenticated SCMP messages.
+const DefaultSCMPAcceptanceWindow = 5 * time.Minute
+
+const (
+	// scmpKeyFailureTTL is the time for which a failure to fetch the key for an
+	// AS-Host pair is remembered. In that time, messages from the AS are
+	// rejected without contacting the SCION Daemon.
+	scmpKeyFailureTTL = 10 * time.Second
+	// scmpKeyCacheSize is the maximum number of cached keys and failures.
+	scmpKeyCacheSize = 1024
+)
+
+// SCMPVerifier verifies the SCION Packet Authenticator Option (SPAO) of SCMP
+// messages. The authenticator is checked with the AS-Host DRKey of the
+// originating AS for the local host, which is fetched from the SCION Daemon.
+// It implements snet.SCMPVerifier.
+//
+// The fetched keys are cached until the end of their epoch, and failures to
+// fetch a key are cached for a short time, so that a stream of SCMP messages
+// results in at most a few requests to the SCION Daemon per epoch. A
+// SCMPVerifier must not be copied after first use.
+type SCMPVerifier struct {
+	Connector Connector
+	// AcceptanceWindow is the time window around the current time in which
+	// the timestamp of the message must lie. If zero,
+	// DefaultSCMPAcceptanceWindow is used.
+	AcceptanceWindow time.Duration
+
+	mtx      sync.Mutex
+	keys     map[scmpKeyID][]drkey.ASHostKey
+	failures map[scmpKeyID]time.Time
+}
+
+type scmpKeyID struct {
+	srcIA, dstIA addr.IA
+	dstHost      string
+}
+
+// Verify verifies the authenticator of the SCMP packet. It returns an error if
+// the packet is not authenticated, or if the authenticator is invalid.
+func (v *SCMPVerifier) Verify(ctx context.Context, pkt *snet.Packet) error {
+	var scionL slayers.SCION
+	if err := scionL.DecodeFromBytes(pkt.Bytes, nil); err != nil {
+		return serrors.WrapStr("decoding SCION header", err)
+	}
+	if scionL.NextHdr != slayers.End2EndClass {
+		return serrors.New("SCMP message is not authenticated")
+	}
+	var e2e slayers.EndToEndExtn
+	if err := e2e.DecodeFromBytes(scionL.Payload, nil); err != nil {
+		return serrors.WrapStr("decoding end-to-end extension", err)
+	}
+	if e2e.NextHdr != slayers.L4SCMP {
+		return serrors.New("unexpected L4 protocol", "protocol", e2e.NextHdr)
+	}
+	opt, err := e2e.FindOption(slayers.OptTypeAuthenticator)
+	if err != nil {
+		return serrors.New("SCMP message is not authenticated")
+	}
+	authOpt, err := slayers.ParsePacketAuthOption(opt)
+	if err != nil {
+		return serrors.WrapStr("parsing authenticator option", err)
+	}
+	spi := authOpt.SPI()
+	if !spi.IsDRKey() || spi.DRKeyProto() != uint16(drkey.SCMP) ||
+		spi.Type() != slayers.PacketAuthASHost ||
+		spi.Direction() != slayers.PacketAuthSenderSide {
+		return serrors.New("unsupported SPI", "spi", spi)
+	}
+	if authOpt.Algorithm() != slayers.PacketAuthCMAC {
+		return serrors.New("unsupported algorithm", "algorithm", authOpt.Algorithm())
+	}
+
+	key, err := v.key(ctx, pkt, authOpt.TimestampSN())
+	if err != nil {
+		return err
+	}
+	mac, err := spao.ComputeAuthCMAC(
+		spao.MACInput{
+			Key:        key.Key[:],
+			Header:     authOpt,
+			ScionLayer: &scionL,
+			PldType:    slayers.L4SCMP,
+			Pld:        e2e.Payload,
+		},
+		make([]byte, spao.MACBufferSize),
+		nil,
+	)
+	if err != nil {
+		return serrors.WrapStr("computing authenticator", err)
+	}
+	if subtle.ConstantTimeCompare(mac, authOpt.Authenticator()) != 1 {
+		return serrors.New("invalid authenticator")
+	}
+	return nil
+}
+
+// key fetches the AS-Host key of the epoch in which the timestamp lies within
+// the acceptance window. The epochs around the current time are considered.
+func (v *SCMPVerifier) key(ctx context.Context, pkt *snet.Packet,
+	timestamp uint64) (drkey.ASHostKey, error) {
+
+	window := v.AcceptanceWindow
+	if window == 0 {
+		window = DefaultSCMPAcceptanceWindow
+	}
+	now := time.Now()
+	validity := cppki.Validity{
+		NotBefore: now.Add(-window / 2),
+		NotAfter:  now.Add(window / 2),
+	}
+	meta := drkey.ASHostMeta{
+		ProtoId:  drkey.SCMP,
+		Validity: now,
+		SrcIA:    pkt.Source.IA,
+		DstIA:    pkt.Destination.IA,
+		DstHost:  pkt.Destination.Host.IP().String(),
+	}
+	key, err := v.getKey(ctx, meta)
+	if err != nil {
+		return drkey.ASHostKey{}, err
+	}
+	if validity.Contains(spao.AbsoluteTimestamp(key.Epoch, timestamp)) {
+		return key, nil
+	}
+	// The message might have been authenticated with the key of the adjacent
+	// epoch, if it was sent close to the epoch boundary.
+	for _, t := range []time.Time{
+		key.Epoch.NotBefore.Add(-time.Second),
+		key.Epoch.NotAfter.Add(time.Second),
+	} {
+		meta.Validity = t
+		key, err := v.getKey(ctx, meta)
+		if err != nil {
+			return drkey.ASHostKey{}, err
+		}
+		if validity.Contains(spao.AbsoluteTimestamp(key.Epoch, timestamp)) {
+			return key, nil
+		}
+	}
+	return drkey.ASHostKey{}, serrors.New("timestamp outside of acceptance window",
+		"timestamp", timestamp)
+}
+
+// getKey returns the key for the meta data from the cache, or fetches it from
+// the SCION Daemon.
+func (v *SCMPVerifier) getKey(ctx context.Context,
+	meta drkey.ASHostMeta) (drkey.ASHostKey, error) {
+
+	id := scmpKeyID{srcIA: meta.SrcIA, dstIA: meta.DstIA, dstHost: meta.DstHost}
+	now := time.Now()
+	v.mtx.Lock()
+	if until, ok := v.failures[id]; ok && now.Before(until) {
+		v.mtx.Unlock()
+		return drkey.ASHostKey{}, serrors.New("fetching DRKey failed recently",
+			"src_isd_as", meta.SrcIA)
+	}
+	for _, key := range v.keys[id] {
+		if key.Epoch.Contains(meta.Validity) {
+			v.mtx.Unlock()
+			return key, nil
+		}
+	}
+	v.mtx.Unlock()
+
+	key, err := v.Connector.DRKeyGetASHostKey(ctx, meta)
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	if v.keys == nil {
+		v.keys = make(map[scmpKeyID][]drkey.ASHostKey)
+		v.failures = make(map[scmpKeyID]time.Time)
+	}
+	if len(v.keys)+len(v.failures) >= scmpKeyCacheSize {
+		v.evictLocked(now)
+	}
+	if err != nil {
+		v.failures[id] = now.Add(scmpKeyFailureTTL)
+		return drkey.ASHostKey{}, serrors.WrapStr("fetching DRKey", err)
+	}
+	delete(v.failures, id)
+	v.keys[id] = append(v.keys[id], key)
+	return key, nil
+}
+
+// evictLocked removes the expired keys and failures. If the cache is still
+// full, it is emptied.
+func (v *SCMPVerifier) evictLocked(now time.Time) {
+	for id, until := range v.failures {
+		if !now.Before(until) {
+			delete(v.failures, id)
+		}
+	}
+	for id, keys := range v.keys {
+		valid := keys[:0]
+		for _, key := range keys {
+			if now.Before(key.Epoch.NotAfter) {
+				valid = append(valid, key)
+			}
+		}
+		if len(valid) == 0 {
+			delete(v.keys, id)
+			continue
+		}
+		v.keys[id] = valid
+	}
+	if len(v.keys)+len(v.failures) >= scmpKeyCacheSize {
+		v.keys = make(map[scmpKeyID][]drkey.ASHostKey)
+		v.failures = make(map[scmpKeyID]time.Time)
+	}
+}
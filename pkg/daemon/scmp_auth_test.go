@@ -0,0 +1,207 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon_test
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/daemon/mock_daemon"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path/empty"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/spao"
+)
+
+func TestSCMPVerifier(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	router := addr.HostIP(netip.MustParseAddr("10.0.0.1"))
+	host := addr.HostIP(netip.MustParseAddr("10.0.0.2"))
+	now := time.Now()
+	begin := util.TimeToSecs(now) - 60
+	key := drkey.ASHostKey{
+		Epoch: drkey.NewEpoch(begin, begin+3600),
+		Key:   drkey.Key{0x01, 0x02, 0x03},
+	}
+
+	testCases := map[string]struct {
+		timestamp time.Time
+		key       drkey.Key
+		auth      bool
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			timestamp: now,
+			key:       key.Key,
+			auth:      true,
+			assertErr: assert.NoError,
+		},
+		"wrong key": {
+			timestamp: now,
+			key:       drkey.Key{0x04},
+			auth:      true,
+			assertErr: assert.Error,
+		},
+		"outdated": {
+			timestamp: now.Add(-30 * time.Second),
+			key:       key.Key,
+			auth:      true,
+			assertErr: assert.Error,
+		},
+		"not authenticated": {
+			timestamp: now,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			conn := mock_daemon.NewMockConnector(ctrl)
+			conn.EXPECT().DRKeyGetASHostKey(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, meta drkey.ASHostMeta) (drkey.ASHostKey, error) {
+					assert.Equal(t, drkey.SCMP, meta.ProtoId)
+					assert.Equal(t, ia, meta.SrcIA)
+					assert.Equal(t, "10.0.0.2", meta.DstHost)
+					return key, nil
+				},
+			).AnyTimes()
+			v := daemon.SCMPVerifier{Connector: conn, AcceptanceWindow: 10 * time.Second}
+
+			raw := newSCMPPacket(t, ia, router, host, tc.auth,
+				drkey.ASHostKey{Epoch: key.Epoch, Key: tc.key}, tc.timestamp)
+			pkt := &snet.Packet{
+				Bytes: raw,
+				PacketInfo: snet.PacketInfo{
+					Source:      snet.SCIONAddress{IA: ia, Host: router},
+					Destination: snet.SCIONAddress{IA: ia, Host: host},
+				},
+			}
+			tc.assertErr(t, v.Verify(context.Background(), pkt))
+		})
+	}
+}
+
+func TestSCMPVerifierCache(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	router := addr.HostIP(netip.MustParseAddr("10.0.0.1"))
+	host := addr.HostIP(netip.MustParseAddr("10.0.0.2"))
+	now := time.Now()
+	begin := util.TimeToSecs(now) - 60
+	key := drkey.ASHostKey{
+		Epoch: drkey.NewEpoch(begin, begin+3600),
+		Key:   drkey.Key{0x01, 0x02, 0x03},
+	}
+	newPacket := func(srcIA addr.IA) *snet.Packet {
+		return &snet.Packet{
+			Bytes: newSCMPPacket(t, srcIA, router, host, true, key, now),
+			PacketInfo: snet.PacketInfo{
+				Source:      snet.SCIONAddress{IA: srcIA, Host: router},
+				Destination: snet.SCIONAddress{IA: ia, Host: host},
+			},
+		}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	conn := mock_daemon.NewMockConnector(ctrl)
+	v := &daemon.SCMPVerifier{Connector: conn, AcceptanceWindow: 10 * time.Second}
+
+	conn.EXPECT().DRKeyGetASHostKey(gomock.Any(), gomock.Any()).Return(key, nil)
+	assert.NoError(t, v.Verify(context.Background(), newPacket(ia)))
+	assert.NoError(t, v.Verify(context.Background(), newPacket(ia)))
+
+	other := xtest.MustParseIA("1-ff00:0:111")
+	conn.EXPECT().DRKeyGetASHostKey(gomock.Any(), gomock.Any()).
+		Return(drkey.ASHostKey{}, serrors.New("test"))
+	assert.Error(t, v.Verify(context.Background(), newPacket(other)))
+	assert.Error(t, v.Verify(context.Background(), newPacket(other)))
+}
+
+func newSCMPPacket(t *testing.T, ia addr.IA, src, dst addr.Host, auth bool,
+	key drkey.ASHostKey, ts time.Time) []byte {
+
+	scionL := &slayers.SCION{
+		NextHdr:  slayers.L4SCMP,
+		PathType: empty.PathType,
+		Path:     empty.Path{},
+		SrcIA:    ia,
+		DstIA:    ia,
+	}
+	require.NoError(t, scionL.SetSrcAddr(src))
+	require.NoError(t, scionL.SetDstAddr(dst))
+	scmpH := &slayers.SCMP{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeExternalInterfaceDown, 0),
+	}
+	scmpH.SetNetworkLayerForChecksum(scionL)
+	scmpP := &slayers.SCMPExternalInterfaceDown{IA: ia, IfID: 4}
+	opts := gopacket.SerializeOptions{ComputeChecksums: true, FixLengths: true}
+
+	scmpBuf := gopacket.NewSerializeBuffer()
+	require.NoError(t, gopacket.SerializeLayers(scmpBuf, opts, scmpH, scmpP))
+	pld := scmpBuf.Bytes()
+
+	buf := gopacket.NewSerializeBuffer()
+	if !auth {
+		require.NoError(t, gopacket.SerializeLayers(buf, opts, scionL, gopacket.Payload(pld)))
+		return buf.Bytes()
+	}
+	spi, err := slayers.MakePacketAuthSPIDRKey(uint16(drkey.SCMP),
+		slayers.PacketAuthASHost, slayers.PacketAuthSenderSide)
+	require.NoError(t, err)
+	timestamp, err := spao.RelativeTimestamp(key.Epoch, ts)
+	require.NoError(t, err)
+	opt, err := slayers.NewPacketAuthOption(slayers.PacketAuthOptionParams{
+		SPI:         spi,
+		Algorithm:   slayers.PacketAuthCMAC,
+		TimestampSN: timestamp,
+		Auth:        make([]byte, 16),
+	})
+	require.NoError(t, err)
+	_, err = spao.ComputeAuthCMAC(
+		spao.MACInput{
+			Key:        key.Key[:],
+			Header:     opt,
+			ScionLayer: scionL,
+			PldType:    slayers.L4SCMP,
+			Pld:        pld,
+		},
+		make([]byte, spao.MACBufferSize),
+		opt.Authenticator(),
+	)
+	require.NoError(t, err)
+	scionL.NextHdr = slayers.End2EndClass
+	e2e := &slayers.EndToEndExtn{
+		Options: []*slayers.EndToEndOption{opt.EndToEndOption},
+	}
+	e2e.NextHdr = slayers.L4SCMP
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, scionL, e2e, gopacket.Payload(pld)))
+	return buf.Bytes()
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// AuditAction is the kind of operation that is audited.
+type AuditAction string
+
+const (
+	// AuditRegistration is the action for hidden segment registrations.
+	AuditRegistration AuditAction = "registration"
+	// AuditLookup is the action for authoritative hidden segment lookups.
+	AuditLookup AuditAction = "lookup"
+)
+
+// AuditRecord describes a single authorization decision taken by a hidden
+// path server.
+type AuditRecord struct {
+	// Time is the time the decision was taken.
+	Time time.Time `json:"time"`
+	// Action is the operation that was requested.
+	Action AuditAction `json:"action"`
+	// LocalIA is the ISD-AS of the server that took the decision.
+	LocalIA addr.IA `json:"local_isd_as"`
+	// Peer is the ISD-AS of the requester.
+	Peer addr.IA `json:"peer_isd_as"`
+	// DstIA is the destination of the requested segments. It is only set for
+	// lookups.
+	DstIA addr.IA `json:"dst_isd_as,omitempty"`
+	// GroupIDs are the groups that were requested.
+	GroupIDs []GroupID `json:"group_ids"`
+	// MatchedGroups are the requested groups that the peer is authorized for.
+	MatchedGroups []GroupID `json:"matched_group_ids"`
+	// Segments is the number of segments in the registration.
+	Segments int `json:"segments,omitempty"`
+	// Allowed indicates whether the request was authorized.
+	Allowed bool `json:"allowed"`
+	// Reason describes why the request was denied.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Auditor records authorization decisions.
+type Auditor interface {
+	Audit(context.Context, AuditRecord)
+}
+
+// MultiAuditor forwards audit records to all contained auditors.
+type MultiAuditor []Auditor
+
+// Audit forwards the record to all auditors.
+func (m MultiAuditor) Audit(ctx context.Context, r AuditRecord) {
+	for _, a := range m {
+		a.Audit(ctx, r)
+	}
+}
+
+// WriterAuditor writes the audit records as JSON lines to the underlying
+// writer.
+type WriterAuditor struct {
+	mtx sync.Mutex
+	w   io.Writer
+}
+
+// NewWriterAuditor creates an auditor that writes to the given writer. If the
+// writer implements io.Closer it is closed when the auditor is closed.
+func NewWriterAuditor(w io.Writer) *WriterAuditor {
+	return &WriterAuditor{w: w}
+}
+
+// NewFileAuditor creates an auditor that appends to the file at the given
+// path. The file is created if it doesn't exist.
+func NewFileAuditor(path string) (*WriterAuditor, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return nil, serrors.WrapStr("opening audit log", err, "path", path)
+	}
+	return NewWriterAuditor(f), nil
+}
+
+// Audit writes the record. Write failures are logged, but otherwise ignored.
+func (a *WriterAuditor) Audit(ctx context.Context, r AuditRecord) {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		log.FromCtx(ctx).Error("Failed to encode audit record", "err", err)
+		return
+	}
+	raw = append(raw, '\n')
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if _, err := a.w.Write(raw); err != nil {
+		log.FromCtx(ctx).Error("Failed to write audit record", "err", err)
+	}
+}
+
+// Close closes the underlying writer if it implements io.Closer.
+func (a *WriterAuditor) Close() error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if c, ok := a.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// GroupStats are the counts of the audited decisions for a group and action.
+type GroupStats struct {
+	GroupID GroupID
+	Action  AuditAction
+	// Allowed is the number of requests for the group that were authorized.
+	Allowed uint64
+	// Denied is the number of requests for the group that were denied.
+	Denied uint64
+	// Segments is the number of segments in the authorized registrations.
+	Segments uint64
+	// LastRequest is the time of the last request for the group.
+	LastRequest time.Time
+}
+
+// UnknownGroupID is the group ID under which the statistics of all groups
+// that are not configured are counted.
+var UnknownGroupID = GroupID{}
+
+// StatsAuditor counts the audited decisions per group and action. A request
+// for multiple groups is counted for each group; it is counted as allowed for
+// the groups that the peer is authorized for. Only the configured groups are
+// counted separately, the requests for any other group are counted once under
+// UnknownGroupID, such that peers cannot grow the statistics without bound.
+type StatsAuditor struct {
+	mtx    sync.Mutex
+	groups map[GroupID]struct{}
+	stats  map[statsKey]*GroupStats
+}
+
+type statsKey struct {
+	id     GroupID
+	action AuditAction
+}
+
+// SetGroups sets the configured groups.
+func (a *StatsAuditor) SetGroups(groups Groups) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.groups = make(map[GroupID]struct{}, len(groups))
+	for id := range groups {
+		a.groups[id] = struct{}{}
+	}
+}
+
+// Audit counts the record.
+func (a *StatsAuditor) Audit(_ context.Context, r AuditRecord) {
+	matched := make(map[GroupID]struct{}, len(r.MatchedGroups))
+	for _, id := range r.MatchedGroups {
+		matched[id] = struct{}{}
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.stats == nil {
+		a.stats = make(map[statsKey]*GroupStats)
+	}
+	var unknown bool
+	for _, id := range r.GroupIDs {
+		if _, ok := a.groups[id]; !ok {
+			unknown = true
+			continue
+		}
+		_, ok := matched[id]
+		a.count(id, r, ok && r.Allowed)
+	}
+	if unknown {
+		a.count(UnknownGroupID, r, false)
+	}
+}
+
+func (a *StatsAuditor) count(id GroupID, r AuditRecord, allowed bool) {
+	k := statsKey{id: id, action: r.Action}
+	s, ok := a.stats[k]
+	if !ok {
+		s = &GroupStats{GroupID: id, Action: r.Action}
+		a.stats[k] = s
+	}
+	if allowed {
+		s.Allowed++
+		s.Segments += uint64(r.Segments)
+	} else {
+		s.Denied++
+	}
+	if r.Time.After(s.LastRequest) {
+		s.LastRequest = r.Time
+	}
+}
+
+// Stats returns the counts ordered by group ID and action.
+func (a *StatsAuditor) Stats() []GroupStats {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	stats := make([]GroupStats, 0, len(a.stats))
+	for _, s := range a.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].GroupID != stats[j].GroupID {
+			return stats[i].GroupID.ToUint64() < stats[j].GroupID.ToUint64()
+		}
+		return stats[i].Action < stats[j].Action
+	})
+	return stats
+}
+
+func audit(ctx context.Context, a Auditor, r AuditRecord, now time.Time, err error) {
+	if a == nil {
+		return
+	}
+	r.Time = now
+	r.Allowed = err == nil
+	if err != nil {
+		r.Reason = err.Error()
+	}
+	a.Audit(ctx, r)
+}
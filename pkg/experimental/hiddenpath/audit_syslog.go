@@ -0,0 +1,33 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package hiddenpath
+
+import (
+	"log/syslog"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// NewSyslogAuditor creates an auditor that writes to the local syslog daemon
+// using the authpriv facility and the given tag.
+func NewSyslogAuditor(tag string) (*WriterAuditor, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTHPRIV, tag)
+	if err != nil {
+		return nil, serrors.WrapStr("connecting to syslog", err)
+	}
+	return NewWriterAuditor(w), nil
+}
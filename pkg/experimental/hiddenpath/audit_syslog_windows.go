@@ -0,0 +1,24 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// NewSyslogAuditor returns an error, syslog is not supported on windows.
+func NewSyslogAuditor(tag string) (*WriterAuditor, error) {
+	return nil, serrors.New("syslog is not supported on windows")
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath/mock_hiddenpath"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+type recordingAuditor struct {
+	records []hiddenpath.AuditRecord
+}
+
+func (a *recordingAuditor) Audit(_ context.Context, r hiddenpath.AuditRecord) {
+	a.records = append(a.records, r)
+}
+
+func TestWriterAuditor(t *testing.T) {
+	var buf bytes.Buffer
+	a := hiddenpath.NewWriterAuditor(&buf)
+	id := mustParseGroupID(t, "ff00:0:4-5")
+	a.Audit(context.Background(), hiddenpath.AuditRecord{
+		Action:        hiddenpath.AuditLookup,
+		LocalIA:       xtest.MustParseIA("1-ff00:0:114"),
+		Peer:          xtest.MustParseIA("1-ff00:0:111"),
+		GroupIDs:      []hiddenpath.GroupID{id},
+		MatchedGroups: []hiddenpath.GroupID{id},
+		Allowed:       true,
+	})
+	require.NoError(t, a.Close())
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "lookup", got["action"])
+	assert.Equal(t, "1-ff00:0:111", got["peer_isd_as"])
+	assert.Equal(t, []interface{}{"ff00:0:4-5"}, got["group_ids"])
+	assert.Equal(t, true, got["allowed"])
+	assert.NotContains(t, got, "dst_isd_as")
+}
+
+func TestStatsAuditor(t *testing.T) {
+	a := &hiddenpath.StatsAuditor{}
+	id1 := mustParseGroupID(t, "ff00:0:4-5")
+	id2 := mustParseGroupID(t, "ff00:0:4-6")
+	a.SetGroups(hiddenpath.Groups{id1: {ID: id1}, id2: {ID: id2}})
+	now := time.Now()
+	a.Audit(context.Background(), hiddenpath.AuditRecord{
+		Time:          now.Add(-time.Minute),
+		Action:        hiddenpath.AuditRegistration,
+		GroupIDs:      []hiddenpath.GroupID{id1},
+		MatchedGroups: []hiddenpath.GroupID{id1},
+		Segments:      3,
+		Allowed:       true,
+	})
+	a.Audit(context.Background(), hiddenpath.AuditRecord{
+		Time:     now,
+		Action:   hiddenpath.AuditRegistration,
+		GroupIDs: []hiddenpath.GroupID{id1},
+		Segments: 2,
+	})
+	a.Audit(context.Background(), hiddenpath.AuditRecord{
+		Time:          now,
+		Action:        hiddenpath.AuditLookup,
+		GroupIDs:      []hiddenpath.GroupID{id1, id2},
+		MatchedGroups: []hiddenpath.GroupID{id2},
+		Allowed:       true,
+	})
+	// Groups that are not configured are counted once per request.
+	a.Audit(context.Background(), hiddenpath.AuditRecord{
+		Time:   now,
+		Action: hiddenpath.AuditLookup,
+		GroupIDs: []hiddenpath.GroupID{
+			mustParseGroupID(t, "ff00:0:5-1"),
+			mustParseGroupID(t, "ff00:0:5-2"),
+		},
+	})
+
+	assert.Equal(t, []hiddenpath.GroupStats{
+		{
+			GroupID:     hiddenpath.UnknownGroupID,
+			Action:      hiddenpath.AuditLookup,
+			Denied:      1,
+			LastRequest: now,
+		},
+		{GroupID: id1, Action: hiddenpath.AuditLookup, Denied: 1, LastRequest: now},
+		{
+			GroupID:     id1,
+			Action:      hiddenpath.AuditRegistration,
+			Allowed:     1,
+			Denied:      1,
+			Segments:    3,
+			LastRequest: now,
+		},
+		{GroupID: id2, Action: hiddenpath.AuditLookup, Allowed: 1, LastRequest: now},
+	}, a.Stats())
+}
+
+func TestRegistryServerAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	localIA := xtest.MustParseIA("1-ff00:0:114")
+	writer := xtest.MustParseIA("2-ff00:0:221")
+	id := mustParseGroupID(t, "ff00:0:4-5")
+	auditor := &recordingAuditor{}
+	verifier := mock_hiddenpath.NewMockVerifier(ctrl)
+	gomock.InOrder(
+		verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()),
+		verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(serrors.New("invalid signature")),
+	)
+	db := mock_hiddenpath.NewMockStore(ctrl)
+	db.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	s := hiddenpath.RegistryServer{
+		Groups: map[hiddenpath.GroupID]*hiddenpath.Group{
+			id: {
+				Writers:    map[addr.IA]struct{}{writer: {}},
+				Registries: map[addr.IA]struct{}{localIA: {}},
+			},
+		},
+		DB:       db,
+		Verifier: verifier,
+		LocalIA:  localIA,
+		Auditor:  auditor,
+	}
+
+	err := s.Register(context.Background(), hiddenpath.Registration{
+		GroupID:  id,
+		Segments: []*seg.Meta{{Type: seg.TypeDown}},
+		Peer:     &snet.SVCAddr{IA: writer},
+	})
+	require.NoError(t, err)
+	err = s.Register(context.Background(), hiddenpath.Registration{
+		GroupID:  mustParseGroupID(t, "ff00:0:4-6"),
+		Segments: []*seg.Meta{{Type: seg.TypeDown}},
+		Peer:     &snet.SVCAddr{IA: writer},
+	})
+	require.Error(t, err)
+	err = s.Register(context.Background(), hiddenpath.Registration{
+		GroupID:  id,
+		Segments: []*seg.Meta{{Type: seg.TypeDown}},
+		Peer:     &snet.SVCAddr{IA: writer},
+	})
+	require.Error(t, err)
+
+	require.Len(t, auditor.records, 3)
+	assert.True(t, auditor.records[0].Allowed)
+	assert.Equal(t, hiddenpath.AuditRegistration, auditor.records[0].Action)
+	assert.Equal(t, writer, auditor.records[0].Peer)
+	assert.Equal(t, []hiddenpath.GroupID{id}, auditor.records[0].MatchedGroups)
+	assert.Equal(t, 1, auditor.records[0].Segments)
+	assert.False(t, auditor.records[1].Allowed)
+	assert.Empty(t, auditor.records[1].MatchedGroups)
+	assert.NotEmpty(t, auditor.records[1].Reason)
+	assert.False(t, auditor.records[2].Allowed)
+	assert.Contains(t, auditor.records[2].Reason, "invalid signature")
+}
+
+func TestAuthoritativeServerAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	reader := xtest.MustParseIA("1-ff00:0:111")
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	allowed := mustParseGroupID(t, "ff00:0:110-1")
+	denied := mustParseGroupID(t, "ff00:0:110-2")
+	auditor := &recordingAuditor{}
+	db := mock_hiddenpath.NewMockStore(ctrl)
+	db.EXPECT().Get(gomock.Any(), dst, []hiddenpath.GroupID{allowed})
+	s := hiddenpath.AuthoritativeServer{
+		Groups: map[hiddenpath.GroupID]*hiddenpath.Group{
+			allowed: {
+				Readers:    map[addr.IA]struct{}{reader: {}},
+				Registries: map[addr.IA]struct{}{localIA: {}},
+			},
+			denied: {
+				Registries: map[addr.IA]struct{}{localIA: {}},
+			},
+		},
+		DB:      db,
+		LocalIA: localIA,
+		Auditor: auditor,
+	}
+
+	_, err := s.Segments(context.Background(), hiddenpath.SegmentRequest{
+		GroupIDs: []hiddenpath.GroupID{allowed},
+		DstIA:    dst,
+		Peer:     reader,
+	})
+	require.NoError(t, err)
+	_, err = s.Segments(context.Background(), hiddenpath.SegmentRequest{
+		GroupIDs: []hiddenpath.GroupID{allowed, denied},
+		DstIA:    dst,
+		Peer:     reader,
+	})
+	require.Error(t, err)
+
+	require.Len(t, auditor.records, 2)
+	assert.True(t, auditor.records[0].Allowed)
+	assert.Equal(t, hiddenpath.AuditLookup, auditor.records[0].Action)
+	assert.Equal(t, dst, auditor.records[0].DstIA)
+	assert.False(t, auditor.records[1].Allowed)
+	assert.Equal(t, []hiddenpath.GroupID{allowed, denied}, auditor.records[1].GroupIDs)
+	assert.Equal(t, []hiddenpath.GroupID{allowed}, auditor.records[1].MatchedGroups)
+}
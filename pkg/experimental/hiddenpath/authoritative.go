@@ -17,9 +17,13 @@ package hiddenpath
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/tracing"
 )
 
 // SegmentRequest is a request for hidden segments.
@@ -31,6 +35,9 @@ type SegmentRequest struct {
 	DstIA addr.IA
 	// Peer is ISD-AS of the requesting peer.
 	Peer addr.IA
+	// Visited are the registries that already handled the lookup. A
+	// FederatedServer does not forward the lookup to them.
+	Visited []addr.IA
 }
 
 // AuthoritativeServer serves segments from the database.
@@ -41,33 +48,67 @@ type AuthoritativeServer struct {
 	DB Store
 	// LocalIA is the ISD-AS this server is run in.
 	LocalIA addr.IA
+	// Auditor records the authorization decisions. If nil, no audit records
+	// are written.
+	Auditor Auditor
+	// Clock provides the time of the audit records. If nil, the wall clock is
+	// used.
+	Clock clock.Clock
 }
 
 // Segments returns the segments for the request or errors out if there was an
 // error.
 func (s AuthoritativeServer) Segments(ctx context.Context,
-	req SegmentRequest) ([]*seg.Meta, error) {
+	req SegmentRequest) (segs []*seg.Meta, err error) {
+
+	ctx, span := tracing.StartOTelSpan(ctx, "hiddenpath.authoritative_lookup",
+		attribute.String("dst", req.DstIA.String()),
+	)
+	defer func() {
+		span.SetAttributes(attribute.Int("segments", len(segs)))
+		tracing.OTelError(span, err)
+		span.End()
+	}()
+
+	matched, err := s.authorize(req)
+	audit(ctx, s.Auditor, AuditRecord{
+		Action:        AuditLookup,
+		LocalIA:       s.LocalIA,
+		Peer:          req.Peer,
+		DstIA:         req.DstIA,
+		GroupIDs:      req.GroupIDs,
+		MatchedGroups: matched,
+	}, clock.Now(s.Clock), err)
+	if err != nil {
+		return nil, err
+	}
+	return s.DB.Get(ctx, req.DstIA, req.GroupIDs)
+}
 
+// authorize checks that the peer is allowed to read all requested groups. It
+// returns the groups that were authorized before the first failing check.
+func (s AuthoritativeServer) authorize(req SegmentRequest) ([]GroupID, error) {
 	if len(req.GroupIDs) == 0 {
 		return nil, serrors.New("no group IDs provided")
 	}
+	matched := make([]GroupID, 0, len(req.GroupIDs))
 	for _, id := range req.GroupIDs {
 		group, ok := s.Groups[id]
-		if !ok {
-			return nil, serrors.New("request for unknown group", "group_id", id)
-		}
-		if !canRead(req.Peer, group) {
-			return nil, serrors.New("not allowed to read group", "group_id", id)
-		}
-		if !isAuthoritative(s.LocalIA, group) {
-			return nil, serrors.New("not authoritative for group", "group_id", id)
+		var reason AuthorizationReason
+		switch {
+		case !ok:
+			reason = ReasonUnknownGroup
+		case !canRead(req.Peer, group):
+			reason = ReasonNotMember
+		case !isAuthoritative(s.LocalIA, group):
+			reason = ReasonNotRegistry
+		default:
+			matched = append(matched, id)
+			continue
 		}
+		return matched, &GroupNotAuthorizedError{Group: id, Peer: req.Peer, Reason: reason}
 	}
-	segs, err := s.DB.Get(ctx, req.DstIA, req.GroupIDs)
-	if err != nil {
-		return nil, err
-	}
-	return segs, nil
+	return matched, nil
 }
 
 func canRead(peer addr.IA, group *Group) bool {
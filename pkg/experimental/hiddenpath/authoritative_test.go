@@ -82,7 +82,7 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 				}
 			},
 			want:      nil,
-			assertErr: assert.Error,
+			assertErr: assertNotAuthorized(hiddenpath.ReasonUnknownGroup),
 		},
 		"not reader in group": {
 			request: hiddenpath.SegmentRequest{
@@ -111,7 +111,7 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 				}
 			},
 			want:      nil,
-			assertErr: assert.Error,
+			assertErr: assertNotAuthorized(hiddenpath.ReasonNotMember),
 		},
 		"non authoritative for group": {
 			request: hiddenpath.SegmentRequest{
@@ -140,7 +140,7 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 				}
 			},
 			want:      nil,
-			assertErr: assert.Error,
+			assertErr: assertNotAuthorized(hiddenpath.ReasonNotRegistry),
 		},
 		"db error": {
 			request: hiddenpath.SegmentRequest{
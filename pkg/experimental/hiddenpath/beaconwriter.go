@@ -20,6 +20,8 @@ import (
 	"strconv"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/scionproto/scion/control/beacon"
 	"github.com/scionproto/scion/control/beaconing"
 	"github.com/scionproto/scion/control/ifstate"
@@ -29,6 +31,7 @@ import (
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/tracing"
 )
 
 // SegmentRegistration is a registration for hidden segments.
@@ -37,9 +40,16 @@ type SegmentRegistration struct {
 	Seg     seg.Meta
 }
 
+// RegistrationReply is the reply of a remote to a segment registration.
+type RegistrationReply struct {
+	// LoadHint is the load reported by a hidden segment registry. It is zero
+	// if the remote does not report its load.
+	LoadHint uint32
+}
+
 // Register is used to register segments to a remote.
 type Register interface {
-	RegisterSegment(context.Context, SegmentRegistration, net.Addr) error
+	RegisterSegment(context.Context, SegmentRegistration, net.Addr) (RegistrationReply, error)
 }
 
 // BeaconWriter terminates segments and registers them at remotes. The remotes
@@ -65,6 +75,13 @@ type BeaconWriter struct {
 	RegistrationPolicy RegistrationPolicy
 	// AddressResolver is used to resolve remote ASes.
 	AddressResolver AddressResolver
+	// Stats counts the registrations. If it is nil, nothing is counted.
+	Stats *beaconing.RegistrationStats
+	// Selector selects the registry of a hidden path group at which a segment
+	// is registered. The next registry in the order of the selector is only
+	// tried if the registration fails. If it is nil, segments are registered
+	// at all registries of the group.
+	Selector *RegistrySelector
 }
 
 // Write iterates the segments channel and for each of the segments: it extends
@@ -97,18 +114,24 @@ func (w *BeaconWriter) Write(ctx context.Context, segments []beacon.Beacon,
 			continue
 		}
 		for id, addrs := range remoteRegistries(regPolicy) {
+			if w.Selector != nil && id.ToUint64() != 0 {
+				expected++
+				order := w.Selector.Order(id, addrs)
+				wg.Add(1)
+				go func(bseg beacon.Beacon, id GroupID) {
+					defer log.HandlePanic()
+					defer wg.Done()
+					for _, a := range order {
+						if w.newRemoteWriter(summary, id, a).run(ctx, bseg) {
+							return
+						}
+					}
+				}(b, id)
+				continue
+			}
 			for _, a := range addrs {
 				expected++
-				rw := remoteWriter{
-					internalErrors:  w.InternalErrors,
-					registered:      w.Registered,
-					summary:         summary,
-					hiddenPathGroup: id,
-					resolveRemote: func(ctx context.Context) (net.Addr, error) {
-						return w.AddressResolver.Resolve(ctx, a)
-					},
-					rpc: w.RPC,
-				}
+				rw := w.newRemoteWriter(summary, id, a)
 				if id.ToUint64() == 0 {
 					// public
 					seg := b.Segment
@@ -133,30 +156,64 @@ func (w *BeaconWriter) Write(ctx context.Context, segments []beacon.Beacon,
 	return beaconing.WriteStats{Count: summary.count, StartIAs: summary.srcs}, nil
 }
 
+// newRemoteWriter creates a writer that registers a segment at the given
+// registry of the hidden path group.
+func (w *BeaconWriter) newRemoteWriter(summary *summary, id GroupID,
+	registry addr.IA) *remoteWriter {
+
+	rw := &remoteWriter{
+		internalErrors:  w.InternalErrors,
+		registered:      w.Registered,
+		stats:           w.Stats,
+		summary:         summary,
+		hiddenPathGroup: id,
+		resolveRemote: func(ctx context.Context) (net.Addr, error) {
+			return w.AddressResolver.Resolve(ctx, registry)
+		},
+		rpc: w.RPC,
+	}
+	if w.Selector != nil && id.ToUint64() != 0 {
+		rw.report = func(reply RegistrationReply, err error) {
+			w.Selector.ReportRegistration(registry, reply, err)
+		}
+	}
+	return rw
+}
+
 // remoteWriter registers one segment with the path server.
 type remoteWriter struct {
 	internalErrors  metrics.Counter
 	registered      metrics.Counter
+	stats           *beaconing.RegistrationStats
 	summary         *summary
 	hiddenPathGroup GroupID
 	resolveRemote   func(context.Context) (net.Addr, error)
 	rpc             Register
+	// report is called with the outcome of the registration. It can be nil.
+	report func(RegistrationReply, error)
 }
 
-// run resolves, and writes the segment to the remote registry.
-func (w *remoteWriter) run(ctx context.Context, bseg beacon.Beacon) {
+// run resolves, and writes the segment to the remote registry. It returns
+// whether the segment was registered.
+func (w *remoteWriter) run(ctx context.Context, bseg beacon.Beacon) bool {
 	reg := SegmentRegistration{
 		Seg:     seg.Meta{Type: seg.TypeDown, Segment: bseg.Segment},
 		GroupID: w.hiddenPathGroup,
 	}
 
+	ctx, span := tracing.StartOTelSpan(ctx, "hiddenpath.register_remote",
+		attribute.String("group", w.hpGroup()),
+	)
+	defer span.End()
 	logger := log.FromCtx(ctx)
 
 	addr, err := w.resolveRemote(ctx)
 	if err != nil {
 		logger.Error("Unable to choose server", "hp_group", w.hpGroup(), "err", err)
+		tracing.OTelError(span, err)
 		metrics.CounterInc(w.internalErrors)
-		return
+		w.reportResult(RegistrationReply{}, err)
+		return false
 	}
 
 	labels := writerLabels{
@@ -165,13 +222,18 @@ func (w *remoteWriter) run(ctx context.Context, bseg beacon.Beacon) {
 		SegType: w.segTypeString(),
 	}
 
-	if err := w.rpc.RegisterSegment(ctx, reg, addr); err != nil {
+	reply, err := w.rpc.RegisterSegment(ctx, reg, addr)
+	w.reportResult(reply, err)
+	if err != nil {
 		logger.Error("Unable to register segment",
 			"seg_type", w.segTypeString(), "addr", addr, "hp_group", w.hpGroup(), "err", err)
+		tracing.OTelError(span, err)
 		metrics.CounterInc(metrics.CounterWith(w.registered,
 			labels.WithResult(prom.ErrNetwork).Expand()...))
-		return
+		w.stats.Record(seg.TypeDown, 1, false)
+		return false
 	}
+	w.stats.Record(seg.TypeDown, 1, true)
 	w.summary.AddSrc(bseg.Segment.FirstIA())
 	w.summary.Inc()
 
@@ -179,6 +241,13 @@ func (w *remoteWriter) run(ctx context.Context, bseg beacon.Beacon) {
 		labels.WithResult(prom.Success).Expand()...))
 	logger.Debug("Successfully registered segment", "seg_type", w.segTypeString(),
 		"addr", addr, "seg", bseg.Segment, "hp_group", w.hpGroup())
+	return true
+}
+
+func (w *remoteWriter) reportResult(reply RegistrationReply, err error) {
+	if w.report != nil {
+		w.report(reply, err)
+	}
 }
 
 func (w *remoteWriter) hpGroup() string {
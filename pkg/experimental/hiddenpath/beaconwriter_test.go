@@ -38,6 +38,7 @@ import (
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath/mock_hiddenpath"
 	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/pkg/private/xtest/graph"
 	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
@@ -99,6 +100,7 @@ func TestRemoteBeaconWriterWrite(t *testing.T) {
 		createRPC func(*testing.T, *gomock.Controller) hiddenpath.Register
 		policy    hiddenpath.RegistrationPolicy
 		resolver  func(*gomock.Controller) hiddenpath.AddressResolver
+		selector  *hiddenpath.RegistrySelector
 	}{
 		"Only public registration": {
 			beacons: [][]uint16{
@@ -112,17 +114,17 @@ func TestRemoteBeaconWriterWrite(t *testing.T) {
 				rpc.EXPECT().RegisterSegment(gomock.Any(), gomock.Any(),
 					matchSVCCS("1-ff00:0:120")).DoAndReturn(
 					func(_ context.Context, reg hiddenpath.SegmentRegistration,
-						remote net.Addr) error {
+						remote net.Addr) (hiddenpath.RegistrationReply, error) {
 						validatePublicSeg(t, reg.Seg.Segment, remote.(*snet.SVCAddr), topo)
-						return nil
+						return hiddenpath.RegistrationReply{}, nil
 					},
 				)
 				rpc.EXPECT().RegisterSegment(gomock.Any(), gomock.Any(),
 					matchSVCCS("1-ff00:0:130")).DoAndReturn(
 					func(_ context.Context, reg hiddenpath.SegmentRegistration,
-						remote net.Addr) error {
+						remote net.Addr) (hiddenpath.RegistrationReply, error) {
 						validatePublicSeg(t, reg.Seg.Segment, remote.(*snet.SVCAddr), topo)
-						return nil
+						return hiddenpath.RegistrationReply{}, nil
 					},
 				)
 
@@ -150,9 +152,10 @@ func TestRemoteBeaconWriterWrite(t *testing.T) {
 						IA:   xtest.MustParseIA("1-ff00:0:114"),
 						Host: xtest.MustParseUDPAddr(t, "10.1.0.1:404"),
 					}}).Times(2).DoAndReturn(
-					func(_ context.Context, reg hiddenpath.SegmentRegistration, _ net.Addr) error {
+					func(_ context.Context, reg hiddenpath.SegmentRegistration,
+						_ net.Addr) (hiddenpath.RegistrationReply, error) {
 						validateHS(t, reg.Seg.Segment)
-						return nil
+						return hiddenpath.RegistrationReply{}, nil
 					},
 				)
 				return rpc
@@ -181,6 +184,63 @@ func TestRemoteBeaconWriterWrite(t *testing.T) {
 				return resolver
 			},
 		},
+		"registry failover": {
+			beacons: [][]uint16{
+				{graph.If_120_X_111_B},
+			},
+			createRPC: func(t *testing.T,
+				ctrl *gomock.Controller) hiddenpath.Register {
+				rpc := mock_hiddenpath.NewMockRegister(ctrl)
+				gomock.InOrder(
+					rpc.EXPECT().RegisterSegment(gomock.Any(), gomock.Any(),
+						addrMatcher{udp: &snet.UDPAddr{
+							IA:   xtest.MustParseIA("1-ff00:0:114"),
+							Host: xtest.MustParseUDPAddr(t, "10.1.0.1:404"),
+						}}).Return(hiddenpath.RegistrationReply{}, serrors.New("unavailable")),
+					rpc.EXPECT().RegisterSegment(gomock.Any(), gomock.Any(),
+						addrMatcher{udp: &snet.UDPAddr{
+							IA:   xtest.MustParseIA("1-ff00:0:115"),
+							Host: xtest.MustParseUDPAddr(t, "10.1.0.2:404"),
+						}}).DoAndReturn(
+						func(_ context.Context, reg hiddenpath.SegmentRegistration,
+							_ net.Addr) (hiddenpath.RegistrationReply, error) {
+							validateHS(t, reg.Seg.Segment)
+							return hiddenpath.RegistrationReply{}, nil
+						},
+					),
+				)
+				return rpc
+			},
+			policy: hiddenpath.RegistrationPolicy{
+				uint64(graph.If_111_B_120_X): hiddenpath.InterfacePolicy{
+					Groups: map[hiddenpath.GroupID]*hiddenpath.Group{
+						mustParseGroupID(t, "ff00:0:140-2"): {
+							ID: mustParseGroupID(t, "ff00:0:140-2"),
+							Registries: map[addr.IA]struct{}{
+								xtest.MustParseIA("1-ff00:0:114"): {},
+								xtest.MustParseIA("1-ff00:0:115"): {},
+							},
+							Writers: map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:111"): {}},
+						},
+					},
+				},
+			},
+			resolver: func(ctrl *gomock.Controller) hiddenpath.AddressResolver {
+				resolver := mock_hiddenpath.NewMockAddressResolver(ctrl)
+				resolver.EXPECT().Resolve(gomock.Any(), xtest.MustParseIA("1-ff00:0:114")).
+					Return(&snet.UDPAddr{
+						IA:   xtest.MustParseIA("1-ff00:0:114"),
+						Host: xtest.MustParseUDPAddr(t, "10.1.0.1:404"),
+					}, nil)
+				resolver.EXPECT().Resolve(gomock.Any(), xtest.MustParseIA("1-ff00:0:115")).
+					Return(&snet.UDPAddr{
+						IA:   xtest.MustParseIA("1-ff00:0:115"),
+						Host: xtest.MustParseUDPAddr(t, "10.1.0.2:404"),
+					}, nil)
+				return resolver
+			},
+			selector: mustNewRegistrySelector(t, ""),
+		},
 	}
 	for name, tc := range testCases {
 		name, tc := name, tc
@@ -208,6 +268,7 @@ func TestRemoteBeaconWriterWrite(t *testing.T) {
 				},
 				RegistrationPolicy: tc.policy,
 				AddressResolver:    tc.resolver(ctrl),
+				Selector:           tc.selector,
 			}
 			g := graph.NewDefaultGraph(ctrl)
 			var beacons []beacon.Beacon
@@ -251,13 +312,13 @@ func testSigner(t *testing.T, priv crypto.Signer, ia addr.IA) trust.Signer {
 	}
 }
 
-var macFactory = func() hash.Hash {
+var macFactory = func() (hash.Hash, bool) {
 	mac, err := scrypto.InitMac(make([]byte, 16))
 	// This can only happen if the library is messed up badly.
 	if err != nil {
 		panic(err)
 	}
-	return mac
+	return mac, false
 }
 
 type segVerifier struct {
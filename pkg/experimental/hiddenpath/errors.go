@@ -0,0 +1,65 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"fmt"
+
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+// AuthorizationReason is the reason why a peer is not authorized for a hidden
+// path group.
+type AuthorizationReason int
+
+const (
+	// ReasonUnknownGroup indicates that the group is not known locally.
+	ReasonUnknownGroup AuthorizationReason = iota + 1
+	// ReasonNotMember indicates that the peer does not have the role in the
+	// group that the request requires.
+	ReasonNotMember
+	// ReasonNotRegistry indicates that the local AS is not a registry of the
+	// group.
+	ReasonNotRegistry
+)
+
+func (r AuthorizationReason) String() string {
+	switch r {
+	case ReasonUnknownGroup:
+		return "unknown group"
+	case ReasonNotMember:
+		return "peer not member of group"
+	case ReasonNotRegistry:
+		return "not registry for group"
+	default:
+		return fmt.Sprintf("unknown reason (%d)", int(r))
+	}
+}
+
+// GroupNotAuthorizedError indicates that a peer is not authorized to read from
+// or write to a hidden path group. It can be extracted from the errors of the
+// lookup and registration servers with errors.As.
+type GroupNotAuthorizedError struct {
+	// Group is the group the peer is not authorized for.
+	Group GroupID
+	// Peer is the ISD-AS of the peer.
+	Peer addr.IA
+	// Reason is the reason why the peer is not authorized.
+	Reason AuthorizationReason
+}
+
+func (e *GroupNotAuthorizedError) Error() string {
+	return fmt.Sprintf("%s: group %s, peer %s", e.Reason, e.Group, e.Peer)
+}
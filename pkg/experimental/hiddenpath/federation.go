@@ -0,0 +1,251 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/clock"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/tracing"
+)
+
+// FederatedServer serves hidden segment lookups at a registry. If the local
+// registry has no segments for a request, the lookup is forwarded to the other
+// registries of the requested groups. This way, a reader only has to contact a
+// single registry of a group, even if the writers registered their segments at
+// a different one.
+//
+// The forwarded request lists the registries that already handled the lookup,
+// i.e., the registries that forwarded the request and the registries that were
+// contacted by them. These registries are never contacted again, which
+// prevents forwarding loops and bounds the number of forwarded requests by the
+// number of registries of the group.
+type FederatedServer struct {
+	// Local is the lookup of the local registry. It is also responsible for
+	// authorizing the request.
+	Local Lookuper
+	// Groups is the current set of groups.
+	Groups map[GroupID]*Group
+	// LocalIA is the ISD-AS this server is run in.
+	LocalIA addr.IA
+	// RPC is used to query the remote registries.
+	RPC RPC
+	// Resolver resolves the addresses of the remote registries.
+	Resolver AddressResolver
+	// Verifier verifies the segments of the remote registries.
+	Verifier Verifier
+	// Cache caches the results of forwarded lookups. If nil, the results are
+	// not cached.
+	Cache *FederationCache
+}
+
+// Segments returns the segments of the local registry. If there are none, it
+// returns the segments of the other registries of the requested groups. Errors
+// of individual remote registries are only reported if no segments were found.
+func (s FederatedServer) Segments(ctx context.Context,
+	req SegmentRequest) ([]*seg.Meta, error) {
+
+	segs, err := s.Local.Segments(ctx, req)
+	if err != nil || len(segs) > 0 {
+		return segs, err
+	}
+	key := federationKey(req)
+	if segs, ok := s.Cache.get(key); ok {
+		return segs, nil
+	}
+	requests := s.remoteRequests(req)
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	// Every contacted registry is marked as visited, such that the remote
+	// registries do not forward the request to each other.
+	visited := append([]addr.IA{}, req.Visited...)
+	visited = append(visited, s.LocalIA)
+	for registry := range requests {
+		visited = append(visited, registry)
+	}
+
+	type segsOrErr struct {
+		segs []*seg.Meta
+		err  error
+	}
+
+	replies := make(chan segsOrErr, len(requests))
+	for registry, groups := range requests {
+		go func(r addr.IA, g []GroupID) {
+			defer log.HandlePanic()
+			ctx, span := tracing.StartOTelSpan(ctx, "hiddenpath.federate",
+				attribute.String("registry", r.String()),
+			)
+			defer span.End()
+
+			a, err := s.Resolver.Resolve(ctx, r)
+			if err != nil {
+				replies <- segsOrErr{err: serrors.WrapStr("resolving registry", err,
+					"registry", r)}
+				return
+			}
+			reply, err := s.RPC.HiddenSegments(ctx, SegmentRequest{
+				GroupIDs: g,
+				DstIA:    req.DstIA,
+				Visited:  visited,
+			}, a)
+			if err != nil {
+				replies <- segsOrErr{err: serrors.WrapStr("requesting segments", err,
+					"registry", r)}
+				return
+			}
+			if err := s.Verifier.Verify(ctx, reply, a); err != nil {
+				replies <- segsOrErr{
+					err: serrors.New("can not verify segments", "crypto-source", r,
+						"server", a),
+				}
+				return
+			}
+			replies <- segsOrErr{segs: reply}
+		}(registry, groups)
+	}
+
+	var errs serrors.List
+	seen := make(map[string]struct{})
+	for range requests {
+		reply := <-replies
+		if e := reply.err; e != nil {
+			errs = append(errs, e)
+			continue
+		}
+		for _, m := range reply.segs {
+			id := string(m.Segment.FullID())
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			segs = append(segs, m)
+		}
+	}
+	if len(errs) > 0 {
+		if len(segs) == 0 {
+			return nil, errs.ToError()
+		}
+		log.FromCtx(ctx).Debug("Ignoring failed federated lookups", "err", errs.ToError())
+		return segs, nil
+	}
+	s.Cache.put(key, segs)
+	return segs, nil
+}
+
+// remoteRequests returns the groups to request per remote registry. The local
+// registry and the registries that already handled the lookup are skipped.
+func (s FederatedServer) remoteRequests(req SegmentRequest) map[addr.IA][]GroupID {
+	skip := map[addr.IA]struct{}{s.LocalIA: {}}
+	for _, ia := range req.Visited {
+		skip[ia] = struct{}{}
+	}
+	requests := make(map[addr.IA][]GroupID)
+	for _, id := range req.GroupIDs {
+		group, ok := s.Groups[id]
+		if !ok {
+			continue
+		}
+		for _, registry := range group.GetRegistries() {
+			if _, ok := skip[registry]; ok {
+				continue
+			}
+			requests[registry] = append(requests[registry], id)
+		}
+	}
+	return requests
+}
+
+// FederationCache caches the segments that were looked up at remote
+// registries. It is safe for concurrent use.
+type FederationCache struct {
+	// TTL is the duration for which a result is cached. A result is never
+	// cached past the expiry of its earliest expiring segment.
+	TTL time.Duration
+	// Clock provides the current time. If nil, the wall clock is used.
+	Clock clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]federationEntry
+}
+
+type federationEntry struct {
+	segs   []*seg.Meta
+	expiry time.Time
+}
+
+func (c *FederationCache) get(key string) ([]*seg.Meta, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !clock.Now(c.Clock).Before(e.expiry) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.segs, true
+}
+
+func (c *FederationCache) put(key string, segs []*seg.Meta) {
+	if c == nil || c.TTL <= 0 {
+		return
+	}
+	now := clock.Now(c.Clock)
+	expiry := now.Add(c.TTL)
+	for _, m := range segs {
+		if e := m.Segment.MaxExpiry(); e.Before(expiry) {
+			expiry = e
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]federationEntry)
+	}
+	for k, e := range c.entries {
+		if !now.Before(e.expiry) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = federationEntry{segs: segs, expiry: expiry}
+}
+
+// federationKey identifies the result of a lookup independent of the order of
+// the requested groups.
+func federationKey(req SegmentRequest) string {
+	groups := make([]string, 0, len(req.GroupIDs))
+	for _, id := range req.GroupIDs {
+		groups = append(groups, id.String())
+	}
+	sort.Strings(groups)
+	return req.DstIA.String() + " " + strings.Join(groups, ",")
+}
@@ -0,0 +1,338 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath/mock_hiddenpath"
+	"github.com/scionproto/scion/pkg/private/clock"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/pkg/slayers/path"
+)
+
+func TestFederatedServerSegments(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:110")
+	reg2 := xtest.MustParseIA("1-ff00:0:111")
+	reg3 := xtest.MustParseIA("1-ff00:0:112")
+	addrs := map[addr.IA]net.Addr{
+		reg2: &net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 30252},
+		reg3: &net.UDPAddr{IP: net.ParseIP("10.0.0.3"), Port: 30252},
+	}
+	group := &hiddenpath.Group{
+		ID:    hiddenpath.GroupID{OwnerAS: local.AS(), Suffix: 1},
+		Owner: local,
+		Registries: map[addr.IA]struct{}{
+			local: {},
+			reg2:  {},
+			reg3:  {},
+		},
+	}
+	groups := map[hiddenpath.GroupID]*hiddenpath.Group{group.ID: group}
+	dst := xtest.MustParseIA("1-ff00:0:120")
+	seg1 := createFederationSeg(t, 1)
+	seg2 := createFederationSeg(t, 2)
+
+	testCases := map[string]struct {
+		request   hiddenpath.SegmentRequest
+		lookuper  func(*gomock.Controller) hiddenpath.Lookuper
+		rpc       func(*gomock.Controller) hiddenpath.RPC
+		verifier  func(*gomock.Controller) hiddenpath.Verifier
+		want      []*seg.Meta
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"local segments": {
+			request: hiddenpath.SegmentRequest{
+				GroupIDs: []hiddenpath.GroupID{group.ID},
+				DstIA:    dst,
+			},
+			lookuper: func(c *gomock.Controller) hiddenpath.Lookuper {
+				l := mock_hiddenpath.NewMockLookuper(c)
+				l.EXPECT().Segments(gomock.Any(), gomock.Any()).Return([]*seg.Meta{seg1}, nil)
+				return l
+			},
+			rpc: func(c *gomock.Controller) hiddenpath.RPC {
+				return mock_hiddenpath.NewMockRPC(c)
+			},
+			verifier: func(c *gomock.Controller) hiddenpath.Verifier {
+				return mock_hiddenpath.NewMockVerifier(c)
+			},
+			want:      []*seg.Meta{seg1},
+			assertErr: assert.NoError,
+		},
+		"local error": {
+			request: hiddenpath.SegmentRequest{
+				GroupIDs: []hiddenpath.GroupID{group.ID},
+				DstIA:    dst,
+			},
+			lookuper: func(c *gomock.Controller) hiddenpath.Lookuper {
+				l := mock_hiddenpath.NewMockLookuper(c)
+				l.EXPECT().Segments(gomock.Any(), gomock.Any()).
+					Return(nil, serrors.New("not allowed to read group"))
+				return l
+			},
+			rpc: func(c *gomock.Controller) hiddenpath.RPC {
+				return mock_hiddenpath.NewMockRPC(c)
+			},
+			verifier: func(c *gomock.Controller) hiddenpath.Verifier {
+				return mock_hiddenpath.NewMockVerifier(c)
+			},
+			assertErr: assert.Error,
+		},
+		"forward to unvisited registry": {
+			request: hiddenpath.SegmentRequest{
+				GroupIDs: []hiddenpath.GroupID{group.ID},
+				DstIA:    dst,
+				Visited:  []addr.IA{reg3},
+			},
+			lookuper: func(c *gomock.Controller) hiddenpath.Lookuper {
+				l := mock_hiddenpath.NewMockLookuper(c)
+				l.EXPECT().Segments(gomock.Any(), gomock.Any()).Return(nil, nil)
+				return l
+			},
+			rpc: func(c *gomock.Controller) hiddenpath.RPC {
+				r := mock_hiddenpath.NewMockRPC(c)
+				r.EXPECT().HiddenSegments(gomock.Any(), hiddenpath.SegmentRequest{
+					GroupIDs: []hiddenpath.GroupID{group.ID},
+					DstIA:    dst,
+					Visited:  []addr.IA{reg3, local, reg2},
+				}, addrs[reg2]).Return([]*seg.Meta{seg1}, nil)
+				return r
+			},
+			verifier: func(c *gomock.Controller) hiddenpath.Verifier {
+				v := mock_hiddenpath.NewMockVerifier(c)
+				v.EXPECT().Verify(gomock.Any(), []*seg.Meta{seg1}, addrs[reg2]).Return(nil)
+				return v
+			},
+			want:      []*seg.Meta{seg1},
+			assertErr: assert.NoError,
+		},
+		"all registries visited": {
+			request: hiddenpath.SegmentRequest{
+				GroupIDs: []hiddenpath.GroupID{group.ID},
+				DstIA:    dst,
+				Visited:  []addr.IA{reg2, reg3},
+			},
+			lookuper: func(c *gomock.Controller) hiddenpath.Lookuper {
+				l := mock_hiddenpath.NewMockLookuper(c)
+				l.EXPECT().Segments(gomock.Any(), gomock.Any()).Return(nil, nil)
+				return l
+			},
+			rpc: func(c *gomock.Controller) hiddenpath.RPC {
+				return mock_hiddenpath.NewMockRPC(c)
+			},
+			verifier: func(c *gomock.Controller) hiddenpath.Verifier {
+				return mock_hiddenpath.NewMockVerifier(c)
+			},
+			assertErr: assert.NoError,
+		},
+		"duplicate segments": {
+			request: hiddenpath.SegmentRequest{
+				GroupIDs: []hiddenpath.GroupID{group.ID},
+				DstIA:    dst,
+			},
+			lookuper: func(c *gomock.Controller) hiddenpath.Lookuper {
+				l := mock_hiddenpath.NewMockLookuper(c)
+				l.EXPECT().Segments(gomock.Any(), gomock.Any()).Return(nil, nil)
+				return l
+			},
+			rpc: func(c *gomock.Controller) hiddenpath.RPC {
+				r := mock_hiddenpath.NewMockRPC(c)
+				r.EXPECT().HiddenSegments(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*seg.Meta{seg1}, nil).Times(2)
+				return r
+			},
+			verifier: func(c *gomock.Controller) hiddenpath.Verifier {
+				v := mock_hiddenpath.NewMockVerifier(c)
+				v.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(2)
+				return v
+			},
+			want:      []*seg.Meta{seg1},
+			assertErr: assert.NoError,
+		},
+		"partial failure": {
+			request: hiddenpath.SegmentRequest{
+				GroupIDs: []hiddenpath.GroupID{group.ID},
+				DstIA:    dst,
+			},
+			lookuper: func(c *gomock.Controller) hiddenpath.Lookuper {
+				l := mock_hiddenpath.NewMockLookuper(c)
+				l.EXPECT().Segments(gomock.Any(), gomock.Any()).Return(nil, nil)
+				return l
+			},
+			rpc: func(c *gomock.Controller) hiddenpath.RPC {
+				r := mock_hiddenpath.NewMockRPC(c)
+				r.EXPECT().HiddenSegments(gomock.Any(), gomock.Any(), addrs[reg2]).
+					Return(nil, serrors.New("unavailable"))
+				r.EXPECT().HiddenSegments(gomock.Any(), gomock.Any(), addrs[reg3]).
+					Return([]*seg.Meta{seg2}, nil)
+				return r
+			},
+			verifier: func(c *gomock.Controller) hiddenpath.Verifier {
+				v := mock_hiddenpath.NewMockVerifier(c)
+				v.EXPECT().Verify(gomock.Any(), gomock.Any(), addrs[reg3]).Return(nil)
+				return v
+			},
+			want:      []*seg.Meta{seg2},
+			assertErr: assert.NoError,
+		},
+		"verification failure": {
+			request: hiddenpath.SegmentRequest{
+				GroupIDs: []hiddenpath.GroupID{group.ID},
+				DstIA:    dst,
+				Visited:  []addr.IA{reg3},
+			},
+			lookuper: func(c *gomock.Controller) hiddenpath.Lookuper {
+				l := mock_hiddenpath.NewMockLookuper(c)
+				l.EXPECT().Segments(gomock.Any(), gomock.Any()).Return(nil, nil)
+				return l
+			},
+			rpc: func(c *gomock.Controller) hiddenpath.RPC {
+				r := mock_hiddenpath.NewMockRPC(c)
+				r.EXPECT().HiddenSegments(gomock.Any(), gomock.Any(), addrs[reg2]).
+					Return([]*seg.Meta{seg1}, nil)
+				return r
+			},
+			verifier: func(c *gomock.Controller) hiddenpath.Verifier {
+				v := mock_hiddenpath.NewMockVerifier(c)
+				v.EXPECT().Verify(gomock.Any(), gomock.Any(), addrs[reg2]).
+					Return(serrors.New("invalid signature"))
+				return v
+			},
+			assertErr: assert.Error,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := hiddenpath.FederatedServer{
+				Local:    tc.lookuper(ctrl),
+				Groups:   groups,
+				LocalIA:  local,
+				RPC:      tc.rpc(ctrl),
+				Resolver: newFederationResolver(ctrl, addrs),
+				Verifier: tc.verifier(ctrl),
+			}
+			got, err := server.Segments(context.Background(), tc.request)
+			tc.assertErr(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFederatedServerCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	local := xtest.MustParseIA("1-ff00:0:110")
+	remote := xtest.MustParseIA("1-ff00:0:111")
+	addrs := map[addr.IA]net.Addr{
+		remote: &net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 30252},
+	}
+	group := &hiddenpath.Group{
+		ID:         hiddenpath.GroupID{OwnerAS: local.AS(), Suffix: 1},
+		Owner:      local,
+		Registries: map[addr.IA]struct{}{local: {}, remote: {}},
+	}
+	req := hiddenpath.SegmentRequest{
+		GroupIDs: []hiddenpath.GroupID{group.ID},
+		DstIA:    xtest.MustParseIA("1-ff00:0:120"),
+	}
+	want := []*seg.Meta{createFederationSeg(t, 1)}
+
+	lookuper := mock_hiddenpath.NewMockLookuper(ctrl)
+	lookuper.EXPECT().Segments(gomock.Any(), req).Return(nil, nil).Times(3)
+	rpc := mock_hiddenpath.NewMockRPC(ctrl)
+	rpc.EXPECT().HiddenSegments(gomock.Any(), gomock.Any(), addrs[remote]).
+		Return(want, nil).Times(2)
+	verifier := mock_hiddenpath.NewMockVerifier(ctrl)
+	verifier.EXPECT().Verify(gomock.Any(), want, addrs[remote]).Return(nil).Times(2)
+
+	clk := clock.NewManual(time.Now())
+	server := hiddenpath.FederatedServer{
+		Local:    lookuper,
+		Groups:   map[hiddenpath.GroupID]*hiddenpath.Group{group.ID: group},
+		LocalIA:  local,
+		RPC:      rpc,
+		Resolver: newFederationResolver(ctrl, addrs),
+		Verifier: verifier,
+		Cache: &hiddenpath.FederationCache{
+			TTL:   time.Minute,
+			Clock: clk,
+		},
+	}
+
+	// The first lookup is forwarded, the second one is served from the cache.
+	for i := 0; i < 2; i++ {
+		got, err := server.Segments(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	// After the TTL, the lookup is forwarded again.
+	clk.Advance(time.Minute)
+	got, err := server.Segments(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func newFederationResolver(ctrl *gomock.Controller,
+	addrs map[addr.IA]net.Addr) hiddenpath.AddressResolver {
+
+	r := mock_hiddenpath.NewMockAddressResolver(ctrl)
+	r.EXPECT().Resolve(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, ia addr.IA) (net.Addr, error) {
+			a, ok := addrs[ia]
+			if !ok {
+				return nil, serrors.New("unknown registry", "isd_as", ia)
+			}
+			return a, nil
+		},
+	).AnyTimes()
+	return r
+}
+
+func createFederationSeg(t *testing.T, id uint16) *seg.Meta {
+	t.Helper()
+	ps, err := seg.CreateSegment(time.Now(), id)
+	require.NoError(t, err)
+	require.NoError(t, ps.AddASEntry(context.Background(), seg.ASEntry{
+		Local: xtest.MustParseIA("1-ff00:0:120"),
+		HopEntry: seg.HopEntry{
+			HopField: seg.HopField{
+				ExpTime: 63,
+				MAC:     [path.MacLen]byte{0x11, 0x11, 0x11, 0x11, 0x11, 0x11},
+			},
+		},
+	}, graph.NewSigner()))
+	return &seg.Meta{Type: seg.TypeDown, Segment: ps}
+}
@@ -18,12 +18,15 @@ import (
 	"context"
 	"net"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/segment/segverifier"
 	infra "github.com/scionproto/scion/private/segment/verifier"
+	"github.com/scionproto/scion/private/tracing"
 )
 
 // Lookuper is used to lookup segments.
@@ -93,6 +96,10 @@ func (s ForwardServer) Segments(ctx context.Context,
 	for registry, groups := range requests {
 		go func(r addr.IA, g []GroupID) {
 			defer log.HandlePanic()
+			ctx, span := tracing.StartOTelSpan(ctx, "hiddenpath.forward",
+				attribute.String("registry", r.String()),
+			)
+			defer span.End()
 
 			req := SegmentRequest{
 				GroupIDs: g,
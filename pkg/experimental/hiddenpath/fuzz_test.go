@@ -0,0 +1,58 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+)
+
+// To fuzz, run:
+//
+//	go test -run '^$' -fuzz FuzzGroupsUnmarshalYAML ./pkg/experimental/hiddenpath
+
+func FuzzGroupsUnmarshalYAML(f *testing.F) {
+	for _, pattern := range []string{"testdata/groups*.yml", "testdata/groups.d/*"} {
+		files, err := filepath.Glob(pattern)
+		require.NoError(f, err)
+		for _, file := range files {
+			raw, err := os.ReadFile(file)
+			require.NoError(f, err)
+			f.Add(raw)
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		groups := make(hiddenpath.Groups)
+		if err := yaml.Unmarshal(data, &groups); err != nil {
+			return
+		}
+		if err := groups.Validate(); err != nil || len(groups) == 0 {
+			return
+		}
+		// Valid groups must survive a round trip.
+		raw, err := yaml.Marshal(groups)
+		require.NoError(t, err)
+		parsed := make(hiddenpath.Groups)
+		require.NoError(t, yaml.Unmarshal(raw, &parsed))
+		assert.Equal(t, groups, parsed)
+	})
+}
@@ -16,6 +16,10 @@ package hiddenpath
 
 import (
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -50,6 +54,11 @@ func (id GroupID) String() string {
 	return fmt.Sprintf("%s-%x", id.OwnerAS, id.Suffix)
 }
 
+// MarshalText implements encoding.TextMarshaler.
+func (id GroupID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
 // ParseGroupID parses the string representation of the group ID.
 func ParseGroupID(s string) (GroupID, error) {
 	v := strings.Replace(s, "_", ":", 2)
@@ -159,7 +168,7 @@ func (g Groups) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if len(yg.Groups) == 0 {
 		return nil
 	}
-	groups, err := parseGroups(yg.Groups)
+	groups, err := parseGroups(yg.Groups, yg.Templates)
 	if err != nil {
 		return err
 	}
@@ -176,24 +185,34 @@ func (g Groups) MarshalYAML() (interface{}, error) {
 	}, nil
 }
 
-// LoadHiddenPathGroups loads the hiddenpath groups configuration file.
+// LoadHiddenPathGroups loads the hiddenpath groups configuration. The location
+// can be a single file, a directory, in which case all YAML files in the
+// directory are loaded, or a glob pattern. If the location begins with http://
+// or https:// the configuration is fetched via HTTP. Each file can reference
+// further files with the include directive. The groups of all files are merged,
+// a group ID that is defined more than once results in an error.
 func LoadHiddenPathGroups(location string) (Groups, error) {
-	ret := make(Groups)
 	if location == "" {
 		return nil, nil
 	}
-	c, err := config.LoadResource(location)
+	files, err := expandGroupsLocation(location)
 	if err != nil {
 		return nil, serrors.WithCtx(err, "location", location)
 	}
-	defer c.Close()
-	if err := yaml.NewDecoder(c).Decode(&ret); err != nil {
-		return nil, serrors.WrapStr("parsing", err, "location", location)
+	l := groupsLoader{
+		groups:  make(Groups),
+		sources: make(map[GroupID]string),
+		loaded:  make(map[string]struct{}),
+	}
+	for _, file := range files {
+		if err := l.load(file); err != nil {
+			return nil, err
+		}
 	}
-	if err := ret.Validate(); err != nil {
-		return nil, serrors.WrapStr("validating", err, "file", c)
+	if err := l.groups.Validate(); err != nil {
+		return nil, serrors.WrapStr("validating", err, "location", location)
 	}
-	return ret, nil
+	return l.groups, nil
 }
 
 // Roles returns the roles the given ISD-AS has in this set of groups.
@@ -212,20 +231,145 @@ func (g Groups) Roles(ia addr.IA) Roles {
 	return r
 }
 
+// ReaderGroups returns the groups in which the given ISD-AS is a reader.
+func (g Groups) ReaderGroups(ia addr.IA) Groups {
+	result := make(Groups)
+	for id, group := range g {
+		if _, ok := group.Readers[ia]; ok {
+			result[id] = group
+		}
+	}
+	return result
+}
+
+// WriterGroupIDs returns the IDs of the groups in which the given ISD-AS is a
+// writer, in ascending order. A group that is contained in multiple sets is
+// only returned once.
+func WriterGroupIDs(ia addr.IA, sets ...Groups) []GroupID {
+	seen := make(map[GroupID]struct{})
+	var ids []GroupID
+	for _, groups := range sets {
+		for id, group := range groups {
+			if _, ok := group.Writers[ia]; !ok {
+				continue
+			}
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+	sortGroupIDs(ids)
+	return ids
+}
+
+// Membership describes the access of an AS to the hidden paths of a set of
+// groups.
+type Membership struct {
+	// IA is the AS the membership belongs to.
+	IA addr.IA
+	// Readers contains the IDs of the groups in which the AS is a reader, in
+	// ascending order.
+	Readers []GroupID
+	// Writers contains the IDs of the groups in which the AS is a writer, in
+	// ascending order.
+	Writers []GroupID
+	// Registries contains the registries of the groups in which the AS is a
+	// reader or a writer, in ascending order. These are the registries the AS
+	// queries or registers hidden paths at.
+	Registries []addr.IA
+}
+
+// Memberships returns the membership of every AS that is a reader or a writer
+// in any of the groups, in ascending order of the ASes.
+func (g Groups) Memberships() []Membership {
+	byIA := make(map[addr.IA]*Membership)
+	registries := make(map[addr.IA]map[addr.IA]struct{})
+	get := func(ia addr.IA) *Membership {
+		m, ok := byIA[ia]
+		if !ok {
+			m = &Membership{IA: ia}
+			byIA[ia] = m
+			registries[ia] = make(map[addr.IA]struct{})
+		}
+		return m
+	}
+	for id, group := range g {
+		for ia := range group.Readers {
+			m := get(ia)
+			m.Readers = append(m.Readers, id)
+			for r := range group.Registries {
+				registries[ia][r] = struct{}{}
+			}
+		}
+		for ia := range group.Writers {
+			m := get(ia)
+			m.Writers = append(m.Writers, id)
+			for r := range group.Registries {
+				registries[ia][r] = struct{}{}
+			}
+		}
+	}
+	result := make([]Membership, 0, len(byIA))
+	for ia, m := range byIA {
+		sortGroupIDs(m.Readers)
+		sortGroupIDs(m.Writers)
+		for r := range registries[ia] {
+			m.Registries = append(m.Registries, r)
+		}
+		sort.Slice(m.Registries, func(i, j int) bool {
+			return m.Registries[i] < m.Registries[j]
+		})
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].IA < result[j].IA
+	})
+	return result
+}
+
+func sortGroupIDs(ids []GroupID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].ToUint64() < ids[j].ToUint64()
+	})
+}
+
 type groupInfo struct {
 	Owner      string   `yaml:"owner,omitempty"`
+	Extends    []string `yaml:"extends,omitempty"`
 	Writers    []string `yaml:"writers,omitempty"`
 	Readers    []string `yaml:"readers,omitempty"`
 	Registries []string `yaml:"registries,omitempty"`
 }
 
-func parseGroups(groups map[string]*groupInfo) (Groups, error) {
+// groupTemplate is a set of writers, readers and registries that can be
+// referenced by multiple groups with the extends directive.
+type groupTemplate struct {
+	Writers    []string `yaml:"writers,omitempty"`
+	Readers    []string `yaml:"readers,omitempty"`
+	Registries []string `yaml:"registries,omitempty"`
+}
+
+// parseGroups parses the raw groups. The templates a group extends are expanded,
+// i.e., the writers, readers and registries of the templates are added to the
+// ones of the group.
+func parseGroups(groups map[string]*groupInfo,
+	templates map[string]*groupTemplate) (Groups, error) {
+
 	result := make(Groups)
 	for rawID, rawGroup := range groups {
 		id, err := ParseGroupID(rawID)
 		if err != nil {
 			return nil, serrors.WrapStr("parsing group ID", err)
 		}
+		if rawGroup == nil {
+			return nil, serrors.New("empty group definition", "group_id", id)
+		}
+		rawGroup, err = expandTemplates(rawGroup, templates)
+		if err != nil {
+			return nil, serrors.WrapStr("expanding templates", err, "group_id", id)
+		}
 		owner, err := addr.ParseIA(rawGroup.Owner)
 		if err != nil {
 			return nil, serrors.WrapStr("parsing owner", err, "group_id", id)
@@ -253,6 +397,28 @@ func parseGroups(groups map[string]*groupInfo) (Groups, error) {
 	return result, nil
 }
 
+func expandTemplates(group *groupInfo, templates map[string]*groupTemplate) (*groupInfo, error) {
+	if len(group.Extends) == 0 {
+		return group, nil
+	}
+	expanded := &groupInfo{
+		Owner:      group.Owner,
+		Writers:    append([]string(nil), group.Writers...),
+		Readers:    append([]string(nil), group.Readers...),
+		Registries: append([]string(nil), group.Registries...),
+	}
+	for _, name := range group.Extends {
+		tmpl, ok := templates[name]
+		if !ok || tmpl == nil {
+			return nil, serrors.New("referring to unknown template", "template", name)
+		}
+		expanded.Writers = append(expanded.Writers, tmpl.Writers...)
+		expanded.Readers = append(expanded.Readers, tmpl.Readers...)
+		expanded.Registries = append(expanded.Registries, tmpl.Registries...)
+	}
+	return expanded, nil
+}
+
 func marshalGroups(groups Groups) map[string]*groupInfo {
 	result := make(map[string]*groupInfo, len(groups))
 	for id, group := range groups {
@@ -287,3 +453,142 @@ func stringsToIASet(rawIAs []string) (map[addr.IA]struct{}, error) {
 	}
 	return result, nil
 }
+
+// groupsFileInfo is the YAML representation of a hidden path groups file.
+type groupsFileInfo struct {
+	Include   []string                  `yaml:"include,omitempty"`
+	Templates map[string]*groupTemplate `yaml:"templates,omitempty"`
+	Groups    map[string]*groupInfo     `yaml:"groups,omitempty"`
+}
+
+// groupsLoader loads hidden path groups from multiple files and merges them.
+type groupsLoader struct {
+	groups Groups
+	// sources keeps track of the file that defined a group.
+	sources map[GroupID]string
+	// loaded contains the files that have already been loaded. Files that are
+	// referenced multiple times are only loaded once.
+	loaded map[string]struct{}
+}
+
+func (l *groupsLoader) load(location string) error {
+	if _, ok := l.loaded[location]; ok {
+		return nil
+	}
+	l.loaded[location] = struct{}{}
+
+	c, err := config.LoadResource(location)
+	if err != nil {
+		return serrors.WithCtx(err, "location", location)
+	}
+	defer c.Close()
+	info := groupsFileInfo{}
+	if err := yaml.NewDecoder(c).Decode(&info); err != nil && err != io.EOF {
+		return serrors.WrapStr("parsing", err, "location", location)
+	}
+	groups, err := parseGroups(info.Groups, info.Templates)
+	if err != nil {
+		return serrors.WrapStr("parsing groups", err, "location", location)
+	}
+	for id, group := range groups {
+		if other, ok := l.sources[id]; ok {
+			return serrors.New("duplicate group ID", "group_id", id,
+				"location", location, "previous_location", other)
+		}
+		l.groups[id] = group
+		l.sources[id] = location
+	}
+	for _, include := range info.Include {
+		resolved, err := resolveInclude(location, include)
+		if err != nil {
+			return serrors.WrapStr("resolving include", err,
+				"location", location, "include", include)
+		}
+		files, err := expandGroupsLocation(resolved)
+		if err != nil {
+			return serrors.WrapStr("expanding include", err,
+				"location", location, "include", include)
+		}
+		for _, file := range files {
+			if err := l.load(file); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expandGroupsLocation expands the location to the list of files that should be
+// loaded. Directories are expanded to the YAML files they contain and glob
+// patterns to the matching files, in lexical order.
+func expandGroupsLocation(location string) ([]string, error) {
+	if isURL(location) {
+		return []string{location}, nil
+	}
+	if strings.ContainsAny(location, "*?[") {
+		matches, err := filepath.Glob(location)
+		if err != nil {
+			return nil, serrors.WrapStr("expanding glob", err)
+		}
+		if len(matches) == 0 {
+			return nil, serrors.New("glob does not match any file")
+		}
+		return cleanPaths(matches), nil
+	}
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, serrors.WrapStr("loading config from disk", err)
+	}
+	if !info.IsDir() {
+		return cleanPaths([]string{location}), nil
+	}
+	entries, err := os.ReadDir(location)
+	if err != nil {
+		return nil, serrors.WrapStr("reading directory", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		files = append(files, filepath.Join(location, entry.Name()))
+	}
+	return cleanPaths(files), nil
+}
+
+// resolveInclude resolves an include relative to the location of the file that
+// contains the include directive.
+func resolveInclude(location, include string) (string, error) {
+	if isURL(include) {
+		return include, nil
+	}
+	if isURL(location) {
+		base, err := url.Parse(location)
+		if err != nil {
+			return "", err
+		}
+		ref, err := url.Parse(include)
+		if err != nil {
+			return "", err
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+	if filepath.IsAbs(include) {
+		return include, nil
+	}
+	return filepath.Join(filepath.Dir(location), include), nil
+}
+
+func cleanPaths(paths []string) []string {
+	result := make([]string, 0, len(paths))
+	for _, p := range paths {
+		result = append(result, filepath.Clean(p))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
@@ -117,6 +117,115 @@ func TestNewGroup(t *testing.T) {
 	}
 }
 
+func TestLoadHiddenPathGroupsMultipleFiles(t *testing.T) {
+	want := hiddenpath.Groups{
+		hiddenpath.GroupID{
+			OwnerAS: xtest.MustParseAS("ff00:0:110"),
+			Suffix:  0x69b5,
+		}: {
+			ID: hiddenpath.GroupID{
+				OwnerAS: xtest.MustParseAS("ff00:0:110"),
+				Suffix:  0x69b5,
+			},
+			Owner: xtest.MustParseIA("1-ff00:0:110"),
+			Writers: map[addr.IA]struct{}{
+				xtest.MustParseIA("1-ff00:0:111"): {},
+				xtest.MustParseIA("1-ff00:0:112"): {},
+			},
+			Readers: map[addr.IA]struct{}{
+				xtest.MustParseIA("1-ff00:0:114"): {},
+			},
+			Registries: map[addr.IA]struct{}{
+				xtest.MustParseIA("1-ff00:0:111"): {},
+				xtest.MustParseIA("1-ff00:0:113"): {},
+			},
+		},
+		hiddenpath.GroupID{
+			OwnerAS: xtest.MustParseAS("ff00:0:222"),
+			Suffix:  0xabcd,
+		}: {
+			ID: hiddenpath.GroupID{
+				OwnerAS: xtest.MustParseAS("ff00:0:222"),
+				Suffix:  0xabcd,
+			},
+			Owner: xtest.MustParseIA("1-ff00:0:222"),
+			Writers: map[addr.IA]struct{}{
+				xtest.MustParseIA("1-ff00:0:111"): {},
+				xtest.MustParseIA("1-ff00:0:112"): {},
+			},
+			Readers: map[addr.IA]struct{}{
+				xtest.MustParseIA("1-ff00:0:114"): {},
+			},
+			Registries: map[addr.IA]struct{}{
+				xtest.MustParseIA("1-ff00:0:115"): {},
+			},
+		},
+		hiddenpath.GroupID{
+			OwnerAS: xtest.MustParseAS("ff00:0:333"),
+			Suffix:  0x1,
+		}: {
+			ID: hiddenpath.GroupID{
+				OwnerAS: xtest.MustParseAS("ff00:0:333"),
+				Suffix:  0x1,
+			},
+			Owner: xtest.MustParseIA("1-ff00:0:333"),
+			Writers: map[addr.IA]struct{}{
+				xtest.MustParseIA("1-ff00:0:111"): {},
+			},
+			Readers: map[addr.IA]struct{}{},
+			Registries: map[addr.IA]struct{}{
+				xtest.MustParseIA("1-ff00:0:333"): {},
+			},
+		},
+	}
+	testcases := map[string]struct {
+		input       string
+		want        hiddenpath.Groups
+		assertError assert.ErrorAssertionFunc
+	}{
+		"directory": {
+			input:       "./testdata/groups.d",
+			want:        want,
+			assertError: assert.NoError,
+		},
+		"glob": {
+			input:       "./testdata/groups.d/tenant*",
+			want:        want,
+			assertError: assert.NoError,
+		},
+		"glob without match": {
+			input:       "./testdata/groups.d/none*",
+			assertError: assert.Error,
+		},
+		"duplicate group ID": {
+			input:       "./testdata/groups_duplicate.yml",
+			assertError: assert.Error,
+		},
+	}
+	for name, tc := range testcases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := hiddenpath.LoadHiddenPathGroups(tc.input)
+			tc.assertError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLoadHiddenPathGroupsTemplates(t *testing.T) {
+	want, err := hiddenpath.LoadHiddenPathGroups("./testdata/groups.yml")
+	require.NoError(t, err)
+
+	got, err := hiddenpath.LoadHiddenPathGroups("./testdata/groups_templates.yml")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = hiddenpath.LoadHiddenPathGroups("./testdata/groups_unknown_template.yml")
+	assert.Error(t, err)
+}
+
 func TestGroupValidate(t *testing.T) {
 	testcases := map[string]struct {
 		input       *hiddenpath.Group
@@ -196,3 +305,83 @@ func TestGroupValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupsReaderAndWriterGroups(t *testing.T) {
+	id1 := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110"), Suffix: 2}
+	id2 := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110"), Suffix: 1}
+	id3 := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:120"), Suffix: 1}
+	reader := xtest.MustParseIA("1-ff00:0:113")
+	writer := xtest.MustParseIA("1-ff00:0:111")
+	configured := hiddenpath.Groups{
+		id1: {
+			ID:      id1,
+			Writers: map[addr.IA]struct{}{writer: {}},
+			Readers: map[addr.IA]struct{}{reader: {}},
+		},
+		id2: {
+			ID:      id2,
+			Writers: map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:112"): {}},
+			Readers: map[addr.IA]struct{}{reader: {}},
+		},
+	}
+	learned := hiddenpath.Groups{
+		id1: configured[id1],
+		id3: {
+			ID:      id3,
+			Writers: map[addr.IA]struct{}{writer: {}},
+		},
+	}
+
+	assert.Equal(t, configured, configured.ReaderGroups(reader))
+	assert.Empty(t, configured.ReaderGroups(writer))
+	assert.Equal(t, []hiddenpath.GroupID{id1, id3},
+		hiddenpath.WriterGroupIDs(writer, configured, learned))
+	assert.Equal(t, []hiddenpath.GroupID{id2},
+		hiddenpath.WriterGroupIDs(xtest.MustParseIA("1-ff00:0:112"), configured, learned))
+	assert.Empty(t, hiddenpath.WriterGroupIDs(reader, configured, learned))
+}
+
+func TestGroupsMemberships(t *testing.T) {
+	id1 := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110"), Suffix: 2}
+	id2 := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110"), Suffix: 1}
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	ia113 := xtest.MustParseIA("1-ff00:0:113")
+	reg1 := xtest.MustParseIA("1-ff00:0:120")
+	reg2 := xtest.MustParseIA("1-ff00:0:121")
+	groups := hiddenpath.Groups{
+		id1: {
+			ID:         id1,
+			Writers:    map[addr.IA]struct{}{ia111: {}},
+			Readers:    map[addr.IA]struct{}{ia113: {}, ia112: {}},
+			Registries: map[addr.IA]struct{}{reg1: {}},
+		},
+		id2: {
+			ID:         id2,
+			Writers:    map[addr.IA]struct{}{ia112: {}},
+			Readers:    map[addr.IA]struct{}{ia113: {}},
+			Registries: map[addr.IA]struct{}{reg2: {}, reg1: {}},
+		},
+	}
+
+	expected := []hiddenpath.Membership{
+		{
+			IA:         ia111,
+			Writers:    []hiddenpath.GroupID{id1},
+			Registries: []addr.IA{reg1},
+		},
+		{
+			IA:         ia112,
+			Readers:    []hiddenpath.GroupID{id1},
+			Writers:    []hiddenpath.GroupID{id2},
+			Registries: []addr.IA{reg1, reg2},
+		},
+		{
+			IA:         ia113,
+			Readers:    []hiddenpath.GroupID{id2, id1},
+			Registries: []addr.IA{reg1, reg2},
+		},
+	}
+	assert.Equal(t, expected, groups.Memberships())
+	assert.Empty(t, hiddenpath.Groups{}.Memberships())
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// GroupFetcher fetches the hidden path groups in which the local AS is a
+// reader.
+type GroupFetcher interface {
+	ReaderGroups(ctx context.Context, server net.Addr) (Groups, error)
+}
+
+// LearnedGroups holds the hidden path groups that are learned at runtime, as
+// opposed to the groups that are configured locally. It is safe for concurrent
+// use. The zero value is ready to use, and a nil LearnedGroups contains no
+// groups.
+type LearnedGroups struct {
+	mu     sync.RWMutex
+	groups Groups
+}
+
+// Groups returns the currently known groups. The returned value must not be
+// modified.
+func (l *LearnedGroups) Groups() Groups {
+	if l == nil {
+		return nil
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.groups
+}
+
+// Set replaces the currently known groups.
+func (l *LearnedGroups) Set(groups Groups) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.groups = groups
+}
+
+// GroupSyncer periodically fetches the hidden path groups in which the local
+// AS is a reader from the control service and stores them in the learned
+// groups. If fetching fails, the previously learned groups are kept.
+type GroupSyncer struct {
+	// Fetcher fetches the groups.
+	Fetcher GroupFetcher
+	// Server is the address of the control service.
+	Server net.Addr
+	// Groups is updated with the fetched groups.
+	Groups *LearnedGroups
+}
+
+// Name returns the task name.
+func (s GroupSyncer) Name() string {
+	return "hiddenpath_group_syncer"
+}
+
+// Run fetches the groups once.
+func (s GroupSyncer) Run(ctx context.Context) {
+	groups, err := s.Fetcher.ReaderGroups(ctx, s.Server)
+	if err != nil {
+		log.FromCtx(ctx).Info("Failed to fetch hidden path groups", "err", err)
+		return
+	}
+	s.Groups.Set(groups)
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath/mock_hiddenpath"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+func TestGroupSyncerRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	id := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:110"), Suffix: 1}
+	groups := hiddenpath.Groups{
+		id: {
+			ID:      id,
+			Writers: map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:111"): {}},
+		},
+	}
+	server := &snet.SVCAddr{SVC: addr.SvcCS}
+	fetcher := mock_hiddenpath.NewMockGroupFetcher(ctrl)
+	learned := &hiddenpath.LearnedGroups{}
+	syncer := hiddenpath.GroupSyncer{
+		Fetcher: fetcher,
+		Server:  server,
+		Groups:  learned,
+	}
+
+	fetcher.EXPECT().ReaderGroups(gomock.Any(), server).Return(groups, nil)
+	syncer.Run(context.Background())
+	assert.Equal(t, groups, learned.Groups())
+
+	// A failed fetch keeps the previously learned groups.
+	fetcher.EXPECT().ReaderGroups(gomock.Any(), server).Return(nil, serrors.New("test"))
+	syncer.Run(context.Background())
+	assert.Equal(t, groups, learned.Groups())
+
+	fetcher.EXPECT().ReaderGroups(gomock.Any(), server).Return(hiddenpath.Groups{}, nil)
+	syncer.Run(context.Background())
+	assert.Empty(t, learned.Groups())
+}
+
+func TestLearnedGroupsNil(t *testing.T) {
+	var learned *hiddenpath.LearnedGroups
+	assert.Nil(t, learned.Groups())
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"sort"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	hspb "github.com/scionproto/scion/pkg/proto/hidden_segment"
+)
+
+// GroupsServer serves the hidden path groups in which the local AS is a
+// reader.
+type GroupsServer struct {
+	// Groups are the configured hidden path groups.
+	Groups hiddenpath.Groups
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+}
+
+// ReaderGroups returns the groups in which the local AS is a reader.
+func (s GroupsServer) ReaderGroups(ctx context.Context,
+	_ *hspb.ReaderGroupsRequest) (*hspb.ReaderGroupsResponse, error) {
+
+	groups := s.Groups.ReaderGroups(s.LocalIA)
+	reply := &hspb.ReaderGroupsResponse{
+		Groups: make([]*hspb.HiddenPathGroup, 0, len(groups)),
+	}
+	for _, id := range sortedGroupIDs(groups) {
+		group := &hspb.HiddenPathGroup{Id: id.ToUint64()}
+		for writer := range groups[id].Writers {
+			group.Writers = append(group.Writers, uint64(writer))
+		}
+		sort.Slice(group.Writers, func(i, j int) bool {
+			return group.Writers[i] < group.Writers[j]
+		})
+		reply.Groups = append(reply.Groups, group)
+	}
+	return reply, nil
+}
+
+// GroupFetcher fetches the hidden path groups in which the local AS is a reader
+// from the control service.
+type GroupFetcher struct {
+	// Dialer dials a new gRPC connection.
+	Dialer libgrpc.Dialer
+}
+
+// ReaderGroups fetches the groups from the given server. The returned groups
+// only contain the ID and the writers of the group.
+func (f GroupFetcher) ReaderGroups(ctx context.Context,
+	server net.Addr) (hiddenpath.Groups, error) {
+
+	conn, err := f.Dialer.Dial(ctx, server)
+	if err != nil {
+		return nil, serrors.WrapStr("dialing", err)
+	}
+	defer conn.Close()
+	client := hspb.NewHiddenSegmentGroupsServiceClient(conn)
+	rep, err := client.ReaderGroups(ctx, &hspb.ReaderGroupsRequest{}, libgrpc.RetryProfile...)
+	if err != nil {
+		return nil, err
+	}
+	groups := make(hiddenpath.Groups, len(rep.Groups))
+	for _, pb := range rep.Groups {
+		id := hiddenpath.GroupIDFromUint64(pb.Id)
+		if id.ToUint64() == 0 {
+			return nil, serrors.New("missing group id")
+		}
+		writers := make(map[addr.IA]struct{}, len(pb.Writers))
+		for _, writer := range pb.Writers {
+			writers[addr.IA(writer)] = struct{}{}
+		}
+		groups[id] = &hiddenpath.Group{
+			ID:      id,
+			Writers: writers,
+		}
+	}
+	return groups, nil
+}
+
+func sortedGroupIDs(groups hiddenpath.Groups) []hiddenpath.GroupID {
+	ids := make([]hiddenpath.GroupID, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].ToUint64() < ids[j].ToUint64()
+	})
+	return ids
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	hpgrpc "github.com/scionproto/scion/pkg/experimental/hiddenpath/grpc"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	hspb "github.com/scionproto/scion/pkg/proto/hidden_segment"
+	"github.com/scionproto/scion/pkg/proto/hidden_segment/mock_hidden_segment"
+)
+
+func TestGroupsServerReaderGroups(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:113")
+	id1 := mustParseGroupID(t, "ff00:0:110-2")
+	id2 := mustParseGroupID(t, "ff00:0:110-1")
+	s := hpgrpc.GroupsServer{
+		Groups: hiddenpath.Groups{
+			id1: {
+				ID: id1,
+				Writers: map[addr.IA]struct{}{
+					xtest.MustParseIA("1-ff00:0:112"): {},
+					xtest.MustParseIA("1-ff00:0:111"): {},
+				},
+				Readers: map[addr.IA]struct{}{local: {}},
+			},
+			id2: {
+				ID:      id2,
+				Writers: map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:111"): {}},
+				Readers: map[addr.IA]struct{}{xtest.MustParseIA("1-ff00:0:114"): {}},
+			},
+		},
+		LocalIA: local,
+	}
+	got, err := s.ReaderGroups(context.Background(), &hspb.ReaderGroupsRequest{})
+	require.NoError(t, err)
+	want := []*hspb.HiddenPathGroup{
+		{
+			Id: id1.ToUint64(),
+			Writers: []uint64{
+				uint64(xtest.MustParseIA("1-ff00:0:111")),
+				uint64(xtest.MustParseIA("1-ff00:0:112")),
+			},
+		},
+	}
+	assert.Equal(t, want, got.Groups)
+}
+
+func TestGroupFetcherReaderGroups(t *testing.T) {
+	id := mustParseGroupID(t, "ff00:0:110-2")
+	writer := xtest.MustParseIA("1-ff00:0:111")
+	testCases := map[string]struct {
+		reply       *hspb.ReaderGroupsResponse
+		want        hiddenpath.Groups
+		assertError assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			reply: &hspb.ReaderGroupsResponse{
+				Groups: []*hspb.HiddenPathGroup{
+					{Id: id.ToUint64(), Writers: []uint64{uint64(writer)}},
+				},
+			},
+			want: hiddenpath.Groups{
+				id: {
+					ID:      id,
+					Writers: map[addr.IA]struct{}{writer: {}},
+				},
+			},
+			assertError: assert.NoError,
+		},
+		"empty": {
+			reply:       &hspb.ReaderGroupsResponse{},
+			want:        hiddenpath.Groups{},
+			assertError: assert.NoError,
+		},
+		"missing id": {
+			reply: &hspb.ReaderGroupsResponse{
+				Groups: []*hspb.HiddenPathGroup{{Writers: []uint64{uint64(writer)}}},
+			},
+			assertError: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := mock_hidden_segment.NewMockHiddenSegmentGroupsServiceServer(ctrl)
+			server.EXPECT().ReaderGroups(gomock.Any(), gomock.Any()).Return(tc.reply, nil)
+			svc := xtest.NewGRPCService()
+			hspb.RegisterHiddenSegmentGroupsServiceServer(svc.Server(), server)
+			svc.Start(t)
+
+			f := hpgrpc.GroupFetcher{Dialer: svc}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			got, err := f.ReaderGroups(ctx, &net.UDPAddr{})
+			tc.assertError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
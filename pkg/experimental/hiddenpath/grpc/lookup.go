@@ -46,9 +46,8 @@ func (s *SegmentServer) HiddenSegments(ctx context.Context,
 	req := fromHSPB(pbReq)
 	reply, err := s.Lookup.Segments(ctx, req)
 	if err != nil {
-		// TODO(lukedirtwalker): determine the proper error code here.
 		logger.Debug("Failed to look up segments", "err", err)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorToStatus(err)
 	}
 
 	return &hspb.HiddenSegmentsResponse{
@@ -92,9 +91,8 @@ func (s AuthoritativeSegmentServer) AuthoritativeHiddenSegments(ctx context.Cont
 	req.Peer = peerIA
 	reply, err := s.Lookup.Segments(ctx, req)
 	if err != nil {
-		// TODO(lukedirtwalker): determine the proper error code here.
 		logger.Debug("Failed to look up segments", "err", err)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorToStatus(err)
 	}
 	return &hspb.AuthoritativeHiddenSegmentsResponse{
 		Segments: toHSPB(reply),
@@ -106,9 +104,14 @@ func fromHSPB(pbReq *hspb.HiddenSegmentsRequest) hiddenpath.SegmentRequest {
 	for _, id := range pbReq.GroupIds {
 		groups = append(groups, hiddenpath.GroupIDFromUint64(id))
 	}
+	var visited []addr.IA
+	for _, ia := range pbReq.VisitedRegistries {
+		visited = append(visited, addr.IA(ia))
+	}
 	return hiddenpath.SegmentRequest{
 		GroupIDs: groups,
 		DstIA:    addr.IA(pbReq.DstIsdAs),
+		Visited:  visited,
 	}
 }
 
@@ -22,14 +22,19 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath/grpc"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath/mock_hiddenpath"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
+	edpb "github.com/scionproto/scion/pkg/proto/error_details"
 	hspb "github.com/scionproto/scion/pkg/proto/hidden_segment"
 	"github.com/scionproto/scion/pkg/scrypto/signed"
 	seg "github.com/scionproto/scion/pkg/segment"
@@ -66,6 +71,50 @@ func TestSegmentServerHiddenSegments(t *testing.T) {
 			want:      nil,
 			assertErr: assert.Error,
 		},
+		"not authorized": {
+			createCtx: func(t *testing.T) context.Context { return context.Background() },
+			lookuper: func(ctrl *gomock.Controller) hiddenpath.Lookuper {
+				ret := mock_hiddenpath.NewMockLookuper(ctrl)
+				ret.EXPECT().Segments(gomock.Any(), gomock.Any()).Return(nil,
+					&hiddenpath.GroupNotAuthorizedError{
+						Group:  mustParseGroupIDs(t, "ff00:0:22-1")[0],
+						Peer:   xtest.MustParseIA("1-ff00:0:111"),
+						Reason: hiddenpath.ReasonNotMember,
+					},
+				).Times(1)
+				return ret
+			},
+			request: &hspb.HiddenSegmentsRequest{
+				GroupIds: groupIDsToInts(mustParseGroupIDs(t, "ff00:0:22-1")),
+				DstIsdAs: mustIA("1-ff00:0:110"),
+			},
+			want: nil,
+			assertErr: assertStatusDetail(codes.PermissionDenied, &edpb.ErrorGroupNotAuthorized{
+				GroupId: mustParseGroupIDs(t, "ff00:0:22-1")[0].ToUint64(),
+				IsdAs:   mustIA("1-ff00:0:111"),
+				Reason:  edpb.ErrorGroupNotAuthorized_REASON_NOT_MEMBER,
+			}),
+		},
+		"remote error with detail": {
+			createCtx: func(t *testing.T) context.Context { return context.Background() },
+			lookuper: func(ctrl *gomock.Controller) hiddenpath.Lookuper {
+				ret := mock_hiddenpath.NewMockLookuper(ctrl)
+				remote := libgrpc.StatusError(codes.PermissionDenied, "remote",
+					&edpb.ErrorGroupNotAuthorized{GroupId: 1},
+				)
+				ret.EXPECT().Segments(gomock.Any(), gomock.Any()).Return(nil,
+					serrors.List{serrors.New("other"), serrors.WrapStr("forwarding", remote)},
+				).Times(1)
+				return ret
+			},
+			request: &hspb.HiddenSegmentsRequest{
+				GroupIds: groupIDsToInts(mustParseGroupIDs(t, "ff00:0:22-1")),
+				DstIsdAs: mustIA("1-ff00:0:110"),
+			},
+			want: nil,
+			assertErr: assertStatusDetail(codes.PermissionDenied,
+				&edpb.ErrorGroupNotAuthorized{GroupId: 1}),
+		},
 		"valid": {
 			createCtx: func(t *testing.T) context.Context { return context.Background() },
 			lookuper: func(ctrl *gomock.Controller) hiddenpath.Lookuper {
@@ -234,6 +283,48 @@ func TestAuthoritativeSegmentServerAuthoritativeHiddenSegments(t *testing.T) {
 			},
 			assertErr: assert.NoError,
 		},
+		"visited registries": {
+			createCtx: func(t *testing.T) context.Context {
+				return peer.NewContext(context.Background(), &peer.Peer{Addr: &snet.UDPAddr{
+					IA: xtest.MustParseIA("1-ff00:0:14"),
+				}})
+			},
+			lookuper: func(ctrl *gomock.Controller) hiddenpath.Lookuper {
+				lookuper := mock_hiddenpath.NewMockLookuper(ctrl)
+				lookuper.EXPECT().Segments(gomock.Any(), hiddenpath.SegmentRequest{
+					GroupIDs: mustParseGroupIDs(t, "ff00:0:22-1"),
+					DstIA:    xtest.MustParseIA("1-ff00:0:110"),
+					Peer:     xtest.MustParseIA("1-ff00:0:14"),
+					Visited: []addr.IA{
+						xtest.MustParseIA("1-ff00:0:14"),
+						xtest.MustParseIA("1-ff00:0:15"),
+					},
+				}).Return(segsMeta, nil)
+				return lookuper
+			},
+			verifier: func(ctrl *gomock.Controller) infra.Verifier {
+				body := marshalBody(t, &hspb.HiddenSegmentsRequest{
+					GroupIds: groupIDsToInts(mustParseGroupIDs(t, "ff00:0:22-1")),
+					DstIsdAs: mustIA("1-ff00:0:110"),
+					VisitedRegistries: []uint64{
+						mustIA("1-ff00:0:14"),
+						mustIA("1-ff00:0:15"),
+					},
+				})
+				v := mock_infra.NewMockVerifier(ctrl)
+				v.EXPECT().WithServer(gomock.Any()).Return(v)
+				v.EXPECT().WithIA(xtest.MustParseIA("1-ff00:0:14")).Return(v)
+				v.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()).Return(&signed.Message{
+					Body: body,
+				}, nil)
+				return v
+			},
+			authoritative: true,
+			want: &hspb.AuthoritativeHiddenSegmentsResponse{
+				Segments: grpc.ToHSPB(segsMeta),
+			},
+			assertErr: assert.NoError,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -295,3 +386,18 @@ func createSegs(t *testing.T) ([]*seg.Meta, *hspb.HiddenSegmentsResponse) {
 	}
 	return ret1, ret2
 }
+
+// assertStatusDetail asserts that the error is a gRPC status error with the
+// given code that carries the given detail.
+func assertStatusDetail(c codes.Code, want proto.Message) assert.ErrorAssertionFunc {
+	return func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+		if !assert.Equal(t, c, status.Code(err), msgAndArgs...) {
+			return false
+		}
+		got := want.ProtoReflect().New().Interface()
+		if !assert.True(t, libgrpc.ErrorDetail(err, got), msgAndArgs...) {
+			return false
+		}
+		return assert.True(t, proto.Equal(want, got), msgAndArgs...)
+	}
+}
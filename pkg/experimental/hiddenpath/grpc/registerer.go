@@ -16,6 +16,7 @@ package grpc
 
 import (
 	"context"
+	"crypto/rand"
 	"net"
 
 	"google.golang.org/protobuf/proto"
@@ -31,6 +32,10 @@ import (
 	seg "github.com/scionproto/scion/pkg/segment"
 )
 
+// nonceLength is the length of the random nonce of a hidden segment
+// registration.
+const nonceLength = 16
+
 // Signer signs requests.
 type Signer interface {
 	// Sign signs the msg and returns a signed message.
@@ -50,24 +55,30 @@ type Registerer struct {
 // RegisterSegment registers the segment at the remote. If the hidden path group
 // ID is not defined it is registered via a normal segment registration message
 func (s Registerer) RegisterSegment(ctx context.Context,
-	reg hiddenpath.SegmentRegistration, remote net.Addr) error {
+	reg hiddenpath.SegmentRegistration, remote net.Addr) (hiddenpath.RegistrationReply, error) {
 
 	if reg.Seg.Segment == nil {
-		return serrors.New("no segments to register")
+		return hiddenpath.RegistrationReply{}, serrors.New("no segments to register")
 	}
 	if reg.GroupID.ToUint64() == 0 { // do regular public registration
-		return s.RegularRegistration.RegisterSegment(ctx, reg.Seg, remote)
+		err := s.RegularRegistration.RegisterSegment(ctx, reg.Seg, remote)
+		return hiddenpath.RegistrationReply{}, err
 	}
 
 	conn, err := s.Dialer.Dial(ctx, remote)
 	if err != nil {
-		return err
+		return hiddenpath.RegistrationReply{}, err
 	}
 	defer conn.Close()
 
+	nonce := make([]byte, nonceLength)
+	if _, err := rand.Read(nonce); err != nil {
+		return hiddenpath.RegistrationReply{}, serrors.WrapStr("generating nonce", err)
+	}
 	client := hspb.NewHiddenSegmentRegistrationServiceClient(conn)
 	body := &hspb.HiddenSegmentRegistrationRequestBody{
 		GroupId: reg.GroupID.ToUint64(),
+		Nonce:   nonce,
 		Segments: map[int32]*hppb.Segments{
 			int32(reg.Seg.Type): {Segments: []*control_plane.PathSegment{
 				seg.PathSegmentToPB(reg.Seg.Segment),
@@ -76,13 +87,16 @@ func (s Registerer) RegisterSegment(ctx context.Context,
 	}
 	rawBody, err := proto.Marshal(body)
 	if err != nil {
-		return err
+		return hiddenpath.RegistrationReply{}, err
 	}
 	signedMsg, err := s.Signer.Sign(ctx, rawBody)
 	if err != nil {
-		return err
+		return hiddenpath.RegistrationReply{}, err
 	}
 	req := &hspb.HiddenSegmentRegistrationRequest{SignedRequest: signedMsg}
-	_, err = client.HiddenSegmentRegistration(ctx, req, libgrpc.RetryProfile...)
-	return err
+	rep, err := client.HiddenSegmentRegistration(ctx, req, libgrpc.RetryProfile...)
+	if err != nil {
+		return hiddenpath.RegistrationReply{}, err
+	}
+	return hiddenpath.RegistrationReply{LoadHint: rep.LoadHint}, nil
 }
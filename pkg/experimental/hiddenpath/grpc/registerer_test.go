@@ -45,13 +45,14 @@ func TestRegistererRegisterSegment(t *testing.T) {
 		hpServer  func(*gomock.Controller) hidden_segment.HiddenSegmentRegistrationServiceServer
 		signer    func(ctrl *gomock.Controller) hpgrpc.Signer
 		regular   func(*gomock.Controller) beaconing.RPC
+		want      hiddenpath.RegistrationReply
 		assertErr assert.ErrorAssertionFunc
 	}{
 		"valid hidden": {
 			hpServer: func(c *gomock.Controller) hspb.HiddenSegmentRegistrationServiceServer {
 				s := mock_hidden_segment.NewMockHiddenSegmentRegistrationServiceServer(c)
 				s.EXPECT().HiddenSegmentRegistration(gomock.Any(), gomock.Any()).
-					Return(&hidden_segment.HiddenSegmentRegistrationResponse{}, nil)
+					Return(&hidden_segment.HiddenSegmentRegistrationResponse{LoadHint: 3}, nil)
 				return s
 			},
 			signer: func(ctrl *gomock.Controller) hpgrpc.Signer {
@@ -69,6 +70,7 @@ func TestRegistererRegisterSegment(t *testing.T) {
 				GroupID: hiddenpath.GroupID{Suffix: 42},
 				Seg:     createSeg(t),
 			},
+			want:      hiddenpath.RegistrationReply{LoadHint: 3},
 			assertErr: assert.NoError,
 		},
 		"valid public": {
@@ -112,8 +114,9 @@ func TestRegistererRegisterSegment(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
 
-			err := s.RegisterSegment(ctx, tc.input, &net.UDPAddr{})
+			got, err := s.RegisterSegment(ctx, tc.input, &net.UDPAddr{})
 			tc.assertErr(t, err)
+			assert.Equal(t, tc.want, got)
 		})
 	}
 
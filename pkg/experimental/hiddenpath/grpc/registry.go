@@ -17,6 +17,8 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
@@ -37,6 +39,32 @@ import (
 type RegistrationServer struct {
 	Registry hiddenpath.Registry
 	Verifier infra.Verifier
+	// Load tracks the concurrent registrations, which are reported to the
+	// writers as load hint. If it is nil, no load hint is reported.
+	Load *RegistrationLoad
+}
+
+// RegistrationLoad counts the registrations that are processed concurrently.
+// The zero value is ready to use.
+type RegistrationLoad struct {
+	inflight int64
+}
+
+// enter marks the start of a registration and returns the number of
+// registrations in progress, including the new one.
+func (l *RegistrationLoad) enter() uint32 {
+	if l == nil {
+		return 0
+	}
+	return uint32(atomic.AddInt64(&l.inflight, 1))
+}
+
+// exit marks the end of a registration.
+func (l *RegistrationLoad) exit() {
+	if l == nil {
+		return
+	}
+	atomic.AddInt64(&l.inflight, -1)
 }
 
 // HiddenSegmentRegistration handles the gRPC hidden segment registration
@@ -45,6 +73,8 @@ func (s RegistrationServer) HiddenSegmentRegistration(ctx context.Context,
 	req *hspb.HiddenSegmentRegistrationRequest) (*hspb.HiddenSegmentRegistrationResponse, error) {
 
 	logger := log.FromCtx(ctx)
+	load := s.Load.enter()
+	defer s.Load.exit()
 
 	p, peerIA, err := getPeer(ctx)
 	if err != nil {
@@ -62,6 +92,7 @@ func (s RegistrationServer) HiddenSegmentRegistration(ctx context.Context,
 		return nil, status.Error(codes.InvalidArgument, "parsing body")
 	}
 	id := hiddenpath.GroupIDFromUint64(reqBody.GroupId)
+	now := time.Now()
 	var segs []*seg.Meta
 	for rawType, rawSegs := range reqBody.Segments {
 		for i, rawSeg := range rawSegs.Segments {
@@ -70,6 +101,10 @@ func (s RegistrationServer) HiddenSegmentRegistration(ctx context.Context,
 				return nil, status.Error(codes.InvalidArgument,
 					fmt.Sprintf("invalid segment %d: %v", i, err))
 			}
+			if err := checkExpired(s, now); err != nil {
+				logger.Debug("Rejecting expired segment", "err", err)
+				return nil, err
+			}
 			segs = append(segs, &seg.Meta{
 				Segment: s,
 				Type:    seg.Type(rawType),
@@ -77,15 +112,17 @@ func (s RegistrationServer) HiddenSegmentRegistration(ctx context.Context,
 		}
 	}
 	err = s.Registry.Register(ctx, hiddenpath.Registration{
-		Segments: segs,
-		GroupID:  id,
-		Peer:     p,
+		Segments:  segs,
+		GroupID:   id,
+		Peer:      p,
+		Nonce:     reqBody.Nonce,
+		Timestamp: msg.Header.Timestamp,
 	})
 	if err != nil {
 		logger.Debug("Error during registration", "err", err)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorToStatus(err)
 	}
-	return &hspb.HiddenSegmentRegistrationResponse{}, nil
+	return &hspb.HiddenSegmentRegistrationResponse{LoadHint: load}, nil
 }
 
 func getPeer(ctx context.Context) (*snet.SVCAddr, addr.IA, error) {
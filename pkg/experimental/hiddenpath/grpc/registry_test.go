@@ -18,20 +18,25 @@ import (
 	"context"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
 	hpgrpc "github.com/scionproto/scion/pkg/experimental/hiddenpath/grpc"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath/mock_hiddenpath"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/pkg/private/xtest/graph"
 	"github.com/scionproto/scion/pkg/proto/control_plane"
+	edpb "github.com/scionproto/scion/pkg/proto/error_details"
 	hspb "github.com/scionproto/scion/pkg/proto/hidden_segment"
 	"github.com/scionproto/scion/pkg/scrypto/signed"
 	seg "github.com/scionproto/scion/pkg/segment"
@@ -183,13 +188,56 @@ func TestRegistrationServerHiddenSegmentRegistration(t *testing.T) {
 			want:      nil,
 			assertErr: assert.Error,
 		},
+		"expired segment": {
+			ctx: peer.NewContext(context.Background(), &peer.Peer{Addr: &snet.UDPAddr{
+				IA: xtest.MustParseIA("1-ff00:0:110"),
+			}}),
+			registry: func(ctrl *gomock.Controller) hiddenpath.Registry {
+				return mock_hiddenpath.NewMockRegistry(ctrl)
+			},
+			verifier: func(ctrl *gomock.Controller) infra.Verifier {
+				s, err := seg.CreateSegment(time.Now().Add(-24*time.Hour), 1337)
+				require.NoError(t, err)
+				require.NoError(t, s.AddASEntry(context.Background(), seg.ASEntry{
+					Local: xtest.MustParseIA("1-ff00:0:110"),
+				}, graph.NewSigner()))
+
+				body := marshalBody(t, &hspb.HiddenSegmentRegistrationRequestBody{
+					Segments: map[int32]*hspb.Segments{
+						1: {Segments: []*control_plane.PathSegment{
+							seg.PathSegmentToPB(s),
+						}},
+					},
+				})
+				v := mock_infra.NewMockVerifier(ctrl)
+				v.EXPECT().WithServer(gomock.Any()).Return(v)
+				v.EXPECT().WithIA(xtest.MustParseIA("1-ff00:0:110")).Return(v)
+				v.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()).Return(&signed.Message{
+					Body: body,
+				}, nil)
+				return v
+			},
+			want: nil,
+			assertErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				var detail edpb.ErrorSegmentExpired
+				return assert.Equal(t, codes.FailedPrecondition, status.Code(err)) &&
+					assert.True(t, libgrpc.ErrorDetail(err, &detail)) &&
+					assert.NotEmpty(t, detail.SegmentId)
+			},
+		},
 		"valid": {
 			ctx: peer.NewContext(context.Background(), &peer.Peer{Addr: &snet.UDPAddr{
 				IA: xtest.MustParseIA("1-ff00:0:110"),
 			}}),
 			registry: func(ctrl *gomock.Controller) hiddenpath.Registry {
 				registry := mock_hiddenpath.NewMockRegistry(ctrl)
-				registry.EXPECT().Register(gomock.Any(), gomock.Any())
+				registry.EXPECT().Register(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, reg hiddenpath.Registration) error {
+						assert.Equal(t, []byte("nonce-123"), reg.Nonce)
+						assert.Equal(t, time.Unix(1000, 0), reg.Timestamp)
+						return nil
+					},
+				)
 				return registry
 			},
 			verifier: func(ctrl *gomock.Controller) infra.Verifier {
@@ -202,16 +250,18 @@ func TestRegistrationServerHiddenSegmentRegistration(t *testing.T) {
 							seg.PathSegmentToPB(s),
 						}},
 					},
+					Nonce: []byte("nonce-123"),
 				})
 				v := mock_infra.NewMockVerifier(ctrl)
 				v.EXPECT().WithServer(gomock.Any()).Return(v)
 				v.EXPECT().WithIA(xtest.MustParseIA("1-ff00:0:110")).Return(v)
 				v.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()).Return(&signed.Message{
-					Body: body,
+					Header: signed.Header{Timestamp: time.Unix(1000, 0)},
+					Body:   body,
 				}, nil)
 				return v
 			},
-			want:      &hspb.HiddenSegmentRegistrationResponse{},
+			want:      &hspb.HiddenSegmentRegistrationResponse{LoadHint: 1},
 			assertErr: assert.NoError,
 		},
 	}
@@ -226,6 +276,7 @@ func TestRegistrationServerHiddenSegmentRegistration(t *testing.T) {
 			s := hpgrpc.RegistrationServer{
 				Registry: tc.registry(ctrl),
 				Verifier: tc.verifier(ctrl),
+				Load:     &hpgrpc.RegistrationLoad{},
 			}
 			got, err := s.HiddenSegmentRegistration(tc.ctx,
 				&hspb.HiddenSegmentRegistrationRequest{})
@@ -30,13 +30,33 @@ import (
 	"github.com/scionproto/scion/private/segment/segfetcher"
 )
 
+type groupsKey struct{}
+
+// ContextWithGroups returns a copy of ctx that restricts the hidden segment
+// lookup of the Requester to the given groups. The groups are queried
+// regardless of the local group configuration.
+func ContextWithGroups(ctx context.Context, ids []hiddenpath.GroupID) context.Context {
+	return context.WithValue(ctx, groupsKey{}, ids)
+}
+
+// GroupsFromContext returns the groups that were attached to the context with
+// ContextWithGroups.
+func GroupsFromContext(ctx context.Context) []hiddenpath.GroupID {
+	ids, _ := ctx.Value(groupsKey{}).([]hiddenpath.GroupID)
+	return ids
+}
+
 // Requester fetches segments from a remote using gRPC.
 type Requester struct {
 	// Dialer dials a new gRPC connection.
 	Dialer libgrpc.Dialer
 	// HPGroups is used to fetch hidden segments when the destination IA belongs
-	// to the writers of a group configuration.
+	// to the writers of a group configuration. The groups attached to the
+	// request context with ContextWithGroups take precedence.
 	HPGroups hiddenpath.Groups
+	// LearnedGroups are the groups that are learned from the control service.
+	// They are used in addition to HPGroups. It can be nil.
+	LearnedGroups *hiddenpath.LearnedGroups
 	// RegularLookup is the regular segment lookup.
 	RegularLookup segfetcher.RPC
 }
@@ -87,16 +107,16 @@ func (f *Requester) Segments(ctx context.Context, req segfetcher.Request,
 func (f *Requester) hiddenSegments(ctx context.Context, req segfetcher.Request,
 	server net.Addr) ([]*seg.Meta, error) {
 
-	conn, err := f.Dialer.Dial(ctx, server)
-	if err != nil {
-		return nil, err
-	}
-	defer conn.Close()
-
 	groups := []uint64{}
-	for _, g := range f.HPGroups {
-		if _, ok := g.Writers[req.Dst]; ok {
-			groups = append(groups, g.ID.ToUint64())
+	if ids := GroupsFromContext(ctx); len(ids) > 0 {
+		for _, id := range ids {
+			groups = append(groups, id.ToUint64())
+		}
+	} else {
+		for _, id := range hiddenpath.WriterGroupIDs(req.Dst, f.HPGroups,
+			f.LearnedGroups.Groups()) {
+
+			groups = append(groups, id.ToUint64())
 		}
 	}
 
@@ -104,6 +124,12 @@ func (f *Requester) hiddenSegments(ctx context.Context, req segfetcher.Request,
 		return nil, nil
 	}
 
+	conn, err := f.Dialer.Dial(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
 	client := hspb.NewHiddenSegmentLookupServiceClient(conn)
 	rep, err := client.HiddenSegments(ctx,
 		&hspb.HiddenSegmentsRequest{
@@ -141,9 +167,15 @@ func (r AuthoritativeRequester) HiddenSegments(ctx context.Context,
 		groups = append(groups, id.ToUint64())
 	}
 
+	var visited []uint64
+	for _, ia := range req.Visited {
+		visited = append(visited, uint64(ia))
+	}
+
 	pbReq := &hspb.HiddenSegmentsRequest{
-		GroupIds: groups,
-		DstIsdAs: uint64(req.DstIA),
+		GroupIds:          groups,
+		DstIsdAs:          uint64(req.DstIA),
+		VisitedRegistries: visited,
 	}
 	rawReq, err := proto.Marshal(pbReq)
 	if err != nil {
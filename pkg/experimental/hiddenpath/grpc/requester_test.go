@@ -55,6 +55,8 @@ func TestRequesterSegments(t *testing.T) {
 	t.Run("cases", func(t *testing.T) {
 		testCases := map[string]struct {
 			hpGroups    hiddenpath.Groups
+			learned     hiddenpath.Groups
+			ctxGroups   []hiddenpath.GroupID
 			input       segfetcher.Request
 			regular     func(*gomock.Controller) segfetcher.RPC
 			want        int
@@ -88,6 +90,35 @@ func TestRequesterSegments(t *testing.T) {
 				want:        0,
 				assertError: assert.NoError,
 			},
+			"dst in writers of learned group": {
+				learned: defaultGroups,
+				regular: func(c *gomock.Controller) segfetcher.RPC {
+					ret := mock_segfetcher.NewMockRPC(c)
+					ret.EXPECT().Segments(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(segfetcher.SegmentsReply{}, nil).Times(1)
+					return ret
+				},
+				input: segfetcher.Request{
+					Dst: xtest.MustParseIA("1-ff00:0:3"),
+				},
+				want:        1,
+				assertError: assert.NoError,
+			},
+			"dst not in writers, groups in context": {
+				hpGroups:  defaultGroups,
+				ctxGroups: []hiddenpath.GroupID{hpID},
+				regular: func(c *gomock.Controller) segfetcher.RPC {
+					ret := mock_segfetcher.NewMockRPC(c)
+					ret.EXPECT().Segments(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(segfetcher.SegmentsReply{}, nil).Times(1)
+					return ret
+				},
+				input: segfetcher.Request{
+					Dst: xtest.MustParseIA("1-ff00:0:7"),
+				},
+				want:        1,
+				assertError: assert.NoError,
+			},
 			"invalid": {
 				hpGroups: defaultGroups,
 				regular: func(c *gomock.Controller) segfetcher.RPC {
@@ -120,14 +151,20 @@ func TestRequesterSegments(t *testing.T) {
 				hspb.RegisterHiddenSegmentLookupServiceServer(svc.Server(), server)
 				svc.Start(t)
 
+				learned := &hiddenpath.LearnedGroups{}
+				learned.Set(tc.learned)
 				requester := &hpgrpc.Requester{
 					Dialer:        svc,
 					RegularLookup: tc.regular(ctrl),
 					HPGroups:      tc.hpGroups,
+					LearnedGroups: learned,
 				}
 
 				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 				defer cancel()
+				if tc.ctxGroups != nil {
+					ctx = hpgrpc.ContextWithGroups(ctx, tc.ctxGroups)
+				}
 
 				got, err := requester.Segments(ctx, tc.input, &net.UDPAddr{})
 				tc.assertError(t, err)
@@ -0,0 +1,84 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	edpb "github.com/scionproto/scion/pkg/proto/error_details"
+	seg "github.com/scionproto/scion/pkg/segment"
+)
+
+var authorizationReasons = map[hiddenpath.AuthorizationReason]edpb.ErrorGroupNotAuthorized_Reason{
+	hiddenpath.ReasonUnknownGroup: edpb.ErrorGroupNotAuthorized_REASON_UNKNOWN_GROUP,
+	hiddenpath.ReasonNotMember:    edpb.ErrorGroupNotAuthorized_REASON_NOT_MEMBER,
+	hiddenpath.ReasonNotRegistry:  edpb.ErrorGroupNotAuthorized_REASON_NOT_REGISTRY,
+}
+
+// errorToStatus converts an error of a hidden path lookup or registration to
+// a gRPC status error. Authorization failures are reported as
+// PermissionDenied with an ErrorGroupNotAuthorized detail. Statuses with
+// details that were returned by a remote registry are passed on unchanged.
+// For lookups that were forwarded to several registries, the first error with
+// details is reported. All other errors are reported as Internal.
+func errorToStatus(err error) error {
+	var errs serrors.List
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			st := errorToStatus(e)
+			if _, ok := libgrpc.DetailedStatus(st); ok {
+				return st
+			}
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	var authErr *hiddenpath.GroupNotAuthorizedError
+	if errors.As(err, &authErr) {
+		return libgrpc.StatusError(codes.PermissionDenied, err.Error(),
+			&edpb.ErrorGroupNotAuthorized{
+				GroupId: authErr.Group.ToUint64(),
+				IsdAs:   uint64(authErr.Peer),
+				Reason:  authorizationReasons[authErr.Reason],
+			},
+		)
+	}
+	if st, ok := libgrpc.DetailedStatus(err); ok {
+		return st.Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// checkExpired returns a FailedPrecondition status error with an
+// ErrorSegmentExpired detail if the segment is expired at the given time.
+func checkExpired(s *seg.PathSegment, now time.Time) error {
+	exp := s.MaxExpiry()
+	if now.Before(exp) {
+		return nil
+	}
+	return libgrpc.StatusError(codes.FailedPrecondition, "segment expired",
+		&edpb.ErrorSegmentExpired{
+			SegmentId:  s.ID(),
+			Expiration: timestamppb.New(exp),
+		},
+	)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/pkg/experimental/hiddenpath (interfaces: Store,RPC,Verifier,Lookuper,AddressResolver,Discoverer,Registry,Register)
+// Source: github.com/scionproto/scion/pkg/experimental/hiddenpath (interfaces: Store,RPC,Verifier,Lookuper,AddressResolver,Discoverer,Registry,Register,GroupFetcher)
 
 // Package mock_hiddenpath is a generated GoMock package.
 package mock_hiddenpath
@@ -54,17 +54,17 @@ func (mr *MockStoreMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call
 }
 
 // Put mocks base method.
-func (m *MockStore) Put(arg0 context.Context, arg1 []*segment.Meta, arg2 hiddenpath.GroupID) error {
+func (m *MockStore) Put(arg0 context.Context, arg1 []*segment.Meta, arg2 hiddenpath.GroupID, arg3 addr.IA) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Put indicates an expected call of Put.
-func (mr *MockStoreMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) Put(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), arg0, arg1, arg2, arg3)
 }
 
 // MockRPC is a mock of RPC interface.
@@ -317,11 +317,12 @@ func (m *MockRegister) EXPECT() *MockRegisterMockRecorder {
 }
 
 // RegisterSegment mocks base method.
-func (m *MockRegister) RegisterSegment(arg0 context.Context, arg1 hiddenpath.SegmentRegistration, arg2 net.Addr) error {
+func (m *MockRegister) RegisterSegment(arg0 context.Context, arg1 hiddenpath.SegmentRegistration, arg2 net.Addr) (hiddenpath.RegistrationReply, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RegisterSegment", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(hiddenpath.RegistrationReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // RegisterSegment indicates an expected call of RegisterSegment.
@@ -329,3 +330,41 @@ func (mr *MockRegisterMockRecorder) RegisterSegment(arg0, arg1, arg2 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSegment", reflect.TypeOf((*MockRegister)(nil).RegisterSegment), arg0, arg1, arg2)
 }
+
+// MockGroupFetcher is a mock of GroupFetcher interface.
+type MockGroupFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupFetcherMockRecorder
+}
+
+// MockGroupFetcherMockRecorder is the mock recorder for MockGroupFetcher.
+type MockGroupFetcherMockRecorder struct {
+	mock *MockGroupFetcher
+}
+
+// NewMockGroupFetcher creates a new mock instance.
+func NewMockGroupFetcher(ctrl *gomock.Controller) *MockGroupFetcher {
+	mock := &MockGroupFetcher{ctrl: ctrl}
+	mock.recorder = &MockGroupFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupFetcher) EXPECT() *MockGroupFetcherMockRecorder {
+	return m.recorder
+}
+
+// ReaderGroups mocks base method.
+func (m *MockGroupFetcher) ReaderGroups(arg0 context.Context, arg1 net.Addr) (hiddenpath.Groups, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReaderGroups", arg0, arg1)
+	ret0, _ := ret[0].(hiddenpath.Groups)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReaderGroups indicates an expected call of ReaderGroups.
+func (mr *MockGroupFetcherMockRecorder) ReaderGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReaderGroups", reflect.TypeOf((*MockGroupFetcher)(nil).ReaderGroups), arg0, arg1)
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/metrics"
+)
+
+// SegmentQuota limits the number of segments that a writer AS stores in a
+// hidden path group. If a registration exceeds the limit, the least recently
+// registered segments of the writer in the group are evicted. These are the
+// segments that were superseded by more recent registrations, since a writer
+// periodically re-registers the segments that are still current.
+//
+// The quota only accounts for the segments that are registered after it was
+// created. Segments that were stored before, e.g., in a persistent DB, expire
+// by themselves.
+//
+// The quota is safe for concurrent use.
+type SegmentQuota struct {
+	// MaxSegments is the maximum number of segments per group and writer AS.
+	MaxSegments int
+	// Evictions counts the evicted segments. It can be nil.
+	Evictions metrics.Counter
+
+	mu     sync.Mutex
+	usages map[quotaKey]*quotaUsage
+}
+
+type quotaKey struct {
+	group  GroupID
+	writer addr.IA
+}
+
+// quotaUsage holds the segments of a writer in a group in the order in which
+// they were registered, the most recent first.
+type quotaUsage struct {
+	order    *list.List
+	segments map[string]*list.Element
+}
+
+type quotaEntry struct {
+	id     string
+	expiry time.Time
+}
+
+// QuotaSegment identifies a segment that is accounted for by the quota.
+type QuotaSegment struct {
+	ID     []byte
+	Expiry time.Time
+}
+
+// Register records the registration of the segments by the writer in the
+// group and returns the IDs of the segments that must be evicted to respect
+// the quota. Segments that are registered again are moved to the front of the
+// registration order. Expired segments are dropped from the accounting
+// without being evicted. If a single registration exceeds the quota, the
+// segments that were listed first are evicted.
+func (q *SegmentQuota) Register(g GroupID, writer addr.IA, segs []QuotaSegment,
+	now time.Time) [][]byte {
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.usages == nil {
+		q.usages = make(map[quotaKey]*quotaUsage)
+	}
+	key := quotaKey{group: g, writer: writer}
+	usage, ok := q.usages[key]
+	if !ok {
+		usage = &quotaUsage{order: list.New(), segments: make(map[string]*list.Element)}
+		q.usages[key] = usage
+	}
+	for _, s := range segs {
+		id := string(s.ID)
+		if e, ok := usage.segments[id]; ok {
+			usage.order.Remove(e)
+		}
+		usage.segments[id] = usage.order.PushFront(quotaEntry{id: id, expiry: s.Expiry})
+	}
+	usage.prune(now)
+
+	var evicted [][]byte
+	for q.MaxSegments > 0 && usage.order.Len() > q.MaxSegments {
+		e := usage.order.Back()
+		entry := usage.order.Remove(e).(quotaEntry)
+		delete(usage.segments, entry.id)
+		evicted = append(evicted, []byte(entry.id))
+	}
+	if usage.order.Len() == 0 {
+		delete(q.usages, key)
+	}
+	metrics.CounterAdd(q.Evictions, float64(len(evicted)))
+	return evicted
+}
+
+// Usage returns the number of segments that are accounted for the writer in
+// the group.
+func (q *SegmentQuota) Usage(g GroupID, writer addr.IA) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	usage, ok := q.usages[quotaKey{group: g, writer: writer}]
+	if !ok {
+		return 0
+	}
+	return usage.order.Len()
+}
+
+func (u *quotaUsage) prune(now time.Time) {
+	for e := u.order.Front(); e != nil; {
+		next := e.Next()
+		if entry := e.Value.(quotaEntry); !entry.expiry.After(now) {
+			u.order.Remove(e)
+			delete(u.segments, entry.id)
+		}
+		e = next
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+func TestSegmentQuotaRegister(t *testing.T) {
+	now := time.Now()
+	writer := xtest.MustParseIA("1-ff00:0:110")
+	g1 := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:111"), Suffix: 1}
+	g2 := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:111"), Suffix: 2}
+	segment := func(id string, expiry time.Duration) hiddenpath.QuotaSegment {
+		return hiddenpath.QuotaSegment{ID: []byte(id), Expiry: now.Add(expiry)}
+	}
+
+	q := &hiddenpath.SegmentQuota{MaxSegments: 2}
+	assert.Empty(t, q.Register(g1, writer, []hiddenpath.QuotaSegment{
+		segment("a", time.Hour), segment("b", time.Hour),
+	}, now))
+	// Re-registering a refreshes it, such that b is the least recently
+	// registered segment.
+	assert.Empty(t, q.Register(g1, writer, []hiddenpath.QuotaSegment{
+		segment("a", time.Hour),
+	}, now))
+	assert.Equal(t, [][]byte{[]byte("b")}, q.Register(g1, writer,
+		[]hiddenpath.QuotaSegment{segment("c", time.Hour)}, now))
+	assert.Equal(t, 2, q.Usage(g1, writer))
+
+	// The groups have separate quotas.
+	assert.Empty(t, q.Register(g2, writer, []hiddenpath.QuotaSegment{
+		segment("d", time.Hour), segment("e", time.Hour),
+	}, now))
+	assert.Equal(t, 2, q.Usage(g2, writer))
+
+	// Expired segments are no longer accounted for and not evicted.
+	assert.Empty(t, q.Register(g1, writer, []hiddenpath.QuotaSegment{
+		segment("f", 2*time.Hour),
+	}, now.Add(time.Hour)))
+	assert.Equal(t, 1, q.Usage(g1, writer))
+}
+
+func TestSegmentQuotaUnlimited(t *testing.T) {
+	writer := xtest.MustParseIA("1-ff00:0:110")
+	g := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:111"), Suffix: 1}
+	q := &hiddenpath.SegmentQuota{}
+	var segs []hiddenpath.QuotaSegment
+	for _, id := range []string{"a", "b", "c"} {
+		segs = append(segs, hiddenpath.QuotaSegment{
+			ID:     []byte(id),
+			Expiry: time.Now().Add(time.Hour),
+		})
+	}
+	assert.Empty(t, q.Register(g, writer, segs, time.Now()))
+	assert.Equal(t, 3, q.Usage(g, writer))
+}
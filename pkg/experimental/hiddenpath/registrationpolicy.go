@@ -73,7 +73,7 @@ func (p RegistrationPolicy) UnmarshalYAML(unmarshal func(interface{}) error) err
 	if err := unmarshal(&rawPolicy); err != nil {
 		return serrors.WrapStr("parsing yaml", err)
 	}
-	groups, err := parseGroups(rawPolicy.Groups)
+	groups, err := parseGroups(rawPolicy.Groups, rawPolicy.Templates)
 	if err != nil {
 		return err
 	}
@@ -103,7 +103,7 @@ func LoadConfiguration(location string) (Groups, RegistrationPolicy, error) {
 	if err := yaml.NewDecoder(c).Decode(&info); err != nil {
 		return nil, nil, serrors.WrapStr("parsing", err, "location", location)
 	}
-	groups, err := parseGroups(info.Groups)
+	groups, err := parseGroups(info.Groups, info.Templates)
 	if err != nil {
 		return nil, nil, serrors.WrapStr("parsing groups", err, "location", location)
 	}
@@ -121,8 +121,9 @@ func LoadConfiguration(location string) (Groups, RegistrationPolicy, error) {
 }
 
 type registrationPolicyInfo struct {
-	Groups   map[string]*groupInfo `yaml:"groups,omitempty"`
-	Policies map[uint64][]string   `yaml:"registration_policy_per_interface,omitempty"`
+	Templates map[string]*groupTemplate `yaml:"templates,omitempty"`
+	Groups    map[string]*groupInfo     `yaml:"groups,omitempty"`
+	Policies  map[uint64][]string       `yaml:"registration_policy_per_interface,omitempty"`
 }
 
 func parsePolicies(groups Groups, rawPolicies map[uint64][]string) (RegistrationPolicy, error) {
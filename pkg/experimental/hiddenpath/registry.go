@@ -16,11 +16,16 @@ package hiddenpath
 
 import (
 	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/tracing"
 )
 
 // Registry handles registrations.
@@ -37,6 +42,10 @@ type Registration struct {
 	GroupID GroupID
 	// Peer is the address of the writer of the segments.
 	Peer *snet.SVCAddr
+	// Nonce is the random nonce of the registration.
+	Nonce []byte
+	// Timestamp is the time at which the writer signed the registration.
+	Timestamp time.Time
 }
 
 // RegistryServer handles hidden segment registrations.
@@ -49,34 +58,82 @@ type RegistryServer struct {
 	Verifier Verifier
 	// LocalIA is the IA this handler is in.
 	LocalIA addr.IA
+	// Auditor records the authorization decisions. If nil, no audit records
+	// are written.
+	Auditor Auditor
+	// Replay rejects replayed registrations. If nil, registrations are not
+	// checked for replays.
+	Replay *ReplayFilter
+	// Clock provides the local time against which the registration timestamps
+	// are checked. If nil, the wall clock is used.
+	Clock clock.Clock
 }
 
 // Register registers the given registration.
-func (h RegistryServer) Register(ctx context.Context, reg Registration) error {
+func (h RegistryServer) Register(ctx context.Context, reg Registration) (err error) {
+	ctx, span := tracing.StartOTelSpan(ctx, "hiddenpath.register",
+		attribute.String("group", reg.GroupID.String()),
+		attribute.Int("segments", len(reg.Segments)),
+	)
+	defer func() {
+		tracing.OTelError(span, err)
+		span.End()
+	}()
+
 	// validate first
+	now := clock.Now(h.Clock)
+	record := AuditRecord{
+		Action:   AuditRegistration,
+		LocalIA:  h.LocalIA,
+		Peer:     reg.Peer.IA,
+		GroupIDs: []GroupID{reg.GroupID},
+		Segments: len(reg.Segments),
+	}
+	if err := h.authorize(reg); err != nil {
+		audit(ctx, h.Auditor, record, now, err)
+		return err
+	}
+	if h.Replay != nil {
+		if err := h.Replay.Check(reg.Peer.IA, reg.Nonce, reg.Timestamp, now); err != nil {
+			audit(ctx, h.Auditor, record, now, err)
+			return err
+		}
+	}
+	record.MatchedGroups = []GroupID{reg.GroupID}
+
+	// verify segments
+	if err := h.Verifier.Verify(ctx, reg.Segments, reg.Peer); err != nil {
+		err = serrors.WrapStr("verifying segments", err)
+		audit(ctx, h.Auditor, record, now, err)
+		return err
+	}
+	audit(ctx, h.Auditor, record, now, nil)
+
+	// store segments in db
+	if err := h.DB.Put(ctx, reg.Segments, reg.GroupID, reg.Peer.IA); err != nil {
+		return serrors.WrapStr("writing segments", err)
+	}
+	return nil
+}
+
+func (h RegistryServer) authorize(reg Registration) error {
+	notAuthorized := func(reason AuthorizationReason) error {
+		return &GroupNotAuthorizedError{Group: reg.GroupID, Peer: reg.Peer.IA, Reason: reason}
+	}
 	group, ok := h.Groups[reg.GroupID]
 	if !ok {
-		return serrors.New("unknown group")
+		return notAuthorized(ReasonUnknownGroup)
 	}
 	if _, ok := group.Writers[reg.Peer.IA]; !ok {
-		return serrors.New("sender not writer in group")
+		return notAuthorized(ReasonNotMember)
 	}
 	if _, ok := group.Registries[h.LocalIA]; !ok {
-		return serrors.New("receiver not registry in group")
+		return notAuthorized(ReasonNotRegistry)
 	}
 	for _, s := range reg.Segments {
 		if s.Type != seg.TypeDown {
 			return serrors.New("wrong segment type", "segment", s, "expected", seg.TypeDown)
 		}
 	}
-
-	// verify segments
-	if err := h.Verifier.Verify(ctx, reg.Segments, reg.Peer); err != nil {
-		return serrors.WrapStr("verifying segments", err)
-	}
-	// store segments in db
-	if err := h.DB.Put(ctx, reg.Segments, reg.GroupID); err != nil {
-		return serrors.WrapStr("writing segments", err)
-	}
 	return nil
 }
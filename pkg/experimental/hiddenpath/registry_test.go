@@ -17,13 +17,22 @@ package hiddenpath_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath/mock_hiddenpath"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	seg "github.com/scionproto/scion/pkg/segment"
@@ -65,7 +74,7 @@ func TestRegistryRegister(t *testing.T) {
 			verifier: func(ctrl *gomock.Controller) hiddenpath.Verifier {
 				return mock_hiddenpath.NewMockVerifier(ctrl)
 			},
-			assertErr: assert.Error,
+			assertErr: assertNotAuthorized(hiddenpath.ReasonUnknownGroup),
 		},
 		"peer not writer": {
 			reg: hiddenpath.Registration{
@@ -79,7 +88,7 @@ func TestRegistryRegister(t *testing.T) {
 			verifier: func(ctrl *gomock.Controller) hiddenpath.Verifier {
 				return mock_hiddenpath.NewMockVerifier(ctrl)
 			},
-			assertErr: assert.Error,
+			assertErr: assertNotAuthorized(hiddenpath.ReasonNotMember),
 		},
 		"local not registry": {
 			reg: hiddenpath.Registration{
@@ -93,7 +102,7 @@ func TestRegistryRegister(t *testing.T) {
 			verifier: func(ctrl *gomock.Controller) hiddenpath.Verifier {
 				return mock_hiddenpath.NewMockVerifier(ctrl)
 			},
-			assertErr: assert.Error,
+			assertErr: assertNotAuthorized(hiddenpath.ReasonNotRegistry),
 		},
 		"invalid seg type": {
 			reg: hiddenpath.Registration{
@@ -137,7 +146,7 @@ func TestRegistryRegister(t *testing.T) {
 			db: func(ctrl *gomock.Controller) hiddenpath.Store {
 				db := mock_hiddenpath.NewMockStore(ctrl)
 				db.EXPECT().Put(gomock.Any(), []*seg.Meta{{Type: seg.TypeDown}},
-					mustParseGroupID(t, "ff00:0:4-5")).Return(serrors.New("test"))
+					mustParseGroupID(t, "ff00:0:4-5"), writer).Return(serrors.New("test"))
 				return db
 			},
 			verifier: func(ctrl *gomock.Controller) hiddenpath.Verifier {
@@ -159,7 +168,7 @@ func TestRegistryRegister(t *testing.T) {
 			db: func(ctrl *gomock.Controller) hiddenpath.Store {
 				db := mock_hiddenpath.NewMockStore(ctrl)
 				db.EXPECT().Put(gomock.Any(), []*seg.Meta{{Type: seg.TypeDown}},
-					mustParseGroupID(t, "ff00:0:4-5"))
+					mustParseGroupID(t, "ff00:0:4-5"), writer)
 				return db
 			},
 			verifier: func(ctrl *gomock.Controller) hiddenpath.Verifier {
@@ -192,3 +201,90 @@ func TestRegistryRegister(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryRegisterReplay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	localIA := xtest.MustParseIA("1-ff00:0:114")
+	writer := xtest.MustParseIA("2-ff00:0:221")
+	id := mustParseGroupID(t, "ff00:0:4-5")
+	reg := hiddenpath.Registration{
+		GroupID:   id,
+		Segments:  []*seg.Meta{{Type: seg.TypeDown}},
+		Peer:      &snet.SVCAddr{IA: writer, SVC: addr.SvcCS},
+		Nonce:     []byte("0123456789abcdef"),
+		Timestamp: time.Now(),
+	}
+	c := clock.NewManual(reg.Timestamp)
+	db := mock_hiddenpath.NewMockStore(ctrl)
+	db.EXPECT().Put(gomock.Any(), reg.Segments, id, writer)
+	verifier := mock_hiddenpath.NewMockVerifier(ctrl)
+	verifier.EXPECT().Verify(gomock.Any(), reg.Segments, reg.Peer)
+
+	h := hiddenpath.RegistryServer{
+		Groups: map[hiddenpath.GroupID]*hiddenpath.Group{
+			id: {
+				Writers:    map[addr.IA]struct{}{writer: {}},
+				Registries: map[addr.IA]struct{}{localIA: {}},
+			},
+		},
+		DB:       db,
+		Verifier: verifier,
+		LocalIA:  localIA,
+		Replay:   &hiddenpath.ReplayFilter{},
+		Clock:    c,
+	}
+	assert.NoError(t, h.Register(context.Background(), reg))
+	// The replayed registration is rejected before the segments are verified
+	// and stored.
+	assert.Error(t, h.Register(context.Background(), reg))
+
+	stale := reg
+	stale.Nonce = []byte("fedcba9876543210")
+	stale.Timestamp = time.Now().Add(-time.Hour)
+	assert.Error(t, h.Register(context.Background(), stale))
+
+	// Once the local time moved past the replay window, a registration with
+	// the same timestamp is too old.
+	late := reg
+	late.Nonce = []byte("0011223344556677")
+	c.Advance(hiddenpath.DefaultReplayWindow + time.Second)
+	assert.Error(t, h.Register(context.Background(), late))
+}
+
+func TestRegistryRegisterTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	id := mustParseGroupID(t, "ff00:0:4-5")
+	h := hiddenpath.RegistryServer{
+		LocalIA: xtest.MustParseIA("1-ff00:0:114"),
+	}
+	err := h.Register(context.Background(), hiddenpath.Registration{
+		GroupID:  id,
+		Segments: []*seg.Meta{{Type: seg.TypeDown}},
+		Peer:     &snet.SVCAddr{IA: xtest.MustParseIA("2-ff00:0:221"), SVC: addr.SvcCS},
+	})
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "hiddenpath.register", spans[0].Name())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("group", id.String()),
+		attribute.Int("segments", 1),
+	}, spans[0].Attributes())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func assertNotAuthorized(reason hiddenpath.AuthorizationReason) assert.ErrorAssertionFunc {
+	return func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+		var authErr *hiddenpath.GroupNotAuthorizedError
+		if !assert.ErrorAs(t, err, &authErr, msgAndArgs...) {
+			return false
+		}
+		return assert.Equal(t, reason, authErr.Reason, msgAndArgs...)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// RegistrySelector orders the registries of a hidden path group for a writer.
+// Instead of registering the segments at every registry of a group, the writer
+// registers them at the first registry of the order and only fails over to the
+// next one if the registration fails.
+//
+// Registries are ordered by the number of consecutive failed registrations and
+// probes, then by the load hint they reported with the last registration, and
+// finally by their position in the previous order. The last position keeps
+// the order stable across equally good registries. It is persisted, such that
+// the writer keeps using the same registry after a restart.
+type RegistrySelector struct {
+	stateFile string
+
+	mu     sync.Mutex
+	health map[addr.IA]*registryHealth
+	order  map[GroupID][]addr.IA
+}
+
+type registryHealth struct {
+	failures int
+	loadHint uint32
+}
+
+// registrySelectorState is the persisted state of the RegistrySelector.
+type registrySelectorState struct {
+	Groups map[string][]addr.IA `json:"groups"`
+}
+
+// NewRegistrySelector creates a registry selector that persists the failover
+// order in the given file. If the file exists, the order is initialized from
+// it. If the file name is empty, the order is not persisted.
+func NewRegistrySelector(stateFile string) (*RegistrySelector, error) {
+	s := &RegistrySelector{
+		stateFile: stateFile,
+		health:    make(map[addr.IA]*registryHealth),
+		order:     make(map[GroupID][]addr.IA),
+	}
+	if stateFile == "" {
+		return s, nil
+	}
+	raw, err := os.ReadFile(stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, serrors.WrapStr("reading registry selection state", err,
+			"file", stateFile)
+	}
+	var state registrySelectorState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, serrors.WrapStr("parsing registry selection state", err,
+			"file", stateFile)
+	}
+	for rawID, order := range state.Groups {
+		id, err := ParseGroupID(rawID)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing group ID", err, "file", stateFile)
+		}
+		s.order[id] = order
+	}
+	return s, nil
+}
+
+// Order returns the registries of the group in the order in which they should
+// be tried. If the order changed, it is persisted.
+func (s *RegistrySelector) Order(id GroupID, registries []addr.IA) []addr.IA {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous := make(map[addr.IA]int, len(s.order[id]))
+	for i, ia := range s.order[id] {
+		previous[ia] = i
+	}
+	position := func(ia addr.IA) int {
+		if i, ok := previous[ia]; ok {
+			return i
+		}
+		return len(previous)
+	}
+	order := append([]addr.IA{}, registries...)
+	sort.Slice(order, func(i, j int) bool {
+		a, b := s.healthOf(order[i]), s.healthOf(order[j])
+		if a.failures != b.failures {
+			return a.failures < b.failures
+		}
+		if a.loadHint != b.loadHint {
+			return a.loadHint < b.loadHint
+		}
+		if pa, pb := position(order[i]), position(order[j]); pa != pb {
+			return pa < pb
+		}
+		return order[i] < order[j]
+	})
+	if !equalOrder(s.order[id], order) {
+		s.order[id] = order
+		if err := s.persist(); err != nil {
+			log.Info("Failed to persist registry selection state", "err", err)
+		}
+	}
+	return order
+}
+
+// ReportRegistration records the outcome of a registration at the registry.
+func (s *RegistrySelector) ReportRegistration(registry addr.IA, reply RegistrationReply,
+	err error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.mutableHealth(registry)
+	if err != nil {
+		h.failures++
+		return
+	}
+	h.failures = 0
+	h.loadHint = reply.LoadHint
+}
+
+// ReportProbe records the outcome of a reachability probe of the registry. A
+// successful probe does not reset the load hint of the registry.
+func (s *RegistrySelector) ReportProbe(registry addr.IA, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.mutableHealth(registry)
+	if err != nil {
+		h.failures++
+		return
+	}
+	h.failures = 0
+}
+
+func (s *RegistrySelector) healthOf(registry addr.IA) registryHealth {
+	if h, ok := s.health[registry]; ok {
+		return *h
+	}
+	return registryHealth{}
+}
+
+func (s *RegistrySelector) mutableHealth(registry addr.IA) *registryHealth {
+	h, ok := s.health[registry]
+	if !ok {
+		h = &registryHealth{}
+		s.health[registry] = h
+	}
+	return h
+}
+
+func (s *RegistrySelector) persist() error {
+	if s.stateFile == "" {
+		return nil
+	}
+	state := registrySelectorState{Groups: make(map[string][]addr.IA, len(s.order))}
+	for id, order := range s.order {
+		state.Groups[id.String()] = order
+	}
+	raw, err := json.MarshalIndent(state, "", "    ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.stateFile), ".registry-selection-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.stateFile)
+}
+
+func equalOrder(a, b []addr.IA) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RegistryProber periodically probes the reachability of the registries in
+// the registration policy and reports the results to the selector. A registry
+// is reachable if its address can be resolved, which requires a path to the
+// registry and a successful discovery request.
+type RegistryProber struct {
+	// Policy is the registration policy of the writer.
+	Policy RegistrationPolicy
+	// Resolver resolves the addresses of the registries.
+	Resolver AddressResolver
+	// Selector receives the results of the probes.
+	Selector *RegistrySelector
+	// Timeout is the timeout of a single probe. If zero, the probe is bounded
+	// by the deadline of the run.
+	Timeout time.Duration
+}
+
+// Name returns the task name.
+func (p *RegistryProber) Name() string {
+	return "control_hiddenpath_registry_prober"
+}
+
+// Run probes all registries once.
+func (p *RegistryProber) Run(ctx context.Context) {
+	registries := make(map[addr.IA]struct{})
+	for _, ip := range p.Policy {
+		for _, group := range ip.Groups {
+			for registry := range group.Registries {
+				registries[registry] = struct{}{}
+			}
+		}
+	}
+	var wg sync.WaitGroup
+	for registry := range registries {
+		wg.Add(1)
+		go func(registry addr.IA) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			ctx := ctx
+			if p.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+				defer cancel()
+			}
+			_, err := p.Resolver.Resolve(ctx, registry)
+			if err != nil {
+				log.FromCtx(ctx).Debug("Hidden path registry not reachable",
+					"registry", registry, "err", err)
+			}
+			p.Selector.ReportProbe(registry, err)
+		}(registry)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath/mock_hiddenpath"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+func TestRegistrySelectorOrder(t *testing.T) {
+	id := mustParseGroupID(t, "ff00:0:140-2")
+	reg1 := xtest.MustParseIA("1-ff00:0:114")
+	reg2 := xtest.MustParseIA("1-ff00:0:115")
+	reg3 := xtest.MustParseIA("1-ff00:0:116")
+	registries := []addr.IA{reg3, reg1, reg2}
+
+	s := mustNewRegistrySelector(t, "")
+	assert.Equal(t, []addr.IA{reg1, reg2, reg3}, s.Order(id, registries))
+
+	// Failed registrations move the registry to the end.
+	s.ReportRegistration(reg1, hiddenpath.RegistrationReply{}, serrors.New("unavailable"))
+	assert.Equal(t, []addr.IA{reg2, reg3, reg1}, s.Order(id, registries))
+
+	// Lower load hints are preferred.
+	s.ReportRegistration(reg2, hiddenpath.RegistrationReply{LoadHint: 5}, nil)
+	s.ReportRegistration(reg3, hiddenpath.RegistrationReply{LoadHint: 2}, nil)
+	assert.Equal(t, []addr.IA{reg3, reg2, reg1}, s.Order(id, registries))
+
+	// A successful probe makes the registry eligible again. Among equally good
+	// registries, the previous order is kept.
+	s.ReportRegistration(reg2, hiddenpath.RegistrationReply{LoadHint: 2}, nil)
+	s.ReportProbe(reg1, nil)
+	assert.Equal(t, []addr.IA{reg1, reg3, reg2}, s.Order(id, registries))
+	s.ReportRegistration(reg1, hiddenpath.RegistrationReply{LoadHint: 2}, nil)
+	assert.Equal(t, []addr.IA{reg1, reg3, reg2}, s.Order(id, registries))
+}
+
+func TestRegistrySelectorPersistence(t *testing.T) {
+	id := mustParseGroupID(t, "ff00:0:140-2")
+	reg1 := xtest.MustParseIA("1-ff00:0:114")
+	reg2 := xtest.MustParseIA("1-ff00:0:115")
+	registries := []addr.IA{reg1, reg2}
+	file := filepath.Join(t.TempDir(), "registries.json")
+
+	s := mustNewRegistrySelector(t, file)
+	s.ReportRegistration(reg1, hiddenpath.RegistrationReply{}, serrors.New("unavailable"))
+	assert.Equal(t, []addr.IA{reg2, reg1}, s.Order(id, registries))
+
+	// The new selector has no health information, so the persisted failover
+	// order is used.
+	restarted := mustNewRegistrySelector(t, file)
+	assert.Equal(t, []addr.IA{reg2, reg1}, restarted.Order(id, registries))
+
+	t.Run("invalid state", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "invalid.json")
+		require.NoError(t, os.WriteFile(invalid, []byte("{"), 0644))
+		_, err := hiddenpath.NewRegistrySelector(invalid)
+		assert.Error(t, err)
+	})
+}
+
+func TestRegistryProberRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	id := mustParseGroupID(t, "ff00:0:140-2")
+	reg1 := xtest.MustParseIA("1-ff00:0:114")
+	reg2 := xtest.MustParseIA("1-ff00:0:115")
+	group := &hiddenpath.Group{
+		ID:         id,
+		Registries: map[addr.IA]struct{}{reg1: {}, reg2: {}},
+	}
+
+	resolver := mock_hiddenpath.NewMockAddressResolver(ctrl)
+	resolver.EXPECT().Resolve(gomock.Any(), reg1).Return(nil, serrors.New("no path"))
+	resolver.EXPECT().Resolve(gomock.Any(), reg2).Return(&net.UDPAddr{}, nil)
+
+	selector := mustNewRegistrySelector(t, "")
+	prober := &hiddenpath.RegistryProber{
+		Policy: hiddenpath.RegistrationPolicy{
+			1: {Groups: map[hiddenpath.GroupID]*hiddenpath.Group{id: group}},
+			2: {Groups: map[hiddenpath.GroupID]*hiddenpath.Group{id: group}},
+		},
+		Resolver: resolver,
+		Selector: selector,
+	}
+	prober.Run(context.Background())
+	assert.Equal(t, []addr.IA{reg2, reg1}, selector.Order(id, []addr.IA{reg1, reg2}))
+}
+
+func mustNewRegistrySelector(t *testing.T, file string) *hiddenpath.RegistrySelector {
+	t.Helper()
+	s, err := hiddenpath.NewRegistrySelector(file)
+	require.NoError(t, err)
+	return s
+}
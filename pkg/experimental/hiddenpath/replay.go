@@ -0,0 +1,102 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// DefaultReplayWindow is the default maximum difference between the
+	// signature timestamp of a registration and the local time.
+	DefaultReplayWindow = 5 * time.Minute
+	// MinNonceLength is the minimum length of a registration nonce.
+	MinNonceLength = 8
+)
+
+// ReplayFilter protects the registry against replayed registrations. A
+// registration is only accepted if its signature timestamp is within the replay
+// window around the local time and its nonce has not been seen from the same
+// writer within the window. Thus, a captured registration can neither be
+// replayed later nor repeatedly within the window.
+//
+// The filter is safe for concurrent use.
+type ReplayFilter struct {
+	// Window is the maximum difference between the signature timestamp and the
+	// local time. If zero, DefaultReplayWindow is used.
+	Window time.Duration
+
+	mu   sync.Mutex
+	seen map[replayKey]time.Time
+}
+
+type replayKey struct {
+	writer addr.IA
+	nonce  string
+}
+
+// Check checks the registration from the writer and records its nonce. An
+// error is returned if the registration is stale, from the future, or a
+// replay.
+func (f *ReplayFilter) Check(writer addr.IA, nonce []byte, ts, now time.Time) error {
+	if len(nonce) < MinNonceLength {
+		return serrors.New("nonce too short", "length", len(nonce), "min", MinNonceLength)
+	}
+	if ts.IsZero() {
+		return serrors.New("missing timestamp")
+	}
+	window := f.window()
+	if ts.Before(now.Add(-window)) {
+		return serrors.New("registration too old", "timestamp", ts, "window", window)
+	}
+	if ts.After(now.Add(window)) {
+		return serrors.New("registration timestamp in the future",
+			"timestamp", ts, "window", window)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prune(now)
+	key := replayKey{writer: writer, nonce: string(nonce)}
+	if _, ok := f.seen[key]; ok {
+		return serrors.New("replayed registration", "writer", writer)
+	}
+	if f.seen == nil {
+		f.seen = make(map[replayKey]time.Time)
+	}
+	// After this point in time, the registration is rejected as too old, so the
+	// nonce no longer needs to be remembered.
+	f.seen[key] = ts.Add(window)
+	return nil
+}
+
+func (f *ReplayFilter) window() time.Duration {
+	if f.Window == 0 {
+		return DefaultReplayWindow
+	}
+	return f.Window
+}
+
+func (f *ReplayFilter) prune(now time.Time) {
+	for key, expiry := range f.seen {
+		if now.After(expiry) {
+			delete(f.seen, key)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+func TestReplayFilterCheck(t *testing.T) {
+	writer := xtest.MustParseIA("1-ff00:0:110")
+	other := xtest.MustParseIA("1-ff00:0:111")
+	now := time.Now()
+	nonce := []byte("0123456789abcdef")
+
+	testCases := map[string]struct {
+		prepare   func(*hiddenpath.ReplayFilter)
+		nonce     []byte
+		ts        time.Time
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			nonce:     nonce,
+			ts:        now,
+			assertErr: assert.NoError,
+		},
+		"short nonce": {
+			nonce:     []byte("short"),
+			ts:        now,
+			assertErr: assert.Error,
+		},
+		"missing timestamp": {
+			nonce:     nonce,
+			assertErr: assert.Error,
+		},
+		"too old": {
+			nonce:     nonce,
+			ts:        now.Add(-time.Minute - time.Second),
+			assertErr: assert.Error,
+		},
+		"in the future": {
+			nonce:     nonce,
+			ts:        now.Add(time.Minute + time.Second),
+			assertErr: assert.Error,
+		},
+		"replay": {
+			prepare: func(f *hiddenpath.ReplayFilter) {
+				assert.NoError(t, f.Check(writer, nonce, now.Add(-time.Second), now))
+			},
+			nonce:     nonce,
+			ts:        now,
+			assertErr: assert.Error,
+		},
+		"same nonce other writer": {
+			prepare: func(f *hiddenpath.ReplayFilter) {
+				assert.NoError(t, f.Check(other, nonce, now, now))
+			},
+			nonce:     nonce,
+			ts:        now,
+			assertErr: assert.NoError,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			f := &hiddenpath.ReplayFilter{Window: time.Minute}
+			if tc.prepare != nil {
+				tc.prepare(f)
+			}
+			tc.assertErr(t, f.Check(writer, tc.nonce, tc.ts, now))
+		})
+	}
+}
+
+func TestReplayFilterExpiry(t *testing.T) {
+	writer := xtest.MustParseIA("1-ff00:0:110")
+	nonce := []byte("0123456789abcdef")
+	now := time.Now()
+	f := &hiddenpath.ReplayFilter{Window: time.Minute}
+
+	assert.NoError(t, f.Check(writer, nonce, now, now))
+	assert.Error(t, f.Check(writer, nonce, now, now.Add(time.Minute)))
+	// Once the window passed, the registration is rejected as too old, even
+	// though the nonce was forgotten.
+	assert.Error(t, f.Check(writer, nonce, now, now.Add(time.Minute+time.Second)))
+	// A fresh registration with the same nonce is not a replay anymore.
+	later := now.Add(2 * time.Minute)
+	assert.NoError(t, f.Check(writer, nonce, later, later))
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/pathdb"
@@ -29,14 +30,22 @@ type Store interface {
 	// Get gets the segments that end at the given IA and are in one of the given
 	// hidden path groups.
 	Get(context.Context, addr.IA, []GroupID) ([]*seg.Meta, error)
-	// Put puts the given segments in the database and associates them with the
-	// given hidden path group ID.
-	Put(context.Context, []*seg.Meta, GroupID) error
+	// Put puts the given segments of the writer AS in the database and
+	// associates them with the given hidden path group ID.
+	Put(ctx context.Context, segs []*seg.Meta, g GroupID, writer addr.IA) error
 }
 
 // Storer implements the path DB interface for a hidden segments.
 type Storer struct {
 	DB pathdb.DB
+	// Clock provides the current time. Segments that expired are not returned,
+	// even if they are not yet removed from the DB. If nil, the wall clock is
+	// used.
+	Clock clock.Clock
+	// Quota limits the number of segments per group and writer AS. Segments
+	// that exceed the quota are removed from the group. If nil, the number of
+	// segments is not limited.
+	Quota *SegmentQuota
 }
 
 // Get returns segments from the store using a db provider.
@@ -50,18 +59,52 @@ func (s *Storer) Get(ctx context.Context, ia addr.IA,
 	if err != nil {
 		return nil, err
 	}
-	return res.SegMetas(), nil
+	now := clock.Now(s.Clock)
+	var segs []*seg.Meta
+	for _, m := range res.SegMetas() {
+		if m.Segment.MaxExpiry().After(now) {
+			segs = append(segs, m)
+		}
+	}
+	return segs, nil
 }
 
-// Put stores segments in the store using a db provider.
-func (s *Storer) Put(ctx context.Context, segs []*seg.Meta, g GroupID) error {
+// Put stores segments in the store using a db provider. If the quota of the
+// writer is exceeded, the least recently registered segments are removed from
+// the group.
+func (s *Storer) Put(ctx context.Context, segs []*seg.Meta, g GroupID,
+	writer addr.IA) error {
+
+	evicted := make(map[string]struct{})
+	if s.Quota != nil {
+		registered := make([]QuotaSegment, 0, len(segs))
+		for _, m := range segs {
+			registered = append(registered, QuotaSegment{
+				ID:     m.Segment.ID(),
+				Expiry: m.Segment.MaxExpiry(),
+			})
+		}
+		for _, id := range s.Quota.Register(g, writer, registered, clock.Now(s.Clock)) {
+			evicted[string(id)] = struct{}{}
+		}
+	}
 	var errs serrors.List
-	for _, seg := range segs {
-		_, e := s.DB.InsertWithHPGroupIDs(ctx, seg, convert([]GroupID{g}))
+	for _, m := range segs {
+		if _, ok := evicted[string(m.Segment.ID())]; ok {
+			// The segment does not fit into the quota. It is still removed
+			// below, in case it was stored by an earlier registration.
+			continue
+		}
+		_, e := s.DB.InsertWithHPGroupIDs(ctx, m, convert([]GroupID{g}))
 		if e != nil {
 			errs = append(errs, e)
 		}
 	}
+	for id := range evicted {
+		if _, e := s.DB.DeleteFromHPGroup(ctx, []byte(id), g.ToUint64()); e != nil {
+			errs = append(errs, serrors.WrapStr("evicting segment", e, "group", g))
+		}
+	}
 	return errs.ToError()
 }
 
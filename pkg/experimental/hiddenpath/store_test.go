@@ -25,6 +25,8 @@ import (
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/pkg/private/xtest/graph"
@@ -101,6 +103,29 @@ func TestStorerGet(t *testing.T) {
 	}
 }
 
+func TestStorerGetExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	want, dbresult := createSegs(t)
+	db := mock_pathdb.NewMockDB(ctrl)
+	db.EXPECT().Get(gomock.Any(), gomock.Any()).Return(dbresult, nil).Times(2)
+	c := clock.NewManual(time.Now())
+	s := hiddenpath.Storer{
+		DB:    db,
+		Clock: c,
+	}
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	got, err := s.Get(context.Background(), ia, nil)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	c.Set(want[0].Segment.MaxExpiry())
+	got, err = s.Get(context.Background(), ia, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
 func TestStorerPut(t *testing.T) {
 	want, _ := createSegs(t)
 	testCases := map[string]struct {
@@ -147,12 +172,63 @@ func TestStorerPut(t *testing.T) {
 			s := hiddenpath.Storer{
 				DB: tc.db(ctrl),
 			}
-			err := s.Put(context.Background(), tc.inputSegs, tc.inputGroup)
+			err := s.Put(context.Background(), tc.inputSegs, tc.inputGroup,
+				xtest.MustParseIA("1-ff00:0:110"))
 			tc.assertErr(t, err)
 		})
 	}
 }
 
+func TestStorerPutQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	writer := xtest.MustParseIA("1-ff00:0:110")
+	group := hiddenpath.GroupID{OwnerAS: xtest.MustParseAS("ff00:0:111"), Suffix: 42}
+	seg1, seg2 := createSeg(t, 1), createSeg(t, 2)
+	evictions := metrics.NewTestCounter()
+	db := mock_pathdb.NewMockDB(ctrl)
+	s := hiddenpath.Storer{
+		DB:    db,
+		Clock: clock.NewManual(time.Now()),
+		Quota: &hiddenpath.SegmentQuota{MaxSegments: 1, Evictions: evictions},
+	}
+	db.EXPECT().InsertWithHPGroupIDs(gomock.Any(), seg1, []uint64{group.ToUint64()})
+	require.NoError(t, s.Put(context.Background(), []*seg.Meta{seg1}, group, writer))
+
+	// The registration of seg2 supersedes seg1.
+	db.EXPECT().InsertWithHPGroupIDs(gomock.Any(), seg2, []uint64{group.ToUint64()})
+	db.EXPECT().DeleteFromHPGroup(gomock.Any(), seg1.Segment.ID(), group.ToUint64())
+	require.NoError(t, s.Put(context.Background(), []*seg.Meta{seg2}, group, writer))
+	assert.Equal(t, 1, s.Quota.Usage(group, writer))
+	assert.Equal(t, float64(1), metrics.CounterValue(evictions))
+
+	// The quota of another writer is independent.
+	other := xtest.MustParseIA("1-ff00:0:112")
+	db.EXPECT().InsertWithHPGroupIDs(gomock.Any(), seg1, []uint64{group.ToUint64()})
+	require.NoError(t, s.Put(context.Background(), []*seg.Meta{seg1}, group, other))
+
+	// A registration that exceeds the quota by itself only stores the segments
+	// listed last.
+	db.EXPECT().InsertWithHPGroupIDs(gomock.Any(), seg1, []uint64{group.ToUint64()})
+	db.EXPECT().DeleteFromHPGroup(gomock.Any(), seg2.Segment.ID(), group.ToUint64())
+	require.NoError(t, s.Put(context.Background(), []*seg.Meta{seg2, seg1}, group, writer))
+	assert.Equal(t, float64(2), metrics.CounterValue(evictions))
+}
+
+func createSeg(t *testing.T, egress uint16) *seg.Meta {
+	t.Helper()
+	asEntry := seg.ASEntry{
+		Local: xtest.MustParseIA("1-ff00:0:110"),
+		HopEntry: seg.HopEntry{
+			HopField: seg.HopField{ConsEgress: egress},
+		},
+	}
+	ps, _ := seg.CreateSegment(time.Now(), 1337)
+	require.NoError(t, ps.AddASEntry(context.Background(), asEntry, graph.NewSigner()))
+	return &seg.Meta{Type: seg.TypeDown, Segment: ps}
+}
+
 func createSegs(t *testing.T) ([]*seg.Meta, query.Results) {
 	t.Helper()
 	asEntry := seg.ASEntry{
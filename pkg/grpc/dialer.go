@@ -21,6 +21,7 @@ import (
 
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/resolver/manual"
 
@@ -91,6 +92,14 @@ func (SimpleDialer) Dial(ctx context.Context, address net.Addr) (*grpc.ClientCon
 // for AS internal communication, and is capable of resolving svc addresses.
 type TCPDialer struct {
 	SvcResolver func(addr.SVC) []resolver.Address
+	// Credentials, if not nil, secures the connections, e.g., with mutual TLS.
+	// Otherwise, the connections are not secured.
+	Credentials credentials.TransportCredentials
+	// Health, if not nil, enables failover between the instances of an svc
+	// address. The instances are tried one after the other, and instances that
+	// recently failed are tried last. Otherwise, the requests are spread
+	// across all instances in round robin fashion.
+	Health *InstanceHealth
 }
 
 // Dial dials a gRPC connection over TCP. It resolves svc addresses.
@@ -102,24 +111,43 @@ func (t *TCPDialer) Dial(ctx context.Context, dst net.Addr) (*grpc.ClientConn, e
 			return nil, serrors.New("could not resolve")
 		}
 
-		r := manual.NewBuilderWithScheme("svc")
-		r.InitialState(resolver.State{Addresses: targets})
-		return grpc.DialContext(ctx, r.Scheme()+":///"+v.SVC.BaseString(),
-			grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"round_robin":{}}]}`),
-			grpc.WithInsecure(),
-			grpc.WithResolvers(r),
+		opts := []grpc.DialOption{
+			t.transportCredentials(),
 			UnaryClientInterceptor(),
 			StreamClientInterceptor(),
-		)
+		}
+		if t.Health != nil {
+			// The default pick_first balancer tries the targets in order.
+			targets = t.Health.Order(targets)
+			opts = append(opts,
+				grpc.WithContextDialer(t.Health.contextDialer()),
+				t.Health.unaryInterceptor(),
+			)
+		} else {
+			opts = append(opts, grpc.WithDefaultServiceConfig(
+				`{"loadBalancingConfig": [{"round_robin":{}}]}`))
+		}
+
+		r := manual.NewBuilderWithScheme("svc")
+		r.InitialState(resolver.State{Addresses: targets})
+		opts = append(opts, grpc.WithResolvers(r))
+		return grpc.DialContext(ctx, r.Scheme()+":///"+v.SVC.BaseString(), opts...)
 	}
 
 	return grpc.DialContext(ctx, dst.String(),
-		grpc.WithInsecure(),
+		t.transportCredentials(),
 		UnaryClientInterceptor(),
 		StreamClientInterceptor(),
 	)
 }
 
+func (t *TCPDialer) transportCredentials() grpc.DialOption {
+	if t.Credentials == nil {
+		return grpc.WithInsecure()
+	}
+	return grpc.WithTransportCredentials(t.Credentials)
+}
+
 // AddressRewriter redirects to QUIC endpoints.
 type AddressRewriter interface {
 	RedirectToQUIC(ctx context.Context, address net.Addr) (net.Addr, bool, error)
@@ -0,0 +1,152 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultFailureMemory is the default duration for which a failed instance is
+// remembered as unhealthy.
+const DefaultFailureMemory = 30 * time.Second
+
+// InstanceHealth remembers which instances of a service recently failed. It is
+// used to order the instances of an svc address such that instances that
+// recently failed are tried last. The zero value is ready to use.
+type InstanceHealth struct {
+	// FailureMemory is the duration for which a failure is remembered. If
+	// zero, DefaultFailureMemory is used.
+	FailureMemory time.Duration
+
+	mu       sync.Mutex
+	failures map[string]time.Time
+}
+
+// ReportFailure records a failure of the instance with the given address.
+func (h *InstanceHealth) ReportFailure(address string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.failures == nil {
+		h.failures = make(map[string]time.Time)
+	}
+	h.failures[address] = time.Now()
+}
+
+// ReportSuccess clears any failure recorded for the instance with the given
+// address.
+func (h *InstanceHealth) ReportSuccess(address string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.failures, address)
+}
+
+// Healthy indicates whether the instance with the given address did not fail
+// recently.
+func (h *InstanceHealth) Healthy(address string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.healthyLocked(address)
+}
+
+// Order returns the addresses ordered for failover. Healthy instances come
+// first in random order, such that the load is spread across them. Instances
+// that recently failed come last, the least recently failed one first.
+func (h *InstanceHealth) Order(addrs []resolver.Address) []resolver.Address {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var healthy, failed []resolver.Address
+	for _, a := range addrs {
+		if h.healthyLocked(a.Addr) {
+			healthy = append(healthy, a)
+		} else {
+			failed = append(failed, a)
+		}
+	}
+	rand.Shuffle(len(healthy), func(i, j int) {
+		healthy[i], healthy[j] = healthy[j], healthy[i]
+	})
+	sort.SliceStable(failed, func(i, j int) bool {
+		return h.failures[failed[i].Addr].Before(h.failures[failed[j].Addr])
+	})
+	return append(healthy, failed...)
+}
+
+func (h *InstanceHealth) healthyLocked(address string) bool {
+	failed, ok := h.failures[address]
+	if !ok {
+		return true
+	}
+	if time.Now().Sub(failed) < h.failureMemory() {
+		return false
+	}
+	delete(h.failures, address)
+	return true
+}
+
+func (h *InstanceHealth) failureMemory() time.Duration {
+	if h.FailureMemory == 0 {
+		return DefaultFailureMemory
+	}
+	return h.FailureMemory
+}
+
+// contextDialer returns a dialer that records instances that cannot be
+// connected to as failed.
+func (h *InstanceHealth) contextDialer() func(context.Context, string) (net.Conn, error) {
+	var d net.Dialer
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		conn, err := d.DialContext(ctx, "tcp", address)
+		if err != nil {
+			h.ReportFailure(address)
+			return nil, err
+		}
+		return conn, nil
+	}
+}
+
+// unaryInterceptor records the instance that served an RPC as failed if the
+// RPC failed with codes.Unavailable, and as healthy if it succeeded.
+func (h *InstanceHealth) unaryInterceptor() grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(
+		func(ctx context.Context, method string, req, reply interface{},
+			cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+			var p peer.Peer
+			err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Peer(&p))...)
+			if p.Addr == nil {
+				return err
+			}
+			switch {
+			case err == nil:
+				h.ReportSuccess(p.Addr.String())
+			case status.Code(err) == codes.Unavailable:
+				h.ReportFailure(p.Addr.String())
+			}
+			return err
+		},
+	)
+}
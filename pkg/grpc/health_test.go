@@ -0,0 +1,107 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/grpc/resolver"
+
+	"github.com/scionproto/scion/pkg/addr"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+func TestInstanceHealthOrder(t *testing.T) {
+	addrs := []resolver.Address{{Addr: "a"}, {Addr: "b"}, {Addr: "c"}, {Addr: "d"}}
+
+	t.Run("failed instances last", func(t *testing.T) {
+		var h libgrpc.InstanceHealth
+		h.ReportFailure("c")
+		h.ReportFailure("a")
+		ordered := h.Order(addrs)
+		require.Len(t, ordered, 4)
+		assert.ElementsMatch(t, []resolver.Address{{Addr: "b"}, {Addr: "d"}}, ordered[:2])
+		assert.Equal(t, []resolver.Address{{Addr: "c"}, {Addr: "a"}}, ordered[2:])
+		assert.False(t, h.Healthy("a"))
+		assert.True(t, h.Healthy("b"))
+	})
+	t.Run("success clears failure", func(t *testing.T) {
+		var h libgrpc.InstanceHealth
+		h.ReportFailure("a")
+		h.ReportSuccess("a")
+		assert.True(t, h.Healthy("a"))
+	})
+	t.Run("failure expires", func(t *testing.T) {
+		h := libgrpc.InstanceHealth{FailureMemory: time.Millisecond}
+		h.ReportFailure("a")
+		time.Sleep(2 * time.Millisecond)
+		assert.True(t, h.Healthy("a"))
+		assert.ElementsMatch(t, addrs, h.Order(addrs))
+	})
+}
+
+func TestTCPDialFailover(t *testing.T) {
+	lis, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	s := grpc.NewServer()
+	helloworldpb.RegisterGreeterServer(s, &server{})
+	var bg errgroup.Group
+	bg.Go(func() error {
+		return s.Serve(lis)
+	})
+	defer func() {
+		s.Stop()
+		assert.NoError(t, bg.Wait())
+	}()
+
+	unused, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	unusedAddr := unused.Addr().String()
+	require.NoError(t, unused.Close())
+
+	health := &libgrpc.InstanceHealth{}
+	dialer := libgrpc.TCPDialer{
+		SvcResolver: func(addr.SVC) []resolver.Address {
+			return []resolver.Address{{Addr: unusedAddr}, {Addr: lis.Addr().String()}}
+		},
+		Health: health,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for i := 0; i < 5; i++ {
+		conn, err := dialer.Dial(ctx, &snet.SVCAddr{SVC: addr.SvcCS})
+		require.NoError(t, err)
+		c := helloworldpb.NewGreeterClient(conn)
+		_, err = c.SayHello(ctx, &helloworldpb.HelloRequest{Name: "dummy"})
+		assert.NoError(t, err)
+		require.NoError(t, conn.Close())
+	}
+	assert.True(t, health.Healthy(lis.Addr().String()))
+	// The unused instance is tried first, unless it is already known to have
+	// failed, so it must have been recorded as failed.
+	assert.False(t, health.Healthy(unusedAddr))
+}
@@ -22,11 +22,28 @@ import (
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	opentracing "github.com/opentracing/opentracing-go"
 	jaeger "github.com/uber/jaeger-client-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
 )
 
+// TraceIDMetadataKey is the gRPC metadata key that carries the trace ID of a
+// request across service boundaries, see log.TraceID.
+const TraceIDMetadataKey = "scion-trace-id"
+
+// otelInstrumentationName is the name of the OpenTelemetry tracer that records
+// the spans of the RPCs.
+const otelInstrumentationName = "github.com/scionproto/scion/pkg/grpc"
+
+// LogIDClientInterceptor attaches the trace ID of the context to the outgoing
+// request. If the context does not carry a trace ID, a new one is created. The
+// trace ID is added to the context of the errors returned by the RPC.
 func LogIDClientInterceptor() grpc.UnaryClientInterceptor {
 	return func(
 		ctx context.Context,
@@ -37,13 +54,20 @@ func LogIDClientInterceptor() grpc.UnaryClientInterceptor {
 		opts ...grpc.CallOption,
 	) error {
 
-		logger := loggerFromSpan(opentracing.SpanFromContext(ctx))
+		ctx, id := outgoingTraceID(ctx)
+		logger := log.FromCtx(ctx)
 		logger.Debug("Outgoing RPC", "method", method, "target", cc.Target())
-		ctx = log.CtxWith(ctx, logger)
-		return invoker(ctx, method, req, resp, cc, opts...)
+		if err := invoker(ctx, method, req, resp, cc, opts...); err != nil {
+			return serrors.WrapStr("RPC failed", err, "method", method, "request_trace_id", id)
+		}
+		return nil
 	}
 }
 
+// LogIDClientStreamInterceptor attaches the trace ID of the context to the
+// outgoing stream. If the context does not carry a trace ID, a new one is
+// created.
+
 func LogIDClientStreamInterceptor() grpc.StreamClientInterceptor {
 	return func(
 		ctx context.Context,
@@ -54,14 +78,21 @@ func LogIDClientStreamInterceptor() grpc.StreamClientInterceptor {
 		opts ...grpc.CallOption,
 	) (grpc.ClientStream, error) {
 
-		logger := loggerFromSpan(opentracing.SpanFromContext(ctx))
+		ctx, id := outgoingTraceID(ctx)
+		logger := log.FromCtx(ctx)
 		logger.Debug("Outgoing RPC", "method", method, "target", cc.Target())
-		ctx = log.CtxWith(ctx, logger)
-
-		return streamer(ctx, desc, cc, method, opts...)
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, serrors.WrapStr("RPC failed", err, "method", method, "request_trace_id", id)
+		}
+		return stream, nil
 	}
 }
 
+// LogIDServerInterceptor attaches the trace ID of the incoming request to the
+// context of the handler. If the request does not carry a trace ID, a new one
+// is created.
+
 func LogIDServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -70,13 +101,16 @@ func LogIDServerInterceptor() grpc.UnaryServerInterceptor {
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
 
-		logger := loggerFromSpan(opentracing.SpanFromContext(ctx))
-		logger.Debug("Serving RPC", "method", info.FullMethod)
-		ctx = log.CtxWith(ctx, logger)
+		ctx = incomingTraceID(ctx)
+		log.FromCtx(ctx).Debug("Serving RPC", "method", info.FullMethod)
 		return handler(ctx, req)
 	}
 }
 
+// LogIDServerStreamInterceptor attaches the trace ID of the incoming stream to
+// the context of the handler. If the stream does not carry a trace ID, a new
+// one is created.
+
 func LogIDServerStreamInterceptor() grpc.StreamServerInterceptor {
 	return func(
 		srv interface{},
@@ -85,10 +119,8 @@ func LogIDServerStreamInterceptor() grpc.StreamServerInterceptor {
 		handler grpc.StreamHandler,
 	) error {
 
-		ctx := ss.Context()
-		logger := loggerFromSpan(opentracing.SpanFromContext(ctx))
-		logger.Debug("Serving RPC", "method", info.FullMethod)
-		ctx = log.CtxWith(ctx, logger)
+		ctx := incomingTraceID(ss.Context())
+		log.FromCtx(ctx).Debug("Serving RPC", "method", info.FullMethod)
 
 		ss = &serverStream{
 			ServerStream: ss,
@@ -110,6 +142,33 @@ func loggerFromSpan(span opentracing.Span) log.Logger {
 	return log.New("debug_id", log.NewDebugID(), "trace_id", id)
 }
 
+// outgoingTraceID returns a context with a logger that includes the trace ID
+// and with outgoing metadata that carries the trace ID. The trace ID is taken
+// from ctx, or created if ctx does not carry one.
+func outgoingTraceID(ctx context.Context) (context.Context, log.TraceID) {
+	id, ok := log.TraceIDFromCtx(ctx)
+	if !ok {
+		id = log.NewTraceID()
+	}
+	ctx = log.CtxWith(ctx, loggerFromSpan(opentracing.SpanFromContext(ctx)))
+	ctx = log.WithTraceID(ctx, id)
+	return metadata.AppendToOutgoingContext(ctx, TraceIDMetadataKey, id.String()), id
+}
+
+// incomingTraceID returns a context with a logger that includes the trace ID of
+// the incoming request. If the request does not carry a valid trace ID, a new
+// one is created.
+func incomingTraceID(ctx context.Context) context.Context {
+	id := log.NewTraceID()
+	if values := metadata.ValueFromIncomingContext(ctx, TraceIDMetadataKey); len(values) > 0 {
+		if parsed, err := log.ParseTraceID(values[0]); err == nil {
+			id = parsed
+		}
+	}
+	ctx = log.CtxWith(ctx, loggerFromSpan(opentracing.SpanFromContext(ctx)))
+	return log.WithTraceID(ctx, id)
+}
+
 func openTracingInterceptorWithTarget() grpc.UnaryClientInterceptor {
 	// Need to declare a new function here because the span decorator binds to one of the
 	// args of the previous interceptor.
@@ -140,6 +199,138 @@ func openTracingInterceptorWithTarget() grpc.UnaryClientInterceptor {
 	}
 }
 
+// OTelClientInterceptor records an OpenTelemetry client span for the outgoing
+// request and propagates its span context in the request metadata, using the
+// global tracer provider and text map propagator.
+func OTelClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, resp interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+
+		ctx, span := otel.Tracer(otelInstrumentationName).Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("target", cc.Target())),
+		)
+		defer span.End()
+		err := invoker(injectOTel(ctx), method, req, resp, cc, opts...)
+		otelError(span, err)
+		return err
+	}
+}
+
+// OTelClientStreamInterceptor propagates the OpenTelemetry span context of the
+// context in the metadata of the outgoing stream.
+func OTelClientStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+
+		return streamer(injectOTel(ctx), desc, cc, method, opts...)
+	}
+}
+
+// OTelServerInterceptor records an OpenTelemetry server span for the incoming
+// request. The span is a child of the span context that is propagated in the
+// request metadata, if any.
+func OTelServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+
+		ctx, span := startOTelServerSpan(ctx, info.FullMethod)
+		defer span.End()
+		resp, err := handler(ctx, req)
+		otelError(span, err)
+		return resp, err
+	}
+}
+
+// OTelServerStreamInterceptor records an OpenTelemetry server span for the
+// incoming stream. The span is a child of the span context that is propagated
+// in the stream metadata, if any.
+func OTelServerStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+
+		ctx, span := startOTelServerSpan(ss.Context(), info.FullMethod)
+		defer span.End()
+		err := handler(srv, &serverStream{
+			ServerStream: ss,
+			ctx:          ctx,
+		})
+		otelError(span, err)
+		return err
+	}
+}
+
+// injectOTel returns a context with outgoing metadata that carries the
+// OpenTelemetry span context of ctx.
+func injectOTel(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func startOTelServerSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	return otel.Tracer(otelInstrumentationName).Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+	)
+}
+
+func otelError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+}
+
+// metadataCarrier adapts the gRPC metadata to the OpenTelemetry text map
+// carrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
 // UnaryClientInterceptor constructs the default unary RPC client-side interceptor for
 // SCION control-plane applications.
 func UnaryClientInterceptor() grpc.DialOption {
@@ -147,6 +338,7 @@ func UnaryClientInterceptor() grpc.DialOption {
 		grpc_retry.UnaryClientInterceptor(),
 		grpcprom.UnaryClientInterceptor,
 		openTracingInterceptorWithTarget(),
+		OTelClientInterceptor(),
 		LogIDClientInterceptor(),
 	)
 }
@@ -157,6 +349,7 @@ func StreamClientInterceptor() grpc.DialOption {
 	return grpc.WithChainStreamInterceptor(
 		grpcprom.StreamClientInterceptor,
 		otgrpc.OpenTracingStreamClientInterceptor(opentracing.GlobalTracer()),
+		OTelClientStreamInterceptor(),
 		LogIDClientStreamInterceptor(),
 	)
 }
@@ -175,6 +368,7 @@ func UnaryServerInterceptor() grpc.ServerOption {
 	return grpc.ChainUnaryInterceptor(
 		grpcprom.UnaryServerInterceptor,
 		otgrpc.OpenTracingServerInterceptor(opentracing.GlobalTracer()),
+		OTelServerInterceptor(),
 		LogIDServerInterceptor(),
 	)
 }
@@ -185,6 +379,7 @@ func StreamServerInterceptor() grpc.ServerOption {
 	return grpc.ChainStreamInterceptor(
 		grpcprom.StreamServerInterceptor,
 		otgrpc.OpenTracingStreamServerInterceptor(opentracing.GlobalTracer()),
+		OTelServerStreamInterceptor(),
 		LogIDServerStreamInterceptor(),
 	)
 }
@@ -0,0 +1,160 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/grpc/status"
+
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// traceServer records the trace ID of the last request.
+type traceServer struct {
+	helloworldpb.UnimplementedGreeterServer
+	traceIDs chan log.TraceID
+}
+
+func (s *traceServer) SayHello(ctx context.Context,
+	in *helloworldpb.HelloRequest) (*helloworldpb.HelloReply, error) {
+
+	id, ok := log.TraceIDFromCtx(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "no trace ID")
+	}
+	s.traceIDs <- id
+	if in.Name == "fail" {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &helloworldpb.HelloReply{Message: "hello"}, nil
+}
+
+func TestTraceIDPropagation(t *testing.T) {
+	lis, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	srv := &traceServer{traceIDs: make(chan log.TraceID, 1)}
+	s := grpc.NewServer(libgrpc.UnaryServerInterceptor())
+	helloworldpb.RegisterGreeterServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(),
+		grpc.WithInsecure(),
+		libgrpc.UnaryClientInterceptor(),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	c := helloworldpb.NewGreeterClient(conn)
+
+	t.Run("context trace ID is propagated", func(t *testing.T) {
+		id := log.NewTraceID()
+		ctx := log.WithTraceID(context.Background(), id)
+		_, err := c.SayHello(ctx, &helloworldpb.HelloRequest{Name: "test"})
+		require.NoError(t, err)
+		assert.Equal(t, id, <-srv.traceIDs)
+	})
+	t.Run("trace ID is created if missing", func(t *testing.T) {
+		_, err := c.SayHello(context.Background(), &helloworldpb.HelloRequest{Name: "test"})
+		require.NoError(t, err)
+		assert.NotZero(t, <-srv.traceIDs)
+	})
+	t.Run("errors carry the trace ID", func(t *testing.T) {
+		id := log.NewTraceID()
+		ctx := log.WithTraceID(context.Background(), id)
+		_, err := c.SayHello(ctx, &helloworldpb.HelloRequest{Name: "fail"})
+		assert.Equal(t, id, <-srv.traceIDs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "request_trace_id="+id.String())
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+// spanServer records the OpenTelemetry span context of the last request.
+type spanServer struct {
+	helloworldpb.UnimplementedGreeterServer
+	spans chan trace.SpanContext
+}
+
+func (s *spanServer) SayHello(ctx context.Context,
+	in *helloworldpb.HelloRequest) (*helloworldpb.HelloReply, error) {
+
+	s.spans <- trace.SpanContextFromContext(ctx)
+	return &helloworldpb.HelloReply{Message: "hello"}, nil
+}
+
+func TestOTelPropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	}()
+
+	lis, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	srv := &spanServer{spans: make(chan trace.SpanContext, 1)}
+	s := grpc.NewServer(libgrpc.UnaryServerInterceptor())
+	helloworldpb.RegisterGreeterServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(),
+		grpc.WithInsecure(),
+		libgrpc.UnaryClientInterceptor(),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	c := helloworldpb.NewGreeterClient(conn)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	_, err = c.SayHello(ctx, &helloworldpb.HelloRequest{Name: "test"})
+	require.NoError(t, err)
+	parent.End()
+
+	serverSpan := <-srv.spans
+	assert.Equal(t, parent.SpanContext().TraceID(), serverSpan.TraceID())
+	assert.False(t, serverSpan.IsRemote())
+
+	spans := map[trace.SpanKind]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.SpanKind()] = span
+	}
+	require.Contains(t, spans, trace.SpanKindClient)
+	require.Contains(t, spans, trace.SpanKindServer)
+	client, server := spans[trace.SpanKindClient], spans[trace.SpanKindServer]
+	assert.Equal(t, parent.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.True(t, server.Parent().IsRemote())
+	assert.Equal(t, serverSpan.SpanID(), server.SpanContext().SpanID())
+}
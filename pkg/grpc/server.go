@@ -0,0 +1,41 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// GracefulStop gracefully stops the server, i.e., it stops accepting new
+// connections and RPCs and waits for the pending RPCs to finish. If ctx is
+// done before all pending RPCs finished, the server is stopped forcefully.
+func GracefulStop(ctx context.Context, s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		defer log.HandlePanic()
+		defer close(done)
+		s.GracefulStop()
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.Stop()
+		<-done
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+// blockingServer blocks requests until release is closed or the request is
+// canceled.
+type blockingServer struct {
+	helloworldpb.UnimplementedGreeterServer
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingServer) SayHello(ctx context.Context,
+	in *helloworldpb.HelloRequest) (*helloworldpb.HelloReply, error) {
+
+	close(s.started)
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &helloworldpb.HelloReply{Message: "hello"}, nil
+}
+
+func TestGracefulStop(t *testing.T) {
+	testCases := map[string]struct {
+		Release      bool
+		AssertRPCErr assert.ErrorAssertionFunc
+	}{
+		"pending RPC finishes": {
+			Release:      true,
+			AssertRPCErr: assert.NoError,
+		},
+		"pending RPC is aborted": {
+			Release:      false,
+			AssertRPCErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			lis, err := net.Listen("tcp4", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer lis.Close()
+
+			srv := &blockingServer{
+				started: make(chan struct{}),
+				release: make(chan struct{}),
+			}
+			s := grpc.NewServer()
+			helloworldpb.RegisterGreeterServer(s, srv)
+			go func() { _ = s.Serve(lis) }()
+
+			conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+			require.NoError(t, err)
+			defer conn.Close()
+			c := helloworldpb.NewGreeterClient(conn)
+
+			rpcErr := make(chan error, 1)
+			go func() {
+				_, err := c.SayHello(context.Background(),
+					&helloworldpb.HelloRequest{Name: "test"})
+				rpcErr <- err
+			}()
+			xtest.AssertReadReturnsBefore(t, srv.started, time.Second)
+
+			ctx, cancel := context.WithTimeout(context.Background(),
+				100*time.Millisecond)
+			defer cancel()
+			stopped := make(chan struct{})
+			go func() {
+				defer close(stopped)
+				libgrpc.GracefulStop(ctx, s)
+			}()
+			if tc.Release {
+				close(srv.release)
+			}
+			xtest.AssertReadReturnsBefore(t, stopped, time.Second)
+			tc.AssertRPCErr(t, <-rpcErr)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// StatusError returns a gRPC status error with the given code and message.
+// The details are attached to the status such that clients can inspect them
+// with ErrorDetail. Details that cannot be encoded are dropped.
+func StatusError(c codes.Code, msg string, details ...proto.Message) error {
+	pb := status.New(c, msg).Proto()
+	for _, d := range details {
+		a, err := anypb.New(d)
+		if err != nil {
+			continue
+		}
+		pb.Details = append(pb.Details, a)
+	}
+	return status.FromProto(pb).Err()
+}
+
+// ErrorDetail looks for an error detail of the same type as detail in the gRPC
+// status carried by err and unmarshals it into detail. The status is also
+// found if err wraps it. It returns false if there is no such detail.
+func ErrorDetail(err error, detail proto.Message) bool {
+	st, ok := status.FromError(err)
+	if !ok || st == nil {
+		return false
+	}
+	for _, a := range st.Proto().Details {
+		if a.MessageIs(detail) {
+			return a.UnmarshalTo(detail) == nil
+		}
+	}
+	return false
+}
+
+// DetailedStatus returns the gRPC status carried by err if it has error details
+// attached. Servers use it to pass the status of a failed upstream call on to
+// their clients, instead of reporting it as an internal error.
+func DetailedStatus(err error) (*status.Status, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st == nil || len(st.Proto().Details) == 0 {
+		return nil, false
+	}
+	return st, true
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	edpb "github.com/scionproto/scion/pkg/proto/error_details"
+)
+
+func TestStatusError(t *testing.T) {
+	want := &edpb.ErrorTRCMissing{Isd: 1, Base: 2, Serial: 3}
+	err := libgrpc.StatusError(codes.NotFound, "TRC not found", want)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	t.Run("detail", func(t *testing.T) {
+		var got edpb.ErrorTRCMissing
+		require.True(t, libgrpc.ErrorDetail(err, &got))
+		assert.True(t, proto.Equal(want, &got))
+	})
+	t.Run("wrapped", func(t *testing.T) {
+		var got edpb.ErrorTRCMissing
+		require.True(t, libgrpc.ErrorDetail(serrors.WrapStr("fetching", err), &got))
+		assert.True(t, proto.Equal(want, &got))
+	})
+	t.Run("other detail", func(t *testing.T) {
+		var got edpb.ErrorSegmentExpired
+		assert.False(t, libgrpc.ErrorDetail(err, &got))
+	})
+	t.Run("no status", func(t *testing.T) {
+		var got edpb.ErrorTRCMissing
+		assert.False(t, libgrpc.ErrorDetail(serrors.New("plain"), &got))
+	})
+}
+
+func TestDetailedStatus(t *testing.T) {
+	detailed := libgrpc.StatusError(codes.PermissionDenied, "denied",
+		&edpb.ErrorGroupNotAuthorized{GroupId: 1})
+	st, ok := libgrpc.DetailedStatus(serrors.WrapStr("looking up", detailed))
+	require.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	assert.Len(t, st.Details(), 1)
+
+	_, ok = libgrpc.DetailedStatus(status.Error(codes.Internal, "internal"))
+	assert.False(t, ok)
+	_, ok = libgrpc.DetailedStatus(serrors.New("plain"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const traceIDKey loggerContextKey = "trace_id"
+
+// TraceID identifies a request across service boundaries. For example, a path
+// lookup that is issued by an application, forwarded by the SCION Daemon to the
+// local control service and from there to a remote control service carries the
+// same TraceID in all the services. The TraceID is propagated in the gRPC
+// metadata, see the interceptors in package github.com/scionproto/scion/pkg/grpc.
+type TraceID uint64
+
+// NewTraceID creates a new random trace ID.
+func NewTraceID() TraceID {
+	return TraceID(rand.Uint64())
+}
+
+// ParseTraceID parses the hexadecimal representation of a trace ID.
+func ParseTraceID(s string) (TraceID, error) {
+	id, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, serrors.WrapStr("parsing trace ID", err, "input", s)
+	}
+	return TraceID(id), nil
+}
+
+func (id TraceID) String() string {
+	return fmt.Sprintf("%016x", uint64(id))
+}
+
+// WithTraceID returns a new context, based on ctx, that carries the trace ID.
+// The logger attached to the returned context includes the trace ID in all log
+// lines.
+func WithTraceID(ctx context.Context, id TraceID) context.Context {
+	ctx = context.WithValue(ctx, traceIDKey, id)
+	return CtxWith(ctx, FromCtx(ctx).New("request_trace_id", id))
+}
+
+// TraceIDFromCtx returns the trace ID carried by ctx, if there is one.
+func TraceIDFromCtx(ctx context.Context) (TraceID, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	id, ok := ctx.Value(traceIDKey).(TraceID)
+	return id, ok
+}
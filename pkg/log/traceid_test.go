@@ -0,0 +1,49 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/log"
+)
+
+func TestTraceID(t *testing.T) {
+	t.Run("parse roundtrip", func(t *testing.T) {
+		id := log.TraceID(0xab)
+		assert.Equal(t, "00000000000000ab", id.String())
+		parsed, err := log.ParseTraceID(id.String())
+		require.NoError(t, err)
+		assert.Equal(t, id, parsed)
+	})
+	t.Run("parse invalid", func(t *testing.T) {
+		_, err := log.ParseTraceID("not-hex")
+		assert.Error(t, err)
+	})
+	t.Run("context without trace ID", func(t *testing.T) {
+		_, ok := log.TraceIDFromCtx(context.Background())
+		assert.False(t, ok)
+	})
+	t.Run("context with trace ID", func(t *testing.T) {
+		ctx := log.WithTraceID(context.Background(), 42)
+		id, ok := log.TraceIDFromCtx(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, log.TraceID(42), id)
+	})
+}
@@ -140,6 +140,15 @@ func GaugeWith(g Gauge, labelValues ...string) Gauge {
 	return g.With(labelValues...)
 }
 
+// GaugeDelete removes the time series of the gauge, i.e., of the labels that
+// were provided with GaugeWith. This is a no-op if g is nil or if the gauge
+// does not support removing time series.
+func GaugeDelete(g Gauge) {
+	if d, ok := g.(interface{ Delete() }); ok {
+		d.Delete()
+	}
+}
+
 // HistogramObserve adds an observation to the histogram.
 // This is a no-op if h is nil.
 func HistogramObserve(h Histogram, value float64) {
@@ -118,6 +118,11 @@ func (g *gauge) Add(delta float64) {
 	g.gv.With(makeLabels(g.lvs...)).Add(delta)
 }
 
+// Delete removes the time series of the labels of the gauge.
+func (g *gauge) Delete() {
+	g.gv.Delete(makeLabels(g.lvs...))
+}
+
 // newGauge wraps the GaugeVec and returns a usable Gauge object.
 func newGauge(gv *prometheus.GaugeVec) *gauge {
 	return &gauge{
@@ -0,0 +1,78 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clock abstracts the current time, such that expiry logic can be
+// tested deterministically.
+//
+// Components that depend on the current time expose an optional Clock field.
+// If it is nil, the wall clock is used. Tests set it to a Manual clock and
+// move time forward explicitly:
+//
+//	c := clock.NewManual(time.Now())
+//	p := trust.FetchingProvider{Clock: c, ...}
+//	c.Advance(24 * time.Hour)
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Now returns the current time of the clock. If the clock is nil, the wall
+// clock time is returned.
+func Now(c Clock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// Manual is a clock that only moves when it is set or advanced explicitly.
+// It is safe for concurrent use.
+type Manual struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManual returns a manual clock that is set to now.
+func NewManual(now time.Time) *Manual {
+	return &Manual{now: now}
+}
+
+// Now returns the current time of the clock.
+func (m *Manual) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+// Set sets the clock to now.
+func (m *Manual) Set(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = now
+}
+
+// Advance moves the clock forward by d and returns the new time.
+func (m *Manual) Advance(d time.Duration) time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = m.now.Add(d)
+	return m.now
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/private/clock"
+)
+
+func TestNow(t *testing.T) {
+	before := time.Now()
+	now := clock.Now(nil)
+	assert.False(t, now.Before(before))
+	assert.False(t, now.After(time.Now()))
+
+	fixed := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, fixed, clock.Now(clock.NewManual(fixed)))
+}
+
+func TestManual(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewManual(start)
+	assert.Equal(t, start, c.Now())
+	assert.Equal(t, start.Add(time.Hour), c.Advance(time.Hour))
+	assert.Equal(t, start.Add(time.Hour), c.Now())
+	c.Set(start)
+	assert.Equal(t, start, c.Now())
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt/proto"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
 )
 
 const MinRevTTL = 10 * time.Second // MinRevTTL is the minimum lifetime of a revocation
@@ -55,6 +56,10 @@ type RevInfo struct {
 	RawTimestamp uint32
 	// RawTTL validity period of the revocation in seconds
 	RawTTL uint32
+	// Signed is the signed form of the revocation issued by the control
+	// service of the revoking AS. It is nil for revocations that were not
+	// signed, e.g., the ones reported by the border routers via SCMP.
+	Signed *cryptopb.SignedMessage
 }
 
 func (r *RevInfo) IA() addr.IA {
@@ -0,0 +1,2055 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.21.10
+// source: proto/control_plane/v1/admin.proto
+
+package control_plane
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BeaconsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BeaconsRequest) Reset() {
+	*x = BeaconsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeaconsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeaconsRequest) ProtoMessage() {}
+
+func (x *BeaconsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeaconsRequest.ProtoReflect.Descriptor instead.
+func (*BeaconsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type BeaconsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Neighbors []*NeighborBeacons `protobuf:"bytes,1,rep,name=neighbors,proto3" json:"neighbors,omitempty"`
+}
+
+func (x *BeaconsResponse) Reset() {
+	*x = BeaconsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeaconsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeaconsResponse) ProtoMessage() {}
+
+func (x *BeaconsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeaconsResponse.ProtoReflect.Descriptor instead.
+func (*BeaconsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BeaconsResponse) GetNeighbors() []*NeighborBeacons {
+	if x != nil {
+		return x.Neighbors
+	}
+	return nil
+}
+
+type NeighborBeacons struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsdAs       uint64                 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	InterfaceId uint64                 `protobuf:"varint,2,opt,name=interface_id,json=interfaceId,proto3" json:"interface_id,omitempty"`
+	Beacons     uint32                 `protobuf:"varint,3,opt,name=beacons,proto3" json:"beacons,omitempty"`
+	Origins     uint32                 `protobuf:"varint,4,opt,name=origins,proto3" json:"origins,omitempty"`
+	LastUpdated *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+}
+
+func (x *NeighborBeacons) Reset() {
+	*x = NeighborBeacons{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NeighborBeacons) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NeighborBeacons) ProtoMessage() {}
+
+func (x *NeighborBeacons) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NeighborBeacons.ProtoReflect.Descriptor instead.
+func (*NeighborBeacons) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NeighborBeacons) GetIsdAs() uint64 {
+	if x != nil {
+		return x.IsdAs
+	}
+	return 0
+}
+
+func (x *NeighborBeacons) GetInterfaceId() uint64 {
+	if x != nil {
+		return x.InterfaceId
+	}
+	return 0
+}
+
+func (x *NeighborBeacons) GetBeacons() uint32 {
+	if x != nil {
+		return x.Beacons
+	}
+	return 0
+}
+
+func (x *NeighborBeacons) GetOrigins() uint32 {
+	if x != nil {
+		return x.Origins
+	}
+	return 0
+}
+
+func (x *NeighborBeacons) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+type ListBeaconsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListBeaconsRequest) Reset() {
+	*x = ListBeaconsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBeaconsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBeaconsRequest) ProtoMessage() {}
+
+func (x *ListBeaconsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBeaconsRequest.ProtoReflect.Descriptor instead.
+func (*ListBeaconsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+type ListBeaconsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Beacons []*BeaconSummary `protobuf:"bytes,1,rep,name=beacons,proto3" json:"beacons,omitempty"`
+}
+
+func (x *ListBeaconsResponse) Reset() {
+	*x = ListBeaconsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBeaconsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBeaconsResponse) ProtoMessage() {}
+
+func (x *ListBeaconsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBeaconsResponse.ProtoReflect.Descriptor instead.
+func (*ListBeaconsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListBeaconsResponse) GetBeacons() []*BeaconSummary {
+	if x != nil {
+		return x.Beacons
+	}
+	return nil
+}
+
+type BeaconSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartIsdAs         uint64                 `protobuf:"varint,2,opt,name=start_isd_as,json=startIsdAs,proto3" json:"start_isd_as,omitempty"`
+	IngressInterfaceId uint64                 `protobuf:"varint,3,opt,name=ingress_interface_id,json=ingressInterfaceId,proto3" json:"ingress_interface_id,omitempty"`
+	Interfaces         []*SegmentInterface    `protobuf:"bytes,4,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	Usages             []string               `protobuf:"bytes,5,rep,name=usages,proto3" json:"usages,omitempty"`
+	Timestamp          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Expiration         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	LastUpdated        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+}
+
+func (x *BeaconSummary) Reset() {
+	*x = BeaconSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeaconSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeaconSummary) ProtoMessage() {}
+
+func (x *BeaconSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeaconSummary.ProtoReflect.Descriptor instead.
+func (*BeaconSummary) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BeaconSummary) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *BeaconSummary) GetStartIsdAs() uint64 {
+	if x != nil {
+		return x.StartIsdAs
+	}
+	return 0
+}
+
+func (x *BeaconSummary) GetIngressInterfaceId() uint64 {
+	if x != nil {
+		return x.IngressInterfaceId
+	}
+	return 0
+}
+
+func (x *BeaconSummary) GetInterfaces() []*SegmentInterface {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *BeaconSummary) GetUsages() []string {
+	if x != nil {
+		return x.Usages
+	}
+	return nil
+}
+
+func (x *BeaconSummary) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *BeaconSummary) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+func (x *BeaconSummary) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+type SegmentInterface struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsdAs       uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	InterfaceId uint64 `protobuf:"varint,2,opt,name=interface_id,json=interfaceId,proto3" json:"interface_id,omitempty"`
+}
+
+func (x *SegmentInterface) Reset() {
+	*x = SegmentInterface{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SegmentInterface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentInterface) ProtoMessage() {}
+
+func (x *SegmentInterface) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentInterface.ProtoReflect.Descriptor instead.
+func (*SegmentInterface) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SegmentInterface) GetIsdAs() uint64 {
+	if x != nil {
+		return x.IsdAs
+	}
+	return 0
+}
+
+func (x *SegmentInterface) GetInterfaceId() uint64 {
+	if x != nil {
+		return x.InterfaceId
+	}
+	return 0
+}
+
+type AdminSegmentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AdminSegmentsRequest) Reset() {
+	*x = AdminSegmentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdminSegmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminSegmentsRequest) ProtoMessage() {}
+
+func (x *AdminSegmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminSegmentsRequest.ProtoReflect.Descriptor instead.
+func (*AdminSegmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+type AdminSegmentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Segments []*SegmentSummary `protobuf:"bytes,1,rep,name=segments,proto3" json:"segments,omitempty"`
+}
+
+func (x *AdminSegmentsResponse) Reset() {
+	*x = AdminSegmentsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdminSegmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminSegmentsResponse) ProtoMessage() {}
+
+func (x *AdminSegmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminSegmentsResponse.ProtoReflect.Descriptor instead.
+func (*AdminSegmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AdminSegmentsResponse) GetSegments() []*SegmentSummary {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+type SegmentSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type             SegmentType            `protobuf:"varint,2,opt,name=type,proto3,enum=proto.control_plane.v1.SegmentType" json:"type,omitempty"`
+	StartIsdAs       uint64                 `protobuf:"varint,3,opt,name=start_isd_as,json=startIsdAs,proto3" json:"start_isd_as,omitempty"`
+	EndIsdAs         uint64                 `protobuf:"varint,4,opt,name=end_isd_as,json=endIsdAs,proto3" json:"end_isd_as,omitempty"`
+	Hops             uint32                 `protobuf:"varint,5,opt,name=hops,proto3" json:"hops,omitempty"`
+	Expiration       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	LastUpdated      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	HiddenPathGroups []uint64               `protobuf:"varint,8,rep,packed,name=hidden_path_groups,json=hiddenPathGroups,proto3" json:"hidden_path_groups,omitempty"`
+	Interfaces       []*SegmentInterface    `protobuf:"bytes,9,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+}
+
+func (x *SegmentSummary) Reset() {
+	*x = SegmentSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SegmentSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentSummary) ProtoMessage() {}
+
+func (x *SegmentSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentSummary.ProtoReflect.Descriptor instead.
+func (*SegmentSummary) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SegmentSummary) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SegmentSummary) GetType() SegmentType {
+	if x != nil {
+		return x.Type
+	}
+	return SegmentType_SEGMENT_TYPE_UNSPECIFIED
+}
+
+func (x *SegmentSummary) GetStartIsdAs() uint64 {
+	if x != nil {
+		return x.StartIsdAs
+	}
+	return 0
+}
+
+func (x *SegmentSummary) GetEndIsdAs() uint64 {
+	if x != nil {
+		return x.EndIsdAs
+	}
+	return 0
+}
+
+func (x *SegmentSummary) GetHops() uint32 {
+	if x != nil {
+		return x.Hops
+	}
+	return 0
+}
+
+func (x *SegmentSummary) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+func (x *SegmentSummary) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *SegmentSummary) GetHiddenPathGroups() []uint64 {
+	if x != nil {
+		return x.HiddenPathGroups
+	}
+	return nil
+}
+
+func (x *SegmentSummary) GetInterfaces() []*SegmentInterface {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+type TrustMaterialRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TrustMaterialRequest) Reset() {
+	*x = TrustMaterialRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrustMaterialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrustMaterialRequest) ProtoMessage() {}
+
+func (x *TrustMaterialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrustMaterialRequest.ProtoReflect.Descriptor instead.
+func (*TrustMaterialRequest) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{10}
+}
+
+type TrustMaterialResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Trcs   []*TRCSummary   `protobuf:"bytes,1,rep,name=trcs,proto3" json:"trcs,omitempty"`
+	Chains []*ChainSummary `protobuf:"bytes,2,rep,name=chains,proto3" json:"chains,omitempty"`
+}
+
+func (x *TrustMaterialResponse) Reset() {
+	*x = TrustMaterialResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrustMaterialResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrustMaterialResponse) ProtoMessage() {}
+
+func (x *TrustMaterialResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrustMaterialResponse.ProtoReflect.Descriptor instead.
+func (*TrustMaterialResponse) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TrustMaterialResponse) GetTrcs() []*TRCSummary {
+	if x != nil {
+		return x.Trcs
+	}
+	return nil
+}
+
+func (x *TrustMaterialResponse) GetChains() []*ChainSummary {
+	if x != nil {
+		return x.Chains
+	}
+	return nil
+}
+
+type TRCSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Isd       uint32                 `protobuf:"varint,1,opt,name=isd,proto3" json:"isd,omitempty"`
+	Base      uint64                 `protobuf:"varint,2,opt,name=base,proto3" json:"base,omitempty"`
+	Serial    uint64                 `protobuf:"varint,3,opt,name=serial,proto3" json:"serial,omitempty"`
+	NotBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+}
+
+func (x *TRCSummary) Reset() {
+	*x = TRCSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TRCSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TRCSummary) ProtoMessage() {}
+
+func (x *TRCSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TRCSummary.ProtoReflect.Descriptor instead.
+func (*TRCSummary) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TRCSummary) GetIsd() uint32 {
+	if x != nil {
+		return x.Isd
+	}
+	return 0
+}
+
+func (x *TRCSummary) GetBase() uint64 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *TRCSummary) GetSerial() uint64 {
+	if x != nil {
+		return x.Serial
+	}
+	return 0
+}
+
+func (x *TRCSummary) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *TRCSummary) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
+type ChainSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsdAs        uint64                 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	IssuerIsdAs  uint64                 `protobuf:"varint,2,opt,name=issuer_isd_as,json=issuerIsdAs,proto3" json:"issuer_isd_as,omitempty"`
+	SubjectKeyId []byte                 `protobuf:"bytes,3,opt,name=subject_key_id,json=subjectKeyId,proto3" json:"subject_key_id,omitempty"`
+	NotBefore    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+}
+
+func (x *ChainSummary) Reset() {
+	*x = ChainSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChainSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainSummary) ProtoMessage() {}
+
+func (x *ChainSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainSummary.ProtoReflect.Descriptor instead.
+func (*ChainSummary) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ChainSummary) GetIsdAs() uint64 {
+	if x != nil {
+		return x.IsdAs
+	}
+	return 0
+}
+
+func (x *ChainSummary) GetIssuerIsdAs() uint64 {
+	if x != nil {
+		return x.IssuerIsdAs
+	}
+	return 0
+}
+
+func (x *ChainSummary) GetSubjectKeyId() []byte {
+	if x != nil {
+		return x.SubjectKeyId
+	}
+	return nil
+}
+
+func (x *ChainSummary) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *ChainSummary) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
+type HiddenPathStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *HiddenPathStatsRequest) Reset() {
+	*x = HiddenPathStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathStatsRequest) ProtoMessage() {}
+
+func (x *HiddenPathStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathStatsRequest.ProtoReflect.Descriptor instead.
+func (*HiddenPathStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{14}
+}
+
+type HiddenPathStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Groups []*HiddenPathGroupStats `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *HiddenPathStatsResponse) Reset() {
+	*x = HiddenPathStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathStatsResponse) ProtoMessage() {}
+
+func (x *HiddenPathStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathStatsResponse.ProtoReflect.Descriptor instead.
+func (*HiddenPathStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HiddenPathStatsResponse) GetGroups() []*HiddenPathGroupStats {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type HiddenPathGroupStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId     uint64                 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Action      string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Allowed     uint64                 `protobuf:"varint,3,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Denied      uint64                 `protobuf:"varint,4,opt,name=denied,proto3" json:"denied,omitempty"`
+	Segments    uint64                 `protobuf:"varint,5,opt,name=segments,proto3" json:"segments,omitempty"`
+	LastRequest *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_request,json=lastRequest,proto3" json:"last_request,omitempty"`
+}
+
+func (x *HiddenPathGroupStats) Reset() {
+	*x = HiddenPathGroupStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathGroupStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathGroupStats) ProtoMessage() {}
+
+func (x *HiddenPathGroupStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathGroupStats.ProtoReflect.Descriptor instead.
+func (*HiddenPathGroupStats) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *HiddenPathGroupStats) GetGroupId() uint64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *HiddenPathGroupStats) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *HiddenPathGroupStats) GetAllowed() uint64 {
+	if x != nil {
+		return x.Allowed
+	}
+	return 0
+}
+
+func (x *HiddenPathGroupStats) GetDenied() uint64 {
+	if x != nil {
+		return x.Denied
+	}
+	return 0
+}
+
+func (x *HiddenPathGroupStats) GetSegments() uint64 {
+	if x != nil {
+		return x.Segments
+	}
+	return 0
+}
+
+func (x *HiddenPathGroupStats) GetLastRequest() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRequest
+	}
+	return nil
+}
+
+type RegistrationsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegistrationsRequest) Reset() {
+	*x = RegistrationsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegistrationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationsRequest) ProtoMessage() {}
+
+func (x *RegistrationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationsRequest.ProtoReflect.Descriptor instead.
+func (*RegistrationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{17}
+}
+
+type RegistrationsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Registrations []*RegistrationStats `protobuf:"bytes,1,rep,name=registrations,proto3" json:"registrations,omitempty"`
+}
+
+func (x *RegistrationsResponse) Reset() {
+	*x = RegistrationsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegistrationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationsResponse) ProtoMessage() {}
+
+func (x *RegistrationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationsResponse.ProtoReflect.Descriptor instead.
+func (*RegistrationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RegistrationsResponse) GetRegistrations() []*RegistrationStats {
+	if x != nil {
+		return x.Registrations
+	}
+	return nil
+}
+
+type RegistrationStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type        SegmentType            `protobuf:"varint,1,opt,name=type,proto3,enum=proto.control_plane.v1.SegmentType" json:"type,omitempty"`
+	Succeeded   uint64                 `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed      uint64                 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	LastSuccess *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	LastFailure *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_failure,json=lastFailure,proto3" json:"last_failure,omitempty"`
+}
+
+func (x *RegistrationStats) Reset() {
+	*x = RegistrationStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_admin_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegistrationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationStats) ProtoMessage() {}
+
+func (x *RegistrationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_admin_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationStats.ProtoReflect.Descriptor instead.
+func (*RegistrationStats) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RegistrationStats) GetType() SegmentType {
+	if x != nil {
+		return x.Type
+	}
+	return SegmentType_SEGMENT_TYPE_UNSPECIFIED
+}
+
+func (x *RegistrationStats) GetSucceeded() uint64 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *RegistrationStats) GetFailed() uint64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *RegistrationStats) GetLastSuccess() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSuccess
+	}
+	return nil
+}
+
+func (x *RegistrationStats) GetLastFailure() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFailure
+	}
+	return nil
+}
+
+var File_proto_control_plane_v1_admin_proto protoreflect.FileDescriptor
+
+var file_proto_control_plane_v1_admin_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f,
+	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x10, 0x0a, 0x0e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x58, 0x0a, 0x0f, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73,
+	0x52, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x22, 0xbe, 0x01, 0x0a, 0x0f,
+	0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x12,
+	0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x65, 0x61, 0x63,
+	0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x73, 0x12, 0x3d, 0x0a,
+	0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x14, 0x0a, 0x12,
+	0x4c, 0x69, 0x73, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x56, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x07, 0x62, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x52, 0x07, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x22, 0x8a, 0x03, 0x0a, 0x0d, 0x42,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0c,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x30,
+	0x0a, 0x14, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x69, 0x6e,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x49, 0x64,
+	0x12, 0x48, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x0a,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x3a, 0x0a, 0x0a,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x4c, 0x0a, 0x10, 0x53, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x69,
+	0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64,
+	0x41, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x49, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5b, 0x0a,
+	0x15, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xa0, 0x03, 0x0a, 0x0e, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x37, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x1c, 0x0a, 0x0a, 0x65, 0x6e, 0x64, 0x5f,
+	0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x6e,
+	0x64, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x70, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x68, 0x6f, 0x70, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f,
+	0x70, 0x61, 0x74, 0x68, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x10, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x12, 0x48, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x22, 0x16, 0x0a,
+	0x14, 0x54, 0x72, 0x75, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x8d, 0x01, 0x0a, 0x15, 0x54, 0x72, 0x75, 0x73, 0x74, 0x4d,
+	0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x36, 0x0a, 0x04, 0x74, 0x72, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x52, 0x43, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x52, 0x04, 0x74, 0x72, 0x63, 0x73, 0x12, 0x3c, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x06, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x73, 0x22, 0xbe, 0x01, 0x0a, 0x0a, 0x54, 0x52, 0x43, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x73, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x03, 0x69, 0x73, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x12, 0x39, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x37, 0x0a,
+	0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6e, 0x6f,
+	0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0xe3, 0x01, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x22,
+	0x0a, 0x0d, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x49, 0x73, 0x64,
+	0x41, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6b, 0x65,
+	0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f,
+	0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0x18, 0x0a, 0x16,
+	0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5f, 0x0a, 0x17, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
+	0x50, 0x61, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x44, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0xd6, 0x01, 0x0a, 0x14, 0x48, 0x69, 0x64, 0x64,
+	0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x64, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x64,
+	0x65, 0x6e, 0x69, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x68, 0x0a, 0x15, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4f, 0x0a, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x80, 0x02, 0x0a, 0x11, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x53,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x66,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x32, 0x97, 0x05, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5c, 0x0a, 0x07, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e,
+	0x73, 0x12, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x68, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x65, 0x61, 0x63,
+	0x6f, 0x6e, 0x73, 0x12, 0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f,
+	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x69,
+	0x0a, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2c, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6e, 0x0a, 0x0d, 0x54, 0x72, 0x75,
+	0x73, 0x74, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x2c, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x75, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x72, 0x75, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x74, 0x0a, 0x0f, 0x48, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x6e, 0x0a, 0x0d, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
+	0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63,
+	0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_control_plane_v1_admin_proto_rawDescOnce sync.Once
+	file_proto_control_plane_v1_admin_proto_rawDescData = file_proto_control_plane_v1_admin_proto_rawDesc
+)
+
+func file_proto_control_plane_v1_admin_proto_rawDescGZIP() []byte {
+	file_proto_control_plane_v1_admin_proto_rawDescOnce.Do(func() {
+		file_proto_control_plane_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_control_plane_v1_admin_proto_rawDescData)
+	})
+	return file_proto_control_plane_v1_admin_proto_rawDescData
+}
+
+var file_proto_control_plane_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_proto_control_plane_v1_admin_proto_goTypes = []interface{}{
+	(*BeaconsRequest)(nil),          // 0: proto.control_plane.v1.BeaconsRequest
+	(*BeaconsResponse)(nil),         // 1: proto.control_plane.v1.BeaconsResponse
+	(*NeighborBeacons)(nil),         // 2: proto.control_plane.v1.NeighborBeacons
+	(*ListBeaconsRequest)(nil),      // 3: proto.control_plane.v1.ListBeaconsRequest
+	(*ListBeaconsResponse)(nil),     // 4: proto.control_plane.v1.ListBeaconsResponse
+	(*BeaconSummary)(nil),           // 5: proto.control_plane.v1.BeaconSummary
+	(*SegmentInterface)(nil),        // 6: proto.control_plane.v1.SegmentInterface
+	(*AdminSegmentsRequest)(nil),    // 7: proto.control_plane.v1.AdminSegmentsRequest
+	(*AdminSegmentsResponse)(nil),   // 8: proto.control_plane.v1.AdminSegmentsResponse
+	(*SegmentSummary)(nil),          // 9: proto.control_plane.v1.SegmentSummary
+	(*TrustMaterialRequest)(nil),    // 10: proto.control_plane.v1.TrustMaterialRequest
+	(*TrustMaterialResponse)(nil),   // 11: proto.control_plane.v1.TrustMaterialResponse
+	(*TRCSummary)(nil),              // 12: proto.control_plane.v1.TRCSummary
+	(*ChainSummary)(nil),            // 13: proto.control_plane.v1.ChainSummary
+	(*HiddenPathStatsRequest)(nil),  // 14: proto.control_plane.v1.HiddenPathStatsRequest
+	(*HiddenPathStatsResponse)(nil), // 15: proto.control_plane.v1.HiddenPathStatsResponse
+	(*HiddenPathGroupStats)(nil),    // 16: proto.control_plane.v1.HiddenPathGroupStats
+	(*RegistrationsRequest)(nil),    // 17: proto.control_plane.v1.RegistrationsRequest
+	(*RegistrationsResponse)(nil),   // 18: proto.control_plane.v1.RegistrationsResponse
+	(*RegistrationStats)(nil),       // 19: proto.control_plane.v1.RegistrationStats
+	(*timestamppb.Timestamp)(nil),   // 20: google.protobuf.Timestamp
+	(SegmentType)(0),                // 21: proto.control_plane.v1.SegmentType
+}
+var file_proto_control_plane_v1_admin_proto_depIdxs = []int32{
+	2,  // 0: proto.control_plane.v1.BeaconsResponse.neighbors:type_name -> proto.control_plane.v1.NeighborBeacons
+	20, // 1: proto.control_plane.v1.NeighborBeacons.last_updated:type_name -> google.protobuf.Timestamp
+	5,  // 2: proto.control_plane.v1.ListBeaconsResponse.beacons:type_name -> proto.control_plane.v1.BeaconSummary
+	6,  // 3: proto.control_plane.v1.BeaconSummary.interfaces:type_name -> proto.control_plane.v1.SegmentInterface
+	20, // 4: proto.control_plane.v1.BeaconSummary.timestamp:type_name -> google.protobuf.Timestamp
+	20, // 5: proto.control_plane.v1.BeaconSummary.expiration:type_name -> google.protobuf.Timestamp
+	20, // 6: proto.control_plane.v1.BeaconSummary.last_updated:type_name -> google.protobuf.Timestamp
+	9,  // 7: proto.control_plane.v1.AdminSegmentsResponse.segments:type_name -> proto.control_plane.v1.SegmentSummary
+	21, // 8: proto.control_plane.v1.SegmentSummary.type:type_name -> proto.control_plane.v1.SegmentType
+	20, // 9: proto.control_plane.v1.SegmentSummary.expiration:type_name -> google.protobuf.Timestamp
+	20, // 10: proto.control_plane.v1.SegmentSummary.last_updated:type_name -> google.protobuf.Timestamp
+	6,  // 11: proto.control_plane.v1.SegmentSummary.interfaces:type_name -> proto.control_plane.v1.SegmentInterface
+	12, // 12: proto.control_plane.v1.TrustMaterialResponse.trcs:type_name -> proto.control_plane.v1.TRCSummary
+	13, // 13: proto.control_plane.v1.TrustMaterialResponse.chains:type_name -> proto.control_plane.v1.ChainSummary
+	20, // 14: proto.control_plane.v1.TRCSummary.not_before:type_name -> google.protobuf.Timestamp
+	20, // 15: proto.control_plane.v1.TRCSummary.not_after:type_name -> google.protobuf.Timestamp
+	20, // 16: proto.control_plane.v1.ChainSummary.not_before:type_name -> google.protobuf.Timestamp
+	20, // 17: proto.control_plane.v1.ChainSummary.not_after:type_name -> google.protobuf.Timestamp
+	16, // 18: proto.control_plane.v1.HiddenPathStatsResponse.groups:type_name -> proto.control_plane.v1.HiddenPathGroupStats
+	20, // 19: proto.control_plane.v1.HiddenPathGroupStats.last_request:type_name -> google.protobuf.Timestamp
+	19, // 20: proto.control_plane.v1.RegistrationsResponse.registrations:type_name -> proto.control_plane.v1.RegistrationStats
+	21, // 21: proto.control_plane.v1.RegistrationStats.type:type_name -> proto.control_plane.v1.SegmentType
+	20, // 22: proto.control_plane.v1.RegistrationStats.last_success:type_name -> google.protobuf.Timestamp
+	20, // 23: proto.control_plane.v1.RegistrationStats.last_failure:type_name -> google.protobuf.Timestamp
+	0,  // 24: proto.control_plane.v1.AdminService.Beacons:input_type -> proto.control_plane.v1.BeaconsRequest
+	3,  // 25: proto.control_plane.v1.AdminService.ListBeacons:input_type -> proto.control_plane.v1.ListBeaconsRequest
+	7,  // 26: proto.control_plane.v1.AdminService.Segments:input_type -> proto.control_plane.v1.AdminSegmentsRequest
+	10, // 27: proto.control_plane.v1.AdminService.TrustMaterial:input_type -> proto.control_plane.v1.TrustMaterialRequest
+	14, // 28: proto.control_plane.v1.AdminService.HiddenPathStats:input_type -> proto.control_plane.v1.HiddenPathStatsRequest
+	17, // 29: proto.control_plane.v1.AdminService.Registrations:input_type -> proto.control_plane.v1.RegistrationsRequest
+	1,  // 30: proto.control_plane.v1.AdminService.Beacons:output_type -> proto.control_plane.v1.BeaconsResponse
+	4,  // 31: proto.control_plane.v1.AdminService.ListBeacons:output_type -> proto.control_plane.v1.ListBeaconsResponse
+	8,  // 32: proto.control_plane.v1.AdminService.Segments:output_type -> proto.control_plane.v1.AdminSegmentsResponse
+	11, // 33: proto.control_plane.v1.AdminService.TrustMaterial:output_type -> proto.control_plane.v1.TrustMaterialResponse
+	15, // 34: proto.control_plane.v1.AdminService.HiddenPathStats:output_type -> proto.control_plane.v1.HiddenPathStatsResponse
+	18, // 35: proto.control_plane.v1.AdminService.Registrations:output_type -> proto.control_plane.v1.RegistrationsResponse
+	30, // [30:36] is the sub-list for method output_type
+	24, // [24:30] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_proto_control_plane_v1_admin_proto_init() }
+func file_proto_control_plane_v1_admin_proto_init() {
+	if File_proto_control_plane_v1_admin_proto != nil {
+		return
+	}
+	file_proto_control_plane_v1_seg_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_proto_control_plane_v1_admin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BeaconsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BeaconsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NeighborBeacons); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBeaconsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBeaconsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BeaconSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SegmentInterface); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdminSegmentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdminSegmentsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SegmentSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrustMaterialRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrustMaterialResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TRCSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChainSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathGroupStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegistrationsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegistrationsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_admin_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegistrationStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_control_plane_v1_admin_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_control_plane_v1_admin_proto_goTypes,
+		DependencyIndexes: file_proto_control_plane_v1_admin_proto_depIdxs,
+		MessageInfos:      file_proto_control_plane_v1_admin_proto_msgTypes,
+	}.Build()
+	File_proto_control_plane_v1_admin_proto = out.File
+	file_proto_control_plane_v1_admin_proto_rawDesc = nil
+	file_proto_control_plane_v1_admin_proto_goTypes = nil
+	file_proto_control_plane_v1_admin_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	Beacons(ctx context.Context, in *BeaconsRequest, opts ...grpc.CallOption) (*BeaconsResponse, error)
+	ListBeacons(ctx context.Context, in *ListBeaconsRequest, opts ...grpc.CallOption) (*ListBeaconsResponse, error)
+	Segments(ctx context.Context, in *AdminSegmentsRequest, opts ...grpc.CallOption) (*AdminSegmentsResponse, error)
+	TrustMaterial(ctx context.Context, in *TrustMaterialRequest, opts ...grpc.CallOption) (*TrustMaterialResponse, error)
+	HiddenPathStats(ctx context.Context, in *HiddenPathStatsRequest, opts ...grpc.CallOption) (*HiddenPathStatsResponse, error)
+	Registrations(ctx context.Context, in *RegistrationsRequest, opts ...grpc.CallOption) (*RegistrationsResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) Beacons(ctx context.Context, in *BeaconsRequest, opts ...grpc.CallOption) (*BeaconsResponse, error) {
+	out := new(BeaconsResponse)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.AdminService/Beacons", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListBeacons(ctx context.Context, in *ListBeaconsRequest, opts ...grpc.CallOption) (*ListBeaconsResponse, error) {
+	out := new(ListBeaconsResponse)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.AdminService/ListBeacons", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Segments(ctx context.Context, in *AdminSegmentsRequest, opts ...grpc.CallOption) (*AdminSegmentsResponse, error) {
+	out := new(AdminSegmentsResponse)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.AdminService/Segments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TrustMaterial(ctx context.Context, in *TrustMaterialRequest, opts ...grpc.CallOption) (*TrustMaterialResponse, error) {
+	out := new(TrustMaterialResponse)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.AdminService/TrustMaterial", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) HiddenPathStats(ctx context.Context, in *HiddenPathStatsRequest, opts ...grpc.CallOption) (*HiddenPathStatsResponse, error) {
+	out := new(HiddenPathStatsResponse)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.AdminService/HiddenPathStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Registrations(ctx context.Context, in *RegistrationsRequest, opts ...grpc.CallOption) (*RegistrationsResponse, error) {
+	out := new(RegistrationsResponse)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.AdminService/Registrations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	Beacons(context.Context, *BeaconsRequest) (*BeaconsResponse, error)
+	ListBeacons(context.Context, *ListBeaconsRequest) (*ListBeaconsResponse, error)
+	Segments(context.Context, *AdminSegmentsRequest) (*AdminSegmentsResponse, error)
+	TrustMaterial(context.Context, *TrustMaterialRequest) (*TrustMaterialResponse, error)
+	HiddenPathStats(context.Context, *HiddenPathStatsRequest) (*HiddenPathStatsResponse, error)
+	Registrations(context.Context, *RegistrationsRequest) (*RegistrationsResponse, error)
+}
+
+// UnimplementedAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (*UnimplementedAdminServiceServer) Beacons(context.Context, *BeaconsRequest) (*BeaconsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Beacons not implemented")
+}
+func (*UnimplementedAdminServiceServer) ListBeacons(context.Context, *ListBeaconsRequest) (*ListBeaconsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBeacons not implemented")
+}
+func (*UnimplementedAdminServiceServer) Segments(context.Context, *AdminSegmentsRequest) (*AdminSegmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Segments not implemented")
+}
+func (*UnimplementedAdminServiceServer) TrustMaterial(context.Context, *TrustMaterialRequest) (*TrustMaterialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrustMaterial not implemented")
+}
+func (*UnimplementedAdminServiceServer) HiddenPathStats(context.Context, *HiddenPathStatsRequest) (*HiddenPathStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HiddenPathStats not implemented")
+}
+func (*UnimplementedAdminServiceServer) Registrations(context.Context, *RegistrationsRequest) (*RegistrationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Registrations not implemented")
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_Beacons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeaconsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Beacons(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.AdminService/Beacons",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Beacons(ctx, req.(*BeaconsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListBeacons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBeaconsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListBeacons(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.AdminService/ListBeacons",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListBeacons(ctx, req.(*ListBeaconsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Segments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminSegmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Segments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.AdminService/Segments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Segments(ctx, req.(*AdminSegmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TrustMaterial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrustMaterialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TrustMaterial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.AdminService/TrustMaterial",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TrustMaterial(ctx, req.(*TrustMaterialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_HiddenPathStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HiddenPathStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).HiddenPathStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.AdminService/HiddenPathStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).HiddenPathStats(ctx, req.(*HiddenPathStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Registrations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Registrations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.AdminService/Registrations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Registrations(ctx, req.(*RegistrationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.control_plane.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Beacons",
+			Handler:    _AdminService_Beacons_Handler,
+		},
+		{
+			MethodName: "ListBeacons",
+			Handler:    _AdminService_ListBeacons_Handler,
+		},
+		{
+			MethodName: "Segments",
+			Handler:    _AdminService_Segments_Handler,
+		},
+		{
+			MethodName: "TrustMaterial",
+			Handler:    _AdminService_TrustMaterial_Handler,
+		},
+		{
+			MethodName: "HiddenPathStats",
+			Handler:    _AdminService_HiddenPathStats_Handler,
+		},
+		{
+			MethodName: "Registrations",
+			Handler:    _AdminService_Registrations_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/control_plane/v1/admin.proto",
+}
@@ -0,0 +1,479 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.21.10
+// source: proto/control_plane/v1/colibri.proto
+
+package control_plane
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ColibriReservationID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	As     uint64 `protobuf:"varint,1,opt,name=as,proto3" json:"as,omitempty"`
+	Suffix uint32 `protobuf:"varint,2,opt,name=suffix,proto3" json:"suffix,omitempty"`
+}
+
+func (x *ColibriReservationID) Reset() {
+	*x = ColibriReservationID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_colibri_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColibriReservationID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColibriReservationID) ProtoMessage() {}
+
+func (x *ColibriReservationID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_colibri_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColibriReservationID.ProtoReflect.Descriptor instead.
+func (*ColibriReservationID) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_colibri_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ColibriReservationID) GetAs() uint64 {
+	if x != nil {
+		return x.As
+	}
+	return 0
+}
+
+func (x *ColibriReservationID) GetSuffix() uint32 {
+	if x != nil {
+		return x.Suffix
+	}
+	return 0
+}
+
+type ColibriReserveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SrcIsdAs   uint64                 `protobuf:"varint,1,opt,name=src_isd_as,json=srcIsdAs,proto3" json:"src_isd_as,omitempty"`
+	DstIsdAs   uint64                 `protobuf:"varint,2,opt,name=dst_isd_as,json=dstIsdAs,proto3" json:"dst_isd_as,omitempty"`
+	Ingress    uint64                 `protobuf:"varint,3,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	Egress     uint64                 `protobuf:"varint,4,opt,name=egress,proto3" json:"egress,omitempty"`
+	Bandwidth  uint64                 `protobuf:"varint,5,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	Expiration *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expiration,proto3" json:"expiration,omitempty"`
+}
+
+func (x *ColibriReserveRequest) Reset() {
+	*x = ColibriReserveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_colibri_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColibriReserveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColibriReserveRequest) ProtoMessage() {}
+
+func (x *ColibriReserveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_colibri_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColibriReserveRequest.ProtoReflect.Descriptor instead.
+func (*ColibriReserveRequest) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_colibri_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ColibriReserveRequest) GetSrcIsdAs() uint64 {
+	if x != nil {
+		return x.SrcIsdAs
+	}
+	return 0
+}
+
+func (x *ColibriReserveRequest) GetDstIsdAs() uint64 {
+	if x != nil {
+		return x.DstIsdAs
+	}
+	return 0
+}
+
+func (x *ColibriReserveRequest) GetIngress() uint64 {
+	if x != nil {
+		return x.Ingress
+	}
+	return 0
+}
+
+func (x *ColibriReserveRequest) GetEgress() uint64 {
+	if x != nil {
+		return x.Egress
+	}
+	return 0
+}
+
+func (x *ColibriReserveRequest) GetBandwidth() uint64 {
+	if x != nil {
+		return x.Bandwidth
+	}
+	return 0
+}
+
+func (x *ColibriReserveRequest) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+type ColibriReserveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Id            *ColibriReservationID  `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Bandwidth     uint64                 `protobuf:"varint,3,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	Expiration    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	FailureReason string                 `protobuf:"bytes,5,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+}
+
+func (x *ColibriReserveResponse) Reset() {
+	*x = ColibriReserveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_colibri_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColibriReserveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColibriReserveResponse) ProtoMessage() {}
+
+func (x *ColibriReserveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_colibri_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColibriReserveResponse.ProtoReflect.Descriptor instead.
+func (*ColibriReserveResponse) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_colibri_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ColibriReserveResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *ColibriReserveResponse) GetId() *ColibriReservationID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ColibriReserveResponse) GetBandwidth() uint64 {
+	if x != nil {
+		return x.Bandwidth
+	}
+	return 0
+}
+
+func (x *ColibriReserveResponse) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+func (x *ColibriReserveResponse) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+var File_proto_control_plane_v1_colibri_proto protoreflect.FileDescriptor
+
+var file_proto_control_plane_v1_colibri_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f,
+	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x3e, 0x0a, 0x14, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x61, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x66, 0x66, 0x69,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x22,
+	0xdf, 0x01, 0x0a, 0x15, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x0a, 0x73, 0x72, 0x63,
+	0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73,
+	0x72, 0x63, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x1c, 0x0a, 0x0a, 0x64, 0x73, 0x74, 0x5f, 0x69,
+	0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x73, 0x74,
+	0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x61, 0x6e, 0x64,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0xf3, 0x01, 0x0a, 0x16, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x3c, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6c, 0x69, 0x62, 0x72, 0x69, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x25, 0x0a, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x32, 0x7c, 0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6a, 0x0a, 0x07, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x12, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6c, 0x69, 0x62, 0x72, 0x69, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c,
+	0x69, 0x62, 0x72, 0x69, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73,
+	0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_control_plane_v1_colibri_proto_rawDescOnce sync.Once
+	file_proto_control_plane_v1_colibri_proto_rawDescData = file_proto_control_plane_v1_colibri_proto_rawDesc
+)
+
+func file_proto_control_plane_v1_colibri_proto_rawDescGZIP() []byte {
+	file_proto_control_plane_v1_colibri_proto_rawDescOnce.Do(func() {
+		file_proto_control_plane_v1_colibri_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_control_plane_v1_colibri_proto_rawDescData)
+	})
+	return file_proto_control_plane_v1_colibri_proto_rawDescData
+}
+
+var file_proto_control_plane_v1_colibri_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_control_plane_v1_colibri_proto_goTypes = []interface{}{
+	(*ColibriReservationID)(nil),   // 0: proto.control_plane.v1.ColibriReservationID
+	(*ColibriReserveRequest)(nil),  // 1: proto.control_plane.v1.ColibriReserveRequest
+	(*ColibriReserveResponse)(nil), // 2: proto.control_plane.v1.ColibriReserveResponse
+	(*timestamppb.Timestamp)(nil),  // 3: google.protobuf.Timestamp
+}
+var file_proto_control_plane_v1_colibri_proto_depIdxs = []int32{
+	3, // 0: proto.control_plane.v1.ColibriReserveRequest.expiration:type_name -> google.protobuf.Timestamp
+	0, // 1: proto.control_plane.v1.ColibriReserveResponse.id:type_name -> proto.control_plane.v1.ColibriReservationID
+	3, // 2: proto.control_plane.v1.ColibriReserveResponse.expiration:type_name -> google.protobuf.Timestamp
+	1, // 3: proto.control_plane.v1.ColibriService.Reserve:input_type -> proto.control_plane.v1.ColibriReserveRequest
+	2, // 4: proto.control_plane.v1.ColibriService.Reserve:output_type -> proto.control_plane.v1.ColibriReserveResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_control_plane_v1_colibri_proto_init() }
+func file_proto_control_plane_v1_colibri_proto_init() {
+	if File_proto_control_plane_v1_colibri_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_control_plane_v1_colibri_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColibriReservationID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_colibri_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColibriReserveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_colibri_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColibriReserveResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_control_plane_v1_colibri_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_control_plane_v1_colibri_proto_goTypes,
+		DependencyIndexes: file_proto_control_plane_v1_colibri_proto_depIdxs,
+		MessageInfos:      file_proto_control_plane_v1_colibri_proto_msgTypes,
+	}.Build()
+	File_proto_control_plane_v1_colibri_proto = out.File
+	file_proto_control_plane_v1_colibri_proto_rawDesc = nil
+	file_proto_control_plane_v1_colibri_proto_goTypes = nil
+	file_proto_control_plane_v1_colibri_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ColibriServiceClient is the client API for ColibriService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ColibriServiceClient interface {
+	Reserve(ctx context.Context, in *ColibriReserveRequest, opts ...grpc.CallOption) (*ColibriReserveResponse, error)
+}
+
+type colibriServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewColibriServiceClient(cc grpc.ClientConnInterface) ColibriServiceClient {
+	return &colibriServiceClient{cc}
+}
+
+func (c *colibriServiceClient) Reserve(ctx context.Context, in *ColibriReserveRequest, opts ...grpc.CallOption) (*ColibriReserveResponse, error) {
+	out := new(ColibriReserveResponse)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.ColibriService/Reserve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ColibriServiceServer is the server API for ColibriService service.
+type ColibriServiceServer interface {
+	Reserve(context.Context, *ColibriReserveRequest) (*ColibriReserveResponse, error)
+}
+
+// UnimplementedColibriServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedColibriServiceServer struct {
+}
+
+func (*UnimplementedColibriServiceServer) Reserve(context.Context, *ColibriReserveRequest) (*ColibriReserveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reserve not implemented")
+}
+
+func RegisterColibriServiceServer(s *grpc.Server, srv ColibriServiceServer) {
+	s.RegisterService(&_ColibriService_serviceDesc, srv)
+}
+
+func _ColibriService_Reserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ColibriReserveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriServiceServer).Reserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.ColibriService/Reserve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriServiceServer).Reserve(ctx, req.(*ColibriReserveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ColibriService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.control_plane.v1.ColibriService",
+	HandlerType: (*ColibriServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Reserve",
+			Handler:    _ColibriService_Reserve_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/control_plane/v1/colibri.proto",
+}
@@ -300,6 +300,100 @@ func (x *TRCResponse) GetTrc() []byte {
 	return nil
 }
 
+type CRLsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AuthorityKeyId []byte `protobuf:"bytes,1,opt,name=authority_key_id,json=authorityKeyId,proto3" json:"authority_key_id,omitempty"`
+}
+
+func (x *CRLsRequest) Reset() {
+	*x = CRLsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_cppki_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRLsRequest) ProtoMessage() {}
+
+func (x *CRLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_cppki_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRLsRequest.ProtoReflect.Descriptor instead.
+func (*CRLsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_cppki_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CRLsRequest) GetAuthorityKeyId() []byte {
+	if x != nil {
+		return x.AuthorityKeyId
+	}
+	return nil
+}
+
+type CRLsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Crls [][]byte `protobuf:"bytes,1,rep,name=crls,proto3" json:"crls,omitempty"`
+}
+
+func (x *CRLsResponse) Reset() {
+	*x = CRLsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_cppki_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRLsResponse) ProtoMessage() {}
+
+func (x *CRLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_cppki_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRLsResponse.ProtoReflect.Descriptor instead.
+func (*CRLsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_cppki_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CRLsResponse) GetCrls() [][]byte {
+	if x != nil {
+		return x.Crls
+	}
+	return nil
+}
+
 type VerificationKeyID struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -314,7 +408,7 @@ type VerificationKeyID struct {
 func (x *VerificationKeyID) Reset() {
 	*x = VerificationKeyID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_cppki_proto_msgTypes[5]
+		mi := &file_proto_control_plane_v1_cppki_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -327,7 +421,7 @@ func (x *VerificationKeyID) String() string {
 func (*VerificationKeyID) ProtoMessage() {}
 
 func (x *VerificationKeyID) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_cppki_proto_msgTypes[5]
+	mi := &file_proto_control_plane_v1_cppki_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -340,7 +434,7 @@ func (x *VerificationKeyID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerificationKeyID.ProtoReflect.Descriptor instead.
 func (*VerificationKeyID) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_cppki_proto_rawDescGZIP(), []int{5}
+	return file_proto_control_plane_v1_cppki_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *VerificationKeyID) GetIsdAs() uint64 {
@@ -402,32 +496,43 @@ var file_proto_control_plane_v1_cppki_proto_rawDesc = []byte{
 	0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20,
 	0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x22, 0x1f, 0x0a, 0x0b, 0x54,
 	0x52, 0x43, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x72,
-	0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x74, 0x72, 0x63, 0x22, 0x8a, 0x01, 0x0a,
-	0x11, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79,
-	0x49, 0x44, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x75, 0x62,
-	0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12,
-	0x19, 0x0a, 0x08, 0x74, 0x72, 0x63, 0x5f, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x07, 0x74, 0x72, 0x63, 0x42, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72,
-	0x63, 0x5f, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
-	0x74, 0x72, 0x63, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x32, 0xc3, 0x01, 0x0a, 0x14, 0x54, 0x72,
-	0x75, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x59, 0x0a, 0x06, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x25, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61,
-	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61,
-	0x69, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x50, 0x0a,
-	0x03, 0x54, 0x52, 0x43, 0x12, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e,
-	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x52,
-	0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x54, 0x52, 0x43, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
-	0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63,
-	0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x70,
-	0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
-	0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x74, 0x72, 0x63, 0x22, 0x37, 0x0a, 0x0b,
+	0x43, 0x52, 0x4c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x4b, 0x65, 0x79, 0x49, 0x64, 0x22, 0x22, 0x0a, 0x0c, 0x43, 0x52, 0x4c, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x72, 0x6c, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x72, 0x6c, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x11, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x49, 0x44, 0x12,
+	0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c,
+	0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x74, 0x72, 0x63, 0x5f, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x74, 0x72, 0x63, 0x42, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x63, 0x5f, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x72, 0x63,
+	0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x32, 0x98, 0x02, 0x0a, 0x14, 0x54, 0x72, 0x75, 0x73, 0x74,
+	0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x59, 0x0a, 0x06, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x03, 0x54, 0x52,
+	0x43, 0x12, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x52, 0x43, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x52, 0x43, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x04,
+	0x43, 0x52, 0x4c, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x52,
+	0x4c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x52, 0x4c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -442,25 +547,29 @@ func file_proto_control_plane_v1_cppki_proto_rawDescGZIP() []byte {
 	return file_proto_control_plane_v1_cppki_proto_rawDescData
 }
 
-var file_proto_control_plane_v1_cppki_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_control_plane_v1_cppki_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_proto_control_plane_v1_cppki_proto_goTypes = []interface{}{
 	(*ChainsRequest)(nil),         // 0: proto.control_plane.v1.ChainsRequest
 	(*ChainsResponse)(nil),        // 1: proto.control_plane.v1.ChainsResponse
 	(*Chain)(nil),                 // 2: proto.control_plane.v1.Chain
 	(*TRCRequest)(nil),            // 3: proto.control_plane.v1.TRCRequest
 	(*TRCResponse)(nil),           // 4: proto.control_plane.v1.TRCResponse
-	(*VerificationKeyID)(nil),     // 5: proto.control_plane.v1.VerificationKeyID
-	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*CRLsRequest)(nil),           // 5: proto.control_plane.v1.CRLsRequest
+	(*CRLsResponse)(nil),          // 6: proto.control_plane.v1.CRLsResponse
+	(*VerificationKeyID)(nil),     // 7: proto.control_plane.v1.VerificationKeyID
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
 }
 var file_proto_control_plane_v1_cppki_proto_depIdxs = []int32{
-	6, // 0: proto.control_plane.v1.ChainsRequest.date:type_name -> google.protobuf.Timestamp
+	8, // 0: proto.control_plane.v1.ChainsRequest.date:type_name -> google.protobuf.Timestamp
 	2, // 1: proto.control_plane.v1.ChainsResponse.chains:type_name -> proto.control_plane.v1.Chain
 	0, // 2: proto.control_plane.v1.TrustMaterialService.Chains:input_type -> proto.control_plane.v1.ChainsRequest
 	3, // 3: proto.control_plane.v1.TrustMaterialService.TRC:input_type -> proto.control_plane.v1.TRCRequest
-	1, // 4: proto.control_plane.v1.TrustMaterialService.Chains:output_type -> proto.control_plane.v1.ChainsResponse
-	4, // 5: proto.control_plane.v1.TrustMaterialService.TRC:output_type -> proto.control_plane.v1.TRCResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
+	5, // 4: proto.control_plane.v1.TrustMaterialService.CRLs:input_type -> proto.control_plane.v1.CRLsRequest
+	1, // 5: proto.control_plane.v1.TrustMaterialService.Chains:output_type -> proto.control_plane.v1.ChainsResponse
+	4, // 6: proto.control_plane.v1.TrustMaterialService.TRC:output_type -> proto.control_plane.v1.TRCResponse
+	6, // 7: proto.control_plane.v1.TrustMaterialService.CRLs:output_type -> proto.control_plane.v1.CRLsResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
 	2, // [2:2] is the sub-list for extension extendee
 	0, // [0:2] is the sub-list for field type_name
@@ -533,6 +642,30 @@ func file_proto_control_plane_v1_cppki_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_cppki_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRLsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_cppki_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRLsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_cppki_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VerificationKeyID); i {
 			case 0:
 				return &v.state
@@ -551,7 +684,7 @@ func file_proto_control_plane_v1_cppki_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_control_plane_v1_cppki_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -579,6 +712,7 @@ const _ = grpc.SupportPackageIsVersion6
 type TrustMaterialServiceClient interface {
 	Chains(ctx context.Context, in *ChainsRequest, opts ...grpc.CallOption) (*ChainsResponse, error)
 	TRC(ctx context.Context, in *TRCRequest, opts ...grpc.CallOption) (*TRCResponse, error)
+	CRLs(ctx context.Context, in *CRLsRequest, opts ...grpc.CallOption) (*CRLsResponse, error)
 }
 
 type trustMaterialServiceClient struct {
@@ -607,10 +741,20 @@ func (c *trustMaterialServiceClient) TRC(ctx context.Context, in *TRCRequest, op
 	return out, nil
 }
 
+func (c *trustMaterialServiceClient) CRLs(ctx context.Context, in *CRLsRequest, opts ...grpc.CallOption) (*CRLsResponse, error) {
+	out := new(CRLsResponse)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.TrustMaterialService/CRLs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrustMaterialServiceServer is the server API for TrustMaterialService service.
 type TrustMaterialServiceServer interface {
 	Chains(context.Context, *ChainsRequest) (*ChainsResponse, error)
 	TRC(context.Context, *TRCRequest) (*TRCResponse, error)
+	CRLs(context.Context, *CRLsRequest) (*CRLsResponse, error)
 }
 
 // UnimplementedTrustMaterialServiceServer can be embedded to have forward compatible implementations.
@@ -623,6 +767,9 @@ func (*UnimplementedTrustMaterialServiceServer) Chains(context.Context, *ChainsR
 func (*UnimplementedTrustMaterialServiceServer) TRC(context.Context, *TRCRequest) (*TRCResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TRC not implemented")
 }
+func (*UnimplementedTrustMaterialServiceServer) CRLs(context.Context, *CRLsRequest) (*CRLsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CRLs not implemented")
+}
 
 func RegisterTrustMaterialServiceServer(s *grpc.Server, srv TrustMaterialServiceServer) {
 	s.RegisterService(&_TrustMaterialService_serviceDesc, srv)
@@ -664,6 +811,24 @@ func _TrustMaterialService_TRC_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrustMaterialService_CRLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CRLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrustMaterialServiceServer).CRLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.TrustMaterialService/CRLs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrustMaterialServiceServer).CRLs(ctx, req.(*CRLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _TrustMaterialService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.control_plane.v1.TrustMaterialService",
 	HandlerType: (*TrustMaterialServiceServer)(nil),
@@ -676,6 +841,10 @@ var _TrustMaterialService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TRC",
 			Handler:    _TrustMaterialService_TRC_Handler,
 		},
+		{
+			MethodName: "CRLs",
+			Handler:    _TrustMaterialService_CRLs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/control_plane/v1/cppki.proto",
@@ -171,6 +171,21 @@ func (m *MockTrustMaterialServiceServer) EXPECT() *MockTrustMaterialServiceServe
 	return m.recorder
 }
 
+// CRLs mocks base method.
+func (m *MockTrustMaterialServiceServer) CRLs(arg0 context.Context, arg1 *control_plane.CRLsRequest) (*control_plane.CRLsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CRLs", arg0, arg1)
+	ret0, _ := ret[0].(*control_plane.CRLsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CRLs indicates an expected call of CRLs.
+func (mr *MockTrustMaterialServiceServerMockRecorder) CRLs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CRLs", reflect.TypeOf((*MockTrustMaterialServiceServer)(nil).CRLs), arg0, arg1)
+}
+
 // Chains mocks base method.
 func (m *MockTrustMaterialServiceServer) Chains(arg0 context.Context, arg1 *control_plane.ChainsRequest) (*control_plane.ChainsResponse, error) {
 	m.ctrl.T.Helper()
@@ -82,8 +82,11 @@ type SegmentsRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SrcIsdAs uint64 `protobuf:"varint,1,opt,name=src_isd_as,json=srcIsdAs,proto3" json:"src_isd_as,omitempty"`
-	DstIsdAs uint64 `protobuf:"varint,2,opt,name=dst_isd_as,json=dstIsdAs,proto3" json:"dst_isd_as,omitempty"`
+	SrcIsdAs  uint64          `protobuf:"varint,1,opt,name=src_isd_as,json=srcIsdAs,proto3" json:"src_isd_as,omitempty"`
+	DstIsdAs  uint64          `protobuf:"varint,2,opt,name=dst_isd_as,json=dstIsdAs,proto3" json:"dst_isd_as,omitempty"`
+	Filter    *SegmentsFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	PageSize  uint32          `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken []byte          `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 }
 
 func (x *SegmentsRequest) Reset() {
@@ -132,19 +135,113 @@ func (x *SegmentsRequest) GetDstIsdAs() uint64 {
 	return 0
 }
 
+func (x *SegmentsRequest) GetFilter() *SegmentsFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *SegmentsRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SegmentsRequest) GetPageToken() []byte {
+	if x != nil {
+		return x.PageToken
+	}
+	return nil
+}
+
+type SegmentsFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Interfaces  []*SegmentsFilter_Interface `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	TransitIsds []uint32                    `protobuf:"varint,2,rep,packed,name=transit_isds,json=transitIsds,proto3" json:"transit_isds,omitempty"`
+	MaxHops     uint32                      `protobuf:"varint,3,opt,name=max_hops,json=maxHops,proto3" json:"max_hops,omitempty"`
+	MinLifetime uint32                      `protobuf:"varint,4,opt,name=min_lifetime,json=minLifetime,proto3" json:"min_lifetime,omitempty"`
+}
+
+func (x *SegmentsFilter) Reset() {
+	*x = SegmentsFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SegmentsFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentsFilter) ProtoMessage() {}
+
+func (x *SegmentsFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentsFilter.ProtoReflect.Descriptor instead.
+func (*SegmentsFilter) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SegmentsFilter) GetInterfaces() []*SegmentsFilter_Interface {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *SegmentsFilter) GetTransitIsds() []uint32 {
+	if x != nil {
+		return x.TransitIsds
+	}
+	return nil
+}
+
+func (x *SegmentsFilter) GetMaxHops() uint32 {
+	if x != nil {
+		return x.MaxHops
+	}
+	return 0
+}
+
+func (x *SegmentsFilter) GetMinLifetime() uint32 {
+	if x != nil {
+		return x.MinLifetime
+	}
+	return 0
+}
+
 type SegmentsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Segments                    map[int32]*SegmentsResponse_Segments `protobuf:"bytes,1,rep,name=segments,proto3" json:"segments,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SignedRevocations           []*crypto.SignedMessage              `protobuf:"bytes,2,rep,name=signed_revocations,json=signedRevocations,proto3" json:"signed_revocations,omitempty"`
+	NextPageToken               []byte                               `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	DeprecatedSignedRevocations [][]byte                             `protobuf:"bytes,1000,rep,name=deprecated_signed_revocations,json=deprecatedSignedRevocations,proto3" json:"deprecated_signed_revocations,omitempty"`
 }
 
 func (x *SegmentsResponse) Reset() {
 	*x = SegmentsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[1]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -157,7 +254,7 @@ func (x *SegmentsResponse) String() string {
 func (*SegmentsResponse) ProtoMessage() {}
 
 func (x *SegmentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[1]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -170,7 +267,7 @@ func (x *SegmentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SegmentsResponse.ProtoReflect.Descriptor instead.
 func (*SegmentsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{1}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SegmentsResponse) GetSegments() map[int32]*SegmentsResponse_Segments {
@@ -180,6 +277,20 @@ func (x *SegmentsResponse) GetSegments() map[int32]*SegmentsResponse_Segments {
 	return nil
 }
 
+func (x *SegmentsResponse) GetSignedRevocations() []*crypto.SignedMessage {
+	if x != nil {
+		return x.SignedRevocations
+	}
+	return nil
+}
+
+func (x *SegmentsResponse) GetNextPageToken() []byte {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return nil
+}
+
 func (x *SegmentsResponse) GetDeprecatedSignedRevocations() [][]byte {
 	if x != nil {
 		return x.DeprecatedSignedRevocations
@@ -187,6 +298,85 @@ func (x *SegmentsResponse) GetDeprecatedSignedRevocations() [][]byte {
 	return nil
 }
 
+type RevocationBody struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsdAs       uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	InterfaceId uint64 `protobuf:"varint,2,opt,name=interface_id,json=interfaceId,proto3" json:"interface_id,omitempty"`
+	LinkType    uint32 `protobuf:"varint,3,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"`
+	Timestamp   uint32 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Ttl         uint32 `protobuf:"varint,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *RevocationBody) Reset() {
+	*x = RevocationBody{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevocationBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevocationBody) ProtoMessage() {}
+
+func (x *RevocationBody) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevocationBody.ProtoReflect.Descriptor instead.
+func (*RevocationBody) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RevocationBody) GetIsdAs() uint64 {
+	if x != nil {
+		return x.IsdAs
+	}
+	return 0
+}
+
+func (x *RevocationBody) GetInterfaceId() uint64 {
+	if x != nil {
+		return x.InterfaceId
+	}
+	return 0
+}
+
+func (x *RevocationBody) GetLinkType() uint32 {
+	if x != nil {
+		return x.LinkType
+	}
+	return 0
+}
+
+func (x *RevocationBody) GetTimestamp() uint32 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *RevocationBody) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
 type SegmentsRegistrationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -198,7 +388,7 @@ type SegmentsRegistrationRequest struct {
 func (x *SegmentsRegistrationRequest) Reset() {
 	*x = SegmentsRegistrationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[2]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -211,7 +401,7 @@ func (x *SegmentsRegistrationRequest) String() string {
 func (*SegmentsRegistrationRequest) ProtoMessage() {}
 
 func (x *SegmentsRegistrationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[2]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -224,7 +414,7 @@ func (x *SegmentsRegistrationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SegmentsRegistrationRequest.ProtoReflect.Descriptor instead.
 func (*SegmentsRegistrationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{2}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SegmentsRegistrationRequest) GetSegments() map[int32]*SegmentsRegistrationRequest_Segments {
@@ -243,7 +433,7 @@ type SegmentsRegistrationResponse struct {
 func (x *SegmentsRegistrationResponse) Reset() {
 	*x = SegmentsRegistrationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[3]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -256,7 +446,7 @@ func (x *SegmentsRegistrationResponse) String() string {
 func (*SegmentsRegistrationResponse) ProtoMessage() {}
 
 func (x *SegmentsRegistrationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[3]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,7 +459,7 @@ func (x *SegmentsRegistrationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SegmentsRegistrationResponse.ProtoReflect.Descriptor instead.
 func (*SegmentsRegistrationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{3}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{5}
 }
 
 type BeaconRequest struct {
@@ -283,7 +473,7 @@ type BeaconRequest struct {
 func (x *BeaconRequest) Reset() {
 	*x = BeaconRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[4]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -296,7 +486,7 @@ func (x *BeaconRequest) String() string {
 func (*BeaconRequest) ProtoMessage() {}
 
 func (x *BeaconRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[4]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,7 +499,7 @@ func (x *BeaconRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BeaconRequest.ProtoReflect.Descriptor instead.
 func (*BeaconRequest) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{4}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *BeaconRequest) GetSegment() *PathSegment {
@@ -328,7 +518,7 @@ type BeaconResponse struct {
 func (x *BeaconResponse) Reset() {
 	*x = BeaconResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[5]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -341,7 +531,7 @@ func (x *BeaconResponse) String() string {
 func (*BeaconResponse) ProtoMessage() {}
 
 func (x *BeaconResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[5]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -354,7 +544,7 @@ func (x *BeaconResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BeaconResponse.ProtoReflect.Descriptor instead.
 func (*BeaconResponse) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{5}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{7}
 }
 
 type PathSegment struct {
@@ -369,7 +559,7 @@ type PathSegment struct {
 func (x *PathSegment) Reset() {
 	*x = PathSegment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[6]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -382,7 +572,7 @@ func (x *PathSegment) String() string {
 func (*PathSegment) ProtoMessage() {}
 
 func (x *PathSegment) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[6]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -395,7 +585,7 @@ func (x *PathSegment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PathSegment.ProtoReflect.Descriptor instead.
 func (*PathSegment) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{6}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PathSegment) GetSegmentInfo() []byte {
@@ -424,7 +614,7 @@ type SegmentInformation struct {
 func (x *SegmentInformation) Reset() {
 	*x = SegmentInformation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[7]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -437,7 +627,7 @@ func (x *SegmentInformation) String() string {
 func (*SegmentInformation) ProtoMessage() {}
 
 func (x *SegmentInformation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[7]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -450,7 +640,7 @@ func (x *SegmentInformation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SegmentInformation.ProtoReflect.Descriptor instead.
 func (*SegmentInformation) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{7}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SegmentInformation) GetTimestamp() int64 {
@@ -479,7 +669,7 @@ type ASEntry struct {
 func (x *ASEntry) Reset() {
 	*x = ASEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[8]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -492,7 +682,7 @@ func (x *ASEntry) String() string {
 func (*ASEntry) ProtoMessage() {}
 
 func (x *ASEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[8]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,7 +695,7 @@ func (x *ASEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ASEntry.ProtoReflect.Descriptor instead.
 func (*ASEntry) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{8}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ASEntry) GetSigned() *crypto.SignedMessage {
@@ -538,7 +728,7 @@ type ASEntrySignedBody struct {
 func (x *ASEntrySignedBody) Reset() {
 	*x = ASEntrySignedBody{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[9]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -551,7 +741,7 @@ func (x *ASEntrySignedBody) String() string {
 func (*ASEntrySignedBody) ProtoMessage() {}
 
 func (x *ASEntrySignedBody) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[9]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,7 +754,7 @@ func (x *ASEntrySignedBody) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ASEntrySignedBody.ProtoReflect.Descriptor instead.
 func (*ASEntrySignedBody) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{9}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ASEntrySignedBody) GetIsdAs() uint64 {
@@ -621,7 +811,7 @@ type HopEntry struct {
 func (x *HopEntry) Reset() {
 	*x = HopEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[10]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -634,7 +824,7 @@ func (x *HopEntry) String() string {
 func (*HopEntry) ProtoMessage() {}
 
 func (x *HopEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[10]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -647,7 +837,7 @@ func (x *HopEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HopEntry.ProtoReflect.Descriptor instead.
 func (*HopEntry) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{10}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *HopEntry) GetHopField() *HopField {
@@ -678,7 +868,7 @@ type PeerEntry struct {
 func (x *PeerEntry) Reset() {
 	*x = PeerEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[11]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -691,7 +881,7 @@ func (x *PeerEntry) String() string {
 func (*PeerEntry) ProtoMessage() {}
 
 func (x *PeerEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[11]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -704,7 +894,7 @@ func (x *PeerEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerEntry.ProtoReflect.Descriptor instead.
 func (*PeerEntry) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{11}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PeerEntry) GetPeerIsdAs() uint64 {
@@ -740,16 +930,17 @@ type HopField struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ingress uint64 `protobuf:"varint,1,opt,name=ingress,proto3" json:"ingress,omitempty"`
-	Egress  uint64 `protobuf:"varint,2,opt,name=egress,proto3" json:"egress,omitempty"`
-	ExpTime uint32 `protobuf:"varint,3,opt,name=exp_time,json=expTime,proto3" json:"exp_time,omitempty"`
-	Mac     []byte `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`
+	Ingress  uint64 `protobuf:"varint,1,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	Egress   uint64 `protobuf:"varint,2,opt,name=egress,proto3" json:"egress,omitempty"`
+	ExpTime  uint32 `protobuf:"varint,3,opt,name=exp_time,json=expTime,proto3" json:"exp_time,omitempty"`
+	Mac      []byte `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`
+	KeyPhase bool   `protobuf:"varint,5,opt,name=key_phase,json=keyPhase,proto3" json:"key_phase,omitempty"`
 }
 
 func (x *HopField) Reset() {
 	*x = HopField{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[12]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -762,7 +953,7 @@ func (x *HopField) String() string {
 func (*HopField) ProtoMessage() {}
 
 func (x *HopField) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[12]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -775,7 +966,7 @@ func (x *HopField) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HopField.ProtoReflect.Descriptor instead.
 func (*HopField) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{12}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *HopField) GetIngress() uint64 {
@@ -806,6 +997,68 @@ func (x *HopField) GetMac() []byte {
 	return nil
 }
 
+func (x *HopField) GetKeyPhase() bool {
+	if x != nil {
+		return x.KeyPhase
+	}
+	return false
+}
+
+type SegmentsFilter_Interface struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsdAs uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	Id    uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *SegmentsFilter_Interface) Reset() {
+	*x = SegmentsFilter_Interface{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SegmentsFilter_Interface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentsFilter_Interface) ProtoMessage() {}
+
+func (x *SegmentsFilter_Interface) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentsFilter_Interface.ProtoReflect.Descriptor instead.
+func (*SegmentsFilter_Interface) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *SegmentsFilter_Interface) GetIsdAs() uint64 {
+	if x != nil {
+		return x.IsdAs
+	}
+	return 0
+}
+
+func (x *SegmentsFilter_Interface) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type SegmentsResponse_Segments struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -817,7 +1070,7 @@ type SegmentsResponse_Segments struct {
 func (x *SegmentsResponse_Segments) Reset() {
 	*x = SegmentsResponse_Segments{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[13]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -830,7 +1083,7 @@ func (x *SegmentsResponse_Segments) String() string {
 func (*SegmentsResponse_Segments) ProtoMessage() {}
 
 func (x *SegmentsResponse_Segments) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[13]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,7 +1096,7 @@ func (x *SegmentsResponse_Segments) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SegmentsResponse_Segments.ProtoReflect.Descriptor instead.
 func (*SegmentsResponse_Segments) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{1, 0}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{2, 0}
 }
 
 func (x *SegmentsResponse_Segments) GetSegments() []*PathSegment {
@@ -864,7 +1117,7 @@ type SegmentsRegistrationRequest_Segments struct {
 func (x *SegmentsRegistrationRequest_Segments) Reset() {
 	*x = SegmentsRegistrationRequest_Segments{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[15]
+		mi := &file_proto_control_plane_v1_seg_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -877,7 +1130,7 @@ func (x *SegmentsRegistrationRequest_Segments) String() string {
 func (*SegmentsRegistrationRequest_Segments) ProtoMessage() {}
 
 func (x *SegmentsRegistrationRequest_Segments) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[15]
+	mi := &file_proto_control_plane_v1_seg_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -890,7 +1143,7 @@ func (x *SegmentsRegistrationRequest_Segments) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use SegmentsRegistrationRequest_Segments.ProtoReflect.Descriptor instead.
 func (*SegmentsRegistrationRequest_Segments) Descriptor() ([]byte, []int) {
-	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{2, 0}
+	return file_proto_control_plane_v1_seg_proto_rawDescGZIP(), []int{4, 0}
 }
 
 func (x *SegmentsRegistrationRequest_Segments) GetSegments() []*PathSegment {
@@ -911,164 +1164,207 @@ var file_proto_control_plane_v1_seg_proto_rawDesc = []byte{
 	0x76, 0x31, 0x2f, 0x73, 0x65, 0x67, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
 	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
 	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4d, 0x0a, 0x0f, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x0a, 0x73, 0x72, 0x63, 0x5f,
-	0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x72,
-	0x63, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x1c, 0x0a, 0x0a, 0x64, 0x73, 0x74, 0x5f, 0x69, 0x73,
-	0x64, 0x5f, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x73, 0x74, 0x49,
-	0x73, 0x64, 0x41, 0x73, 0x22, 0xe8, 0x02, 0x0a, 0x10, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x08, 0x73, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x43, 0x0a,
-	0x1d, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x67, 0x6e,
-	0x65, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe8,
-	0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x1b, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65,
-	0x64, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x1a, 0x4b, 0x0a, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x3f,
-	0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
-	0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65,
-	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a,
-	0x6e, 0x0a, 0x0d, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x47, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x31, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
-	0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0xc4, 0x02, 0x0a, 0x1b, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x5d, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x41, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
-	0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x4b,
-	0x0a, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x3f, 0x0a, 0x08, 0x73, 0x65,
-	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61,
-	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x79, 0x0a, 0x0d, 0x53,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x52,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c,
-	0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1e, 0x0a, 0x1c, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4e, 0x0a, 0x0d, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc9, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x0a, 0x73, 0x72, 0x63,
+	0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73,
+	0x72, 0x63, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x1c, 0x0a, 0x0a, 0x64, 0x73, 0x74, 0x5f, 0x69,
+	0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x73, 0x74,
+	0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x3e, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0xf7, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x50, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x73,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x10, 0x0a, 0x0e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x70, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x68,
-	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x73,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x3e, 0x0a, 0x0a, 0x61, 0x73,
-	0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x74, 0x5f, 0x69, 0x73, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0b, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x74, 0x49, 0x73, 0x64, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78,
+	0x5f, 0x68, 0x6f, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6d, 0x61, 0x78,
+	0x48, 0x6f, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x69, 0x66, 0x65,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x4c,
+	0x69, 0x66, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x1a, 0x32, 0x0a, 0x09, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0xdf, 0x03, 0x0a, 0x10,
+	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x52, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x36, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x4d, 0x0a, 0x12, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x72,
+	0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x11, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x43, 0x0a, 0x1d, 0x64,
+	0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x5f, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe8, 0x07, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x1b, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x1a, 0x4b, 0x0a, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x3f, 0x0a, 0x08,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70,
-	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x53, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x09, 0x61, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x51, 0x0a, 0x12, 0x53, 0x65,
-	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d,
-	0x0a, 0x0a, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x09, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x94, 0x01,
-	0x0a, 0x07, 0x41, 0x53, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x69, 0x67,
-	0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65,
-	0x64, 0x12, 0x51, 0x0a, 0x08, 0x75, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74,
-	0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
-	0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x08, 0x75, 0x6e, 0x73, 0x69,
-	0x67, 0x6e, 0x65, 0x64, 0x22, 0xb0, 0x02, 0x0a, 0x11, 0x41, 0x53, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73,
-	0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41,
-	0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x49, 0x73, 0x64, 0x41,
-	0x73, 0x12, 0x3d, 0x0a, 0x09, 0x68, 0x6f, 0x70, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e,
-	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f,
-	0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x68, 0x6f, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x44, 0x0a, 0x0c, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
-	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x6e, 0x0a,
+	0x0d, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x47, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x31, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f,
+	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x97, 0x01,
+	0x0a, 0x0e, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6f, 0x64, 0x79,
+	0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x69,
+	0x6e, 0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c,
+	0x69, 0x6e, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0xc4, 0x02, 0x0a, 0x1b, 0x53, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5d, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x4b, 0x0a, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x3f, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x1a, 0x79, 0x0a, 0x0d, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x52, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1e,
+	0x0a, 0x1c, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4e,
+	0x0a, 0x0d, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3d, 0x0a, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x10,
+	0x0a, 0x0e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x70, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x3e, 0x0a, 0x0a, 0x61, 0x73, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
 	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x50, 0x65, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x45,
-	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d, 0x74, 0x75, 0x12, 0x4d, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x65,
-	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x70,
+	0x41, 0x53, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x61, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x22, 0x51, 0x0a, 0x12, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x94, 0x01, 0x0a, 0x07, 0x41, 0x53, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x51, 0x0a, 0x08, 0x75, 0x6e, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x08, 0x75, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x22, 0xb0, 0x02, 0x0a,
+	0x11, 0x41, 0x53, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x42, 0x6f,
+	0x64, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x6e, 0x65, 0x78, 0x74, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x3d, 0x0a, 0x09, 0x68, 0x6f, 0x70,
+	0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61,
-	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x65, 0x78, 0x74,
-	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x6a, 0x0a, 0x08, 0x48, 0x6f, 0x70, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x3d, 0x0a, 0x09, 0x68, 0x6f, 0x70, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
-	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x48, 0x6f, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x08, 0x68, 0x6f, 0x70, 0x46, 0x69, 0x65,
-	0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x6d, 0x74,
-	0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73,
-	0x4d, 0x74, 0x75, 0x22, 0xac, 0x01, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70, 0x65, 0x65, 0x72, 0x49, 0x73, 0x64, 0x41,
-	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x65, 0x72,
-	0x5f, 0x6d, 0x74, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x70, 0x65, 0x65, 0x72,
-	0x4d, 0x74, 0x75, 0x12, 0x3d, 0x0a, 0x09, 0x68, 0x6f, 0x70, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
-	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x48, 0x6f, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x08, 0x68, 0x6f, 0x70, 0x46, 0x69, 0x65,
-	0x6c, 0x64, 0x22, 0x69, 0x0a, 0x08, 0x48, 0x6f, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x18,
-	0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x67, 0x72, 0x65,
-	0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73,
-	0x12, 0x19, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x07, 0x65, 0x78, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d,
-	0x61, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x61, 0x63, 0x2a, 0x6e, 0x0a,
-	0x0b, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18,
-	0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53,
-	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45,
-	0x47, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12,
-	0x15, 0x0a, 0x11, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e,
-	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x52, 0x45, 0x10, 0x03, 0x32, 0x77, 0x0a,
-	0x14, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5f, 0x0a, 0x08, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x12, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
-	0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xa2, 0x01, 0x0a, 0x1a, 0x53, 0x65, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33,
+	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08,
+	0x68, 0x6f, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x44, 0x0a, 0x0c, 0x70, 0x65, 0x65, 0x72,
+	0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70,
-	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x73, 0x0a, 0x16, 0x53,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x59, 0x0a, 0x06, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x12,
-	0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f,
-	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
-	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73,
-	0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f,
-	0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
-	0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d, 0x74, 0x75,
+	0x12, 0x4d, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x6a, 0x0a, 0x08, 0x48, 0x6f, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x3d, 0x0a, 0x09, 0x68,
+	0x6f, 0x70, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x52, 0x08, 0x68, 0x6f, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x6d, 0x74, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x4d, 0x74, 0x75, 0x22, 0xac, 0x01, 0x0a, 0x09,
+	0x50, 0x65, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x65, 0x65,
+	0x72, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x70, 0x65, 0x65, 0x72, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x65,
+	0x72, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x6d, 0x74, 0x75, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x74, 0x75, 0x12, 0x3d, 0x0a, 0x09, 0x68,
+	0x6f, 0x70, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x52, 0x08, 0x68, 0x6f, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x22, 0x86, 0x01, 0x0a, 0x08, 0x48,
+	0x6f, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x78, 0x70,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x65, 0x78, 0x70,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x6d, 0x61, 0x63, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x50, 0x68,
+	0x61, 0x73, 0x65, 0x2a, 0x6e, 0x0a, 0x0b, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11,
+	0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x52,
+	0x45, 0x10, 0x03, 0x32, 0x77, 0x0a, 0x14, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f,
+	0x6f, 0x6b, 0x75, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5f, 0x0a, 0x08, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xa2, 0x01, 0x0a,
+	0x1a, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x14,
+	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x32, 0x73, 0x0a, 0x16, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x59, 0x0a, 0x06, 0x42,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1084,57 +1380,63 @@ func file_proto_control_plane_v1_seg_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_control_plane_v1_seg_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_control_plane_v1_seg_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_proto_control_plane_v1_seg_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_proto_control_plane_v1_seg_proto_goTypes = []interface{}{
 	(SegmentType)(0),                             // 0: proto.control_plane.v1.SegmentType
 	(*SegmentsRequest)(nil),                      // 1: proto.control_plane.v1.SegmentsRequest
-	(*SegmentsResponse)(nil),                     // 2: proto.control_plane.v1.SegmentsResponse
-	(*SegmentsRegistrationRequest)(nil),          // 3: proto.control_plane.v1.SegmentsRegistrationRequest
-	(*SegmentsRegistrationResponse)(nil),         // 4: proto.control_plane.v1.SegmentsRegistrationResponse
-	(*BeaconRequest)(nil),                        // 5: proto.control_plane.v1.BeaconRequest
-	(*BeaconResponse)(nil),                       // 6: proto.control_plane.v1.BeaconResponse
-	(*PathSegment)(nil),                          // 7: proto.control_plane.v1.PathSegment
-	(*SegmentInformation)(nil),                   // 8: proto.control_plane.v1.SegmentInformation
-	(*ASEntry)(nil),                              // 9: proto.control_plane.v1.ASEntry
-	(*ASEntrySignedBody)(nil),                    // 10: proto.control_plane.v1.ASEntrySignedBody
-	(*HopEntry)(nil),                             // 11: proto.control_plane.v1.HopEntry
-	(*PeerEntry)(nil),                            // 12: proto.control_plane.v1.PeerEntry
-	(*HopField)(nil),                             // 13: proto.control_plane.v1.HopField
-	(*SegmentsResponse_Segments)(nil),            // 14: proto.control_plane.v1.SegmentsResponse.Segments
-	nil,                                          // 15: proto.control_plane.v1.SegmentsResponse.SegmentsEntry
-	(*SegmentsRegistrationRequest_Segments)(nil), // 16: proto.control_plane.v1.SegmentsRegistrationRequest.Segments
-	nil,                                   // 17: proto.control_plane.v1.SegmentsRegistrationRequest.SegmentsEntry
-	(*crypto.SignedMessage)(nil),          // 18: proto.crypto.v1.SignedMessage
-	(*PathSegmentUnsignedExtensions)(nil), // 19: proto.control_plane.v1.PathSegmentUnsignedExtensions
-	(*PathSegmentExtensions)(nil),         // 20: proto.control_plane.v1.PathSegmentExtensions
+	(*SegmentsFilter)(nil),                       // 2: proto.control_plane.v1.SegmentsFilter
+	(*SegmentsResponse)(nil),                     // 3: proto.control_plane.v1.SegmentsResponse
+	(*RevocationBody)(nil),                       // 4: proto.control_plane.v1.RevocationBody
+	(*SegmentsRegistrationRequest)(nil),          // 5: proto.control_plane.v1.SegmentsRegistrationRequest
+	(*SegmentsRegistrationResponse)(nil),         // 6: proto.control_plane.v1.SegmentsRegistrationResponse
+	(*BeaconRequest)(nil),                        // 7: proto.control_plane.v1.BeaconRequest
+	(*BeaconResponse)(nil),                       // 8: proto.control_plane.v1.BeaconResponse
+	(*PathSegment)(nil),                          // 9: proto.control_plane.v1.PathSegment
+	(*SegmentInformation)(nil),                   // 10: proto.control_plane.v1.SegmentInformation
+	(*ASEntry)(nil),                              // 11: proto.control_plane.v1.ASEntry
+	(*ASEntrySignedBody)(nil),                    // 12: proto.control_plane.v1.ASEntrySignedBody
+	(*HopEntry)(nil),                             // 13: proto.control_plane.v1.HopEntry
+	(*PeerEntry)(nil),                            // 14: proto.control_plane.v1.PeerEntry
+	(*HopField)(nil),                             // 15: proto.control_plane.v1.HopField
+	(*SegmentsFilter_Interface)(nil),             // 16: proto.control_plane.v1.SegmentsFilter.Interface
+	(*SegmentsResponse_Segments)(nil),            // 17: proto.control_plane.v1.SegmentsResponse.Segments
+	nil,                                          // 18: proto.control_plane.v1.SegmentsResponse.SegmentsEntry
+	(*SegmentsRegistrationRequest_Segments)(nil), // 19: proto.control_plane.v1.SegmentsRegistrationRequest.Segments
+	nil,                                   // 20: proto.control_plane.v1.SegmentsRegistrationRequest.SegmentsEntry
+	(*crypto.SignedMessage)(nil),          // 21: proto.crypto.v1.SignedMessage
+	(*PathSegmentUnsignedExtensions)(nil), // 22: proto.control_plane.v1.PathSegmentUnsignedExtensions
+	(*PathSegmentExtensions)(nil),         // 23: proto.control_plane.v1.PathSegmentExtensions
 }
 var file_proto_control_plane_v1_seg_proto_depIdxs = []int32{
-	15, // 0: proto.control_plane.v1.SegmentsResponse.segments:type_name -> proto.control_plane.v1.SegmentsResponse.SegmentsEntry
-	17, // 1: proto.control_plane.v1.SegmentsRegistrationRequest.segments:type_name -> proto.control_plane.v1.SegmentsRegistrationRequest.SegmentsEntry
-	7,  // 2: proto.control_plane.v1.BeaconRequest.segment:type_name -> proto.control_plane.v1.PathSegment
-	9,  // 3: proto.control_plane.v1.PathSegment.as_entries:type_name -> proto.control_plane.v1.ASEntry
-	18, // 4: proto.control_plane.v1.ASEntry.signed:type_name -> proto.crypto.v1.SignedMessage
-	19, // 5: proto.control_plane.v1.ASEntry.unsigned:type_name -> proto.control_plane.v1.PathSegmentUnsignedExtensions
-	11, // 6: proto.control_plane.v1.ASEntrySignedBody.hop_entry:type_name -> proto.control_plane.v1.HopEntry
-	12, // 7: proto.control_plane.v1.ASEntrySignedBody.peer_entries:type_name -> proto.control_plane.v1.PeerEntry
-	20, // 8: proto.control_plane.v1.ASEntrySignedBody.extensions:type_name -> proto.control_plane.v1.PathSegmentExtensions
-	13, // 9: proto.control_plane.v1.HopEntry.hop_field:type_name -> proto.control_plane.v1.HopField
-	13, // 10: proto.control_plane.v1.PeerEntry.hop_field:type_name -> proto.control_plane.v1.HopField
-	7,  // 11: proto.control_plane.v1.SegmentsResponse.Segments.segments:type_name -> proto.control_plane.v1.PathSegment
-	14, // 12: proto.control_plane.v1.SegmentsResponse.SegmentsEntry.value:type_name -> proto.control_plane.v1.SegmentsResponse.Segments
-	7,  // 13: proto.control_plane.v1.SegmentsRegistrationRequest.Segments.segments:type_name -> proto.control_plane.v1.PathSegment
-	16, // 14: proto.control_plane.v1.SegmentsRegistrationRequest.SegmentsEntry.value:type_name -> proto.control_plane.v1.SegmentsRegistrationRequest.Segments
-	1,  // 15: proto.control_plane.v1.SegmentLookupService.Segments:input_type -> proto.control_plane.v1.SegmentsRequest
-	3,  // 16: proto.control_plane.v1.SegmentRegistrationService.SegmentsRegistration:input_type -> proto.control_plane.v1.SegmentsRegistrationRequest
-	5,  // 17: proto.control_plane.v1.SegmentCreationService.Beacon:input_type -> proto.control_plane.v1.BeaconRequest
-	2,  // 18: proto.control_plane.v1.SegmentLookupService.Segments:output_type -> proto.control_plane.v1.SegmentsResponse
-	4,  // 19: proto.control_plane.v1.SegmentRegistrationService.SegmentsRegistration:output_type -> proto.control_plane.v1.SegmentsRegistrationResponse
-	6,  // 20: proto.control_plane.v1.SegmentCreationService.Beacon:output_type -> proto.control_plane.v1.BeaconResponse
-	18, // [18:21] is the sub-list for method output_type
-	15, // [15:18] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	2,  // 0: proto.control_plane.v1.SegmentsRequest.filter:type_name -> proto.control_plane.v1.SegmentsFilter
+	16, // 1: proto.control_plane.v1.SegmentsFilter.interfaces:type_name -> proto.control_plane.v1.SegmentsFilter.Interface
+	18, // 2: proto.control_plane.v1.SegmentsResponse.segments:type_name -> proto.control_plane.v1.SegmentsResponse.SegmentsEntry
+	21, // 3: proto.control_plane.v1.SegmentsResponse.signed_revocations:type_name -> proto.crypto.v1.SignedMessage
+	20, // 4: proto.control_plane.v1.SegmentsRegistrationRequest.segments:type_name -> proto.control_plane.v1.SegmentsRegistrationRequest.SegmentsEntry
+	9,  // 5: proto.control_plane.v1.BeaconRequest.segment:type_name -> proto.control_plane.v1.PathSegment
+	11, // 6: proto.control_plane.v1.PathSegment.as_entries:type_name -> proto.control_plane.v1.ASEntry
+	21, // 7: proto.control_plane.v1.ASEntry.signed:type_name -> proto.crypto.v1.SignedMessage
+	22, // 8: proto.control_plane.v1.ASEntry.unsigned:type_name -> proto.control_plane.v1.PathSegmentUnsignedExtensions
+	13, // 9: proto.control_plane.v1.ASEntrySignedBody.hop_entry:type_name -> proto.control_plane.v1.HopEntry
+	14, // 10: proto.control_plane.v1.ASEntrySignedBody.peer_entries:type_name -> proto.control_plane.v1.PeerEntry
+	23, // 11: proto.control_plane.v1.ASEntrySignedBody.extensions:type_name -> proto.control_plane.v1.PathSegmentExtensions
+	15, // 12: proto.control_plane.v1.HopEntry.hop_field:type_name -> proto.control_plane.v1.HopField
+	15, // 13: proto.control_plane.v1.PeerEntry.hop_field:type_name -> proto.control_plane.v1.HopField
+	9,  // 14: proto.control_plane.v1.SegmentsResponse.Segments.segments:type_name -> proto.control_plane.v1.PathSegment
+	17, // 15: proto.control_plane.v1.SegmentsResponse.SegmentsEntry.value:type_name -> proto.control_plane.v1.SegmentsResponse.Segments
+	9,  // 16: proto.control_plane.v1.SegmentsRegistrationRequest.Segments.segments:type_name -> proto.control_plane.v1.PathSegment
+	19, // 17: proto.control_plane.v1.SegmentsRegistrationRequest.SegmentsEntry.value:type_name -> proto.control_plane.v1.SegmentsRegistrationRequest.Segments
+	1,  // 18: proto.control_plane.v1.SegmentLookupService.Segments:input_type -> proto.control_plane.v1.SegmentsRequest
+	5,  // 19: proto.control_plane.v1.SegmentRegistrationService.SegmentsRegistration:input_type -> proto.control_plane.v1.SegmentsRegistrationRequest
+	7,  // 20: proto.control_plane.v1.SegmentCreationService.Beacon:input_type -> proto.control_plane.v1.BeaconRequest
+	3,  // 21: proto.control_plane.v1.SegmentLookupService.Segments:output_type -> proto.control_plane.v1.SegmentsResponse
+	6,  // 22: proto.control_plane.v1.SegmentRegistrationService.SegmentsRegistration:output_type -> proto.control_plane.v1.SegmentsRegistrationResponse
+	8,  // 23: proto.control_plane.v1.SegmentCreationService.Beacon:output_type -> proto.control_plane.v1.BeaconResponse
+	21, // [21:24] is the sub-list for method output_type
+	18, // [18:21] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_proto_control_plane_v1_seg_proto_init() }
@@ -1157,7 +1459,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SegmentsResponse); i {
+			switch v := v.(*SegmentsFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1169,7 +1471,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SegmentsRegistrationRequest); i {
+			switch v := v.(*SegmentsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1181,7 +1483,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SegmentsRegistrationResponse); i {
+			switch v := v.(*RevocationBody); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1193,7 +1495,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BeaconRequest); i {
+			switch v := v.(*SegmentsRegistrationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1205,7 +1507,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BeaconResponse); i {
+			switch v := v.(*SegmentsRegistrationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1217,7 +1519,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PathSegment); i {
+			switch v := v.(*BeaconRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1229,7 +1531,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SegmentInformation); i {
+			switch v := v.(*BeaconResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1241,7 +1543,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ASEntry); i {
+			switch v := v.(*PathSegment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1253,7 +1555,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ASEntrySignedBody); i {
+			switch v := v.(*SegmentInformation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1265,7 +1567,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HopEntry); i {
+			switch v := v.(*ASEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1277,7 +1579,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeerEntry); i {
+			switch v := v.(*ASEntrySignedBody); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1289,7 +1591,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HopField); i {
+			switch v := v.(*HopEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1301,7 +1603,19 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SegmentsResponse_Segments); i {
+			switch v := v.(*PeerEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_seg_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HopField); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1313,6 +1627,30 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			}
 		}
 		file_proto_control_plane_v1_seg_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SegmentsFilter_Interface); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_seg_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SegmentsResponse_Segments); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_seg_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SegmentsRegistrationRequest_Segments); i {
 			case 0:
 				return &v.state
@@ -1331,7 +1669,7 @@ func file_proto_control_plane_v1_seg_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_control_plane_v1_seg_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   17,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
@@ -27,6 +27,104 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type PeeringMode int32
+
+const (
+	PeeringMode_PEERING_MODE_UNSPECIFIED PeeringMode = 0
+	PeeringMode_PEERING_MODE_FORBID      PeeringMode = 1
+	PeeringMode_PEERING_MODE_REQUIRE     PeeringMode = 2
+)
+
+// Enum value maps for PeeringMode.
+var (
+	PeeringMode_name = map[int32]string{
+		0: "PEERING_MODE_UNSPECIFIED",
+		1: "PEERING_MODE_FORBID",
+		2: "PEERING_MODE_REQUIRE",
+	}
+	PeeringMode_value = map[string]int32{
+		"PEERING_MODE_UNSPECIFIED": 0,
+		"PEERING_MODE_FORBID":      1,
+		"PEERING_MODE_REQUIRE":     2,
+	}
+)
+
+func (x PeeringMode) Enum() *PeeringMode {
+	p := new(PeeringMode)
+	*p = x
+	return p
+}
+
+func (x PeeringMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PeeringMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_daemon_v1_daemon_proto_enumTypes[0].Descriptor()
+}
+
+func (PeeringMode) Type() protoreflect.EnumType {
+	return &file_proto_daemon_v1_daemon_proto_enumTypes[0]
+}
+
+func (x PeeringMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PeeringMode.Descriptor instead.
+func (PeeringMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{0}
+}
+
+type DisjointnessMode int32
+
+const (
+	DisjointnessMode_DISJOINTNESS_MODE_UNSPECIFIED DisjointnessMode = 0
+	DisjointnessMode_DISJOINTNESS_MODE_LINK        DisjointnessMode = 1
+	DisjointnessMode_DISJOINTNESS_MODE_AS          DisjointnessMode = 2
+)
+
+// Enum value maps for DisjointnessMode.
+var (
+	DisjointnessMode_name = map[int32]string{
+		0: "DISJOINTNESS_MODE_UNSPECIFIED",
+		1: "DISJOINTNESS_MODE_LINK",
+		2: "DISJOINTNESS_MODE_AS",
+	}
+	DisjointnessMode_value = map[string]int32{
+		"DISJOINTNESS_MODE_UNSPECIFIED": 0,
+		"DISJOINTNESS_MODE_LINK":        1,
+		"DISJOINTNESS_MODE_AS":          2,
+	}
+)
+
+func (x DisjointnessMode) Enum() *DisjointnessMode {
+	p := new(DisjointnessMode)
+	*p = x
+	return p
+}
+
+func (x DisjointnessMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DisjointnessMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_daemon_v1_daemon_proto_enumTypes[1].Descriptor()
+}
+
+func (DisjointnessMode) Type() protoreflect.EnumType {
+	return &file_proto_daemon_v1_daemon_proto_enumTypes[1]
+}
+
+func (x DisjointnessMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DisjointnessMode.Descriptor instead.
+func (DisjointnessMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{1}
+}
+
 type LinkType int32
 
 const (
@@ -63,11 +161,11 @@ func (x LinkType) String() string {
 }
 
 func (LinkType) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_daemon_v1_daemon_proto_enumTypes[0].Descriptor()
+	return file_proto_daemon_v1_daemon_proto_enumTypes[2].Descriptor()
 }
 
 func (LinkType) Type() protoreflect.EnumType {
-	return &file_proto_daemon_v1_daemon_proto_enumTypes[0]
+	return &file_proto_daemon_v1_daemon_proto_enumTypes[2]
 }
 
 func (x LinkType) Number() protoreflect.EnumNumber {
@@ -76,7 +174,62 @@ func (x LinkType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LinkType.Descriptor instead.
 func (LinkType) EnumDescriptor() ([]byte, []int) {
-	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{0}
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{2}
+}
+
+type LinkRelation int32
+
+const (
+	LinkRelation_LINK_RELATION_UNSPECIFIED LinkRelation = 0
+	LinkRelation_LINK_RELATION_CORE        LinkRelation = 1
+	LinkRelation_LINK_RELATION_PARENT      LinkRelation = 2
+	LinkRelation_LINK_RELATION_CHILD       LinkRelation = 3
+	LinkRelation_LINK_RELATION_PEER        LinkRelation = 4
+)
+
+// Enum value maps for LinkRelation.
+var (
+	LinkRelation_name = map[int32]string{
+		0: "LINK_RELATION_UNSPECIFIED",
+		1: "LINK_RELATION_CORE",
+		2: "LINK_RELATION_PARENT",
+		3: "LINK_RELATION_CHILD",
+		4: "LINK_RELATION_PEER",
+	}
+	LinkRelation_value = map[string]int32{
+		"LINK_RELATION_UNSPECIFIED": 0,
+		"LINK_RELATION_CORE":        1,
+		"LINK_RELATION_PARENT":      2,
+		"LINK_RELATION_CHILD":       3,
+		"LINK_RELATION_PEER":        4,
+	}
+)
+
+func (x LinkRelation) Enum() *LinkRelation {
+	p := new(LinkRelation)
+	*p = x
+	return p
+}
+
+func (x LinkRelation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LinkRelation) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_daemon_v1_daemon_proto_enumTypes[3].Descriptor()
+}
+
+func (LinkRelation) Type() protoreflect.EnumType {
+	return &file_proto_daemon_v1_daemon_proto_enumTypes[3]
+}
+
+func (x LinkRelation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LinkRelation.Descriptor instead.
+func (LinkRelation) EnumDescriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{3}
 }
 
 type PathsRequest struct {
@@ -84,10 +237,14 @@ type PathsRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SourceIsdAs      uint64 `protobuf:"varint,1,opt,name=source_isd_as,json=sourceIsdAs,proto3" json:"source_isd_as,omitempty"`
-	DestinationIsdAs uint64 `protobuf:"varint,2,opt,name=destination_isd_as,json=destinationIsdAs,proto3" json:"destination_isd_as,omitempty"`
-	Refresh          bool   `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`
-	Hidden           bool   `protobuf:"varint,4,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	SourceIsdAs        uint64      `protobuf:"varint,1,opt,name=source_isd_as,json=sourceIsdAs,proto3" json:"source_isd_as,omitempty"`
+	DestinationIsdAs   uint64      `protobuf:"varint,2,opt,name=destination_isd_as,json=destinationIsdAs,proto3" json:"destination_isd_as,omitempty"`
+	Refresh            bool        `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	Hidden             bool        `protobuf:"varint,4,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	Peering            PeeringMode `protobuf:"varint,5,opt,name=peering,proto3,enum=proto.daemon.v1.PeeringMode" json:"peering,omitempty"`
+	HiddenPathGroupIds []uint64    `protobuf:"varint,6,rep,packed,name=hidden_path_group_ids,json=hiddenPathGroupIds,proto3" json:"hidden_path_group_ids,omitempty"`
+	Policy             string      `protobuf:"bytes,7,opt,name=policy,proto3" json:"policy,omitempty"`
+	Waypoints          []uint64    `protobuf:"varint,8,rep,packed,name=waypoints,proto3" json:"waypoints,omitempty"`
 }
 
 func (x *PathsRequest) Reset() {
@@ -150,6 +307,34 @@ func (x *PathsRequest) GetHidden() bool {
 	return false
 }
 
+func (x *PathsRequest) GetPeering() PeeringMode {
+	if x != nil {
+		return x.Peering
+	}
+	return PeeringMode_PEERING_MODE_UNSPECIFIED
+}
+
+func (x *PathsRequest) GetHiddenPathGroupIds() []uint64 {
+	if x != nil {
+		return x.HiddenPathGroupIds
+	}
+	return nil
+}
+
+func (x *PathsRequest) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *PathsRequest) GetWaypoints() []uint64 {
+	if x != nil {
+		return x.Waypoints
+	}
+	return nil
+}
+
 type PathsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -197,27 +382,17 @@ func (x *PathsResponse) GetPaths() []*Path {
 	return nil
 }
 
-type Path struct {
+type WatchPathsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Raw          []byte                 `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
-	Interface    *Interface             `protobuf:"bytes,2,opt,name=interface,proto3" json:"interface,omitempty"`
-	Interfaces   []*PathInterface       `protobuf:"bytes,3,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
-	Mtu          uint32                 `protobuf:"varint,4,opt,name=mtu,proto3" json:"mtu,omitempty"`
-	Expiration   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiration,proto3" json:"expiration,omitempty"`
-	Latency      []*durationpb.Duration `protobuf:"bytes,6,rep,name=latency,proto3" json:"latency,omitempty"`
-	Bandwidth    []uint64               `protobuf:"varint,7,rep,packed,name=bandwidth,proto3" json:"bandwidth,omitempty"`
-	Geo          []*GeoCoordinates      `protobuf:"bytes,8,rep,name=geo,proto3" json:"geo,omitempty"`
-	LinkType     []LinkType             `protobuf:"varint,9,rep,packed,name=link_type,json=linkType,proto3,enum=proto.daemon.v1.LinkType" json:"link_type,omitempty"`
-	InternalHops []uint32               `protobuf:"varint,10,rep,packed,name=internal_hops,json=internalHops,proto3" json:"internal_hops,omitempty"`
-	Notes        []string               `protobuf:"bytes,11,rep,name=notes,proto3" json:"notes,omitempty"`
-	EpicAuths    *EpicAuths             `protobuf:"bytes,12,opt,name=epic_auths,json=epicAuths,proto3" json:"epic_auths,omitempty"`
+	SourceIsdAs      uint64 `protobuf:"varint,1,opt,name=source_isd_as,json=sourceIsdAs,proto3" json:"source_isd_as,omitempty"`
+	DestinationIsdAs uint64 `protobuf:"varint,2,opt,name=destination_isd_as,json=destinationIsdAs,proto3" json:"destination_isd_as,omitempty"`
 }
 
-func (x *Path) Reset() {
-	*x = Path{}
+func (x *WatchPathsRequest) Reset() {
+	*x = WatchPathsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -225,13 +400,13 @@ func (x *Path) Reset() {
 	}
 }
 
-func (x *Path) String() string {
+func (x *WatchPathsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Path) ProtoMessage() {}
+func (*WatchPathsRequest) ProtoMessage() {}
 
-func (x *Path) ProtoReflect() protoreflect.Message {
+func (x *WatchPathsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -243,106 +418,35 @@ func (x *Path) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Path.ProtoReflect.Descriptor instead.
-func (*Path) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchPathsRequest.ProtoReflect.Descriptor instead.
+func (*WatchPathsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Path) GetRaw() []byte {
-	if x != nil {
-		return x.Raw
-	}
-	return nil
-}
-
-func (x *Path) GetInterface() *Interface {
-	if x != nil {
-		return x.Interface
-	}
-	return nil
-}
-
-func (x *Path) GetInterfaces() []*PathInterface {
-	if x != nil {
-		return x.Interfaces
-	}
-	return nil
-}
-
-func (x *Path) GetMtu() uint32 {
+func (x *WatchPathsRequest) GetSourceIsdAs() uint64 {
 	if x != nil {
-		return x.Mtu
+		return x.SourceIsdAs
 	}
 	return 0
 }
 
-func (x *Path) GetExpiration() *timestamppb.Timestamp {
-	if x != nil {
-		return x.Expiration
-	}
-	return nil
-}
-
-func (x *Path) GetLatency() []*durationpb.Duration {
-	if x != nil {
-		return x.Latency
-	}
-	return nil
-}
-
-func (x *Path) GetBandwidth() []uint64 {
-	if x != nil {
-		return x.Bandwidth
-	}
-	return nil
-}
-
-func (x *Path) GetGeo() []*GeoCoordinates {
-	if x != nil {
-		return x.Geo
-	}
-	return nil
-}
-
-func (x *Path) GetLinkType() []LinkType {
-	if x != nil {
-		return x.LinkType
-	}
-	return nil
-}
-
-func (x *Path) GetInternalHops() []uint32 {
-	if x != nil {
-		return x.InternalHops
-	}
-	return nil
-}
-
-func (x *Path) GetNotes() []string {
-	if x != nil {
-		return x.Notes
-	}
-	return nil
-}
-
-func (x *Path) GetEpicAuths() *EpicAuths {
+func (x *WatchPathsRequest) GetDestinationIsdAs() uint64 {
 	if x != nil {
-		return x.EpicAuths
+		return x.DestinationIsdAs
 	}
-	return nil
+	return 0
 }
 
-type EpicAuths struct {
+type WatchPathsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AuthPhvf []byte `protobuf:"bytes,1,opt,name=auth_phvf,json=authPhvf,proto3" json:"auth_phvf,omitempty"`
-	AuthLhvf []byte `protobuf:"bytes,2,opt,name=auth_lhvf,json=authLhvf,proto3" json:"auth_lhvf,omitempty"`
+	Paths []*Path `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
 }
 
-func (x *EpicAuths) Reset() {
-	*x = EpicAuths{}
+func (x *WatchPathsResponse) Reset() {
+	*x = WatchPathsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -350,13 +454,13 @@ func (x *EpicAuths) Reset() {
 	}
 }
 
-func (x *EpicAuths) String() string {
+func (x *WatchPathsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EpicAuths) ProtoMessage() {}
+func (*WatchPathsResponse) ProtoMessage() {}
 
-func (x *EpicAuths) ProtoReflect() protoreflect.Message {
+func (x *WatchPathsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -368,36 +472,30 @@ func (x *EpicAuths) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EpicAuths.ProtoReflect.Descriptor instead.
-func (*EpicAuths) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchPathsResponse.ProtoReflect.Descriptor instead.
+func (*WatchPathsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *EpicAuths) GetAuthPhvf() []byte {
-	if x != nil {
-		return x.AuthPhvf
-	}
-	return nil
-}
-
-func (x *EpicAuths) GetAuthLhvf() []byte {
+func (x *WatchPathsResponse) GetPaths() []*Path {
 	if x != nil {
-		return x.AuthLhvf
+		return x.Paths
 	}
 	return nil
 }
 
-type PathInterface struct {
+type PathByFingerprintRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IsdAs uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
-	Id    uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	SourceIsdAs      uint64 `protobuf:"varint,1,opt,name=source_isd_as,json=sourceIsdAs,proto3" json:"source_isd_as,omitempty"`
+	DestinationIsdAs uint64 `protobuf:"varint,2,opt,name=destination_isd_as,json=destinationIsdAs,proto3" json:"destination_isd_as,omitempty"`
+	Fingerprint      []byte `protobuf:"bytes,3,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
 }
 
-func (x *PathInterface) Reset() {
-	*x = PathInterface{}
+func (x *PathByFingerprintRequest) Reset() {
+	*x = PathByFingerprintRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -405,13 +503,13 @@ func (x *PathInterface) Reset() {
 	}
 }
 
-func (x *PathInterface) String() string {
+func (x *PathByFingerprintRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PathInterface) ProtoMessage() {}
+func (*PathByFingerprintRequest) ProtoMessage() {}
 
-func (x *PathInterface) ProtoReflect() protoreflect.Message {
+func (x *PathByFingerprintRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -423,37 +521,42 @@ func (x *PathInterface) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PathInterface.ProtoReflect.Descriptor instead.
-func (*PathInterface) Descriptor() ([]byte, []int) {
+// Deprecated: Use PathByFingerprintRequest.ProtoReflect.Descriptor instead.
+func (*PathByFingerprintRequest) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PathInterface) GetIsdAs() uint64 {
+func (x *PathByFingerprintRequest) GetSourceIsdAs() uint64 {
 	if x != nil {
-		return x.IsdAs
+		return x.SourceIsdAs
 	}
 	return 0
 }
 
-func (x *PathInterface) GetId() uint64 {
+func (x *PathByFingerprintRequest) GetDestinationIsdAs() uint64 {
 	if x != nil {
-		return x.Id
+		return x.DestinationIsdAs
 	}
 	return 0
 }
 
-type GeoCoordinates struct {
+func (x *PathByFingerprintRequest) GetFingerprint() []byte {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return nil
+}
+
+type PathByFingerprintResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Latitude  float32 `protobuf:"fixed32,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude float32 `protobuf:"fixed32,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	Address   string  `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Path *Path `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 }
 
-func (x *GeoCoordinates) Reset() {
-	*x = GeoCoordinates{}
+func (x *PathByFingerprintResponse) Reset() {
+	*x = PathByFingerprintResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -461,13 +564,13 @@ func (x *GeoCoordinates) Reset() {
 	}
 }
 
-func (x *GeoCoordinates) String() string {
+func (x *PathByFingerprintResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GeoCoordinates) ProtoMessage() {}
+func (*PathByFingerprintResponse) ProtoMessage() {}
 
-func (x *GeoCoordinates) ProtoReflect() protoreflect.Message {
+func (x *PathByFingerprintResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -479,42 +582,32 @@ func (x *GeoCoordinates) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GeoCoordinates.ProtoReflect.Descriptor instead.
-func (*GeoCoordinates) Descriptor() ([]byte, []int) {
+// Deprecated: Use PathByFingerprintResponse.ProtoReflect.Descriptor instead.
+func (*PathByFingerprintResponse) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GeoCoordinates) GetLatitude() float32 {
-	if x != nil {
-		return x.Latitude
-	}
-	return 0
-}
-
-func (x *GeoCoordinates) GetLongitude() float32 {
-	if x != nil {
-		return x.Longitude
-	}
-	return 0
-}
-
-func (x *GeoCoordinates) GetAddress() string {
+func (x *PathByFingerprintResponse) GetPath() *Path {
 	if x != nil {
-		return x.Address
+		return x.Path
 	}
-	return ""
+	return nil
 }
 
-type ASRequest struct {
+type DisjointPathsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IsdAs uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	SourceIsdAs      uint64           `protobuf:"varint,1,opt,name=source_isd_as,json=sourceIsdAs,proto3" json:"source_isd_as,omitempty"`
+	DestinationIsdAs uint64           `protobuf:"varint,2,opt,name=destination_isd_as,json=destinationIsdAs,proto3" json:"destination_isd_as,omitempty"`
+	Count            uint32           `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Mode             DisjointnessMode `protobuf:"varint,4,opt,name=mode,proto3,enum=proto.daemon.v1.DisjointnessMode" json:"mode,omitempty"`
+	Refresh          bool             `protobuf:"varint,5,opt,name=refresh,proto3" json:"refresh,omitempty"`
 }
 
-func (x *ASRequest) Reset() {
-	*x = ASRequest{}
+func (x *DisjointPathsRequest) Reset() {
+	*x = DisjointPathsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -522,13 +615,13 @@ func (x *ASRequest) Reset() {
 	}
 }
 
-func (x *ASRequest) String() string {
+func (x *DisjointPathsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ASRequest) ProtoMessage() {}
+func (*DisjointPathsRequest) ProtoMessage() {}
 
-func (x *ASRequest) ProtoReflect() protoreflect.Message {
+func (x *DisjointPathsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -540,30 +633,56 @@ func (x *ASRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ASRequest.ProtoReflect.Descriptor instead.
-func (*ASRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DisjointPathsRequest.ProtoReflect.Descriptor instead.
+func (*DisjointPathsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ASRequest) GetIsdAs() uint64 {
+func (x *DisjointPathsRequest) GetSourceIsdAs() uint64 {
 	if x != nil {
-		return x.IsdAs
+		return x.SourceIsdAs
 	}
 	return 0
 }
 
-type ASResponse struct {
+func (x *DisjointPathsRequest) GetDestinationIsdAs() uint64 {
+	if x != nil {
+		return x.DestinationIsdAs
+	}
+	return 0
+}
+
+func (x *DisjointPathsRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DisjointPathsRequest) GetMode() DisjointnessMode {
+	if x != nil {
+		return x.Mode
+	}
+	return DisjointnessMode_DISJOINTNESS_MODE_UNSPECIFIED
+}
+
+func (x *DisjointPathsRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+type DisjointPathsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IsdAs uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
-	Core  bool   `protobuf:"varint,2,opt,name=core,proto3" json:"core,omitempty"`
-	Mtu   uint32 `protobuf:"varint,3,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	Paths []*Path `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
 }
 
-func (x *ASResponse) Reset() {
-	*x = ASResponse{}
+func (x *DisjointPathsResponse) Reset() {
+	*x = DisjointPathsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -571,13 +690,13 @@ func (x *ASResponse) Reset() {
 	}
 }
 
-func (x *ASResponse) String() string {
+func (x *DisjointPathsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ASResponse) ProtoMessage() {}
+func (*DisjointPathsResponse) ProtoMessage() {}
 
-func (x *ASResponse) ProtoReflect() protoreflect.Message {
+func (x *DisjointPathsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -589,40 +708,40 @@ func (x *ASResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ASResponse.ProtoReflect.Descriptor instead.
-func (*ASResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DisjointPathsResponse.ProtoReflect.Descriptor instead.
+func (*DisjointPathsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ASResponse) GetIsdAs() uint64 {
-	if x != nil {
-		return x.IsdAs
-	}
-	return 0
-}
-
-func (x *ASResponse) GetCore() bool {
-	if x != nil {
-		return x.Core
-	}
-	return false
-}
-
-func (x *ASResponse) GetMtu() uint32 {
+func (x *DisjointPathsResponse) GetPaths() []*Path {
 	if x != nil {
-		return x.Mtu
+		return x.Paths
 	}
-	return 0
+	return nil
 }
 
-type InterfacesRequest struct {
+type Path struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Raw          []byte                 `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	Interface    *Interface             `protobuf:"bytes,2,opt,name=interface,proto3" json:"interface,omitempty"`
+	Interfaces   []*PathInterface       `protobuf:"bytes,3,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	Mtu          uint32                 `protobuf:"varint,4,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	Expiration   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Latency      []*durationpb.Duration `protobuf:"bytes,6,rep,name=latency,proto3" json:"latency,omitempty"`
+	Bandwidth    []uint64               `protobuf:"varint,7,rep,packed,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	Geo          []*GeoCoordinates      `protobuf:"bytes,8,rep,name=geo,proto3" json:"geo,omitempty"`
+	LinkType     []LinkType             `protobuf:"varint,9,rep,packed,name=link_type,json=linkType,proto3,enum=proto.daemon.v1.LinkType" json:"link_type,omitempty"`
+	InternalHops []uint32               `protobuf:"varint,10,rep,packed,name=internal_hops,json=internalHops,proto3" json:"internal_hops,omitempty"`
+	Notes        []string               `protobuf:"bytes,11,rep,name=notes,proto3" json:"notes,omitempty"`
+	EpicAuths    *EpicAuths             `protobuf:"bytes,12,opt,name=epic_auths,json=epicAuths,proto3" json:"epic_auths,omitempty"`
+	Peering      bool                   `protobuf:"varint,13,opt,name=peering,proto3" json:"peering,omitempty"`
 }
 
-func (x *InterfacesRequest) Reset() {
-	*x = InterfacesRequest{}
+func (x *Path) Reset() {
+	*x = Path{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -630,13 +749,13 @@ func (x *InterfacesRequest) Reset() {
 	}
 }
 
-func (x *InterfacesRequest) String() string {
+func (x *Path) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InterfacesRequest) ProtoMessage() {}
+func (*Path) ProtoMessage() {}
 
-func (x *InterfacesRequest) ProtoReflect() protoreflect.Message {
+func (x *Path) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -648,21 +767,113 @@ func (x *InterfacesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InterfacesRequest.ProtoReflect.Descriptor instead.
-func (*InterfacesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Path.ProtoReflect.Descriptor instead.
+func (*Path) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{8}
 }
 
-type InterfacesResponse struct {
+func (x *Path) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *Path) GetInterface() *Interface {
+	if x != nil {
+		return x.Interface
+	}
+	return nil
+}
+
+func (x *Path) GetInterfaces() []*PathInterface {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *Path) GetMtu() uint32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+func (x *Path) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+func (x *Path) GetLatency() []*durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *Path) GetBandwidth() []uint64 {
+	if x != nil {
+		return x.Bandwidth
+	}
+	return nil
+}
+
+func (x *Path) GetGeo() []*GeoCoordinates {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *Path) GetLinkType() []LinkType {
+	if x != nil {
+		return x.LinkType
+	}
+	return nil
+}
+
+func (x *Path) GetInternalHops() []uint32 {
+	if x != nil {
+		return x.InternalHops
+	}
+	return nil
+}
+
+func (x *Path) GetNotes() []string {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *Path) GetEpicAuths() *EpicAuths {
+	if x != nil {
+		return x.EpicAuths
+	}
+	return nil
+}
+
+func (x *Path) GetPeering() bool {
+	if x != nil {
+		return x.Peering
+	}
+	return false
+}
+
+type EpicAuths struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Interfaces map[uint64]*Interface `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	AuthPhvf []byte `protobuf:"bytes,1,opt,name=auth_phvf,json=authPhvf,proto3" json:"auth_phvf,omitempty"`
+	AuthLhvf []byte `protobuf:"bytes,2,opt,name=auth_lhvf,json=authLhvf,proto3" json:"auth_lhvf,omitempty"`
 }
 
-func (x *InterfacesResponse) Reset() {
-	*x = InterfacesResponse{}
+func (x *EpicAuths) Reset() {
+	*x = EpicAuths{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -670,13 +881,13 @@ func (x *InterfacesResponse) Reset() {
 	}
 }
 
-func (x *InterfacesResponse) String() string {
+func (x *EpicAuths) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InterfacesResponse) ProtoMessage() {}
+func (*EpicAuths) ProtoMessage() {}
 
-func (x *InterfacesResponse) ProtoReflect() protoreflect.Message {
+func (x *EpicAuths) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -688,28 +899,36 @@ func (x *InterfacesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InterfacesResponse.ProtoReflect.Descriptor instead.
-func (*InterfacesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use EpicAuths.ProtoReflect.Descriptor instead.
+func (*EpicAuths) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *InterfacesResponse) GetInterfaces() map[uint64]*Interface {
+func (x *EpicAuths) GetAuthPhvf() []byte {
 	if x != nil {
-		return x.Interfaces
+		return x.AuthPhvf
 	}
 	return nil
 }
 
-type Interface struct {
+func (x *EpicAuths) GetAuthLhvf() []byte {
+	if x != nil {
+		return x.AuthLhvf
+	}
+	return nil
+}
+
+type PathInterface struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address *Underlay `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	IsdAs uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	Id    uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *Interface) Reset() {
-	*x = Interface{}
+func (x *PathInterface) Reset() {
+	*x = PathInterface{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -717,13 +936,13 @@ func (x *Interface) Reset() {
 	}
 }
 
-func (x *Interface) String() string {
+func (x *PathInterface) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Interface) ProtoMessage() {}
+func (*PathInterface) ProtoMessage() {}
 
-func (x *Interface) ProtoReflect() protoreflect.Message {
+func (x *PathInterface) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -735,26 +954,37 @@ func (x *Interface) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Interface.ProtoReflect.Descriptor instead.
-func (*Interface) Descriptor() ([]byte, []int) {
+// Deprecated: Use PathInterface.ProtoReflect.Descriptor instead.
+func (*PathInterface) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Interface) GetAddress() *Underlay {
+func (x *PathInterface) GetIsdAs() uint64 {
 	if x != nil {
-		return x.Address
+		return x.IsdAs
 	}
-	return nil
+	return 0
 }
 
-type ServicesRequest struct {
+func (x *PathInterface) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GeoCoordinates struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Latitude  float32 `protobuf:"fixed32,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float32 `protobuf:"fixed32,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Address   string  `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
 }
 
-func (x *ServicesRequest) Reset() {
-	*x = ServicesRequest{}
+func (x *GeoCoordinates) Reset() {
+	*x = GeoCoordinates{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -762,13 +992,13 @@ func (x *ServicesRequest) Reset() {
 	}
 }
 
-func (x *ServicesRequest) String() string {
+func (x *GeoCoordinates) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServicesRequest) ProtoMessage() {}
+func (*GeoCoordinates) ProtoMessage() {}
 
-func (x *ServicesRequest) ProtoReflect() protoreflect.Message {
+func (x *GeoCoordinates) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -780,21 +1010,42 @@ func (x *ServicesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServicesRequest.ProtoReflect.Descriptor instead.
-func (*ServicesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GeoCoordinates.ProtoReflect.Descriptor instead.
+func (*GeoCoordinates) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{11}
 }
 
-type ServicesResponse struct {
+func (x *GeoCoordinates) GetLatitude() float32 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoCoordinates) GetLongitude() float32 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GeoCoordinates) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type ASRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Services map[string]*ListService `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	IsdAs uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
 }
 
-func (x *ServicesResponse) Reset() {
-	*x = ServicesResponse{}
+func (x *ASRequest) Reset() {
+	*x = ASRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -802,13 +1053,13 @@ func (x *ServicesResponse) Reset() {
 	}
 }
 
-func (x *ServicesResponse) String() string {
+func (x *ASRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServicesResponse) ProtoMessage() {}
+func (*ASRequest) ProtoMessage() {}
 
-func (x *ServicesResponse) ProtoReflect() protoreflect.Message {
+func (x *ASRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -820,28 +1071,30 @@ func (x *ServicesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServicesResponse.ProtoReflect.Descriptor instead.
-func (*ServicesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ASRequest.ProtoReflect.Descriptor instead.
+func (*ASRequest) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ServicesResponse) GetServices() map[string]*ListService {
+func (x *ASRequest) GetIsdAs() uint64 {
 	if x != nil {
-		return x.Services
+		return x.IsdAs
 	}
-	return nil
+	return 0
 }
 
-type ListService struct {
+type ASResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Services []*Service `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	IsdAs uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	Core  bool   `protobuf:"varint,2,opt,name=core,proto3" json:"core,omitempty"`
+	Mtu   uint32 `protobuf:"varint,3,opt,name=mtu,proto3" json:"mtu,omitempty"`
 }
 
-func (x *ListService) Reset() {
-	*x = ListService{}
+func (x *ASResponse) Reset() {
+	*x = ASResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -849,13 +1102,13 @@ func (x *ListService) Reset() {
 	}
 }
 
-func (x *ListService) String() string {
+func (x *ASResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListService) ProtoMessage() {}
+func (*ASResponse) ProtoMessage() {}
 
-func (x *ListService) ProtoReflect() protoreflect.Message {
+func (x *ASResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -867,28 +1120,40 @@ func (x *ListService) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListService.ProtoReflect.Descriptor instead.
-func (*ListService) Descriptor() ([]byte, []int) {
+// Deprecated: Use ASResponse.ProtoReflect.Descriptor instead.
+func (*ASResponse) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ListService) GetServices() []*Service {
+func (x *ASResponse) GetIsdAs() uint64 {
 	if x != nil {
-		return x.Services
+		return x.IsdAs
 	}
-	return nil
+	return 0
 }
 
-type Service struct {
+func (x *ASResponse) GetCore() bool {
+	if x != nil {
+		return x.Core
+	}
+	return false
+}
+
+func (x *ASResponse) GetMtu() uint32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+type InterfacesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Uri string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
 }
 
-func (x *Service) Reset() {
-	*x = Service{}
+func (x *InterfacesRequest) Reset() {
+	*x = InterfacesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -896,13 +1161,13 @@ func (x *Service) Reset() {
 	}
 }
 
-func (x *Service) String() string {
+func (x *InterfacesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Service) ProtoMessage() {}
+func (*InterfacesRequest) ProtoMessage() {}
 
-func (x *Service) ProtoReflect() protoreflect.Message {
+func (x *InterfacesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -914,28 +1179,21 @@ func (x *Service) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Service.ProtoReflect.Descriptor instead.
-func (*Service) Descriptor() ([]byte, []int) {
+// Deprecated: Use InterfacesRequest.ProtoReflect.Descriptor instead.
+func (*InterfacesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Service) GetUri() string {
-	if x != nil {
-		return x.Uri
-	}
-	return ""
-}
-
-type Underlay struct {
+type InterfacesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Interfaces map[uint64]*Interface `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *Underlay) Reset() {
-	*x = Underlay{}
+func (x *InterfacesResponse) Reset() {
+	*x = InterfacesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -943,13 +1201,13 @@ func (x *Underlay) Reset() {
 	}
 }
 
-func (x *Underlay) String() string {
+func (x *InterfacesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Underlay) ProtoMessage() {}
+func (*InterfacesResponse) ProtoMessage() {}
 
-func (x *Underlay) ProtoReflect() protoreflect.Message {
+func (x *InterfacesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -961,29 +1219,28 @@ func (x *Underlay) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Underlay.ProtoReflect.Descriptor instead.
-func (*Underlay) Descriptor() ([]byte, []int) {
+// Deprecated: Use InterfacesResponse.ProtoReflect.Descriptor instead.
+func (*InterfacesResponse) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *Underlay) GetAddress() string {
+func (x *InterfacesResponse) GetInterfaces() map[uint64]*Interface {
 	if x != nil {
-		return x.Address
+		return x.Interfaces
 	}
-	return ""
+	return nil
 }
 
-type NotifyInterfaceDownRequest struct {
+type Interface struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IsdAs uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
-	Id    uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Address *Underlay `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 }
 
-func (x *NotifyInterfaceDownRequest) Reset() {
-	*x = NotifyInterfaceDownRequest{}
+func (x *Interface) Reset() {
+	*x = Interface{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -991,13 +1248,13 @@ func (x *NotifyInterfaceDownRequest) Reset() {
 	}
 }
 
-func (x *NotifyInterfaceDownRequest) String() string {
+func (x *Interface) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotifyInterfaceDownRequest) ProtoMessage() {}
+func (*Interface) ProtoMessage() {}
 
-func (x *NotifyInterfaceDownRequest) ProtoReflect() protoreflect.Message {
+func (x *Interface) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1009,33 +1266,26 @@ func (x *NotifyInterfaceDownRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotifyInterfaceDownRequest.ProtoReflect.Descriptor instead.
-func (*NotifyInterfaceDownRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Interface.ProtoReflect.Descriptor instead.
+func (*Interface) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *NotifyInterfaceDownRequest) GetIsdAs() uint64 {
-	if x != nil {
-		return x.IsdAs
-	}
-	return 0
-}
-
-func (x *NotifyInterfaceDownRequest) GetId() uint64 {
+func (x *Interface) GetAddress() *Underlay {
 	if x != nil {
-		return x.Id
+		return x.Address
 	}
-	return 0
+	return nil
 }
 
-type NotifyInterfaceDownResponse struct {
+type ServicesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *NotifyInterfaceDownResponse) Reset() {
-	*x = NotifyInterfaceDownResponse{}
+func (x *ServicesRequest) Reset() {
+	*x = ServicesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1043,13 +1293,13 @@ func (x *NotifyInterfaceDownResponse) Reset() {
 	}
 }
 
-func (x *NotifyInterfaceDownResponse) String() string {
+func (x *ServicesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotifyInterfaceDownResponse) ProtoMessage() {}
+func (*ServicesRequest) ProtoMessage() {}
 
-func (x *NotifyInterfaceDownResponse) ProtoReflect() protoreflect.Message {
+func (x *ServicesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1061,25 +1311,21 @@ func (x *NotifyInterfaceDownResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotifyInterfaceDownResponse.ProtoReflect.Descriptor instead.
-func (*NotifyInterfaceDownResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ServicesRequest.ProtoReflect.Descriptor instead.
+func (*ServicesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{17}
 }
 
-type DRKeyHostASRequest struct {
+type ServicesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ValTime    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=val_time,json=valTime,proto3" json:"val_time,omitempty"`
-	ProtocolId drkey.Protocol         `protobuf:"varint,2,opt,name=protocol_id,json=protocolId,proto3,enum=proto.drkey.v1.Protocol" json:"protocol_id,omitempty"`
-	SrcIa      uint64                 `protobuf:"varint,3,opt,name=src_ia,json=srcIa,proto3" json:"src_ia,omitempty"`
-	DstIa      uint64                 `protobuf:"varint,4,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
-	SrcHost    string                 `protobuf:"bytes,5,opt,name=src_host,json=srcHost,proto3" json:"src_host,omitempty"`
+	Services map[string]*ListService `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *DRKeyHostASRequest) Reset() {
-	*x = DRKeyHostASRequest{}
+func (x *ServicesResponse) Reset() {
+	*x = ServicesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1087,13 +1333,13 @@ func (x *DRKeyHostASRequest) Reset() {
 	}
 }
 
-func (x *DRKeyHostASRequest) String() string {
+func (x *ServicesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DRKeyHostASRequest) ProtoMessage() {}
+func (*ServicesResponse) ProtoMessage() {}
 
-func (x *DRKeyHostASRequest) ProtoReflect() protoreflect.Message {
+func (x *ServicesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1105,73 +1351,90 @@ func (x *DRKeyHostASRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DRKeyHostASRequest.ProtoReflect.Descriptor instead.
-func (*DRKeyHostASRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ServicesResponse.ProtoReflect.Descriptor instead.
+func (*ServicesResponse) Descriptor() ([]byte, []int) {
 	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *DRKeyHostASRequest) GetValTime() *timestamppb.Timestamp {
+func (x *ServicesResponse) GetServices() map[string]*ListService {
 	if x != nil {
-		return x.ValTime
+		return x.Services
 	}
 	return nil
 }
 
-func (x *DRKeyHostASRequest) GetProtocolId() drkey.Protocol {
-	if x != nil {
-		return x.ProtocolId
-	}
-	return drkey.Protocol(0)
-}
+type ListService struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *DRKeyHostASRequest) GetSrcIa() uint64 {
-	if x != nil {
-		return x.SrcIa
-	}
-	return 0
+	Services []*Service `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
 }
 
-func (x *DRKeyHostASRequest) GetDstIa() uint64 {
-	if x != nil {
-		return x.DstIa
+func (x *ListService) Reset() {
+	*x = ListService{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *DRKeyHostASRequest) GetSrcHost() string {
+func (x *ListService) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListService) ProtoMessage() {}
+
+func (x *ListService) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListService.ProtoReflect.Descriptor instead.
+func (*ListService) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListService) GetServices() []*Service {
 	if x != nil {
-		return x.SrcHost
+		return x.Services
 	}
-	return ""
+	return nil
 }
 
-type DRKeyHostASResponse struct {
+type Service struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EpochBegin *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=epoch_begin,json=epochBegin,proto3" json:"epoch_begin,omitempty"`
-	EpochEnd   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch_end,json=epochEnd,proto3" json:"epoch_end,omitempty"`
-	Key        []byte                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Uri string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
 }
 
-func (x *DRKeyHostASResponse) Reset() {
-	*x = DRKeyHostASResponse{}
+func (x *Service) Reset() {
+	*x = Service{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[19]
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DRKeyHostASResponse) String() string {
+func (x *Service) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DRKeyHostASResponse) ProtoMessage() {}
+func (*Service) ProtoMessage() {}
 
-func (x *DRKeyHostASResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[19]
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1182,61 +1445,85 @@ func (x *DRKeyHostASResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DRKeyHostASResponse.ProtoReflect.Descriptor instead.
-func (*DRKeyHostASResponse) Descriptor() ([]byte, []int) {
-	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *DRKeyHostASResponse) GetEpochBegin() *timestamppb.Timestamp {
+func (x *Service) GetUri() string {
 	if x != nil {
-		return x.EpochBegin
+		return x.Uri
 	}
-	return nil
+	return ""
 }
 
-func (x *DRKeyHostASResponse) GetEpochEnd() *timestamppb.Timestamp {
-	if x != nil {
-		return x.EpochEnd
+type LocalTopologyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LocalTopologyRequest) Reset() {
+	*x = LocalTopologyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *DRKeyHostASResponse) GetKey() []byte {
-	if x != nil {
-		return x.Key
+func (x *LocalTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalTopologyRequest) ProtoMessage() {}
+
+func (x *LocalTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type DRKeyASHostRequest struct {
+// Deprecated: Use LocalTopologyRequest.ProtoReflect.Descriptor instead.
+func (*LocalTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{21}
+}
+
+type LocalTopologyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ValTime    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=val_time,json=valTime,proto3" json:"val_time,omitempty"`
-	ProtocolId drkey.Protocol         `protobuf:"varint,2,opt,name=protocol_id,json=protocolId,proto3,enum=proto.drkey.v1.Protocol" json:"protocol_id,omitempty"`
-	SrcIa      uint64                 `protobuf:"varint,3,opt,name=src_ia,json=srcIa,proto3" json:"src_ia,omitempty"`
-	DstIa      uint64                 `protobuf:"varint,4,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
-	DstHost    string                 `protobuf:"bytes,5,opt,name=dst_host,json=dstHost,proto3" json:"dst_host,omitempty"`
+	IsdAs           uint64            `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	Core            bool              `protobuf:"varint,2,opt,name=core,proto3" json:"core,omitempty"`
+	Mtu             uint32            `protobuf:"varint,3,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	BorderRouters   []*BorderRouter   `protobuf:"bytes,4,rep,name=border_routers,json=borderRouters,proto3" json:"border_routers,omitempty"`
+	ControlServices []*ControlService `protobuf:"bytes,5,rep,name=control_services,json=controlServices,proto3" json:"control_services,omitempty"`
 }
 
-func (x *DRKeyASHostRequest) Reset() {
-	*x = DRKeyASHostRequest{}
+func (x *LocalTopologyResponse) Reset() {
+	*x = LocalTopologyResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[20]
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DRKeyASHostRequest) String() string {
+func (x *LocalTopologyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DRKeyASHostRequest) ProtoMessage() {}
+func (*LocalTopologyResponse) ProtoMessage() {}
 
-func (x *DRKeyASHostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[20]
+func (x *LocalTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1247,73 +1534,73 @@ func (x *DRKeyASHostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DRKeyASHostRequest.ProtoReflect.Descriptor instead.
-func (*DRKeyASHostRequest) Descriptor() ([]byte, []int) {
-	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use LocalTopologyResponse.ProtoReflect.Descriptor instead.
+func (*LocalTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *DRKeyASHostRequest) GetValTime() *timestamppb.Timestamp {
+func (x *LocalTopologyResponse) GetIsdAs() uint64 {
 	if x != nil {
-		return x.ValTime
+		return x.IsdAs
 	}
-	return nil
+	return 0
 }
 
-func (x *DRKeyASHostRequest) GetProtocolId() drkey.Protocol {
+func (x *LocalTopologyResponse) GetCore() bool {
 	if x != nil {
-		return x.ProtocolId
+		return x.Core
 	}
-	return drkey.Protocol(0)
+	return false
 }
 
-func (x *DRKeyASHostRequest) GetSrcIa() uint64 {
+func (x *LocalTopologyResponse) GetMtu() uint32 {
 	if x != nil {
-		return x.SrcIa
+		return x.Mtu
 	}
 	return 0
 }
 
-func (x *DRKeyASHostRequest) GetDstIa() uint64 {
+func (x *LocalTopologyResponse) GetBorderRouters() []*BorderRouter {
 	if x != nil {
-		return x.DstIa
+		return x.BorderRouters
 	}
-	return 0
+	return nil
 }
 
-func (x *DRKeyASHostRequest) GetDstHost() string {
+func (x *LocalTopologyResponse) GetControlServices() []*ControlService {
 	if x != nil {
-		return x.DstHost
+		return x.ControlServices
 	}
-	return ""
+	return nil
 }
 
-type DRKeyASHostResponse struct {
+type BorderRouter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EpochBegin *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=epoch_begin,json=epochBegin,proto3" json:"epoch_begin,omitempty"`
-	EpochEnd   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch_end,json=epochEnd,proto3" json:"epoch_end,omitempty"`
-	Key        []byte                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Name            string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	InternalAddress *Underlay                `protobuf:"bytes,2,opt,name=internal_address,json=internalAddress,proto3" json:"internal_address,omitempty"`
+	Interfaces      []*BorderRouterInterface `protobuf:"bytes,3,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
 }
 
-func (x *DRKeyASHostResponse) Reset() {
-	*x = DRKeyASHostResponse{}
+func (x *BorderRouter) Reset() {
+	*x = BorderRouter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[21]
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DRKeyASHostResponse) String() string {
+func (x *BorderRouter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DRKeyASHostResponse) ProtoMessage() {}
+func (*BorderRouter) ProtoMessage() {}
 
-func (x *DRKeyASHostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[21]
+func (x *BorderRouter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1324,62 +1611,61 @@ func (x *DRKeyASHostResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DRKeyASHostResponse.ProtoReflect.Descriptor instead.
-func (*DRKeyASHostResponse) Descriptor() ([]byte, []int) {
-	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use BorderRouter.ProtoReflect.Descriptor instead.
+func (*BorderRouter) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *DRKeyASHostResponse) GetEpochBegin() *timestamppb.Timestamp {
+func (x *BorderRouter) GetName() string {
 	if x != nil {
-		return x.EpochBegin
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *DRKeyASHostResponse) GetEpochEnd() *timestamppb.Timestamp {
+func (x *BorderRouter) GetInternalAddress() *Underlay {
 	if x != nil {
-		return x.EpochEnd
+		return x.InternalAddress
 	}
 	return nil
 }
 
-func (x *DRKeyASHostResponse) GetKey() []byte {
+func (x *BorderRouter) GetInterfaces() []*BorderRouterInterface {
 	if x != nil {
-		return x.Key
+		return x.Interfaces
 	}
 	return nil
 }
 
-type DRKeyHostHostRequest struct {
+type BorderRouterInterface struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ValTime    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=val_time,json=valTime,proto3" json:"val_time,omitempty"`
-	ProtocolId drkey.Protocol         `protobuf:"varint,2,opt,name=protocol_id,json=protocolId,proto3,enum=proto.drkey.v1.Protocol" json:"protocol_id,omitempty"`
-	SrcIa      uint64                 `protobuf:"varint,3,opt,name=src_ia,json=srcIa,proto3" json:"src_ia,omitempty"`
-	DstIa      uint64                 `protobuf:"varint,4,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
-	SrcHost    string                 `protobuf:"bytes,5,opt,name=src_host,json=srcHost,proto3" json:"src_host,omitempty"`
-	DstHost    string                 `protobuf:"bytes,6,opt,name=dst_host,json=dstHost,proto3" json:"dst_host,omitempty"`
+	Id            uint64       `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	NeighborIsdAs uint64       `protobuf:"varint,2,opt,name=neighbor_isd_as,json=neighborIsdAs,proto3" json:"neighbor_isd_as,omitempty"`
+	NeighborId    uint64       `protobuf:"varint,3,opt,name=neighbor_id,json=neighborId,proto3" json:"neighbor_id,omitempty"`
+	Relation      LinkRelation `protobuf:"varint,4,opt,name=relation,proto3,enum=proto.daemon.v1.LinkRelation" json:"relation,omitempty"`
+	Mtu           uint32       `protobuf:"varint,5,opt,name=mtu,proto3" json:"mtu,omitempty"`
 }
 
-func (x *DRKeyHostHostRequest) Reset() {
-	*x = DRKeyHostHostRequest{}
+func (x *BorderRouterInterface) Reset() {
+	*x = BorderRouterInterface{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[22]
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DRKeyHostHostRequest) String() string {
+func (x *BorderRouterInterface) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DRKeyHostHostRequest) ProtoMessage() {}
+func (*BorderRouterInterface) ProtoMessage() {}
 
-func (x *DRKeyHostHostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[22]
+func (x *BorderRouterInterface) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1390,80 +1676,72 @@ func (x *DRKeyHostHostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DRKeyHostHostRequest.ProtoReflect.Descriptor instead.
-func (*DRKeyHostHostRequest) Descriptor() ([]byte, []int) {
-	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *DRKeyHostHostRequest) GetValTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ValTime
-	}
-	return nil
+// Deprecated: Use BorderRouterInterface.ProtoReflect.Descriptor instead.
+func (*BorderRouterInterface) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *DRKeyHostHostRequest) GetProtocolId() drkey.Protocol {
+func (x *BorderRouterInterface) GetId() uint64 {
 	if x != nil {
-		return x.ProtocolId
+		return x.Id
 	}
-	return drkey.Protocol(0)
+	return 0
 }
 
-func (x *DRKeyHostHostRequest) GetSrcIa() uint64 {
+func (x *BorderRouterInterface) GetNeighborIsdAs() uint64 {
 	if x != nil {
-		return x.SrcIa
+		return x.NeighborIsdAs
 	}
 	return 0
 }
 
-func (x *DRKeyHostHostRequest) GetDstIa() uint64 {
+func (x *BorderRouterInterface) GetNeighborId() uint64 {
 	if x != nil {
-		return x.DstIa
+		return x.NeighborId
 	}
 	return 0
 }
 
-func (x *DRKeyHostHostRequest) GetSrcHost() string {
+func (x *BorderRouterInterface) GetRelation() LinkRelation {
 	if x != nil {
-		return x.SrcHost
+		return x.Relation
 	}
-	return ""
+	return LinkRelation_LINK_RELATION_UNSPECIFIED
 }
 
-func (x *DRKeyHostHostRequest) GetDstHost() string {
+func (x *BorderRouterInterface) GetMtu() uint32 {
 	if x != nil {
-		return x.DstHost
+		return x.Mtu
 	}
-	return ""
+	return 0
 }
 
-type DRKeyHostHostResponse struct {
+type ControlService struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EpochBegin *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=epoch_begin,json=epochBegin,proto3" json:"epoch_begin,omitempty"`
-	EpochEnd   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch_end,json=epochEnd,proto3" json:"epoch_end,omitempty"`
-	Key        []byte                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Name    string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address *Underlay `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
 }
 
-func (x *DRKeyHostHostResponse) Reset() {
-	*x = DRKeyHostHostResponse{}
+func (x *ControlService) Reset() {
+	*x = ControlService{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[23]
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DRKeyHostHostResponse) String() string {
+func (x *ControlService) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DRKeyHostHostResponse) ProtoMessage() {}
+func (*ControlService) ProtoMessage() {}
 
-func (x *DRKeyHostHostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[23]
+func (x *ControlService) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1474,44 +1752,1081 @@ func (x *DRKeyHostHostResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DRKeyHostHostResponse.ProtoReflect.Descriptor instead.
-func (*DRKeyHostHostResponse) Descriptor() ([]byte, []int) {
-	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ControlService.ProtoReflect.Descriptor instead.
+func (*ControlService) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *DRKeyHostHostResponse) GetEpochBegin() *timestamppb.Timestamp {
+func (x *ControlService) GetName() string {
 	if x != nil {
-		return x.EpochBegin
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *DRKeyHostHostResponse) GetEpochEnd() *timestamppb.Timestamp {
+func (x *ControlService) GetAddress() *Underlay {
 	if x != nil {
-		return x.EpochEnd
+		return x.Address
 	}
 	return nil
 }
 
-func (x *DRKeyHostHostResponse) GetKey() []byte {
-	if x != nil {
-		return x.Key
+type Underlay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *Underlay) Reset() {
+	*x = Underlay{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-var File_proto_daemon_v1_daemon_proto protoreflect.FileDescriptor
+func (x *Underlay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-var file_proto_daemon_v1_daemon_proto_rawDesc = []byte{
-	0x0a, 0x1c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2f, 0x76,
-	0x31, 0x2f, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x1a,
-	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+func (*Underlay) ProtoMessage() {}
+
+func (x *Underlay) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Underlay.ProtoReflect.Descriptor instead.
+func (*Underlay) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Underlay) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type NotifyInterfaceDownRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsdAs uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	Id    uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *NotifyInterfaceDownRequest) Reset() {
+	*x = NotifyInterfaceDownRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NotifyInterfaceDownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyInterfaceDownRequest) ProtoMessage() {}
+
+func (x *NotifyInterfaceDownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyInterfaceDownRequest.ProtoReflect.Descriptor instead.
+func (*NotifyInterfaceDownRequest) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *NotifyInterfaceDownRequest) GetIsdAs() uint64 {
+	if x != nil {
+		return x.IsdAs
+	}
+	return 0
+}
+
+func (x *NotifyInterfaceDownRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type NotifyInterfaceDownResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NotifyInterfaceDownResponse) Reset() {
+	*x = NotifyInterfaceDownResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NotifyInterfaceDownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyInterfaceDownResponse) ProtoMessage() {}
+
+func (x *NotifyInterfaceDownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyInterfaceDownResponse.ProtoReflect.Descriptor instead.
+func (*NotifyInterfaceDownResponse) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{28}
+}
+
+type ReportPathUsageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Usages []*PathUsage `protobuf:"bytes,1,rep,name=usages,proto3" json:"usages,omitempty"`
+}
+
+func (x *ReportPathUsageRequest) Reset() {
+	*x = ReportPathUsageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportPathUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPathUsageRequest) ProtoMessage() {}
+
+func (x *ReportPathUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPathUsageRequest.ProtoReflect.Descriptor instead.
+func (*ReportPathUsageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReportPathUsageRequest) GetUsages() []*PathUsage {
+	if x != nil {
+		return x.Usages
+	}
+	return nil
+}
+
+type PathUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DestinationIsdAs uint64                 `protobuf:"varint,1,opt,name=destination_isd_as,json=destinationIsdAs,proto3" json:"destination_isd_as,omitempty"`
+	Fingerprint      []byte                 `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	PacketsSent      uint64                 `protobuf:"varint,3,opt,name=packets_sent,json=packetsSent,proto3" json:"packets_sent,omitempty"`
+	PacketsLost      uint64                 `protobuf:"varint,4,opt,name=packets_lost,json=packetsLost,proto3" json:"packets_lost,omitempty"`
+	RttSamples       []*durationpb.Duration `protobuf:"bytes,5,rep,name=rtt_samples,json=rttSamples,proto3" json:"rtt_samples,omitempty"`
+}
+
+func (x *PathUsage) Reset() {
+	*x = PathUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathUsage) ProtoMessage() {}
+
+func (x *PathUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathUsage.ProtoReflect.Descriptor instead.
+func (*PathUsage) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PathUsage) GetDestinationIsdAs() uint64 {
+	if x != nil {
+		return x.DestinationIsdAs
+	}
+	return 0
+}
+
+func (x *PathUsage) GetFingerprint() []byte {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return nil
+}
+
+func (x *PathUsage) GetPacketsSent() uint64 {
+	if x != nil {
+		return x.PacketsSent
+	}
+	return 0
+}
+
+func (x *PathUsage) GetPacketsLost() uint64 {
+	if x != nil {
+		return x.PacketsLost
+	}
+	return 0
+}
+
+func (x *PathUsage) GetRttSamples() []*durationpb.Duration {
+	if x != nil {
+		return x.RttSamples
+	}
+	return nil
+}
+
+type ReportPathUsageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReportPathUsageResponse) Reset() {
+	*x = ReportPathUsageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportPathUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPathUsageResponse) ProtoMessage() {}
+
+func (x *ReportPathUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPathUsageResponse.ProtoReflect.Descriptor instead.
+func (*ReportPathUsageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{31}
+}
+
+type DRKeyHostASRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValTime    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=val_time,json=valTime,proto3" json:"val_time,omitempty"`
+	ProtocolId drkey.Protocol         `protobuf:"varint,2,opt,name=protocol_id,json=protocolId,proto3,enum=proto.drkey.v1.Protocol" json:"protocol_id,omitempty"`
+	SrcIa      uint64                 `protobuf:"varint,3,opt,name=src_ia,json=srcIa,proto3" json:"src_ia,omitempty"`
+	DstIa      uint64                 `protobuf:"varint,4,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
+	SrcHost    string                 `protobuf:"bytes,5,opt,name=src_host,json=srcHost,proto3" json:"src_host,omitempty"`
+}
+
+func (x *DRKeyHostASRequest) Reset() {
+	*x = DRKeyHostASRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyHostASRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyHostASRequest) ProtoMessage() {}
+
+func (x *DRKeyHostASRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyHostASRequest.ProtoReflect.Descriptor instead.
+func (*DRKeyHostASRequest) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DRKeyHostASRequest) GetValTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValTime
+	}
+	return nil
+}
+
+func (x *DRKeyHostASRequest) GetProtocolId() drkey.Protocol {
+	if x != nil {
+		return x.ProtocolId
+	}
+	return drkey.Protocol(0)
+}
+
+func (x *DRKeyHostASRequest) GetSrcIa() uint64 {
+	if x != nil {
+		return x.SrcIa
+	}
+	return 0
+}
+
+func (x *DRKeyHostASRequest) GetDstIa() uint64 {
+	if x != nil {
+		return x.DstIa
+	}
+	return 0
+}
+
+func (x *DRKeyHostASRequest) GetSrcHost() string {
+	if x != nil {
+		return x.SrcHost
+	}
+	return ""
+}
+
+type DRKeyHostASResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EpochBegin *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=epoch_begin,json=epochBegin,proto3" json:"epoch_begin,omitempty"`
+	EpochEnd   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch_end,json=epochEnd,proto3" json:"epoch_end,omitempty"`
+	Key        []byte                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *DRKeyHostASResponse) Reset() {
+	*x = DRKeyHostASResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyHostASResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyHostASResponse) ProtoMessage() {}
+
+func (x *DRKeyHostASResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyHostASResponse.ProtoReflect.Descriptor instead.
+func (*DRKeyHostASResponse) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DRKeyHostASResponse) GetEpochBegin() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EpochBegin
+	}
+	return nil
+}
+
+func (x *DRKeyHostASResponse) GetEpochEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EpochEnd
+	}
+	return nil
+}
+
+func (x *DRKeyHostASResponse) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type DRKeyASHostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValTime    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=val_time,json=valTime,proto3" json:"val_time,omitempty"`
+	ProtocolId drkey.Protocol         `protobuf:"varint,2,opt,name=protocol_id,json=protocolId,proto3,enum=proto.drkey.v1.Protocol" json:"protocol_id,omitempty"`
+	SrcIa      uint64                 `protobuf:"varint,3,opt,name=src_ia,json=srcIa,proto3" json:"src_ia,omitempty"`
+	DstIa      uint64                 `protobuf:"varint,4,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
+	DstHost    string                 `protobuf:"bytes,5,opt,name=dst_host,json=dstHost,proto3" json:"dst_host,omitempty"`
+}
+
+func (x *DRKeyASHostRequest) Reset() {
+	*x = DRKeyASHostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyASHostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyASHostRequest) ProtoMessage() {}
+
+func (x *DRKeyASHostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyASHostRequest.ProtoReflect.Descriptor instead.
+func (*DRKeyASHostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DRKeyASHostRequest) GetValTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValTime
+	}
+	return nil
+}
+
+func (x *DRKeyASHostRequest) GetProtocolId() drkey.Protocol {
+	if x != nil {
+		return x.ProtocolId
+	}
+	return drkey.Protocol(0)
+}
+
+func (x *DRKeyASHostRequest) GetSrcIa() uint64 {
+	if x != nil {
+		return x.SrcIa
+	}
+	return 0
+}
+
+func (x *DRKeyASHostRequest) GetDstIa() uint64 {
+	if x != nil {
+		return x.DstIa
+	}
+	return 0
+}
+
+func (x *DRKeyASHostRequest) GetDstHost() string {
+	if x != nil {
+		return x.DstHost
+	}
+	return ""
+}
+
+type DRKeyASHostResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EpochBegin *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=epoch_begin,json=epochBegin,proto3" json:"epoch_begin,omitempty"`
+	EpochEnd   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch_end,json=epochEnd,proto3" json:"epoch_end,omitempty"`
+	Key        []byte                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *DRKeyASHostResponse) Reset() {
+	*x = DRKeyASHostResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyASHostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyASHostResponse) ProtoMessage() {}
+
+func (x *DRKeyASHostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyASHostResponse.ProtoReflect.Descriptor instead.
+func (*DRKeyASHostResponse) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DRKeyASHostResponse) GetEpochBegin() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EpochBegin
+	}
+	return nil
+}
+
+func (x *DRKeyASHostResponse) GetEpochEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EpochEnd
+	}
+	return nil
+}
+
+func (x *DRKeyASHostResponse) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type DRKeyHostHostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValTime    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=val_time,json=valTime,proto3" json:"val_time,omitempty"`
+	ProtocolId drkey.Protocol         `protobuf:"varint,2,opt,name=protocol_id,json=protocolId,proto3,enum=proto.drkey.v1.Protocol" json:"protocol_id,omitempty"`
+	SrcIa      uint64                 `protobuf:"varint,3,opt,name=src_ia,json=srcIa,proto3" json:"src_ia,omitempty"`
+	DstIa      uint64                 `protobuf:"varint,4,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
+	SrcHost    string                 `protobuf:"bytes,5,opt,name=src_host,json=srcHost,proto3" json:"src_host,omitempty"`
+	DstHost    string                 `protobuf:"bytes,6,opt,name=dst_host,json=dstHost,proto3" json:"dst_host,omitempty"`
+}
+
+func (x *DRKeyHostHostRequest) Reset() {
+	*x = DRKeyHostHostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyHostHostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyHostHostRequest) ProtoMessage() {}
+
+func (x *DRKeyHostHostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyHostHostRequest.ProtoReflect.Descriptor instead.
+func (*DRKeyHostHostRequest) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DRKeyHostHostRequest) GetValTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValTime
+	}
+	return nil
+}
+
+func (x *DRKeyHostHostRequest) GetProtocolId() drkey.Protocol {
+	if x != nil {
+		return x.ProtocolId
+	}
+	return drkey.Protocol(0)
+}
+
+func (x *DRKeyHostHostRequest) GetSrcIa() uint64 {
+	if x != nil {
+		return x.SrcIa
+	}
+	return 0
+}
+
+func (x *DRKeyHostHostRequest) GetDstIa() uint64 {
+	if x != nil {
+		return x.DstIa
+	}
+	return 0
+}
+
+func (x *DRKeyHostHostRequest) GetSrcHost() string {
+	if x != nil {
+		return x.SrcHost
+	}
+	return ""
+}
+
+func (x *DRKeyHostHostRequest) GetDstHost() string {
+	if x != nil {
+		return x.DstHost
+	}
+	return ""
+}
+
+type DRKeyHostHostResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EpochBegin *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=epoch_begin,json=epochBegin,proto3" json:"epoch_begin,omitempty"`
+	EpochEnd   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch_end,json=epochEnd,proto3" json:"epoch_end,omitempty"`
+	Key        []byte                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *DRKeyHostHostResponse) Reset() {
+	*x = DRKeyHostHostResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyHostHostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyHostHostResponse) ProtoMessage() {}
+
+func (x *DRKeyHostHostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyHostHostResponse.ProtoReflect.Descriptor instead.
+func (*DRKeyHostHostResponse) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DRKeyHostHostResponse) GetEpochBegin() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EpochBegin
+	}
+	return nil
+}
+
+func (x *DRKeyHostHostResponse) GetEpochEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EpochEnd
+	}
+	return nil
+}
+
+func (x *DRKeyHostHostResponse) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type ColibriReserveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DestinationIsdAs uint64                 `protobuf:"varint,1,opt,name=destination_isd_as,json=destinationIsdAs,proto3" json:"destination_isd_as,omitempty"`
+	Egress           uint64                 `protobuf:"varint,2,opt,name=egress,proto3" json:"egress,omitempty"`
+	Bandwidth        uint64                 `protobuf:"varint,3,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	Expiration       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiration,proto3" json:"expiration,omitempty"`
+}
+
+func (x *ColibriReserveRequest) Reset() {
+	*x = ColibriReserveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColibriReserveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColibriReserveRequest) ProtoMessage() {}
+
+func (x *ColibriReserveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColibriReserveRequest.ProtoReflect.Descriptor instead.
+func (*ColibriReserveRequest) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ColibriReserveRequest) GetDestinationIsdAs() uint64 {
+	if x != nil {
+		return x.DestinationIsdAs
+	}
+	return 0
+}
+
+func (x *ColibriReserveRequest) GetEgress() uint64 {
+	if x != nil {
+		return x.Egress
+	}
+	return 0
+}
+
+func (x *ColibriReserveRequest) GetBandwidth() uint64 {
+	if x != nil {
+		return x.Bandwidth
+	}
+	return 0
+}
+
+func (x *ColibriReserveRequest) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+type ColibriReserveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	IdAs          uint64                 `protobuf:"varint,2,opt,name=id_as,json=idAs,proto3" json:"id_as,omitempty"`
+	IdSuffix      uint32                 `protobuf:"varint,3,opt,name=id_suffix,json=idSuffix,proto3" json:"id_suffix,omitempty"`
+	Bandwidth     uint64                 `protobuf:"varint,4,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	Expiration    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	FailureReason string                 `protobuf:"bytes,6,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+}
+
+func (x *ColibriReserveResponse) Reset() {
+	*x = ColibriReserveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColibriReserveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColibriReserveResponse) ProtoMessage() {}
+
+func (x *ColibriReserveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColibriReserveResponse.ProtoReflect.Descriptor instead.
+func (*ColibriReserveResponse) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ColibriReserveResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *ColibriReserveResponse) GetIdAs() uint64 {
+	if x != nil {
+		return x.IdAs
+	}
+	return 0
+}
+
+func (x *ColibriReserveResponse) GetIdSuffix() uint32 {
+	if x != nil {
+		return x.IdSuffix
+	}
+	return 0
+}
+
+func (x *ColibriReserveResponse) GetBandwidth() uint64 {
+	if x != nil {
+		return x.Bandwidth
+	}
+	return 0
+}
+
+func (x *ColibriReserveResponse) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+func (x *ColibriReserveResponse) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+type HiddenPathGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *HiddenPathGroupsRequest) Reset() {
+	*x = HiddenPathGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathGroupsRequest) ProtoMessage() {}
+
+func (x *HiddenPathGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathGroupsRequest.ProtoReflect.Descriptor instead.
+func (*HiddenPathGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{40}
+}
+
+type HiddenPathGroupsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Groups []*HiddenPathGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *HiddenPathGroupsResponse) Reset() {
+	*x = HiddenPathGroupsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathGroupsResponse) ProtoMessage() {}
+
+func (x *HiddenPathGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathGroupsResponse.ProtoReflect.Descriptor instead.
+func (*HiddenPathGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *HiddenPathGroupsResponse) GetGroups() []*HiddenPathGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type HiddenPathGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Writers []uint64 `protobuf:"varint,2,rep,packed,name=writers,proto3" json:"writers,omitempty"`
+	Learned bool     `protobuf:"varint,3,opt,name=learned,proto3" json:"learned,omitempty"`
+}
+
+func (x *HiddenPathGroup) Reset() {
+	*x = HiddenPathGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_daemon_v1_daemon_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathGroup) ProtoMessage() {}
+
+func (x *HiddenPathGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_daemon_v1_daemon_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathGroup.ProtoReflect.Descriptor instead.
+func (*HiddenPathGroup) Descriptor() ([]byte, []int) {
+	return file_proto_daemon_v1_daemon_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *HiddenPathGroup) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *HiddenPathGroup) GetWriters() []uint64 {
+	if x != nil {
+		return x.Writers
+	}
+	return nil
+}
+
+func (x *HiddenPathGroup) GetLearned() bool {
+	if x != nil {
+		return x.Learned
+	}
+	return false
+}
+
+var File_proto_daemon_v1_daemon_proto protoreflect.FileDescriptor
+
+var file_proto_daemon_v1_daemon_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2f, 0x76,
+	0x31, 0x2f, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
 	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x1a, 0x1a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2f, 0x76, 0x31,
-	0x2f, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x92, 0x01, 0x0a,
+	0x2f, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb3, 0x02, 0x0a,
 	0x0c, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a,
 	0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x73, 0x64, 0x41,
@@ -1521,112 +2836,254 @@ var file_proto_daemon_v1_daemon_proto_rawDesc = []byte{
 	0x18, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
 	0x52, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x69, 0x64,
 	0x64, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x68, 0x69, 0x64, 0x64, 0x65,
-	0x6e, 0x22, 0x3c, 0x0a, 0x0d, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22,
-	0x94, 0x04, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x38, 0x0a, 0x09, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x6e, 0x12, 0x36, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x31, 0x0a, 0x15, 0x68, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x04, 0x52, 0x12, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e,
+	0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x61, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x04, 0x52, 0x09, 0x77, 0x61, 0x79, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x22, 0x3c, 0x0a, 0x0d, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73,
+	0x22, 0x65, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x73, 0x64, 0x41, 0x73, 0x22, 0x41, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a,
+	0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x74, 0x68, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x18, 0x50,
+	0x61, 0x74, 0x68, 0x42, 0x79, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e,
+	0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b,
+	0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x22, 0x46, 0x0a, 0x19, 0x50,
+	0x61, 0x74, 0x68, 0x42, 0x79, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64,
+	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x22, 0xcf, 0x01, 0x0a, 0x14, 0x44, 0x69, 0x73, 0x6a, 0x6f, 0x69, 0x6e, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x73, 0x64, 0x41, 0x73,
+	0x12, 0x2c, 0x0a, 0x12, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x6a, 0x6f, 0x69, 0x6e, 0x74, 0x6e, 0x65, 0x73,
+	0x73, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x22, 0x44, 0x0a, 0x15, 0x44, 0x69, 0x73, 0x6a, 0x6f, 0x69, 0x6e,
+	0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b,
+	0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
-	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72,
-	0x66, 0x61, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
-	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x03, 0x6d, 0x74, 0x75, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07,
-	0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77,
-	0x69, 0x64, 0x74, 0x68, 0x18, 0x07, 0x20, 0x03, 0x28, 0x04, 0x52, 0x09, 0x62, 0x61, 0x6e, 0x64,
-	0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x31, 0x0a, 0x03, 0x67, 0x65, 0x6f, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6f, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61,
-	0x74, 0x65, 0x73, 0x52, 0x03, 0x67, 0x65, 0x6f, 0x12, 0x36, 0x0a, 0x09, 0x6c, 0x69, 0x6e, 0x6b,
-	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
-	0x6e, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x68, 0x6f, 0x70,
-	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
-	0x6c, 0x48, 0x6f, 0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x0b,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x65,
-	0x70, 0x69, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76,
-	0x31, 0x2e, 0x45, 0x70, 0x69, 0x63, 0x41, 0x75, 0x74, 0x68, 0x73, 0x52, 0x09, 0x65, 0x70, 0x69,
-	0x63, 0x41, 0x75, 0x74, 0x68, 0x73, 0x22, 0x45, 0x0a, 0x09, 0x45, 0x70, 0x69, 0x63, 0x41, 0x75,
-	0x74, 0x68, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x70, 0x68, 0x76, 0x66,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x50, 0x68, 0x76, 0x66,
-	0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x6c, 0x68, 0x76, 0x66, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x4c, 0x68, 0x76, 0x66, 0x22, 0x36, 0x0a,
-	0x0d, 0x50, 0x61, 0x74, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x15,
-	0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
-	0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x64, 0x0a, 0x0e, 0x47, 0x65, 0x6f, 0x43, 0x6f, 0x6f, 0x72,
-	0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74,
-	0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74,
-	0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x22, 0x0a, 0x09, 0x41,
-	0x53, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f,
-	0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x22,
-	0x49, 0x0a, 0x0a, 0x41, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x15, 0x0a,
-	0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69,
-	0x73, 0x64, 0x41, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d, 0x74, 0x75, 0x22, 0x13, 0x0a, 0x11, 0x49, 0x6e,
-	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
-	0xc4, 0x01, 0x0a, 0x12, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74,
-	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
-	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x1a, 0x59, 0x0a, 0x0f, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76,
-	0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x40, 0x0a, 0x09, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x64, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x52,
-	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x11, 0x0a, 0x0f, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xba, 0x01, 0x0a, 0x10,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x4b, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x1a, 0x59, 0x0a,
-	0x0d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76,
-	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x43, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x1b, 0x0a,
-	0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x22, 0x24, 0x0a, 0x08, 0x55, 0x6e,
-	0x64, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x22, 0x43, 0x0a, 0x1a, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15,
+	0x50, 0x61, 0x74, 0x68, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0xae, 0x04, 0x0a, 0x04,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x38, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x12, 0x3e, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d,
+	0x74, 0x75, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33,
+	0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x04, 0x52, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x12, 0x31, 0x0a, 0x03, 0x67, 0x65, 0x6f, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x6f, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x52,
+	0x03, 0x67, 0x65, 0x6f, 0x12, 0x36, 0x0a, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x68, 0x6f, 0x70, 0x73, 0x18, 0x0a, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x48, 0x6f, 0x70,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x70, 0x69, 0x63, 0x5f,
+	0x61, 0x75, 0x74, 0x68, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x70,
+	0x69, 0x63, 0x41, 0x75, 0x74, 0x68, 0x73, 0x52, 0x09, 0x65, 0x70, 0x69, 0x63, 0x41, 0x75, 0x74,
+	0x68, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x22, 0x45, 0x0a, 0x09,
+	0x45, 0x70, 0x69, 0x63, 0x41, 0x75, 0x74, 0x68, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74,
+	0x68, 0x5f, 0x70, 0x68, 0x76, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x61, 0x75,
+	0x74, 0x68, 0x50, 0x68, 0x76, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x6c,
+	0x68, 0x76, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x4c,
+	0x68, 0x76, 0x66, 0x22, 0x36, 0x0a, 0x0d, 0x50, 0x61, 0x74, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x64, 0x0a, 0x0e, 0x47,
+	0x65, 0x6f, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e,
+	0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x6c, 0x6f,
+	0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x22, 0x22, 0x0a, 0x09, 0x41, 0x53, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15,
 	0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
-	0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1d, 0x0a, 0x1b, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0xcf, 0x01, 0x0a, 0x12, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f,
-	0x73, 0x74, 0x41, 0x53, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x76,
+	0x69, 0x73, 0x64, 0x41, 0x73, 0x22, 0x49, 0x0a, 0x0a, 0x41, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d, 0x74, 0x75,
+	0x22, 0x13, 0x0a, 0x11, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc4, 0x01, 0x0a, 0x12, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0a,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x33, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x73, 0x1a, 0x59, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61,
+	0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x40, 0x0a, 0x09,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x64,
+	0x65, 0x72, 0x6c, 0x61, 0x79, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x11,
+	0x0a, 0x0f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0xba, 0x01, 0x0a, 0x10, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x1a, 0x59, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61,
+	0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x43,
+	0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x34, 0x0a,
+	0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x22, 0x1b, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69,
+	0x22, 0x16, 0x0a, 0x14, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xe6, 0x01, 0x0a, 0x15, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x74, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d, 0x74, 0x75, 0x12,
+	0x44, 0x0a, 0x0e, 0x62, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x52, 0x0d, 0x62, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x72, 0x73, 0x12, 0x4a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x22, 0xb0, 0x01, 0x0a, 0x0c, 0x42, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x44, 0x0a, 0x10, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x6e, 0x64, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x52, 0x0f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x46, 0x0a, 0x0a,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x73, 0x22, 0xbd, 0x01, 0x0a, 0x15, 0x42, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
+	0x72, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62,
+	0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x65, 0x69,
+	0x67, 0x68, 0x62, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b,
+	0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x03, 0x6d, 0x74, 0x75, 0x22, 0x59, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e,
+	0x64, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
+	0x24, 0x0a, 0x08, 0x55, 0x6e, 0x64, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x43, 0x0a, 0x1a, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1d, 0x0a, 0x1b, 0x4e, 0x6f,
+	0x74, 0x69, 0x66, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x44, 0x6f, 0x77,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4c, 0x0a, 0x16, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x75, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x06, 0x75, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0xdd, 0x01, 0x0a, 0x09, 0x50, 0x61, 0x74, 0x68,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x73,
+	0x64, 0x41, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x5f, 0x6c, 0x6f, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x4c, 0x6f, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72,
+	0x74, 0x74, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x74, 0x74,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x50, 0x61, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0xcf, 0x01, 0x0a, 0x12, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74,
+	0x41, 0x53, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x76, 0x61, 0x6c,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x76, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x39, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x72,
+	0x6b, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52,
+	0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x73,
+	0x72, 0x63, 0x5f, 0x69, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x72, 0x63,
+	0x49, 0x61, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x73, 0x74, 0x5f, 0x69, 0x61, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x64, 0x73, 0x74, 0x49, 0x61, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x72, 0x63,
+	0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x72, 0x63,
+	0x48, 0x6f, 0x73, 0x74, 0x22, 0x9d, 0x01, 0x0a, 0x13, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f,
+	0x73, 0x74, 0x41, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0b,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x45,
+	0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x22, 0xcf, 0x01, 0x0a, 0x12, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x41, 0x53,
+	0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x76,
 	0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
 	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x76, 0x61, 0x6c, 0x54, 0x69,
@@ -1636,10 +3093,10 @@ var file_proto_daemon_v1_daemon_proto_rawDesc = []byte{
 	0x6c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x49, 0x64, 0x12, 0x15, 0x0a,
 	0x06, 0x73, 0x72, 0x63, 0x5f, 0x69, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73,
 	0x72, 0x63, 0x49, 0x61, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x73, 0x74, 0x5f, 0x69, 0x61, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x73, 0x74, 0x49, 0x61, 0x12, 0x19, 0x0a, 0x08, 0x73,
-	0x72, 0x63, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73,
-	0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x22, 0x9d, 0x01, 0x0a, 0x13, 0x44, 0x52, 0x4b, 0x65, 0x79,
-	0x48, 0x6f, 0x73, 0x74, 0x41, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x73, 0x74, 0x49, 0x61, 0x12, 0x19, 0x0a, 0x08, 0x64,
+	0x73, 0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64,
+	0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x22, 0x9d, 0x01, 0x0a, 0x13, 0x44, 0x52, 0x4b, 0x65, 0x79,
+	0x41, 0x53, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b,
 	0x0a, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
@@ -1648,111 +3105,193 @@ var file_proto_daemon_v1_daemon_proto_rawDesc = []byte{
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65, 0x70, 0x6f, 0x63,
 	0x68, 0x45, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0xcf, 0x01, 0x0a, 0x12, 0x44, 0x52, 0x4b, 0x65, 0x79,
-	0x41, 0x53, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a,
-	0x08, 0x76, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x76, 0x61, 0x6c,
-	0x54, 0x69, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x6f, 0x6c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x49, 0x64, 0x12,
-	0x15, 0x0a, 0x06, 0x73, 0x72, 0x63, 0x5f, 0x69, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x05, 0x73, 0x72, 0x63, 0x49, 0x61, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x73, 0x74, 0x5f, 0x69, 0x61,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x73, 0x74, 0x49, 0x61, 0x12, 0x19, 0x0a,
-	0x08, 0x64, 0x73, 0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x64, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x22, 0x9d, 0x01, 0x0a, 0x13, 0x44, 0x52, 0x4b,
-	0x65, 0x79, 0x41, 0x53, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x0a, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x37, 0x0a,
-	0x09, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65, 0x70,
-	0x6f, 0x63, 0x68, 0x45, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0xec, 0x01, 0x0a, 0x14, 0x44, 0x52, 0x4b,
-	0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x35, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x07, 0x76, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
-	0x6c, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x72, 0x63, 0x5f, 0x69, 0x61, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x72, 0x63, 0x49, 0x61, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x73,
-	0x74, 0x5f, 0x69, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x73, 0x74, 0x49,
-	0x61, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x72, 0x63, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
-	0x64, 0x73, 0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x64, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x22, 0x9f, 0x01, 0x0a, 0x15, 0x44, 0x52, 0x4b, 0x65,
-	0x79, 0x48, 0x6f, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x62, 0x65, 0x67, 0x69, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x0a, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x37,
-	0x0a, 0x09, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0xec, 0x01, 0x0a, 0x14, 0x44, 0x52, 0x4b, 0x65, 0x79,
+	0x48, 0x6f, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x35, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65,
-	0x70, 0x6f, 0x63, 0x68, 0x45, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x2a, 0x6c, 0x0a, 0x08, 0x4c, 0x69, 0x6e,
-	0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
-	0x12, 0x14, 0x0a, 0x10, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x49,
-	0x52, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x5f, 0x48, 0x4f, 0x50, 0x10, 0x02, 0x12,
-	0x16, 0x0a, 0x12, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4f, 0x50, 0x45,
-	0x4e, 0x5f, 0x4e, 0x45, 0x54, 0x10, 0x03, 0x32, 0xd4, 0x05, 0x0a, 0x0d, 0x44, 0x61, 0x65, 0x6d,
-	0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x05, 0x50, 0x61, 0x74,
-	0x68, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x02, 0x41, 0x53, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x53, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61,
-	0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x0a, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
-	0x65, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64,
-	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
-	0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a,
-	0x08, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x72, 0x0a, 0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x12, 0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79,
-	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x49, 0x6e, 0x74,
-	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x0b, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x41, 0x53, 0x48,
-	0x6f, 0x73, 0x74, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d,
-	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x41, 0x53, 0x48, 0x6f, 0x73,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79,
-	0x41, 0x53, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x5a, 0x0a, 0x0b, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x53, 0x12,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x76,
+	0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x49,
+	0x64, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x72, 0x63, 0x5f, 0x69, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x73, 0x72, 0x63, 0x49, 0x61, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x73, 0x74, 0x5f,
+	0x69, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x73, 0x74, 0x49, 0x61, 0x12,
+	0x19, 0x0a, 0x08, 0x73, 0x72, 0x63, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x73,
+	0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x73,
+	0x74, 0x48, 0x6f, 0x73, 0x74, 0x22, 0x9f, 0x01, 0x0a, 0x15, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48,
+	0x6f, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3b, 0x0a, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x37, 0x0a, 0x09,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x45, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0xb7, 0x01, 0x0a, 0x15, 0x43, 0x6f, 0x6c, 0x69,
+	0x62, 0x72, 0x69, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2c, 0x0a, 0x12, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x61, 0x6e, 0x64,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0xe7, 0x01, 0x0a, 0x16, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x13, 0x0a, 0x05, 0x69, 0x64, 0x5f, 0x61,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x69, 0x64, 0x41, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x69, 0x64, 0x5f, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x69, 0x64, 0x53, 0x75, 0x66, 0x66, 0x69, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61,
+	0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62,
+	0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x19, 0x0a, 0x17, 0x48,
+	0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x54, 0x0a, 0x18, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
+	0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x38, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x55, 0x0a, 0x0f,
+	0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x77, 0x72, 0x69, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04,
+	0x52, 0x07, 0x77, 0x72, 0x69, 0x74, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x61,
+	0x72, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x72,
+	0x6e, 0x65, 0x64, 0x2a, 0x5e, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x45, 0x45, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x17, 0x0a, 0x13, 0x50, 0x45, 0x45, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x46, 0x4f, 0x52, 0x42, 0x49, 0x44, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x45, 0x45,
+	0x52, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52,
+	0x45, 0x10, 0x02, 0x2a, 0x6b, 0x0a, 0x10, 0x44, 0x69, 0x73, 0x6a, 0x6f, 0x69, 0x6e, 0x74, 0x6e,
+	0x65, 0x73, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x1d, 0x44, 0x49, 0x53, 0x4a, 0x4f,
+	0x49, 0x4e, 0x54, 0x4e, 0x45, 0x53, 0x53, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x44, 0x49,
+	0x53, 0x4a, 0x4f, 0x49, 0x4e, 0x54, 0x4e, 0x45, 0x53, 0x53, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f,
+	0x4c, 0x49, 0x4e, 0x4b, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x44, 0x49, 0x53, 0x4a, 0x4f, 0x49,
+	0x4e, 0x54, 0x4e, 0x45, 0x53, 0x53, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x41, 0x53, 0x10, 0x02,
+	0x2a, 0x6c, 0x0a, 0x08, 0x4c, 0x69, 0x6e, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x15,
+	0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x49, 0x4e, 0x4b, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x17, 0x0a,
+	0x13, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49,
+	0x5f, 0x48, 0x4f, 0x50, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x4e, 0x45, 0x54, 0x10, 0x03, 0x2a, 0x90,
+	0x01, 0x0a, 0x0c, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1d, 0x0a, 0x19, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16,
+	0x0a, 0x12, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x43, 0x4f, 0x52, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x52,
+	0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x41, 0x52, 0x45, 0x4e, 0x54, 0x10, 0x02,
+	0x12, 0x17, 0x0a, 0x13, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x43, 0x48, 0x49, 0x4c, 0x44, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x4c, 0x49, 0x4e,
+	0x4b, 0x5f, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x45, 0x45, 0x52, 0x10,
+	0x04, 0x32, 0x99, 0x0b, 0x0a, 0x0d, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x05, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x1d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a,
+	0x0a, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
 	0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76,
-	0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x53, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74,
-	0x41, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x0d,
-	0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x25, 0x2e,
+	0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x6c, 0x0a, 0x11, 0x50, 0x61, 0x74, 0x68,
+	0x42, 0x79, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x29, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x61, 0x74, 0x68, 0x42, 0x79, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x42,
+	0x79, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x0d, 0x44, 0x69, 0x73, 0x6a, 0x6f, 0x69,
+	0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x6a, 0x6f, 0x69,
+	0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x69, 0x73, 0x6a, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x02, 0x41, 0x53, 0x12, 0x1a,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x53, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x53, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x0a, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x51, 0x0a, 0x08, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x20,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x0d, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64,
+	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
-	0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74,
-	0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2e,
-	0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69,
-	0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x72, 0x0a, 0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66,
+	0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x12, 0x2b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x44, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f,
+	0x74, 0x69, 0x66, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x44, 0x6f, 0x77,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x66, 0x0a, 0x0f, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x27,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x0b, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x41, 0x53, 0x48, 0x6f,
+	0x73, 0x74, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x41, 0x53, 0x48, 0x6f, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x41,
+	0x53, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x5a, 0x0a, 0x0b, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x53, 0x12, 0x23,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x53, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x41,
+	0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x0d, 0x44,
+	0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x25, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x48,
+	0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a,
+	0x0e, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12,
+	0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72,
+	0x69, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x69, 0x0a, 0x10, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64,
+	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50,
+	0x61, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2e, 0x5a,
+	0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f,
+	0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1767,88 +3306,140 @@ func file_proto_daemon_v1_daemon_proto_rawDescGZIP() []byte {
 	return file_proto_daemon_v1_daemon_proto_rawDescData
 }
 
-var file_proto_daemon_v1_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_daemon_v1_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_proto_daemon_v1_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_daemon_v1_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_proto_daemon_v1_daemon_proto_goTypes = []interface{}{
-	(LinkType)(0),                       // 0: proto.daemon.v1.LinkType
-	(*PathsRequest)(nil),                // 1: proto.daemon.v1.PathsRequest
-	(*PathsResponse)(nil),               // 2: proto.daemon.v1.PathsResponse
-	(*Path)(nil),                        // 3: proto.daemon.v1.Path
-	(*EpicAuths)(nil),                   // 4: proto.daemon.v1.EpicAuths
-	(*PathInterface)(nil),               // 5: proto.daemon.v1.PathInterface
-	(*GeoCoordinates)(nil),              // 6: proto.daemon.v1.GeoCoordinates
-	(*ASRequest)(nil),                   // 7: proto.daemon.v1.ASRequest
-	(*ASResponse)(nil),                  // 8: proto.daemon.v1.ASResponse
-	(*InterfacesRequest)(nil),           // 9: proto.daemon.v1.InterfacesRequest
-	(*InterfacesResponse)(nil),          // 10: proto.daemon.v1.InterfacesResponse
-	(*Interface)(nil),                   // 11: proto.daemon.v1.Interface
-	(*ServicesRequest)(nil),             // 12: proto.daemon.v1.ServicesRequest
-	(*ServicesResponse)(nil),            // 13: proto.daemon.v1.ServicesResponse
-	(*ListService)(nil),                 // 14: proto.daemon.v1.ListService
-	(*Service)(nil),                     // 15: proto.daemon.v1.Service
-	(*Underlay)(nil),                    // 16: proto.daemon.v1.Underlay
-	(*NotifyInterfaceDownRequest)(nil),  // 17: proto.daemon.v1.NotifyInterfaceDownRequest
-	(*NotifyInterfaceDownResponse)(nil), // 18: proto.daemon.v1.NotifyInterfaceDownResponse
-	(*DRKeyHostASRequest)(nil),          // 19: proto.daemon.v1.DRKeyHostASRequest
-	(*DRKeyHostASResponse)(nil),         // 20: proto.daemon.v1.DRKeyHostASResponse
-	(*DRKeyASHostRequest)(nil),          // 21: proto.daemon.v1.DRKeyASHostRequest
-	(*DRKeyASHostResponse)(nil),         // 22: proto.daemon.v1.DRKeyASHostResponse
-	(*DRKeyHostHostRequest)(nil),        // 23: proto.daemon.v1.DRKeyHostHostRequest
-	(*DRKeyHostHostResponse)(nil),       // 24: proto.daemon.v1.DRKeyHostHostResponse
-	nil,                                 // 25: proto.daemon.v1.InterfacesResponse.InterfacesEntry
-	nil,                                 // 26: proto.daemon.v1.ServicesResponse.ServicesEntry
-	(*timestamppb.Timestamp)(nil),       // 27: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),         // 28: google.protobuf.Duration
-	(drkey.Protocol)(0),                 // 29: proto.drkey.v1.Protocol
+	(PeeringMode)(0),                    // 0: proto.daemon.v1.PeeringMode
+	(DisjointnessMode)(0),               // 1: proto.daemon.v1.DisjointnessMode
+	(LinkType)(0),                       // 2: proto.daemon.v1.LinkType
+	(LinkRelation)(0),                   // 3: proto.daemon.v1.LinkRelation
+	(*PathsRequest)(nil),                // 4: proto.daemon.v1.PathsRequest
+	(*PathsResponse)(nil),               // 5: proto.daemon.v1.PathsResponse
+	(*WatchPathsRequest)(nil),           // 6: proto.daemon.v1.WatchPathsRequest
+	(*WatchPathsResponse)(nil),          // 7: proto.daemon.v1.WatchPathsResponse
+	(*PathByFingerprintRequest)(nil),    // 8: proto.daemon.v1.PathByFingerprintRequest
+	(*PathByFingerprintResponse)(nil),   // 9: proto.daemon.v1.PathByFingerprintResponse
+	(*DisjointPathsRequest)(nil),        // 10: proto.daemon.v1.DisjointPathsRequest
+	(*DisjointPathsResponse)(nil),       // 11: proto.daemon.v1.DisjointPathsResponse
+	(*Path)(nil),                        // 12: proto.daemon.v1.Path
+	(*EpicAuths)(nil),                   // 13: proto.daemon.v1.EpicAuths
+	(*PathInterface)(nil),               // 14: proto.daemon.v1.PathInterface
+	(*GeoCoordinates)(nil),              // 15: proto.daemon.v1.GeoCoordinates
+	(*ASRequest)(nil),                   // 16: proto.daemon.v1.ASRequest
+	(*ASResponse)(nil),                  // 17: proto.daemon.v1.ASResponse
+	(*InterfacesRequest)(nil),           // 18: proto.daemon.v1.InterfacesRequest
+	(*InterfacesResponse)(nil),          // 19: proto.daemon.v1.InterfacesResponse
+	(*Interface)(nil),                   // 20: proto.daemon.v1.Interface
+	(*ServicesRequest)(nil),             // 21: proto.daemon.v1.ServicesRequest
+	(*ServicesResponse)(nil),            // 22: proto.daemon.v1.ServicesResponse
+	(*ListService)(nil),                 // 23: proto.daemon.v1.ListService
+	(*Service)(nil),                     // 24: proto.daemon.v1.Service
+	(*LocalTopologyRequest)(nil),        // 25: proto.daemon.v1.LocalTopologyRequest
+	(*LocalTopologyResponse)(nil),       // 26: proto.daemon.v1.LocalTopologyResponse
+	(*BorderRouter)(nil),                // 27: proto.daemon.v1.BorderRouter
+	(*BorderRouterInterface)(nil),       // 28: proto.daemon.v1.BorderRouterInterface
+	(*ControlService)(nil),              // 29: proto.daemon.v1.ControlService
+	(*Underlay)(nil),                    // 30: proto.daemon.v1.Underlay
+	(*NotifyInterfaceDownRequest)(nil),  // 31: proto.daemon.v1.NotifyInterfaceDownRequest
+	(*NotifyInterfaceDownResponse)(nil), // 32: proto.daemon.v1.NotifyInterfaceDownResponse
+	(*ReportPathUsageRequest)(nil),      // 33: proto.daemon.v1.ReportPathUsageRequest
+	(*PathUsage)(nil),                   // 34: proto.daemon.v1.PathUsage
+	(*ReportPathUsageResponse)(nil),     // 35: proto.daemon.v1.ReportPathUsageResponse
+	(*DRKeyHostASRequest)(nil),          // 36: proto.daemon.v1.DRKeyHostASRequest
+	(*DRKeyHostASResponse)(nil),         // 37: proto.daemon.v1.DRKeyHostASResponse
+	(*DRKeyASHostRequest)(nil),          // 38: proto.daemon.v1.DRKeyASHostRequest
+	(*DRKeyASHostResponse)(nil),         // 39: proto.daemon.v1.DRKeyASHostResponse
+	(*DRKeyHostHostRequest)(nil),        // 40: proto.daemon.v1.DRKeyHostHostRequest
+	(*DRKeyHostHostResponse)(nil),       // 41: proto.daemon.v1.DRKeyHostHostResponse
+	(*ColibriReserveRequest)(nil),       // 42: proto.daemon.v1.ColibriReserveRequest
+	(*ColibriReserveResponse)(nil),      // 43: proto.daemon.v1.ColibriReserveResponse
+	(*HiddenPathGroupsRequest)(nil),     // 44: proto.daemon.v1.HiddenPathGroupsRequest
+	(*HiddenPathGroupsResponse)(nil),    // 45: proto.daemon.v1.HiddenPathGroupsResponse
+	(*HiddenPathGroup)(nil),             // 46: proto.daemon.v1.HiddenPathGroup
+	nil,                                 // 47: proto.daemon.v1.InterfacesResponse.InterfacesEntry
+	nil,                                 // 48: proto.daemon.v1.ServicesResponse.ServicesEntry
+	(*timestamppb.Timestamp)(nil),       // 49: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),         // 50: google.protobuf.Duration
+	(drkey.Protocol)(0),                 // 51: proto.drkey.v1.Protocol
 }
 var file_proto_daemon_v1_daemon_proto_depIdxs = []int32{
-	3,  // 0: proto.daemon.v1.PathsResponse.paths:type_name -> proto.daemon.v1.Path
-	11, // 1: proto.daemon.v1.Path.interface:type_name -> proto.daemon.v1.Interface
-	5,  // 2: proto.daemon.v1.Path.interfaces:type_name -> proto.daemon.v1.PathInterface
-	27, // 3: proto.daemon.v1.Path.expiration:type_name -> google.protobuf.Timestamp
-	28, // 4: proto.daemon.v1.Path.latency:type_name -> google.protobuf.Duration
-	6,  // 5: proto.daemon.v1.Path.geo:type_name -> proto.daemon.v1.GeoCoordinates
-	0,  // 6: proto.daemon.v1.Path.link_type:type_name -> proto.daemon.v1.LinkType
-	4,  // 7: proto.daemon.v1.Path.epic_auths:type_name -> proto.daemon.v1.EpicAuths
-	25, // 8: proto.daemon.v1.InterfacesResponse.interfaces:type_name -> proto.daemon.v1.InterfacesResponse.InterfacesEntry
-	16, // 9: proto.daemon.v1.Interface.address:type_name -> proto.daemon.v1.Underlay
-	26, // 10: proto.daemon.v1.ServicesResponse.services:type_name -> proto.daemon.v1.ServicesResponse.ServicesEntry
-	15, // 11: proto.daemon.v1.ListService.services:type_name -> proto.daemon.v1.Service
-	27, // 12: proto.daemon.v1.DRKeyHostASRequest.val_time:type_name -> google.protobuf.Timestamp
-	29, // 13: proto.daemon.v1.DRKeyHostASRequest.protocol_id:type_name -> proto.drkey.v1.Protocol
-	27, // 14: proto.daemon.v1.DRKeyHostASResponse.epoch_begin:type_name -> google.protobuf.Timestamp
-	27, // 15: proto.daemon.v1.DRKeyHostASResponse.epoch_end:type_name -> google.protobuf.Timestamp
-	27, // 16: proto.daemon.v1.DRKeyASHostRequest.val_time:type_name -> google.protobuf.Timestamp
-	29, // 17: proto.daemon.v1.DRKeyASHostRequest.protocol_id:type_name -> proto.drkey.v1.Protocol
-	27, // 18: proto.daemon.v1.DRKeyASHostResponse.epoch_begin:type_name -> google.protobuf.Timestamp
-	27, // 19: proto.daemon.v1.DRKeyASHostResponse.epoch_end:type_name -> google.protobuf.Timestamp
-	27, // 20: proto.daemon.v1.DRKeyHostHostRequest.val_time:type_name -> google.protobuf.Timestamp
-	29, // 21: proto.daemon.v1.DRKeyHostHostRequest.protocol_id:type_name -> proto.drkey.v1.Protocol
-	27, // 22: proto.daemon.v1.DRKeyHostHostResponse.epoch_begin:type_name -> google.protobuf.Timestamp
-	27, // 23: proto.daemon.v1.DRKeyHostHostResponse.epoch_end:type_name -> google.protobuf.Timestamp
-	11, // 24: proto.daemon.v1.InterfacesResponse.InterfacesEntry.value:type_name -> proto.daemon.v1.Interface
-	14, // 25: proto.daemon.v1.ServicesResponse.ServicesEntry.value:type_name -> proto.daemon.v1.ListService
-	1,  // 26: proto.daemon.v1.DaemonService.Paths:input_type -> proto.daemon.v1.PathsRequest
-	7,  // 27: proto.daemon.v1.DaemonService.AS:input_type -> proto.daemon.v1.ASRequest
-	9,  // 28: proto.daemon.v1.DaemonService.Interfaces:input_type -> proto.daemon.v1.InterfacesRequest
-	12, // 29: proto.daemon.v1.DaemonService.Services:input_type -> proto.daemon.v1.ServicesRequest
-	17, // 30: proto.daemon.v1.DaemonService.NotifyInterfaceDown:input_type -> proto.daemon.v1.NotifyInterfaceDownRequest
-	21, // 31: proto.daemon.v1.DaemonService.DRKeyASHost:input_type -> proto.daemon.v1.DRKeyASHostRequest
-	19, // 32: proto.daemon.v1.DaemonService.DRKeyHostAS:input_type -> proto.daemon.v1.DRKeyHostASRequest
-	23, // 33: proto.daemon.v1.DaemonService.DRKeyHostHost:input_type -> proto.daemon.v1.DRKeyHostHostRequest
-	2,  // 34: proto.daemon.v1.DaemonService.Paths:output_type -> proto.daemon.v1.PathsResponse
-	8,  // 35: proto.daemon.v1.DaemonService.AS:output_type -> proto.daemon.v1.ASResponse
-	10, // 36: proto.daemon.v1.DaemonService.Interfaces:output_type -> proto.daemon.v1.InterfacesResponse
-	13, // 37: proto.daemon.v1.DaemonService.Services:output_type -> proto.daemon.v1.ServicesResponse
-	18, // 38: proto.daemon.v1.DaemonService.NotifyInterfaceDown:output_type -> proto.daemon.v1.NotifyInterfaceDownResponse
-	22, // 39: proto.daemon.v1.DaemonService.DRKeyASHost:output_type -> proto.daemon.v1.DRKeyASHostResponse
-	20, // 40: proto.daemon.v1.DaemonService.DRKeyHostAS:output_type -> proto.daemon.v1.DRKeyHostASResponse
-	24, // 41: proto.daemon.v1.DaemonService.DRKeyHostHost:output_type -> proto.daemon.v1.DRKeyHostHostResponse
-	34, // [34:42] is the sub-list for method output_type
-	26, // [26:34] is the sub-list for method input_type
-	26, // [26:26] is the sub-list for extension type_name
-	26, // [26:26] is the sub-list for extension extendee
-	0,  // [0:26] is the sub-list for field type_name
+	0,  // 0: proto.daemon.v1.PathsRequest.peering:type_name -> proto.daemon.v1.PeeringMode
+	12, // 1: proto.daemon.v1.PathsResponse.paths:type_name -> proto.daemon.v1.Path
+	12, // 2: proto.daemon.v1.WatchPathsResponse.paths:type_name -> proto.daemon.v1.Path
+	12, // 3: proto.daemon.v1.PathByFingerprintResponse.path:type_name -> proto.daemon.v1.Path
+	1,  // 4: proto.daemon.v1.DisjointPathsRequest.mode:type_name -> proto.daemon.v1.DisjointnessMode
+	12, // 5: proto.daemon.v1.DisjointPathsResponse.paths:type_name -> proto.daemon.v1.Path
+	20, // 6: proto.daemon.v1.Path.interface:type_name -> proto.daemon.v1.Interface
+	14, // 7: proto.daemon.v1.Path.interfaces:type_name -> proto.daemon.v1.PathInterface
+	49, // 8: proto.daemon.v1.Path.expiration:type_name -> google.protobuf.Timestamp
+	50, // 9: proto.daemon.v1.Path.latency:type_name -> google.protobuf.Duration
+	15, // 10: proto.daemon.v1.Path.geo:type_name -> proto.daemon.v1.GeoCoordinates
+	2,  // 11: proto.daemon.v1.Path.link_type:type_name -> proto.daemon.v1.LinkType
+	13, // 12: proto.daemon.v1.Path.epic_auths:type_name -> proto.daemon.v1.EpicAuths
+	47, // 13: proto.daemon.v1.InterfacesResponse.interfaces:type_name -> proto.daemon.v1.InterfacesResponse.InterfacesEntry
+	30, // 14: proto.daemon.v1.Interface.address:type_name -> proto.daemon.v1.Underlay
+	48, // 15: proto.daemon.v1.ServicesResponse.services:type_name -> proto.daemon.v1.ServicesResponse.ServicesEntry
+	24, // 16: proto.daemon.v1.ListService.services:type_name -> proto.daemon.v1.Service
+	27, // 17: proto.daemon.v1.LocalTopologyResponse.border_routers:type_name -> proto.daemon.v1.BorderRouter
+	29, // 18: proto.daemon.v1.LocalTopologyResponse.control_services:type_name -> proto.daemon.v1.ControlService
+	30, // 19: proto.daemon.v1.BorderRouter.internal_address:type_name -> proto.daemon.v1.Underlay
+	28, // 20: proto.daemon.v1.BorderRouter.interfaces:type_name -> proto.daemon.v1.BorderRouterInterface
+	3,  // 21: proto.daemon.v1.BorderRouterInterface.relation:type_name -> proto.daemon.v1.LinkRelation
+	30, // 22: proto.daemon.v1.ControlService.address:type_name -> proto.daemon.v1.Underlay
+	34, // 23: proto.daemon.v1.ReportPathUsageRequest.usages:type_name -> proto.daemon.v1.PathUsage
+	50, // 24: proto.daemon.v1.PathUsage.rtt_samples:type_name -> google.protobuf.Duration
+	49, // 25: proto.daemon.v1.DRKeyHostASRequest.val_time:type_name -> google.protobuf.Timestamp
+	51, // 26: proto.daemon.v1.DRKeyHostASRequest.protocol_id:type_name -> proto.drkey.v1.Protocol
+	49, // 27: proto.daemon.v1.DRKeyHostASResponse.epoch_begin:type_name -> google.protobuf.Timestamp
+	49, // 28: proto.daemon.v1.DRKeyHostASResponse.epoch_end:type_name -> google.protobuf.Timestamp
+	49, // 29: proto.daemon.v1.DRKeyASHostRequest.val_time:type_name -> google.protobuf.Timestamp
+	51, // 30: proto.daemon.v1.DRKeyASHostRequest.protocol_id:type_name -> proto.drkey.v1.Protocol
+	49, // 31: proto.daemon.v1.DRKeyASHostResponse.epoch_begin:type_name -> google.protobuf.Timestamp
+	49, // 32: proto.daemon.v1.DRKeyASHostResponse.epoch_end:type_name -> google.protobuf.Timestamp
+	49, // 33: proto.daemon.v1.DRKeyHostHostRequest.val_time:type_name -> google.protobuf.Timestamp
+	51, // 34: proto.daemon.v1.DRKeyHostHostRequest.protocol_id:type_name -> proto.drkey.v1.Protocol
+	49, // 35: proto.daemon.v1.DRKeyHostHostResponse.epoch_begin:type_name -> google.protobuf.Timestamp
+	49, // 36: proto.daemon.v1.DRKeyHostHostResponse.epoch_end:type_name -> google.protobuf.Timestamp
+	49, // 37: proto.daemon.v1.ColibriReserveRequest.expiration:type_name -> google.protobuf.Timestamp
+	49, // 38: proto.daemon.v1.ColibriReserveResponse.expiration:type_name -> google.protobuf.Timestamp
+	46, // 39: proto.daemon.v1.HiddenPathGroupsResponse.groups:type_name -> proto.daemon.v1.HiddenPathGroup
+	20, // 40: proto.daemon.v1.InterfacesResponse.InterfacesEntry.value:type_name -> proto.daemon.v1.Interface
+	23, // 41: proto.daemon.v1.ServicesResponse.ServicesEntry.value:type_name -> proto.daemon.v1.ListService
+	4,  // 42: proto.daemon.v1.DaemonService.Paths:input_type -> proto.daemon.v1.PathsRequest
+	6,  // 43: proto.daemon.v1.DaemonService.WatchPaths:input_type -> proto.daemon.v1.WatchPathsRequest
+	8,  // 44: proto.daemon.v1.DaemonService.PathByFingerprint:input_type -> proto.daemon.v1.PathByFingerprintRequest
+	10, // 45: proto.daemon.v1.DaemonService.DisjointPaths:input_type -> proto.daemon.v1.DisjointPathsRequest
+	16, // 46: proto.daemon.v1.DaemonService.AS:input_type -> proto.daemon.v1.ASRequest
+	18, // 47: proto.daemon.v1.DaemonService.Interfaces:input_type -> proto.daemon.v1.InterfacesRequest
+	21, // 48: proto.daemon.v1.DaemonService.Services:input_type -> proto.daemon.v1.ServicesRequest
+	25, // 49: proto.daemon.v1.DaemonService.LocalTopology:input_type -> proto.daemon.v1.LocalTopologyRequest
+	31, // 50: proto.daemon.v1.DaemonService.NotifyInterfaceDown:input_type -> proto.daemon.v1.NotifyInterfaceDownRequest
+	33, // 51: proto.daemon.v1.DaemonService.ReportPathUsage:input_type -> proto.daemon.v1.ReportPathUsageRequest
+	38, // 52: proto.daemon.v1.DaemonService.DRKeyASHost:input_type -> proto.daemon.v1.DRKeyASHostRequest
+	36, // 53: proto.daemon.v1.DaemonService.DRKeyHostAS:input_type -> proto.daemon.v1.DRKeyHostASRequest
+	40, // 54: proto.daemon.v1.DaemonService.DRKeyHostHost:input_type -> proto.daemon.v1.DRKeyHostHostRequest
+	42, // 55: proto.daemon.v1.DaemonService.ColibriReserve:input_type -> proto.daemon.v1.ColibriReserveRequest
+	44, // 56: proto.daemon.v1.DaemonService.HiddenPathGroups:input_type -> proto.daemon.v1.HiddenPathGroupsRequest
+	5,  // 57: proto.daemon.v1.DaemonService.Paths:output_type -> proto.daemon.v1.PathsResponse
+	7,  // 58: proto.daemon.v1.DaemonService.WatchPaths:output_type -> proto.daemon.v1.WatchPathsResponse
+	9,  // 59: proto.daemon.v1.DaemonService.PathByFingerprint:output_type -> proto.daemon.v1.PathByFingerprintResponse
+	11, // 60: proto.daemon.v1.DaemonService.DisjointPaths:output_type -> proto.daemon.v1.DisjointPathsResponse
+	17, // 61: proto.daemon.v1.DaemonService.AS:output_type -> proto.daemon.v1.ASResponse
+	19, // 62: proto.daemon.v1.DaemonService.Interfaces:output_type -> proto.daemon.v1.InterfacesResponse
+	22, // 63: proto.daemon.v1.DaemonService.Services:output_type -> proto.daemon.v1.ServicesResponse
+	26, // 64: proto.daemon.v1.DaemonService.LocalTopology:output_type -> proto.daemon.v1.LocalTopologyResponse
+	32, // 65: proto.daemon.v1.DaemonService.NotifyInterfaceDown:output_type -> proto.daemon.v1.NotifyInterfaceDownResponse
+	35, // 66: proto.daemon.v1.DaemonService.ReportPathUsage:output_type -> proto.daemon.v1.ReportPathUsageResponse
+	39, // 67: proto.daemon.v1.DaemonService.DRKeyASHost:output_type -> proto.daemon.v1.DRKeyASHostResponse
+	37, // 68: proto.daemon.v1.DaemonService.DRKeyHostAS:output_type -> proto.daemon.v1.DRKeyHostASResponse
+	41, // 69: proto.daemon.v1.DaemonService.DRKeyHostHost:output_type -> proto.daemon.v1.DRKeyHostHostResponse
+	43, // 70: proto.daemon.v1.DaemonService.ColibriReserve:output_type -> proto.daemon.v1.ColibriReserveResponse
+	45, // 71: proto.daemon.v1.DaemonService.HiddenPathGroups:output_type -> proto.daemon.v1.HiddenPathGroupsResponse
+	57, // [57:72] is the sub-list for method output_type
+	42, // [42:57] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_proto_daemon_v1_daemon_proto_init() }
@@ -1869,8 +3460,212 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PathsResponse); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchPathsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchPathsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathByFingerprintRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathByFingerprintResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisjointPathsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisjointPathsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Path); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EpicAuths); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathInterface); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoCoordinates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ASRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ASResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InterfacesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InterfacesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Interface); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServicesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1881,8 +3676,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Path); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListService); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1893,8 +3688,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EpicAuths); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Service); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1905,8 +3700,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PathInterface); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalTopologyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1917,8 +3712,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GeoCoordinates); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalTopologyResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1929,8 +3724,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ASRequest); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BorderRouter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1941,8 +3736,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ASResponse); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BorderRouterInterface); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1953,8 +3748,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InterfacesRequest); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ControlService); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1965,8 +3760,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InterfacesResponse); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Underlay); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1977,8 +3772,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Interface); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotifyInterfaceDownRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1989,8 +3784,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServicesRequest); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotifyInterfaceDownResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2001,8 +3796,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServicesResponse); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportPathUsageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2013,8 +3808,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListService); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathUsage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2025,8 +3820,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Service); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportPathUsageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2037,8 +3832,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Underlay); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyHostASRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2049,8 +3844,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NotifyInterfaceDownRequest); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyHostASResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2061,8 +3856,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NotifyInterfaceDownResponse); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyASHostRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2073,8 +3868,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DRKeyHostASRequest); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyASHostResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2085,8 +3880,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DRKeyHostASResponse); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyHostHostRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2097,8 +3892,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DRKeyASHostRequest); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyHostHostResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2109,8 +3904,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DRKeyASHostResponse); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColibriReserveRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2121,8 +3916,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DRKeyHostHostRequest); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColibriReserveResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2133,8 +3928,32 @@ func file_proto_daemon_v1_daemon_proto_init() {
 				return nil
 			}
 		}
-		file_proto_daemon_v1_daemon_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DRKeyHostHostResponse); i {
+		file_proto_daemon_v1_daemon_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathGroupsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathGroupsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_daemon_v1_daemon_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathGroup); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2151,8 +3970,8 @@ func file_proto_daemon_v1_daemon_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_daemon_v1_daemon_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   26,
+			NumEnums:      4,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -2180,13 +3999,20 @@ const _ = grpc.SupportPackageIsVersion6
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type DaemonServiceClient interface {
 	Paths(ctx context.Context, in *PathsRequest, opts ...grpc.CallOption) (*PathsResponse, error)
+	WatchPaths(ctx context.Context, in *WatchPathsRequest, opts ...grpc.CallOption) (DaemonService_WatchPathsClient, error)
+	PathByFingerprint(ctx context.Context, in *PathByFingerprintRequest, opts ...grpc.CallOption) (*PathByFingerprintResponse, error)
+	DisjointPaths(ctx context.Context, in *DisjointPathsRequest, opts ...grpc.CallOption) (*DisjointPathsResponse, error)
 	AS(ctx context.Context, in *ASRequest, opts ...grpc.CallOption) (*ASResponse, error)
 	Interfaces(ctx context.Context, in *InterfacesRequest, opts ...grpc.CallOption) (*InterfacesResponse, error)
 	Services(ctx context.Context, in *ServicesRequest, opts ...grpc.CallOption) (*ServicesResponse, error)
+	LocalTopology(ctx context.Context, in *LocalTopologyRequest, opts ...grpc.CallOption) (*LocalTopologyResponse, error)
 	NotifyInterfaceDown(ctx context.Context, in *NotifyInterfaceDownRequest, opts ...grpc.CallOption) (*NotifyInterfaceDownResponse, error)
+	ReportPathUsage(ctx context.Context, in *ReportPathUsageRequest, opts ...grpc.CallOption) (*ReportPathUsageResponse, error)
 	DRKeyASHost(ctx context.Context, in *DRKeyASHostRequest, opts ...grpc.CallOption) (*DRKeyASHostResponse, error)
 	DRKeyHostAS(ctx context.Context, in *DRKeyHostASRequest, opts ...grpc.CallOption) (*DRKeyHostASResponse, error)
 	DRKeyHostHost(ctx context.Context, in *DRKeyHostHostRequest, opts ...grpc.CallOption) (*DRKeyHostHostResponse, error)
+	ColibriReserve(ctx context.Context, in *ColibriReserveRequest, opts ...grpc.CallOption) (*ColibriReserveResponse, error)
+	HiddenPathGroups(ctx context.Context, in *HiddenPathGroupsRequest, opts ...grpc.CallOption) (*HiddenPathGroupsResponse, error)
 }
 
 type daemonServiceClient struct {
@@ -2206,6 +4032,56 @@ func (c *daemonServiceClient) Paths(ctx context.Context, in *PathsRequest, opts
 	return out, nil
 }
 
+func (c *daemonServiceClient) WatchPaths(ctx context.Context, in *WatchPathsRequest, opts ...grpc.CallOption) (DaemonService_WatchPathsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DaemonService_serviceDesc.Streams[0], "/proto.daemon.v1.DaemonService/WatchPaths", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daemonServiceWatchPathsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DaemonService_WatchPathsClient interface {
+	Recv() (*WatchPathsResponse, error)
+	grpc.ClientStream
+}
+
+type daemonServiceWatchPathsClient struct {
+	grpc.ClientStream
+}
+
+func (x *daemonServiceWatchPathsClient) Recv() (*WatchPathsResponse, error) {
+	m := new(WatchPathsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daemonServiceClient) PathByFingerprint(ctx context.Context, in *PathByFingerprintRequest, opts ...grpc.CallOption) (*PathByFingerprintResponse, error) {
+	out := new(PathByFingerprintResponse)
+	err := c.cc.Invoke(ctx, "/proto.daemon.v1.DaemonService/PathByFingerprint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) DisjointPaths(ctx context.Context, in *DisjointPathsRequest, opts ...grpc.CallOption) (*DisjointPathsResponse, error) {
+	out := new(DisjointPathsResponse)
+	err := c.cc.Invoke(ctx, "/proto.daemon.v1.DaemonService/DisjointPaths", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonServiceClient) AS(ctx context.Context, in *ASRequest, opts ...grpc.CallOption) (*ASResponse, error) {
 	out := new(ASResponse)
 	err := c.cc.Invoke(ctx, "/proto.daemon.v1.DaemonService/AS", in, out, opts...)
@@ -2233,6 +4109,15 @@ func (c *daemonServiceClient) Services(ctx context.Context, in *ServicesRequest,
 	return out, nil
 }
 
+func (c *daemonServiceClient) LocalTopology(ctx context.Context, in *LocalTopologyRequest, opts ...grpc.CallOption) (*LocalTopologyResponse, error) {
+	out := new(LocalTopologyResponse)
+	err := c.cc.Invoke(ctx, "/proto.daemon.v1.DaemonService/LocalTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonServiceClient) NotifyInterfaceDown(ctx context.Context, in *NotifyInterfaceDownRequest, opts ...grpc.CallOption) (*NotifyInterfaceDownResponse, error) {
 	out := new(NotifyInterfaceDownResponse)
 	err := c.cc.Invoke(ctx, "/proto.daemon.v1.DaemonService/NotifyInterfaceDown", in, out, opts...)
@@ -2242,6 +4127,15 @@ func (c *daemonServiceClient) NotifyInterfaceDown(ctx context.Context, in *Notif
 	return out, nil
 }
 
+func (c *daemonServiceClient) ReportPathUsage(ctx context.Context, in *ReportPathUsageRequest, opts ...grpc.CallOption) (*ReportPathUsageResponse, error) {
+	out := new(ReportPathUsageResponse)
+	err := c.cc.Invoke(ctx, "/proto.daemon.v1.DaemonService/ReportPathUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonServiceClient) DRKeyASHost(ctx context.Context, in *DRKeyASHostRequest, opts ...grpc.CallOption) (*DRKeyASHostResponse, error) {
 	out := new(DRKeyASHostResponse)
 	err := c.cc.Invoke(ctx, "/proto.daemon.v1.DaemonService/DRKeyASHost", in, out, opts...)
@@ -2269,16 +4163,41 @@ func (c *daemonServiceClient) DRKeyHostHost(ctx context.Context, in *DRKeyHostHo
 	return out, nil
 }
 
+func (c *daemonServiceClient) ColibriReserve(ctx context.Context, in *ColibriReserveRequest, opts ...grpc.CallOption) (*ColibriReserveResponse, error) {
+	out := new(ColibriReserveResponse)
+	err := c.cc.Invoke(ctx, "/proto.daemon.v1.DaemonService/ColibriReserve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) HiddenPathGroups(ctx context.Context, in *HiddenPathGroupsRequest, opts ...grpc.CallOption) (*HiddenPathGroupsResponse, error) {
+	out := new(HiddenPathGroupsResponse)
+	err := c.cc.Invoke(ctx, "/proto.daemon.v1.DaemonService/HiddenPathGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DaemonServiceServer is the server API for DaemonService service.
 type DaemonServiceServer interface {
 	Paths(context.Context, *PathsRequest) (*PathsResponse, error)
+	WatchPaths(*WatchPathsRequest, DaemonService_WatchPathsServer) error
+	PathByFingerprint(context.Context, *PathByFingerprintRequest) (*PathByFingerprintResponse, error)
+	DisjointPaths(context.Context, *DisjointPathsRequest) (*DisjointPathsResponse, error)
 	AS(context.Context, *ASRequest) (*ASResponse, error)
 	Interfaces(context.Context, *InterfacesRequest) (*InterfacesResponse, error)
 	Services(context.Context, *ServicesRequest) (*ServicesResponse, error)
+	LocalTopology(context.Context, *LocalTopologyRequest) (*LocalTopologyResponse, error)
 	NotifyInterfaceDown(context.Context, *NotifyInterfaceDownRequest) (*NotifyInterfaceDownResponse, error)
+	ReportPathUsage(context.Context, *ReportPathUsageRequest) (*ReportPathUsageResponse, error)
 	DRKeyASHost(context.Context, *DRKeyASHostRequest) (*DRKeyASHostResponse, error)
 	DRKeyHostAS(context.Context, *DRKeyHostASRequest) (*DRKeyHostASResponse, error)
 	DRKeyHostHost(context.Context, *DRKeyHostHostRequest) (*DRKeyHostHostResponse, error)
+	ColibriReserve(context.Context, *ColibriReserveRequest) (*ColibriReserveResponse, error)
+	HiddenPathGroups(context.Context, *HiddenPathGroupsRequest) (*HiddenPathGroupsResponse, error)
 }
 
 // UnimplementedDaemonServiceServer can be embedded to have forward compatible implementations.
@@ -2288,6 +4207,15 @@ type UnimplementedDaemonServiceServer struct {
 func (*UnimplementedDaemonServiceServer) Paths(context.Context, *PathsRequest) (*PathsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Paths not implemented")
 }
+func (*UnimplementedDaemonServiceServer) WatchPaths(*WatchPathsRequest, DaemonService_WatchPathsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPaths not implemented")
+}
+func (*UnimplementedDaemonServiceServer) PathByFingerprint(context.Context, *PathByFingerprintRequest) (*PathByFingerprintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PathByFingerprint not implemented")
+}
+func (*UnimplementedDaemonServiceServer) DisjointPaths(context.Context, *DisjointPathsRequest) (*DisjointPathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisjointPaths not implemented")
+}
 func (*UnimplementedDaemonServiceServer) AS(context.Context, *ASRequest) (*ASResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AS not implemented")
 }
@@ -2297,9 +4225,15 @@ func (*UnimplementedDaemonServiceServer) Interfaces(context.Context, *Interfaces
 func (*UnimplementedDaemonServiceServer) Services(context.Context, *ServicesRequest) (*ServicesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Services not implemented")
 }
+func (*UnimplementedDaemonServiceServer) LocalTopology(context.Context, *LocalTopologyRequest) (*LocalTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LocalTopology not implemented")
+}
 func (*UnimplementedDaemonServiceServer) NotifyInterfaceDown(context.Context, *NotifyInterfaceDownRequest) (*NotifyInterfaceDownResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NotifyInterfaceDown not implemented")
 }
+func (*UnimplementedDaemonServiceServer) ReportPathUsage(context.Context, *ReportPathUsageRequest) (*ReportPathUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportPathUsage not implemented")
+}
 func (*UnimplementedDaemonServiceServer) DRKeyASHost(context.Context, *DRKeyASHostRequest) (*DRKeyASHostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DRKeyASHost not implemented")
 }
@@ -2309,6 +4243,12 @@ func (*UnimplementedDaemonServiceServer) DRKeyHostAS(context.Context, *DRKeyHost
 func (*UnimplementedDaemonServiceServer) DRKeyHostHost(context.Context, *DRKeyHostHostRequest) (*DRKeyHostHostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DRKeyHostHost not implemented")
 }
+func (*UnimplementedDaemonServiceServer) ColibriReserve(context.Context, *ColibriReserveRequest) (*ColibriReserveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ColibriReserve not implemented")
+}
+func (*UnimplementedDaemonServiceServer) HiddenPathGroups(context.Context, *HiddenPathGroupsRequest) (*HiddenPathGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HiddenPathGroups not implemented")
+}
 
 func RegisterDaemonServiceServer(s *grpc.Server, srv DaemonServiceServer) {
 	s.RegisterService(&_DaemonService_serviceDesc, srv)
@@ -2332,6 +4272,63 @@ func _DaemonService_Paths_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_WatchPaths_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPathsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServiceServer).WatchPaths(m, &daemonServiceWatchPathsServer{stream})
+}
+
+type DaemonService_WatchPathsServer interface {
+	Send(*WatchPathsResponse) error
+	grpc.ServerStream
+}
+
+type daemonServiceWatchPathsServer struct {
+	grpc.ServerStream
+}
+
+func (x *daemonServiceWatchPathsServer) Send(m *WatchPathsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DaemonService_PathByFingerprint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PathByFingerprintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).PathByFingerprint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.daemon.v1.DaemonService/PathByFingerprint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).PathByFingerprint(ctx, req.(*PathByFingerprintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_DisjointPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisjointPathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).DisjointPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.daemon.v1.DaemonService/DisjointPaths",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).DisjointPaths(ctx, req.(*DisjointPathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DaemonService_AS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ASRequest)
 	if err := dec(in); err != nil {
@@ -2386,6 +4383,24 @@ func _DaemonService_Services_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_LocalTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocalTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).LocalTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.daemon.v1.DaemonService/LocalTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).LocalTopology(ctx, req.(*LocalTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DaemonService_NotifyInterfaceDown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NotifyInterfaceDownRequest)
 	if err := dec(in); err != nil {
@@ -2404,6 +4419,24 @@ func _DaemonService_NotifyInterfaceDown_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_ReportPathUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportPathUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ReportPathUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.daemon.v1.DaemonService/ReportPathUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ReportPathUsage(ctx, req.(*ReportPathUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DaemonService_DRKeyASHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DRKeyASHostRequest)
 	if err := dec(in); err != nil {
@@ -2458,6 +4491,42 @@ func _DaemonService_DRKeyHostHost_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_ColibriReserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ColibriReserveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ColibriReserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.daemon.v1.DaemonService/ColibriReserve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ColibriReserve(ctx, req.(*ColibriReserveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_HiddenPathGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HiddenPathGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).HiddenPathGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.daemon.v1.DaemonService/HiddenPathGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).HiddenPathGroups(ctx, req.(*HiddenPathGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DaemonService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.daemon.v1.DaemonService",
 	HandlerType: (*DaemonServiceServer)(nil),
@@ -2466,6 +4535,14 @@ var _DaemonService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Paths",
 			Handler:    _DaemonService_Paths_Handler,
 		},
+		{
+			MethodName: "PathByFingerprint",
+			Handler:    _DaemonService_PathByFingerprint_Handler,
+		},
+		{
+			MethodName: "DisjointPaths",
+			Handler:    _DaemonService_DisjointPaths_Handler,
+		},
 		{
 			MethodName: "AS",
 			Handler:    _DaemonService_AS_Handler,
@@ -2478,10 +4555,18 @@ var _DaemonService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Services",
 			Handler:    _DaemonService_Services_Handler,
 		},
+		{
+			MethodName: "LocalTopology",
+			Handler:    _DaemonService_LocalTopology_Handler,
+		},
 		{
 			MethodName: "NotifyInterfaceDown",
 			Handler:    _DaemonService_NotifyInterfaceDown_Handler,
 		},
+		{
+			MethodName: "ReportPathUsage",
+			Handler:    _DaemonService_ReportPathUsage_Handler,
+		},
 		{
 			MethodName: "DRKeyASHost",
 			Handler:    _DaemonService_DRKeyASHost_Handler,
@@ -2494,7 +4579,21 @@ var _DaemonService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DRKeyHostHost",
 			Handler:    _DaemonService_DRKeyHostHost_Handler,
 		},
+		{
+			MethodName: "ColibriReserve",
+			Handler:    _DaemonService_ColibriReserve_Handler,
+		},
+		{
+			MethodName: "HiddenPathGroups",
+			Handler:    _DaemonService_HiddenPathGroups_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPaths",
+			Handler:       _DaemonService_WatchPaths_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/daemon/v1/daemon.proto",
 }
@@ -0,0 +1,395 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.21.10
+// source: proto/error_details/v1/error_details.proto
+
+package error_details
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ErrorGroupNotAuthorized_Reason int32
+
+const (
+	ErrorGroupNotAuthorized_REASON_UNSPECIFIED   ErrorGroupNotAuthorized_Reason = 0
+	ErrorGroupNotAuthorized_REASON_UNKNOWN_GROUP ErrorGroupNotAuthorized_Reason = 1
+	ErrorGroupNotAuthorized_REASON_NOT_MEMBER    ErrorGroupNotAuthorized_Reason = 2
+	ErrorGroupNotAuthorized_REASON_NOT_REGISTRY  ErrorGroupNotAuthorized_Reason = 3
+)
+
+// Enum value maps for ErrorGroupNotAuthorized_Reason.
+var (
+	ErrorGroupNotAuthorized_Reason_name = map[int32]string{
+		0: "REASON_UNSPECIFIED",
+		1: "REASON_UNKNOWN_GROUP",
+		2: "REASON_NOT_MEMBER",
+		3: "REASON_NOT_REGISTRY",
+	}
+	ErrorGroupNotAuthorized_Reason_value = map[string]int32{
+		"REASON_UNSPECIFIED":   0,
+		"REASON_UNKNOWN_GROUP": 1,
+		"REASON_NOT_MEMBER":    2,
+		"REASON_NOT_REGISTRY":  3,
+	}
+)
+
+func (x ErrorGroupNotAuthorized_Reason) Enum() *ErrorGroupNotAuthorized_Reason {
+	p := new(ErrorGroupNotAuthorized_Reason)
+	*p = x
+	return p
+}
+
+func (x ErrorGroupNotAuthorized_Reason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorGroupNotAuthorized_Reason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_error_details_v1_error_details_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorGroupNotAuthorized_Reason) Type() protoreflect.EnumType {
+	return &file_proto_error_details_v1_error_details_proto_enumTypes[0]
+}
+
+func (x ErrorGroupNotAuthorized_Reason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorGroupNotAuthorized_Reason.Descriptor instead.
+func (ErrorGroupNotAuthorized_Reason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_error_details_v1_error_details_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ErrorGroupNotAuthorized struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId uint64                         `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	IsdAs   uint64                         `protobuf:"varint,2,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	Reason  ErrorGroupNotAuthorized_Reason `protobuf:"varint,3,opt,name=reason,proto3,enum=proto.error_details.v1.ErrorGroupNotAuthorized_Reason" json:"reason,omitempty"`
+}
+
+func (x *ErrorGroupNotAuthorized) Reset() {
+	*x = ErrorGroupNotAuthorized{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_error_details_v1_error_details_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorGroupNotAuthorized) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorGroupNotAuthorized) ProtoMessage() {}
+
+func (x *ErrorGroupNotAuthorized) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_error_details_v1_error_details_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorGroupNotAuthorized.ProtoReflect.Descriptor instead.
+func (*ErrorGroupNotAuthorized) Descriptor() ([]byte, []int) {
+	return file_proto_error_details_v1_error_details_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ErrorGroupNotAuthorized) GetGroupId() uint64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *ErrorGroupNotAuthorized) GetIsdAs() uint64 {
+	if x != nil {
+		return x.IsdAs
+	}
+	return 0
+}
+
+func (x *ErrorGroupNotAuthorized) GetReason() ErrorGroupNotAuthorized_Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return ErrorGroupNotAuthorized_REASON_UNSPECIFIED
+}
+
+type ErrorSegmentExpired struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SegmentId  []byte                 `protobuf:"bytes,1,opt,name=segment_id,json=segmentId,proto3" json:"segment_id,omitempty"`
+	Expiration *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiration,proto3" json:"expiration,omitempty"`
+}
+
+func (x *ErrorSegmentExpired) Reset() {
+	*x = ErrorSegmentExpired{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_error_details_v1_error_details_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorSegmentExpired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorSegmentExpired) ProtoMessage() {}
+
+func (x *ErrorSegmentExpired) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_error_details_v1_error_details_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorSegmentExpired.ProtoReflect.Descriptor instead.
+func (*ErrorSegmentExpired) Descriptor() ([]byte, []int) {
+	return file_proto_error_details_v1_error_details_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ErrorSegmentExpired) GetSegmentId() []byte {
+	if x != nil {
+		return x.SegmentId
+	}
+	return nil
+}
+
+func (x *ErrorSegmentExpired) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+type ErrorTRCMissing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Isd    uint32 `protobuf:"varint,1,opt,name=isd,proto3" json:"isd,omitempty"`
+	Base   uint64 `protobuf:"varint,2,opt,name=base,proto3" json:"base,omitempty"`
+	Serial uint64 `protobuf:"varint,3,opt,name=serial,proto3" json:"serial,omitempty"`
+}
+
+func (x *ErrorTRCMissing) Reset() {
+	*x = ErrorTRCMissing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_error_details_v1_error_details_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorTRCMissing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorTRCMissing) ProtoMessage() {}
+
+func (x *ErrorTRCMissing) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_error_details_v1_error_details_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorTRCMissing.ProtoReflect.Descriptor instead.
+func (*ErrorTRCMissing) Descriptor() ([]byte, []int) {
+	return file_proto_error_details_v1_error_details_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ErrorTRCMissing) GetIsd() uint32 {
+	if x != nil {
+		return x.Isd
+	}
+	return 0
+}
+
+func (x *ErrorTRCMissing) GetBase() uint64 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *ErrorTRCMissing) GetSerial() uint64 {
+	if x != nil {
+		return x.Serial
+	}
+	return 0
+}
+
+var File_proto_error_details_v1_error_details_proto protoreflect.FileDescriptor
+
+var file_proto_error_details_v1_error_details_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x87, 0x02, 0x0a, 0x17, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x4e, 0x6f, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65,
+	0x64, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06,
+	0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x73,
+	0x64, 0x41, 0x73, 0x12, 0x4e, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x36, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x6f, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x7a, 0x65, 0x64, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0x6a, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x12, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f,
+	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x10, 0x01, 0x12,
+	0x15, 0x0a, 0x11, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x4d, 0x45,
+	0x4d, 0x42, 0x45, 0x52, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e,
+	0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x52, 0x59, 0x10, 0x03, 0x22,
+	0x70, 0x0a, 0x13, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x45,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x4f, 0x0a, 0x0f, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x52, 0x43, 0x4d, 0x69, 0x73,
+	0x73, 0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x73, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x03, 0x69, 0x73, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f,
+	0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_proto_error_details_v1_error_details_proto_rawDescOnce sync.Once
+	file_proto_error_details_v1_error_details_proto_rawDescData = file_proto_error_details_v1_error_details_proto_rawDesc
+)
+
+func file_proto_error_details_v1_error_details_proto_rawDescGZIP() []byte {
+	file_proto_error_details_v1_error_details_proto_rawDescOnce.Do(func() {
+		file_proto_error_details_v1_error_details_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_error_details_v1_error_details_proto_rawDescData)
+	})
+	return file_proto_error_details_v1_error_details_proto_rawDescData
+}
+
+var file_proto_error_details_v1_error_details_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_error_details_v1_error_details_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_error_details_v1_error_details_proto_goTypes = []interface{}{
+	(ErrorGroupNotAuthorized_Reason)(0), // 0: proto.error_details.v1.ErrorGroupNotAuthorized.Reason
+	(*ErrorGroupNotAuthorized)(nil),     // 1: proto.error_details.v1.ErrorGroupNotAuthorized
+	(*ErrorSegmentExpired)(nil),         // 2: proto.error_details.v1.ErrorSegmentExpired
+	(*ErrorTRCMissing)(nil),             // 3: proto.error_details.v1.ErrorTRCMissing
+	(*timestamppb.Timestamp)(nil),       // 4: google.protobuf.Timestamp
+}
+var file_proto_error_details_v1_error_details_proto_depIdxs = []int32{
+	0, // 0: proto.error_details.v1.ErrorGroupNotAuthorized.reason:type_name -> proto.error_details.v1.ErrorGroupNotAuthorized.Reason
+	4, // 1: proto.error_details.v1.ErrorSegmentExpired.expiration:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_error_details_v1_error_details_proto_init() }
+func file_proto_error_details_v1_error_details_proto_init() {
+	if File_proto_error_details_v1_error_details_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_error_details_v1_error_details_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorGroupNotAuthorized); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_error_details_v1_error_details_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorSegmentExpired); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_error_details_v1_error_details_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorTRCMissing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_error_details_v1_error_details_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_error_details_v1_error_details_proto_goTypes,
+		DependencyIndexes: file_proto_error_details_v1_error_details_proto_depIdxs,
+		EnumInfos:         file_proto_error_details_v1_error_details_proto_enumTypes,
+		MessageInfos:      file_proto_error_details_v1_error_details_proto_msgTypes,
+	}.Build()
+	File_proto_error_details_v1_error_details_proto = out.File
+	file_proto_error_details_v1_error_details_proto_rawDesc = nil
+	file_proto_error_details_v1_error_details_proto_goTypes = nil
+	file_proto_error_details_v1_error_details_proto_depIdxs = nil
+}
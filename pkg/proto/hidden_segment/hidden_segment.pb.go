@@ -127,6 +127,7 @@ type HiddenSegmentRegistrationRequestBody struct {
 
 	Segments map[int32]*Segments `protobuf:"bytes,1,rep,name=segments,proto3" json:"segments,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	GroupId  uint64              `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Nonce    []byte              `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
 }
 
 func (x *HiddenSegmentRegistrationRequestBody) Reset() {
@@ -175,10 +176,19 @@ func (x *HiddenSegmentRegistrationRequestBody) GetGroupId() uint64 {
 	return 0
 }
 
+func (x *HiddenSegmentRegistrationRequestBody) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
 type HiddenSegmentRegistrationResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	LoadHint uint32 `protobuf:"varint,1,opt,name=load_hint,json=loadHint,proto3" json:"load_hint,omitempty"`
 }
 
 func (x *HiddenSegmentRegistrationResponse) Reset() {
@@ -213,13 +223,21 @@ func (*HiddenSegmentRegistrationResponse) Descriptor() ([]byte, []int) {
 	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{3}
 }
 
+func (x *HiddenSegmentRegistrationResponse) GetLoadHint() uint32 {
+	if x != nil {
+		return x.LoadHint
+	}
+	return 0
+}
+
 type HiddenSegmentsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GroupIds []uint64 `protobuf:"varint,1,rep,packed,name=group_ids,json=groupIds,proto3" json:"group_ids,omitempty"`
-	DstIsdAs uint64   `protobuf:"varint,2,opt,name=dst_isd_as,json=dstIsdAs,proto3" json:"dst_isd_as,omitempty"`
+	GroupIds          []uint64 `protobuf:"varint,1,rep,packed,name=group_ids,json=groupIds,proto3" json:"group_ids,omitempty"`
+	DstIsdAs          uint64   `protobuf:"varint,2,opt,name=dst_isd_as,json=dstIsdAs,proto3" json:"dst_isd_as,omitempty"`
+	VisitedRegistries []uint64 `protobuf:"varint,3,rep,packed,name=visited_registries,json=visitedRegistries,proto3" json:"visited_registries,omitempty"`
 }
 
 func (x *HiddenSegmentsRequest) Reset() {
@@ -268,6 +286,13 @@ func (x *HiddenSegmentsRequest) GetDstIsdAs() uint64 {
 	return 0
 }
 
+func (x *HiddenSegmentsRequest) GetVisitedRegistries() []uint64 {
+	if x != nil {
+		return x.VisitedRegistries
+	}
+	return nil
+}
+
 type HiddenSegmentsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -315,6 +340,146 @@ func (x *HiddenSegmentsResponse) GetSegments() map[int32]*Segments {
 	return nil
 }
 
+type ReaderGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReaderGroupsRequest) Reset() {
+	*x = ReaderGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReaderGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReaderGroupsRequest) ProtoMessage() {}
+
+func (x *ReaderGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReaderGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ReaderGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{6}
+}
+
+type ReaderGroupsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Groups []*HiddenPathGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *ReaderGroupsResponse) Reset() {
+	*x = ReaderGroupsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReaderGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReaderGroupsResponse) ProtoMessage() {}
+
+func (x *ReaderGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReaderGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ReaderGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReaderGroupsResponse) GetGroups() []*HiddenPathGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type HiddenPathGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Writers []uint64 `protobuf:"varint,2,rep,packed,name=writers,proto3" json:"writers,omitempty"`
+}
+
+func (x *HiddenPathGroup) Reset() {
+	*x = HiddenPathGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HiddenPathGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiddenPathGroup) ProtoMessage() {}
+
+func (x *HiddenPathGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiddenPathGroup.ProtoReflect.Descriptor instead.
+func (*HiddenPathGroup) Descriptor() ([]byte, []int) {
+	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HiddenPathGroup) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *HiddenPathGroup) GetWriters() []uint64 {
+	if x != nil {
+		return x.Writers
+	}
+	return nil
+}
+
 type AuthoritativeHiddenSegmentsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -326,7 +491,7 @@ type AuthoritativeHiddenSegmentsRequest struct {
 func (x *AuthoritativeHiddenSegmentsRequest) Reset() {
 	*x = AuthoritativeHiddenSegmentsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[6]
+		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -339,7 +504,7 @@ func (x *AuthoritativeHiddenSegmentsRequest) String() string {
 func (*AuthoritativeHiddenSegmentsRequest) ProtoMessage() {}
 
 func (x *AuthoritativeHiddenSegmentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[6]
+	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +517,7 @@ func (x *AuthoritativeHiddenSegmentsRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use AuthoritativeHiddenSegmentsRequest.ProtoReflect.Descriptor instead.
 func (*AuthoritativeHiddenSegmentsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{6}
+	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AuthoritativeHiddenSegmentsRequest) GetSignedRequest() *crypto.SignedMessage {
@@ -373,7 +538,7 @@ type AuthoritativeHiddenSegmentsResponse struct {
 func (x *AuthoritativeHiddenSegmentsResponse) Reset() {
 	*x = AuthoritativeHiddenSegmentsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[7]
+		mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -386,7 +551,7 @@ func (x *AuthoritativeHiddenSegmentsResponse) String() string {
 func (*AuthoritativeHiddenSegmentsResponse) ProtoMessage() {}
 
 func (x *AuthoritativeHiddenSegmentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[7]
+	mi := &file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,7 +564,7 @@ func (x *AuthoritativeHiddenSegmentsResponse) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use AuthoritativeHiddenSegmentsResponse.ProtoReflect.Descriptor instead.
 func (*AuthoritativeHiddenSegmentsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{7}
+	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AuthoritativeHiddenSegmentsResponse) GetSegments() map[int32]*Segments {
@@ -432,7 +597,7 @@ var file_proto_hidden_segment_v1_hidden_segment_proto_rawDesc = []byte{
 	0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e,
 	0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
 	0x52, 0x0d, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
-	0x8a, 0x02, 0x0a, 0x24, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0xa0, 0x02, 0x0a, 0x24, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
 	0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x67, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d,
 	0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4b, 0x2e, 0x70, 0x72, 0x6f,
@@ -442,94 +607,119 @@ var file_proto_hidden_segment_v1_hidden_segment_proto_rawDesc = []byte{
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
 	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
 	0x73, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x1a, 0x5e, 0x0a, 0x0d,
-	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x37, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65,
-	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x23, 0x0a, 0x21,
-	0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x52, 0x0a, 0x15, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x67,
-	0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x0a, 0x64, 0x73, 0x74, 0x5f, 0x69,
-	0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x73, 0x74,
-	0x49, 0x73, 0x64, 0x41, 0x73, 0x22, 0xd3, 0x01, 0x0a, 0x16, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
-	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x59, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65,
-	0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64,
-	0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x5e, 0x0a, 0x0d, 0x53,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x37,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x6b, 0x0a, 0x22, 0x41,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64,
-	0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x45, 0x0a, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x6e, 0x65,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xed, 0x01, 0x0a, 0x23, 0x41, 0x75, 0x74,
-	0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
-	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x66, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x4a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65,
-	0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74,
-	0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
-	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08,
-	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x5e, 0x0a, 0x0d, 0x53, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x37, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0xb9, 0x01, 0x0a, 0x20, 0x48, 0x69, 0x64,
-	0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x94, 0x01,
-	0x0a, 0x19, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x1a, 0x5e, 0x0a, 0x0d, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x37, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x40, 0x0a, 0x21, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d,
 	0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68,
-	0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x32, 0x91, 0x01, 0x0a, 0x1a, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x73, 0x0a, 0x0e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69,
-	0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
-	0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
+	0x68, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c, 0x6f, 0x61, 0x64,
+	0x48, 0x69, 0x6e, 0x74, 0x22, 0x81, 0x01, 0x0a, 0x15, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x0a, 0x64,
+	0x73, 0x74, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x64, 0x73, 0x74, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x76, 0x69, 0x73,
+	0x69, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x11, 0x76, 0x69, 0x73, 0x69, 0x74, 0x65, 0x64, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xd3, 0x01, 0x0a, 0x16, 0x48, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69,
 	0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e,
 	0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xc6, 0x01, 0x0a, 0x27, 0x41, 0x75, 0x74,
-	0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x5e,
+	0x0a, 0x0d, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x37, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x15,
+	0x0a, 0x13, 0x52, 0x65, 0x61, 0x64, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x58, 0x0a, 0x14, 0x52, 0x65, 0x61, 0x64, 0x65, 0x72, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a,
+	0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61,
+	0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22,
+	0x3b, 0x0a, 0x0f, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x69, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x04, 0x52, 0x07, 0x77, 0x72, 0x69, 0x74, 0x65, 0x72, 0x73, 0x22, 0x6b, 0x0a, 0x22,
+	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x45, 0x0a, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xed, 0x01, 0x0a, 0x23, 0x41, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x66, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x4a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64,
+	0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x5e, 0x0a, 0x0d, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x37, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0xb9, 0x01, 0x0a, 0x20, 0x48, 0x69,
+	0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x94,
+	0x01, 0x0a, 0x19, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x91, 0x01, 0x0a, 0x1a, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
 	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x9a, 0x01, 0x0a, 0x1b, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
-	0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x12, 0x3b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64,
-	0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64,
-	0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x3c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e,
-	0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x73, 0x0a, 0x0e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68,
+	0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68,
+	0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x8b, 0x01, 0x0a, 0x1a, 0x48, 0x69,
+	0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6d, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68,
+	0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xc6, 0x01, 0x0a, 0x27, 0x41, 0x75, 0x74, 0x68,
 	0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e,
-	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x68, 0x69, 0x64, 0x64, 0x65,
-	0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x9a, 0x01, 0x0a, 0x1b, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x3b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64,
+	0x65, 0x6e, 0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x3c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73,
+	0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e,
+	0x5f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -544,7 +734,7 @@ func file_proto_hidden_segment_v1_hidden_segment_proto_rawDescGZIP() []byte {
 	return file_proto_hidden_segment_v1_hidden_segment_proto_rawDescData
 }
 
-var file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_hidden_segment_v1_hidden_segment_proto_goTypes = []interface{}{
 	(*Segments)(nil),                             // 0: proto.hidden_segment.v1.Segments
 	(*HiddenSegmentRegistrationRequest)(nil),     // 1: proto.hidden_segment.v1.HiddenSegmentRegistrationRequest
@@ -552,35 +742,41 @@ var file_proto_hidden_segment_v1_hidden_segment_proto_goTypes = []interface{}{
 	(*HiddenSegmentRegistrationResponse)(nil),    // 3: proto.hidden_segment.v1.HiddenSegmentRegistrationResponse
 	(*HiddenSegmentsRequest)(nil),                // 4: proto.hidden_segment.v1.HiddenSegmentsRequest
 	(*HiddenSegmentsResponse)(nil),               // 5: proto.hidden_segment.v1.HiddenSegmentsResponse
-	(*AuthoritativeHiddenSegmentsRequest)(nil),   // 6: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest
-	(*AuthoritativeHiddenSegmentsResponse)(nil),  // 7: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse
-	nil,                               // 8: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry
-	nil,                               // 9: proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry
-	nil,                               // 10: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry
-	(*control_plane.PathSegment)(nil), // 11: proto.control_plane.v1.PathSegment
-	(*crypto.SignedMessage)(nil),      // 12: proto.crypto.v1.SignedMessage
+	(*ReaderGroupsRequest)(nil),                  // 6: proto.hidden_segment.v1.ReaderGroupsRequest
+	(*ReaderGroupsResponse)(nil),                 // 7: proto.hidden_segment.v1.ReaderGroupsResponse
+	(*HiddenPathGroup)(nil),                      // 8: proto.hidden_segment.v1.HiddenPathGroup
+	(*AuthoritativeHiddenSegmentsRequest)(nil),   // 9: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest
+	(*AuthoritativeHiddenSegmentsResponse)(nil),  // 10: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse
+	nil,                               // 11: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry
+	nil,                               // 12: proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry
+	nil,                               // 13: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry
+	(*control_plane.PathSegment)(nil), // 14: proto.control_plane.v1.PathSegment
+	(*crypto.SignedMessage)(nil),      // 15: proto.crypto.v1.SignedMessage
 }
 var file_proto_hidden_segment_v1_hidden_segment_proto_depIdxs = []int32{
-	11, // 0: proto.hidden_segment.v1.Segments.segments:type_name -> proto.control_plane.v1.PathSegment
-	12, // 1: proto.hidden_segment.v1.HiddenSegmentRegistrationRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
-	8,  // 2: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.segments:type_name -> proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry
-	9,  // 3: proto.hidden_segment.v1.HiddenSegmentsResponse.segments:type_name -> proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry
-	12, // 4: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
-	10, // 5: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.segments:type_name -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry
-	0,  // 6: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
-	0,  // 7: proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
-	0,  // 8: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
-	1,  // 9: proto.hidden_segment.v1.HiddenSegmentRegistrationService.HiddenSegmentRegistration:input_type -> proto.hidden_segment.v1.HiddenSegmentRegistrationRequest
-	4,  // 10: proto.hidden_segment.v1.HiddenSegmentLookupService.HiddenSegments:input_type -> proto.hidden_segment.v1.HiddenSegmentsRequest
-	6,  // 11: proto.hidden_segment.v1.AuthoritativeHiddenSegmentLookupService.AuthoritativeHiddenSegments:input_type -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest
-	3,  // 12: proto.hidden_segment.v1.HiddenSegmentRegistrationService.HiddenSegmentRegistration:output_type -> proto.hidden_segment.v1.HiddenSegmentRegistrationResponse
-	5,  // 13: proto.hidden_segment.v1.HiddenSegmentLookupService.HiddenSegments:output_type -> proto.hidden_segment.v1.HiddenSegmentsResponse
-	7,  // 14: proto.hidden_segment.v1.AuthoritativeHiddenSegmentLookupService.AuthoritativeHiddenSegments:output_type -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse
-	12, // [12:15] is the sub-list for method output_type
-	9,  // [9:12] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	14, // 0: proto.hidden_segment.v1.Segments.segments:type_name -> proto.control_plane.v1.PathSegment
+	15, // 1: proto.hidden_segment.v1.HiddenSegmentRegistrationRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
+	11, // 2: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.segments:type_name -> proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry
+	12, // 3: proto.hidden_segment.v1.HiddenSegmentsResponse.segments:type_name -> proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry
+	8,  // 4: proto.hidden_segment.v1.ReaderGroupsResponse.groups:type_name -> proto.hidden_segment.v1.HiddenPathGroup
+	15, // 5: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
+	13, // 6: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.segments:type_name -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry
+	0,  // 7: proto.hidden_segment.v1.HiddenSegmentRegistrationRequestBody.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
+	0,  // 8: proto.hidden_segment.v1.HiddenSegmentsResponse.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
+	0,  // 9: proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse.SegmentsEntry.value:type_name -> proto.hidden_segment.v1.Segments
+	1,  // 10: proto.hidden_segment.v1.HiddenSegmentRegistrationService.HiddenSegmentRegistration:input_type -> proto.hidden_segment.v1.HiddenSegmentRegistrationRequest
+	4,  // 11: proto.hidden_segment.v1.HiddenSegmentLookupService.HiddenSegments:input_type -> proto.hidden_segment.v1.HiddenSegmentsRequest
+	6,  // 12: proto.hidden_segment.v1.HiddenSegmentGroupsService.ReaderGroups:input_type -> proto.hidden_segment.v1.ReaderGroupsRequest
+	9,  // 13: proto.hidden_segment.v1.AuthoritativeHiddenSegmentLookupService.AuthoritativeHiddenSegments:input_type -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsRequest
+	3,  // 14: proto.hidden_segment.v1.HiddenSegmentRegistrationService.HiddenSegmentRegistration:output_type -> proto.hidden_segment.v1.HiddenSegmentRegistrationResponse
+	5,  // 15: proto.hidden_segment.v1.HiddenSegmentLookupService.HiddenSegments:output_type -> proto.hidden_segment.v1.HiddenSegmentsResponse
+	7,  // 16: proto.hidden_segment.v1.HiddenSegmentGroupsService.ReaderGroups:output_type -> proto.hidden_segment.v1.ReaderGroupsResponse
+	10, // 17: proto.hidden_segment.v1.AuthoritativeHiddenSegmentLookupService.AuthoritativeHiddenSegments:output_type -> proto.hidden_segment.v1.AuthoritativeHiddenSegmentsResponse
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_proto_hidden_segment_v1_hidden_segment_proto_init() }
@@ -662,7 +858,7 @@ func file_proto_hidden_segment_v1_hidden_segment_proto_init() {
 			}
 		}
 		file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AuthoritativeHiddenSegmentsRequest); i {
+			switch v := v.(*ReaderGroupsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -674,6 +870,42 @@ func file_proto_hidden_segment_v1_hidden_segment_proto_init() {
 			}
 		}
 		file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReaderGroupsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HiddenPathGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthoritativeHiddenSegmentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_hidden_segment_v1_hidden_segment_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AuthoritativeHiddenSegmentsResponse); i {
 			case 0:
 				return &v.state
@@ -692,9 +924,9 @@ func file_proto_hidden_segment_v1_hidden_segment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_hidden_segment_v1_hidden_segment_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   14,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   4,
 		},
 		GoTypes:           file_proto_hidden_segment_v1_hidden_segment_proto_goTypes,
 		DependencyIndexes: file_proto_hidden_segment_v1_hidden_segment_proto_depIdxs,
@@ -858,6 +1090,78 @@ var _HiddenSegmentLookupService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "proto/hidden_segment/v1/hidden_segment.proto",
 }
 
+// HiddenSegmentGroupsServiceClient is the client API for HiddenSegmentGroupsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HiddenSegmentGroupsServiceClient interface {
+	ReaderGroups(ctx context.Context, in *ReaderGroupsRequest, opts ...grpc.CallOption) (*ReaderGroupsResponse, error)
+}
+
+type hiddenSegmentGroupsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHiddenSegmentGroupsServiceClient(cc grpc.ClientConnInterface) HiddenSegmentGroupsServiceClient {
+	return &hiddenSegmentGroupsServiceClient{cc}
+}
+
+func (c *hiddenSegmentGroupsServiceClient) ReaderGroups(ctx context.Context, in *ReaderGroupsRequest, opts ...grpc.CallOption) (*ReaderGroupsResponse, error) {
+	out := new(ReaderGroupsResponse)
+	err := c.cc.Invoke(ctx, "/proto.hidden_segment.v1.HiddenSegmentGroupsService/ReaderGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HiddenSegmentGroupsServiceServer is the server API for HiddenSegmentGroupsService service.
+type HiddenSegmentGroupsServiceServer interface {
+	ReaderGroups(context.Context, *ReaderGroupsRequest) (*ReaderGroupsResponse, error)
+}
+
+// UnimplementedHiddenSegmentGroupsServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedHiddenSegmentGroupsServiceServer struct {
+}
+
+func (*UnimplementedHiddenSegmentGroupsServiceServer) ReaderGroups(context.Context, *ReaderGroupsRequest) (*ReaderGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReaderGroups not implemented")
+}
+
+func RegisterHiddenSegmentGroupsServiceServer(s *grpc.Server, srv HiddenSegmentGroupsServiceServer) {
+	s.RegisterService(&_HiddenSegmentGroupsService_serviceDesc, srv)
+}
+
+func _HiddenSegmentGroupsService_ReaderGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReaderGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HiddenSegmentGroupsServiceServer).ReaderGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.hidden_segment.v1.HiddenSegmentGroupsService/ReaderGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HiddenSegmentGroupsServiceServer).ReaderGroups(ctx, req.(*ReaderGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _HiddenSegmentGroupsService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.hidden_segment.v1.HiddenSegmentGroupsService",
+	HandlerType: (*HiddenSegmentGroupsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReaderGroups",
+			Handler:    _HiddenSegmentGroupsService_ReaderGroups_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/hidden_segment/v1/hidden_segment.proto",
+}
+
 // AuthoritativeHiddenSegmentLookupServiceClient is the client API for AuthoritativeHiddenSegmentLookupService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/pkg/proto/hidden_segment (interfaces: AuthoritativeHiddenSegmentLookupServiceServer,HiddenSegmentRegistrationServiceServer,HiddenSegmentLookupServiceServer)
+// Source: github.com/scionproto/scion/pkg/proto/hidden_segment (interfaces: AuthoritativeHiddenSegmentLookupServiceServer,HiddenSegmentRegistrationServiceServer,HiddenSegmentLookupServiceServer,HiddenSegmentGroupsServiceServer)
 
 // Package mock_hidden_segment is a generated GoMock package.
 package mock_hidden_segment
@@ -125,3 +125,41 @@ func (mr *MockHiddenSegmentLookupServiceServerMockRecorder) HiddenSegments(arg0,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HiddenSegments", reflect.TypeOf((*MockHiddenSegmentLookupServiceServer)(nil).HiddenSegments), arg0, arg1)
 }
+
+// MockHiddenSegmentGroupsServiceServer is a mock of HiddenSegmentGroupsServiceServer interface.
+type MockHiddenSegmentGroupsServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockHiddenSegmentGroupsServiceServerMockRecorder
+}
+
+// MockHiddenSegmentGroupsServiceServerMockRecorder is the mock recorder for MockHiddenSegmentGroupsServiceServer.
+type MockHiddenSegmentGroupsServiceServerMockRecorder struct {
+	mock *MockHiddenSegmentGroupsServiceServer
+}
+
+// NewMockHiddenSegmentGroupsServiceServer creates a new mock instance.
+func NewMockHiddenSegmentGroupsServiceServer(ctrl *gomock.Controller) *MockHiddenSegmentGroupsServiceServer {
+	mock := &MockHiddenSegmentGroupsServiceServer{ctrl: ctrl}
+	mock.recorder = &MockHiddenSegmentGroupsServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHiddenSegmentGroupsServiceServer) EXPECT() *MockHiddenSegmentGroupsServiceServerMockRecorder {
+	return m.recorder
+}
+
+// ReaderGroups mocks base method.
+func (m *MockHiddenSegmentGroupsServiceServer) ReaderGroups(arg0 context.Context, arg1 *hidden_segment.ReaderGroupsRequest) (*hidden_segment.ReaderGroupsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReaderGroups", arg0, arg1)
+	ret0, _ := ret[0].(*hidden_segment.ReaderGroupsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReaderGroups indicates an expected call of ReaderGroups.
+func (mr *MockHiddenSegmentGroupsServiceServerMockRecorder) ReaderGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReaderGroups", reflect.TypeOf((*MockHiddenSegmentGroupsServiceServer)(nil).ReaderGroups), arg0, arg1)
+}
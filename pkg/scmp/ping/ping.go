@@ -301,7 +301,10 @@ type reply struct {
 	Source   snet.SCIONAddress
 	Size     int
 	Reply    snet.SCMPEchoReply
-	Error    error
+	// TooBigMTU is the MTU reported in an SCMP packet too big message. It is
+	// zero for all other messages.
+	TooBigMTU int
+	Error     error
 }
 
 type scmpHandler struct {
@@ -311,12 +314,17 @@ type scmpHandler struct {
 
 func (h scmpHandler) Handle(pkt *snet.Packet) error {
 	echo, err := h.handle(pkt)
+	var tooBigMTU int
+	if tooBig, ok := pkt.Payload.(snet.SCMPPacketTooBig); ok {
+		tooBigMTU = int(tooBig.MTU)
+	}
 	h.replies <- reply{
-		Received: time.Now(),
-		Source:   pkt.Source,
-		Size:     len(pkt.Bytes),
-		Reply:    echo,
-		Error:    err,
+		Received:  time.Now(),
+		Source:    pkt.Source,
+		Size:      len(pkt.Bytes),
+		Reply:     echo,
+		TooBigMTU: tooBigMTU,
+		Error:     err,
 	}
 	return nil
 }
@@ -333,6 +341,8 @@ func (h scmpHandler) handle(pkt *snet.Packet) (snet.SCMPEchoReply, error) {
 	case snet.SCMPInternalConnectivityDown:
 		return snet.SCMPEchoReply{}, serrors.New("internal connectivity is down",
 			"isd_as", s.IA, "ingress", s.Ingress, "egress", s.Egress)
+	case snet.SCMPPacketTooBig:
+		return snet.SCMPEchoReply{}, serrors.New("packet too big", "mtu", s.MTU)
 	default:
 		return snet.SCMPEchoReply{}, serrors.New("not SCMPEchoReply",
 			"type", common.TypeOf(pkt.Payload),
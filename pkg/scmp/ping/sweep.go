@@ -0,0 +1,251 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ping
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+)
+
+// DefaultSweepAttempts is the default number of echo requests that are sent
+// for a packet size before it is considered too big.
+const DefaultSweepAttempts = 3
+
+// SweepConfig configures the MTU sweep.
+type SweepConfig struct {
+	Dispatcher reliable.Dispatcher
+	Local      *snet.UDPAddr
+	Remote     *snet.UDPAddr
+
+	// MaxSize is the largest SCION packet size that is probed.
+	MaxSize int
+	// Attempts is the number of echo requests that are sent for a packet size
+	// before it is considered too big. If zero, DefaultSweepAttempts is used.
+	Attempts int
+	// Timeout is the time until an echo request is considered lost.
+	Timeout time.Duration
+
+	// ErrHandler is invoked for every error that does not cause the sweep to
+	// abort. Execution time must be small, as it is run synchronously.
+	ErrHandler func(err error)
+	// ProbeHandler is invoked for every probed packet size. Execution time
+	// must be small, as it is run synchronously.
+	ProbeHandler func(Probe)
+}
+
+// Probe is the result of probing a packet size.
+type Probe struct {
+	// Size is the SCION packet size.
+	Size int
+	// Success indicates whether an echo reply was received.
+	Success bool
+	// TooBigMTU is the MTU reported in an SCMP packet too big message, or
+	// zero if none was received.
+	TooBigMTU int
+}
+
+// SweepResult is the result of an MTU sweep.
+type SweepResult struct {
+	// MTU is the largest SCION packet size for which an echo reply was
+	// received. It is zero if no reply was received at all.
+	MTU int
+	// ReportedMTU is the smallest MTU reported in SCMP packet too big
+	// messages, or zero if none was received.
+	ReportedMTU int
+	// Probes is the number of probed packet sizes.
+	Probes int
+}
+
+// Sweep discovers the largest SCION packet size that reaches the remote and
+// back on the path of the remote address. It searches the packet size with
+// SCMP echo requests of increasing and decreasing size, starting with the
+// maximum size. Sizes above the MTU reported in SCMP packet too big messages
+// are skipped.
+func Sweep(ctx context.Context, cfg SweepConfig) (SweepResult, error) {
+	// The payload carries the 8 byte request time, like in Run.
+	minSize, err := Size(cfg.Local, cfg.Remote, 8)
+	if err != nil {
+		return SweepResult{}, err
+	}
+	if cfg.MaxSize < minSize {
+		return SweepResult{}, serrors.New("maximum size smaller than minimum packet size",
+			"max_size", cfg.MaxSize, "min_size", minSize)
+	}
+	overhead := minSize - 8
+	if cfg.Attempts == 0 {
+		cfg.Attempts = DefaultSweepAttempts
+	}
+
+	id := rand.Uint64()
+	replies := make(chan reply, 10)
+	svc := snet.DefaultPacketDispatcherService{
+		Dispatcher: cfg.Dispatcher,
+		SCMPHandler: scmpHandler{
+			id:      uint16(id),
+			replies: replies,
+		},
+	}
+	conn, port, err := svc.Register(ctx, cfg.Local.IA, cfg.Local.Host, addr.SvcNone)
+	if err != nil {
+		return SweepResult{}, err
+	}
+	defer conn.Close()
+	local := cfg.Local.Copy()
+	local.Host.Port = int(port)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	p := &pinger{
+		timeout:    cfg.Timeout,
+		id:         id,
+		conn:       conn,
+		local:      local,
+		replies:    replies,
+		errHandler: cfg.ErrHandler,
+	}
+	go func() {
+		defer log.HandlePanic()
+		p.drain(ctx)
+	}()
+
+	s := &sweeper{
+		pinger:   p,
+		remote:   cfg.Remote,
+		overhead: overhead,
+		attempts: cfg.Attempts,
+		handler:  cfg.ProbeHandler,
+	}
+	return search(ctx, minSize, cfg.MaxSize, s.probe)
+}
+
+// probeFunc probes a packet size.
+type probeFunc func(ctx context.Context, size int) (Probe, error)
+
+// search searches the largest packet size in [min, max] for which the probe
+// succeeds. It assumes that the probe succeeds for all sizes up to the MTU of
+// the path, and fails for all larger sizes.
+func search(ctx context.Context, min, max int, probe probeFunc) (SweepResult, error) {
+	var res SweepResult
+	do := func(size int) (bool, error) {
+		p, err := probe(ctx, size)
+		if err != nil {
+			return false, err
+		}
+		res.Probes++
+		if p.TooBigMTU != 0 && (res.ReportedMTU == 0 || p.TooBigMTU < res.ReportedMTU) {
+			res.ReportedMTU = p.TooBigMTU
+		}
+		return p.Success, nil
+	}
+	// lo is the largest size known to succeed, hi the smallest size known to
+	// fail.
+	lo, hi := min-1, max+1
+	for _, size := range []int{max, min} {
+		ok, err := do(size)
+		if err != nil {
+			return res, err
+		}
+		if ok {
+			lo = size
+			break
+		}
+		hi = size
+	}
+	if lo < min {
+		return res, nil
+	}
+	for hi-lo > 1 {
+		size := lo + (hi-lo)/2
+		if r := res.ReportedMTU; r > lo && r < hi {
+			// Sizes above the reported MTU are expected to fail, the reported
+			// MTU is probed directly.
+			size, hi = r, r+1
+		}
+		ok, err := do(size)
+		if err != nil {
+			return res, err
+		}
+		if ok {
+			lo = size
+		} else {
+			hi = size
+		}
+	}
+	res.MTU = lo
+	return res, nil
+}
+
+type sweeper struct {
+	*pinger
+	remote   *snet.UDPAddr
+	overhead int
+	attempts int
+	handler  func(Probe)
+}
+
+// probe sends echo requests of the given size until a reply or an SCMP packet
+// too big message is received, or all attempts timed out.
+func (s *sweeper) probe(ctx context.Context, size int) (Probe, error) {
+	res := Probe{Size: size}
+	pld := make([]byte, size-s.overhead)
+	sizes := make(map[uint16]int, s.attempts)
+	for i := 0; i < s.attempts && !res.Success && res.TooBigMTU == 0; i++ {
+		s.pld = pld
+		seq := uint16(s.sentSequence + 1)
+		if err := s.send(s.remote); err != nil {
+			return Probe{}, serrors.WrapStr("sending", err)
+		}
+		sizes[seq] = size
+		timeout := time.NewTimer(s.timeout)
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				timeout.Stop()
+				return Probe{}, ctx.Err()
+			case <-timeout.C:
+				break wait
+			case r := <-s.replies:
+				switch {
+				case r.TooBigMTU != 0:
+					res.TooBigMTU = r.TooBigMTU
+				case r.Error != nil:
+					if s.errHandler != nil {
+						s.errHandler(r.Error)
+					}
+					continue
+				case sizes[r.Reply.SeqNumber] == size:
+					res.Success = true
+				default:
+					// Late reply to a request of another size.
+					continue
+				}
+				timeout.Stop()
+				break wait
+			}
+		}
+	}
+	if s.handler != nil {
+		s.handler(res)
+	}
+	return res, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ping
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	// pathProbe simulates a path with the given MTU. If reportMTU is set, the
+	// path answers too big packets with an SCMP packet too big message.
+	pathProbe := func(mtu int, reportMTU bool) probeFunc {
+		return func(_ context.Context, size int) (Probe, error) {
+			p := Probe{Size: size, Success: size <= mtu}
+			if !p.Success && reportMTU {
+				p.TooBigMTU = mtu
+			}
+			return p, nil
+		}
+	}
+
+	testCases := map[string]struct {
+		probe       probeFunc
+		expected    int
+		reported    int
+		maxProbes   int
+		assertError assert.ErrorAssertionFunc
+	}{
+		"max size": {
+			probe:       pathProbe(1500, false),
+			expected:    1500,
+			maxProbes:   1,
+			assertError: assert.NoError,
+		},
+		"binary search": {
+			probe:       pathProbe(1234, false),
+			expected:    1234,
+			maxProbes:   13,
+			assertError: assert.NoError,
+		},
+		"packet too big": {
+			probe:       pathProbe(1234, true),
+			expected:    1234,
+			reported:    1234,
+			maxProbes:   3,
+			assertError: assert.NoError,
+		},
+		"no reply": {
+			probe:       pathProbe(0, false),
+			expected:    0,
+			maxProbes:   2,
+			assertError: assert.NoError,
+		},
+		"error": {
+			probe: func(context.Context, int) (Probe, error) {
+				return Probe{}, errors.New("test")
+			},
+			assertError: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			res, err := search(context.Background(), 100, 1500, tc.probe)
+			tc.assertError(t, err)
+			if err != nil {
+				return
+			}
+			require.Equal(t, tc.expected, res.MTU)
+			assert.Equal(t, tc.reported, res.ReportedMTU)
+			assert.LessOrEqual(t, res.Probes, tc.maxProbes)
+		})
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package probe provides SCMP based probing of SCION paths. ProbePath sends
+// SCMP echo requests over a path and reports the round trip time statistics,
+// TracePath sends SCMP traceroute requests to every router on a path.
+//
+// The package is the library counterpart of the "scion ping" and
+// "scion traceroute" commands; it allows monitoring tools to embed SCION
+// probing without shelling out to the CLI.
+package probe
+
+import (
+	"context"
+	"math"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scmp/ping"
+	"github.com/scionproto/scion/pkg/scmp/traceroute"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/addrutil"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/topology/underlay"
+)
+
+// DefaultTimeout is the time after which a probe is considered lost if no
+// timeout is configured.
+const DefaultTimeout = time.Second
+
+// Target identifies the probed host and the path to it.
+type Target struct {
+	// Dispatcher is the dispatcher used to send and receive the probes.
+	Dispatcher reliable.Dispatcher
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+	// LocalIP is the local IP address. If nil, the address is resolved based
+	// on the underlay next hop of the path.
+	LocalIP net.IP
+	// Remote is the address of the probed host.
+	Remote *snet.UDPAddr
+	// Path is the path to the probed host.
+	Path snet.Path
+}
+
+// addresses returns the local and the remote address for the probes.
+func (t Target) addresses() (*snet.UDPAddr, *snet.UDPAddr, error) {
+	if t.Remote == nil || t.Remote.Host == nil {
+		return nil, nil, serrors.New("remote address must be set")
+	}
+	if t.Path == nil {
+		return nil, nil, serrors.New("path must be set")
+	}
+	remote := t.Remote.Copy()
+	remote.Path = t.Path.Dataplane()
+	remote.NextHop = t.Path.UnderlayNextHop()
+	if remote.NextHop == nil && t.LocalIA.Equal(remote.IA) {
+		remote.NextHop = &net.UDPAddr{
+			IP:   remote.Host.IP,
+			Port: underlay.EndhostPort,
+		}
+	}
+	localIP := t.LocalIP
+	if localIP == nil {
+		target := remote.Host.IP
+		if remote.NextHop != nil {
+			target = remote.NextHop.IP
+		}
+		var err error
+		if localIP, err = addrutil.ResolveLocal(target); err != nil {
+			return nil, nil, serrors.WrapStr("resolving local address", err)
+		}
+	}
+	local := &snet.UDPAddr{IA: t.LocalIA, Host: &net.UDPAddr{IP: localIP}}
+	return local, remote, nil
+}
+
+// ProbeConfig configures ProbePath.
+type ProbeConfig struct {
+	Target
+	// Count is the number of echo requests to send.
+	Count uint16
+	// Interval is the time between two echo requests.
+	Interval time.Duration
+	// Timeout is the time after which an echo request is considered lost. If
+	// zero, DefaultTimeout is used.
+	Timeout time.Duration
+	// PayloadSize is the size of the echo payload in bytes.
+	PayloadSize int
+	// OnReply, if set, is called synchronously for every received reply.
+	OnReply func(Reply)
+	// OnError, if set, is called synchronously for every error that does not
+	// abort probing.
+	OnError func(error)
+}
+
+// Reply is a received echo reply.
+type Reply struct {
+	// Sequence is the sequence number of the reply.
+	Sequence int
+	// Size is the size of the SCION packet.
+	Size int
+	// Source is the address of the replying host.
+	Source snet.SCIONAddress
+	// RTT is the round trip time.
+	RTT time.Duration
+	// State indicates whether the reply was in time and in order.
+	State ping.State
+}
+
+// ProbeResult is the result of ProbePath.
+type ProbeResult struct {
+	// Local is the local address the probes were sent from.
+	Local *snet.UDPAddr
+	// Replies are the received replies.
+	Replies []Reply
+	// Stats are the statistics of the probing run.
+	Stats Stats
+}
+
+// Stats summarizes a probing run.
+type Stats struct {
+	// Sent is the number of sent echo requests.
+	Sent int
+	// Received is the number of received echo replies.
+	Received int
+	// Loss is the percentage of requests that were not answered.
+	Loss int
+	// Time is the duration of the run.
+	Time time.Duration
+	// MinRTT, AvgRTT, MaxRTT and MdevRTT describe the distribution of the
+	// round trip times. They are zero if no reply was received.
+	MinRTT  time.Duration
+	AvgRTT  time.Duration
+	MaxRTT  time.Duration
+	MdevRTT time.Duration
+}
+
+// ProbePath sends SCMP echo requests to the target over the configured path.
+// It blocks until all requests are sent and answered or timed out, or until
+// the context is canceled.
+func ProbePath(ctx context.Context, cfg ProbeConfig) (*ProbeResult, error) {
+	local, remote, err := cfg.addresses()
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := timeoutOrDefault(cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	res := &ProbeResult{Local: local}
+	start := time.Now()
+	stats, err := ping.Run(ctx, ping.Config{
+		Dispatcher:  cfg.Dispatcher,
+		Local:       local,
+		Remote:      remote,
+		Attempts:    cfg.Count,
+		Interval:    cfg.Interval,
+		Timeout:     timeout,
+		PayloadSize: cfg.PayloadSize,
+		ErrHandler:  cfg.OnError,
+		UpdateHandler: func(u ping.Update) {
+			r := Reply{
+				Sequence: u.Sequence,
+				Size:     u.Size,
+				Source:   u.Source,
+				RTT:      u.RTT,
+				State:    u.State,
+			}
+			res.Replies = append(res.Replies, r)
+			if cfg.OnReply != nil {
+				cfg.OnReply(r)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	rtts := make([]time.Duration, 0, len(res.Replies))
+	for _, r := range res.Replies {
+		rtts = append(rtts, r.RTT)
+	}
+	res.Stats = ComputeStats(stats.Sent, stats.Received, rtts)
+	res.Stats.Time = time.Since(start)
+	return res, nil
+}
+
+// ComputeStats computes the loss and the round trip time statistics.
+func ComputeStats(sent, received int, rtts []time.Duration) Stats {
+	s := Stats{Sent: sent, Received: received}
+	if sent != 0 {
+		s.Loss = 100 - received*100/sent
+	}
+	if len(rtts) == 0 {
+		return s
+	}
+	s.MinRTT, s.MaxRTT = rtts[0], rtts[0]
+	var sum time.Duration
+	for _, rtt := range rtts {
+		if rtt < s.MinRTT {
+			s.MinRTT = rtt
+		}
+		if rtt > s.MaxRTT {
+			s.MaxRTT = rtt
+		}
+		sum += rtt
+	}
+	s.AvgRTT = sum / time.Duration(len(rtts))
+	var sd float64
+	for _, rtt := range rtts {
+		sd += math.Pow(float64(rtt-s.AvgRTT), 2)
+	}
+	s.MdevRTT = time.Duration(math.Sqrt(sd / float64(len(rtts))))
+	return s
+}
+
+// TraceConfig configures TracePath.
+type TraceConfig struct {
+	Target
+	// ProbesPerHop is the number of probes sent to every router. If zero, 3
+	// probes are sent.
+	ProbesPerHop int
+	// Interval is the minimum time between two consecutive probes of the same
+	// router. If zero, the next probe is sent as soon as the previous one was
+	// answered or timed out.
+	Interval time.Duration
+	// Timeout is the time after which a probe is considered lost. If zero,
+	// DefaultTimeout is used.
+	Timeout time.Duration
+	// EPIC indicates whether the EPIC path type is used.
+	EPIC bool
+	// OnHop, if set, is called synchronously for every probed hop.
+	OnHop func(Hop)
+	// OnError, if set, is called synchronously for every error that does not
+	// abort tracing.
+	OnError func(error)
+}
+
+// Hop is the result of probing a single router on the path.
+type Hop struct {
+	// Index is the index of the hop on the path.
+	Index int
+	// Remote is the address of the router. It is empty if no probe was
+	// answered.
+	Remote snet.SCIONAddress
+	// Interface is the ID of the probed interface.
+	Interface uint64
+	// Egress indicates whether the probed interface is the egress interface
+	// of the router in the direction of the path. Otherwise, it is the ingress
+	// interface.
+	Egress bool
+	// RTTs are the round trip times of the probes, in the order in which the
+	// probes were sent. The RTT of a probe that was not answered in time
+	// exceeds the timeout.
+	RTTs []time.Duration
+	// Lost is the number of probes that were not answered in time.
+	Lost int
+}
+
+// TraceResult is the result of TracePath.
+type TraceResult struct {
+	// Local is the local address the probes were sent from.
+	Local *snet.UDPAddr
+	// Hops are the probed hops, in path order.
+	Hops []Hop
+	// Sent is the number of sent probes.
+	Sent int
+	// Received is the number of received replies.
+	Received int
+}
+
+// TracePath sends SCMP traceroute requests to every router on the configured
+// path. It blocks until all routers were probed, or until the context is
+// canceled.
+func TracePath(ctx context.Context, cfg TraceConfig) (*TraceResult, error) {
+	local, remote, err := cfg.addresses()
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := timeoutOrDefault(cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	probes := cfg.ProbesPerHop
+	if probes == 0 {
+		probes = 3
+	}
+	res := &TraceResult{Local: local}
+	stats, err := traceroute.Run(ctx, traceroute.Config{
+		Dispatcher:   cfg.Dispatcher,
+		Local:        local,
+		Remote:       remote,
+		PathEntry:    cfg.Path,
+		Timeout:      timeout,
+		EPIC:         cfg.EPIC,
+		ProbesPerHop: probes,
+		Interval:     cfg.Interval,
+		ErrHandler:   cfg.OnError,
+		UpdateHandler: func(u traceroute.Update) {
+			h := Hop{
+				Index:     u.Index,
+				Remote:    u.Remote,
+				Interface: u.Interface,
+				Egress:    u.Egress,
+				RTTs:      u.RTTs,
+			}
+			for _, rtt := range u.RTTs {
+				if rtt > timeout {
+					h.Lost++
+				}
+			}
+			res.Hops = append(res.Hops, h)
+			if cfg.OnHop != nil {
+				cfg.OnHop(h)
+			}
+		},
+	})
+	res.Sent, res.Received = int(stats.Sent), int(stats.Recv)
+	return res, err
+}
+
+func timeoutOrDefault(timeout time.Duration) (time.Duration, error) {
+	switch {
+	case timeout < 0:
+		return 0, serrors.New("negative timeout", "timeout", timeout)
+	case timeout == 0:
+		return DefaultTimeout, nil
+	default:
+		return timeout, nil
+	}
+}
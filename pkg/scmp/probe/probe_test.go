@@ -0,0 +1,91 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/scmp/probe"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestComputeStats(t *testing.T) {
+	testCases := map[string]struct {
+		sent, received int
+		rtts           []time.Duration
+		expected       probe.Stats
+	}{
+		"nothing sent": {},
+		"nothing received": {
+			sent:     4,
+			expected: probe.Stats{Sent: 4, Loss: 100},
+		},
+		"partial loss": {
+			sent:     4,
+			received: 3,
+			rtts:     []time.Duration{2 * time.Millisecond, 4 * time.Millisecond, 6 * time.Millisecond},
+			expected: probe.Stats{
+				Sent:     4,
+				Received: 3,
+				Loss:     25,
+				MinRTT:   2 * time.Millisecond,
+				AvgRTT:   4 * time.Millisecond,
+				MaxRTT:   6 * time.Millisecond,
+				MdevRTT:  1632993,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, probe.ComputeStats(tc.sent, tc.received, tc.rtts))
+		})
+	}
+}
+
+func TestProbePathInvalidConfig(t *testing.T) {
+	_, err := probe.ProbePath(context.Background(), probe.ProbeConfig{})
+	assert.Error(t, err)
+	_, err = probe.TracePath(context.Background(), probe.TraceConfig{})
+	assert.Error(t, err)
+
+	target := probe.Target{
+		LocalIA: xtest.MustParseIA("1-ff00:0:110"),
+		LocalIP: net.IP{127, 0, 0, 1},
+		Remote: &snet.UDPAddr{
+			IA:   xtest.MustParseIA("1-ff00:0:110"),
+			Host: &net.UDPAddr{IP: net.IP{127, 0, 0, 2}},
+		},
+		Path: snetpath.Path{DataplanePath: snetpath.Empty{}},
+	}
+	_, err = probe.ProbePath(context.Background(), probe.ProbeConfig{
+		Target:  target,
+		Timeout: -time.Second,
+	})
+	assert.Error(t, err)
+	_, err = probe.TracePath(context.Background(), probe.TraceConfig{
+		Target:  target,
+		Timeout: -time.Second,
+	})
+	assert.Error(t, err)
+}
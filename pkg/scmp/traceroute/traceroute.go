@@ -40,6 +40,10 @@ type Update struct {
 	Remote snet.SCIONAddress
 	// Interface is the interface ID of the remote router.
 	Interface uint64
+	// Egress indicates whether the probed interface is the egress interface
+	// of the hop in the direction of the path. Otherwise, it is the ingress
+	// interface.
+	Egress bool
 	// RTTs are the RTTs for this hop. To detect whether there was a timeout the
 	// value of the RTT can be compared against the timeout value from the
 	// configuration.
@@ -68,6 +72,10 @@ type Config struct {
 
 	// ProbesPerHop indicates how many probes should be done per hop.
 	ProbesPerHop int
+	// Interval is the minimum time between two consecutive probes of the same
+	// hop. If zero, the next probe is sent as soon as the previous one was
+	// answered or timed out.
+	Interval time.Duration
 	// ErrHandler is invoked for every error that does not cause tracerouting to
 	// abort. Execution time must be small, as it is run synchronously.
 	ErrHandler func(error)
@@ -78,6 +86,7 @@ type Config struct {
 
 type tracerouter struct {
 	probesPerHop  int
+	interval      time.Duration
 	timeout       time.Duration
 	conn          snet.PacketConn
 	local         *snet.UDPAddr
@@ -114,6 +123,7 @@ func Run(ctx context.Context, cfg Config) (Stats, error) {
 	local.Host.Port = int(port)
 	t := tracerouter{
 		probesPerHop:  cfg.ProbesPerHop,
+		interval:      cfg.Interval,
 		timeout:       cfg.Timeout,
 		conn:          conn,
 		local:         local,
@@ -176,6 +186,7 @@ func (t *tracerouter) Traceroute(ctx context.Context) (Stats, error) {
 			if err != nil {
 				return t.stats, serrors.WrapStr("probing hop", err, "hop_index", i)
 			}
+			u.Egress = true
 			if t.updateHandler != nil && !u.empty() {
 				t.updateHandler(u)
 			}
@@ -250,8 +261,16 @@ func (t *tracerouter) probeHop(ctx context.Context, hfIdx uint8, egress bool) (U
 			Payload: snet.SCMPTracerouteRequest{Identifier: t.id},
 		},
 	}
+	var sendTs time.Time
 	for i := 0; i < t.probesPerHop; i++ {
-		sendTs := time.Now()
+		if i != 0 && t.interval > 0 {
+			select {
+			case <-time.After(time.Until(sendTs.Add(t.interval))):
+			case <-ctx.Done():
+				return u, nil
+			}
+		}
+		sendTs = time.Now()
 		t.stats.Sent++
 		if err := t.conn.WriteTo(pkt, t.remote.NextHop); err != nil {
 			return u, serrors.WrapStr("writing", err)
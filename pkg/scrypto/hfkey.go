@@ -0,0 +1,201 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scrypto
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// HFKeyOverlap is the time after the start of a key epoch during which
+	// the hop field MAC key of the previous epoch is still accepted. It is the
+	// maximum lifetime of a hop field, such that all hop fields issued in the
+	// previous epoch expire before their key is dropped.
+	HFKeyOverlap = 24 * time.Hour
+	// HFKeyRolloverLead is the time before the start of a key epoch at which
+	// the hop field MAC key of that epoch is already accepted. It accounts for
+	// clock skew between the control service and the routers.
+	HFKeyRolloverLead = time.Minute
+	// MinHFKeyRotationPeriod is the minimum hop field MAC key rotation period.
+	// The key phase only distinguishes two consecutive epochs, thus the key of
+	// the next epoch can only be accepted once the overlap of the previous
+	// epoch has passed.
+	MinHFKeyRotationPeriod = HFKeyOverlap + HFKeyRolloverLead
+)
+
+// HFKeySchedule derives the rotating hop field MAC keys of an AS from the AS
+// master key. Time is divided into key epochs of length Period, counted from
+// the Unix epoch. Hop fields carry the parity of the epoch whose key
+// authenticates them as key phase.
+//
+// The control service issues hop fields with the key of the current epoch.
+// Routers accept the keys of the current and the previous epoch during the
+// overlap window, such that rotating the key does not break paths that are
+// in use.
+type HFKeySchedule struct {
+	// Master is the AS master key the hop field MAC keys are derived from.
+	Master []byte
+	// Period is the key rotation period.
+	Period time.Duration
+	// Algorithms selects the MAC algorithm of each key epoch.
+	Algorithms HFMACAlgorithms
+}
+
+// HFMACAlgorithms selects the hop field MAC algorithm per key epoch. The key
+// epochs before Epoch use Previous, the others use Current. This allows to
+// switch the algorithm of an AS at a key epoch boundary, while the hop fields
+// issued in the previous epoch remain valid during the overlap window.
+type HFMACAlgorithms struct {
+	// Current is the algorithm of the key epochs starting at Epoch.
+	Current MACAlgorithm
+	// Previous is the algorithm of the key epochs before Epoch.
+	Previous MACAlgorithm
+	// Epoch is the first key epoch that uses Current. If zero, all key epochs
+	// use Current.
+	Epoch uint64
+}
+
+// Validate validates the algorithms.
+func (a HFMACAlgorithms) Validate() error {
+	if err := a.Current.Validate(); err != nil {
+		return err
+	}
+	return a.Previous.Validate()
+}
+
+// At returns the MAC algorithm of the key epoch.
+func (a HFMACAlgorithms) At(epoch uint64) MACAlgorithm {
+	if epoch < a.Epoch {
+		return a.Previous
+	}
+	return a.Current
+}
+
+// Validate validates the key schedule.
+func (s HFKeySchedule) Validate() error {
+	if len(s.Master) == 0 {
+		return serrors.New("master key not set")
+	}
+	if s.Period < MinHFKeyRotationPeriod {
+		return serrors.New("rotation period too short", "period", s.Period,
+			"min", MinHFKeyRotationPeriod)
+	}
+	return s.Algorithms.Validate()
+}
+
+// Epoch returns the key epoch at time t.
+func (s HFKeySchedule) Epoch(t time.Time) uint64 {
+	return uint64(t.Unix()) / uint64(s.Period/time.Second)
+}
+
+// EpochStart returns the start time of the key epoch.
+func (s HFKeySchedule) EpochStart(epoch uint64) time.Time {
+	return time.Unix(int64(epoch*uint64(s.Period/time.Second)), 0)
+}
+
+// Key returns the hop field MAC key of the key epoch.
+func (s HFKeySchedule) Key(epoch uint64) []byte {
+	salt := make([]byte, len(hfMacSalt)+8)
+	copy(salt, hfMacSalt)
+	binary.BigEndian.PutUint64(salt[len(hfMacSalt):], epoch)
+	return pbkdf2.Key(s.Master, salt, 1000, 16, sha256.New)
+}
+
+// VerificationKeys returns the hop field MAC keys that are accepted at time t,
+// indexed by key phase. The key of the current epoch is always accepted. The
+// other entry holds the key of the next epoch, if it starts within
+// HFKeyRolloverLead, or the key of the previous epoch, if the current epoch
+// started less than HFKeyOverlap ago. Otherwise, it is nil.
+func (s HFKeySchedule) VerificationKeys(t time.Time) [2][]byte {
+	var keys [2][]byte
+	for i, epoch := range s.verificationEpochs(t) {
+		if epoch != nil {
+			keys[i] = s.Key(*epoch)
+		}
+	}
+	return keys
+}
+
+// VerificationAlgorithms returns the MAC algorithms of the keys returned by
+// VerificationKeys, indexed by key phase. The entry of a nil key is empty.
+func (s HFKeySchedule) VerificationAlgorithms(t time.Time) [2]MACAlgorithm {
+	var algs [2]MACAlgorithm
+	for i, epoch := range s.verificationEpochs(t) {
+		if epoch != nil {
+			algs[i] = s.Algorithms.At(*epoch)
+		}
+	}
+	return algs
+}
+
+// verificationEpochs returns the key epochs whose keys are accepted at time t,
+// indexed by key phase.
+func (s HFKeySchedule) verificationEpochs(t time.Time) [2]*uint64 {
+	var epochs [2]*uint64
+	epoch := s.Epoch(t)
+	epochs[KeyPhaseIndex(epoch)] = &epoch
+	switch {
+	case !t.Add(HFKeyRolloverLead).Before(s.EpochStart(epoch + 1)):
+		next := epoch + 1
+		epochs[KeyPhaseIndex(next)] = &next
+	case epoch > 0 && t.Before(s.EpochStart(epoch).Add(HFKeyOverlap)):
+		prev := epoch - 1
+		epochs[KeyPhaseIndex(prev)] = &prev
+	}
+	return epochs
+}
+
+// MACGen returns a generator for the MACs to issue hop fields with. The
+// generator returns a MAC that uses the key and algorithm of the current epoch
+// together with the key phase of that epoch.
+func (s HFKeySchedule) MACGen() func() (hash.Hash, bool) {
+	var mtx sync.Mutex
+	var cachedEpoch uint64
+	var cachedKey []byte
+	return func() (hash.Hash, bool) {
+		epoch := s.Epoch(time.Now())
+		mtx.Lock()
+		if cachedKey == nil || cachedEpoch != epoch {
+			cachedEpoch, cachedKey = epoch, s.Key(epoch)
+		}
+		key := cachedKey
+		mtx.Unlock()
+		mac, err := NewMAC(s.Algorithms.At(epoch), key)
+		if err != nil {
+			// This can only happen if the library is messed up badly.
+			panic(err)
+		}
+		return mac, KeyPhase(epoch)
+	}
+}
+
+// KeyPhase returns the key phase of the key epoch.
+func KeyPhase(epoch uint64) bool {
+	return epoch%2 == 1
+}
+
+// KeyPhaseIndex returns the index of the key phase of the key epoch, i.e., 1
+// if the key phase is set and 0 otherwise.
+func KeyPhaseIndex(epoch uint64) int {
+	return int(epoch % 2)
+}
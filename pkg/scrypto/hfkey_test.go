@@ -0,0 +1,126 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scrypto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/scrypto"
+)
+
+func TestHFKeyScheduleValidate(t *testing.T) {
+	master := []byte("0123456789abcdef")
+	assert.NoError(t, scrypto.HFKeySchedule{
+		Master: master,
+		Period: scrypto.MinHFKeyRotationPeriod,
+	}.Validate())
+	assert.Error(t, scrypto.HFKeySchedule{Period: 48 * time.Hour}.Validate())
+	assert.Error(t, scrypto.HFKeySchedule{Master: master, Period: 24 * time.Hour}.Validate())
+	assert.Error(t, scrypto.HFKeySchedule{
+		Master:     master,
+		Period:     48 * time.Hour,
+		Algorithms: scrypto.HFMACAlgorithms{Current: "md5"},
+	}.Validate())
+}
+
+func TestHFKeyScheduleVerificationKeys(t *testing.T) {
+	s := scrypto.HFKeySchedule{
+		Master: []byte("0123456789abcdef"),
+		Period: 48 * time.Hour,
+	}
+	const epoch = 1001
+	start := s.EpochStart(epoch)
+	require.Equal(t, uint64(epoch), s.Epoch(start))
+	require.Equal(t, uint64(epoch-1), s.Epoch(start.Add(-time.Second)))
+	assert.NotEqual(t, s.Key(epoch), s.Key(epoch+1))
+
+	testCases := map[string]struct {
+		time time.Time
+		keys [2][]byte
+	}{
+		"epoch start": {
+			time: start,
+			keys: [2][]byte{s.Key(epoch - 1), s.Key(epoch)},
+		},
+		"overlap end": {
+			time: start.Add(scrypto.HFKeyOverlap - time.Second),
+			keys: [2][]byte{s.Key(epoch - 1), s.Key(epoch)},
+		},
+		"after overlap": {
+			time: start.Add(scrypto.HFKeyOverlap),
+			keys: [2][]byte{nil, s.Key(epoch)},
+		},
+		"rollover lead": {
+			time: s.EpochStart(epoch + 1).Add(-scrypto.HFKeyRolloverLead),
+			keys: [2][]byte{s.Key(epoch + 1), s.Key(epoch)},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.keys, s.VerificationKeys(tc.time))
+		})
+	}
+}
+
+func TestHFKeyScheduleMACGen(t *testing.T) {
+	s := scrypto.HFKeySchedule{
+		Master: []byte("0123456789abcdef"),
+		Period: 48 * time.Hour,
+	}
+	mac, phase := s.MACGen()()
+	epoch := s.Epoch(time.Now())
+	assert.Equal(t, scrypto.KeyPhase(epoch), phase)
+
+	expected, err := scrypto.InitMac(s.Key(epoch))
+	require.NoError(t, err)
+	input := []byte("hop field mac input")
+	mac.Write(input)
+	expected.Write(input)
+	assert.Equal(t, expected.Sum(nil), mac.Sum(nil))
+}
+
+func TestHFKeyScheduleAlgorithms(t *testing.T) {
+	now := time.Now()
+	s := scrypto.HFKeySchedule{
+		Master: []byte("0123456789abcdef"),
+		Period: 48 * time.Hour,
+	}
+	epoch := s.Epoch(now)
+	s.Algorithms = scrypto.HFMACAlgorithms{
+		Current:  scrypto.MACAlgorithmHMACSHA256,
+		Previous: scrypto.MACAlgorithmAESCMAC,
+		Epoch:    epoch,
+	}
+	assert.Equal(t, scrypto.MACAlgorithmAESCMAC, s.Algorithms.At(epoch-1))
+	assert.Equal(t, scrypto.MACAlgorithmHMACSHA256, s.Algorithms.At(epoch))
+
+	start := s.EpochStart(epoch)
+	var expected [2]scrypto.MACAlgorithm
+	expected[scrypto.KeyPhaseIndex(epoch)] = scrypto.MACAlgorithmHMACSHA256
+	expected[scrypto.KeyPhaseIndex(epoch-1)] = scrypto.MACAlgorithmAESCMAC
+	assert.Equal(t, expected, s.VerificationAlgorithms(start))
+
+	mac, _ := s.MACGen()()
+	expectedMAC, err := scrypto.NewMAC(scrypto.MACAlgorithmHMACSHA256, s.Key(epoch))
+	require.NoError(t, err)
+	input := []byte("hop field mac input")
+	mac.Write(input)
+	expectedMAC.Write(input)
+	assert.Equal(t, expectedMAC.Sum(nil), mac.Sum(nil))
+}
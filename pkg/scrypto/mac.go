@@ -16,6 +16,7 @@ package scrypto
 
 import (
 	"crypto/aes"
+	"crypto/hmac"
 	"crypto/sha256"
 	"hash"
 
@@ -35,6 +36,46 @@ var (
 	hfMacSalt = []byte("Derive OF Key")
 )
 
+// MACAlgorithm is the algorithm used to compute hop field MACs. All routers and
+// control services of an AS must use the same algorithm.
+type MACAlgorithm string
+
+const (
+	// MACAlgorithmAESCMAC computes hop field MACs with AES-CMAC. It is the
+	// default algorithm.
+	MACAlgorithmAESCMAC MACAlgorithm = "aes-cmac"
+	// MACAlgorithmHMACSHA256 computes hop field MACs with HMAC-SHA256. It is
+	// a fallback for hardware without AES acceleration.
+	MACAlgorithmHMACSHA256 MACAlgorithm = "hmac-sha256"
+)
+
+// Validate checks that the algorithm is known. The empty algorithm stands for
+// the default algorithm.
+func (a MACAlgorithm) Validate() error {
+	switch a {
+	case "", MACAlgorithmAESCMAC, MACAlgorithmHMACSHA256:
+		return nil
+	default:
+		return serrors.New("unknown MAC algorithm", "algorithm", string(a))
+	}
+}
+
+// NewMAC creates the MAC of the algorithm with the given key. The empty
+// algorithm stands for the default algorithm.
+func NewMAC(alg MACAlgorithm, key []byte) (hash.Hash, error) {
+	switch alg {
+	case "", MACAlgorithmAESCMAC:
+		return InitMac(key)
+	case MACAlgorithmHMACSHA256:
+		if len(key) == 0 {
+			return nil, serrors.New("empty key")
+		}
+		return hmac.New(sha256.New, key), nil
+	default:
+		return nil, serrors.New("unknown MAC algorithm", "algorithm", string(alg))
+	}
+}
+
 func InitMac(key []byte) (hash.Hash, error) {
 	block, err := aes.NewCipher(key)
 	if err != nil {
@@ -48,17 +89,23 @@ func InitMac(key []byte) (hash.Hash, error) {
 }
 
 func HFMacFactory(key []byte) (func() hash.Hash, error) {
+	return HFMacFactoryWithAlgorithm(MACAlgorithmAESCMAC, key)
+}
+
+// HFMacFactoryWithAlgorithm is like HFMacFactory, but the MACs use the given
+// algorithm.
+func HFMacFactoryWithAlgorithm(alg MACAlgorithm, key []byte) (func() hash.Hash, error) {
 	// Generate keys
 	// This uses 16B keys with 1000 hash iterations, which is the same as the
 	// defaults used by pycrypto.
 	hfGenKey := pbkdf2.Key(key, hfMacSalt, 1000, 16, sha256.New)
 
 	// First check for MAC creation errors.
-	if _, err := InitMac(hfGenKey); err != nil {
+	if _, err := NewMAC(alg, hfGenKey); err != nil {
 		return nil, err
 	}
 	f := func() hash.Hash {
-		mac, _ := InitMac(hfGenKey)
+		mac, _ := NewMAC(alg, hfGenKey)
 		return mac
 	}
 	return f, nil
@@ -0,0 +1,59 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scrypto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/scrypto"
+)
+
+func TestNewMAC(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	input := []byte("hop field mac input")
+	sums := make(map[string]bool)
+	for _, alg := range []scrypto.MACAlgorithm{
+		scrypto.MACAlgorithmAESCMAC,
+		scrypto.MACAlgorithmHMACSHA256,
+	} {
+		t.Run(string(alg), func(t *testing.T) {
+			require.NoError(t, alg.Validate())
+			mac, err := scrypto.NewMAC(alg, key)
+			require.NoError(t, err)
+			mac.Write(input)
+			sum := mac.Sum(nil)
+			assert.GreaterOrEqual(t, len(sum), 16)
+			sums[string(sum)] = true
+		})
+	}
+	assert.Len(t, sums, 2)
+
+	mac, err := scrypto.NewMAC("", key)
+	require.NoError(t, err)
+	expected, err := scrypto.InitMac(key)
+	require.NoError(t, err)
+	mac.Write(input)
+	expected.Write(input)
+	assert.Equal(t, expected.Sum(nil), mac.Sum(nil))
+
+	assert.Error(t, scrypto.MACAlgorithm("md5").Validate())
+	_, err = scrypto.NewMAC("md5", key)
+	assert.Error(t, err)
+	_, err = scrypto.NewMAC(scrypto.MACAlgorithmHMACSHA256, nil)
+	assert.Error(t, err)
+}
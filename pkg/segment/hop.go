@@ -98,6 +98,7 @@ type HopField struct {
 	ConsIngress uint16
 	ConsEgress  uint16
 	MAC         [path.MacLen]byte
+	KeyPhase    bool
 }
 
 func hopFieldFromPB(pb *cppb.HopField) (HopField, error) {
@@ -120,5 +121,6 @@ func hopFieldFromPB(pb *cppb.HopField) (HopField, error) {
 		ConsIngress: uint16(pb.Ingress),
 		ConsEgress:  uint16(pb.Egress),
 		MAC:         m,
+		KeyPhase:    pb.KeyPhase,
 	}, nil
 }
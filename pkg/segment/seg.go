@@ -266,10 +266,11 @@ func (ps *PathSegment) AddASEntry(ctx context.Context, asEntry ASEntry, signer S
 		HopEntry: &cppb.HopEntry{
 			IngressMtu: uint32(asEntry.HopEntry.IngressMTU),
 			HopField: &cppb.HopField{
-				ExpTime: uint32(asEntry.HopEntry.HopField.ExpTime),
-				Ingress: uint64(asEntry.HopEntry.HopField.ConsIngress),
-				Egress:  uint64(asEntry.HopEntry.HopField.ConsEgress),
-				Mac:     asEntry.HopEntry.HopField.MAC[:],
+				ExpTime:  uint32(asEntry.HopEntry.HopField.ExpTime),
+				Ingress:  uint64(asEntry.HopEntry.HopField.ConsIngress),
+				Egress:   uint64(asEntry.HopEntry.HopField.ConsEgress),
+				Mac:      asEntry.HopEntry.HopField.MAC[:],
+				KeyPhase: asEntry.HopEntry.HopField.KeyPhase,
 			},
 		},
 		PeerEntries: make([]*cppb.PeerEntry, 0, len(asEntry.PeerEntries)),
@@ -283,10 +284,11 @@ func (ps *PathSegment) AddASEntry(ctx context.Context, asEntry ASEntry, signer S
 				PeerInterface: uint64(peer.PeerInterface),
 				PeerMtu:       uint32(peer.PeerMTU),
 				HopField: &cppb.HopField{
-					ExpTime: uint32(peer.HopField.ExpTime),
-					Ingress: uint64(peer.HopField.ConsIngress),
-					Egress:  uint64(peer.HopField.ConsEgress),
-					Mac:     peer.HopField.MAC[:],
+					ExpTime:  uint32(peer.HopField.ExpTime),
+					Ingress:  uint64(peer.HopField.ConsIngress),
+					Egress:   uint64(peer.HopField.ConsEgress),
+					Mac:      peer.HopField.MAC[:],
+					KeyPhase: peer.HopField.KeyPhase,
 				},
 			},
 		)
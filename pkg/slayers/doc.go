@@ -84,6 +84,17 @@ Packet data can be created by instantiating the various slayers.* types. To gene
 	}
 	packedData := buf.Bytes()
 
+gopacket.NewSerializeBuffer allocates memory that grows with the serialized layers. In the fast
+path of high-throughput applications, a slayers.SerializeBuffer can be used instead. It serializes
+into a caller-provided slice and does not allocate once it has been set up:
+
+	raw := make([]byte, 1500)
+	buf := slayers.NewSerializeBuffer(raw)
+	if err := gopacket.SerializeLayers(buf, opts, s, hbh, udp, &pld); err != nil {
+		// Handle error
+	}
+	packedData := buf.Bytes() // A sub-slice of raw.
+
 BFD and gopacket/layers
 
 slayers does intentionally not import gopacket/layers, as this contains a
@@ -0,0 +1,126 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slayers_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+// The fuzz targets in this file run as regular tests on the seed corpus, i.e.,
+// the packets in the testdata directory, and any crashers recorded in
+// testdata/fuzz. To fuzz, run, e.g.:
+//
+//	go test -run '^$' -fuzz FuzzDecodeSCION ./pkg/slayers
+
+func FuzzDecodePacket(f *testing.F) {
+	for _, pkt := range seedPackets(f) {
+		f.Add(pkt)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pkt := gopacket.NewPacket(data, slayers.LayerTypeSCION, gopacket.DecodeOptions{
+			NoCopy:             true,
+			SkipDecodeRecovery: true,
+		})
+		for _, l := range pkt.Layers() {
+			gopacket.LayerString(l)
+		}
+	})
+}
+
+func FuzzDecodeSCION(f *testing.F) {
+	for _, pkt := range seedPackets(f) {
+		f.Add(pkt)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzLayer(t, &slayers.SCION{}, data)
+	})
+}
+
+func FuzzDecodeSCMP(f *testing.F) {
+	for _, pkt := range seedPackets(f) {
+		packet := gopacket.NewPacket(pkt, slayers.LayerTypeSCION, gopacket.Default)
+		if l := packet.Layer(slayers.LayerTypeSCMP); l != nil {
+			f.Add(append(l.LayerContents(), l.LayerPayload()...))
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var scmp slayers.SCMP
+		if !fuzzLayer(t, &scmp, data) {
+			return
+		}
+		// Decode the message the same way the SCMP decoder would.
+		var msg fuzzableLayer
+		switch scmp.TypeCode.Type() {
+		case slayers.SCMPTypeEchoRequest, slayers.SCMPTypeEchoReply:
+			msg = &slayers.SCMPEcho{}
+		case slayers.SCMPTypeTracerouteRequest, slayers.SCMPTypeTracerouteReply:
+			msg = &slayers.SCMPTraceroute{}
+		case slayers.SCMPTypeExternalInterfaceDown:
+			msg = &slayers.SCMPExternalInterfaceDown{}
+		case slayers.SCMPTypeInternalConnectivityDown:
+			msg = &slayers.SCMPInternalConnectivityDown{}
+		case slayers.SCMPTypeDestinationUnreachable:
+			msg = &slayers.SCMPDestinationUnreachable{}
+		case slayers.SCMPTypePacketTooBig:
+			msg = &slayers.SCMPPacketTooBig{}
+		case slayers.SCMPTypeParameterProblem:
+			msg = &slayers.SCMPParameterProblem{}
+		default:
+			return
+		}
+		fuzzLayer(t, msg, scmp.Payload)
+	})
+}
+
+type fuzzableLayer interface {
+	DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error
+	SerializeTo(b gopacket.SerializeBuffer, opts gopacket.SerializeOptions) error
+}
+
+// fuzzLayer decodes the data into the layer. If the data is decoded
+// successfully, the layer must be serializable. It returns whether the data
+// was decoded.
+func fuzzLayer(t *testing.T, l fuzzableLayer, data []byte) bool {
+	if err := l.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		return false
+	}
+	buf := gopacket.NewSerializeBuffer()
+	if err := l.SerializeTo(buf, gopacket.SerializeOptions{FixLengths: true}); err != nil {
+		t.Fatalf("serializing decoded layer: %v", err)
+	}
+	return true
+}
+
+func seedPackets(f *testing.F) [][]byte {
+	files, err := filepath.Glob(filepath.Join(goldenDir, "*.bin"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	var pkts [][]byte
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		pkts = append(pkts, raw)
+	}
+	return pkts
+}
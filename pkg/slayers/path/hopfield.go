@@ -42,7 +42,7 @@ const expTimeUnit = MaxTTL / 256 // ~5m38.5s
 //	 0                   1                   2                   3
 //	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
 //	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-//	|r r r r r r I E|    ExpTime    |           ConsIngress         |
+//	|r r r r r K I E|    ExpTime    |           ConsIngress         |
 //	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 //	|        ConsEgress             |                               |
 //	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+                               +
@@ -55,6 +55,10 @@ type HopField struct {
 	// EgressRouterAlert flag. If the EgressRouterAlert is set, the egress router (in
 	// construction direction) will process the L4 payload in the packet.
 	EgressRouterAlert bool
+	// KeyPhase selects which of the two hop field MAC keys of the AS
+	// authenticates the HopField. It allows an AS to roll over its key while
+	// the HopFields authenticated with the previous key are still in use.
+	KeyPhase bool
 	// Exptime is the expiry time of a HopField. The field is 1-byte long, thus there are 256
 	// different values available to express an expiration time. The expiration time expressed by
 	// the value of this field is relative, and an absolute expiration time in seconds is computed
@@ -86,6 +90,7 @@ func (h *HopField) DecodeFromBytes(raw []byte) (err error) {
 	}
 	h.EgressRouterAlert = raw[0]&0x1 == 0x1
 	h.IngressRouterAlert = raw[0]&0x2 == 0x2
+	h.KeyPhase = raw[0]&0x4 == 0x4
 	h.ExpTime = raw[1]
 	//@ assert &raw[2:4][0] == &raw[2] && &raw[2:4][1] == &raw[3]
 	h.ConsIngress = binary.BigEndian.Uint16(raw[2:4])
@@ -120,6 +125,9 @@ func (h *HopField) SerializeTo(b []byte) (err error) {
 	if h.IngressRouterAlert {
 		b[0] |= 0x2
 	}
+	if h.KeyPhase {
+		b[0] |= 0x4
+	}
 	b[1] = h.ExpTime
 	//@ assert &b[2:4][0] == &b[2] && &b[2:4][1] == &b[3]
 	binary.BigEndian.PutUint16(b[2:4], h.ConsIngress)
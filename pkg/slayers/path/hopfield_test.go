@@ -27,6 +27,7 @@ func TestHopSerializeDecode(t *testing.T) {
 	want := &path.HopField{
 		IngressRouterAlert: true,
 		EgressRouterAlert:  true,
+		KeyPhase:           true,
 		ExpTime:            63,
 		ConsIngress:        1,
 		ConsEgress:         0,
@@ -138,6 +138,7 @@ func TestSCIONLayerString(t *testing.T) {
 				`}] HopFields=[{` +
 				`IngressRouterAlert=true ` +
 				`EgressRouterAlert=false ` +
+				`KeyPhase=false ` +
 				`ExpTime=63 ` +
 				`ConsIngress=4 ` +
 				`ConsEgress=5 ` +
@@ -175,6 +176,7 @@ func TestSCIONLayerString(t *testing.T) {
 				`} FirstHop={ ` +
 				`IngressRouterAlert=false ` +
 				`EgressRouterAlert=false ` +
+				`KeyPhase=false ` +
 				`ExpTime=63 ` +
 				`ConsIngress=5 ` +
 				`ConsEgress=6 ` +
@@ -182,6 +184,7 @@ func TestSCIONLayerString(t *testing.T) {
 				`} SecondHop={ ` +
 				`IngressRouterAlert=false ` +
 				`EgressRouterAlert=false ` +
+				`KeyPhase=false ` +
 				`ExpTime=63 ` +
 				`ConsIngress=2 ` +
 				`ConsEgress=3 ` +
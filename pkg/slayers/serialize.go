@@ -0,0 +1,132 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slayers
+
+import (
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+var _ gopacket.SerializeBuffer = (*SerializeBuffer)(nil)
+
+// SerializeBuffer is a gopacket.SerializeBuffer that serializes into a
+// caller-provided byte slice instead of allocating its own memory. Once the
+// buffer has been set up, serializing a packet does not allocate, which makes
+// it suitable for the per-packet fast path of high-throughput applications.
+//
+// Since gopacket serializes the layers from the innermost to the outermost
+// one, prepended bytes are placed in front of the end of the provided slice,
+// i.e., in front of len(raw). Appended bytes are placed in the spare capacity
+// after len(raw). The serialized data returned by Bytes is thus a sub-slice
+// of the provided slice that does not necessarily start at index 0.
+//
+// If the provided slice is too small, PrependBytes and AppendBytes return an
+// error instead of growing the buffer.
+//
+// Note that converting a gopacket.Payload to a gopacket.SerializableLayer
+// allocates. To serialize without allocations, pass a pointer to the payload
+// instead.
+//
+// Usage:
+//
+//	raw := make([]byte, common.SupportedMTU)
+//	var buf slayers.SerializeBuffer
+//	for {
+//		buf.Reset(raw)
+//		if err := gopacket.SerializeLayers(&buf, opts, &scn, &udp, &pld); err != nil {
+//			// Handle error
+//		}
+//		send(buf.Bytes())
+//	}
+type SerializeBuffer struct {
+	data []byte
+	// origin is the position in data at which serialization starts, i.e.,
+	// the length of the slice that was provided by the caller.
+	origin int
+	start  int
+	end    int
+	layers []gopacket.LayerType
+}
+
+// NewSerializeBuffer creates a new buffer that serializes into raw.
+func NewSerializeBuffer(raw []byte) *SerializeBuffer {
+	b := &SerializeBuffer{}
+	b.Reset(raw)
+	return b
+}
+
+// Reset sets the slice the buffer serializes into and clears the buffer. The
+// recorded layer types are cleared as well, but the memory backing them is
+// reused.
+func (b *SerializeBuffer) Reset(raw []byte) {
+	b.data = raw[:cap(raw)]
+	b.origin = len(raw)
+	_ = b.Clear()
+}
+
+// Bytes returns the serialized data. The returned slice aliases the slice
+// that was passed to NewSerializeBuffer or Reset.
+func (b *SerializeBuffer) Bytes() []byte {
+	return b.data[b.start:b.end]
+}
+
+// PrependBytes returns a slice of num bytes that is placed in front of the
+// currently serialized data. The content of the returned slice is undefined.
+func (b *SerializeBuffer) PrependBytes(num int) ([]byte, error) {
+	if num < 0 {
+		return nil, serrors.New("invalid number of bytes", "num", num)
+	}
+	if num > b.start {
+		return nil, serrors.New("buffer too small to prepend",
+			"requested", num, "available", b.start)
+	}
+	b.start -= num
+	return b.data[b.start : b.start+num], nil
+}
+
+// AppendBytes returns a slice of num bytes that is placed after the currently
+// serialized data. The content of the returned slice is undefined.
+func (b *SerializeBuffer) AppendBytes(num int) ([]byte, error) {
+	if num < 0 {
+		return nil, serrors.New("invalid number of bytes", "num", num)
+	}
+	if available := len(b.data) - b.end; num > available {
+		return nil, serrors.New("buffer too small to append",
+			"requested", num, "available", available)
+	}
+	b.end += num
+	return b.data[b.end-num : b.end], nil
+}
+
+// Clear resets the buffer to its initial state, such that it can be reused
+// to serialize another packet into the same slice.
+func (b *SerializeBuffer) Clear() error {
+	b.start = b.origin
+	b.end = b.origin
+	b.layers = b.layers[:0]
+	return nil
+}
+
+// Layers returns the types of the layers that have been serialized into the
+// buffer.
+func (b *SerializeBuffer) Layers() []gopacket.LayerType {
+	return b.layers
+}
+
+// PushLayer records that a layer of the given type has been serialized.
+func (b *SerializeBuffer) PushLayer(l gopacket.LayerType) {
+	b.layers = append(b.layers, l)
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slayers_test
+
+import (
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+func TestSerializeBuffer(t *testing.T) {
+	t.Run("prepend and append", func(t *testing.T) {
+		raw := make([]byte, 4, 6)
+		b := slayers.NewSerializeBuffer(raw)
+		assert.Empty(t, b.Bytes())
+
+		buf, err := b.PrependBytes(2)
+		require.NoError(t, err)
+		copy(buf, []byte{3, 4})
+		buf, err = b.PrependBytes(2)
+		require.NoError(t, err)
+		copy(buf, []byte{1, 2})
+		buf, err = b.AppendBytes(2)
+		require.NoError(t, err)
+		copy(buf, []byte{5, 6})
+		assert.Equal(t, []byte{1, 2, 3, 4, 5, 6}, b.Bytes())
+
+		_, err = b.PrependBytes(1)
+		assert.Error(t, err)
+		_, err = b.AppendBytes(1)
+		assert.Error(t, err)
+		assert.Equal(t, []byte{1, 2, 3, 4, 5, 6}, b.Bytes())
+
+		require.NoError(t, b.Clear())
+		assert.Empty(t, b.Bytes())
+		_, err = b.PrependBytes(4)
+		assert.NoError(t, err)
+	})
+	t.Run("layers", func(t *testing.T) {
+		b := slayers.NewSerializeBuffer(make([]byte, 8))
+		b.PushLayer(slayers.LayerTypeSCMP)
+		b.PushLayer(slayers.LayerTypeSCION)
+		assert.Equal(t, []gopacket.LayerType{slayers.LayerTypeSCMP, slayers.LayerTypeSCION},
+			b.Layers())
+		b.Reset(make([]byte, 8))
+		assert.Empty(t, b.Layers())
+	})
+	t.Run("too small for packet", func(t *testing.T) {
+		s, u, pld := prepUDPPacket(t)
+		b := slayers.NewSerializeBuffer(make([]byte, 64))
+		opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+		assert.Error(t, gopacket.SerializeLayers(b, opts, s, u, pld))
+	})
+}
+
+func TestSerializeBufferEqualsGopacket(t *testing.T) {
+	testCases := map[string]func(t *testing.T) []gopacket.SerializableLayer{
+		"UDP": func(t *testing.T) []gopacket.SerializableLayer {
+			s, u, pld := prepUDPPacket(t)
+			return []gopacket.SerializableLayer{s, u, pld}
+		},
+		"SCMP": func(t *testing.T) []gopacket.SerializableLayer {
+			s, scmp, msg, pld := prepSCMPPacket(t)
+			return []gopacket.SerializableLayer{s, scmp, msg, pld}
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+			expected := gopacket.NewSerializeBuffer()
+			require.NoError(t, gopacket.SerializeLayers(expected, opts, tc(t)...))
+
+			// Serialize twice to ensure the buffer is properly reused.
+			b := slayers.NewSerializeBuffer(make([]byte, 1500))
+			for i := 0; i < 2; i++ {
+				require.NoError(t, gopacket.SerializeLayers(b, opts, tc(t)...))
+				assert.Equal(t, expected.Bytes(), b.Bytes())
+				assert.Equal(t, expected.Layers(), b.Layers())
+			}
+		})
+	}
+}
+
+func TestSerializeBufferNoAllocs(t *testing.T) {
+	s, scmp, msg, pld := prepSCMPPacket(t)
+	b := slayers.NewSerializeBuffer(make([]byte, 1500))
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := gopacket.SerializeLayers(b, opts, s, scmp, msg, &pld); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Zero(t, allocs)
+}
+
+func BenchmarkSerializeUDPGopacketBuffer(b *testing.B) {
+	s, u, pld := prepUDPPacket(b)
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffer := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(buffer, opts, s, u, &pld); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSerializeUDPSerializeBuffer(b *testing.B) {
+	s, u, pld := prepUDPPacket(b)
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	buffer := slayers.NewSerializeBuffer(make([]byte, 1500))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := gopacket.SerializeLayers(buffer, opts, s, u, &pld); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSerializeSCMPGopacketBuffer(b *testing.B) {
+	s, scmp, msg, pld := prepSCMPPacket(b)
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffer := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(buffer, opts, s, scmp, msg, &pld); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSerializeSCMPSerializeBuffer(b *testing.B) {
+	s, scmp, msg, pld := prepSCMPPacket(b)
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	buffer := slayers.NewSerializeBuffer(make([]byte, 1500))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := gopacket.SerializeLayers(buffer, opts, s, scmp, msg, &pld); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func prepUDPPacket(t testing.TB) (*slayers.SCION, *slayers.UDP, gopacket.Payload) {
+	t.Helper()
+	s := prepPacket(t, slayers.L4UDP)
+	u := &slayers.UDP{SrcPort: 1280, DstPort: 80}
+	u.SetNetworkLayerForChecksum(s)
+	return s, u, gopacket.Payload(mkPayload(1024))
+}
+
+func prepSCMPPacket(t testing.TB) (*slayers.SCION, *slayers.SCMP, *slayers.SCMPPacketTooBig,
+	gopacket.Payload) {
+
+	t.Helper()
+	s := prepPacket(t, slayers.L4SCMP)
+	scmp := &slayers.SCMP{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypePacketTooBig, 0),
+	}
+	scmp.SetNetworkLayerForChecksum(s)
+	msg := &slayers.SCMPPacketTooBig{MTU: 1280}
+	return s, scmp, msg, gopacket.Payload(mkPayload(256))
+}
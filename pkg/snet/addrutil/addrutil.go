@@ -54,6 +54,7 @@ func (p Pather) GetPath(svc addr.SVC, ps *seg.PathSegment) (*snet.SVCAddr, error
 			ConsEgress:  entry.HopEntry.HopField.ConsEgress,
 			ExpTime:     entry.HopEntry.HopField.ExpTime,
 			Mac:         entry.HopEntry.HopField.MAC,
+			KeyPhase:    entry.HopEntry.HopField.KeyPhase,
 		}
 		// the last AS entry is our AS for this we don't need to modify the beta.
 		if i < len(ps.ASEntries)-1 {
@@ -16,6 +16,7 @@
 package snet
 
 import (
+	"fmt"
 	"net"
 	"time"
 
@@ -37,6 +38,29 @@ func (e *OpError) Error() string {
 	return e.typeCode.String()
 }
 
+// Unwrap returns a *PathDownError if the SCMP message revoked an interface,
+// and nil otherwise.
+func (e *OpError) Unwrap() error {
+	if e.revInfo == nil {
+		return nil
+	}
+	return &PathDownError{
+		Interface: PathInterface{IA: e.revInfo.IA(), ID: e.revInfo.IfID},
+	}
+}
+
+// PathDownError indicates that the path used to send a packet is down, because
+// an SCMP message reported that one of its interfaces failed. The error can be
+// extracted from the errors returned by Read and ReadFrom with errors.As.
+type PathDownError struct {
+	// Interface is the interface that is down.
+	Interface PathInterface
+}
+
+func (e *PathDownError) Error() string {
+	return fmt.Sprintf("path down: interface %s", e.Interface)
+}
+
 var _ net.Conn = (*Conn)(nil)
 var _ net.PacketConn = (*Conn)(nil)
 
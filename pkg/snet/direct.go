@@ -0,0 +1,157 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"net"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/topology/underlay"
+)
+
+var _ PacketDispatcherService = (*DirectPacketDispatcherService)(nil)
+
+// DirectPacketDispatcherService opens the underlay socket of the application
+// directly instead of registering with the dispatcher. It is meant for
+// dedicated hosts, e.g., containers or servers, that run a single SCION
+// application and no dispatcher.
+//
+// Border routers deliver the packets for all SCION applications of a host to
+// the same underlay port, so only a single connection can be registered per
+// host address. The connection replies to SCMP echo requests in place of the
+// dispatcher, and drops UDP packets for other ports. SVC registrations are not
+// supported.
+type DirectPacketDispatcherService struct {
+	// UnderlayPort is the underlay port the connection is bound to. If it is
+	// zero, underlay.EndhostPort is used.
+	UnderlayPort int
+	// SCMPHandler is invoked for packets that contain an SCMP L4, except for
+	// echo requests. If the handler is nil, errors are returned back to
+	// applications every time an SCMP message is received.
+	SCMPHandler SCMPHandler
+	// Metrics injected into SCIONPacketConn.
+	SCIONPacketConnMetrics SCIONPacketConnMetrics
+	// Pcapng, if set, is injected into SCIONPacketConn to record the packets
+	// for debugging.
+	Pcapng *PcapngWriter
+}
+
+// Register opens the underlay socket on the IP address of the registration.
+// The SCION/UDP port of the connection is the port of the registration, or
+// the underlay port if the registration doesn't specify a port.
+func (s *DirectPacketDispatcherService) Register(ctx context.Context, ia addr.IA,
+	registration *net.UDPAddr, svc addr.SVC) (PacketConn, uint16, error) {
+
+	if registration == nil {
+		return nil, 0, serrors.New("registration address must be set")
+	}
+	if svc != addr.SvcNone {
+		return nil, 0, serrors.New("SVC registration is not supported without dispatcher",
+			"svc", svc)
+	}
+	underlayPort := s.UnderlayPort
+	if underlayPort == 0 {
+		underlayPort = underlay.EndhostPort
+	}
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{
+		IP:   registration.IP,
+		Port: underlayPort,
+		Zone: registration.Zone,
+	})
+	if err != nil {
+		return nil, 0, serrors.WrapStr("opening underlay socket", err)
+	}
+	port := uint16(registration.Port)
+	if port == 0 {
+		port = uint16(conn.LocalAddr().(*net.UDPAddr).Port)
+	}
+	return &directPacketConn{
+		SCIONPacketConn: SCIONPacketConn{
+			Conn:        conn,
+			SCMPHandler: s.SCMPHandler,
+			Metrics:     s.SCIONPacketConnMetrics,
+			Pcapng:      s.Pcapng,
+		},
+		port: port,
+	}, port, nil
+}
+
+// directPacketConn is a SCIONPacketConn on an underlay socket that is not
+// shared through the dispatcher.
+type directPacketConn struct {
+	SCIONPacketConn
+	// port is the SCION/UDP port of the connection.
+	port uint16
+}
+
+func (c *directPacketConn) ReadFrom(pkt *Packet, ov *net.UDPAddr) error {
+	for {
+		var lastHop net.UDPAddr
+		if err := c.readFrom(pkt, &lastHop); err != nil {
+			return err
+		}
+		switch p := pkt.Payload.(type) {
+		case UDPPayload:
+			if p.DstPort != c.port {
+				continue
+			}
+		case SCMPEchoRequest:
+			if err := c.replyEcho(pkt, p, &lastHop); err != nil {
+				log.Debug("Failed to reply to SCMP echo request", "src", pkt.Source,
+					"err", err)
+			}
+			continue
+		case SCMPPayload:
+			if err := c.handleSCMP(p, pkt); err != nil {
+				return err
+			}
+			continue
+		}
+		if ov != nil {
+			*ov = lastHop
+		}
+		return nil
+	}
+}
+
+func (c *directPacketConn) replyEcho(pkt *Packet, req SCMPEchoRequest,
+	lastHop *net.UDPAddr) error {
+
+	rpath, ok := pkt.Path.(RawPath)
+	if !ok {
+		return serrors.New("unexpected path", "type", common.TypeOf(pkt.Path))
+	}
+	replyPath, err := DefaultReplyPather{}.ReplyPath(rpath)
+	if err != nil {
+		return serrors.WrapStr("creating reply path", err)
+	}
+	reply := &Packet{
+		PacketInfo: PacketInfo{
+			Destination: pkt.Source,
+			Source:      pkt.Destination,
+			Path:        replyPath,
+			Payload: SCMPEchoReply{
+				Identifier: req.Identifier,
+				SeqNumber:  req.SeqNumber,
+				Payload:    req.Payload,
+			},
+		},
+	}
+	return c.WriteTo(reply, lastHop)
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestDirectPacketDispatcherService(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	localhost := netip.MustParseAddr("127.0.0.1")
+
+	// Reserve a free underlay port for the connection.
+	tmp, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	underlayAddr := tmp.LocalAddr().(*net.UDPAddr)
+	require.NoError(t, tmp.Close())
+
+	s := &snet.DirectPacketDispatcherService{UnderlayPort: underlayAddr.Port}
+	_, _, err = s.Register(context.Background(), ia,
+		&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}, addr.SvcCS)
+	assert.Error(t, err)
+	conn, port, err := s.Register(context.Background(), ia,
+		&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 40000}, addr.SvcNone)
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, uint16(40000), port)
+
+	peer, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer peer.Close()
+	peerConn := &snet.SCIONPacketConn{Conn: peer}
+	send := func(payload snet.Payload) {
+		pkt := &snet.Packet{
+			PacketInfo: snet.PacketInfo{
+				Source:      snet.SCIONAddress{IA: ia, Host: addr.HostIP(localhost)},
+				Destination: snet.SCIONAddress{IA: ia, Host: addr.HostIP(localhost)},
+				Path:        snetpath.Empty{},
+				Payload:     payload,
+			},
+		}
+		require.NoError(t, peerConn.WriteTo(pkt, underlayAddr))
+	}
+
+	// The echo request is answered by the connection, the packet for the
+	// other port is dropped.
+	send(snet.SCMPEchoRequest{Identifier: 1, SeqNumber: 2, Payload: []byte("ping")})
+	send(snet.UDPPayload{SrcPort: 1234, DstPort: 40001, Payload: []byte("other")})
+	send(snet.UDPPayload{SrcPort: 1234, DstPort: 40000, Payload: []byte("data")})
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	var pkt snet.Packet
+	var lastHop net.UDPAddr
+	require.NoError(t, conn.ReadFrom(&pkt, &lastHop))
+	assert.Equal(t, snet.UDPPayload{SrcPort: 1234, DstPort: 40000, Payload: []byte("data")},
+		pkt.Payload)
+	assert.Equal(t, peer.LocalAddr().(*net.UDPAddr).Port, lastHop.Port)
+
+	require.NoError(t, peer.SetReadDeadline(time.Now().Add(time.Second)))
+	reply := snet.Packet{Bytes: make(snet.Bytes, 1500)}
+	n, err := peer.Read(reply.Bytes)
+	require.NoError(t, err)
+	reply.Bytes = reply.Bytes[:n]
+	require.NoError(t, reply.Decode())
+	assert.Equal(t, snet.SCMPEchoReply{Identifier: 1, SeqNumber: 2, Payload: []byte("ping")},
+		reply.Payload)
+}
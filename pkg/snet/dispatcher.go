@@ -50,6 +50,9 @@ type DefaultPacketDispatcherService struct {
 	SCMPHandler SCMPHandler
 	// Metrics injected into SCIONPacketConn.
 	SCIONPacketConnMetrics SCIONPacketConnMetrics
+	// Pcapng, if set, is injected into SCIONPacketConn to record the packets
+	// for debugging.
+	Pcapng *PcapngWriter
 }
 
 func (s *DefaultPacketDispatcherService) Register(ctx context.Context, ia addr.IA,
@@ -63,6 +66,7 @@ func (s *DefaultPacketDispatcherService) Register(ctx context.Context, ia addr.I
 		Conn:        rconn,
 		SCMPHandler: s.SCMPHandler,
 		Metrics:     s.SCIONPacketConnMetrics,
+		Pcapng:      s.Pcapng,
 	}, port, nil
 }
 
@@ -88,6 +92,18 @@ type SCMPHandler interface {
 	Handle(pkt *Packet) error
 }
 
+// scmpVerificationTimeout bounds the time spent verifying a single SCMP
+// message, so that unverifiable messages cannot block the reading of packets
+// from the connection for long.
+const scmpVerificationTimeout = 500 * time.Millisecond
+
+// SCMPVerifier verifies the authenticity of SCMP messages, e.g., by checking
+// the SCION Packet Authenticator Option.
+type SCMPVerifier interface {
+	// Verify returns an error if the SCMP packet is not authentic.
+	Verify(ctx context.Context, pkt *Packet) error
+}
+
 // DefaultSCMPHandler handles SCMP messages received from the network. If a
 // revocation handler is configured, it is informed of any received interface
 // down messages.
@@ -95,8 +111,15 @@ type DefaultSCMPHandler struct {
 	// RevocationHandler manages revocations received via SCMP. If nil, the
 	// handler is not called.
 	RevocationHandler RevocationHandler
+	// Verifier, if set, is used to verify SCMP error messages. Messages that
+	// fail verification are dropped, i.e., they neither revoke interfaces nor
+	// are they returned to the caller.
+	Verifier SCMPVerifier
 	// SCMPErrors reports the total number of SCMP Errors encountered.
 	SCMPErrors metrics.Counter
+	// PathUsage, if set, records a lost packet for the recorded paths that
+	// traverse an interface that is reported down.
+	PathUsage *PathUsageRecorder
 }
 
 func (h DefaultSCMPHandler) Handle(pkt *Packet) error {
@@ -107,10 +130,21 @@ func (h DefaultSCMPHandler) Handle(pkt *Packet) error {
 	typeCode := slayers.CreateSCMPTypeCode(scmp.Type(), scmp.Code())
 	if !typeCode.InfoMsg() {
 		metrics.CounterInc(h.SCMPErrors)
+		if h.Verifier != nil {
+			ctx, cancelF := context.WithTimeout(context.Background(), scmpVerificationTimeout)
+			err := h.Verifier.Verify(ctx, pkt)
+			cancelF()
+			if err != nil {
+				log.Debug("Dropping unverified scmp packet", "scmp", typeCode,
+					"src", pkt.Source, "err", err)
+				return nil
+			}
+		}
 	}
 	switch scmp.Type() {
 	case slayers.SCMPTypeExternalInterfaceDown:
 		msg := pkt.Payload.(SCMPExternalInterfaceDown)
+		h.PathUsage.recordInterfaceDown(msg.IA, msg.Interface)
 		return h.handleSCMPRev(typeCode, &path_mgmt.RevInfo{
 			IfID:         common.IFIDType(msg.Interface),
 			RawIsdas:     msg.IA,
@@ -119,6 +153,7 @@ func (h DefaultSCMPHandler) Handle(pkt *Packet) error {
 		})
 	case slayers.SCMPTypeInternalConnectivityDown:
 		msg := pkt.Payload.(SCMPInternalConnectivityDown)
+		h.PathUsage.recordInterfaceDown(msg.IA, msg.Ingress, msg.Egress)
 		return h.handleSCMPRev(typeCode, &path_mgmt.RevInfo{
 			IfID:         common.IFIDType(msg.Egress),
 			RawIsdas:     msg.IA,
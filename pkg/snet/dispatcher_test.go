@@ -0,0 +1,93 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/mock_snet"
+)
+
+func TestDefaultSCMPHandlerInterfaceDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	revHandler := mock_snet.NewMockRevocationHandler(ctrl)
+	revHandler.EXPECT().Revoke(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, rev *path_mgmt.RevInfo) error {
+			assert.Equal(t, ia, rev.IA())
+			assert.Equal(t, common.IFIDType(4), rev.IfID)
+			return nil
+		},
+	)
+	h := snet.DefaultSCMPHandler{RevocationHandler: revHandler}
+	err := h.Handle(&snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Payload: snet.SCMPExternalInterfaceDown{IA: ia, Interface: 4},
+		},
+	})
+
+	var opErr *snet.OpError
+	require.True(t, errors.As(err, &opErr))
+	var pathDown *snet.PathDownError
+	require.True(t, errors.As(err, &pathDown))
+	assert.Equal(t, snet.PathInterface{IA: ia, ID: 4}, pathDown.Interface)
+}
+
+func TestOpErrorWithoutRevocation(t *testing.T) {
+	var pathDown *snet.PathDownError
+	assert.False(t, errors.As(&snet.OpError{}, &pathDown))
+}
+
+func TestDefaultSCMPHandlerVerifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pkt := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Payload: snet.SCMPExternalInterfaceDown{
+				IA:        xtest.MustParseIA("1-ff00:0:110"),
+				Interface: 4,
+			},
+		},
+	}
+
+	t.Run("verified", func(t *testing.T) {
+		verifier := mock_snet.NewMockSCMPVerifier(ctrl)
+		verifier.EXPECT().Verify(gomock.Any(), pkt).Return(nil)
+		revHandler := mock_snet.NewMockRevocationHandler(ctrl)
+		revHandler.EXPECT().Revoke(gomock.Any(), gomock.Any())
+		h := snet.DefaultSCMPHandler{RevocationHandler: revHandler, Verifier: verifier}
+		var pathDown *snet.PathDownError
+		assert.True(t, errors.As(h.Handle(pkt), &pathDown))
+	})
+	t.Run("not verified", func(t *testing.T) {
+		verifier := mock_snet.NewMockSCMPVerifier(ctrl)
+		verifier.EXPECT().Verify(gomock.Any(), pkt).Return(errors.New("invalid"))
+		revHandler := mock_snet.NewMockRevocationHandler(ctrl)
+		h := snet.DefaultSCMPHandler{RevocationHandler: revHandler, Verifier: verifier}
+		assert.NoError(t, h.Handle(pkt))
+	})
+}
@@ -15,6 +15,7 @@
 package snet
 
 import (
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
 	"github.com/scionproto/scion/pkg/slayers"
 )
 
@@ -22,3 +23,10 @@ func SCMPParameterProblemWithCode(m SCMPParameterProblem, c slayers.SCMPCode) SC
 	m.code = c
 	return m
 }
+
+func NewRevocationOpError(revInfo *path_mgmt.RevInfo) *OpError {
+	return &OpError{
+		typeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeExternalInterfaceDown, 0),
+		revInfo:  revInfo,
+	}
+}
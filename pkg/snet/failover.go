@@ -0,0 +1,276 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// failoverQueryTimeout is the timeout for looking up the alternate paths.
+const failoverQueryTimeout = 5 * time.Second
+
+// SwitchReason describes why a FailoverConn switched its path.
+type SwitchReason string
+
+const (
+	// SwitchRevocation indicates that an interface on the path was revoked by
+	// an SCMP message.
+	SwitchRevocation SwitchReason = "revocation"
+	// SwitchTimeout indicates that no packet was received while the path was
+	// used for sending during the configured timeout.
+	SwitchTimeout SwitchReason = "timeout"
+)
+
+// PathSwitch describes a path switch of a FailoverConn.
+type PathSwitch struct {
+	// Old is the path that was used before the switch.
+	Old Path
+	// New is the path that is used after the switch.
+	New Path
+	// Reason is the reason for the switch.
+	Reason SwitchReason
+}
+
+// FailoverConfig configures a FailoverConn.
+type FailoverConfig struct {
+	// Router is used to look up the alternate paths to the remote.
+	Router Router
+	// Filter, if set, is applied to the paths returned by the router, e.g. to
+	// apply a path policy.
+	Filter func([]Path) []Path
+	// Timeout is the time after which the path is considered broken if
+	// packets are sent, but no packet is received. If zero, the path is only
+	// switched on revocations.
+	Timeout time.Duration
+	// OnSwitch, if set, is called after the path was switched.
+	OnSwitch func(PathSwitch)
+}
+
+var _ net.Conn = (*FailoverConn)(nil)
+
+// FailoverConn wraps a connection with a fixed remote address and
+// transparently switches the path used for writing if the path fails. A path
+// fails if an SCMP message revokes one of its interfaces, or if packets are
+// sent over it for the configured timeout without any packet being received.
+// The new path is chosen from the policy-filtered paths returned by the
+// router, avoiding the revoked interfaces.
+//
+// Revocations are only detected if the SCMP handler of the underlying
+// connection returns them as *OpError, as DefaultSCMPHandler does. Read does
+// not return the SCMP errors that caused a path switch.
+type FailoverConn struct {
+	*Conn
+	cfg FailoverConfig
+
+	mtx        sync.Mutex
+	path       Path
+	remote     *UDPAddr
+	unanswered time.Time
+	revoked    map[PathInterface]time.Time
+}
+
+// NewFailoverConn wraps the connection, which must have been created by Dial.
+// The path is the path currently used by the connection. If it is nil, the
+// first path returned by the router is used.
+func NewFailoverConn(conn *Conn, path Path, cfg FailoverConfig) (*FailoverConn, error) {
+	if conn.remote == nil {
+		return nil, serrors.New("connection without remote address")
+	}
+	if cfg.Router == nil {
+		return nil, serrors.New("router must be set")
+	}
+	c := &FailoverConn{
+		Conn:    conn,
+		cfg:     cfg,
+		revoked: make(map[PathInterface]time.Time),
+	}
+	if path == nil {
+		paths, err := c.queryPaths()
+		if err != nil {
+			return nil, err
+		}
+		if len(paths) == 0 {
+			return nil, serrors.New("no path available", "dst", conn.remote.IA)
+		}
+		path = paths[0]
+	}
+	c.setPathLocked(path)
+	return c, nil
+}
+
+// Path returns the path currently in use.
+func (c *FailoverConn) Path() Path {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.path
+}
+
+// Write sends b to the remote address over the current path. If the timeout
+// expired, an alternate path is chosen before sending.
+func (c *FailoverConn) Write(b []byte) (int, error) {
+	c.mtx.Lock()
+	now := time.Now()
+	expired := c.cfg.Timeout > 0 && !c.unanswered.IsZero() &&
+		now.Sub(c.unanswered) > c.cfg.Timeout
+	if expired || c.unanswered.IsZero() {
+		// On expiry, give the new path a full timeout period, even if no
+		// alternative is found.
+		c.unanswered = now
+	}
+	current := c.path
+	c.mtx.Unlock()
+
+	if expired {
+		c.notify(c.switchPath(current, SwitchTimeout))
+	}
+	c.mtx.Lock()
+	remote := c.remote
+	c.mtx.Unlock()
+	return c.Conn.WriteTo(b, remote)
+}
+
+// Read reads data from the connection. SCMP errors revoking the current path
+// cause a path switch and are not returned.
+func (c *FailoverConn) Read(b []byte) (int, error) {
+	for {
+		n, err := c.Conn.Read(b)
+		if err == nil {
+			c.mtx.Lock()
+			c.unanswered = time.Time{}
+			c.mtx.Unlock()
+			return n, nil
+		}
+		var opErr *OpError
+		if !errors.As(err, &opErr) || opErr.RevInfo() == nil {
+			return n, err
+		}
+		if !c.revoke(opErr.RevInfo()) {
+			return n, err
+		}
+	}
+}
+
+// revoke records the revocation and switches the path if it is affected. It
+// returns whether the current path was affected.
+func (c *FailoverConn) revoke(rev *path_mgmt.RevInfo) bool {
+	c.mtx.Lock()
+	intf := PathInterface{IA: rev.IA(), ID: rev.IfID}
+	c.revoked[intf] = rev.Expiration()
+	current := c.path
+	c.mtx.Unlock()
+	if !containsInterface(current, intf) {
+		return false
+	}
+
+	c.notify(c.switchPath(current, SwitchRevocation))
+	return true
+}
+
+// switchPath switches from the path current to the next usable path. The paths
+// are looked up without holding the lock, so that reads and writes are not
+// blocked by the lookup. If the path was switched concurrently in the meantime,
+// or if no other path is usable, the path is kept and nil is returned.
+func (c *FailoverConn) switchPath(current Path, reason SwitchReason) *PathSwitch {
+	paths, err := c.queryPaths()
+	if err != nil {
+		return nil
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	fp := Fingerprint(current)
+	if Fingerprint(c.path) != fp {
+		return nil
+	}
+	now := time.Now()
+	for intf, expiry := range c.revoked {
+		if now.After(expiry) {
+			delete(c.revoked, intf)
+		}
+	}
+	// Prefer the paths after the current one, so that consecutive timeouts
+	// cycle through all paths.
+	start := 0
+	for i, p := range paths {
+		if Fingerprint(p) == fp {
+			start = i + 1
+			break
+		}
+	}
+	for i := 0; i < len(paths); i++ {
+		p := paths[(start+i)%len(paths)]
+		if Fingerprint(p) == fp || c.isRevokedLocked(p) {
+			continue
+		}
+		old := c.path
+		c.setPathLocked(p)
+		return &PathSwitch{Old: old, New: p, Reason: reason}
+	}
+	return nil
+}
+
+func (c *FailoverConn) isRevokedLocked(p Path) bool {
+	for intf := range c.revoked {
+		if containsInterface(p, intf) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *FailoverConn) setPathLocked(p Path) {
+	remote := c.Conn.remote.Copy()
+	remote.Path = p.Dataplane()
+	remote.NextHop = CopyUDPAddr(p.UnderlayNextHop())
+	c.path, c.remote = p, remote
+}
+
+func (c *FailoverConn) queryPaths() ([]Path, error) {
+	ctx, cancelF := context.WithTimeout(context.Background(), failoverQueryTimeout)
+	defer cancelF()
+	paths, err := c.cfg.Router.AllRoutes(ctx, c.Conn.remote.IA)
+	if err != nil {
+		return nil, serrors.WrapStr("looking up paths", err, "dst", c.Conn.remote.IA)
+	}
+	if c.cfg.Filter != nil {
+		paths = c.cfg.Filter(paths)
+	}
+	return paths, nil
+}
+
+func (c *FailoverConn) notify(s *PathSwitch) {
+	if s != nil && c.cfg.OnSwitch != nil {
+		c.cfg.OnSwitch(*s)
+	}
+}
+
+func containsInterface(p Path, intf PathInterface) bool {
+	if p == nil || p.Metadata() == nil {
+		return false
+	}
+	for _, i := range p.Metadata().Interfaces {
+		if i == intf {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/mock_snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestFailoverConn(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	remoteIA := xtest.MustParseIA("1-ff00:0:112")
+	p1 := newTestPath(localIA, remoteIA, 1, 0)
+	p2 := newTestPath(localIA, remoteIA, 2, 0)
+
+	expectWrite := func(pconn *mock_snet.MockPacketConn, p snet.Path) *gomock.Call {
+		return pconn.EXPECT().WriteTo(gomock.Any(), p.UnderlayNextHop()).DoAndReturn(
+			func(pkt *snet.Packet, _ *net.UDPAddr) error {
+				assert.Equal(t, p.Dataplane(), pkt.Path)
+				return nil
+			},
+		)
+	}
+
+	t.Run("revocation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		conn, pconn := dialTestConn(t, ctrl, localIA, remoteIA)
+		router := mock_snet.NewMockRouter(ctrl)
+		router.EXPECT().AllRoutes(gomock.Any(), remoteIA).Return([]snet.Path{p1, p2}, nil)
+
+		var switches []snet.PathSwitch
+		fconn, err := snet.NewFailoverConn(conn, p1, snet.FailoverConfig{
+			Router:   router,
+			OnSwitch: func(s snet.PathSwitch) { switches = append(switches, s) },
+		})
+		require.NoError(t, err)
+
+		readErr := serrors.New("test")
+		gomock.InOrder(
+			pconn.EXPECT().ReadFrom(gomock.Any(), gomock.Any()).Return(
+				snet.NewRevocationOpError(&path_mgmt.RevInfo{
+					IfID:         1,
+					RawIsdas:     remoteIA,
+					RawTimestamp: util.TimeToSecs(time.Now()),
+					RawTTL:       10,
+				}),
+			),
+			pconn.EXPECT().ReadFrom(gomock.Any(), gomock.Any()).Return(readErr),
+		)
+		_, err = fconn.Read(make([]byte, 10))
+		assert.ErrorIs(t, err, readErr)
+		assert.Equal(t, p2, fconn.Path())
+		require.Len(t, switches, 1)
+		assert.Equal(t, snet.PathSwitch{Old: p1, New: p2, Reason: snet.SwitchRevocation},
+			switches[0])
+
+		expectWrite(pconn, p2)
+		_, err = fconn.Write([]byte("hello"))
+		assert.NoError(t, err)
+	})
+	t.Run("revocation of unused path", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		conn, pconn := dialTestConn(t, ctrl, localIA, remoteIA)
+		fconn, err := snet.NewFailoverConn(conn, p1, snet.FailoverConfig{
+			Router: mock_snet.NewMockRouter(ctrl),
+		})
+		require.NoError(t, err)
+
+		pconn.EXPECT().ReadFrom(gomock.Any(), gomock.Any()).Return(
+			snet.NewRevocationOpError(&path_mgmt.RevInfo{
+				IfID:         2,
+				RawIsdas:     remoteIA,
+				RawTimestamp: util.TimeToSecs(time.Now()),
+				RawTTL:       10,
+			}),
+		)
+		_, err = fconn.Read(make([]byte, 10))
+		var opErr *snet.OpError
+		assert.ErrorAs(t, err, &opErr)
+		assert.Equal(t, p1, fconn.Path())
+	})
+	t.Run("timeout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		conn, pconn := dialTestConn(t, ctrl, localIA, remoteIA)
+		router := mock_snet.NewMockRouter(ctrl)
+		router.EXPECT().AllRoutes(gomock.Any(), remoteIA).Return([]snet.Path{p1, p2}, nil).
+			Times(2)
+
+		var switches []snet.PathSwitch
+		fconn, err := snet.NewFailoverConn(conn, nil, snet.FailoverConfig{
+			Router:   router,
+			Timeout:  10 * time.Millisecond,
+			OnSwitch: func(s snet.PathSwitch) { switches = append(switches, s) },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, p1, fconn.Path())
+
+		gomock.InOrder(
+			expectWrite(pconn, p1),
+			expectWrite(pconn, p1),
+			expectWrite(pconn, p2),
+		)
+		_, err = fconn.Write([]byte("hello"))
+		require.NoError(t, err)
+		_, err = fconn.Write([]byte("hello"))
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+		_, err = fconn.Write([]byte("hello"))
+		require.NoError(t, err)
+		require.Len(t, switches, 1)
+		assert.Equal(t, snet.SwitchTimeout, switches[0].Reason)
+	})
+	t.Run("lookup does not block", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		conn, pconn := dialTestConn(t, ctrl, localIA, remoteIA)
+		router := mock_snet.NewMockRouter(ctrl)
+		lookup, release := make(chan struct{}), make(chan struct{})
+		router.EXPECT().AllRoutes(gomock.Any(), remoteIA).DoAndReturn(
+			func(context.Context, addr.IA) ([]snet.Path, error) {
+				close(lookup)
+				<-release
+				return []snet.Path{p1, p2}, nil
+			},
+		)
+		fconn, err := snet.NewFailoverConn(conn, p1, snet.FailoverConfig{Router: router})
+		require.NoError(t, err)
+
+		pconn.EXPECT().ReadFrom(gomock.Any(), gomock.Any()).Return(
+			snet.NewRevocationOpError(&path_mgmt.RevInfo{
+				IfID:         1,
+				RawIsdas:     remoteIA,
+				RawTimestamp: util.TimeToSecs(time.Now()),
+				RawTTL:       10,
+			}),
+		)
+		pconn.EXPECT().ReadFrom(gomock.Any(), gomock.Any()).Return(serrors.New("test"))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = fconn.Read(make([]byte, 10))
+		}()
+		<-lookup
+		expectWrite(pconn, p1)
+		_, err = fconn.Write([]byte("hello"))
+		assert.NoError(t, err)
+		assert.Equal(t, p1, fconn.Path())
+		close(release)
+		<-done
+		assert.Equal(t, p2, fconn.Path())
+	})
+}
+
+func dialTestConn(t *testing.T, ctrl *gomock.Controller,
+	localIA, remoteIA addr.IA) (*snet.Conn, *mock_snet.MockPacketConn) {
+
+	pconn := mock_snet.NewMockPacketConn(ctrl)
+	disp := mock_snet.NewMockPacketDispatcherService(ctrl)
+	disp.EXPECT().Register(gomock.Any(), localIA, gomock.Any(), addr.SvcNone).
+		Return(pconn, uint16(40000), nil)
+	network := &snet.SCIONNetwork{LocalIA: localIA, Dispatcher: disp}
+	conn, err := network.Dial(context.Background(), "udp",
+		&net.UDPAddr{IP: net.IP{127, 0, 0, 1}},
+		&snet.UDPAddr{IA: remoteIA, Host: &net.UDPAddr{IP: net.IP{10, 1, 0, 1}, Port: 80}},
+		addr.SvcNone,
+	)
+	require.NoError(t, err)
+	return conn, pconn
+}
+
+// newTestPath creates a path that leaves the local AS and enters the remote AS
+// on the interface with the given ID.
+func newTestPath(localIA, remoteIA addr.IA, ifID common.IFIDType,
+	latency time.Duration) snet.Path {
+
+	meta := snet.PathMetadata{
+		Interfaces: []snet.PathInterface{
+			{IA: localIA, ID: ifID},
+			{IA: remoteIA, ID: ifID},
+		},
+	}
+	if latency != 0 {
+		meta.Latency = []time.Duration{latency}
+	}
+	return snetpath.Path{
+		Src:           localIA,
+		Dst:           remoteIA,
+		Meta:          meta,
+		NextHop:       &net.UDPAddr{IP: net.IP{10, 0, 0, byte(ifID)}, Port: 30041},
+		DataplanePath: snetpath.SCION{Raw: []byte{byte(ifID)}},
+	}
+}
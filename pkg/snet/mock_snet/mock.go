@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/pkg/snet (interfaces: PacketDispatcherService,Network,PacketConn,Path,PathQuerier,Router,RevocationHandler)
+// Source: github.com/scionproto/scion/pkg/snet (interfaces: PacketDispatcherService,Network,PacketConn,Path,PathQuerier,Router,RevocationHandler,SCMPVerifier)
 
 // Package mock_snet is a generated GoMock package.
 package mock_snet
@@ -435,3 +435,40 @@ func (mr *MockRevocationHandlerMockRecorder) Revoke(arg0, arg1 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRevocationHandler)(nil).Revoke), arg0, arg1)
 }
+
+// MockSCMPVerifier is a mock of SCMPVerifier interface.
+type MockSCMPVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockSCMPVerifierMockRecorder
+}
+
+// MockSCMPVerifierMockRecorder is the mock recorder for MockSCMPVerifier.
+type MockSCMPVerifierMockRecorder struct {
+	mock *MockSCMPVerifier
+}
+
+// NewMockSCMPVerifier creates a new mock instance.
+func NewMockSCMPVerifier(ctrl *gomock.Controller) *MockSCMPVerifier {
+	mock := &MockSCMPVerifier{ctrl: ctrl}
+	mock.recorder = &MockSCMPVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSCMPVerifier) EXPECT() *MockSCMPVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockSCMPVerifier) Verify(arg0 context.Context, arg1 *snet.Packet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockSCMPVerifierMockRecorder) Verify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockSCMPVerifier)(nil).Verify), arg0, arg1)
+}
@@ -0,0 +1,248 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Scheduler decides on which paths a packet of a MultiPathConn is sent.
+// A scheduler keeps state across calls and must not be shared between
+// connections. The connection serializes the calls to Schedule.
+type Scheduler interface {
+	// Schedule returns the indices of the paths the next packet is sent on.
+	// The paths are never empty.
+	Schedule(paths []Path) []int
+}
+
+// RoundRobinScheduler sends each packet on the next path.
+type RoundRobinScheduler struct {
+	next int
+}
+
+func (s *RoundRobinScheduler) Schedule(paths []Path) []int {
+	i := s.next % len(paths)
+	s.next = i + 1
+	return []int{i}
+}
+
+// LatencyWeightedScheduler distributes the packets across the paths in
+// proportion to the inverse of the path latency, i.e. a path with half the
+// latency carries twice as many packets. Paths with unknown latency are
+// weighted like the slowest path with known latency.
+type LatencyWeightedScheduler struct {
+	credits []float64
+}
+
+func (s *LatencyWeightedScheduler) Schedule(paths []Path) []int {
+	if len(s.credits) != len(paths) {
+		s.credits = make([]float64, len(paths))
+	}
+	weights := latencyWeights(paths)
+	// Smooth weighted round-robin: every path gains its weight, the path with
+	// the most credit is picked and pays the total weight.
+	var total float64
+	best := 0
+	for i, w := range weights {
+		s.credits[i] += w
+		total += w
+		if s.credits[i] > s.credits[best] {
+			best = i
+		}
+	}
+	s.credits[best] -= total
+	return []int{best}
+}
+
+func latencyWeights(paths []Path) []float64 {
+	latencies := make([]time.Duration, len(paths))
+	var max time.Duration
+	for i, p := range paths {
+		latencies[i] = pathLatency(p)
+		if latencies[i] > max {
+			max = latencies[i]
+		}
+	}
+	weights := make([]float64, len(paths))
+	for i, l := range latencies {
+		if l <= 0 {
+			l = max
+		}
+		if l <= 0 {
+			// No latency is known at all, weight all paths equally.
+			l = time.Millisecond
+		}
+		weights[i] = 1 / l.Seconds()
+	}
+	return weights
+}
+
+// pathLatency returns the sum of the hop latencies of the path, or 0 if the
+// latency is not fully known.
+func pathLatency(p Path) time.Duration {
+	meta := p.Metadata()
+	if meta == nil || len(meta.Latency) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, l := range meta.Latency {
+		if l < 0 {
+			return 0
+		}
+		total += l
+	}
+	return total
+}
+
+// RedundantScheduler duplicates each packet on multiple paths.
+type RedundantScheduler struct {
+	// Copies is the number of paths each packet is sent on. If zero, or if it
+	// exceeds the number of paths, each packet is sent on all paths.
+	Copies int
+}
+
+func (s *RedundantScheduler) Schedule(paths []Path) []int {
+	n := len(paths)
+	if s.Copies > 0 && s.Copies < n {
+		n = s.Copies
+	}
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+// SelectDisjointPaths selects up to n paths that don't share any interface.
+// The paths are considered in order, so the preferred paths should come
+// first. Fewer than n paths are returned if not enough disjoint paths exist.
+func SelectDisjointPaths(paths []Path, n int) []Path {
+	used := make(map[PathInterface]struct{})
+	var selected []Path
+	for _, p := range paths {
+		if len(selected) == n {
+			break
+		}
+		meta := p.Metadata()
+		if meta == nil {
+			continue
+		}
+		disjoint := true
+		for _, intf := range meta.Interfaces {
+			if _, ok := used[intf]; ok {
+				disjoint = false
+				break
+			}
+		}
+		if !disjoint {
+			continue
+		}
+		for _, intf := range meta.Interfaces {
+			used[intf] = struct{}{}
+		}
+		selected = append(selected, p)
+	}
+	return selected
+}
+
+var _ net.Conn = (*MultiPathConn)(nil)
+
+// MultiPathConn wraps a connection with a fixed remote address and sends the
+// written packets over multiple paths. The scheduler decides which paths each
+// packet is sent on, e.g. to load-balance across the paths or to send the
+// packets redundantly.
+type MultiPathConn struct {
+	*Conn
+	scheduler Scheduler
+
+	mtx     sync.Mutex
+	paths   []Path
+	remotes []*UDPAddr
+}
+
+// NewMultiPathConn wraps the connection, which must have been created by
+// Dial. The paths should be disjoint, see SelectDisjointPaths.
+func NewMultiPathConn(conn *Conn, paths []Path, scheduler Scheduler) (*MultiPathConn, error) {
+	if conn.remote == nil {
+		return nil, serrors.New("connection without remote address")
+	}
+	if scheduler == nil {
+		return nil, serrors.New("scheduler must be set")
+	}
+	c := &MultiPathConn{
+		Conn:      conn,
+		scheduler: scheduler,
+	}
+	if err := c.SetPaths(paths); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetPaths replaces the paths the packets are sent on.
+func (c *MultiPathConn) SetPaths(paths []Path) error {
+	if len(paths) == 0 {
+		return serrors.New("no paths")
+	}
+	remotes := make([]*UDPAddr, 0, len(paths))
+	for _, p := range paths {
+		remote := c.Conn.remote.Copy()
+		remote.Path = p.Dataplane()
+		remote.NextHop = CopyUDPAddr(p.UnderlayNextHop())
+		remotes = append(remotes, remote)
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.paths = append([]Path(nil), paths...)
+	c.remotes = remotes
+	return nil
+}
+
+// Paths returns the paths the packets are sent on.
+func (c *MultiPathConn) Paths() []Path {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return append([]Path(nil), c.paths...)
+}
+
+// Write sends b on the paths selected by the scheduler. It only returns an
+// error if sending failed on all selected paths.
+func (c *MultiPathConn) Write(b []byte) (int, error) {
+	c.mtx.Lock()
+	indices := c.scheduler.Schedule(c.paths)
+	remotes := make([]*UDPAddr, 0, len(indices))
+	for _, i := range indices {
+		remotes = append(remotes, c.remotes[i])
+	}
+	c.mtx.Unlock()
+
+	if len(remotes) == 0 {
+		return 0, serrors.New("no path scheduled")
+	}
+	var errs serrors.List
+	for _, remote := range remotes {
+		if _, err := c.Conn.WriteTo(b, remote); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == len(remotes) {
+		return 0, errs.ToError()
+	}
+	return len(b), nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestSchedulers(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	remoteIA := xtest.MustParseIA("1-ff00:0:112")
+	paths := []snet.Path{
+		newTestPath(localIA, remoteIA, 1, 10*time.Millisecond),
+		newTestPath(localIA, remoteIA, 2, 20*time.Millisecond),
+		newTestPath(localIA, remoteIA, 3, 0),
+	}
+	count := func(s snet.Scheduler, n int) []int {
+		counts := make([]int, len(paths))
+		for i := 0; i < n; i++ {
+			for _, idx := range s.Schedule(paths) {
+				counts[idx]++
+			}
+		}
+		return counts
+	}
+
+	t.Run("round-robin", func(t *testing.T) {
+		assert.Equal(t, []int{2, 2, 2}, count(&snet.RoundRobinScheduler{}, 6))
+	})
+	t.Run("latency weighted", func(t *testing.T) {
+		// The path with unknown latency is weighted like the slowest path.
+		assert.Equal(t, []int{4, 2, 2}, count(&snet.LatencyWeightedScheduler{}, 8))
+	})
+	t.Run("redundant", func(t *testing.T) {
+		assert.Equal(t, []int{4, 4, 4}, count(&snet.RedundantScheduler{}, 4))
+		assert.Equal(t, []int{4, 4, 0}, count(&snet.RedundantScheduler{Copies: 2}, 4))
+	})
+}
+
+func TestSelectDisjointPaths(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	remoteIA := xtest.MustParseIA("1-ff00:0:112")
+	p1 := newTestPath(localIA, remoteIA, 1, 0)
+	p2 := newTestPath(localIA, remoteIA, 2, 0)
+	// p3 shares the first interface with p1.
+	p3 := snetpath.Path{
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: localIA, ID: 1},
+				{IA: remoteIA, ID: 3},
+			},
+		},
+	}
+	assert.Equal(t, []snet.Path{p1, p2},
+		snet.SelectDisjointPaths([]snet.Path{p1, p3, p2}, 3))
+	assert.Equal(t, []snet.Path{p1}, snet.SelectDisjointPaths([]snet.Path{p1, p3, p2}, 1))
+}
+
+func TestMultiPathConn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	remoteIA := xtest.MustParseIA("1-ff00:0:112")
+	p1 := newTestPath(localIA, remoteIA, 1, 0)
+	p2 := newTestPath(localIA, remoteIA, 2, 0)
+	conn, pconn := dialTestConn(t, ctrl, localIA, remoteIA)
+
+	mconn, err := snet.NewMultiPathConn(conn, []snet.Path{p1, p2}, &snet.RedundantScheduler{})
+	require.NoError(t, err)
+
+	var sent []snet.DataplanePath
+	pconn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(pkt *snet.Packet, nextHop *net.UDPAddr) error {
+			sent = append(sent, pkt.Path)
+			if nextHop.String() == p2.UnderlayNextHop().String() {
+				return serrors.New("test")
+			}
+			return nil
+		},
+	).Times(2)
+	n, err := mconn.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []snet.DataplanePath{p1.Dataplane(), p2.Dataplane()}, sent)
+
+	require.NoError(t, mconn.SetPaths([]snet.Path{p2}))
+	pconn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).Return(serrors.New("test"))
+	_, err = mconn.Write([]byte("hello"))
+	assert.Error(t, err)
+}
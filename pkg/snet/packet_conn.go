@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -113,6 +114,9 @@ type SCIONPacketConn struct {
 	SCMPHandler SCMPHandler
 	// Metrics are the metrics exported by the conn.
 	Metrics SCIONPacketConnMetrics
+	// Pcapng, if set, receives a copy of every packet that is sent or
+	// received on the connection.
+	Pcapng *PcapngWriter
 }
 
 func (c *SCIONPacketConn) SetDeadline(d time.Time) error {
@@ -136,6 +140,9 @@ func (c *SCIONPacketConn) WriteTo(pkt *Packet, ov *net.UDPAddr) error {
 	}
 	metrics.CounterAdd(c.Metrics.WriteBytes, float64(n))
 	metrics.CounterInc(c.Metrics.WritePackets)
+	if c.Pcapng != nil {
+		c.writePcapng(pkt.Bytes, c.localUnderlay(pkt.Source.Host), ov)
+	}
 	return nil
 }
 
@@ -150,15 +157,7 @@ func (c *SCIONPacketConn) ReadFrom(pkt *Packet, ov *net.UDPAddr) error {
 			return err
 		}
 		if scmp, ok := pkt.Payload.(SCMPPayload); ok {
-			if c.SCMPHandler == nil {
-				metrics.CounterInc(c.Metrics.SCMPErrors)
-				return serrors.New("scmp packet received, but no handler found",
-					"type_code", slayers.CreateSCMPTypeCode(scmp.Type(), scmp.Code()),
-					"src", pkt.Source)
-			}
-			if err := c.SCMPHandler.Handle(pkt); err != nil {
-				// Return error intact s.t. applications can handle custom
-				// error types returned by SCMP handlers.
+			if err := c.handleSCMP(scmp, pkt); err != nil {
 				return err
 			}
 			continue
@@ -169,6 +168,18 @@ func (c *SCIONPacketConn) ReadFrom(pkt *Packet, ov *net.UDPAddr) error {
 	}
 }
 
+func (c *SCIONPacketConn) handleSCMP(scmp SCMPPayload, pkt *Packet) error {
+	if c.SCMPHandler == nil {
+		metrics.CounterInc(c.Metrics.SCMPErrors)
+		return serrors.New("scmp packet received, but no handler found",
+			"type_code", slayers.CreateSCMPTypeCode(scmp.Type(), scmp.Code()),
+			"src", pkt.Source)
+	}
+	// Return error intact s.t. applications can handle custom error types
+	// returned by SCMP handlers.
+	return c.SCMPHandler.Handle(pkt)
+}
+
 func (c *SCIONPacketConn) readFrom(pkt *Packet, ov *net.UDPAddr) error {
 	pkt.Prepare()
 	n, lastHopNetAddr, err := c.Conn.ReadFrom(pkt.Bytes)
@@ -194,12 +205,31 @@ func (c *SCIONPacketConn) readFrom(pkt *Packet, ov *net.UDPAddr) error {
 		return serrors.WrapStr("decoding packet", err)
 	}
 
+	if c.Pcapng != nil {
+		c.writePcapng(pkt.Bytes, lastHop, c.localUnderlay(pkt.Destination.Host))
+	}
 	if ov != nil {
 		*ov = *lastHop
 	}
 	return nil
 }
 
+func (c *SCIONPacketConn) writePcapng(raw []byte, src, dst *net.UDPAddr) {
+	if err := c.Pcapng.WritePacket(raw, src, dst); err != nil {
+		log.Debug("Failed to write packet to pcapng file", "err", err)
+	}
+}
+
+// localUnderlay returns the underlay address of the connection. If the
+// connection is not bound to a specific UDP address, e.g., because it goes
+// through the dispatcher, the address is derived from the SCION host address.
+func (c *SCIONPacketConn) localUnderlay(host addr.Host) *net.UDPAddr {
+	if local, ok := c.Conn.LocalAddr().(*net.UDPAddr); ok && !local.IP.IsUnspecified() {
+		return local
+	}
+	return hostUnderlay(host)
+}
+
 func (c *SCIONPacketConn) SetReadDeadline(d time.Time) error {
 	return c.Conn.SetReadDeadline(d)
 }
@@ -17,12 +17,14 @@ package snet
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"net"
 	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/slayers"
 )
 
@@ -140,6 +142,9 @@ type PathMetadata struct {
 
 	// EpicAuths contains the EPIC authenticators.
 	EpicAuths EpicAuths
+
+	// Peering indicates whether the path traverses a peering link.
+	Peering bool
 }
 
 func (pm *PathMetadata) Copy() *PathMetadata {
@@ -157,6 +162,7 @@ func (pm *PathMetadata) Copy() *PathMetadata {
 		LinkType:     append(pm.LinkType[:0:0], pm.LinkType...),
 		InternalHops: append(pm.InternalHops[:0:0], pm.InternalHops...),
 		Notes:        append(pm.Notes[:0:0], pm.Notes...),
+		Peering:      pm.Peering,
 		EpicAuths: EpicAuths{
 			AuthPHVF: append([]byte(nil), pm.EpicAuths.AuthPHVF...),
 			AuthLHVF: append([]byte(nil), pm.EpicAuths.AuthLHVF...),
@@ -208,6 +214,20 @@ func (pf PathFingerprint) String() string {
 	return fmt.Sprintf("%x", []byte(pf))
 }
 
+// ParsePathFingerprint parses the hex encoded string representation of a path
+// fingerprint, as returned by PathFingerprint.String.
+func ParsePathFingerprint(s string) (PathFingerprint, error) {
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return "", serrors.WrapStr("decoding path fingerprint", err, "fingerprint", s)
+	}
+	if len(raw) != sha256.Size {
+		return "", serrors.New("invalid path fingerprint length",
+			"expected", sha256.Size, "actual", len(raw))
+	}
+	return PathFingerprint(raw), nil
+}
+
 // Fingerprint uniquely identifies the path based on the sequence of
 // ASes and BRs, i.e. by its PathInterfaces.
 // Other metadata, such as MTU or NextHop have no effect on the fingerprint.
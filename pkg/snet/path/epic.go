@@ -37,7 +37,7 @@ type EPIC struct {
 
 func NewEPICDataplanePath(p SCION, auths snet.EpicAuths) (*EPIC, error) {
 	if !auths.SupportsEpic() {
-		return &EPIC{}, serrors.New("EPIC not supported")
+		return nil, serrors.New("EPIC not supported")
 	}
 	epicPath := &EPIC{
 		AuthPHVF: append([]byte(nil), auths.AuthPHVF...),
@@ -0,0 +1,85 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package path_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	libepic "github.com/scionproto/scion/pkg/experimental/epic"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/epic"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestEPICSetPath(t *testing.T) {
+	timestamp := util.TimeToSecs(time.Now().Add(-time.Minute))
+	decoded := scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{SegLen: [3]uint8{2, 0, 0}},
+			NumINF:   1,
+			NumHops:  2,
+		},
+		InfoFields: []path.InfoField{{ConsDir: true, Timestamp: timestamp}},
+		HopFields:  []path.HopField{{ConsEgress: 4}, {ConsIngress: 1}},
+	}
+	raw := make([]byte, decoded.Len())
+	require.NoError(t, decoded.SerializeTo(raw))
+	auths := snet.EpicAuths{
+		AuthPHVF: xtest.MustParseHexString("000102030405060708090a0b0c0d0e0f"),
+		AuthLHVF: xtest.MustParseHexString("0f0e0d0c0b0a09080706050403020100"),
+	}
+
+	_, err := snetpath.NewEPICDataplanePath(snetpath.SCION{Raw: raw}, snet.EpicAuths{})
+	assert.Error(t, err)
+
+	p, err := snetpath.NewEPICDataplanePath(snetpath.SCION{Raw: raw}, auths)
+	require.NoError(t, err)
+
+	s := &slayers.SCION{
+		SrcIA:      xtest.MustParseIA("1-ff00:0:110"),
+		DstIA:      xtest.MustParseIA("1-ff00:0:111"),
+		PayloadLen: 100,
+	}
+	require.NoError(t, s.SetSrcAddr(addr.MustParseHost("10.0.0.1")))
+	require.NoError(t, s.SetDstAddr(addr.MustParseHost("10.0.0.2")))
+
+	var pktIDs []epic.PktID
+	for i := 0; i < 2; i++ {
+		require.NoError(t, p.SetPath(s))
+		require.Equal(t, epic.PathType, s.PathType)
+		ep, ok := s.Path.(*epic.Path)
+		require.True(t, ok)
+		// The hop validation fields must be accepted by the verification in the
+		// router.
+		assert.NoError(t, libepic.VerifyHVF(auths.AuthPHVF, ep.PktID, s, timestamp,
+			ep.PHVF, nil))
+		assert.NoError(t, libepic.VerifyHVF(auths.AuthLHVF, ep.PktID, s, timestamp,
+			ep.LHVF, nil))
+		assert.NoError(t, libepic.VerifyTimestamp(time.Unix(int64(timestamp), 0),
+			ep.PktID.Timestamp, time.Now()))
+		pktIDs = append(pktIDs, ep.PktID)
+	}
+	assert.NotEqual(t, pktIDs[0], pktIDs[1], "packet IDs must be unique")
+}
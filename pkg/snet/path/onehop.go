@@ -18,14 +18,23 @@ import (
 	"crypto/rand"
 	"hash"
 	"math/big"
+	"net"
 	"time"
 
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/pkg/slayers"
 	"github.com/scionproto/scion/pkg/slayers/path"
 	"github.com/scionproto/scion/pkg/slayers/path/onehop"
+	"github.com/scionproto/scion/pkg/snet"
 )
 
+// OneHopExpTime is the relative expiration time of the hop fields of the
+// one-hop paths created by NewOneHopPath, which corresponds to 6 hours.
+const OneHopExpTime = 63
+
 type OneHop struct {
 	Info      path.InfoField
 	FirstHop  path.HopField
@@ -68,3 +77,47 @@ func NewOneHop(
 	ohp.FirstHop.Mac = path.MAC(mac, ohp.Info, ohp.FirstHop, nil)
 	return ohp, nil
 }
+
+// NewOneHopPath creates a path from the local AS src to the neighbor AS dst
+// over the local egress interface. One-hop paths do not depend on any path
+// segments, hence they can be used to communicate with a neighbor before
+// beaconing has taken place, e.g., for bootstrapping or diagnostics.
+//
+// The first hop field is authenticated with mac, which must be keyed with the
+// hop field key of the local AS (see scrypto.InitMac). The second hop field is
+// filled in by the ingress router of the neighbor. nextHop is the underlay
+// address of the local router that owns the egress interface.
+//
+// The path can be used like any other path, e.g., by setting its dataplane
+// path and next hop on an snet.UDPAddr or snet.SVCAddr.
+func NewOneHopPath(src, dst addr.IA, egress uint16, nextHop *net.UDPAddr,
+	mac hash.Hash) (Path, error) {
+
+	if egress == 0 {
+		return Path{}, serrors.New("egress interface must not be zero")
+	}
+	if nextHop == nil {
+		return Path{}, serrors.New("next hop must not be nil")
+	}
+	now := time.Now()
+	ohp, err := NewOneHop(egress, now, OneHopExpTime, mac)
+	if err != nil {
+		return Path{}, err
+	}
+	return Path{
+		Src:           src,
+		Dst:           dst,
+		DataplanePath: ohp,
+		NextHop:       nextHop,
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: src, ID: common.IFIDType(egress)},
+				// The ingress interface of the neighbor is not known
+				// beforehand.
+				{IA: dst},
+			},
+			Expiry: util.SecsToTime(ohp.Info.Timestamp).Add(
+				path.ExpTimeToDuration(OneHopExpTime)),
+		},
+	}, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package path_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/onehop"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestNewOneHopPath(t *testing.T) {
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:111")
+	nextHop := &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 31002}
+	mac, err := scrypto.InitMac([]byte("0123456789abcdef"))
+	require.NoError(t, err)
+
+	_, err = snetpath.NewOneHopPath(src, dst, 0, nextHop, mac)
+	assert.Error(t, err)
+	_, err = snetpath.NewOneHopPath(src, dst, 4, nil, mac)
+	assert.Error(t, err)
+
+	p, err := snetpath.NewOneHopPath(src, dst, 4, nextHop, mac)
+	require.NoError(t, err)
+	assert.Equal(t, src, p.Source())
+	assert.Equal(t, dst, p.Destination())
+	assert.Equal(t, nextHop, p.UnderlayNextHop())
+
+	meta := p.Metadata()
+	require.Len(t, meta.Interfaces, 2)
+	assert.Equal(t, src, meta.Interfaces[0].IA)
+	assert.EqualValues(t, 4, meta.Interfaces[0].ID)
+	assert.Equal(t, dst, meta.Interfaces[1].IA)
+	assert.WithinDuration(t, time.Now().Add(path.ExpTimeToDuration(snetpath.OneHopExpTime)),
+		meta.Expiry, 2*time.Second)
+
+	s := &slayers.SCION{}
+	require.NoError(t, p.Dataplane().SetPath(s))
+	require.Equal(t, onehop.PathType, s.PathType)
+	ohp := s.Path.(*onehop.Path)
+	assert.Equal(t, uint16(4), ohp.FirstHop.ConsEgress)
+	mac.Reset()
+	expected := path.MAC(mac, ohp.Info, ohp.FirstHop, nil)
+	assert.Equal(t, expected, ohp.FirstHop.Mac)
+	assert.Equal(t, path.HopField{}, ohp.SecondHop)
+}
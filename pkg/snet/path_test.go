@@ -0,0 +1,54 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+func TestFingerprint(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	remoteIA := xtest.MustParseIA("1-ff00:0:112")
+
+	a := snet.Fingerprint(newTestPath(localIA, remoteIA, 1, 10*time.Millisecond))
+	// Metadata other than the interfaces does not influence the fingerprint.
+	assert.Equal(t, a, snet.Fingerprint(newTestPath(localIA, remoteIA, 1, 0)))
+	assert.NotEqual(t, a, snet.Fingerprint(newTestPath(localIA, remoteIA, 2, 0)))
+
+	parsed, err := snet.ParsePathFingerprint(a.String())
+	require.NoError(t, err)
+	assert.Equal(t, a, parsed)
+}
+
+func TestParsePathFingerprint(t *testing.T) {
+	testCases := map[string]string{
+		"not hex":   "xyz",
+		"too short": "abcd",
+		"empty":     "",
+	}
+	for name, input := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := snet.ParsePathFingerprint(input)
+			assert.Error(t, err)
+		})
+	}
+}
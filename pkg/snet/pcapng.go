@@ -0,0 +1,150 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/topology/underlay"
+	"github.com/scionproto/scion/private/underlay/pcap"
+)
+
+// PcapngEnv is the environment variable holding the path of the pcapng file
+// that the connections created by the packet dispatcher services write their
+// packets to, see OpenPcapngFromEnv.
+const PcapngEnv = "SCION_PCAPNG"
+
+// PcapngWriter writes SCION packets to a pcapng file for debugging. Each
+// packet is wrapped in IP and UDP headers with its underlay addresses, so
+// that Wireshark dissects it as SCION without further configuration. The
+// writer is safe for concurrent use.
+type PcapngWriter struct {
+	mu     sync.Mutex
+	w      *pcapgo.NgWriter
+	closer io.Closer
+	buf    gopacket.SerializeBuffer
+}
+
+// NewPcapngWriter writes the pcapng section header to w and returns a writer
+// that appends the packets to it.
+func NewPcapngWriter(w io.Writer) (*PcapngWriter, error) {
+	ngw, err := pcapgo.NewNgWriter(w, layers.LinkTypeRaw)
+	if err != nil {
+		return nil, serrors.WrapStr("writing pcapng header", err)
+	}
+	if err := ngw.Flush(); err != nil {
+		return nil, serrors.WrapStr("writing pcapng header", err)
+	}
+	return &PcapngWriter{w: ngw, buf: gopacket.NewSerializeBuffer()}, nil
+}
+
+// CreatePcapngFile creates the file with the given path and returns a writer
+// for it. The file is closed when the writer is closed.
+func CreatePcapngFile(path string) (*PcapngWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, serrors.WrapStr("creating pcapng file", err)
+	}
+	w, err := NewPcapngWriter(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	w.closer = f
+	return w, nil
+}
+
+var pcapngFromEnv struct {
+	once sync.Once
+	w    *PcapngWriter
+}
+
+// OpenPcapngFromEnv returns the writer for the file in the SCION_PCAPNG
+// environment variable, or nil if it is not set or the file cannot be
+// created. The file is created once per process and shared by all callers.
+func OpenPcapngFromEnv() *PcapngWriter {
+	pcapngFromEnv.once.Do(func() {
+		path, ok := os.LookupEnv(PcapngEnv)
+		if !ok || path == "" {
+			return
+		}
+		w, err := CreatePcapngFile(path)
+		if err != nil {
+			log.Info("Failed to open pcapng file", "path", path, "err", err)
+			return
+		}
+		log.Info("Writing SCION packets to pcapng file", "path", path)
+		pcapngFromEnv.w = w
+	})
+	return pcapngFromEnv.w
+}
+
+// WritePacket writes the raw SCION packet, sent from src to dst in the
+// underlay. Nil addresses are replaced with the unspecified address and the
+// end host port.
+func (w *PcapngWriter) WritePacket(raw []byte, src, dst *net.UDPAddr) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := pcap.Serialize(w.buf, raw, src, dst); err != nil {
+		return serrors.WrapStr("serializing underlay headers", err)
+	}
+	data := w.buf.Bytes()
+	err := w.w.WritePacket(gopacket.CaptureInfo{
+		Timestamp:     time.Now(),
+		CaptureLength: len(data),
+		Length:        len(data),
+	}, data)
+	if err != nil {
+		return err
+	}
+	// Flush every packet, so that the file can be inspected while the
+	// application is running.
+	return w.w.Flush()
+}
+
+// Close flushes the writer and closes the underlying file, if it was created
+// by CreatePcapngFile.
+func (w *PcapngWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.w.Flush()
+	if w.closer != nil {
+		if cerr := w.closer.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// hostUnderlay returns the underlay address of the end host with the given
+// SCION host address. It is used for the side of a packet whose underlay
+// address is not known to the connection.
+func hostUnderlay(host addr.Host) *net.UDPAddr {
+	if host.Type() != addr.HostTypeIP {
+		return nil
+	}
+	return &net.UDPAddr{IP: host.IP().AsSlice(), Port: underlay.EndhostPort}
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"bytes"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestPcapng(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	localhost := netip.MustParseAddr("127.0.0.1")
+
+	var buf bytes.Buffer
+	w, err := snet.NewPcapngWriter(&buf)
+	require.NoError(t, err)
+
+	a, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer a.Close()
+	b, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer b.Close()
+	aConn := &snet.SCIONPacketConn{Conn: a, Pcapng: w}
+	bConn := &snet.SCIONPacketConn{Conn: b, Pcapng: w}
+
+	payload := snet.UDPPayload{SrcPort: 1234, DstPort: 40000, Payload: []byte("data")}
+	require.NoError(t, aConn.WriteTo(&snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Source:      snet.SCIONAddress{IA: ia, Host: addr.HostIP(localhost)},
+			Destination: snet.SCIONAddress{IA: ia, Host: addr.HostIP(localhost)},
+			Path:        snetpath.Empty{},
+			Payload:     payload,
+		},
+	}, b.LocalAddr().(*net.UDPAddr)))
+	require.NoError(t, bConn.SetReadDeadline(time.Now().Add(time.Second)))
+	var pkt snet.Packet
+	require.NoError(t, bConn.ReadFrom(&pkt, nil))
+	require.NoError(t, w.Close())
+
+	r, err := pcapgo.NewNgReader(&buf, pcapgo.DefaultNgReaderOptions)
+	require.NoError(t, err)
+	assert.Equal(t, layers.LinkTypeRaw, r.LinkType())
+	// The packet is recorded once when it is sent and once when it is
+	// received, both times with the underlay addresses of the sockets.
+	for i := 0; i < 2; i++ {
+		data, _, err := r.ReadPacketData()
+		require.NoError(t, err)
+		decoded := gopacket.NewPacket(data, layers.LayerTypeIPv4, gopacket.Default)
+		udp, ok := decoded.Layer(layers.LayerTypeUDP).(*layers.UDP)
+		require.True(t, ok)
+		assert.Equal(t, a.LocalAddr().(*net.UDPAddr).Port, int(udp.SrcPort))
+		assert.Equal(t, b.LocalAddr().(*net.UDPAddr).Port, int(udp.DstPort))
+
+		var scn slayers.SCION
+		require.NoError(t, scn.DecodeFromBytes(udp.Payload, gopacket.NilDecodeFeedback))
+		assert.Equal(t, ia, scn.SrcIA)
+		assert.Equal(t, slayers.L4UDP, scn.NextHdr)
+	}
+	_, _, err = r.ReadPacketData()
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resolver maps names to SCION addresses. The addresses of a name are
+// published in DNS TXT records of the form:
+//
+//	scion=1-ff00:0:110,192.0.2.1
+//
+// A name can have multiple such records; TXT records without the scion=
+// prefix are ignored. The lookups are done with a pluggable Backend, by
+// default the system DNS resolver, and the results are cached.
+package resolver
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+const (
+	// DefaultTTL is the default time for which lookup results are cached.
+	DefaultTTL = 5 * time.Minute
+
+	txtPrefix = "scion="
+)
+
+// ErrNoAddress indicates that a name has no SCION address.
+var ErrNoAddress = serrors.New("no SCION address")
+
+// Default is the resolver that uses the system DNS resolver.
+var Default = &Resolver{}
+
+// Backend looks up the TXT records of a name. *net.Resolver implements
+// Backend.
+type Backend interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Resolver resolves names to SCION addresses. It is safe for concurrent use.
+// The zero value uses the system DNS resolver and the default TTL.
+type Resolver struct {
+	// Backend is used to look up the TXT records. If nil, net.DefaultResolver
+	// is used.
+	Backend Backend
+	// TTL is the time for which lookup results are cached. If zero,
+	// DefaultTTL is used. If negative, the results are not cached.
+	TTL time.Duration
+
+	mtx   sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	addrs   []addr.Addr
+	expires time.Time
+}
+
+// LookupAddr returns the SCION addresses of the name. If the name has no
+// SCION address, an error wrapping ErrNoAddress is returned.
+func (r *Resolver) LookupAddr(ctx context.Context, name string) ([]addr.Addr, error) {
+	key := strings.ToLower(strings.TrimSuffix(name, "."))
+	if addrs, ok := r.cached(key); ok {
+		return addrs, nil
+	}
+	backend := r.Backend
+	if backend == nil {
+		backend = net.DefaultResolver
+	}
+	records, err := backend.LookupTXT(ctx, name)
+	if err != nil {
+		return nil, serrors.WrapStr("looking up TXT records", err, "name", name)
+	}
+	addrs, err := ParseRecords(records)
+	if err != nil {
+		return nil, serrors.WithCtx(err, "name", name)
+	}
+	if len(addrs) == 0 {
+		return nil, serrors.WithCtx(ErrNoAddress, "name", name)
+	}
+	r.store(key, addrs)
+	return copyAddrs(addrs), nil
+}
+
+// ResolveIA resolves s to an ISD-AS. s is either an ISD-AS, e.g.,
+// "1-ff00:0:110", or a name. For names, the ISD-AS of the first address is
+// returned.
+func (r *Resolver) ResolveIA(ctx context.Context, s string) (addr.IA, error) {
+	if ia, err := addr.ParseIA(s); err == nil {
+		return ia, nil
+	}
+	addrs, err := r.LookupAddr(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+	return addrs[0].IA, nil
+}
+
+// ResolveUDPAddr resolves address to a SCION UDP address. address is either
+// a SCION address, e.g., "1-ff00:0:110,192.0.2.1:8080", or a name with an
+// optional port, e.g., "example.org:8080". For names, the first address is
+// returned.
+func (r *Resolver) ResolveUDPAddr(ctx context.Context, address string) (*snet.UDPAddr, error) {
+	if a, err := snet.ParseUDPAddr(address); err == nil {
+		return a, nil
+	}
+	host, port := address, 0
+	if h, p, err := net.SplitHostPort(address); err == nil {
+		if port, err = strconv.Atoi(p); err != nil || port < 0 || port > 65535 {
+			return nil, serrors.New("invalid port", "addr", address)
+		}
+		host = h
+	}
+	addrs, err := r.LookupAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ip := addrs[0].Host.IP()
+	return &snet.UDPAddr{
+		IA: addrs[0].IA,
+		Host: &net.UDPAddr{
+			IP:   ip.AsSlice(),
+			Zone: ip.Zone(),
+			Port: port,
+		},
+	}, nil
+}
+
+func (r *Resolver) cached(key string) ([]addr.Addr, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	e, ok := r.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expires) {
+		delete(r.cache, key)
+		return nil, false
+	}
+	return copyAddrs(e.addrs), true
+}
+
+func (r *Resolver) store(key string, addrs []addr.Addr) {
+	ttl := r.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	if ttl < 0 {
+		return
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.cache == nil {
+		r.cache = make(map[string]cacheEntry)
+	}
+	r.cache[key] = cacheEntry{addrs: addrs, expires: time.Now().Add(ttl)}
+}
+
+// ParseRecords parses the SCION addresses in the TXT records. Records without
+// the scion= prefix are ignored. Only IP host addresses are supported.
+func ParseRecords(records []string) ([]addr.Addr, error) {
+	var addrs []addr.Addr
+	for _, record := range records {
+		if !strings.HasPrefix(record, txtPrefix) {
+			continue
+		}
+		raw := strings.TrimSpace(strings.TrimPrefix(record, txtPrefix))
+		a, err := addr.ParseAddr(raw)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing TXT record", err, "record", record)
+		}
+		if a.Host.Type() != addr.HostTypeIP {
+			return nil, serrors.New("unsupported host type", "record", record,
+				"type", a.Host.Type())
+		}
+		addrs = append(addrs, a)
+	}
+	return addrs, nil
+}
+
+func copyAddrs(addrs []addr.Addr) []addr.Addr {
+	return append([]addr.Addr(nil), addrs...)
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet/resolver"
+)
+
+// backend is a Backend that serves static records and counts the lookups.
+type backend struct {
+	records map[string][]string
+	lookups int
+}
+
+func (b *backend) LookupTXT(_ context.Context, name string) ([]string, error) {
+	b.lookups++
+	records, ok := b.records[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func TestParseRecords(t *testing.T) {
+	testCases := map[string]struct {
+		records   []string
+		want      []addr.Addr
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"ipv4 and ipv6": {
+			records: []string{
+				"scion=1-ff00:0:110,192.0.2.1",
+				"scion=1-ff00:0:111,2001:db8::1",
+			},
+			want: []addr.Addr{
+				addr.MustParseAddr("1-ff00:0:110,192.0.2.1"),
+				addr.MustParseAddr("1-ff00:0:111,2001:db8::1"),
+			},
+			assertErr: assert.NoError,
+		},
+		"other records ignored": {
+			records: []string{
+				"v=spf1 -all",
+				"scion=1-ff00:0:110,192.0.2.1",
+			},
+			want:      []addr.Addr{addr.MustParseAddr("1-ff00:0:110,192.0.2.1")},
+			assertErr: assert.NoError,
+		},
+		"no records": {
+			records:   []string{"v=spf1 -all"},
+			assertErr: assert.NoError,
+		},
+		"malformed": {
+			records:   []string{"scion=1-ff00:0:110"},
+			assertErr: assert.Error,
+		},
+		"svc": {
+			records:   []string{"scion=1-ff00:0:110,CS"},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := resolver.ParseRecords(tc.records)
+			tc.assertErr(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestResolveUDPAddr(t *testing.T) {
+	b := &backend{records: map[string][]string{
+		"example.org": {"scion=1-ff00:0:110,192.0.2.1"},
+		"ip.only":     {"v=spf1 -all"},
+	}}
+	r := &resolver.Resolver{Backend: b}
+	ctx := context.Background()
+
+	t.Run("scion address", func(t *testing.T) {
+		got, err := r.ResolveUDPAddr(ctx, "1-ff00:0:111,192.0.2.2:80")
+		require.NoError(t, err)
+		assert.Equal(t, "1-ff00:0:111,192.0.2.2:80", got.String())
+	})
+	t.Run("name with port", func(t *testing.T) {
+		got, err := r.ResolveUDPAddr(ctx, "example.org:8080")
+		require.NoError(t, err)
+		assert.Equal(t, "1-ff00:0:110,192.0.2.1:8080", got.String())
+	})
+	t.Run("name without port", func(t *testing.T) {
+		got, err := r.ResolveUDPAddr(ctx, "example.org")
+		require.NoError(t, err)
+		assert.Equal(t, "1-ff00:0:110,192.0.2.1:0", got.String())
+	})
+	t.Run("invalid port", func(t *testing.T) {
+		_, err := r.ResolveUDPAddr(ctx, "example.org:http")
+		assert.Error(t, err)
+	})
+	t.Run("no scion address", func(t *testing.T) {
+		_, err := r.ResolveUDPAddr(ctx, "ip.only")
+		assert.True(t, errors.Is(err, resolver.ErrNoAddress), err)
+	})
+	t.Run("unknown name", func(t *testing.T) {
+		_, err := r.ResolveUDPAddr(ctx, "unknown.org:80")
+		assert.Error(t, err)
+	})
+}
+
+func TestResolveIA(t *testing.T) {
+	b := &backend{records: map[string][]string{
+		"example.org": {"scion=1-ff00:0:110,192.0.2.1"},
+	}}
+	r := &resolver.Resolver{Backend: b}
+
+	ia, err := r.ResolveIA(context.Background(), "1-ff00:0:111")
+	require.NoError(t, err)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:111"), ia)
+	assert.Zero(t, b.lookups)
+
+	ia, err = r.ResolveIA(context.Background(), "example.org")
+	require.NoError(t, err)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:110"), ia)
+}
+
+func TestResolverCache(t *testing.T) {
+	records := map[string][]string{
+		"example.org": {"scion=1-ff00:0:110,192.0.2.1"},
+	}
+	t.Run("cached", func(t *testing.T) {
+		b := &backend{records: records}
+		r := &resolver.Resolver{Backend: b}
+		for i := 0; i < 3; i++ {
+			_, err := r.LookupAddr(context.Background(), "example.org")
+			require.NoError(t, err)
+		}
+		_, err := r.LookupAddr(context.Background(), "Example.org.")
+		require.NoError(t, err)
+		assert.Equal(t, 1, b.lookups)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		b := &backend{records: records}
+		r := &resolver.Resolver{Backend: b, TTL: -1}
+		for i := 0; i < 3; i++ {
+			_, err := r.LookupAddr(context.Background(), "example.org")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 3, b.lookups)
+	})
+	t.Run("errors not cached", func(t *testing.T) {
+		b := &backend{records: records}
+		r := &resolver.Resolver{Backend: b}
+		for i := 0; i < 2; i++ {
+			_, err := r.LookupAddr(context.Background(), "unknown.org")
+			require.Error(t, err)
+		}
+		assert.Equal(t, 2, b.lookups)
+	})
+}
@@ -30,11 +30,18 @@
 //
 // Multiple networking contexts can share the same SCIOND and/or dispatcher.
 //
+// On dedicated hosts without dispatcher, a networking context can use the
+// DirectPacketDispatcherService, which binds the underlay socket in the
+// application itself. Only one connection per host address is possible in
+// this mode.
+//
 // Write calls never return SCMP errors directly. If a write call caused an
 // SCMP message to be received by the Conn, it can be inspected by calling
 // Read. In this case, the error value is non-nil and can be type asserted to
 // *OpError. Method SCMP() can be called on the error to extract the SCMP
-// header.
+// header. If the SCMP message reported that an interface on the path is down,
+// errors.As can be used to extract a *PathDownError that contains the failed
+// interface.
 //
 // Important: not draining SCMP errors via Read calls can cause the dispatcher
 // to shutdown the socket (see https://github.com/scionproto/scion/pull/1356).
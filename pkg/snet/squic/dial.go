@@ -0,0 +1,273 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package squic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/addrutil"
+	"github.com/scionproto/scion/pkg/snet/resolver"
+)
+
+// Dialer dials QUIC connections over SCION. Every connection uses its own
+// SCION connection, whose path is chosen by the policy and switched to an
+// alternate path if it fails. The server follows the path switch, as it
+// replies on the reversed path of the received packets.
+//
+// The DialEarly method has the signature of the Dial function of the
+// quic-go HTTP/3 round tripper, so that HTTP/3 requests can be sent over
+// SCION with:
+//
+//	rt := &http3.RoundTripper{Dial: dialer.DialEarly}
+type Dialer struct {
+	// Network is the SCION network on which the connections are opened.
+	Network *snet.SCIONNetwork
+	// Router is used to look up the paths to the remote.
+	Router snet.Router
+	// Resolver resolves names to SCION addresses. If nil, resolver.Default is
+	// used.
+	Resolver *resolver.Resolver
+	// LocalIP is the local IP address of the connections. If nil, it is
+	// chosen based on the underlay next hop of the path.
+	LocalIP net.IP
+	// Policy, if set, is applied to the paths to the remote, e.g. the Filter
+	// method of a path policy. The first path that passes the policy is used.
+	Policy func([]snet.Path) []snet.Path
+	// FailoverTimeout is the time after which the path is switched if packets
+	// are sent, but none is received. If zero, the path is only switched on
+	// revocations.
+	FailoverTimeout time.Duration
+	// OnPathSwitch, if set, is called after the path of a connection was
+	// switched.
+	OnPathSwitch func(remote *snet.UDPAddr, s snet.PathSwitch)
+}
+
+// Dial dials a QUIC connection to the address, which is either a SCION
+// address, e.g., "1-ff00:0:110,10.0.0.1:443", or a name with a port, e.g.,
+// "example.org:443". If the server name of the TLS configuration is not set,
+// it is derived from the address.
+func (d *Dialer) Dial(ctx context.Context, address string, tlsCfg *tls.Config,
+	cfg *quic.Config) (quic.Connection, error) {
+
+	conn, remote, tlsCfg, err := d.prepare(ctx, address, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	session, err := quic.Dial(ctx, conn, remote, tlsCfg, cfg)
+	if err != nil {
+		conn.Close()
+		return nil, serrors.WrapStr("dialing QUIC/SCION", err)
+	}
+	closeOnDone(session, conn)
+	return session, nil
+}
+
+// DialEarly is the same as Dial, except that it returns a connection that can
+// be used before the handshake completes.
+func (d *Dialer) DialEarly(ctx context.Context, address string, tlsCfg *tls.Config,
+	cfg *quic.Config) (quic.EarlyConnection, error) {
+
+	conn, remote, tlsCfg, err := d.prepare(ctx, address, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	session, err := quic.DialEarly(ctx, conn, remote, tlsCfg, cfg)
+	if err != nil {
+		conn.Close()
+		return nil, serrors.WrapStr("dialing QUIC/SCION", err)
+	}
+	closeOnDone(session, conn)
+	return session, nil
+}
+
+// prepare resolves the address and opens the SCION connection to it. It
+// returns the TLS configuration with the server name set.
+func (d *Dialer) prepare(ctx context.Context, address string,
+	tlsCfg *tls.Config) (*failoverPacketConn, *snet.UDPAddr, *tls.Config, error) {
+
+	if tlsCfg == nil {
+		return nil, nil, nil, serrors.New("tls.Config not set")
+	}
+	r := d.Resolver
+	if r == nil {
+		r = resolver.Default
+	}
+	remote, err := r.ResolveUDPAddr(ctx, address)
+	if err != nil {
+		return nil, nil, nil, serrors.WrapStr("resolving address", err, "addr", address)
+	}
+	conn, err := d.open(ctx, remote)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tlsCfg = tlsCfg.Clone()
+	if tlsCfg.ServerName == "" {
+		tlsCfg.ServerName = dialServerName(address, remote)
+	}
+	return conn, remote, tlsCfg, nil
+}
+
+func (d *Dialer) open(ctx context.Context, remote *snet.UDPAddr) (*failoverPacketConn, error) {
+	paths, err := d.Router.AllRoutes(ctx, remote.IA)
+	if err != nil {
+		return nil, serrors.WrapStr("looking up paths", err, "dst", remote.IA)
+	}
+	if d.Policy != nil {
+		paths = d.Policy(paths)
+	}
+	if len(paths) == 0 {
+		return nil, serrors.New("no path available", "dst", remote.IA)
+	}
+	path := paths[0]
+	localIP := d.LocalIP
+	if localIP == nil {
+		// In the local AS, the next hop is the remote host itself.
+		nextHop := remote.Host.IP
+		if path.UnderlayNextHop() != nil {
+			nextHop = path.UnderlayNextHop().IP
+		}
+		if localIP, err = addrutil.ResolveLocal(nextHop); err != nil {
+			return nil, serrors.WrapStr("resolving local address", err)
+		}
+	}
+	conn, err := d.Network.Dial(ctx, "udp", &net.UDPAddr{IP: localIP}, remote, addr.SvcNone)
+	if err != nil {
+		return nil, serrors.WrapStr("dialing SCION", err)
+	}
+	cfg := snet.FailoverConfig{
+		Router:  d.Router,
+		Filter:  d.Policy,
+		Timeout: d.FailoverTimeout,
+	}
+	if d.OnPathSwitch != nil {
+		cfg.OnSwitch = func(s snet.PathSwitch) { d.OnPathSwitch(remote, s) }
+	}
+	fconn, err := snet.NewFailoverConn(conn, path, cfg)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &failoverPacketConn{FailoverConn: fconn}, nil
+}
+
+// dialServerName returns the TLS server name for the dialed address. For
+// names, the name itself is used.
+func dialServerName(address string, remote *snet.UDPAddr) string {
+	if _, err := snet.ParseUDPAddr(address); err == nil {
+		return computeServerName(remote)
+	}
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return address
+}
+
+// closeOnDone closes the connection once the QUIC session is closed. QUIC
+// does not close connections that it did not open itself.
+func closeOnDone(session quic.Connection, conn net.PacketConn) {
+	go func() {
+		defer log.HandlePanic()
+		<-session.Context().Done()
+		conn.Close()
+	}()
+}
+
+// ListenPacket opens a SCION connection on the local address for use as QUIC
+// transport, e.g., with the Serve method of the quic-go HTTP/3 server. The
+// replies are sent on the reversed path of the received packets, so that the
+// server follows path switches of the clients. SCMP errors are not returned
+// by ReadFrom, as they would make QUIC close the transport.
+func ListenPacket(ctx context.Context, n *snet.SCIONNetwork,
+	local *net.UDPAddr) (net.PacketConn, error) {
+
+	conn, err := n.Listen(ctx, "udp", local, addr.SvcNone)
+	if err != nil {
+		return nil, serrors.WrapStr("listening SCION", err)
+	}
+	return newPacketConn(conn), nil
+}
+
+// Listen listens for QUIC connections on the local address. The Accept method
+// of the returned listener returns the first stream of every connection as a
+// net.Conn. Closing the listener closes the SCION connection.
+func Listen(ctx context.Context, n *snet.SCIONNetwork, local *net.UDPAddr,
+	tlsCfg *tls.Config, cfg *quic.Config) (*ConnListener, error) {
+
+	conn, err := ListenPacket(ctx, n, local)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := quic.Listen(conn, tlsCfg, cfg)
+	if err != nil {
+		conn.Close()
+		return nil, serrors.WrapStr("listening QUIC/SCION", err)
+	}
+	l := NewConnListener(listener)
+	l.conn = conn
+	return l, nil
+}
+
+// packetConn wraps a SCION connection and drops the SCMP errors.
+type packetConn struct {
+	net.PacketConn
+}
+
+func newPacketConn(conn net.PacketConn) *packetConn {
+	return &packetConn{PacketConn: conn}
+}
+
+func (c *packetConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	for {
+		n, a, err := c.PacketConn.ReadFrom(b)
+		if !isSCMPError(err) {
+			return n, a, err
+		}
+	}
+}
+
+// failoverPacketConn adapts a connection with failover to a net.PacketConn.
+// All packets are sent to the remote of the connection over the current path,
+// regardless of the destination address passed to WriteTo.
+type failoverPacketConn struct {
+	*snet.FailoverConn
+}
+
+func (c *failoverPacketConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	for {
+		n, err := c.FailoverConn.Read(b)
+		if !isSCMPError(err) {
+			return n, c.FailoverConn.RemoteAddr(), err
+		}
+	}
+}
+
+func (c *failoverPacketConn) WriteTo(b []byte, _ net.Addr) (int, error) {
+	return c.FailoverConn.Write(b)
+}
+
+func isSCMPError(err error) bool {
+	var opErr *snet.OpError
+	return errors.As(err, &opErr)
+}
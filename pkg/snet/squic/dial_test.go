@@ -0,0 +1,111 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package squic_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/mock_snet"
+	"github.com/scionproto/scion/pkg/snet/resolver"
+	"github.com/scionproto/scion/pkg/snet/squic"
+)
+
+// scmpConn is a net.PacketConn that returns the given number of SCMP errors
+// before returning a packet.
+type scmpConn struct {
+	net.PacketConn
+	errors int
+}
+
+func (c *scmpConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	if c.errors > 0 {
+		c.errors--
+		return 0, nil, &snet.OpError{}
+	}
+	return copy(b, "packet"), &net.UDPAddr{}, nil
+}
+
+func TestPacketConnDropsSCMPErrors(t *testing.T) {
+	conn := squic.NewPacketConn(&scmpConn{errors: 3})
+	buf := make([]byte, 16)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "packet", string(buf[:n]))
+}
+
+func TestDialServerName(t *testing.T) {
+	testCases := map[string]struct {
+		address string
+		want    string
+	}{
+		"scion address": {
+			address: "1-ff00:0:110,10.0.0.1:443",
+			want:    "1-ff00:0:110,10.0.0.1",
+		},
+		"name with port": {
+			address: "example.org:443",
+			want:    "example.org",
+		},
+		"name": {
+			address: "example.org",
+			want:    "example.org",
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			remote := &snet.UDPAddr{
+				IA:   xtest.MustParseIA("1-ff00:0:110"),
+				Host: &net.UDPAddr{IP: net.IP{10, 0, 0, 1}, Port: 443},
+			}
+			assert.Equal(t, tc.want, squic.DialServerName(tc.address, remote))
+		})
+	}
+}
+
+func TestDialerPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := mock_snet.NewMockPath(ctrl)
+	router := mock_snet.NewMockRouter(ctrl)
+	router.EXPECT().AllRoutes(gomock.Any(), xtest.MustParseIA("1-ff00:0:110")).
+		Return([]snet.Path{path}, nil)
+
+	var filtered []snet.Path
+	d := &squic.Dialer{
+		Network:  &snet.SCIONNetwork{},
+		Router:   router,
+		Resolver: &resolver.Resolver{},
+		Policy: func(paths []snet.Path) []snet.Path {
+			filtered = paths
+			return nil
+		},
+	}
+	_, err := d.DialEarly(context.Background(), "1-ff00:0:110,10.0.0.1:443",
+		&tls.Config{}, nil)
+	assert.ErrorContains(t, err, "no path available")
+	assert.Equal(t, []snet.Path{path}, filtered)
+}
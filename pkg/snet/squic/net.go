@@ -52,6 +52,8 @@ type ConnListener struct {
 
 	ctx    context.Context
 	cancel func()
+	// conn is the transport opened by Listen, if any.
+	conn net.PacketConn
 }
 
 // NewConnListener constructs a new listener with the appropriate buffers set.
@@ -84,10 +86,17 @@ func (l *ConnListener) AcceptCtx(ctx context.Context) (net.Conn, error) {
 	return newAcceptingConn(ctx, session), nil
 }
 
-// Close closes the listener.
+// Close closes the listener. If the listener was created by Listen, the
+// SCION connection is closed as well.
 func (l *ConnListener) Close() error {
 	l.cancel()
-	return l.Listener.Close()
+	err := l.Listener.Close()
+	if l.conn != nil {
+		if connErr := l.conn.Close(); err == nil {
+			err = connErr
+		}
+	}
+	return err
 }
 
 // acceptingConn is a net.Conn wrapper for a QUIC stream that is yet to
@@ -0,0 +1,326 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sudp provides a high-level API for SCION/UDP connections. It
+// connects to the SCION daemon and the dispatcher, looks up and selects paths
+// and handles SCMP messages with sane defaults, so that simple applications
+// only need to provide the address strings:
+//
+//	conn, err := sudp.DialUDP(ctx, "1-ff00:0:110,10.0.0.1:8080")
+//	...
+//	conn, err := sudp.DialUDP(ctx, "example.org:8080")
+//	...
+//	conn, err := sudp.ListenUDP(ctx, ":8080")
+//
+// Names are resolved to SCION addresses with the DNS TXT records described in
+// package resolver.
+//
+// The addresses of the daemon and the dispatcher are taken from the
+// SCION_DAEMON and SCION_DISPATCHER environment variables, or the system
+// defaults if they are not set. They can be overridden with options.
+//
+// For debugging, the sent and received packets are written to the pcapng file
+// in the SCION_PCAPNG environment variable, if it is set. The file can be
+// opened directly in Wireshark.
+package sudp
+
+import (
+	"context"
+	"net"
+	"os"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/addrutil"
+	"github.com/scionproto/scion/pkg/snet/resolver"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+)
+
+const (
+	// DaemonEnv is the environment variable holding the address of the SCION
+	// daemon.
+	DaemonEnv = "SCION_DAEMON"
+	// DispatcherEnv is the environment variable holding the path of the
+	// dispatcher socket.
+	DispatcherEnv = "SCION_DISPATCHER"
+
+	connectTimeout = 5 * time.Second
+)
+
+// Option configures DialUDP and ListenUDP.
+type Option func(*options)
+
+type options struct {
+	daemonAddr      string
+	connector       daemon.Connector
+	dispatcher      snet.PacketDispatcherService
+	dispatcherPath  string
+	filter          func([]snet.Path) []snet.Path
+	failoverTimeout time.Duration
+	pcapng          *snet.PcapngWriter
+	resolver        *resolver.Resolver
+}
+
+// WithDaemon sets the address of the SCION daemon.
+func WithDaemon(address string) Option {
+	return func(o *options) { o.daemonAddr = address }
+}
+
+// WithConnector uses the given daemon connection instead of connecting to the
+// SCION daemon. The connection is not closed when the returned connection is
+// closed.
+func WithConnector(conn daemon.Connector) Option {
+	return func(o *options) { o.connector = conn }
+}
+
+// WithDispatcher sets the path of the dispatcher socket.
+func WithDispatcher(path string) Option {
+	return func(o *options) { o.dispatcherPath = path }
+}
+
+// WithPolicy sets the filter that is applied to the paths to the remote, e.g.
+// the Filter method of a path policy. The first path that passes the filter
+// is used.
+func WithPolicy(filter func([]snet.Path) []snet.Path) Option {
+	return func(o *options) { o.filter = filter }
+}
+
+// WithFailoverTimeout sets the time after which the path of a dialed
+// connection is switched if packets are sent, but none is received. By
+// default, the path is only switched on revocations.
+func WithFailoverTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.failoverTimeout = timeout }
+}
+
+// WithPcapng writes the sent and received packets to the given writer instead
+// of the file in the SCION_PCAPNG environment variable.
+func WithPcapng(w *snet.PcapngWriter) Option {
+	return func(o *options) { o.pcapng = w }
+}
+
+// WithResolver sets the resolver that is used to resolve the remote name in
+// DialUDP. By default, resolver.Default is used.
+func WithResolver(r *resolver.Resolver) Option {
+	return func(o *options) { o.resolver = r }
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		daemonAddr:     daemon.DefaultAPIAddress,
+		dispatcherPath: reliable.DefaultDispPath,
+		resolver:       resolver.Default,
+	}
+	if a, ok := os.LookupEnv(DaemonEnv); ok {
+		o.daemonAddr = a
+	}
+	if path, ok := os.LookupEnv(DispatcherEnv); ok {
+		o.dispatcherPath = path
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Conn is a connection to a fixed remote returned by DialUDP. It switches to
+// an alternate path if the current path fails.
+type Conn struct {
+	*snet.FailoverConn
+	sd     daemon.Connector
+	ownsSD bool
+}
+
+// Close closes the connection and the daemon connection, if it was opened by
+// DialUDP.
+func (c *Conn) Close() error {
+	return closeAll(c.FailoverConn.Close(), c.sd, c.ownsSD)
+}
+
+// ListenConn is a connection returned by ListenUDP. The addresses returned by
+// ReadFrom contain the reply path, so that they can directly be passed to
+// WriteTo.
+type ListenConn struct {
+	*snet.Conn
+	sd     daemon.Connector
+	ownsSD bool
+}
+
+// Close closes the connection and the daemon connection, if it was opened by
+// ListenUDP.
+func (c *ListenConn) Close() error {
+	return closeAll(c.Conn.Close(), c.sd, c.ownsSD)
+}
+
+// DialUDP opens a connection to the remote address, e.g.,
+// "1-ff00:0:110,10.0.0.1:8080", or name, e.g., "example.org:8080". The first
+// path to the remote that passes the policy is used, the local address is
+// chosen based on the path.
+//
+// The context is used for the connection setup, it doesn't affect the
+// returned connection.
+func DialUDP(ctx context.Context, remote string, opts ...Option) (*Conn, error) {
+	o := newOptions(opts)
+	raddr, err := o.resolver.ResolveUDPAddr(ctx, remote)
+	if err != nil {
+		return nil, serrors.WrapStr("resolving remote address", err, "addr", remote)
+	}
+	sd, ownsSD, err := o.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := dial(ctx, sd, raddr, o)
+	if err != nil {
+		return nil, closeAll(err, sd, ownsSD)
+	}
+	return &Conn{FailoverConn: conn, sd: sd, ownsSD: ownsSD}, nil
+}
+
+func dial(ctx context.Context, sd daemon.Connector, remote *snet.UDPAddr,
+	o *options) (*snet.FailoverConn, error) {
+
+	localIA, err := sd.LocalIA(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("querying local ISD-AS", err)
+	}
+	router := &snet.BaseRouter{Querier: daemon.Querier{Connector: sd, IA: localIA}}
+	paths, err := router.AllRoutes(ctx, remote.IA)
+	if err != nil {
+		return nil, serrors.WrapStr("looking up paths", err, "dst", remote.IA)
+	}
+	if o.filter != nil {
+		paths = o.filter(paths)
+	}
+	if len(paths) == 0 {
+		return nil, serrors.New("no path available", "dst", remote.IA)
+	}
+	path := paths[0]
+	// Resolve the local IP based on the underlay next hop. In the local AS,
+	// the next hop is the remote host itself.
+	nextHop := remote.Host.IP
+	if path.UnderlayNextHop() != nil {
+		nextHop = path.UnderlayNextHop().IP
+	}
+	localIP, err := addrutil.ResolveLocal(nextHop)
+	if err != nil {
+		return nil, serrors.WrapStr("resolving local address", err)
+	}
+	conn, err := o.network(sd, localIA).Dial(ctx, "udp", &net.UDPAddr{IP: localIP},
+		remote, addr.SvcNone)
+	if err != nil {
+		return nil, serrors.WrapStr("dialing", err)
+	}
+	fconn, err := snet.NewFailoverConn(conn, path, snet.FailoverConfig{
+		Router:  router,
+		Filter:  o.filter,
+		Timeout: o.failoverTimeout,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return fconn, nil
+}
+
+// ListenUDP opens a connection on the local address, e.g., ":8080". If the
+// IP is omitted or unspecified, a default IP of this host in the local AS is
+// used.
+//
+// The context is used for the connection setup, it doesn't affect the
+// returned connection.
+func ListenUDP(ctx context.Context, local string, opts ...Option) (*ListenConn, error) {
+	laddr, err := net.ResolveUDPAddr("udp", local)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing local address", err, "addr", local)
+	}
+	o := newOptions(opts)
+	sd, ownsSD, err := o.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := listen(ctx, sd, laddr, o)
+	if err != nil {
+		return nil, closeAll(err, sd, ownsSD)
+	}
+	return &ListenConn{Conn: conn, sd: sd, ownsSD: ownsSD}, nil
+}
+
+func listen(ctx context.Context, sd daemon.Connector, local *net.UDPAddr,
+	o *options) (*snet.Conn, error) {
+
+	localIA, err := sd.LocalIA(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("querying local ISD-AS", err)
+	}
+	if local.IP == nil || local.IP.IsUnspecified() {
+		if local.IP, err = addrutil.DefaultLocalIP(ctx, sd); err != nil {
+			return nil, serrors.WrapStr("resolving default address", err)
+		}
+	}
+	conn, err := o.network(sd, localIA).Listen(ctx, "udp", local, addr.SvcNone)
+	if err != nil {
+		return nil, serrors.WrapStr("listening", err)
+	}
+	return conn, nil
+}
+
+// connect returns the daemon connection and whether it was opened by connect.
+func (o *options) connect(ctx context.Context) (daemon.Connector, bool, error) {
+	if o.connector != nil {
+		return o.connector, false, nil
+	}
+	ctx, cancelF := context.WithTimeout(ctx, connectTimeout)
+	defer cancelF()
+	sd, err := daemon.NewService(o.daemonAddr).Connect(ctx)
+	if err != nil {
+		return nil, false, serrors.WrapStr("connecting to SCION Daemon", err,
+			"addr", o.daemonAddr)
+	}
+	return sd, true, nil
+}
+
+func (o *options) network(sd daemon.Connector, localIA addr.IA) *snet.SCIONNetwork {
+	dispatcher := o.dispatcher
+	if dispatcher == nil {
+		pcapng := o.pcapng
+		if pcapng == nil {
+			pcapng = snet.OpenPcapngFromEnv()
+		}
+		dispatcher = &snet.DefaultPacketDispatcherService{
+			Dispatcher: reliable.NewDispatcher(o.dispatcherPath),
+			SCMPHandler: snet.DefaultSCMPHandler{
+				RevocationHandler: daemon.RevHandler{Connector: sd},
+			},
+			Pcapng: pcapng,
+		}
+	}
+	return &snet.SCIONNetwork{
+		LocalIA:    localIA,
+		Dispatcher: dispatcher,
+	}
+}
+
+// closeAll closes the daemon connection if it is owned and returns err, or
+// the error of closing the daemon connection if err is nil.
+func closeAll(err error, sd daemon.Connector, owned bool) error {
+	if !owned {
+		return err
+	}
+	if sdErr := sd.Close(); err == nil {
+		return sdErr
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sudp_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/daemon/mock_daemon"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/mock_snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/pkg/snet/sudp"
+)
+
+func TestDialUDP(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:111")
+	remote := xtest.MustParseIA("1-ff00:0:110")
+	paths := []snet.Path{testPath(local, remote, 1), testPath(local, remote, 2)}
+	secondOnly := func(ps []snet.Path) []snet.Path { return ps[1:] }
+
+	t.Run("valid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		sd := mock_daemon.NewMockConnector(ctrl)
+		sd.EXPECT().LocalIA(gomock.Any()).Return(local, nil)
+		sd.EXPECT().Paths(gomock.Any(), remote, local, daemon.PathReqFlags{}).
+			Return(paths, nil)
+		pconn := mock_snet.NewMockPacketConn(ctrl)
+		pconn.EXPECT().Close()
+		disp := mock_snet.NewMockPacketDispatcherService(ctrl)
+		disp.EXPECT().Register(gomock.Any(), local, gomock.Any(), addr.SvcNone).
+			Return(pconn, uint16(40000), nil)
+
+		conn, err := sudp.DialUDP(context.Background(), "1-ff00:0:110,127.0.0.1:8080",
+			sudp.WithConnector(sd),
+			sudp.WithPacketDispatcher(disp),
+			sudp.WithPolicy(secondOnly),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, snet.Fingerprint(paths[1]), snet.Fingerprint(conn.Path()))
+		laddr := conn.LocalAddr().(*snet.UDPAddr)
+		assert.Equal(t, local, laddr.IA)
+		assert.Equal(t, "127.0.0.1:40000", laddr.Host.String())
+		// The daemon connection is not owned by the connection, closing it
+		// must not close the daemon connection.
+		assert.NoError(t, conn.Close())
+	})
+	t.Run("no path", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		sd := mock_daemon.NewMockConnector(ctrl)
+		sd.EXPECT().LocalIA(gomock.Any()).Return(local, nil)
+		sd.EXPECT().Paths(gomock.Any(), remote, local, daemon.PathReqFlags{}).
+			Return(paths[:1], nil)
+
+		_, err := sudp.DialUDP(context.Background(), "1-ff00:0:110,127.0.0.1:8080",
+			sudp.WithConnector(sd),
+			sudp.WithPacketDispatcher(mock_snet.NewMockPacketDispatcherService(ctrl)),
+			sudp.WithPolicy(secondOnly),
+		)
+		assert.Error(t, err)
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		_, err := sudp.DialUDP(context.Background(), "127.0.0.1:8080")
+		assert.Error(t, err)
+	})
+}
+
+func TestListenUDP(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:111")
+
+	t.Run("unspecified IP", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		sd := mock_daemon.NewMockConnector(ctrl)
+		sd.EXPECT().LocalIA(gomock.Any()).Return(local, nil)
+		sd.EXPECT().SVCInfo(gomock.Any(), []addr.SVC{addr.SvcCS}).Return(
+			map[addr.SVC][]string{addr.SvcCS: {"127.0.0.1:30252"}}, nil,
+		)
+		pconn := mock_snet.NewMockPacketConn(ctrl)
+		pconn.EXPECT().Close()
+		disp := mock_snet.NewMockPacketDispatcherService(ctrl)
+		disp.EXPECT().Register(gomock.Any(), local,
+			&net.UDPAddr{IP: net.ParseIP("127.0.0.1").To4(), Port: 8080}, addr.SvcNone,
+		).Return(pconn, uint16(8080), nil)
+
+		conn, err := sudp.ListenUDP(context.Background(), ":8080",
+			sudp.WithConnector(sd),
+			sudp.WithPacketDispatcher(disp),
+		)
+		require.NoError(t, err)
+		laddr := conn.LocalAddr().(*snet.UDPAddr)
+		assert.Equal(t, local, laddr.IA)
+		assert.Equal(t, "127.0.0.1:8080", laddr.Host.String())
+		assert.NoError(t, conn.Close())
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		_, err := sudp.ListenUDP(context.Background(), "1-ff00:0:110,127.0.0.1:8080")
+		assert.Error(t, err)
+	})
+}
+
+func testPath(src, dst addr.IA, ifID uint64) snet.Path {
+	return snetpath.Path{
+		Src:           src,
+		Dst:           dst,
+		DataplanePath: snetpath.Empty{},
+		NextHop:       &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 30041},
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: src, ID: common.IFIDType(ifID)},
+				{IA: dst, ID: common.IFIDType(ifID + 10)},
+			},
+		},
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+)
+
+// PathUsage contains the statistics about the usage of a path that an
+// application collected since the last report.
+type PathUsage struct {
+	// Destination is the destination ISD-AS of the path.
+	Destination addr.IA
+	// Fingerprint is the fingerprint of the path.
+	Fingerprint PathFingerprint
+	// PacketsSent is the number of packets that were sent over the path.
+	PacketsSent uint64
+	// PacketsLost is the number of packets that are assumed to be lost. The
+	// losses are inferred from SCMP errors that indicate that an interface on
+	// the path is down.
+	PacketsLost uint64
+	// RTTs contains the round trip time samples that were measured on the
+	// path.
+	RTTs []time.Duration
+}
+
+// PathUsageReporter receives path usage statistics, e.g., the SCION Daemon.
+type PathUsageReporter interface {
+	ReportPathUsage(ctx context.Context, usages []PathUsage) error
+}
+
+// PathUsageRecorder collects the usage statistics of paths and reports them to
+// a PathUsageReporter. Recording path usage is opt-in: applications record the
+// sent packets and the RTT samples, and set the recorder on the
+// DefaultSCMPHandler to infer losses from SCMP errors. Report must be called
+// periodically to send the collected statistics.
+//
+// The zero value is ready to use. All methods are safe for concurrent use and
+// can be called on a nil recorder, in which case they do nothing.
+type PathUsageRecorder struct {
+	// Reporter receives the collected statistics.
+	Reporter PathUsageReporter
+
+	mu    sync.Mutex
+	paths map[PathFingerprint]*recordedPath
+}
+
+type recordedPath struct {
+	usage      PathUsage
+	interfaces []PathInterface
+}
+
+// RecordSent records that packets were sent over the path.
+func (r *PathUsageRecorder) RecordSent(path Path, packets int) {
+	if r == nil || packets <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p := r.path(path); p != nil {
+		p.usage.PacketsSent += uint64(packets)
+	}
+}
+
+// RecordRTT records a round trip time sample for the path.
+func (r *PathUsageRecorder) RecordRTT(path Path, rtt time.Duration) {
+	if r == nil || rtt <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p := r.path(path); p != nil {
+		p.usage.RTTs = append(p.usage.RTTs, rtt)
+	}
+}
+
+// Report sends the statistics that were collected since the last report to
+// the reporter and resets them. Statistics of paths that were not used since
+// the last report are not sent.
+func (r *PathUsageRecorder) Report(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	usages := r.collect()
+	if len(usages) == 0 || r.Reporter == nil {
+		return nil
+	}
+	return r.Reporter.ReportPathUsage(ctx, usages)
+}
+
+func (r *PathUsageRecorder) collect() []PathUsage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var usages []PathUsage
+	for fp, p := range r.paths {
+		if p.usage.PacketsSent == 0 && p.usage.PacketsLost == 0 && len(p.usage.RTTs) == 0 {
+			// The path was not used since the last report, forget about it.
+			delete(r.paths, fp)
+			continue
+		}
+		usages = append(usages, p.usage)
+		p.usage = PathUsage{Destination: p.usage.Destination, Fingerprint: fp}
+	}
+	return usages
+}
+
+// recordInterfaceDown records a lost packet for every recorded path that
+// traverses one of the interfaces.
+func (r *PathUsageRecorder) recordInterfaceDown(ia addr.IA, ifIDs ...uint64) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.paths {
+		if traversesAny(p.interfaces, ia, ifIDs) {
+			p.usage.PacketsLost++
+		}
+	}
+}
+
+// path returns the entry for the path, creating it if necessary. Paths
+// without a fingerprint, e.g., empty paths, are not recorded. The caller
+// must hold the lock.
+func (r *PathUsageRecorder) path(path Path) *recordedPath {
+	fp := Fingerprint(path)
+	if fp == "" {
+		return nil
+	}
+	if p, ok := r.paths[fp]; ok {
+		return p
+	}
+	if r.paths == nil {
+		r.paths = make(map[PathFingerprint]*recordedPath)
+	}
+	p := &recordedPath{
+		usage: PathUsage{
+			Destination: path.Destination(),
+			Fingerprint: fp,
+		},
+		interfaces: append([]PathInterface(nil), path.Metadata().Interfaces...),
+	}
+	r.paths[fp] = p
+	return p
+}
+
+func traversesAny(interfaces []PathInterface, ia addr.IA, ifIDs []uint64) bool {
+	for _, intf := range interfaces {
+		if intf.IA != ia {
+			continue
+		}
+		for _, id := range ifIDs {
+			if intf.ID == common.IFIDType(id) {
+				return true
+			}
+		}
+	}
+	return false
+}
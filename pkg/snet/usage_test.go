@@ -0,0 +1,105 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+type reporterFunc func(context.Context, []snet.PathUsage) error
+
+func (f reporterFunc) ReportPathUsage(ctx context.Context, usages []snet.PathUsage) error {
+	return f(ctx, usages)
+}
+
+func TestPathUsageRecorder(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	remoteIA := xtest.MustParseIA("1-ff00:0:111")
+	p1 := snetpath.Path{
+		Dst: remoteIA,
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{{IA: localIA, ID: 1}, {IA: remoteIA, ID: 2}},
+		},
+	}
+	p2 := snetpath.Path{
+		Dst: remoteIA,
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{{IA: localIA, ID: 3}, {IA: remoteIA, ID: 4}},
+		},
+	}
+
+	var reported []snet.PathUsage
+	r := &snet.PathUsageRecorder{
+		Reporter: reporterFunc(func(_ context.Context, usages []snet.PathUsage) error {
+			reported = usages
+			return nil
+		}),
+	}
+	r.RecordSent(p1, 10)
+	r.RecordSent(p2, 5)
+	r.RecordRTT(p1, 20*time.Millisecond)
+	r.RecordSent(snetpath.Path{Dst: remoteIA}, 3)
+
+	h := snet.DefaultSCMPHandler{PathUsage: r}
+	err := h.Handle(&snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Payload: snet.SCMPExternalInterfaceDown{IA: remoteIA, Interface: 4},
+		},
+	})
+	require.Error(t, err)
+
+	require.NoError(t, r.Report(context.Background()))
+	assert.ElementsMatch(t, []snet.PathUsage{
+		{
+			Destination: remoteIA,
+			Fingerprint: snet.Fingerprint(p1),
+			PacketsSent: 10,
+			RTTs:        []time.Duration{20 * time.Millisecond},
+		},
+		{
+			Destination: remoteIA,
+			Fingerprint: snet.Fingerprint(p2),
+			PacketsSent: 5,
+			PacketsLost: 1,
+		},
+	}, reported)
+
+	// Nothing was recorded since the last report.
+	reported = nil
+	require.NoError(t, r.Report(context.Background()))
+	assert.Nil(t, reported)
+}
+
+func TestPathUsageRecorderNil(t *testing.T) {
+	var r *snet.PathUsageRecorder
+	p := snetpath.Path{
+		Dst: xtest.MustParseIA("1-ff00:0:111"),
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{{ID: 1}, {ID: 2}},
+		},
+	}
+	r.RecordSent(p, 1)
+	r.RecordRTT(p, time.Millisecond)
+	assert.NoError(t, r.Report(context.Background()))
+}
@@ -52,7 +52,7 @@ func (c *scionConnWriter) WriteTo(b []byte, raddr net.Addr) (int, error) {
 		if !ok {
 			return 0, serrors.New("invalid destination host IP", "ip", a.Host.IP)
 		}
-		dst = SCIONAddress{IA: a.IA, Host: addr.HostIP(hostIP)}
+		dst = SCIONAddress{IA: a.IA, Host: addr.HostIP(hostIP.Unmap())}
 		port, path = a.Host.Port, a.Path
 		nextHop = a.NextHop
 		if nextHop == nil && c.base.scionNet.LocalIA.Equal(a.IA) {
@@ -82,7 +82,7 @@ func (c *scionConnWriter) WriteTo(b []byte, raddr net.Addr) (int, error) {
 			Destination: dst,
 			Source: SCIONAddress{
 				IA:   c.base.scionNet.LocalIA,
-				Host: addr.HostIP(listenHostIP),
+				Host: addr.HostIP(listenHostIP.Unmap()),
 			},
 			Path: path,
 			Payload: UDPPayload{
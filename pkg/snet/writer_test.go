@@ -14,3 +14,81 @@
 // limitations under the License.
 
 package snet_test
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/mock_snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestConnWriteTo(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+
+	testCases := map[string]struct {
+		Listen          *net.UDPAddr
+		Remote          *net.UDPAddr
+		ExpectedSrc     addr.Host
+		ExpectedDst     addr.Host
+		ExpectedNextHop *net.UDPAddr
+	}{
+		"IPv4 in 16-byte representation": {
+			Listen:      &net.UDPAddr{IP: net.ParseIP("192.0.2.1")},
+			Remote:      &net.UDPAddr{IP: net.ParseIP("192.0.2.2"), Port: 80},
+			ExpectedSrc: addr.HostIP(netip.MustParseAddr("192.0.2.1")),
+			ExpectedDst: addr.HostIP(netip.MustParseAddr("192.0.2.2")),
+			ExpectedNextHop: &net.UDPAddr{
+				IP:   net.ParseIP("192.0.2.2"),
+				Port: 30041,
+			},
+		},
+		"IPv6 link-local": {
+			Listen:      &net.UDPAddr{IP: net.ParseIP("fe80::1"), Zone: "eth0"},
+			Remote:      &net.UDPAddr{IP: net.ParseIP("fe80::2"), Port: 80, Zone: "eth0"},
+			ExpectedSrc: addr.HostIP(netip.MustParseAddr("fe80::1")),
+			ExpectedDst: addr.HostIP(netip.MustParseAddr("fe80::2")),
+			ExpectedNextHop: &net.UDPAddr{
+				IP:   net.ParseIP("fe80::2"),
+				Port: 30041,
+				Zone: "eth0",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			pconn := mock_snet.NewMockPacketConn(ctrl)
+			disp := mock_snet.NewMockPacketDispatcherService(ctrl)
+			disp.EXPECT().Register(gomock.Any(), localIA, gomock.Any(), addr.SvcNone).
+				Return(pconn, uint16(40000), nil)
+			network := &snet.SCIONNetwork{LocalIA: localIA, Dispatcher: disp}
+			conn, err := network.Listen(context.Background(), "udp", tc.Listen, addr.SvcNone)
+			require.NoError(t, err)
+
+			pconn.EXPECT().WriteTo(gomock.Any(), tc.ExpectedNextHop).DoAndReturn(
+				func(pkt *snet.Packet, _ *net.UDPAddr) error {
+					assert.Equal(t, tc.ExpectedSrc, pkt.Source.Host)
+					assert.Equal(t, tc.ExpectedDst, pkt.Destination.Host)
+					return nil
+				},
+			)
+			_, err = conn.WriteTo([]byte("hello"), &snet.UDPAddr{
+				IA:   localIA,
+				Path: snetpath.Empty{},
+				Host: tc.Remote,
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
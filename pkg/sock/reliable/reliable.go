@@ -84,8 +84,6 @@ var (
 )
 
 const (
-	// DefaultDispPath contains the system default for a dispatcher socket.
-	DefaultDispPath = "/run/shm/dispatcher/default.sock"
 	// DefaultDispSocketFileMode allows read/write to the user and group only.
 	DefaultDispSocketFileMode = 0770
 )
@@ -160,6 +158,19 @@ func Dial(ctx context.Context, address string) (*Conn, error) {
 	return newConn(c), nil
 }
 
+// NewConn wraps an established connection, e.g., one that was inherited from
+// another process. buffered is the data that was already read from the
+// connection, but not yet returned by ReadFrom, see Buffered.
+func NewConn(c *net.UnixConn, buffered []byte) (*Conn, error) {
+	conn := newConn(c)
+	if len(buffered) > len(conn.readPacketizer.freeSpace) {
+		return nil, serrors.New("buffered data too large", "len", len(buffered))
+	}
+	n := copy(conn.readPacketizer.freeSpace, buffered)
+	conn.readPacketizer.addData(n)
+	return conn, nil
+}
+
 func registerMetricsWrapper(ctx context.Context, dispatcher string, ia addr.IA,
 	public *net.UDPAddr, svc addr.SVC) (*Conn, uint16, error) {
 
@@ -340,6 +351,15 @@ func (conn *Conn) Read(buf []byte) (int, error) {
 	return n, err
 }
 
+// Buffered returns a copy of the data that was read from the socket, but not
+// yet returned by ReadFrom, e.g., the beginning of a partially received
+// message.
+func (conn *Conn) Buffered() []byte {
+	conn.readMutex.Lock()
+	defer conn.readMutex.Unlock()
+	return append([]byte(nil), conn.readPacketizer.data...)
+}
+
 // Listener listens on Unix sockets and returns Conn sockets on Accept().
 type Listener struct {
 	*net.UnixListener
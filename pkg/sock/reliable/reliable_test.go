@@ -0,0 +1,64 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reliable
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnBufferedHandover(t *testing.T) {
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: filepath.Join(t.TempDir(), "sock")})
+	require.NoError(t, err)
+	defer l.Close()
+	client, err := net.DialUnix("unix", nil, l.Addr().(*net.UnixAddr))
+	require.NoError(t, err)
+	defer client.Close()
+	server, err := l.AcceptUnix()
+	require.NoError(t, err)
+	defer server.Close()
+
+	frame := func(payload string) []byte {
+		b := make([]byte, 128)
+		n, err := (&UnderlayPacket{Payload: []byte(payload)}).SerializeTo(b)
+		require.NoError(t, err)
+		return b[:n]
+	}
+	first, second := frame("first"), frame("second")
+
+	// The first connection reads the first message and the beginning of the
+	// second one.
+	_, err = client.Write(append(append([]byte(nil), first...), second[:5]...))
+	require.NoError(t, err)
+	conn := newConn(server)
+	buf := make([]byte, 128)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(buf[:n]))
+	assert.Equal(t, second[:5], conn.Buffered())
+
+	// The second connection continues where the first one stopped.
+	handedOver, err := NewConn(server, conn.Buffered())
+	require.NoError(t, err)
+	_, err = client.Write(second[5:])
+	require.NoError(t, err)
+	n, _, err = handedOver.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(buf[:n]))
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stun implements the subset of the STUN protocol (RFC 5389) that is
+// needed to discover the address of an end host as seen by the border router,
+// i.e., the address that a NAT between the host and the router maps it to.
+//
+// Only binding requests without attributes and binding success responses with
+// an XOR-MAPPED-ADDRESS attribute are supported.
+package stun
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"net/netip"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// HeaderLen is the length of the STUN message header.
+	HeaderLen = 20
+
+	magicCookie           = 0x2112A442
+	typeBindingRequest    = 0x0001
+	typeBindingSuccess    = 0x0101
+	attrXORMappedAddress  = 0x0020
+	familyIPv4            = 0x01
+	familyIPv6            = 0x02
+	xorMappedAddrV4AttLen = 8
+	xorMappedAddrV6AttLen = 20
+)
+
+// TxID is the transaction ID of a STUN message.
+type TxID [12]byte
+
+// NewTxID returns a random transaction ID.
+func NewTxID() TxID {
+	var id TxID
+	if _, err := rand.Read(id[:]); err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Request returns a binding request with the given transaction ID.
+func Request(id TxID) []byte {
+	return appendHeader(make([]byte, 0, HeaderLen), typeBindingRequest, 0, id)
+}
+
+// Is returns whether b looks like a STUN message. It can be used to tell STUN
+// messages apart from other packets received on the same socket, e.g., SCION
+// packets.
+func Is(b []byte) bool {
+	return len(b) >= HeaderLen &&
+		b[0]&0xc0 == 0 &&
+		binary.BigEndian.Uint32(b[4:8]) == magicCookie &&
+		int(binary.BigEndian.Uint16(b[2:4]))+HeaderLen == len(b)
+}
+
+// ParseRequest parses a binding request and returns its transaction ID.
+func ParseRequest(b []byte) (TxID, error) {
+	if !Is(b) {
+		return TxID{}, serrors.New("not a STUN message")
+	}
+	if t := binary.BigEndian.Uint16(b[0:2]); t != typeBindingRequest {
+		return TxID{}, serrors.New("not a binding request", "type", t)
+	}
+	var id TxID
+	copy(id[:], b[8:HeaderLen])
+	return id, nil
+}
+
+// AppendResponse appends a binding success response with the given
+// transaction ID and mapped address to b, and returns the extended buffer.
+func AppendResponse(b []byte, id TxID, mapped netip.AddrPort) []byte {
+	ip := mapped.Addr().Unmap()
+	attrLen, family := xorMappedAddrV4AttLen, byte(familyIPv4)
+	if ip.Is6() {
+		attrLen, family = xorMappedAddrV6AttLen, familyIPv6
+	}
+	b = appendHeader(b, typeBindingSuccess, 4+attrLen, id)
+	b = binary.BigEndian.AppendUint16(b, attrXORMappedAddress)
+	b = binary.BigEndian.AppendUint16(b, uint16(attrLen))
+	b = append(b, 0, family)
+	b = binary.BigEndian.AppendUint16(b, mapped.Port()^magicCookie>>16)
+	start := len(b)
+	b = append(b, ip.AsSlice()...)
+	xorAddr(b[start:], id)
+	return b
+}
+
+// ParseResponse parses a binding success response and returns its
+// transaction ID and the mapped address.
+func ParseResponse(b []byte) (TxID, netip.AddrPort, error) {
+	if !Is(b) {
+		return TxID{}, netip.AddrPort{}, serrors.New("not a STUN message")
+	}
+	if t := binary.BigEndian.Uint16(b[0:2]); t != typeBindingSuccess {
+		return TxID{}, netip.AddrPort{}, serrors.New("not a binding success response",
+			"type", t)
+	}
+	var id TxID
+	copy(id[:], b[8:HeaderLen])
+	attrs := b[HeaderLen:]
+	for len(attrs) >= 4 {
+		attrType := binary.BigEndian.Uint16(attrs[0:2])
+		attrLen := int(binary.BigEndian.Uint16(attrs[2:4]))
+		if len(attrs) < 4+attrLen {
+			return TxID{}, netip.AddrPort{}, serrors.New("truncated attribute",
+				"type", attrType)
+		}
+		if attrType == attrXORMappedAddress {
+			mapped, err := parseXORMappedAddress(attrs[4:4+attrLen], id)
+			return id, mapped, err
+		}
+		// Attributes are padded to a multiple of 4 bytes.
+		next := 4 + (attrLen+3)&^3
+		if next > len(attrs) {
+			break
+		}
+		attrs = attrs[next:]
+	}
+	return TxID{}, netip.AddrPort{}, serrors.New("missing XOR-MAPPED-ADDRESS attribute")
+}
+
+func parseXORMappedAddress(v []byte, id TxID) (netip.AddrPort, error) {
+	if len(v) < 4 {
+		return netip.AddrPort{}, serrors.New("truncated XOR-MAPPED-ADDRESS")
+	}
+	port := binary.BigEndian.Uint16(v[2:4]) ^ magicCookie>>16
+	var raw []byte
+	switch v[1] {
+	case familyIPv4:
+		if len(v) != xorMappedAddrV4AttLen {
+			return netip.AddrPort{}, serrors.New("invalid IPv4 XOR-MAPPED-ADDRESS length",
+				"len", len(v))
+		}
+	case familyIPv6:
+		if len(v) != xorMappedAddrV6AttLen {
+			return netip.AddrPort{}, serrors.New("invalid IPv6 XOR-MAPPED-ADDRESS length",
+				"len", len(v))
+		}
+	default:
+		return netip.AddrPort{}, serrors.New("unknown address family", "family", v[1])
+	}
+	raw = append(raw, v[4:]...)
+	xorAddr(raw, id)
+	ip, _ := netip.AddrFromSlice(raw)
+	return netip.AddrPortFrom(ip, port), nil
+}
+
+func appendHeader(b []byte, msgType uint16, length int, id TxID) []byte {
+	b = binary.BigEndian.AppendUint16(b, msgType)
+	b = binary.BigEndian.AppendUint16(b, uint16(length))
+	b = binary.BigEndian.AppendUint32(b, magicCookie)
+	return append(b, id[:]...)
+}
+
+// xorAddr XORs the address with the magic cookie, followed by the transaction
+// ID for IPv6 addresses.
+func xorAddr(ip []byte, id TxID) {
+	var key [16]byte
+	binary.BigEndian.PutUint32(key[0:4], magicCookie)
+	copy(key[4:], id[:])
+	for i := range ip {
+		ip[i] ^= key[i]
+	}
+}
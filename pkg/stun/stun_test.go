@@ -0,0 +1,100 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stun_test
+
+import (
+	"encoding/hex"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/stun"
+)
+
+func TestRequest(t *testing.T) {
+	id := stun.NewTxID()
+	req := stun.Request(id)
+	assert.True(t, stun.Is(req))
+	parsed, err := stun.ParseRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	_, _, err = stun.ParseResponse(req)
+	assert.Error(t, err)
+}
+
+func TestResponse(t *testing.T) {
+	for _, mapped := range []string{"192.0.2.1:32853", "[2001:db8::1]:40000"} {
+		t.Run(mapped, func(t *testing.T) {
+			id := stun.NewTxID()
+			resp := stun.AppendResponse(nil, id, netip.MustParseAddrPort(mapped))
+			assert.True(t, stun.Is(resp))
+			parsedID, parsed, err := stun.ParseResponse(resp)
+			require.NoError(t, err)
+			assert.Equal(t, id, parsedID)
+			assert.Equal(t, mapped, parsed.String())
+
+			_, err = stun.ParseRequest(resp)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestParseResponseRFC5769 checks the sample responses of RFC 5769. The
+// attributes other than XOR-MAPPED-ADDRESS are skipped.
+func TestParseResponseRFC5769(t *testing.T) {
+	testCases := map[string]struct {
+		Raw      string
+		Expected string
+	}{
+		"IPv4": {
+			Raw: `0101003c 2112a442 b7e7a701 bc34d686 fa87dfae
+				8022000b 74657374 20766563 746f7220
+				00200008 0001a147 e112a643
+				00080014 2b91f599 fd9e90c3 8c7489f9 2af9ba53 f06be7d7
+				80280004 c07d4c96`,
+			Expected: "192.0.2.1:32853",
+		},
+		"IPv6": {
+			Raw: `01010048 2112a442 b7e7a701 bc34d686 fa87dfae
+				8022000b 74657374 20766563 746f7220
+				00200014 0002a147 0113a9fa a5d3f179 bc25f4b5 bed2b9d9
+				00080014 a382954e 4be67bf1 1784c97c 8292c275 bfe3ed41
+				80280004 c8fb0b4c`,
+			Expected: "[2001:db8:1234:5678:11:2233:4455:6677]:32853",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			raw, err := hex.DecodeString(strings.Join(strings.Fields(tc.Raw), ""))
+			require.NoError(t, err)
+			id, mapped, err := stun.ParseResponse(raw)
+			require.NoError(t, err)
+			assert.Equal(t, "b7e7a701bc34d686fa87dfae", hex.EncodeToString(id[:]))
+			assert.Equal(t, tc.Expected, mapped.String())
+		})
+	}
+}
+
+func TestIs(t *testing.T) {
+	req := stun.Request(stun.NewTxID())
+	assert.False(t, stun.Is(req[:stun.HeaderLen-1]), "truncated")
+	assert.False(t, stun.Is(append(req, 0, 0, 0, 0)), "length mismatch")
+	req[4] = 0
+	assert.False(t, stun.Is(req), "magic cookie")
+}
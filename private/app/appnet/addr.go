@@ -100,12 +100,13 @@ func (r AddressRewriter) RedirectToQUIC(ctx context.Context,
 
 		// During One-Hop Path operation, use SVC resolution to also bootstrap the path.
 		p, u, quicRedirect, err := r.resolveSVC(ctx, path, fa.SVC)
+		r.reportHealth(fa, err)
 		if err != nil {
 			// For a revoked path we don't fallback we want to give the option
 			// to retry with a new path.
 			isRevokedPath := func(err error) bool {
-				var opErr *snet.OpError
-				return errors.As(err, &opErr) && opErr.RevInfo() != nil
+				var pathDown *snet.PathDownError
+				return errors.As(err, &pathDown)
 			}
 			if r.SVCResolutionFraction < 1.0 && !isRevokedPath(err) {
 				// SVC resolution failed but we allow legacy behavior and have some
@@ -200,6 +201,24 @@ func (r AddressRewriter) resolveSVC(ctx context.Context, p snet.Path,
 	return reply.ReturnPath, u, true, nil
 }
 
+// reportHealth reports the outcome of the SVC resolution to the SVC router,
+// if the request was sent to an instance in the local AS and the SVC router
+// tracks the health of the instances.
+func (r AddressRewriter) reportHealth(fa *snet.SVCAddr, err error) {
+	reporter, ok := r.SVCRouter.(SVCHealthReporter)
+	if !ok || fa.NextHop == nil {
+		return
+	}
+	if _, local := fa.Path.(path.Empty); !local {
+		return
+	}
+	if err != nil {
+		reporter.ReportFailure(fa.SVC, fa.NextHop)
+		return
+	}
+	reporter.ReportSuccess(fa.SVC, fa.NextHop)
+}
+
 func (r AddressRewriter) resolutionCtx(ctx context.Context) (context.Context, context.CancelFunc) {
 	deadline, ok := ctx.Deadline()
 	if !ok {
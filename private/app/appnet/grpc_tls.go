@@ -0,0 +1,229 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appnet
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/env"
+	"github.com/scionproto/scion/private/trust"
+)
+
+// IntraASTLS creates the transport credentials for mutual TLS on the AS
+// internal gRPC connections.
+type IntraASTLS struct {
+	// Config is the TLS configuration of the service.
+	Config env.GRPCTLS
+	// IA is the local AS. If the peer certificates are verified against the
+	// TRCs, the peers must present a certificate of the local AS.
+	IA addr.IA
+	// GetCertificate and GetClientCertificate return the CP AS certificate of
+	// the local AS. They are used if no certificate file is configured.
+	GetCertificate       func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+	GetClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
+	// TLSVerifier verifies the peer certificates against the TRCs. It is used
+	// if no CA file is configured.
+	TLSVerifier *trust.TLSCryptoVerifier
+}
+
+// ServerCredentials returns the credentials of the gRPC server. It returns
+// nil if TLS is disabled.
+func (c *IntraASTLS) ServerCredentials() (credentials.TransportCredentials, error) {
+	if !c.Config.Enabled {
+		return nil, nil
+	}
+	getCertificate := c.GetCertificate
+	if c.Config.CertFile != "" {
+		files := &certificateFiles{certFile: c.Config.CertFile, keyFile: c.Config.KeyFile}
+		getCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return files.get()
+		}
+	}
+	if getCertificate == nil {
+		return nil, serrors.New("cert_file must be set")
+	}
+	verifyPeer, verifyConn, err := c.verifier(x509.ExtKeyUsageClientAuth)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		GetCertificate:        getCertificate,
+		ClientAuth:            tls.RequireAnyClientCert,
+		VerifyPeerCertificate: verifyPeer,
+		VerifyConnection:      verifyConn,
+		MinVersion:            tls.VersionTLS13,
+	}), nil
+}
+
+// ClientCredentials returns the credentials of the gRPC clients. It returns
+// nil if TLS is disabled.
+func (c *IntraASTLS) ClientCredentials() (credentials.TransportCredentials, error) {
+	if !c.Config.Enabled {
+		return nil, nil
+	}
+	getCertificate := c.GetClientCertificate
+	if c.Config.CertFile != "" {
+		files := &certificateFiles{certFile: c.Config.CertFile, keyFile: c.Config.KeyFile}
+		getCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return files.get()
+		}
+	}
+	if getCertificate == nil {
+		return nil, serrors.New("cert_file must be set")
+	}
+	verifyPeer, verifyConn, err := c.verifier(x509.ExtKeyUsageServerAuth)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		// The services are addressed by the IP addresses in the topology,
+		// the server certificate is verified by verifyPeer instead of the
+		// host name.
+		InsecureSkipVerify:    true,
+		GetClientCertificate:  getCertificate,
+		VerifyPeerCertificate: verifyPeer,
+		VerifyConnection:      verifyConn,
+		MinVersion:            tls.VersionTLS13,
+	}), nil
+}
+
+type (
+	verifyPeerFunc func([][]byte, [][]*x509.Certificate) error
+	verifyConnFunc func(tls.ConnectionState) error
+)
+
+// verifier returns the callbacks that verify the certificate of the peer for
+// the given extended key usage.
+func (c *IntraASTLS) verifier(usage x509.ExtKeyUsage) (verifyPeerFunc, verifyConnFunc, error) {
+	if c.Config.CAFile != "" {
+		raw, err := os.ReadFile(c.Config.CAFile)
+		if err != nil {
+			return nil, nil, serrors.WrapStr("reading CA file", err, "file", c.Config.CAFile)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(raw) {
+			return nil, nil, serrors.New("no CA certificates found", "file", c.Config.CAFile)
+		}
+		return caVerifier(roots, usage), nil, nil
+	}
+	if c.TLSVerifier == nil {
+		return nil, nil, serrors.New("ca_file must be set")
+	}
+	verifyPeer := c.TLSVerifier.VerifyClientCertificate
+	if usage == x509.ExtKeyUsageServerAuth {
+		verifyPeer = c.TLSVerifier.VerifyServerCertificate
+	}
+	verifyConn := func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return serrors.New("no peer certificate provided")
+		}
+		ia, err := cppki.ExtractIA(cs.PeerCertificates[0].Subject)
+		if err != nil {
+			return serrors.WrapStr("extracting ISD-AS from peer certificate", err)
+		}
+		if !ia.Equal(c.IA) {
+			return serrors.New("peer certificate of remote AS", "isd_as", ia)
+		}
+		return nil
+	}
+	return verifyPeer, verifyConn, nil
+}
+
+// caVerifier returns a callback that verifies the certificate chain of the
+// peer against the roots.
+func caVerifier(roots *x509.CertPool, usage x509.ExtKeyUsage) verifyPeerFunc {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return serrors.New("no peer certificate provided")
+		}
+		intermediates := x509.NewCertPool()
+		var leaf *x509.Certificate
+		for i, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return serrors.WrapStr("parsing peer certificate", err)
+			}
+			if i == 0 {
+				leaf = cert
+				continue
+			}
+			intermediates.AddCert(cert)
+		}
+		_, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{usage},
+		})
+		if err != nil {
+			return serrors.WrapStr("verifying peer certificate", err)
+		}
+		return nil
+	}
+}
+
+// certificateFiles loads a certificate from PEM files. The certificate is
+// reloaded when the files change, e.g., after the certificate was renewed.
+type certificateFiles struct {
+	certFile, keyFile string
+
+	mtx     sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func (f *certificateFiles) get() (*tls.Certificate, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	var modTime time.Time
+	for _, file := range []string{f.certFile, f.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return f.cached(serrors.WrapStr("accessing certificate file", err, "file", file))
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	if f.cert != nil && !modTime.After(f.modTime) {
+		return f.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+	if err != nil {
+		return f.cached(serrors.WrapStr("loading certificate", err,
+			"cert_file", f.certFile, "key_file", f.keyFile))
+	}
+	f.cert, f.modTime = &cert, modTime
+	return f.cert, nil
+}
+
+// cached returns the previously loaded certificate if the files cannot be
+// loaded, e.g., because they are being replaced.
+func (f *certificateFiles) cached(err error) (*tls.Certificate, error) {
+	if f.cert == nil {
+		return nil, err
+	}
+	log.Info("Using previously loaded certificate", "err", err)
+	return f.cert, nil
+}
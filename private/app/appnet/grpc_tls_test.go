@@ -0,0 +1,168 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appnet_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	infraenv "github.com/scionproto/scion/private/app/appnet"
+	"github.com/scionproto/scion/private/env"
+)
+
+func TestIntraASTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := writeCert(t, dir, "ca", nil, nil)
+	writeCert(t, dir, "server", ca, caKey)
+	writeCert(t, dir, "client", ca, caKey)
+	otherCA, otherCAKey := writeCert(t, dir, "other-ca", nil, nil)
+	writeCert(t, dir, "other-client", otherCA, otherCAKey)
+
+	serverTLS := &infraenv.IntraASTLS{Config: env.GRPCTLS{
+		Enabled:  true,
+		CertFile: filepath.Join(dir, "server.pem"),
+		KeyFile:  filepath.Join(dir, "server.key"),
+		CAFile:   filepath.Join(dir, "ca.pem"),
+	}}
+	creds, err := serverTLS.ServerCredentials()
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	check := func(t *testing.T, cfg env.GRPCTLS) error {
+		clientTLS := &infraenv.IntraASTLS{Config: cfg}
+		creds, err := clientTLS.ClientCredentials()
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, lis.Addr().String(),
+			grpc.WithTransportCredentials(creds))
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	t.Run("valid client", func(t *testing.T) {
+		assert.NoError(t, check(t, env.GRPCTLS{
+			Enabled:  true,
+			CertFile: filepath.Join(dir, "client.pem"),
+			KeyFile:  filepath.Join(dir, "client.key"),
+			CAFile:   filepath.Join(dir, "ca.pem"),
+		}))
+	})
+	t.Run("client of other CA", func(t *testing.T) {
+		assert.Error(t, check(t, env.GRPCTLS{
+			Enabled:  true,
+			CertFile: filepath.Join(dir, "other-client.pem"),
+			KeyFile:  filepath.Join(dir, "other-client.key"),
+			CAFile:   filepath.Join(dir, "ca.pem"),
+		}))
+	})
+	t.Run("server of other CA", func(t *testing.T) {
+		assert.Error(t, check(t, env.GRPCTLS{
+			Enabled:  true,
+			CertFile: filepath.Join(dir, "client.pem"),
+			KeyFile:  filepath.Join(dir, "client.key"),
+			CAFile:   filepath.Join(dir, "other-ca.pem"),
+		}))
+	})
+}
+
+func TestIntraASTLSConfig(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		c := &infraenv.IntraASTLS{}
+		creds, err := c.ServerCredentials()
+		assert.NoError(t, err)
+		assert.Nil(t, creds)
+		creds, err = c.ClientCredentials()
+		assert.NoError(t, err)
+		assert.Nil(t, creds)
+	})
+	t.Run("no certificate", func(t *testing.T) {
+		c := &infraenv.IntraASTLS{Config: env.GRPCTLS{Enabled: true}}
+		_, err := c.ClientCredentials()
+		assert.Error(t, err)
+	})
+	t.Run("no verifier", func(t *testing.T) {
+		c := &infraenv.IntraASTLS{Config: env.GRPCTLS{
+			Enabled:  true,
+			CertFile: "cert.pem",
+			KeyFile:  "cert.key",
+		}}
+		_, err := c.ServerCredentials()
+		assert.Error(t, err)
+	})
+}
+
+// writeCert writes a certificate and its key to dir. The certificate is
+// signed by parent, or self-signed if parent is nil.
+func writeCert(t *testing.T, dir, name string, parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	rawKey, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, name+".pem"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}), 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rawKey}), 0600)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+	return cert, key
+}
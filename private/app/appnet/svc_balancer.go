@@ -0,0 +1,145 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appnet
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// DefaultSVCFailureWindow is the default duration for which a failed
+	// request reduces the weight of an SVC instance.
+	DefaultSVCFailureWindow = time.Minute
+
+	// maxSVCWeightShift limits the number of failures that are taken into
+	// account. An instance without recent failures has weight
+	// 1<<maxSVCWeightShift, every recent failure halves the weight.
+	maxSVCWeightShift = 3
+)
+
+// SVCHealthReporter is implemented by SVC resolvers that take the health of
+// the SVC instances into account. The AddressRewriter reports the outcome of
+// the SVC resolution requests to the instances in the local AS.
+type SVCHealthReporter interface {
+	// ReportSuccess reports a successful request to the instance.
+	ReportSuccess(svc addr.SVC, instance *net.UDPAddr)
+	// ReportFailure reports a failed request to the instance.
+	ReportFailure(svc addr.SVC, instance *net.UDPAddr)
+}
+
+// SVCBalancer is an SVCResolver that spreads the requests across all instances
+// of a service with weighted round-robin. The weight of an instance is halved
+// for every failure that was reported within the failure window, so that
+// instances that recently failed are picked less often. Instances are never
+// excluded entirely, so that they are picked up again once they recover.
+type SVCBalancer struct {
+	// Instances returns the underlay addresses of all instances of the
+	// service.
+	Instances func(addr.SVC) ([]*net.UDPAddr, error)
+	// FailureWindow is the duration for which a reported failure reduces the
+	// weight of an instance. If zero, DefaultSVCFailureWindow is used.
+	FailureWindow time.Duration
+
+	mtx       sync.Mutex
+	instances map[string]*svcInstance
+}
+
+// svcInstance is the state of an SVC instance.
+type svcInstance struct {
+	// failures are the times of the recently reported failures.
+	failures []time.Time
+	// current is the current weight of the smooth weighted round-robin.
+	current int
+}
+
+// GetUnderlay returns the underlay address of the next instance of the
+// service.
+func (b *SVCBalancer) GetUnderlay(svc addr.SVC) (*net.UDPAddr, error) {
+	candidates, err := b.Instances(svc)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, serrors.New("no instances available", "svc", svc)
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	now := time.Now()
+	var best *svcInstance
+	var bestAddr *net.UDPAddr
+	total := 0
+	for _, a := range candidates {
+		s := b.instance(svc, a)
+		w := b.weight(s, now)
+		s.current += w
+		total += w
+		if best == nil || s.current > best.current {
+			best, bestAddr = s, a
+		}
+	}
+	best.current -= total
+	return bestAddr, nil
+}
+
+// ReportSuccess resets the failures of the instance.
+func (b *SVCBalancer) ReportSuccess(svc addr.SVC, instance *net.UDPAddr) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.instance(svc, instance).failures = nil
+}
+
+// ReportFailure records a failure of the instance.
+func (b *SVCBalancer) ReportFailure(svc addr.SVC, instance *net.UDPAddr) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	s := b.instance(svc, instance)
+	s.failures = append(s.failures, time.Now())
+	if len(s.failures) > maxSVCWeightShift {
+		s.failures = s.failures[len(s.failures)-maxSVCWeightShift:]
+	}
+}
+
+func (b *SVCBalancer) instance(svc addr.SVC, a *net.UDPAddr) *svcInstance {
+	if b.instances == nil {
+		b.instances = make(map[string]*svcInstance)
+	}
+	key := svc.BaseString() + " " + a.String()
+	s, ok := b.instances[key]
+	if !ok {
+		s = &svcInstance{}
+		b.instances[key] = s
+	}
+	return s
+}
+
+func (b *SVCBalancer) weight(s *svcInstance, now time.Time) int {
+	window := b.FailureWindow
+	if window == 0 {
+		window = DefaultSVCFailureWindow
+	}
+	recent := 0
+	for _, t := range s.failures {
+		if now.Sub(t) < window {
+			recent++
+		}
+	}
+	return 1 << (maxSVCWeightShift - recent)
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appnet_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	infraenv "github.com/scionproto/scion/private/app/appnet"
+)
+
+func TestSVCBalancer(t *testing.T) {
+	a := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 30252}
+	b := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 30252}
+	balancer := &infraenv.SVCBalancer{
+		Instances: func(addr.SVC) ([]*net.UDPAddr, error) {
+			return []*net.UDPAddr{a, b}, nil
+		},
+	}
+	pick := func(n int) map[string]int {
+		picks := map[string]int{}
+		for i := 0; i < n; i++ {
+			u, err := balancer.GetUnderlay(addr.SvcCS)
+			require.NoError(t, err)
+			picks[u.String()]++
+		}
+		return picks
+	}
+
+	t.Run("healthy instances are picked alike", func(t *testing.T) {
+		assert.Equal(t, map[string]int{a.String(): 8, b.String(): 8}, pick(16))
+	})
+	t.Run("failed instance is picked less often", func(t *testing.T) {
+		balancer.ReportFailure(addr.SvcCS, a)
+		balancer.ReportFailure(addr.SvcCS, a)
+		assert.Equal(t, map[string]int{a.String(): 2, b.String(): 8}, pick(10))
+	})
+	t.Run("success restores the weight", func(t *testing.T) {
+		balancer.ReportSuccess(addr.SvcCS, a)
+		picks := pick(16)
+		assert.Equal(t, 8, picks[a.String()])
+	})
+	t.Run("no instances", func(t *testing.T) {
+		empty := &infraenv.SVCBalancer{
+			Instances: func(addr.SVC) ([]*net.UDPAddr, error) { return nil, nil },
+		}
+		_, err := empty.GetUnderlay(addr.SvcCS)
+		assert.Error(t, err)
+	})
+}
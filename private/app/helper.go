@@ -19,6 +19,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/daemon"
@@ -86,6 +87,33 @@ func SIGHUPChannel(ctx context.Context) chan struct{} {
 	return ch
 }
 
+// WithShutdownTimeout returns a context that is canceled once the timeout
+// expired after ctx is done. It bounds the time the application spends on
+// finishing the work in progress during shutdown, e.g., by passing it to
+// GracefulStop-like functions that are called when ctx is done. The caller
+// must call the returned cancel function to release the resources.
+func WithShutdownTimeout(ctx context.Context,
+	timeout time.Duration) (context.Context, context.CancelFunc) {
+
+	shutdownCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer log.HandlePanic()
+		select {
+		case <-ctx.Done():
+		case <-shutdownCtx.Done():
+			return
+		}
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-shutdownCtx.Done():
+		}
+	}()
+	return shutdownCtx, cancel
+}
+
 // Cleanup defines a list of cleanup hooks. This can be helpful when creating an
 // app and then adding multiple cleanup hooks and to make sure that the all
 // execute without error.
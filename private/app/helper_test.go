@@ -0,0 +1,51 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/private/app"
+)
+
+func TestWithShutdownTimeout(t *testing.T) {
+	t.Run("not started before ctx is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		shutdownCtx, cancelShutdown := app.WithShutdownTimeout(ctx, time.Millisecond)
+		defer cancelShutdown()
+		select {
+		case <-shutdownCtx.Done():
+			t.Fatal("shutdown context done before ctx")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+	t.Run("done after timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		shutdownCtx, cancelShutdown := app.WithShutdownTimeout(ctx, 10*time.Millisecond)
+		defer cancelShutdown()
+		cancel()
+		assert.NoError(t, shutdownCtx.Err())
+		select {
+		case <-shutdownCtx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("shutdown context not done after timeout")
+		}
+	})
+}
@@ -18,6 +18,7 @@ package launcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -49,8 +50,13 @@ const (
 	cfgLogConsoleStacktraceLevel = "log.console.stacktrace_level"
 	cfgGeneralID                 = "general.id"
 	cfgConfigFile                = "config"
+	cfgValidate                  = "validate"
 )
 
+// shutdownForceMargin is the time the application gets in addition to its
+// shutdown timeout before the shutdown is forced.
+const shutdownForceMargin = time.Second
+
 // Application models a SCION server application.
 type Application struct {
 	// TOMLConfig holds the Go data structure for the application-specific
@@ -67,6 +73,10 @@ type Application struct {
 	// DEPRECATED. This field will be removed once Anapaya/scion#5000 is implemented.
 	Samplers []func(command.Pather) *cobra.Command
 
+	// Commands contains additional subcommands of the application. If empty,
+	// only the default subcommands are available.
+	Commands []func(command.Pather) *cobra.Command
+
 	// ShortName is the short name of the application. If empty, the executable name is used.
 	// The ShortName could be, for example, "SCION Daemon" for the SCION Daemon.
 	ShortName string
@@ -83,6 +93,20 @@ type Application struct {
 	// Run method will return a non-zero exit code.
 	Main func(ctx context.Context) error
 
+	// Validate performs application-specific validation if the application is
+	// started with the --validate flag. It is called after the TOML
+	// configuration has been loaded and should load and cross-check everything
+	// the configuration refers to, e.g., the topology, keys and policy files.
+	// To report all problems at once, it should return a serrors.List. If nil,
+	// only the TOML configuration is validated.
+	Validate func(ctx context.Context) error
+
+	// ShutdownTimeout returns the time the application has to shut down after
+	// it received SIGTERM. It is called after the configuration has been
+	// loaded. Once the timeout expired, the application is forcefully
+	// terminated. If nil, env.ShutdownGraceInterval is used.
+	ShutdownTimeout func() time.Duration
+
 	// ErrorWriter specifies where error output should be printed. If nil, os.Stderr is used.
 	ErrorWriter io.Writer
 
@@ -109,8 +133,11 @@ func (a *Application) run() error {
 	executable := filepath.Base(os.Args[0])
 	shortName := a.getShortName(executable)
 
-	cmd := newCommandTemplate(executable, shortName, a.TOMLConfig, a.Samplers...)
+	cmd := newCommandTemplate(executable, shortName, a.TOMLConfig, a.Commands, a.Samplers...)
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if validate, _ := cmd.Flags().GetBool(cfgValidate); validate {
+			return a.validateCommand(cmd.Context(), cmd.OutOrStdout())
+		}
 		return a.executeCommand(cmd.Context(), shortName)
 	}
 	a.config = viper.New()
@@ -133,14 +160,17 @@ func (a *Application) run() error {
 	go func() {
 		defer log.HandlePanic()
 		<-sigtermCtx.Done()
-		log.Info("Received SIGTERM signal, exiting...")
+		timeout := a.getShutdownTimeout()
+		log.Info("Received SIGTERM signal, exiting...", "timeout", timeout)
 
 		// If the main goroutine shuts down everything in time, this won't get
-		// a chance to run.
-		time.AfterFunc(5*time.Second, func() {
+		// a chance to run. The application uses the timeout to finish the work
+		// in progress, it gets a bit more time to release its resources.
+		wait := timeout + shutdownForceMargin
+		time.AfterFunc(wait, func() {
 			defer log.HandlePanic()
-			panic("Main goroutine did not shut down in time (waited 5s). " +
-				"It's probably stuck. Forcing shutdown.")
+			panic(fmt.Sprintf("Main goroutine did not shut down in time (waited %s). "+
+				"It's probably stuck. Forcing shutdown.", wait))
 		})
 
 		cancel()
@@ -149,6 +179,13 @@ func (a *Application) run() error {
 	return cmd.ExecuteContext(ctx)
 }
 
+func (a *Application) getShutdownTimeout() time.Duration {
+	if a.ShutdownTimeout == nil {
+		return env.ShutdownGraceInterval
+	}
+	return a.ShutdownTimeout()
+}
+
 func (a *Application) getShortName(executable string) string {
 	if a.ShortName != "" {
 		return a.ShortName
@@ -159,20 +196,9 @@ func (a *Application) getShortName(executable string) string {
 func (a *Application) executeCommand(ctx context.Context, shortName string) error {
 	os.Setenv("TZ", "UTC")
 
-	// Load launcher configurations from the same config file as the custom
-	// application configuration.
-	a.config.SetConfigType("toml")
-	a.config.SetConfigFile(a.config.GetString(cfgConfigFile))
-	if err := a.config.ReadInConfig(); err != nil {
-		return serrors.WrapStr("loading generic server config from file", err,
-			"file", a.config.GetString(cfgConfigFile))
-	}
-
-	if err := libconfig.LoadFile(a.config.GetString(cfgConfigFile), a.TOMLConfig); err != nil {
-		return serrors.WrapStr("loading config from file", err,
-			"file", a.config.GetString(cfgConfigFile))
+	if err := a.loadConfig(); err != nil {
+		return err
 	}
-	a.TOMLConfig.InitDefaults()
 
 	logEntriesTotal := metrics.NewPromCounterFrom(
 		prometheus.CounterOpts{
@@ -216,6 +242,67 @@ func (a *Application) executeCommand(ctx context.Context, shortName string) erro
 	return a.Main(ctx)
 }
 
+// loadConfig loads the launcher configuration and the application-specific
+// TOML configuration from the configuration file.
+func (a *Application) loadConfig() error {
+	// Load launcher configurations from the same config file as the custom
+	// application configuration.
+	a.config.SetConfigType("toml")
+	a.config.SetConfigFile(a.config.GetString(cfgConfigFile))
+	if err := a.config.ReadInConfig(); err != nil {
+		return serrors.WrapStr("loading generic server config from file", err,
+			"file", a.config.GetString(cfgConfigFile))
+	}
+
+	if err := libconfig.LoadFile(a.config.GetString(cfgConfigFile), a.TOMLConfig); err != nil {
+		return serrors.WrapStr("loading config from file", err,
+			"file", a.config.GetString(cfgConfigFile))
+	}
+	a.TOMLConfig.InitDefaults()
+	return nil
+}
+
+// validateCommand loads and validates the configuration without starting the
+// application. All errors are written to the error writer.
+func (a *Application) validateCommand(ctx context.Context, out io.Writer) error {
+	os.Setenv("TZ", "UTC")
+
+	file := a.config.GetString(cfgConfigFile)
+	if err := a.loadConfig(); err != nil {
+		return err
+	}
+	var errs serrors.List
+	errs = appendErrors(errs, a.TOMLConfig.Validate())
+	if a.Validate != nil {
+		errs = appendErrors(errs, a.Validate(ctx))
+	}
+	if len(errs) != 0 {
+		for _, err := range errs {
+			fmt.Fprintf(a.getErrorWriter(), "error: %v\n", err)
+		}
+		return serrors.New("invalid configuration", "file", file, "errors", len(errs))
+	}
+	fmt.Fprintf(out, "Configuration %s is valid.\n", file)
+	return nil
+}
+
+// appendErrors appends err to errs. If err is a serrors.List, its elements are
+// appended individually.
+func appendErrors(errs serrors.List, err error) serrors.List {
+	var list serrors.List
+	switch {
+	case err == nil:
+		return errs
+	case errors.As(err, &list):
+		for _, e := range list {
+			errs = appendErrors(errs, e)
+		}
+		return errs
+	default:
+		return append(errs, err)
+	}
+}
+
 func (a *Application) getLogging() log.Config {
 	return log.Config{
 		Console: log.ConsoleConfig{
@@ -253,12 +340,14 @@ type IDConfig interface {
 
 // newCommandTemplate returns a cobra command template for a SCION server application.
 func newCommandTemplate(executable string, shortName string, config config.Sampler,
+	commands []func(command.Pather) *cobra.Command,
 	samplers ...func(command.Pather) *cobra.Command) *cobra.Command {
 
 	cmd := &cobra.Command{
-		Use:           executable,
-		Short:         shortName,
-		Example:       fmt.Sprintf("  %s --config %s", executable, "config.toml"),
+		Use:   executable,
+		Short: shortName,
+		Example: fmt.Sprintf("  %[1]s --config %[2]s\n  %[1]s --config %[2]s --validate",
+			executable, "config.toml"),
 		SilenceErrors: true,
 		SilenceUsage:  true,
 		Args:          cobra.NoArgs,
@@ -270,7 +359,12 @@ func newCommandTemplate(executable string, shortName string, config config.Sampl
 		),
 		command.NewVersion(cmd),
 	)
+	for _, newCmd := range commands {
+		cmd.AddCommand(newCmd(cmd))
+	}
 	cmd.Flags().String(cfgConfigFile, "", "Configuration file (required)")
+	cmd.Flags().Bool(cfgValidate, false,
+		"Validate the configuration and everything it refers to, report all errors and exit")
 	cmd.MarkFlagRequired(cfgConfigFile)
 	return cmd
 }
@@ -0,0 +1,101 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package launcher
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/config"
+)
+
+type testConfig struct {
+	Value int `toml:"value"`
+}
+
+func (c *testConfig) Sample(io.Writer, config.Path, config.CtxMap) {}
+
+func (c *testConfig) InitDefaults() {}
+
+func (c *testConfig) Validate() error {
+	if c.Value < 0 {
+		return serrors.New("negative value")
+	}
+	return nil
+}
+
+func TestValidateCommand(t *testing.T) {
+	testCases := map[string]struct {
+		Config    string
+		Validate  func(context.Context) error
+		Errors    int
+		AssertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Config:    "value = 1",
+			Validate:  func(context.Context) error { return nil },
+			AssertErr: assert.NoError,
+		},
+		"invalid config only": {
+			Config:    "value = -1",
+			Errors:    1,
+			AssertErr: assert.Error,
+		},
+		"all errors reported": {
+			Config: "value = -1",
+			Validate: func(context.Context) error {
+				return serrors.List{serrors.New("missing topology"), serrors.New("missing keys")}
+			},
+			Errors:    3,
+			AssertErr: assert.Error,
+		},
+		"unparsable config": {
+			Config:    "value = ",
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			file := filepath.Join(t.TempDir(), "config.toml")
+			require.NoError(t, os.WriteFile(file, []byte(tc.Config), 0644))
+			var out, errOut bytes.Buffer
+			a := &Application{
+				TOMLConfig:  &testConfig{},
+				Validate:    tc.Validate,
+				ErrorWriter: &errOut,
+				config:      viper.New(),
+			}
+			a.config.Set(cfgConfigFile, file)
+
+			err := a.validateCommand(context.Background(), &out)
+			tc.AssertErr(t, err)
+			assert.Equal(t, tc.Errors, bytes.Count(errOut.Bytes(), []byte("error: ")))
+			if err == nil {
+				assert.Contains(t, out.String(), "is valid")
+			}
+		})
+	}
+}
@@ -79,7 +79,12 @@ func Choose(
 ) (snet.Path, error) {
 
 	o := applyOption(opts)
-	paths, err := fetchPaths(ctx, conn, remote, o.refresh, o.seq)
+	flags := daemon.PathReqFlags{
+		Refresh:      o.refresh,
+		Hidden:       o.hidden,
+		HiddenGroups: o.hiddenGroups,
+	}
+	paths, err := fetchPaths(ctx, conn, remote, flags, o.seq)
 	if err != nil {
 		return nil, serrors.WrapStr("fetching paths", err)
 	}
@@ -113,6 +118,9 @@ func Choose(
 	if o.interactive {
 		return printAndChoose(paths, remote, o.colorScheme)
 	}
+	if o.prober != nil {
+		return Race(ctx, paths, o.raceCandidates, o.prober)
+	}
 
 	return paths[rand.Intn(len(paths))], nil
 }
@@ -169,11 +177,11 @@ func fetchPaths(
 	ctx context.Context,
 	conn daemon.Connector,
 	remote addr.IA,
-	refresh bool,
+	flags daemon.PathReqFlags,
 	seq string,
 ) ([]snet.Path, error) {
 
-	allPaths, err := conn.Paths(ctx, remote, 0, daemon.PathReqFlags{Refresh: refresh})
+	allPaths, err := conn.Paths(ctx, remote, 0, flags)
 	if err != nil {
 		return nil, serrors.WrapStr("retrieving paths", err)
 	}
@@ -320,6 +328,12 @@ type options struct {
 	colorScheme ColorScheme
 	probeCfg    *ProbeConfig
 	epic        bool
+
+	hidden       bool
+	hiddenGroups []uint64
+
+	raceCandidates int
+	prober         PathProber
 }
 
 type Option func(o *options)
@@ -344,6 +358,15 @@ func WithRefresh(refresh bool) Option {
 	}
 }
 
+// WithHidden requests hidden paths. If groups are given, the hidden path lookup
+// is restricted to the hidden path groups with these IDs.
+func WithHidden(hidden bool, groups ...uint64) Option {
+	return func(o *options) {
+		o.hidden = hidden
+		o.hiddenGroups = groups
+	}
+}
+
 func WithSequence(seq string) Option {
 	return func(o *options) {
 		o.seq = seq
@@ -367,3 +390,12 @@ func WithEPIC(epic bool) Option {
 		o.epic = epic
 	}
 }
+
+// WithRace chooses the path that responds first when probing k candidate
+// paths in parallel, instead of choosing a random path. See Race.
+func WithRace(k int, prober PathProber) Option {
+	return func(o *options) {
+		o.raceCandidates = k
+		o.prober = prober
+	}
+}
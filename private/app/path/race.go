@@ -0,0 +1,108 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package path
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scmp/probe"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+)
+
+// DefaultRaceCandidates is the default number of paths probed by Race.
+const DefaultRaceCandidates = 3
+
+// PathProber measures the round trip time over a path. It returns an error if
+// no reply is received.
+type PathProber func(ctx context.Context, path snet.Path) (time.Duration, error)
+
+// EchoProber returns a PathProber that sends a single SCMP echo request to the
+// remote host and waits at most timeout for the reply.
+func EchoProber(cfg ProbeConfig, remote *snet.UDPAddr, timeout time.Duration) PathProber {
+	return func(ctx context.Context, path snet.Path) (time.Duration, error) {
+		res, err := probe.ProbePath(ctx, probe.ProbeConfig{
+			Target: probe.Target{
+				Dispatcher: reliable.NewDispatcher(cfg.Dispatcher),
+				LocalIA:    cfg.LocalIA,
+				LocalIP:    cfg.LocalIP,
+				Remote:     remote,
+				Path:       path,
+			},
+			Count:    1,
+			Interval: time.Second,
+			Timeout:  timeout,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if res.Stats.Received == 0 {
+			return 0, serrors.New("no reply received")
+		}
+		return res.Stats.MinRTT, nil
+	}
+}
+
+// Race probes the first k paths in parallel and returns the path that
+// responds first, i.e. the path with the lowest round trip time. If k is
+// zero, DefaultRaceCandidates paths are probed. Empty paths, i.e. paths to
+// the local AS, are returned immediately.
+func Race(ctx context.Context, paths []snet.Path, k int, prober PathProber) (snet.Path, error) {
+	if len(paths) == 0 {
+		return nil, serrors.New("no paths to probe")
+	}
+	for _, p := range paths {
+		if _, isEmpty := p.Dataplane().(snetpath.Empty); isEmpty {
+			return p, nil
+		}
+	}
+	if k == 0 {
+		k = DefaultRaceCandidates
+	}
+	if k < len(paths) {
+		paths = paths[:k]
+	}
+
+	ctx, cancelF := context.WithCancel(ctx)
+	defer cancelF()
+	type result struct {
+		path snet.Path
+		rtt  time.Duration
+		err  error
+	}
+	results := make(chan result, len(paths))
+	for _, p := range paths {
+		p := p
+		go func() {
+			defer log.HandlePanic()
+			rtt, err := prober(ctx, p)
+			results <- result{path: p, rtt: rtt, err: err}
+		}()
+	}
+	var errs serrors.List
+	for range paths {
+		r := <-results
+		if r.err == nil {
+			log.FromCtx(ctx).Debug("Path won race", "path", r.path, "rtt", r.rtt)
+			return r.path, nil
+		}
+		errs = append(errs, r.err)
+	}
+	return nil, serrors.WrapStr("no path responded", errs.ToError())
+}
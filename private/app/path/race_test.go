@@ -0,0 +1,70 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package path_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/path"
+	apppath "github.com/scionproto/scion/private/app/path"
+)
+
+func TestRace(t *testing.T) {
+	newPath := func(id byte) snet.Path {
+		return path.Path{DataplanePath: path.SCION{Raw: []byte{id}}}
+	}
+	slow, fast, dead, unprobed := newPath(1), newPath(2), newPath(3), newPath(4)
+	delays := map[byte]time.Duration{
+		1: 50 * time.Millisecond,
+		2: time.Millisecond,
+		4: 0,
+	}
+	prober := func(ctx context.Context, p snet.Path) (time.Duration, error) {
+		d, ok := delays[p.Dataplane().(path.SCION).Raw[0]]
+		if !ok {
+			return 0, serrors.New("timeout")
+		}
+		select {
+		case <-time.After(d):
+			return d, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+
+	t.Run("fastest candidate wins", func(t *testing.T) {
+		p, err := apppath.Race(context.Background(),
+			[]snet.Path{slow, dead, fast, unprobed}, 3, prober)
+		require.NoError(t, err)
+		assert.Equal(t, fast, p)
+	})
+	t.Run("no candidate responds", func(t *testing.T) {
+		_, err := apppath.Race(context.Background(), []snet.Path{dead, slow}, 1, prober)
+		assert.Error(t, err)
+	})
+	t.Run("empty path", func(t *testing.T) {
+		empty := path.Path{DataplanePath: path.Empty{}}
+		p, err := apppath.Race(context.Background(), []snet.Path{dead, empty}, 0, prober)
+		require.NoError(t, err)
+		assert.Equal(t, empty, p)
+	})
+}
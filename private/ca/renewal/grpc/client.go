@@ -0,0 +1,111 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+
+	"github.com/scionproto/scion/pkg/addr"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/scrypto/cms/protocol"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// ChainRequester requests renewed certificate chains from the control service
+// of the issuing CA.
+type ChainRequester struct {
+	// IA is the local ISD-AS.
+	IA addr.IA
+	// Dialer dials a new gRPC connection.
+	Dialer libgrpc.Dialer
+	// Router is used to find a path to the CA if it is not in the local AS.
+	Router snet.Router
+}
+
+// RequestChain sends the renewal request to the CA and returns the renewed
+// certificate chain. The chain is validated, but not verified against the TRC.
+func (r ChainRequester) RequestChain(ctx context.Context, ca addr.IA,
+	req *cppb.ChainRenewalRequest) ([]*x509.Certificate, error) {
+
+	remote, err := r.remote(ctx, ca)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := r.Dialer.Dial(ctx, remote)
+	if err != nil {
+		return nil, serrors.WrapStr("dialing", err, "remote", remote)
+	}
+	defer conn.Close()
+	client := cppb.NewChainRenewalServiceClient(conn)
+	rep, err := client.ChainRenewal(ctx, req, libgrpc.RetryProfile...)
+	if err != nil {
+		return nil, serrors.WrapStr("requesting certificate chain", err, "remote", remote)
+	}
+	chain, err := extractResponseChain(rep.CmsSignedResponse)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing response", err, "remote", remote)
+	}
+	return chain, nil
+}
+
+func (r ChainRequester) remote(ctx context.Context, ca addr.IA) (net.Addr, error) {
+	if ca == r.IA {
+		return &snet.SVCAddr{IA: ca, SVC: addr.SvcCS}, nil
+	}
+	path, err := r.Router.Route(ctx, ca)
+	if err != nil || path == nil {
+		return nil, serrors.WrapStr("no path to CA", err, "isd_as", ca)
+	}
+	return &snet.SVCAddr{
+		IA:      path.Destination(),
+		Path:    path.Dataplane(),
+		NextHop: path.UnderlayNextHop(),
+		SVC:     addr.SvcCS,
+	}, nil
+}
+
+// extractResponseChain extracts the certificate chain from the CMS signed
+// response. The signature is not verified; the chain is verified against the
+// TRC by the caller instead.
+func extractResponseChain(raw []byte) ([]*x509.Certificate, error) {
+	if len(raw) == 0 {
+		return nil, serrors.New("CMS signed response missing")
+	}
+	ci, err := protocol.ParseContentInfo(raw)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing ContentInfo", err)
+	}
+	sd, err := ci.SignedDataContent()
+	if err != nil {
+		return nil, serrors.WrapStr("parsing SignedData", err)
+	}
+	content, err := sd.EncapContentInfo.DataEContent()
+	if err != nil {
+		return nil, serrors.WrapStr("parsing content", err)
+	}
+	chain, err := x509.ParseCertificates(content)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing certificate chain", err)
+	}
+	if err := cppki.ValidateChain(chain); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
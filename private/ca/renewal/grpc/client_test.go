@@ -0,0 +1,139 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	mock_cp "github.com/scionproto/scion/pkg/proto/control_plane/mock_control_plane"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/pkg/scrypto/signed"
+	"github.com/scionproto/scion/pkg/snet/mock_snet"
+	"github.com/scionproto/scion/private/ca/renewal/grpc"
+	"github.com/scionproto/scion/private/trust"
+)
+
+func TestChainRequesterRequestChain(t *testing.T) {
+	caKey, caChain := genChain(t)
+	_, chain := genChain(t)
+	caSigner := trust.Signer{
+		PrivateKey: caKey,
+		Algorithm:  signed.ECDSAWithSHA256,
+		ChainValidity: cppki.Validity{
+			NotBefore: time.Now(),
+			NotAfter:  time.Now().Add(time.Hour),
+		},
+		Expiration:   time.Now().Add(time.Hour - time.Minute),
+		IA:           xtest.MustParseIA("1-ff00:0:110"),
+		SubjectKeyID: caChain[0].SubjectKeyId,
+		Chain:        caChain,
+	}
+	signedChain, err := caSigner.SignCMS(context.Background(),
+		append(chain[0].Raw, chain[1].Raw...))
+	require.NoError(t, err)
+
+	local := xtest.MustParseIA("1-ff00:0:111")
+	tests := map[string]struct {
+		CA        string
+		Server    func(*gomock.Controller) *mock_cp.MockChainRenewalServiceServer
+		Router    func(*gomock.Controller) *mock_snet.MockRouter
+		Expected  []*x509.Certificate
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			CA: "1-ff00:0:111",
+			Server: func(ctrl *gomock.Controller) *mock_cp.MockChainRenewalServiceServer {
+				srv := mock_cp.NewMockChainRenewalServiceServer(ctrl)
+				srv.EXPECT().ChainRenewal(gomock.Any(), gomock.Any()).Return(
+					&cppb.ChainRenewalResponse{CmsSignedResponse: signedChain}, nil,
+				)
+				return srv
+			},
+			Router:    mock_snet.NewMockRouter,
+			Expected:  chain,
+			Assertion: assert.NoError,
+		},
+		"RPC fail": {
+			CA: "1-ff00:0:111",
+			Server: func(ctrl *gomock.Controller) *mock_cp.MockChainRenewalServiceServer {
+				srv := mock_cp.NewMockChainRenewalServiceServer(ctrl)
+				srv.EXPECT().ChainRenewal(gomock.Any(), gomock.Any()).Return(
+					nil, serrors.New("internal"),
+				).AnyTimes()
+				return srv
+			},
+			Router:    mock_snet.NewMockRouter,
+			Assertion: assert.Error,
+		},
+		"CMS missing": {
+			CA: "1-ff00:0:111",
+			Server: func(ctrl *gomock.Controller) *mock_cp.MockChainRenewalServiceServer {
+				srv := mock_cp.NewMockChainRenewalServiceServer(ctrl)
+				srv.EXPECT().ChainRenewal(gomock.Any(), gomock.Any()).Return(
+					&cppb.ChainRenewalResponse{}, nil,
+				)
+				return srv
+			},
+			Router:    mock_snet.NewMockRouter,
+			Assertion: assert.Error,
+		},
+		"no path to CA": {
+			CA:     "1-ff00:0:110",
+			Server: mock_cp.NewMockChainRenewalServiceServer,
+			Router: func(ctrl *gomock.Controller) *mock_snet.MockRouter {
+				r := mock_snet.NewMockRouter(ctrl)
+				r.EXPECT().Route(gomock.Any(), xtest.MustParseIA("1-ff00:0:110")).Return(
+					nil, serrors.New("no path"),
+				)
+				return r
+			},
+			Assertion: assert.Error,
+		},
+	}
+	for name, tc := range tests {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := xtest.NewGRPCService()
+			cppb.RegisterChainRenewalServiceServer(svc.Server(), tc.Server(ctrl))
+			svc.Start(t)
+
+			r := grpc.ChainRequester{
+				IA:     local,
+				Dialer: svc,
+				Router: tc.Router(ctrl),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			renewed, err := r.RequestChain(ctx, xtest.MustParseIA(tc.CA),
+				&cppb.ChainRenewalRequest{})
+			tc.Assertion(t, err)
+			assert.Equal(t, tc.Expected, renewed)
+		})
+	}
+}
@@ -143,14 +143,22 @@ func (s StringSampler) ConfigName() string {
 	return s.Name
 }
 
-// ValidateAll validates all validators. The first error encountered is returned.
+// ValidateAll validates all validators. All validators are run, such that all
+// errors are reported at once. If a single validator fails, its error is
+// returned. If multiple validators fail, a serrors.List of their errors is
+// returned.
 func ValidateAll(validators ...Validator) error {
+	var errs serrors.List
 	for _, v := range validators {
 		if err := v.Validate(); err != nil {
-			return serrors.WrapStr("Unable to validate", err, "type", fmt.Sprintf("%T", v))
+			errs = append(errs,
+				serrors.WrapStr("Unable to validate", err, "type", fmt.Sprintf("%T", v)))
 		}
 	}
-	return nil
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errs.ToError()
 }
 
 // InitAll initializes all defaulters.
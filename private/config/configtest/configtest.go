@@ -0,0 +1,131 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configtest contains helpers to test configuration samples.
+package configtest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/scionproto/scion/private/config"
+)
+
+var (
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+	// tableRe matches table headers, e.g., "[path]" or "[[remotes]]". Headers
+	// that are commented out match as well.
+	tableRe = regexp.MustCompile(`^#?\s*\[\[?\s*([A-Za-z0-9_.-]+)\s*\]\]?\s*$`)
+	// keyRe matches options, e.g., "address = ...". Options that are commented
+	// out match as well.
+	keyRe = regexp.MustCompile(`^#?\s*([A-Za-z0-9_-]+)\s*=`)
+)
+
+// CheckSampleCoverage checks that the sample of cfg covers every option of
+// cfg, i.e., every exported field with a toml tag, including the fields of
+// nested tables. Options that are commented out in the sample, e.g., because
+// they are optional, count as covered.
+func CheckSampleCoverage(t *testing.T, cfg config.Config) {
+	t.Helper()
+	var sample bytes.Buffer
+	cfg.Sample(&sample, nil, nil)
+	covered := SampleOptions(sample.Bytes())
+	for _, option := range Options(cfg) {
+		if _, ok := covered[option]; !ok {
+			t.Errorf("option %q is not covered by the sample", option)
+		}
+	}
+}
+
+// Options returns the sorted options of the configuration struct v, i.e., the
+// fully qualified keys of all exported fields with a toml tag. The fields of
+// nested structs are returned instead of the struct itself, unless the struct
+// implements encoding.TextUnmarshaler.
+func Options(v interface{}) []string {
+	var options []string
+	collectOptions(reflect.TypeOf(v), "", &options)
+	sort.Strings(options)
+	return options
+}
+
+func collectOptions(typ reflect.Type, prefix string, options *[]string) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous {
+			if t := indirect(field.Type); t.Kind() == reflect.Struct {
+				collectOptions(t, prefix, options)
+			}
+			continue
+		}
+		name := strings.Split(field.Tag.Get("toml"), ",")[0]
+		if field.PkgPath != "" || name == "" || name == "-" {
+			continue
+		}
+		if t := indirect(field.Type); isTable(t) {
+			collectOptions(t, prefix+name+".", options)
+			continue
+		}
+		*options = append(*options, prefix+name)
+	}
+}
+
+// SampleOptions returns the set of fully qualified options that appear in the
+// sample, including the ones that are commented out. Table headers count as
+// options too, such that map-valued options, e.g., "[drkey.delegation]", are
+// covered.
+func SampleOptions(sample []byte) map[string]struct{} {
+	options := make(map[string]struct{})
+	table := ""
+	scanner := bufio.NewScanner(bytes.NewReader(sample))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if m := tableRe.FindStringSubmatch(line); m != nil {
+			options[m[1]] = struct{}{}
+			table = m[1] + "."
+			continue
+		}
+		if m := keyRe.FindStringSubmatch(line); m != nil {
+			options[table+m[1]] = struct{}{}
+		}
+	}
+	return options
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isTable returns whether the type is represented as a TOML table with
+// options.
+func isTable(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || reflect.PtrTo(t).Implements(textUnmarshaler) {
+		return false
+	}
+	var options []string
+	collectOptions(t, "", &options)
+	return len(options) > 0
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtest_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/private/config"
+	"github.com/scionproto/scion/private/config/configtest"
+)
+
+type inner struct {
+	Enabled bool   `toml:"enabled" comment:"Whether it is enabled."`
+	Name    string `toml:"name,omitempty" comment:"The name."`
+}
+
+type testConfig struct {
+	config.NoDefaulter
+	config.NoValidator
+
+	Address string            `toml:"address"`
+	Inner   inner             `toml:"inner"`
+	Hosts   map[string]string `toml:"hosts"`
+	Ignored string            `toml:"-"`
+}
+
+func (cfg *testConfig) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, `
+address = "127.0.0.1"
+
+[hosts]
+# a = "b"
+
+[inner]
+`)
+	config.WriteStructSample(dst, &cfg.Inner)
+}
+
+func (cfg *testConfig) ConfigName() string {
+	return "test"
+}
+
+func TestOptions(t *testing.T) {
+	assert.Equal(t,
+		[]string{"address", "hosts", "inner.enabled", "inner.name"},
+		configtest.Options(&testConfig{}),
+	)
+}
+
+func TestSampleOptions(t *testing.T) {
+	var sample bytes.Buffer
+	(&testConfig{}).Sample(&sample, nil, nil)
+	assert.Equal(t,
+		map[string]struct{}{
+			"address":       {},
+			"hosts":         {},
+			"hosts.a":       {},
+			"inner":         {},
+			"inner.enabled": {},
+			"inner.name":    {},
+		},
+		configtest.SampleOptions(sample.Bytes()),
+	)
+}
+
+func TestCheckSampleCoverage(t *testing.T) {
+	configtest.CheckSampleCoverage(t, &testConfig{})
+}
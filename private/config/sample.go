@@ -17,8 +17,11 @@ package config
 import (
 	"bufio"
 	"bytes"
+	"encoding"
 	"fmt"
 	"io"
+	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -70,3 +73,57 @@ func writeWithIndent(dst io.Writer, src io.Reader) {
 func writeHeader(dst io.Writer, path Path) {
 	WriteString(dst, fmt.Sprintf("\n[%s]", strings.Join(path, ".")))
 }
+
+// WriteStructSample writes a sample for the struct pointed to by v to dst. The
+// sample is generated from the struct tags, such that it cannot drift from the
+// code: Every exported field with a toml tag is written as an option with the
+// current value of the field. The comment tag of the field is written above
+// the option. Nested structs are not supported. It panics if an error occurs.
+func WriteStructSample(dst io.Writer, v interface{}) {
+	val := reflect.Indirect(reflect.ValueOf(v))
+	if val.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("Unable to write struct sample for %T", v))
+	}
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := strings.Split(field.Tag.Get("toml"), ",")[0]
+		if field.PkgPath != "" || field.Anonymous || name == "" || name == "-" {
+			continue
+		}
+		WriteString(dst, "\n")
+		for _, line := range strings.Split(field.Tag.Get("comment"), "\n") {
+			WriteString(dst, strings.TrimSpace("# "+line)+"\n")
+		}
+		WriteString(dst, fmt.Sprintf("%s = %s\n", name, sampleValue(val.Field(i))))
+	}
+}
+
+// sampleValue formats the value as TOML.
+func sampleValue(v reflect.Value) string {
+	if v.CanAddr() {
+		v = v.Addr()
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			panic(fmt.Sprintf("Unable to marshal sample value err=%s", err))
+		}
+		return fmt.Sprintf("%q", text)
+	}
+	v = reflect.Indirect(v)
+	switch v.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(v.Interface())
+	case reflect.Float32, reflect.Float64:
+		f := strconv.FormatFloat(v.Float(), 'f', -1, 64)
+		if !strings.Contains(f, ".") {
+			f += ".0"
+		}
+		return f
+	case reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	}
+	panic(fmt.Sprintf("Unable to write sample value of type %s", v.Type()))
+}
@@ -37,6 +37,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	jaeger "github.com/uber/jaeger-client-go"
 	jaegercfg "github.com/uber/jaeger-client-go/config"
+	"go.opentelemetry.io/otel"
+	otjaeger "go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/scionproto/scion/pkg/daemon"
 	"github.com/scionproto/scion/pkg/log"
@@ -58,8 +65,25 @@ const (
 	// attempting to connect to the daemon on start.
 	SciondInitConnectPeriod = 20 * time.Second
 
-	// ShutdownGraceInterval is the time applications wait after issuing a
-	// clean shutdown signal, before forcerfully tearing down the application.
+	// DefaultTracingSamplingRatio is the fraction of the traces that are
+	// sampled by the OpenTelemetry tracer provider if debug mode is disabled.
+	// Traces that are started by a sampled remote parent are always sampled.
+	DefaultTracingSamplingRatio = 0.001
+
+	// TracingAPIOpenTracing selects the OpenTracing API to record and export
+	// the spans.
+	TracingAPIOpenTracing = "opentracing"
+	// TracingAPIOpenTelemetry selects the OpenTelemetry API to record and
+	// export the spans.
+	TracingAPIOpenTelemetry = "opentelemetry"
+
+	// tracerShutdownTimeout is the maximum time for which the pending spans
+	// are flushed on shutdown.
+	tracerShutdownTimeout = 5 * time.Second
+
+	// ShutdownGraceInterval is the default time applications have to shut down
+	// cleanly after they received the shutdown signal, before they are
+	// forcefully torn down.
 	ShutdownGraceInterval = 5 * time.Second
 )
 
@@ -82,16 +106,27 @@ type General struct {
 	// ReconnectToDispatcher can be set to true to enable transparent dispatcher
 	// reconnects.
 	ReconnectToDispatcher bool `toml:"reconnect_to_dispatcher,omitempty"`
+	// ShutdownTimeout is the time the application has to finish the work in
+	// progress after it received SIGTERM, e.g., to drain the in-flight
+	// packets or to finish the outstanding RPCs. (default 5s)
+	ShutdownTimeout util.DurWrap `toml:"shutdown_timeout,omitempty"`
 }
 
-// InitDefaults sets the default value for Topology if not already set.
+// InitDefaults sets the default value for ShutdownTimeout if not already set.
 func (cfg *General) InitDefaults() {
+	if cfg.ShutdownTimeout.Duration == 0 {
+		cfg.ShutdownTimeout.Duration = ShutdownGraceInterval
+	}
 }
 
 func (cfg *General) Validate() error {
 	if cfg.ID == "" {
 		return serrors.New("no element id specified")
 	}
+	if cfg.ShutdownTimeout.Duration < 0 {
+		return serrors.New("shutdown_timeout must not be negative",
+			"value", cfg.ShutdownTimeout)
+	}
 	return cfg.checkDir()
 }
 
@@ -213,6 +248,14 @@ type Tracing struct {
 	// Agent is the address of the local agent that handles the reported
 	// traces. (default: localhost:6831)
 	Agent string `toml:"agent,omitempty"`
+	// Collector is the HTTP endpoint of the Jaeger collector, e.g.,
+	// http://jaeger:14268/api/traces. If set, the traces are reported directly
+	// to the collector instead of the local agent.
+	Collector string `toml:"collector,omitempty"`
+	// API is the tracing API whose spans are exported, either "opentracing"
+	// or "opentelemetry". The spans recorded with the other API are dropped,
+	// such that no span is exported twice. (default: opentracing)
+	API string `toml:"api,omitempty"`
 }
 
 func (cfg *Tracing) InitDefaults() {
@@ -222,6 +265,18 @@ func (cfg *Tracing) InitDefaults() {
 			strconv.Itoa(jaeger.DefaultUDPSpanServerPort),
 		)
 	}
+	if cfg.API == "" {
+		cfg.API = TracingAPIOpenTracing
+	}
+}
+
+func (cfg *Tracing) Validate() error {
+	switch cfg.API {
+	case TracingAPIOpenTracing, TracingAPIOpenTelemetry:
+		return nil
+	default:
+		return serrors.New("unknown tracing API", "api", cfg.API)
+	}
 }
 
 func (cfg *Tracing) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
@@ -232,14 +287,48 @@ func (cfg *Tracing) ConfigName() string {
 	return "tracing"
 }
 
+// InitTracer initializes the global OpenTracing tracer and the global
+// OpenTelemetry tracer provider and propagator. Only the one of the configured
+// API exports spans, the other one is a noop.
+func (cfg *Tracing) InitTracer(id string) (io.Closer, error) {
+	tracer, trCloser, err := cfg.NewTracer(id)
+	if err != nil {
+		return nil, err
+	}
+	tp, tpCloser, err := cfg.NewTracerProvider(id)
+	if err != nil {
+		trCloser.Close()
+		return nil, err
+	}
+	opentracing.SetGlobalTracer(tracer)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tracerClosers{trCloser, tpCloser}, nil
+}
+
+// tracerClosers closes all tracers.
+type tracerClosers []io.Closer
+
+func (c tracerClosers) Close() error {
+	var errs serrors.List
+	for _, closer := range c {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs.ToError()
+}
+
 // NewTracer creates a new Tracer for the given configuration. In case tracing
-// is disabled this still returns noop-objects for convenience of the caller.
+// is disabled, or the OpenTelemetry API is selected, this still returns
+// noop-objects for convenience of the caller.
 func (cfg *Tracing) NewTracer(id string) (opentracing.Tracer, io.Closer, error) {
 	traceConfig := jaegercfg.Configuration{
 		ServiceName: id,
-		Disabled:    !cfg.Enabled,
+		Disabled:    !cfg.Enabled || cfg.API == TracingAPIOpenTelemetry,
 		Reporter: &jaegercfg.ReporterConfig{
 			LocalAgentHostPort: cfg.Agent,
+			CollectorEndpoint:  cfg.Collector,
 		},
 	}
 	if cfg.Debug {
@@ -254,6 +343,63 @@ func (cfg *Tracing) NewTracer(id string) (opentracing.Tracer, io.Closer, error)
 		jaegercfg.Injector(opentracing.Binary, bp))
 }
 
+// NewTracerProvider creates a new OpenTelemetry TracerProvider for the given
+// configuration. The spans are exported to the same Jaeger agent or collector
+// as the ones of the tracer created by NewTracer. In case tracing is disabled,
+// or the OpenTelemetry API is not selected, this still returns noop-objects for
+// convenience of the caller.
+func (cfg *Tracing) NewTracerProvider(id string) (trace.TracerProvider, io.Closer, error) {
+	if !cfg.Enabled || cfg.API != TracingAPIOpenTelemetry {
+		return trace.NewNoopTracerProvider(), nopCloser{}, nil
+	}
+	var endpoint otjaeger.EndpointOption
+	if cfg.Collector != "" {
+		endpoint = otjaeger.WithCollectorEndpoint(otjaeger.WithEndpoint(cfg.Collector))
+	} else {
+		host, port, err := net.SplitHostPort(cfg.Agent)
+		if err != nil {
+			return nil, nil, serrors.WrapStr("parsing agent address", err, "agent", cfg.Agent)
+		}
+		endpoint = otjaeger.WithAgentEndpoint(
+			otjaeger.WithAgentHost(host),
+			otjaeger.WithAgentPort(port),
+		)
+	}
+	exporter, err := otjaeger.New(endpoint)
+	if err != nil {
+		return nil, nil, serrors.WrapStr("creating exporter", err)
+	}
+	sampler := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(DefaultTracingSamplingRatio))
+	if cfg.Debug {
+		sampler = sdktrace.AlwaysSample()
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(id),
+		)),
+	)
+	return tp, tracerProviderCloser{tp: tp}, nil
+}
+
+// tracerProviderCloser flushes the pending spans and shuts down the tracer
+// provider on Close.
+type tracerProviderCloser struct {
+	tp *sdktrace.TracerProvider
+}
+
+func (c tracerProviderCloser) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), tracerShutdownTimeout)
+	defer cancel()
+	return c.tp.Shutdown(ctx)
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
 // QUIC contains configuration for control-plane speakers.
 type QUIC struct {
 	Address string `toml:"address,omitempty"`
@@ -266,3 +412,38 @@ func (cfg *QUIC) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 func (cfg *QUIC) ConfigName() string {
 	return "quic"
 }
+
+var _ config.Config = (*GRPCTLS)(nil)
+
+// GRPCTLS contains the TLS configuration of the AS internal gRPC connections.
+type GRPCTLS struct {
+	config.NoDefaulter
+	// Enabled enables mutual TLS on the AS internal gRPC connections.
+	Enabled bool `toml:"enabled,omitempty"`
+	// CertFile is the PEM file with the certificate chain presented to the
+	// peers. If empty, the CP AS certificate of the local AS is used.
+	CertFile string `toml:"cert_file,omitempty"`
+	// KeyFile is the PEM file with the private key of the certificate in
+	// CertFile.
+	KeyFile string `toml:"key_file,omitempty"`
+	// CAFile is the PEM file with the CA certificates that the certificates of
+	// the peers are verified against. If empty, they are verified against the
+	// TRCs of the local ISD.
+	CAFile string `toml:"ca_file,omitempty"`
+}
+
+func (cfg *GRPCTLS) Validate() error {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return serrors.New("cert_file and key_file must be set together",
+			"cert_file", cfg.CertFile, "key_file", cfg.KeyFile)
+	}
+	return nil
+}
+
+func (cfg *GRPCTLS) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteString(dst, grpcTLSSample)
+}
+
+func (cfg *GRPCTLS) ConfigName() string {
+	return "grpc_tls"
+}
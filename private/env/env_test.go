@@ -0,0 +1,73 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"testing"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/scionproto/scion/private/env"
+)
+
+func TestTracingAPI(t *testing.T) {
+	testCases := map[string]struct {
+		API           string
+		OpenTracing   bool
+		OpenTelemetry bool
+	}{
+		"opentracing": {
+			API:         env.TracingAPIOpenTracing,
+			OpenTracing: true,
+		},
+		"opentelemetry": {
+			API:           env.TracingAPIOpenTelemetry,
+			OpenTelemetry: true,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := env.Tracing{Enabled: true, API: tc.API}
+			cfg.InitDefaults()
+			require.NoError(t, cfg.Validate())
+
+			tracer, trCloser, err := cfg.NewTracer("test")
+			require.NoError(t, err)
+			defer trCloser.Close()
+			_, noop := tracer.(*opentracing.NoopTracer)
+			assert.Equal(t, tc.OpenTracing, !noop)
+
+			tp, tpCloser, err := cfg.NewTracerProvider("test")
+			require.NoError(t, err)
+			defer tpCloser.Close()
+			assert.Equal(t, tc.OpenTelemetry, tp != trace.NewNoopTracerProvider())
+		})
+	}
+}
+
+func TestTracingValidate(t *testing.T) {
+	cfg := env.Tracing{}
+	cfg.InitDefaults()
+	assert.Equal(t, env.TracingAPIOpenTracing, cfg.API)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.API = "zipkin"
+	assert.Error(t, cfg.Validate())
+}
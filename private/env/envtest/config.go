@@ -59,6 +59,11 @@ func InitTestSCIOND(cfg *env.Daemon) {
 	cfg.Address = "garbage"
 }
 
+func InitTestGRPCTLS(cfg *env.GRPCTLS) {
+	cfg.Enabled = true
+	cfg.CAFile = "garbage"
+}
+
 func CheckTest(t *testing.T, general *env.General, metrics *env.Metrics,
 	tracing *env.Tracing, d *env.Daemon, id string) {
 
@@ -81,6 +86,7 @@ func CheckTestGeneral(t *testing.T, cfg *env.General, id string) {
 	assert.Equal(t, "/share/conf", cfg.ConfigDir)
 	assert.Equal(t, filepath.Join(cfg.ConfigDir, env.TopologyFile), cfg.Topology())
 	assert.False(t, cfg.ReconnectToDispatcher)
+	assert.Equal(t, env.ShutdownGraceInterval, cfg.ShutdownTimeout.Duration)
 }
 
 func CheckTestMetrics(t *testing.T, cfg *env.Metrics) {
@@ -98,9 +104,18 @@ func CheckTestTracing(t *testing.T, cfg *env.Tracing) {
 		),
 		cfg.Agent,
 	)
+	assert.Empty(t, cfg.Collector)
+	assert.Equal(t, env.TracingAPIOpenTracing, cfg.API)
 }
 
 func CheckTestSciond(t *testing.T, cfg *env.Daemon, id string) {
 	assert.Equal(t, daemon.DefaultAPIAddress, cfg.Address)
 	assert.Equal(t, env.SciondInitConnectPeriod, cfg.InitialConnectPeriod.Duration)
 }
+
+func CheckTestGRPCTLS(t *testing.T, cfg *env.GRPCTLS) {
+	assert.False(t, cfg.Enabled)
+	assert.Empty(t, cfg.CertFile)
+	assert.Empty(t, cfg.KeyFile)
+	assert.Empty(t, cfg.CAFile)
+}
@@ -64,3 +64,14 @@ func TestSCIONDClientSample(t *testing.T) {
 	assert.NoError(t, err)
 	InitTestSCIOND(&cfg)
 }
+
+func TestGRPCTLSSample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg env.GRPCTLS
+	cfg.Sample(&sample, nil, nil)
+	InitTestGRPCTLS(&cfg)
+	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
+	assert.NoError(t, err)
+	CheckTestGRPCTLS(t, &cfg)
+	assert.NoError(t, cfg.Validate())
+}
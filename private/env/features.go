@@ -24,6 +24,9 @@ var _ config.Config = (*Features)(nil)
 
 // Features contains all feature flags. Add feature flags to this structure as
 // needed. Feature flags are always boolean. Don't use any other types here!
+//
+// The sample is generated from the struct, every flag must have a comment tag
+// that describes it.
 type Features struct {
 	config.NoDefaulter
 	config.NoValidator
@@ -32,10 +35,10 @@ type Features struct {
 	// with the appropriate digest algorithm instead of always using ECDSAWithSHA512.
 	//
 	// Experimental: This field is experimental and will be subject to change.
-	AppropriateDigest bool `toml:"appropriate_digest_algorithm"`
+	AppropriateDigest bool `toml:"appropriate_digest_algorithm" comment:"Sign issued certificates with the appropriate digest algorithm\ninstead of always using ECDSAWithSHA512. (experimental)"`
 
 	// Example:
-	// DanceAtMidnight bool `toml:"dance_at_midnight,omitempty"`
+	// DanceAtMidnight bool `toml:"dance_at_midnight,omitempty" comment:"Dance at midnight."`
 
 	// ExperimentalSCMPAuthentication enables experimental, DRKey-based
 	// authentication of SCMP messages.
@@ -44,11 +47,12 @@ type Features struct {
 	// generated with a dummy key!
 	//
 	// Experimental: This field is experimental and will be subject to change.
-	ExperimentalSCMPAuthentication bool `toml:"experimental_scmp_authentication"`
+	ExperimentalSCMPAuthentication bool `toml:"experimental_scmp_authentication" comment:"Authenticate SCMP error messages with DRKey-based SPAO.\nThe authenticator is generated with a dummy key! (experimental)"`
 }
 
 func (cfg *Features) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, featuresSample)
+	config.WriteStructSample(dst, cfg)
 }
 
 func (cfg *Features) ConfigName() string {
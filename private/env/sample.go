@@ -23,10 +23,15 @@ config_dir = "/share/conf"
 
 # Enable the snetproxy reconnecter. (default false)
 reconnect_to_dispatcher = false
+
+# Time the service has to finish the work in progress after it received
+# SIGTERM, before it is forcefully terminated. (default 5s)
+shutdown_timeout = "5s"
 `
 
 const featuresSample = `
-# Feature flags are various boolean properties as defined in go/lib/env/features.go
+# Feature flags are various boolean properties as defined in private/env/features.go.
+# All feature flags are disabled by default.
 `
 
 const daemonSample = `
@@ -35,6 +40,10 @@ address = "127.0.0.1:30255"
 
 # Maximum time spent attempting to connect to SCION Daemon on start. (default 20s)
 initial_connect_period = "20s"
+
+# Path to a fake daemon configuration file that replaces the local daemon with
+# a fake data source. Only intended for testing. (default "", i.e., disabled)
+# fake_data = ""
 `
 
 const metricsSample = `
@@ -53,6 +62,14 @@ debug = false
 # Address of the local agent that handles the reported traces.
 # (default: localhost:6831)
 agent = "localhost:6831"
+# HTTP endpoint of the Jaeger collector, e.g., "http://jaeger:14268/api/traces".
+# If set, the traces are reported directly to the collector instead of the
+# local agent. (default "")
+# collector = ""
+# The tracing API whose spans are exported, either "opentracing" or
+# "opentelemetry". The spans recorded with the other API are dropped, such that
+# no span is exported twice. (default "opentracing")
+api = "opentracing"
 `
 
 const quicSample = `
@@ -60,3 +77,24 @@ const quicSample = `
 # the public IP and a high port is started. (default "")
 address = ""
 `
+
+const grpcTLSSample = `
+# Enable mutual TLS on the AS internal gRPC connections, e.g., between the
+# SCION Daemon and the control service. All services of the AS that talk to
+# each other must agree on this setting. (default false)
+enabled = false
+
+# PEM file with the certificate chain presented to the peers. If empty, the CP
+# AS certificate of the local AS is used. Only the control service has access
+# to it. (default "")
+cert_file = ""
+
+# PEM file with the private key of the certificate in cert_file. (default "")
+key_file = ""
+
+# PEM file with the CA certificates that the certificates of the peers are
+# verified against. If empty, they are verified against the TRCs of the local
+# ISD, and the peers must present a CP AS certificate of the local AS.
+# (default "")
+ca_file = ""
+`
@@ -0,0 +1,136 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const authorizationKey = "authorization"
+
+// GRPCCredentials attaches Bearer tokens created by TokenSource to the
+// requests of a gRPC client. Use it with grpc.WithPerRPCCredentials.
+type GRPCCredentials struct {
+	TokenSource TokenSource
+	// RequireTLS indicates whether the tokens may only be sent over a secure
+	// transport.
+	RequireTLS bool
+}
+
+func (c GRPCCredentials) GetRequestMetadata(context.Context,
+	...string) (map[string]string, error) {
+
+	token, err := c.TokenSource.Token()
+	if err != nil {
+		return nil, serrors.WrapStr("computing bearer token", err)
+	}
+	return map[string]string{authorizationKey: "Bearer " + token.String()}, nil
+}
+
+func (c GRPCCredentials) RequireTransportSecurity() bool {
+	return c.RequireTLS
+}
+
+// GRPCVerifier verifies the JWT Bearer tokens of gRPC requests as defined by
+// the SCION CA JWT specification.
+//
+// The only accepted algorithm is HS256.
+type GRPCVerifier struct {
+	// Generator that creates keys for HS256. The keys must be at least 256-bit
+	// long.
+	Generator KeyFunc
+	// Logger is an optional Logger to be used for listing successful/unsuccessful authorization
+	// attempts. If nil, no logging is done.
+	Logger log.Logger
+}
+
+// UnaryServerInterceptor returns an interceptor that rejects unary requests
+// without a valid token.
+func (v *GRPCVerifier) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		if err := v.authorize(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that rejects streams without
+// a valid token.
+func (v *GRPCVerifier) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+
+		if err := v.authorize(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (v *GRPCVerifier) authorize(ctx context.Context) error {
+	if v.Generator == nil {
+		log.SafeDebug(v.Logger, "Key generator must not be nil")
+		return status.Error(codes.Internal, "server error")
+	}
+	key, err := v.Generator()
+	if err != nil {
+		log.SafeDebug(v.Logger, "Key generator returned error", "err", err)
+		return status.Error(codes.Internal, "server error")
+	}
+	if len(key) < 256/8 {
+		log.SafeDebug(v.Logger, "Refusing to verify, key must be at least 256 bits long",
+			"length", len(key)*8)
+		return status.Error(codes.Internal, "server error")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationKey)
+	if len(values) != 1 || !strings.HasPrefix(values[0], "Bearer ") {
+		log.SafeDebug(v.Logger, "Bearer token missing")
+		return status.Error(codes.Unauthenticated, "authorization error")
+	}
+	token, err := jwt.ParseString(strings.TrimPrefix(values[0], "Bearer "),
+		jwt.WithVerify(jwa.HS256, key),
+	)
+	if err != nil {
+		log.SafeDebug(v.Logger, "Token verification failed", "err", err)
+		return status.Error(codes.Unauthenticated, "authorization error")
+	}
+	err = jwt.Validate(token,
+		jwt.WithClock(jwt.ClockFunc(time.Now)),
+		jwt.WithAcceptableSkew(DefaultAcceptableSkew),
+	)
+	if err != nil {
+		log.SafeDebug(v.Logger, "Token validation failed", "err", err)
+		return status.Error(codes.Unauthenticated, "authorization error")
+	}
+	log.SafeDebug(v.Logger, "Authorization successful", "subject", token.Subject())
+	return nil
+}
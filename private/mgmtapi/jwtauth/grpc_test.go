@@ -0,0 +1,87 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/private/mgmtapi/jwtauth"
+)
+
+func TestGRPCAuthorization(t *testing.T) {
+	testCases := map[string]struct {
+		ClientKey []byte
+		NoToken   bool
+		ServerKey []byte
+		Code      codes.Code
+	}{
+		"valid": {
+			ClientKey: serverKey,
+			ServerKey: serverKey,
+			Code:      codes.OK,
+		},
+		"wrong key": {
+			ClientKey: badKey,
+			ServerKey: serverKey,
+			Code:      codes.Unauthenticated,
+		},
+		"no token": {
+			NoToken:   true,
+			ServerKey: serverKey,
+			Code:      codes.Unauthenticated,
+		},
+		"short server key": {
+			ClientKey: serverKey,
+			ServerKey: shortKey,
+			Code:      codes.Internal,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if !tc.NoToken {
+				creds := jwtauth.GRPCCredentials{
+					TokenSource: &jwtauth.JWTTokenSource{
+						Subject:   "admin",
+						Generator: keyFunc(tc.ClientKey, nil),
+					},
+				}
+				md, err := creds.GetRequestMetadata(ctx)
+				require.NoError(t, err)
+				ctx = metadata.NewIncomingContext(ctx, metadata.New(md))
+			}
+			v := &jwtauth.GRPCVerifier{Generator: keyFunc(tc.ServerKey, nil)}
+			called := false
+			_, err := v.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{},
+				func(context.Context, interface{}) (interface{}, error) {
+					called = true
+					return nil, nil
+				},
+			)
+			assert.Equal(t, tc.Code, status.Code(err))
+			assert.Equal(t, tc.Code == codes.OK, called)
+		})
+	}
+}
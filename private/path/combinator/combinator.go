@@ -59,7 +59,19 @@ import (
 func Combine(src, dst addr.IA, ups, cores, downs []*seg.PathSegment,
 	findAllIdentical bool) []Path {
 
-	solutions := newDMG(ups, cores, downs).GetPaths(vertexFromIA(src), vertexFromIA(dst))
+	return CombineWithWaypoints(src, dst, ups, cores, downs, findAllIdentical, nil)
+}
+
+// CombineWithWaypoints is like Combine, but only returns the paths that pass
+// through all the waypoints. A waypoint with AS number 0 (e.g., 1-0) is
+// satisfied by any AS of that ISD. The waypoints are evaluated while combining
+// the segments, so forwarding paths are only built for the combinations that
+// satisfy them.
+func CombineWithWaypoints(src, dst addr.IA, ups, cores, downs []*seg.PathSegment,
+	findAllIdentical bool, waypoints []addr.IA) []Path {
+
+	solutions := newDMG(ups, cores, downs).GetPaths(vertexFromIA(src), vertexFromIA(dst),
+		waypoints)
 	paths := make([]Path, len(solutions))
 	for i, solution := range solutions {
 		paths[i] = solution.Path()
@@ -142,6 +142,87 @@ func TestMultiPeering(t *testing.T) {
 	}
 }
 
+func TestPeeringMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+
+	result := combinator.Combine(
+		xtest.MustParseIA("1-ff00:0:112"),
+		xtest.MustParseIA("2-ff00:0:212"),
+		[]*seg.PathSegment{g.Beacon([]uint16{graph.If_130_B_111_A, graph.If_111_A_112_X})},
+		[]*seg.PathSegment{g.Beacon([]uint16{graph.If_210_X_110_X, graph.If_110_X_130_A})},
+		[]*seg.PathSegment{g.Beacon([]uint16{graph.If_210_X_211_A, graph.If_211_A_212_X})},
+		false,
+	)
+	var peering, nonPeering int
+	for _, p := range result {
+		sp := scion.Decoded{}
+		require.NoError(t, sp.DecodeFromBytes(p.SCIONPath.Raw))
+		expected := false
+		for _, info := range sp.InfoFields {
+			expected = expected || info.Peer
+		}
+		assert.Equal(t, expected, p.Metadata.Peering)
+		if p.Metadata.Peering {
+			peering++
+		} else {
+			nonPeering++
+		}
+	}
+	assert.NotZero(t, peering)
+	assert.NotZero(t, nonPeering)
+}
+
+func TestWaypoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+
+	ups := []*seg.PathSegment{g.Beacon([]uint16{graph.If_130_B_111_A, graph.If_111_A_112_X})}
+	cores := []*seg.PathSegment{g.Beacon([]uint16{graph.If_210_X_110_X, graph.If_110_X_130_A})}
+	downs := []*seg.PathSegment{g.Beacon([]uint16{graph.If_210_X_211_A, graph.If_211_A_212_X})}
+	combine := func(waypoints ...addr.IA) []combinator.Path {
+		return combinator.CombineWithWaypoints(xtest.MustParseIA("1-ff00:0:112"),
+			xtest.MustParseIA("2-ff00:0:212"), ups, cores, downs, false, waypoints)
+	}
+	traverses := func(p combinator.Path, ia addr.IA) bool {
+		for _, intf := range p.Metadata.Interfaces {
+			if intf.IA == ia {
+				return true
+			}
+		}
+		return false
+	}
+	all := combine()
+	require.NotEmpty(t, all)
+
+	t.Run("AS waypoint", func(t *testing.T) {
+		core := xtest.MustParseIA("1-ff00:0:130")
+		result := combine(core)
+		require.NotEmpty(t, result)
+		assert.Less(t, len(result), len(all))
+		for _, p := range result {
+			assert.True(t, traverses(p, core))
+			assert.False(t, p.Metadata.Peering)
+		}
+	})
+	t.Run("ISD waypoint", func(t *testing.T) {
+		assert.Equal(t, all, combine(xtest.MustParseIA("2-0")))
+	})
+	t.Run("multiple waypoints", func(t *testing.T) {
+		result := combine(xtest.MustParseIA("1-ff00:0:130"), xtest.MustParseIA("2-ff00:0:210"))
+		require.NotEmpty(t, result)
+		for _, p := range result {
+			assert.True(t, traverses(p, xtest.MustParseIA("2-ff00:0:210")))
+		}
+	})
+	t.Run("unsatisfiable waypoint", func(t *testing.T) {
+		assert.Empty(t, combine(xtest.MustParseIA("1-ff00:0:131")))
+		assert.Empty(t, combine(xtest.MustParseIA("3-0")))
+	})
+}
+
 func TestSameCoreParent(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
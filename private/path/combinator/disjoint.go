@@ -0,0 +1,113 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// DisjointnessMode determines the resources that disjoint paths should not
+// share.
+type DisjointnessMode int
+
+const (
+	// LinkDisjoint minimizes the inter-AS links shared by the paths.
+	LinkDisjoint DisjointnessMode = iota
+	// ASDisjoint minimizes the transit ASes shared by the paths. The source
+	// and destination AS are shared by all paths and thus not considered.
+	ASDisjoint
+)
+
+// resource is an inter-AS link, or a transit AS if only the IA of a is set.
+type resource struct {
+	a, b snet.PathInterface
+}
+
+// SelectDisjoint selects up to k maximally disjoint paths. The paths are
+// selected greedily: The first path is the first of the given paths, and each
+// following path is the one that shares the fewest resources with the already
+// selected paths. Ties are broken by the order of the given paths, such that
+// the order expresses the preference among equally disjoint paths. Paths
+// without metadata are ignored.
+func SelectDisjoint(paths []snet.Path, k int, mode DisjointnessMode) []snet.Path {
+	if k <= 0 {
+		return nil
+	}
+	candidates := make([][]resource, len(paths))
+	for i, p := range paths {
+		if meta := p.Metadata(); meta != nil {
+			candidates[i] = resources(meta.Interfaces, mode)
+		}
+	}
+	used := make(map[resource]struct{})
+	selected := make([]snet.Path, 0, k)
+	done := make([]bool, len(paths))
+	for len(selected) < k {
+		best, bestOverlap := -1, 0
+		for i, res := range candidates {
+			if done[i] || res == nil {
+				continue
+			}
+			overlap := 0
+			for _, r := range res {
+				if _, ok := used[r]; ok {
+					overlap++
+				}
+			}
+			if best == -1 || overlap < bestOverlap {
+				best, bestOverlap = i, overlap
+			}
+		}
+		if best == -1 {
+			break
+		}
+		done[best] = true
+		selected = append(selected, paths[best])
+		for _, r := range candidates[best] {
+			used[r] = struct{}{}
+		}
+	}
+	return selected
+}
+
+// resources returns the resources used by the path with the given interfaces.
+// The returned slice is non-nil, also for paths without interfaces, e.g., for
+// paths within the local AS.
+func resources(interfaces []snet.PathInterface, mode DisjointnessMode) []resource {
+	res := []resource{}
+	switch mode {
+	case ASDisjoint:
+		if len(interfaces) < 2 {
+			return res
+		}
+		src, dst := interfaces[0].IA, interfaces[len(interfaces)-1].IA
+		seen := make(map[addr.IA]struct{})
+		for _, intf := range interfaces[1 : len(interfaces)-1] {
+			if _, ok := seen[intf.IA]; ok || intf.IA == src || intf.IA == dst {
+				continue
+			}
+			seen[intf.IA] = struct{}{}
+			res = append(res, resource{a: snet.PathInterface{IA: intf.IA}})
+		}
+	default:
+		// The interfaces are pairs of the egress and ingress interface of the
+		// traversed inter-AS links.
+		for i := 0; i+1 < len(interfaces); i += 2 {
+			res = append(res, resource{a: interfaces[i], b: interfaces[i+1]})
+		}
+	}
+	return res
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/path/combinator"
+)
+
+func TestSelectDisjoint(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	ia120 := xtest.MustParseIA("1-ff00:0:120")
+	ia130 := xtest.MustParseIA("1-ff00:0:130")
+	newPath := func(hops ...interface{}) snet.Path {
+		var intfs []snet.PathInterface
+		for i := 0; i < len(hops); i += 2 {
+			intfs = append(intfs, snet.PathInterface{
+				IA: hops[i].(addr.IA),
+				ID: common.IFIDType(hops[i+1].(int)),
+			})
+		}
+		return snetpath.Path{Src: ia110, Dst: ia112, Meta: snet.PathMetadata{Interfaces: intfs}}
+	}
+	// p1 and p2 share the link 110#1-120#1, p4 is link disjoint from p1 and
+	// p2, but traverses the same AS. p3 is disjoint from all other paths.
+	p1 := newPath(ia110, 1, ia120, 1, ia120, 2, ia112, 1)
+	p2 := newPath(ia110, 1, ia120, 1, ia120, 3, ia112, 2)
+	p3 := newPath(ia110, 2, ia130, 1, ia130, 2, ia112, 3)
+	p4 := newPath(ia110, 3, ia120, 4, ia120, 5, ia112, 4)
+	paths := []snet.Path{p1, p2, p3, p4}
+
+	testCases := map[string]struct {
+		K        int
+		Mode     combinator.DisjointnessMode
+		Expected []snet.Path
+	}{
+		"link disjoint": {
+			K:        3,
+			Mode:     combinator.LinkDisjoint,
+			Expected: []snet.Path{p1, p3, p4},
+		},
+		"AS disjoint": {
+			K:        3,
+			Mode:     combinator.ASDisjoint,
+			Expected: []snet.Path{p1, p3, p2},
+		},
+		"more than available": {
+			K:        10,
+			Mode:     combinator.LinkDisjoint,
+			Expected: []snet.Path{p1, p3, p4, p2},
+		},
+		"single path": {
+			K:        1,
+			Mode:     combinator.ASDisjoint,
+			Expected: []snet.Path{p1},
+		},
+		"zero": {
+			K:    0,
+			Mode: combinator.LinkDisjoint,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, combinator.SelectDisjoint(paths, tc.K, tc.Mode))
+		})
+	}
+}
@@ -192,8 +192,9 @@ func (g *dmg) AddEdge(src, dst vertex, segment *inputSegment, e *edge) {
 	neighborMap[dst][segment] = e
 }
 
-// GetPaths returns all the paths from src to dst, sorted according to weight.
-func (g *dmg) GetPaths(src, dst vertex) pathSolutionList {
+// GetPaths returns all the paths from src to dst that traverse all the
+// waypoints, sorted according to weight.
+func (g *dmg) GetPaths(src, dst vertex, waypoints []addr.IA) pathSolutionList {
 	var solutions pathSolutionList
 	queue := pathSolutionList{&pathSolution{currentVertex: src}}
 	for len(queue) > 0 {
@@ -226,6 +227,9 @@ func (g *dmg) GetPaths(src, dst vertex) pathSolutionList {
 					})
 
 				if nextVertex == dst {
+					if !newSolution.traverses(waypoints) {
+						continue
+					}
 					solutions = append(solutions, newSolution)
 					// Do not break, because we want all solutions
 				} else {
@@ -308,6 +312,40 @@ type pathSolution struct {
 	cost int
 }
 
+// traverses returns whether the solution passes through all the waypoints. A
+// waypoint with a wildcard AS number matches any AS in its ISD.
+func (solution *pathSolution) traverses(waypoints []addr.IA) bool {
+	if len(waypoints) == 0 {
+		return true
+	}
+	matched := make([]bool, len(waypoints))
+	remaining := len(waypoints)
+	for _, solEdge := range solution.edges {
+		asEntries := solEdge.segment.ASEntries
+		for asEntryIdx := len(asEntries) - 1; asEntryIdx >= solEdge.edge.Shortcut; asEntryIdx-- {
+			local := asEntries[asEntryIdx].Local
+			for i, wp := range waypoints {
+				if matched[i] || !matchesWaypoint(local, wp) {
+					continue
+				}
+				matched[i] = true
+				remaining--
+			}
+			if remaining == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesWaypoint(ia, waypoint addr.IA) bool {
+	if waypoint.AS() == 0 {
+		return ia.ISD() == waypoint.ISD()
+	}
+	return ia == waypoint
+}
+
 // Path builds the forwarding path with metadata by extracting it from a path
 // between source and destination in the DMG.
 func (solution *pathSolution) Path() Path {
@@ -343,6 +381,7 @@ func (solution *pathSolution) Path() Path {
 					ConsIngress: entry.HopField.ConsIngress,
 					ConsEgress:  entry.HopField.ConsEgress,
 					Mac:         entry.HopField.MAC,
+					KeyPhase:    entry.HopField.KeyPhase,
 				}
 				forwardingLinkMtu = entry.IngressMTU
 				epicAuth = getAuth(&asEntry)
@@ -355,6 +394,7 @@ func (solution *pathSolution) Path() Path {
 					ConsIngress: peer.HopField.ConsIngress,
 					ConsEgress:  peer.HopField.ConsEgress,
 					Mac:         peer.HopField.MAC,
+					KeyPhase:    peer.HopField.KeyPhase,
 				}
 				forwardingLinkMtu = peer.PeerMTU
 				epicAuth = getAuthPeer(&asEntry, solEdge.edge.Peer-1)
@@ -426,6 +466,7 @@ func (solution *pathSolution) Path() Path {
 			LinkType:     staticInfo.LinkType,
 			InternalHops: staticInfo.InternalHops,
 			Notes:        staticInfo.Notes,
+			Peering:      segments.Peering(),
 		},
 		Weight: solution.cost,
 	}
@@ -662,6 +703,17 @@ func (s segmentList) ASEntries() []seg.ASEntry {
 	return asEntries
 }
 
+// Peering returns whether any of the segments is joined with the next one
+// over a peering link.
+func (s segmentList) Peering() bool {
+	for _, segment := range s {
+		if segment.InfoField.Peer {
+			return true
+		}
+	}
+	return false
+}
+
 func (s segmentList) ComputeExpTime() time.Time {
 	minTimestamp := maxExpirationTime
 	for _, segment := range s {
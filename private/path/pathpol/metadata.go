@@ -0,0 +1,319 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// Metadata is a path policy that checks the static metadata of a path against
+// a list of predicates, e.g. "latency < 50ms", "bandwidth > 100Mbps" or
+// "mtu >= 1400". A path is accepted only if it satisfies all predicates. A path
+// for which the metadata required by a predicate is not known, e.g. because an
+// AS on the path did not announce the latency of a hop, does not satisfy the
+// predicate.
+type Metadata struct {
+	Predicates []*MetadataPredicate
+}
+
+// Eval returns the set of paths that satisfy all predicates.
+func (m *Metadata) Eval(paths []snet.Path) []snet.Path {
+	if m == nil || len(m.Predicates) == 0 {
+		return paths
+	}
+	result := []snet.Path{}
+	for _, path := range paths {
+		if m.evalPath(path.Metadata()) {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
+func (m *Metadata) evalPath(pm *snet.PathMetadata) bool {
+	if pm == nil {
+		return false
+	}
+	for _, p := range m.Predicates {
+		if !p.Eval(pm) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Metadata) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Predicates)
+}
+
+func (m *Metadata) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &m.Predicates)
+}
+
+func (m *Metadata) MarshalYAML() (interface{}, error) {
+	return m.Predicates, nil
+}
+
+func (m *Metadata) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshal(&m.Predicates)
+}
+
+// MetadataAttribute is a path property that can be constrained by a
+// MetadataPredicate.
+type MetadataAttribute string
+
+const (
+	// AttributeLatency is the sum of the announced latencies of all hops.
+	AttributeLatency MetadataAttribute = "latency"
+	// AttributeBandwidth is the minimum of the announced bandwidths of all hops.
+	AttributeBandwidth MetadataAttribute = "bandwidth"
+	// AttributeMTU is the MTU of the path.
+	AttributeMTU MetadataAttribute = "mtu"
+)
+
+// Comparator is the comparison operator of a MetadataPredicate.
+type Comparator string
+
+const (
+	Less         Comparator = "<"
+	LessEqual    Comparator = "<="
+	Greater      Comparator = ">"
+	GreaterEqual Comparator = ">="
+	Equal        Comparator = "=="
+)
+
+// MetadataPredicate constrains a single metadata attribute of a path. The
+// string representation is "<attribute> <comparator> <value>". Latency values
+// are Go durations, e.g. "50ms", bandwidth values are bit rates with an
+// optional unit (bps, Kbps, Mbps, Gbps, Tbps), e.g. "100Mbps", and MTU values
+// are a number of bytes.
+type MetadataPredicate struct {
+	Attribute  MetadataAttribute
+	Comparator Comparator
+	// Value is the value the attribute is compared with. The unit is
+	// nanoseconds for the latency, Kbit/s for the bandwidth, and bytes for the
+	// MTU.
+	Value uint64
+}
+
+// MetadataPredicateFromString parses a metadata predicate.
+func MetadataPredicateFromString(str string) (*MetadataPredicate, error) {
+	parts := strings.Fields(str)
+	if len(parts) != 3 {
+		return nil, serrors.New("metadata predicate must consist of attribute, "+
+			"comparator and value", "predicate", str)
+	}
+	p := &MetadataPredicate{
+		Attribute:  MetadataAttribute(strings.ToLower(parts[0])),
+		Comparator: Comparator(parts[1]),
+	}
+	switch p.Comparator {
+	case Less, LessEqual, Greater, GreaterEqual, Equal:
+	default:
+		return nil, serrors.New("unknown comparator", "predicate", str,
+			"comparator", parts[1])
+	}
+	var err error
+	switch p.Attribute {
+	case AttributeLatency:
+		var d time.Duration
+		if d, err = time.ParseDuration(parts[2]); err == nil && d < 0 {
+			err = serrors.New("negative latency")
+		}
+		p.Value = uint64(d)
+	case AttributeBandwidth:
+		p.Value, err = parseBandwidth(parts[2])
+	case AttributeMTU:
+		p.Value, err = strconv.ParseUint(parts[2], 10, 16)
+	default:
+		return nil, serrors.New("unknown metadata attribute", "predicate", str,
+			"attribute", parts[0])
+	}
+	if err != nil {
+		return nil, serrors.WrapStr("parsing value", err, "predicate", str)
+	}
+	return p, nil
+}
+
+// Eval returns whether the path metadata satisfies the predicate.
+func (p *MetadataPredicate) Eval(pm *snet.PathMetadata) bool {
+	v, ok := p.attributeValue(pm)
+	if !ok {
+		return false
+	}
+	switch p.Comparator {
+	case Less:
+		return v < p.Value
+	case LessEqual:
+		return v <= p.Value
+	case Greater:
+		return v > p.Value
+	case GreaterEqual:
+		return v >= p.Value
+	case Equal:
+		return v == p.Value
+	default:
+		return false
+	}
+}
+
+// attributeValue extracts the attribute value from the metadata. It returns
+// false if the value is not known.
+func (p *MetadataPredicate) attributeValue(pm *snet.PathMetadata) (uint64, bool) {
+	switch p.Attribute {
+	case AttributeLatency:
+		// A path with N interfaces has N-1 latency entries.
+		if len(pm.Interfaces) == 0 || len(pm.Latency) != len(pm.Interfaces)-1 {
+			return 0, false
+		}
+		var total time.Duration
+		for _, l := range pm.Latency {
+			if l < 0 {
+				return 0, false
+			}
+			total += l
+		}
+		return uint64(total), true
+	case AttributeBandwidth:
+		if len(pm.Interfaces) == 0 || len(pm.Bandwidth) != len(pm.Interfaces)-1 {
+			return 0, false
+		}
+		var min uint64
+		for i, bw := range pm.Bandwidth {
+			if bw == 0 {
+				return 0, false
+			}
+			if i == 0 || bw < min {
+				min = bw
+			}
+		}
+		return min, true
+	case AttributeMTU:
+		if pm.MTU == 0 {
+			return 0, false
+		}
+		return uint64(pm.MTU), true
+	default:
+		return 0, false
+	}
+}
+
+func (p *MetadataPredicate) String() string {
+	var value string
+	switch p.Attribute {
+	case AttributeLatency:
+		value = time.Duration(p.Value).String()
+	case AttributeBandwidth:
+		value = formatBandwidth(p.Value)
+	default:
+		value = strconv.FormatUint(p.Value, 10)
+	}
+	return fmt.Sprintf("%s %s %s", p.Attribute, p.Comparator, value)
+}
+
+func (p *MetadataPredicate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p *MetadataPredicate) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	parsed, err := MetadataPredicateFromString(str)
+	if err != nil {
+		return err
+	}
+	*p = *parsed
+	return nil
+}
+
+func (p *MetadataPredicate) MarshalYAML() (interface{}, error) {
+	return p.String(), nil
+}
+
+func (p *MetadataPredicate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	parsed, err := MetadataPredicateFromString(str)
+	if err != nil {
+		return err
+	}
+	*p = *parsed
+	return nil
+}
+
+// bandwidthUnits maps the bit rate units to their value in Kbit/s, the unit
+// used in the path metadata.
+var bandwidthUnits = []struct {
+	name string
+	kbps uint64
+}{
+	{name: "Tbps", kbps: 1000 * 1000 * 1000},
+	{name: "Gbps", kbps: 1000 * 1000},
+	{name: "Mbps", kbps: 1000},
+	{name: "Kbps", kbps: 1},
+}
+
+// parseBandwidth parses a bit rate and returns it in Kbit/s. A value without
+// unit is interpreted as Kbit/s. A value in bit/s must be a multiple of 1Kbit/s.
+func parseBandwidth(s string) (uint64, error) {
+	lower := strings.ToLower(s)
+	for _, u := range bandwidthUnits {
+		suffix := strings.ToLower(u.name)
+		if strings.HasSuffix(lower, suffix) {
+			v, err := strconv.ParseUint(strings.TrimSuffix(lower, suffix), 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			if v > math.MaxUint64/u.kbps {
+				return 0, serrors.New("bandwidth out of range", "value", s)
+			}
+			return v * u.kbps, nil
+		}
+	}
+	if strings.HasSuffix(lower, "bps") {
+		v, err := strconv.ParseUint(strings.TrimSuffix(lower, "bps"), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		// The announced bandwidths have a granularity of Kbit/s. Truncating
+		// the value would change the meaning of the predicate.
+		if v%1000 != 0 {
+			return 0, serrors.New("bandwidth must be a multiple of 1Kbps", "value", s)
+		}
+		return v / 1000, nil
+	}
+	return strconv.ParseUint(lower, 10, 64)
+}
+
+func formatBandwidth(kbps uint64) string {
+	for _, u := range bandwidthUnits {
+		if kbps != 0 && kbps%u.kbps == 0 {
+			return strconv.FormatUint(kbps/u.kbps, 10) + u.name
+		}
+	}
+	return strconv.FormatUint(kbps, 10) + "Kbps"
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestMetadataPredicateFromString(t *testing.T) {
+	tests := map[string]struct {
+		Input    string
+		Expected *MetadataPredicate
+		String   string
+	}{
+		"latency": {
+			Input: "latency < 50ms",
+			Expected: &MetadataPredicate{
+				Attribute:  AttributeLatency,
+				Comparator: Less,
+				Value:      uint64(50 * time.Millisecond),
+			},
+			String: "latency < 50ms",
+		},
+		"bandwidth": {
+			Input: "bandwidth > 100Mbps",
+			Expected: &MetadataPredicate{
+				Attribute:  AttributeBandwidth,
+				Comparator: Greater,
+				Value:      100000,
+			},
+			String: "bandwidth > 100Mbps",
+		},
+		"bandwidth bps": {
+			Input: "bandwidth >= 2500000bps",
+			Expected: &MetadataPredicate{
+				Attribute:  AttributeBandwidth,
+				Comparator: GreaterEqual,
+				Value:      2500,
+			},
+			String: "bandwidth >= 2500Kbps",
+		},
+		"mtu": {
+			Input: "MTU >= 1400",
+			Expected: &MetadataPredicate{
+				Attribute:  AttributeMTU,
+				Comparator: GreaterEqual,
+				Value:      1400,
+			},
+			String: "mtu >= 1400",
+		},
+		"missing value":       {Input: "mtu >="},
+		"unknown attribute":   {Input: "jitter < 5ms"},
+		"unknown comparator":  {Input: "mtu != 1400"},
+		"invalid latency":     {Input: "latency < 50"},
+		"negative latency":    {Input: "latency < -5ms"},
+		"invalid bandwidth":   {Input: "bandwidth > fastMbps"},
+		"bandwidth overflow":  {Input: "bandwidth > 18446744073709552Tbps"},
+		"sub-Kbps bandwidth":  {Input: "bandwidth > 500bps"},
+		"partial Kbps":        {Input: "bandwidth > 1500bps"},
+		"mtu out of range":    {Input: "mtu > 70000"},
+		"too many parts":      {Input: "mtu > 1400 bytes"},
+		"missing whitespaces": {Input: "mtu>1400"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := MetadataPredicateFromString(test.Input)
+			if test.Expected == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, p)
+			assert.Equal(t, test.String, p.String())
+		})
+	}
+}
+
+func TestMetadataEval(t *testing.T) {
+	ifaces := []snet.PathInterface{
+		{IA: xtest.MustParseIA("1-ff00:0:110"), ID: 1},
+		{IA: xtest.MustParseIA("1-ff00:0:111"), ID: 2},
+		{IA: xtest.MustParseIA("1-ff00:0:111"), ID: 3},
+		{IA: xtest.MustParseIA("1-ff00:0:112"), ID: 4},
+	}
+	fast := snetpath.Path{
+		Meta: snet.PathMetadata{
+			Interfaces: ifaces,
+			MTU:        1472,
+			Latency:    []time.Duration{10 * time.Millisecond, 0, 15 * time.Millisecond},
+			Bandwidth:  []uint64{1000000, 400000, 1000000},
+		},
+	}
+	slow := snetpath.Path{
+		Meta: snet.PathMetadata{
+			Interfaces: ifaces,
+			MTU:        1280,
+			Latency:    []time.Duration{40 * time.Millisecond, 0, 15 * time.Millisecond},
+			Bandwidth:  []uint64{10000, 400000, 1000000},
+		},
+	}
+	unknown := snetpath.Path{
+		Meta: snet.PathMetadata{
+			Interfaces: ifaces,
+			Latency: []time.Duration{
+				10 * time.Millisecond, snet.LatencyUnset, 15 * time.Millisecond,
+			},
+			Bandwidth: []uint64{1000000, 0, 1000000},
+		},
+	}
+	paths := []snet.Path{fast, slow, unknown}
+
+	tests := map[string]struct {
+		Predicates []string
+		Expected   []snet.Path
+	}{
+		"no predicates": {
+			Expected: paths,
+		},
+		"latency": {
+			Predicates: []string{"latency < 50ms"},
+			Expected:   []snet.Path{fast},
+		},
+		"bandwidth": {
+			Predicates: []string{"bandwidth >= 400Mbps"},
+			Expected:   []snet.Path{fast},
+		},
+		"mtu": {
+			Predicates: []string{"mtu >= 1280"},
+			Expected:   []snet.Path{fast, slow},
+		},
+		"all": {
+			Predicates: []string{"latency <= 55ms", "bandwidth > 1Mbps", "mtu == 1280"},
+			Expected:   []snet.Path{slow},
+		},
+		"none": {
+			Predicates: []string{"latency < 1ms"},
+			Expected:   []snet.Path{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &Metadata{}
+			for _, p := range test.Predicates {
+				m.Predicates = append(m.Predicates, mustMetadataPredicate(t, p))
+			}
+			assert.Equal(t, test.Expected, m.Eval(paths))
+		})
+	}
+}
+
+func mustMetadataPredicate(t *testing.T, str string) *MetadataPredicate {
+	p, err := MetadataPredicateFromString(str)
+	require.NoError(t, err)
+	return p
+}
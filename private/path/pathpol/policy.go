@@ -14,13 +14,15 @@
 // limitations under the License.
 
 // Package pathpol implements path policies, documentation in doc/PathPolicy.md
-// Currently implemented: ACL, Sequence, Extends and Options.
+// Currently implemented: ACL, Sequence, Metadata, Extends and Options.
 //
 // A policy has Filter() method that takes a slice of paths and returns a
 // filtered slice of paths.
 package pathpol
 
 import (
+	"encoding/json"
+	"os"
 	"sort"
 
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -38,6 +40,50 @@ type ExtPolicy struct {
 // guaranteed to yield an object that is identical to the initial one.
 type PolicyMap map[string]*ExtPolicy
 
+// Policies resolves the extended policies of all policies in the map and
+// returns the resulting policies keyed by their name.
+func (m PolicyMap) Policies() (map[string]*Policy, error) {
+	extended := make([]*ExtPolicy, 0, len(m))
+	for name, extPolicy := range m {
+		if extPolicy == nil {
+			return nil, serrors.New("empty policy", "policy", name)
+		}
+		if extPolicy.Policy == nil {
+			extPolicy.Policy = &Policy{}
+		}
+		extPolicy.Name = name
+		extended = append(extended, extPolicy)
+	}
+	policies := make(map[string]*Policy, len(m))
+	for name, extPolicy := range m {
+		policy, err := PolicyFromExtPolicy(extPolicy, extended)
+		if err != nil {
+			return nil, serrors.WrapStr("resolving policy", err, "policy", name)
+		}
+		policies[name] = policy
+	}
+	return policies, nil
+}
+
+// LoadPolicyMap loads the named policies from a JSON file that contains a
+// PolicyMap, e.g.
+//
+//	{
+//	  "no_133": {"acl": ["- 1-ff00:0:133#0", "+"]},
+//	  "via_111": {"extends": ["no_133"], "sequence": "0* 1-ff00:0:111 0*"}
+//	}
+func LoadPolicyMap(file string) (map[string]*Policy, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("reading policy file", err, "file", file)
+	}
+	var m PolicyMap
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, serrors.WrapStr("parsing policy file", err, "file", file)
+	}
+	return m.Policies()
+}
+
 // FilterOptions contains options for filtering.
 type FilterOptions struct {
 	// IgnoreSequence can be used to ignore the sequence part of policies.
@@ -51,6 +97,7 @@ type Policy struct {
 	Sequence    *Sequence    `json:"sequence,omitempty"`
 	LocalISDAS  *LocalISDAS  `json:"local_isd_ases,omitempty"`
 	RemoteISDAS *RemoteISDAS `json:"remote_isd_ases,omitempty"`
+	Metadata    *Metadata    `json:"metadata,omitempty"`
 	Options     []Option     `json:"options,omitempty"`
 }
 
@@ -82,6 +129,9 @@ func (p *Policy) FilterOpt(paths []snet.Path, opts FilterOptions) []snet.Path {
 		paths = p.RemoteISDAS.Eval(paths)
 	}
 	paths = p.ACL.Eval(paths)
+	if p.Metadata != nil {
+		paths = p.Metadata.Eval(paths)
+	}
 	if p.Sequence != nil && !opts.IgnoreSequence {
 		paths = p.Sequence.Eval(paths)
 	}
@@ -145,6 +195,10 @@ func (p *Policy) applyExtended(extends []string, exPolicies []*ExtPolicy) error
 		if p.RemoteISDAS == nil {
 			p.RemoteISDAS = policy.RemoteISDAS
 		}
+		// Replace metadata filter.
+		if p.Metadata == nil {
+			p.Metadata = policy.Metadata
+		}
 	}
 	return nil
 }
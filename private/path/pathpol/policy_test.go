@@ -17,6 +17,8 @@ package pathpol
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -610,6 +612,12 @@ func TestPolicyJsonConversion(t *testing.T) {
 							},
 						},
 					},
+					Metadata: &Metadata{
+						Predicates: []*MetadataPredicate{
+							mustMetadataPredicate(t, "latency < 50ms"),
+							mustMetadataPredicate(t, "bandwidth >= 100Mbps"),
+						},
+					},
 				},
 			},
 			Weight: 0,
@@ -623,6 +631,43 @@ func TestPolicyJsonConversion(t *testing.T) {
 	assert.Equal(t, policy, &pol)
 }
 
+func TestLoadPolicyMap(t *testing.T) {
+	write := func(t *testing.T, raw string) string {
+		file := filepath.Join(t.TempDir(), "policies.json")
+		require.NoError(t, os.WriteFile(file, []byte(raw), 0644))
+		return file
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		file := write(t, `{
+			"no_133": {"acl": ["- 1-ff00:0:133#0", "+"]},
+			"via_111": {"extends": ["no_133"], "sequence": "0* 1-ff00:0:111 0*"}
+		}`)
+		policies, err := LoadPolicyMap(file)
+		require.NoError(t, err)
+		require.Len(t, policies, 2)
+		assert.Equal(t, "no_133", policies["no_133"].Name)
+		assert.NotNil(t, policies["no_133"].ACL)
+		assert.Nil(t, policies["no_133"].Sequence)
+		assert.Equal(t, "via_111", policies["via_111"].Name)
+		assert.Equal(t, policies["no_133"].ACL, policies["via_111"].ACL)
+		assert.NotNil(t, policies["via_111"].Sequence)
+	})
+	t.Run("unknown extended policy", func(t *testing.T) {
+		file := write(t, `{"a": {"extends": ["b"]}}`)
+		_, err := LoadPolicyMap(file)
+		assert.Error(t, err)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := LoadPolicyMap(write(t, `{"a": `))
+		assert.Error(t, err)
+	})
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadPolicyMap(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+}
+
 func newSequence(t *testing.T, str string) *Sequence {
 	seq, err := NewSequence(str)
 	require.NoError(t, err)
@@ -70,6 +70,21 @@ func (mr *MockDBMockRecorder) DeleteExpired(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockDB)(nil).DeleteExpired), arg0, arg1)
 }
 
+// DeleteFromHPGroup mocks base method.
+func (m *MockDB) DeleteFromHPGroup(arg0 context.Context, arg1 []byte, arg2 uint64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFromHPGroup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFromHPGroup indicates an expected call of DeleteFromHPGroup.
+func (mr *MockDBMockRecorder) DeleteFromHPGroup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHPGroup", reflect.TypeOf((*MockDB)(nil).DeleteFromHPGroup), arg0, arg1, arg2)
+}
+
 // Get mocks base method.
 func (m *MockDB) Get(arg0 context.Context, arg1 *query.Params) (query.Results, error) {
 	m.ctrl.T.Helper()
@@ -212,6 +227,21 @@ func (mr *MockTransactionMockRecorder) DeleteExpired(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockTransaction)(nil).DeleteExpired), arg0, arg1)
 }
 
+// DeleteFromHPGroup mocks base method.
+func (m *MockTransaction) DeleteFromHPGroup(arg0 context.Context, arg1 []byte, arg2 uint64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFromHPGroup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFromHPGroup indicates an expected call of DeleteFromHPGroup.
+func (mr *MockTransactionMockRecorder) DeleteFromHPGroup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHPGroup", reflect.TypeOf((*MockTransaction)(nil).DeleteFromHPGroup), arg0, arg1, arg2)
+}
+
 // Get mocks base method.
 func (m *MockTransaction) Get(arg0 context.Context, arg1 *query.Params) (query.Results, error) {
 	m.ctrl.T.Helper()
@@ -354,6 +384,21 @@ func (mr *MockReadWriteMockRecorder) DeleteExpired(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockReadWrite)(nil).DeleteExpired), arg0, arg1)
 }
 
+// DeleteFromHPGroup mocks base method.
+func (m *MockReadWrite) DeleteFromHPGroup(arg0 context.Context, arg1 []byte, arg2 uint64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFromHPGroup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFromHPGroup indicates an expected call of DeleteFromHPGroup.
+func (mr *MockReadWriteMockRecorder) DeleteFromHPGroup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHPGroup", reflect.TypeOf((*MockReadWrite)(nil).DeleteFromHPGroup), arg0, arg1, arg2)
+}
+
 // Get mocks base method.
 func (m *MockReadWrite) Get(arg0 context.Context, arg1 *query.Params) (query.Results, error) {
 	m.ctrl.T.Helper()
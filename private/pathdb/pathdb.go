@@ -57,6 +57,10 @@ type ReadWrite interface {
 	// DeleteExpired deletes all paths segments that are expired, using now as a reference.
 	// Returns the number of deleted segments.
 	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+	// DeleteFromHPGroup removes the path segment with the given ID from the
+	// hidden path group. The path segment is deleted if it is not in any other
+	// group. Returns the number of deleted segments.
+	DeleteFromHPGroup(ctx context.Context, segID []byte, hpGroupID uint64) (int, error)
 	// InsertNextQuery inserts or updates the timestamp nextQuery for the given
 	// src-dst pair and policy. Returns true if an insert/update happened or
 	// false if the stored timestamp is already newer.
@@ -36,6 +36,74 @@ type Params struct {
 	Intfs      []*IntfSpec
 	StartsAt   []addr.IA
 	EndsAt     []addr.IA
+	// TransitISDs restricts the result to segments that only traverse ASes in
+	// the listed ISDs. If empty, segments in any ISD are returned.
+	TransitISDs []addr.ISD
+	// MaxHops restricts the result to segments with at most MaxHops AS
+	// entries. If zero, the number of hops is not restricted.
+	MaxHops int
+	// ValidUntil restricts the result to segments that do not expire before
+	// the given time. If zero, the expiry is not restricted.
+	ValidUntil time.Time
+}
+
+// TraversedBy returns whether the segment traverses the interface. The
+// interfaces are matched the same way they are indexed in the path database,
+// i.e., both interfaces of the hop entry and the ingress interface of the peer
+// entries are considered.
+func (s *IntfSpec) TraversedBy(ps *seg.PathSegment) bool {
+	if s.IfID == 0 {
+		return false
+	}
+	for _, entry := range ps.ASEntries {
+		if entry.Local != s.IA {
+			continue
+		}
+		hf := entry.HopEntry.HopField
+		if s.IfID == common.IFIDType(hf.ConsIngress) ||
+			s.IfID == common.IFIDType(hf.ConsEgress) {
+			return true
+		}
+		for _, peer := range entry.PeerEntries {
+			if s.IfID == common.IFIDType(peer.HopField.ConsIngress) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Matches returns whether the segment satisfies the TransitISDs, MaxHops and
+// ValidUntil filters of the parameters. The remaining parameters are matched
+// against the indexed columns of the path database. A nil parameter set
+// matches every segment.
+func (p *Params) Matches(ps *seg.PathSegment) bool {
+	if p == nil {
+		return true
+	}
+	if p.MaxHops > 0 && len(ps.ASEntries) > p.MaxHops {
+		return false
+	}
+	if !p.ValidUntil.IsZero() && ps.MinExpiry().Before(p.ValidUntil) {
+		return false
+	}
+	if len(p.TransitISDs) > 0 {
+		for _, entry := range ps.ASEntries {
+			if !containsISD(p.TransitISDs, entry.Local.ISD()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsISD(isds []addr.ISD, isd addr.ISD) bool {
+	for _, i := range isds {
+		if i == isd {
+			return true
+		}
+	}
+	return false
 }
 
 type Result struct {
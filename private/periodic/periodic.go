@@ -156,6 +156,25 @@ func (r *Runner) Stop() {
 	r.metric.event(EventStop)
 }
 
+// Shutdown is like Stop, it waits for the current run of the task to finish.
+// If ctx is done before that, the context of the current run is canceled as
+// with Kill.
+func (r *Runner) Shutdown(ctx context.Context) {
+	if r == nil {
+		return
+	}
+	r.ticker.Stop()
+	close(r.stop)
+	select {
+	case <-r.loopFinished:
+		r.metric.event(EventStop)
+	case <-ctx.Done():
+		r.cancelF()
+		<-r.loopFinished
+		r.metric.event(EventKill)
+	}
+}
+
 // Kill is like stop but it also cancels the context of the current running method.
 func (r *Runner) Kill() {
 	if r == nil {
@@ -209,6 +228,9 @@ func (r *Runner) onTick() {
 		ctx, cancelF := context.WithTimeout(r.ctx, r.timeout)
 		span, ctx := opentracing.StartSpanFromContext(ctx, "periodic."+r.task.Name())
 		defer span.Finish()
+		// Every run is traced separately, such that the RPCs issued by the
+		// task can be correlated across services.
+		ctx = log.WithTraceID(ctx, log.NewTraceID())
 		start := time.Now()
 		r.task.Run(ctx)
 		r.metric.setRuntime(time.Since(start))
@@ -174,6 +174,55 @@ func TestTaskDoesNotRunAfterKill(t *testing.T) {
 	assert.LessOrEqual(t, p.Seconds()/1e9, metrics.GaugeValue(m.Runtime))
 }
 
+func TestShutdown(t *testing.T) {
+	testCases := map[string]struct {
+		ShutdownTimeout time.Duration
+		WantErr         error
+		WantEvent       string
+	}{
+		"run finishes": {
+			ShutdownTimeout: time.Second,
+			WantErr:         nil,
+			WantEvent:       periodic.EventStop,
+		},
+		"run canceled": {
+			ShutdownTimeout: 10 * time.Millisecond,
+			WantErr:         context.Canceled,
+			WantEvent:       periodic.EventKill,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			events := metrics.NewTestCounter()
+			m := periodic.Metrics{
+				Events: func(s string) metrics.Counter {
+					return events.With("event_type", s)
+				},
+			}
+			started, errChan := make(chan struct{}), make(chan error, 1)
+			fn := taskFunc(func(ctx context.Context) {
+				close(started)
+				select {
+				case <-ctx.Done():
+				case <-time.After(100 * time.Millisecond):
+				}
+				errChan <- ctx.Err()
+			})
+			r := periodic.StartWithMetrics(fn, &m, 10*time.Millisecond, time.Hour)
+			xtest.AssertReadReturnsBefore(t, started, time.Second)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.ShutdownTimeout)
+			defer cancel()
+			err := runWithTimeout(func() { r.Shutdown(ctx) }, 2*time.Second)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.WantErr, <-errChan)
+			assert.Equal(t, float64(1), metrics.CounterValue(m.Events(tc.WantEvent)))
+		})
+	}
+}
+
 func TestTriggerNow(t *testing.T) {
 	events := metrics.NewTestCounter()
 	m := periodic.Metrics{
@@ -0,0 +1,134 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"hash/fnv"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// GRPCLimiter limits the rate of the requests served by a gRPC server, per
+// client and in total. Requests above the rate are rejected with
+// codes.ResourceExhausted, the gRPC equivalent of HTTP status 429, which
+// tells the clients to back off.
+type GRPCLimiter struct {
+	// Services are the full names of the limited gRPC services, e.g.,
+	// "proto.control_plane.v1.SegmentLookupService". The requests for other
+	// services are not limited.
+	Services []string
+	// PerClient, if not nil, limits the requests per client. Clients are
+	// identified by their IP address and SCION peers by their ISD-AS.
+	PerClient *Limiter
+	// Global, if not nil, limits the requests of all clients together.
+	Global *Limiter
+	// Rejected, if not nil, counts the rejected requests. It is labeled with
+	// the method and the limit that was exceeded, "client" or "global".
+	Rejected metrics.Counter
+}
+
+// UnaryServerInterceptor returns an interceptor that rejects the unary
+// requests above the rate limits.
+func (l *GRPCLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+
+		if err := l.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that rejects the streams
+// above the rate limits.
+func (l *GRPCLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+
+		if err := l.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (l *GRPCLimiter) allow(ctx context.Context, method string) error {
+	if !l.limited(method) {
+		return nil
+	}
+	now := time.Now()
+	// The client limit is checked first, such that a misbehaving client does
+	// not use up the global limit.
+	if l.PerClient != nil && !l.PerClient.Allow(clientKey(ctx), now) {
+		metrics.CounterInc(metrics.CounterWith(l.Rejected, "method", method, "limit", "client"))
+		return status.Error(codes.ResourceExhausted, "client rate limit exceeded")
+	}
+	if l.Global != nil && !l.Global.Allow(0, now) {
+		metrics.CounterInc(metrics.CounterWith(l.Rejected, "method", method, "limit", "global"))
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return nil
+}
+
+func (l *GRPCLimiter) limited(method string) bool {
+	for _, s := range l.Services {
+		if strings.HasPrefix(method, "/"+s+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// clientKey returns the key of the client of the request. The port is not
+// part of the key, clients can choose it freely. Clients in other ASes are
+// identified by their ISD-AS only, since they can also choose the host address
+// in their AS freely.
+func clientKey(ctx context.Context) uint64 {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return 0
+	}
+	h := fnv.New64a()
+	switch a := p.Addr.(type) {
+	case *net.TCPAddr:
+		h.Write(a.IP.To16())
+	case *net.UDPAddr:
+		h.Write(a.IP.To16())
+	case *snet.UDPAddr:
+		h.Write([]byte(a.IA.String()))
+	default:
+		h.Write([]byte(a.String()))
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/ratelimit"
+)
+
+func TestGRPCLimiter(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	call := func(l *ratelimit.GRPCLimiter, method string, client net.Addr) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: client})
+		_, err := l.UnaryServerInterceptor()(ctx, nil,
+			&grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+	const limited = "/proto.control_plane.v1.SegmentLookupService/Segments"
+	client1 := &net.TCPAddr{IP: net.IP{10, 0, 0, 1}, Port: 40000}
+	client2 := &net.TCPAddr{IP: net.IP{10, 0, 0, 2}, Port: 40000}
+
+	t.Run("per client", func(t *testing.T) {
+		rejected := metrics.NewTestCounter()
+		l := &ratelimit.GRPCLimiter{
+			Services:  []string{"proto.control_plane.v1.SegmentLookupService"},
+			PerClient: &ratelimit.Limiter{Rate: 0.001, Burst: 2},
+			Rejected:  rejected,
+		}
+		assert.NoError(t, call(l, limited, client1))
+		assert.NoError(t, call(l, limited, client1))
+		err := call(l, limited, client1)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.NoError(t, call(l, limited, client2))
+		// Other services are not limited.
+		assert.NoError(t, call(l, "/proto.control_plane.v1.TrustMaterialService/TRC", client1))
+		assert.Equal(t, 1.0, metrics.CounterValue(
+			rejected.With("method", limited, "limit", "client")))
+	})
+	t.Run("per AS", func(t *testing.T) {
+		l := &ratelimit.GRPCLimiter{
+			Services:  []string{"proto.control_plane.v1.SegmentLookupService"},
+			PerClient: &ratelimit.Limiter{Rate: 0.001, Burst: 2},
+		}
+		remote := func(ia string, ip net.IP) net.Addr {
+			return &snet.UDPAddr{
+				IA:   xtest.MustParseIA(ia),
+				Host: &net.UDPAddr{IP: ip, Port: 40000},
+			}
+		}
+		// A remote AS does not get around the limit by changing the host
+		// address.
+		assert.NoError(t, call(l, limited, remote("1-ff00:0:110", client1.IP)))
+		assert.NoError(t, call(l, limited, remote("1-ff00:0:110", client2.IP)))
+		err := call(l, limited, remote("1-ff00:0:110", net.IP{10, 0, 0, 3}))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.NoError(t, call(l, limited, remote("1-ff00:0:111", client1.IP)))
+	})
+	t.Run("global", func(t *testing.T) {
+		l := &ratelimit.GRPCLimiter{
+			Services:  []string{"proto.control_plane.v1.SegmentLookupService"},
+			PerClient: &ratelimit.Limiter{Rate: 0.001, Burst: 2},
+			Global:    &ratelimit.Limiter{Rate: 0.001, Burst: 3},
+		}
+		assert.NoError(t, call(l, limited, client1))
+		assert.NoError(t, call(l, limited, client1))
+		assert.NoError(t, call(l, limited, client2))
+		err := call(l, limited, client2)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit implements token bucket rate limiters.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter limits the rate of events per key. Each key has its own token
+// bucket, which holds at most Burst tokens and is refilled with Rate tokens
+// per second. An event is allowed if the bucket of its key holds a token.
+//
+// Buckets that are full again are removed from time to time, so the memory
+// used by the limiter is bounded by the number of keys that are active within
+// Burst/Rate seconds. Limiter is safe for concurrent use.
+type Limiter struct {
+	// Rate is the number of events per second allowed per key. If it is
+	// zero or negative, all events are allowed.
+	Rate float64
+	// Burst is the number of events allowed in a burst per key. If it is
+	// smaller than one, a burst of one event is allowed.
+	Burst int
+
+	mtx       sync.Mutex
+	buckets   map[uint64]*bucket
+	sweepSize int
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Allow reports whether an event for key is allowed at time now, and if so,
+// takes a token from the bucket of key.
+func (l *Limiter) Allow(key uint64, now time.Time) bool {
+	if l.Rate <= 0 {
+		return true
+	}
+	burst := l.burst()
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.buckets == nil {
+		l.buckets = make(map[uint64]*bucket)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		l.sweep(now, burst)
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * l.Rate
+		if b.tokens > burst {
+			b.tokens = burst
+		}
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep removes the full buckets once the number of buckets has doubled since
+// the last sweep. Removing a full bucket does not change the behavior of the
+// limiter, a new bucket is created full.
+func (l *Limiter) sweep(now time.Time, burst float64) {
+	if len(l.buckets) < 2*l.sweepSize || len(l.buckets) < 64 {
+		return
+	}
+	for k, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.Rate >= burst {
+			delete(l.buckets, k)
+		}
+	}
+	l.sweepSize = len(l.buckets)
+}
+
+func (l *Limiter) burst() float64 {
+	if l.Burst < 1 {
+		return 1
+	}
+	return float64(l.Burst)
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/private/ratelimit"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Now()
+
+	t.Run("unlimited", func(t *testing.T) {
+		l := &ratelimit.Limiter{}
+		for i := 0; i < 100; i++ {
+			assert.True(t, l.Allow(1, now))
+		}
+	})
+	t.Run("burst and refill", func(t *testing.T) {
+		l := &ratelimit.Limiter{Rate: 10, Burst: 3}
+		for i := 0; i < 3; i++ {
+			assert.True(t, l.Allow(1, now), i)
+		}
+		assert.False(t, l.Allow(1, now))
+		// Other keys have their own bucket.
+		assert.True(t, l.Allow(2, now))
+
+		assert.False(t, l.Allow(1, now.Add(50*time.Millisecond)))
+		assert.True(t, l.Allow(1, now.Add(100*time.Millisecond)))
+		assert.False(t, l.Allow(1, now.Add(100*time.Millisecond)))
+
+		// The bucket holds at most Burst tokens.
+		later := now.Add(time.Hour)
+		for i := 0; i < 3; i++ {
+			assert.True(t, l.Allow(1, later), i)
+		}
+		assert.False(t, l.Allow(1, later))
+	})
+	t.Run("default burst", func(t *testing.T) {
+		l := &ratelimit.Limiter{Rate: 1}
+		assert.True(t, l.Allow(1, now))
+		assert.False(t, l.Allow(1, now))
+	})
+	t.Run("many keys", func(t *testing.T) {
+		l := &ratelimit.Limiter{Rate: 1, Burst: 1}
+		for k := uint64(0); k < 1000; k++ {
+			assert.True(t, l.Allow(k, now.Add(time.Duration(k)*time.Second)))
+		}
+		// The exhausted bucket of the most recent key survives the sweeps.
+		assert.False(t, l.Allow(999, now.Add(999*time.Second)))
+	})
+}
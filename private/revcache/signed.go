@@ -0,0 +1,95 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revcache
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	pmproto "github.com/scionproto/scion/pkg/private/ctrl/path_mgmt/proto"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
+	"github.com/scionproto/scion/pkg/scrypto/signed"
+	seg "github.com/scionproto/scion/pkg/segment"
+	infra "github.com/scionproto/scion/private/segment/verifier"
+)
+
+// Sign signs the revocation with the given signer and returns the signed
+// message. The revocation itself is not modified.
+func Sign(ctx context.Context, signer seg.Signer,
+	rev *path_mgmt.RevInfo) (*cryptopb.SignedMessage, error) {
+
+	raw, err := proto.Marshal(&cppb.RevocationBody{
+		IsdAs:       uint64(rev.RawIsdas),
+		InterfaceId: uint64(rev.IfID),
+		LinkType:    uint32(rev.LinkType),
+		Timestamp:   rev.RawTimestamp,
+		Ttl:         rev.RawTTL,
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("packing revocation", err)
+	}
+	return signer.Sign(ctx, raw)
+}
+
+// Verify verifies the signed revocation and returns the contained revocation.
+// The signature must have been created by the AS that revokes the interface,
+// and the revocation must be active.
+func Verify(ctx context.Context, verifier infra.Verifier,
+	sm *cryptopb.SignedMessage) (*path_mgmt.RevInfo, error) {
+
+	raw, err := signed.ExtractUnverifiedBody(sm)
+	if err != nil {
+		return nil, serrors.WrapStr("extracting revocation", err)
+	}
+	unverified, err := parseBody(raw)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := verifier.WithIA(addr.IA(unverified.IsdAs)).Verify(ctx, sm)
+	if err != nil {
+		return nil, serrors.WrapStr("verifying revocation", err,
+			"isd_as", addr.IA(unverified.IsdAs))
+	}
+	body, err := parseBody(msg.Body)
+	if err != nil {
+		return nil, err
+	}
+	rev := &path_mgmt.RevInfo{
+		IfID:         common.IFIDType(body.InterfaceId),
+		RawIsdas:     addr.IA(body.IsdAs),
+		LinkType:     pmproto.LinkType(body.LinkType),
+		RawTimestamp: body.Timestamp,
+		RawTTL:       body.Ttl,
+		Signed:       sm,
+	}
+	if err := rev.Active(); err != nil {
+		return nil, serrors.WrapStr("revocation not active", err, "revocation", rev)
+	}
+	return rev, nil
+}
+
+func parseBody(raw []byte) (*cppb.RevocationBody, error) {
+	var body cppb.RevocationBody
+	if err := proto.Unmarshal(raw, &body); err != nil {
+		return nil, serrors.WrapStr("parsing revocation", err)
+	}
+	return &body, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revcache_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt/proto"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
+	"github.com/scionproto/scion/pkg/scrypto/signed"
+	"github.com/scionproto/scion/private/revcache"
+	infra "github.com/scionproto/scion/private/segment/verifier"
+)
+
+func TestSignVerify(t *testing.T) {
+	key := mustGenerateKey(t)
+	signer := testSigner{key: key}
+	newRev := func(ia addr.IA, ts time.Time) *path_mgmt.RevInfo {
+		return &path_mgmt.RevInfo{
+			IfID:         5,
+			RawIsdas:     ia,
+			LinkType:     proto.LinkType_child,
+			RawTimestamp: util.TimeToSecs(ts),
+			RawTTL:       uint32(path_mgmt.MinRevTTL.Seconds()),
+		}
+	}
+
+	testCases := map[string]struct {
+		rev       *path_mgmt.RevInfo
+		verifier  testVerifier
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			rev:       newRev(ia110, time.Now()),
+			verifier:  testVerifier{key: &key.PublicKey, signers: []addr.IA{ia110}},
+			assertErr: assert.NoError,
+		},
+		"signed by other AS": {
+			rev:       newRev(ia110, time.Now()),
+			verifier:  testVerifier{key: &key.PublicKey, signers: []addr.IA{ia211}},
+			assertErr: assert.Error,
+		},
+		"wrong key": {
+			rev: newRev(ia110, time.Now()),
+			verifier: testVerifier{
+				key:     &mustGenerateKey(t).PublicKey,
+				signers: []addr.IA{ia110},
+			},
+			assertErr: assert.Error,
+		},
+		"expired": {
+			rev:       newRev(ia110, time.Now().Add(-time.Minute)),
+			verifier:  testVerifier{key: &key.PublicKey, signers: []addr.IA{ia110}},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			sm, err := revcache.Sign(context.Background(), signer, tc.rev)
+			require.NoError(t, err)
+			rev, err := revcache.Verify(context.Background(), tc.verifier, sm)
+			tc.assertErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.True(t, tc.rev.Equal(rev))
+			assert.Equal(t, sm, rev.Signed)
+		})
+	}
+}
+
+func mustGenerateKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+type testSigner struct {
+	key crypto.Signer
+}
+
+func (s testSigner) Sign(_ context.Context, msg []byte,
+	associatedData ...[]byte) (*cryptopb.SignedMessage, error) {
+
+	hdr := signed.Header{
+		SignatureAlgorithm: signed.ECDSAWithSHA256,
+		Timestamp:          time.Now(),
+	}
+	return signed.Sign(hdr, msg, s.key, associatedData...)
+}
+
+// testVerifier verifies signatures with a fixed key that belongs to one of the
+// signer ASes.
+type testVerifier struct {
+	key     crypto.PublicKey
+	signers []addr.IA
+	ia      addr.IA
+}
+
+func (v testVerifier) Verify(_ context.Context, sm *cryptopb.SignedMessage,
+	associatedData ...[]byte) (*signed.Message, error) {
+
+	if !v.ia.IsZero() {
+		found := false
+		for _, ia := range v.signers {
+			found = found || ia == v.ia
+		}
+		if !found {
+			return nil, serrors.New("no key for AS", "isd_as", v.ia)
+		}
+	}
+	return signed.Verify(sm, v.key, associatedData...)
+}
+
+func (v testVerifier) WithServer(net.Addr) infra.Verifier {
+	return v
+}
+
+func (v testVerifier) WithIA(ia addr.IA) infra.Verifier {
+	v.ia = ia
+	return v
+}
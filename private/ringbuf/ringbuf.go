@@ -16,6 +16,7 @@ package ringbuf
 
 import (
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/private/ringbuf/internal/metrics"
 )
@@ -126,14 +127,42 @@ func (r *Ring) Read(entries EntryList, block bool) (int, bool) {
 		// available.
 		return -1, blocked
 	}
-	n := min(r.readable, len(entries))
-	r.read(entries[:n])
-	r.readable -= n
-	r.writable += n
-	r.writableC.Broadcast()
-	r.metrics.ReadEntries.Observe(float64(n))
-	r.metrics.UsedEntries.Set(float64(r.readable))
-	return n, blocked
+	return r.readLocked(entries), blocked
+}
+
+// ReadDeadline copies entries from the internal ring buffer. It blocks until
+// it is able to read at least one entry, the Ring is closed, or the deadline
+// expires.
+// In case entries is of length zero, the call returns immediately.
+// Returns the number of entries read, 0 if the deadline expired, or -1 if the
+// RingBuf is closed, and a bool indicating if the read blocked.
+func (r *Ring) ReadDeadline(entries EntryList, deadline time.Time) (int, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var blocked, expired bool
+	r.metrics.ReadCalls.Inc()
+	if len(entries) > 0 && r.readable == 0 && !r.closed {
+		// The timer wakes up the waiting reader once the deadline expires.
+		timer := time.AfterFunc(time.Until(deadline), func() {
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+			expired = true
+			r.readableC.Broadcast()
+		})
+		defer timer.Stop()
+		r.metrics.ReadsBlocked.Inc()
+		for r.readable == 0 && !r.closed && !expired {
+			blocked = true
+			r.readableC.Wait()
+		}
+	}
+	if r.closed && r.readable == 0 {
+		return -1, blocked
+	}
+	if r.readable == 0 {
+		return 0, blocked
+	}
+	return r.readLocked(entries), blocked
 }
 
 // Close closes the ring buffer, and causes all blocked readers/writers to be
@@ -146,6 +175,19 @@ func (r *Ring) Close() {
 	r.readableC.Broadcast()
 }
 
+// readLocked reads as many entries as available and returns their number. The
+// mutex must be held.
+func (r *Ring) readLocked(entries EntryList) int {
+	n := min(r.readable, len(entries))
+	r.read(entries[:n])
+	r.readable -= n
+	r.writable += n
+	r.writableC.Broadcast()
+	r.metrics.ReadEntries.Observe(float64(n))
+	r.metrics.UsedEntries.Set(float64(r.readable))
+	return n
+}
+
 func (r *Ring) write(entries EntryList) {
 	n := copy(r.entries[r.writeIndex:], entries)
 	r.writeIndex += n
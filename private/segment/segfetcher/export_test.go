@@ -17,3 +17,11 @@ package segfetcher
 var (
 	RevocationsString = revocationsString
 )
+
+// Len returns the number of entries in the cache, including the expired ones
+// that have not been removed yet.
+func (c *NegativeCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
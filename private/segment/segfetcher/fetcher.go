@@ -20,12 +20,16 @@ import (
 	"net"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
-	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/pathdb"
 	"github.com/scionproto/scion/private/segment/segfetcher/internal/metrics"
 	"github.com/scionproto/scion/private/segment/seghandler"
+	"github.com/scionproto/scion/private/tracing"
 )
 
 const (
@@ -61,16 +65,35 @@ type Fetcher struct {
 	// QueryInterval specifies after how much time segments should be
 	// refetched at the remote server.
 	QueryInterval time.Duration
+	// NegativeCache caches requests that were answered without segments. If
+	// it is nil, every such request is forwarded to the remote server again.
+	// The cache is bypassed for refresh requests.
+	NegativeCache *NegativeCache
 	Metrics       metrics.Fetcher
+	// Clock provides the current time for the negative cache and the next
+	// query times. If nil, the wall clock is used.
+	Clock clock.Clock
 }
 
 // Fetch loads the requested segments from the path DB or requests them from a remote path server.
 func (f *Fetcher) Fetch(ctx context.Context, reqs Requests, refresh bool) (Segments, error) {
 	// Load local and cached segments from DB
-	loadedSegs, fetchReqs, err := f.Resolver.Resolve(ctx, reqs, refresh)
+	resolveCtx, span := tracing.StartOTelSpan(ctx, "segfetcher.resolve",
+		attribute.Bool("refresh", refresh),
+	)
+	loadedSegs, fetchReqs, err := f.Resolver.Resolve(resolveCtx, reqs, refresh)
+	span.SetAttributes(
+		attribute.Int("loaded_segments", len(loadedSegs)),
+		attribute.Int("fetch_requests", len(fetchReqs)),
+	)
+	tracing.OTelError(span, err)
+	span.End()
 	if err != nil {
 		return Segments{}, serrors.Wrap(errDB, err)
 	}
+	if !refresh {
+		fetchReqs = f.NegativeCache.filter(fetchReqs, clock.Now(f.Clock))
+	}
 	if len(fetchReqs) == 0 {
 		return loadedSegs, nil
 	}
@@ -94,7 +117,10 @@ func (f *Fetcher) Request(ctx context.Context, reqs Requests) (Segments, error)
 func (f *Fetcher) waitOnProcessed(ctx context.Context,
 	replies <-chan ReplyOrErr) (Segments, error) {
 
-	var segs Segments
+	var (
+		segs     Segments
+		detailed error
+	)
 	logger := log.FromCtx(ctx)
 	for reply := range replies {
 		// TODO(lukedirtwalker): Should we do this in go routines?
@@ -104,13 +130,19 @@ func (f *Fetcher) waitOnProcessed(ctx context.Context,
 				labels.Result = metrics.ErrTimeout
 			}
 			f.Metrics.SegRequests(labels).Inc()
+			// Errors with gRPC error details are returned, such that the caller
+			// can report the cause if no paths are found.
+			if _, ok := libgrpc.DetailedStatus(reply.Err); ok && detailed == nil {
+				detailed = reply.Err
+			}
 			continue
 		}
 		if len(reply.Segments) == 0 {
+			f.NegativeCache.Add(reply.Req, clock.Now(f.Clock))
 			f.Metrics.SegRequests(labels.WithResult(metrics.OkSuccess)).Inc()
 			continue
 		}
-		r := f.ReplyHandler.Handle(ctx, replyToRecs(reply.Segments), reply.Peer)
+		r := f.handleReply(ctx, reply)
 		if err := r.Err(); err != nil {
 			f.Metrics.SegRequests(labels.WithResult(metrics.ErrProcess)).Inc()
 			return segs, serrors.WrapStr("processing reply", err)
@@ -121,6 +153,11 @@ func (f *Fetcher) waitOnProcessed(ctx context.Context,
 			log.FromCtx(ctx).Debug("Error during verification of segments/revocations",
 				"errors", r.VerificationErrors().ToError())
 		}
+		if r.Stats().SegsInserted() > 0 {
+			// New segments might make destinations with a cached negative
+			// answer reachable.
+			f.NegativeCache.Clear()
+		}
 		segs = append(segs, Segments(r.Stats().VerifiedSegs)...)
 		nextQuery := f.nextQuery(segs)
 		_, err := f.PathDB.InsertNextQuery(ctx, reply.Req.Src, reply.Req.Dst, nextQuery)
@@ -129,7 +166,20 @@ func (f *Fetcher) waitOnProcessed(ctx context.Context,
 		}
 		f.Metrics.SegRequests(labels.WithResult(metrics.OkSuccess)).Inc()
 	}
-	return segs, nil
+	return segs, detailed
+}
+
+// handleReply verifies and stores the segments of the reply.
+func (f *Fetcher) handleReply(ctx context.Context, reply ReplyOrErr) *seghandler.ProcessedResult {
+	ctx, span := tracing.StartOTelSpan(ctx, "segfetcher.process_reply",
+		attribute.String("req.src", reply.Req.Src.String()),
+		attribute.String("req.dst", reply.Req.Dst.String()),
+		attribute.Int("segments", len(reply.Segments)),
+	)
+	defer span.End()
+	r := f.ReplyHandler.Handle(ctx, replyToRecs(reply), reply.Peer)
+	tracing.OTelError(span, r.Err())
+	return r
 }
 
 // nextQuery decides the next time a query should be issued based on the
@@ -138,7 +188,7 @@ func (f *Fetcher) nextQuery(segs Segments) time.Time {
 	// Determine the lead time for the latest segment expiration.
 	// We want to request new segments before the last one has expired.
 	expirationLead := maxSegmentExpiry(segs).Add(-expirationLeadTime)
-	return f.nearestNextQueryTime(time.Now(), expirationLead)
+	return f.nearestNextQueryTime(clock.Now(f.Clock), expirationLead)
 }
 
 // nearestNextQueryTime finds the nearest next query time in the interval spanned
@@ -181,8 +231,9 @@ func maxSegmentExpiry(segs Segments) time.Time {
 	return max
 }
 
-func replyToRecs(reply []*seg.Meta) seghandler.Segments {
+func replyToRecs(reply ReplyOrErr) seghandler.Segments {
 	return seghandler.Segments{
-		Segs: reply,
+		Segs:      reply.Segments,
+		SRevInfos: reply.SRevInfos,
 	}
 }
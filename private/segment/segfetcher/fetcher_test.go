@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/private/pathdb/mock_pathdb"
 	"github.com/scionproto/scion/private/segment/segfetcher"
 	"github.com/scionproto/scion/private/segment/segfetcher/mock_segfetcher"
@@ -104,3 +105,81 @@ func TestFetcher(t *testing.T) {
 		})
 	}
 }
+
+func TestFetcherNegativeCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+
+	req := segfetcher.Request{SegType: Down, Src: core_130, Dst: non_core_111}
+	emptyReply := func(_ context.Context, reqs segfetcher.Requests) <-chan segfetcher.ReplyOrErr {
+		replies := make(chan segfetcher.ReplyOrErr, len(reqs))
+		for _, req := range reqs {
+			replies <- segfetcher.ReplyOrErr{Req: req}
+		}
+		close(replies)
+		return replies
+	}
+	tf := NewTestFetcher(ctrl)
+	tf.Resolver.EXPECT().Resolve(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(segfetcher.Segments{}, segfetcher.Requests{req}, nil).Times(4)
+	// The second fetch is answered from the negative cache, the third one is
+	// forwarded again after the cache was cleared and the fourth one bypasses
+	// the cache because it is a refresh.
+	tf.Requester.EXPECT().Request(gomock.Any(), segfetcher.Requests{req}).
+		DoAndReturn(emptyReply).Times(3)
+	f := tf.Fetcher()
+	f.NegativeCache = &segfetcher.NegativeCache{TTL: time.Minute}
+	f.Metrics = segfetcher.NewFetcherMetrics("negative_cache_test")
+
+	for i := 0; i < 2; i++ {
+		segs, err := f.Fetch(ctx, segfetcher.Requests{req}, false)
+		require.NoError(t, err)
+		assert.Empty(t, segs)
+	}
+	f.NegativeCache.Clear()
+	segs, err := f.Fetch(ctx, segfetcher.Requests{req}, false)
+	require.NoError(t, err)
+	assert.Empty(t, segs)
+	segs, err = f.Fetch(ctx, segfetcher.Requests{req}, true)
+	require.NoError(t, err)
+	assert.Empty(t, segs)
+}
+
+func TestFetcherNegativeCacheExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+
+	req := segfetcher.Request{SegType: Down, Src: core_130, Dst: non_core_111}
+	emptyReply := func(_ context.Context, reqs segfetcher.Requests) <-chan segfetcher.ReplyOrErr {
+		replies := make(chan segfetcher.ReplyOrErr, len(reqs))
+		for _, req := range reqs {
+			replies <- segfetcher.ReplyOrErr{Req: req}
+		}
+		close(replies)
+		return replies
+	}
+	tf := NewTestFetcher(ctrl)
+	tf.Resolver.EXPECT().Resolve(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(segfetcher.Segments{}, segfetcher.Requests{req}, nil).Times(3)
+	// The second fetch is answered from the negative cache, the third one is
+	// forwarded again after the cached answer expired.
+	tf.Requester.EXPECT().Request(gomock.Any(), segfetcher.Requests{req}).
+		DoAndReturn(emptyReply).Times(2)
+	c := clock.NewManual(time.Now())
+	f := tf.Fetcher()
+	f.NegativeCache = &segfetcher.NegativeCache{TTL: time.Minute}
+	f.Metrics = segfetcher.NewFetcherMetrics("negative_cache_expiry_test")
+	f.Clock = c
+
+	for i := 0; i < 2; i++ {
+		_, err := f.Fetch(ctx, segfetcher.Requests{req}, false)
+		require.NoError(t, err)
+	}
+	c.Advance(time.Minute + time.Second)
+	_, err := f.Fetch(ctx, segfetcher.Requests{req}, false)
+	require.NoError(t, err)
+}
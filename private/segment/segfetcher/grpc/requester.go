@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/peer"
 
 	libgrpc "github.com/scionproto/scion/pkg/grpc"
@@ -39,12 +40,23 @@ const (
 	// This timeout needs to be long enough to allow for service address
 	// resolution and the QUIC handshake to complete (two roundtrips).
 	DefaultRPCDialTimeout time.Duration = 1 * time.Second
+	// maxPages is the maximum number of pages that are requested for a single
+	// lookup.
+	maxPages = 1000
 )
 
 // Requester fetches segments from a remote using gRPC.
 type Requester struct {
 	// Dialer dials a new gRPC connection.
 	Dialer libgrpc.Dialer
+	// PageSize is the number of segments that are requested per page. If
+	// zero, the server decides on the page size.
+	PageSize int
+	// Compression enables the gzip compression of the requests and responses.
+	Compression bool
+	// Filter is sent with every request and restricts the segments that are
+	// returned by the server. If nil, the segments are not filtered.
+	Filter *cppb.SegmentsFilter
 }
 
 func (f *Requester) Segments(ctx context.Context, req segfetcher.Request,
@@ -59,31 +71,50 @@ func (f *Requester) Segments(ctx context.Context, req segfetcher.Request,
 	defer conn.Close()
 
 	var segPeer peer.Peer
-	client := cppb.NewSegmentLookupServiceClient(conn)
-	rep, err := client.Segments(ctx,
-		&cppb.SegmentsRequest{
-			SrcIsdAs: uint64(req.Src),
-			DstIsdAs: uint64(req.Dst),
-		},
-		libgrpc.RetryOption,
-		grpc.Peer(&segPeer),
-	)
-	if err != nil {
-		return segfetcher.SegmentsReply{}, err
+	opts := []grpc.CallOption{libgrpc.RetryOption, grpc.Peer(&segPeer)}
+	if f.Compression {
+		opts = append(opts, grpc.UseCompressor(gzip.Name))
 	}
-	var segs []*seg.Meta
-	for segType, segments := range rep.Segments {
-		for i, pb := range segments.Segments {
-			ps, err := seg.SegmentFromPB(pb)
-			if err != nil {
-				return segfetcher.SegmentsReply{},
-					serrors.WrapStr("parsing segments", err, "index", i)
+	client := cppb.NewSegmentLookupServiceClient(conn)
+	var reply segfetcher.SegmentsReply
+	var token []byte
+	for page := 0; ; page++ {
+		if page == maxPages {
+			return segfetcher.SegmentsReply{}, serrors.New("too many pages",
+				"pages", maxPages)
+		}
+		rep, err := client.Segments(ctx,
+			&cppb.SegmentsRequest{
+				SrcIsdAs:  uint64(req.Src),
+				DstIsdAs:  uint64(req.Dst),
+				PageSize:  uint32(f.PageSize),
+				PageToken: token,
+				Filter:    f.Filter,
+			},
+			opts...,
+		)
+		if err != nil {
+			return segfetcher.SegmentsReply{}, err
+		}
+		for segType, segments := range rep.Segments {
+			for i, pb := range segments.Segments {
+				ps, err := seg.SegmentFromPB(pb)
+				if err != nil {
+					return segfetcher.SegmentsReply{},
+						serrors.WrapStr("parsing segments", err, "index", i, "page", page)
+				}
+				reply.Segments = append(reply.Segments, &seg.Meta{
+					Type:    seg.Type(segType),
+					Segment: ps,
+				})
 			}
-			segs = append(segs, &seg.Meta{
-				Type:    seg.Type(segType),
-				Segment: ps,
-			})
 		}
+		reply.SRevInfos = append(reply.SRevInfos, rep.SignedRevocations...)
+		if len(rep.NextPageToken) == 0 {
+			break
+		}
+		token = rep.NextPageToken
 	}
-	return segfetcher.SegmentsReply{Segments: segs, Peer: segPeer.Addr}, nil
+	reply.Peer = segPeer.Addr
+	return reply, nil
 }
@@ -0,0 +1,106 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segfetcher
+
+import (
+	"sync"
+	"time"
+)
+
+// NegativeCache remembers segment requests that were answered without any
+// segments. While a request is cached, the fetcher does not forward it to the
+// remote server again. This prevents request storms towards the core for
+// destinations that are currently unreachable.
+//
+// The cache is safe for concurrent use. A nil cache is valid and caches
+// nothing.
+type NegativeCache struct {
+	// TTL is the time a negative answer is cached.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[Request]time.Time
+	// nextSweep is the time after which the next Add removes the expired
+	// entries.
+	nextSweep time.Time
+}
+
+// Add caches the negative answer for the request.
+func (c *NegativeCache) Add(req Request, now time.Time) {
+	if c == nil || c.TTL <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[Request]time.Time)
+	}
+	// Expired entries are otherwise only removed when they are looked up
+	// again, which never happens for one-off requests. Sweeping at most once
+	// per TTL bounds the cache to the requests added within two TTLs.
+	if !now.Before(c.nextSweep) {
+		for r, expiry := range c.entries {
+			if !now.Before(expiry) {
+				delete(c.entries, r)
+			}
+		}
+		c.nextSweep = now.Add(c.TTL)
+	}
+	c.entries[req] = now.Add(c.TTL)
+}
+
+// Contains returns whether a negative answer for the request is cached.
+func (c *NegativeCache) Contains(req Request, now time.Time) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expiry, ok := c.entries[req]
+	if !ok {
+		return false
+	}
+	if !now.Before(expiry) {
+		delete(c.entries, req)
+		return false
+	}
+	return true
+}
+
+// Clear drops all cached negative answers. It is called when new segments
+// arrive, since they might make previously unreachable destinations
+// reachable.
+func (c *NegativeCache) Clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+}
+
+// filter returns the requests that do not have a cached negative answer.
+func (c *NegativeCache) filter(reqs Requests, now time.Time) Requests {
+	if c == nil {
+		return reqs
+	}
+	filtered := make(Requests, 0, len(reqs))
+	for _, req := range reqs {
+		if !c.Contains(req, now) {
+			filtered = append(filtered, req)
+		}
+	}
+	return filtered
+}
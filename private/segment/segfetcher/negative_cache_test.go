@@ -0,0 +1,67 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segfetcher_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/private/segment/segfetcher"
+)
+
+func TestNegativeCache(t *testing.T) {
+	now := time.Now()
+	req := segfetcher.Request{SegType: Down, Src: core_130, Dst: non_core_111}
+	other := segfetcher.Request{SegType: Down, Src: core_130, Dst: non_core_112}
+
+	t.Run("nil cache", func(t *testing.T) {
+		var c *segfetcher.NegativeCache
+		c.Add(req, now)
+		assert.False(t, c.Contains(req, now))
+		c.Clear()
+	})
+	t.Run("zero TTL", func(t *testing.T) {
+		c := &segfetcher.NegativeCache{}
+		c.Add(req, now)
+		assert.False(t, c.Contains(req, now))
+	})
+	t.Run("expiry", func(t *testing.T) {
+		c := &segfetcher.NegativeCache{TTL: time.Second}
+		c.Add(req, now)
+		assert.True(t, c.Contains(req, now))
+		assert.True(t, c.Contains(req, now.Add(time.Second-1)))
+		assert.False(t, c.Contains(other, now))
+		assert.False(t, c.Contains(req, now.Add(time.Second)))
+	})
+	t.Run("sweep", func(t *testing.T) {
+		c := &segfetcher.NegativeCache{TTL: time.Second}
+		c.Add(req, now)
+		// The expired entry is removed by Add, even though it is never looked
+		// up again.
+		c.Add(other, now.Add(time.Second))
+		assert.Equal(t, 1, c.Len())
+		assert.True(t, c.Contains(other, now.Add(time.Second)))
+	})
+	t.Run("clear", func(t *testing.T) {
+		c := &segfetcher.NegativeCache{TTL: time.Second}
+		c.Add(req, now)
+		c.Add(other, now)
+		c.Clear()
+		assert.False(t, c.Contains(req, now))
+		assert.False(t, c.Contains(other, now))
+	})
+}
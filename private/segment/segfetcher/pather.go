@@ -20,10 +20,12 @@ import (
 	"fmt"
 	"net"
 	"sort"
-	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
 	rawpath "github.com/scionproto/scion/pkg/slayers/path"
@@ -31,6 +33,7 @@ import (
 	"github.com/scionproto/scion/pkg/snet/path"
 	"github.com/scionproto/scion/private/path/combinator"
 	"github.com/scionproto/scion/private/revcache"
+	"github.com/scionproto/scion/private/tracing"
 )
 
 // Pather errors.
@@ -38,6 +41,23 @@ var (
 	ErrBadDst = errors.New("bad destination AS")
 )
 
+type waypointsKey struct{}
+
+// ContextWithWaypoints attaches the waypoints to the context. Pather.GetPaths
+// only returns the paths that pass through all the waypoints attached to its
+// context. A waypoint with AS number 0 (e.g., 1-0) is satisfied by any AS of
+// that ISD.
+func ContextWithWaypoints(ctx context.Context, waypoints []addr.IA) context.Context {
+	return context.WithValue(ctx, waypointsKey{}, waypoints)
+}
+
+// WaypointsFromContext returns the waypoints that were attached to the context
+// with ContextWithWaypoints.
+func WaypointsFromContext(ctx context.Context) []addr.IA {
+	waypoints, _ := ctx.Value(waypointsKey{}).([]addr.IA)
+	return waypoints
+}
+
 // Pather is used to construct paths from the path database. If necessary, paths
 // are fetched over the network.
 type Pather struct {
@@ -49,12 +69,17 @@ type Pather struct {
 	RevCache revcache.RevCache
 	Fetcher  *Fetcher
 	Splitter Splitter
+	// Clock provides the current time against which the expiry of the paths
+	// is checked. If nil, the wall clock is used.
+	Clock clock.Clock
 }
 
 // GetPaths returns all non-revoked and non-expired paths to the destination.
 // The paths are sorted from best to worst according to the weighting in path
 // combinator. In case the destination AS is the same as the local AS, a slice
-// containing an empty path is returned.
+// containing an empty path is returned. If waypoints are attached to the
+// context with ContextWithWaypoints, only the paths that traverse all of them
+// are returned.
 func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 	refresh bool) ([]snet.Path, error) {
 
@@ -63,14 +88,21 @@ func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 		return nil, serrors.WithCtx(ErrBadDst, "dst", dst)
 	}
 	src := p.IA
+	waypoints := WaypointsFromContext(ctx)
 	if dst.Equal(src) {
-		// For AS local communication, an empty path is used.
+		// For AS local communication, an empty path is used. It only satisfies
+		// the waypoints that match the local AS.
+		for _, wp := range waypoints {
+			if wp != src && (wp.AS() != 0 || wp.ISD() != src.ISD()) {
+				return nil, nil
+			}
+		}
 		return []snet.Path{path.Path{
 			Src: src,
 			Dst: dst,
 			Meta: snet.PathMetadata{
 				MTU:    p.MTU,
-				Expiry: time.Now().Add(rawpath.MaxTTL),
+				Expiry: clock.Now(p.Clock).Add(rawpath.MaxTTL),
 			},
 		}}, nil
 	}
@@ -83,8 +115,13 @@ func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 	if fetchErr != nil {
 		logger.Debug("Fetching failed, attempting to build paths anyway", "err", fetchErr)
 	}
-	paths := p.buildAllPaths(src, dst, segs)
+	_, span := tracing.StartOTelSpan(ctx, "segfetcher.combine",
+		attribute.Int("segments", len(segs)),
+	)
+	paths := p.buildAllPaths(src, dst, segs, waypoints)
 	paths = p.filterRevoked(ctx, paths)
+	span.SetAttributes(attribute.Int("paths", len(paths)))
+	span.End()
 	if len(paths) == 0 {
 		if fetchErr != nil {
 			return nil, fetchErr
@@ -94,15 +131,18 @@ func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 	return p.translatePaths(paths)
 }
 
-func (p *Pather) buildAllPaths(src, dst addr.IA, segs Segments) []combinator.Path {
+func (p *Pather) buildAllPaths(src, dst addr.IA, segs Segments,
+	waypoints []addr.IA) []combinator.Path {
+
 	up, core, down := categorizeSegs(segs)
 	destinations := p.findDestinations(dst, up, core)
 	var paths []combinator.Path
 	for dst := range destinations {
-		paths = append(paths, combinator.Combine(src, dst, up, core, down, false)...)
+		paths = append(paths,
+			combinator.CombineWithWaypoints(src, dst, up, core, down, false, waypoints)...)
 	}
 	// Filter expired paths
-	now := time.Now()
+	now := clock.Now(p.Clock)
 	var validPaths []combinator.Path
 	for _, path := range paths {
 		if path.Metadata.Expiry.After(now) {
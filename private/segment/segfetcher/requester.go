@@ -22,8 +22,10 @@ import (
 
 	"github.com/opentracing/opentracing-go"
 
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/tracing"
 )
@@ -35,7 +37,9 @@ var ErrNotReachable = serrors.New("remote not reachable")
 // meta data like the Peer address that is to be used for verification.
 type SegmentsReply struct {
 	Segments []*seg.Meta
-	Peer     net.Addr
+	// SRevInfos are the signed revocations attached to the reply.
+	SRevInfos []*cryptopb.SignedMessage
+	Peer      net.Addr
 }
 
 // RPC is used to fetch segments from a remote.
@@ -50,10 +54,11 @@ type DstProvider interface {
 
 // ReplyOrErr is a seg reply or an error for the given request.
 type ReplyOrErr struct {
-	Req      Request
-	Segments []*seg.Meta
-	Peer     net.Addr
-	Err      error
+	Req       Request
+	Segments  []*seg.Meta
+	SRevInfos []*cryptopb.SignedMessage
+	Peer      net.Addr
+	Err       error
 }
 
 // Requester requests segments.
@@ -129,7 +134,10 @@ func (r *DefaultRequester) requestWorker(ctx context.Context, reqs Requests, i i
 	}
 	for tryIndex := 0; ctx.Err() == nil && tryIndex < r.MaxRetries+1; tryIndex++ {
 		r, err := try(ctx)
-		if errors.Is(err, ErrNotReachable) {
+		// Errors with gRPC error details are definitive answers of the server,
+		// e.g., a rejected hidden segment lookup, and are not retried.
+		_, detailed := libgrpc.DetailedStatus(err)
+		if errors.Is(err, ErrNotReachable) || detailed {
 			logger.Debug("Segment lookup failed", "try", tryIndex+1, "peer", r.Peer, "err", err)
 			reply(ReplyOrErr{Req: req, Err: err})
 			return
@@ -138,7 +146,7 @@ func (r *DefaultRequester) requestWorker(ctx context.Context, reqs Requests, i i
 			logger.Debug("Segment lookup failed", "try", tryIndex+1, "peer", r.Peer, "err", err)
 			continue
 		}
-		reply(ReplyOrErr{Req: req, Segments: r.Segments, Peer: r.Peer})
+		reply(ReplyOrErr{Req: req, Segments: r.Segments, SRevInfos: r.SRevInfos, Peer: r.Peer})
 		return
 	}
 	err := ctx.Err()
@@ -24,9 +24,12 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
+	edpb "github.com/scionproto/scion/pkg/proto/error_details"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/segment/segfetcher"
 	"github.com/scionproto/scion/private/segment/segfetcher/mock_segfetcher"
@@ -133,6 +136,21 @@ func TestRequester(t *testing.T) {
 				}
 			},
 		},
+		"Error with details": {
+			Reqs: segfetcher.Requests{req_1_111},
+			Expect: func(api *mock_segfetcher.MockRPC) []segfetcher.ReplyOrErr {
+				// errors with details are definitive, the request is not retried.
+				req := req_1_111
+				err := libgrpc.StatusError(codes.PermissionDenied, "not authorized",
+					&edpb.ErrorGroupNotAuthorized{GroupId: 42},
+				)
+				api.EXPECT().Segments(gomock.Any(), gomock.Eq(req), gomock.Any()).
+					Return(segfetcher.SegmentsReply{}, err)
+				return []segfetcher.ReplyOrErr{
+					{Req: req_1_111, Err: err},
+				}
+			},
+		},
 		"Up cores": {
 			Reqs: segfetcher.Requests{req_111_1},
 			Expect: func(api *mock_segfetcher.MockRPC) []segfetcher.ReplyOrErr {
@@ -16,9 +16,9 @@ package segfetcher
 
 import (
 	"context"
-	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/pathdb"
@@ -59,6 +59,9 @@ type DefaultResolver struct {
 	DB        pathdb.ReadWrite
 	RevCache  revcache.RevCache
 	LocalInfo LocalInfo
+	// Clock provides the current time against which the next query time is
+	// checked. If nil, the wall clock is used.
+	Clock clock.Clock
 }
 
 // Resolve resolves requests. It loads the segments that are locally available
@@ -130,7 +133,7 @@ func (r *DefaultResolver) loadSegment(ctx context.Context, req Request) (query.R
 
 func (r *DefaultResolver) needsFetching(ctx context.Context, req Request) (bool, error) {
 	nq, err := r.DB.GetNextQuery(ctx, req.Src, req.Dst)
-	return time.Now().After(nq), err
+	return clock.Now(r.Clock).After(nq), err
 }
 
 func (r *DefaultResolver) allRevoked(ctx context.Context, results query.Results) (bool, error) {
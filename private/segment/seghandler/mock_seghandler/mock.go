@@ -11,6 +11,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	path_mgmt "github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	crypto "github.com/scionproto/scion/pkg/proto/crypto"
 	segment "github.com/scionproto/scion/pkg/segment"
 	seghandler "github.com/scionproto/scion/private/segment/seghandler"
 	segverifier "github.com/scionproto/scion/private/segment/segverifier"
@@ -105,3 +106,18 @@ func (mr *MockVerifierMockRecorder) Verify(arg0, arg1, arg2 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockVerifier)(nil).Verify), arg0, arg1, arg2)
 }
+
+// VerifyRevocations mocks base method.
+func (m *MockVerifier) VerifyRevocations(arg0 context.Context, arg1 []*crypto.SignedMessage, arg2 net.Addr) ([]*path_mgmt.RevInfo, []error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyRevocations", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*path_mgmt.RevInfo)
+	ret1, _ := ret[1].([]error)
+	return ret0, ret1
+}
+
+// VerifyRevocations indicates an expected call of VerifyRevocations.
+func (mr *MockVerifierMockRecorder) VerifyRevocations(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyRevocations", reflect.TypeOf((*MockVerifier)(nil).VerifyRevocations), arg0, arg1, arg2)
+}
@@ -17,6 +17,7 @@ package seghandler
 import (
 	"errors"
 
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/segment/segverifier"
@@ -29,6 +30,9 @@ type Stats struct {
 	segVerifyErrors int
 	// VerifiedSegs contains all segments that were successfully verified.
 	VerifiedSegs []*seg.Meta
+	// VerifiedRevs contains all revocations that were successfully verified
+	// and stored.
+	VerifiedRevs []*path_mgmt.RevInfo
 }
 
 // SegsInserted returns the amount of inserted segments.
@@ -19,6 +19,7 @@ import (
 	"net"
 
 	"github.com/scionproto/scion/pkg/private/serrors"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/segment/segverifier"
 )
@@ -27,12 +28,15 @@ import (
 var (
 	ErrVerification = serrors.New("all segments failed to verify")
 	ErrDB           = serrors.New("database error")
+	ErrRevocation   = serrors.New("revocation verification error")
 )
 
 // Segments is a list of segments and revocations belonging to them.
 // Optionally a hidden path group ID is attached.
 type Segments struct {
 	Segs []*seg.Meta
+	// SRevInfos are the signed revocations of interfaces on the segments.
+	SRevInfos []*cryptopb.SignedMessage
 }
 
 // Handler is a handler that verifies and stores seg replies. The handler
@@ -43,13 +47,17 @@ type Handler struct {
 	Storage  Storage
 }
 
-// Handle verifies and stores a set of segments.
+// Handle verifies and stores a set of segments and revocations.
 func (h *Handler) Handle(ctx context.Context, recs Segments, server net.Addr) *ProcessedResult {
 	verifiedCh, units := h.Verifier.Verify(ctx, recs, server)
-	if units == 0 {
-		return &ProcessedResult{}
+	result := &ProcessedResult{}
+	if units > 0 {
+		result = h.verifyAndStore(ctx, verifiedCh, units)
+	}
+	if len(recs.SRevInfos) > 0 {
+		h.verifyAndStoreRevs(ctx, recs.SRevInfos, server, result)
 	}
-	return h.verifyAndStore(ctx, verifiedCh, units)
+	return result
 }
 
 func (h *Handler) verifyAndStore(ctx context.Context,
@@ -72,6 +80,25 @@ func (h *Handler) verifyAndStore(ctx context.Context,
 	return result
 }
 
+// verifyAndStoreRevs verifies the signed revocations and stores the valid ones.
+// Revocation verification errors do not fail the result.
+func (h *Handler) verifyAndStoreRevs(ctx context.Context, sRevInfos []*cryptopb.SignedMessage,
+	server net.Addr, result *ProcessedResult) {
+
+	revs, verifyErrs := h.Verifier.VerifyRevocations(ctx, sRevInfos, server)
+	result.verifyErrs = append(result.verifyErrs, verifyErrs...)
+	if len(revs) == 0 {
+		return
+	}
+	if err := h.Storage.StoreRevs(ctx, revs); err != nil {
+		if result.err == nil {
+			result.err = serrors.Wrap(ErrDB, err)
+		}
+		return
+	}
+	result.stats.VerifiedRevs = append(result.stats.VerifiedRevs, revs...)
+}
+
 func (h *Handler) storeResults(ctx context.Context, verifiedUnits []segverifier.UnitResult,
 	stats *Stats) ([]error, error) {
 
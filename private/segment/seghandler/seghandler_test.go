@@ -22,8 +22,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
 	"github.com/scionproto/scion/pkg/private/mocks/net/mock_net"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/private/segment/seghandler"
 	"github.com/scionproto/scion/private/segment/seghandler/mock_seghandler"
@@ -202,3 +204,40 @@ func TestReplyHandlerStorageError(t *testing.T) {
 	assert.Zero(t, stats.SegsUpdated())
 	assert.Zero(t, stats.SegsInserted())
 }
+
+// TestReplyHandlerRevocations tests that verified revocations are stored and
+// that revocation verification errors do not fail the result.
+func TestReplyHandlerRevocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx, cancelF := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelF()
+
+	sRevInfos := []*cryptopb.SignedMessage{
+		{HeaderAndBody: []byte("valid")},
+		{HeaderAndBody: []byte("invalid")},
+	}
+	segs := seghandler.Segments{SRevInfos: sRevInfos}
+	verified := make(chan segverifier.UnitResult)
+	close(verified)
+	rev := &path_mgmt.RevInfo{IfID: 1, Signed: sRevInfos[0]}
+	revErr := serrors.WrapStr("test err", seghandler.ErrRevocation)
+
+	storage := mock_seghandler.NewMockStorage(ctrl)
+	verifier := mock_seghandler.NewMockVerifier(ctrl)
+	verifier.EXPECT().Verify(ctx, segs, gomock.Any()).Return(verified, 0)
+	verifier.EXPECT().VerifyRevocations(ctx, sRevInfos, gomock.Any()).
+		Return([]*path_mgmt.RevInfo{rev}, []error{revErr})
+	storage.EXPECT().StoreRevs(gomock.Any(), []*path_mgmt.RevInfo{rev})
+	handler := seghandler.Handler{
+		Storage:  storage,
+		Verifier: verifier,
+	}
+
+	r := handler.Handle(ctx, segs, nil)
+	assert.NoError(t, r.Err())
+	assert.Len(t, r.VerificationErrors(), 1)
+	stats := r.Stats()
+	assert.Equal(t, []*path_mgmt.RevInfo{rev}, stats.VerifiedRevs)
+	assert.Zero(t, len(stats.VerifiedSegs))
+}
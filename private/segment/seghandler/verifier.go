@@ -18,6 +18,10 @@ import (
 	"context"
 	"net"
 
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
+	"github.com/scionproto/scion/private/revcache"
 	"github.com/scionproto/scion/private/segment/segverifier"
 	infra "github.com/scionproto/scion/private/segment/verifier"
 )
@@ -25,6 +29,11 @@ import (
 // Verifier is used to verify a segment reply.
 type Verifier interface {
 	Verify(context.Context, Segments, net.Addr) (chan segverifier.UnitResult, int)
+	// VerifyRevocations verifies the signed revocations and returns the
+	// revocations that are valid together with the verification errors of the
+	// others.
+	VerifyRevocations(context.Context, []*cryptopb.SignedMessage,
+		net.Addr) ([]*path_mgmt.RevInfo, []error)
 }
 
 // DefaultVerifier is a convenience wrapper around segverifier that implements
@@ -39,3 +48,22 @@ func (v *DefaultVerifier) Verify(ctx context.Context, recs Segments,
 
 	return segverifier.StartVerification(ctx, v.Verifier, server, recs.Segs)
 }
+
+// VerifyRevocations verifies the signed revocations with the crypto material
+// fetched from the given server.
+func (v *DefaultVerifier) VerifyRevocations(ctx context.Context,
+	sRevInfos []*cryptopb.SignedMessage, server net.Addr) ([]*path_mgmt.RevInfo, []error) {
+
+	var revs []*path_mgmt.RevInfo
+	var errs []error
+	verifier := v.Verifier.WithServer(server)
+	for _, sRevInfo := range sRevInfos {
+		rev, err := revcache.Verify(ctx, verifier, sRevInfo)
+		if err != nil {
+			errs = append(errs, serrors.Wrap(ErrRevocation, err))
+			continue
+		}
+		revs = append(revs, rev)
+	}
+	return revs, errs
+}
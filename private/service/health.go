@@ -0,0 +1,182 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/scionproto/scion/pkg/log"
+)
+
+const (
+	// DefaultHealthCheckTimeout is the timeout for evaluating all readiness
+	// checks once.
+	DefaultHealthCheckTimeout = 2 * time.Second
+	// DefaultHealthUpdateInterval is the interval in which the gRPC serving
+	// status is updated.
+	DefaultHealthUpdateInterval = 5 * time.Second
+)
+
+// ReadinessCheck reports whether a part of the service is ready to serve
+// requests. A nil error means the part is ready.
+type ReadinessCheck func(ctx context.Context) error
+
+// Health tracks the liveness and the readiness of a service.
+//
+// The service is live as long as it serves the HTTP endpoints. It is ready if
+// all registered readiness checks succeed. The state is exposed with the HTTP
+// status pages "live" and "ready", and with the standard gRPC health checking
+// protocol, see UpdateGRPC.
+type Health struct {
+	// Timeout is the timeout for evaluating all checks once. If zero,
+	// DefaultHealthCheckTimeout is used.
+	Timeout time.Duration
+
+	mtx    sync.Mutex
+	checks map[string]ReadinessCheck
+}
+
+// AddCheck registers a readiness check with the given name. A check that is
+// registered with an existing name replaces the existing check.
+func (h *Health) AddCheck(name string, check ReadinessCheck) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.checks == nil {
+		h.checks = make(map[string]ReadinessCheck)
+	}
+	h.checks[name] = check
+}
+
+// Check evaluates all readiness checks. It returns the result of every check
+// by name; a nil error indicates that the check succeeded.
+func (h *Health) Check(ctx context.Context) map[string]error {
+	h.mtx.Lock()
+	checks := make(map[string]ReadinessCheck, len(h.checks))
+	for name, check := range h.checks {
+		checks[name] = check
+	}
+	h.mtx.Unlock()
+
+	timeout := h.Timeout
+	if timeout == 0 {
+		timeout = DefaultHealthCheckTimeout
+	}
+	ctx, cancelF := context.WithTimeout(ctx, timeout)
+	defer cancelF()
+
+	var mtx sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]error, len(checks))
+	for name, check := range checks {
+		name, check := name, check
+		wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			err := check(ctx)
+			mtx.Lock()
+			defer mtx.Unlock()
+			results[name] = err
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Ready returns whether all readiness checks succeed.
+func (h *Health) Ready(ctx context.Context) bool {
+	for _, err := range h.Check(ctx) {
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// AddStatusPages adds the "live" and "ready" status pages to the given pages.
+// Both respond with status 200 if the service is live or ready respectively,
+// and with status 503 otherwise. The "ready" page lists the result of every
+// check.
+func (h *Health) AddStatusPages(pages StatusPages) {
+	pages["live"] = StatusPage{
+		Info: "liveness of the service",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprintln(w, "live")
+		},
+		Special: true,
+	}
+	pages["ready"] = StatusPage{
+		Info:    "readiness of the service",
+		Handler: h.handleReady,
+		Special: true,
+	}
+}
+
+func (h *Health) handleReady(w http.ResponseWriter, r *http.Request) {
+	results := h.Check(r.Context())
+	names := make([]string, 0, len(results))
+	ready := true
+	for name, err := range results {
+		names = append(names, name)
+		ready = ready && err == nil
+	}
+	sort.Strings(names)
+
+	w.Header().Set("Content-Type", "text/plain")
+	if !ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	for _, name := range names {
+		if err := results[name]; err != nil {
+			fmt.Fprintf(w, "%s: %s\n", name, err)
+			continue
+		}
+		fmt.Fprintf(w, "%s: ok\n", name)
+	}
+}
+
+// UpdateGRPC periodically evaluates the readiness checks and sets the serving
+// status of the overall service, i.e., the empty service name, on the gRPC
+// health server. It returns when the context is canceled. If the interval is
+// zero, DefaultHealthUpdateInterval is used.
+func (h *Health) UpdateGRPC(ctx context.Context, s *health.Server, interval time.Duration) {
+	if interval == 0 {
+		interval = DefaultHealthUpdateInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if h.Ready(ctx) {
+			status = healthpb.HealthCheckResponse_SERVING
+		}
+		s.SetServingStatus("", status)
+		select {
+		case <-ctx.Done():
+			s.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
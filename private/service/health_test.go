@@ -0,0 +1,125 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/service"
+)
+
+func TestHealthStatusPages(t *testing.T) {
+	testCases := map[string]struct {
+		Checks map[string]service.ReadinessCheck
+		Status int
+		Body   string
+	}{
+		"no checks": {
+			Status: http.StatusOK,
+			Body:   "",
+		},
+		"ready": {
+			Checks: map[string]service.ReadinessCheck{
+				"b": func(context.Context) error { return nil },
+				"a": func(context.Context) error { return nil },
+			},
+			Status: http.StatusOK,
+			Body:   "a: ok\nb: ok\n",
+		},
+		"not ready": {
+			Checks: map[string]service.ReadinessCheck{
+				"db": func(context.Context) error { return serrors.New("unreachable") },
+				"topology": func(context.Context) error {
+					return nil
+				},
+			},
+			Status: http.StatusServiceUnavailable,
+			Body:   "db: unreachable\ntopology: ok\n",
+		},
+		"timeout": {
+			Checks: map[string]service.ReadinessCheck{
+				"slow": func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			},
+			Status: http.StatusServiceUnavailable,
+			Body:   "slow: context deadline exceeded\n",
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			h := &service.Health{Timeout: 10 * time.Millisecond}
+			for name, check := range tc.Checks {
+				h.AddCheck(name, check)
+			}
+			pages := service.StatusPages{}
+			h.AddStatusPages(pages)
+
+			rr := httptest.NewRecorder()
+			pages["ready"].Handler(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+			assert.Equal(t, tc.Status, rr.Code)
+			assert.Equal(t, tc.Body, rr.Body.String())
+
+			rr = httptest.NewRecorder()
+			pages["live"].Handler(rr, httptest.NewRequest(http.MethodGet, "/live", nil))
+			assert.Equal(t, http.StatusOK, rr.Code)
+		})
+	}
+}
+
+func TestHealthUpdateGRPC(t *testing.T) {
+	ready := make(chan struct{})
+	h := &service.Health{}
+	h.AddCheck("ready", func(context.Context) error {
+		select {
+		case <-ready:
+			return nil
+		default:
+			return serrors.New("not ready")
+		}
+	})
+	s := health.NewServer()
+	ctx, cancelF := context.WithCancel(context.Background())
+	defer cancelF()
+	go h.UpdateGRPC(ctx, s, 10*time.Millisecond)
+
+	status := func() healthpb.HealthCheckResponse_ServingStatus {
+		rep, err := s.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN
+		}
+		return rep.Status
+	}
+	require.Eventually(t, func() bool {
+		return status() == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+	close(ready)
+	require.Eventually(t, func() bool {
+		return status() == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 5*time.Millisecond)
+}
@@ -33,6 +33,26 @@ type Cleanable interface {
 	DeleteExpiredBeacons(ctx context.Context, now time.Time) (int, error)
 }
 
+// Limits bounds the number of beacons kept in the database. A zero value
+// means that the respective number is not limited.
+type Limits struct {
+	// MaxPerOrigin is the maximum number of beacons per origin AS. If there
+	// are more beacons for an origin AS, the longest beacons are evicted, in
+	// the same order in which they are least preferred as candidates.
+	MaxPerOrigin int
+	// MaxTotal is the maximum number of beacons in the database. If there are
+	// more beacons, the least recently updated beacons are evicted.
+	MaxTotal int
+}
+
+// Limitable is a database that can evict beacons to enforce size limits.
+type Limitable interface {
+	// DeleteExcessBeacons evicts beacons until the database satisfies the
+	// limits. The return value indicates the number of beacons that were
+	// removed.
+	DeleteExcessBeacons(ctx context.Context, limits Limits) (int, error)
+}
+
 type QueryParams struct {
 	// SegIDs defines the list of segment IDs that beacons need to match at least one of.
 	// If a specified segment ID is shorter than a full segment ID,
@@ -0,0 +1,447 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	storagebeacon "github.com/scionproto/scion/private/storage/beacon"
+	"github.com/scionproto/scion/private/storage/db"
+)
+
+var _ beacon.DB = (*Backend)(nil)
+
+// Backend implements the beacon DB with a PostgreSQL backend. The database can
+// be shared by multiple control service instances of the same AS.
+type Backend struct {
+	db *sql.DB
+	*executor
+}
+
+// New returns a new PostgreSQL backend connected with the given connection
+// string. Pending schema migrations are applied. If the schema version of the
+// database is newer than the one understood by this backend, an error is
+// returned.
+func New(connection string, ia addr.IA) (*Backend, error) {
+	db, err := db.NewPostgres(connection, Name, Migrations)
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{
+		executor: &executor{
+			db: db,
+			ia: ia,
+		},
+		db: db,
+	}, nil
+}
+
+// SetMaxOpenConns sets the maximum number of open connections.
+func (b *Backend) SetMaxOpenConns(maxOpenConns int) {
+	b.db.SetMaxOpenConns(maxOpenConns)
+}
+
+// SetMaxIdleConns sets the maximum number of idle connections.
+func (b *Backend) SetMaxIdleConns(maxIdleConns int) {
+	b.db.SetMaxIdleConns(maxIdleConns)
+}
+
+// Close closes the database.
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+type executor struct {
+	db db.Sqler
+	ia addr.IA
+}
+
+type beaconMeta struct {
+	RowID    int64
+	InfoTime time.Time
+}
+
+func (e *executor) BeaconSources(ctx context.Context) ([]addr.IA, error) {
+	query := `SELECT DISTINCT StartIsd, StartAs FROM Beacons`
+	rows, err := e.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, db.NewReadError("Error selecting source IAs", err)
+	}
+	defer rows.Close()
+	var ias []addr.IA
+	for rows.Next() {
+		var isd addr.ISD
+		var as addr.AS
+		if err := rows.Scan(&isd, &as); err != nil {
+			return nil, err
+		}
+		ia, err := addr.IAFrom(isd, as)
+		if err != nil {
+			return nil, err
+		}
+		ias = append(ias, ia)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ias, nil
+}
+
+func (e *executor) CandidateBeacons(
+	ctx context.Context,
+	setSize int,
+	usage beacon.Usage,
+	src addr.IA,
+) ([]beacon.Beacon, error) {
+
+	// The driver rejects unused arguments, thus the source arguments are only
+	// passed if they are referenced.
+	args := []interface{}{usage, setSize}
+	srcCond := ``
+	if !src.IsZero() {
+		srcCond = `AND StartIsd = $3 AND StartAs = $4`
+		args = append(args, src.ISD(), src.AS())
+	}
+	query := fmt.Sprintf(`
+		SELECT b.Beacon, b.InIntfID
+		FROM Beacons b
+		WHERE ( b.Usage & $1 ) = $1 %s
+		ORDER BY b.HopsLength ASC
+		LIMIT $2
+	`, srcCond)
+	rows, err := e.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, db.NewReadError("Error selecting beacons", err)
+	}
+	defer rows.Close()
+
+	beacons := make([]beacon.Beacon, 0, setSize)
+	for rows.Next() {
+		var rawBeacon sql.RawBytes
+		var inIntfID common.IFIDType
+		if err = rows.Scan(&rawBeacon, &inIntfID); err != nil {
+			return nil, db.NewReadError(beacon.ErrReadingRows, err)
+		}
+		s, err := beacon.UnpackBeacon(rawBeacon)
+		if err != nil {
+			return nil, db.NewDataError(beacon.ErrParse, err)
+		}
+		beacons = append(beacons, beacon.Beacon{Segment: s, InIfId: uint16(inIntfID)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return beacons, nil
+}
+
+// InsertBeacon inserts the beacon if it is new or updates the changed
+// information.
+func (e *executor) InsertBeacon(
+	ctx context.Context,
+	b beacon.Beacon,
+	usage beacon.Usage,
+) (beacon.InsertStats, error) {
+
+	var ret beacon.InsertStats
+	err := db.DoInTx(ctx, e.db, func(ctx context.Context, tx *sql.Tx) error {
+		meta, err := getBeaconMeta(ctx, tx, b.Segment.ID())
+		if err != nil {
+			return err
+		}
+		if meta != nil {
+			// Update the beacon data if it is newer.
+			if !b.Segment.Info.Timestamp.After(meta.InfoTime) {
+				return nil
+			}
+			if err := updateExistingBeacon(ctx, tx, b, usage, meta.RowID,
+				time.Now()); err != nil {
+				return err
+			}
+			ret.Updated = 1
+			return nil
+		}
+		inserted, err := insertNewBeacon(ctx, tx, b, usage, time.Now())
+		if err != nil {
+			return err
+		}
+		if inserted {
+			ret.Inserted = 1
+		}
+		return nil
+	})
+	if err != nil {
+		return beacon.InsertStats{}, err
+	}
+	return ret, nil
+}
+
+func (e *executor) GetBeacons(
+	ctx context.Context,
+	params *storagebeacon.QueryParams,
+) ([]storagebeacon.Beacon, error) {
+
+	stmt, args := e.buildQuery(params)
+	rows, err := e.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, serrors.WrapStr("looking up beacons", err, "query", stmt)
+	}
+	defer rows.Close()
+	var res []storagebeacon.Beacon
+	for rows.Next() {
+		var RowID int64
+		var lastUpdated int64
+		var usage int
+		var rawBeacon sql.RawBytes
+		var InIntfID uint16
+		err = rows.Scan(&RowID, &lastUpdated, &usage, &rawBeacon, &InIntfID)
+		if err != nil {
+			return nil, serrors.WrapStr("reading row", err)
+		}
+		seg, err := beacon.UnpackBeacon(rawBeacon)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing beacon", err)
+		}
+		res = append(res, storagebeacon.Beacon{
+			Beacon: beacon.Beacon{
+				Segment: seg,
+				InIfId:  InIntfID,
+			},
+			Usage:       beacon.Usage(usage),
+			LastUpdated: time.Unix(0, lastUpdated),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (e *executor) buildQuery(params *storagebeacon.QueryParams) (string, []interface{}) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	query := "SELECT DISTINCT RowID, LastUpdated, Usage, Beacon, InIntfID FROM Beacons"
+	if params == nil {
+		return query, args
+	}
+	where := []string{}
+	if len(params.SegIDs) > 0 {
+		subQ := make([]string, 0, len(params.SegIDs))
+		for _, segID := range params.SegIDs {
+			subQ = append(subQ, fmt.Sprintf("encode(SegID, 'hex') LIKE "+
+				"(encode(%s::BYTEA, 'hex') || '%%')", arg(segID)))
+		}
+		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+	}
+	if len(params.StartsAt) > 0 {
+		subQ := []string{}
+		for _, as := range params.StartsAt {
+			switch {
+			case as.IsZero():
+				continue
+			case as.ISD() == 0:
+				subQ = append(subQ, "StartAs="+arg(as.AS()))
+			case as.AS() == 0:
+				subQ = append(subQ, "StartIsd="+arg(as.ISD()))
+			case as.ISD() != 0 && as.AS() != 0:
+				subQ = append(subQ, fmt.Sprintf("(StartIsd=%s AND StartAs=%s)",
+					arg(as.ISD()), arg(as.AS())))
+			}
+		}
+		if len(subQ) > 0 {
+			where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+		}
+	}
+	if len(params.IngressInterfaces) > 0 {
+		subQ := make([]string, 0, len(params.IngressInterfaces))
+		for _, intf := range params.IngressInterfaces {
+			subQ = append(subQ, "InIntfID="+arg(intf))
+		}
+		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+	}
+
+	if len(params.Usages) > 0 {
+		subQ := []string{}
+		for _, u := range params.Usages {
+			if u > 0 {
+				p := arg(int64(u))
+				subQ = append(subQ, fmt.Sprintf("(Usage & %s = %s)", p, p))
+			}
+		}
+		if len(subQ) > 0 {
+			where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+		}
+	}
+
+	if !params.ValidAt.IsZero() {
+		p := arg(params.ValidAt.Unix())
+		where = append(where, fmt.Sprintf("(InfoTime <= %s AND %s <= ExpirationTime)", p, p))
+	}
+	// Assemble the query.
+	if len(where) > 0 {
+		query += "\n" + fmt.Sprintf("WHERE %s", strings.Join(where, " AND\n"))
+	}
+	query += "\n" + "ORDER BY LastUpdated DESC"
+	return query, args
+}
+
+// getBeaconMeta gets the metadata for existing beacons. The row is locked until
+// the end of the transaction.
+func getBeaconMeta(ctx context.Context, tx *sql.Tx, segID []byte) (*beaconMeta, error) {
+	var rowID, infoTime int64
+	query := "SELECT RowID, InfoTime FROM Beacons WHERE SegID=$1 FOR UPDATE"
+	err := tx.QueryRowContext(ctx, query, segID).Scan(&rowID, &infoTime)
+	// New beacons are not in the table.
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, db.NewReadError("Failed to lookup beacon", err)
+	}
+	meta := &beaconMeta{
+		RowID:    rowID,
+		InfoTime: time.Unix(infoTime, 0),
+	}
+	return meta, nil
+}
+
+// updateExistingBeacon updates the changeable data for an existing beacon.
+func updateExistingBeacon(
+	ctx context.Context,
+	tx *sql.Tx,
+	b beacon.Beacon,
+	usage beacon.Usage,
+	rowID int64,
+	now time.Time,
+) error {
+
+	fullID := b.Segment.FullID()
+	packedSeg, err := beacon.PackBeacon(b.Segment)
+	if err != nil {
+		return err
+	}
+	infoTime := b.Segment.Info.Timestamp.Unix()
+	lastUpdated := now.UnixNano()
+	expTime := b.Segment.MaxExpiry().Unix()
+	inst := `UPDATE Beacons SET FullID=$1, InIntfID=$2, HopsLength=$3, InfoTime=$4,
+			ExpirationTime=$5, LastUpdated=$6, Usage=$7, Beacon=$8
+			WHERE RowID=$9`
+	_, err = tx.ExecContext(ctx, inst, fullID, b.InIfId, len(b.Segment.ASEntries), infoTime,
+		expTime, lastUpdated, usage, packedSeg, rowID)
+	if err != nil {
+		return db.NewWriteError("update segment", err)
+	}
+	return nil
+}
+
+// insertNewBeacon inserts a new beacon. If a concurrent transaction inserted
+// the same beacon in the meantime, nothing is inserted and false is returned.
+func insertNewBeacon(
+	ctx context.Context,
+	tx *sql.Tx,
+	b beacon.Beacon,
+	usage beacon.Usage,
+	now time.Time,
+) (bool, error) {
+
+	segID := b.Segment.ID()
+	fullID := b.Segment.FullID()
+	packed, err := beacon.PackBeacon(b.Segment)
+	if err != nil {
+		return false, db.NewInputDataError("pack segment", err)
+	}
+	start := b.Segment.FirstIA()
+	infoTime := b.Segment.Info.Timestamp.Unix()
+	lastUpdated := now.UnixNano()
+	expTime := b.Segment.MaxExpiry().Unix()
+
+	// Insert beacon.
+	inst := `
+	INSERT INTO Beacons (SegID, FullID, StartIsd, StartAs, InIntfID, HopsLength, InfoTime,
+		ExpirationTime, LastUpdated, Usage, Beacon)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT DO NOTHING
+	`
+	res, err := tx.ExecContext(ctx, inst, segID, fullID, start.ISD(), start.AS(), b.InIfId,
+		len(b.Segment.ASEntries), infoTime, expTime, lastUpdated, usage, packed)
+	if err != nil {
+		return false, db.NewWriteError("insert beacon", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, db.NewWriteError("insert beacon", err)
+	}
+	return n > 0, nil
+}
+
+func (e *executor) DeleteExpiredBeacons(ctx context.Context, now time.Time) (int, error) {
+	return db.DeleteInTx(ctx, e.db, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `DELETE FROM Beacons WHERE ExpirationTime < $1`
+		return tx.ExecContext(ctx, delStmt, now.Unix())
+	})
+}
+
+func (e *executor) DeleteExcessBeacons(
+	ctx context.Context,
+	limits storagebeacon.Limits,
+) (int, error) {
+
+	var deleted int
+	if limits.MaxPerOrigin > 0 {
+		n, err := db.DeleteInTx(ctx, e.db, func(tx *sql.Tx) (sql.Result, error) {
+			delStmt := `
+			DELETE FROM Beacons WHERE RowID IN (
+				SELECT RowID FROM (
+					SELECT RowID, ROW_NUMBER() OVER (
+						PARTITION BY StartIsd, StartAs
+						ORDER BY HopsLength ASC, LastUpdated DESC
+					) AS OriginRank
+					FROM Beacons
+				) AS Ranked WHERE OriginRank > $1
+			)`
+			return tx.ExecContext(ctx, delStmt, limits.MaxPerOrigin)
+		})
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	if limits.MaxTotal > 0 {
+		n, err := db.DeleteInTx(ctx, e.db, func(tx *sql.Tx) (sql.Result, error) {
+			delStmt := `
+			DELETE FROM Beacons WHERE RowID IN (
+				SELECT RowID FROM Beacons
+				ORDER BY LastUpdated DESC
+				OFFSET $1
+			)`
+			return tx.ExecContext(ctx, delStmt, limits.MaxTotal)
+		})
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/private/storage/beacon/dbtest"
+	"github.com/scionproto/scion/private/storage/beacon/postgres"
+)
+
+// connectionEnv is the environment variable that holds the connection string
+// of the PostgreSQL database used by the tests. The tests are skipped if it is
+// not set. All data in the database is deleted by the tests.
+const connectionEnv = "SCION_TEST_POSTGRES"
+
+var testIA = xtest.MustParseIA("1-ff00:0:333")
+
+type TestBackend struct {
+	*postgres.Backend
+}
+
+func (b *TestBackend) Prepare(t *testing.T, _ context.Context) {
+	connection := os.Getenv(connectionEnv)
+	if b.Backend != nil {
+		b.Backend.Close()
+	}
+	reset(t, connection)
+	db, err := postgres.New(connection, testIA)
+	require.NoError(t, err)
+	b.Backend = db
+}
+
+func TestBeaconDBSuite(t *testing.T) {
+	if os.Getenv(connectionEnv) == "" {
+		t.Skipf("%s not set", connectionEnv)
+	}
+	tdb := &TestBackend{}
+	dbtest.Run(t, tdb)
+}
+
+// reset deletes all beacons.
+func reset(t *testing.T, connection string) {
+	db, err := sql.Open("postgres", connection)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`DO $$ BEGIN
+		IF to_regclass('Beacons') IS NOT NULL THEN DELETE FROM Beacons; END IF;
+	END $$`)
+	require.NoError(t, err)
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+// Name identifies the beacon DB in the schema migrations table.
+const Name = "beacondb"
+
+// Migrations are the PostgreSQL schema migrations of the beacon DB, in the
+// order they are applied. Existing migrations must never be modified, changes
+// to the schema are made by appending a new migration.
+var Migrations = []string{
+	`CREATE TABLE Beacons(
+		RowID BIGSERIAL PRIMARY KEY,
+		SegID BYTEA UNIQUE NOT NULL,
+		FullID BYTEA UNIQUE NOT NULL,
+		StartIsd INTEGER NOT NULL,
+		StartAs BIGINT NOT NULL,
+		InIntfID INTEGER NOT NULL,
+		HopsLength INTEGER NOT NULL,
+		InfoTime BIGINT NOT NULL,
+		ExpirationTime BIGINT NOT NULL,
+		LastUpdated BIGINT NOT NULL,
+		Usage INTEGER NOT NULL,
+		Beacon BYTEA NOT NULL
+	);
+	CREATE INDEX BeaconsExpirationTimeIndex ON Beacons(ExpirationTime);`,
+}
@@ -400,6 +400,49 @@ func (e *executor) DeleteExpiredBeacons(ctx context.Context, now time.Time) (int
 	})
 }
 
+func (e *executor) DeleteExcessBeacons(
+	ctx context.Context,
+	limits storagebeacon.Limits,
+) (int, error) {
+
+	var deleted int
+	if limits.MaxPerOrigin > 0 {
+		n, err := e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+			delStmt := `
+			DELETE FROM Beacons WHERE RowID IN (
+				SELECT RowID FROM (
+					SELECT RowID, ROW_NUMBER() OVER (
+						PARTITION BY StartIsd, StartAs
+						ORDER BY HopsLength ASC, LastUpdated DESC
+					) AS Rank
+					FROM Beacons
+				) WHERE Rank > ?
+			)`
+			return tx.ExecContext(ctx, delStmt, limits.MaxPerOrigin)
+		})
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	if limits.MaxTotal > 0 {
+		n, err := e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+			delStmt := `
+			DELETE FROM Beacons WHERE RowID IN (
+				SELECT RowID FROM Beacons
+				ORDER BY LastUpdated DESC
+				LIMIT -1 OFFSET ?
+			)`
+			return tx.ExecContext(ctx, delStmt, limits.MaxTotal)
+		})
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
+
 func (e *executor) deleteInTx(
 	ctx context.Context,
 	delFunc func(tx *sql.Tx) (sql.Result, error),
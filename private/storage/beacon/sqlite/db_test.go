@@ -29,6 +29,7 @@ import (
 	"github.com/scionproto/scion/control/beacon"
 	"github.com/scionproto/scion/control/beacon/beacondbtest"
 	"github.com/scionproto/scion/pkg/private/xtest"
+	storagebeacon "github.com/scionproto/scion/private/storage/beacon"
 	"github.com/scionproto/scion/private/storage/beacon/dbtest"
 	"github.com/scionproto/scion/private/storage/beacon/sqlite"
 )
@@ -82,6 +83,47 @@ func TestOpenNewer(t *testing.T) {
 	assert.Nil(t, b)
 }
 
+func TestDeleteExcessBeacons(t *testing.T) {
+	db, err := sqlite.New("file::memory:", testIA)
+	require.NoError(t, err)
+	defer db.Close()
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+
+	b1 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info1, 1, 10, beacon.UsageProp)
+	b2 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info2, 2, 10, beacon.UsageProp)
+	beacondbtest.InsertBeacon(t, db, beacondbtest.Info3, 3, 10, beacon.UsageProp)
+	b4 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info4, 4, 10, beacon.UsageProp)
+
+	deleted, err := db.DeleteExcessBeacons(ctx, storagebeacon.Limits{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	// Info2 and Info3 share the origin AS, the longer Info3 is evicted.
+	deleted, err = db.DeleteExcessBeacons(ctx, storagebeacon.Limits{MaxPerOrigin: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	res, err := db.CandidateBeacons(ctx, 10, beacon.UsageProp, 0)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, segIDs(b1, b2, b4), segIDs(res...))
+
+	// The least recently updated beacon is evicted.
+	deleted, err = db.DeleteExcessBeacons(ctx, storagebeacon.Limits{MaxTotal: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	res, err = db.CandidateBeacons(ctx, 10, beacon.UsageProp, 0)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, segIDs(b2, b4), segIDs(res...))
+}
+
+func segIDs(beacons ...beacon.Beacon) []string {
+	ids := make([]string, 0, len(beacons))
+	for _, b := range beacons {
+		ids = append(ids, string(b.Segment.ID()))
+	}
+	return ids
+}
+
 func setupDB(t *testing.T) (*sqlite.Backend, string) {
 	tmpFile := tempFilename(t)
 	b, err := sqlite.New(tmpFile, testIA)
@@ -0,0 +1,268 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache provides an in-memory cache in front of a path DB.
+//
+// The results of segment lookups are cached per query. Writes go through to
+// the wrapped path DB and invalidate all cached results, such that a lookup
+// never returns results that are older than the last write. This trades a
+// lower hit rate after writes for not having to evaluate the queries against
+// the written segments.
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/pathdb"
+	"github.com/scionproto/scion/private/pathdb/query"
+	"github.com/scionproto/scion/private/storage"
+)
+
+const (
+	// DefaultSize is the default maximum number of cached lookups.
+	DefaultSize = 10000
+	// DefaultTTL is the default time for which a lookup is cached.
+	DefaultTTL = time.Minute
+)
+
+// Config configures the cache.
+type Config struct {
+	// Size is the maximum number of cached lookups. If more lookups are
+	// cached, the least recently used ones are evicted. If zero, DefaultSize
+	// is used.
+	Size int
+	// TTL is the time for which a lookup is cached. It bounds the time for
+	// which expired segments are returned from the cache. If zero, DefaultTTL
+	// is used.
+	TTL time.Duration
+	// Lookups counts the lookups with the label "result" set to "hit" or
+	// "miss". Optional.
+	Lookups metrics.Counter
+}
+
+type entry struct {
+	results query.Results
+	expiry  time.Time
+}
+
+// WrapDB wraps the given path DB into one that caches the results of the Get
+// operations.
+func WrapDB(pathDB storage.PathDB, cfg Config) (storage.PathDB, error) {
+	if cfg.Size == 0 {
+		cfg.Size = DefaultSize
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = DefaultTTL
+	}
+	entries, err := lru.New(cfg.Size)
+	if err != nil {
+		return nil, serrors.WrapStr("creating cache", err, "size", cfg.Size)
+	}
+	return &cachedPathDB{
+		PathDB:  pathDB,
+		cfg:     cfg,
+		entries: entries,
+	}, nil
+}
+
+var _ storage.PathDB = (*cachedPathDB)(nil)
+
+type cachedPathDB struct {
+	storage.PathDB
+	cfg     Config
+	entries *lru.Cache
+
+	mu sync.Mutex
+	// generation is incremented on every invalidation. Lookups that raced
+	// with an invalidation are not cached.
+	generation uint64
+}
+
+func (db *cachedPathDB) Get(ctx context.Context, params *query.Params) (query.Results, error) {
+	// Lookups of all segments are rare and potentially large, they are not
+	// cached.
+	if params == nil {
+		return db.PathDB.Get(ctx, params)
+	}
+	k := key(params)
+	if v, ok := db.entries.Get(k); ok {
+		e := v.(entry)
+		if time.Now().Before(e.expiry) {
+			db.observe("hit")
+			return copyResults(e.results), nil
+		}
+		db.entries.Remove(k)
+	}
+	db.observe("miss")
+
+	gen := db.currentGeneration()
+	res, err := db.PathDB.Get(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if gen == db.generation {
+		db.entries.Add(k, entry{
+			results: copyResults(res),
+			expiry:  time.Now().Add(db.cfg.TTL),
+		})
+	}
+	return res, nil
+}
+
+func (db *cachedPathDB) Insert(ctx context.Context, meta *seg.Meta) (pathdb.InsertStats, error) {
+	stats, err := db.PathDB.Insert(ctx, meta)
+	if stats.Inserted > 0 || stats.Updated > 0 || err != nil {
+		db.invalidate()
+	}
+	return stats, err
+}
+
+func (db *cachedPathDB) InsertWithHPGroupIDs(ctx context.Context, meta *seg.Meta,
+	hpGroupIDs []uint64) (pathdb.InsertStats, error) {
+
+	stats, err := db.PathDB.InsertWithHPGroupIDs(ctx, meta, hpGroupIDs)
+	if stats.Inserted > 0 || stats.Updated > 0 || err != nil {
+		db.invalidate()
+	}
+	return stats, err
+}
+
+func (db *cachedPathDB) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	n, err := db.PathDB.DeleteExpired(ctx, now)
+	if n > 0 || err != nil {
+		db.invalidate()
+	}
+	return n, err
+}
+
+func (db *cachedPathDB) DeleteFromHPGroup(ctx context.Context, segID []byte,
+	hpGroupID uint64) (int, error) {
+
+	n, err := db.PathDB.DeleteFromHPGroup(ctx, segID, hpGroupID)
+	// The group association might be removed even if no segment is deleted.
+	db.invalidate()
+	return n, err
+}
+
+func (db *cachedPathDB) BeginTransaction(ctx context.Context,
+	opts *sql.TxOptions) (pathdb.Transaction, error) {
+
+	tx, err := db.PathDB.BeginTransaction(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction{Transaction: tx, db: db}, nil
+}
+
+func (db *cachedPathDB) currentGeneration() uint64 {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.generation
+}
+
+func (db *cachedPathDB) invalidate() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.generation++
+	db.entries.Purge()
+}
+
+func (db *cachedPathDB) observe(result string) {
+	metrics.CounterInc(metrics.CounterWith(db.cfg.Lookups, prom.LabelResult, result))
+}
+
+// transaction reads and writes bypass the cache. The cache is invalidated when
+// the transaction is committed.
+type transaction struct {
+	pathdb.Transaction
+	db *cachedPathDB
+}
+
+func (tx *transaction) Commit() error {
+	err := tx.Transaction.Commit()
+	tx.db.invalidate()
+	return err
+}
+
+// key returns the cache key of the query parameters.
+func key(p *query.Params) string {
+	var b []byte
+	var buf [8]byte
+	putInt := func(v uint64) {
+		binary.BigEndian.PutUint64(buf[:], v)
+		b = append(b, buf[:]...)
+	}
+	putIAs := func(ias []addr.IA) {
+		putInt(uint64(len(ias)))
+		for _, ia := range ias {
+			putInt(uint64(ia))
+		}
+	}
+	putInt(uint64(len(p.SegIDs)))
+	for _, id := range p.SegIDs {
+		putInt(uint64(len(id)))
+		b = append(b, id...)
+	}
+	putInt(uint64(len(p.SegTypes)))
+	for _, t := range p.SegTypes {
+		putInt(uint64(t))
+	}
+	putInt(uint64(len(p.HPGroupIDs)))
+	for _, id := range p.HPGroupIDs {
+		putInt(id)
+	}
+	putInt(uint64(len(p.Intfs)))
+	for _, intf := range p.Intfs {
+		putInt(uint64(intf.IA))
+		putInt(uint64(intf.IfID))
+	}
+	putIAs(p.StartsAt)
+	putIAs(p.EndsAt)
+	putInt(uint64(len(p.TransitISDs)))
+	for _, isd := range p.TransitISDs {
+		putInt(uint64(isd))
+	}
+	putInt(uint64(p.MaxHops))
+	if !p.ValidUntil.IsZero() {
+		putInt(uint64(p.ValidUntil.UnixNano()))
+	}
+	return string(b)
+}
+
+// copyResults returns a copy of the results, such that callers can modify the
+// returned slice and results. The segments are shared.
+func copyResults(results query.Results) query.Results {
+	if results == nil {
+		return nil
+	}
+	c := make(query.Results, 0, len(results))
+	for _, r := range results {
+		cr := *r
+		c = append(c, &cr)
+	}
+	return c
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/pathdb/query"
+	"github.com/scionproto/scion/private/storage"
+	"github.com/scionproto/scion/private/storage/path/cache"
+	pathdbtest "github.com/scionproto/scion/private/storage/path/dbtest"
+	"github.com/scionproto/scion/private/storage/path/sqlite"
+)
+
+var ifs = []uint64{0, 5, 2, 3, 6, 3, 1, 0}
+
+type TestPathDB struct {
+	storage.PathDB
+}
+
+func (b *TestPathDB) Prepare(t *testing.T, _ context.Context) {
+	db, err := sqlite.New("file::memory:")
+	require.NoError(t, err)
+	b.PathDB, err = cache.WrapDB(db, cache.Config{})
+	require.NoError(t, err)
+}
+
+// TestCacheFunctionality tests that the cache succeeds the normal path db test
+// suite.
+func TestCacheFunctionality(t *testing.T) {
+	tdb := &TestPathDB{}
+	pathdbtest.TestPathDB(t, tdb)
+}
+
+func TestCache(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+	params := &query.Params{SegTypes: []seg.Type{seg.TypeUp}}
+
+	t.Run("lookups are cached", func(t *testing.T) {
+		backend, db := setupDB(t, cache.Config{})
+		pseg, _ := pathdbtest.AllocPathSegment(t, ifs, uint32(time.Now().Unix()))
+		pathdbtest.InsertSeg(t, ctx, db, pseg, nil)
+
+		res, err := db.Get(ctx, params)
+		require.NoError(t, err)
+		assert.Len(t, res, 1)
+		res, err = db.Get(ctx, params)
+		require.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.EqualValues(t, 1, backend.gets())
+
+		// Different parameters are looked up separately.
+		_, err = db.Get(ctx, &query.Params{SegTypes: []seg.Type{seg.TypeDown}})
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, backend.gets())
+	})
+	t.Run("insert invalidates", func(t *testing.T) {
+		backend, db := setupDB(t, cache.Config{})
+		_, err := db.Get(ctx, params)
+		require.NoError(t, err)
+		pseg, _ := pathdbtest.AllocPathSegment(t, ifs, uint32(time.Now().Unix()))
+		pathdbtest.InsertSeg(t, ctx, db, pseg, nil)
+
+		res, err := db.Get(ctx, params)
+		require.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.EqualValues(t, 2, backend.gets())
+
+		// Inserting the same segment again does not change the database.
+		pathdbtest.InsertSeg(t, ctx, db, pseg, nil)
+		_, err = db.Get(ctx, params)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, backend.gets())
+	})
+	t.Run("delete expired invalidates", func(t *testing.T) {
+		backend, db := setupDB(t, cache.Config{})
+		pseg, _ := pathdbtest.AllocPathSegment(t, ifs, uint32(time.Now().Unix()))
+		pathdbtest.InsertSeg(t, ctx, db, pseg, nil)
+		_, err := db.Get(ctx, params)
+		require.NoError(t, err)
+
+		n, err := db.DeleteExpired(ctx, pseg.MaxExpiry().Add(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		res, err := db.Get(ctx, params)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+		assert.EqualValues(t, 2, backend.gets())
+	})
+	t.Run("commit invalidates", func(t *testing.T) {
+		backend, db := setupDB(t, cache.Config{})
+		_, err := db.Get(ctx, params)
+		require.NoError(t, err)
+		tx, err := db.BeginTransaction(ctx, nil)
+		require.NoError(t, err)
+		pseg, _ := pathdbtest.AllocPathSegment(t, ifs, uint32(time.Now().Unix()))
+		pathdbtest.InsertSeg(t, ctx, tx, pseg, nil)
+		require.NoError(t, tx.Commit())
+
+		res, err := db.Get(ctx, params)
+		require.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.EqualValues(t, 2, backend.gets())
+	})
+	t.Run("entries expire", func(t *testing.T) {
+		backend, db := setupDB(t, cache.Config{TTL: time.Millisecond})
+		_, err := db.Get(ctx, params)
+		require.NoError(t, err)
+		time.Sleep(2 * time.Millisecond)
+		_, err = db.Get(ctx, params)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, backend.gets())
+	})
+	t.Run("results are copied", func(t *testing.T) {
+		_, db := setupDB(t, cache.Config{})
+		pseg, _ := pathdbtest.AllocPathSegment(t, ifs, uint32(time.Now().Unix()))
+		pathdbtest.InsertSeg(t, ctx, db, pseg, nil)
+		res, err := db.Get(ctx, params)
+		require.NoError(t, err)
+		res[0].Type = seg.TypeDown
+		res, err = db.Get(ctx, params)
+		require.NoError(t, err)
+		assert.Equal(t, seg.TypeUp, res[0].Type)
+	})
+}
+
+// BenchmarkGet measures the lookups of the segments between a fixed set of
+// ASes, with and without the cache.
+func BenchmarkGet(b *testing.B) {
+	for _, cached := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cached=%t", cached), func(b *testing.B) {
+			ctx := context.Background()
+			backend, err := sqlite.New(filepath.Join(b.TempDir(), "path.db"))
+			require.NoError(b, err)
+			var db storage.PathDB = backend
+			if cached {
+				db, err = cache.WrapDB(db, cache.Config{})
+				require.NoError(b, err)
+			}
+			defer db.Close()
+			t := &testing.T{}
+			pseg, _ := pathdbtest.AllocPathSegment(t, ifs, uint32(time.Now().Unix()))
+			pathdbtest.InsertSeg(t, ctx, db, pseg, nil)
+			params := []*query.Params{
+				{StartsAt: []addr.IA{pseg.FirstIA()}},
+				{EndsAt: []addr.IA{pseg.LastIA()}},
+				{SegTypes: []seg.Type{seg.TypeUp}},
+			}
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if _, err := db.Get(ctx, params[i%len(params)]); err != nil {
+						b.Error(err)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+// countingDB counts the lookups that reach the database.
+type countingDB struct {
+	storage.PathDB
+	n int64
+}
+
+func (db *countingDB) Get(ctx context.Context, params *query.Params) (query.Results, error) {
+	atomic.AddInt64(&db.n, 1)
+	return db.PathDB.Get(ctx, params)
+}
+
+func (db *countingDB) gets() int64 {
+	return atomic.LoadInt64(&db.n)
+}
+
+func setupDB(t *testing.T, cfg cache.Config) (*countingDB, storage.PathDB) {
+	backend, err := sqlite.New("file::memory:")
+	require.NoError(t, err)
+	counting := &countingDB{PathDB: backend}
+	db, err := cache.WrapDB(counting, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return counting, db
+}
@@ -87,6 +87,8 @@ func TestPathDB(t *testing.T, db TestablePathDB) {
 		testWrapper(testUpdateIntfToSeg))
 	t.Run("DeleteExpired should delete expired segments",
 		testWrapper(testDeleteExpired))
+	t.Run("DeleteFromHPGroup should delete segments without groups",
+		testWrapper(testDeleteFromHPGroup))
 	t.Run("Get should return the correct path segments",
 		testWrapper(testGetMixed))
 	t.Run("Get with nil params should return all path segments",
@@ -99,6 +101,8 @@ func TestPathDB(t *testing.T, db TestablePathDB) {
 		testWrapper(testGetWithIntfs))
 	t.Run("Get should return all path segment with given HPGroupIDs",
 		testWrapper(testGetWithHPGroupIDs))
+	t.Run("Get should apply the segment filters",
+		testWrapper(testGetWithSegmentFilters))
 	t.Run("NextQuery",
 		testWrapper(testNextQuery))
 
@@ -127,6 +131,8 @@ func TestPathDB(t *testing.T, db TestablePathDB) {
 			txTestWrapper(testUpdateIntfToSeg))
 		t.Run("DeleteExpired should delete expired segments",
 			txTestWrapper(testDeleteExpired))
+		t.Run("DeleteFromHPGroup should delete segments without groups",
+			txTestWrapper(testDeleteFromHPGroup))
 		t.Run("Get should return the correct path segments",
 			txTestWrapper(testGetMixed))
 		t.Run("Get with nil params should return all path segments",
@@ -139,6 +145,8 @@ func TestPathDB(t *testing.T, db TestablePathDB) {
 			txTestWrapper(testGetWithIntfs))
 		t.Run("Get should return all path segment with given HPGroupIDs",
 			txTestWrapper(testGetWithHPGroupIDs))
+		t.Run("Get should apply the segment filters",
+			txTestWrapper(testGetWithSegmentFilters))
 		t.Run("NextQuery",
 			txTestWrapper(testNextQuery))
 		t.Run("Rollback", func(t *testing.T) {
@@ -267,6 +275,31 @@ func testDeleteExpired(t *testing.T, pathDB pathdb.ReadWrite) {
 	assert.Equal(t, 1, deleted, "Deleted")
 }
 
+func testDeleteFromHPGroup(t *testing.T, pathDB pathdb.ReadWrite) {
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+	pseg, segID := AllocPathSegment(t, ifs1, 10)
+	stat := InsertSeg(t, ctx, pathDB, pseg, hpGroupIDs)
+	require.Equal(t, pathdb.InsertStats{Inserted: 1}, stat)
+
+	deleted, err := pathDB.DeleteFromHPGroup(ctx, segID, hpGroupIDs[1])
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted, "Deleted")
+	res, err := pathDB.Get(ctx, &query.Params{HPGroupIDs: hpGroupIDs[1:]})
+	require.NoError(t, err)
+	assert.Empty(t, res, "Removed from group")
+	res, err = pathDB.Get(ctx, &query.Params{HPGroupIDs: hpGroupIDs[:1]})
+	require.NoError(t, err)
+	assert.Len(t, res, 1, "Remaining group")
+
+	deleted, err = pathDB.DeleteFromHPGroup(ctx, segID, hpGroupIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted, "Deleted")
+	res, err = pathDB.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
 func testGetMixed(t *testing.T, pathDB pathdb.ReadWrite) {
 	// Setup
 	TS := uint32(10)
@@ -395,6 +428,62 @@ func testGetWithIntfs(t *testing.T, pathDB pathdb.ReadWrite) {
 	assert.Equal(t, 2, len(res), "Result count")
 }
 
+func testGetWithSegmentFilters(t *testing.T, pathDB pathdb.ReadWrite) {
+	// Setup
+	TS := uint32(10)
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+	pseg1, _ := AllocPathSegment(t, ifs1, TS)
+	pseg2, _ := AllocPathSegment(t, ifs2, TS)
+	stat := InsertSeg(t, ctx, pathDB, pseg1, hpGroupIDs)
+	require.Equal(t, stat, pathdb.InsertStats{Inserted: 1})
+	stat = InsertSeg(t, ctx, pathDB, pseg2, hpGroupIDs[:1])
+	require.Equal(t, stat, pathdb.InsertStats{Inserted: 1})
+	testCases := map[string]struct {
+		Params   *query.Params
+		Expected int
+	}{
+		"max hops satisfied": {
+			Params:   &query.Params{MaxHops: 3},
+			Expected: 2,
+		},
+		"max hops exceeded": {
+			Params:   &query.Params{MaxHops: 2},
+			Expected: 0,
+		},
+		"transit ISD": {
+			Params:   &query.Params{TransitISDs: []addr.ISD{1, 2}},
+			Expected: 2,
+		},
+		"other transit ISD": {
+			Params:   &query.Params{TransitISDs: []addr.ISD{2}},
+			Expected: 0,
+		},
+		"valid until": {
+			Params:   &query.Params{ValidUntil: pseg1.MinExpiry()},
+			Expected: 2,
+		},
+		"expires too early": {
+			Params:   &query.Params{ValidUntil: pseg1.MinExpiry().Add(time.Second)},
+			Expected: 0,
+		},
+		"combined with interface": {
+			Params: &query.Params{
+				Intfs:   []*query.IntfSpec{{IA: ia330, IfID: 5}},
+				MaxHops: 3,
+			},
+			Expected: 1,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			res, err := pathDB.Get(ctx, tc.Params)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, len(res), "Result count")
+		})
+	}
+}
+
 func testGetWithHPGroupIDs(t *testing.T, pathDB pathdb.ReadWrite) {
 	// Setup
 	TS := uint32(10)
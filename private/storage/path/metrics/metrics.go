@@ -39,6 +39,7 @@ const (
 	promOpInsert          promOp = "insert"
 	promOpInsertHpCfg     promOp = "insert_with_hpcfg"
 	promOpDeleteExpired   promOp = "delete_expired"
+	promOpDeleteFromGroup promOp = "delete_from_hp_group"
 	promOpGet             promOp = "get"
 	promOpGetAll          promOp = "get_all"
 	promOpInsertNextQuery promOp = "insert_next_query"
@@ -201,6 +202,18 @@ func (db *metricsExecutor) DeleteExpired(ctx context.Context, now time.Time) (in
 	return cnt, err
 }
 
+func (db *metricsExecutor) DeleteFromHPGroup(ctx context.Context, segID []byte,
+	hpGroupID uint64) (int, error) {
+
+	var cnt int
+	var err error
+	db.metrics.Observe(ctx, promOpDeleteFromGroup, func(ctx context.Context) error {
+		cnt, err = db.pathDB.DeleteFromHPGroup(ctx, segID, hpGroupID)
+		return err
+	})
+	return cnt, err
+}
+
 func (db *metricsExecutor) Get(ctx context.Context, params *query.Params) (query.Results, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, fmt.Sprintf("pathdb.%s", string(promOpGet)))
 	defer span.Finish()
@@ -0,0 +1,515 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/pathdb"
+	"github.com/scionproto/scion/private/pathdb/query"
+	"github.com/scionproto/scion/private/storage/db"
+	"github.com/scionproto/scion/private/storage/path"
+	"github.com/scionproto/scion/private/storage/utils"
+)
+
+type segMeta struct {
+	RowID  int64
+	FullID []byte
+	Seg    *seg.PathSegment
+}
+
+var _ pathdb.DB = (*Backend)(nil)
+
+// Backend implements the path DB with a PostgreSQL backend. In contrast to the
+// SQLite backend, concurrent access is not serialized in the process, because
+// multiple services can share the database. Concurrent writes are resolved by
+// the database.
+type Backend struct {
+	db *sql.DB
+	*executor
+}
+
+// New returns a new PostgreSQL backend connected with the given connection
+// string. Pending schema migrations are applied. If the schema version of the
+// database is newer than the one understood by this backend, an error is
+// returned.
+func New(connection string) (*Backend, error) {
+	db, err := db.NewPostgres(connection, Name, Migrations)
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{
+		executor: &executor{
+			db: db,
+		},
+		db: db,
+	}, nil
+}
+
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+func (b *Backend) SetMaxOpenConns(maxOpenConns int) {
+	b.db.SetMaxOpenConns(maxOpenConns)
+}
+
+func (b *Backend) SetMaxIdleConns(maxIdleConns int) {
+	b.db.SetMaxIdleConns(maxIdleConns)
+}
+
+func (b *Backend) BeginTransaction(ctx context.Context,
+	opts *sql.TxOptions) (pathdb.Transaction, error) {
+
+	tx, err := b.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, serrors.WrapStr("Failed to create transaction", err)
+	}
+	return &transaction{
+		executor: &executor{
+			db: tx,
+		},
+		tx: tx,
+	}, nil
+}
+
+var _ (pathdb.Transaction) = (*transaction)(nil)
+
+type transaction struct {
+	*executor
+	tx *sql.Tx
+}
+
+func (tx *transaction) Commit() error {
+	return tx.tx.Commit()
+}
+
+func (tx *transaction) Rollback() error {
+	return tx.tx.Rollback()
+}
+
+var _ (pathdb.ReadWrite) = (*executor)(nil)
+
+type executor struct {
+	db db.Sqler
+}
+
+func (e *executor) Insert(ctx context.Context, segMeta *seg.Meta) (pathdb.InsertStats, error) {
+	// The way the SQL queries are built requires each path segment to be
+	// registered with a 0 hidden path group id.
+	return e.InsertWithHPGroupIDs(ctx, segMeta, []uint64{0})
+}
+
+func (e *executor) InsertWithHPGroupIDs(ctx context.Context, segMeta *seg.Meta,
+	hpGroupIDs []uint64) (pathdb.InsertStats, error) {
+
+	var stats pathdb.InsertStats
+	err := db.DoInTx(ctx, e.db, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		stats, err = insert(ctx, tx, segMeta, hpGroupIDs)
+		return err
+	})
+	return stats, err
+}
+
+func insert(ctx context.Context, tx *sql.Tx, segMeta *seg.Meta,
+	hpGroupIDs []uint64) (pathdb.InsertStats, error) {
+
+	pseg := segMeta.Segment
+	meta, err := get(ctx, tx, pseg.ID())
+	if err != nil {
+		return pathdb.InsertStats{}, err
+	}
+	if meta == nil {
+		inserted, err := insertFull(ctx, tx, pseg, segMeta.Type, hpGroupIDs)
+		if err != nil || !inserted {
+			return pathdb.InsertStats{}, err
+		}
+		return pathdb.InsertStats{Inserted: 1}, nil
+	}
+	newLastHopVersion, err := utils.ExtractLastHopVersion(pseg)
+	if err != nil {
+		return pathdb.InsertStats{}, err
+	}
+	oldLastHopVersion, err := utils.ExtractLastHopVersion(meta.Seg)
+	if err != nil {
+		return pathdb.InsertStats{}, err
+	}
+	// If the segment is older than the one already present in the path DB.
+	if newLastHopVersion <= oldLastHopVersion {
+		return pathdb.InsertStats{}, nil
+	}
+	oldFullID := meta.FullID
+	meta.Seg = pseg
+	if err := updateExisting(ctx, tx, meta, segMeta.Type, oldFullID, hpGroupIDs); err != nil {
+		return pathdb.InsertStats{}, err
+	}
+	return pathdb.InsertStats{Updated: 1}, nil
+}
+
+// get returns the metadata of the segment with the given ID, or nil if there
+// is none. The row is locked until the end of the transaction.
+func get(ctx context.Context, tx *sql.Tx, segID []byte) (*segMeta, error) {
+	query := "SELECT RowID, FullID, Segment FROM Segments WHERE SegID=$1 FOR UPDATE"
+	var meta segMeta
+	var rawSeg []byte
+	err := tx.QueryRowContext(ctx, query, segID).Scan(&meta.RowID, &meta.FullID, &rawSeg)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, serrors.WrapStr("Failed to lookup segment", err)
+	}
+	meta.Seg, err = pathdb.UnpackSegment(rawSeg)
+	if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func updateExisting(ctx context.Context, tx *sql.Tx, meta *segMeta,
+	segType seg.Type, oldFullID []byte, hpGroupIDs []uint64) error {
+
+	packedSeg, err := pathdb.PackSegment(meta.Seg)
+	if err != nil {
+		return err
+	}
+	newFullID := meta.Seg.FullID()
+	_, err = tx.ExecContext(ctx, `UPDATE Segments SET FullID=$1, LastUpdated=$2, InfoTs=$3,
+		Segment=$4, MaxExpiry=$5 WHERE RowID=$6`,
+		newFullID, time.Now().UnixNano(), meta.Seg.Info.Timestamp.UnixNano(), packedSeg,
+		meta.Seg.MaxExpiry().Unix(), meta.RowID)
+	if err != nil {
+		return serrors.WrapStr("Failed to update segment", err)
+	}
+	if err := insertType(ctx, tx, meta.RowID, segType); err != nil {
+		return err
+	}
+	for _, hpGroupID := range hpGroupIDs {
+		if err := insertHPGroupID(ctx, tx, meta.RowID, hpGroupID); err != nil {
+			return err
+		}
+	}
+	if !bytes.Equal(newFullID, oldFullID) {
+		// Delete all old interfaces and then insert the new ones.
+		_, err := tx.ExecContext(ctx, `DELETE FROM IntfToSeg WHERE SegRowID=$1`, meta.RowID)
+		if err != nil {
+			return err
+		}
+		if err := insertInterfaces(ctx, tx, meta.Seg.ASEntries, meta.RowID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertType(ctx context.Context, tx *sql.Tx, segRowID int64, segType seg.Type) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO SegTypes (SegRowID, Type) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, segRowID, segType)
+	if err != nil {
+		return serrors.WrapStr("Failed to insert type", err)
+	}
+	return nil
+}
+
+func insertHPGroupID(ctx context.Context, tx *sql.Tx, segRowID int64, hpGroupID uint64) error {
+	// The group ID is stored as signed integer, because the driver does not
+	// support unsigned integers with the high bit set.
+	_, err := tx.ExecContext(ctx, `INSERT INTO HPGroupIDs (SegRowID, GroupID) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, segRowID, int64(hpGroupID))
+	if err != nil {
+		return serrors.WrapStr("Failed to insert hpGroupID", err)
+	}
+	return nil
+}
+
+// insertFull inserts a new segment. If a concurrent transaction inserted the
+// same segment in the meantime, nothing is inserted and false is returned.
+func insertFull(ctx context.Context, tx *sql.Tx, pseg *seg.PathSegment, segType seg.Type,
+	hpGroupIDs []uint64) (bool, error) {
+
+	packedSeg, err := pathdb.PackSegment(pseg)
+	if err != nil {
+		return false, err
+	}
+	st := pseg.FirstIA()
+	end := pseg.LastIA()
+	inst := `INSERT INTO Segments (SegID, FullID, LastUpdated, InfoTs, Segment, MaxExpiry,
+			StartIsdID, StartAsID, EndIsdID, EndAsID)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT DO NOTHING
+			RETURNING RowID`
+	var segRowID int64
+	err = tx.QueryRowContext(ctx, inst, pseg.ID(), pseg.FullID(), time.Now().UnixNano(),
+		pseg.Info.Timestamp.UnixNano(), packedSeg, pseg.MaxExpiry().Unix(),
+		st.ISD(), st.AS(), end.ISD(), end.AS()).Scan(&segRowID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, serrors.WrapStr("Failed to insert path segment", err)
+	}
+	if err := insertInterfaces(ctx, tx, pseg.ASEntries, segRowID); err != nil {
+		return false, err
+	}
+	if err := insertType(ctx, tx, segRowID, segType); err != nil {
+		return false, err
+	}
+	// The way the SQL queries are built requires each path segment to be
+	// registered with a 0 hidden path group id (if there is not a different
+	// one set).
+	if len(hpGroupIDs) == 0 {
+		hpGroupIDs = []uint64{0}
+	}
+	for _, hpGroupID := range hpGroupIDs {
+		if err := insertHPGroupID(ctx, tx, segRowID, hpGroupID); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func insertInterfaces(ctx context.Context, tx *sql.Tx, ases []seg.ASEntry, segRowID int64) error {
+	stmt, err := tx.PrepareContext(ctx,
+		`INSERT INTO IntfToSeg (IsdID, AsID, IntfID, SegRowID) VALUES ($1, $2, $3, $4)`)
+	if err != nil {
+		return serrors.WrapStr("Failed to prepare insert into IntfToSeg", err)
+	}
+	defer stmt.Close()
+	insertIntf := func(ia addr.IA, ifID uint16) error {
+		if ifID == 0 {
+			return nil
+		}
+		_, err := stmt.ExecContext(ctx, ia.ISD(), ia.AS(), ifID, segRowID)
+		return err
+	}
+	for _, as := range ases {
+		hof := as.HopEntry.HopField
+		if err := insertIntf(as.Local, hof.ConsIngress); err != nil {
+			return serrors.WrapStr("inserting Ingress into IntfToSeg", err)
+		}
+		if err := insertIntf(as.Local, hof.ConsEgress); err != nil {
+			return serrors.WrapStr("inserting Egress into IntfToSeg", err)
+		}
+		for i, peer := range as.PeerEntries {
+			if err := insertIntf(as.Local, peer.HopField.ConsIngress); err != nil {
+				return serrors.WrapStr("insert peering Ingress into IntfToSeg", err, "index", i)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *executor) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	return db.DeleteInTx(ctx, e.db, func(tx *sql.Tx) (sql.Result, error) {
+		return tx.ExecContext(ctx, `DELETE FROM Segments WHERE MaxExpiry < $1`, now.Unix())
+	})
+}
+
+func (e *executor) DeleteFromHPGroup(ctx context.Context, segID []byte,
+	hpGroupID uint64) (int, error) {
+
+	return db.DeleteInTx(ctx, e.db, func(tx *sql.Tx) (sql.Result, error) {
+		_, err := tx.ExecContext(ctx, `DELETE FROM HPGroupIDs WHERE GroupID = $1 AND
+			SegRowID IN (SELECT RowID FROM Segments WHERE SegID = $2)`, int64(hpGroupID), segID)
+		if err != nil {
+			return nil, err
+		}
+		return tx.ExecContext(ctx, `DELETE FROM Segments WHERE SegID = $1 AND
+			NOT EXISTS (SELECT 1 FROM HPGroupIDs h WHERE h.SegRowID = Segments.RowID)`, segID)
+	})
+}
+
+func (e *executor) Get(ctx context.Context, params *query.Params) (query.Results, error) {
+	stmt, args := buildQuery(params)
+	rows, err := e.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, serrors.WrapStr("Error looking up path segment", err, "q", stmt)
+	}
+	defer rows.Close()
+	var res []*query.Result
+	for rows.Next() {
+		var segRowID int64
+		var rawSeg sql.RawBytes
+		var lastUpdated int64
+		var segTypes path.SegTypes
+		var hpGroupIDs path.GroupIDs
+		if err = rows.Scan(
+			&segRowID, &rawSeg, &lastUpdated, &segTypes, &hpGroupIDs); err != nil {
+
+			return nil, serrors.WrapStr("Error reading DB response", err)
+		}
+		parsed, err := pathdb.UnpackSegment(rawSeg)
+		if err != nil {
+			return nil, serrors.WrapStr("unmarshalling segment", err)
+		}
+		// Filters that cannot be expressed on the indexed columns are applied
+		// to the unpacked segment.
+		if !params.Matches(parsed) {
+			continue
+		}
+		for _, t := range segTypes {
+			res = append(res, &query.Result{
+				LastUpdate: time.Unix(0, lastUpdated),
+				Type:       t,
+				Seg:        parsed,
+				HPGroupIDs: []uint64(hpGroupIDs),
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func buildQuery(params *query.Params) (string, []interface{}) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	query := []string{
+		"SELECT s.RowID, s.Segment, s.LastUpdated, " +
+			"string_agg(DISTINCT t.Type::TEXT, ','), " +
+			"string_agg(DISTINCT h.GroupID::TEXT, ',') FROM Segments s",
+		"JOIN SegTypes t ON t.SegRowID=s.RowID",
+		"JOIN HPGroupIDs h ON h.SegRowID=s.RowID",
+	}
+	if params == nil {
+		query = append(query, "GROUP BY s.RowID")
+		query = append(query, "ORDER BY s.RowID ASC")
+		return strings.Join(query, "\n"), args
+	}
+	joins := []string{}
+	where := []string{}
+	if len(params.SegIDs) > 0 {
+		subQ := make([]string, 0, len(params.SegIDs))
+		for _, segID := range params.SegIDs {
+			subQ = append(subQ, "s.SegID="+arg(segID))
+		}
+		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+	}
+	if len(params.SegTypes) > 0 {
+		subQ := []string{}
+		for _, segType := range params.SegTypes {
+			subQ = append(subQ, "t.Type="+arg(segType))
+		}
+		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+	}
+	if len(params.HPGroupIDs) > 0 {
+		subQ := []string{}
+		for _, hpGroupID := range params.HPGroupIDs {
+			subQ = append(subQ, fmt.Sprintf("(h.GroupID=%s)", arg(int64(hpGroupID))))
+		}
+		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+	}
+	if len(params.Intfs) > 0 {
+		joins = append(joins, "JOIN IntfToSeg i ON i.SegRowID=s.RowID")
+		subQ := []string{}
+		for _, spec := range params.Intfs {
+			subQ = append(subQ, fmt.Sprintf("(i.IsdID=%s AND i.AsID=%s AND i.IntfID=%s)",
+				arg(spec.IA.ISD()), arg(spec.IA.AS()), arg(spec.IfID)))
+		}
+		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+	}
+	if len(params.StartsAt) > 0 {
+		subQ := []string{}
+		for _, as := range params.StartsAt {
+			if as.AS() == 0 {
+				subQ = append(subQ, fmt.Sprintf("(s.StartIsdID=%s)", arg(as.ISD())))
+			} else {
+				subQ = append(subQ, fmt.Sprintf("(s.StartIsdID=%s AND s.StartAsID=%s)",
+					arg(as.ISD()), arg(as.AS())))
+			}
+		}
+		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+	}
+	if len(params.EndsAt) > 0 {
+		subQ := []string{}
+		for _, as := range params.EndsAt {
+			if as.AS() == 0 {
+				subQ = append(subQ, fmt.Sprintf("(s.EndIsdID=%s)", arg(as.ISD())))
+			} else {
+				subQ = append(subQ, fmt.Sprintf("(s.EndIsdID=%s AND s.EndAsID=%s)",
+					arg(as.ISD()), arg(as.AS())))
+			}
+		}
+		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+	}
+	if !params.ValidUntil.IsZero() {
+		// The maximum expiry is a necessary condition, the minimum expiry is
+		// checked on the unpacked segment.
+		where = append(where, fmt.Sprintf("(s.MaxExpiry>=%s)", arg(params.ValidUntil.Unix())))
+	}
+	if len(joins) > 0 {
+		query = append(query, strings.Join(joins, "\n"))
+	}
+	if len(where) > 0 {
+		query = append(query, fmt.Sprintf("WHERE %s", strings.Join(where, " AND\n")))
+	}
+	query = append(query, "GROUP BY s.RowID")
+	query = append(query, "ORDER BY s.RowID ASC")
+	return strings.Join(query, "\n"), args
+}
+
+func (e *executor) GetAll(ctx context.Context) (query.Results, error) {
+	return e.Get(ctx, nil)
+}
+
+func (e *executor) InsertNextQuery(ctx context.Context, src, dst addr.IA,
+	nextQuery time.Time) (bool, error) {
+
+	// The next query time is only updated if the new one is later than the
+	// existing one.
+	query := `
+		INSERT INTO NextQuery (SrcIsdID, SrcAsID, DstIsdID, DstAsID, NextQuery)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (SrcIsdID, SrcAsID, DstIsdID, DstAsID) DO UPDATE
+		SET NextQuery=EXCLUDED.NextQuery
+		WHERE NextQuery.NextQuery < EXCLUDED.NextQuery
+	`
+	r, err := e.db.ExecContext(ctx, query, src.ISD(), src.AS(), dst.ISD(), dst.AS(),
+		nextQuery.UnixNano())
+	if err != nil {
+		return false, serrors.WrapStr("Failed to execute statement", err)
+	}
+	n, err := r.RowsAffected()
+	return n > 0, err
+}
+
+func (e *executor) GetNextQuery(ctx context.Context, src, dst addr.IA) (time.Time, error) {
+	query := `
+		SELECT NextQuery FROM NextQuery
+		WHERE SrcIsdID=$1 AND SrcAsID=$2 AND DstIsdID=$3 AND DstAsID=$4
+	`
+	var nanos int64
+	err := e.db.QueryRowContext(ctx, query, src.ISD(), src.AS(), dst.ISD(), dst.AS()).Scan(&nanos)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, nanos), nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pathdbtest "github.com/scionproto/scion/private/storage/path/dbtest"
+)
+
+// connectionEnv is the environment variable that holds the connection string
+// of the PostgreSQL database used by the tests. The tests are skipped if it is
+// not set. All data in the database is deleted by the tests.
+const connectionEnv = "SCION_TEST_POSTGRES"
+
+var _ pathdbtest.TestablePathDB = (*TestPathDB)(nil)
+
+type TestPathDB struct {
+	*Backend
+}
+
+func (b *TestPathDB) Prepare(t *testing.T, _ context.Context) {
+	connection := os.Getenv(connectionEnv)
+	if b.Backend != nil {
+		b.Backend.Close()
+	}
+	reset(t, connection)
+	db, err := New(connection)
+	require.NoError(t, err)
+	b.Backend = db
+}
+
+func TestPathDBSuite(t *testing.T) {
+	if os.Getenv(connectionEnv) == "" {
+		t.Skipf("%s not set", connectionEnv)
+	}
+	tdb := &TestPathDB{}
+	pathdbtest.TestPathDB(t, tdb)
+}
+
+// reset deletes all path segments and next query entries.
+func reset(t *testing.T, connection string) {
+	db, err := sql.Open("postgres", connection)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`DO $$ BEGIN
+		IF to_regclass('Segments') IS NOT NULL THEN
+			DELETE FROM Segments;
+			DELETE FROM NextQuery;
+		END IF;
+	END $$`)
+	require.NoError(t, err)
+}
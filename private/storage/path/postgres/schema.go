@@ -0,0 +1,64 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+// Name identifies the path DB in the schema migrations table.
+const Name = "pathdb"
+
+// Migrations are the PostgreSQL schema migrations of the path DB, in the order
+// they are applied. Existing migrations must never be modified, changes to the
+// schema are made by appending a new migration.
+var Migrations = []string{
+	`CREATE TABLE Segments(
+		RowID BIGSERIAL PRIMARY KEY,
+		SegID BYTEA UNIQUE NOT NULL,
+		FullID BYTEA UNIQUE NOT NULL,
+		LastUpdated BIGINT NOT NULL,
+		InfoTs BIGINT NOT NULL,
+		Segment BYTEA NOT NULL,
+		MaxExpiry BIGINT NOT NULL,
+		StartIsdID INTEGER NOT NULL,
+		StartAsID BIGINT NOT NULL,
+		EndIsdID INTEGER NOT NULL,
+		EndAsID BIGINT NOT NULL
+	);
+	CREATE INDEX SegmentsMaxExpiryIndex ON Segments(MaxExpiry);
+	CREATE TABLE IntfToSeg(
+		IsdID INTEGER NOT NULL,
+		AsID BIGINT NOT NULL,
+		IntfID BIGINT NOT NULL,
+		SegRowID BIGINT NOT NULL REFERENCES Segments(RowID) ON DELETE CASCADE
+	);
+	CREATE INDEX RowIdIndex ON IntfToSeg(SegRowID);
+	CREATE INDEX IntfIndex ON IntfToSeg(IsdID, AsID, IntfID);
+	CREATE TABLE SegTypes(
+		SegRowID BIGINT NOT NULL REFERENCES Segments(RowID) ON DELETE CASCADE,
+		Type INTEGER NOT NULL,
+		PRIMARY KEY (SegRowID, Type)
+	);
+	CREATE TABLE HPGroupIDs(
+		SegRowID BIGINT NOT NULL REFERENCES Segments(RowID) ON DELETE CASCADE,
+		GroupID BIGINT NOT NULL,
+		PRIMARY KEY (SegRowID, GroupID)
+	);
+	CREATE TABLE NextQuery(
+		SrcIsdID INTEGER NOT NULL,
+		SrcAsID BIGINT NOT NULL,
+		DstIsdID INTEGER NOT NULL,
+		DstAsID BIGINT NOT NULL,
+		NextQuery BIGINT NOT NULL,
+		PRIMARY KEY (SrcIsdID, SrcAsID, DstIsdID, DstAsID)
+	);`,
+}
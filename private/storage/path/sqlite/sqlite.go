@@ -377,6 +377,20 @@ func (e *executor) DeleteExpired(ctx context.Context, now time.Time) (int, error
 	})
 }
 
+func (e *executor) DeleteFromHPGroup(ctx context.Context, segID []byte,
+	hpGroupID uint64) (int, error) {
+
+	return e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+		_, err := tx.ExecContext(ctx, `DELETE FROM HPGroupIDs WHERE GroupID = ? AND
+			SegRowID IN (SELECT RowID FROM Segments WHERE SegID = ?)`, int64(hpGroupID), segID)
+		if err != nil {
+			return nil, err
+		}
+		return tx.ExecContext(ctx, `DELETE FROM Segments WHERE SegID = ? AND
+			NOT EXISTS (SELECT 1 FROM HPGroupIDs h WHERE h.SegRowID = Segments.RowID)`, segID)
+	})
+}
+
 func (e *executor) deleteInTx(ctx context.Context,
 	delFunc func(tx *sql.Tx) (sql.Result, error)) (int, error) {
 
@@ -416,6 +430,11 @@ func (e *executor) Get(ctx context.Context, params *query.Params) (query.Results
 		if err != nil {
 			return nil, serrors.WrapStr("unmarshalling segment", err)
 		}
+		// Filters that cannot be expressed on the indexed columns are applied
+		// to the unpacked segment.
+		if !params.Matches(parsed) {
+			continue
+		}
 		for _, t := range segTypes {
 			res = append(res, &query.Result{
 				LastUpdate: time.Unix(0, lastUpdated),
@@ -505,6 +524,12 @@ func (e *executor) buildQuery(params *query.Params) (string, []interface{}) {
 		}
 		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
 	}
+	if !params.ValidUntil.IsZero() {
+		// The maximum expiry is a necessary condition, the minimum expiry is
+		// checked on the unpacked segment.
+		where = append(where, "(s.MaxExpiry>=?)")
+		args = append(args, params.ValidUntil.Unix())
+	}
 	// Assemble the query.
 	if len(joins) > 0 {
 		query = append(query, strings.Join(joins, "\n"))
@@ -19,26 +19,36 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
+	"regexp"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/scionproto/scion/control/beacon"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/drkey"
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/pathdb"
 	"github.com/scionproto/scion/private/periodic"
 	"github.com/scionproto/scion/private/revcache"
 	"github.com/scionproto/scion/private/revcache/memrevcache"
 	beaconstorage "github.com/scionproto/scion/private/storage/beacon"
+	postgresbeacondb "github.com/scionproto/scion/private/storage/beacon/postgres"
 	sqlitebeacondb "github.com/scionproto/scion/private/storage/beacon/sqlite"
 	"github.com/scionproto/scion/private/storage/cleaner"
 	"github.com/scionproto/scion/private/storage/db"
 	sqlitelevel1 "github.com/scionproto/scion/private/storage/drkey/level1/sqlite"
 	sqlitelevel2 "github.com/scionproto/scion/private/storage/drkey/level2/sqlite"
 	sqlitesecret "github.com/scionproto/scion/private/storage/drkey/secret/sqlite"
+	postgrespathdb "github.com/scionproto/scion/private/storage/path/postgres"
 	sqlitepathdb "github.com/scionproto/scion/private/storage/path/sqlite"
 	truststorage "github.com/scionproto/scion/private/storage/trust"
+	postgrestrustdb "github.com/scionproto/scion/private/storage/trust/postgres"
 	sqlitetrustdb "github.com/scionproto/scion/private/storage/trust/sqlite"
 	"github.com/scionproto/scion/private/trust"
 )
@@ -49,6 +59,9 @@ type Backend string
 const (
 	// BackendSqlite indicates an sqlite backend.
 	BackendSqlite Backend = "sqlite"
+	// BackendPostgres indicates a PostgreSQL backend. In contrast to sqlite,
+	// the database can be shared by multiple services.
+	BackendPostgres Backend = "postgres"
 	// DefaultPath indicates the default connection string for a generic database.
 	DefaultPath              = "/share/scion.db"
 	DefaultTrustDBPath       = "/share/data/%s.trust.db"
@@ -109,9 +122,11 @@ var _ (config.Config) = (*DBConfig)(nil)
 
 // DBConfig is the configuration for the connection to a database.
 type DBConfig struct {
-	Connection   string `toml:"connection,omitempty"`
-	MaxOpenConns int    `toml:"max_open_conns,omitempty"`
-	MaxIdleConns int    `toml:"max_idle_conns,omitempty"`
+	// Backend is the database backend. If empty, BackendSqlite is used.
+	Backend      Backend `toml:"backend,omitempty"`
+	Connection   string  `toml:"connection,omitempty"`
+	MaxOpenConns int     `toml:"max_open_conns,omitempty"`
+	MaxIdleConns int     `toml:"max_idle_conns,omitempty"`
 }
 
 type writeDefault struct {
@@ -147,7 +162,35 @@ func (cfg *DBConfig) InitDefaults() {
 }
 
 func (cfg *DBConfig) Validate() error {
-	return nil
+	switch cfg.Backend {
+	case "", BackendSqlite, BackendPostgres:
+		return nil
+	default:
+		return serrors.New("unsupported database backend", "backend", cfg.Backend)
+	}
+}
+
+// backend returns the configured backend, or the default backend if none is
+// configured.
+func (cfg *DBConfig) backend() Backend {
+	if cfg.Backend == "" {
+		return BackendSqlite
+	}
+	return cfg.Backend
+}
+
+var passwordRe = regexp.MustCompile(`password=\S+`)
+
+// redactedConnection returns the connection string with the password removed,
+// such that it can be logged.
+func (cfg *DBConfig) redactedConnection() string {
+	if cfg.backend() != BackendPostgres {
+		return cfg.Connection
+	}
+	if u, err := url.Parse(cfg.Connection); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return passwordRe.ReplaceAllString(cfg.Connection, "password=xxxxx")
 }
 
 // Sample writes a config sample to the writer.
@@ -160,9 +203,11 @@ func (cfg *DBConfig) ConfigName() string {
 	return "db"
 }
 
-func NewBeaconStorage(c DBConfig, ia addr.IA) (BeaconDB, error) {
-	log.Info("Connecting BeaconDB", "backend", BackendSqlite, "connection", c.Connection)
-	db, err := sqlitebeacondb.New(c.Connection, ia)
+// NewBeaconStorage creates the beacon database. Expired beacons are removed,
+// and beacons in excess of the limits are evicted periodically.
+func NewBeaconStorage(c DBConfig, ia addr.IA, limits beaconstorage.Limits) (BeaconDB, error) {
+	log.Info("Connecting BeaconDB", "backend", c.backend(), "connection", c.redactedConnection())
+	db, err := newBeaconBackend(c, ia)
 	if err != nil {
 		return nil, err
 	}
@@ -179,27 +224,94 @@ func NewBeaconStorage(c DBConfig, ia addr.IA) (BeaconDB, error) {
 		30*time.Second,
 		30*time.Second,
 	)
-	return beaconDBWithCleaner{
+	result := beaconDBWithCleaner{
 		BeaconDB: db,
 		cleaner:  cleaner,
-	}, nil
+	}
+	if limits.MaxPerOrigin > 0 || limits.MaxTotal > 0 {
+		result.evictor = periodic.Start(
+			&beaconEvictor{
+				db:     db,
+				limits: limits,
+				evicted: metrics.NewPromCounter(prom.SafeRegister(
+					prometheus.NewCounterVec(prometheus.CounterOpts{
+						Name: "control_beaconstorage_evicted_total",
+						Help: "Number of beacons evicted to enforce the beacon storage limits.",
+					}, []string{}),
+				).(*prometheus.CounterVec)),
+			},
+			30*time.Second,
+			30*time.Second,
+		)
+	}
+	return result, nil
+}
+
+// beaconBackend is a beacon database backend.
+type beaconBackend interface {
+	BeaconDB
+	db.LimitSetter
+	beaconstorage.Cleanable
+	beaconstorage.Limitable
+}
+
+func newBeaconBackend(c DBConfig, ia addr.IA) (beaconBackend, error) {
+	if c.backend() == BackendPostgres {
+		db, err := postgresbeacondb.New(c.Connection, ia)
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	}
+	db, err := sqlitebeacondb.New(c.Connection, ia)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
 }
 
 // beaconDBWithCleaner implements the BeaconDB interface and stops both the
-// database and the cleanup task on Close.
+// database and the cleanup tasks on Close.
 type beaconDBWithCleaner struct {
 	BeaconDB
 	cleaner *periodic.Runner
+	evictor *periodic.Runner
 }
 
 func (b beaconDBWithCleaner) Close() error {
 	b.cleaner.Kill()
+	if b.evictor != nil {
+		b.evictor.Kill()
+	}
 	return b.BeaconDB.Close()
 }
 
+// beaconEvictor periodically evicts beacons to enforce the storage limits.
+type beaconEvictor struct {
+	db      beaconstorage.Limitable
+	limits  beaconstorage.Limits
+	evicted metrics.Counter
+}
+
+func (e *beaconEvictor) Name() string {
+	return "control_beaconstorage_evictor"
+}
+
+func (e *beaconEvictor) Run(ctx context.Context) {
+	count, err := e.db.DeleteExcessBeacons(ctx, e.limits)
+	if err != nil {
+		log.FromCtx(ctx).Error("Failed to evict beacons", "err", err)
+	}
+	if count > 0 {
+		log.FromCtx(ctx).Info("Evicted beacons", "count", count,
+			"max_per_origin", e.limits.MaxPerOrigin, "max_total", e.limits.MaxTotal)
+		metrics.CounterAdd(e.evicted, float64(count))
+	}
+}
+
 func NewPathStorage(c DBConfig) (PathDB, error) {
-	log.Info("Connecting PathDB", "backend", BackendSqlite, "connection", c.Connection)
-	db, err := sqlitepathdb.New(c.Connection)
+	log.Info("Connecting PathDB", "backend", c.backend(), "connection", c.redactedConnection())
+	db, err := newPathBackend(c)
 	if err != nil {
 		return nil, err
 	}
@@ -223,6 +335,27 @@ func NewPathStorage(c DBConfig) (PathDB, error) {
 	}, nil
 }
 
+// pathBackend is a path database backend.
+type pathBackend interface {
+	PathDB
+	db.LimitSetter
+}
+
+func newPathBackend(c DBConfig) (pathBackend, error) {
+	if c.backend() == BackendPostgres {
+		db, err := postgrespathdb.New(c.Connection)
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	}
+	db, err := sqlitepathdb.New(c.Connection)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
 // pathDBWithCleaner implements the path DB interface and stops both the
 // database and the cleanup task on Close.
 type pathDBWithCleaner struct {
@@ -241,7 +374,15 @@ func NewRevocationStorage() revcache.RevCache {
 }
 
 func NewTrustStorage(c DBConfig) (TrustDB, error) {
-	log.Info("Connecting TrustDB", "backend", BackendSqlite, "connection", c.Connection)
+	log.Info("Connecting TrustDB", "backend", c.backend(), "connection", c.redactedConnection())
+	if c.backend() == BackendPostgres {
+		db, err := postgrestrustdb.New(c.Connection)
+		if err != nil {
+			return nil, err
+		}
+		SetConnLimits(db, c)
+		return db, nil
+	}
 	db, err := sqlitetrustdb.New(c.Connection)
 	if err != nil {
 		return nil, err
@@ -251,7 +392,11 @@ func NewTrustStorage(c DBConfig) (TrustDB, error) {
 }
 
 func NewDRKeySecretValueStorage(c DBConfig) (drkey.SecretValueDB, error) {
-	log.Info("Connecting DRKeySecretValueDB", "	", BackendSqlite, "connection", c.Connection)
+	if c.backend() != BackendSqlite {
+		return nil, serrors.New("DRKey storage only supports the sqlite backend",
+			"backend", c.backend())
+	}
+	log.Info("Connecting DRKeySecretValueDB", "backend", BackendSqlite, "connection", c.Connection)
 	db, err := sqlitesecret.NewBackend(c.Connection)
 	if err != nil {
 		return nil, err
@@ -261,7 +406,11 @@ func NewDRKeySecretValueStorage(c DBConfig) (drkey.SecretValueDB, error) {
 }
 
 func NewDRKeyLevel1Storage(c DBConfig) (drkey.Level1DB, error) {
-	log.Info("Connecting DRKeyLevel1DB", "	", BackendSqlite, "connection", c.Connection)
+	if c.backend() != BackendSqlite {
+		return nil, serrors.New("DRKey storage only supports the sqlite backend",
+			"backend", c.backend())
+	}
+	log.Info("Connecting DRKeyLevel1DB", "backend", BackendSqlite, "connection", c.Connection)
 	db, err := sqlitelevel1.NewBackend(c.Connection)
 	if err != nil {
 		return nil, err
@@ -271,7 +420,11 @@ func NewDRKeyLevel1Storage(c DBConfig) (drkey.Level1DB, error) {
 }
 
 func NewDRKeyLevel2Storage(c DBConfig) (drkey.Level2DB, error) {
-	log.Info("Connecting DRKeyDB", "	", BackendSqlite, "connection", c.Connection)
+	if c.backend() != BackendSqlite {
+		return nil, serrors.New("DRKey storage only supports the sqlite backend",
+			"backend", c.backend())
+	}
+	log.Info("Connecting DRKeyDB", "backend", BackendSqlite, "connection", c.Connection)
 	db, err := sqlitelevel2.NewBackend(c.Connection)
 	if err != nil {
 		return nil, err
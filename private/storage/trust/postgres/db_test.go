@@ -0,0 +1,68 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/storage/trust/dbtest"
+	"github.com/scionproto/scion/private/storage/trust/postgres"
+)
+
+// connectionEnv is the environment variable that holds the connection string
+// of the PostgreSQL database used by the tests. The tests are skipped if it is
+// not set. All data in the database is deleted by the tests.
+const connectionEnv = "SCION_TEST_POSTGRES"
+
+type testDB struct {
+	postgres.DB
+}
+
+func (b *testDB) Prepare(t *testing.T, _ context.Context) {
+	connection := os.Getenv(connectionEnv)
+	if b.DB != (postgres.DB{}) {
+		b.DB.Close()
+	}
+	reset(t, connection)
+	db, err := postgres.New(connection)
+	require.NoError(t, err)
+	b.DB = db
+}
+
+func TestDB(t *testing.T) {
+	if os.Getenv(connectionEnv) == "" {
+		t.Skipf("%s not set", connectionEnv)
+	}
+	dbtest.Run(t, &testDB{}, dbtest.Config{})
+}
+
+// reset deletes all certificate chains and TRCs.
+func reset(t *testing.T, connection string) {
+	db, err := sql.Open("postgres", connection)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`DO $$ BEGIN
+		IF to_regclass('trcs') IS NOT NULL THEN
+			DELETE FROM chains;
+			DELETE FROM trcs;
+		END IF;
+	END $$`)
+	require.NoError(t, err)
+}
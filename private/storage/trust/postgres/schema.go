@@ -0,0 +1,44 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+// Name identifies the trust DB in the schema migrations table.
+const Name = "trustdb"
+
+// Migrations are the PostgreSQL schema migrations of the trust DB, in the order
+// they are applied. Existing migrations must never be modified, changes to the
+// schema are made by appending a new migration.
+var Migrations = []string{
+	`CREATE TABLE chains(
+		isd_id INTEGER NOT NULL,
+		as_id BIGINT NOT NULL,
+		key_id BYTEA NOT NULL,
+		not_before TIMESTAMPTZ NOT NULL,
+		not_after TIMESTAMPTZ NOT NULL,
+		chain_fingerprint BYTEA NOT NULL,
+		as_cert BYTEA NOT NULL,
+		ca_cert BYTEA NOT NULL,
+		PRIMARY KEY (isd_id, as_id, key_id, not_before, not_after, chain_fingerprint)
+	);
+	CREATE INDEX chains_fingerprint_index ON chains(chain_fingerprint);
+	CREATE TABLE trcs(
+		isd_id INTEGER NOT NULL,
+		base BIGINT NOT NULL,
+		serial BIGINT NOT NULL,
+		fingerprint BYTEA NOT NULL,
+		trc BYTEA NOT NULL,
+		PRIMARY KEY (isd_id, base, serial)
+	);`,
+}
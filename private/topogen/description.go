@@ -0,0 +1,136 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topogen
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Description is the compact description of a test topology. It uses the same
+// format as the .topo files of the python topology generator.
+type Description struct {
+	ASes  map[addr.IA]AS `yaml:"ASes"`
+	Links []Link         `yaml:"links"`
+}
+
+// AS describes an AS of the topology.
+type AS struct {
+	Core          bool    `yaml:"core,omitempty"`
+	Voting        bool    `yaml:"voting,omitempty"`
+	Authoritative bool    `yaml:"authoritative,omitempty"`
+	Issuing       bool    `yaml:"issuing,omitempty"`
+	CertIssuer    addr.IA `yaml:"cert_issuer,omitempty"`
+	// MTU is the MTU within the AS. If zero, the default MTU is used.
+	MTU int `yaml:"mtu,omitempty"`
+}
+
+// Link describes a link between two ASes. The endpoints are in the format
+// ISD-AS[-BR][#IFID], e.g., 1-ff00:0:110-A#1. Endpoints with the same BR
+// name are served by the same border router. If the interface ID is omitted,
+// the lowest free one is assigned.
+type Link struct {
+	A string `yaml:"a"`
+	B string `yaml:"b"`
+	// LinkAtoB is the type of the link from the perspective of A, i.e., one
+	// of CHILD, CORE or PEER.
+	LinkAtoB string `yaml:"linkAtoB"`
+	// MTU is the MTU of the link. If zero, the default MTU is used.
+	MTU int `yaml:"mtu,omitempty"`
+}
+
+// LoadDescription loads the description from a .topo file.
+func LoadDescription(file string) (*Description, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("reading topology description", err, "file", file)
+	}
+	return ParseDescription(raw)
+}
+
+// ParseDescription parses the description in the .topo format.
+func ParseDescription(raw []byte) (*Description, error) {
+	var d Description
+	if err := yaml.Unmarshal(raw, &d); err != nil {
+		return nil, serrors.WrapStr("parsing topology description", err)
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Validate checks that all links connect ASes of the description and have a
+// known type.
+func (d *Description) Validate() error {
+	if len(d.ASes) == 0 {
+		return serrors.New("no ASes")
+	}
+	for _, l := range d.Links {
+		for _, raw := range []string{l.A, l.B} {
+			ep, err := parseEndpoint(raw)
+			if err != nil {
+				return err
+			}
+			if _, ok := d.ASes[ep.IA]; !ok {
+				return serrors.New("link endpoint in unknown AS", "endpoint", raw)
+			}
+		}
+		switch strings.ToUpper(l.LinkAtoB) {
+		case "CHILD", "CORE", "PEER":
+		default:
+			return serrors.New("unknown link type", "type", l.LinkAtoB)
+		}
+	}
+	return nil
+}
+
+// endpoint is the parsed endpoint of a link.
+type endpoint struct {
+	IA addr.IA
+	// BR is the name of the border router. It is empty if the endpoint is
+	// served by a dedicated border router.
+	BR   string
+	IFID common.IFIDType
+}
+
+func parseEndpoint(raw string) (endpoint, error) {
+	var ep endpoint
+	ia := raw
+	if i := strings.Index(raw, "#"); i >= 0 {
+		ifid, err := strconv.ParseUint(raw[i+1:], 10, 16)
+		if err != nil || ifid == 0 {
+			return endpoint{}, serrors.New("invalid interface ID", "endpoint", raw)
+		}
+		ep.IFID, ia = common.IFIDType(ifid), raw[:i]
+	}
+	// The ISD-AS contains exactly one dash, a further one separates the BR
+	// name.
+	if parts := strings.SplitN(ia, "-", 3); len(parts) == 3 {
+		ia, ep.BR = parts[0]+"-"+parts[1], parts[2]
+	}
+	var err error
+	if ep.IA, err = addr.ParseIA(ia); err != nil {
+		return endpoint{}, serrors.WrapStr("parsing endpoint", err, "endpoint", raw)
+	}
+	return ep, nil
+}
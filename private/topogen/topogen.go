@@ -0,0 +1,434 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package topogen generates the configuration of test topologies from a
+// compact description. It is the Go counterpart of the python topology
+// generator and allows integration tests to construct topologies without
+// invoking the python tooling.
+//
+// For every AS of the description, the following files are written to the
+// AS directory (e.g., ASff00_0_110) in the output directory:
+//
+//   - topology.json
+//   - the TOML configuration of the control service, the border routers and
+//     the daemon
+//   - the master keys, the AS certificates and keys, and the TRCs of all ISDs
+package topogen
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/private/app/command"
+	jsontopo "github.com/scionproto/scion/private/topology/json"
+	"github.com/scionproto/scion/scion-pki/testcrypto"
+)
+
+const (
+	// DefaultBasePort is the first port that is assigned to the services.
+	DefaultBasePort = 31000
+	// DefaultMTU is the MTU of ASes and links that do not specify one.
+	DefaultMTU = 1472
+)
+
+// Config configures the generation of a topology.
+type Config struct {
+	// Out is the output directory.
+	Out string
+	// IP is the address all services listen on. If nil, 127.0.0.1 is used.
+	IP net.IP
+	// BasePort is the first port that is assigned. Ports are assigned
+	// sequentially. If zero, DefaultBasePort is used.
+	BasePort int
+	// DefaultMTU is the MTU of ASes and links that do not specify one. If zero,
+	// DefaultMTU is used.
+	DefaultMTU int
+	// ASValidity is the validity period of the AS certificates. If zero, the
+	// testcrypto default is used.
+	ASValidity time.Duration
+}
+
+func (cfg *Config) initDefaults() {
+	if cfg.IP == nil {
+		cfg.IP = net.IPv4(127, 0, 0, 1)
+	}
+	if cfg.BasePort == 0 {
+		cfg.BasePort = DefaultBasePort
+	}
+	if cfg.DefaultMTU == 0 {
+		cfg.DefaultMTU = DefaultMTU
+	}
+}
+
+// Generate generates the topology described by desc into the output
+// directory. The generated AS topologies are returned.
+func Generate(desc *Description, cfg Config) (map[addr.IA]*jsontopo.Topology, error) {
+	if err := desc.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.initDefaults()
+	g := generator{
+		desc:  desc,
+		cfg:   cfg,
+		port:  cfg.BasePort,
+		topos: make(map[addr.IA]*jsontopo.Topology, len(desc.ASes)),
+	}
+	if err := g.genTopos(); err != nil {
+		return nil, err
+	}
+	for _, ia := range g.sortedIAs() {
+		if err := g.writeAS(ia); err != nil {
+			return nil, serrors.WithCtx(err, "isd_as", ia)
+		}
+	}
+	if err := g.genCrypto(); err != nil {
+		return nil, err
+	}
+	return g.topos, nil
+}
+
+// ASDir returns the directory of the AS within the output directory.
+func ASDir(out string, ia addr.IA) string {
+	return filepath.Join(out,
+		addr.FormatAS(ia.AS(), addr.WithDefaultPrefix(), addr.WithFileSeparator()))
+}
+
+// CSName returns the name of the control service of the AS.
+func CSName(ia addr.IA) string {
+	return "cs" + fileFmt(ia) + "-1"
+}
+
+// SDName returns the name of the daemon of the AS.
+func SDName(ia addr.IA) string {
+	return "sd" + fileFmt(ia)
+}
+
+type generator struct {
+	desc  *Description
+	cfg   Config
+	port  int
+	topos map[addr.IA]*jsontopo.Topology
+	sd    map[addr.IA]string
+}
+
+func (g *generator) sortedIAs() []addr.IA {
+	ias := make([]addr.IA, 0, len(g.desc.ASes))
+	for ia := range g.desc.ASes {
+		ias = append(ias, ia)
+	}
+	sort.Slice(ias, func(i, j int) bool { return ias[i] < ias[j] })
+	return ias
+}
+
+func (g *generator) nextAddr() string {
+	a := &net.UDPAddr{IP: g.cfg.IP, Port: g.port}
+	g.port++
+	return a.String()
+}
+
+func (g *generator) genTopos() error {
+	g.sd = make(map[addr.IA]string, len(g.desc.ASes))
+	for _, ia := range g.sortedIAs() {
+		as := g.desc.ASes[ia]
+		topo := &jsontopo.Topology{
+			IA:            ia.String(),
+			MTU:           g.mtu(as.MTU),
+			BorderRouters: make(map[string]*jsontopo.BRInfo),
+			ControlService: map[string]*jsontopo.ServerInfo{
+				CSName(ia): {Addr: g.nextAddr()},
+			},
+		}
+		topo.DiscoveryService = map[string]*jsontopo.ServerInfo{
+			CSName(ia): {Addr: topo.ControlService[CSName(ia)].Addr},
+		}
+		if as.Core {
+			topo.Attributes = append(topo.Attributes, jsontopo.AttrCore)
+		}
+		g.topos[ia] = topo
+		g.sd[ia] = g.nextAddr()
+	}
+
+	// brIDs and namedBRs track the border router IDs per AS, ifIDs the
+	// assigned interface IDs.
+	brIDs := make(map[addr.IA]int)
+	namedBRs := make(map[string]string)
+	ifIDs := make(map[addr.IA]map[common.IFIDType]struct{})
+	for ia := range g.desc.ASes {
+		ifIDs[ia] = make(map[common.IFIDType]struct{})
+	}
+	// Explicit interface IDs are reserved first, such that automatically
+	// assigned ones do not clash with them.
+	eps := make([][2]endpoint, 0, len(g.desc.Links))
+	for _, l := range g.desc.Links {
+		var pair [2]endpoint
+		for i, raw := range []string{l.A, l.B} {
+			ep, err := parseEndpoint(raw)
+			if err != nil {
+				return err
+			}
+			if ep.IFID != 0 {
+				if _, ok := ifIDs[ep.IA][ep.IFID]; ok {
+					return serrors.New("duplicate interface ID", "endpoint", raw)
+				}
+				ifIDs[ep.IA][ep.IFID] = struct{}{}
+			}
+			pair[i] = ep
+		}
+		eps = append(eps, pair)
+	}
+
+	for i, l := range g.desc.Links {
+		var brs [2]*jsontopo.BRInfo
+		var public [2]string
+		for j := range eps[i] {
+			ep := &eps[i][j]
+			if ep.IFID == 0 {
+				ep.IFID = nextIFID(ifIDs[ep.IA])
+			}
+			name := ""
+			if ep.BR != "" {
+				name = namedBRs[ep.IA.String()+"-"+ep.BR]
+			}
+			if name == "" {
+				brIDs[ep.IA]++
+				name = fmt.Sprintf("br%s-%d", fileFmt(ep.IA), brIDs[ep.IA])
+				if ep.BR != "" {
+					namedBRs[ep.IA.String()+"-"+ep.BR] = name
+				}
+			}
+			topo := g.topos[ep.IA]
+			br, ok := topo.BorderRouters[name]
+			if !ok {
+				br = &jsontopo.BRInfo{
+					InternalAddr: g.nextAddr(),
+					Interfaces:   make(map[common.IFIDType]*jsontopo.BRInterface),
+				}
+				topo.BorderRouters[name] = br
+			}
+			brs[j], public[j] = br, g.nextAddr()
+		}
+		a, b := eps[i][0], eps[i][1]
+		linkToA, linkToB := linkTypes(l.LinkAtoB)
+		brs[0].Interfaces[a.IFID] = g.brInterface(b, public[0], public[1], linkToA, l.MTU)
+		brs[1].Interfaces[b.IFID] = g.brInterface(a, public[1], public[0], linkToB, l.MTU)
+	}
+	return nil
+}
+
+// linkTypes returns the link_to values of the interfaces in A and in B.
+func linkTypes(linkAtoB string) (string, string) {
+	switch linkAtoB = strings.ToLower(linkAtoB); linkAtoB {
+	case "child":
+		return "child", "parent"
+	default:
+		return linkAtoB, linkAtoB
+	}
+}
+
+func (g *generator) brInterface(remote endpoint, public, remoteAddr, linkTo string,
+	mtu int) *jsontopo.BRInterface {
+
+	intf := &jsontopo.BRInterface{
+		Underlay: jsontopo.Underlay{
+			Public: public,
+			Remote: remoteAddr,
+		},
+		IA:     remote.IA.String(),
+		LinkTo: linkTo,
+		MTU:    g.mtu(mtu),
+	}
+	if linkTo == "peer" {
+		intf.RemoteIFID = remote.IFID
+	}
+	return intf
+}
+
+func (g *generator) mtu(mtu int) int {
+	if mtu == 0 {
+		return g.cfg.DefaultMTU
+	}
+	return mtu
+}
+
+func nextIFID(used map[common.IFIDType]struct{}) common.IFIDType {
+	ifid := common.IFIDType(1)
+	for ; ; ifid++ {
+		if _, ok := used[ifid]; !ok {
+			break
+		}
+	}
+	used[ifid] = struct{}{}
+	return ifid
+}
+
+func (g *generator) writeAS(ia addr.IA) error {
+	dir := ASDir(g.cfg.Out, ia)
+	if err := os.MkdirAll(filepath.Join(dir, "keys"), 0755); err != nil {
+		return serrors.WrapStr("creating AS directory", err)
+	}
+	topo := g.topos[ia]
+	raw, err := json.MarshalIndent(topo, "", "    ")
+	if err != nil {
+		return serrors.WrapStr("encoding topology", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "topology.json"), raw, 0644); err != nil {
+		return serrors.WrapStr("writing topology", err)
+	}
+
+	cacheDir := filepath.Join(g.cfg.Out, "gen-cache")
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return serrors.WrapStr("creating cache directory", err)
+	}
+	cs := CSName(ia)
+	csCfg := map[string]interface{}{
+		"general":   generalEntry(cs, dir),
+		"log":       logEntry(),
+		"trust_db":  dbEntry(cacheDir, cs, "trust"),
+		"beacon_db": dbEntry(cacheDir, cs, "beacon"),
+		"path_db":   dbEntry(cacheDir, cs, "path"),
+	}
+	if g.desc.ASes[ia].Issuing {
+		csCfg["ca"] = map[string]interface{}{"mode": "in-process"}
+	}
+	if err := writeTOML(filepath.Join(dir, cs+".toml"), csCfg); err != nil {
+		return err
+	}
+	for name := range topo.BorderRouters {
+		brCfg := map[string]interface{}{
+			"general": generalEntry(name, dir),
+			"log":     logEntry(),
+		}
+		if err := writeTOML(filepath.Join(dir, name+".toml"), brCfg); err != nil {
+			return err
+		}
+	}
+	sd := SDName(ia)
+	sdCfg := map[string]interface{}{
+		"general":  generalEntry(sd, dir),
+		"log":      logEntry(),
+		"trust_db": dbEntry(cacheDir, sd, "trust"),
+		"path_db":  dbEntry(cacheDir, sd, "path"),
+		"sd":       map[string]interface{}{"address": g.sd[ia]},
+	}
+	if err := writeTOML(filepath.Join(dir, "sd.toml"), sdCfg); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"master0.key", "master1.key"} {
+		key := make([]byte, 16)
+		if _, err := rand.Read(key); err != nil {
+			return serrors.WrapStr("generating master key", err)
+		}
+		file := filepath.Join(dir, "keys", name)
+		encoded := []byte(base64.StdEncoding.EncodeToString(key))
+		if err := os.WriteFile(file, encoded, 0600); err != nil {
+			return serrors.WrapStr("writing master key", err, "file", file)
+		}
+	}
+	return nil
+}
+
+func generalEntry(id, configDir string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         id,
+		"config_dir": configDir,
+	}
+}
+
+func logEntry() map[string]interface{} {
+	return map[string]interface{}{
+		"console": map[string]interface{}{"level": "debug"},
+	}
+}
+
+func dbEntry(dir, name, db string) map[string]interface{} {
+	return map[string]interface{}{
+		"connection": filepath.Join(dir, fmt.Sprintf("%s.%s.db", name, db)),
+	}
+}
+
+func writeTOML(file string, cfg map[string]interface{}) error {
+	tree, err := toml.TreeFromMap(cfg)
+	if err != nil {
+		return serrors.WrapStr("encoding configuration", err, "file", file)
+	}
+	if err := os.WriteFile(file, []byte(tree.String()), 0644); err != nil {
+		return serrors.WrapStr("writing configuration", err, "file", file)
+	}
+	return nil
+}
+
+// genCrypto generates the keys, certificates and TRCs with the testcrypto
+// command of scion-pki, and copies the TRCs to the certs directory of every
+// AS.
+func (g *generator) genCrypto() error {
+	raw, err := yaml.Marshal(g.desc)
+	if err != nil {
+		return serrors.WrapStr("encoding topology description", err)
+	}
+	topoFile := filepath.Join(g.cfg.Out, "topology.topo")
+	if err := os.WriteFile(topoFile, raw, 0644); err != nil {
+		return serrors.WrapStr("writing topology description", err)
+	}
+	args := []string{"-t", topoFile, "-o", g.cfg.Out}
+	if g.cfg.ASValidity != 0 {
+		args = append(args, "--as-validity", util.FmtDuration(g.cfg.ASValidity))
+	}
+	var buf bytes.Buffer
+	cmd := testcrypto.Cmd(command.StringPather(""))
+	cmd.SetArgs(args)
+	cmd.SetOutput(&buf)
+	if err := cmd.Execute(); err != nil {
+		return serrors.WrapStr("generating crypto material", err, "output", buf.String())
+	}
+
+	trcs, err := filepath.Glob(filepath.Join(g.cfg.Out, "ISD*", "trcs", "*.trc"))
+	if err != nil {
+		return serrors.WrapStr("listing TRCs", err)
+	}
+	for ia := range g.desc.ASes {
+		certs := filepath.Join(ASDir(g.cfg.Out, ia), "certs")
+		for _, trc := range trcs {
+			raw, err := os.ReadFile(trc)
+			if err != nil {
+				return serrors.WrapStr("reading TRC", err, "file", trc)
+			}
+			dst := filepath.Join(certs, filepath.Base(trc))
+			if err := os.WriteFile(dst, raw, 0644); err != nil {
+				return serrors.WrapStr("writing TRC", err, "file", dst)
+			}
+		}
+	}
+	return nil
+}
+
+func fileFmt(ia addr.IA) string {
+	return strings.ReplaceAll(ia.String(), ":", "_")
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topogen_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlconfig "github.com/scionproto/scion/control/config"
+	daemonconfig "github.com/scionproto/scion/daemon/config"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/private/config"
+	"github.com/scionproto/scion/private/topogen"
+	"github.com/scionproto/scion/private/topology"
+	routerconfig "github.com/scionproto/scion/router/config"
+)
+
+func TestParseDescription(t *testing.T) {
+	testCases := map[string]struct {
+		Input        string
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Input: `
+ASes:
+  "1-ff00:0:110": {core: true}
+links:
+  - {a: "1-ff00:0:110#1", b: "1-ff00:0:110#2", linkAtoB: CORE}
+`,
+			ErrAssertion: assert.NoError,
+		},
+		"no ASes": {
+			Input:        `links: []`,
+			ErrAssertion: assert.Error,
+		},
+		"unknown AS": {
+			Input: `
+ASes:
+  "1-ff00:0:110": {core: true}
+links:
+  - {a: "1-ff00:0:110#1", b: "1-ff00:0:111#2", linkAtoB: CORE}
+`,
+			ErrAssertion: assert.Error,
+		},
+		"invalid interface": {
+			Input: `
+ASes:
+  "1-ff00:0:110": {core: true}
+links:
+  - {a: "1-ff00:0:110#0", b: "1-ff00:0:110#2", linkAtoB: CORE}
+`,
+			ErrAssertion: assert.Error,
+		},
+		"unknown link type": {
+			Input: `
+ASes:
+  "1-ff00:0:110": {core: true}
+links:
+  - {a: "1-ff00:0:110#1", b: "1-ff00:0:110#2", linkAtoB: SIBLING}
+`,
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := topogen.ParseDescription([]byte(tc.Input))
+			tc.ErrAssertion(t, err)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	desc, err := topogen.LoadDescription("testdata/tiny.topo")
+	require.NoError(t, err)
+	out := t.TempDir()
+	topos, err := topogen.Generate(desc, topogen.Config{Out: out})
+	require.NoError(t, err)
+	require.Len(t, topos, 3)
+
+	core, leaf111 := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:111")
+	for ia := range desc.ASes {
+		dir := topogen.ASDir(out, ia)
+		topo, err := topology.RWTopologyFromJSONFile(filepath.Join(dir, "topology.json"))
+		require.NoError(t, err, ia)
+		assert.Equal(t, ia, topo.IA)
+
+		var cs controlconfig.Config
+		require.NoError(t, config.LoadFile(
+			filepath.Join(dir, topogen.CSName(ia)+".toml"), &cs))
+		assert.Equal(t, dir, cs.General.ConfigDir)
+		assert.Equal(t, ia == core, cs.CA.Mode == controlconfig.InProcess)
+		var sd daemonconfig.Config
+		require.NoError(t, config.LoadFile(filepath.Join(dir, "sd.toml"), &sd))
+		assert.Equal(t, topogen.SDName(ia), sd.General.ID)
+		for name := range topo.BR {
+			var br routerconfig.Config
+			require.NoError(t, config.LoadFile(filepath.Join(dir, name+".toml"), &br))
+			assert.Equal(t, name, br.General.ID)
+		}
+
+		for _, file := range []string{
+			"keys/master0.key",
+			"keys/master1.key",
+			"certs/ISD1-B1-S1.trc",
+			"crypto/as/cp-as.key",
+		} {
+			_, err := os.Stat(filepath.Join(dir, file))
+			assert.NoError(t, err, file)
+		}
+	}
+
+	// The named BR A serves both links of the core AS.
+	coreTopo := topos[core]
+	assert.Equal(t, 1400, coreTopo.MTU)
+	require.Len(t, coreTopo.BorderRouters, 1)
+	br := coreTopo.BorderRouters["br1-ff00_0_110-1"]
+	require.NotNil(t, br)
+	require.Len(t, br.Interfaces, 2)
+	assert.Equal(t, "child", br.Interfaces[1].LinkTo)
+	assert.Equal(t, 1280, br.Interfaces[1].MTU)
+	assert.Equal(t, topogen.DefaultMTU, br.Interfaces[2].MTU)
+
+	// The leaf AS has a BR per link, the interface of the peering link is
+	// assigned automatically.
+	leafTopo := topos[leaf111]
+	require.Len(t, leafTopo.BorderRouters, 2)
+	parent := leafTopo.BorderRouters["br1-ff00_0_111-1"].Interfaces[41]
+	require.NotNil(t, parent)
+	assert.Equal(t, "parent", parent.LinkTo)
+	assert.Equal(t, br.Interfaces[1].Underlay.Public, parent.Underlay.Remote)
+	assert.Equal(t, br.Interfaces[1].Underlay.Remote, parent.Underlay.Public)
+	peer := leafTopo.BorderRouters["br1-ff00_0_111-2"].Interfaces[1]
+	require.NotNil(t, peer)
+	assert.Equal(t, "peer", peer.LinkTo)
+	assert.Equal(t, common.IFIDType(2), peer.RemoteIFID)
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/scionproto/scion/pkg/private/common"
+)
+
+// Diff describes the changes between two topologies.
+type Diff struct {
+	AddedInterfaces   []common.IFIDType
+	RemovedInterfaces []common.IFIDType
+	ChangedInterfaces []common.IFIDType
+	// AddedServices, RemovedServices and ChangedServices contain the service
+	// entries in the form "<service type>/<id>", e.g. "control/cs1-ff00_0_110-1".
+	// Border routers are listed with the "router" service type.
+	AddedServices   []string
+	RemovedServices []string
+	ChangedServices []string
+}
+
+// ComputeDiff computes the changes from the old to the new topology. A nil old
+// topology is treated as an empty topology.
+func ComputeDiff(new, old *RWTopology) Diff {
+	if old == nil {
+		old = NewRWTopology()
+	}
+	var d Diff
+	for id, info := range new.IFInfoMap {
+		oldInfo, ok := old.IFInfoMap[id]
+		switch {
+		case !ok:
+			d.AddedInterfaces = append(d.AddedInterfaces, id)
+		case !reflect.DeepEqual(info, oldInfo):
+			d.ChangedInterfaces = append(d.ChangedInterfaces, id)
+		}
+	}
+	for id := range old.IFInfoMap {
+		if _, ok := new.IFInfoMap[id]; !ok {
+			d.RemovedInterfaces = append(d.RemovedInterfaces, id)
+		}
+	}
+	d.diffServices(Router, routerAddrs(new.BR), routerAddrs(old.BR))
+	d.diffServices(Control, svcAddrs(new.CS), svcAddrs(old.CS))
+	d.diffServices(Discovery, svcAddrs(new.DS), svcAddrs(old.DS))
+	d.diffServices(Gateway, gatewayAddrs(new.SIG), gatewayAddrs(old.SIG))
+	d.diffServices(HiddenSegmentLookup, svcAddrs(new.HiddenSegmentLookup),
+		svcAddrs(old.HiddenSegmentLookup))
+	d.diffServices(HiddenSegmentRegistration, svcAddrs(new.HiddenSegmentRegistration),
+		svcAddrs(old.HiddenSegmentRegistration))
+
+	for _, ids := range [][]common.IFIDType{
+		d.AddedInterfaces, d.RemovedInterfaces, d.ChangedInterfaces,
+	} {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+	sort.Strings(d.AddedServices)
+	sort.Strings(d.RemovedServices)
+	sort.Strings(d.ChangedServices)
+	return d
+}
+
+// Empty returns whether the diff contains no changes.
+func (d Diff) Empty() bool {
+	return len(d.AddedInterfaces) == 0 && len(d.RemovedInterfaces) == 0 &&
+		len(d.ChangedInterfaces) == 0 && len(d.AddedServices) == 0 &&
+		len(d.RemovedServices) == 0 && len(d.ChangedServices) == 0
+}
+
+// LogCtx returns the diff as key-value pairs suitable for structured logging.
+// Empty categories are omitted.
+func (d Diff) LogCtx() []interface{} {
+	var ctx []interface{}
+	add := func(key string, n int, v interface{}) {
+		if n > 0 {
+			ctx = append(ctx, key, v)
+		}
+	}
+	add("added_interfaces", len(d.AddedInterfaces), d.AddedInterfaces)
+	add("removed_interfaces", len(d.RemovedInterfaces), d.RemovedInterfaces)
+	add("changed_interfaces", len(d.ChangedInterfaces), d.ChangedInterfaces)
+	add("added_services", len(d.AddedServices), d.AddedServices)
+	add("removed_services", len(d.RemovedServices), d.RemovedServices)
+	add("changed_services", len(d.ChangedServices), d.ChangedServices)
+	return ctx
+}
+
+func (d *Diff) diffServices(svc ServiceType, new, old map[string]interface{}) {
+	for id, v := range new {
+		oldV, ok := old[id]
+		switch {
+		case !ok:
+			d.AddedServices = append(d.AddedServices, svc.String()+"/"+id)
+		case !reflect.DeepEqual(v, oldV):
+			d.ChangedServices = append(d.ChangedServices, svc.String()+"/"+id)
+		}
+	}
+	for id := range old {
+		if _, ok := new[id]; !ok {
+			d.RemovedServices = append(d.RemovedServices, svc.String()+"/"+id)
+		}
+	}
+}
+
+func svcAddrs(m IDAddrMap) map[string]interface{} {
+	r := make(map[string]interface{}, len(m))
+	for id, a := range m {
+		r[id] = a
+	}
+	return r
+}
+
+// routerAddrs only considers the internal address of the routers, changes to
+// their interfaces are reported as interface changes.
+func routerAddrs(m map[string]BRInfo) map[string]interface{} {
+	r := make(map[string]interface{}, len(m))
+	for id, br := range m {
+		r[id] = br.InternalAddr
+	}
+	return r
+}
+
+func gatewayAddrs(m map[string]GatewayInfo) map[string]interface{} {
+	r := make(map[string]interface{}, len(m))
+	for id, gw := range m {
+		r[id] = gw
+	}
+	return r
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/private/topology"
+)
+
+func TestComputeDiff(t *testing.T) {
+	t.Run("no changes", func(t *testing.T) {
+		d := topology.ComputeDiff(defaultTopo(t), defaultTopo(t))
+		assert.True(t, d.Empty())
+		assert.Empty(t, d.LogCtx())
+	})
+	t.Run("initial load", func(t *testing.T) {
+		d := topology.ComputeDiff(defaultTopo(t), nil)
+		assert.Equal(t, []common.IFIDType{1, 3, 8, 11}, d.AddedInterfaces)
+		assert.Contains(t, d.AddedServices, "control/cs1-ff00:0:311-2")
+		assert.Contains(t, d.AddedServices, "router/br1-ff00:0:311-1")
+	})
+	t.Run("changes", func(t *testing.T) {
+		old := defaultTopo(t)
+		new := defaultTopo(t)
+		delete(new.IFInfoMap, 3)
+		intf := new.IFInfoMap[8]
+		intf.MTU = 1400
+		new.IFInfoMap[8] = intf
+		intf.ID = 42
+		new.IFInfoMap[42] = intf
+		delete(new.CS, "cs1-ff00:0:311-2")
+		cs := new.CS["cs1-ff00:0:311-3"]
+		cs.SCIONAddress = &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 30252}
+		new.CS["cs1-ff00:0:311-3"] = cs
+
+		d := topology.ComputeDiff(new, old)
+		assert.Equal(t, topology.Diff{
+			AddedInterfaces:   []common.IFIDType{42},
+			RemovedInterfaces: []common.IFIDType{3},
+			ChangedInterfaces: []common.IFIDType{8},
+			RemovedServices:   []string{"control/cs1-ff00:0:311-2"},
+			ChangedServices:   []string{"control/cs1-ff00:0:311-3"},
+		}, d)
+		assert.Equal(t, []interface{}{
+			"added_interfaces", []common.IFIDType{42},
+			"removed_interfaces", []common.IFIDType{3},
+			"changed_interfaces", []common.IFIDType{8},
+			"removed_services", []string{"control/cs1-ff00:0:311-2"},
+			"changed_services", []string{"control/cs1-ff00:0:311-3"},
+		}, d.LogCtx())
+	})
+}
@@ -18,6 +18,7 @@ package topology
 var (
 	RawAddrToTopoAddr   = rawAddrToTopoAddr
 	RawBRIntfTopoBRAddr = rawBRIntfTopoBRAddr
+	RawBRIntfRemoteAddr = rawBRIntfRemoteAddr
 )
 
 // SetFile allows to change the file for testing. This is helpful because we
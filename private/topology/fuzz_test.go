@@ -0,0 +1,62 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scionproto/scion/private/topology"
+)
+
+// To fuzz, run:
+//
+//	go test -run '^$' -fuzz FuzzRWTopologyFromJSONBytes ./private/topology
+
+func FuzzRWTopologyFromJSONBytes(f *testing.F) {
+	files, err := filepath.Glob("testdata/*.json")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(raw)
+	}
+	// Host names in the fuzzed addresses must not be resolved over the network.
+	resolver := net.DefaultResolver
+	net.DefaultResolver = &net.Resolver{
+		PreferGo: true,
+		Dial: func(context.Context, string, string) (net.Conn, error) {
+			return nil, errors.New("DNS is disabled while fuzzing")
+		},
+	}
+	f.Cleanup(func() { net.DefaultResolver = resolver })
+	f.Fuzz(func(t *testing.T, data []byte) {
+		topo, err := topology.RWTopologyFromJSONBytes(data)
+		if err != nil {
+			return
+		}
+		// A successfully loaded topology must be usable.
+		topology.FromRWTopology(topo).InterfaceIDs()
+		topo.Copy()
+	})
+}
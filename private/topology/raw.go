@@ -54,6 +54,25 @@ func rawBRIntfTopoBRAddr(i *jsontopo.BRInterface) (*net.UDPAddr, error) {
 	return resolveToUDPAddr(rawIP, port)
 }
 
+// rawBRIntfRemoteAddr returns the remote underlay address of the interface.
+// It must be of the same IP family as the local address. If the remote address
+// is an IPv6 link-local address without zone, the zone of the local address is
+// used.
+func rawBRIntfRemoteAddr(i *jsontopo.BRInterface, local *net.UDPAddr) (*net.UDPAddr, error) {
+	remote, err := rawAddrToUDPAddr(i.Underlay.Remote)
+	if err != nil {
+		return nil, err
+	}
+	if (local.IP.To4() == nil) != (remote.IP.To4() == nil) {
+		return nil, serrors.New("local and remote address family mismatch",
+			"local", local, "remote", remote)
+	}
+	if remote.Zone == "" && remote.IP.IsLinkLocalUnicast() && remote.IP.To4() == nil {
+		remote.Zone = local.Zone
+	}
+	return remote, nil
+}
+
 func splitHostPort(rawAddr string) (string, int, error) {
 	rh, rp, err := net.SplitHostPort(rawAddr)
 	if err != nil {
@@ -83,7 +83,7 @@ func NewLoader(cfg LoaderCfg) (*Loader, error) {
 		cfg:         cfg,
 		subscribers: make(map[*Subscription]chan struct{}),
 	}
-	if err := l.reload(); err != nil {
+	if _, err := l.reload(); err != nil {
 		return nil, err
 	}
 	return l, nil
@@ -96,11 +96,15 @@ func (l *Loader) Run(ctx context.Context) error {
 	for {
 		select {
 		case <-l.cfg.Reload:
-			if err := l.reload(); err != nil {
+			diff, err := l.reload()
+			switch {
+			case err != nil:
 				log.FromCtx(ctx).Error("Failed to reload topology file",
 					"file", l.cfg.File, "err", err)
-			} else {
-				log.FromCtx(ctx).Info("Reloaded topology")
+			case diff.Empty():
+				log.FromCtx(ctx).Info("Reloaded topology, no changes")
+			default:
+				log.FromCtx(ctx).Info("Reloaded topology", diff.LogCtx()...)
 			}
 		case <-ctx.Done():
 			return nil
@@ -160,6 +164,15 @@ func (l *Loader) ControlServiceAddresses() []*net.UDPAddr {
 	return addrs
 }
 
+// ControlServiceNames returns the names of all control service instances in
+// the topology.
+func (l *Loader) ControlServiceNames() []string {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	return l.topo.SVCNames(addr.SvcCS)
+}
+
 func (l *Loader) ControlServiceAddress(id string) *net.UDPAddr {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
@@ -214,6 +227,15 @@ func (l *Loader) GetUnderlay(svc addr.SVC) (*net.UDPAddr, error) {
 	return l.topo.UnderlayAnycast(svc)
 }
 
+// UnderlayMulticast returns the underlay addresses of all servers of the
+// given SVC type.
+func (l *Loader) UnderlayMulticast(svc addr.SVC) ([]*net.UDPAddr, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	return l.topo.UnderlayMulticast(svc)
+}
+
 // Get gets the instance of the topology.
 //
 // Deprecated: New code should use accessor methods instead.
@@ -275,11 +297,13 @@ func (l *Loader) notifyAllLocked() {
 	}
 }
 
-func (l *Loader) reload() error {
+// reload loads and validates the topology file and returns the changes with
+// respect to the previously loaded topology.
+func (l *Loader) reload() (Diff, error) {
 	newTopo, err := l.load()
 	if err != nil {
 		metrics.CounterInc(l.cfg.Metrics.ReadErrors)
-		return serrors.WrapStr("loading topology", err)
+		return Diff{}, serrors.WrapStr("loading topology", err)
 	}
 
 	l.mtx.Lock()
@@ -292,14 +316,15 @@ func (l *Loader) reload() error {
 
 	if err := l.validate(newTopo.Writable(), old); err != nil {
 		metrics.CounterInc(l.cfg.Metrics.ValidationErrors)
-		return serrors.WrapStr("validating update", err)
+		return Diff{}, serrors.WrapStr("validating update", err)
 	}
+	diff := ComputeDiff(newTopo.Writable(), old)
 	l.topo = newTopo
 	metrics.CounterInc(l.cfg.Metrics.Updates)
 	metrics.GaugeSetCurrentTime(l.cfg.Metrics.LastUpdate)
 
 	l.notifyAllLocked()
-	return nil
+	return diff, nil
 }
 
 func (l *Loader) load() (Topology, error) {
@@ -270,12 +270,14 @@ func (t *RWTopology) populateBR(raw *jsontopo.Topology) error {
 				return serrors.WrapStr("unable to extract "+
 					"underlay external data-plane local address", err)
 			}
-			if ifinfo.Remote, err = rawAddrToUDPAddr(rawIntf.Underlay.Remote); err != nil {
+			if ifinfo.Remote, err = rawBRIntfRemoteAddr(rawIntf, ifinfo.Local); err != nil {
 				return serrors.WrapStr("unable to extract "+
 					"underlay external data-plane remote address", err)
 			}
+			// The address family is chosen per link, independently of the
+			// other links and the internal address of the router.
 			ifinfo.Underlay = underlay.UDPIPv6
-			if ifinfo.Local.IP.To4() != nil && ifinfo.Remote.IP.To4() != nil {
+			if ifinfo.Local.IP.To4() != nil {
 				ifinfo.Underlay = underlay.UDPIPv4
 			}
 			brInfo.IFs[ifid] = &ifinfo
@@ -488,6 +488,61 @@ func TestExternalDataPlanePort(t *testing.T) {
 	}
 }
 
+func TestExternalDataPlaneRemote(t *testing.T) {
+	testCases := map[string]struct {
+		Local           *net.UDPAddr
+		Remote          string
+		ExpectedAddress *net.UDPAddr
+		ExpectedError   assert.ErrorAssertionFunc
+	}{
+		"IPv4": {
+			Local:           &net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: 42},
+			Remote:          "192.0.2.2:42",
+			ExpectedAddress: &net.UDPAddr{IP: net.IP{192, 0, 2, 2}, Port: 42},
+			ExpectedError:   assert.NoError,
+		},
+		"IPv6": {
+			Local:           &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 42},
+			Remote:          "[2001:db8::2]:42",
+			ExpectedAddress: &net.UDPAddr{IP: net.ParseIP("2001:db8::2"), Port: 42},
+			ExpectedError:   assert.NoError,
+		},
+		"IPv6 link-local inherits zone": {
+			Local:  &net.UDPAddr{IP: net.ParseIP("fe80::1"), Port: 42, Zone: "eth0"},
+			Remote: "[fe80::2]:42",
+			ExpectedAddress: &net.UDPAddr{
+				IP:   net.ParseIP("fe80::2"),
+				Port: 42,
+				Zone: "eth0",
+			},
+			ExpectedError: assert.NoError,
+		},
+		"IPv6 link-local with zone": {
+			Local:  &net.UDPAddr{IP: net.ParseIP("fe80::1"), Port: 42, Zone: "eth0"},
+			Remote: "[fe80::2%eth1]:42",
+			ExpectedAddress: &net.UDPAddr{
+				IP:   net.ParseIP("fe80::2"),
+				Port: 42,
+				Zone: "eth1",
+			},
+			ExpectedError: assert.NoError,
+		},
+		"family mismatch": {
+			Local:         &net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: 42},
+			Remote:        "[2001:db8::2]:42",
+			ExpectedError: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			raw := &jsontopo.BRInterface{Underlay: jsontopo.Underlay{Remote: tc.Remote}}
+			remote, err := RawBRIntfRemoteAddr(raw, tc.Local)
+			tc.ExpectedError(t, err)
+			assert.Equal(t, tc.ExpectedAddress, remote)
+		})
+	}
+}
+
 func TestRawAddrMap_ToTopoAddr(t *testing.T) {
 	testCases := []struct {
 		name        string
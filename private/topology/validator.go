@@ -18,6 +18,7 @@ import (
 	"reflect"
 	"sync"
 
+	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/serrors"
 )
 
@@ -102,15 +103,15 @@ func (v *validatorWrap) Validate(new, old *RWTopology) error {
 }
 
 // generalValidator is used to validate updates if no specific element information
-// is set. It only checks that the topology is non-nil and the immutable fields are
-// not modified.
+// is set. It checks that the topology is non-nil, that no addresses overlap, and
+// that the immutable fields are not modified.
 type generalValidator struct{}
 
 func (v *generalValidator) General(topo *RWTopology) error {
 	if topo == nil {
 		return serrors.New("Topo must not be nil")
 	}
-	return nil
+	return validateOverlaps(topo)
 }
 
 func (v *generalValidator) Immutable(new, old *RWTopology) error {
@@ -200,3 +201,34 @@ func (v *routerValidator) Immutable(new, old *RWTopology) error {
 	}
 	return nil
 }
+
+// validateOverlaps checks that no two border routers share an internal address
+// and that no two interfaces share a local underlay address. Addresses without
+// a port are not checked.
+func validateOverlaps(topo *RWTopology) error {
+	internal := make(map[string]string, len(topo.BR))
+	for name, br := range topo.BR {
+		if br.InternalAddr == nil || br.InternalAddr.Port == 0 {
+			continue
+		}
+		a := br.InternalAddr.String()
+		if other, ok := internal[a]; ok {
+			return serrors.New("Border routers share internal address",
+				"address", a, "routers", []string{other, name})
+		}
+		internal[a] = name
+	}
+	local := make(map[string]common.IFIDType, len(topo.IFInfoMap))
+	for id, intf := range topo.IFInfoMap {
+		if intf.Local == nil || intf.Local.Port == 0 {
+			continue
+		}
+		a := intf.Local.String()
+		if other, ok := local[a]; ok {
+			return serrors.New("Interfaces share local underlay address",
+				"address", a, "interfaces", []common.IFIDType{other, id})
+		}
+		local[a] = id
+	}
+	return nil
+}
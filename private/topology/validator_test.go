@@ -64,6 +64,25 @@ func TestDefaultValidatorValidate(t *testing.T) {
 			}),
 			assertErr: assert.NoError,
 		},
+		"overlapping interface addresses": {
+			loadOld: defaultTopo,
+			loadNew: topoWithModification(t, func(topo *topology.RWTopology) {
+				intf := topo.IFInfoMap[8]
+				intf.Local = topo.IFInfoMap[1].Local
+				topo.IFInfoMap[8] = intf
+			}),
+			assertErr: assert.Error,
+		},
+		"overlapping internal addresses": {
+			loadOld: defaultTopo,
+			loadNew: topoWithModification(t, func(topo *topology.RWTopology) {
+				for name, br := range topo.BR {
+					br.InternalAddr = &net.UDPAddr{IP: net.ParseIP("10.1.0.1"), Port: 30042}
+					topo.BR[name] = br
+				}
+			}),
+			assertErr: assert.Error,
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
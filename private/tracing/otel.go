@@ -0,0 +1,45 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the OpenTelemetry tracer that records the
+// spans of the SCION services.
+const InstrumentationName = "github.com/scionproto/scion"
+
+// StartOTelSpan starts a new OpenTelemetry span with the global tracer
+// provider. The span is a child of the span in ctx, if any.
+func StartOTelSpan(ctx context.Context, name string,
+	attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// OTelError records the error on the span and sets its status to error. If err
+// is nil, the span is not modified.
+func OTelError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
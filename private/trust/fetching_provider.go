@@ -17,8 +17,10 @@ package trust
 import (
 	"context"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/opentracing/opentracing-go"
@@ -26,6 +28,7 @@ import (
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/pkg/scrypto"
@@ -34,6 +37,10 @@ import (
 	"github.com/scionproto/scion/private/trust/internal/metrics"
 )
 
+// DefaultTRCDiscoveryInterval is the default minimum interval between two
+// discoveries of TRC updates for the same ISD.
+const DefaultTRCDiscoveryInterval = time.Minute
+
 var (
 	errNotFound = serrors.New("not found")
 	errInactive = serrors.New("inactive")
@@ -54,6 +61,49 @@ type FetchingProvider struct {
 	Recurser Recurser
 	Fetcher  Fetcher
 	Router   Router
+	// Clock provides the current time against which the validity of TRCs and
+	// certificate chains is checked. If nil, the wall clock is used.
+	Clock clock.Clock
+	// TRCDiscovery, if not nil, limits how often TRC updates are discovered
+	// per ISD. If nil, the discovery is not limited.
+	TRCDiscovery *TRCDiscoveryLimiter
+}
+
+// TRCDiscoveryLimiter limits the discovery of TRC updates to one attempt per
+// ISD and interval. Discovering the updates requires requests to remote
+// servers, and it is triggered by every chain request while the latest TRC is
+// inactive or fetched chains cannot be verified. An attempt that found no
+// update is thus cached negatively until the interval passed. The zero value
+// is ready to use.
+type TRCDiscoveryLimiter struct {
+	// Interval is the minimum interval between two attempts for the same ISD.
+	// If zero, DefaultTRCDiscoveryInterval is used.
+	Interval time.Duration
+
+	mtx  sync.Mutex
+	next map[addr.ISD]time.Time
+}
+
+// reserve returns whether TRC updates of the ISD may be discovered now. If so,
+// further attempts are rejected until the interval passed.
+func (l *TRCDiscoveryLimiter) reserve(isd addr.ISD, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if now.Before(l.next[isd]) {
+		return false
+	}
+	if l.next == nil {
+		l.next = make(map[addr.ISD]time.Time)
+	}
+	interval := l.Interval
+	if interval == 0 {
+		interval = DefaultTRCDiscoveryInterval
+	}
+	l.next[isd] = now.Add(interval)
+	return true
 }
 
 // GetChains returns certificate chains that match the chain query. If no chain
@@ -92,7 +142,7 @@ func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 		return nil, serrors.New("ISD-AS must not contain a wildcard", "isd_as", query.IA)
 	}
 	if query.Date.IsZero() {
-		query.Date = time.Now()
+		query.Date = clock.Now(p.Clock)
 		logger.Debug("Set date for chain request with zero time")
 	}
 
@@ -109,7 +159,19 @@ func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 		return chains, nil
 	}
 
-	trcs, result, err := activeTRCs(ctx, p.DB, query.IA.ISD())
+	trcs, result, err := activeTRCs(ctx, p.DB, query.IA.ISD(), clock.Now(p.Clock))
+	if errors.Is(err, errInactive) {
+		// The latest TRC in the database is no longer active. Resolve the TRC
+		// updates that were issued in the meantime.
+		updated, discoverErr := p.discoverTRCUpdates(ctx, query.IA.ISD(), &o)
+		if discoverErr != nil {
+			logger.Info("Failed to discover TRC updates",
+				"isd", query.IA.ISD(), "err", discoverErr)
+		}
+		if updated {
+			trcs, result, err = activeTRCs(ctx, p.DB, query.IA.ISD(), clock.Now(p.Clock))
+		}
+	}
 	if err != nil {
 		logger.Info("Failed to get TRC for chain verification",
 			"isd", query.IA.ISD(), "err", err)
@@ -140,8 +202,27 @@ func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 		return nil, serrors.WrapStr("fetching chains from remote", err, "server", o.server)
 	}
 
+	verified := filterVerifiableChains(chains, trcs)
+	if len(verified) == 0 && len(chains) > 0 {
+		// The chains might be issued by a CA that is only certified by a TRC
+		// update that is not locally available yet.
+		updated, err := p.discoverTRCUpdates(ctx, query.IA.ISD(), &o)
+		if err != nil {
+			logger.Info("Failed to discover TRC updates",
+				"isd", query.IA.ISD(), "err", err)
+		}
+		if updated {
+			if trcs, result, err = activeTRCs(ctx, p.DB, query.IA.ISD(),
+				clock.Now(p.Clock)); err != nil {
+
+				setProviderMetric(span, l.WithResult(result), err)
+				return nil, serrors.WrapStr("fetching active TRCs from database", err)
+			}
+			verified = filterVerifiableChains(chains, trcs)
+		}
+	}
 	// For simplicity, we ignore non-verifiable chains.
-	chains = filterVerifiableChains(chains, trcs)
+	chains = verified
 	if len(chains) > 0 {
 		// FIXME(roosd): Should probably be a transaction.
 		for _, chain := range chains {
@@ -159,13 +240,52 @@ func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 	return chains, nil
 }
 
-// GetSignedTRC returns the signed TRC. Currently, this method only uses the DB
-// and doesn't recurse over the network.
-// TODO(lukedirtwalker): add recursing functionality.
+// GetSignedTRC returns the signed TRC. If the requested TRC is not available
+// locally, but it is an update of the latest TRC in the database, the TRC and
+// all the intermediate updates are resolved over the network. The zero value
+// is returned if the TRC cannot be found.
 func (p FetchingProvider) GetSignedTRC(ctx context.Context, id cppki.TRCID,
 	opts ...Option) (cppki.SignedTRC, error) {
 
-	return p.DB.SignedTRC(ctx, id)
+	trc, err := p.DB.SignedTRC(ctx, id)
+	if err != nil || !trc.IsZero() || id.Base.IsLatest() || id.Serial.IsLatest() {
+		return trc, err
+	}
+
+	l := metrics.ProviderLabels{Type: metrics.TRC, Trigger: metrics.FromCtx(ctx)}
+	o := applyOptions(opts)
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "trustengine.get_trc")
+	defer span.Finish()
+	opentracingext.Component.Set(span, "trust")
+	span.SetTag("trc_id.isd", id.ISD)
+	span.SetTag("trc_id.base", id.Base)
+	span.SetTag("trc_id.serial", id.Serial)
+
+	latest, err := p.DB.SignedTRC(ctx, cppki.TRCID{
+		ISD:    id.ISD,
+		Base:   scrypto.LatestVer,
+		Serial: scrypto.LatestVer,
+	})
+	if err != nil {
+		setProviderMetric(span, l.WithResult(metrics.ErrDB), err)
+		return cppki.SignedTRC{}, err
+	}
+	if latest.IsZero() || latest.TRC.ID.Base != id.Base || latest.TRC.ID.Serial >= id.Serial {
+		setProviderMetric(span, l.WithResult(metrics.ErrNotFound), nil)
+		return cppki.SignedTRC{}, nil
+	}
+	if err := p.prepareRecursion(ctx, id.ISD, &o); err != nil {
+		setProviderMetric(span, l.WithResult(metrics.ErrNotAllowed), err)
+		return cppki.SignedTRC{}, err
+	}
+	trc, result, err := p.fetchTRCUpdates(ctx, latest, id.Serial, o.server)
+	if err != nil {
+		setProviderMetric(span, l.WithResult(result), err)
+		return cppki.SignedTRC{}, err
+	}
+	setProviderMetric(span, l.WithResult(metrics.Success), nil)
+	return trc, nil
 }
 
 // NotifyTRC notifies the provider of the existence of a TRC. This method only
@@ -216,29 +336,102 @@ func (p FetchingProvider) NotifyTRC(ctx context.Context, id cppki.TRCID, opts ..
 			return serrors.WrapStr("choosing server", err)
 		}
 	}
+	if _, result, err := p.fetchTRCUpdates(ctx, trc, id.Serial, o.server); err != nil {
+		setProviderMetric(span, l.WithResult(result), err)
+		return err
+	}
+	return nil
+}
+
+// discoverTRCUpdates resolves the TRC updates of the ISD that succeed the
+// latest TRC in the database. Updates are fetched one by one until the server
+// does not provide the next one. It returns whether any update was inserted.
+// Nothing is resolved if the updates of the ISD were discovered recently, see
+// TRCDiscoveryLimiter.
+func (p FetchingProvider) discoverTRCUpdates(ctx context.Context, isd addr.ISD,
+	o *options) (bool, error) {
+
+	if !p.TRCDiscovery.reserve(isd, clock.Now(p.Clock)) {
+		log.FromCtx(ctx).Debug("Skipping TRC update discovery, attempted recently", "isd", isd)
+		return false, nil
+	}
+	trc, err := p.DB.SignedTRC(ctx, cppki.TRCID{
+		ISD:    isd,
+		Base:   scrypto.LatestVer,
+		Serial: scrypto.LatestVer,
+	})
+	if err != nil {
+		return false, err
+	}
+	if trc.IsZero() {
+		return false, errNotFound
+	}
+	if err := p.prepareRecursion(ctx, isd, o); err != nil {
+		return false, err
+	}
+	latest, _, err := p.fetchTRCUpdates(ctx, trc, scrypto.LatestVer, o.server)
+	return latest.TRC.ID != trc.TRC.ID, err
+}
+
+// prepareRecursion checks that recursion is allowed for the client, and
+// chooses a server if none is set yet.
+func (p FetchingProvider) prepareRecursion(ctx context.Context, isd addr.ISD,
+	o *options) error {
+
+	if err := p.Recurser.AllowRecursion(o.client); err != nil {
+		return serrors.WrapStr("recursion not allowed", err)
+	}
+	if o.server != nil {
+		return nil
+	}
+	server, err := p.Router.ChooseServer(ctx, isd)
+	if err != nil {
+		return serrors.WrapStr("choosing server", err)
+	}
+	o.server = server
+	return nil
+}
+
+// fetchTRCUpdates fetches the TRC updates that succeed trc up to the given
+// serial number, verifies each of them against its predecessor and inserts
+// them into the database. If serial is scrypto.LatestVer, updates are fetched
+// until the server fails to provide the next one. The latest verified TRC is
+// returned, together with the metrics result in case of an error.
+func (p FetchingProvider) fetchTRCUpdates(ctx context.Context, trc cppki.SignedTRC,
+	serial scrypto.Version, server net.Addr) (cppki.SignedTRC, string, error) {
+
+	discover := serial.IsLatest()
 	// In general, we expect only one TRC update missing, thus sequential
 	// fetching should be fine here.
-	for serial := trc.TRC.ID.Serial + 1; serial <= id.Serial; serial++ {
-		toFetch := cppki.TRCID{ISD: id.ISD, Base: id.Base, Serial: serial}
-		fetched, err := p.Fetcher.TRC(ctx, toFetch, o.server)
+	for next := trc.TRC.ID.Serial + 1; discover || next <= serial; next++ {
+		toFetch := cppki.TRCID{ISD: trc.TRC.ID.ISD, Base: trc.TRC.ID.Base, Serial: next}
+		fetched, err := p.Fetcher.TRC(ctx, toFetch, server)
+		if err != nil && discover {
+			log.FromCtx(ctx).Debug("No further TRC update discovered",
+				"id", toFetch, "err", err)
+			return trc, metrics.Success, nil
+		}
 		if err != nil {
-			setProviderMetric(span, l.WithResult(metrics.ErrInternal), err)
-			return serrors.WrapStr("resolving TRC update", err, "id", toFetch)
+			return trc, metrics.ErrInternal,
+				serrors.WrapStr("resolving TRC update", err, "id", toFetch)
 		}
 		if err := fetched.Verify(&trc.TRC); err != nil {
-			setProviderMetric(span, l.WithResult(metrics.ErrVerify), err)
-			return serrors.WrapStr("verifying TRC update", err, "id", toFetch)
+			return trc, metrics.ErrVerify,
+				serrors.WrapStr("verifying TRC update", err, "id", toFetch)
 		}
 		if _, err := p.DB.InsertTRC(ctx, fetched); err != nil {
-			setProviderMetric(span, l.WithResult(metrics.ErrInternal), err)
-			return serrors.WrapStr("inserting TRC update", err, "id", toFetch)
+			return trc, metrics.ErrInternal,
+				serrors.WrapStr("inserting TRC update", err, "id", toFetch)
 		}
+		log.FromCtx(ctx).Info("Inserted TRC update", "id", toFetch)
 		trc = fetched
 	}
-	return nil
+	return trc, metrics.Success, nil
 }
 
-func activeTRCs(ctx context.Context, db DB, isd addr.ISD) ([]cppki.SignedTRC, string, error) {
+func activeTRCs(ctx context.Context, db DB, isd addr.ISD,
+	now time.Time) ([]cppki.SignedTRC, string, error) {
+
 	trc, err := db.SignedTRC(ctx, cppki.TRCID{
 		ISD:    isd,
 		Base:   scrypto.LatestVer,
@@ -250,13 +443,12 @@ func activeTRCs(ctx context.Context, db DB, isd addr.ISD) ([]cppki.SignedTRC, st
 	if trc.IsZero() {
 		return nil, metrics.ErrNotFound, errNotFound
 	}
-	// XXX(roosd): This could resolve newer TRCs over the network. However,
-	// for every GetChains by the verifier, there should be a NotifyTRC, such
-	// that should never run into this condition in the first place.
-	if !trc.TRC.Validity.Contains(time.Now()) {
+	// Newer TRCs are resolved over the network by the caller if the latest
+	// TRC is no longer active.
+	if !trc.TRC.Validity.Contains(now) {
 		return nil, metrics.ErrInactive, errInactive
 	}
-	if !trc.TRC.InGracePeriod(time.Now()) {
+	if !trc.TRC.InGracePeriod(now) {
 		return []cppki.SignedTRC{trc}, metrics.Success, nil
 	}
 	grace, err := db.SignedTRC(ctx, cppki.TRCID{
@@ -24,8 +24,10 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/pkg/scrypto"
@@ -37,6 +39,8 @@ import (
 func TestFetchingProviderGetChains(t *testing.T) {
 	dir := genCrypto(t)
 	trc := xtest.LoadTRC(t, filepath.Join(dir, "ISD1/trcs/ISD1-B1-S1.trc"))
+	base := xtest.LoadTRC(t, filepath.Join(dir, "trcs/ISD1-B1-S1.trc"))
+	updated := xtest.LoadTRC(t, filepath.Join(dir, "trcs/ISD1-B1-S2.trc"))
 	valid := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_110.pem"))
 	inactive := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_110.pem"))
 	inactive[0].NotAfter = time.Now().Add(-time.Second)
@@ -421,12 +425,44 @@ func TestFetchingProviderGetChains(t *testing.T) {
 				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
 					ia:   query.IA,
 					skid: query.SubjectKeyID}).Return(nil, nil)
-				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil).Times(2)
 				return db
 			},
 			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
 				r := mock_trust.NewMockRecurser(ctrl)
-				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil).Times(2)
+				return r
+			},
+			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
+				return mock_trust.NewMockRouter(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				forged := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_110.pem"))
+				forged[0].Signature[30] ^= 0xFF
+				f.EXPECT().Chains(gomock.Any(), query, &net.UDPAddr{Port: 90}).Return(
+					[][]*x509.Certificate{forged}, nil,
+				)
+				f.EXPECT().TRC(gomock.Any(), cppki.TRCID{ISD: 1, Base: 1, Serial: 2},
+					&net.UDPAddr{Port: 90}).Return(cppki.SignedTRC{}, serrors.New("not found"))
+				return f
+			},
+			Query:        query,
+			Options:      []trust.Option{trust.Server(&net.UDPAddr{Port: 90})},
+			ErrAssertion: assert.NoError,
+		},
+		"unverifiable chain, TRC update discovered": {
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().Chains(gomock.Any(), chainQueryMatcher{
+					ia:   query.IA,
+					skid: query.SubjectKeyID}).Return(nil, nil)
+				updatableTRCs(db, base, updated)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil).Times(2)
 				return r
 			},
 			Router: func(t *testing.T, ctrl *gomock.Controller) trust.Router {
@@ -439,6 +475,11 @@ func TestFetchingProviderGetChains(t *testing.T) {
 				f.EXPECT().Chains(gomock.Any(), query, &net.UDPAddr{Port: 90}).Return(
 					[][]*x509.Certificate{forged}, nil,
 				)
+				f.EXPECT().TRC(gomock.Any(), updated.TRC.ID, &net.UDPAddr{Port: 90}).Return(
+					updated, nil,
+				)
+				f.EXPECT().TRC(gomock.Any(), cppki.TRCID{ISD: 1, Base: 1, Serial: 3},
+					&net.UDPAddr{Port: 90}).Return(cppki.SignedTRC{}, serrors.New("not found"))
 				return f
 			},
 			Query:        query,
@@ -783,3 +824,225 @@ func TestFetchingProviderNotifyTRC(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchingProviderGetChainsInactiveTRC(t *testing.T) {
+	dir := genCrypto(t)
+	base := xtest.LoadTRC(t, filepath.Join(dir, "trcs/ISD1-B1-S1.trc"))
+	updated := xtest.LoadTRC(t, filepath.Join(dir, "trcs/ISD1-B1-S2.trc"))
+	valid := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_110.pem"))
+	now := base.TRC.Validity.NotAfter.Add(time.Second)
+	require.True(t, updated.TRC.Validity.Contains(now))
+
+	query := trust.ChainQuery{
+		IA:           xtest.MustParseIA("1-ff00:0:110"),
+		Date:         now,
+		SubjectKeyID: valid[0].SubjectKeyId,
+	}
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	db := mock_trust.NewMockDB(mctrl)
+	db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return([][]*x509.Certificate{valid}, nil)
+	updatableTRCs(db, base, updated)
+	recurser := mock_trust.NewMockRecurser(mctrl)
+	recurser.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+	router := mock_trust.NewMockRouter(mctrl)
+	router.EXPECT().ChooseServer(gomock.Any(), addr.ISD(1)).Return(&net.UDPAddr{Port: 90}, nil)
+	fetcher := mock_trust.NewMockFetcher(mctrl)
+	fetcher.EXPECT().TRC(gomock.Any(), updated.TRC.ID, &net.UDPAddr{Port: 90}).Return(
+		updated, nil,
+	)
+	fetcher.EXPECT().TRC(gomock.Any(), cppki.TRCID{ISD: 1, Base: 1, Serial: 3},
+		&net.UDPAddr{Port: 90}).Return(cppki.SignedTRC{}, serrors.New("not found"))
+
+	p := trust.FetchingProvider{
+		DB:       db,
+		Recurser: recurser,
+		Fetcher:  fetcher,
+		Router:   router,
+		Clock:    clock.NewManual(now),
+	}
+	chains, err := p.GetChains(context.Background(), query)
+	require.NoError(t, err)
+	assert.Equal(t, [][]*x509.Certificate{valid}, chains)
+}
+
+func TestFetchingProviderGetChainsTRCDiscoveryLimited(t *testing.T) {
+	dir := genCrypto(t)
+	base := xtest.LoadTRC(t, filepath.Join(dir, "trcs/ISD1-B1-S1.trc"))
+	valid := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_110.pem"))
+	now := base.TRC.Validity.NotAfter.Add(time.Second)
+
+	query := trust.ChainQuery{
+		IA:           xtest.MustParseIA("1-ff00:0:110"),
+		Date:         now,
+		SubjectKeyID: valid[0].SubjectKeyId,
+	}
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	db := mock_trust.NewMockDB(mctrl)
+	db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(
+		[][]*x509.Certificate{valid}, nil).AnyTimes()
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(base, nil).AnyTimes()
+	recurser := mock_trust.NewMockRecurser(mctrl)
+	recurser.EXPECT().AllowRecursion(gomock.Any()).Return(nil).Times(2)
+	fetcher := mock_trust.NewMockFetcher(mctrl)
+	// The update is only requested once per interval.
+	fetcher.EXPECT().TRC(gomock.Any(), cppki.TRCID{ISD: 1, Base: 1, Serial: 2},
+		&net.UDPAddr{Port: 90}).Return(cppki.SignedTRC{}, serrors.New("not found")).Times(2)
+
+	clk := clock.NewManual(now)
+	p := trust.FetchingProvider{
+		DB:           db,
+		Recurser:     recurser,
+		Fetcher:      fetcher,
+		Router:       mock_trust.NewMockRouter(mctrl),
+		Clock:        clk,
+		TRCDiscovery: &trust.TRCDiscoveryLimiter{Interval: time.Minute},
+	}
+	for i := 0; i < 3; i++ {
+		_, err := p.GetChains(context.Background(), query, trust.Server(&net.UDPAddr{Port: 90}))
+		assert.Error(t, err)
+	}
+	clk.Advance(time.Minute)
+	_, err := p.GetChains(context.Background(), query, trust.Server(&net.UDPAddr{Port: 90}))
+	assert.Error(t, err)
+}
+
+func TestFetchingProviderGetSignedTRC(t *testing.T) {
+	dir := genCrypto(t)
+
+	base := xtest.LoadTRC(t, filepath.Join(dir, "trcs/ISD1-B1-S1.trc"))
+	updated := xtest.LoadTRC(t, filepath.Join(dir, "trcs/ISD1-B1-S2.trc"))
+	latestID := cppki.TRCID{ISD: 1, Base: scrypto.LatestVer, Serial: scrypto.LatestVer}
+
+	testCases := map[string]struct {
+		ID           cppki.TRCID
+		DB           func(t *testing.T, ctrl *gomock.Controller) trust.DB
+		Recurser     func(t *testing.T, ctrl *gomock.Controller) trust.Recurser
+		Fetcher      func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher
+		ErrAssertion assert.ErrorAssertionFunc
+		ExpectedTRC  cppki.SignedTRC
+	}{
+		"TRC in database": {
+			ID: base.TRC.ID,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), base.TRC.ID).Return(base, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				return mock_trust.NewMockRecurser(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				return mock_trust.NewMockFetcher(ctrl)
+			},
+			ErrAssertion: assert.NoError,
+			ExpectedTRC:  base,
+		},
+		"TRC update resolved": {
+			ID: updated.TRC.ID,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), updated.TRC.ID).Return(
+					cppki.SignedTRC{}, nil,
+				)
+				db.EXPECT().SignedTRC(gomock.Any(), latestID).Return(base, nil)
+				db.EXPECT().InsertTRC(gomock.Any(), updated).Return(true, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(nil)
+				return r
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				f := mock_trust.NewMockFetcher(ctrl)
+				f.EXPECT().TRC(gomock.Any(), updated.TRC.ID, &net.UDPAddr{Port: 90}).Return(
+					updated, nil,
+				)
+				return f
+			},
+			ErrAssertion: assert.NoError,
+			ExpectedTRC:  updated,
+		},
+		"different base not resolved": {
+			ID: cppki.TRCID{ISD: 1, Base: 2, Serial: 2},
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), cppki.TRCID{ISD: 1, Base: 2, Serial: 2}).
+					Return(cppki.SignedTRC{}, nil)
+				db.EXPECT().SignedTRC(gomock.Any(), latestID).Return(base, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				return mock_trust.NewMockRecurser(ctrl)
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				return mock_trust.NewMockFetcher(ctrl)
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"recursion not allowed": {
+			ID: updated.TRC.ID,
+			DB: func(t *testing.T, ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), updated.TRC.ID).Return(
+					cppki.SignedTRC{}, nil,
+				)
+				db.EXPECT().SignedTRC(gomock.Any(), latestID).Return(base, nil)
+				return db
+			},
+			Recurser: func(t *testing.T, ctrl *gomock.Controller) trust.Recurser {
+				r := mock_trust.NewMockRecurser(ctrl)
+				r.EXPECT().AllowRecursion(gomock.Any()).Return(serrors.New("not allowed"))
+				return r
+			},
+			Fetcher: func(t *testing.T, ctrl *gomock.Controller) trust.Fetcher {
+				return mock_trust.NewMockFetcher(ctrl)
+			},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			p := trust.FetchingProvider{
+				DB:       tc.DB(t, mctrl),
+				Recurser: tc.Recurser(t, mctrl),
+				Fetcher:  tc.Fetcher(t, mctrl),
+				Router:   mock_trust.NewMockRouter(mctrl),
+			}
+			trc, err := p.GetSignedTRC(context.Background(), tc.ID,
+				trust.Server(&net.UDPAddr{Port: 90}))
+			tc.ErrAssertion(t, err)
+			assert.Equal(t, tc.ExpectedTRC, trc)
+		})
+	}
+}
+
+// updatableTRCs sets up the database to return base as latest TRC until the
+// update is inserted.
+func updatableTRCs(db *mock_trust.MockDB, base, update cppki.SignedTRC) {
+	latest := base
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id cppki.TRCID) (cppki.SignedTRC, error) {
+			switch {
+			case id.Serial.IsLatest():
+				return latest, nil
+			case id == base.TRC.ID:
+				return base, nil
+			default:
+				return cppki.SignedTRC{}, nil
+			}
+		},
+	).AnyTimes()
+	db.EXPECT().InsertTRC(gomock.Any(), update).DoAndReturn(
+		func(_ context.Context, trc cppki.SignedTRC) (bool, error) {
+			latest = trc
+			return true, nil
+		},
+	)
+}
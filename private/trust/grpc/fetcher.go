@@ -144,6 +144,58 @@ func (f Fetcher) TRC(ctx context.Context, id cppki.TRCID,
 	return trc, nil
 }
 
+// CRLs fetches the certificate revocation lists over the network. If
+// authorityKeyID is set, only the CRL issued by the CA certificate with that
+// subject key ID is fetched.
+func (f Fetcher) CRLs(ctx context.Context, authorityKeyID []byte,
+	server net.Addr) ([]*x509.RevocationList, error) {
+
+	labels := requestLabels{
+		Type:    trustmetrics.CRLReq,
+		Trigger: trustmetrics.FromCtx(ctx),
+		Peer:    trustmetrics.PeerToLabel(server, f.IA),
+	}
+	span, ctx := opentracing.StartSpanFromContext(ctx, "trustengine.fetch_crls")
+	defer span.Finish()
+	tracing.Component(span, "trust")
+	span.SetTag("query.authority_key_id", fmt.Sprintf("%x", authorityKeyID))
+
+	logger := log.FromCtx(ctx)
+	logger.Debug("Fetch CRLs from remote",
+		"authority_key_id", fmt.Sprintf("%x", authorityKeyID), "server", server)
+
+	conn, err := f.Dialer.Dial(ctx, server)
+	if err != nil {
+		f.updateMetric(span, labels.WithResult(trustmetrics.ErrTransmit), err)
+		return nil, serrors.WrapStr("dialing", err)
+	}
+	defer conn.Close()
+	client := cppb.NewTrustMaterialServiceClient(conn)
+	rep, err := client.CRLs(ctx, &cppb.CRLsRequest{AuthorityKeyId: authorityKeyID},
+		grpc.RetryProfile...)
+	if err != nil {
+		f.updateMetric(span, labels.WithResult(trustmetrics.ErrTransmit), err)
+		return nil, serrors.WrapStr("receiving CRLs", err)
+	}
+	crls, err := repToCRLs(rep.Crls)
+	if err != nil {
+		f.updateMetric(span, labels.WithResult(trustmetrics.ErrParse), err)
+		return nil, err
+	}
+	logger.Debug("Received CRLs from remote", "crls", len(crls))
+	if len(authorityKeyID) != 0 {
+		for i, crl := range crls {
+			if !bytes.Equal(crl.AuthorityKeyId, authorityKeyID) {
+				err := serrors.New("AuthorityKeyID mismatch", "index", i)
+				f.updateMetric(span, labels.WithResult(trustmetrics.ErrMismatch), err)
+				return nil, serrors.WrapStr("CRLs do not match query", err)
+			}
+		}
+	}
+	f.updateMetric(span, labels.WithResult(trustmetrics.Success), nil)
+	return crls, nil
+}
+
 func (f Fetcher) updateMetric(span opentracing.Span, l requestLabels, err error) {
 	if f.Requests != nil {
 		f.Requests.With(l.Expand()...).Add(1)
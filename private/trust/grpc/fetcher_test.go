@@ -16,7 +16,12 @@ package grpc_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
 	"net"
 	"os"
 	"path/filepath"
@@ -249,3 +254,85 @@ func TestFetcherTRC(t *testing.T) {
 		})
 	}
 }
+
+func TestFetcherCRLs(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "CA"},
+		SubjectKeyId:          []byte("ca-key-id"),
+		KeyUsage:              x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	raw, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now(),
+		NextUpdate: time.Now().Add(time.Hour),
+	}, ca, key)
+	require.NoError(t, err)
+	crl, err := x509.ParseRevocationList(raw)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		Server         func(mctrl *gomock.Controller) *mock_cp.MockTrustMaterialServiceServer
+		AuthorityKeyID []byte
+		Assertion      assert.ErrorAssertionFunc
+		Expected       []*x509.RevocationList
+	}{
+		"garbage CRL": {
+			Server: func(mctrl *gomock.Controller) *mock_cp.MockTrustMaterialServiceServer {
+				srv := mock_cp.NewMockTrustMaterialServiceServer(mctrl)
+				srv.EXPECT().CRLs(gomock.Any(), gomock.Any()).Return(
+					&cppb.CRLsResponse{Crls: [][]byte{[]byte("garbage")}}, nil,
+				)
+				return srv
+			},
+			Assertion: assert.Error,
+		},
+		"mismatching authority key ID": {
+			Server: func(mctrl *gomock.Controller) *mock_cp.MockTrustMaterialServiceServer {
+				srv := mock_cp.NewMockTrustMaterialServiceServer(mctrl)
+				srv.EXPECT().CRLs(gomock.Any(), gomock.Any()).Return(
+					&cppb.CRLsResponse{Crls: [][]byte{raw}}, nil,
+				)
+				return srv
+			},
+			AuthorityKeyID: []byte("other-key-id"),
+			Assertion:      assert.Error,
+		},
+		"valid CRLs": {
+			Server: func(mctrl *gomock.Controller) *mock_cp.MockTrustMaterialServiceServer {
+				srv := mock_cp.NewMockTrustMaterialServiceServer(mctrl)
+				srv.EXPECT().CRLs(gomock.Any(), gomock.Any()).Return(
+					&cppb.CRLsResponse{Crls: [][]byte{raw}}, nil,
+				)
+				return srv
+			},
+			AuthorityKeyID: ca.SubjectKeyId,
+			Assertion:      assert.NoError,
+			Expected:       []*x509.RevocationList{crl},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			svc := xtest.NewGRPCService()
+			cppb.RegisterTrustMaterialServiceServer(svc.Server(), tc.Server(mctrl))
+			svc.Start(t)
+
+			f := trustgrpc.Fetcher{Dialer: svc}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			crls, err := f.CRLs(ctx, tc.AuthorityKeyID, &net.UDPAddr{})
+			tc.Assertion(t, err)
+			assert.Equal(t, tc.Expected, crls)
+		})
+	}
+}
@@ -60,3 +60,15 @@ func idToReq(id cppki.TRCID) *cppb.TRCRequest {
 		Serial: uint64(id.Serial),
 	}
 }
+
+func repToCRLs(raw [][]byte) ([]*x509.RevocationList, error) {
+	crls := make([]*x509.RevocationList, 0, len(raw))
+	for _, r := range raw {
+		crl, err := x509.ParseRevocationList(r)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing CRL", err)
+		}
+		crls = append(crls, crl)
+	}
+	return crls, nil
+}
@@ -35,6 +35,7 @@ const (
 const (
 	TRCReq    = "trc_request"
 	ChainReq  = "chain_request"
+	CRLReq    = "crl_request"
 	NotifyTRC = "trc_notify"
 	LatestTRC = "latest_trc_number"
 )
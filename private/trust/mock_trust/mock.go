@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/private/trust (interfaces: DB,Fetcher,Inspector,KeyRing,Provider,Recurser,Router)
+// Source: github.com/scionproto/scion/private/trust (interfaces: CRLFetcher,DB,Fetcher,Inspector,KeyRing,Provider,Recurser,Router)
 
 // Package mock_trust is a generated GoMock package.
 package mock_trust
@@ -17,6 +17,44 @@ import (
 	trust "github.com/scionproto/scion/private/trust"
 )
 
+// MockCRLFetcher is a mock of CRLFetcher interface.
+type MockCRLFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockCRLFetcherMockRecorder
+}
+
+// MockCRLFetcherMockRecorder is the mock recorder for MockCRLFetcher.
+type MockCRLFetcherMockRecorder struct {
+	mock *MockCRLFetcher
+}
+
+// NewMockCRLFetcher creates a new mock instance.
+func NewMockCRLFetcher(ctrl *gomock.Controller) *MockCRLFetcher {
+	mock := &MockCRLFetcher{ctrl: ctrl}
+	mock.recorder = &MockCRLFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCRLFetcher) EXPECT() *MockCRLFetcherMockRecorder {
+	return m.recorder
+}
+
+// CRLs mocks base method.
+func (m *MockCRLFetcher) CRLs(arg0 context.Context, arg1 []byte, arg2 net.Addr) ([]*x509.RevocationList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CRLs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*x509.RevocationList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CRLs indicates an expected call of CRLs.
+func (mr *MockCRLFetcherMockRecorder) CRLs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CRLs", reflect.TypeOf((*MockCRLFetcher)(nil).CRLs), arg0, arg1, arg2)
+}
+
 // MockDB is a mock of DB interface.
 type MockDB struct {
 	ctrl     *gomock.Controller
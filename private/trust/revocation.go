@@ -0,0 +1,362 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/clock"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	truststorage "github.com/scionproto/scion/private/storage/trust"
+)
+
+// RevocationChecker checks whether certificate chains have been revoked.
+type RevocationChecker interface {
+	// Revoked indicates whether the AS certificate of the chain has been
+	// revoked by the CA that issued it.
+	Revoked(chain []*x509.Certificate) (bool, error)
+	// Generation is incremented every time the revocation information
+	// changes. It allows callers to invalidate cached revocation decisions.
+	Generation() uint64
+}
+
+// ErrCRLExpired indicates that the stored CRL of a CA is past its next update
+// time, and the revocation status of the certificates issued by the CA is
+// unknown.
+var ErrCRLExpired = serrors.New("CRL expired")
+
+// RevocationStore keeps the latest certificate revocation list (CRL) of every
+// CA. CRLs are identified by the subject key ID of the issuing CA certificate,
+// i.e., the authority key ID of the CRL.
+type RevocationStore struct {
+	// DB is used to resolve the CA certificates that issue the CRLs. A CA
+	// certificate is only considered if it is part of a stored certificate
+	// chain that verifies against the latest TRC of its ISD.
+	DB DB
+	// Clock provides the current time against which the next update time of
+	// the CRLs is checked. If nil, the wall clock is used.
+	Clock clock.Clock
+
+	mtx        sync.RWMutex
+	crls       map[string]*x509.RevocationList
+	generation uint64
+}
+
+// InsertCRL inserts the CRL into the store. A CRL only replaces the stored CRL
+// of the same CA if it was issued later. Before it is inserted, the CRL is
+// verified against the CA certificate that issued it. Returns true if the CRL
+// was inserted. CRLs that are past their next update time are rejected.
+func (s *RevocationStore) InsertCRL(ctx context.Context,
+	crl *x509.RevocationList) (bool, error) {
+
+	if len(crl.AuthorityKeyId) == 0 {
+		return false, serrors.New("CRL without authority key ID")
+	}
+	if expired(crl, clock.Now(s.Clock)) {
+		return false, serrors.WithCtx(ErrCRLExpired, "issuer", crl.Issuer,
+			"next_update", crl.NextUpdate)
+	}
+	key := string(crl.AuthorityKeyId)
+	if !s.newer(key, crl) {
+		return false, nil
+	}
+	ca, err := s.issuerCA(ctx, crl)
+	if err != nil {
+		return false, err
+	}
+	if err := checkCRLSignature(crl, ca); err != nil {
+		return false, err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if existing, ok := s.crls[key]; ok && !crl.ThisUpdate.After(existing.ThisUpdate) {
+		return false, nil
+	}
+	if s.crls == nil {
+		s.crls = make(map[string]*x509.RevocationList)
+	}
+	s.crls[key] = crl
+	s.generation++
+	return true, nil
+}
+
+// Generation returns the number of CRLs that have been inserted.
+func (s *RevocationStore) Generation() uint64 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.generation
+}
+
+// expired indicates whether the CRL is past its next update time. CRLs without
+// next update time do not expire.
+func expired(crl *x509.RevocationList, now time.Time) bool {
+	return !crl.NextUpdate.IsZero() && now.After(crl.NextUpdate)
+}
+
+// newer indicates whether the CRL was issued later than the stored CRL of the
+// same CA.
+func (s *RevocationStore) newer(key string, crl *x509.RevocationList) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	existing, ok := s.crls[key]
+	return !ok || crl.ThisUpdate.After(existing.ThisUpdate)
+}
+
+// issuerCA resolves the CA certificate that issued the CRL. The certificate is
+// looked up in the stored chains of the issuer's ISD and must verify against
+// the latest TRC of that ISD.
+func (s *RevocationStore) issuerCA(ctx context.Context,
+	crl *x509.RevocationList) (*x509.Certificate, error) {
+
+	if s.DB == nil {
+		return nil, serrors.New("no trust database to resolve CRL issuer")
+	}
+	ia, err := cppki.ExtractIA(crl.Issuer)
+	if err != nil {
+		return nil, serrors.WrapStr("extracting ISD-AS of CRL issuer", err)
+	}
+	trc, err := s.DB.SignedTRC(ctx, cppki.TRCID{
+		ISD:    ia.ISD(),
+		Base:   scrypto.LatestVer,
+		Serial: scrypto.LatestVer,
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("loading TRC", err, "isd", ia.ISD())
+	}
+	if trc.IsZero() {
+		return nil, serrors.New("TRC not found", "isd", ia.ISD())
+	}
+	chains, err := s.DB.Chains(ctx, ChainQuery{
+		IA:   addr.MustIAFrom(ia.ISD(), 0),
+		Date: clock.Now(s.Clock),
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("loading certificate chains", err, "isd", ia.ISD())
+	}
+	opts := cppki.VerifyOptions{TRC: []*cppki.TRC{&trc.TRC}}
+	for _, chain := range chains {
+		ca := chain[1]
+		if !bytes.Equal(ca.SubjectKeyId, crl.AuthorityKeyId) ||
+			!bytes.Equal(ca.RawSubject, crl.RawIssuer) {
+			continue
+		}
+		if err := cppki.VerifyChain(chain, opts); err != nil {
+			continue
+		}
+		return ca, nil
+	}
+	return nil, serrors.New("CA certificate of CRL issuer not found", "issuer", crl.Issuer,
+		"authority_key_id", fmt.Sprintf("%x", crl.AuthorityKeyId))
+}
+
+// CRLs returns the stored CRLs. If authorityKeyID is set, only the CRL issued
+// by the CA certificate with that subject key ID is returned.
+func (s *RevocationStore) CRLs(authorityKeyID []byte) []*x509.RevocationList {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if len(authorityKeyID) != 0 {
+		if crl, ok := s.crls[string(authorityKeyID)]; ok {
+			return []*x509.RevocationList{crl}
+		}
+		return nil
+	}
+	crls := make([]*x509.RevocationList, 0, len(s.crls))
+	for _, crl := range s.crls {
+		crls = append(crls, crl)
+	}
+	sort.Slice(crls, func(i, j int) bool {
+		return bytes.Compare(crls[i].AuthorityKeyId, crls[j].AuthorityKeyId) < 0
+	})
+	return crls
+}
+
+// Revoked checks whether the AS certificate of the chain is listed in the CRL
+// of the CA certificate in the chain. The CRL must be signed by the CA
+// certificate, otherwise an error is returned. If the CRL is past its next
+// update time, the revocation status is unknown and ErrCRLExpired is returned.
+// It is up to the caller to decide whether to accept the chain in that case.
+func (s *RevocationStore) Revoked(chain []*x509.Certificate) (bool, error) {
+	if len(chain) != 2 {
+		return false, serrors.New("invalid chain length", "expected", 2, "actual", len(chain))
+	}
+	as, ca := chain[0], chain[1]
+	s.mtx.RLock()
+	crl, ok := s.crls[string(ca.SubjectKeyId)]
+	s.mtx.RUnlock()
+	if !ok {
+		return false, nil
+	}
+	if expired(crl, clock.Now(s.Clock)) {
+		return false, serrors.WithCtx(ErrCRLExpired, "issuer", crl.Issuer,
+			"next_update", crl.NextUpdate)
+	}
+	if err := checkCRLSignature(crl, ca); err != nil {
+		return false, err
+	}
+	for _, entry := range crl.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(as.SerialNumber) == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkCRLSignature checks that the CRL was issued by the CA certificate.
+func checkCRLSignature(crl *x509.RevocationList, ca *x509.Certificate) error {
+	if !bytes.Equal(crl.RawIssuer, ca.RawSubject) {
+		return serrors.New("CRL issuer does not match CA certificate subject",
+			"issuer", crl.Issuer, "subject", ca.Subject)
+	}
+	// The CA certificates of the control plane PKI are not required to have
+	// the CRL signing key usage set, it is implied by the certificate signing
+	// key usage.
+	issuer := ca
+	if ca.KeyUsage&x509.KeyUsageCertSign != 0 {
+		withCRLSign := *ca
+		withCRLSign.KeyUsage |= x509.KeyUsageCRLSign
+		issuer = &withCRLSign
+	}
+	if err := crl.CheckSignatureFrom(issuer); err != nil {
+		return serrors.WrapStr("verifying CRL signature", err)
+	}
+	return nil
+}
+
+// CRLFetcher fetches certificate revocation lists from a remote server.
+type CRLFetcher interface {
+	// CRLs fetches the CRLs issued by the CA certificate with the given
+	// subject key ID. If authorityKeyID is empty, the CRLs of all CAs known to
+	// the server are fetched.
+	CRLs(ctx context.Context, authorityKeyID []byte,
+		server net.Addr) ([]*x509.RevocationList, error)
+}
+
+// CRLUpdater distributes CRLs by fetching them from the authoritative control
+// services. The CRLs are fetched for every ISD with a TRC in the database,
+// i.e., for every ISD whose certificate chains can be verified.
+type CRLUpdater struct {
+	// DB provides the TRCs of the ISDs whose CRLs are fetched.
+	DB      truststorage.TrustAPI
+	Store   *RevocationStore
+	Fetcher CRLFetcher
+	Router  Router
+}
+
+// Update fetches the CRLs and inserts them into the revocation store. CRLs that
+// cannot be verified are ignored. A failure to fetch the CRLs of one ISD does
+// not prevent the CRLs of the other ISDs from being fetched. Returns the number
+// of inserted CRLs.
+func (u CRLUpdater) Update(ctx context.Context) (int, error) {
+	trcs, err := u.DB.SignedTRCs(ctx, truststorage.TRCsQuery{Latest: true})
+	if err != nil {
+		return 0, serrors.WrapStr("loading TRCs", err)
+	}
+	isds := make(map[addr.ISD]struct{}, len(trcs))
+	for _, trc := range trcs {
+		isds[trc.TRC.ID.ISD] = struct{}{}
+	}
+	inserted := 0
+	var errs serrors.List
+	for isd := range isds {
+		n, err := u.update(ctx, isd)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		inserted += n
+	}
+	return inserted, errs.ToError()
+}
+
+func (u CRLUpdater) update(ctx context.Context, isd addr.ISD) (int, error) {
+	server, err := u.Router.ChooseServer(ctx, isd)
+	if err != nil {
+		return 0, serrors.WrapStr("choosing server", err, "isd", isd)
+	}
+	crls, err := u.Fetcher.CRLs(ctx, nil, server)
+	if err != nil {
+		return 0, serrors.WrapStr("fetching CRLs", err, "isd", isd, "server", server)
+	}
+	logger := log.FromCtx(ctx)
+	inserted := 0
+	for _, crl := range crls {
+		ok, err := u.Store.InsertCRL(ctx, crl)
+		if err != nil {
+			logger.Info("Ignoring fetched CRL", "issuer", crl.Issuer, "err", err)
+			continue
+		}
+		if ok {
+			inserted++
+		}
+	}
+	return inserted, nil
+}
+
+// LoadCRLs loads all *.crl files located in a directory into the revocation
+// store. The files can either be DER or PEM encoded. Files that cannot be
+// parsed or verified, or that do not contain a newer CRL are ignored.
+func LoadCRLs(ctx context.Context, dir string, store *RevocationStore) (LoadResult, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return LoadResult{}, serrors.WithCtx(err, "dir", dir)
+	}
+
+	files, err := filepath.Glob(fmt.Sprintf("%s/*.crl", dir))
+	if err != nil {
+		return LoadResult{}, serrors.WithCtx(err, "dir", dir)
+	}
+
+	res := LoadResult{Ignored: map[string]error{}}
+	for _, f := range files {
+		raw, err := os.ReadFile(f)
+		if err != nil {
+			return res, serrors.WithCtx(err, "file", f)
+		}
+		block, _ := pem.Decode(raw)
+		if block != nil && block.Type == "X509 CRL" {
+			raw = block.Bytes
+		}
+		crl, err := x509.ParseRevocationList(raw)
+		if err != nil {
+			res.Ignored[f] = err
+			continue
+		}
+		inserted, err := store.InsertCRL(ctx, crl)
+		if err != nil {
+			res.Ignored[f] = err
+			continue
+		}
+		if !inserted {
+			res.Ignored[f] = ErrAlreadyExists
+			continue
+		}
+		res.Loaded = append(res.Loaded, f)
+	}
+	return res, nil
+}
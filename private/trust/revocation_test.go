@@ -0,0 +1,429 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/clock"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	truststorage "github.com/scionproto/scion/private/storage/trust"
+	"github.com/scionproto/scion/private/trust"
+	"github.com/scionproto/scion/private/trust/mock_trust"
+)
+
+func TestRevocationStoreInsertCRL(t *testing.T) {
+	dir := genCrypto(t)
+	ca, caKey := loadCA(t, dir)
+
+	now := time.Now()
+	older := newCRL(t, ca, caKey, now.Add(-time.Hour))
+	newer := newCRL(t, ca, caKey, now)
+
+	ctx := context.Background()
+	store := newRevocationStore(t, dir)
+	assert.Empty(t, store.CRLs(nil))
+
+	inserted, err := store.InsertCRL(ctx, older)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+	inserted, err = store.InsertCRL(ctx, newer)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+	inserted, err = store.InsertCRL(ctx, older)
+	require.NoError(t, err)
+	assert.False(t, inserted)
+
+	assert.Equal(t, []*x509.RevocationList{newer}, store.CRLs(nil))
+	assert.Equal(t, []*x509.RevocationList{newer}, store.CRLs(ca.SubjectKeyId))
+	assert.Empty(t, store.CRLs([]byte("unknown")))
+
+	_, err = store.InsertCRL(ctx, &x509.RevocationList{})
+	assert.Error(t, err)
+
+	// The CRL was due to be replaced a day ago.
+	expired := newCRL(t, ca, caKey, now.Add(-48*time.Hour))
+	inserted, err = newRevocationStore(t, dir).InsertCRL(ctx, expired)
+	assert.ErrorIs(t, err, trust.ErrCRLExpired)
+	assert.False(t, inserted)
+}
+
+func TestRevocationStoreInsertCRLVerification(t *testing.T) {
+	dir := genCrypto(t)
+	ca, caKey := loadCA(t, dir)
+	crl := newCRL(t, ca, caKey, time.Now())
+	forged := *crl
+	forged.Signature = append([]byte(nil), crl.Signature...)
+	forged.Signature[len(forged.Signature)-1] ^= 0xFF
+
+	// A self-signed certificate with the subject and key ID of the CA, but a
+	// different key, is not part of any chain in the database.
+	rogueKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := *ca
+	template.SerialNumber = big.NewInt(1)
+	template.PublicKey = rogueKey.Public()
+	raw, err := x509.CreateCertificate(rand.Reader, &template, &template, rogueKey.Public(),
+		rogueKey)
+	require.NoError(t, err)
+	rogue, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+	rogueCRL := newCRL(t, rogue, rogueKey, time.Now())
+
+	testCases := map[string]struct {
+		crl   *x509.RevocationList
+		store func(t *testing.T) *trust.RevocationStore
+	}{
+		"forged signature": {
+			crl:   &forged,
+			store: func(t *testing.T) *trust.RevocationStore { return newRevocationStore(t, dir) },
+		},
+		"no database": {
+			crl:   crl,
+			store: func(t *testing.T) *trust.RevocationStore { return &trust.RevocationStore{} },
+		},
+		"unknown CA": {
+			crl: crl,
+			store: func(t *testing.T) *trust.RevocationStore {
+				ctrl := gomock.NewController(t)
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(
+					xtest.LoadTRC(t, filepath.Join(dir, "ISD1/trcs/ISD1-B1-S1.trc")), nil)
+				db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(nil, nil)
+				return &trust.RevocationStore{DB: db}
+			},
+		},
+		"no TRC": {
+			crl: crl,
+			store: func(t *testing.T) *trust.RevocationStore {
+				ctrl := gomock.NewController(t)
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(cppki.SignedTRC{}, nil)
+				return &trust.RevocationStore{DB: db}
+			},
+		},
+		"CA not in database": {
+			crl:   rogueCRL,
+			store: func(t *testing.T) *trust.RevocationStore { return newRevocationStore(t, dir) },
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			store := tc.store(t)
+			inserted, err := store.InsertCRL(context.Background(), tc.crl)
+			assert.Error(t, err)
+			assert.False(t, inserted)
+			assert.Empty(t, store.CRLs(nil))
+		})
+	}
+}
+
+func TestRevocationStoreRevoked(t *testing.T) {
+	dir := genCrypto(t)
+	ca, caKey := loadCA(t, dir)
+	chain111 := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_111.pem"))
+	chain112 := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_112.pem"))
+
+	crl := newCRL(t, ca, caKey, time.Now(), chain111[0].SerialNumber)
+
+	testCases := map[string]struct {
+		crl       *x509.RevocationList
+		chain     []*x509.Certificate
+		revoked   bool
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"revoked": {
+			crl:       crl,
+			chain:     chain111,
+			revoked:   true,
+			assertErr: assert.NoError,
+		},
+		"not revoked": {
+			crl:       crl,
+			chain:     chain112,
+			assertErr: assert.NoError,
+		},
+		"no CRL": {
+			chain:     chain111,
+			assertErr: assert.NoError,
+		},
+		"invalid chain": {
+			crl:       crl,
+			chain:     chain111[:1],
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			store := newRevocationStore(t, dir)
+			if tc.crl != nil {
+				_, err := store.InsertCRL(context.Background(), tc.crl)
+				require.NoError(t, err)
+			}
+			revoked, err := store.Revoked(tc.chain)
+			tc.assertErr(t, err)
+			assert.Equal(t, tc.revoked, revoked)
+		})
+	}
+}
+
+func TestRevocationStoreRevokedExpired(t *testing.T) {
+	dir := genCrypto(t)
+	ca, caKey := loadCA(t, dir)
+	chain := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_111.pem"))
+
+	now := time.Now()
+	crl := newCRL(t, ca, caKey, now)
+	store := newRevocationStore(t, dir)
+	clk := clock.NewManual(now)
+	store.Clock = clk
+	_, err := store.InsertCRL(context.Background(), crl)
+	require.NoError(t, err)
+
+	revoked, err := store.Revoked(chain)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Once the CRL is past its next update time, the revocation status is
+	// unknown.
+	clk.Set(crl.NextUpdate.Add(time.Second))
+	_, err = store.Revoked(chain)
+	assert.ErrorIs(t, err, trust.ErrCRLExpired)
+}
+
+func TestLoadCRLs(t *testing.T) {
+	dir := genCrypto(t)
+	ca, caKey := loadCA(t, dir)
+	crl := newCRL(t, ca, caKey, time.Now())
+
+	crlDir := t.TempDir()
+	der := filepath.Join(crlDir, "ca.crl")
+	require.NoError(t, os.WriteFile(der, crl.Raw, 0644))
+	encoded := pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl.Raw})
+	pemFile := filepath.Join(crlDir, "ca-copy.crl")
+	require.NoError(t, os.WriteFile(pemFile, encoded, 0644))
+	garbage := filepath.Join(crlDir, "garbage.crl")
+	require.NoError(t, os.WriteFile(garbage, []byte("garbage"), 0644))
+	forged := append([]byte(nil), newCRL(t, ca, caKey, time.Now().Add(time.Hour)).Raw...)
+	forged[len(forged)-1] ^= 0xFF
+	forgedFile := filepath.Join(crlDir, "forged.crl")
+	require.NoError(t, os.WriteFile(forgedFile, forged, 0644))
+
+	ctx := context.Background()
+	store := newRevocationStore(t, dir)
+	res, err := trust.LoadCRLs(ctx, crlDir, store)
+	require.NoError(t, err)
+	// Files are loaded in lexical order, thus, the PEM file is loaded first.
+	assert.Equal(t, []string{pemFile}, res.Loaded)
+	assert.ErrorIs(t, res.Ignored[der], trust.ErrAlreadyExists)
+	assert.Error(t, res.Ignored[garbage])
+	assert.Error(t, res.Ignored[forgedFile])
+	assert.Equal(t, []*x509.RevocationList{crl}, store.CRLs(nil))
+
+	_, err = trust.LoadCRLs(ctx, filepath.Join(crlDir, "missing"), store)
+	assert.Error(t, err)
+}
+
+func TestVerifyRevoked(t *testing.T) {
+	dir := genCrypto(t)
+	ca, caKey := loadCA(t, dir)
+
+	msg := []byte("random")
+	chain := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_110.pem"))
+	key := loadKey(t, filepath.Join(dir, "ISD1/ASff00_0_110/crypto/as/cp-as.key"))
+	sign := validSignS(t, msg, "1-ff00:0:110", key)
+
+	store := newRevocationStore(t, dir)
+	_, err := store.InsertCRL(context.Background(),
+		newCRL(t, ca, caKey, time.Now(), chain[0].SerialNumber))
+	require.NoError(t, err)
+
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	p := mock_trust.NewMockProvider(mctrl)
+	p.EXPECT().NotifyTRC(gomock.Any(), cppki.TRCID{ISD: 1, Base: 1, Serial: 1},
+		trust.OptionsMatcher{}).Return(nil)
+	p.EXPECT().GetChains(gomock.Any(), gomock.Any(), trust.OptionsMatcher{}).Return(
+		[][]*x509.Certificate{chain}, nil,
+	)
+
+	v := trust.Verifier{
+		Engine:      p,
+		Revocations: store,
+	}
+	_, err = v.Verify(context.Background(), sign, nil)
+	assert.Error(t, err)
+}
+
+func TestVerifierCacheRevoked(t *testing.T) {
+	dir := genCrypto(t)
+	ca, caKey := loadCA(t, dir)
+
+	msg := []byte("random")
+	chain := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_110.pem"))
+	key := loadKey(t, filepath.Join(dir, "ISD1/ASff00_0_110/crypto/as/cp-as.key"))
+	sign := validSignS(t, msg, "1-ff00:0:110", key)
+
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	p := mock_trust.NewMockProvider(mctrl)
+	p.EXPECT().NotifyTRC(gomock.Any(), cppki.TRCID{ISD: 1, Base: 1, Serial: 1},
+		trust.OptionsMatcher{}).Return(nil)
+	p.EXPECT().GetChains(gomock.Any(), gomock.Any(), trust.OptionsMatcher{}).Return(
+		[][]*x509.Certificate{chain}, nil,
+	).Times(2)
+
+	store := newRevocationStore(t, dir)
+	v := trust.Verifier{
+		Engine:      p,
+		Revocations: store,
+		Cache:       cache.New(time.Minute, time.Minute),
+	}
+	_, err := v.Verify(context.Background(), sign, nil)
+	require.NoError(t, err)
+	_, err = v.Verify(context.Background(), sign, nil)
+	require.NoError(t, err)
+
+	// The cached chain must not be used after it has been revoked.
+	_, err = store.InsertCRL(context.Background(),
+		newCRL(t, ca, caKey, time.Now(), chain[0].SerialNumber))
+	require.NoError(t, err)
+	_, err = v.Verify(context.Background(), sign, nil)
+	assert.Error(t, err)
+}
+
+func TestCRLUpdater(t *testing.T) {
+	dir := genCrypto(t)
+	ca, caKey := loadCA(t, dir)
+	crl := newCRL(t, ca, caKey, time.Now())
+	server := &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 31000}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router := mock_trust.NewMockRouter(ctrl)
+	router.EXPECT().ChooseServer(gomock.Any(), addr.ISD(1)).Return(server, nil).Times(2)
+	router.EXPECT().ChooseServer(gomock.Any(), addr.ISD(2)).Return(
+		nil, serrors.New("no path")).Times(2)
+	fetcher := mock_trust.NewMockCRLFetcher(ctrl)
+	fetcher.EXPECT().CRLs(gomock.Any(), nil, server).Return(
+		[]*x509.RevocationList{crl, {AuthorityKeyId: []byte("unknown")}}, nil).Times(2)
+
+	u := trust.CRLUpdater{
+		DB: trcsDB{
+			xtest.LoadTRC(t, filepath.Join(dir, "ISD1/trcs/ISD1-B1-S1.trc")),
+			{TRC: cppki.TRC{ID: cppki.TRCID{ISD: 2, Base: 1, Serial: 1}}},
+		},
+		Store:   newRevocationStore(t, dir),
+		Fetcher: fetcher,
+		Router:  router,
+	}
+	// The CRLs of ISD 1 are fetched, even though ISD 2 cannot be reached.
+	inserted, err := u.Update(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, inserted)
+	assert.Equal(t, []*x509.RevocationList{crl}, u.Store.CRLs(nil))
+	inserted, err = u.Update(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 0, inserted)
+}
+
+// trcsDB provides the latest TRCs of the ISDs to the CRL updater.
+type trcsDB cppki.SignedTRCs
+
+func (db trcsDB) SignedTRCs(context.Context,
+	truststorage.TRCsQuery) (cppki.SignedTRCs, error) {
+
+	return cppki.SignedTRCs(db), nil
+}
+
+func (db trcsDB) Chain(context.Context, []byte) ([]*x509.Certificate, error) {
+	return nil, nil
+}
+
+// newRevocationStore returns a revocation store that resolves the CA
+// certificates from the certificate chains generated in dir.
+func newRevocationStore(t *testing.T, dir string) *trust.RevocationStore {
+	t.Helper()
+	var chains [][]*x509.Certificate
+	for _, as := range []string{"110", "111", "112"} {
+		file := filepath.Join(dir, "certs/ISD1-ASff00_0_"+as+".pem")
+		chains = append(chains, xtest.LoadChain(t, file))
+	}
+	ctrl := gomock.NewController(t)
+	db := mock_trust.NewMockDB(ctrl)
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(
+		xtest.LoadTRC(t, filepath.Join(dir, "ISD1/trcs/ISD1-B1-S1.trc")), nil).AnyTimes()
+	db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(chains, nil).AnyTimes()
+	return &trust.RevocationStore{DB: db}
+}
+
+func loadCA(t *testing.T, dir string) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+	caDir := filepath.Join(dir, "ISD1/ASff00_0_110/crypto/ca")
+	ca := xtest.LoadChain(t, filepath.Join(caDir, "ISD1-ASff00_0_110.ca.crt"))[0]
+	return ca, xtest.LoadSigner(t, filepath.Join(caDir, "cp-ca.key"))
+}
+
+func newCRL(t *testing.T, ca *x509.Certificate, key crypto.Signer, thisUpdate time.Time,
+	serials ...*big.Int) *x509.RevocationList {
+
+	t.Helper()
+	var entries []x509.RevocationListEntry
+	for _, serial := range serials {
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: thisUpdate,
+		})
+	}
+	// The CA certificates of the control plane PKI do not have the CRL signing
+	// key usage set, which is required for creating a CRL with the standard
+	// library.
+	issuer := *ca
+	issuer.KeyUsage |= x509.KeyUsageCRLSign
+	raw, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(thisUpdate.UnixNano()),
+		ThisUpdate:                thisUpdate,
+		NextUpdate:                thisUpdate.Add(24 * time.Hour),
+		RevokedCertificateEntries: entries,
+	}, &issuer, key)
+	require.NoError(t, err)
+	crl, err := x509.ParseRevocationList(raw)
+	require.NoError(t, err)
+	return crl
+}
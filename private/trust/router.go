@@ -17,10 +17,10 @@ package trust
 import (
 	"context"
 	"net"
-	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/scrypto/cppki"
 	"github.com/scionproto/scion/pkg/snet"
@@ -55,6 +55,9 @@ type AuthRouter struct {
 	ISD    addr.ISD
 	Router snet.Router
 	DB     DB
+	// Clock provides the current time against which the validity of remote
+	// TRCs is checked. If nil, the wall clock is used.
+	Clock clock.Clock
 }
 
 // ChooseServer builds a CS address for crypto with the subject in a given ISD.
@@ -95,7 +98,7 @@ func (r AuthRouter) dstISD(ctx context.Context, destination addr.ISD) (addr.ISD,
 			"reason", "remote TRC not found")
 		return r.ISD, nil
 	}
-	if !sTRC.TRC.Validity.Contains(time.Now()) {
+	if !sTRC.TRC.Validity.Contains(clock.Now(r.Clock)) {
 		logger.Info("Direct to ISD-local authoritative servers",
 			"reason", "remote TRC outside of validity period")
 		return r.ISD, nil
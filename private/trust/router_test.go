@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/pkg/scrypto/cppki"
@@ -165,3 +166,38 @@ func TestCSRouterChooseServer(t *testing.T) {
 		})
 	}
 }
+
+func TestCSRouterChooseServerClock(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	notAfter := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	db := mock_trust.NewMockDB(mctrl)
+	db.EXPECT().SignedTRC(gomock.Any(), cppki.TRCID{ISD: addr.ISD(2)}).Return(
+		cppki.SignedTRC{TRC: cppki.TRC{Validity: cppki.Validity{NotAfter: notAfter}}},
+		nil,
+	).Times(2)
+	r := mock_snet.NewMockRouter(mctrl)
+	p := mock_snet.NewMockPath(mctrl)
+	p.EXPECT().Dataplane().AnyTimes().Return(path.SCION{})
+	p.EXPECT().Destination().AnyTimes().Return(xtest.MustParseIA("1-ff00:0:110"))
+	p.EXPECT().UnderlayNextHop().AnyTimes().Return(nil)
+
+	c := clock.NewManual(notAfter.Add(-time.Hour))
+	router := trust.AuthRouter{
+		ISD:    1,
+		Router: r,
+		DB:     db,
+		Clock:  c,
+	}
+	// The remote TRC is still valid, the remote ISD is queried.
+	r.EXPECT().Route(gomock.Any(), addr.MustIAFrom(2, 0)).Return(p, nil)
+	_, err := router.ChooseServer(context.Background(), 2)
+	require.NoError(t, err)
+
+	// Once the remote TRC expired, the local ISD is queried.
+	c.Advance(2 * time.Hour)
+	r.EXPECT().Route(gomock.Any(), addr.MustIAFrom(1, 0)).Return(p, nil)
+	_, err = router.ChooseServer(context.Background(), 2)
+	require.NoError(t, err)
+}
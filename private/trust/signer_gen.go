@@ -60,7 +60,7 @@ func (s SignerGen) Generate(ctx context.Context) (Signer, error) {
 		return Signer{}, serrors.New("no private key found")
 	}
 
-	trcs, res, err := activeTRCs(ctx, s.DB, s.IA.ISD())
+	trcs, res, err := activeTRCs(ctx, s.DB, s.IA.ISD(), time.Now())
 	if err != nil {
 		metrics.Signer.Generate(l.WithResult(res)).Inc()
 		return Signer{}, serrors.WrapStr("loading TRC", err)
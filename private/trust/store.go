@@ -77,7 +77,7 @@ func LoadChains(ctx context.Context, dir string, db DB) (LoadResult, error) {
 			res.Ignored[f] = err
 			continue
 		}
-		trcs, _, err := activeTRCs(ctx, db, ia.ISD())
+		trcs, _, err := activeTRCs(ctx, db, ia.ISD(), time.Now())
 		if errors.Is(err, errNotFound) {
 			res.Ignored[f] = serrors.New("TRC not found", "isd", ia.ISD())
 			continue
@@ -32,6 +32,8 @@ const defaultTimeout = 5 * time.Second
 type TLSCryptoVerifier struct {
 	DB      DB
 	Timeout time.Duration
+	// Revocations, if set, is used to reject revoked peer certificates.
+	Revocations RevocationChecker
 }
 
 // NewTLSCryptoVerifier returns a new instance with the defaultTimeout.
@@ -130,13 +132,23 @@ func (v *TLSCryptoVerifier) verifyParsedPeerCertificate(
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), v.Timeout)
 	defer cancel()
-	trcs, _, err := activeTRCs(ctx, v.DB, ia.ISD())
+	trcs, _, err := activeTRCs(ctx, v.DB, ia.ISD(), time.Now())
 	if err != nil {
 		return 0, serrors.WrapStr("loading TRCs", err)
 	}
 	if err := verifyChain(chain, trcs); err != nil {
 		return 0, serrors.WrapStr("verifying chains", err)
 	}
+	if v.Revocations != nil {
+		revoked, err := v.Revocations.Revoked(chain)
+		if err != nil {
+			return 0, serrors.WrapStr("checking revocation status", err)
+		}
+		if revoked {
+			return 0, serrors.New("peer certificate revoked", "isd_as", ia,
+				"serial", chain[0].SerialNumber)
+		}
+	}
 	return ia, nil
 }
 
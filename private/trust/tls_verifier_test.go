@@ -15,6 +15,7 @@
 package trust_test
 
 import (
+	"context"
 	"crypto/tls"
 	"net"
 	"os/exec"
@@ -41,9 +42,17 @@ func TestTLSCryptoVerifierVerifyServerCertificate(t *testing.T) {
 	out, _ := exec.Command("tree", dir).CombinedOutput()
 	t.Log(string(out))
 
+	ca, caKey := loadCA(t, dir)
+	chain111 := xtest.LoadChain(t, crt111File)
+	revoked := newRevocationStore(t, dir)
+	_, err := revoked.InsertCRL(context.Background(),
+		newCRL(t, ca, caKey, time.Now(), chain111[0].SerialNumber))
+	require.NoError(t, err)
+
 	testCases := map[string]struct {
-		db        func(ctrl *gomock.Controller) trust.DB
-		assertErr assert.ErrorAssertionFunc
+		db          func(ctrl *gomock.Controller) trust.DB
+		revocations trust.RevocationChecker
+		assertErr   assert.ErrorAssertionFunc
 	}{
 		"valid": {
 			db: func(ctrl *gomock.Controller) trust.DB {
@@ -53,6 +62,15 @@ func TestTLSCryptoVerifierVerifyServerCertificate(t *testing.T) {
 			},
 			assertErr: assert.NoError,
 		},
+		"revoked": {
+			db: func(ctrl *gomock.Controller) trust.DB {
+				db := mock_trust.NewMockDB(ctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+				return db
+			},
+			revocations: revoked,
+			assertErr:   assert.Error,
+		},
 	}
 	for name, tc := range testCases {
 		name, tc := name, tc
@@ -62,8 +80,9 @@ func TestTLSCryptoVerifierVerifyServerCertificate(t *testing.T) {
 
 			db := tc.db(ctrl)
 			verifier := trust.TLSCryptoVerifier{
-				DB:      db,
-				Timeout: 5 * time.Second,
+				DB:          db,
+				Timeout:     5 * time.Second,
+				Revocations: tc.revocations,
 			}
 			rawChain := loadRawChain(t, crt111File)
 			err := verifier.VerifyServerCertificate(rawChain, nil)
@@ -27,6 +27,7 @@ import (
 
 	"github.com/scionproto/scion/pkg/addr"
 	libmetrics "github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/clock"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
@@ -50,12 +51,18 @@ type Verifier struct {
 	BoundServer net.Addr
 	// Engine provides verified certificate chains.
 	Engine Provider
+	// Revocations, if set, is used to discard certificate chains that have
+	// been revoked.
+	Revocations RevocationChecker
 
 	// Cache keeps track of recently used certificates. If nil no cache is used.
 	// This API is experimental.
 	Cache              *cache.Cache
 	CacheHits          libmetrics.Counter
 	MaxCacheExpiration time.Duration
+	// Clock provides the current time at which the certificate chains must be
+	// valid. If nil, the wall clock is used.
+	Clock clock.Clock
 }
 
 // Verify verifies the signature of the msg.
@@ -102,7 +109,7 @@ func (v Verifier) Verify(ctx context.Context, signedMsg *cryptopb.SignedMessage,
 	query := ChainQuery{
 		IA:           ia,
 		SubjectKeyID: keyID.SubjectKeyId,
-		Date:         time.Now(),
+		Date:         clock.Now(v.Clock),
 	}
 	chains, err := v.getChains(ctx, query)
 	if err != nil {
@@ -143,6 +150,12 @@ func (v *Verifier) notifyTRC(ctx context.Context, id cppki.TRCID) error {
 
 func (v *Verifier) getChains(ctx context.Context, q ChainQuery) ([][]*x509.Certificate, error) {
 	key := fmt.Sprintf("chain-%s-%x", q.IA, q.SubjectKeyID)
+	if v.Revocations != nil {
+		// Chains that were cached before a CRL was inserted must be checked
+		// against the new CRL. Keying the cache by the generation of the
+		// revocation information invalidates them.
+		key = fmt.Sprintf("%s-%d", key, v.Revocations.Generation())
+	}
 
 	cachedChains, ok := v.cacheGet(key, "chains")
 	if ok {
@@ -153,6 +166,7 @@ func (v *Verifier) getChains(ctx context.Context, q ChainQuery) ([][]*x509.Certi
 	if err != nil {
 		return nil, err
 	}
+	chains = filterRevoked(chains, v.Revocations)
 	if len(chains) != 0 {
 		v.cacheAdd(key, chains, v.cacheExpiration(chains))
 	}
@@ -190,11 +204,30 @@ func (v *Verifier) cacheExpiration(chains [][]*x509.Certificate) time.Duration {
 		dur = defaultCacheExpiration
 	}
 	validity := time.Duration(rand.Int63n(int64(dur-(dur/2))) + int64(dur/2))
-	expiration := time.Now().Add(validity)
+	now := clock.Now(v.Clock)
+	expiration := now.Add(validity)
 	for _, chain := range chains {
 		if notAfter := chain[0].NotAfter; notAfter.Before(expiration) {
 			expiration = notAfter
 		}
 	}
-	return time.Until(expiration)
+	return expiration.Sub(now)
+}
+
+// filterRevoked returns the chains that have not been revoked. Chains for which
+// the revocation status cannot be determined are discarded as well.
+func filterRevoked(chains [][]*x509.Certificate,
+	revocations RevocationChecker) [][]*x509.Certificate {
+
+	if revocations == nil {
+		return chains
+	}
+	filtered := make([][]*x509.Certificate, 0, len(chains))
+	for _, chain := range chains {
+		if revoked, err := revocations.Revoked(chain); err != nil || revoked {
+			continue
+		}
+		filtered = append(filtered, chain)
+	}
+	return filtered
 }
@@ -13,13 +13,16 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build go1.9 && linux
-// +build go1.9,linux
-
 // Package conn implements underlay sockets.
+//
+// The sockets are supported on Linux, macOS, the BSDs and Windows. Batch reads
+// and writes only read or write multiple packets per system call on Linux. On
+// macOS and the BSDs they read or write a single packet per system call, on
+// Windows they are emulated with the regular reads and writes.
 package conn
 
 import (
+	"context"
 	"net"
 	"syscall"
 	"time"
@@ -59,9 +62,16 @@ type Config struct {
 	// ReceiveBufferSize is the size of the operating system receive buffer, in
 	// bytes.
 	ReceiveBufferSize int
+	// ReusePort sets SO_REUSEPORT on listening sockets, such that multiple
+	// sockets can be bound to the same address. On Linux, the kernel then
+	// distributes the incoming packets among the sockets by flow; on macOS
+	// and the BSDs, only one of the sockets receives the packets. It is not
+	// supported on Windows. It has no effect on connected sockets.
+	ReusePort bool
 }
 
-// New opens a new underlay socket on the specified addresses.
+// New opens a new underlay socket on the specified addresses. If both are
+// set, they must be of the same IP family.
 //
 // The config can be used to customize socket behavior.
 func New(listen, remote *net.UDPAddr, cfg *Config) (Conn, error) {
@@ -72,12 +82,32 @@ func New(listen, remote *net.UDPAddr, cfg *Config) (Conn, error) {
 	if listen == nil && remote == nil {
 		panic("either listen or remote must be set")
 	}
+	if listen != nil && remote != nil && (listen.IP.To4() == nil) != (remote.IP.To4() == nil) {
+		return nil, serrors.New("listen and remote address family mismatch",
+			"listen", listen, "remote", remote)
+	}
 	if a.IP.To4() != nil {
 		return newConnUDPIPv4(listen, remote, cfg)
 	}
 	return newConnUDPIPv6(listen, remote, cfg)
 }
 
+// NewFromUDPConn wraps an already open socket, e.g., one that was inherited
+// from another process. The socket options, e.g., the buffer sizes, are kept
+// as they are.
+func NewFromUDPConn(c *net.UDPConn) (Conn, error) {
+	listen, ok := c.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		return nil, serrors.New("not a UDP socket", "addr", c.LocalAddr())
+	}
+	remote, _ := c.RemoteAddr().(*net.UDPAddr)
+	base := connUDPBase{conn: c, Listen: listen, Remote: remote}
+	if listen.IP.To4() != nil {
+		return &connUDPIPv4{connUDPBase: base, pconn: ipv4.NewPacketConn(c)}, nil
+	}
+	return &connUDPIPv6{connUDPBase: base, pconn: ipv6.NewPacketConn(c)}, nil
+}
+
 type connUDPIPv4 struct {
 	connUDPBase
 	pconn *ipv4.PacketConn
@@ -95,11 +125,17 @@ func newConnUDPIPv4(listen, remote *net.UDPAddr, cfg *Config) (*connUDPIPv4, err
 // ReadBatch reads up to len(msgs) packets, and stores them in msgs.
 // It returns the number of packets read, and an error if any.
 func (c *connUDPIPv4) ReadBatch(msgs Messages) (int, error) {
-	n, err := c.pconn.ReadBatch(msgs, syscall.MSG_WAITFORONE)
+	if !batchSupported {
+		return c.readSingle(msgs)
+	}
+	n, err := c.pconn.ReadBatch(msgs, readBatchFlags)
 	return n, err
 }
 
 func (c *connUDPIPv4) WriteBatch(msgs Messages, flags int) (int, error) {
+	if !batchSupported {
+		return c.writeSingle(msgs)
+	}
 	return c.pconn.WriteBatch(msgs, flags)
 }
 
@@ -133,11 +169,17 @@ func newConnUDPIPv6(listen, remote *net.UDPAddr, cfg *Config) (*connUDPIPv6, err
 // ReadBatch reads up to len(msgs) packets, and stores them in msgs.
 // It returns the number of packets read, and an error if any.
 func (c *connUDPIPv6) ReadBatch(msgs Messages) (int, error) {
-	n, err := c.pconn.ReadBatch(msgs, syscall.MSG_WAITFORONE)
+	if !batchSupported {
+		return c.readSingle(msgs)
+	}
+	n, err := c.pconn.ReadBatch(msgs, readBatchFlags)
 	return n, err
 }
 
 func (c *connUDPIPv6) WriteBatch(msgs Messages, flags int) (int, error) {
+	if !batchSupported {
+		return c.writeSingle(msgs)
+	}
 	return c.pconn.WriteBatch(msgs, flags)
 }
 
@@ -168,7 +210,7 @@ func (cc *connUDPBase) initConnUDP(network string, laddr, raddr *net.UDPAddr, cf
 		return serrors.New("listen address must be specified")
 	}
 	if raddr == nil {
-		if c, err = net.ListenUDP(network, laddr); err != nil {
+		if c, err = listenUDP(network, laddr, cfg.ReusePort); err != nil {
 			return serrors.WrapStr("Error listening on socket", err,
 				"network", network, "listen", laddr)
 		}
@@ -202,13 +244,11 @@ func (cc *connUDPBase) initConnUDP(network string, laddr, raddr *net.UDPAddr, cf
 				"remote", raddr,
 			)
 		}
-		if after/2 < target {
-			// Note: kernel doubles value passed in SetSendBuffer, value
-			// returned is the doubled value
+		if after/bufferSizeFactor < target {
 			log.Info("Send buffer size smaller than requested",
 				"expected", target,
-				"actual", after/2,
-				"before", before/2,
+				"actual", after/bufferSizeFactor,
+				"before", before/bufferSizeFactor,
 			)
 		}
 	}
@@ -236,13 +276,11 @@ func (cc *connUDPBase) initConnUDP(network string, laddr, raddr *net.UDPAddr, cf
 				"remote", raddr,
 			)
 		}
-		if after/2 < target {
-			// Note: kernel doubles value passed in SetReadBuffer, value
-			// returned is the doubled value
+		if after/bufferSizeFactor < target {
 			log.Info("Receive buffer size smaller than requested",
 				"expected", target,
-				"actual", after/2,
-				"before", before/2,
+				"actual", after/bufferSizeFactor,
+				"before", before/bufferSizeFactor,
 			)
 		}
 	}
@@ -268,6 +306,44 @@ func (c *connUDPBase) WriteTo(b []byte, dst *net.UDPAddr) (int, error) {
 	return c.conn.WriteTo(b, dst)
 }
 
+// readSingle reads a single packet into the first buffer of the first
+// message. It is used on platforms that do not support batch reads.
+func (c *connUDPBase) readSingle(msgs Messages) (int, error) {
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+	n, src, err := c.conn.ReadFromUDP(msgs[0].Buffers[0])
+	if err != nil {
+		return 0, err
+	}
+	msgs[0].N = n
+	msgs[0].Addr = src
+	return 1, nil
+}
+
+// writeSingle writes the first buffer of each message as a separate packet.
+// It is used on platforms that do not support batch writes.
+func (c *connUDPBase) writeSingle(msgs Messages) (int, error) {
+	for i := range msgs {
+		var n int
+		var err error
+		if c.Remote != nil {
+			n, err = c.conn.Write(msgs[i].Buffers[0])
+		} else {
+			dst, ok := msgs[i].Addr.(*net.UDPAddr)
+			if !ok {
+				return i, serrors.New("invalid destination address", "addr", msgs[i].Addr)
+			}
+			n, err = c.conn.WriteToUDP(msgs[i].Buffers[0], dst)
+		}
+		if err != nil {
+			return i, err
+		}
+		msgs[i].N = n
+	}
+	return len(msgs), nil
+}
+
 func (c *connUDPBase) LocalAddr() *net.UDPAddr {
 	return c.Listen
 }
@@ -276,6 +352,11 @@ func (c *connUDPBase) RemoteAddr() *net.UDPAddr {
 	return c.Remote
 }
 
+// SyscallConn returns the raw socket, e.g., to pass it to another process.
+func (c *connUDPBase) SyscallConn() (syscall.RawConn, error) {
+	return c.conn.SyscallConn()
+}
+
 func (c *connUDPBase) Close() error {
 	if c.closed {
 		return nil
@@ -284,8 +365,30 @@ func (c *connUDPBase) Close() error {
 	return c.conn.Close()
 }
 
-// NewReadMessages allocates memory for reading IPv4 Linux network stack
-// messages.
+func listenUDP(network string, laddr *net.UDPAddr, reusePort bool) (*net.UDPConn, error) {
+	if !reusePort {
+		return net.ListenUDP(network, laddr)
+	}
+	lc := net.ListenConfig{
+		Control: func(_, _ string, rc syscall.RawConn) error {
+			var sockErr error
+			err := rc.Control(func(fd uintptr) {
+				sockErr = setReusePort(fd)
+			})
+			if err != nil {
+				return err
+			}
+			return sockErr
+		},
+	}
+	c, err := lc.ListenPacket(context.Background(), network, laddr.String())
+	if err != nil {
+		return nil, err
+	}
+	return c.(*net.UDPConn), nil
+}
+
+// NewReadMessages allocates memory for reading IPv4 network stack messages.
 func NewReadMessages(n int) Messages {
 	m := make(Messages, n)
 	for i := range m {
@@ -0,0 +1,37 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// batchSupported indicates that the sockets read and write batches of packets.
+const batchSupported = true
+
+// readBatchFlags are the flags of the batch reads. With MSG_WAITFORONE, a
+// batch read returns as soon as at least one packet was read.
+const readBatchFlags = syscall.MSG_WAITFORONE
+
+// bufferSizeFactor is the factor between the socket buffer sizes reported by
+// the kernel and the configured ones. The kernel doubles the configured sizes
+// to account for its bookkeeping overhead.
+const bufferSizeFactor = 2
+
+func setReusePort(fd uintptr) error {
+	return syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, unix.SO_REUSEPORT, 1)
+}
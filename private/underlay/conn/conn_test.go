@@ -0,0 +1,94 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnBatch(t *testing.T) {
+	testCases := map[string]struct {
+		Read  func(c Conn, msgs Messages) (int, error)
+		Write func(c Conn, msgs Messages) (int, error)
+	}{
+		"batch": {
+			Read:  func(c Conn, msgs Messages) (int, error) { return c.ReadBatch(msgs) },
+			Write: func(c Conn, msgs Messages) (int, error) { return c.WriteBatch(msgs, 0) },
+		},
+		// The fallback for platforms without batch support is exercised on
+		// all platforms.
+		"single": {
+			Read: func(c Conn, msgs Messages) (int, error) {
+				return c.(*connUDPIPv4).readSingle(msgs)
+			},
+			Write: func(c Conn, msgs Messages) (int, error) {
+				return c.(*connUDPIPv4).writeSingle(msgs)
+			},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			local := &net.UDPAddr{IP: net.IP{127, 0, 0, 1}}
+			server, err := New(local, nil, &Config{})
+			require.NoError(t, err)
+			defer server.Close()
+			serverAddr := server.(*connUDPIPv4).conn.LocalAddr().(*net.UDPAddr)
+			client, err := New(local, nil, &Config{})
+			require.NoError(t, err)
+			defer client.Close()
+
+			out := Messages{
+				{Buffers: [][]byte{[]byte("first")}, Addr: serverAddr},
+				{Buffers: [][]byte{[]byte("second")}, Addr: serverAddr},
+			}
+			n, err := tc.Write(client, out)
+			require.NoError(t, err)
+			assert.Equal(t, 2, n)
+
+			require.NoError(t, server.SetReadDeadline(time.Now().Add(5*time.Second)))
+			var payloads []string
+			for len(payloads) < 2 {
+				in := NewReadMessages(2)
+				for i := range in {
+					in[i].Buffers[0] = make([]byte, 1500)
+				}
+				n, err := tc.Read(server, in)
+				require.NoError(t, err)
+				for _, msg := range in[:n] {
+					payloads = append(payloads, string(msg.Buffers[0][:msg.N]))
+				}
+			}
+			assert.Equal(t, []string{"first", "second"}, payloads)
+		})
+	}
+}
+
+func TestConnWriteSingleInvalidAddr(t *testing.T) {
+	c, err := New(&net.UDPAddr{IP: net.IP{127, 0, 0, 1}}, nil, &Config{})
+	require.NoError(t, err)
+	defer c.Close()
+
+	msgs := Messages{{Buffers: [][]byte{[]byte("payload")}}}
+	n, err := c.(*connUDPIPv4).writeSingle(msgs)
+	assert.Error(t, err)
+	assert.Zero(t, n)
+}
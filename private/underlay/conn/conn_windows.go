@@ -0,0 +1,35 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// batchSupported indicates that the sockets read and write batches of packets.
+// Windows does not support the batch operations, they are emulated with the
+// regular reads and writes.
+const batchSupported = false
+
+// readBatchFlags are the flags of the batch reads.
+const readBatchFlags = 0
+
+// bufferSizeFactor is the factor between the socket buffer sizes reported by
+// the kernel and the configured ones.
+const bufferSizeFactor = 1
+
+func setReusePort(uintptr) error {
+	return serrors.New("SO_REUSEPORT is not supported on windows")
+}
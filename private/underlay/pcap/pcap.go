@@ -0,0 +1,69 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pcap wraps SCION packets in the IP and UDP headers of their underlay
+// addresses, so that they can be written to pcap files that Wireshark
+// dissects as SCION without further configuration.
+package pcap
+
+import (
+	"net"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/private/topology/underlay"
+)
+
+// Serialize serializes the raw SCION packet, sent from src to dst in the
+// underlay, with its IP and UDP headers to buf. Addresses without an IP are
+// replaced with the unspecified IPv4 address and the end host port. IPv6
+// headers are used unless both addresses are IPv4 addresses.
+func Serialize(buf gopacket.SerializeBuffer, raw []byte, src, dst *net.UDPAddr) error {
+	src, dst = underlayOrDefault(src), underlayOrDefault(dst)
+	udp := &layers.UDP{SrcPort: layers.UDPPort(src.Port), DstPort: layers.UDPPort(dst.Port)}
+	var ip interface {
+		gopacket.NetworkLayer
+		gopacket.SerializableLayer
+	}
+	if src.IP.To4() != nil && dst.IP.To4() != nil {
+		ip = &layers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    src.IP.To4(),
+			DstIP:    dst.IP.To4(),
+		}
+	} else {
+		ip = &layers.IPv6{
+			Version:    6,
+			HopLimit:   64,
+			NextHeader: layers.IPProtocolUDP,
+			SrcIP:      src.IP.To16(),
+			DstIP:      dst.IP.To16(),
+		}
+	}
+	if err := udp.SetNetworkLayerForChecksum(ip); err != nil {
+		return err
+	}
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	return gopacket.SerializeLayers(buf, opts, ip, udp, gopacket.Payload(raw))
+}
+
+func underlayOrDefault(a *net.UDPAddr) *net.UDPAddr {
+	if a == nil || a.IP == nil {
+		return &net.UDPAddr{IP: net.IPv4zero, Port: underlay.EndhostPort}
+	}
+	return a
+}
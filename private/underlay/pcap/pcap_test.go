@@ -0,0 +1,87 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pcap_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/topology/underlay"
+	"github.com/scionproto/scion/private/underlay/pcap"
+)
+
+func TestSerialize(t *testing.T) {
+	raw := []byte("scion packet")
+	testCases := map[string]struct {
+		Src, Dst  *net.UDPAddr
+		SrcIP     net.IP
+		DstIP     net.IP
+		SrcPort   layers.UDPPort
+		DstPort   layers.UDPPort
+		IPVersion gopacket.LayerType
+	}{
+		"IPv4": {
+			Src:       &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 30042},
+			Dst:       &net.UDPAddr{IP: net.ParseIP("192.0.2.2"), Port: 31000},
+			SrcIP:     net.ParseIP("192.0.2.1").To4(),
+			DstIP:     net.ParseIP("192.0.2.2").To4(),
+			SrcPort:   30042,
+			DstPort:   31000,
+			IPVersion: layers.LayerTypeIPv4,
+		},
+		"IPv6": {
+			Src:       &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 30042},
+			Dst:       &net.UDPAddr{IP: net.ParseIP("2001:db8::2"), Port: 31000},
+			SrcIP:     net.ParseIP("2001:db8::1"),
+			DstIP:     net.ParseIP("2001:db8::2"),
+			SrcPort:   30042,
+			DstPort:   31000,
+			IPVersion: layers.LayerTypeIPv6,
+		},
+		"unknown source": {
+			Dst:       &net.UDPAddr{IP: net.ParseIP("192.0.2.2"), Port: 31000},
+			SrcIP:     net.IPv4zero.To4(),
+			DstIP:     net.ParseIP("192.0.2.2").To4(),
+			SrcPort:   underlay.EndhostPort,
+			DstPort:   31000,
+			IPVersion: layers.LayerTypeIPv4,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			buf := gopacket.NewSerializeBuffer()
+			require.NoError(t, pcap.Serialize(buf, raw, tc.Src, tc.Dst))
+
+			pkt := gopacket.NewPacket(buf.Bytes(), tc.IPVersion, gopacket.Default)
+			require.Nil(t, pkt.ErrorLayer())
+			ip := pkt.NetworkLayer()
+			require.NotNil(t, ip)
+			assert.Equal(t, tc.IPVersion, ip.LayerType())
+			assert.Equal(t, tc.SrcIP, net.IP(ip.NetworkFlow().Src().Raw()))
+			assert.Equal(t, tc.DstIP, net.IP(ip.NetworkFlow().Dst().Raw()))
+			udp, ok := pkt.Layer(layers.LayerTypeUDP).(*layers.UDP)
+			require.True(t, ok)
+			assert.Equal(t, tc.SrcPort, udp.SrcPort)
+			assert.Equal(t, tc.DstPort, udp.DstPort)
+			assert.Equal(t, raw, udp.Payload)
+		})
+	}
+}
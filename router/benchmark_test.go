@@ -0,0 +1,208 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/private/topology"
+)
+
+// The benchmarks in this file measure the cost of the individual steps of the
+// packet processing pipeline. To profile a running router instead, see the
+// profiling_addr option of the router configuration.
+
+func BenchmarkProcessPkt(b *testing.B) {
+	testCases := map[string]struct {
+		prepare func(b *testing.B) []byte
+		ingress uint16
+	}{
+		"inbound": {
+			prepare: func(b *testing.B) []byte {
+				spkt := prepBaseMsg(b, []byte("actualpayloadbytes"), 0)
+				require.NoError(b, spkt.SetDstAddr(addr.MustParseHost("10.0.100.100")))
+				return toMsg(b, spkt)
+			},
+			ingress: 1,
+		},
+		"br transit": {
+			prepare: func(b *testing.B) []byte {
+				return toMsg(b, prepTransitMsg(b))
+			},
+			ingress: 1,
+		},
+	}
+	for name, tc := range testCases {
+		b.Run(name, func(b *testing.B) {
+			dp := prepBenchmarkDP()
+			p := newPacketProcessor(dp)
+			orig := tc.prepare(b)
+			raw := make([]byte, len(orig), bufSize)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// Processing modifies the packet in place.
+				copy(raw, orig)
+				if _, err := p.processPkt(raw, nil, tc.ingress); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyCurrentMAC(b *testing.B) {
+	p := newPacketProcessor(prepBenchmarkDP())
+	require.NoError(b, p.reset())
+	spkt := prepBaseMsg(b, []byte("actualpayloadbytes"), 0)
+	dpath := spkt.Path.(*scion.Decoded)
+	p.infoField = dpath.InfoFields[0]
+	p.hopField = dpath.HopFields[2]
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := p.verifyCurrentMAC(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHopFieldMAC(b *testing.B) {
+	var input, full [path.MACBufferSize]byte
+	b.Run("generic cmac", func(b *testing.B) {
+		mac, err := scrypto.InitMac(testKey)
+		require.NoError(b, err)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			mac.Reset()
+			mac.Write(input[:])
+			mac.Sum(full[:0])
+		}
+	})
+	for _, alg := range []scrypto.MACAlgorithm{
+		scrypto.MACAlgorithmAESCMAC,
+		scrypto.MACAlgorithmHMACSHA256,
+	} {
+		b.Run(string(alg), func(b *testing.B) {
+			mac, err := newHopFieldMAC(alg, testKey)
+			require.NoError(b, err)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				mac.compute(&full, &input)
+			}
+		})
+	}
+}
+
+// BenchmarkProcessBatch measures the processing of a batch of transit packets
+// with and without precomputing their MACs, optionally in parallel. The
+// reported time is per packet.
+func BenchmarkProcessBatch(b *testing.B) {
+	const batchSize = 32
+	orig := toMsg(b, prepTransitMsg(b))
+	for name, workers := range map[string]int{
+		"per packet":       -1,
+		"batch":            0,
+		"batch, 2 workers": 2,
+	} {
+		precompute := workers >= 0
+		b.Run(name, func(b *testing.B) {
+			p := newPacketProcessor(prepBenchmarkDP())
+			if precompute {
+				var pool *macWorkerPool
+				if workers > 0 {
+					pool = newMACWorkerPool(workers)
+				}
+				p.batch = newMACBatch(batchSize, pool)
+			}
+			pkts := make([]packet, batchSize)
+			for i := range pkts {
+				pkts[i] = packet{rawPacket: make([]byte, len(orig), bufSize), ingress: 1}
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i += batchSize {
+				for _, pkt := range pkts {
+					// Processing modifies the packet in place.
+					copy(pkt.rawPacket, orig)
+				}
+				if precompute {
+					p.updateMACs()
+					p.batch.precompute(pkts, p.macs)
+				}
+				for j, pkt := range pkts {
+					if p.batch != nil {
+						p.batch.current = j
+					}
+					if _, err := p.processPkt(pkt.rawPacket, nil, pkt.ingress); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkUpdateSCIONLayer(b *testing.B) {
+	p := newPacketProcessor(prepBenchmarkDP())
+	raw := toMsg(b, prepTransitMsg(b))
+	require.NoError(b, p.scionLayer.DecodeFromBytes(raw, gopacket.NilDecodeFeedback))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := updateSCIONLayer(raw, p.scionLayer, p.buffer); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// prepBenchmarkDP creates a data plane of the AS 1-ff00:0:110 with a parent
+// interface 1 and a child interface 2.
+func prepBenchmarkDP() *DataPlane {
+	return NewDP(
+		map[uint16]BatchConn{1: nil, 2: nil},
+		map[uint16]topology.LinkType{1: topology.Parent, 2: topology.Child},
+		nil,
+		map[uint16]*net.UDPAddr{},
+		nil,
+		xtest.MustParseIA("1-ff00:0:110"),
+		nil,
+		testKey,
+	)
+}
+
+// prepTransitMsg prepares a packet that enters the AS on interface 1 and
+// leaves it on interface 2.
+func prepTransitMsg(t testing.TB) *slayers.SCION {
+	spkt := prepBaseMsg(t, []byte("actualpayloadbytes"), 0)
+	spkt.DstIA = xtest.MustParseIA("1-ff00:0:113")
+	dpath := spkt.Path.(*scion.Decoded)
+	dpath.PathMeta.CurrHF = 1
+	dpath.HopFields[1] = path.HopField{ConsIngress: 1, ConsEgress: 2}
+	dpath.HopFields[1].Mac = computeMAC(t, testKey, dpath.InfoFields[0], dpath.HopFields[1])
+	return spkt
+}
@@ -169,6 +169,10 @@ func (s *Session) String() string {
 // Run initializes the Session's timers and state machine, and starts sending out BFD control
 // packets on the point to point link.
 //
+// Run returns once the Session is closed or ctx is done. If ctx is done, a final control
+// packet in the Down state is sent to the remote end, such that it can take the link down
+// without waiting for its detection time to expire.
+//
 // Run must only be called once.
 func (s *Session) Run(ctx context.Context) error {
 	logger := log.FromCtx(ctx)
@@ -248,21 +252,7 @@ MainLoop:
 			// Send timer guaranteed to be expired, so we can reset.
 			sendTimer.Reset(s.computeNextSendInterval())
 
-			// These conversions are guaranteed to not return an error, because the input has been
-			// sanitized.
-			desiredMinTxInterval, _ := durationToBFDInterval(s.desiredMinTXInterval)
-			requiredMinRxInterval, _ := durationToBFDInterval(s.RequiredMinRxInterval)
-
-			*pkt = layers.BFD{
-				Version:               1,
-				State:                 layers.BFDState(s.getLocalState()),
-				DetectMultiplier:      s.DetectMult,
-				MyDiscriminator:       s.LocalDiscriminator,
-				YourDiscriminator:     s.remoteDiscriminator,
-				DesiredMinTxInterval:  desiredMinTxInterval,
-				RequiredMinRxInterval: requiredMinRxInterval,
-			}
-
+			s.fillPacket(pkt, s.getLocalState())
 			if err := s.Sender.Send(pkt); err != nil {
 				logger.Debug("error sending message", "err", err)
 				continue
@@ -286,11 +276,50 @@ MainLoop:
 				// avoid flooding the network while the session is down.
 				s.desiredMinTXInterval = defaultTransmissionInterval
 			}
+		case <-ctx.Done():
+			s.sendFinal(logger, pkt)
+			break MainLoop
 		}
 	}
 	return nil
 }
 
+// sendFinal announces the Down state to the remote end, if the remote end is
+// known.
+func (s *Session) sendFinal(logger log.Logger, pkt *layers.BFD) {
+	s.setLocalState(stateDown)
+	if s.getRemoteDiscriminator() == 0 {
+		return
+	}
+	s.fillPacket(pkt, stateDown)
+	if err := s.Sender.Send(pkt); err != nil {
+		logger.Debug("error sending final message", "err", err)
+		return
+	}
+	if s.Metrics.PacketsSent != nil {
+		s.Metrics.PacketsSent.Add(1)
+	}
+}
+
+// fillPacket populates pkt with the Session's current parameters, announcing
+// the local state st.
+func (s *Session) fillPacket(pkt *layers.BFD, st state) {
+	// These conversions are guaranteed to not return an error, because the input has been
+	// sanitized.
+	desiredMinTxInterval, _ := durationToBFDInterval(s.desiredMinTXInterval)
+	requiredMinRxInterval, _ := durationToBFDInterval(s.RequiredMinRxInterval)
+
+	*pkt = layers.BFD{
+		Version:               1,
+		State:                 layers.BFDState(st),
+		DetectMultiplier:      s.DetectMult,
+		MyDiscriminator:       s.LocalDiscriminator,
+		YourDiscriminator:     s.getRemoteDiscriminator(),
+		DesiredMinTxInterval:  desiredMinTxInterval,
+		RequiredMinRxInterval: requiredMinRxInterval,
+	}
+}
+
 func (s *Session) Close() error {
 	s.initMessages()
 	close(s.messages)
@@ -28,6 +28,7 @@ import (
 
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/log/testlog"
+	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/router/bfd"
 )
 
@@ -404,6 +405,65 @@ func TestSessionDebootstrap(t *testing.T) {
 	wg.Wait()
 }
 
+func TestSessionShutdown(t *testing.T) {
+	// The detection time of the sessions is 1s; B is expected to go down
+	// well before that once A is shut down.
+	sessionA := &bfd.Session{
+		DetectMult:            10,
+		DesiredMinTxInterval:  100 * time.Millisecond,
+		RequiredMinRxInterval: 100 * time.Millisecond,
+		LocalDiscriminator:    1,
+		ReceiveQueueSize:      10,
+	}
+	sessionB := &bfd.Session{
+		DetectMult:            10,
+		DesiredMinTxInterval:  100 * time.Millisecond,
+		RequiredMinRxInterval: 100 * time.Millisecond,
+		LocalDiscriminator:    2,
+		ReceiveQueueSize:      10,
+	}
+	loggerA := testlog.NewLogger(t).New("session", "loggerA")
+	loggerB := testlog.NewLogger(t).New("session", "loggerB")
+	sessionA.SetLogger(loggerA)
+	sessionB.SetLogger(loggerB)
+
+	linkAToB := &redirectSender{Destination: sessionB}
+	linkBToA := &redirectSender{Destination: sessionA}
+	sessionA.Sender = linkAToB
+	sessionB.Sender = linkBToA
+
+	ctxA, cancelA := context.WithCancel(log.CtxWith(context.Background(), loggerA))
+	defer cancelA()
+	doneA := make(chan struct{})
+	go func() {
+		defer close(doneA)
+		err := sessionA.Run(ctxA)
+		require.NoError(t, err)
+	}()
+	doneB := make(chan struct{})
+	go func() {
+		defer close(doneB)
+		err := sessionB.Run(log.CtxWith(context.Background(), loggerB))
+		require.NoError(t, err)
+	}()
+	linkAToB.Sending(true)
+	linkBToA.Sending(true)
+
+	require.Eventually(t, func() bool { return sessionA.IsUp() && sessionB.IsUp() },
+		3*time.Second, 10*time.Millisecond)
+
+	cancelA()
+	xtest.AssertReadReturnsBefore(t, doneA, time.Second)
+	linkBToA.Sending(false)
+	assert.False(t, sessionA.IsUp())
+	assert.Eventually(t, func() bool { return !sessionB.IsUp() },
+		300*time.Millisecond, 10*time.Millisecond)
+
+	linkBToA.Close()
+	linkAToB.Close()
+	xtest.AssertReadReturnsBefore(t, doneB, time.Second)
+}
+
 func TestSessionRun(t *testing.T) {
 	testCases := map[string]struct {
 		session *bfd.Session
@@ -0,0 +1,373 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/private/underlay/pcap"
+	"github.com/scionproto/scion/router/control"
+)
+
+// captureQueueSize is the number of captured packets that can be queued for
+// the sink. Sampled packets are dropped if the queue is full.
+const captureQueueSize = 1024
+
+// captureSnapLen is the snapshot length of the pcap files.
+const captureSnapLen = 65535
+
+// StartCapture starts mirroring the forwarded packets that match the
+// configuration to its sink. A running capture is stopped first.
+func (d *DataPlane) StartCapture(cfg control.CaptureConfig) error {
+	if cfg.Fraction <= 0 || cfg.Fraction > 1 {
+		return serrors.New("fraction must be in (0, 1]", "fraction", cfg.Fraction)
+	}
+	var sink captureSink
+	var err error
+	switch {
+	case cfg.File != "" && cfg.UDPSink != nil:
+		return serrors.New("only one of file and UDP sink can be set")
+	case cfg.File != "":
+		sink, err = newPcapSink(d.CaptureDir, cfg.File)
+	case cfg.UDPSink != nil:
+		sink, err = newUDPSink(cfg.UDPSink)
+	default:
+		return serrors.New("either file or UDP sink must be set")
+	}
+	if err != nil {
+		return err
+	}
+	c := newPacketCapture(cfg, sink)
+	d.mtx.Lock()
+	old := d.packetCapture()
+	d.capture.Store(c)
+	d.mtx.Unlock()
+	if old != nil {
+		old.stop()
+	}
+	log.Info("Started packet capture", "fraction", cfg.Fraction, "src_isd_as", cfg.SrcIA,
+		"dst_isd_as", cfg.DstIA, "file", cfg.File, "udp_sink", cfg.UDPSink)
+	return nil
+}
+
+// StopCapture stops the running capture and returns its final status.
+func (d *DataPlane) StopCapture() control.CaptureStatus {
+	d.mtx.Lock()
+	c := d.packetCapture()
+	d.capture.Store((*packetCapture)(nil))
+	d.mtx.Unlock()
+	if c == nil {
+		return control.CaptureStatus{}
+	}
+	c.stop()
+	status := c.status()
+	status.Active = false
+	log.Info("Stopped packet capture", "captured", status.Captured, "dropped", status.Dropped)
+	return status
+}
+
+// CaptureStatus returns the status of the running capture.
+func (d *DataPlane) CaptureStatus() control.CaptureStatus {
+	c := d.packetCapture()
+	if c == nil {
+		return control.CaptureStatus{}
+	}
+	return c.status()
+}
+
+// packetCapture returns the running capture, or nil.
+func (d *DataPlane) packetCapture() *packetCapture {
+	c, _ := d.capture.Load().(*packetCapture)
+	return c
+}
+
+// packetCapture mirrors a sample of the forwarded packets to a sink. The
+// packets are sampled by the packet processors, and written to the sink by a
+// separate goroutine, so that a slow sink does not slow down the forwarding.
+type packetCapture struct {
+	cfg   control.CaptureConfig
+	sink  captureSink
+	queue chan capturedPacket
+	stopC chan struct{}
+	done  chan struct{}
+	once  sync.Once
+
+	captured uint64
+	dropped  uint64
+}
+
+// capturedPacket is a copy of a forwarded packet.
+type capturedPacket struct {
+	ts      time.Time
+	ingress uint16
+	egress  uint16
+	// src and dst are the underlay addresses the packet was received from and
+	// is sent to. They are nil if unknown.
+	src *net.UDPAddr
+	dst *net.UDPAddr
+	raw []byte
+}
+
+type captureSink interface {
+	write(pkt capturedPacket, scn *slayers.SCION) error
+	close() error
+}
+
+func newPacketCapture(cfg control.CaptureConfig, sink captureSink) *packetCapture {
+	c := &packetCapture{
+		cfg:   cfg,
+		sink:  sink,
+		queue: make(chan capturedPacket, captureQueueSize),
+		stopC: make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go func() {
+		defer log.HandlePanic()
+		c.run()
+	}()
+	return c
+}
+
+// capture samples the forwarded packet raw. It is called by the packet
+// processors and does not block.
+func (c *packetCapture) capture(raw []byte, src, dst *net.UDPAddr, ingress, egress uint16) {
+	if !c.matches(raw) {
+		return
+	}
+	if c.cfg.Fraction < 1 && rand.Float64() >= c.cfg.Fraction {
+		return
+	}
+	pkt := capturedPacket{
+		ts:      time.Now(),
+		ingress: ingress,
+		egress:  egress,
+		src:     src,
+		dst:     dst,
+		raw:     append([]byte(nil), raw...),
+	}
+	select {
+	case c.queue <- pkt:
+	default:
+		atomic.AddUint64(&c.dropped, 1)
+	}
+}
+
+// matches checks the ISD-AS filters against the address header of the raw
+// packet, without decoding it.
+func (c *packetCapture) matches(raw []byte) bool {
+	if len(raw) < slayers.CmnHdrLen+2*addr.IABytes {
+		return false
+	}
+	dst := addr.IA(binary.BigEndian.Uint64(raw[slayers.CmnHdrLen:]))
+	src := addr.IA(binary.BigEndian.Uint64(raw[slayers.CmnHdrLen+addr.IABytes:]))
+	return matchIA(c.cfg.SrcIA, src) && matchIA(c.cfg.DstIA, dst)
+}
+
+func matchIA(filter, ia addr.IA) bool {
+	return (filter.ISD() == 0 || filter.ISD() == ia.ISD()) &&
+		(filter.AS() == 0 || filter.AS() == ia.AS())
+}
+
+func (c *packetCapture) run() {
+	defer close(c.done)
+	var scn slayers.SCION
+	for {
+		select {
+		case <-c.stopC:
+			// Write the packets that were queued before the capture was
+			// stopped.
+			for {
+				select {
+				case pkt := <-c.queue:
+					c.write(pkt, &scn)
+				default:
+					return
+				}
+			}
+		case pkt := <-c.queue:
+			c.write(pkt, &scn)
+		}
+	}
+}
+
+func (c *packetCapture) write(pkt capturedPacket, scn *slayers.SCION) {
+	// Only SCION packets are captured, the router also forwards other
+	// packets, e.g., STUN responses.
+	if err := scn.DecodeFromBytes(pkt.raw, gopacket.NilDecodeFeedback); err != nil {
+		return
+	}
+	if err := c.sink.write(pkt, scn); err != nil {
+		log.Debug("Failed to write captured packet", "err", err)
+		atomic.AddUint64(&c.dropped, 1)
+		return
+	}
+	atomic.AddUint64(&c.captured, 1)
+}
+
+func (c *packetCapture) stop() {
+	c.once.Do(func() {
+		close(c.stopC)
+		<-c.done
+		if err := c.sink.close(); err != nil {
+			log.Info("Failed to close packet capture sink", "err", err)
+		}
+	})
+}
+
+func (c *packetCapture) status() control.CaptureStatus {
+	return control.CaptureStatus{
+		Active:   true,
+		Config:   c.cfg,
+		Captured: atomic.LoadUint64(&c.captured),
+		Dropped:  atomic.LoadUint64(&c.dropped),
+	}
+}
+
+// pcapSink writes the captured packets to a pcap file. The packets are
+// wrapped in IP/UDP headers with their underlay addresses, so that packet
+// analyzers dissect them as SCION. Unknown underlay addresses are replaced
+// with the unspecified address and the end host port.
+type pcapSink struct {
+	f   *os.File
+	w   *pcapgo.Writer
+	buf gopacket.SerializeBuffer
+}
+
+// newPcapSink creates the pcap file with the given name in the capture
+// directory. Existing files are not overwritten.
+func newPcapSink(dir, file string) (*pcapSink, error) {
+	if dir == "" {
+		return nil, serrors.New("no capture directory configured")
+	}
+	if err := control.ValidateCaptureFile(file); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, file), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, serrors.WrapStr("creating capture file", err)
+	}
+	w := pcapgo.NewWriter(f)
+	if err := w.WriteFileHeader(captureSnapLen, layers.LinkTypeRaw); err != nil {
+		f.Close()
+		return nil, serrors.WrapStr("writing capture file header", err)
+	}
+	return &pcapSink{f: f, w: w, buf: gopacket.NewSerializeBuffer()}, nil
+}
+
+func (s *pcapSink) write(pkt capturedPacket, _ *slayers.SCION) error {
+	if err := pcap.Serialize(s.buf, pkt.raw, pkt.src, pkt.dst); err != nil {
+		return err
+	}
+	data := s.buf.Bytes()
+	return s.w.WritePacket(gopacket.CaptureInfo{
+		Timestamp:     pkt.ts,
+		CaptureLength: len(data),
+		Length:        len(data),
+	}, data)
+}
+
+func (s *pcapSink) close() error {
+	return s.f.Close()
+}
+
+// CaptureRecord is the JSON record that is sent to the UDP sink for each
+// captured packet.
+type CaptureRecord struct {
+	Time             time.Time `json:"time"`
+	IngressInterface uint16    `json:"ingress_interface"`
+	EgressInterface  uint16    `json:"egress_interface"`
+	UnderlaySrc      string    `json:"underlay_src,omitempty"`
+	UnderlayDst      string    `json:"underlay_dst,omitempty"`
+	SrcIA            addr.IA   `json:"src_isd_as"`
+	SrcHost          string    `json:"src_host"`
+	DstIA            addr.IA   `json:"dst_isd_as"`
+	DstHost          string    `json:"dst_host"`
+	PathType         string    `json:"path_type"`
+	NextHdr          string    `json:"next_hdr"`
+	TrafficClass     uint8     `json:"traffic_class"`
+	FlowID           uint32    `json:"flow_id"`
+	// Packet is the raw SCION packet.
+	Packet []byte `json:"packet"`
+}
+
+// udpSink sends a CaptureRecord for each captured packet to a local UDP
+// socket.
+type udpSink struct {
+	conn *net.UDPConn
+}
+
+func newUDPSink(a *net.UDPAddr) (*udpSink, error) {
+	if !a.IP.IsLoopback() {
+		return nil, serrors.New("UDP sink must be a loopback address", "addr", a)
+	}
+	conn, err := net.DialUDP("udp", nil, a)
+	if err != nil {
+		return nil, serrors.WrapStr("connecting to UDP sink", err)
+	}
+	return &udpSink{conn: conn}, nil
+}
+
+func (s *udpSink) write(pkt capturedPacket, scn *slayers.SCION) error {
+	rec := CaptureRecord{
+		Time:             pkt.ts,
+		IngressInterface: pkt.ingress,
+		EgressInterface:  pkt.egress,
+		SrcIA:            scn.SrcIA,
+		DstIA:            scn.DstIA,
+		PathType:         scn.PathType.String(),
+		NextHdr:          scn.NextHdr.String(),
+		TrafficClass:     scn.TrafficClass,
+		FlowID:           scn.FlowID,
+		Packet:           pkt.raw,
+	}
+	if pkt.src != nil {
+		rec.UnderlaySrc = pkt.src.String()
+	}
+	if pkt.dst != nil {
+		rec.UnderlayDst = pkt.dst.String()
+	}
+	if src, err := scn.SrcAddr(); err == nil {
+		rec.SrcHost = src.String()
+	}
+	if dst, err := scn.DstAddr(); err == nil {
+		rec.DstHost = dst.String()
+	}
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = s.conn.Write(raw)
+	return err
+}
+
+func (s *udpSink) close() error {
+	return s.conn.Close()
+}
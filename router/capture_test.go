@@ -0,0 +1,162 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path/empty"
+	"github.com/scionproto/scion/router/control"
+)
+
+func TestPacketCapturePcap(t *testing.T) {
+	dir := t.TempDir()
+	d := &DataPlane{CaptureDir: dir}
+	cfg := control.CaptureConfig{
+		Fraction: 1,
+		SrcIA:    xtest.MustParseIA("1-ff00:0:111"),
+		File:     "capture.pcap",
+	}
+	require.NoError(t, d.StartCapture(cfg))
+	assert.Equal(t, control.CaptureStatus{Active: true, Config: cfg}, d.CaptureStatus())
+
+	src := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 50000}
+	dst := &net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 31000}
+	raw := captureTestPacket(t, "1-ff00:0:111", "1-ff00:0:110")
+	d.packetCapture().capture(raw, src, dst, 1, 0)
+	d.packetCapture().capture(captureTestPacket(t, "1-ff00:0:112", "1-ff00:0:110"),
+		src, dst, 2, 0)
+	d.packetCapture().capture([]byte("not a SCION packet"), src, dst, 1, 0)
+
+	status := d.StopCapture()
+	assert.False(t, status.Active)
+	assert.Equal(t, uint64(1), status.Captured)
+	assert.Equal(t, control.CaptureStatus{}, d.CaptureStatus())
+
+	info, err := os.Stat(filepath.Join(dir, "capture.pcap"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	f, err := os.Open(filepath.Join(dir, "capture.pcap"))
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := pcapgo.NewReader(f)
+	require.NoError(t, err)
+	assert.Equal(t, layers.LinkTypeRaw, r.LinkType())
+	data, _, err := r.ReadPacketData()
+	require.NoError(t, err)
+	pkt := gopacket.NewPacket(data, layers.LayerTypeIPv4, gopacket.Default)
+	ip := pkt.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+	assert.True(t, src.IP.Equal(ip.SrcIP))
+	assert.True(t, dst.IP.Equal(ip.DstIP))
+	udp := pkt.Layer(layers.LayerTypeUDP).(*layers.UDP)
+	assert.Equal(t, layers.UDPPort(50000), udp.SrcPort)
+	assert.Equal(t, layers.UDPPort(31000), udp.DstPort)
+	assert.Equal(t, raw, udp.Payload)
+	_, _, err = r.ReadPacketData()
+	assert.Error(t, err)
+}
+
+func TestPacketCaptureUDPSink(t *testing.T) {
+	sink, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	d := &DataPlane{}
+	require.NoError(t, d.StartCapture(control.CaptureConfig{
+		Fraction: 1,
+		DstIA:    xtest.MustParseIA("1-0"),
+		UDPSink:  sink.LocalAddr().(*net.UDPAddr),
+	}))
+	defer d.StopCapture()
+
+	raw := captureTestPacket(t, "2-ff00:0:222", "1-ff00:0:110")
+	d.packetCapture().capture(captureTestPacket(t, "1-ff00:0:110", "2-ff00:0:222"), nil, nil, 0, 1)
+	d.packetCapture().capture(raw, nil, nil, 1, 0)
+
+	buf := make([]byte, 4096)
+	require.NoError(t, sink.SetReadDeadline(time.Now().Add(time.Second)))
+	n, err := sink.Read(buf)
+	require.NoError(t, err)
+	var rec CaptureRecord
+	require.NoError(t, json.Unmarshal(buf[:n], &rec))
+	assert.Equal(t, uint16(1), rec.IngressInterface)
+	assert.Equal(t, xtest.MustParseIA("2-ff00:0:222"), rec.SrcIA)
+	assert.Equal(t, "10.0.0.1", rec.SrcHost)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:110"), rec.DstIA)
+	assert.Equal(t, "10.0.0.2", rec.DstHost)
+	assert.Equal(t, "Empty (0)", rec.PathType)
+	assert.Equal(t, raw, rec.Packet)
+}
+
+func TestStartCaptureInvalid(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "existing.pcap"), nil, 0600))
+	file := "capture.pcap"
+	sink := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 40000}
+	testCases := map[string]control.CaptureConfig{
+		"zero fraction":     {File: file},
+		"fraction too big":  {Fraction: 1.5, File: file},
+		"no sink":           {Fraction: 1},
+		"two sinks":         {Fraction: 1, File: file, UDPSink: sink},
+		"non-loopback sink": {Fraction: 1, UDPSink: &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1)}},
+		"absolute path":     {Fraction: 1, File: filepath.Join(dir, file)},
+		"parent directory":  {Fraction: 1, File: "../" + file},
+		"dot dot":           {Fraction: 1, File: ".."},
+		"existing file":     {Fraction: 1, File: "existing.pcap"},
+	}
+	for name, cfg := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := &DataPlane{CaptureDir: dir}
+			assert.Error(t, d.StartCapture(cfg))
+			assert.False(t, d.CaptureStatus().Active)
+		})
+	}
+}
+
+func TestStartCaptureNoDirectory(t *testing.T) {
+	d := &DataPlane{}
+	assert.Error(t, d.StartCapture(control.CaptureConfig{Fraction: 1, File: "capture.pcap"}))
+	assert.False(t, d.CaptureStatus().Active)
+}
+
+func captureTestPacket(t *testing.T, src, dst string) []byte {
+	scn := &slayers.SCION{
+		NextHdr:  slayers.L4UDP,
+		PathType: empty.PathType,
+		Path:     empty.Path{},
+		SrcIA:    xtest.MustParseIA(src),
+		DstIA:    xtest.MustParseIA(dst),
+	}
+	require.NoError(t, scn.SetSrcAddr(addr.MustParseHost("10.0.0.1")))
+	require.NoError(t, scn.SetDstAddr(addr.MustParseHost("10.0.0.2")))
+	buf := gopacket.NewSerializeBuffer()
+	require.NoError(t, gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		scn, gopacket.Payload("payload")))
+	return buf.Bytes()
+}
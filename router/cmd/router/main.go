@@ -20,7 +20,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	_ "net/http/pprof"
+	"net/http/pprof"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
@@ -30,6 +31,7 @@ import (
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/private/app"
 	"github.com/scionproto/scion/private/app/launcher"
+	"github.com/scionproto/scion/private/ratelimit"
 	"github.com/scionproto/scion/private/service"
 	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/router"
@@ -44,7 +46,11 @@ func main() {
 	application := launcher.Application{
 		TOMLConfig: &globalCfg,
 		ShortName:  "SCION Router",
-		Main:       realMain,
+		Validate:   validateConfig,
+		ShutdownTimeout: func() time.Duration {
+			return globalCfg.General.ShutdownTimeout.Duration
+		},
+		Main: realMain,
 	}
 	application.Run()
 }
@@ -60,9 +66,29 @@ func realMain(ctx context.Context) error {
 		DataPlane: router.DataPlane{
 			Metrics:                        metrics,
 			ExperimentalSCMPAuthentication: globalCfg.Features.ExperimentalSCMPAuthentication,
+			CaptureDir:                     globalCfg.Router.CaptureDir,
 		},
-		ReceiveBufferSize: globalCfg.Router.ReceiveBufferSize,
-		SendBufferSize:    globalCfg.Router.SendBufferSize,
+		ReceiveBufferSize:  globalCfg.Router.ReceiveBufferSize,
+		SendBufferSize:     globalCfg.Router.SendBufferSize,
+		NumExternalSockets: globalCfg.Router.NumExternalSockets,
+	}
+	if timeout := globalCfg.Router.NATMappingTimeout.Duration; timeout != 0 {
+		if err := dp.DataPlane.SetNATMappingTimeout(timeout); err != nil {
+			return serrors.WrapStr("enabling NAT traversal", err)
+		}
+	}
+	err = dp.DataPlane.SetSCMPErrorRateLimits(
+		&ratelimit.Limiter{
+			Rate:  globalCfg.Router.SCMPErrorRatePerAS,
+			Burst: globalCfg.Router.SCMPErrorBurst,
+		},
+		&ratelimit.Limiter{
+			Rate:  globalCfg.Router.SCMPErrorRatePerInterface,
+			Burst: globalCfg.Router.SCMPErrorBurst,
+		},
+	)
+	if err != nil {
+		return serrors.WrapStr("setting SCMP error rate limits", err)
 	}
 	iaCtx := &control.IACtx{
 		Config: controlConfig,
@@ -75,12 +101,51 @@ func realMain(ctx context.Context) error {
 		"info":      service.NewInfoStatusPage(),
 		"config":    service.NewConfigStatusPage(globalCfg),
 		"log/level": service.NewLogLevelStatusPage(),
-		"topology":  topologyHandler(iaCtx.Config.Topo),
+		"topology":  topologyHandler(iaCtx),
 	}
+	readiness := &service.Health{}
+	readiness.AddCheck("dataplane", func(context.Context) error {
+		if !dp.DataPlane.Running() {
+			return serrors.New("dataplane not running")
+		}
+		return nil
+	})
+	readiness.AddStatusPages(statusPages)
 	if err := statusPages.Register(http.DefaultServeMux, globalCfg.General.ID); err != nil {
 		return err
 	}
 
+	reload := func() (topology.Diff, error) {
+		cfg, err := loadControlConfig()
+		if err != nil {
+			return topology.Diff{}, err
+		}
+		diff, err := iaCtx.Reconfigure(cfg)
+		if err != nil {
+			return diff, err
+		}
+		if diff.Empty() {
+			log.Info("Reloaded topology, no changes")
+		} else {
+			log.Info("Reloaded topology", diff.LogCtx()...)
+		}
+		return diff, nil
+	}
+	g.Go(func() error {
+		defer log.HandlePanic()
+		sighup := app.SIGHUPChannel(errCtx)
+		for {
+			select {
+			case <-errCtx.Done():
+				return nil
+			case <-sighup:
+				if _, err := reload(); err != nil {
+					log.Error("Failed to reload topology", "err", err)
+				}
+			}
+		}
+	})
+
 	var cleanup app.Cleanup
 	g.Go(func() error {
 		defer log.HandlePanic()
@@ -101,6 +166,8 @@ func realMain(ctx context.Context) error {
 			Info:      service.NewInfoStatusPage().Handler,
 			LogLevel:  service.NewLogLevelStatusPage().Handler,
 			Dataplane: dp,
+			Reload:    reload,
+			Capture:   &dp.DataPlane,
 		}
 		log.Info("Exposing API", "addr", globalCfg.API.Addr)
 		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
@@ -118,6 +185,22 @@ func realMain(ctx context.Context) error {
 			return nil
 		})
 	}
+	if globalCfg.Router.ProfilingAddr != "" {
+		log.Info("Exposing profiling endpoint", "addr", globalCfg.Router.ProfilingAddr)
+		profilingServer := &http.Server{
+			Addr:    globalCfg.Router.ProfilingAddr,
+			Handler: profilingHandler(),
+		}
+		cleanup.Add(profilingServer.Close)
+		g.Go(func() error {
+			defer log.HandlePanic()
+			err := profilingServer.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return serrors.WrapStr("serving profiling endpoint", err)
+			}
+			return nil
+		})
+	}
 	g.Go(func() error {
 		defer log.HandlePanic()
 		return globalCfg.Metrics.ServePrometheus(errCtx)
@@ -128,6 +211,11 @@ func realMain(ctx context.Context) error {
 			NumProcessors:         globalCfg.Router.NumProcessors,
 			NumSlowPathProcessors: globalCfg.Router.NumSlowPathProcessors,
 			BatchSize:             globalCfg.Router.BatchSize,
+			SpareSockets: globalCfg.Router.SpareInterfaces *
+				globalCfg.Router.NumExternalSockets,
+			DrainTimeout: globalCfg.General.ShutdownTimeout.Duration,
+			MACBatchSize: globalCfg.Router.MACBatchSize,
+			MACWorkers:   globalCfg.Router.MACWorkers,
 		}
 		if err := dp.DataPlane.Run(errCtx, runConfig); err != nil {
 			return serrors.WrapStr("running dataplane", err)
@@ -138,19 +226,27 @@ func realMain(ctx context.Context) error {
 	return g.Wait()
 }
 
+// validateConfig loads the topology and the master keys referenced by the
+// configuration and reports all errors.
+func validateConfig(context.Context) error {
+	return control.ValidateConfig(globalCfg.General.ID, globalCfg.General.ConfigDir)
+}
+
 func loadControlConfig() (*control.Config, error) {
 	newConf, err := control.LoadConfig(globalCfg.General.ID, globalCfg.General.ConfigDir)
 	if err != nil {
 		return nil, serrors.WrapStr("loading topology", err)
 	}
+	newConf.HopFieldKeyRotation = globalCfg.Router.HopFieldKeyRotation.Duration
+	newConf.HopFieldMACAlgorithms = globalCfg.Router.HopFieldMACAlgorithms()
 	return newConf, nil
 }
 
-func topologyHandler(topo topology.Topology) service.StatusPage {
+func topologyHandler(iaCtx *control.IACtx) service.StatusPage {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
-		bytes, err := json.MarshalIndent(topo, "", "    ")
+		bytes, err := json.MarshalIndent(iaCtx.CurrentConfig().Topo, "", "    ")
 		if err != nil {
 			http.Error(w, "Unable to marshal topology", http.StatusInternalServerError)
 			return
@@ -162,3 +258,16 @@ func topologyHandler(topo topology.Topology) service.StatusPage {
 		Handler: handler,
 	}
 }
+
+// profilingHandler returns a handler that only serves the net/http/pprof
+// endpoints, such that profiling can be exposed independently of the metrics
+// and the management API.
+func profilingHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
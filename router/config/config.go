@@ -18,10 +18,13 @@ package config
 
 import (
 	"io"
+	"net"
 	"runtime"
 
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/env"
 	api "github.com/scionproto/scion/private/mgmtapi"
@@ -44,6 +47,51 @@ type RouterConfig struct {
 	NumProcessors         int `toml:"num_processors,omitempty"`
 	NumSlowPathProcessors int `toml:"num_slow_processors,omitempty"`
 	BatchSize             int `toml:"batch_size,omitempty"`
+	NumExternalSockets    int `toml:"num_external_sockets,omitempty"`
+	SpareInterfaces       int `toml:"spare_interfaces,omitempty"`
+	// HopFieldKeyRotation is the rotation period of the hop field MAC key. If
+	// zero, the key is not rotated. It must be equal to the rotation period
+	// configured in the control service of the AS.
+	HopFieldKeyRotation util.DurWrap `toml:"hop_field_key_rotation,omitempty"`
+	// HopFieldMACAlgorithm is the algorithm of the hop field MACs. If empty,
+	// AES-CMAC is used. It must be equal to the algorithm configured in the
+	// control service of the AS.
+	HopFieldMACAlgorithm scrypto.MACAlgorithm `toml:"hop_field_mac_algorithm,omitempty"`
+	// HopFieldMACPrevAlgorithm is the algorithm of the hop field MACs in the
+	// key epochs before HopFieldMACEpoch.
+	HopFieldMACPrevAlgorithm scrypto.MACAlgorithm `toml:"hop_field_mac_prev_algorithm,omitempty"`
+	// HopFieldMACEpoch is the first key epoch that uses HopFieldMACAlgorithm.
+	// It requires the hop field MAC key to be rotated.
+	HopFieldMACEpoch uint64 `toml:"hop_field_mac_epoch,omitempty"`
+	// NATMappingTimeout is the time after which the NAT mapping of an end
+	// host expires if it sends no more STUN requests. If zero, STUN requests
+	// are not answered.
+	NATMappingTimeout util.DurWrap `toml:"nat_mapping_timeout,omitempty"`
+	// SCMPErrorRatePerAS is the number of SCMP error messages per second that
+	// are sent in response to packets from a single source AS. If zero, the
+	// rate is not limited.
+	SCMPErrorRatePerAS float64 `toml:"scmp_error_rate_per_as,omitempty"`
+	// SCMPErrorRatePerInterface is the number of SCMP error messages per
+	// second that are sent on a single interface. If zero, the rate is not
+	// limited.
+	SCMPErrorRatePerInterface float64 `toml:"scmp_error_rate_per_interface,omitempty"`
+	// SCMPErrorBurst is the number of SCMP error messages that can be sent in
+	// a burst above the rate limits.
+	SCMPErrorBurst int `toml:"scmp_error_burst,omitempty"`
+	// MACBatchSize is the maximum number of queued packets whose hop field
+	// MACs a processor computes together before processing them. If zero,
+	// the MACs are computed while processing each packet.
+	MACBatchSize int `toml:"mac_batch_size,omitempty"`
+	// MACWorkers is the number of goroutines, shared by all processors, that
+	// compute the hop field MACs of a batch in parallel. If zero, every
+	// processor computes the MACs of its batches itself.
+	MACWorkers int `toml:"mac_workers,omitempty"`
+	// ProfilingAddr is the address on which the net/http/pprof endpoints are
+	// served. If empty, the profiling endpoint is disabled.
+	ProfilingAddr string `toml:"profiling_addr,omitempty"`
+	// CaptureDir is the directory in which the pcap files of packet captures
+	// are created. If empty, packets cannot be captured to files.
+	CaptureDir string `toml:"capture_dir,omitempty"`
 }
 
 func (cfg *RouterConfig) ConfigName() string {
@@ -66,6 +114,53 @@ func (cfg *RouterConfig) Validate() error {
 	if cfg.NumSlowPathProcessors < 1 {
 		return serrors.New("Provided router config is invalid. NumSlowPathProcessors < 1")
 	}
+	if cfg.NumExternalSockets < 1 {
+		return serrors.New("Provided router config is invalid. NumExternalSockets < 1")
+	}
+	if cfg.SpareInterfaces < 0 {
+		return serrors.New("Provided router config is invalid. SpareInterfaces < 0")
+	}
+	if rotation := cfg.HopFieldKeyRotation.Duration; rotation != 0 &&
+		rotation < scrypto.MinHFKeyRotationPeriod {
+
+		return serrors.New("Provided router config is invalid. HopFieldKeyRotation too short",
+			"value", cfg.HopFieldKeyRotation, "min", scrypto.MinHFKeyRotationPeriod)
+	}
+	if err := cfg.HopFieldMACAlgorithm.Validate(); err != nil {
+		return serrors.WrapStr("Provided router config is invalid. HopFieldMACAlgorithm", err)
+	}
+	if err := cfg.HopFieldMACPrevAlgorithm.Validate(); err != nil {
+		return serrors.WrapStr("Provided router config is invalid. HopFieldMACPrevAlgorithm",
+			err)
+	}
+	if cfg.HopFieldMACEpoch != 0 && cfg.HopFieldKeyRotation.Duration == 0 {
+		return serrors.New("Provided router config is invalid. " +
+			"HopFieldMACEpoch requires HopFieldKeyRotation")
+	}
+	if cfg.NATMappingTimeout.Duration < 0 {
+		return serrors.New("Provided router config is invalid. NATMappingTimeout < 0")
+	}
+	if cfg.SCMPErrorRatePerAS < 0 {
+		return serrors.New("Provided router config is invalid. SCMPErrorRatePerAS < 0")
+	}
+	if cfg.SCMPErrorRatePerInterface < 0 {
+		return serrors.New("Provided router config is invalid. SCMPErrorRatePerInterface < 0")
+	}
+	if cfg.SCMPErrorBurst < 1 {
+		return serrors.New("Provided router config is invalid. SCMPErrorBurst < 1")
+	}
+	if cfg.MACBatchSize < 0 {
+		return serrors.New("Provided router config is invalid. MACBatchSize < 0")
+	}
+	if cfg.MACWorkers < 0 {
+		return serrors.New("Provided router config is invalid. MACWorkers < 0")
+	}
+	if cfg.ProfilingAddr != "" {
+		if _, _, err := net.SplitHostPort(cfg.ProfilingAddr); err != nil {
+			return serrors.WrapStr("Provided router config is invalid. ProfilingAddr", err,
+				"addr", cfg.ProfilingAddr)
+		}
+	}
 
 	return nil
 }
@@ -80,6 +175,24 @@ func (cfg *RouterConfig) InitDefaults() {
 	if cfg.BatchSize == 0 {
 		cfg.BatchSize = 256
 	}
+	if cfg.NumExternalSockets == 0 {
+		cfg.NumExternalSockets = 1
+	}
+	if cfg.SpareInterfaces == 0 {
+		cfg.SpareInterfaces = 4
+	}
+	if cfg.SCMPErrorBurst == 0 {
+		cfg.SCMPErrorBurst = 10
+	}
+}
+
+// HopFieldMACAlgorithms returns the hop field MAC algorithms per key epoch.
+func (cfg *RouterConfig) HopFieldMACAlgorithms() scrypto.HFMACAlgorithms {
+	return scrypto.HFMACAlgorithms{
+		Current:  cfg.HopFieldMACAlgorithm,
+		Previous: cfg.HopFieldMACPrevAlgorithm,
+		Epoch:    cfg.HopFieldMACEpoch,
+	}
 }
 
 func (cfg *RouterConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/pkg/log/logtest"
+	"github.com/scionproto/scion/private/config/configtest"
 	"github.com/scionproto/scion/private/env/envtest"
 	apitest "github.com/scionproto/scion/private/mgmtapi/mgmtapitest"
 	"github.com/scionproto/scion/router/config"
@@ -36,6 +37,7 @@ func TestConfigSample(t *testing.T) {
 	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
 	assert.NoError(t, err)
 	CheckTestConfig(t, &cfg, config.IDSample)
+	configtest.CheckSampleCoverage(t, &cfg)
 }
 
 func InitTestConfig(cfg *config.Config) {
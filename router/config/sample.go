@@ -32,7 +32,89 @@ num_processors = 8
 num_slow_processors = 1
 
 # The batch size used by the receiver and forwarder to
-# read or write from / to the network socket.
+# read or write from / to the network socket. On Linux, a batch
+# is read or written with a single recvmmsg or sendmmsg system call.
 # (default 256)
 batch_size = 256
+
+# The number of underlay sockets per external interface. Socket i uses the
+# local and remote underlay ports of the interface plus i, so the ports of the
+# range must be available on both ends of the link, and both ends must be
+# configured with the same number of sockets. Packets sent over an interface
+# are spread across its sockets by flow.
+# (default 1)
+num_external_sockets = 1
+
+# The number of external interfaces that can be added to the running router
+# when the topology is reloaded, in addition to the interfaces configured at
+# startup. The interfaces of removed links do not count towards this limit.
+# (default 4)
+spare_interfaces = 4
+
+# The rotation period of the hop field MAC key. The key is derived from the AS
+# master key and rotated at multiples of the period since the Unix epoch. The
+# period must be at least 24h1m and equal to the one configured in the control
+# service of the AS. If zero, the key is not rotated. (default 0s)
+hop_field_key_rotation = "0s"
+
+# The algorithm of the hop field MACs, either "aes-cmac" or "hmac-sha256". It
+# must be equal to the one configured in the control service of the AS.
+# (default "aes-cmac")
+hop_field_mac_algorithm = "aes-cmac"
+
+# The algorithm of the hop field MACs in the key epochs before
+# hop_field_mac_epoch. (default "aes-cmac")
+hop_field_mac_prev_algorithm = "aes-cmac"
+
+# The first key epoch, counted in hop_field_key_rotation periods since the Unix
+# epoch, whose hop field MACs use hop_field_mac_algorithm. It must be equal to
+# the one configured in the control service of the AS, and requires the key to
+# be rotated. If zero, all key epochs use hop_field_mac_algorithm. (default 0)
+hop_field_mac_epoch = 0
+
+# The time after which the NAT mapping of an end host in the local AS expires if
+# the end host sends no more STUN binding requests. If set, the router answers
+# STUN binding requests on its internal interface with the address of the end
+# host as seen by the router, and sends the packets to the end host to the
+# mapped port. This allows end hosts behind a NAT to receive packets. If zero,
+# STUN requests are not answered. (default 0s)
+nat_mapping_timeout = "0s"
+
+# The number of SCMP error messages per second that are sent in response to
+# packets from a single source AS. Limiting the rate prevents the router from
+# being abused to reflect SCMP traffic. If zero, the rate is not limited.
+# (default 0)
+scmp_error_rate_per_as = 0.0
+
+# The number of SCMP error messages per second that are sent on a single
+# interface. If zero, the rate is not limited. (default 0)
+scmp_error_rate_per_interface = 0.0
+
+# The number of SCMP error messages that can be sent in a burst above the rate
+# limits. (default 10)
+scmp_error_burst = 10
+
+# The maximum number of queued packets whose hop field MACs a processor
+# computes together before processing them. Computing the MACs in a batch lets
+# the CPU pipeline the cipher operations of independent packets. If zero, the
+# MACs are computed while processing each packet. (default 0)
+mac_batch_size = 0
+
+# The number of goroutines, shared by all processors, that compute the hop
+# field MACs of a batch in parallel. Only used if mac_batch_size is larger than
+# 1. If zero, every processor computes the MACs of its batches itself.
+# (default 0)
+mac_workers = 0
+
+# The address on which the router serves the net/http/pprof profiling endpoints
+# under /debug/pprof/ (ip:port or :port). The endpoints expose internal state
+# of the router and must not be reachable from untrusted networks. If empty,
+# the profiling endpoint is disabled. (default "")
+profiling_addr = ""
+
+# The directory in which the pcap files of packet captures are created. The
+# management API only accepts file names, the files are created in this
+# directory and existing files are not overwritten. If empty, packets cannot be
+# captured to files. (default "")
+capture_dir = ""
 `
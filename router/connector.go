@@ -15,6 +15,7 @@
 package router
 
 import (
+	"math"
 	"net"
 	"sync"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/private/underlay/conn"
 	"github.com/scionproto/scion/router/control"
@@ -40,6 +42,11 @@ type Connector struct {
 
 	ReceiveBufferSize int
 	SendBufferSize    int
+	// NumExternalSockets is the number of underlay sockets per external
+	// interface. Socket i uses the configured local and remote ports plus i.
+	// Both ends of a link must be configured with the same number of sockets.
+	// Zero is treated as one.
+	NumExternalSockets int
 }
 
 var errMultiIA = serrors.New("different IA not allowed")
@@ -129,19 +136,135 @@ func (c *Connector) AddExternalInterface(localIfID common.IFIDType, link control
 		return c.DataPlane.AddNextHop(intf, link.Remote.Addr)
 	}
 
-	connection, err := conn.New(link.Local.Addr, link.Remote.Addr,
-		&conn.Config{ReceiveBufferSize: c.ReceiveBufferSize, SendBufferSize: c.SendBufferSize})
+	conns, err := c.openSockets(localIfID, link)
 	if err != nil {
 		return err
 	}
 	if !link.BFD.Disable {
-		err := c.DataPlane.AddExternalInterfaceBFD(intf, connection, link.Local,
+		err := c.DataPlane.AddExternalInterfaceBFD(intf, conns[0], link.Local,
 			link.Remote, link.BFD)
 		if err != nil {
 			return serrors.WrapStr("adding external BFD", err, "if_id", localIfID)
 		}
 	}
-	return c.DataPlane.AddExternalInterface(intf, connection)
+	if err := c.DataPlane.AddExternalInterface(intf, conns[0]); err != nil {
+		return err
+	}
+	for _, connection := range conns[1:] {
+		if err := c.DataPlane.AddExternalInterfaceSocket(intf, connection); err != nil {
+			return serrors.WrapStr("adding external socket", err, "if_id", localIfID)
+		}
+	}
+	return nil
+}
+
+// openSockets opens the underlay sockets of an external interface.
+func (c *Connector) openSockets(localIfID common.IFIDType,
+	link control.LinkInfo) ([]BatchConn, error) {
+
+	numSockets := c.NumExternalSockets
+	if numSockets < 1 {
+		numSockets = 1
+	}
+	conns := make([]BatchConn, 0, numSockets)
+	closeAll := func() {
+		for _, sock := range conns {
+			sock.Close()
+		}
+	}
+	for i := 0; i < numSockets; i++ {
+		local, remote := offsetPort(link.Local.Addr, i), offsetPort(link.Remote.Addr, i)
+		if local.Port > math.MaxUint16 || remote.Port > math.MaxUint16 {
+			closeAll()
+			return nil, serrors.New("underlay port range exceeds maximum port",
+				"if_id", localIfID, "sockets", numSockets)
+		}
+		connection, err := conn.New(local, remote, &conn.Config{
+			ReceiveBufferSize: c.ReceiveBufferSize,
+			SendBufferSize:    c.SendBufferSize,
+		})
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		conns = append(conns, connection)
+	}
+	return conns, nil
+}
+
+// AttachExternalInterface adds a link between the local and remote address to
+// the running dataplane.
+func (c *Connector) AttachExternalInterface(localIfID common.IFIDType, link control.LinkInfo,
+	owned bool) error {
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	intf := uint16(localIfID)
+	log.Info("Attaching external interface", "interface", localIfID,
+		"remote_isd_as", link.Remote.IA, "remote_addr", link.Remote.Addr,
+		"owned", owned, "bfd", !link.BFD.Disable)
+
+	if !c.ia.Equal(link.Local.IA) {
+		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", link.Local.IA)
+	}
+	if !owned {
+		if err := c.DataPlane.AttachSiblingInterface(intf, link); err != nil {
+			return serrors.WrapStr("attaching sibling interface", err, "if_id", localIfID)
+		}
+		if len(c.siblingInterfaces) == 0 {
+			c.siblingInterfaces = make(map[uint16]control.SiblingInterface)
+		}
+		c.siblingInterfaces[intf] = control.SiblingInterface{
+			InterfaceID:       intf,
+			InternalInterface: link.Remote.Addr,
+			Relationship:      link.LinkTo,
+			MTU:               link.MTU,
+			NeighborIA:        link.Remote.IA,
+			State:             control.InterfaceDown,
+		}
+		return nil
+	}
+	conns, err := c.openSockets(localIfID, link)
+	if err != nil {
+		return err
+	}
+	if err := c.DataPlane.AttachExternalInterface(intf, conns, link); err != nil {
+		for _, sock := range conns {
+			sock.Close()
+		}
+		return serrors.WrapStr("attaching external interface", err, "if_id", localIfID)
+	}
+	if len(c.externalInterfaces) == 0 {
+		c.externalInterfaces = make(map[uint16]control.ExternalInterface)
+	}
+	c.externalInterfaces[intf] = control.ExternalInterface{
+		InterfaceID: intf,
+		Link:        link,
+		State:       control.InterfaceDown,
+	}
+	return nil
+}
+
+// DetachExternalInterface removes the link of the given interface from the
+// running dataplane.
+func (c *Connector) DetachExternalInterface(localIfID common.IFIDType) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	intf := uint16(localIfID)
+	log.Info("Detaching external interface", "interface", localIfID)
+
+	if err := c.DataPlane.DetachInterface(intf); err != nil {
+		return err
+	}
+	delete(c.externalInterfaces, intf)
+	delete(c.siblingInterfaces, intf)
+	return nil
+}
+
+// offsetPort returns a copy of the address with the port increased by the
+// given offset.
+func offsetPort(a *net.UDPAddr, offset int) *net.UDPAddr {
+	return &net.UDPAddr{IP: a.IP, Port: a.Port + offset, Zone: a.Zone}
 }
 
 // AddSvc adds the service address for the given ISD-AS.
@@ -180,6 +303,30 @@ func (c *Connector) SetKey(ia addr.IA, index int, key []byte) error {
 	return c.DataPlane.SetKey(key)
 }
 
+// SetKeySchedule sets the schedule of the rotating hop field MAC keys for the
+// given ISD-AS.
+func (c *Connector) SetKeySchedule(ia addr.IA, schedule scrypto.HFKeySchedule) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	log.Debug("Setting key schedule", "isd_as", ia, "period", schedule.Period)
+	if !c.ia.Equal(ia) {
+		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", ia)
+	}
+	return c.DataPlane.SetKeySchedule(schedule)
+}
+
+// SetMACAlgorithm sets the hop field MAC algorithm for the given ISD-AS. It is
+// used together with SetKey and must be called before it.
+func (c *Connector) SetMACAlgorithm(ia addr.IA, alg scrypto.MACAlgorithm) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	log.Debug("Setting MAC algorithm", "isd_as", ia, "algorithm", alg)
+	if !c.ia.Equal(ia) {
+		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", ia)
+	}
+	return c.DataPlane.SetMACAlgorithm(alg)
+}
+
 func (c *Connector) ListInternalInterfaces() ([]control.InternalInterface, error) {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
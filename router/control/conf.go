@@ -18,12 +18,14 @@ import (
 	"crypto/sha256"
 	"net"
 	"sort"
+	"strings"
 
 	"golang.org/x/crypto/pbkdf2"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/private/topology"
 )
@@ -37,6 +39,15 @@ type Dataplane interface {
 	AddSvc(ia addr.IA, svc addr.SVC, ip net.IP) error
 	DelSvc(ia addr.IA, svc addr.SVC, ip net.IP) error
 	SetKey(ia addr.IA, index int, key []byte) error
+	SetKeySchedule(ia addr.IA, schedule scrypto.HFKeySchedule) error
+	SetMACAlgorithm(ia addr.IA, alg scrypto.MACAlgorithm) error
+}
+
+// ReconfigurableDataplane is a dataplane whose external interfaces can be
+// changed while it is running.
+type ReconfigurableDataplane interface {
+	AttachExternalInterface(localIfID common.IFIDType, info LinkInfo, owned bool) error
+	DetachExternalInterface(localIfID common.IFIDType) error
 }
 
 // LinkInfo contains the information about a link between an internal and
@@ -63,6 +74,58 @@ type ObservableDataplane interface {
 	ListSiblingInterfaces() ([]SiblingInterface, error)
 }
 
+// PacketCapture is a dataplane that can mirror the forwarded packets to a
+// sink for debugging.
+type PacketCapture interface {
+	// StartCapture starts capturing the packets that match the configuration.
+	// A running capture is stopped first.
+	StartCapture(cfg CaptureConfig) error
+	// StopCapture stops the running capture and returns its final status.
+	StopCapture() CaptureStatus
+	// CaptureStatus returns the status of the running capture.
+	CaptureStatus() CaptureStatus
+}
+
+// CaptureConfig configures the capture of forwarded packets.
+type CaptureConfig struct {
+	// Fraction is the fraction of the matching packets that is captured. It
+	// must be in (0, 1].
+	Fraction float64
+	// SrcIA and DstIA, if not zero, restrict the capture to the packets from
+	// and to the given AS. A zero ISD or AS number matches any.
+	SrcIA addr.IA
+	DstIA addr.IA
+	// File is the name of the pcap file the packets are written to. The file
+	// is created in the capture directory of the router and must not exist.
+	File string
+	// UDPSink is the loopback address the packets are sent to, together with
+	// the fields of their SCION header, as JSON. Exactly one of File and
+	// UDPSink must be set.
+	UDPSink *net.UDPAddr
+}
+
+// CaptureStatus is the status of a packet capture.
+type CaptureStatus struct {
+	// Active indicates whether the capture is running.
+	Active bool
+	Config CaptureConfig
+	// Captured is the number of packets written to the sink.
+	Captured uint64
+	// Dropped is the number of sampled packets that were dropped because the
+	// sink did not keep up.
+	Dropped uint64
+}
+
+// ValidateCaptureFile checks that the name of a capture file is a plain file
+// name, i.e., that it does not refer to a file outside of the capture
+// directory.
+func ValidateCaptureFile(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return serrors.New("capture file must be a file name without path", "file", name)
+	}
+	return nil
+}
+
 // InternalInterface represents the internal interface of a router.
 type InternalInterface struct {
 	IA   addr.IA
@@ -120,9 +183,24 @@ func ConfigDataplane(dp Dataplane, cfg *Config) error {
 	// XXX HSR currently only support 1 key, so use Key0
 	// Should it be an error if no key is set?
 	if len(cfg.MasterKeys.Key0) > 0 {
-		key0 := DeriveHFMacKey(cfg.MasterKeys.Key0)
-		if err := dp.SetKey(cfg.IA, 0, key0); err != nil {
-			return err
+		if cfg.HopFieldKeyRotation != 0 {
+			schedule := scrypto.HFKeySchedule{
+				Master:     cfg.MasterKeys.Key0,
+				Period:     cfg.HopFieldKeyRotation,
+				Algorithms: cfg.HopFieldMACAlgorithms,
+			}
+			if err := dp.SetKeySchedule(cfg.IA, schedule); err != nil {
+				return err
+			}
+		} else {
+			alg := cfg.HopFieldMACAlgorithms.Current
+			if err := dp.SetMACAlgorithm(cfg.IA, alg); err != nil {
+				return err
+			}
+			key0 := DeriveHFMacKey(cfg.MasterKeys.Key0)
+			if err := dp.SetKey(cfg.IA, 0, key0); err != nil {
+				return err
+			}
 		}
 	}
 	// Add internal interfaces
@@ -170,38 +248,7 @@ func confExternalInterfaces(dp Dataplane, cfg *Config) error {
 	sort.Slice(ifids, func(i, j int) bool { return ifids[i] < ifids[j] })
 	// External interfaces
 	for _, ifid := range ifids {
-		iface := infoMap[ifid]
-		linkInfo := LinkInfo{
-			Local: LinkEnd{
-				IA:   cfg.IA,
-				Addr: snet.CopyUDPAddr(iface.Local),
-				IFID: iface.ID,
-			},
-			Remote: LinkEnd{
-				IA:   iface.IA,
-				Addr: snet.CopyUDPAddr(iface.Remote),
-				IFID: iface.RemoteIFID,
-			},
-			Instance: iface.BRName,
-			BFD:      WithDefaults(BFD(iface.BFD)),
-			LinkTo:   iface.LinkType,
-			MTU:      iface.MTU,
-		}
-
-		_, owned := cfg.BR.IFs[ifid]
-		if !owned {
-			// XXX The current implementation effectively uses IP/UDP tunnels to create
-			// the SCION network as an overlay, with forwarding to local hosts being a special case.
-			// When setting up external interfaces that belong to other routers in the AS, they
-			// are basically IP/UDP tunnels between the two border routers, and as such is
-			// configured in the data plane.
-			linkInfo.Local.Addr = snet.CopyUDPAddr(cfg.BR.InternalAddr)
-			linkInfo.Remote.Addr = snet.CopyUDPAddr(iface.InternalAddr)
-			// For internal BFD always use the default configuration, which can be modified with
-			// the env variables.
-			linkInfo.BFD = BFDDefaults
-		}
-
+		linkInfo, owned := newLinkInfo(cfg, infoMap[ifid])
 		if err := dp.AddExternalInterface(ifid, linkInfo, owned); err != nil {
 			return err
 		}
@@ -209,6 +256,42 @@ func confExternalInterfaces(dp Dataplane, cfg *Config) error {
 	return nil
 }
 
+// newLinkInfo creates the link information for the interface, and reports
+// whether the interface is owned by this router.
+func newLinkInfo(cfg *Config, iface topology.IFInfo) (LinkInfo, bool) {
+	linkInfo := LinkInfo{
+		Local: LinkEnd{
+			IA:   cfg.IA,
+			Addr: snet.CopyUDPAddr(iface.Local),
+			IFID: iface.ID,
+		},
+		Remote: LinkEnd{
+			IA:   iface.IA,
+			Addr: snet.CopyUDPAddr(iface.Remote),
+			IFID: iface.RemoteIFID,
+		},
+		Instance: iface.BRName,
+		BFD:      WithDefaults(BFD(iface.BFD)),
+		LinkTo:   iface.LinkType,
+		MTU:      iface.MTU,
+	}
+
+	_, owned := cfg.BR.IFs[iface.ID]
+	if !owned {
+		// XXX The current implementation effectively uses IP/UDP tunnels to create
+		// the SCION network as an overlay, with forwarding to local hosts being a special case.
+		// When setting up external interfaces that belong to other routers in the AS, they
+		// are basically IP/UDP tunnels between the two border routers, and as such is
+		// configured in the data plane.
+		linkInfo.Local.Addr = snet.CopyUDPAddr(cfg.BR.InternalAddr)
+		linkInfo.Remote.Addr = snet.CopyUDPAddr(iface.InternalAddr)
+		// For internal BFD always use the default configuration, which can be modified with
+		// the env variables.
+		linkInfo.BFD = BFDDefaults
+	}
+	return linkInfo, owned
+}
+
 var svcTypes = []addr.SVC{
 	addr.SvcDS,
 	addr.SvcCS,
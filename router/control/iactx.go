@@ -18,10 +18,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/private/keyconf"
 	"github.com/scionproto/scion/private/topology"
 )
@@ -37,6 +41,11 @@ type Config struct {
 	BR *topology.BRInfo
 	// MasterKeys holds the local AS master keys.
 	MasterKeys keyconf.Master
+	// HopFieldKeyRotation is the rotation period of the hop field MAC key. If
+	// zero, the key is not rotated.
+	HopFieldKeyRotation time.Duration
+	// HopFieldMACAlgorithms selects the hop field MAC algorithm per key epoch.
+	HopFieldMACAlgorithms scrypto.HFMACAlgorithms
 }
 
 // LoadConfig sets up the configuration, loading it from the supplied config directory.
@@ -51,6 +60,21 @@ func LoadConfig(id, confDir string) (*Config, error) {
 	return conf, nil
 }
 
+// ValidateConfig loads the configuration from the supplied config directory
+// like LoadConfig, but reports all errors at once in a serrors.List instead of
+// stopping at the first one.
+func ValidateConfig(id, confDir string) error {
+	conf := &Config{}
+	var errs serrors.List
+	if err := conf.loadTopo(id, confDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := conf.loadMasterKeys(confDir); err != nil {
+		errs = append(errs, err)
+	}
+	return errs.ToError()
+}
+
 func (cfg *Config) String() string {
 	return fmt.Sprintf("{IA: %s, BR.Name: %s", cfg.IA, cfg.BR.Name)
 }
@@ -98,6 +122,8 @@ type IACtx struct {
 	Config *Config
 	// DP is the underlying data plane.
 	DP Dataplane
+
+	mtx sync.Mutex
 }
 
 // Configure configures the dataplane for the given context.
@@ -120,6 +146,62 @@ func (iac *IACtx) Configure() error {
 	return nil
 }
 
+// Reconfigure applies the interface changes of the new configuration to the
+// running dataplane, which must implement ReconfigurableDataplane. Added
+// interfaces are attached, removed interfaces are detached, and changed
+// interfaces are detached and attached again. Other changes, e.g., to the
+// service addresses or the keys, are not applied. If an interface cannot be
+// attached or detached, the remaining changes are still applied, but the
+// current configuration is kept.
+func (iac *IACtx) Reconfigure(cfg *Config) (topology.Diff, error) {
+	iac.mtx.Lock()
+	defer iac.mtx.Unlock()
+
+	dp, ok := iac.DP.(ReconfigurableDataplane)
+	if !ok {
+		return topology.Diff{}, serrors.New("dataplane does not support reconfiguration")
+	}
+	if cfg == nil || iac.Config == nil {
+		return topology.Diff{}, serrors.New("empty configuration")
+	}
+	newTopo, oldTopo := cfg.Topo.Writable(), iac.Config.Topo.Writable()
+	validator := topology.RouterValidator{ID: iac.Config.BR.Name}
+	if err := validator.Validate(newTopo, oldTopo); err != nil {
+		return topology.Diff{}, serrors.WrapStr("validating topology", err)
+	}
+	diff := topology.ComputeDiff(newTopo, oldTopo)
+
+	var errs serrors.List
+	for _, ids := range [][]common.IFIDType{diff.RemovedInterfaces, diff.ChangedInterfaces} {
+		for _, ifid := range ids {
+			if err := dp.DetachExternalInterface(ifid); err != nil {
+				errs = append(errs, serrors.WrapStr("detaching interface", err, "if_id", ifid))
+			}
+		}
+	}
+	infoMap := cfg.Topo.IFInfoMap()
+	for _, ids := range [][]common.IFIDType{diff.AddedInterfaces, diff.ChangedInterfaces} {
+		for _, ifid := range ids {
+			linkInfo, owned := newLinkInfo(cfg, infoMap[ifid])
+			if err := dp.AttachExternalInterface(ifid, linkInfo, owned); err != nil {
+				errs = append(errs, serrors.WrapStr("attaching interface", err, "if_id", ifid))
+			}
+		}
+	}
+	if err := errs.ToError(); err != nil {
+		return diff, err
+	}
+	iac.Config = cfg
+	return diff, nil
+}
+
+// CurrentConfig returns the configuration that was most recently applied.
+func (iac *IACtx) CurrentConfig() *Config {
+	iac.mtx.Lock()
+	defer iac.mtx.Unlock()
+	return iac.Config
+}
+
 func dumpConfig(cfg *Config) (string, error) {
 	if cfg == nil {
 		return "", serrors.New("empty configuration")
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/router/control (interfaces: ObservableDataplane)
+// Source: github.com/scionproto/scion/router/control (interfaces: ObservableDataplane,PacketCapture)
 
 // Package mock_api is a generated GoMock package.
 package mock_api
@@ -78,3 +78,68 @@ func (mr *MockObservableDataplaneMockRecorder) ListSiblingInterfaces() *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSiblingInterfaces", reflect.TypeOf((*MockObservableDataplane)(nil).ListSiblingInterfaces))
 }
+
+// MockPacketCapture is a mock of PacketCapture interface.
+type MockPacketCapture struct {
+	ctrl     *gomock.Controller
+	recorder *MockPacketCaptureMockRecorder
+}
+
+// MockPacketCaptureMockRecorder is the mock recorder for MockPacketCapture.
+type MockPacketCaptureMockRecorder struct {
+	mock *MockPacketCapture
+}
+
+// NewMockPacketCapture creates a new mock instance.
+func NewMockPacketCapture(ctrl *gomock.Controller) *MockPacketCapture {
+	mock := &MockPacketCapture{ctrl: ctrl}
+	mock.recorder = &MockPacketCaptureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPacketCapture) EXPECT() *MockPacketCaptureMockRecorder {
+	return m.recorder
+}
+
+// CaptureStatus mocks base method.
+func (m *MockPacketCapture) CaptureStatus() control.CaptureStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureStatus")
+	ret0, _ := ret[0].(control.CaptureStatus)
+	return ret0
+}
+
+// CaptureStatus indicates an expected call of CaptureStatus.
+func (mr *MockPacketCaptureMockRecorder) CaptureStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureStatus", reflect.TypeOf((*MockPacketCapture)(nil).CaptureStatus))
+}
+
+// StartCapture mocks base method.
+func (m *MockPacketCapture) StartCapture(arg0 control.CaptureConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCapture", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartCapture indicates an expected call of StartCapture.
+func (mr *MockPacketCaptureMockRecorder) StartCapture(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCapture", reflect.TypeOf((*MockPacketCapture)(nil).StartCapture), arg0)
+}
+
+// StopCapture mocks base method.
+func (m *MockPacketCapture) StopCapture() control.CaptureStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopCapture")
+	ret0, _ := ret[0].(control.CaptureStatus)
+	return ret0
+}
+
+// StopCapture indicates an expected call of StopCapture.
+func (mr *MockPacketCaptureMockRecorder) StopCapture() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCapture", reflect.TypeOf((*MockPacketCapture)(nil).StopCapture))
+}
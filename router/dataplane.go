@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/subtle"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash"
@@ -28,6 +29,7 @@ import (
 	"net"
 	"net/netip"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/gopacket"
@@ -50,7 +52,9 @@ import (
 	"github.com/scionproto/scion/pkg/slayers/path/onehop"
 	"github.com/scionproto/scion/pkg/slayers/path/scion"
 	"github.com/scionproto/scion/pkg/spao"
+	"github.com/scionproto/scion/pkg/stun"
 	"github.com/scionproto/scion/private/drkey/drkeyutil"
+	"github.com/scionproto/scion/private/ratelimit"
 	"github.com/scionproto/scion/private/topology"
 	underlayconn "github.com/scionproto/scion/private/underlay/conn"
 	"github.com/scionproto/scion/router/bfd"
@@ -71,6 +75,14 @@ const (
 	// e2eAuthHdrLen is the length in bytes of added information when a SCMP packet
 	// needs to be authenticated: 16B (e2e.option.Len()) + 16B (CMAC_tag.Len()).
 	e2eAuthHdrLen = 32
+
+	// keyRotationCheckInterval is the interval in which the hop field keys
+	// are updated if the hop field MAC key is rotated.
+	keyRotationCheckInterval = 10 * time.Second
+
+	// drainCheckInterval is the interval in which the queues are checked
+	// while the dataplane is draining.
+	drainCheckInterval = 10 * time.Millisecond
 )
 
 type bfdSession interface {
@@ -94,29 +106,136 @@ type BatchConn interface {
 // XXX(lukedirtwalker): this is still in development and not feature complete.
 // Currently, only the following features are supported:
 //   - initializing connections; MUST be done prior to calling Run
+//   - attaching and detaching interfaces of a running dataplane
 type DataPlane struct {
 	interfaces        map[uint16]BatchConn
-	external          map[uint16]BatchConn
-	linkTypes         map[uint16]topology.LinkType
-	neighborIAs       map[uint16]addr.IA
+	additionalSockets map[uint16][]BatchConn
 	peerInterfaces    map[uint16]uint16
 	internal          BatchConn
 	internalIP        netip.Addr
-	internalNextHops  map[uint16]*net.UDPAddr
 	svc               *services
 	macFactory        func() hash.Hash
-	bfdSessions       map[uint16]bfdSession
+	macAlgorithm      scrypto.MACAlgorithm
+	keySchedule       *scrypto.HFKeySchedule
 	localIA           addr.IA
 	mtx               sync.Mutex
 	running           bool
 	Metrics           *Metrics
-	forwardingMetrics map[uint16]interfaceMetrics
+	// fwTables holds the *forwardingTables used by the packet processing.
+	fwTables atomic.Value
+	// hfKeys holds the *hopFieldKeys used to verify and issue hop fields.
+	hfKeys atomic.Value
+	// nat holds the NAT mappings of end hosts in the local AS. If nil, STUN
+	// requests are not answered.
+	nat *natMappings
+	// scmpPerAS and scmpPerInterface limit the rate of the SCMP error
+	// messages per source AS of the offending packets and per interface the
+	// messages are sent on. If nil, the rate is not limited.
+	scmpPerAS        *ratelimit.Limiter
+	scmpPerInterface *ratelimit.Limiter
+	// capture holds the *packetCapture that mirrors the forwarded packets
+	// for debugging. It holds nil if no packets are captured.
+	capture atomic.Value
 
 	ExperimentalSCMPAuthentication bool
+	// CaptureDir is the directory in which the pcap files of packet captures
+	// are created. If empty, packets can only be captured to a UDP sink.
+	CaptureDir string
 
 	// The pool that stores all the packet buffers as described in the design document. See
 	// https://github.com/scionproto/scion/blob/master/doc/dev/design/BorderRouter.rst
 	packetPool chan []byte
+
+	// The state of the running dataplane that is required to attach and
+	// detach interfaces. It is protected by mtx.
+	runCtx      context.Context
+	runCfg      *RunConfig
+	procQs      []chan packet
+	slowQs      []chan slowPacket
+	stops       map[uint16]chan struct{}
+	bfdCancels  map[bfdSession]context.CancelFunc
+	conns       map[uint16][]BatchConn
+	poolSockets int
+	maxSockets  int
+	// draining is set to 1 once the dataplane is shutting down. The
+	// receivers then discard the packets they read, such that the queued
+	// packets can be forwarded.
+	draining uint32
+	// procLocks contains a lock for each fast path and slow path processor,
+	// which is held while the processor processes a packet.
+	procLocks []sync.Mutex
+}
+
+// forwardingTables contains the per-interface state that is looked up while
+// processing packets. Once the dataplane is running, the tables are never
+// modified in place. Attaching or detaching an interface publishes a modified
+// copy instead, so the packet processors can use them without locking.
+type forwardingTables struct {
+	external          map[uint16]BatchConn
+	linkTypes         map[uint16]topology.LinkType
+	neighborIAs       map[uint16]addr.IA
+	internalNextHops  map[uint16]*net.UDPAddr
+	bfdSessions       map[uint16]bfdSession
+	forwardingMetrics map[uint16]interfaceMetrics
+	// fwQs contains the forwarder queues of each socket of an interface. It is
+	// only set on a running dataplane.
+	fwQs map[uint16][]chan packet
+}
+
+// clone returns a copy of the tables, which can be modified without affecting
+// the original.
+func (t *forwardingTables) clone() *forwardingTables {
+	c := &forwardingTables{
+		external:          make(map[uint16]BatchConn, len(t.external)),
+		linkTypes:         make(map[uint16]topology.LinkType, len(t.linkTypes)),
+		neighborIAs:       make(map[uint16]addr.IA, len(t.neighborIAs)),
+		internalNextHops:  make(map[uint16]*net.UDPAddr, len(t.internalNextHops)),
+		bfdSessions:       make(map[uint16]bfdSession, len(t.bfdSessions)),
+		forwardingMetrics: make(map[uint16]interfaceMetrics, len(t.forwardingMetrics)),
+		fwQs:              make(map[uint16][]chan packet, len(t.fwQs)),
+	}
+	for k, v := range t.external {
+		c.external[k] = v
+	}
+	for k, v := range t.linkTypes {
+		c.linkTypes[k] = v
+	}
+	for k, v := range t.neighborIAs {
+		c.neighborIAs[k] = v
+	}
+	for k, v := range t.internalNextHops {
+		c.internalNextHops[k] = v
+	}
+	for k, v := range t.bfdSessions {
+		c.bfdSessions[k] = v
+	}
+	for k, v := range t.forwardingMetrics {
+		c.forwardingMetrics[k] = v
+	}
+	for k, v := range t.fwQs {
+		c.fwQs[k] = v
+	}
+	return c
+}
+
+// forwardingTables returns the current forwarding tables. The returned tables
+// must not be modified.
+func (d *DataPlane) forwardingTables() *forwardingTables {
+	if t, ok := d.fwTables.Load().(*forwardingTables); ok {
+		return t
+	}
+	return &forwardingTables{}
+}
+
+// initialTables returns the forwarding tables for modification before the
+// dataplane is started.
+func (d *DataPlane) initialTables() *forwardingTables {
+	t, ok := d.fwTables.Load().(*forwardingTables)
+	if !ok {
+		t = &forwardingTables{}
+		d.fwTables.Store(t)
+	}
+	return t
 }
 
 var (
@@ -128,6 +247,7 @@ var (
 	emptyValue                    = errors.New("empty value")
 	malformedPath                 = errors.New("malformed path content")
 	modifyExisting                = errors.New("modifying a running dataplane is not allowed")
+	notRunning                    = errors.New("dataplane is not running")
 	noSVCBackend                  = errors.New("cannot find internal IP for the SVC")
 	unsupportedPathType           = errors.New("unsupported path type")
 	unsupportedPathTypeNextHeader = errors.New("unsupported combination")
@@ -144,6 +264,7 @@ var (
 	macVerificationFailed         = errors.New("MAC verification failed")
 	badPacketSize                 = errors.New("bad packet size")
 	slowPathRequired              = errors.New("slow-path required")
+	scmpRateLimited               = errors.New("SCMP error rate limit exceeded")
 
 	// zeroBuffer will be used to reset the Authenticator option in the
 	// scionPacketProcessor.OptAuth
@@ -192,16 +313,166 @@ func (d *DataPlane) SetKey(key []byte) error {
 		return alreadySet
 	}
 	// First check for MAC creation errors.
-	if _, err := scrypto.InitMac(key); err != nil {
+	alg := d.macAlgorithm
+	if _, err := scrypto.NewMAC(alg, key); err != nil {
 		return err
 	}
 	d.macFactory = func() hash.Hash {
-		mac, _ := scrypto.InitMac(key)
+		mac, _ := scrypto.NewMAC(alg, key)
 		return mac
 	}
+	d.hfKeys.Store(&hopFieldKeys{
+		keys: [2][]byte{key},
+		algs: [2]scrypto.MACAlgorithm{alg},
+	})
 	return nil
 }
 
+// SetMACAlgorithm sets the algorithm used for MAC verification with the key set
+// by SetKey. It must be called before SetKey. If it is not called, AES-CMAC is
+// used.
+func (d *DataPlane) SetMACAlgorithm(alg scrypto.MACAlgorithm) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.running {
+		return modifyExisting
+	}
+	if d.macFactory != nil {
+		return alreadySet
+	}
+	if err := alg.Validate(); err != nil {
+		return err
+	}
+	d.macAlgorithm = alg
+	return nil
+}
+
+// SetNATMappingTimeout enables answering STUN binding requests of end hosts
+// in the local AS, so that end hosts behind a NAT can discover their mapped
+// address. Packets to such an end host are sent to the mapped port until no
+// request was received from it for the timeout.
+func (d *DataPlane) SetNATMappingTimeout(timeout time.Duration) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.running {
+		return modifyExisting
+	}
+	if timeout <= 0 {
+		return emptyValue
+	}
+	d.nat = newNATMappings(timeout)
+	return nil
+}
+
+// SetSCMPErrorRateLimits sets the limiters for the rate of the SCMP error
+// messages sent per source AS of the offending packets, and per interface the
+// messages are sent on. A nil limiter disables the respective limit.
+func (d *DataPlane) SetSCMPErrorRateLimits(perAS, perInterface *ratelimit.Limiter) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.running {
+		return modifyExisting
+	}
+	d.scmpPerAS = perAS
+	d.scmpPerInterface = perInterface
+	return nil
+}
+
+// SetKeySchedule sets the schedule of the rotating hop field MAC keys. It is
+// used instead of SetKey if the hop field MAC key is rotated. While the
+// dataplane is running, the accepted keys are updated according to the
+// schedule.
+func (d *DataPlane) SetKeySchedule(s scrypto.HFKeySchedule) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.running {
+		return modifyExisting
+	}
+	if d.macFactory != nil {
+		return alreadySet
+	}
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	keys := newHopFieldKeys(s, time.Now())
+	key, alg := keys.issuingKey(), keys.issuingAlgorithm()
+	// The MACs for BFD messages use the key that is current at startup.
+	d.macFactory = func() hash.Hash {
+		mac, _ := scrypto.NewMAC(alg, key)
+		return mac
+	}
+	d.keySchedule = &s
+	d.hfKeys.Store(keys)
+	return nil
+}
+
+// hopFieldKeys are the hop field MAC keys accepted by the router.
+type hopFieldKeys struct {
+	// keys are the accepted keys indexed by key phase. A nil entry means that
+	// hop fields with the corresponding key phase are rejected.
+	keys [2][]byte
+	// algs are the MAC algorithms of the accepted keys indexed by key phase.
+	algs [2]scrypto.MACAlgorithm
+	// phase is the key phase of the key the router issues hop fields with.
+	phase bool
+}
+
+func newHopFieldKeys(s scrypto.HFKeySchedule, now time.Time) *hopFieldKeys {
+	epoch := s.Epoch(now)
+	return &hopFieldKeys{
+		keys:  s.VerificationKeys(now),
+		algs:  s.VerificationAlgorithms(now),
+		phase: scrypto.KeyPhase(epoch),
+	}
+}
+
+func (k *hopFieldKeys) issuingKey() []byte {
+	return k.keys[keyPhaseIndex(k.phase)]
+}
+
+func (k *hopFieldKeys) issuingAlgorithm() scrypto.MACAlgorithm {
+	return k.algs[keyPhaseIndex(k.phase)]
+}
+
+func (k *hopFieldKeys) equal(o *hopFieldKeys) bool {
+	return k.phase == o.phase && k.algs == o.algs &&
+		bytes.Equal(k.keys[0], o.keys[0]) && bytes.Equal(k.keys[1], o.keys[1])
+}
+
+// hopFieldKeys returns the current hop field keys.
+func (d *DataPlane) hopFieldKeys() *hopFieldKeys {
+	if k, ok := d.hfKeys.Load().(*hopFieldKeys); ok {
+		return k
+	}
+	return &hopFieldKeys{}
+}
+
+// rotateKeys updates the accepted hop field keys according to the key
+// schedule until the context is canceled.
+func (d *DataPlane) rotateKeys(ctx context.Context) {
+	ticker := time.NewTicker(keyRotationCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			keys := newHopFieldKeys(*d.keySchedule, now)
+			if !keys.equal(d.hopFieldKeys()) {
+				log.Info("Updating hop field keys", "epoch", d.keySchedule.Epoch(now))
+				d.hfKeys.Store(keys)
+			}
+		}
+	}
+}
+
+func keyPhaseIndex(phase bool) int {
+	if phase {
+		return 1
+	}
+	return 0
+}
+
 // AddInternalInterface sets the interface the data-plane will use to
 // send/receive traffic in the local AS. This can only be called once; future
 // calls will return an error. This can only be called on a not yet running
@@ -223,11 +494,13 @@ func (d *DataPlane) AddInternalInterface(conn BatchConn, ip net.IP) error {
 	}
 	d.interfaces[0] = conn
 	d.internal = conn
-	var ok bool
-	d.internalIP, ok = netip.AddrFromSlice(ip)
+	internalIP, ok := netip.AddrFromSlice(ip)
 	if !ok {
 		return serrors.New("invalid ip", "ip", ip)
 	}
+	// IPv4 addresses in 16-byte representation must be used as IPv4 host
+	// addresses in the SCION header.
+	d.internalIP = internalIP.Unmap()
 	return nil
 }
 
@@ -243,20 +516,56 @@ func (d *DataPlane) AddExternalInterface(ifID uint16, conn BatchConn) error {
 	if conn == nil {
 		return emptyValue
 	}
-	if _, exists := d.external[ifID]; exists {
+	t := d.initialTables()
+	if _, exists := t.external[ifID]; exists {
 		return serrors.WithCtx(alreadySet, "ifID", ifID)
 	}
-	if d.external == nil {
-		d.external = make(map[uint16]BatchConn)
+	if t.external == nil {
+		t.external = make(map[uint16]BatchConn)
 	}
 	if d.interfaces == nil {
 		d.interfaces = make(map[uint16]BatchConn)
 	}
 	d.interfaces[ifID] = conn
-	d.external[ifID] = conn
+	t.external[ifID] = conn
 	return nil
 }
 
+// AddExternalInterfaceSocket adds an additional socket to the external
+// interface with the given ID. Packets received on any socket of the interface
+// are processed alike, packets sent over the interface are spread across its
+// sockets by flow. The interface must have been added with
+// AddExternalInterface before. This can only be called on a not yet running
+// dataplane.
+func (d *DataPlane) AddExternalInterfaceSocket(ifID uint16, conn BatchConn) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.running {
+		return modifyExisting
+	}
+	if conn == nil {
+		return emptyValue
+	}
+	if _, exists := d.initialTables().external[ifID]; !exists {
+		return serrors.New("adding socket to unknown external interface", "ifID", ifID)
+	}
+	if d.additionalSockets == nil {
+		d.additionalSockets = make(map[uint16][]BatchConn)
+	}
+	d.additionalSockets[ifID] = append(d.additionalSockets[ifID], conn)
+	return nil
+}
+
+// sockets returns all sockets of each interface. The first socket of an
+// interface is the one it was added with.
+func (d *DataPlane) sockets() map[uint16][]BatchConn {
+	sockets := make(map[uint16][]BatchConn, len(d.interfaces))
+	for ifID, conn := range d.interfaces {
+		sockets[ifID] = append([]BatchConn{conn}, d.additionalSockets[ifID]...)
+	}
+	return sockets
+}
+
 // AddNeighborIA adds the neighboring IA for a given interface ID. If an IA for
 // the given ID is already set, this method will return an error. This can only
 // be called on a yet running dataplane.
@@ -269,13 +578,14 @@ func (d *DataPlane) AddNeighborIA(ifID uint16, remote addr.IA) error {
 	if remote.IsZero() {
 		return emptyValue
 	}
-	if _, exists := d.neighborIAs[ifID]; exists {
+	t := d.initialTables()
+	if _, exists := t.neighborIAs[ifID]; exists {
 		return serrors.WithCtx(alreadySet, "ifID", ifID)
 	}
-	if d.neighborIAs == nil {
-		d.neighborIAs = make(map[uint16]addr.IA)
+	if t.neighborIAs == nil {
+		t.neighborIAs = make(map[uint16]addr.IA)
 	}
-	d.neighborIAs[ifID] = remote
+	t.neighborIAs[ifID] = remote
 	return nil
 }
 
@@ -283,13 +593,14 @@ func (d *DataPlane) AddNeighborIA(ifID uint16, remote addr.IA) error {
 // the given ID is already set, this method will return an error. This can only
 // be called on a not yet running dataplane.
 func (d *DataPlane) AddLinkType(ifID uint16, linkTo topology.LinkType) error {
-	if _, exists := d.linkTypes[ifID]; exists {
+	t := d.initialTables()
+	if _, exists := t.linkTypes[ifID]; exists {
 		return serrors.WithCtx(alreadySet, "ifID", ifID)
 	}
-	if d.linkTypes == nil {
-		d.linkTypes = make(map[uint16]topology.LinkType)
+	if t.linkTypes == nil {
+		t.linkTypes = make(map[uint16]topology.LinkType)
 	}
-	d.linkTypes[ifID] = linkTo
+	t.linkTypes[ifID] = linkTo
 	return nil
 }
 
@@ -298,7 +609,7 @@ func (d *DataPlane) AddLinkType(ifID uint16, linkTo topology.LinkType) error {
 // a different type, this method will return an error. This can only
 // be called on a not yet running dataplane.
 func (d *DataPlane) AddRemotePeer(local, remote uint16) error {
-	if t, ok := d.linkTypes[local]; ok && t != topology.Peer {
+	if t, ok := d.initialTables().linkTypes[local]; ok && t != topology.Peer {
 		return serrors.WithCtx(unsupportedPathType, "type", t)
 	}
 	if _, exists := d.peerInterfaces[local]; exists {
@@ -323,6 +634,12 @@ func (d *DataPlane) AddExternalInterfaceBFD(ifID uint16, conn BatchConn,
 	if conn == nil {
 		return emptyValue
 	}
+	return d.addExternalBFD(d.initialTables(), ifID, conn, src, dst, cfg)
+}
+
+func (d *DataPlane) addExternalBFD(t *forwardingTables, ifID uint16, conn BatchConn,
+	src, dst control.LinkEnd, cfg control.BFD) error {
+
 	var m bfd.Metrics
 	if d.Metrics != nil {
 		labels := prometheus.Labels{
@@ -341,28 +658,28 @@ func (d *DataPlane) AddExternalInterfaceBFD(ifID uint16, conn BatchConn,
 	if err != nil {
 		return err
 	}
-	return d.addBFDController(ifID, s, cfg, m)
+	return d.addBFDController(t, ifID, s, cfg, m)
 }
 
 // getInterfaceState checks if there is a bfd session for the input interfaceID and
 // returns InterfaceUp if the relevant bfdsession state is up, or if there is no BFD
 // session. Otherwise, it returns InterfaceDown.
 func (d *DataPlane) getInterfaceState(interfaceID uint16) control.InterfaceState {
-	bfdSessions := d.bfdSessions
+	bfdSessions := d.forwardingTables().bfdSessions
 	if bfdSession, ok := bfdSessions[interfaceID]; ok && !bfdSession.IsUp() {
 		return control.InterfaceDown
 	}
 	return control.InterfaceUp
 }
 
-func (d *DataPlane) addBFDController(ifID uint16, s *bfdSend, cfg control.BFD,
-	metrics bfd.Metrics) error {
+func (d *DataPlane) addBFDController(t *forwardingTables, ifID uint16, s *bfdSend,
+	cfg control.BFD, metrics bfd.Metrics) error {
 
 	if cfg.Disable {
 		return errBFDDisabled
 	}
-	if d.bfdSessions == nil {
-		d.bfdSessions = make(map[uint16]bfdSession)
+	if t.bfdSessions == nil {
+		t.bfdSessions = make(map[uint16]bfdSession)
 	}
 
 	// Generate random discriminator. It can't be zero.
@@ -371,7 +688,7 @@ func (d *DataPlane) addBFDController(ifID uint16, s *bfdSend, cfg control.BFD,
 		return err
 	}
 	disc := layers.BFDDiscriminator(uint32(discInt.Uint64()) + 1)
-	d.bfdSessions[ifID] = &bfd.Session{
+	t.bfdSessions[ifID] = &bfd.Session{
 		Sender:                s,
 		DetectMult:            layers.BFDDetectMultiplier(cfg.DetectMult),
 		DesiredMinTxInterval:  cfg.DesiredMinTxInterval,
@@ -435,13 +752,14 @@ func (d *DataPlane) AddNextHop(ifID uint16, a *net.UDPAddr) error {
 	if a == nil {
 		return emptyValue
 	}
-	if _, exists := d.internalNextHops[ifID]; exists {
+	t := d.initialTables()
+	if _, exists := t.internalNextHops[ifID]; exists {
 		return serrors.WithCtx(alreadySet, "ifID", ifID)
 	}
-	if d.internalNextHops == nil {
-		d.internalNextHops = make(map[uint16]*net.UDPAddr)
+	if t.internalNextHops == nil {
+		t.internalNextHops = make(map[uint16]*net.UDPAddr)
 	}
-	d.internalNextHops[ifID] = a
+	t.internalNextHops[ifID] = a
 	return nil
 }
 
@@ -460,11 +778,16 @@ func (d *DataPlane) AddNextHopBFD(ifID uint16, src, dst *net.UDPAddr, cfg contro
 	if dst == nil {
 		return emptyValue
 	}
+	return d.addNextHopBFD(d.initialTables(), ifID, src, dst, cfg, sibling)
+}
+
+func (d *DataPlane) addNextHopBFD(t *forwardingTables, ifID uint16, src, dst *net.UDPAddr,
+	cfg control.BFD, sibling string) error {
 
-	for k, v := range d.internalNextHops {
+	for k, v := range t.internalNextHops {
 		if v.String() == dst.String() {
-			if c, ok := d.bfdSessions[k]; ok {
-				d.bfdSessions[ifID] = c
+			if c, ok := t.bfdSessions[k]; ok {
+				t.bfdSessions[ifID] = c
 				return nil
 			}
 		}
@@ -484,7 +807,7 @@ func (d *DataPlane) AddNextHopBFD(ifID uint16, src, dst *net.UDPAddr, cfg contro
 	if err != nil {
 		return err
 	}
-	return d.addBFDController(ifID, s, cfg, m)
+	return d.addBFDController(t, ifID, s, cfg, m)
 }
 
 func max(a int, b int) int {
@@ -498,6 +821,29 @@ type RunConfig struct {
 	NumProcessors         int
 	NumSlowPathProcessors int
 	BatchSize             int
+	// SpareSockets is the number of sockets that can be attached to the
+	// running dataplane in addition to the sockets configured before it was
+	// started.
+	SpareSockets int
+	// DrainTimeout is the maximum time the dataplane spends on forwarding
+	// the queued packets once the context passed to Run is done. If zero,
+	// the queued packets are dropped.
+	DrainTimeout time.Duration
+	// MACBatchSize is the maximum number of queued packets whose hop field
+	// MACs a processor computes together before processing the packets. If
+	// it is at most 1, the MACs are computed while processing each packet.
+	MACBatchSize int
+	// MACWorkers is the number of goroutines, shared by all processors, that
+	// compute the MACs of a batch in parallel. If zero, every processor
+	// computes the MACs of its batches itself.
+	MACWorkers int
+}
+
+// Running returns whether the dataplane was started and forwards packets.
+func (d *DataPlane) Running() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.running
 }
 
 func (d *DataPlane) Run(ctx context.Context, cfg *RunConfig) error {
@@ -505,67 +851,340 @@ func (d *DataPlane) Run(ctx context.Context, cfg *RunConfig) error {
 	d.running = true
 	d.initMetrics()
 
+	sockets := d.sockets()
+	numSockets := 0
+	for _, conns := range sockets {
+		numSockets += len(conns)
+	}
 	processorQueueSize := max(
-		len(d.interfaces)*cfg.BatchSize/cfg.NumProcessors,
+		numSockets*cfg.BatchSize/cfg.NumProcessors,
 		cfg.BatchSize)
 
-	d.initPacketPool(cfg, processorQueueSize)
-	procQs, fwQs, slowQs := initQueues(cfg, d.interfaces, processorQueueSize)
+	d.initPacketPool(cfg, numSockets, processorQueueSize)
+	procQs, fwQs, slowQs := initQueues(cfg, sockets, processorQueueSize)
+	t := d.initialTables()
+	t.fwQs = fwQs
 
-	for ifID, conn := range d.interfaces {
-		go func(ifID uint16, conn BatchConn) {
-			defer log.HandlePanic()
-			d.runReceiver(ifID, conn, cfg, procQs)
-		}(ifID, conn)
-		go func(ifID uint16, conn BatchConn) {
-			defer log.HandlePanic()
-			d.runForwarder(ifID, conn, cfg, fwQs[ifID])
-		}(ifID, conn)
+	d.runCtx, d.runCfg, d.procQs, d.slowQs = ctx, cfg, procQs, slowQs
+	d.procLocks = make([]sync.Mutex, cfg.NumProcessors+cfg.NumSlowPathProcessors)
+	d.conns = sockets
+	d.poolSockets, d.maxSockets = numSockets, numSockets+cfg.SpareSockets
+	d.stops = make(map[uint16]chan struct{}, len(sockets))
+	d.bfdCancels = make(map[bfdSession]context.CancelFunc)
+
+	for ifID, conns := range sockets {
+		stop := make(chan struct{})
+		d.stops[ifID] = stop
+		d.runSockets(ifID, conns, fwQs[ifID], stop)
+	}
+	var macPool *macWorkerPool
+	if cfg.MACBatchSize > 1 && cfg.MACWorkers > 0 {
+		macPool = newMACWorkerPool(cfg.MACWorkers)
 	}
 	for i := 0; i < cfg.NumProcessors; i++ {
 		go func(i int) {
 			defer log.HandlePanic()
-			d.runProcessor(i, procQs[i], fwQs, slowQs[i%cfg.NumSlowPathProcessors])
+			d.runProcessor(i, procQs[i], slowQs[i%cfg.NumSlowPathProcessors],
+				cfg.MACBatchSize, macPool)
 		}(i)
 	}
 	for i := 0; i < cfg.NumSlowPathProcessors; i++ {
 		go func(i int) {
 			defer log.HandlePanic()
-			d.runSlowPathProcessor(i, slowQs[i], fwQs)
+			d.runSlowPathProcessor(i, cfg.NumProcessors+i, slowQs[i])
 		}(i)
 	}
 
-	for k, v := range d.bfdSessions {
-		go func(ifID uint16, c bfdSession) {
+	for k, v := range t.bfdSessions {
+		d.runBFD(k, v)
+	}
+	if d.keySchedule != nil {
+		go func() {
 			defer log.HandlePanic()
-			if err := c.Run(ctx); err != nil && err != bfd.AlreadyRunning {
-				log.Error("BFD session failed to start", "ifID", ifID, "err", err)
-			}
-		}(k, v)
+			d.rotateKeys(ctx)
+		}()
 	}
 
 	d.mtx.Unlock()
 	<-ctx.Done()
+	d.drain(cfg.DrainTimeout)
+	return nil
+}
+
+// drain stops accepting new packets and waits until the queued packets are
+// processed and forwarded, or until the timeout expired.
+func (d *DataPlane) drain(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	atomic.StoreUint32(&d.draining, 1)
+	deadline := time.Now().Add(timeout)
+	for !d.queuesEmpty() {
+		if time.Now().After(deadline) {
+			log.Info("Drain timeout expired, dropping queued packets", "timeout", timeout)
+			return
+		}
+		time.Sleep(drainCheckInterval)
+	}
+	log.Debug("Drained queued packets")
+}
+
+// queuesEmpty returns whether all processing and forwarding queues are empty.
+func (d *DataPlane) queuesEmpty() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for _, q := range d.procQs {
+		if len(q) > 0 {
+			return false
+		}
+	}
+	for _, q := range d.slowQs {
+		if len(q) > 0 {
+			return false
+		}
+	}
+	for _, qs := range d.forwardingTables().fwQs {
+		for _, q := range qs {
+			if len(q) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// runSockets starts the receiver and the forwarder of each socket of an
+// interface. They stop when the stop channel is closed.
+func (d *DataPlane) runSockets(ifID uint16, conns []BatchConn, fwQs []chan packet,
+	stop <-chan struct{}) {
+
+	cfg, procQs := d.runCfg, d.procQs
+	for i, conn := range conns {
+		go func(conn BatchConn) {
+			defer log.HandlePanic()
+			d.runReceiver(ifID, conn, cfg, procQs, stop)
+		}(conn)
+		go func(conn BatchConn, c <-chan packet) {
+			defer log.HandlePanic()
+			d.runForwarder(ifID, conn, cfg, c, stop)
+		}(conn, fwQs[i])
+	}
+}
+
+// runBFD starts the BFD session, unless it is already running because it is
+// shared with another interface.
+func (d *DataPlane) runBFD(ifID uint16, s bfdSession) {
+	if _, running := d.bfdCancels[s]; running {
+		return
+	}
+	ctx, cancel := context.WithCancel(d.runCtx)
+	d.bfdCancels[s] = cancel
+	go func() {
+		defer log.HandlePanic()
+		if err := s.Run(ctx); err != nil && err != bfd.AlreadyRunning {
+			log.Error("BFD session failed to start", "ifID", ifID, "err", err)
+		}
+	}()
+}
+
+// AttachExternalInterface adds an external interface to the running
+// dataplane. The interface uses the given sockets, the first of which is also
+// used for the BFD session if BFD is enabled for the link. The number of
+// sockets that can be attached is limited by RunConfig.SpareSockets.
+func (d *DataPlane) AttachExternalInterface(ifID uint16, conns []BatchConn,
+	link control.LinkInfo) error {
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if !d.running {
+		return notRunning
+	}
+	if len(conns) == 0 || link.Remote.IA.IsZero() {
+		return emptyValue
+	}
+	cur := d.forwardingTables()
+	if _, exists := cur.neighborIAs[ifID]; exists {
+		return serrors.WithCtx(alreadySet, "ifID", ifID)
+	}
+	t := cur.clone()
+	t.external[ifID] = conns[0]
+	t.linkTypes[ifID] = link.LinkTo
+	t.neighborIAs[ifID] = link.Remote.IA
+	if !link.BFD.Disable {
+		err := d.addExternalBFD(t, ifID, conns[0], link.Local, link.Remote, link.BFD)
+		if err != nil {
+			return err
+		}
+	}
+	if err := d.reservePacketBuffers(len(conns)); err != nil {
+		return err
+	}
+	t.forwardingMetrics[ifID] = newInterfaceMetrics(d.Metrics, ifID, d.localIA, t.neighborIAs)
+	fwQs := make([]chan packet, len(conns))
+	for i := range fwQs {
+		fwQs[i] = make(chan packet, d.runCfg.BatchSize)
+	}
+	t.fwQs[ifID] = fwQs
+	stop := make(chan struct{})
+	d.stops[ifID] = stop
+	d.conns[ifID] = conns
+
+	d.fwTables.Store(t)
+	d.runSockets(ifID, conns, fwQs, stop)
+	if s, ok := t.bfdSessions[ifID]; ok {
+		d.runBFD(ifID, s)
+	}
+	return nil
+}
+
+// AttachSiblingInterface adds an interface that is owned by a sibling router
+// to the running dataplane. Packets leaving the AS through the interface are
+// forwarded to the internal address of the sibling router, link.Remote.Addr.
+func (d *DataPlane) AttachSiblingInterface(ifID uint16, link control.LinkInfo) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if !d.running {
+		return notRunning
+	}
+	if link.Remote.Addr == nil || link.Remote.IA.IsZero() {
+		return emptyValue
+	}
+	cur := d.forwardingTables()
+	if _, exists := cur.neighborIAs[ifID]; exists {
+		return serrors.WithCtx(alreadySet, "ifID", ifID)
+	}
+	t := cur.clone()
+	t.linkTypes[ifID] = link.LinkTo
+	t.neighborIAs[ifID] = link.Remote.IA
+	if !link.BFD.Disable {
+		err := d.addNextHopBFD(t, ifID, link.Local.Addr, link.Remote.Addr, link.BFD,
+			link.Instance)
+		if err != nil {
+			return err
+		}
+	}
+	t.internalNextHops[ifID] = link.Remote.Addr
+
+	d.fwTables.Store(t)
+	if s, ok := t.bfdSessions[ifID]; ok {
+		d.runBFD(ifID, s)
+	}
+	return nil
+}
+
+// DetachInterface removes an external or sibling interface from the running
+// dataplane. The sockets of an external interface are closed. Packets that
+// are in flight to the interface are dropped.
+func (d *DataPlane) DetachInterface(ifID uint16) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if !d.running {
+		return notRunning
+	}
+	cur := d.forwardingTables()
+	if _, exists := cur.neighborIAs[ifID]; !exists || ifID == 0 {
+		return serrors.New("detaching unknown interface", "ifID", ifID)
+	}
+	t := cur.clone()
+	delete(t.external, ifID)
+	delete(t.linkTypes, ifID)
+	delete(t.neighborIAs, ifID)
+	delete(t.internalNextHops, ifID)
+	delete(t.fwQs, ifID)
+	// The forwarding metrics are kept, packets that were received on the
+	// interface might still be processed.
+	session, hasBFD := t.bfdSessions[ifID]
+	delete(t.bfdSessions, ifID)
+	d.fwTables.Store(t)
+	// Once the processors no longer use the previous tables, nothing is added
+	// to the forwarder queues of the interface anymore.
+	d.waitForProcessors()
+
+	if hasBFD && !sharedBFDSession(t, session) {
+		if cancel, ok := d.bfdCancels[session]; ok {
+			cancel()
+			delete(d.bfdCancels, session)
+		}
+	}
+	if stop, ok := d.stops[ifID]; ok {
+		close(stop)
+		delete(d.stops, ifID)
+	}
+	// Return the packets that are still queued to the pool. The forwarders
+	// drop their queued packets as well, whoever reads a packet first
+	// returns it.
+	for _, q := range cur.fwQs[ifID] {
+		close(q)
+		for p := range q {
+			d.returnPacketToPool(p.rawPacket)
+		}
+	}
+	for _, conn := range d.conns[ifID] {
+		if err := conn.Close(); err != nil {
+			log.Info("Failed to close socket of detached interface", "ifID", ifID,
+				"err", err)
+		}
+	}
+	delete(d.conns, ifID)
 	return nil
 }
 
+// sharedBFDSession returns whether the BFD session is used by any interface in
+// the tables.
+func sharedBFDSession(t *forwardingTables, s bfdSession) bool {
+	for _, other := range t.bfdSessions {
+		if other == s {
+			return true
+		}
+	}
+	return false
+}
+
+// reservePacketBuffers makes sure that the packet pool contains the buffers
+// for n additional sockets. Buffers of detached sockets are reused.
+func (d *DataPlane) reservePacketBuffers(n int) error {
+	active := 0
+	for _, conns := range d.conns {
+		active += len(conns)
+	}
+	if active+n > d.maxSockets {
+		return serrors.New("no spare sockets left", "active", active, "requested", n,
+			"max", d.maxSockets)
+	}
+	for ; d.poolSockets < active+n; d.poolSockets++ {
+		for i := 0; i < socketBuffers(d.runCfg); i++ {
+			d.packetPool <- make([]byte, bufSize)
+		}
+	}
+	return nil
+}
+
+// socketBuffers returns the number of packet buffers used by the receiver and
+// the forwarder of a socket.
+func socketBuffers(cfg *RunConfig) int {
+	return 3 * cfg.BatchSize
+}
+
 // initializePacketPool calculates the size of the packet pool based on the
-// current dataplane settings and allocates all the buffers
-func (d *DataPlane) initPacketPool(cfg *RunConfig, processorQueueSize int) {
-	poolSize := len(d.interfaces)*cfg.BatchSize +
+// current dataplane settings and allocates all the buffers. The pool has room
+// for the buffers of the spare sockets, but they are only allocated once the
+// sockets are attached.
+func (d *DataPlane) initPacketPool(cfg *RunConfig, numSockets int, processorQueueSize int) {
+	poolSize := numSockets*cfg.BatchSize +
 		(cfg.NumProcessors+cfg.NumSlowPathProcessors)*(processorQueueSize+1) +
-		len(d.interfaces)*(2*cfg.BatchSize)
+		numSockets*(2*cfg.BatchSize)
 
 	log.Debug("Initialize packet pool of size", "poolSize", poolSize)
-	d.packetPool = make(chan []byte, poolSize)
+	d.packetPool = make(chan []byte, poolSize+cfg.SpareSockets*socketBuffers(cfg))
 	for i := 0; i < poolSize; i++ {
 		d.packetPool <- make([]byte, bufSize)
 	}
 }
 
-// initializes the processing routines and forwarders queues
-func initQueues(cfg *RunConfig, interfaces map[uint16]BatchConn,
-	processorQueueSize int) ([]chan packet, map[uint16]chan packet,
+// initializes the processing routines and forwarders queues. There is one
+// forwarder queue per socket of each interface.
+func initQueues(cfg *RunConfig, sockets map[uint16][]BatchConn,
+	processorQueueSize int) ([]chan packet, map[uint16][]chan packet,
 	[]chan slowPacket) {
 
 	procQs := make([]chan packet, cfg.NumProcessors)
@@ -576,9 +1195,12 @@ func initQueues(cfg *RunConfig, interfaces map[uint16]BatchConn,
 	for i := 0; i < cfg.NumSlowPathProcessors; i++ {
 		slowQs[i] = make(chan slowPacket, processorQueueSize)
 	}
-	fwQs := make(map[uint16]chan packet)
-	for ifID := range interfaces {
-		fwQs[ifID] = make(chan packet, cfg.BatchSize)
+	fwQs := make(map[uint16][]chan packet)
+	for ifID, conns := range sockets {
+		fwQs[ifID] = make([]chan packet, len(conns))
+		for i := range conns {
+			fwQs[ifID][i] = make(chan packet, cfg.BatchSize)
+		}
 	}
 	return procQs, fwQs, slowQs
 }
@@ -605,7 +1227,7 @@ type slowPacket struct {
 }
 
 func (d *DataPlane) runReceiver(ifID uint16, conn BatchConn, cfg *RunConfig,
-	procQs []chan packet) {
+	procQs []chan packet, stop <-chan struct{}) {
 
 	log.Debug("Run receiver for", "interface", ifID)
 	randomValue := make([]byte, 16)
@@ -614,8 +1236,9 @@ func (d *DataPlane) runReceiver(ifID uint16, conn BatchConn, cfg *RunConfig,
 	}
 
 	msgs := underlayconn.NewReadMessages(cfg.BatchSize)
-	numReusable := 0                     // unused buffers from previous loop
-	metrics := d.forwardingMetrics[ifID] // If receiver exists, fw metrics exist too.
+	numReusable := 0 // unused buffers from previous loop
+	// If receiver exists, fw metrics exist too.
+	metrics := d.forwardingTables().forwardingMetrics[ifID]
 	flowIDBuffer := make([]byte, 3)
 	hasher := fnv.New32a()
 
@@ -626,8 +1249,13 @@ func (d *DataPlane) runReceiver(ifID uint16, conn BatchConn, cfg *RunConfig,
 		metrics[sc].InputPacketsTotal.Inc()
 		metrics[sc].InputBytesTotal.Add(float64(size))
 
-		procID, err := computeProcID(pkt.Buffers[0],
-			cfg.NumProcessors, randomValue, flowIDBuffer, hasher)
+		var procID uint32
+		var err error
+		// STUN requests are not SCION packets, any processor can answer them.
+		if ifID != 0 || d.nat == nil || !stun.Is(pkt.Buffers[0][:pkt.N]) {
+			procID, err = computeProcID(pkt.Buffers[0],
+				cfg.NumProcessors, randomValue, flowIDBuffer, hasher)
+		}
 		if err != nil {
 			log.Debug("Error while computing procID", "err", err)
 			d.returnPacketToPool(pkt.Buffers[0])
@@ -658,9 +1286,21 @@ func (d *DataPlane) runReceiver(ifID uint16, conn BatchConn, cfg *RunConfig,
 		numPkts, err := conn.ReadBatch(msgs)
 		numReusable = len(msgs) - numPkts
 		if err != nil {
+			if stopped(stop) {
+				// The socket was closed because the interface was detached.
+				for _, msg := range msgs {
+					d.returnPacketToPool(msg.Buffers[0])
+				}
+				return
+			}
 			log.Debug("Error while reading batch", "interfaceID", ifID, "err", err)
 			continue
 		}
+		if atomic.LoadUint32(&d.draining) == 1 {
+			// The buffers are reused for the next read.
+			numReusable = len(msgs)
+			continue
+		}
 		for _, pkt := range msgs[:numPkts] {
 			enqueueForProcessing(pkt)
 		}
@@ -690,69 +1330,135 @@ func computeProcID(data []byte, numProcRoutines int, randomValue []byte,
 	return hasher.Sum32() % uint32(numProcRoutines), nil
 }
 
+// stopped returns whether the stop channel is closed.
+func stopped(stop <-chan struct{}) bool {
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
+
 func (d *DataPlane) returnPacketToPool(pkt []byte) {
 	d.packetPool <- pkt[:cap(pkt)]
 }
 
-func (d *DataPlane) runProcessor(id int, q <-chan packet,
-	fwQs map[uint16]chan packet, slowQ chan<- slowPacket) {
+func (d *DataPlane) runProcessor(id int, q <-chan packet, slowQ chan<- slowPacket,
+	macBatchSize int, macPool *macWorkerPool) {
 
 	log.Debug("Initialize processor with", "id", id)
 	processor := newPacketProcessor(d)
+	var pkts []packet
+	if macBatchSize > 1 {
+		processor.batch = newMACBatch(macBatchSize, macPool)
+		pkts = make([]packet, 0, macBatchSize)
+	}
 	for d.running {
 		p, ok := <-q
 		if !ok {
 			continue
 		}
-		result, err := processor.processPkt(p.rawPacket, p.srcAddr, p.ingress)
-
-		sc := classOfSize(len(p.rawPacket))
-		metrics := d.forwardingMetrics[p.ingress][sc]
-		metrics.ProcessedPackets.Inc()
+		if processor.batch == nil {
+			d.beginProcessing(id)
+			d.processPacket(id, processor, p, slowQ)
+			d.endProcessing(id)
+			continue
+		}
+		pkts = readQueuedPackets(q, append(pkts[:0], p))
+		processor.updateMACs()
+		processor.batch.precompute(pkts, processor.macs)
+		for i, p := range pkts {
+			processor.batch.current = i
+			d.beginProcessing(id)
+			d.processPacket(id, processor, p, slowQ)
+			d.endProcessing(id)
+		}
+	}
+}
 
-		egress := result.EgressID
-		switch {
-		case err == nil:
-		case errors.Is(err, slowPathRequired):
-			select {
-			case slowQ <- slowPacket{p, result.SlowPathRequest}:
-			default:
-				metrics.DroppedPacketsBusySlowPath.Inc()
-				d.returnPacketToPool(p.rawPacket)
+// readQueuedPackets appends the packets that are queued to pkts, up to its
+// capacity, without blocking.
+func readQueuedPackets(q <-chan packet, pkts []packet) []packet {
+	for len(pkts) < cap(pkts) {
+		select {
+		case p, ok := <-q:
+			if !ok {
+				return pkts
 			}
-			continue
+			pkts = append(pkts, p)
 		default:
-			log.Debug("Error processing packet", "err", err)
-			metrics.DroppedPacketsInvalid.Inc()
-			d.returnPacketToPool(p.rawPacket)
-			continue
+			return pkts
 		}
-		if result.OutPkt == nil { // e.g. BFD case no message is forwarded
-			d.returnPacketToPool(p.rawPacket)
-			continue
-		}
-		fwCh, ok := fwQs[egress]
-		if !ok {
-			log.Debug("Error determining forwarder. Egress is invalid", "egress", egress)
-			metrics.DroppedPacketsInvalid.Inc()
-			d.returnPacketToPool(p.rawPacket)
-			continue
+	}
+	return pkts
+}
+
+// processPacket processes the packet and passes it on to the forwarder of the
+// egress interface or to the slow path.
+func (d *DataPlane) processPacket(id int, processor *scionPacketProcessor, p packet,
+	slowQ chan<- slowPacket) {
+
+	result, err := processor.processPkt(p.rawPacket, p.srcAddr, p.ingress)
+
+	sc := classOfSize(len(p.rawPacket))
+	metrics := processor.ft.forwardingMetrics[p.ingress][sc]
+	metrics.ProcessedPackets[pathTypeOf(p.rawPacket)].Inc()
+
+	egress := result.EgressID
+	switch {
+	case err == nil:
+	case errors.Is(err, slowPathRequired):
+		// The packet is answered with an SCMP error by the slow path, but
+		// it is not forwarded.
+		switch result.SlowPathRequest.cause {
+		case macVerificationFailed:
+			metrics.DroppedPacketsMACFailure.Inc()
+		case expiredHop:
+			metrics.DroppedPacketsExpiredHop.Inc()
 		}
-		p.rawPacket = result.OutPkt
-		p.dstAddr = result.OutAddr
-		p.trafficType = result.TrafficType
 		select {
-		case fwCh <- p:
+		case slowQ <- slowPacket{p, result.SlowPathRequest}:
 		default:
+			metrics.DroppedPacketsBusySlowPath.Inc()
 			d.returnPacketToPool(p.rawPacket)
-			metrics.DroppedPacketsBusyForwarder.Inc()
 		}
-
+		return
+	default:
+		log.Debug("Error processing packet", "err", err)
+		metrics.DroppedPacketsInvalid.Inc()
+		d.returnPacketToPool(p.rawPacket)
+		return
+	}
+	if result.OutPkt == nil { // e.g. BFD case no message is forwarded
+		d.returnPacketToPool(p.rawPacket)
+		return
+	}
+	fwChs, ok := processor.ft.fwQs[egress]
+	if !ok {
+		log.Debug("Error determining forwarder. Egress is invalid", "egress", egress)
+		metrics.DroppedPacketsInvalid.Inc()
+		d.returnPacketToPool(p.rawPacket)
+		return
+	}
+	// The receivers dispatch packets to the processors by flow, so picking
+	// the socket by processor keeps the packets of a flow on one socket.
+	fwCh := fwChs[id%len(fwChs)]
+	p.rawPacket = result.OutPkt
+	p.dstAddr = result.OutAddr
+	p.trafficType = result.TrafficType
+	if c := d.packetCapture(); c != nil {
+		c.capture(p.rawPacket, p.srcAddr, p.dstAddr, p.ingress, egress)
+	}
+	select {
+	case fwCh <- p:
+	default:
+		d.returnPacketToPool(p.rawPacket)
+		metrics.DroppedPacketsBusyForwarder.Inc()
 	}
 }
 
-func (d *DataPlane) runSlowPathProcessor(id int, q <-chan slowPacket,
-	fwQs map[uint16]chan packet) {
+func (d *DataPlane) runSlowPathProcessor(id, slot int, q <-chan slowPacket) {
 
 	log.Debug("Initialize slow-path processor with", "id", id)
 	processor := newSlowPathProcessor(d)
@@ -761,29 +1467,67 @@ func (d *DataPlane) runSlowPathProcessor(id int, q <-chan slowPacket,
 		if !ok {
 			continue
 		}
-		res, err := processor.processPacket(p)
-		sc := classOfSize(len(p.rawPacket))
-		metrics := d.forwardingMetrics[p.packet.ingress][sc]
-		if err != nil {
-			log.Debug("Error processing packet", "err", err)
-			metrics.DroppedPacketsInvalid.Inc()
-			d.returnPacketToPool(p.packet.rawPacket)
-			continue
-		}
-		p.packet.dstAddr = res.OutAddr
-		p.packet.rawPacket = res.OutPkt
+		d.beginProcessing(slot)
+		d.processSlowPacket(id, processor, p)
+		d.endProcessing(slot)
+	}
+}
 
-		fwCh, ok := fwQs[res.EgressID]
-		if !ok {
-			log.Debug("Error determining forwarder. Egress is invalid", "egress", res.EgressID)
-			d.returnPacketToPool(p.packet.rawPacket)
-			continue
-		}
-		select {
-		case fwCh <- p.packet:
-		default:
-			d.returnPacketToPool(p.packet.rawPacket)
-		}
+// processSlowPacket processes the packet that requires the slow path and passes
+// the result on to the forwarder of the egress interface.
+func (d *DataPlane) processSlowPacket(id int, processor *slowPathPacketProcessor,
+	p slowPacket) {
+
+	res, err := processor.processPacket(p)
+	sc := classOfSize(len(p.rawPacket))
+	metrics := processor.ft.forwardingMetrics[p.packet.ingress][sc]
+	if errors.Is(err, scmpRateLimited) {
+		metrics.DroppedPacketsSCMPRateLimited.Inc()
+		d.returnPacketToPool(p.packet.rawPacket)
+		return
+	}
+	if err != nil {
+		log.Debug("Error processing packet", "err", err)
+		metrics.DroppedPacketsInvalid.Inc()
+		d.returnPacketToPool(p.packet.rawPacket)
+		return
+	}
+	p.packet.dstAddr = res.OutAddr
+	p.packet.rawPacket = res.OutPkt
+
+	fwChs, ok := processor.ft.fwQs[res.EgressID]
+	if !ok {
+		log.Debug("Error determining forwarder. Egress is invalid", "egress", res.EgressID)
+		d.returnPacketToPool(p.packet.rawPacket)
+		return
+	}
+	fwCh := fwChs[id%len(fwChs)]
+	select {
+	case fwCh <- p.packet:
+	default:
+		d.returnPacketToPool(p.packet.rawPacket)
+	}
+}
+
+// beginProcessing marks that the processor in the slot started processing a
+// packet. It must be called before the processor loads the forwarding tables.
+func (d *DataPlane) beginProcessing(slot int) {
+	d.procLocks[slot].Lock()
+}
+
+// endProcessing marks that the processor in the slot finished processing a
+// packet, i.e., it no longer uses the forwarding tables it loaded.
+func (d *DataPlane) endProcessing(slot int) {
+	d.procLocks[slot].Unlock()
+}
+
+// waitForProcessors waits until the processors finished the packets they are
+// processing. Afterwards, no processor uses forwarding tables that were
+// replaced before the call.
+func (d *DataPlane) waitForProcessors() {
+	for i := range d.procLocks {
+		d.procLocks[i].Lock()
+		d.procLocks[i].Unlock() //nolint:staticcheck // Only waits for the holder.
 	}
 }
 
@@ -805,6 +1549,7 @@ func newSlowPathProcessor(d *DataPlane) *slowPathPacketProcessor {
 
 type slowPathPacketProcessor struct {
 	d         *DataPlane
+	ft        *forwardingTables
 	ingressID uint16
 	rawPkt    []byte
 	srcAddr   *net.UDPAddr
@@ -852,6 +1597,7 @@ type processResult struct {
 func (p *slowPathPacketProcessor) processPacket(pkt slowPacket) (processResult, error) {
 	var err error
 	p.reset()
+	p.ft = p.d.forwardingTables()
 	p.ingressID = pkt.ingress
 	p.srcAddr = pkt.srcAddr
 	p.rawPkt = pkt.rawPacket
@@ -877,6 +1623,13 @@ func (p *slowPathPacketProcessor) processPacket(pkt slowPacket) (processResult,
 		if p.path == nil {
 			return processResult{}, malformedPath
 		}
+	case onehop.PathType:
+		// One-hop paths only end up in the slow path for SCMP error messages
+		// to the local control service.
+		if pkt.slowPathRequest.typ != slowPathSCMP {
+			return processResult{}, serrors.New("Path type not supported for slow-path",
+				"type", pathType)
+		}
 	default:
 		//unsupported path type
 		return processResult{}, serrors.New("Path type not supported for slow-path",
@@ -884,6 +1637,9 @@ func (p *slowPathPacketProcessor) processPacket(pkt slowPacket) (processResult,
 	}
 	switch pkt.slowPathRequest.typ {
 	case slowPathSCMP: //SCMP
+		if !p.d.allowSCMPError(p.scionLayer.SrcIA, p.ingressID) {
+			return processResult{}, scmpRateLimited
+		}
 		s := pkt.slowPathRequest
 		var layer gopacket.SerializableLayer
 		switch s.scmpType {
@@ -907,6 +1663,16 @@ func (p *slowPathPacketProcessor) processPacket(pkt slowPacket) (processResult,
 	}
 }
 
+// allowSCMPError reports whether the rate limits permit an SCMP error message
+// in response to a packet from srcIA that is sent on the interface ifID.
+func (d *DataPlane) allowSCMPError(srcIA addr.IA, ifID uint16) bool {
+	now := time.Now()
+	if d.scmpPerAS != nil && !d.scmpPerAS.Allow(uint64(srcIA), now) {
+		return false
+	}
+	return d.scmpPerInterface == nil || d.scmpPerInterface.Allow(uint64(ifID), now)
+}
+
 func updateOutputMetrics(metrics interfaceMetrics, packets []packet) {
 	// We need to collect stats by traffic type and size class.
 	// Try to reduce the metrics lookup penalty by using some
@@ -930,7 +1696,8 @@ func updateOutputMetrics(metrics interfaceMetrics, packets []packet) {
 	}
 }
 
-func (d *DataPlane) runForwarder(ifID uint16, conn BatchConn, cfg *RunConfig, c <-chan packet) {
+func (d *DataPlane) runForwarder(ifID uint16, conn BatchConn, cfg *RunConfig, c <-chan packet,
+	stop <-chan struct{}) {
 
 	log.Debug("Initialize forwarder for", "interface", ifID)
 
@@ -944,11 +1711,22 @@ func (d *DataPlane) runForwarder(ifID uint16, conn BatchConn, cfg *RunConfig, c
 		msgs[i].Buffers = make([][]byte, 1)
 	}
 
-	metrics := d.forwardingMetrics[ifID]
+	metrics := d.forwardingTables().forwardingMetrics[ifID]
 
 	toWrite := 0
 	for d.running {
-		toWrite += readUpTo(c, cfg.BatchSize-toWrite, toWrite == 0, pkts[toWrite:])
+		toWrite += readUpTo(c, stop, cfg.BatchSize-toWrite, toWrite == 0, pkts[toWrite:])
+		if stopped(stop) {
+			// The interface was detached, drop the queued packets.
+			for {
+				for _, p := range pkts[:toWrite] {
+					d.returnPacketToPool(p.rawPacket)
+				}
+				if toWrite = readUpTo(c, nil, cfg.BatchSize, false, pkts); toWrite == 0 {
+					return
+				}
+			}
+		}
 
 		// Turn the packets into underlay messages that WriteBatch can send.
 		for i, p := range pkts[:toWrite] {
@@ -988,15 +1766,21 @@ func (d *DataPlane) runForwarder(ifID uint16, conn BatchConn, cfg *RunConfig, c
 	}
 }
 
-func readUpTo(c <-chan packet, n int, needsBlocking bool, pkts []packet) int {
+func readUpTo(c <-chan packet, stop <-chan struct{}, n int, needsBlocking bool,
+	pkts []packet) int {
+
 	i := 0
 	if needsBlocking {
-		p, ok := <-c
-		if !ok {
+		select {
+		case p, ok := <-c:
+			if !ok {
+				return i
+			}
+			pkts[i] = p
+			i++
+		case <-stop:
 			return i
 		}
-		pkts[i] = p
-		i++
 	}
 
 	for ; i < n; i++ {
@@ -1018,7 +1802,6 @@ func newPacketProcessor(d *DataPlane) *scionPacketProcessor {
 	p := &scionPacketProcessor{
 		d:              d,
 		buffer:         gopacket.NewSerializeBuffer(),
-		mac:            d.macFactory(),
 		macInputBuffer: make([]byte, max(path.MACBufferSize, libepic.MACBufferSize)),
 	}
 	p.scionLayer.RecyclePaths()
@@ -1038,7 +1821,7 @@ func (p *scionPacketProcessor) reset() error {
 	if err := p.buffer.Clear(); err != nil {
 		return serrors.WrapStr("Failed to clear buffer", err)
 	}
-	p.mac.Reset()
+	p.updateMACs()
 	p.cachedMac = nil
 	// Reset hbh layer
 	p.hbhLayer = slayers.HopByHopExtnSkipper{}
@@ -1047,22 +1830,51 @@ func (p *scionPacketProcessor) reset() error {
 	return nil
 }
 
+// updateMACs recreates the hop field MACs if the hop field keys changed.
+func (p *scionPacketProcessor) updateMACs() {
+	keys := p.d.hopFieldKeys()
+	if keys == p.keys {
+		return
+	}
+	p.keys = keys
+	for i, key := range keys.keys {
+		p.macs[i] = nil
+		if key != nil {
+			p.macs[i], _ = newHopFieldMAC(keys.algs[i], key)
+		}
+	}
+}
+
+// mac returns the hop field MAC for hop fields with the given key phase, or nil
+// if no key is accepted for the key phase.
+func (p *scionPacketProcessor) mac(keyPhase bool) *hopFieldMAC {
+	return p.macs[keyPhaseIndex(keyPhase)]
+}
+
 func (p *scionPacketProcessor) processPkt(rawPkt []byte,
 	srcAddr *net.UDPAddr, ingressID uint16) (processResult, error) {
 
 	if err := p.reset(); err != nil {
 		return processResult{}, err
 	}
+	p.ft = p.d.forwardingTables()
 	p.rawPkt = rawPkt
 	p.srcAddr = srcAddr
 	p.ingressID = ingressID
 
+	if ingressID == 0 && p.d.nat != nil && stun.Is(rawPkt) {
+		return p.processSTUN()
+	}
+
 	// parse SCION header and skip extensions;
 	var err error
 	p.lastLayer, err = decodeLayers(p.rawPkt, &p.scionLayer, &p.hbhLayer, &p.e2eLayer)
 	if err != nil {
 		return processResult{}, err
 	}
+	if ingressID == 0 && p.d.nat != nil {
+		p.learnNATHost()
+	}
 
 	pld := p.lastLayer.LayerPayload()
 
@@ -1092,8 +1904,38 @@ func (p *scionPacketProcessor) processPkt(rawPkt []byte,
 	}
 }
 
+// processSTUN answers the STUN binding request of an end host in the local AS
+// with the address the request was received from, and records it as the NAT
+// mapping of the end host.
+func (p *scionPacketProcessor) processSTUN() (processResult, error) {
+	id, err := stun.ParseRequest(p.rawPkt)
+	if err != nil {
+		return processResult{}, err
+	}
+	mapped := p.srcAddr.AddrPort()
+	p.d.nat.update(mapped, time.Now())
+	return processResult{
+		OutPkt:  stun.AppendResponse(p.rawPkt[:0], id, mapped),
+		OutAddr: p.srcAddr,
+	}, nil
+}
+
+// learnNATHost records the UDP port the end host behind a NAT sent the packet
+// from, so that the packets to that port are sent to its mapping.
+func (p *scionPacketProcessor) learnNATHost() {
+	src, err := p.scionLayer.SrcAddr()
+	if err != nil || src.Type() != addr.HostTypeIP {
+		return
+	}
+	port, _, ok := udpPorts(p.lastLayer)
+	if !ok {
+		return
+	}
+	p.d.nat.learn(p.srcAddr.AddrPort(), netip.AddrPortFrom(src.IP(), port), time.Now())
+}
+
 func (p *scionPacketProcessor) processInterBFD(oh *onehop.Path, data []byte) error {
-	if len(p.d.bfdSessions) == 0 {
+	if len(p.ft.bfdSessions) == 0 {
 		return noBFDSessionConfigured
 	}
 
@@ -1102,7 +1944,7 @@ func (p *scionPacketProcessor) processInterBFD(oh *onehop.Path, data []byte) err
 		return err
 	}
 
-	if v, ok := p.d.bfdSessions[p.ingressID]; ok {
+	if v, ok := p.ft.bfdSessions[p.ingressID]; ok {
 		v.ReceiveMessage(bfd)
 		return nil
 	}
@@ -1111,7 +1953,7 @@ func (p *scionPacketProcessor) processInterBFD(oh *onehop.Path, data []byte) err
 }
 
 func (p *scionPacketProcessor) processIntraBFD(data []byte) error {
-	if len(p.d.bfdSessions) == 0 {
+	if len(p.ft.bfdSessions) == 0 {
 		return noBFDSessionConfigured
 	}
 
@@ -1121,14 +1963,14 @@ func (p *scionPacketProcessor) processIntraBFD(data []byte) error {
 	}
 
 	ifID := uint16(0)
-	for k, v := range p.d.internalNextHops {
+	for k, v := range p.ft.internalNextHops {
 		if bytes.Equal(v.IP, p.srcAddr.IP) && v.Port == p.srcAddr.Port {
 			ifID = k
 			break
 		}
 	}
 
-	if v, ok := p.d.bfdSessions[ifID]; ok {
+	if v, ok := p.ft.bfdSessions[ifID]; ok {
 		v.ReceiveMessage(bfd)
 		return nil
 	}
@@ -1200,6 +2042,8 @@ func (p *scionPacketProcessor) processEPIC() (processResult, error) {
 type scionPacketProcessor struct {
 	// d is a reference to the dataplane instance that initiated this processor.
 	d *DataPlane
+	// ft are the forwarding tables used for the current packet.
+	ft *forwardingTables
 	// ingressID is the interface ID this packet came in, determined from the
 	// socket.
 	ingressID uint16
@@ -1210,8 +2054,14 @@ type scionPacketProcessor struct {
 	srcAddr *net.UDPAddr
 	// buffer is the buffer that can be used to serialize gopacket layers.
 	buffer gopacket.SerializeBuffer
-	// mac is the hasher for the MAC computation.
-	mac hash.Hash
+	// macs are the hop field MACs indexed by key phase. An entry is nil if no
+	// key is accepted for the key phase.
+	macs [2]*hopFieldMAC
+	// keys are the hop field keys the MACs were created from.
+	keys *hopFieldKeys
+	// batch holds the MACs precomputed for the batch of packets that is
+	// processed. It is nil if the MACs are not precomputed.
+	batch *macBatch
 
 	// scionLayer is the SCION gopacket layer.
 	scionLayer slayers.SCION
@@ -1236,6 +2086,10 @@ type scionPacketProcessor struct {
 	cachedMac []byte
 	// macInputBuffer avoid allocating memory during processing.
 	macInputBuffer []byte
+	// macInput and fullMAC hold the input and the result of the MAC
+	// computation of the current hop field.
+	macInput [path.MACBufferSize]byte
+	fullMAC  [path.MACBufferSize]byte
 
 	// bfdLayer is reusable buffer for parsing BFD messages
 	bfdLayer layers.BFD
@@ -1438,7 +2292,7 @@ func (p *scionPacketProcessor) validateTransitUnderlaySrc() (processResult, erro
 		return processResult{}, nil
 	}
 	pktIngressID := p.ingressInterface()
-	expectedSrc, ok := p.d.internalNextHops[pktIngressID]
+	expectedSrc, ok := p.ft.internalNextHops[pktIngressID]
 	if !ok || !expectedSrc.IP.Equal(p.srcAddr.IP) {
 		// Drop
 		return processResult{}, invalidSrcAddrForTransit
@@ -1448,8 +2302,8 @@ func (p *scionPacketProcessor) validateTransitUnderlaySrc() (processResult, erro
 
 func (p *scionPacketProcessor) validateEgressID() (processResult, error) {
 	pktEgressID := p.egressInterface()
-	_, ih := p.d.internalNextHops[pktEgressID]
-	_, eh := p.d.external[pktEgressID]
+	_, ih := p.ft.internalNextHops[pktEgressID]
+	_, eh := p.ft.external[pktEgressID]
 	if !ih && !eh {
 		errCode := slayers.SCMPCodeUnknownHopFieldEgress
 		if !p.infoField.ConsDir {
@@ -1465,7 +2319,7 @@ func (p *scionPacketProcessor) validateEgressID() (processResult, error) {
 		return processResult{SlowPathRequest: slowPathRequest}, slowPathRequired
 	}
 
-	ingress, egress := p.d.linkTypes[p.ingressID], p.d.linkTypes[pktEgressID]
+	ingress, egress := p.ft.linkTypes[p.ingressID], p.ft.linkTypes[pktEgressID]
 	if !p.effectiveXover {
 		// Check that the interface pair is valid within a single segment.
 		// No check required if the packet is received from an internal interface.
@@ -1545,11 +2399,21 @@ func (p *scionPacketProcessor) currentHopPointer() uint16 {
 }
 
 func (p *scionPacketProcessor) verifyCurrentMAC() (processResult, error) {
-	fullMac := path.FullMAC(p.mac, p.infoField, p.hopField, p.macInputBuffer[:path.MACBufferSize])
-	if subtle.ConstantTimeCompare(p.hopField.Mac[:path.MacLen], fullMac[:path.MacLen]) == 0 {
+	// If no key is accepted for the key phase, the MAC verification fails.
+	var expected []byte
+	if mac := p.mac(p.hopField.KeyPhase); mac != nil {
+		path.MACInput(p.infoField.SegID, p.infoField.Timestamp, p.hopField.ExpTime,
+			p.hopField.ConsIngress, p.hopField.ConsEgress, p.macInput[:])
+		if !p.batch.lookup(mac, p.hopField.KeyPhase, &p.fullMAC, &p.macInput) {
+			mac.compute(&p.fullMAC, &p.macInput)
+		}
+		expected = p.fullMAC[:path.MacLen]
+	}
+	if expected == nil || subtle.ConstantTimeCompare(p.hopField.Mac[:path.MacLen], expected) == 0 {
 		log.Debug("SCMP: MAC verification failed", "expected", fmt.Sprintf(
-			"%x", fullMac[:path.MacLen]),
+			"%x", expected),
 			"actual", fmt.Sprintf("%x", p.hopField.Mac[:path.MacLen]),
+			"key_phase", p.hopField.KeyPhase,
 			"cons_dir", p.infoField.ConsDir,
 			"if_id", p.ingressID, "curr_inf", p.path.PathMeta.CurrINF,
 			"curr_hf", p.path.PathMeta.CurrHF, "seg_id", p.infoField.SegID)
@@ -1563,13 +2427,13 @@ func (p *scionPacketProcessor) verifyCurrentMAC() (processResult, error) {
 	}
 	// Add the full MAC to the SCION packet processor,
 	// such that EPIC does not need to recalculate it.
-	p.cachedMac = fullMac
+	p.cachedMac = p.fullMAC[:]
 
 	return processResult{}, nil
 }
 
 func (p *scionPacketProcessor) resolveInbound() (*net.UDPAddr, processResult, error) {
-	a, err := p.d.resolveLocalDst(p.scionLayer)
+	a, err := p.d.resolveLocalDst(p.scionLayer, p.lastLayer)
 	switch {
 	case errors.Is(err, noSVCBackend):
 		log.Debug("SCMP: no SVC backend")
@@ -1650,12 +2514,18 @@ func (p *scionPacketProcessor) egressInterface() uint16 {
 }
 
 func (p *scionPacketProcessor) validateEgressUp() (processResult, error) {
-	egressID := p.egressInterface()
-	if v, ok := p.d.bfdSessions[egressID]; ok {
+	return p.validateInterfaceUp(p.egressInterface())
+}
+
+// validateInterfaceUp checks that the BFD session of the egress interface, if
+// any, is up. Otherwise, an SCMP interface down message is requested to notify
+// the source of the packet.
+func (p *scionPacketProcessor) validateInterfaceUp(egressID uint16) (processResult, error) {
+	if v, ok := p.ft.bfdSessions[egressID]; ok {
 		if !v.IsUp() {
 			var s slowPathRequest
 			log.Debug("SCMP: bfd session down")
-			if _, external := p.d.external[egressID]; !external {
+			if _, external := p.ft.external[egressID]; !external {
 				s = slowPathRequest{
 					scmpType:  slayers.SCMPTypeInternalConnectivityDown,
 					code:      0,
@@ -1712,7 +2582,7 @@ func (p *scionPacketProcessor) handleEgressRouterAlert() (processResult, error)
 		return processResult{}, nil
 	}
 	egressID := p.egressInterface()
-	if _, ok := p.d.external[egressID]; !ok {
+	if _, ok := p.ft.external[egressID]; !ok {
 		return processResult{}, nil
 	}
 	*alert = false
@@ -1854,7 +2724,7 @@ func (p *scionPacketProcessor) process() (processResult, error) {
 		return r, err
 	}
 	egressID := p.egressInterface()
-	if _, ok := p.d.external[egressID]; ok {
+	if _, ok := p.ft.external[egressID]; ok {
 		// Not ASTransit in
 		if err := p.processEgress(); err != nil {
 			return processResult{}, err
@@ -1874,7 +2744,7 @@ func (p *scionPacketProcessor) process() (processResult, error) {
 		return processResult{EgressID: egressID, OutPkt: p.rawPkt, TrafficType: tt}, nil
 	}
 	// ASTransit in: pkt leaving this AS through another BR.
-	if a, ok := p.d.internalNextHops[egressID]; ok {
+	if a, ok := p.ft.internalNextHops[egressID]; ok {
 		return processResult{OutAddr: a, OutPkt: p.rawPkt, TrafficType: ttInTransit}, nil
 	}
 	errCode := slayers.SCMPCodeUnknownHopFieldEgress
@@ -1913,7 +2783,7 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 				"type", "ohp", "egress", ohp.FirstHop.ConsEgress,
 				"localIA", p.d.localIA, "srcIA", s.SrcIA)
 		}
-		neighborIA, ok := p.d.neighborIAs[ohp.FirstHop.ConsEgress]
+		neighborIA, ok := p.ft.neighborIAs[ohp.FirstHop.ConsEgress]
 		if !ok {
 			// TODO parameter problem invalid interface
 			return processResult{}, serrors.WithCtx(cannotRoute,
@@ -1924,12 +2794,22 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 				"type", "ohp", "egress", ohp.FirstHop.ConsEgress,
 				"neighborIA", neighborIA, "dstIA", s.DstIA)
 		}
-		mac := path.MAC(p.mac, ohp.Info, ohp.FirstHop, p.macInputBuffer[:path.MACBufferSize])
-		if subtle.ConstantTimeCompare(ohp.FirstHop.Mac[:], mac[:]) == 0 {
+		var mac [path.MacLen]byte
+		h := p.mac(ohp.FirstHop.KeyPhase)
+		if h != nil {
+			mac = h.mac(ohp.Info, ohp.FirstHop)
+		}
+		if h == nil || subtle.ConstantTimeCompare(ohp.FirstHop.Mac[:], mac[:]) == 0 {
 			// TODO parameter problem -> invalid MAC
 			return processResult{}, serrors.New("MAC", "expected", fmt.Sprintf("%x", mac),
 				"actual", fmt.Sprintf("%x", ohp.FirstHop.Mac), "type", "ohp")
 		}
+		// Beacons are sent over one-hop paths. If the link is down, the
+		// control service is notified such that it stops beaconing over the
+		// interface.
+		if r, err := p.validateInterfaceUp(ohp.FirstHop.ConsEgress); err != nil {
+			return r, err
+		}
 		ohp.Info.UpdateSegID(ohp.FirstHop.Mac)
 
 		if err := updateSCIONLayer(p.rawPkt, s, p.buffer); err != nil {
@@ -1945,7 +2825,7 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 			"type", "ohp", "ingress", p.ingressID,
 			"localIA", p.d.localIA, "dstIA", s.DstIA)
 	}
-	neighborIA := p.d.neighborIAs[p.ingressID]
+	neighborIA := p.ft.neighborIAs[p.ingressID]
 	if !neighborIA.Equal(s.SrcIA) {
 		return processResult{}, serrors.WrapStr("bad source IA", cannotRoute,
 			"type", "ohp", "ingress", p.ingressID,
@@ -1955,24 +2835,25 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 	ohp.SecondHop = path.HopField{
 		ConsIngress: p.ingressID,
 		ExpTime:     ohp.FirstHop.ExpTime,
+		KeyPhase:    p.keys.phase,
 	}
-	// XXX(roosd): Here we leak the buffer into the SCION packet header.
-	// This is okay because we do not operate on the buffer or the packet
-	// for the rest of processing.
-	ohp.SecondHop.Mac = path.MAC(p.mac, ohp.Info, ohp.SecondHop,
-		p.macInputBuffer[:path.MACBufferSize])
+	ohp.SecondHop.Mac = p.mac(p.keys.phase).mac(ohp.Info, ohp.SecondHop)
 
 	if err := updateSCIONLayer(p.rawPkt, s, p.buffer); err != nil {
 		return processResult{}, err
 	}
-	a, err := p.d.resolveLocalDst(s)
+	a, err := p.d.resolveLocalDst(s, p.lastLayer)
 	if err != nil {
 		return processResult{}, err
 	}
 	return processResult{OutAddr: a, OutPkt: p.rawPkt}, nil
 }
 
-func (d *DataPlane) resolveLocalDst(s slayers.SCION) (*net.UDPAddr, error) {
+// resolveLocalDst returns the underlay address of the destination in the local
+// AS of the packet with the SCION header s and the last decoded layer l.
+func (d *DataPlane) resolveLocalDst(s slayers.SCION,
+	l gopacket.DecodingLayer) (*net.UDPAddr, error) {
+
 	dst, err := s.DstAddr()
 	if err != nil {
 		// TODO parameter problem.
@@ -1988,12 +2869,33 @@ func (d *DataPlane) resolveLocalDst(s slayers.SCION) (*net.UDPAddr, error) {
 		}
 		return a, nil
 	case addr.HostTypeIP:
+		if d.nat != nil {
+			// Packets without UDP header are looked up with port 0, they
+			// reach the end host if it is the only one behind its NAT.
+			_, dstPort, _ := udpPorts(l)
+			host := netip.AddrPortFrom(dst.IP(), dstPort)
+			if port, ok := d.nat.port(host, time.Now()); ok {
+				return &net.UDPAddr{IP: dst.IP().AsSlice(), Port: int(port)}, nil
+			}
+		}
 		return addEndhostPort(dst.IP().AsSlice()), nil
 	default:
 		panic("unexpected address type returned from DstAddr")
 	}
 }
 
+// udpPorts returns the ports of the SCION/UDP header following the layer l.
+func udpPorts(l gopacket.DecodingLayer) (src, dst uint16, ok bool) {
+	if l.NextLayerType() != slayers.LayerTypeSCIONUDP {
+		return 0, 0, false
+	}
+	pld := l.LayerPayload()
+	if len(pld) < 4 {
+		return 0, 0, false
+	}
+	return binary.BigEndian.Uint16(pld[0:2]), binary.BigEndian.Uint16(pld[2:4]), true
+}
+
 func addEndhostPort(dst net.IP) *net.UDPAddr {
 	return &net.UDPAddr{IP: dst, Port: topology.EndhostPort}
 }
@@ -2047,10 +2949,10 @@ func newBFDSend(conn BatchConn, srcIA, dstIA addr.IA, srcAddr, dstAddr *net.UDPA
 	if !ok {
 		return nil, serrors.New("invalid destination IP", "ip", dstAddr.IP)
 	}
-	if err := scn.SetSrcAddr(addr.HostIP(srcAddrIP)); err != nil {
+	if err := scn.SetSrcAddr(addr.HostIP(srcAddrIP.Unmap())); err != nil {
 		panic(err) // Must work
 	}
-	if err := scn.SetDstAddr(addr.HostIP(dstAddrIP)); err != nil {
+	if err := scn.SetDstAddr(addr.HostIP(dstAddrIP.Unmap())); err != nil {
 		panic(err) // Must work
 	}
 
@@ -2116,72 +3018,30 @@ func (p *slowPathPacketProcessor) prepareSCMP(
 	cause error,
 ) ([]byte, error) {
 
-	// *copy* and reverse path -- the original path should not be modified as this writes directly
-	// back to rawPkt (quote).
-	var path *scion.Raw
-	pathType := p.scionLayer.Path.Type()
-	switch pathType {
-	case scion.PathType:
-		var ok bool
-		path, ok = p.scionLayer.Path.(*scion.Raw)
-		if !ok {
-			return nil, serrors.WithCtx(cannotRoute, "details", "unsupported path type",
-				"path type", pathType)
-		}
-	case epic.PathType:
-		epicPath, ok := p.scionLayer.Path.(*epic.Path)
-		if !ok {
-			return nil, serrors.WithCtx(cannotRoute, "details", "unsupported path type",
-				"path type", pathType)
-		}
-		path = epicPath.ScionPath
-	default:
-		return nil, serrors.WithCtx(cannotRoute, "details", "unsupported path type",
-			"path type", pathType)
-	}
-	decPath, err := path.ToDecoded()
-	if err != nil {
-		return nil, serrors.Wrap(cannotRoute, err, "details", "decoding raw path")
-	}
-	revPathTmp, err := decPath.Reverse()
-	if err != nil {
-		return nil, serrors.Wrap(cannotRoute, err, "details", "reversing path for SCMP")
-	}
-	revPath := revPathTmp.(*scion.Decoded)
-
-	peering, err := determinePeer(revPath.PathMeta, revPath.InfoFields[revPath.PathMeta.CurrINF])
-	if err != nil {
-		return nil, serrors.Wrap(cannotRoute, err, "details", "peering cannot be determined")
-	}
-
-	// Revert potential path segment switches that were done during processing.
-	if revPath.IsXover() && !peering {
-		// An effective cross-over is a change of segment other than at
-		// a peering hop.
-		if err := revPath.IncPath(); err != nil {
-			return nil, serrors.Wrap(cannotRoute, err, "details", "reverting cross over for SCMP")
+	var replyPath path.Path
+	if p.scionLayer.Path.Type() == onehop.PathType {
+		// SCMP messages for one-hop paths are only sent to the local AS, e.g.,
+		// to notify the control service that the egress interface of a beacon
+		// is down. They are delivered over an empty path.
+		if p.ingressID != 0 {
+			return nil, serrors.WithCtx(cannotRoute, "details",
+				"SCMP for one-hop path from remote AS")
 		}
-	}
-	// If the packet is sent to an external router, we need to increment the
-	// path to prepare it for the next hop.
-	_, external := p.d.external[p.ingressID]
-	if external {
-		infoField := &revPath.InfoFields[revPath.PathMeta.CurrINF]
-		if infoField.ConsDir && !peering {
-			hopField := revPath.HopFields[revPath.PathMeta.CurrHF]
-			infoField.UpdateSegID(hopField.Mac)
-		}
-		if err := revPath.IncPath(); err != nil {
-			return nil, serrors.Wrap(cannotRoute, err, "details", "incrementing path for SCMP")
+		replyPath = empty.Path{}
+	} else {
+		revPath, err := p.reversePath()
+		if err != nil {
+			return nil, err
 		}
+		replyPath = revPath
 	}
 
 	// create new SCION header for reply.
 	var scionL slayers.SCION
 	scionL.FlowID = p.scionLayer.FlowID
 	scionL.TrafficClass = p.scionLayer.TrafficClass
-	scionL.PathType = revPath.Type()
-	scionL.Path = revPath
+	scionL.PathType = replyPath.Type()
+	scionL.Path = replyPath
 	scionL.DstIA = p.scionLayer.SrcIA
 	scionL.SrcIA = p.d.localIA
 	srcA, err := p.scionLayer.SrcAddr()
@@ -2293,6 +3153,72 @@ func (p *slowPathPacketProcessor) prepareSCMP(
 	return p.buffer.Bytes(), nil
 }
 
+// reversePath returns a reversed copy of the SCION path of the packet that can
+// be used to send an SCMP message back to the source.
+func (p *slowPathPacketProcessor) reversePath() (*scion.Decoded, error) {
+	// *copy* and reverse path -- the original path should not be modified as this writes directly
+	// back to rawPkt (quote).
+	var path *scion.Raw
+	pathType := p.scionLayer.Path.Type()
+	switch pathType {
+	case scion.PathType:
+		var ok bool
+		path, ok = p.scionLayer.Path.(*scion.Raw)
+		if !ok {
+			return nil, serrors.WithCtx(cannotRoute, "details", "unsupported path type",
+				"path type", pathType)
+		}
+	case epic.PathType:
+		epicPath, ok := p.scionLayer.Path.(*epic.Path)
+		if !ok {
+			return nil, serrors.WithCtx(cannotRoute, "details", "unsupported path type",
+				"path type", pathType)
+		}
+		path = epicPath.ScionPath
+	default:
+		return nil, serrors.WithCtx(cannotRoute, "details", "unsupported path type",
+			"path type", pathType)
+	}
+	decPath, err := path.ToDecoded()
+	if err != nil {
+		return nil, serrors.Wrap(cannotRoute, err, "details", "decoding raw path")
+	}
+	revPathTmp, err := decPath.Reverse()
+	if err != nil {
+		return nil, serrors.Wrap(cannotRoute, err, "details", "reversing path for SCMP")
+	}
+	revPath := revPathTmp.(*scion.Decoded)
+
+	peering, err := determinePeer(revPath.PathMeta, revPath.InfoFields[revPath.PathMeta.CurrINF])
+	if err != nil {
+		return nil, serrors.Wrap(cannotRoute, err, "details", "peering cannot be determined")
+	}
+
+	// Revert potential path segment switches that were done during processing.
+	if revPath.IsXover() && !peering {
+		// An effective cross-over is a change of segment other than at
+		// a peering hop.
+		if err := revPath.IncPath(); err != nil {
+			return nil, serrors.Wrap(cannotRoute, err, "details", "reverting cross over for SCMP")
+		}
+	}
+	// If the packet is sent to an external router, we need to increment the
+	// path to prepare it for the next hop.
+	_, external := p.ft.external[p.ingressID]
+	if external {
+		infoField := &revPath.InfoFields[revPath.PathMeta.CurrINF]
+		if infoField.ConsDir && !peering {
+			hopField := revPath.HopFields[revPath.PathMeta.CurrHF]
+			infoField.UpdateSegID(hopField.Mac)
+		}
+		if err := revPath.IncPath(); err != nil {
+			return nil, serrors.Wrap(cannotRoute, err, "details", "incrementing path for SCMP")
+		}
+	}
+
+	return revPath, nil
+}
+
 func (p *slowPathPacketProcessor) resetSPAOMetadata(key drkey.ASHostKey, now time.Time) error {
 	// For creating SCMP responses we use sender side.
 	dir := slayers.PacketAuthSenderSide
@@ -2414,13 +3340,14 @@ func nextHdr(layer gopacket.DecodingLayer) slayers.L4ProtocolType {
 // instantiated for all the relevant interfaces so this will not have to be repeated during packet
 // forwarding.
 func (d *DataPlane) initMetrics() {
-	d.forwardingMetrics = make(map[uint16]interfaceMetrics)
-	d.forwardingMetrics[0] = newInterfaceMetrics(d.Metrics, 0, d.localIA, d.neighborIAs)
-	for id := range d.external {
-		if _, notOwned := d.internalNextHops[id]; notOwned {
+	t := d.initialTables()
+	t.forwardingMetrics = make(map[uint16]interfaceMetrics)
+	t.forwardingMetrics[0] = newInterfaceMetrics(d.Metrics, 0, d.localIA, t.neighborIAs)
+	for id := range t.external {
+		if _, notOwned := t.internalNextHops[id]; notOwned {
 			continue
 		}
-		d.forwardingMetrics[id] = newInterfaceMetrics(d.Metrics, id, d.localIA, d.neighborIAs)
+		t.forwardingMetrics[id] = newInterfaceMetrics(d.Metrics, id, d.localIA, t.neighborIAs)
 	}
 
 	// Start our custom /proc/pid/stat collector to export iowait time and (in the future) other
@@ -16,17 +16,20 @@ package router
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"hash/fnv"
 	"math/rand"
 	"net"
 	"net/netip"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -37,6 +40,9 @@ import (
 	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/pkg/slayers"
 	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/empty"
+	"github.com/scionproto/scion/pkg/slayers/path/epic"
+	"github.com/scionproto/scion/pkg/slayers/path/onehop"
 	"github.com/scionproto/scion/pkg/slayers/path/scion"
 	underlayconn "github.com/scionproto/scion/private/underlay/conn"
 	"github.com/scionproto/scion/router/mock_router"
@@ -82,13 +88,13 @@ func TestReceiver(t *testing.T) {
 		NumProcessors: 1,
 		BatchSize:     64,
 	}
-	dp.initPacketPool(runConfig, 64)
-	procCh, _, _ := initQueues(runConfig, dp.interfaces, 64)
+	dp.initPacketPool(runConfig, len(dp.interfaces), 64)
+	procCh, _, _ := initQueues(runConfig, dp.sockets(), 64)
 	initialPoolSize := len(dp.packetPool)
 	dp.running = true
 	dp.initMetrics()
 	go func() {
-		dp.runReceiver(0, dp.internal, runConfig, procCh)
+		dp.runReceiver(0, dp.internal, runConfig, procCh, nil)
 	}()
 	ptrMap := make(map[uintptr]struct{})
 	for i := 0; i < 21; i++ {
@@ -176,12 +182,12 @@ func TestForwarder(t *testing.T) {
 		NumProcessors: 20,
 		BatchSize:     64,
 	}
-	dp.initPacketPool(runConfig, 64)
-	_, fwCh, _ := initQueues(runConfig, dp.interfaces, 64)
+	dp.initPacketPool(runConfig, len(dp.interfaces), 64)
+	_, fwCh, _ := initQueues(runConfig, dp.sockets(), 64)
 	initialPoolSize := len(dp.packetPool)
 	dp.running = true
 	dp.initMetrics()
-	go dp.runForwarder(0, dp.internal, runConfig, fwCh[0])
+	go dp.runForwarder(0, dp.internal, runConfig, fwCh[0][0], nil)
 
 	dstAddr := &net.UDPAddr{IP: net.IP{10, 0, 200, 200}}
 	for i := 0; i < 255; i++ {
@@ -192,7 +198,7 @@ func TestForwarder(t *testing.T) {
 			dstAddr = nil
 		}
 		select {
-		case fwCh[0] <- packet{
+		case fwCh[0][0] <- packet{
 			srcAddr:   nil,
 			dstAddr:   dstAddr,
 			ingress:   0,
@@ -212,6 +218,49 @@ func TestForwarder(t *testing.T) {
 	}
 }
 
+func TestDrain(t *testing.T) {
+	testCases := map[string]struct {
+		Consume     bool
+		WantMinTime time.Duration
+		WantQueued  int
+	}{
+		"queued packets are forwarded": {
+			Consume:    true,
+			WantQueued: 0,
+		},
+		"timeout expires": {
+			Consume:     false,
+			WantMinTime: 100 * time.Millisecond,
+			WantQueued:  1,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			fwQ := make(chan packet, 1)
+			fwQ <- packet{}
+			dp := &DataPlane{
+				procQs: []chan packet{make(chan packet, 1)},
+			}
+			dp.fwTables.Store(&forwardingTables{
+				fwQs: map[uint16][]chan packet{1: {fwQ}},
+			})
+			if tc.Consume {
+				go func() {
+					time.Sleep(20 * time.Millisecond)
+					<-fwQ
+				}()
+			}
+			start := time.Now()
+			dp.drain(100 * time.Millisecond)
+			assert.GreaterOrEqual(t, time.Since(start), tc.WantMinTime)
+			assert.Equal(t, uint32(1), dp.draining)
+			assert.Len(t, fwQ, tc.WantQueued)
+		})
+	}
+}
+
 func TestComputeProcId(t *testing.T) {
 	randomValue := []byte{1, 2, 3, 4}
 	numProcs := 10000
@@ -417,6 +466,20 @@ func TestComputeProcIdErrorCases(t *testing.T) {
 	}
 }
 
+func TestPathTypeOf(t *testing.T) {
+	newPkt := func(pt path.Type) []byte {
+		raw := make([]byte, slayers.CmnHdrLen)
+		raw[8] = uint8(pt)
+		return raw
+	}
+	assert.Equal(t, ptEmpty, pathTypeOf(newPkt(empty.PathType)))
+	assert.Equal(t, ptSCION, pathTypeOf(newPkt(scion.PathType)))
+	assert.Equal(t, ptOneHop, pathTypeOf(newPkt(onehop.PathType)))
+	assert.Equal(t, ptEPIC, pathTypeOf(newPkt(epic.PathType)))
+	assert.Equal(t, ptOther, pathTypeOf(newPkt(path.Type(42))))
+	assert.Equal(t, ptOther, pathTypeOf(make([]byte, slayers.CmnHdrLen-1)))
+}
+
 func TestSlowPathProcessing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -507,6 +570,44 @@ func TestSlowPathProcessing(t *testing.T) {
 			},
 			expectedLayerType: slayers.LayerTypeSCMPParameterProblem,
 		},
+		"onehop egress down": {
+			prepareDP: func(ctrl *gomock.Controller) *DataPlane {
+				dp := NewDP(map[uint16]BatchConn{2: nil},
+					nil, mock_router.NewMockBatchConn(ctrl),
+					fakeInternalNextHops, nil,
+					xtest.MustParseIA("1-ff00:0:110"),
+					map[uint16]addr.IA{2: xtest.MustParseIA("1-ff00:0:111")},
+					testKey)
+				dp.initialTables().bfdSessions = map[uint16]bfdSession{2: downBFDSession{}}
+				return dp
+			},
+			mockMsg: func() []byte {
+				spkt := prepBaseMsg(t, payload, 0)
+				spkt.PathType = onehop.PathType
+				spkt.SrcIA = xtest.MustParseIA("1-ff00:0:110")
+				spkt.DstIA = xtest.MustParseIA("1-ff00:0:111")
+				_ = spkt.SetDstAddr(addr.HostSVC(addr.SvcCS.Multicast()))
+				ohp := &onehop.Path{
+					Info: path.InfoField{
+						ConsDir:   true,
+						SegID:     0x222,
+						Timestamp: util.TimeToSecs(time.Now()),
+					},
+					FirstHop: path.HopField{ExpTime: 63, ConsEgress: 2},
+				}
+				ohp.FirstHop.Mac = computeMAC(t, testKey, ohp.Info, ohp.FirstHop)
+				spkt.Path = ohp
+				return toMsg(t, spkt)
+			},
+			srcInterface: 0,
+			expectedSlowPathRequest: slowPathRequest{
+				scmpType:    slayers.SCMPTypeExternalInterfaceDown,
+				ia:          xtest.MustParseIA("1-ff00:0:110"),
+				interfaceId: 2,
+				cause:       errBFDSessionDown,
+			},
+			expectedLayerType: slayers.LayerTypeSCMPExternalInterfaceDown,
+		},
 	}
 	for name, tc := range testCases {
 		name, tc := name, tc
@@ -547,7 +648,149 @@ func TestSlowPathProcessing(t *testing.T) {
 	}
 }
 
-func toMsg(t *testing.T, spkt *slayers.SCION) []byte {
+// TestProcessPktKeyRotation checks that the hop fields are verified with the
+// key that corresponds to their key phase while the hop field key is rotated.
+func TestProcessPktKeyRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	schedule := scrypto.HFKeySchedule{
+		Master: []byte("0123456789abcdef"),
+		Period: 48 * time.Hour,
+	}
+	epoch := schedule.Epoch(time.Now())
+	start := schedule.EpochStart(epoch)
+	testCases := map[string]struct {
+		now      time.Time
+		keyEpoch uint64
+		valid    bool
+	}{
+		"current key": {
+			now:      start,
+			keyEpoch: epoch,
+			valid:    true,
+		},
+		"previous key during overlap": {
+			now:      start.Add(time.Hour),
+			keyEpoch: epoch - 1,
+			valid:    true,
+		},
+		"previous key after overlap": {
+			now:      start.Add(scrypto.HFKeyOverlap),
+			keyEpoch: epoch - 1,
+		},
+		"next key": {
+			now:      start.Add(time.Hour),
+			keyEpoch: epoch + 1,
+		},
+		"next key during rollover lead": {
+			now:      schedule.EpochStart(epoch + 1).Add(-time.Second),
+			keyEpoch: epoch + 1,
+			valid:    true,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			dp := NewDP(map[uint16]BatchConn{1: nil},
+				nil, mock_router.NewMockBatchConn(ctrl),
+				map[uint16]*net.UDPAddr{}, nil,
+				xtest.MustParseIA("1-ff00:0:110"), nil, testKey)
+			dp.hfKeys.Store(newHopFieldKeys(schedule, tc.now))
+
+			spkt := prepBaseMsg(t, []byte("actualpayloadbytes"), 0)
+			_ = spkt.SetDstAddr(addr.MustParseHost("10.0.100.100"))
+			dpath := spkt.Path.(*scion.Decoded)
+			dpath.HopFields[2].KeyPhase = scrypto.KeyPhase(tc.keyEpoch)
+			dpath.HopFields[2].Mac = computeMAC(t, schedule.Key(tc.keyEpoch),
+				dpath.InfoFields[0], dpath.HopFields[2])
+
+			_, err := newPacketProcessor(dp).processPkt(toMsg(t, spkt), nil, 1)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, slowPathRequired)
+			}
+		})
+	}
+}
+
+// TestProcessPktMACAlgorithmSwitch checks that the hop fields are verified with
+// the MAC algorithm of the key epoch of their key phase.
+func TestProcessPktMACAlgorithmSwitch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	schedule := scrypto.HFKeySchedule{
+		Master: []byte("0123456789abcdef"),
+		Period: 48 * time.Hour,
+	}
+	epoch := schedule.Epoch(time.Now())
+	schedule.Algorithms = scrypto.HFMACAlgorithms{
+		Current:  scrypto.MACAlgorithmHMACSHA256,
+		Previous: scrypto.MACAlgorithmAESCMAC,
+		Epoch:    epoch,
+	}
+	now := schedule.EpochStart(epoch).Add(time.Hour)
+	testCases := map[string]struct {
+		keyEpoch uint64
+		alg      scrypto.MACAlgorithm
+		valid    bool
+	}{
+		"current epoch, current algorithm": {
+			keyEpoch: epoch,
+			alg:      scrypto.MACAlgorithmHMACSHA256,
+			valid:    true,
+		},
+		"current epoch, previous algorithm": {
+			keyEpoch: epoch,
+			alg:      scrypto.MACAlgorithmAESCMAC,
+		},
+		"previous epoch, previous algorithm": {
+			keyEpoch: epoch - 1,
+			alg:      scrypto.MACAlgorithmAESCMAC,
+			valid:    true,
+		},
+		"previous epoch, current algorithm": {
+			keyEpoch: epoch - 1,
+			alg:      scrypto.MACAlgorithmHMACSHA256,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			dp := NewDP(map[uint16]BatchConn{1: nil},
+				nil, mock_router.NewMockBatchConn(ctrl),
+				map[uint16]*net.UDPAddr{}, nil,
+				xtest.MustParseIA("1-ff00:0:110"), nil, testKey)
+			dp.hfKeys.Store(newHopFieldKeys(schedule, now))
+
+			spkt := prepBaseMsg(t, []byte("actualpayloadbytes"), 0)
+			_ = spkt.SetDstAddr(addr.MustParseHost("10.0.100.100"))
+			dpath := spkt.Path.(*scion.Decoded)
+			dpath.HopFields[2].KeyPhase = scrypto.KeyPhase(tc.keyEpoch)
+			mac, err := scrypto.NewMAC(tc.alg, schedule.Key(tc.keyEpoch))
+			require.NoError(t, err)
+			dpath.HopFields[2].Mac = path.MAC(mac, dpath.InfoFields[0], dpath.HopFields[2], nil)
+
+			_, err = newPacketProcessor(dp).processPkt(toMsg(t, spkt), nil, 1)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, slowPathRequired)
+			}
+		})
+	}
+}
+
+// downBFDSession is a BFD session that is always down.
+type downBFDSession struct{}
+
+func (downBFDSession) Run(context.Context) error  { return nil }
+func (downBFDSession) ReceiveMessage(*layers.BFD) {}
+func (downBFDSession) IsUp() bool                 { return false }
+
+func toMsg(t testing.TB, spkt *slayers.SCION) []byte {
 	t.Helper()
 	buffer := gopacket.NewSerializeBuffer()
 	payload := []byte("actualpayloadbytes")
@@ -560,7 +803,7 @@ func toMsg(t *testing.T, spkt *slayers.SCION) []byte {
 	return ret[:len(raw)]
 }
 
-func computeMAC(t *testing.T, key []byte, info path.InfoField, hf path.HopField) [path.MacLen]byte {
+func computeMAC(t testing.TB, key []byte, info path.InfoField, hf path.HopField) [path.MacLen]byte {
 	mac, err := scrypto.InitMac(key)
 	require.NoError(t, err)
 	return path.MAC(mac, info, hf, nil)
@@ -576,7 +819,7 @@ func serializedBaseMsg(t *testing.T, payload []byte, flowId uint32) []byte {
 	return buffer.Bytes()
 }
 
-func prepBaseMsg(t *testing.T, payload []byte, flowId uint32) *slayers.SCION {
+func prepBaseMsg(t testing.TB, payload []byte, flowId uint32) *slayers.SCION {
 	spkt := &slayers.SCION{
 		Version:      0,
 		TrafficClass: 0xb8,
@@ -612,3 +855,32 @@ func prepBaseMsg(t *testing.T, payload []byte, flowId uint32) *slayers.SCION {
 	spkt.Path = dpath
 	return spkt
 }
+
+func TestDetachInterfaceDrainsQueues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := mock_router.NewMockBatchConn(ctrl)
+	conn.EXPECT().Close().Return(nil)
+	fwQ := make(chan packet, 3)
+	for i := 0; i < 2; i++ {
+		fwQ <- packet{rawPacket: make([]byte, 10)}
+	}
+	stop := make(chan struct{})
+	dp := &DataPlane{
+		running:    true,
+		packetPool: make(chan []byte, 2),
+		procLocks:  make([]sync.Mutex, 2),
+		stops:      map[uint16]chan struct{}{2: stop},
+		conns:      map[uint16][]BatchConn{2: {conn}},
+	}
+	dp.fwTables.Store(&forwardingTables{
+		neighborIAs: map[uint16]addr.IA{2: xtest.MustParseIA("1-ff00:0:111")},
+		fwQs:        map[uint16][]chan packet{2: {fwQ}},
+	})
+
+	require.NoError(t, dp.DetachInterface(2))
+	assert.Len(t, dp.packetPool, 2)
+	assert.NotContains(t, dp.forwardingTables().fwQs, uint16(2))
+	assert.True(t, stopped(stop))
+}
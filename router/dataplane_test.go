@@ -34,6 +34,7 @@ import (
 
 	"github.com/scionproto/scion/pkg/addr"
 	libepic "github.com/scionproto/scion/pkg/experimental/epic"
+	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/pkg/scrypto"
@@ -43,6 +44,8 @@ import (
 	"github.com/scionproto/scion/pkg/slayers/path/epic"
 	"github.com/scionproto/scion/pkg/slayers/path/onehop"
 	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/pkg/stun"
+	"github.com/scionproto/scion/private/ratelimit"
 	"github.com/scionproto/scion/private/topology"
 	underlayconn "github.com/scionproto/scion/private/underlay/conn"
 	"github.com/scionproto/scion/router"
@@ -108,6 +111,56 @@ func TestDataPlaneSetKey(t *testing.T) {
 	})
 }
 
+func TestDataPlaneSetMACAlgorithm(t *testing.T) {
+	t.Run("fails after serve", func(t *testing.T) {
+		d := &router.DataPlane{}
+		d.FakeStart()
+		assert.Error(t, d.SetMACAlgorithm(scrypto.MACAlgorithmHMACSHA256))
+	})
+	t.Run("unknown algorithm is not allowed", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.Error(t, d.SetMACAlgorithm("md5"))
+	})
+	t.Run("set before key works", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.NoError(t, d.SetMACAlgorithm(scrypto.MACAlgorithmHMACSHA256))
+		assert.NoError(t, d.SetKey([]byte("dummy key xxxxxx")))
+	})
+	t.Run("set after key fails", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.NoError(t, d.SetKey([]byte("dummy key xxxxxx")))
+		assert.Error(t, d.SetMACAlgorithm(scrypto.MACAlgorithmHMACSHA256))
+	})
+}
+
+func TestDataPlaneSetKeySchedule(t *testing.T) {
+	schedule := scrypto.HFKeySchedule{
+		Master: []byte("dummy key xxxxxx"),
+		Period: 48 * time.Hour,
+	}
+	t.Run("fails after serve", func(t *testing.T) {
+		d := &router.DataPlane{}
+		d.FakeStart()
+		assert.Error(t, d.SetKeySchedule(schedule))
+	})
+	t.Run("invalid schedule is not allowed", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.Error(t, d.SetKeySchedule(scrypto.HFKeySchedule{
+			Master: schedule.Master,
+			Period: time.Hour,
+		}))
+	})
+	t.Run("single set works", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.NoError(t, d.SetKeySchedule(schedule))
+	})
+	t.Run("set after key fails", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.NoError(t, d.SetKey([]byte("dummy key xxxxxx")))
+		assert.Error(t, d.SetKeySchedule(schedule))
+	})
+}
+
 func TestDataPlaneAddExternalInterface(t *testing.T) {
 	t.Run("fails after serve", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -142,6 +195,42 @@ func TestDataPlaneAddExternalInterface(t *testing.T) {
 	})
 }
 
+func TestDataPlaneAddExternalInterfaceSocket(t *testing.T) {
+	t.Run("fails after serve", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.NoError(t, d.AddExternalInterface(42, mock_router.NewMockBatchConn(ctrl)))
+		d.FakeStart()
+		assert.Error(t, d.AddExternalInterfaceSocket(42, mock_router.NewMockBatchConn(ctrl)))
+	})
+	t.Run("setting nil value is not allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.NoError(t, d.AddExternalInterface(42, mock_router.NewMockBatchConn(ctrl)))
+		assert.Error(t, d.AddExternalInterfaceSocket(42, nil))
+	})
+	t.Run("unknown interface fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.Error(t, d.AddExternalInterfaceSocket(42, mock_router.NewMockBatchConn(ctrl)))
+	})
+	t.Run("multiple sockets work", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.NoError(t, d.AddExternalInterface(42, mock_router.NewMockBatchConn(ctrl)))
+		assert.NoError(t, d.AddExternalInterfaceSocket(42, mock_router.NewMockBatchConn(ctrl)))
+		assert.NoError(t, d.AddExternalInterfaceSocket(42, mock_router.NewMockBatchConn(ctrl)))
+	})
+}
+
 func TestDataPlaneAddSVC(t *testing.T) {
 	t.Run("succeeds after serve", func(t *testing.T) {
 		d := &router.DataPlane{}
@@ -535,37 +624,130 @@ func TestDataPlaneRun(t *testing.T) {
 		},
 	}
 	for name, tc := range testCases {
-		name, tc := name, tc
-		t.Run(name, func(t *testing.T) {
-			t.Parallel()
-			runConfig := &router.RunConfig{
-				NumProcessors:         8,
-				BatchSize:             256,
-				NumSlowPathProcessors: 1,
-			}
-			ch := make(chan struct{})
-			dp := tc.prepareDP(ctrl, ch)
-			errors := make(chan error)
-			ctx, cancelF := context.WithCancel(context.Background())
-			defer cancelF()
-			go func() {
-				errors <- dp.Run(ctx, runConfig)
-			}()
-
-			for done := false; !done; {
-				select {
-				case <-ch:
-					done = true
-				case err := <-errors:
-					require.NoError(t, err)
-				case <-time.After(3 * time.Second):
-					t.Fatalf("time out")
+		// The MACs are computed per packet, or in batches of queued packets.
+		for _, macBatchSize := range []int{0, 16} {
+			name, tc, macBatchSize := fmt.Sprintf("%s, mac batch %d", name, macBatchSize), tc,
+				macBatchSize
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				runConfig := &router.RunConfig{
+					NumProcessors:         8,
+					BatchSize:             256,
+					NumSlowPathProcessors: 1,
+					MACBatchSize:          macBatchSize,
+					MACWorkers:            2,
 				}
-			}
-		})
+				ch := make(chan struct{})
+				dp := tc.prepareDP(ctrl, ch)
+				errors := make(chan error)
+				ctx, cancelF := context.WithCancel(context.Background())
+				defer cancelF()
+				go func() {
+					errors <- dp.Run(ctx, runConfig)
+				}()
+
+				for done := false; !done; {
+					select {
+					case <-ch:
+						done = true
+					case err := <-errors:
+						require.NoError(t, err)
+					case <-time.After(3 * time.Second):
+						t.Fatalf("time out")
+					}
+				}
+			})
+		}
 	}
 }
 
+func TestDataPlaneAttachDetachInterface(t *testing.T) {
+	t.Run("fails before run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		link := control.LinkInfo{
+			Remote: control.LinkEnd{IA: xtest.MustParseIA("1-ff00:0:111")},
+		}
+		assert.Error(t, d.AttachExternalInterface(42,
+			[]router.BatchConn{mock_router.NewMockBatchConn(ctrl)}, link))
+		assert.Error(t, d.DetachInterface(42))
+	})
+	t.Run("attach and detach while running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// newConn returns a socket that stops reading once it is closed.
+		newConn := func(closeCalls int) router.BatchConn {
+			closed := make(chan struct{})
+			c := mock_router.NewMockBatchConn(ctrl)
+			c.EXPECT().ReadBatch(gomock.Any()).DoAndReturn(
+				func(underlayconn.Messages) (int, error) {
+					select {
+					case <-closed:
+						return 0, serrors.New("closed")
+					case <-time.After(time.Millisecond):
+						return 0, nil
+					}
+				}).AnyTimes()
+			c.EXPECT().WriteBatch(gomock.Any(), gomock.Any()).Return(0, nil).AnyTimes()
+			c.EXPECT().Close().DoAndReturn(func() error {
+				close(closed)
+				return nil
+			}).Times(closeCalls)
+			return c
+		}
+		link := func(ia string) control.LinkInfo {
+			return control.LinkInfo{
+				Local: control.LinkEnd{
+					IA:   xtest.MustParseIA("1-ff00:0:110"),
+					Addr: &net.UDPAddr{IP: net.ParseIP("10.0.0.100")},
+				},
+				Remote: control.LinkEnd{
+					IA:   xtest.MustParseIA(ia),
+					Addr: &net.UDPAddr{IP: net.ParseIP("10.0.0.200")},
+				},
+				LinkTo: topology.Child,
+				BFD:    control.BFD{Disable: true},
+			}
+		}
+
+		d := &router.DataPlane{Metrics: metrics}
+		require.NoError(t, d.SetIA(xtest.MustParseIA("1-ff00:0:110")))
+		require.NoError(t, d.SetKey([]byte("randomkeyformacs")))
+		require.NoError(t, d.AddInternalInterface(newConn(0), net.IP{10, 0, 0, 1}))
+
+		ctx, cancelF := context.WithCancel(context.Background())
+		defer cancelF()
+		go func() {
+			_ = d.Run(ctx, &router.RunConfig{
+				NumProcessors:         1,
+				NumSlowPathProcessors: 1,
+				BatchSize:             8,
+				SpareSockets:          1,
+			})
+		}()
+		require.Eventually(t, func() bool {
+			return d.AttachSiblingInterface(7, link("1-ff00:0:117")) == nil
+		}, time.Second, 10*time.Millisecond)
+
+		assert.Error(t, d.AttachSiblingInterface(7, link("1-ff00:0:117")))
+		assert.NoError(t, d.AttachExternalInterface(2,
+			[]router.BatchConn{newConn(1)}, link("1-ff00:0:112")))
+		assert.Error(t, d.AttachExternalInterface(3,
+			[]router.BatchConn{newConn(0)}, link("1-ff00:0:113")),
+			"no spare sockets left")
+
+		assert.NoError(t, d.DetachInterface(2))
+		assert.Error(t, d.DetachInterface(2))
+		assert.NoError(t, d.AttachExternalInterface(3,
+			[]router.BatchConn{newConn(1)}, link("1-ff00:0:113")))
+		assert.NoError(t, d.DetachInterface(3))
+		assert.NoError(t, d.DetachInterface(7))
+	})
+}
+
 func TestProcessPkt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1501,6 +1683,137 @@ func TestProcessPkt(t *testing.T) {
 	}
 }
 
+func TestProcessSTUN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	local := xtest.MustParseIA("1-ff00:0:110")
+	mapped := &net.UDPAddr{IP: net.ParseIP("203.0.113.1"), Port: 40000}
+	newDP := func() *router.DataPlane {
+		return router.NewDP(map[uint16]router.BatchConn{1: nil},
+			nil, mock_router.NewMockBatchConn(ctrl),
+			map[uint16]*net.UDPAddr{}, nil, local, nil, key)
+	}
+	stunRequest := func(id stun.TxID) *ipv4.Message {
+		return &ipv4.Message{Buffers: [][]byte{stun.Request(id)}, Addr: mapped}
+	}
+	// inbound returns a packet from a remote AS to the end host behind the NAT.
+	inbound := func() *ipv4.Message {
+		spkt, dpath := prepBaseMsg(time.Now())
+		spkt.DstIA = local
+		_ = spkt.SetDstAddr(addr.MustParseHost("203.0.113.1"))
+		dpath.HopFields = []path.HopField{
+			{ConsIngress: 41, ConsEgress: 40},
+			{ConsIngress: 31, ConsEgress: 30},
+			{ConsIngress: 1, ConsEgress: 0},
+		}
+		dpath.Base.PathMeta.CurrHF = 2
+		dpath.HopFields[2].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[2])
+		return toMsg(t, spkt, dpath)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		dp := newDP()
+		_, err := dp.ProcessPkt(0, stunRequest(stun.NewTxID()))
+		assert.Error(t, err)
+	})
+	t.Run("mapping", func(t *testing.T) {
+		dp := newDP()
+		require.NoError(t, dp.SetNATMappingTimeout(time.Minute))
+
+		result, err := dp.ProcessPkt(1, inbound())
+		require.NoError(t, err)
+		assert.Equal(t, topology.EndhostPort, result.OutAddr.Port)
+
+		id := stun.NewTxID()
+		result, err = dp.ProcessPkt(0, stunRequest(id))
+		require.NoError(t, err)
+		assert.Equal(t, uint16(0), result.EgressID)
+		assert.Equal(t, mapped, result.OutAddr)
+		respID, respMapped, err := stun.ParseResponse(result.OutPkt)
+		require.NoError(t, err)
+		assert.Equal(t, id, respID)
+		assert.Equal(t, "203.0.113.1:40000", respMapped.String())
+
+		result, err = dp.ProcessPkt(1, inbound())
+		require.NoError(t, err)
+		assert.Equal(t, mapped.Port, result.OutAddr.Port)
+		assert.True(t, mapped.IP.Equal(result.OutAddr.IP))
+	})
+	t.Run("external interface", func(t *testing.T) {
+		dp := newDP()
+		require.NoError(t, dp.SetNATMappingTimeout(time.Minute))
+		_, err := dp.ProcessPkt(1, stunRequest(stun.NewTxID()))
+		assert.Error(t, err)
+	})
+}
+
+func TestSCMPErrorRateLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	now := time.Now()
+	// invalidIngress returns a packet from src that arrives on interface 1,
+	// whose hop field has a different ingress interface. The router answers it
+	// with an SCMP parameter problem.
+	invalidIngress := func(src string) *ipv4.Message {
+		spkt, dpath := prepBaseMsg(now)
+		spkt.SrcIA = xtest.MustParseIA(src)
+		spkt.DstIA = xtest.MustParseIA("1-ff00:0:110")
+		_ = spkt.SetDstAddr(addr.MustParseHost("10.0.100.100"))
+		_ = spkt.SetSrcAddr(addr.MustParseHost("10.0.200.200"))
+		dpath.HopFields = []path.HopField{
+			{ConsIngress: 41, ConsEgress: 40},
+			{ConsIngress: 31, ConsEgress: 30},
+			{ConsIngress: 2, ConsEgress: 0},
+		}
+		dpath.Base.PathMeta.CurrHF = 2
+		dpath.HopFields[2].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[2])
+		msg := toMsg(t, spkt, dpath)
+		msg.Addr = &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 50000}
+		return msg
+	}
+	newDP := func(perAS, perInterface *ratelimit.Limiter) *router.DataPlane {
+		dp := router.NewDP(map[uint16]router.BatchConn{1: nil, 2: nil},
+			nil, mock_router.NewMockBatchConn(ctrl),
+			map[uint16]*net.UDPAddr{}, nil, xtest.MustParseIA("1-ff00:0:110"), nil, key)
+		require.NoError(t, dp.SetSCMPErrorRateLimits(perAS, perInterface))
+		return dp
+	}
+	sendSCMP := func(t *testing.T, dp *router.DataPlane, src string) error {
+		msg := invalidIngress(src)
+		res, err := dp.ProcessPkt(1, msg)
+		require.ErrorIs(t, err, router.SlowPathRequired)
+		res, err = dp.ProcessSlowPathPkt(1, msg, res)
+		if err == nil {
+			assert.Equal(t, uint16(1), res.EgressID)
+		}
+		return err
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+		dp := newDP(nil, nil)
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, sendSCMP(t, dp, "2-ff00:0:222"))
+		}
+	})
+	t.Run("per AS", func(t *testing.T) {
+		dp := newDP(&ratelimit.Limiter{Rate: 1, Burst: 2}, nil)
+		assert.NoError(t, sendSCMP(t, dp, "2-ff00:0:222"))
+		assert.NoError(t, sendSCMP(t, dp, "2-ff00:0:222"))
+		assert.Error(t, sendSCMP(t, dp, "2-ff00:0:222"))
+		assert.NoError(t, sendSCMP(t, dp, "2-ff00:0:223"))
+	})
+	t.Run("per interface", func(t *testing.T) {
+		dp := newDP(nil, &ratelimit.Limiter{Rate: 1, Burst: 2})
+		assert.NoError(t, sendSCMP(t, dp, "2-ff00:0:222"))
+		assert.NoError(t, sendSCMP(t, dp, "2-ff00:0:223"))
+		assert.Error(t, sendSCMP(t, dp, "2-ff00:0:224"))
+	})
+}
+
 func toMsg(t *testing.T, spkt *slayers.SCION, dpath path.Path) *ipv4.Message {
 	t.Helper()
 	ret := &ipv4.Message{}
@@ -50,16 +50,18 @@ func NewDP(
 	key []byte) *DataPlane {
 
 	dp := &DataPlane{
-		localIA:          local,
+		localIA:    local,
+		svc:        &services{m: svc},
+		internal:   internal,
+		internalIP: netip.MustParseAddr("198.51.100.1"),
+		Metrics:    metrics,
+	}
+	dp.fwTables.Store(&forwardingTables{
 		external:         external,
 		linkTypes:        linkTypes,
 		neighborIAs:      neighbors,
 		internalNextHops: internalNextHops,
-		svc:              &services{m: svc},
-		internal:         internal,
-		internalIP:       netip.MustParseAddr("198.51.100.1"),
-		Metrics:          metrics,
-	}
+	})
 	if err := dp.SetKey(key); err != nil {
 		panic(err)
 	}
@@ -84,6 +86,27 @@ func (d *DataPlane) ProcessPkt(ifID uint16, m *ipv4.Message) (ProcessResult, err
 	return ProcessResult{processResult: result}, err
 }
 
+// ProcessSlowPathPkt processes a packet in the slow path. The result is the
+// result of processing the same packet in the fast path with ProcessPkt.
+func (d *DataPlane) ProcessSlowPathPkt(ifID uint16, m *ipv4.Message,
+	res ProcessResult) (ProcessResult, error) {
+
+	p := newSlowPathProcessor(d)
+	var srcAddr *net.UDPAddr
+	if m.Addr != nil {
+		srcAddr = m.Addr.(*net.UDPAddr)
+	}
+	result, err := p.processPacket(slowPacket{
+		packet: packet{
+			srcAddr:   srcAddr,
+			ingress:   ifID,
+			rawPacket: m.Buffers[0],
+		},
+		slowPathRequest: res.SlowPathRequest,
+	})
+	return ProcessResult{processResult: result}, err
+}
+
 func ExtractServices(s *services) map[addr.SVC][]*net.UDPAddr {
 	return s.m
 }
@@ -0,0 +1,323 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"hash"
+	"sync"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+)
+
+// hopFieldMAC computes the MACs of hop fields with one key. It is not safe for
+// concurrent use, except for computeBatch with AES-CMAC, which only reads the
+// cipher state. Every packet processor keeps its own instances, such that the
+// cipher state is set up once per key and reused for all packets.
+//
+// The MAC input of a hop field is exactly one AES block, and the CMAC of a
+// single complete block is the encryption of the block XORed with the first
+// CMAC subkey. For AES-CMAC, the subkey is thus precomputed and the MAC is
+// computed with a single block encryption, bypassing the generic CMAC state.
+type hopFieldMAC struct {
+	// block is the AES cipher for AES-CMAC. It is nil for other algorithms.
+	block cipher.Block
+	// k1 is the first CMAC subkey.
+	k1 [aes.BlockSize]byte
+	// h is the MAC for algorithms other than AES-CMAC.
+	h hash.Hash
+	// sum is the buffer for the output of h.
+	sum []byte
+}
+
+func newHopFieldMAC(alg scrypto.MACAlgorithm, key []byte) (*hopFieldMAC, error) {
+	if alg != "" && alg != scrypto.MACAlgorithmAESCMAC {
+		h, err := scrypto.NewMAC(alg, key)
+		if err != nil {
+			return nil, err
+		}
+		return &hopFieldMAC{h: h, sum: make([]byte, 0, h.Size())}, nil
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, serrors.Wrap(scrypto.ErrCipherFailure, err)
+	}
+	m := &hopFieldMAC{block: block}
+	// The first subkey is the encryption of the zero block, doubled in
+	// GF(2^128), see RFC 4493, section 2.3.
+	var l [aes.BlockSize]byte
+	block.Encrypt(l[:], l[:])
+	for i := 0; i < aes.BlockSize-1; i++ {
+		m.k1[i] = l[i]<<1 | l[i+1]>>7
+	}
+	m.k1[aes.BlockSize-1] = l[aes.BlockSize-1] << 1
+	if l[0]&0x80 != 0 {
+		m.k1[aes.BlockSize-1] ^= 0x87
+	}
+	return m, nil
+}
+
+// compute writes the full MAC of the MAC input to dst.
+func (m *hopFieldMAC) compute(dst, input *[path.MACBufferSize]byte) {
+	if m.block == nil {
+		m.h.Reset()
+		// Write must not return an error: https://godoc.org/hash#Hash
+		if _, err := m.h.Write(input[:]); err != nil {
+			panic(err)
+		}
+		copy(dst[:], m.h.Sum(m.sum[:0]))
+		return
+	}
+	subtle.XORBytes(dst[:], input[:], m.k1[:])
+	m.block.Encrypt(dst[:], dst[:])
+}
+
+// computeBatch writes the full MACs of the MAC inputs to dst. For AES-CMAC,
+// the subkey is mixed into all inputs before they are encrypted, such that the
+// independent block encryptions can be pipelined by the CPU.
+func (m *hopFieldMAC) computeBatch(dst, inputs [][path.MACBufferSize]byte) {
+	if m.block == nil {
+		for i := range inputs {
+			m.compute(&dst[i], &inputs[i])
+		}
+		return
+	}
+	for i := range inputs {
+		subtle.XORBytes(dst[i][:], inputs[i][:], m.k1[:])
+	}
+	for i := range dst {
+		m.block.Encrypt(dst[i][:], dst[i][:])
+	}
+}
+
+// mac returns the hop field MAC of the hop field.
+func (m *hopFieldMAC) mac(info path.InfoField, hf path.HopField) [path.MacLen]byte {
+	var input, full [path.MACBufferSize]byte
+	path.MACInput(info.SegID, info.Timestamp, hf.ExpTime, hf.ConsIngress, hf.ConsEgress,
+		input[:])
+	m.compute(&full, &input)
+	var res [path.MacLen]byte
+	copy(res[:], full[:path.MacLen])
+	return res
+}
+
+// minMACChunkSize is the minimum number of MACs that are computed by one
+// worker of a macWorkerPool.
+const minMACChunkSize = 8
+
+// macWorkerPool is a bounded pool of goroutines that is shared by all
+// processors to compute the MACs of a batch in parallel.
+type macWorkerPool struct {
+	workers int
+	jobs    chan macJob
+}
+
+type macJob struct {
+	mac    *hopFieldMAC
+	dst    [][path.MACBufferSize]byte
+	inputs [][path.MACBufferSize]byte
+	done   *sync.WaitGroup
+}
+
+// newMACWorkerPool starts a pool with the given number of workers. The
+// workers run for the lifetime of the process, like the processors.
+func newMACWorkerPool(workers int) *macWorkerPool {
+	p := &macWorkerPool{workers: workers, jobs: make(chan macJob)}
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer log.HandlePanic()
+			for job := range p.jobs {
+				job.mac.computeBatch(job.dst, job.inputs)
+				job.done.Done()
+			}
+		}()
+	}
+	return p
+}
+
+// computeBatch writes the full MACs of the MAC inputs to dst. The inputs are
+// split into chunks, which are handed to idle workers. The caller computes the
+// first chunk, and every chunk for which no worker is idle, itself. Only
+// AES-CMAC is computed in parallel, other algorithms are not safe for
+// concurrent use.
+func (p *macWorkerPool) computeBatch(mac *hopFieldMAC, dst, inputs [][path.MACBufferSize]byte,
+	wg *sync.WaitGroup) {
+
+	if p == nil || mac.block == nil || len(inputs) < 2*minMACChunkSize {
+		mac.computeBatch(dst, inputs)
+		return
+	}
+	// The caller computes one of the chunks.
+	chunks := p.workers + 1
+	size := max(minMACChunkSize, (len(inputs)+chunks-1)/chunks)
+	for start := size; start < len(inputs); start += size {
+		end := start + size
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+		wg.Add(1)
+		select {
+		case p.jobs <- macJob{mac: mac, dst: dst[start:end], inputs: inputs[start:end], done: wg}:
+		default:
+			wg.Done()
+			mac.computeBatch(dst[start:end], inputs[start:end])
+		}
+	}
+	mac.computeBatch(dst[:size], inputs[:size])
+	wg.Wait()
+}
+
+// macBatch holds the MACs of the current hop fields of a batch of packets,
+// which are computed before the packets are processed. The MAC of a packet is
+// only used if the hop field MAC, including its input, matches the one
+// computed during processing, otherwise the MAC is computed again.
+type macBatch struct {
+	entries []macBatchEntry
+	// inputs and macs are the MAC inputs and MACs per key phase.
+	inputs [2][][path.MACBufferSize]byte
+	macs   [2][][path.MACBufferSize]byte
+	// current is the index of the packet that is processed.
+	current int
+	// pool computes the MACs in parallel. If nil, the MACs are computed by
+	// the processor.
+	pool *macWorkerPool
+	wg   sync.WaitGroup
+}
+
+type macBatchEntry struct {
+	// mac is the hop field MAC the MAC was computed with, or nil if no MAC
+	// was computed for the packet.
+	mac *hopFieldMAC
+	// phase is the key phase of the hop field.
+	phase bool
+	// index is the index of the MAC in the MACs of the key phase.
+	index int
+}
+
+func newMACBatch(size int, pool *macWorkerPool) *macBatch {
+	b := &macBatch{entries: make([]macBatchEntry, 0, size), pool: pool}
+	for i := range b.inputs {
+		b.inputs[i] = make([][path.MACBufferSize]byte, 0, size)
+		b.macs[i] = make([][path.MACBufferSize]byte, size)
+	}
+	return b
+}
+
+// precompute computes the MACs of the current hop fields of the packets with
+// the hop field MACs indexed by key phase.
+func (b *macBatch) precompute(pkts []packet, macs [2]*hopFieldMAC) {
+	b.entries = b.entries[:0]
+	b.inputs[0], b.inputs[1] = b.inputs[0][:0], b.inputs[1][:0]
+	b.current = 0
+	for _, pkt := range pkts {
+		var input [path.MACBufferSize]byte
+		phase, ok := currentMACInput(pkt.rawPacket, pkt.ingress, &input)
+		i := keyPhaseIndex(phase)
+		if !ok || macs[i] == nil {
+			b.entries = append(b.entries, macBatchEntry{})
+			continue
+		}
+		b.entries = append(b.entries, macBatchEntry{
+			mac:   macs[i],
+			phase: phase,
+			index: len(b.inputs[i]),
+		})
+		b.inputs[i] = append(b.inputs[i], input)
+	}
+	for i, mac := range macs {
+		if len(b.inputs[i]) > 0 {
+			b.pool.computeBatch(mac, b.macs[i][:len(b.inputs[i])], b.inputs[i], &b.wg)
+		}
+	}
+}
+
+// lookup writes the precomputed MAC of the current packet to dst, if it was
+// computed with the hop field MAC from the MAC input.
+func (b *macBatch) lookup(mac *hopFieldMAC, phase bool,
+	dst, input *[path.MACBufferSize]byte) bool {
+
+	if b == nil || b.current >= len(b.entries) {
+		return false
+	}
+	e := b.entries[b.current]
+	if e.mac == nil || e.mac != mac || e.phase != phase {
+		return false
+	}
+	i := keyPhaseIndex(phase)
+	if b.inputs[i][e.index] != *input {
+		return false
+	}
+	*dst = b.macs[i][e.index]
+	return true
+}
+
+// currentMACInput writes the MAC input of the current hop field of a raw SCION
+// packet with a standard SCION path to input, and returns the key phase of
+// the hop field. As the packet is not validated, the MAC input is only a
+// candidate for the MAC verification during processing.
+func currentMACInput(raw []byte, ingress uint16,
+	input *[path.MACBufferSize]byte) (bool, bool) {
+
+	if len(raw) < slayers.CmnHdrLen || path.Type(raw[8]) != scion.PathType {
+		return false, false
+	}
+	dstType := slayers.AddrType(raw[9] >> 4 & 0xF)
+	srcType := slayers.AddrType(raw[9] & 0xF)
+	offset := slayers.CmnHdrLen + 2*addr.IABytes + dstType.Length() + srcType.Length()
+	if len(raw) < offset {
+		return false, false
+	}
+	var meta scion.MetaHdr
+	if err := meta.DecodeFromBytes(raw[offset:]); err != nil {
+		return false, false
+	}
+	numINF := 0
+	for _, l := range meta.SegLen {
+		if l > 0 {
+			numINF++
+		}
+	}
+	if int(meta.CurrINF) >= numINF {
+		return false, false
+	}
+	infOffset := offset + scion.MetaLen + int(meta.CurrINF)*path.InfoLen
+	hopOffset := offset + scion.MetaLen + numINF*path.InfoLen + int(meta.CurrHF)*path.HopLen
+	if len(raw) < hopOffset+path.HopLen {
+		return false, false
+	}
+	var info path.InfoField
+	var hf path.HopField
+	if info.DecodeFromBytes(raw[infOffset:]) != nil || hf.DecodeFromBytes(raw[hopOffset:]) != nil {
+		return false, false
+	}
+	// The ingress router updates the segment ID before verifying the MAC, see
+	// updateNonConsDirIngressSegID.
+	if peer, err := determinePeer(meta, info); err == nil && !peer &&
+		!info.ConsDir && ingress != 0 {
+
+		info.UpdateSegID(hf.Mac)
+	}
+	path.MACInput(info.SegID, info.Timestamp, hf.ExpTime, hf.ConsIngress, hf.ConsEgress,
+		input[:])
+	return hf.KeyPhase, true
+}
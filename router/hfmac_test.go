@@ -0,0 +1,212 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto/rand"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+)
+
+func TestHopFieldMAC(t *testing.T) {
+	for _, alg := range []scrypto.MACAlgorithm{
+		scrypto.MACAlgorithmAESCMAC,
+		scrypto.MACAlgorithmHMACSHA256,
+	} {
+		t.Run(string(alg), func(t *testing.T) {
+			key := make([]byte, 16)
+			_, err := rand.Read(key)
+			require.NoError(t, err)
+			m, err := newHopFieldMAC(alg, key)
+			require.NoError(t, err)
+			ref, err := scrypto.NewMAC(alg, key)
+			require.NoError(t, err)
+
+			inputs := make([][path.MACBufferSize]byte, 64)
+			for i := range inputs {
+				_, err := rand.Read(inputs[i][:])
+				require.NoError(t, err)
+			}
+			batch := make([][path.MACBufferSize]byte, len(inputs))
+			m.computeBatch(batch, inputs)
+			for i := range inputs {
+				ref.Reset()
+				ref.Write(inputs[i][:])
+				var full [path.MACBufferSize]byte
+				m.compute(&full, &inputs[i])
+				assert.Equal(t, ref.Sum(nil)[:path.MACBufferSize], full[:])
+				assert.Equal(t, full, batch[i])
+			}
+
+			info := path.InfoField{SegID: 0x111, Timestamp: 0x12345678}
+			hf := path.HopField{ConsIngress: 1, ConsEgress: 2, ExpTime: 63}
+			assert.Equal(t, path.MAC(ref, info, hf, nil), m.mac(info, hf))
+		})
+	}
+	_, err := newHopFieldMAC(scrypto.MACAlgorithmAESCMAC, []byte("short"))
+	assert.Error(t, err)
+}
+
+func TestMACWorkerPool(t *testing.T) {
+	pool := newMACWorkerPool(3)
+	for _, alg := range []scrypto.MACAlgorithm{
+		scrypto.MACAlgorithmAESCMAC,
+		scrypto.MACAlgorithmHMACSHA256,
+	} {
+		t.Run(string(alg), func(t *testing.T) {
+			m, err := newHopFieldMAC(alg, testKey)
+			require.NoError(t, err)
+			inputs := make([][path.MACBufferSize]byte, 100)
+			for i := range inputs {
+				_, err := rand.Read(inputs[i][:])
+				require.NoError(t, err)
+			}
+			expected := make([][path.MACBufferSize]byte, len(inputs))
+			m.computeBatch(expected, inputs)
+
+			// Several processors share the pool.
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					// Each processor has its own hop field MACs.
+					m, err := newHopFieldMAC(alg, testKey)
+					if !assert.NoError(t, err) {
+						return
+					}
+					for _, n := range []int{1, 2 * minMACChunkSize, 33, len(inputs)} {
+						var batchWG sync.WaitGroup
+						dst := make([][path.MACBufferSize]byte, n)
+						pool.computeBatch(m, dst, inputs[:n], &batchWG)
+						assert.Equal(t, expected[:n], dst)
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestMACBatch(t *testing.T) {
+	// againstConsDir returns an inbound packet that traverses the segment
+	// against construction direction. The segment ID in the packet is the
+	// one before the update by the ingress router.
+	againstConsDir := func(t *testing.T) []byte {
+		spkt := prepBaseMsg(t, []byte("actualpayloadbytes"), 0)
+		require.NoError(t, spkt.SetDstAddr(addr.MustParseHost("10.0.100.100")))
+		dpath := spkt.Path.(*scion.Decoded)
+		dpath.InfoFields[0].ConsDir = false
+		dpath.HopFields[2] = path.HopField{ConsIngress: 0, ConsEgress: 1}
+		dpath.HopFields[2].Mac = computeMAC(t, testKey, dpath.InfoFields[0], dpath.HopFields[2])
+		dpath.InfoFields[0].UpdateSegID(dpath.HopFields[2].Mac)
+		return toMsg(t, spkt)
+	}
+	testCases := map[string]struct {
+		prepare func(t *testing.T) []byte
+		ingress uint16
+		cached  bool
+	}{
+		"inbound": {
+			prepare: func(t *testing.T) []byte {
+				spkt := prepBaseMsg(t, []byte("actualpayloadbytes"), 0)
+				require.NoError(t, spkt.SetDstAddr(addr.MustParseHost("10.0.100.100")))
+				return toMsg(t, spkt)
+			},
+			ingress: 1,
+			cached:  true,
+		},
+		"transit": {
+			prepare: func(t *testing.T) []byte {
+				return toMsg(t, prepTransitMsg(t))
+			},
+			ingress: 1,
+			cached:  true,
+		},
+		"against construction direction": {
+			prepare: againstConsDir,
+			ingress: 1,
+			cached:  true,
+		},
+		"not a SCION path": {
+			prepare: func(t *testing.T) []byte {
+				raw := toMsg(t, prepTransitMsg(t))
+				raw[8] = 0xff
+				return raw
+			},
+			ingress: 1,
+		},
+		"truncated": {
+			prepare: func(t *testing.T) []byte {
+				return toMsg(t, prepTransitMsg(t))[:40]
+			},
+			ingress: 1,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dp := prepBenchmarkDP()
+			p := newPacketProcessor(dp)
+			p.batch = newMACBatch(4, nil)
+			p.updateMACs()
+			raw := tc.prepare(t)
+			p.batch.precompute([]packet{{rawPacket: raw, ingress: tc.ingress}}, p.macs)
+
+			var input, full [path.MACBufferSize]byte
+			phase, ok := currentMACInput(raw, tc.ingress, &input)
+			assert.Equal(t, tc.cached, ok)
+			assert.Equal(t, tc.cached, p.batch.lookup(p.mac(phase), phase, &full, &input))
+			if !tc.cached {
+				return
+			}
+			// The precomputed MAC is the one computed during processing.
+			_, err := p.processPkt(raw, &net.UDPAddr{}, tc.ingress)
+			require.NoError(t, err)
+			assert.Equal(t, input, p.macInput)
+			assert.Equal(t, full, p.fullMAC)
+		})
+	}
+}
+
+func TestMACBatchMismatch(t *testing.T) {
+	dp := prepBenchmarkDP()
+	p := newPacketProcessor(dp)
+	p.batch = newMACBatch(4, nil)
+	p.updateMACs()
+	raw := toMsg(t, prepTransitMsg(t))
+	p.batch.precompute([]packet{{rawPacket: raw, ingress: 1}}, p.macs)
+
+	var input, full [path.MACBufferSize]byte
+	phase, ok := currentMACInput(raw, 1, &input)
+	require.True(t, ok)
+	// A different input, e.g., after a segment change, is not looked up.
+	input[0] ^= 0xff
+	assert.False(t, p.batch.lookup(p.mac(phase), phase, &full, &input))
+	input[0] ^= 0xff
+	// Neither is a MAC computed with other keys.
+	other, err := newHopFieldMAC(scrypto.MACAlgorithmAESCMAC, []byte("otherkey_xxxxxxx"))
+	require.NoError(t, err)
+	assert.False(t, p.batch.lookup(other, phase, &full, &input))
+	assert.True(t, p.batch.lookup(p.mac(phase), phase, &full, &input))
+}
@@ -23,6 +23,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/empty"
+	"github.com/scionproto/scion/pkg/slayers/path/epic"
+	"github.com/scionproto/scion/pkg/slayers/path/onehop"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
 )
 
 // Metrics defines the data-plane metrics for the BR.
@@ -54,7 +60,7 @@ func NewMetrics() *Metrics {
 				Name: "router_processed_pkts_total",
 				Help: "Total number of packets processed by the processor",
 			},
-			[]string{"interface", "isd_as", "neighbor_isd_as", "sizeclass"},
+			[]string{"interface", "isd_as", "neighbor_isd_as", "sizeclass", "path_type"},
 		),
 		InputBytesTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
@@ -200,6 +206,54 @@ func (t trafficType) String() string {
 	return "other"
 }
 
+// pathType labels processed traffic with the type of the SCION path it carries. Packets that are
+// too short to contain a SCION common header, or that carry a path type unknown to the router,
+// have type Other.
+type pathType uint8
+
+const (
+	ptOther pathType = iota
+	ptEmpty
+	ptSCION
+	ptOneHop
+	ptEPIC
+	ptMax
+)
+
+// pathTypeOf returns the path type of the raw SCION packet. It only inspects the common header
+// and doesn't validate the packet.
+func pathTypeOf(rawPkt []byte) pathType {
+	if len(rawPkt) < slayers.CmnHdrLen {
+		return ptOther
+	}
+	switch path.Type(rawPkt[8]) {
+	case empty.PathType:
+		return ptEmpty
+	case scion.PathType:
+		return ptSCION
+	case onehop.PathType:
+		return ptOneHop
+	case epic.PathType:
+		return ptEPIC
+	}
+	return ptOther
+}
+
+// Returns a human-friendly representation of the given path type.
+func (t pathType) String() string {
+	switch t {
+	case ptEmpty:
+		return "empty"
+	case ptSCION:
+		return "scion"
+	case ptOneHop:
+		return "onehop"
+	case ptEPIC:
+		return "epic"
+	}
+	return "other"
+}
+
 // sizeClass is the number of bits needed to represent some given size. This is quicker than
 // computing Log2 and serves the same purpose.
 type sizeClass uint8
@@ -247,26 +301,31 @@ func (sc sizeClass) String() string {
 // that associates each (traffic-type, size-class) pair with the set of metrics belonging to that
 // interface that have these label values. This set of metrics is itself a trafficMetric structure.
 // Explanation: Metrics are labeled by interface, local-as, neighbor-as, packet size, and (for
-// output metrics only) traffic type. Instances are grouped in a hierarchical manner for efficient
-// access by the using code. forwardingMetrics is a map of interface to interfaceMetrics. To access
-// a specific InputPacketsTotal counter, one refers to:
+// output metrics only) traffic type or (for processed metrics only) path type. Instances are
+// grouped in a hierarchical manner for efficient access by the using code. forwardingMetrics is a
+// map of interface to interfaceMetrics. To access a specific InputPacketsTotal counter, one refers
+// to:
 //
 //	dataplane.forwardingMetrics[interface][size-class].
 //
-// trafficMetrics.Output is an array of outputMetrics indexed by traffic type.
+// trafficMetrics.Output is an array of outputMetrics indexed by traffic type, and
+// trafficMetrics.ProcessedPackets is an array of counters indexed by path type.
 type interfaceMetrics map[sizeClass]trafficMetrics
 
 // trafficMetrics groups all the metrics instances that all share the same interface AND
 // sizeClass label values (but have different names - i.e. they count different things).
 type trafficMetrics struct {
-	InputBytesTotal             prometheus.Counter
-	InputPacketsTotal           prometheus.Counter
-	DroppedPacketsInvalid       prometheus.Counter
-	DroppedPacketsBusyProcessor prometheus.Counter
-	DroppedPacketsBusyForwarder prometheus.Counter
-	DroppedPacketsBusySlowPath  prometheus.Counter
-	ProcessedPackets            prometheus.Counter
-	Output                      [ttMax]outputMetrics
+	InputBytesTotal               prometheus.Counter
+	InputPacketsTotal             prometheus.Counter
+	DroppedPacketsInvalid         prometheus.Counter
+	DroppedPacketsBusyProcessor   prometheus.Counter
+	DroppedPacketsBusyForwarder   prometheus.Counter
+	DroppedPacketsBusySlowPath    prometheus.Counter
+	DroppedPacketsMACFailure      prometheus.Counter
+	DroppedPacketsExpiredHop      prometheus.Counter
+	DroppedPacketsSCMPRateLimited prometheus.Counter
+	ProcessedPackets              [ptMax]prometheus.Counter
+	Output                        [ttMax]outputMetrics
 }
 
 // outputMetrics groups all the metrics about traffic that has reached the output stage. Metrics
@@ -283,7 +342,7 @@ func newInterfaceMetrics(
 	localIA addr.IA,
 	neighbors map[uint16]addr.IA) interfaceMetrics {
 
-	ifLabels := interfaceLabels(0, localIA, neighbors)
+	ifLabels := interfaceLabels(id, localIA, neighbors)
 	m := interfaceMetrics{}
 	for sc := minSizeClass; sc < maxSizeClass; sc++ {
 		scLabels := prometheus.Labels{"sizeclass": sc.String()}
@@ -300,7 +359,14 @@ func newTrafficMetrics(
 	c := trafficMetrics{
 		InputBytesTotal:   metrics.InputBytesTotal.MustCurryWith(ifLabels).With(scLabels),
 		InputPacketsTotal: metrics.InputPacketsTotal.MustCurryWith(ifLabels).With(scLabels),
-		ProcessedPackets:  metrics.ProcessedPackets.MustCurryWith(ifLabels).With(scLabels),
+	}
+
+	// Processed metrics have the extra "pathType" label.
+	for t := ptOther; t < ptMax; t++ {
+		ptLabels := prometheus.Labels{"path_type": t.String()}
+		c.ProcessedPackets[t] =
+			metrics.ProcessedPackets.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(ptLabels)
+		c.ProcessedPackets[t].Add(0)
 	}
 
 	// Output metrics have the extra "trafficType" label.
@@ -328,13 +394,27 @@ func newTrafficMetrics(
 	c.DroppedPacketsBusySlowPath =
 		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
 
+	reasonMap["reason"] = "mac_failure"
+	c.DroppedPacketsMACFailure =
+		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
+
+	reasonMap["reason"] = "expired_hop"
+	c.DroppedPacketsExpiredHop =
+		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
+
+	reasonMap["reason"] = "scmp_rate_limited"
+	c.DroppedPacketsSCMPRateLimited =
+		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
+
 	c.InputBytesTotal.Add(0)
 	c.InputPacketsTotal.Add(0)
 	c.DroppedPacketsInvalid.Add(0)
 	c.DroppedPacketsBusyProcessor.Add(0)
 	c.DroppedPacketsBusyForwarder.Add(0)
 	c.DroppedPacketsBusySlowPath.Add(0)
-	c.ProcessedPackets.Add(0)
+	c.DroppedPacketsMACFailure.Add(0)
+	c.DroppedPacketsExpiredHop.Add(0)
+	c.DroppedPacketsSCMPRateLimited.Add(0)
 	return c
 }
 
@@ -16,10 +16,13 @@ package mgmtapi
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
 	api "github.com/scionproto/scion/private/mgmtapi"
+	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/router/control"
 )
 
@@ -29,6 +32,12 @@ type Server struct {
 	Info      http.HandlerFunc
 	LogLevel  http.HandlerFunc
 	Dataplane control.ObservableDataplane
+	// Reload reloads the topology and applies the interface changes to the
+	// running router. If it is nil, reloading is not supported.
+	Reload func() (topology.Diff, error)
+	// Capture controls the packet capture of the running router. If it is
+	// nil, capturing is not supported.
+	Capture control.PacketCapture
 }
 
 // GetConfig is an indirection to the http handler.
@@ -148,6 +157,182 @@ func (s *Server) GetInterfaces(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ReloadInterfaces reloads the topology and applies the changes of the SCION
+// interfaces to the running router.
+func (s *Server) ReloadInterfaces(w http.ResponseWriter, r *http.Request) {
+	if s.Reload == nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef("reloading is not supported"),
+			Status: http.StatusInternalServerError,
+			Title:  "error reloading interfaces",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	diff, err := s.Reload()
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error reloading interfaces",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	rep := InterfacesReloadResponse{
+		Added:   ifIDs(diff.AddedInterfaces),
+		Removed: ifIDs(diff.RemovedInterfaces),
+		Changed: ifIDs(diff.ChangedInterfaces),
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// GetCapture returns the status of the packet capture.
+func (s *Server) GetCapture(w http.ResponseWriter, r *http.Request) {
+	if !s.captureSupported(w) {
+		return
+	}
+	writeCaptureStatus(w, s.Capture.CaptureStatus())
+}
+
+// StartCapture starts capturing packets with the configuration in the request
+// body.
+func (s *Server) StartCapture(w http.ResponseWriter, r *http.Request) {
+	if !s.captureSupported(w) {
+		return
+	}
+	var req CaptureConfig
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		captureBadRequest(w, "invalid request body: "+err.Error())
+		return
+	}
+	cfg := control.CaptureConfig{Fraction: req.Fraction}
+	if req.SrcIsdAs != nil {
+		ia, err := addr.ParseIA(*req.SrcIsdAs)
+		if err != nil {
+			captureBadRequest(w, "invalid src_isd_as: "+err.Error())
+			return
+		}
+		cfg.SrcIA = ia
+	}
+	if req.DstIsdAs != nil {
+		ia, err := addr.ParseIA(*req.DstIsdAs)
+		if err != nil {
+			captureBadRequest(w, "invalid dst_isd_as: "+err.Error())
+			return
+		}
+		cfg.DstIA = ia
+	}
+	if req.File != nil {
+		if err := control.ValidateCaptureFile(*req.File); err != nil {
+			captureBadRequest(w, "invalid file: "+err.Error())
+			return
+		}
+		cfg.File = *req.File
+	}
+	if req.UdpSink != nil {
+		sink, err := net.ResolveUDPAddr("udp", *req.UdpSink)
+		if err != nil {
+			captureBadRequest(w, "invalid udp_sink: "+err.Error())
+			return
+		}
+		cfg.UDPSink = sink
+	}
+	if err := s.Capture.StartCapture(cfg); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error starting capture",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	writeCaptureStatus(w, s.Capture.CaptureStatus())
+}
+
+// StopCapture stops the packet capture and returns its final status.
+func (s *Server) StopCapture(w http.ResponseWriter, r *http.Request) {
+	if !s.captureSupported(w) {
+		return
+	}
+	writeCaptureStatus(w, s.Capture.StopCapture())
+}
+
+func (s *Server) captureSupported(w http.ResponseWriter) bool {
+	if s.Capture != nil {
+		return true
+	}
+	ErrorResponse(w, Problem{
+		Detail: api.StringRef("capturing is not supported"),
+		Status: http.StatusInternalServerError,
+		Title:  "error capturing packets",
+		Type:   api.StringRef(api.InternalError),
+	})
+	return false
+}
+
+func captureBadRequest(w http.ResponseWriter, detail string) {
+	ErrorResponse(w, Problem{
+		Detail: api.StringRef(detail),
+		Status: http.StatusBadRequest,
+		Title:  "invalid capture configuration",
+		Type:   api.StringRef(api.BadRequest),
+	})
+}
+
+func writeCaptureStatus(w http.ResponseWriter, status control.CaptureStatus) {
+	rep := CaptureStatus{
+		Active:   status.Active,
+		Captured: int64(status.Captured),
+		Dropped:  int64(status.Dropped),
+	}
+	if status.Config.Fraction != 0 {
+		cfg := CaptureConfig{Fraction: status.Config.Fraction}
+		if !status.Config.SrcIA.IsZero() {
+			cfg.SrcIsdAs = api.StringRef(status.Config.SrcIA.String())
+		}
+		if !status.Config.DstIA.IsZero() {
+			cfg.DstIsdAs = api.StringRef(status.Config.DstIA.String())
+		}
+		if status.Config.File != "" {
+			cfg.File = api.StringRef(status.Config.File)
+		}
+		if status.Config.UDPSink != nil {
+			cfg.UdpSink = api.StringRef(status.Config.UDPSink.String())
+		}
+		rep.Config = &cfg
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+func ifIDs(ids []common.IFIDType) []int {
+	r := make([]int, 0, len(ids))
+	for _, id := range ids {
+		r = append(r, int(id))
+	}
+	return r
+}
+
 // Error creates an detailed error response.
 func ErrorResponse(w http.ResponseWriter, p Problem) {
 	w.Header().Set("Content-Type", "application/problem+json")
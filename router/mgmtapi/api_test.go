@@ -15,9 +15,11 @@
 package mgmtapi
 
 import (
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/private/topology"
@@ -37,7 +40,9 @@ var update = xtest.UpdateGoldenFiles()
 func TestAPI(t *testing.T) {
 	testCases := map[string]struct {
 		Handler            func(t *testing.T, ctrl *gomock.Controller) http.Handler
+		Method             string
 		RequestURL         string
+		Body               string
 		ResponseFile       string
 		Status             int
 		IgnoreResponseBody bool
@@ -118,6 +123,122 @@ func TestAPI(t *testing.T) {
 			ResponseFile: "testdata/interfaces-sibling-error.json",
 			Status:       500,
 		},
+		"interfaces reload": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				s := &Server{
+					Reload: func() (topology.Diff, error) {
+						return topology.Diff{
+							AddedInterfaces:   []common.IFIDType{5, 7},
+							ChangedInterfaces: []common.IFIDType{2},
+						}, nil
+					},
+				}
+				return Handler(s)
+			},
+			Method:       http.MethodPost,
+			RequestURL:   "/interfaces/reload",
+			ResponseFile: "testdata/interfaces-reload.json",
+			Status:       200,
+		},
+		"interfaces reload error": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				s := &Server{
+					Reload: func() (topology.Diff, error) {
+						return topology.Diff{}, serrors.New("invalid topology")
+					},
+				}
+				return Handler(s)
+			},
+			Method:       http.MethodPost,
+			RequestURL:   "/interfaces/reload",
+			ResponseFile: "testdata/interfaces-reload-error.json",
+			Status:       500,
+		},
+		"capture status": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				capture := mock_api.NewMockPacketCapture(ctrl)
+				capture.EXPECT().CaptureStatus().Return(control.CaptureStatus{
+					Active: true,
+					Config: control.CaptureConfig{
+						Fraction: 0.5,
+						SrcIA:    xtest.MustParseIA("1-ff00:0:110"),
+						File:     "router.pcap",
+					},
+					Captured: 42,
+					Dropped:  3,
+				})
+				return Handler(&Server{Capture: capture})
+			},
+			RequestURL:   "/capture",
+			ResponseFile: "testdata/capture.json",
+			Status:       200,
+		},
+		"capture start": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				capture := mock_api.NewMockPacketCapture(ctrl)
+				cfg := control.CaptureConfig{
+					Fraction: 1,
+					DstIA:    xtest.MustParseIA("1-0"),
+					UDPSink:  &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 40000},
+				}
+				capture.EXPECT().StartCapture(cfg).Return(nil)
+				capture.EXPECT().CaptureStatus().Return(control.CaptureStatus{
+					Active: true,
+					Config: cfg,
+				})
+				return Handler(&Server{Capture: capture})
+			},
+			Method:       http.MethodPut,
+			RequestURL:   "/capture",
+			Body:         `{"fraction": 1, "dst_isd_as": "1-0", "udp_sink": "127.0.0.1:40000"}`,
+			ResponseFile: "testdata/capture-start.json",
+			Status:       200,
+		},
+		"capture start invalid": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				return Handler(&Server{Capture: mock_api.NewMockPacketCapture(ctrl)})
+			},
+			Method:       http.MethodPut,
+			RequestURL:   "/capture",
+			Body:         `{"fraction": 1, "src_isd_as": "invalid", "file": "router.pcap"}`,
+			ResponseFile: "testdata/capture-start-invalid.json",
+			Status:       400,
+		},
+		"capture start invalid file": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				return Handler(&Server{Capture: mock_api.NewMockPacketCapture(ctrl)})
+			},
+			Method:       http.MethodPut,
+			RequestURL:   "/capture",
+			Body:         `{"fraction": 1, "file": "../etc/router.pcap"}`,
+			ResponseFile: "testdata/capture-start-invalid-file.json",
+			Status:       400,
+		},
+		"capture stop": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				capture := mock_api.NewMockPacketCapture(ctrl)
+				capture.EXPECT().StopCapture().Return(control.CaptureStatus{
+					Config: control.CaptureConfig{
+						Fraction: 0.1,
+						File:     "router.pcap",
+					},
+					Captured: 7,
+				})
+				return Handler(&Server{Capture: capture})
+			},
+			Method:       http.MethodDelete,
+			RequestURL:   "/capture",
+			ResponseFile: "testdata/capture-stop.json",
+			Status:       200,
+		},
+		"capture not supported": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				return Handler(&Server{})
+			},
+			RequestURL:   "/capture",
+			ResponseFile: "testdata/capture-not-supported.json",
+			Status:       500,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -127,7 +248,11 @@ func TestAPI(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			req, err := http.NewRequest("GET", tc.RequestURL, nil)
+			method := tc.Method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req, err := http.NewRequest(method, tc.RequestURL, strings.NewReader(tc.Body))
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
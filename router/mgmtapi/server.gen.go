@@ -12,6 +12,15 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Stop capturing packets
+	// (DELETE /capture)
+	StopCapture(w http.ResponseWriter, r *http.Request)
+	// Get the status of the packet capture
+	// (GET /capture)
+	GetCapture(w http.ResponseWriter, r *http.Request)
+	// Start capturing packets
+	// (PUT /capture)
+	StartCapture(w http.ResponseWriter, r *http.Request)
 	// Prints the TOML configuration file.
 	// (GET /config)
 	GetConfig(w http.ResponseWriter, r *http.Request)
@@ -21,6 +30,9 @@ type ServerInterface interface {
 	// List the SCION interfaces
 	// (GET /interfaces)
 	GetInterfaces(w http.ResponseWriter, r *http.Request)
+	// Reload the SCION interfaces
+	// (POST /interfaces/reload)
+	ReloadInterfaces(w http.ResponseWriter, r *http.Request)
 	// Get logging level
 	// (GET /log/level)
 	GetLogLevel(w http.ResponseWriter, r *http.Request)
@@ -38,6 +50,51 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// StopCapture operation middleware
+func (siw *ServerInterfaceWrapper) StopCapture(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StopCapture(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetCapture operation middleware
+func (siw *ServerInterfaceWrapper) GetCapture(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCapture(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// StartCapture operation middleware
+func (siw *ServerInterfaceWrapper) StartCapture(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartCapture(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetConfig operation middleware
 func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -83,6 +140,21 @@ func (siw *ServerInterfaceWrapper) GetInterfaces(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// ReloadInterfaces operation middleware
+func (siw *ServerInterfaceWrapper) ReloadInterfaces(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReloadInterfaces(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetLogLevel operation middleware
 func (siw *ServerInterfaceWrapper) GetLogLevel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -226,6 +298,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/capture", wrapper.StopCapture)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/capture", wrapper.GetCapture)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/capture", wrapper.StartCapture)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/config", wrapper.GetConfig)
 	})
@@ -235,6 +316,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/interfaces", wrapper.GetInterfaces)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/interfaces/reload", wrapper.ReloadInterfaces)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/log/level", wrapper.GetLogLevel)
 	})
@@ -18,45 +18,59 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xZ33PbNhL+VzBoH5op9cNOem305thOq5kk1lj29KH1eSBiRaIGARYAZet8+t9vFiAp",
-	"/rLs5K7tXJ9skQD2w7ffLnbBRxrrLNcKlLN09kgN2FwrC/7HO8Yv4fcCrMNfsVYOlP+X5bkUMXNCq8lv",
-	"Vit8ZuMUMob/fW1gTWf0q8l+6Ul4aydLxxRnhp8bow3d7XYR5WBjI3JcjM7QJjGlUXxbTvRw3p/hn9zo",
-	"HIwTASMHKwzw20wokRXZrXu4FcqB2TBZvm4sfpUCKQeSahRZgbsHUMQZpmwmrBVaEb0m796fEdyz0ZLk",
-	"LL4DZ4lLmSMuBYIQmNOGBPt2TK5SYcmGyQKIsITxDWK0wInTfkYOYCKS6nvYgPFPWOwKJvdAChwtLLE5",
-	"xGItgJPVljh2J1Tix2fswSPX69IqH5WbGbmHUb0MU9wPD1j02v8wkGkHntnWRAMxiA3sQfhZYxpReGBZ",
-	"LoHO6PF0mlkaUbfN8ad1RqiEes85iJHa26yQTuRSgBkmXRXZCgyCaTGZFdaRFfrElkxxiCUzQByyaSE4",
-	"g1nC9b1CjoHURveY1zoQih6r5ghLYibjQjIXiCwhbis2W/QoSLQTfmhLBnuRbAOkPj2va2JwcAIGmQHF",
-	"VhJ4n4y54mXgoOn7FFwKxgMXlpSzvAdjrdYiKQxwolWw7cGsWdy270wBNYSV1hKYQgiVq+vIKF39mVFR",
-	"zuKHwgFdtbUOMmJTXUhObJHn2rjng6KUJcYGPhKBHWjJfe3TgYq35BsxhnHUxjoKWGrgr2rkTwJGJHEM",
-	"uUO2KyRSx0yW23iR/BsU09kvB/PQE5Gyl8kBb91E1AnngbwTXJiwDJPkvTb3zHCU81kdEpVqaoUx1ZZN",
-	"uQm9+g1ihzKZV2/7qXW15s+lc0zJu4jWJm7FgOaXp/OLT3sYRHBQDhOceT6Q/CzF5K1o4uzrl3FuwFrc",
-	"cjWFdO1WqVAXrmOaHr09Hh/944fx8fh49vpoOp0OpTsFIklX2jxHSk3pp2qC14r0TrGpyJ9b4INQd5fN",
-	"8f4c9OpxxbMnLA78eHXtJznm4CXWln5gV9Mttzb230QTeZlUpjr7HPRfQ9HBQ/O9h1TDQwfV+qnhi7Zq",
-	"SyX0ZXJ9tpjMF6RQHIxk26Zk0GgHyxfoQ1h+GyqVg+qw/MT2qQ5zoxp+g6VqrxjqXU2D4rkWylW7kELd",
-	"HY5ze1mWeH3q6mXDLweZfbHW0UZplBnDtl5+YiWFSm6/YN1lmHpg+d2eoGpHRArrkKWQzPEYLSGQBoQh",
-	"crxPZo9Nj4/W6+l0Np0dHaGzc+ZQyHRG//nrr/zb0Te/sNF6Onp783gUvdnNXj0e79qPXv0bx31N9yjn",
-	"y7PRyZLM6+w3pKFe6CMoVWSokdOLy3Ma0dOf5h/OaEQXJ5fnn67wn/PzS9TLHnw1ZHD5ZZUUqnWvFzSi",
-	"Zxc/f2ov4h/3V9DJB9iA7KtHVo/bYfdBJ4n3iX8d1VY5rIrEZ4i1xse+IWgBKN8cPnfDsjcDTl0YvZKQ",
-	"DbUMjokBpCckLTKGJQ/jvjSAh1wyFc7SsiiPQ70gLNFxXBgDan+w5MFgXWSkIPN1IXEGCrIsa6pRqM4E",
-	"S2/GNyLkvlTf4+Dc6BiAj8nPRjgHeIKTc5VIYVM/q8aHdS+oRCgAYyNS2IJJuSVKO2ILgcUsjlCYVSFO",
-	"lfAVjmN3kGrJwVi/Go728SL+Bbyd9U61UmVhgaU5c2zFLBAnMqxKCzeYBJV1TA0d0yfk+nJODKwhsBZo",
-	"qqLBenJqlp9kNyIwTsZYjzPuix9G1oYlGajGYoZoQ2yxGuXMpXUDVrlnm8OYfGRb7DyKshhtOMhoXaZT",
-	"YetJIpxMVhcmBhJr3jkgJuXASVxzNvKS/srpO1Aj1PIIHTfy7I0Ce2ttMubojBZGjGpmhmjF47Www7XP",
-	"T1dXCxIGeGQkAYXdadlAYrdqRCIUsWCw9wzt0iEJt/b23fR1RMtinM6+e/s2omWRSmdH0+lQ1VamvL4C",
-	"bKoNijPLmNn24sY75q8W/RKMj8drxTZMSLQ55JDwAHe4ZoVEH7KVLtxsJZm6o9FLtF8o8XsBctsNgiYf",
-	"RCscENTnb2AeXIO3jeDAycliPiYXea4bnVUVSaxslcnl+9PR9z9Mv4+I8NlJgfC9p4FYZxkoHuausMOu",
-	"gHrCka9QYzhNWMiRo9odXMcFBl+wo7QhidQr75Kwv7o7b7n5ZcHzGSHSORbKeKmkOHQ+1IXycENcdp+t",
-	"64BCIXeKrLYOrN9YqMfK9rLsdw3kBiyWM9W+nY619Ak0LPHN4uz6VbvwlGwLxnMtbC3qxg0GszWkc/Sb",
-	"AkdytpWacTIi8wX5CRgHQ0bk+qz60WL56M33x0Ox2qu0ni4L/5Lubl61c516PdR4f3gzV9LzN2vlBoh/",
-	"sr/rdHQBSLOJK0vs+cESu8tjX2X/fff0v+6Z2pfVPcRQPW4L1o8mGVjLkucTVV33dqzvdmVp3D9FF/M6",
-	"p4atXdb9ctUQ+QekOspOFnMa0Q0YG1aYjqfjI9ygzkGxXNAZfT2ejo9Dn5P6zU3CVRL+m4C/9A9X3kKr",
-	"Oacz+iO40zAian80OJ5OO18L8Mya5JKJzneCLjG9bwHLIo7BWqyhLyrjCPtNMDGkkxrKpPHxwn9HCDUH",
-	"ndGFEVVqvrr4+KFzZ7YWMlyUscSif/Bw1Ire4BqTyiFPMTIPHcv/Fx/vmBUxwa3hSYsc5CwB4suZuuww",
-	"WvryEeXk+xNrD7DUbPdLrjpNobCuIeD9jFAZMQO9C/Dmxd0A8Y3c8wz9X/7xauAOZcBLfm963dvauOGq",
-	"J+CUddC3nweranQHsMzVhklRf1Ebd1z/pBsarm1c3HnvSp1M6lb/qUCobwn+QG/UNv60SPkRsGtoX2f0",
-	"IiCieTFAyrJDil//nebbP4WP6hKmaT+cQM4UsPtbeWn5Ei/5Kb4dxuePtDCSzmjqXD6bTB5Tbd1u9phr",
-	"43YTlovJ5ggPUGYE9jyeIxzS7v98Qeofowa06bx+PX3z5hhZuKnh9CqHDZitSxG4L7pCR9bPIxFVLKtK",
-	"6ep6tLvYqd8qnv0EHkJruNqWi5WZvLlUyczuZvefAAAA//9UPMQGEyAAAA==",
+	"H4sIAAAAAAAC/9xaW3fbNhL+KzhsH5JT6mInaRq9ObHTak9q6/hy+tB6fSBiJKEGARYAZWu9+u97BheK",
+	"pCjZbtNkd59skbh8880FMwM+JJnKCyVBWpOMHhINplDSgPvxnrJz+KMEY/FXpqQF6f6lRSF4Ri1XcvC7",
+	"URKfmWwBOcX/vtUwS0bJN4PN0gP/1gwuLJWManaitdLJer1OEwYm07zAxZIR7kl02BTfhokOzsdj/FNo",
+	"VYC23GNkYLgGdpNzyfMyv7H3N1xa0Esqwuva4pcLIGEgiaPIFOwdgCRWU2lybgxXkqgZef/xmKDMWglS",
+	"0OwWrCF2QS2xCyAIgVqlid/f9MnlghuypKIEwg2hbIkYDTBilZtRAOiULNQdLEG7JzSzJRUbICWO5oaY",
+	"AjI+48DIdEUsveVy7sbn9N4hV7OwK+sFYXr2vlctQyVzwz0WNXM/NOTKgmO2MVFDBnwJGxBuVj9JE7in",
+	"eSEgGSWHw2FukjSxqwJ/Gqu5nCdOcxYypPYmL4XlheCgu0mXZT4FjWAaTOalsWSKOjGBKQaZoBqIRTYN",
+	"eGVQQ5i6k8gxkGrTDeaZ8oSixuIcbkhGRVYKaj2RAeIqstmgR8JcWe6GNsxgYyQrD2mbnlcVMTh4DhqZ",
+	"AUmnAtg2GWPJguPg1ncLsAvQDjg3JMxyGsyUnPF5qYERJf3eDsyMZs39rS6hgjBVSgCVCCGquvKMoOpn",
+	"ekWYxfa5A6pqZSzkxCxUKRgxZVEobR93imCWBYDGR9yzAw1zn6EkILMVecH70E+bWHseSwX8ZYV8J2BE",
+	"kmVQWGQ7IhEqoyKI8STzr1GcjH7dG4d2eMrGTPZo6zpNLLcOyHvOuPbLUEE+Kn1HNUNzPq5cIlpNZWFU",
+	"Ns0mCKGmv0Nm0Uw+0MKWGj64iR3h1dgbbtgNNY9F9rFhRwZXnHHRYWSnNK+8rchoQXCY/xV0g25/p7m1",
+	"IIlV3tndIHRlDc45uXRTMg+aeD6UXsWVtSotaOdALrZIZQncc2P9cs2taFH4JceTwdXxhCyAMtCG3HG7",
+	"wNW4JqVkoAVdEcqYBmPANG3D79dHgboC5EzTzMvfpuNjeBOB59RmC9Rl21KDqCxFoC+GKTm4bkAY9ocH",
+	"aTJTOqc2GSVMlVMBGyw+8CIWo7PnarJkxY3h8nYb/ieliinNbiMvW4o0IC26FjXkHxdnp0RDpjTbcEtm",
+	"HAQzQXyuycWH8dlpUEGfnNzTzIoVUdIZjTMDVGpEVJ0cBmxTIweHb/vD/rB/MHo9HA6Hj/ptpaKao018",
+	"SIlW1nCqPT50YaktzbYP4QZdcfeXEP3rvEV195OumB7fdrjX1gG7cSXHN7LWT2qWwqX9/nXSdXhlVSzY",
+	"ZyXNwIHZgFboUPuwGacl1jTyO0BP9pPJFDJaGqggE8aZ8+JbgIKUxZNEaKk48F+jbwN2t9aN12aHuscx",
+	"oG6rejpjj/GGWew6TaqofMM7KPPeUI0hnIG0mBPqx3MPN0tSccPrOLeP/Oi6akbiFNLetxFWW4727rB/",
+	"8P0P/cP+4ejVQaerpYkEPl9MlX405MQdT+MEp0PhXM4sePHYAp+4vD2vj3elgztwbfloUYIDf768cpMs",
+	"tfCU3S7cwLatNdRak7+OJnVmErdqydmpv5qVeg2NNxqSNQ3ttdbTmi5aAcpbwraZXB1PBuPJ1ikYzaKF",
+	"5U/Yx7MOpDbVfm5awa+xFGXFE7Vt0yBZobi0UQoRIuNu5sw5CIW1sK+NOwnsTPYrr21RVoGph0C3SIPD",
+	"X99cpwm3kLtNtj09PKFa0xX+zhZUzv86kLBME8rzkGBev/zrSMIyTSSHz4HSPgqcojb4NpzVjGfjXP6l",
+	"Ia7f4ctISrQzhscMZpepbOR0v6IcTwqOXUwbPhVczm/+xLoXfuqe5dcbUqJERHBj0a18wYRJWYBQU2En",
+	"Oc6JRw/1ENGbzYbD0XB0cIDRoaDWgpbJKPnnb7+x73ovfqW92bD37vrhIH29Hr18OFw3H738N477Nqmp",
+	"7uK4d3RBNlbWFXS2zgoEJcscDeTD2flJkiYffhp/Ok7SZHJ0fnJ6if+cnJyjjWzAxyGdy1/EUySuezVJ",
+	"0uT47JfT5iJXk84V1PwTLEFsW4+Ij9vp+HzudOJep9WuDKbl3B0pM4WPXdOtASC82Z8j+2WvO5Q60Woq",
+	"IO9qy1nKO5AekUWZU0k0UObKb7gvBJW+Xg2Nr8znrNwQlWWl1iA3mUjhN6zKowWIYlYKnCFU1TqIo9A6",
+	"59jeomzJ/WG5UHc4uNAqA2B98ktIk7kkJ3IuuFm4WRU+7C2BnHMJoE1KSlNSIVYuJTUlt8DcCInHMGQL",
+	"yV0XwdJbWCjhqklcDUc7f+H/akWz5IOSMhTvVhFGLZ1SA8TyHBhRpe08NaWxVHbldUfk6nxMNMzAs+Zp",
+	"it7gy7SK5Z3spgT6876Ldsw1GCiZaTrPQdYW00RpYsppr6B24TVWU8+qgD75ma6wRitDw6emIK1UOH+5",
+	"qSaF4t6oUmPsVayVUQzCwEFWcdZzJv2NVbcge2jLPVRcz7HX8+xV1UKpea9ipotWU9Vv28nyT5eXk1AS",
+	"OGRkDhJ07C0ibKX5nEtiQGN/17ck95lwQ7Y3w1dpEhpeyejNu3dpEhpByehgOOxK80PI27YAs1AajTPP",
+	"qV61Ng2K+dpGfwHa+eOVpEvKBZ2KToX4ByjhjJYCdUinqrSjqaDyNkmfYvul5H+UIFZtJ6jzQZQUq2h9",
+	"7pbj3tZ4W3IGjBxNxn1yVhSq1r2MnkRDO5qcf/zQe/vD8G1KuItOErir8DVkKs9BMj93CoRBBOoIR758",
+	"UmoVoT5G9ip1MJWV6Hx+H6k0mQs1dSrx8lUd8Iaan+Y8z3CR1rGwqZCdKXadD1Vl1d10Dh3eRsu9lMid",
+	"JNOVBeME8wli6BmEnrKGQoMBaSt1WpUp4QKoX+LF5PjqZTPHFHQVmoPcVEZduyWgpoJ0gnqTYElBV5ju",
+	"kR4ZT8hPrj1FeuTqOP5osHzw+u1hl69uZVq708Kv0g4YhzHtAs/neH979R/o+T+r/TuI39kQaLUAPJB6",
+	"1R9S7PHeFLvN47aV/fVy+3MX2c0L4S3EEB83DdaNJjkYQ+ePB6oq723tvl6H1Hj7FJ2Mq5jqRTuvGiyx",
+	"IHIPSDzKjibjJE2WoI1fwfWgUUBVgKQFT0bJq/6wf+jrnIUTbhB6jX5/Ad74/NUyV3LMUPNWFaHV6uyk",
+	"dj1/OBx+tnv5Zgu7417+I5dUxAwoKNVY37QNYvT9hb1PPAL08K52s4EU0rlBvUTxr9dpMge7LfyPYP8b",
+	"ZL9oSF00+sRtoX8EG7jZPWcHBUVpO0K/pdqSnKMJ+1TcN9HjyjN/DVhvqmMGsbliU9o/8bEcDy6jcGCf",
+	"HBFdSomLBhTu44Og0xnXxl2ttM2R6oZO3Jca7xVbfW51xIuFpjNbXcL6a9rCZe368Y4iX1RbzG/XafJ6",
+	"L5CQgH33PECxwu6AMpZLKjjrvqdygN58WUB1bjL3IYBU/pauIqkVINC2nxYh1mky2FxM7YwWfsSjBoIp",
+	"/qAQlLcEb58j26GgzDIwBlsOZ3Hzmuq7iKugDGrfUzWJmGgeM9nLs58/NTXp3LhfJ0XlOV5YOk7i+bWL",
+	"kbFv8Pxv8fGeGp4RLn1hghwUdA7EVX9VlaaVICacvq6dY8welurd0cBVq4fGjd3Tj6Y1B4vf5NQvxjqI",
+	"r6Vqf1u86mg5d2jJyaZmW6J9zagVjo52TNiphppqaxdjLe0OQnMe80hlOrTsb3LcBlYVSqj5avN1Acrt",
+	"mzmx8b/ziiJ8vhTOz2AGZNwyms29TpxRbUrbb/waabzzqO9GN1chqQPq4e0a42Vxa9M55bJPzlwXoiVU",
+	"AwoG6nDLsW3NnrQvb9CNS7cd5w3fuqypMR8F+jpHYUVw4yxEoTCA6Bq8hgfUTPQZPiDUfFDdDuw6DKqL",
+	"hb9RgdUeX+y0wJRbtG5Atk6BKr1u5bMtUj5/OruPj3hvU9//y+S5X15LF0/RkpviOuj4/CEptUhGycLa",
+	"YjQYPCyUsevRQ6G0XQ9owQfLA6y5qebYJnUcLaqoH1vGru5xj9EGlG69fjV8/foQWbiu4Gw1G5agV9Z9",
+	"nef6ND5mb5+laSJpHrtv8Ua1vVgoMNxXbVul28x9UD4tHUu1BWMa3LGcYw67D3iT5prT01XAFpKjOrJA",
+	"9Pp6/Z8BAI0lj8r5LwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
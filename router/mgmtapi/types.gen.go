@@ -39,6 +39,34 @@ type BFD struct {
 	RequiredMinimumReceive string `json:"required_minimum_receive"`
 }
 
+// CaptureConfig defines model for CaptureConfig.
+type CaptureConfig struct {
+	DstIsdAs *IsdAs `json:"dst_isd_as,omitempty"`
+
+	// File Path of the pcap file the packets are written to. The packets are wrapped in IP/UDP headers with their underlay addresses.
+	File *string `json:"file,omitempty"`
+
+	// Fraction Fraction of the matching packets that is captured, in (0, 1].
+	Fraction float64 `json:"fraction"`
+	SrcIsdAs *IsdAs  `json:"src_isd_as,omitempty"`
+
+	// UdpSink Loopback address the packets are sent to as JSON records with the fields of their SCION header. Exactly one of file and udp_sink must be set.
+	UdpSink *string `json:"udp_sink,omitempty"`
+}
+
+// CaptureStatus defines model for CaptureStatus.
+type CaptureStatus struct {
+	// Active Whether packets are captured.
+	Active bool `json:"active"`
+
+	// Captured Number of packets written to the sink.
+	Captured int64          `json:"captured"`
+	Config   *CaptureConfig `json:"config,omitempty"`
+
+	// Dropped Number of sampled packets that were dropped because the sink did not keep up.
+	Dropped int64 `json:"dropped"`
+}
+
 // Interface defines model for Interface.
 type Interface struct {
 	Bfd BFD `json:"bfd"`
@@ -63,6 +91,18 @@ type InterfaceNeighbor struct {
 	IsdAs   IsdAs  `json:"isd_as"`
 }
 
+// InterfacesReloadResponse defines model for InterfacesReloadResponse.
+type InterfacesReloadResponse struct {
+	// Added Identifiers of the SCION interfaces that were added.
+	Added []int `json:"added"`
+
+	// Changed Identifiers of the SCION interfaces that were changed.
+	Changed []int `json:"changed"`
+
+	// Removed Identifiers of the SCION interfaces that were removed.
+	Removed []int `json:"removed"`
+}
+
 // InterfacesResponse defines model for InterfacesResponse.
 type InterfacesResponse struct {
 	Interfaces        *[]Interface        `json:"interfaces,omitempty"`
@@ -137,5 +177,8 @@ type StandardError struct {
 // BadRequest defines model for BadRequest.
 type BadRequest = StandardError
 
+// StartCaptureJSONRequestBody defines body for StartCapture for application/json ContentType.
+type StartCaptureJSONRequestBody = CaptureConfig
+
 // SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
 type SetLogLevelJSONRequestBody = LogLevel
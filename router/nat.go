@@ -0,0 +1,138 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/netip"
+	"sync"
+	"time"
+)
+
+// natMappings records the underlay addresses that NATs between end hosts and
+// the router map the end hosts to. They are learned from the STUN binding
+// requests of the end hosts, which send them regularly to keep the mappings
+// alive. A mapping is keyed by the mapped IP address and port, several end
+// hosts behind the same NAT thus have separate mappings.
+//
+// The SCION destination address of a packet to an end host behind a NAT is the
+// mapped IP address, which does not identify the end host if several end
+// hosts share it. Like the NAT itself, the router therefore learns which UDP
+// ports of the mapped IP address belong to which mapping from the packets the
+// end hosts send. Packets to an end host are sent to the mapped port that last
+// sent from the destination UDP port or, if there is none, to the only mapping
+// of the destination IP address.
+type natMappings struct {
+	timeout time.Duration
+
+	mtx sync.RWMutex
+	// mappings holds the expiry of the mappings per mapped IP address and
+	// port.
+	mappings map[netip.Addr]map[uint16]time.Time
+	// hosts holds the mapped port per mapped IP address and UDP port of the
+	// end hosts.
+	hosts     map[netip.AddrPort]uint16
+	nextSweep time.Time
+}
+
+func newNATMappings(timeout time.Duration) *natMappings {
+	return &natMappings{
+		timeout:  timeout,
+		mappings: make(map[netip.Addr]map[uint16]time.Time),
+		hosts:    make(map[netip.AddrPort]uint16),
+	}
+}
+
+// update records the mapped address of an end host the STUN binding request
+// was received from.
+func (n *natMappings) update(mapped netip.AddrPort, now time.Time) {
+	ip := natIP(mapped.Addr())
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	ports, ok := n.mappings[ip]
+	if !ok {
+		ports = make(map[uint16]time.Time)
+		n.mappings[ip] = ports
+	}
+	ports[mapped.Port()] = now.Add(n.timeout)
+	if now.Before(n.nextSweep) {
+		return
+	}
+	for ip, ports := range n.mappings {
+		for port, expiry := range ports {
+			if now.After(expiry) {
+				delete(ports, port)
+			}
+		}
+		if len(ports) == 0 {
+			delete(n.mappings, ip)
+		}
+	}
+	for host, port := range n.hosts {
+		if _, ok := n.mappings[host.Addr()][port]; !ok {
+			delete(n.hosts, host)
+		}
+	}
+	n.nextSweep = now.Add(n.timeout)
+}
+
+// learn records that the packet received from the underlay address src was
+// sent from the UDP port of the SCION source address host. It is ignored
+// unless src is a mapped address and host has its IP address, i.e., the end
+// host is behind the NAT.
+func (n *natMappings) learn(src, host netip.AddrPort, now time.Time) {
+	ip := natIP(src.Addr())
+	if natIP(host.Addr()) != ip {
+		return
+	}
+	host = netip.AddrPortFrom(ip, host.Port())
+	n.mtx.RLock()
+	expiry, ok := n.mappings[ip][src.Port()]
+	port, known := n.hosts[host]
+	n.mtx.RUnlock()
+	if !ok || now.After(expiry) || (known && port == src.Port()) {
+		return
+	}
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.hosts[host] = src.Port()
+}
+
+// port returns the mapped port of the end host with the given mapped IP
+// address and UDP port.
+func (n *natMappings) port(dst netip.AddrPort, now time.Time) (uint16, bool) {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	ports := n.mappings[dst.Addr()]
+	if port, ok := n.hosts[dst]; ok {
+		if expiry, ok := ports[port]; ok && !now.After(expiry) {
+			return port, true
+		}
+	}
+	if len(ports) != 1 {
+		return 0, false
+	}
+	for port, expiry := range ports {
+		if !now.After(expiry) {
+			return port, true
+		}
+	}
+	return 0, false
+}
+
+// natIP normalizes ip to the form of the SCION host addresses, which have
+// neither IPv4-mapped IPv6 addresses nor zones.
+func natIP(ip netip.Addr) netip.Addr {
+	return ip.Unmap().WithZone("")
+}
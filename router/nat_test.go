@@ -0,0 +1,80 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNATMappings(t *testing.T) {
+	now := time.Now()
+	host1 := netip.MustParseAddrPort("203.0.113.1:40000")
+	host2 := netip.MustParseAddrPort("203.0.113.1:40001")
+	app := netip.MustParseAddrPort("203.0.113.1:31000")
+
+	t.Run("single end host", func(t *testing.T) {
+		n := newNATMappings(time.Minute)
+		n.update(host1, now)
+		port, ok := n.port(app, now)
+		assert.True(t, ok)
+		assert.Equal(t, host1.Port(), port)
+		_, ok = n.port(netip.MustParseAddrPort("203.0.113.2:31000"), now)
+		assert.False(t, ok)
+		_, ok = n.port(app, now.Add(2*time.Minute))
+		assert.False(t, ok, "expired")
+	})
+	t.Run("several end hosts", func(t *testing.T) {
+		n := newNATMappings(time.Minute)
+		n.update(host1, now)
+		n.update(host2, now)
+		_, ok := n.port(app, now)
+		assert.False(t, ok, "ambiguous")
+
+		n.learn(host2, app, now)
+		port, ok := n.port(app, now)
+		assert.True(t, ok)
+		assert.Equal(t, host2.Port(), port)
+
+		// The STUN request of the other end host does not take over the
+		// learned port.
+		n.update(host1, now.Add(time.Second))
+		port, ok = n.port(app, now.Add(time.Second))
+		assert.True(t, ok)
+		assert.Equal(t, host2.Port(), port)
+
+		// The learned port is dropped with the mapping.
+		n.update(host1, now.Add(90*time.Second))
+		port, ok = n.port(app, now.Add(90*time.Second))
+		assert.True(t, ok)
+		assert.Equal(t, host1.Port(), port)
+		assert.Empty(t, n.hosts)
+	})
+	t.Run("learn", func(t *testing.T) {
+		n := newNATMappings(time.Minute)
+		n.update(host1, now)
+		n.update(host2, now)
+		// Packets from an underlay address without mapping are ignored.
+		n.learn(netip.MustParseAddrPort("203.0.113.1:40002"), app, now)
+		// Packets with a different source address are ignored.
+		n.learn(host1, netip.MustParseAddrPort("192.168.1.10:31000"), now)
+		assert.Empty(t, n.hosts)
+		n.learn(host1, netip.MustParseAddrPort("[::ffff:203.0.113.1]:31000"), now)
+		assert.Equal(t, map[netip.AddrPort]uint16{app: host1.Port()}, n.hosts)
+	})
+}
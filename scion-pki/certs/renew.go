@@ -29,6 +29,7 @@ import (
 
 	"github.com/quic-go/quic-go"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/protobuf/proto"
 
@@ -53,6 +54,8 @@ import (
 	"github.com/scionproto/scion/private/app/flag"
 	"github.com/scionproto/scion/private/app/path"
 	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/env"
+	"github.com/scionproto/scion/private/storage/trust/sqlite"
 	"github.com/scionproto/scion/private/svc"
 	"github.com/scionproto/scion/private/tracing"
 	"github.com/scionproto/scion/private/trust"
@@ -123,6 +126,7 @@ func newRenewCmd(pather command.Pather) *cobra.Command {
 		remotes    []string
 		curve      string
 		expiresIn  string
+		grpcTLS    env.GRPCTLS
 
 		timeout  time.Duration
 		tracer   string
@@ -171,6 +175,13 @@ if applicable, the fresh private key are written to the provided file paths with
 the '<CA>.unverified' suffix, where CA is the ISD-AS number of the CA AS that
 issued the unverifiable certificate chain.
 
+If the CA is the local AS and mutual TLS is enabled on the AS internal gRPC
+connections of the control service (grpc_tls), the \--grpc-tls flag must be
+set. By default, the <chain-file> and <key-file> are then presented to the
+control service, and the certificate of the control service is verified against
+the TRCs. Use the \--grpc-tls.cert, \--grpc-tls.key and \--grpc-tls.ca flags to
+select different files.
+
 The resulting certificate chain is written to the file system, either to
 <chain-file> or to \--out, if specified.
 
@@ -335,6 +346,13 @@ The template is expressed in JSON. A valid example::
 			span.SetTag("ca-options", cas)
 			span.SetTag("remote-options", remotes)
 
+			creds, closer, err := intraASCredentials(ctx, flags.grpcTLS, info.IA,
+				certFile, keyFile, flags.trcFiles)
+			if err != nil {
+				return err
+			}
+			defer closer()
+
 			// Load private key.
 			privPrev, err := key.LoadPrivateKey(keyFile)
 			if err != nil {
@@ -420,12 +438,13 @@ The template is expressed in JSON. A valid example::
 			}
 
 			r := renewer{
-				LocalIA:   info.IA,
-				LocalIP:   localIP,
-				Daemon:    sd,
-				Disatcher: dispatcher,
-				Timeout:   flags.timeout,
-				StdErr:    cmd.ErrOrStderr(),
+				LocalIA:     info.IA,
+				LocalIP:     localIP,
+				Daemon:      sd,
+				Disatcher:   dispatcher,
+				Credentials: creds,
+				Timeout:     flags.timeout,
+				StdErr:      cmd.ErrOrStderr(),
 				PathOptions: func() []path.Option {
 					pathOpts := []path.Option{
 						path.WithInteractive(flags.interactive),
@@ -587,6 +606,15 @@ The template is expressed in JSON. A valid example::
 	cmd.Flags().StringVar(&flags.expiresIn, "expires-in", "",
 		"Remaining time threshold for renewal",
 	)
+	cmd.Flags().BoolVar(&flags.grpcTLS.Enabled, "grpc-tls", false,
+		"Use mutual TLS for the requests to the CA of the local AS")
+	cmd.Flags().StringVar(&flags.grpcTLS.CertFile, "grpc-tls.cert", "",
+		"The certificate chain presented to the local control service (default <chain-file>)")
+	cmd.Flags().StringVar(&flags.grpcTLS.KeyFile, "grpc-tls.key", "",
+		"The private key of the --grpc-tls.cert certificate chain (default <key-file>)")
+	cmd.Flags().StringVar(&flags.grpcTLS.CAFile, "grpc-tls.ca", "",
+		"The CA certificates the local control service is verified against.\n"+
+			"If not set, the control service is verified against the TRCs")
 	cmd.Flags().BoolVar(&flags.force, "force", false,
 		"Force overwritting existing files",
 	)
@@ -621,6 +649,9 @@ type renewer struct {
 	PathOptions func() []path.Option
 	Daemon      daemon.Connector
 	Disatcher   string
+	// Credentials, if set, are used for the requests to the CA of the local
+	// AS.
+	Credentials credentials.TransportCredentials
 	Timeout     time.Duration
 	StdErr      io.Writer
 }
@@ -685,11 +716,65 @@ func (r *renewer) requestLocal(
 			}
 			return addrs
 		},
+		Credentials: r.Credentials,
 	}
 
 	return r.doRequest(ctx, dialer, remote, req)
 }
 
+// intraASCredentials returns the transport credentials for the requests to the
+// control service of the local AS if mutual TLS is enabled. Unless configured
+// otherwise, the certificate chain that is renewed is presented to the control
+// service, and the certificate of the control service is verified against the
+// TRCs. The returned closer must be called once the credentials are no longer
+// used.
+func intraASCredentials(
+	ctx context.Context,
+	cfg env.GRPCTLS,
+	ia addr.IA,
+	certFile string,
+	keyFile string,
+	trcFiles []string,
+) (credentials.TransportCredentials, func(), error) {
+
+	if !cfg.Enabled {
+		return nil, func() {}, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, serrors.WrapStr("validating gRPC TLS flags", err)
+	}
+	if cfg.CertFile == "" {
+		cfg.CertFile, cfg.KeyFile = certFile, keyFile
+	}
+	intraASTLS := infraenv.IntraASTLS{Config: cfg, IA: ia}
+	closer := func() {}
+	if cfg.CAFile == "" {
+		db, err := sqlite.New("file::memory:")
+		if err != nil {
+			return nil, nil, serrors.WrapStr("creating trust database", err)
+		}
+		closer = func() { db.Close() }
+		signedTRCs, err := loadSignedTRCs(trcFiles)
+		if err != nil {
+			closer()
+			return nil, nil, err
+		}
+		for _, trc := range signedTRCs {
+			if _, err := db.InsertTRC(ctx, trc); err != nil {
+				closer()
+				return nil, nil, serrors.WrapStr("inserting TRC", err, "id", trc.TRC.ID)
+			}
+		}
+		intraASTLS.TLSVerifier = trust.NewTLSCryptoVerifier(db)
+	}
+	creds, err := intraASTLS.ClientCredentials()
+	if err != nil {
+		closer()
+		return nil, nil, serrors.WrapStr("initializing TLS for control service connections", err)
+	}
+	return creds, closer, nil
+}
+
 func (r *renewer) requestRemote(
 	ctx context.Context,
 	req *cppb.ChainRenewalRequest,
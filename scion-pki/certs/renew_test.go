@@ -31,6 +31,7 @@ import (
 	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/pkg/scrypto/cppki"
 	"github.com/scionproto/scion/pkg/scrypto/signed"
+	"github.com/scionproto/scion/private/env"
 	"github.com/scionproto/scion/private/trust"
 	"github.com/scionproto/scion/scion-pki/key"
 )
@@ -187,6 +188,77 @@ func TestExtractChain(t *testing.T) {
 	}
 }
 
+func TestIntraASCredentials(t *testing.T) {
+	const (
+		certFile = "testdata/renew/ISD1-ASff00_0_111.pem"
+		keyFile  = "testdata/renew/cp-as.key"
+		trcFile  = "testdata/renew/ISD1-B1-S1.trc"
+	)
+	testCases := map[string]struct {
+		Config       env.GRPCTLS
+		TRCs         []string
+		Credentials  assert.ValueAssertionFunc
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"disabled": {
+			Credentials:  assert.Nil,
+			ErrAssertion: assert.NoError,
+		},
+		"verified against TRCs": {
+			Config:       env.GRPCTLS{Enabled: true},
+			TRCs:         []string{trcFile},
+			Credentials:  assert.NotNil,
+			ErrAssertion: assert.NoError,
+		},
+		"verified against CA file": {
+			Config: env.GRPCTLS{
+				Enabled:  true,
+				CertFile: certFile,
+				KeyFile:  keyFile,
+				CAFile:   "testdata/renew/ISD1-ASff00_0_110.pem",
+			},
+			Credentials:  assert.NotNil,
+			ErrAssertion: assert.NoError,
+		},
+		"missing TRC": {
+			Config:       env.GRPCTLS{Enabled: true},
+			TRCs:         []string{"testdata/renew/missing.trc"},
+			Credentials:  assert.Nil,
+			ErrAssertion: assert.Error,
+		},
+		"missing CA file": {
+			Config: env.GRPCTLS{
+				Enabled: true,
+				CAFile:  "testdata/renew/missing.pem",
+			},
+			Credentials:  assert.Nil,
+			ErrAssertion: assert.Error,
+		},
+		"certificate without key": {
+			Config: env.GRPCTLS{
+				Enabled:  true,
+				CertFile: certFile,
+			},
+			TRCs:         []string{trcFile},
+			Credentials:  assert.Nil,
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			creds, closer, err := intraASCredentials(context.Background(), tc.Config,
+				xtest.MustParseIA("1-ff00:0:111"), certFile, keyFile, tc.TRCs)
+			tc.ErrAssertion(t, err)
+			tc.Credentials(t, creds)
+			if err == nil {
+				closer()
+			}
+		})
+	}
+}
+
 func TestSelectLatestTRCs(t *testing.T) {
 	testCases := map[string]struct {
 		Input  []cppki.SignedTRC
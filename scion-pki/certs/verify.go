@@ -210,6 +210,20 @@ func loadTRC(trcFile string) (cppki.SignedTRC, error) {
 // loadTRCs is a helper function to load the two latest TRCs from files. If any
 // file cannot be read, a nil slice is returned and an error.
 func loadTRCs(trcFiles []string) ([]*cppki.TRC, error) {
+	signedTRCs, err := loadSignedTRCs(trcFiles)
+	if err != nil {
+		return nil, err
+	}
+	latestSignedTRCs, err := selectLatestTRCs(signedTRCs)
+	if err != nil {
+		return nil, serrors.WrapStr("selecting latest TRCs", err)
+	}
+	return trcSlice(latestSignedTRCs), nil
+}
+
+// loadSignedTRCs loads the TRCs from files. The file names can contain glob
+// patterns.
+func loadSignedTRCs(trcFiles []string) ([]cppki.SignedTRC, error) {
 	// Resolve all glob patterns.
 	var resolvedTRCFiles []string
 	for _, trcFile := range trcFiles {
@@ -232,12 +246,7 @@ func loadTRCs(trcFiles []string) ([]*cppki.TRC, error) {
 		}
 		signedTRCs = append(signedTRCs, signedTRC)
 	}
-
-	latestSignedTRCs, err := selectLatestTRCs(signedTRCs)
-	if err != nil {
-		return nil, serrors.WrapStr("selecting latest TRCs", err)
-	}
-	return trcSlice(latestSignedTRCs), nil
+	return signedTRCs, nil
 }
 
 // selectLatestTRCs selects the latest two TRCs by finding the highest base
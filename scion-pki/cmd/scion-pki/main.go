@@ -27,6 +27,7 @@ import (
 	"github.com/scionproto/scion/scion-pki/key"
 	"github.com/scionproto/scion/scion-pki/testcrypto"
 	"github.com/scionproto/scion/scion-pki/trcs"
+	"github.com/scionproto/scion/scion-pki/trust"
 )
 
 // CommandPather returns the path to a command.
@@ -55,6 +56,7 @@ func main() {
 		key.Cmd(cmd),
 		certs.Cmd(cmd),
 		trcs.Cmd(cmd),
+		trust.Cmd(cmd),
 		testcrypto.Cmd(cmd),
 		newGendocs(cmd),
 	)
@@ -33,9 +33,11 @@ import (
 
 func newCombine(pather command.Pather) *cobra.Command {
 	var flags struct {
-		out     string
-		payload string
-		format  string
+		out         string
+		payload     string
+		format      string
+		verify      bool
+		predecessor string
 	}
 
 	cmd := &cobra.Command{
@@ -44,15 +46,23 @@ func newCombine(pather command.Pather) *cobra.Command {
 		Long: `'combine' combines the signatures on partially signed TRCs into one single TRC.
 The command checks that all parts sign the same TRC payload content.
 
-No further checks are made. Check that the TRC is valid and verifiable with the
-appropriate commands.
+By default, no further checks are made. With the --verify flag, the combined TRC
+is verified before it is written. For a base TRC, this checks that all voters
+have proven possession of their keys. For a TRC update, the previous TRC must be
+provided with the --predecessor flag, and the update is verified against it. This
+makes it possible to check that the ceremony collected all required signatures
+before distributing the final TRC.
 `,
 		Example: fmt.Sprintf(`  %[1]s combine --payload ISD1-B1-S1.pld -o ISD1-B1-S1.trc `+
-			`ISD1-B1-S1.org1 ISD1-B1-S1.org2`, pather.CommandPath()),
+			`ISD1-B1-S1.org1 ISD1-B1-S1.org2
+  %[1]s combine --predecessor ISD1-B1-S1.trc -o ISD1-B1-S2.trc `+
+			`ISD1-B1-S2.org1 ISD1-B1-S2.org2`, pather.CommandPath()),
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
-			return RunCombine(args, flags.payload, flags.out, flags.format)
+			verify := flags.verify || flags.predecessor != ""
+			return RunCombine(args, flags.payload, flags.out, flags.format,
+				verify, flags.predecessor)
 		},
 	}
 
@@ -61,13 +71,21 @@ appropriate commands.
 		"The TRC payload. If provided, it will be used as a reference payload to compare the "+
 			"partially signed TRC payloads against. It can be either DER or PEM encoded.")
 	cmd.Flags().StringVar(&flags.format, "format", "der", "Output format (der|pem)")
+	cmd.Flags().BoolVar(&flags.verify, "verify", false,
+		"Verify the combined TRC before writing it.")
+	cmd.Flags().StringVar(&flags.predecessor, "predecessor", "",
+		"The previous TRC to verify a combined TRC update against. Implies --verify.")
 
 	return cmd
 }
 
 // RunCombine combines the partially signed TRC files and writes them to
-// the out directory, pld is the payload file.
-func RunCombine(files []string, pld, out string, format string) error {
+// the out directory, pld is the payload file. If verify is set, the combined
+// TRC is verified before it is written. TRC updates are verified against the
+// predecessor TRC file.
+func RunCombine(files []string, pld, out string, format string,
+	verify bool, predecessor string) error {
+
 	trcs := make(map[string]cppki.SignedTRC)
 	for _, name := range files {
 		dec, err := DecodeFromFile(name)
@@ -83,6 +101,11 @@ func RunCombine(files []string, pld, out string, format string) error {
 	if err != nil {
 		return err
 	}
+	if verify {
+		if err := verifyCombined(packed, predecessor); err != nil {
+			return err
+		}
+	}
 	if format == "pem" {
 		packed = pem.EncodeToMemory(&pem.Block{
 			Type:  "TRC",
@@ -130,6 +153,35 @@ func CombineSignedPayloads(trcs map[string]cppki.SignedTRC) ([]byte, error) {
 	return packed, nil
 }
 
+// verifyCombined verifies the combined TRC. Base TRCs are checked for proof of
+// possession, TRC updates are verified against the predecessor TRC file.
+func verifyCombined(packed []byte, predecessor string) error {
+	signed, err := cppki.DecodeSignedTRC(packed)
+	if err != nil {
+		return serrors.WrapStr("error decoding combined TRC", err)
+	}
+	if signed.TRC.ID.IsBase() {
+		if predecessor != "" {
+			return serrors.New("predecessor not allowed for base TRC", "id", signed.TRC.ID)
+		}
+		if err := signed.Verify(nil); err != nil {
+			return serrors.WrapStr("verifying proof of possession", err, "id", signed.TRC.ID)
+		}
+		return nil
+	}
+	if predecessor == "" {
+		return serrors.New("predecessor required to verify TRC update", "id", signed.TRC.ID)
+	}
+	prev, err := DecodeFromFile(predecessor)
+	if err != nil {
+		return serrors.WrapStr("loading predecessor TRC", err, "file", predecessor)
+	}
+	if err := signed.Verify(&prev.TRC); err != nil {
+		return serrors.WrapStr("verifying TRC update", err, "id", signed.TRC.ID)
+	}
+	return nil
+}
+
 // combineSignerInfos combines all singer infos. It checks that non-unique
 // signer infos are equal. The returned slice is sorted.
 func combineSignerInfos(trcs map[string]cppki.SignedTRC) ([]protocol.SignerInfo, error) {
@@ -111,7 +111,7 @@ func TestCombine(t *testing.T) {
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := trcs.RunCombine(parts, tc.pld, out, tc.format)
+			err := trcs.RunCombine(parts, tc.pld, out, tc.format, false, "")
 			require.NoError(t, err)
 			written, err := trcs.DecodeFromFile(out)
 			require.NoError(t, err)
@@ -124,6 +124,58 @@ func TestCombine(t *testing.T) {
 	}
 }
 
+func TestCombineVerify(t *testing.T) {
+	all := []string{
+		"./testdata/admin/bern/ISD1-B1-S1.regular.trc",
+		"./testdata/admin/bern/ISD1-B1-S1.sensitive.trc",
+		"./testdata/admin/geneva/ISD1-B1-S1.regular.trc",
+		"./testdata/admin/geneva/ISD1-B1-S1.sensitive.trc",
+		"./testdata/admin/zürich/ISD1-B1-S1.regular.trc",
+		"./testdata/admin/zürich/ISD1-B1-S1.sensitive.trc",
+	}
+
+	testCases := map[string]struct {
+		parts        []string
+		predecessor  string
+		assertErr    assert.ErrorAssertionFunc
+		expectedFile bool
+	}{
+		"all signatures": {
+			parts:        all,
+			assertErr:    assert.NoError,
+			expectedFile: true,
+		},
+		"missing signature": {
+			parts:     all[:5],
+			assertErr: assert.Error,
+		},
+		"predecessor for base TRC": {
+			parts:       all,
+			predecessor: "./testdata/admin/ISD1-B1-S1.trc",
+			assertErr:   assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			out := filepath.Join(dir, "combined.der")
+			err := trcs.RunCombine(tc.parts, "", out, "der", true, tc.predecessor)
+			tc.assertErr(t, err)
+			_, err = os.Stat(out)
+			if !tc.expectedFile {
+				assert.True(t, os.IsNotExist(err), "combined TRC must not be written")
+				return
+			}
+			require.NoError(t, err)
+			written, err := trcs.DecodeFromFile(out)
+			require.NoError(t, err)
+			assert.NoError(t, written.Verify(nil))
+		})
+	}
+}
+
 func TestCombineSignerInfos(t *testing.T) {
 	signed, err := trcs.DecodeFromFile("./testdata/admin/ISD1-B1-S1.trc")
 	require.NoError(t, err)
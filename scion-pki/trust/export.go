@@ -0,0 +1,110 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/app/command"
+	"github.com/scionproto/scion/scion-pki/file"
+)
+
+func newExport(pather command.Pather) *cobra.Command {
+	var flags struct {
+		out   string
+		isds  []uint
+		force bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export [flags] <trust-db-file>",
+		Short: "Export the TRCs and certificate chains in a trust database to files",
+		Example: fmt.Sprintf(`  %[1]s export --out exported cs1-1.trust.db
+  %[1]s export --isd 1 --out exported cs1-1.trust.db`, pather.CommandPath()),
+		Long: `'export' writes the TRCs and certificate chains that are stored in the trust
+database of a control service to the output directory.
+
+TRCs are written in DER encoding to files named ISD<isd>-B<base>-S<serial>.trc.
+Certificate chains are written in PEM encoding to files named
+<ISD-AS>.<fingerprint>.pem, where the fingerprint is the one displayed by the
+'list' command.
+
+Existing files are not overwritten, unless the \--force flag is set.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			isds, err := toISDs(flags.isds)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			if err := file.CheckDirExists(flags.out); err != nil {
+				return serrors.WrapStr("checking that output directory exists", err)
+			}
+			m, err := loadMaterial(context.Background(), args[0], isds)
+			if err != nil {
+				return err
+			}
+			opts := []file.Option{file.WithForce(flags.force)}
+			if err := exportMaterial(flags.out, m, opts...); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Exported %d TRC(s) and %d certificate chain(s) to %q\n",
+				len(m.TRCs), len(m.Chains), flags.out)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&flags.out, "out", "o", "", "Output directory (required)")
+	cmd.MarkFlagRequired("out")
+	addISDFlag(&flags.isds, cmd)
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Force overwriting existing files")
+	return cmd
+}
+
+func exportMaterial(dir string, m material, opts ...file.Option) error {
+	for _, trc := range m.TRCs {
+		name := filepath.Join(dir, fmt.Sprintf("%s.trc", trc.TRC.ID))
+		if err := file.WriteFile(name, trc.Raw, 0644, opts...); err != nil {
+			return serrors.WrapStr("writing TRC", err, "id", trc.TRC.ID)
+		}
+	}
+	for _, chain := range m.Chains {
+		// The ISD-AS has been extracted successfully when loading the chains.
+		ia, _ := cppki.ExtractIA(chain[0].Subject)
+		var buf bytes.Buffer
+		for _, cert := range chain {
+			if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+				return serrors.WrapStr("encoding certificate chain", err, "isd_as", ia)
+			}
+		}
+		name := filepath.Join(dir, fmt.Sprintf("%s.%x.pem",
+			addr.FormatIA(ia, addr.WithDefaultPrefix(), addr.WithFileSeparator()),
+			shortFingerprint(chain)))
+		if err := file.WriteFile(name, buf.Bytes(), 0644, opts...); err != nil {
+			return serrors.WrapStr("writing certificate chain", err, "isd_as", ia)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/app/command"
+)
+
+func newList(pather command.Pather) *cobra.Command {
+	var flags struct {
+		isds []uint
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list [flags] <trust-db-file>",
+		Short: "List the TRCs and certificate chains in a trust database",
+		Example: fmt.Sprintf(`  %[1]s list cs1-1.trust.db
+  %[1]s list --isd 1,2 cs1-1.trust.db`, pather.CommandPath()),
+		Long: `'list' lists the TRCs and certificate chains that are stored in the trust
+database of a control service, together with their validity periods.
+
+The status column indicates whether the trust material is currently valid,
+expired, or not yet valid. The fingerprint identifies a certificate chain. It is
+the prefix of the SHA256 hash computed over the AS and the CA certificate, and
+it is also used in the file names of the chains written by 'export'.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			isds, err := toISDs(flags.isds)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			m, err := loadMaterial(context.Background(), args[0], isds)
+			if err != nil {
+				return err
+			}
+			return writeList(cmd.OutOrStdout(), m, time.Now())
+		},
+	}
+	addISDFlag(&flags.isds, cmd)
+	return cmd
+}
+
+func writeList(out io.Writer, m material, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TRC\tNOT BEFORE\tNOT AFTER\tSTATUS")
+	for _, trc := range m.TRCs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", trc.TRC.ID,
+			formatTime(trc.TRC.Validity.NotBefore),
+			formatTime(trc.TRC.Validity.NotAfter),
+			status(trc.TRC.Validity, now),
+		)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ISD-AS\tSUBJECT KEY ID\tFINGERPRINT\tNOT BEFORE\tNOT AFTER\tSTATUS")
+	for _, chain := range m.Chains {
+		// The ISD-AS has been extracted successfully when loading the chains.
+		ia, _ := cppki.ExtractIA(chain[0].Subject)
+		validity := cppki.Validity{NotBefore: chain[0].NotBefore, NotAfter: chain[0].NotAfter}
+		fmt.Fprintf(w, "%s\t%x\t%x\t%s\t%s\t%s\n", ia,
+			chain[0].SubjectKeyId,
+			shortFingerprint(chain),
+			formatTime(validity.NotBefore),
+			formatTime(validity.NotAfter),
+			status(validity, now),
+		)
+	}
+	return w.Flush()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func status(validity cppki.Validity, now time.Time) string {
+	switch {
+	case validity.Contains(now):
+		return "valid"
+	case now.Before(validity.NotBefore):
+		return "not yet valid"
+	default:
+		return "expired"
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trust implements commands to inspect the trust material stored in a
+// trust database of a control service.
+package trust
+
+import (
+	"context"
+	"crypto/x509"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/app/command"
+	truststorage "github.com/scionproto/scion/private/storage/trust"
+	"github.com/scionproto/scion/private/storage/trust/sqlite"
+	"github.com/scionproto/scion/private/trust"
+)
+
+// Cmd returns the trust command.
+func Cmd(pather command.Pather) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trust",
+		Short: "Inspect the trust database of a control service",
+	}
+	joined := command.Join(pather, cmd)
+	cmd.AddCommand(
+		newList(joined),
+		newExport(joined),
+	)
+	return cmd
+}
+
+// material is the trust material loaded from a trust database.
+type material struct {
+	TRCs   cppki.SignedTRCs
+	Chains [][]*x509.Certificate
+}
+
+// loadMaterial loads the TRCs and certificate chains from the trust database
+// at the given path. If isds is not empty, only the material of the listed
+// ISDs is loaded. The result is sorted by ISD, respectively ISD-AS.
+func loadMaterial(ctx context.Context, path string, isds []addr.ISD) (material, error) {
+	// The sqlite backend creates missing databases. Make sure that we never
+	// create an empty database by accident.
+	if _, err := os.Stat(path); err != nil {
+		return material{}, serrors.WrapStr("checking trust database", err)
+	}
+	db, err := sqlite.New(path)
+	if err != nil {
+		return material{}, serrors.WrapStr("opening trust database", err, "path", path)
+	}
+	defer db.Close()
+
+	trcs, err := db.SignedTRCs(ctx, truststorage.TRCsQuery{ISD: isds})
+	if err != nil {
+		return material{}, serrors.WrapStr("loading TRCs", err)
+	}
+	sort.Slice(trcs, func(i, j int) bool {
+		a, b := trcs[i].TRC.ID, trcs[j].TRC.ID
+		if a.ISD != b.ISD {
+			return a.ISD < b.ISD
+		}
+		if a.Base != b.Base {
+			return a.Base < b.Base
+		}
+		return a.Serial < b.Serial
+	})
+
+	all, err := db.Chains(ctx, trust.ChainQuery{})
+	if err != nil {
+		return material{}, serrors.WrapStr("loading certificate chains", err)
+	}
+	var chains [][]*x509.Certificate
+	for _, chain := range all {
+		ia, err := cppki.ExtractIA(chain[0].Subject)
+		if err != nil {
+			return material{}, serrors.WrapStr("extracting ISD-AS from chain", err)
+		}
+		if len(isds) == 0 || containsISD(isds, ia.ISD()) {
+			chains = append(chains, chain)
+		}
+	}
+	sort.SliceStable(chains, func(i, j int) bool {
+		// The ISD-AS has been extracted successfully before.
+		a, _ := cppki.ExtractIA(chains[i][0].Subject)
+		b, _ := cppki.ExtractIA(chains[j][0].Subject)
+		if a != b {
+			return a < b
+		}
+		return chains[i][0].NotBefore.Before(chains[j][0].NotBefore)
+	})
+	return material{TRCs: trcs, Chains: chains}, nil
+}
+
+func containsISD(isds []addr.ISD, isd addr.ISD) bool {
+	for _, v := range isds {
+		if v == isd {
+			return true
+		}
+	}
+	return false
+}
+
+// shortFingerprint returns a short identifier of the certificate chain. It is
+// the prefix of the chain ID that is also used by the trust database.
+func shortFingerprint(chain []*x509.Certificate) []byte {
+	return truststorage.ChainID(chain)[:8]
+}
+
+func addISDFlag(flag *[]uint, cmd *cobra.Command) {
+	cmd.Flags().UintSliceVar(flag, "isd", nil,
+		"Only include the trust material of the listed ISDs (default all)")
+}
+
+func toISDs(raw []uint) ([]addr.ISD, error) {
+	var isds []addr.ISD
+	for _, isd := range raw {
+		if isd > uint(addr.MaxISD) {
+			return nil, serrors.New("ISD not in range", "max", addr.MaxISD, "isd", isd)
+		}
+		isds = append(isds, addr.ISD(isd))
+	}
+	return isds, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/app/command"
+	"github.com/scionproto/scion/private/storage/trust/sqlite"
+	"github.com/scionproto/scion/scion-pki/testcrypto"
+	"github.com/scionproto/scion/scion-pki/trust"
+)
+
+func TestList(t *testing.T) {
+	dbFile := genTrustDB(t)
+
+	testCases := map[string]struct {
+		args      []string
+		expected  []string
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"all": {
+			args: []string{dbFile},
+			expected: []string{
+				"ISD1-B1-S1",
+				"1-ff00:0:110",
+				"1-ff00:0:111",
+			},
+			assertErr: assert.NoError,
+		},
+		"other ISD": {
+			args:      []string{"--isd", "2", dbFile},
+			assertErr: assert.NoError,
+		},
+		"ISD out of range": {
+			args:      []string{"--isd", "70000", dbFile},
+			assertErr: assert.Error,
+		},
+		"missing database": {
+			args:      []string{filepath.Join(t.TempDir(), "missing.db")},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cmd := trust.Cmd(command.StringPather("scion-pki"))
+			cmd.SetArgs(append([]string{"list"}, tc.args...))
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+			err := cmd.Execute()
+			tc.assertErr(t, err)
+			if err != nil {
+				return
+			}
+			// Header lines are always present.
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			assert.Len(t, lines, 3+len(tc.expected))
+			for _, e := range tc.expected {
+				assert.Contains(t, buf.String(), e)
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	dbFile := genTrustDB(t)
+	out := t.TempDir()
+
+	run := func(args ...string) error {
+		cmd := trust.Cmd(command.StringPather("scion-pki"))
+		cmd.SetArgs(append([]string{"export"}, args...))
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		return cmd.Execute()
+	}
+	require.NoError(t, run("--out", out, dbFile))
+
+	trc := xtest.LoadTRC(t, filepath.Join(out, "ISD1-B1-S1.trc"))
+	assert.Equal(t, cppki.TRCID{ISD: 1, Base: 1, Serial: 1}, trc.TRC.ID)
+	chains, err := filepath.Glob(filepath.Join(out, "*.pem"))
+	require.NoError(t, err)
+	assert.Len(t, chains, 2)
+	for _, f := range chains {
+		chain := xtest.LoadChain(t, f)
+		assert.NoError(t, cppki.ValidateChain(chain))
+	}
+
+	// Existing files are only overwritten with the force flag.
+	assert.Error(t, run("--out", out, dbFile))
+	assert.NoError(t, run("--out", out, "--force", dbFile))
+	assert.Error(t, run("--out", filepath.Join(out, "missing"), dbFile))
+}
+
+// genTrustDB creates a trust database that contains the TRC of ISD 1 and the
+// certificate chains of 1-ff00:0:110 and 1-ff00:0:111.
+func genTrustDB(t *testing.T) string {
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	cmd := testcrypto.Cmd(command.StringPather(""))
+	cmd.SetArgs([]string{
+		"-t", "testdata/golden.topo",
+		"-o", dir,
+		"--isd-dir",
+	})
+	cmd.SetOutput(&buf)
+	require.NoError(t, cmd.Execute(), buf.String())
+
+	dbFile := filepath.Join(dir, "trust.db")
+	db, err := sqlite.New(dbFile)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	trc := xtest.LoadTRC(t, filepath.Join(dir, "trcs/ISD1-B1-S1.trc"))
+	_, err = db.InsertTRC(ctx, trc)
+	require.NoError(t, err)
+	for _, as := range []string{"ISD1-ASff00_0_110", "ISD1-ASff00_0_111"} {
+		chain := xtest.LoadChain(t, filepath.Join(dir, "certs", as+".pem"))
+		_, err := db.InsertChain(ctx, chain)
+		require.NoError(t, err)
+	}
+	return dbFile
+}
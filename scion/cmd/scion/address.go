@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/daemon"
@@ -32,15 +33,16 @@ import (
 )
 
 type addrInfo struct {
-	IA      addr.IA `json:"isd_as"`
-	IP      net.IP  `json:"ip"`
-	Address string  `json:"address"`
+	IA      addr.IA `json:"isd_as" yaml:"isd_as"`
+	IP      net.IP  `json:"ip" yaml:"ip"`
+	Address string  `json:"address" yaml:"address"`
 }
 
 func newAddress(pather CommandPather) *cobra.Command {
 	var envFlags flag.SCIONEnvironment
 	var flags struct {
-		json bool
+		json   bool
+		format string
 	}
 
 	var cmd = &cobra.Command{
@@ -55,6 +57,14 @@ Currently, this returns a sensible but arbitrary local address. In the general
 case, the host could have multiple SCION addresses.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.json && !cmd.Flags().Lookup("format").Changed {
+				flags.format = "json"
+			}
+			switch flags.format {
+			case "human", "json", "yaml":
+			default:
+				return serrors.New("format not supported", "format", flags.format)
+			}
 			if err := envFlags.LoadExternalVars(); err != nil {
 				return err
 			}
@@ -79,24 +89,34 @@ case, the host could have multiple SCION addresses.
 				return err
 			}
 			address := fmt.Sprintf("%s,%s", info.IA, localIP)
-			if !flags.json {
-				_, err := fmt.Fprintln(cmd.OutOrStdout(), address)
-				return err
-			}
-
-			enc := json.NewEncoder(cmd.OutOrStdout())
-			enc.SetIndent("", "  ")
-			return enc.Encode(map[string][]addrInfo{
+			res := map[string][]addrInfo{
 				"addresses": {{
 					IA:      info.IA,
 					IP:      localIP,
 					Address: address,
 				}},
-			})
+			}
+			switch flags.format {
+			case "json":
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(res)
+			case "yaml":
+				return yaml.NewEncoder(cmd.OutOrStdout()).Encode(res)
+			default:
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), address)
+				return err
+			}
 		},
 	}
 	envFlags.Register(cmd.Flags())
 	cmd.Flags().BoolVar(&flags.json, "json", false, "Write the output as machine readable json")
+	cmd.Flags().StringVar(&flags.format, "format", "human",
+		"Specify the output format (human|json|yaml)")
+	err := cmd.Flags().MarkDeprecated("json", "json flag is deprecated, use format flag")
+	if err != nil {
+		panic(err)
+	}
 
 	return cmd
 }
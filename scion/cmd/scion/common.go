@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -23,11 +24,30 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
 	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/resolver"
 )
 
+// resolveTimeout is the timeout for resolving names given on the command line.
+const resolveTimeout = 5 * time.Second
+
+// parseHiddenGroups parses the hidden path group IDs passed on the command
+// line.
+func parseHiddenGroups(raw []string) ([]uint64, error) {
+	groups := make([]uint64, 0, len(raw))
+	for _, r := range raw {
+		id, err := hiddenpath.ParseGroupID(r)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing hidden path group ID", err, "id", r)
+		}
+		groups = append(groups, id.ToUint64())
+	}
+	return groups, nil
+}
+
 // Path defines the base model for the `ping` and `traceroute` result path
 type Path struct {
 	// Hex-string representing the paths fingerprint.
@@ -100,3 +120,19 @@ func (d durationMillis) Millis() float64 {
 func (d durationMillis) MillisRounded() float64 {
 	return math.Round(float64(d)/1000) / 1000
 }
+
+// resolveUDPAddr resolves the remote, which is either a SCION address or a name
+// with SCION address TXT records.
+func resolveUDPAddr(remote string) (*snet.UDPAddr, error) {
+	ctx, cancelF := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancelF()
+	return resolver.Default.ResolveUDPAddr(ctx, remote)
+}
+
+// resolveIA resolves the destination, which is either an ISD-AS or a name with
+// SCION address TXT records.
+func resolveIA(dst string) (addr.IA, error) {
+	ctx, cancelF := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancelF()
+	return resolver.Default.ResolveIA(ctx, dst)
+}
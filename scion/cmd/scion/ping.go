@@ -30,6 +30,8 @@ import (
 	"github.com/scionproto/scion/pkg/daemon"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scmp/ping"
+	"github.com/scionproto/scion/pkg/scmp/probe"
 	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/pkg/snet/addrutil"
 	snetpath "github.com/scionproto/scion/pkg/snet/path"
@@ -39,7 +41,6 @@ import (
 	"github.com/scionproto/scion/private/app/path"
 	"github.com/scionproto/scion/private/path/pathpol"
 	"github.com/scionproto/scion/private/tracing"
-	"github.com/scionproto/scion/scion/ping"
 )
 
 type Result struct {
@@ -80,6 +81,7 @@ func newPing(pather CommandPather) *cobra.Command {
 		noColor     bool
 		refresh     bool
 		healthyOnly bool
+		race        uint
 		sequence    string
 		size        uint
 		pktSize     uint
@@ -87,30 +89,57 @@ func newPing(pather CommandPather) *cobra.Command {
 		tracer      string
 		epic        bool
 		format      string
+		sweep       bool
+
+		hidden       bool
+		hiddenGroups []string
 	}
 
 	var cmd = &cobra.Command{
 		Use:   "ping [flags] <remote>",
 		Short: "Test connectivity to a remote SCION host using SCMP echo packets",
 		Example: fmt.Sprintf(`  %[1]s ping 1-ff00:0:110,10.0.0.1
-  %[1]s ping 1-ff00:0:110,10.0.0.1 -c 5`, pather.CommandPath()),
+  %[1]s ping 1-ff00:0:110,10.0.0.1 -c 5
+  %[1]s ping example.org`, pather.CommandPath()),
 		Long: fmt.Sprintf(`'ping' test connectivity to a remote SCION host using SCMP echo packets.
 
+The remote is either a SCION address or a name whose SCION address is published in
+a DNS TXT record of the form "scion=1-ff00:0:110,192.0.2.1".
+
 When the \--count option is set, ping sends the specified number of SCMP echo packets
 and reports back the statistics.
 
 When the \--healthy-only option is set, ping first determines healthy paths through probing and
 chooses amongst them.
 
+When the \--race option is set, ping probes the given number of candidate paths in
+parallel with a single SCMP echo packet each and uses the path that responds first.
+
+When the \--sweep option is set, ping discovers the largest packet size that
+reaches the remote host on every path matching the sequence. The packet size is
+searched with SCMP echo packets of varying size between the minimum packet size
+and the MTU of the path, or the size set with \--packet-size. SCMP packet too big
+messages are used to speed up the search. For every path, the discovered MTU is
+reported together with the MTU advertised in the path metadata.
+
 If no reply packet is received at all, ping will exit with code 1.
 On other errors, ping will exit with code 2.
 
 %s`, app.SequenceHelp),
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			remote, err := snet.ParseUDPAddr(args[0])
+			remote, err := resolveUDPAddr(args[0])
 			if err != nil {
-				return serrors.WrapStr("parsing remote", err)
+				return serrors.WrapStr("resolving remote", err)
+			}
+			hiddenGroups, err := parseHiddenGroups(flags.hiddenGroups)
+			if err != nil {
+				return err
+			}
+			if flags.sweep && (flags.interactive || flags.healthyOnly || flags.epic ||
+				flags.race > 0) {
+				return serrors.New("--sweep cannot be combined with " +
+					"--interactive, --healthy-only, --race or --epic")
 			}
 			if err := app.SetupLog(flags.logLevel); err != nil {
 				return serrors.WrapStr("setting up logging", err)
@@ -158,19 +187,51 @@ On other errors, ping will exit with code 2.
 			}
 			span.SetTag("src.isd_as", info.IA)
 
+			if flags.sweep {
+				ctx := app.WithSignal(traceCtx, os.Interrupt, syscall.SIGTERM)
+				return runSweep(ctx, sweepConfig{
+					sd:         sd,
+					dispatcher: dispatcher,
+					local:      snet.UDPAddr{IA: info.IA, Host: &net.UDPAddr{IP: localIP}},
+					remote:     remote,
+					flags: daemon.PathReqFlags{
+						Refresh:      flags.refresh,
+						Hidden:       flags.hidden || len(hiddenGroups) > 0,
+						HiddenGroups: hiddenGroups,
+					},
+					sequence: flags.sequence,
+					maxSize:  int(flags.pktSize),
+					SweepConfig: ping.SweepConfig{
+						Timeout: flags.timeout,
+						ErrHandler: func(err error) {
+							fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+						},
+					},
+					format: flags.format,
+					out:    cmd.OutOrStdout(),
+					printf: printf,
+				})
+			}
+
 			opts := []path.Option{
 				path.WithInteractive(flags.interactive),
 				path.WithRefresh(flags.refresh),
+				path.WithHidden(flags.hidden || len(hiddenGroups) > 0, hiddenGroups...),
 				path.WithSequence(flags.sequence),
 				path.WithColorScheme(path.DefaultColorScheme(flags.noColor)),
 				path.WithEPIC(flags.epic),
 			}
+			probeCfg := path.ProbeConfig{
+				LocalIA:    info.IA,
+				LocalIP:    localIP,
+				Dispatcher: dispatcher,
+			}
 			if flags.healthyOnly {
-				opts = append(opts, path.WithProbing(&path.ProbeConfig{
-					LocalIA:    info.IA,
-					LocalIP:    localIP,
-					Dispatcher: dispatcher,
-				}))
+				opts = append(opts, path.WithProbing(&probeCfg))
+			}
+			if flags.race > 0 {
+				opts = append(opts, path.WithRace(int(flags.race),
+					path.EchoProber(probeCfg, remote, flags.timeout)))
 			}
 			path, err := path.Choose(traceCtx, sd, remote.IA, opts...)
 			if err != nil {
@@ -341,7 +402,12 @@ On other errors, ping will exit with code 2.
 	cmd.Flags().DurationVar(&flags.timeout, "timeout", time.Second, "timeout per packet")
 	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
 	cmd.Flags().BoolVar(&flags.healthyOnly, "healthy-only", false, "only use healthy paths")
+	cmd.Flags().UintVar(&flags.race, "race", 0,
+		"probe the given number of paths in parallel and use the first responding one")
 	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "set refresh flag for path request")
+	cmd.Flags().BoolVar(&flags.hidden, "hidden", false, "request hidden paths")
+	cmd.Flags().StringSliceVar(&flags.hiddenGroups, "hidden-groups", nil,
+		"restrict the hidden path lookup to the given group IDs (implies --hidden)")
 	cmd.Flags().DurationVar(&flags.interval, "interval", time.Second, "time between packets")
 	cmd.Flags().Uint16VarP(&flags.count, "count", "c", 0, "total number of packets to send")
 	cmd.Flags().UintVarP(&flags.size, "payload-size", "s", 0,
@@ -361,6 +427,9 @@ SCMP echo header and payload are equal to the MTU of the path. This flag overrid
 	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
 	cmd.Flags().StringVar(&flags.tracer, "tracing.agent", "", "Tracing agent address")
 	cmd.Flags().BoolVar(&flags.epic, "epic", false, "Enable EPIC for path probing.")
+	cmd.Flags().BoolVar(&flags.sweep, "sweep", false,
+		`discover the MTU of every path matching the sequence by sending packets of
+varying size. The 'packet_size' flag sets the largest probed packet size.`)
 	cmd.Flags().StringVar(&flags.format, "format", "human",
 		"Specify the output format (human|json|yaml)")
 	return cmd
@@ -376,43 +445,18 @@ func calcMaxPldSize(local, remote *snet.UDPAddr, mtu int) (int, error) {
 
 // calculateStats computes the Stats from the ping stats and updates
 func calculateStats(s ping.Stats, replies []PingUpdate, run time.Duration) Stats {
-	var loss int
-	if s.Sent != 0 {
-		loss = 100 - s.Received*100/s.Sent
-	}
-
-	stats := Stats{
-		Stats: s,
-		Loss:  loss,
-		Time:  durationMillis(run),
+	rtts := make([]time.Duration, 0, len(replies))
+	for _, r := range replies {
+		rtts = append(rtts, time.Duration(r.RTT))
 	}
-
-	if len(replies) == 0 {
-		return stats
-	}
-	minRTT := replies[0].RTT
-	maxRTT := replies[0].RTT
-	var sum durationMillis
-	for i := 0; i < len(replies); i++ {
-		if replies[i].RTT < minRTT {
-			minRTT = replies[i].RTT
-		}
-		if replies[i].RTT > maxRTT {
-			maxRTT = replies[i].RTT
-		}
-		sum += replies[i].RTT
-	}
-	avgRTT := durationMillis(int(sum) / len(replies))
-
-	// standard deviation
-	var sd float64
-	for i := 0; i < len(replies); i++ {
-		sd += math.Pow(float64(replies[i].RTT-avgRTT), 2)
+	ps := probe.ComputeStats(s.Sent, s.Received, rtts)
+	return Stats{
+		Stats:   s,
+		Loss:    ps.Loss,
+		Time:    durationMillis(run),
+		MinRTT:  durationMillis(ps.MinRTT),
+		AvgRTT:  durationMillis(ps.AvgRTT),
+		MaxRTT:  durationMillis(ps.MaxRTT),
+		MdevRTT: durationMillis(ps.MdevRTT),
 	}
-	mdevRTT := math.Sqrt(sd / float64(len(replies)))
-	stats.MinRTT = minRTT
-	stats.MaxRTT = maxRTT
-	stats.AvgRTT = avgRTT
-	stats.MdevRTT = durationMillis(mdevRTT)
-	return stats
 }
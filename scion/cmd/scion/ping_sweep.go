@@ -0,0 +1,163 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scmp/ping"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/addrutil"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/app"
+	"github.com/scionproto/scion/private/app/path"
+	"github.com/scionproto/scion/private/path/pathpol"
+)
+
+// SweepResult is the result of the MTU sweep of a path.
+type SweepResult struct {
+	Path Path `json:"path" yaml:"path"`
+	// AdvertisedMTU is the MTU of the path according to the path metadata.
+	AdvertisedMTU uint16 `json:"advertised_mtu" yaml:"advertised_mtu"`
+	// MTU is the largest SCION packet size for which a reply was received. It
+	// is zero if no reply was received.
+	MTU int `json:"mtu" yaml:"mtu"`
+	// ReportedMTU is the smallest MTU reported in SCMP packet too big
+	// messages.
+	ReportedMTU int `json:"reported_mtu,omitempty" yaml:"reported_mtu,omitempty"`
+	Probes      int `json:"probes" yaml:"probes"`
+}
+
+// sweepConfig configures the MTU sweep of the ping command.
+type sweepConfig struct {
+	sd         daemon.Connector
+	dispatcher string
+	local      snet.UDPAddr
+	remote     *snet.UDPAddr
+	flags      daemon.PathReqFlags
+	sequence   string
+	// maxSize is the largest probed packet size. If zero, the MTU of the path
+	// is used.
+	maxSize int
+	ping.SweepConfig
+
+	format string
+	out    io.Writer
+	printf func(format string, ctx ...interface{})
+}
+
+// runSweep discovers the MTU of every path to the remote that matches the
+// sequence.
+func runSweep(ctx context.Context, cfg sweepConfig) error {
+	paths, err := cfg.sd.Paths(ctx, cfg.remote.IA, 0, cfg.flags)
+	if err != nil {
+		return serrors.WrapStr("retrieving paths", err)
+	}
+	if paths, err = path.Filter(cfg.sequence, paths); err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return serrors.New("no path available")
+	}
+	path.Sort(paths)
+	cfg.printf("MTU sweep to %s over %d paths\n", cfg.remote, len(paths))
+
+	results := make([]SweepResult, 0, len(paths))
+	var replied bool
+	for i, p := range paths {
+		remote := cfg.remote.Copy()
+		remote.Path = p.Dataplane()
+		remote.NextHop = p.UnderlayNextHop()
+		localIP := cfg.local.Host.IP
+		if localIP == nil {
+			target := remote.Host.IP
+			if remote.NextHop != nil {
+				target = remote.NextHop.IP
+			}
+			if localIP, err = addrutil.ResolveLocal(target); err != nil {
+				return serrors.WrapStr("resolving local address", err)
+			}
+		}
+		seq, err := pathpol.GetSequence(p)
+		if err != nil {
+			return serrors.New("get sequence from used path")
+		}
+		res := SweepResult{
+			Path: Path{
+				Fingerprint: snet.Fingerprint(p).String(),
+				Hops:        getHops(p),
+				Sequence:    seq,
+				LocalIP:     localIP,
+				NextHop:     p.UnderlayNextHop().String(),
+			},
+			AdvertisedMTU: p.Metadata().MTU,
+		}
+
+		sweepCfg := cfg.SweepConfig
+		sweepCfg.Dispatcher = reliable.NewDispatcher(cfg.dispatcher)
+		sweepCfg.Local = &snet.UDPAddr{IA: cfg.local.IA, Host: &net.UDPAddr{IP: localIP}}
+		sweepCfg.Remote = remote
+		sweepCfg.MaxSize = cfg.maxSize
+		if sweepCfg.MaxSize == 0 {
+			sweepCfg.MaxSize = int(res.AdvertisedMTU)
+		}
+		r, err := ping.Sweep(ctx, sweepCfg)
+		if err != nil {
+			return serrors.WrapStr("sweeping path", err, "fingerprint", res.Path.Fingerprint)
+		}
+		res.MTU, res.ReportedMTU, res.Probes = r.MTU, r.ReportedMTU, r.Probes
+		results = append(results, res)
+		replied = replied || res.MTU != 0
+		cfg.printf("[%2d] %s\n     %s\n", i, p, formatSweepResult(res))
+	}
+
+	switch cfg.format {
+	case "json":
+		enc := json.NewEncoder(cfg.out)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(results)
+	case "yaml":
+		return yaml.NewEncoder(cfg.out).Encode(results)
+	}
+	if !replied {
+		return app.WithExitCode(serrors.New("no reply packet received"), 1)
+	}
+	return nil
+}
+
+func formatSweepResult(res SweepResult) string {
+	if res.MTU == 0 {
+		return fmt.Sprintf("no reply, advertised_mtu=%d probes=%d",
+			res.AdvertisedMTU, res.Probes)
+	}
+	s := fmt.Sprintf("mtu=%d advertised_mtu=%d", res.MTU, res.AdvertisedMTU)
+	if res.ReportedMTU != 0 {
+		s += fmt.Sprintf(" reported_mtu=%d", res.ReportedMTU)
+	}
+	s += fmt.Sprintf(" probes=%d", res.Probes)
+	if res.MTU < int(res.AdvertisedMTU) {
+		s += " (below advertised MTU)"
+	}
+	return s
+}
@@ -25,7 +25,6 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 
-	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/private/app"
@@ -45,6 +44,9 @@ func newShowpaths(pather CommandPather) *cobra.Command {
 		noColor  bool
 		tracer   string
 		format   string
+		policy   string
+
+		hiddenGroups []string
 	}
 
 	var cmd = &cobra.Command{
@@ -57,23 +59,41 @@ func newShowpaths(pather CommandPather) *cobra.Command {
   %[1]s showpaths 1-ff00:0:111 --sequence="0-0#2 0*" # outgoing IfID=2
   %[1]s showpaths 1-ff00:0:111 --sequence="0* 0-0#41" # incoming IfID=41 at dstIA
   %[1]s showpaths 1-ff00:0:111 --sequence="0* 1-ff00:0:112 0*" # 1-ff00:0:112 on the path
-  %[1]s showpaths 1-ff00:0:110 --no-probe`, pather.CommandPath()),
+  %[1]s showpaths example.org
+  %[1]s showpaths 1-ff00:0:110 --no-probe
+  %[1]s showpaths 1-ff00:0:110 --policy policy.json --refresh
+  %[1]s showpaths 1-ff00:0:110 --hidden-groups ff00:0:110-69b5`, pather.CommandPath()),
 		Long: fmt.Sprintf(`'showpaths' lists available paths between the local and the specified
 SCION ASe a.
 
+The destination is either an ISD-AS or a name whose SCION address is published in
+a DNS TXT record of the form "scion=1-ff00:0:110,192.0.2.1".
+
 By default, the paths are probed. Paths served from the SCION Daemon's might not
 forward traffic successfully (e.g. if a network link went down, or there is a black
 hole on the path). To disable path probing, set the appropriate flag.
 
+The paths can be filtered with a path policy file in JSON format, containing
+an ACL and/or a sequence, e.g.:
+
+  {
+    "acl": ["- 1-ff00:0:133#0", "+"],
+    "sequence": "1-ff00:0:110 0* 1-ff00:0:111"
+  }
+
+Hidden paths are requested with the \--hidden option. By default, all hidden path
+groups configured in the SCION Daemon in which the destination is a writer are
+queried. The \--hidden-groups option restricts the lookup to the given groups.
+
 If no alive path is discovered, json output is not enabled, and probing is not
 disabled, showpaths will exit with the code 1.
 On other errors, showpaths will exit with code 2.
 
 %s`, app.SequenceHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			dst, err := addr.ParseIA(args[0])
+			dst, err := resolveIA(args[0])
 			if err != nil {
-				return serrors.WrapStr("invalid destination ISD-AS", err)
+				return serrors.WrapStr("resolving destination", err)
 			}
 			if err := app.SetupLog(flags.logLevel); err != nil {
 				return serrors.WrapStr("setting up logging", err)
@@ -92,6 +112,18 @@ On other errors, showpaths will exit with code 2.
 				return serrors.WrapStr("get formatting", err)
 			}
 
+			if len(flags.hiddenGroups) > 0 {
+				if flags.cfg.HiddenGroups, err = parseHiddenGroups(flags.hiddenGroups); err != nil {
+					return err
+				}
+				flags.cfg.Hidden = true
+			}
+			if flags.policy != "" {
+				if flags.cfg.Policy, err = showpaths.LoadPolicy(flags.policy); err != nil {
+					return err
+				}
+			}
+
 			cmd.SilenceUsage = true
 
 			if err := envFlags.LoadExternalVars(); err != nil {
@@ -156,6 +188,12 @@ On other errors, showpaths will exit with code 2.
 		"Show extended path meta data information")
 	cmd.Flags().BoolVarP(&flags.cfg.Refresh, "refresh", "r", false,
 		"Set refresh flag for SCION Daemon path request")
+	cmd.Flags().BoolVar(&flags.cfg.Hidden, "hidden", false,
+		"Request hidden paths from the SCION Daemon")
+	cmd.Flags().StringSliceVar(&flags.hiddenGroups, "hidden-groups", nil,
+		"Restrict the hidden path lookup to the given group IDs (implies --hidden)")
+	cmd.Flags().StringVar(&flags.policy, "policy", "",
+		"Path policy file (JSON) used to filter the paths")
 	cmd.Flags().BoolVar(&flags.cfg.NoProbe, "no-probe", false,
 		"Do not probe the paths and print the health status")
 	cmd.Flags().BoolVarP(&flags.json, "json", "j", false,
@@ -31,6 +31,7 @@ import (
 	"github.com/scionproto/scion/pkg/daemon"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scmp/probe"
 	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/pkg/snet/addrutil"
 	"github.com/scionproto/scion/pkg/sock/reliable"
@@ -40,12 +41,19 @@ import (
 	"github.com/scionproto/scion/private/path/pathpol"
 	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/private/tracing"
-	"github.com/scionproto/scion/scion/traceroute"
 )
 
 type ResultTraceroute struct {
-	Path Path      `json:"path" yaml:"path"`
-	Hops []HopInfo `json:"hops" yaml:"hops"`
+	Path       Path            `json:"path" yaml:"path"`
+	Hops       []HopInfo       `json:"hops" yaml:"hops"`
+	Statistics TracerouteStats `json:"statistics" yaml:"statistics"`
+}
+
+// TracerouteStats contains the number of sent and received probes.
+type TracerouteStats struct {
+	Sent     uint `json:"sent" yaml:"sent"`
+	Received uint `json:"received" yaml:"received"`
+	Loss     int  `json:"packet_loss" yaml:"packet_loss"`
 }
 
 type HopInfo struct {
@@ -53,7 +61,33 @@ type HopInfo struct {
 	// IP address of the router responding to the traceroute request.
 	IP             string           `json:"ip" yaml:"ip"`
 	IA             addr.IA          `json:"isd_as" yaml:"isd_as"`
+	Egress         bool             `json:"egress" yaml:"egress"`
 	RoundTripTimes []durationMillis `json:"round_trip_times" yaml:"round_trip_times"`
+	Statistics     HopStats         `json:"statistics" yaml:"statistics"`
+	// Delta is the difference between the minimum RTT of this hop and the
+	// minimum RTT of the previous hop. It approximates the latency of the
+	// segment between the two probed interfaces, either within an AS or on the
+	// inter-AS link. It is omitted if either hop did not respond.
+	Delta *LatencyDelta `json:"latency_delta,omitempty" yaml:"latency_delta,omitempty"`
+}
+
+// HopStats contains the probe statistics of a single hop.
+type HopStats struct {
+	Sent     int            `json:"sent" yaml:"sent"`
+	Received int            `json:"received" yaml:"received"`
+	Loss     int            `json:"packet_loss" yaml:"packet_loss"`
+	MinRTT   durationMillis `json:"min_rtt" yaml:"min_rtt"`
+	AvgRTT   durationMillis `json:"avg_rtt" yaml:"avg_rtt"`
+	MaxRTT   durationMillis `json:"max_rtt" yaml:"max_rtt"`
+	MdevRTT  durationMillis `json:"mdev_rtt" yaml:"mdev_rtt"`
+}
+
+// LatencyDelta is the RTT difference between two consecutive hops.
+type LatencyDelta struct {
+	// InterAS indicates whether the two hops are in different ASes, i.e., the
+	// delta is the latency of an inter-AS link.
+	InterAS bool           `json:"inter_as" yaml:"inter_as"`
+	RTT     durationMillis `json:"rtt" yaml:"rtt"`
 }
 
 func newTraceroute(pather CommandPather) *cobra.Command {
@@ -69,6 +103,8 @@ func newTraceroute(pather CommandPather) *cobra.Command {
 		tracer      string
 		epic        bool
 		format      string
+		count       int
+		interval    time.Duration
 	}
 
 	var cmd = &cobra.Command{
@@ -79,15 +115,29 @@ func newTraceroute(pather CommandPather) *cobra.Command {
 		Long: fmt.Sprintf(`'traceroute' traces the SCION path to a remote AS using
 SCMP traceroute packets.
 
+The remote is either a SCION address or a name whose SCION address is published in
+a DNS TXT record of the form "scion=1-ff00:0:110,192.0.2.1".
+
+Both the ingress and the egress interface of every AS on the path are probed.
+For every hop, the RTT statistics and the RTT difference to the previous hop
+are reported. The difference approximates the latency within an AS (between
+its ingress and egress interface) or of the inter-AS link.
+
 If any packet is dropped, traceroute will exit with code 1.
 On other errors, traceroute will exit with code 2.
 %s`, app.SequenceHelp),
 
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			remote, err := snet.ParseUDPAddr(args[0])
+			remote, err := resolveUDPAddr(args[0])
 			if err != nil {
-				return serrors.WrapStr("parsing remote", err)
+				return serrors.WrapStr("resolving remote", err)
+			}
+			if flags.count <= 0 {
+				return serrors.New("count must be positive", "count", flags.count)
+			}
+			if flags.interval < 0 {
+				return serrors.New("interval must not be negative", "interval", flags.interval)
 			}
 			if err := app.SetupLog(flags.logLevel); err != nil {
 				return serrors.WrapStr("setting up logging", err)
@@ -176,42 +226,50 @@ On other errors, traceroute will exit with code 2.
 			}
 
 			span.SetTag("src.host", localIP)
-			local := &snet.UDPAddr{
-				IA:   info.IA,
-				Host: &net.UDPAddr{IP: localIP},
-			}
 			ctx = app.WithSignal(traceCtx, os.Interrupt, syscall.SIGTERM)
-			var stats traceroute.Stats
-			var updates []traceroute.Update
-			cfg := traceroute.Config{
-				Dispatcher:   reliable.NewDispatcher(dispatcher),
-				Remote:       remote,
-				MTU:          path.Metadata().MTU,
-				Local:        local,
-				PathEntry:    path,
+			tr, err := probe.TracePath(ctx, probe.TraceConfig{
+				Target: probe.Target{
+					Dispatcher: reliable.NewDispatcher(dispatcher),
+					LocalIA:    info.IA,
+					LocalIP:    localIP,
+					Remote:     remote,
+					Path:       path,
+				},
+				ProbesPerHop: flags.count,
+				Interval:     flags.interval,
 				Timeout:      flags.timeout,
-				ProbesPerHop: 3,
-				ErrHandler:   func(err error) { fmt.Fprintf(os.Stderr, "ERROR: %s\n", err) },
-				UpdateHandler: func(u traceroute.Update) {
-					updates = append(updates, u)
-					printf("%d %s %s\n", u.Index, fmtRemote(u.Remote, u.Interface),
-						fmtRTTs(u.RTTs, flags.timeout))
+				EPIC:         flags.epic,
+				OnError:      func(err error) { fmt.Fprintf(os.Stderr, "ERROR: %s\n", err) },
+				OnHop: func(h probe.Hop) {
+					printf("%d %s %s\n", h.Index, fmtRemote(h.Remote, h.Interface),
+						fmtRTTs(h.RTTs, flags.timeout))
 				},
-				EPIC: flags.epic,
-			}
-			stats, err = traceroute.Run(ctx, cfg)
+			})
 			if err != nil {
 				return err
 			}
-			res.Hops = make([]HopInfo, 0, len(updates))
+			res.Statistics = TracerouteStats{Sent: uint(tr.Sent), Received: uint(tr.Received)}
+			if tr.Sent != 0 {
+				res.Statistics.Loss = 100 - tr.Received*100/tr.Sent
+			}
+			res.Hops = make([]HopInfo, 0, len(tr.Hops))
 			hops := getHops(path)
-			for i, update := range updates {
-				res.Hops = append(res.Hops, getHopInfo(update, hops[i]))
+			for i, hop := range tr.Hops {
+				res.Hops = append(res.Hops, getHopInfo(hop, hops[i], flags.timeout))
+			}
+			for i := 1; i < len(res.Hops); i++ {
+				res.Hops[i].Delta = latencyDelta(res.Hops[i-1], res.Hops[i])
 			}
 
 			switch flags.format {
 			case "human":
-				if stats.Sent != stats.Recv {
+				printf("\n--- traceroute statistics ---\n")
+				printf("%d packets transmitted, %d received, %d%% packet loss\n",
+					res.Statistics.Sent, res.Statistics.Received, res.Statistics.Loss)
+				for i, hop := range res.Hops {
+					printf("%d %s\n", tr.Hops[i].Index, fmtHopStats(hop))
+				}
+				if tr.Sent != tr.Received {
 					return app.WithExitCode(serrors.New("packets were lost"), 1)
 				}
 			case "json":
@@ -232,6 +290,9 @@ On other errors, traceroute will exit with code 2.
 	cmd.Flags().BoolVarP(&flags.interactive, "interactive", "i", false, "interactive mode")
 	cmd.Flags().BoolVar(&flags.noColor, "no-color", false, "disable colored output")
 	cmd.Flags().DurationVar(&flags.timeout, "timeout", time.Second, "timeout per packet")
+	cmd.Flags().IntVarP(&flags.count, "count", "c", 3, "number of probes sent to every interface")
+	cmd.Flags().DurationVar(&flags.interval, "interval", 0,
+		"minimum time between two probes to the same interface")
 	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
 	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
 	cmd.Flags().StringVar(&flags.tracer, "tracing.agent", "", "Tracing agent address")
@@ -260,9 +321,36 @@ func fmtRemote(remote snet.SCIONAddress, intf uint64) string {
 	return fmt.Sprintf("%s IfID=%d", remote, intf)
 }
 
-func getHopInfo(u traceroute.Update, hop Hop) HopInfo {
+func fmtHopStats(hop HopInfo) string {
+	s := fmt.Sprintf("%s IfID=%d %d/%d received", hop.IA, hop.InterfaceID,
+		hop.Statistics.Received, hop.Statistics.Sent)
+	if hop.Statistics.Received == 0 {
+		return s
+	}
+	s += fmt.Sprintf(", rtt min/avg/max/mdev = %.3f/%.3f/%.3f/%.3f ms",
+		hop.Statistics.MinRTT.Millis(),
+		hop.Statistics.AvgRTT.Millis(),
+		hop.Statistics.MaxRTT.Millis(),
+		hop.Statistics.MdevRTT.Millis(),
+	)
+	if hop.Delta != nil {
+		kind := "intra-AS"
+		if hop.Delta.InterAS {
+			kind = "inter-AS"
+		}
+		s += fmt.Sprintf(", %s delta = %+.3f ms", kind, hop.Delta.RTT.Millis())
+	}
+	return s
+}
+
+func getHopInfo(u probe.Hop, hop Hop, timeout time.Duration) HopInfo {
 	if u.Remote == (snet.SCIONAddress{}) {
-		return HopInfo{IA: hop.IA, InterfaceID: uint16(hop.ID)}
+		return HopInfo{
+			IA:          hop.IA,
+			InterfaceID: uint16(hop.ID),
+			Egress:      u.Egress,
+			Statistics:  getHopStats(u.RTTs, timeout),
+		}
 	}
 	RTTs := make([]durationMillis, 0, len(u.RTTs))
 	for _, rtt := range u.RTTs {
@@ -272,6 +360,42 @@ func getHopInfo(u traceroute.Update, hop Hop) HopInfo {
 		InterfaceID:    uint16(u.Interface),
 		IP:             u.Remote.Host.IP().String(),
 		IA:             u.Remote.IA,
+		Egress:         u.Egress,
 		RoundTripTimes: RTTs,
+		Statistics:     getHopStats(u.RTTs, timeout),
+	}
+}
+
+// getHopStats computes the statistics of a hop. RTTs exceeding the timeout are
+// counted as lost probes.
+func getHopStats(rtts []time.Duration, timeout time.Duration) HopStats {
+	received := make([]time.Duration, 0, len(rtts))
+	for _, rtt := range rtts {
+		if rtt <= timeout {
+			received = append(received, rtt)
+		}
+	}
+	ps := probe.ComputeStats(len(rtts), len(received), received)
+	return HopStats{
+		Sent:     ps.Sent,
+		Received: ps.Received,
+		Loss:     ps.Loss,
+		MinRTT:   durationMillis(ps.MinRTT),
+		AvgRTT:   durationMillis(ps.AvgRTT),
+		MaxRTT:   durationMillis(ps.MaxRTT),
+		MdevRTT:  durationMillis(ps.MdevRTT),
+	}
+}
+
+// latencyDelta computes the RTT difference between two consecutive hops based
+// on the minimum RTT, which is the least affected by queuing. It returns nil if
+// either hop did not respond.
+func latencyDelta(prev, cur HopInfo) *LatencyDelta {
+	if prev.Statistics.Received == 0 || cur.Statistics.Received == 0 {
+		return nil
+	}
+	return &LatencyDelta{
+		InterAS: !prev.IA.Equal(cur.IA),
+		RTT:     cur.Statistics.MinRTT - prev.Statistics.MinRTT,
 	}
 }
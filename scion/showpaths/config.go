@@ -15,7 +15,12 @@
 package showpaths
 
 import (
+	"encoding/json"
 	"net"
+	"os"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/path/pathpol"
 )
 
 // DefaultMaxPaths is the maximum number of paths that are displayed by default.
@@ -33,6 +38,11 @@ type Config struct {
 	MaxPaths int
 	// Refresh configures whether the daemon is queried with the refresh flag.
 	Refresh bool
+	// Hidden configures whether the daemon is queried for hidden paths.
+	Hidden bool
+	// HiddenGroups restricts the hidden path lookup to the hidden path groups
+	// with the given IDs. It is only used if Hidden is set.
+	HiddenGroups []uint64
 	// NoProbe configures whether the path status is probed or not.
 	NoProbe bool
 	// Sequence is a string of space separated Hop Predicates that is used for
@@ -44,4 +54,25 @@ type Config struct {
 	// Epic filters paths for which EPIC is not available, and when probing, the
 	// EPIC path type header is used.
 	Epic bool
+	// Policy is an optional path policy that is used for filtering.
+	Policy *pathpol.Policy
+}
+
+// LoadPolicy loads a path policy from a JSON file, e.g.
+//
+//	{
+//	  "acl": ["- 1-ff00:0:133#0", "+"],
+//	  "sequence": "1-ff00:0:110 0* 1-ff00:0:111"
+//	}
+func LoadPolicy(file string) (*pathpol.Policy, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("reading policy file", err, "file", file)
+	}
+	var policy pathpol.Policy
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		return nil, serrors.WrapStr("parsing policy file", err, "file", file)
+	}
+	policy.Name = file
+	return &policy, nil
 }
@@ -59,8 +59,8 @@ type Path struct {
 
 // Hop represents an hop on the path.
 type Hop struct {
-	IfID common.IFIDType `json:"ifid"`
-	IA   addr.IA         `json:"isd_as"`
+	IfID common.IFIDType `json:"ifid" yaml:"ifid"`
+	IA   addr.IA         `json:"isd_as" yaml:"isd_as"`
 }
 
 // Human writes human readable output to the writer.
@@ -324,8 +324,11 @@ func Run(ctx context.Context, dst addr.IA, cfg Config) (*Result, error) {
 	// TODO(lukedirtwalker): Replace this with snet.Router once we have the
 	// possibility to have the same functionality, i.e. refresh, fetch all paths.
 	// https://github.com/scionproto/scion/issues/3348
-	allPaths, err := sdConn.Paths(ctx, dst, 0,
-		daemon.PathReqFlags{Refresh: cfg.Refresh})
+	allPaths, err := sdConn.Paths(ctx, dst, 0, daemon.PathReqFlags{
+		Refresh:      cfg.Refresh,
+		Hidden:       cfg.Hidden,
+		HiddenGroups: cfg.HiddenGroups,
+	})
 	if err != nil {
 		return nil, serrors.WrapStr("retrieving paths from the SCION Daemon", err)
 	}
@@ -333,6 +336,9 @@ func Run(ctx context.Context, dst addr.IA, cfg Config) (*Result, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cfg.Policy != nil {
+		paths = cfg.Policy.Filter(paths)
+	}
 	if cfg.MaxPaths != 0 && len(paths) > cfg.MaxPaths {
 		paths = paths[:cfg.MaxPaths]
 	}
@@ -0,0 +1,138 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	_ "net/http/pprof"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/squic"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/app"
+	"github.com/scionproto/scion/private/app/launcher"
+	"github.com/scionproto/scion/private/service"
+	"github.com/scionproto/scion/webgateway"
+	"github.com/scionproto/scion/webgateway/config"
+)
+
+var globalCfg config.Config
+
+func main() {
+	application := launcher.Application{
+		TOMLConfig: &globalCfg,
+		ShortName:  "SCION Web Gateway",
+		Main:       realMain,
+	}
+	application.Run()
+}
+
+func realMain(ctx context.Context) error {
+	cfg := globalCfg.WebGateway
+	listen, err := cfg.ListenAddr()
+	if err != nil {
+		return err
+	}
+	backends, err := cfg.BackendURLs()
+	if err != nil {
+		return err
+	}
+	policies, err := cfg.LoadClientPolicies()
+	if err != nil {
+		return serrors.WrapStr("loading client policies", err)
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertificate, cfg.TLSKey)
+	if err != nil {
+		return serrors.WrapStr("loading TLS certificate", err)
+	}
+
+	connectCtx, cancelF := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelF()
+	sd := &daemon.ReconnectingConnector{Factory: daemon.NewService(cfg.Daemon)}
+	defer sd.Close()
+	localIA, err := sd.LocalIA(connectCtx)
+	if err != nil {
+		return serrors.WrapStr("querying local ISD-AS", err)
+	}
+	network := &snet.SCIONNetwork{
+		LocalIA: localIA,
+		Dispatcher: &snet.DefaultPacketDispatcherService{
+			Dispatcher: reliable.NewDispatcher(cfg.Dispatcher),
+			SCMPHandler: snet.DefaultSCMPHandler{
+				RevocationHandler: daemon.RevHandler{Connector: sd},
+			},
+		},
+	}
+	conn, err := squic.ListenPacket(ctx, network, listen)
+	if err != nil {
+		return err
+	}
+	conn = webgateway.NewPacketConn(conn, &webgateway.PathSelector{
+		Router:          &snet.BaseRouter{Querier: daemon.Querier{Connector: sd, IA: localIA}},
+		Policies:        policies,
+		RefreshInterval: cfg.PathRefreshInterval.Duration,
+	})
+
+	g, errCtx := errgroup.WithContext(ctx)
+	var cleanup app.Cleanup
+	server := &http3.Server{
+		Handler:   webgateway.NewProxy(backends),
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+	}
+	log.Info("Serving HTTP/3 over SCION", "addr", snet.UDPAddr{IA: localIA, Host: listen})
+	g.Go(func() error {
+		defer log.HandlePanic()
+		err := server.Serve(conn)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return serrors.WrapStr("serving HTTP/3", err)
+		}
+		return nil
+	})
+	cleanup.Add(server.Close)
+	cleanup.Add(conn.Close)
+
+	// Start HTTP endpoints.
+	statusPages := service.StatusPages{
+		"info":      service.NewInfoStatusPage(),
+		"config":    service.NewConfigStatusPage(globalCfg),
+		"log/level": service.NewLogLevelStatusPage(),
+	}
+	if err := statusPages.Register(http.DefaultServeMux, globalCfg.General.ID); err != nil {
+		return serrors.WrapStr("registering status pages", err)
+	}
+
+	g.Go(func() error {
+		defer log.HandlePanic()
+		return globalCfg.Metrics.ServePrometheus(errCtx)
+	})
+
+	g.Go(func() error {
+		defer log.HandlePanic()
+		<-errCtx.Done()
+		return cleanup.Do()
+	})
+
+	return g.Wait()
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config contains the configuration of the SCION web gateway.
+package config
+
+import (
+	"io"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/config"
+	"github.com/scionproto/scion/private/env"
+	"github.com/scionproto/scion/private/path/pathpol"
+)
+
+// DefaultPathRefreshInterval is the default time after which the path to a
+// client ISD-AS is looked up again.
+const DefaultPathRefreshInterval = time.Minute
+
+var _ config.Config = (*Config)(nil)
+
+type Config struct {
+	General    env.General      `toml:"general,omitempty"`
+	Logging    log.Config       `toml:"log,omitempty"`
+	Metrics    env.Metrics      `toml:"metrics,omitempty"`
+	WebGateway WebGatewayConfig `toml:"web_gateway,omitempty"`
+}
+
+func (cfg *Config) InitDefaults() {
+	config.InitAll(
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.WebGateway,
+	)
+}
+
+func (cfg *Config) Validate() error {
+	return config.ValidateAll(
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.WebGateway,
+	)
+}
+
+func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteSample(dst, path, config.CtxMap{config.ID: idSample},
+		&cfg.General,
+		&cfg.Logging,
+		&cfg.Metrics,
+		&cfg.WebGateway,
+	)
+}
+
+var _ config.Config = (*WebGatewayConfig)(nil)
+
+// WebGatewayConfig configures on which address HTTP/3 over SCION is served,
+// to which backends the requests are forwarded and on which paths the
+// responses are sent.
+type WebGatewayConfig struct {
+	// Daemon is the address of the SCION Daemon that is queried for paths.
+	Daemon string `toml:"daemon,omitempty"`
+	// Dispatcher is the path to the dispatcher socket.
+	Dispatcher string `toml:"dispatcher,omitempty"`
+	// Listen is the IP address and port in the local AS on which HTTP/3 over
+	// SCION is served, e.g., 10.0.0.1:443.
+	Listen string `toml:"listen,omitempty"`
+	// TLSCertificate is the file containing the PEM encoded TLS certificate
+	// chain.
+	TLSCertificate string `toml:"tls_certificate,omitempty"`
+	// TLSKey is the file containing the PEM encoded TLS private key.
+	TLSKey string `toml:"tls_key,omitempty"`
+	// Backends are the URLs of the HTTP backends, e.g.,
+	// http://192.168.0.10:8080. The requests are forwarded in round-robin
+	// order.
+	Backends []string `toml:"backends,omitempty"`
+	// PathPolicies is the JSON file containing the named path policies, in
+	// the format of the SCION Daemon path policies.
+	PathPolicies string `toml:"path_policies,omitempty"`
+	// ClientPolicies maps client ISD-ASes to the name of the path policy
+	// that is applied to the paths of the responses. The ISD-AS may contain
+	// wildcards, e.g., 1-0 or 0-0.
+	ClientPolicies map[string]string `toml:"client_policies,omitempty"`
+	// PathRefreshInterval is the time after which the path to a client
+	// ISD-AS is looked up again.
+	PathRefreshInterval util.DurWrap `toml:"path_refresh_interval,omitempty"`
+}
+
+func (cfg *WebGatewayConfig) InitDefaults() {
+	if cfg.Daemon == "" {
+		cfg.Daemon = daemon.DefaultAPIAddress
+	}
+	if cfg.Dispatcher == "" {
+		cfg.Dispatcher = reliable.DefaultDispPath
+	}
+	if cfg.PathRefreshInterval.Duration == 0 {
+		cfg.PathRefreshInterval.Duration = DefaultPathRefreshInterval
+	}
+}
+
+func (cfg *WebGatewayConfig) Validate() error {
+	if _, err := cfg.ListenAddr(); err != nil {
+		return err
+	}
+	if cfg.TLSCertificate == "" || cfg.TLSKey == "" {
+		return serrors.New("tls_certificate and tls_key must be set")
+	}
+	if _, err := cfg.BackendURLs(); err != nil {
+		return err
+	}
+	if len(cfg.ClientPolicies) > 0 && cfg.PathPolicies == "" {
+		return serrors.New("client_policies require path_policies to be set")
+	}
+	for raw := range cfg.ClientPolicies {
+		if _, err := addr.ParseIA(raw); err != nil {
+			return serrors.WrapStr("parsing client ISD-AS", err, "isd_as", raw)
+		}
+	}
+	if cfg.PathRefreshInterval.Duration < 0 {
+		return serrors.New("PathRefreshInterval must not be negative")
+	}
+	return nil
+}
+
+// ListenAddr parses the listen address.
+func (cfg *WebGatewayConfig) ListenAddr() (*net.UDPAddr, error) {
+	a, err := net.ResolveUDPAddr("udp", cfg.Listen)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing listen address", err, "listen", cfg.Listen)
+	}
+	if a.IP == nil || a.IP.IsUnspecified() {
+		return nil, serrors.New("listen IP must be specified", "listen", cfg.Listen)
+	}
+	return a, nil
+}
+
+// BackendURLs parses the backend URLs.
+func (cfg *WebGatewayConfig) BackendURLs() ([]*url.URL, error) {
+	if len(cfg.Backends) == 0 {
+		return nil, serrors.New("no backend configured")
+	}
+	backends := make([]*url.URL, 0, len(cfg.Backends))
+	for _, raw := range cfg.Backends {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing backend", err, "backend", raw)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, serrors.New("backend must be an http or https URL", "backend", raw)
+		}
+		backends = append(backends, u)
+	}
+	return backends, nil
+}
+
+// LoadClientPolicies loads the path policies and returns them by client
+// ISD-AS.
+func (cfg *WebGatewayConfig) LoadClientPolicies() (map[addr.IA]*pathpol.Policy, error) {
+	if len(cfg.ClientPolicies) == 0 {
+		return nil, nil
+	}
+	policies, err := pathpol.LoadPolicyMap(cfg.PathPolicies)
+	if err != nil {
+		return nil, err
+	}
+	clients := make(map[addr.IA]*pathpol.Policy, len(cfg.ClientPolicies))
+	for raw, name := range cfg.ClientPolicies {
+		ia, err := addr.ParseIA(raw)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing client ISD-AS", err, "isd_as", raw)
+		}
+		policy, ok := policies[name]
+		if !ok {
+			return nil, serrors.New("unknown path policy", "isd_as", raw, "policy", name)
+		}
+		clients[ia] = policy
+	}
+	return clients, nil
+}
+
+func (cfg *WebGatewayConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, webGatewaySample)
+}
+
+func (cfg *WebGatewayConfig) ConfigName() string {
+	return "web_gateway"
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log/logtest"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/sock/reliable"
+	"github.com/scionproto/scion/private/config/configtest"
+	"github.com/scionproto/scion/private/env/envtest"
+)
+
+func TestConfigSample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg Config
+	cfg.Sample(&sample, nil, nil)
+
+	InitTestConfig(&cfg)
+	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
+	assert.NoError(t, err)
+	CheckTestConfig(t, &cfg, idSample)
+	configtest.CheckSampleCoverage(t, &cfg)
+}
+
+func InitTestConfig(cfg *Config) {
+	envtest.InitTest(&cfg.General, &cfg.Metrics, nil, nil)
+	logtest.InitTestLogging(&cfg.Logging)
+	InitTestWebGatewayConfig(&cfg.WebGateway)
+}
+
+func InitTestWebGatewayConfig(cfg *WebGatewayConfig) {
+	cfg.Daemon = "garbage"
+	cfg.PathPolicies = "garbage"
+}
+
+func CheckTestConfig(t *testing.T, cfg *Config, id string) {
+	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, nil, nil, id)
+	logtest.CheckTestLogging(t, &cfg.Logging, id)
+	CheckTestWebGatewayConfig(t, &cfg.WebGateway)
+}
+
+func CheckTestWebGatewayConfig(t *testing.T, cfg *WebGatewayConfig) {
+	assert.Equal(t, daemon.DefaultAPIAddress, cfg.Daemon)
+	assert.Equal(t, reliable.DefaultDispPath, cfg.Dispatcher)
+	assert.Equal(t, "10.0.0.1:443", cfg.Listen)
+	assert.Equal(t, "/etc/scion/web-gateway.crt", cfg.TLSCertificate)
+	assert.Equal(t, "/etc/scion/web-gateway.key", cfg.TLSKey)
+	assert.Equal(t, []string{"http://192.168.0.10:8080"}, cfg.Backends)
+	assert.Empty(t, cfg.PathPolicies)
+	assert.Empty(t, cfg.ClientPolicies)
+	assert.Equal(t, DefaultPathRefreshInterval, cfg.PathRefreshInterval.Duration)
+}
+
+func TestWebGatewayConfigValidate(t *testing.T) {
+	valid := func() WebGatewayConfig {
+		cfg := WebGatewayConfig{
+			Listen:         "10.0.0.1:443",
+			TLSCertificate: "tls.crt",
+			TLSKey:         "tls.key",
+			Backends:       []string{"http://192.168.0.10:8080"},
+		}
+		cfg.InitDefaults()
+		return cfg
+	}
+	testCases := map[string]struct {
+		modify    func(cfg *WebGatewayConfig)
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			modify:    func(cfg *WebGatewayConfig) {},
+			assertErr: assert.NoError,
+		},
+		"unspecified listen IP": {
+			modify:    func(cfg *WebGatewayConfig) { cfg.Listen = ":443" },
+			assertErr: assert.Error,
+		},
+		"no TLS key": {
+			modify:    func(cfg *WebGatewayConfig) { cfg.TLSKey = "" },
+			assertErr: assert.Error,
+		},
+		"no backend": {
+			modify:    func(cfg *WebGatewayConfig) { cfg.Backends = nil },
+			assertErr: assert.Error,
+		},
+		"backend without scheme": {
+			modify:    func(cfg *WebGatewayConfig) { cfg.Backends = []string{"192.168.0.10:80"} },
+			assertErr: assert.Error,
+		},
+		"client policies without path policies": {
+			modify: func(cfg *WebGatewayConfig) {
+				cfg.ClientPolicies = map[string]string{"1-0": "policy"}
+			},
+			assertErr: assert.Error,
+		},
+		"invalid client ISD-AS": {
+			modify: func(cfg *WebGatewayConfig) {
+				cfg.PathPolicies = "policies.json"
+				cfg.ClientPolicies = map[string]string{"1": "policy"}
+			},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := valid()
+			tc.modify(&cfg)
+			tc.assertErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestLoadClientPolicies(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policies.json")
+	err := os.WriteFile(file, []byte(`{
+		"no_112": {"acl": ["- 1-ff00:0:112#0", "+"]},
+		"via_111": {"sequence": "0* 1-ff00:0:111 0*"}
+	}`), 0644)
+	require.NoError(t, err)
+
+	cfg := WebGatewayConfig{
+		PathPolicies: file,
+		ClientPolicies: map[string]string{
+			"1-ff00:0:110": "via_111",
+			"0-0":          "no_112",
+		},
+	}
+	policies, err := cfg.LoadClientPolicies()
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "via_111", policies[xtest.MustParseIA("1-ff00:0:110")].Name)
+	assert.Equal(t, "no_112", policies[addr.IA(0)].Name)
+
+	cfg.ClientPolicies["1-0"] = "unknown"
+	_, err = cfg.LoadClientPolicies()
+	assert.Error(t, err)
+}
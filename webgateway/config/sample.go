@@ -0,0 +1,55 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+const idSample = "web-gateway"
+
+const webGatewaySample = `
+# Address of the SCION Daemon that is queried for paths.
+# (default 127.0.0.1:30255)
+daemon = "127.0.0.1:30255"
+
+# Path to the dispatcher socket. (default "/run/shm/dispatcher/default.sock")
+dispatcher = "/run/shm/dispatcher/default.sock"
+
+# The IP address and port in the local AS on which HTTP/3 over SCION is
+# served. (required)
+listen = "10.0.0.1:443"
+
+# The file containing the PEM encoded TLS certificate chain. (required)
+tls_certificate = "/etc/scion/web-gateway.crt"
+
+# The file containing the PEM encoded TLS private key. (required)
+tls_key = "/etc/scion/web-gateway.key"
+
+# The URLs of the HTTP backends. The requests are forwarded in round-robin
+# order. (required)
+backends = ["http://192.168.0.10:8080"]
+
+# The JSON file containing the named path policies, in the format of the SCION
+# Daemon path policies. (default "")
+path_policies = ""
+
+# The time after which the path to a client ISD-AS is looked up again.
+# (default 1m)
+path_refresh_interval = "1m"
+
+# The path policies that are applied to the paths of the responses, by client
+# ISD-AS. The ISD-AS may contain wildcards, e.g., "1-0" or "0-0". Clients
+# without a policy are answered on the reversed path of their requests.
+# [web_gateway.client_policies]
+# "1-ff00:0:110" = "low_latency"
+# "0-0" = "no_isd_2"
+`
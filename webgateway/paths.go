@@ -0,0 +1,151 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webgateway
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/path/pathpol"
+)
+
+const (
+	// DefaultRefreshInterval is the default time after which the selected
+	// path to a client ISD-AS is looked up again.
+	DefaultRefreshInterval = time.Minute
+
+	pathQueryTimeout = 5 * time.Second
+)
+
+// PathSelector selects the paths on which the packets to the clients are
+// sent, according to the path policy of the client ISD-AS. The policy of a
+// client is looked up by its ISD-AS, then by its ISD with the wildcard AS,
+// and finally by the wildcard ISD-AS 0-0. Clients without a policy are
+// answered on the reversed path of their packets.
+type PathSelector struct {
+	// Router is used to look up the paths to the clients.
+	Router snet.Router
+	// Policies are the path policies by client ISD-AS.
+	Policies map[addr.IA]*pathpol.Policy
+	// RefreshInterval is the time after which the selected path to a client
+	// ISD-AS is looked up again. If zero, DefaultRefreshInterval is used. If
+	// negative, the selected paths are not cached.
+	RefreshInterval time.Duration
+
+	mtx   sync.Mutex
+	paths map[addr.IA]selectedPath
+}
+
+type selectedPath struct {
+	path    snet.Path
+	refresh time.Time
+}
+
+// Path returns the path to the client ISD-AS, or nil if the packets are sent
+// on the reversed path. The first path that passes the policy is selected.
+// If no path passes the policy, nil is returned.
+func (s *PathSelector) Path(dst addr.IA) snet.Path {
+	policy := s.policy(dst)
+	if policy == nil {
+		return nil
+	}
+	now := time.Now()
+	if path, ok := s.cached(dst, now); ok {
+		return path
+	}
+	// The lookup is done without holding the lock, so that the packets to
+	// other clients are not delayed.
+	path := s.lookup(dst, policy)
+	interval := s.RefreshInterval
+	if interval == 0 {
+		interval = DefaultRefreshInterval
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.paths == nil {
+		s.paths = make(map[addr.IA]selectedPath)
+	}
+	s.paths[dst] = selectedPath{path: path, refresh: now.Add(interval)}
+	return path
+}
+
+func (s *PathSelector) cached(dst addr.IA, now time.Time) (snet.Path, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sel, ok := s.paths[dst]
+	if !ok || !now.Before(sel.refresh) {
+		return nil, false
+	}
+	if sel.path != nil && sel.path.Metadata() != nil &&
+		!now.Before(sel.path.Metadata().Expiry) {
+		return nil, false
+	}
+	return sel.path, true
+}
+
+func (s *PathSelector) policy(dst addr.IA) *pathpol.Policy {
+	for _, ia := range []addr.IA{dst, addr.MustIAFrom(dst.ISD(), 0), 0} {
+		if policy, ok := s.Policies[ia]; ok {
+			return policy
+		}
+	}
+	return nil
+}
+
+func (s *PathSelector) lookup(dst addr.IA, policy *pathpol.Policy) snet.Path {
+	ctx, cancelF := context.WithTimeout(context.Background(), pathQueryTimeout)
+	defer cancelF()
+	paths, err := s.Router.AllRoutes(ctx, dst)
+	if err != nil {
+		log.Info("Looking up paths to client failed", "isd_as", dst, "err", err)
+		return nil
+	}
+	paths = policy.Filter(paths)
+	if len(paths) == 0 {
+		log.Info("No path to client passes the policy, using reversed path",
+			"isd_as", dst, "policy", policy.Name)
+		return nil
+	}
+	return paths[0]
+}
+
+// NewPacketConn wraps the SCION connection of the server, such that the
+// packets to the clients are sent on the paths selected by the selector.
+func NewPacketConn(conn net.PacketConn, selector *PathSelector) net.PacketConn {
+	return &packetConn{PacketConn: conn, selector: selector}
+}
+
+type packetConn struct {
+	net.PacketConn
+	selector *PathSelector
+}
+
+func (c *packetConn) WriteTo(b []byte, a net.Addr) (int, error) {
+	dst, ok := a.(*snet.UDPAddr)
+	if !ok {
+		return c.PacketConn.WriteTo(b, a)
+	}
+	if path := c.selector.Path(dst.IA); path != nil {
+		dst = dst.Copy()
+		dst.Path = path.Dataplane()
+		dst.NextHop = snet.CopyUDPAddr(path.UnderlayNextHop())
+	}
+	return c.PacketConn.WriteTo(b, dst)
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webgateway_test
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/mock_snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/path/pathpol"
+	"github.com/scionproto/scion/webgateway"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+	ia112 = xtest.MustParseIA("1-ff00:0:112")
+	ia210 = xtest.MustParseIA("2-ff00:0:210")
+)
+
+// testPath returns a path to dst via the given AS.
+func testPath(dst, via addr.IA, nextHop string) snet.Path {
+	return snetpath.Path{
+		Dst:           dst,
+		DataplanePath: snetpath.SCION{Raw: []byte(via.String())},
+		NextHop:       &net.UDPAddr{IP: net.ParseIP(nextHop), Port: 30041},
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: via, ID: common.IFIDType(1)},
+				{IA: dst, ID: common.IFIDType(2)},
+			},
+			Expiry: time.Now().Add(time.Hour),
+		},
+	}
+}
+
+// avoid returns a policy that denies the paths via the given AS.
+func avoid(t *testing.T, ia addr.IA) *pathpol.Policy {
+	var policy pathpol.Policy
+	raw := `{"acl": ["- ` + ia.String() + `#0", "+"]}`
+	require.NoError(t, json.Unmarshal([]byte(raw), &policy))
+	return &policy
+}
+
+func TestPathSelector(t *testing.T) {
+	via111 := testPath(ia110, ia111, "10.0.0.1")
+	via112 := testPath(ia110, ia112, "10.0.0.2")
+
+	t.Run("no policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		s := &webgateway.PathSelector{
+			Router:   mock_snet.NewMockRouter(ctrl),
+			Policies: map[addr.IA]*pathpol.Policy{ia111: avoid(t, ia112)},
+		}
+		assert.Nil(t, s.Path(ia110))
+	})
+	t.Run("policy of ISD-AS", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		router := mock_snet.NewMockRouter(ctrl)
+		router.EXPECT().AllRoutes(gomock.Any(), ia110).
+			Return([]snet.Path{via112, via111}, nil)
+		s := &webgateway.PathSelector{
+			Router:   router,
+			Policies: map[addr.IA]*pathpol.Policy{ia110: avoid(t, ia112)},
+		}
+		assert.Equal(t, via111, s.Path(ia110))
+		// The second call is served from the cache.
+		assert.Equal(t, via111, s.Path(ia110))
+	})
+	t.Run("wildcards", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		via210 := testPath(ia210, ia210, "10.0.0.3")
+		router := mock_snet.NewMockRouter(ctrl)
+		router.EXPECT().AllRoutes(gomock.Any(), ia110).
+			Return([]snet.Path{via112, via111}, nil)
+		router.EXPECT().AllRoutes(gomock.Any(), ia210).
+			Return([]snet.Path{via210}, nil)
+		s := &webgateway.PathSelector{
+			Router: router,
+			Policies: map[addr.IA]*pathpol.Policy{
+				addr.MustIAFrom(1, 0): avoid(t, ia112),
+				0:                     avoid(t, ia111),
+			},
+		}
+		assert.Equal(t, via111, s.Path(ia110))
+		assert.Equal(t, via210, s.Path(ia210))
+	})
+	t.Run("no path passes the policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		router := mock_snet.NewMockRouter(ctrl)
+		router.EXPECT().AllRoutes(gomock.Any(), ia110).
+			Return([]snet.Path{via112}, nil).Times(2)
+		s := &webgateway.PathSelector{
+			Router:          router,
+			Policies:        map[addr.IA]*pathpol.Policy{ia110: avoid(t, ia112)},
+			RefreshInterval: -1,
+		}
+		assert.Nil(t, s.Path(ia110))
+		// With a negative refresh interval, nothing is cached.
+		assert.Nil(t, s.Path(ia110))
+	})
+}
+
+// recordingConn records the destination of the last written packet.
+type recordingConn struct {
+	net.PacketConn
+	dst net.Addr
+}
+
+func (c *recordingConn) WriteTo(b []byte, a net.Addr) (int, error) {
+	c.dst = a
+	return len(b), nil
+}
+
+func TestPacketConn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	via111 := testPath(ia110, ia111, "10.0.0.1")
+	router := mock_snet.NewMockRouter(ctrl)
+	router.EXPECT().AllRoutes(gomock.Any(), ia110).Return([]snet.Path{via111}, nil)
+	rec := &recordingConn{}
+	conn := webgateway.NewPacketConn(rec, &webgateway.PathSelector{
+		Router:   router,
+		Policies: map[addr.IA]*pathpol.Policy{ia110: avoid(t, ia112)},
+	})
+
+	reply := snetpath.SCION{Raw: []byte("reply")}
+	client := &snet.UDPAddr{
+		IA:      ia110,
+		Host:    &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 443},
+		Path:    reply,
+		NextHop: &net.UDPAddr{IP: net.ParseIP("10.0.0.9"), Port: 30041},
+	}
+	_, err := conn.WriteTo([]byte("packet"), client)
+	require.NoError(t, err)
+	dst := rec.dst.(*snet.UDPAddr)
+	assert.Equal(t, via111.Dataplane(), dst.Path)
+	assert.Equal(t, via111.UnderlayNextHop(), dst.NextHop)
+	// The address of the caller is not modified.
+	assert.Equal(t, reply, client.Path)
+
+	other := &snet.UDPAddr{
+		IA:   ia111,
+		Host: &net.UDPAddr{IP: net.ParseIP("192.0.2.2"), Port: 443},
+		Path: reply,
+	}
+	_, err = conn.WriteTo([]byte("packet"), other)
+	require.NoError(t, err)
+	assert.Equal(t, other, rec.dst)
+}
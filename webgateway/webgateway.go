@@ -0,0 +1,60 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webgateway implements a gateway that terminates HTTP/3 over SCION
+// and forwards the requests to HTTP backends in the IP world. The responses
+// to the clients are sent on paths that are chosen according to the path
+// policy configured for the client ISD-AS.
+package webgateway
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// RemoteAddrHeader is the request header that carries the SCION address of
+// the client to the backends.
+const RemoteAddrHeader = "X-Scion-Remote-Addr"
+
+// NewProxy returns a handler that forwards the requests to the backends in
+// round-robin order. The paths of the requests are appended to the paths of
+// the backend URLs.
+func NewProxy(backends []*url.URL) http.Handler {
+	var next uint32
+	return &httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			backend := backends[int(atomic.AddUint32(&next, 1)-1)%len(backends)]
+			r.URL.Scheme = backend.Scheme
+			r.URL.Host = backend.Host
+			r.URL.Path = strings.TrimSuffix(backend.Path, "/") + r.URL.Path
+			if r.URL.RawPath != "" {
+				r.URL.RawPath = strings.TrimSuffix(backend.EscapedPath(), "/") + r.URL.RawPath
+			}
+			r.Header.Set(RemoteAddrHeader, r.RemoteAddr)
+			r.Header.Set("X-Forwarded-Proto", "https")
+			// The SCION address is not a valid IP address, the client is
+			// identified by RemoteAddrHeader instead.
+			r.Header["X-Forwarded-For"] = nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Info("Forwarding request failed", "backend", r.URL.Host, "err", err)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webgateway_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/webgateway"
+)
+
+func TestProxy(t *testing.T) {
+	backend := func(name string) *url.URL {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%s %s %t", name, r.URL.Path,
+				r.Header.Get(webgateway.RemoteAddrHeader) != "")
+		}))
+		t.Cleanup(srv.Close)
+		u, err := url.Parse(srv.URL + "/" + name + "/")
+		require.NoError(t, err)
+		return u
+	}
+	proxy := httptest.NewServer(webgateway.NewProxy([]*url.URL{backend("a"), backend("b")}))
+	defer proxy.Close()
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		rsp, err := http.Get(proxy.URL + "/index.html")
+		require.NoError(t, err)
+		body, err := io.ReadAll(rsp.Body)
+		rsp.Body.Close()
+		require.NoError(t, err)
+		got = append(got, string(body))
+	}
+	assert.Equal(t, []string{
+		"a /a/index.html true",
+		"b /b/index.html true",
+		"a /a/index.html true",
+		"b /b/index.html true",
+	}, got)
+}
+
+func TestProxyBackendDown(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	srv.Close()
+
+	proxy := httptest.NewServer(webgateway.NewProxy([]*url.URL{u}))
+	defer proxy.Close()
+	rsp, err := http.Get(proxy.URL)
+	require.NoError(t, err)
+	rsp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, rsp.StatusCode)
+}